@@ -0,0 +1,254 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package diskimage provides read-only access to the files in raw disk
+// images, e.g. ones created with dd during incident response, so that they can
+// be scanned like a directory.
+//
+// Images can contain a single filesystem or be partitioned with an MBR or GPT
+// partition table. Only ext2, ext3 and ext4 filesystems are supported. Other
+// filesystems and virtual machine disk formats such as qcow2 and VMDK are
+// detected and rejected with ErrUnsupported. Such images can be converted to
+// raw ones first, e.g. with "qemu-img convert -O raw".
+package diskimage
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// ErrUnsupported is returned for images whose format or filesystem is not
+// supported.
+var ErrUnsupported = errors.New("unsupported disk image")
+
+const sectorSize = 512
+
+// Image is a filesystem read from a disk image. It implements scalibrfs.FS.
+type Image struct {
+	*extFS
+	file   io.Closer
+	fsType string
+	offset int64
+}
+
+// Open opens the disk image at path and returns its filesystem. For
+// partitioned images the filesystem of the only supported partition is
+// returned. The image must be closed after use.
+func Open(path string) (*Image, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	img, err := newImage(f, info.Size())
+	if err != nil {
+		f.Close()
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	img.file = f
+	return img, nil
+}
+
+// newImage returns the filesystem of an image of the given size.
+func newImage(r io.ReaderAt, size int64) (*Image, error) {
+	fsType := detect(r)
+	switch {
+	case strings.HasPrefix(fsType, "ext"):
+		e, err := newExtFS(r)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s filesystem: %w", fsType, err)
+		}
+		return &Image{extFS: e, fsType: fsType}, nil
+	case fsType != "":
+		return nil, fmt.Errorf("%w: %s", ErrUnsupported, fsType)
+	}
+
+	parts, err := partitions(r)
+	if err != nil {
+		return nil, err
+	}
+	if len(parts) == 0 {
+		return nil, fmt.Errorf("%w: no known filesystem or partition table found", ErrUnsupported)
+	}
+	var supported []partition
+	var types []string
+	for _, p := range parts {
+		if p.offset+p.size > size {
+			return nil, fmt.Errorf("partition %d exceeds the size of the image", p.index)
+		}
+		t := detect(io.NewSectionReader(r, p.offset, p.size))
+		if t == "" {
+			t = "unknown"
+		}
+		types = append(types, fmt.Sprintf("%d (%s)", p.index, t))
+		if strings.HasPrefix(t, "ext") {
+			supported = append(supported, p)
+		}
+	}
+	switch len(supported) {
+	case 0:
+		return nil, fmt.Errorf("%w: no partition with a supported filesystem, found partitions %s", ErrUnsupported, strings.Join(types, ", "))
+	case 1:
+	default:
+		return nil, fmt.Errorf("%w: found several partitions with a supported filesystem %s, extract the one to scan first", ErrUnsupported, strings.Join(types, ", "))
+	}
+	p := supported[0]
+	img, err := newImage(io.NewSectionReader(r, p.offset, p.size), p.size)
+	if err != nil {
+		return nil, fmt.Errorf("partition %d: %w", p.index, err)
+	}
+	img.offset = p.offset
+	return img, nil
+}
+
+// Type returns the type of the image's filesystem, e.g. "ext4".
+func (i *Image) Type() string { return i.fsType }
+
+// Offset returns the offset of the filesystem in the image, which is non-zero
+// for partitioned images.
+func (i *Image) Offset() int64 { return i.offset }
+
+// Close closes the image file.
+func (i *Image) Close() error {
+	if i.file == nil {
+		return nil
+	}
+	return i.file.Close()
+}
+
+// signature identifies a filesystem or disk image format by the bytes at the
+// given offset.
+type signature struct {
+	name   string
+	offset int64
+	magic  []byte
+}
+
+var signatures = []signature{
+	{"qcow2", 0, []byte("QFI\xfb")},
+	{"vmdk", 0, []byte("KDMV")},
+	{"vmdk", 0, []byte("# Disk DescriptorFile")},
+	{"vhdx", 0, []byte("vhdxfile")},
+	{"vhd", 0, []byte("conectix")},
+	{"vdi", 0x40, []byte("\x7f\x10\xda\xbe")},
+	{"xfs", 0, []byte("XFSB")},
+	{"btrfs", 0x10040, []byte("_BHRfS_M")},
+	{"ntfs", 3, []byte("NTFS    ")},
+	{"exfat", 3, []byte("EXFAT   ")},
+	{"vfat", 82, []byte("FAT32   ")},
+	{"vfat", 54, []byte("FAT16   ")},
+	{"vfat", 54, []byte("FAT12   ")},
+	{"squashfs", 0, []byte("hsqs")},
+	{"iso9660", 0x8001, []byte("CD001")},
+	{"lvm2", 0x218, []byte("LVM2 001")},
+	{"luks", 0, []byte("LUKS\xba\xbe")},
+	{"swap", 4086, []byte("SWAPSPACE2")},
+}
+
+// detect returns the type of the filesystem or image format at the start of
+// r, or "" if it's not known.
+func detect(r io.ReaderAt) string {
+	sb := make([]byte, superblockSize)
+	if _, err := r.ReadAt(sb, superblockOffset); err == nil {
+		if t := extType(sb); t != "" {
+			return t
+		}
+	}
+	for _, s := range signatures {
+		buf := make([]byte, len(s.magic))
+		if _, err := r.ReadAt(buf, s.offset); err == nil && bytes.Equal(buf, s.magic) {
+			return s.name
+		}
+	}
+	return ""
+}
+
+// partition is an entry of a partition table.
+type partition struct {
+	// index is the 1-based number of the partition in the table.
+	index  int
+	offset int64
+	size   int64
+}
+
+// partitions returns the partitions of an image with an MBR or GPT partition
+// table, or nil if it doesn't have one.
+func partitions(r io.ReaderAt) ([]partition, error) {
+	mbr := make([]byte, sectorSize)
+	if _, err := r.ReadAt(mbr, 0); err != nil || mbr[510] != 0x55 || mbr[511] != 0xAA {
+		return nil, nil
+	}
+	var parts []partition
+	for n := 0; n < 4; n++ {
+		entry := mbr[446+16*n:]
+		partType := entry[4]
+		start := int64(le.Uint32(entry[8:]))
+		sectors := int64(le.Uint32(entry[12:]))
+		switch partType {
+		case 0:
+			continue
+		case 0xEE:
+			// Protective MBR of a GPT partitioned disk.
+			return gptPartitions(r)
+		case 0x05, 0x0F, 0x85:
+			// Logical partitions in extended partitions aren't listed.
+			continue
+		}
+		parts = append(parts, partition{index: n + 1, offset: start * sectorSize, size: sectors * sectorSize})
+	}
+	return parts, nil
+}
+
+func gptPartitions(r io.ReaderAt) ([]partition, error) {
+	header := make([]byte, 92)
+	if _, err := r.ReadAt(header, sectorSize); err != nil {
+		return nil, fmt.Errorf("failed to read GPT header: %w", err)
+	}
+	if !bytes.Equal(header[:8], []byte("EFI PART")) {
+		return nil, errors.New("invalid GPT header signature")
+	}
+	entriesLBA := int64(le.Uint64(header[0x48:]))
+	count := le.Uint32(header[0x50:])
+	entrySize := le.Uint32(header[0x54:])
+	if entrySize < 128 || count > 1024 {
+		return nil, fmt.Errorf("invalid GPT partition entries: %d entries of %d bytes", count, entrySize)
+	}
+	entries := make([]byte, int(count)*int(entrySize))
+	if _, err := r.ReadAt(entries, entriesLBA*sectorSize); err != nil {
+		return nil, fmt.Errorf("failed to read GPT partition entries: %w", err)
+	}
+	var parts []partition
+	for n := 0; n < int(count); n++ {
+		entry := entries[n*int(entrySize):]
+		if bytes.Equal(entry[:16], make([]byte, 16)) {
+			// Unused entry.
+			continue
+		}
+		first := int64(le.Uint64(entry[32:]))
+		last := int64(le.Uint64(entry[40:]))
+		if last < first {
+			return nil, fmt.Errorf("invalid GPT partition %d", n+1)
+		}
+		parts = append(parts, partition{index: n + 1, offset: first * sectorSize, size: (last - first + 1) * sectorSize})
+	}
+	return parts, nil
+}
@@ -0,0 +1,353 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diskimage_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/osv-scalibr/artifact/diskimage"
+)
+
+// The test images were created from the same directory with
+//
+//	mke2fs -t ext4 -b 4096 -O ^has_journal,64bit -N 512 -d <dir> ext4.img 2M
+//	e2fsck -fyD ext4.img
+//	mke2fs -t ext2 -b 1024 -N 512 -d <dir> ext2.img 2M
+//
+// e2fsck -D builds the hash tree indexes of large directories, and the 1K
+// blocks of ext2.img make data/large.bin use double indirect blocks.
+var images = []struct {
+	path     string
+	wantType string
+}{
+	{path: "testdata/ext4.img", wantType: "ext4"},
+	{path: "testdata/ext2.img", wantType: "ext2"},
+}
+
+const osRelease = `PRETTY_NAME="Debian GNU/Linux 12 (bookworm)"
+ID=debian
+VERSION_ID="12"
+VERSION_CODENAME=bookworm
+`
+
+const dpkgStatus = `Package: curl
+Status: install ok installed
+Version: 7.88.1-10
+Architecture: amd64
+
+`
+
+// largeFile returns the contents of data/large.bin.
+func largeFile() []byte {
+	var b bytes.Buffer
+	for i := 0; i < 40000; i++ {
+		fmt.Fprintf(&b, "%08d\n", i)
+	}
+	return b.Bytes()
+}
+
+func openImage(t *testing.T, path string) *diskimage.Image {
+	t.Helper()
+	img, err := diskimage.Open(path)
+	if err != nil {
+		t.Fatalf("Open(%s): %v", path, err)
+	}
+	t.Cleanup(func() { img.Close() })
+	return img
+}
+
+func TestOpen(t *testing.T) {
+	for _, tc := range images {
+		t.Run(tc.path, func(t *testing.T) {
+			img := openImage(t, tc.path)
+			if got := img.Type(); got != tc.wantType {
+				t.Errorf("Open(%s).Type() = %q, want %q", tc.path, got, tc.wantType)
+			}
+			if got := img.Offset(); got != 0 {
+				t.Errorf("Open(%s).Offset() = %d, want 0", tc.path, got)
+			}
+		})
+	}
+}
+
+func TestFS(t *testing.T) {
+	for _, tc := range images {
+		t.Run(tc.path, func(t *testing.T) {
+			img := openImage(t, tc.path)
+			// The test can't be run on the root as the symlinks in data/ are
+			// opened as files.
+			for dir, file := range map[string]string{
+				"many": "file-with-a-long-name-1.txt",
+				"var":  "lib/dpkg/status",
+				"usr":  "lib/os-release",
+			} {
+				sub, err := fs.Sub(img, dir)
+				if err != nil {
+					t.Fatalf("fs.Sub(%s): %v", dir, err)
+				}
+				if err := fstest.TestFS(sub, file); err != nil {
+					t.Errorf("fstest.TestFS(%s): %v", dir, err)
+				}
+			}
+		})
+	}
+}
+
+func TestReadFile(t *testing.T) {
+	tests := []struct {
+		name    string
+		path    string
+		want    []byte
+		wantErr error
+	}{
+		{
+			name: "regular file",
+			path: "var/lib/dpkg/status",
+			want: []byte(dpkgStatus),
+		}, {
+			name: "relative symlink",
+			path: "etc/os-release",
+			want: []byte(osRelease),
+		}, {
+			name: "absolute symlink to a directory",
+			path: "data/abs-link/status",
+			want: []byte(dpkgStatus),
+		}, {
+			name: "file with several extents",
+			path: "data/large.bin",
+			want: largeFile(),
+		}, {
+			name: "file in a large directory",
+			path: "many/file-with-a-long-name-123.txt",
+			want: []byte("123\n"),
+		}, {
+			name:    "dangling symlink",
+			path:    "data/long-link",
+			wantErr: fs.ErrNotExist,
+		}, {
+			name:    "missing file",
+			path:    "etc/passwd",
+			wantErr: fs.ErrNotExist,
+		}, {
+			name:    "invalid path",
+			path:    "/etc/os-release",
+			wantErr: fs.ErrInvalid,
+		},
+	}
+
+	for _, img := range images {
+		fsys := openImage(t, img.path)
+		for _, tt := range tests {
+			t.Run(img.wantType+"/"+tt.name, func(t *testing.T) {
+				got, err := fs.ReadFile(fsys, tt.path)
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("ReadFile(%s) error: got %v, want %v", tt.path, err, tt.wantErr)
+				}
+				if !bytes.Equal(got, tt.want) {
+					t.Errorf("ReadFile(%s) returned %d bytes that differ from the %d expected ones", tt.path, len(got), len(tt.want))
+				}
+			})
+		}
+	}
+}
+
+func TestReadAt(t *testing.T) {
+	want := largeFile()
+	for _, img := range images {
+		t.Run(img.wantType, func(t *testing.T) {
+			fsys := openImage(t, img.path)
+			f, err := fsys.Open("data/large.bin")
+			if err != nil {
+				t.Fatalf("Open(data/large.bin): %v", err)
+			}
+			defer f.Close()
+			r, ok := f.(io.ReaderAt)
+			if !ok {
+				t.Fatalf("Open(data/large.bin) returned a %T which doesn't implement io.ReaderAt", f)
+			}
+
+			// Reads crossing block and extent boundaries.
+			for _, off := range []int64{0, 4090, 36860, 98300, 131070, int64(len(want)) - 100} {
+				buf := make([]byte, 200)
+				n, err := r.ReadAt(buf, off)
+				wantN := min(len(buf), len(want)-int(off))
+				if n != wantN || (n == len(buf) && err != nil) || (n < len(buf) && err != io.EOF) {
+					t.Errorf("ReadAt(%d): got %d, %v, want %d bytes", off, n, err, wantN)
+				}
+				if !bytes.Equal(buf[:n], want[off:off+int64(n)]) {
+					t.Errorf("ReadAt(%d) returned unexpected data", off)
+				}
+			}
+		})
+	}
+}
+
+func TestReadDir(t *testing.T) {
+	type entry struct {
+		Name string
+		Type fs.FileMode
+	}
+	want := []entry{
+		{"abs-link", fs.ModeSymlink},
+		{"large.bin", 0},
+		{"long-link", fs.ModeSymlink},
+		{"loop1", fs.ModeSymlink},
+		{"loop2", fs.ModeSymlink},
+	}
+	for _, img := range images {
+		t.Run(img.wantType, func(t *testing.T) {
+			fsys := openImage(t, img.path)
+			entries, err := fsys.ReadDir("data")
+			if err != nil {
+				t.Fatalf("ReadDir(data): %v", err)
+			}
+			var got []entry
+			for _, e := range entries {
+				got = append(got, entry{e.Name(), e.Type()})
+			}
+			if diff := cmp.Diff(want, got); diff != "" {
+				t.Errorf("ReadDir(data) unexpected diff (-want +got):\n%s", diff)
+			}
+
+			entries, err = fsys.ReadDir("many")
+			if err != nil {
+				t.Fatalf("ReadDir(many): %v", err)
+			}
+			if len(entries) != 200 {
+				t.Errorf("ReadDir(many) returned %d entries, want 200", len(entries))
+			}
+		})
+	}
+}
+
+func TestSymlinkLoop(t *testing.T) {
+	fsys := openImage(t, "testdata/ext4.img")
+	_, err := fsys.Open("data/loop1")
+	if err == nil {
+		t.Fatal("Open(data/loop1) succeeded, want error")
+	}
+	if errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("Open(data/loop1) returned %v, want a symlink loop error", err)
+	}
+}
+
+// writePartitionedImage writes an image with the given partition table
+// followed by the filesystem in fsPath at offset 1 MiB.
+func writePartitionedImage(t *testing.T, fsPath string, gpt bool) string {
+	t.Helper()
+	data, err := os.ReadFile(fsPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	const start = 2048
+	sectors := uint32(len(data) / 512)
+	img := make([]byte, start*512+len(data))
+	copy(img[start*512:], data)
+
+	entry := img[446:]
+	if gpt {
+		entry[4] = 0xEE
+		binary.LittleEndian.PutUint32(entry[8:], 1)
+		binary.LittleEndian.PutUint32(entry[12:], 0xFFFFFFFF)
+		header := img[512:]
+		copy(header, "EFI PART")
+		binary.LittleEndian.PutUint64(header[0x48:], 2)
+		binary.LittleEndian.PutUint32(header[0x50:], 128)
+		binary.LittleEndian.PutUint32(header[0x54:], 128)
+		// The second entry, the first one is unused.
+		part := img[2*512+128:]
+		copy(part, "\xaf\x3d\xc6\x0f\x83\x84\x72\x47\x8e\x79\x3d\x69\xd8\x47\x7d\xe4")
+		binary.LittleEndian.PutUint64(part[32:], start)
+		binary.LittleEndian.PutUint64(part[40:], start+uint64(sectors)-1)
+	} else {
+		entry[4] = 0x83
+		binary.LittleEndian.PutUint32(entry[8:], start)
+		binary.LittleEndian.PutUint32(entry[12:], sectors)
+	}
+	img[510], img[511] = 0x55, 0xAA
+
+	path := filepath.Join(t.TempDir(), "disk.img")
+	if err := os.WriteFile(path, img, 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestPartitionedImage(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		gpt  bool
+	}{
+		{name: "MBR", gpt: false},
+		{name: "GPT", gpt: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			img := openImage(t, writePartitionedImage(t, "testdata/ext2.img", tc.gpt))
+			if got, want := img.Type(), "ext2"; got != want {
+				t.Errorf("Type() = %q, want %q", got, want)
+			}
+			if got, want := img.Offset(), int64(1<<20); got != want {
+				t.Errorf("Offset() = %d, want %d", got, want)
+			}
+			got, err := fs.ReadFile(img, "etc/os-release")
+			if err != nil {
+				t.Fatalf("ReadFile(etc/os-release): %v", err)
+			}
+			if diff := cmp.Diff(osRelease, string(got)); diff != "" {
+				t.Errorf("ReadFile(etc/os-release) unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestUnsupported(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		offset int
+		header string
+	}{
+		{name: "qcow2", offset: 0, header: "QFI\xfb\x00\x00\x00\x03"},
+		{name: "vmdk", offset: 0, header: "KDMV"},
+		{name: "xfs", offset: 0, header: "XFSB"},
+		{name: "lvm2", offset: 0x218, header: "LVM2 001"},
+		{name: "empty", offset: 0, header: ""},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			data := make([]byte, 1<<16)
+			copy(data[tc.offset:], tc.header)
+			path := filepath.Join(t.TempDir(), "disk.img")
+			if err := os.WriteFile(path, data, 0644); err != nil {
+				t.Fatal(err)
+			}
+			img, err := diskimage.Open(path)
+			if err == nil {
+				img.Close()
+			}
+			if !errors.Is(err, diskimage.ErrUnsupported) {
+				t.Errorf("Open(%s) error: got %v, want %v", tc.name, err, diskimage.ErrUnsupported)
+			}
+		})
+	}
+}
@@ -0,0 +1,740 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diskimage
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"path"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/osv-scalibr/log"
+)
+
+const (
+	superblockOffset = 1024
+	superblockSize   = 1024
+	extMagic         = 0xEF53
+	extentMagic      = 0xF30A
+	rootInode        = 2
+	// maxExtentDepth is the maximum depth of an extent tree.
+	maxExtentDepth = 5
+	// maxSymlinkHops is the maximum number of symlinks followed when resolving
+	// a path, same as on Linux.
+	maxSymlinkHops = 40
+	// maxCachedDirs is the number of directories whose entries are cached to
+	// avoid re-reading the parent directory for each opened file.
+	maxCachedDirs = 1024
+
+	compatHasJournal = 0x4
+
+	incompatCompression = 0x1
+	incompatFiletype    = 0x2
+	incompatRecover     = 0x4
+	incompatJournalDev  = 0x8
+	incompatMetaBG      = 0x10
+	incompatExtents     = 0x40
+	incompat64Bit       = 0x80
+	incompatMMP         = 0x100
+	incompatFlexBG      = 0x200
+	incompatEAInode     = 0x400
+	incompatDirData     = 0x1000
+	incompatCsumSeed    = 0x2000
+	incompatLargeDir    = 0x4000
+	incompatInlineData  = 0x8000
+	incompatEncrypt     = 0x10000
+	incompatCasefold    = 0x20000
+	// supportedIncompat are the incompatible features that don't change how
+	// files are read or that are handled per file.
+	supportedIncompat = incompatFiletype | incompatRecover | incompatExtents | incompat64Bit |
+		incompatMMP | incompatFlexBG | incompatEAInode | incompatCsumSeed | incompatLargeDir |
+		incompatInlineData | incompatEncrypt | incompatCasefold
+
+	inodeFlagEncrypt    = 0x800
+	inodeFlagExtents    = 0x80000
+	inodeFlagInlineData = 0x10000000
+
+	// inlineDataSize is the size of i_block, which holds the data of fast
+	// symlinks and small inline files.
+	inlineDataSize = 60
+)
+
+var le = binary.LittleEndian
+
+var (
+	errNotDir       = errors.New("not a directory")
+	errTooManyLinks = errors.New("too many levels of symbolic links")
+)
+
+// extFS is a read-only fs.FS for ext2, ext3 and ext4 filesystems.
+type extFS struct {
+	r              io.ReaderAt
+	blockSize      uint64
+	inodesCount    uint32
+	inodesPerGroup uint32
+	inodeSize      uint64
+	hasFiletype    bool
+	// inodeTables holds the first block of each block group's inode table.
+	inodeTables []uint64
+
+	mu   sync.Mutex
+	dirs map[uint32][]dirent
+}
+
+// extType returns the name of the ext filesystem with the given superblock,
+// or "" if it isn't one.
+func extType(sb []byte) string {
+	if len(sb) < superblockSize || le.Uint16(sb[0x38:]) != extMagic {
+		return ""
+	}
+	compat := le.Uint32(sb[0x5C:])
+	incompat := le.Uint32(sb[0x60:])
+	switch {
+	case incompat&(incompatExtents|incompat64Bit|incompatFlexBG) != 0:
+		return "ext4"
+	case compat&compatHasJournal != 0:
+		return "ext3"
+	default:
+		return "ext2"
+	}
+}
+
+// newExtFS reads the superblock and group descriptors of the ext filesystem
+// in r.
+func newExtFS(r io.ReaderAt) (*extFS, error) {
+	sb := make([]byte, superblockSize)
+	if _, err := r.ReadAt(sb, superblockOffset); err != nil {
+		return nil, fmt.Errorf("failed to read superblock: %w", err)
+	}
+	if le.Uint16(sb[0x38:]) != extMagic {
+		return nil, errors.New("invalid superblock magic")
+	}
+	incompat := le.Uint32(sb[0x60:])
+	if unsupported := incompat &^ supportedIncompat; unsupported != 0 {
+		return nil, fmt.Errorf("unsupported ext features 0x%x", unsupported)
+	}
+	if incompat&incompatRecover != 0 {
+		log.Warnf("The filesystem wasn't unmounted cleanly, recently written files might be missing or incomplete")
+	}
+
+	logBlockSize := le.Uint32(sb[0x18:])
+	if logBlockSize > 6 {
+		return nil, fmt.Errorf("invalid block size 2^(10+%d)", logBlockSize)
+	}
+	e := &extFS{
+		r:              r,
+		blockSize:      1024 << logBlockSize,
+		inodesCount:    le.Uint32(sb[0x0:]),
+		inodesPerGroup: le.Uint32(sb[0x28:]),
+		inodeSize:      128,
+		hasFiletype:    incompat&incompatFiletype != 0,
+		dirs:           map[uint32][]dirent{},
+	}
+	if revLevel := le.Uint32(sb[0x4C:]); revLevel > 0 {
+		e.inodeSize = uint64(le.Uint16(sb[0x58:]))
+	}
+	if e.inodeSize < 128 || e.inodeSize > e.blockSize {
+		return nil, fmt.Errorf("invalid inode size %d", e.inodeSize)
+	}
+	blocksPerGroup := uint64(le.Uint32(sb[0x20:]))
+	if e.inodesPerGroup == 0 || blocksPerGroup == 0 {
+		return nil, errors.New("invalid block group size")
+	}
+
+	blocksCount := uint64(le.Uint32(sb[0x4:]))
+	descSize := uint64(32)
+	if incompat&incompat64Bit != 0 {
+		blocksCount |= uint64(le.Uint32(sb[0x150:])) << 32
+		if s := uint64(le.Uint16(sb[0xFE:])); s >= 64 {
+			descSize = s
+		}
+	}
+	firstDataBlock := uint64(le.Uint32(sb[0x14:]))
+	if blocksCount <= firstDataBlock {
+		return nil, errors.New("invalid block count")
+	}
+	groups := (blocksCount - firstDataBlock + blocksPerGroup - 1) / blocksPerGroup
+	if groups > uint64(e.inodesCount/e.inodesPerGroup)+1 {
+		return nil, fmt.Errorf("invalid block group count %d", groups)
+	}
+
+	// The group descriptors follow the superblock's block.
+	gdt := make([]byte, groups*descSize)
+	if _, err := r.ReadAt(gdt, int64((firstDataBlock+1)*e.blockSize)); err != nil {
+		return nil, fmt.Errorf("failed to read group descriptors: %w", err)
+	}
+	e.inodeTables = make([]uint64, groups)
+	for i := range e.inodeTables {
+		d := gdt[uint64(i)*descSize:]
+		e.inodeTables[i] = uint64(le.Uint32(d[0x8:]))
+		if descSize >= 64 {
+			e.inodeTables[i] |= uint64(le.Uint32(d[0x28:])) << 32
+		}
+	}
+	return e, nil
+}
+
+// inode holds the fields of an inode needed to read its data.
+type inode struct {
+	num   uint32
+	mode  uint16
+	size  uint64
+	mtime time.Time
+	flags uint32
+	block [inlineDataSize]byte
+}
+
+func (i *inode) isDir() bool     { return i.mode&0xF000 == 0x4000 }
+func (i *inode) isSymlink() bool { return i.mode&0xF000 == 0xA000 }
+
+func (i *inode) fileMode() fs.FileMode {
+	m := fs.FileMode(i.mode & 0o777)
+	switch i.mode & 0xF000 {
+	case 0x4000:
+		m |= fs.ModeDir
+	case 0xA000:
+		m |= fs.ModeSymlink
+	case 0x2000:
+		m |= fs.ModeDevice | fs.ModeCharDevice
+	case 0x6000:
+		m |= fs.ModeDevice
+	case 0x1000:
+		m |= fs.ModeNamedPipe
+	case 0xC000:
+		m |= fs.ModeSocket
+	}
+	if i.mode&0o4000 != 0 {
+		m |= fs.ModeSetuid
+	}
+	if i.mode&0o2000 != 0 {
+		m |= fs.ModeSetgid
+	}
+	if i.mode&0o1000 != 0 {
+		m |= fs.ModeSticky
+	}
+	return m
+}
+
+func (e *extFS) readInode(num uint32) (*inode, error) {
+	if num == 0 || num > e.inodesCount {
+		return nil, fmt.Errorf("invalid inode number %d", num)
+	}
+	group := (num - 1) / e.inodesPerGroup
+	index := (num - 1) % e.inodesPerGroup
+	if int(group) >= len(e.inodeTables) {
+		return nil, fmt.Errorf("inode %d is outside of the block groups", num)
+	}
+	// Only the fields of the original 128 byte inode are needed.
+	buf := make([]byte, 128)
+	off := e.inodeTables[group]*e.blockSize + uint64(index)*e.inodeSize
+	if _, err := e.r.ReadAt(buf, int64(off)); err != nil {
+		return nil, fmt.Errorf("failed to read inode %d: %w", num, err)
+	}
+	i := &inode{
+		num:   num,
+		mode:  le.Uint16(buf[0x0:]),
+		size:  uint64(le.Uint32(buf[0x4:])) | uint64(le.Uint32(buf[0x6C:]))<<32,
+		mtime: time.Unix(int64(int32(le.Uint32(buf[0x10:]))), 0),
+		flags: le.Uint32(buf[0x20:]),
+	}
+	copy(i.block[:], buf[0x28:])
+	return i, nil
+}
+
+// extent maps a range of a file's blocks to blocks of the filesystem.
+type extent struct {
+	logical uint64
+	length  uint64
+	// physical is 0 for unwritten extents, which are read as zeros.
+	physical uint64
+}
+
+// data returns a reader for the contents of a file, directory or symlink.
+func (e *extFS) data(i *inode) (io.ReaderAt, error) {
+	if i.flags&inodeFlagEncrypt != 0 {
+		return nil, errors.New("encrypted files are not supported")
+	}
+	if i.flags&inodeFlagInlineData != 0 || i.isSymlink() && i.size < inlineDataSize && i.flags&inodeFlagExtents == 0 {
+		// Larger inline data continues in an extended attribute.
+		if i.size > inlineDataSize {
+			return nil, errors.New("inline data in extended attributes is not supported")
+		}
+		return bytes.NewReader(i.block[:i.size]), nil
+	}
+
+	var extents []extent
+	var err error
+	if i.flags&inodeFlagExtents != 0 {
+		extents, err = e.extentTree(i.block[:], maxExtentDepth, nil)
+	} else {
+		extents, err = e.blockMap(i)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read block map of inode %d: %w", i.num, err)
+	}
+	sort.Slice(extents, func(a, b int) bool { return extents[a].logical < extents[b].logical })
+	return &inodeReader{fs: e, size: int64(i.size), extents: extents}, nil
+}
+
+// extentTree appends the extents of the extent tree node to out.
+func (e *extFS) extentTree(node []byte, maxDepth int, out []extent) ([]extent, error) {
+	if len(node) < 12 || le.Uint16(node) != extentMagic {
+		return nil, errors.New("invalid extent header")
+	}
+	entries := int(le.Uint16(node[2:]))
+	depth := int(le.Uint16(node[6:]))
+	if depth > maxDepth {
+		return nil, fmt.Errorf("invalid extent tree depth %d", depth)
+	}
+	for n := 0; n < entries; n++ {
+		entry := node[12+12*n:]
+		if len(entry) < 12 {
+			return nil, errors.New("extent entries exceed node")
+		}
+		if depth == 0 {
+			length := uint64(le.Uint16(entry[4:]))
+			physical := uint64(le.Uint16(entry[6:]))<<32 | uint64(le.Uint32(entry[8:]))
+			if length > 32768 {
+				length -= 32768
+				physical = 0
+			}
+			out = append(out, extent{logical: uint64(le.Uint32(entry)), length: length, physical: physical})
+			continue
+		}
+		child := make([]byte, e.blockSize)
+		leaf := uint64(le.Uint16(entry[8:]))<<32 | uint64(le.Uint32(entry[4:]))
+		if _, err := e.r.ReadAt(child, int64(leaf*e.blockSize)); err != nil {
+			return nil, err
+		}
+		var err error
+		if out, err = e.extentTree(child, depth-1, out); err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
+}
+
+// blockMap returns the extents of a file that uses the direct and indirect
+// block pointers of ext2 and ext3.
+func (e *extFS) blockMap(i *inode) ([]extent, error) {
+	m := &blockMapper{fs: e, blocks: (i.size + e.blockSize - 1) / e.blockSize}
+	for n := 0; n < 12 && m.logical < m.blocks; n++ {
+		m.add(uint64(le.Uint32(i.block[4*n:])))
+	}
+	for level := 1; level <= 3 && m.logical < m.blocks; level++ {
+		if err := m.indirect(uint64(le.Uint32(i.block[4*(11+level):])), level); err != nil {
+			return nil, err
+		}
+	}
+	return m.extents, nil
+}
+
+type blockMapper struct {
+	fs      *extFS
+	blocks  uint64
+	logical uint64
+	extents []extent
+}
+
+// add maps the next logical block to the physical block, merging contiguous
+// blocks into one extent. Holes have a physical block of 0.
+func (m *blockMapper) add(physical uint64) {
+	defer func() { m.logical++ }()
+	if physical == 0 {
+		return
+	}
+	if n := len(m.extents); n > 0 {
+		last := &m.extents[n-1]
+		if last.logical+last.length == m.logical && last.physical+last.length == physical {
+			last.length++
+			return
+		}
+	}
+	m.extents = append(m.extents, extent{logical: m.logical, length: 1, physical: physical})
+}
+
+// indirect maps the blocks referenced by an indirect block of the given level.
+func (m *blockMapper) indirect(block uint64, level int) error {
+	perBlock := m.fs.blockSize / 4
+	if block == 0 {
+		span := uint64(1)
+		for l := 0; l < level; l++ {
+			span *= perBlock
+		}
+		m.logical += span
+		return nil
+	}
+	buf := make([]byte, m.fs.blockSize)
+	if _, err := m.fs.r.ReadAt(buf, int64(block*m.fs.blockSize)); err != nil {
+		return err
+	}
+	for n := uint64(0); n < perBlock && m.logical < m.blocks; n++ {
+		p := uint64(le.Uint32(buf[4*n:]))
+		if level == 1 {
+			m.add(p)
+			continue
+		}
+		if err := m.indirect(p, level-1); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// inodeReader reads the contents of an inode through its extents.
+type inodeReader struct {
+	fs      *extFS
+	size    int64
+	extents []extent
+}
+
+func (r *inodeReader) ReadAt(p []byte, off int64) (int, error) {
+	if off < 0 {
+		return 0, errors.New("negative offset")
+	}
+	if off >= r.size {
+		return 0, io.EOF
+	}
+	want := p
+	if int64(len(p)) > r.size-off {
+		want = p[:r.size-off]
+	}
+	bs := r.fs.blockSize
+	n := 0
+	for n < len(want) {
+		pos := uint64(off) + uint64(n)
+		block := pos / bs
+		// The first extent that ends after the block.
+		idx := sort.Search(len(r.extents), func(i int) bool {
+			return r.extents[i].logical+r.extents[i].length > block
+		})
+		var chunk uint64
+		var ext *extent
+		if idx < len(r.extents) && r.extents[idx].logical <= block {
+			ext = &r.extents[idx]
+			chunk = (ext.logical+ext.length)*bs - pos
+		} else if idx < len(r.extents) {
+			chunk = r.extents[idx].logical*bs - pos
+		} else {
+			chunk = uint64(len(want) - n)
+		}
+		chunk = min(chunk, uint64(len(want)-n))
+		buf := want[n : n+int(chunk)]
+		if ext != nil && ext.physical != 0 {
+			physical := (ext.physical+block-ext.logical)*bs + pos%bs
+			if m, err := r.fs.r.ReadAt(buf, int64(physical)); err != nil && !(errors.Is(err, io.EOF) && m == len(buf)) {
+				return n + m, err
+			}
+		} else {
+			clear(buf)
+		}
+		n += len(buf)
+	}
+	if n < len(p) {
+		return n, io.EOF
+	}
+	return n, nil
+}
+
+// dirent is an entry of a directory.
+type dirent struct {
+	name  string
+	inode uint32
+	mode  fs.FileMode
+}
+
+// readDir returns the entries of a directory, sorted by name.
+func (e *extFS) readDir(i *inode) ([]dirent, error) {
+	e.mu.Lock()
+	entries, ok := e.dirs[i.num]
+	e.mu.Unlock()
+	if ok {
+		return entries, nil
+	}
+
+	r, err := e.data(i)
+	if err != nil {
+		return nil, err
+	}
+	if i.flags&inodeFlagInlineData != 0 {
+		return nil, errors.New("inline directories are not supported")
+	}
+	data := make([]byte, i.size)
+	if _, err := r.ReadAt(data, 0); err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+	// Hash tree indexed directories keep their index in entries with inode 0 or
+	// inside the rec_len of "..", so they can be read like linear ones.
+	for off := 0; off+8 <= len(data); {
+		num := le.Uint32(data[off:])
+		recLen := int(le.Uint16(data[off+4:]))
+		nameLen := int(data[off+6])
+		fileType := data[off+7]
+		if !e.hasFiletype {
+			nameLen |= int(fileType) << 8
+			fileType = 0
+		}
+		if recLen < 8 || off+recLen > len(data) || 8+nameLen > recLen {
+			return nil, fmt.Errorf("invalid entry at offset %d of directory inode %d", off, i.num)
+		}
+		name := string(data[off+8 : off+8+nameLen])
+		off += recLen
+		if num == 0 || name == "." || name == ".." {
+			continue
+		}
+		d := dirent{name: name, inode: num}
+		if d.mode, ok = direntModes[fileType]; !ok {
+			// Old filesystems don't store the type in the directory.
+			child, err := e.readInode(num)
+			if err != nil {
+				return nil, err
+			}
+			d.mode = child.fileMode().Type()
+		}
+		entries = append(entries, d)
+	}
+	slices.SortFunc(entries, func(a, b dirent) int { return strings.Compare(a.name, b.name) })
+
+	e.mu.Lock()
+	if len(e.dirs) >= maxCachedDirs {
+		clear(e.dirs)
+	}
+	e.dirs[i.num] = entries
+	e.mu.Unlock()
+	return entries, nil
+}
+
+// direntModes maps the file types stored in directory entries to file modes.
+var direntModes = map[uint8]fs.FileMode{
+	1: 0,
+	2: fs.ModeDir,
+	3: fs.ModeDevice | fs.ModeCharDevice,
+	4: fs.ModeDevice,
+	5: fs.ModeNamedPipe,
+	6: fs.ModeSocket,
+	7: fs.ModeSymlink,
+}
+
+// readLink returns the target of a symlink.
+func (e *extFS) readLink(i *inode) (string, error) {
+	r, err := e.data(i)
+	if err != nil {
+		return "", err
+	}
+	target := make([]byte, i.size)
+	if _, err := r.ReadAt(target, 0); err != nil && !errors.Is(err, io.EOF) {
+		return "", err
+	}
+	return string(target), nil
+}
+
+// lookup returns the inode at the given path. Symlinks are resolved relative
+// to the root of the filesystem, and the last element is only resolved if
+// followLast is set.
+func (e *extFS) lookup(name string, followLast bool) (*inode, error) {
+	for hops := 0; hops <= maxSymlinkHops; hops++ {
+		cur, err := e.readInode(rootInode)
+		if err != nil {
+			return nil, err
+		}
+		if name == "." {
+			return cur, nil
+		}
+		parts := strings.Split(name, "/")
+		resolved := true
+		for n, part := range parts {
+			if !cur.isDir() {
+				return nil, errNotDir
+			}
+			entries, err := e.readDir(cur)
+			if err != nil {
+				return nil, err
+			}
+			idx, found := slices.BinarySearchFunc(entries, part, func(d dirent, name string) int { return strings.Compare(d.name, name) })
+			if !found {
+				return nil, fs.ErrNotExist
+			}
+			next, err := e.readInode(entries[idx].inode)
+			if err != nil {
+				return nil, err
+			}
+			if next.isSymlink() && (n < len(parts)-1 || followLast) {
+				target, err := e.readLink(next)
+				if err != nil {
+					return nil, err
+				}
+				if !path.IsAbs(target) {
+					target = path.Join(append(parts[:n:n], target)...)
+				}
+				name = cleanPath(path.Join(append([]string{target}, parts[n+1:]...)...))
+				resolved = false
+				break
+			}
+			cur = next
+		}
+		if resolved {
+			return cur, nil
+		}
+	}
+	return nil, errTooManyLinks
+}
+
+// cleanPath turns a path into a valid fs.FS path. ".." elements that would
+// leave the root of the filesystem are dropped.
+func cleanPath(p string) string {
+	p = strings.TrimPrefix(path.Clean("/"+p), "/")
+	if p == "" {
+		return "."
+	}
+	return p
+}
+
+func (e *extFS) resolve(op, name string, followLast bool) (*inode, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: op, Path: name, Err: fs.ErrInvalid}
+	}
+	i, err := e.lookup(name, followLast)
+	if err != nil {
+		return nil, &fs.PathError{Op: op, Path: name, Err: err}
+	}
+	return i, nil
+}
+
+// Open opens the named file, following symlinks.
+func (e *extFS) Open(name string) (fs.File, error) {
+	i, err := e.resolve("open", name, true)
+	if err != nil {
+		return nil, err
+	}
+	info := &fileInfo{name: path.Base(name), inode: i}
+	if i.isDir() {
+		entries, err := e.readDir(i)
+		if err != nil {
+			return nil, &fs.PathError{Op: "open", Path: name, Err: err}
+		}
+		return &dir{fs: e, info: info, entries: entries}, nil
+	}
+	r, err := e.data(i)
+	if err != nil {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: err}
+	}
+	return &file{info: info, SectionReader: io.NewSectionReader(r, 0, int64(i.size))}, nil
+}
+
+// ReadDir reads the named directory and returns its entries sorted by name.
+func (e *extFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	i, err := e.resolve("readdir", name, true)
+	if err != nil {
+		return nil, err
+	}
+	if !i.isDir() {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: errNotDir}
+	}
+	entries, err := e.readDir(i)
+	if err != nil {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: err}
+	}
+	return e.dirEntries(entries), nil
+}
+
+// Stat returns a FileInfo describing the named file, following symlinks.
+func (e *extFS) Stat(name string) (fs.FileInfo, error) {
+	i, err := e.resolve("stat", name, true)
+	if err != nil {
+		return nil, err
+	}
+	return &fileInfo{name: path.Base(name), inode: i}, nil
+}
+
+func (e *extFS) dirEntries(entries []dirent) []fs.DirEntry {
+	result := make([]fs.DirEntry, 0, len(entries))
+	for _, d := range entries {
+		result = append(result, &dirEntry{fs: e, dirent: d})
+	}
+	return result
+}
+
+type fileInfo struct {
+	name  string
+	inode *inode
+}
+
+func (i *fileInfo) Name() string       { return i.name }
+func (i *fileInfo) Size() int64        { return int64(i.inode.size) }
+func (i *fileInfo) Mode() fs.FileMode  { return i.inode.fileMode() }
+func (i *fileInfo) ModTime() time.Time { return i.inode.mtime }
+func (i *fileInfo) IsDir() bool        { return i.inode.isDir() }
+func (i *fileInfo) Sys() any           { return nil }
+
+type dirEntry struct {
+	fs *extFS
+	dirent
+}
+
+func (d *dirEntry) Name() string      { return d.name }
+func (d *dirEntry) IsDir() bool       { return d.mode.IsDir() }
+func (d *dirEntry) Type() fs.FileMode { return d.mode }
+
+func (d *dirEntry) Info() (fs.FileInfo, error) {
+	i, err := d.fs.readInode(d.inode)
+	if err != nil {
+		return nil, err
+	}
+	return &fileInfo{name: d.name, inode: i}, nil
+}
+
+// file is an opened file. It implements io.ReaderAt as required by scalibrfs.FS.
+type file struct {
+	info *fileInfo
+	*io.SectionReader
+}
+
+func (f *file) Stat() (fs.FileInfo, error) { return f.info, nil }
+func (f *file) Close() error               { return nil }
+
+// dir is an opened directory.
+type dir struct {
+	fs      *extFS
+	info    *fileInfo
+	entries []dirent
+	offset  int
+}
+
+func (d *dir) Stat() (fs.FileInfo, error) { return d.info, nil }
+func (d *dir) Close() error               { return nil }
+
+func (d *dir) Read([]byte) (int, error) {
+	return 0, &fs.PathError{Op: "read", Path: d.info.name, Err: errors.New("is a directory")}
+}
+
+func (d *dir) ReadDir(n int) ([]fs.DirEntry, error) {
+	rest := d.entries[d.offset:]
+	if n > 0 {
+		if len(rest) == 0 {
+			return nil, io.EOF
+		}
+		rest = rest[:min(n, len(rest))]
+	}
+	d.offset += len(rest)
+	return d.fs.dirEntries(rest), nil
+}
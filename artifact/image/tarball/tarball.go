@@ -0,0 +1,255 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package tarball composes the filesystem of a container image saved with
+// "docker save" so that it can be scanned like a directory, without access to
+// a container runtime.
+//
+// The layers are unpacked into a temporary directory in order. Whiteout files
+// of a layer remove the files of the layers below it, so files that were
+// deleted in the image don't show up in the composed filesystem.
+package tarball
+
+import (
+	"archive/tar"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	v1tarball "github.com/google/go-containerregistry/pkg/v1/tarball"
+	"github.com/google/osv-scalibr/artifact/image/whiteout"
+	scalibrfs "github.com/google/osv-scalibr/fs"
+	"github.com/google/osv-scalibr/log"
+)
+
+// Image is the composed filesystem of a container image read from a tarball.
+// It implements scalibrfs.FS.
+type Image struct {
+	scalibrfs.FS
+	dir    string
+	config *v1.ConfigFile
+	layers int
+}
+
+// Open reads the image tarball at path, e.g. one created with "docker save",
+// and composes the filesystems of its layers. The tarball has to contain a
+// single image. The image must be closed after use to remove the unpacked
+// files.
+func Open(path string) (*Image, error) {
+	img, err := v1tarball.ImageFromPath(path, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read image tarball %s: %w", path, err)
+	}
+	config, err := img.ConfigFile()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the config of %s: %w", path, err)
+	}
+	layers, err := img.Layers()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the layers of %s: %w", path, err)
+	}
+
+	dir, err := os.MkdirTemp("", "scalibr-image-")
+	if err != nil {
+		return nil, err
+	}
+	for i, l := range layers {
+		if err := applyLayer(dir, l); err != nil {
+			os.RemoveAll(dir)
+			return nil, fmt.Errorf("failed to unpack layer %d of %s: %w", i, path, err)
+		}
+	}
+	return &Image{
+		FS:     scalibrfs.DirFS(dir),
+		dir:    dir,
+		config: config,
+		layers: len(layers),
+	}, nil
+}
+
+// ConfigFile returns the config of the image, e.g. its creation time and
+// labels.
+func (i *Image) ConfigFile() *v1.ConfigFile { return i.config }
+
+// LayerCount returns the number of layers the filesystem was composed of.
+func (i *Image) LayerCount() int { return i.layers }
+
+// Close removes the unpacked files of the image.
+func (i *Image) Close() error {
+	return os.RemoveAll(i.dir)
+}
+
+// applyLayer adds the files of the layer to the composed filesystem in root.
+// The whiteouts of the layer only refer to the layers below it, so they're
+// applied in a first pass over the layer before any of its files are added.
+func applyLayer(root string, l v1.Layer) error {
+	if err := forEachEntry(l, func(name string, hdr *tar.Header, r io.Reader) error {
+		return applyWhiteout(root, name)
+	}); err != nil {
+		return err
+	}
+	return forEachEntry(l, func(name string, hdr *tar.Header, r io.Reader) error {
+		if strings.HasPrefix(path.Base(name), whiteout.WhiteoutPrefix) {
+			return nil
+		}
+		return addEntry(root, name, hdr, r)
+	})
+}
+
+// forEachEntry calls fn for every entry of the uncompressed layer, with its
+// path cleaned to be relative to the image root.
+func forEachEntry(l v1.Layer, fn func(name string, hdr *tar.Header, r io.Reader) error) error {
+	rc, err := l.Uncompressed()
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+	tr := tar.NewReader(rc)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		// Cleaning the path as an absolute one removes ".." elements that
+		// would point outside of the image root.
+		name := strings.TrimPrefix(path.Clean("/"+hdr.Name), "/")
+		if name == "" {
+			continue
+		}
+		if err := fn(name, hdr, tr); err != nil {
+			return fmt.Errorf("%s: %w", hdr.Name, err)
+		}
+	}
+}
+
+// applyWhiteout removes the files hidden by the whiteout file at name from the
+// lower layers. Other files are ignored.
+func applyWhiteout(root, name string) error {
+	dir, base := path.Split(name)
+	switch {
+	case base == whiteout.OpaqueWhiteout:
+		// The directory replaces the one of the lower layers.
+		entries, err := os.ReadDir(filepath.Join(root, filepath.FromSlash(dir)))
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		for _, e := range entries {
+			if err := os.RemoveAll(filepath.Join(root, filepath.FromSlash(dir), e.Name())); err != nil {
+				return err
+			}
+		}
+		return nil
+	case strings.HasPrefix(base, whiteout.WhiteoutPrefix):
+		deleted := strings.TrimPrefix(base, whiteout.WhiteoutPrefix)
+		return os.RemoveAll(filepath.Join(root, filepath.FromSlash(dir), deleted))
+	default:
+		return nil
+	}
+}
+
+// addEntry adds the tar entry at name to the composed filesystem in root,
+// replacing the file of a lower layer at the same path.
+func addEntry(root, name string, hdr *tar.Header, r io.Reader) error {
+	target := filepath.Join(root, filepath.FromSlash(name))
+	existing, err := os.Lstat(target)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	if existing != nil && !(hdr.Typeflag == tar.TypeDir && existing.IsDir()) {
+		if err := os.RemoveAll(target); err != nil {
+			return err
+		}
+	}
+	if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		// E.g. a parent directory is a dangling symlink.
+		log.Warnf("Skipping %s in image layer: %v", name, err)
+		return nil
+	}
+
+	switch hdr.Typeflag {
+	case tar.TypeDir:
+		// Keep the directory readable and writable so that it can be scanned
+		// and the files of higher layers can be added.
+		return os.MkdirAll(target, hdr.FileInfo().Mode().Perm()|0700)
+	case tar.TypeReg:
+		f, err := os.OpenFile(target, os.O_CREATE|os.O_EXCL|os.O_WRONLY, hdr.FileInfo().Mode().Perm()|0600)
+		if err != nil {
+			return err
+		}
+		if _, err := io.Copy(f, r); err != nil {
+			f.Close()
+			return err
+		}
+		if err := f.Close(); err != nil {
+			return err
+		}
+		return os.Chtimes(target, hdr.ModTime, hdr.ModTime)
+	case tar.TypeSymlink:
+		linkTarget, err := symlinkTarget(root, target, hdr.Linkname)
+		if err != nil {
+			log.Warnf("Skipping symlink %s in image layer: %v", name, err)
+			return nil
+		}
+		return os.Symlink(linkTarget, target)
+	case tar.TypeLink:
+		src := strings.TrimPrefix(path.Clean("/"+hdr.Linkname), "/")
+		if err := os.Link(filepath.Join(root, filepath.FromSlash(src)), target); err != nil {
+			log.Warnf("Skipping hard link %s in image layer: %v", name, err)
+		}
+		return nil
+	default:
+		// Devices, FIFOs etc. can't contain software inventory.
+		return nil
+	}
+}
+
+// symlinkTarget returns the target to use for a symlink at the path link in
+// root that points to linkname in the image. Absolute targets are made
+// relative so that they point into the image instead of the scanning host.
+// Targets that would point outside of the image are rejected.
+func symlinkTarget(root, link, linkname string) (string, error) {
+	// The parent directory can be a symlink itself, so resolve it to compute
+	// where the target really is.
+	parent, err := filepath.EvalSymlinks(filepath.Dir(link))
+	if err != nil {
+		return "", err
+	}
+	realRoot, err := filepath.EvalSymlinks(root)
+	if err != nil {
+		return "", err
+	}
+	var dest string
+	if path.IsAbs(linkname) {
+		dest = filepath.Join(realRoot, filepath.FromSlash(linkname))
+	} else {
+		dest = filepath.Join(parent, filepath.FromSlash(linkname))
+	}
+	if dest != realRoot && !strings.HasPrefix(dest, realRoot+string(filepath.Separator)) {
+		return "", fmt.Errorf("target %s is outside of the image", linkname)
+	}
+	// The cleaned target doesn't contain ".." elements after symlinks, which
+	// could otherwise lead outside of the image.
+	return filepath.Rel(parent, dest)
+}
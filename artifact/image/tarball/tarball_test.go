@@ -0,0 +1,188 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tarball_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	v1tarball "github.com/google/go-containerregistry/pkg/v1/tarball"
+	"github.com/google/osv-scalibr/artifact/image/tarball"
+)
+
+// entry is a file in a test layer. Directories end with "/".
+type entry struct {
+	name     string
+	content  string
+	typeflag byte
+	linkname string
+}
+
+func layer(t *testing.T, entries ...entry) v1.Layer {
+	t.Helper()
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	for _, e := range entries {
+		hdr := &tar.Header{Name: e.name, Typeflag: e.typeflag, Linkname: e.linkname, Mode: 0644, Size: int64(len(e.content))}
+		switch e.typeflag {
+		case tar.TypeDir:
+			hdr.Mode = 0755
+		case tar.TypeSymlink, tar.TypeLink:
+			hdr.Size = 0
+		default:
+			hdr.Typeflag = tar.TypeReg
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			t.Fatalf("tar.WriteHeader(%s): %v", e.name, err)
+		}
+		if _, err := tw.Write([]byte(e.content)); err != nil {
+			t.Fatalf("tar.Write(%s): %v", e.name, err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatalf("tar.Close(): %v", err)
+	}
+	l, err := v1tarball.LayerFromOpener(func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(buf.Bytes())), nil
+	})
+	if err != nil {
+		t.Fatalf("tarball.LayerFromOpener(): %v", err)
+	}
+	return l
+}
+
+func writeImage(t *testing.T, config v1.Config, created time.Time, layers ...v1.Layer) string {
+	t.Helper()
+	img, err := mutate.AppendLayers(empty.Image, layers...)
+	if err != nil {
+		t.Fatalf("mutate.AppendLayers(): %v", err)
+	}
+	img, err = mutate.Config(img, config)
+	if err != nil {
+		t.Fatalf("mutate.Config(): %v", err)
+	}
+	img, err = mutate.CreatedAt(img, v1.Time{Time: created})
+	if err != nil {
+		t.Fatalf("mutate.CreatedAt(): %v", err)
+	}
+	ref, err := name.ParseReference("example.com/app:latest")
+	if err != nil {
+		t.Fatalf("name.ParseReference(): %v", err)
+	}
+	path := filepath.Join(t.TempDir(), "image.tar")
+	if err := v1tarball.WriteToFile(path, ref, img); err != nil {
+		t.Fatalf("tarball.WriteToFile(): %v", err)
+	}
+	return path
+}
+
+func TestOpen(t *testing.T) {
+	created := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	path := writeImage(t, v1.Config{Labels: map[string]string{"maintainer": "team-a"}}, created,
+		layer(t,
+			entry{name: "etc/", typeflag: tar.TypeDir},
+			entry{name: "etc/deleted.txt", content: "deleted"},
+			entry{name: "usr/lib/os-release", content: "ID=debian\n"},
+			entry{name: "opt/app/old.txt", content: "old"},
+			entry{name: "bin/tool", content: "v1"},
+			entry{name: "../outside.txt", content: "outside"},
+			entry{name: "escape", typeflag: tar.TypeSymlink, linkname: "../../../../etc/passwd"},
+		),
+		layer(t,
+			entry{name: "etc/.wh.deleted.txt"},
+			entry{name: "opt/app/new.txt", content: "new"},
+			entry{name: "opt/app/.wh..wh..opq"},
+			entry{name: "bin/tool", content: "v2"},
+			entry{name: "etc/os-release", typeflag: tar.TypeSymlink, linkname: "/usr/lib/os-release"},
+			entry{name: "bin/tool-link", typeflag: tar.TypeLink, linkname: "bin/tool"},
+		),
+	)
+
+	img, err := tarball.Open(path)
+	if err != nil {
+		t.Fatalf("tarball.Open(%s): %v", path, err)
+	}
+	defer img.Close()
+
+	wantFiles := map[string]string{
+		"usr/lib/os-release": "ID=debian\n",
+		"etc/os-release":     "ID=debian\n",
+		"opt/app/new.txt":    "new",
+		"bin/tool":           "v2",
+		"bin/tool-link":      "v2",
+		"outside.txt":        "outside",
+	}
+	for f, want := range wantFiles {
+		got, err := fs.ReadFile(img, f)
+		if err != nil {
+			t.Errorf("fs.ReadFile(%s): %v", f, err)
+			continue
+		}
+		if string(got) != want {
+			t.Errorf("fs.ReadFile(%s): got %q, want %q", f, got, want)
+		}
+	}
+	for _, f := range []string{"etc/deleted.txt", "etc/.wh.deleted.txt", "opt/app/old.txt", "opt/app/.wh..wh..opq", "escape"} {
+		if _, err := img.Stat(f); !errors.Is(err, fs.ErrNotExist) {
+			t.Errorf("Stat(%s): got error %v, want %v", f, err, fs.ErrNotExist)
+		}
+	}
+
+	if got := img.ConfigFile().Created.Time; !got.Equal(created) {
+		t.Errorf("ConfigFile().Created: got %v, want %v", got, created)
+	}
+	if diff := cmp.Diff(map[string]string{"maintainer": "team-a"}, img.ConfigFile().Config.Labels); diff != "" {
+		t.Errorf("ConfigFile().Config.Labels unexpected diff (-want +got):\n%s", diff)
+	}
+	if got := img.LayerCount(); got != 2 {
+		t.Errorf("LayerCount(): got %d, want 2", got)
+	}
+}
+
+func TestClose(t *testing.T) {
+	path := writeImage(t, v1.Config{}, time.Time{}, layer(t, entry{name: "a.txt", content: "a"}))
+	img, err := tarball.Open(path)
+	if err != nil {
+		t.Fatalf("tarball.Open(%s): %v", path, err)
+	}
+	if err := img.Close(); err != nil {
+		t.Fatalf("Close(): %v", err)
+	}
+	if _, err := img.Stat("a.txt"); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("Stat(a.txt) after Close(): got error %v, want %v", err, fs.ErrNotExist)
+	}
+}
+
+func TestOpenInvalid(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "invalid.tar")
+	if err := os.WriteFile(path, []byte("not a tarball"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tarball.Open(path); err == nil {
+		t.Errorf("tarball.Open(%s) succeeded, want error", path)
+	}
+}
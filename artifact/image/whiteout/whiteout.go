@@ -31,6 +31,9 @@ const (
 	// WhiteoutDirPrefix is the prefix found on whiteout directories. This means the directory cannot
 	// hold any more files in the current layer, as well as future layers.
 	WhiteoutDirPrefix = ".wh..wh..opq."
+	// OpaqueWhiteout is the name of the whiteout file that marks its directory
+	// as opaque: The files of the lower layers in the directory are hidden.
+	OpaqueWhiteout = ".wh..wh..opq"
 )
 
 // Files outputs all of the whiteout files found in an FS.
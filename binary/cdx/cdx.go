@@ -12,18 +12,49 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-// Package cdx provides utilities for writing CycloneDX documents to the filesystem.
+// Package cdx provides utilities for reading and writing CycloneDX documents on the filesystem.
 package cdx
 
 import (
+	"errors"
 	"fmt"
+	"io/fs"
 	"os"
+	"path/filepath"
+	"strings"
 
 	"github.com/CycloneDX/cyclonedx-go"
+	"github.com/google/osv-scalibr/binary/output"
+	"github.com/google/osv-scalibr/converter"
+	"github.com/google/osv-scalibr/log"
 )
 
-// Write writes an CDX document into a file in the choosen format.
+// Options are the settings for writing a CDX document.
+type Options struct {
+	// If set, the document is written without indentation to save space.
+	Compact bool
+	// If set and the file already contains a CDX document, the components of
+	// the new document are merged into it instead of overwriting it, see
+	// converter.MergeCDX. Only supported for local files.
+	Append bool
+}
+
+// Write writes an CDX document into a file in the choosen format. The document
+// is indented for readability.
+// The path can also be a URL such as s3://bucket/result.cdx.json, see output.Create.
 func Write(doc *cyclonedx.BOM, path string, format string) error {
+	return WriteWithOptions(doc, path, format, Options{})
+}
+
+// WriteCompact is like Write but writes the document without indentation to
+// save space.
+func WriteCompact(doc *cyclonedx.BOM, path string, format string) error {
+	return WriteWithOptions(doc, path, format, Options{Compact: true})
+}
+
+// WriteWithOptions writes an CDX document into a file in the chosen format
+// with the given options.
+func WriteWithOptions(doc *cyclonedx.BOM, path string, format string, opts Options) error {
 	var cdxFormat cyclonedx.BOMFileFormat
 	switch format {
 	case "cdx-json":
@@ -33,11 +64,68 @@ func Write(doc *cyclonedx.BOM, path string, format string) error {
 	default:
 		return fmt.Errorf("%s has an invalid CDX format or not supported by SCALIBR", path)
 	}
-	f, err := os.Create(path)
+	if opts.Append {
+		var err error
+		if doc, err = appendTo(doc, path, cdxFormat); err != nil {
+			return err
+		}
+	}
+	f, err := output.Create(path)
 	if err != nil {
 		return err
 	}
+	encoder := cyclonedx.NewBOMEncoder(f, cdxFormat).SetPretty(!opts.Compact)
+	if err := encoder.Encode(doc); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// appendTo returns doc merged into the document stored in the file at path, or
+// doc itself if the file doesn't exist yet.
+func appendTo(doc *cyclonedx.BOM, path string, cdxFormat cyclonedx.BOMFileFormat) (*cyclonedx.BOM, error) {
+	if output.IsURL(path) {
+		return nil, fmt.Errorf("can't append to %s: only local files are supported", path)
+	}
+	existing, err := read(path, cdxFormat)
+	if errors.Is(err, fs.ErrNotExist) {
+		return doc, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("can't append to %s: %w", path, err)
+	}
+	merged := converter.MergeCDX(existing, doc)
+	log.Infof("Added %d components to %s", componentCount(merged)-componentCount(existing), path)
+	return merged, nil
+}
+
+func componentCount(doc *cyclonedx.BOM) int {
+	if doc.Components == nil {
+		return 0
+	}
+	return len(*doc.Components)
+}
+
+// Read reads a CDX document from a file. Files with the .xml extension are
+// parsed as XML, everything else as JSON.
+func Read(path string) (*cyclonedx.BOM, error) {
+	cdxFormat := cyclonedx.BOMFileFormatJSON
+	if strings.ToLower(filepath.Ext(path)) == ".xml" {
+		cdxFormat = cyclonedx.BOMFileFormatXML
+	}
+	return read(path, cdxFormat)
+}
+
+func read(path string, cdxFormat cyclonedx.BOMFileFormat) (*cyclonedx.BOM, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
 	defer f.Close()
-	encoder := cyclonedx.NewBOMEncoder(f, cdxFormat).SetPretty(true)
-	return encoder.Encode(doc)
+	bom := &cyclonedx.BOM{}
+	if err := cyclonedx.NewBOMDecoder(f, cdxFormat).Decode(bom); err != nil {
+		return nil, err
+	}
+	return bom, nil
 }
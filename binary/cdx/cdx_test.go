@@ -87,6 +87,68 @@ func TestWrite(t *testing.T) {
 	}
 }
 
+func TestWriteCompact(t *testing.T) {
+	fullPath := filepath.Join(t.TempDir(), "output")
+	format := "cdx-json"
+	if err := cdx.WriteCompact(doc, fullPath, format); err != nil {
+		t.Fatalf("cdx.WriteCompact(%v, %s, %s) returned an error: %v", doc, fullPath, format, err)
+	}
+
+	got, err := os.ReadFile(fullPath)
+	if err != nil {
+		t.Fatalf("error while reading %s: %v", fullPath, err)
+	}
+	want, err := os.ReadFile("testdata/doc-compact.cyclonedx.json")
+	if err != nil {
+		t.Fatalf("error while reading testdata/doc-compact.cyclonedx.json: %v", err)
+	}
+	if diff := cmp.Diff(strings.TrimSpace(string(want)), strings.TrimSpace(string(got))); diff != "" {
+		t.Errorf("cdx.WriteCompact(%v, %s, %s) produced unexpected results, diff (-want +got):\n%s", doc, fullPath, format, diff)
+	}
+}
+
+func TestWriteWithOptions_Append(t *testing.T) {
+	fullPath := filepath.Join(t.TempDir(), "sbom.cyclonedx.json")
+	format := "cdx-json"
+	component := func(name string) cyclonedx.Component {
+		return cyclonedx.Component{BOMRef: name, Type: cyclonedx.ComponentTypeLibrary, Name: name, Version: "1.0", PackageURL: "pkg:npm/" + name + "@1.0"}
+	}
+	stage1 := cyclonedx.NewBOM()
+	stage1.SerialNumber = "urn:uuid:3e671687-395b-41f5-a30f-a58921a69b79"
+	stage1.Components = &[]cyclonedx.Component{component("a")}
+	stage2 := cyclonedx.NewBOM()
+	stage2.SerialNumber = "urn:uuid:00000000-0000-0000-0000-000000000000"
+	stage2.Components = &[]cyclonedx.Component{component("a"), component("b")}
+
+	// The first write creates the file.
+	for _, d := range []*cyclonedx.BOM{stage1, stage2} {
+		if err := cdx.WriteWithOptions(d, fullPath, format, cdx.Options{Append: true}); err != nil {
+			t.Fatalf("cdx.WriteWithOptions(%v, %s, %s): %v", d, fullPath, format, err)
+		}
+	}
+
+	got, err := cdx.Read(fullPath)
+	if err != nil {
+		t.Fatalf("cdx.Read(%s): %v", fullPath, err)
+	}
+	if got.SerialNumber != stage1.SerialNumber {
+		t.Errorf("cdx.WriteWithOptions() wrote serial number %q, want %q", got.SerialNumber, stage1.SerialNumber)
+	}
+	if got.Version != 2 {
+		t.Errorf("cdx.WriteWithOptions() wrote version %d, want 2", got.Version)
+	}
+	if diff := cmp.Diff(&[]cyclonedx.Component{component("a"), component("b")}, got.Components); diff != "" {
+		t.Errorf("cdx.WriteWithOptions() wrote unexpected components (-want +got):\n%s", diff)
+	}
+}
+
+func TestWriteWithOptions_AppendToURL(t *testing.T) {
+	path := "gs://bucket/sbom.cyclonedx.json"
+	if err := cdx.WriteWithOptions(doc, path, "cdx-json", cdx.Options{Append: true}); err == nil {
+		t.Errorf("cdx.WriteWithOptions(%s) with Append didn't return an error", path)
+	}
+}
+
 func TestWrite_InvalidFormat(t *testing.T) {
 	testDirPath := t.TempDir()
 	fullPath := filepath.Join(testDirPath, "output")
@@ -96,3 +158,76 @@ func TestWrite_InvalidFormat(t *testing.T) {
 		t.Errorf("cdx.Write(%s, %s) didn't return an invalid extension error: %v", fullPath, format, err)
 	}
 }
+
+func TestValidate(t *testing.T) {
+	testCases := []struct {
+		desc        string
+		components  []cyclonedx.Component
+		specVersion cyclonedx.SpecVersion
+		wantErrText string
+	}{
+		{
+			desc: "valid document",
+			components: []cyclonedx.Component{{
+				BOMRef:     "pkg:pypi/software@1.0",
+				Type:       cyclonedx.ComponentTypeLibrary,
+				Name:       "software",
+				Version:    "1.0",
+				PackageURL: "pkg:pypi/software@1.0",
+			}},
+		},
+		{
+			desc:        "invalid component type",
+			components:  []cyclonedx.Component{{Type: "package", Name: "software"}},
+			wantErrText: "components.0.type",
+		},
+		{
+			desc: "invalid hash algorithm",
+			components: []cyclonedx.Component{{
+				Type:   cyclonedx.ComponentTypeLibrary,
+				Name:   "software",
+				Hashes: &[]cyclonedx.Hash{{Algorithm: "CRC32", Value: "cbf43926"}},
+			}},
+			wantErrText: "components.0.hashes.0.alg",
+		},
+		{
+			desc:        "unsupported spec version",
+			specVersion: cyclonedx.SpecVersion1_5,
+			wantErrText: "not supported",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			doc := cyclonedx.NewBOM()
+			doc.Components = &tc.components
+			if tc.specVersion != 0 {
+				doc.SpecVersion = tc.specVersion
+			}
+			err := cdx.Validate(doc)
+			if tc.wantErrText == "" {
+				if err != nil {
+					t.Errorf("cdx.Validate(%v): %v", doc, err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.wantErrText) {
+				t.Errorf("cdx.Validate(%v) returned %v, want error mentioning %q", doc, err, tc.wantErrText)
+			}
+		})
+	}
+}
+
+func TestRead(t *testing.T) {
+	for _, path := range []string{"testdata/doc.cyclonedx.xml", "testdata/doc.cyclonedx.json"} {
+		t.Run(path, func(t *testing.T) {
+			got, err := cdx.Read(path)
+			if err != nil {
+				t.Fatalf("cdx.Read(%s) returned an error: %v", path, err)
+			}
+			if diff := cmp.Diff(doc.Metadata.Component.Name, got.Metadata.Component.Name); diff != "" {
+				t.Errorf("cdx.Read(%s) produced unexpected component name, diff (-want +got):\n%s", path, diff)
+			}
+		})
+	}
+}
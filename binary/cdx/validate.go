@@ -0,0 +1,88 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cdx
+
+import (
+	"bytes"
+	_ "embed" // Needed for go:embed
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/CycloneDX/cyclonedx-go"
+	"github.com/xeipuuv/gojsonschema"
+)
+
+// maxReportedSchemaErrors limits how many schema violations are listed in the
+// validation error.
+const maxReportedSchemaErrors = 10
+
+// The CycloneDX 1.6 schema references the SPDX license list and the JSON
+// signature format schemas, which are loaded alongside it.
+var (
+	//go:embed schema/bom-1.6.schema.json
+	bom16Schema []byte
+	//go:embed schema/spdx.schema.json
+	spdxLicenseSchema []byte
+	//go:embed schema/jsf-0.82.schema.json
+	jsfSchema []byte
+)
+
+var compileBOM16Schema = sync.OnceValues(func() (*gojsonschema.Schema, error) {
+	loader := gojsonschema.NewSchemaLoader()
+	loader.Draft = gojsonschema.Draft7
+	if err := loader.AddSchemas(gojsonschema.NewBytesLoader(spdxLicenseSchema), gojsonschema.NewBytesLoader(jsfSchema)); err != nil {
+		return nil, err
+	}
+	return loader.Compile(gojsonschema.NewBytesLoader(bom16Schema))
+})
+
+// Validate checks the CDX document against the CycloneDX JSON schema of its
+// spec version and returns an error describing the violations if it doesn't
+// match. The document is checked in its JSON encoding, independent of the
+// format it's written in. Only CycloneDX 1.6 is supported.
+func Validate(doc *cyclonedx.BOM) error {
+	if doc.SpecVersion != cyclonedx.SpecVersion1_6 {
+		return fmt.Errorf("validating CycloneDX %s documents is not supported", doc.SpecVersion)
+	}
+	schema, err := compileBOM16Schema()
+	if err != nil {
+		return fmt.Errorf("failed to compile the CycloneDX 1.6 JSON schema: %w", err)
+	}
+	var buf bytes.Buffer
+	if err := cyclonedx.NewBOMEncoder(&buf, cyclonedx.BOMFileFormatJSON).Encode(doc); err != nil {
+		return fmt.Errorf("failed to encode the CDX document as JSON: %w", err)
+	}
+	result, err := schema.Validate(gojsonschema.NewBytesLoader(buf.Bytes()))
+	if err != nil {
+		return fmt.Errorf("failed to validate the CDX document: %w", err)
+	}
+	if result.Valid() {
+		return nil
+	}
+	return fmt.Errorf("CDX document doesn't match the CycloneDX 1.6 JSON schema: %s", formatSchemaErrors(result.Errors()))
+}
+
+func formatSchemaErrors(errs []gojsonschema.ResultError) string {
+	msgs := make([]string, 0, min(len(errs), maxReportedSchemaErrors))
+	for _, e := range errs {
+		if len(msgs) == maxReportedSchemaErrors {
+			msgs = append(msgs, fmt.Sprintf("and %d more", len(errs)-maxReportedSchemaErrors))
+			break
+		}
+		msgs = append(msgs, e.String())
+	}
+	return strings.Join(msgs, "; ")
+}
@@ -16,29 +16,47 @@
 package cli
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"os"
+	"path/filepath"
 	"regexp"
 	"slices"
+	"strconv"
 	"strings"
+	"time"
 
+	"github.com/go-yaml/yaml"
 	"github.com/spdx/tools-golang/spdx/v2/common"
+	"github.com/google/osv-scalibr/artifact/diskimage"
+	"github.com/google/osv-scalibr/artifact/image/tarball"
 	"github.com/google/osv-scalibr/binary/cdx"
+	"github.com/google/osv-scalibr/binary/output"
 	"github.com/google/osv-scalibr/binary/platform"
 	"github.com/google/osv-scalibr/binary/proto"
+	spb "github.com/google/osv-scalibr/binary/proto/scan_result_go_proto"
 	"github.com/google/osv-scalibr/binary/spdx"
 	"github.com/google/osv-scalibr/converter"
 	"github.com/google/osv-scalibr/detector"
 	"github.com/google/osv-scalibr/detector/govulncheck/binary"
 	dl "github.com/google/osv-scalibr/detector/list"
+	"github.com/google/osv-scalibr/enricher"
+	enl "github.com/google/osv-scalibr/enricher/list"
+	"github.com/google/osv-scalibr/extractor"
 	"github.com/google/osv-scalibr/extractor/filesystem"
+	"github.com/google/osv-scalibr/extractor/filesystem/external"
 	el "github.com/google/osv-scalibr/extractor/filesystem/list"
+	"github.com/google/osv-scalibr/extractor/filesystem/resultcache"
 	sl "github.com/google/osv-scalibr/extractor/standalone/list"
 	"github.com/google/osv-scalibr/extractor/standalone"
 	scalibrfs "github.com/google/osv-scalibr/fs"
 	"github.com/google/osv-scalibr/log"
 	"github.com/google/osv-scalibr/plugin"
+	"github.com/google/osv-scalibr/purl"
+	"github.com/google/osv-scalibr/transformer"
+	tl "github.com/google/osv-scalibr/transformer/list"
 	scalibr "github.com/google/osv-scalibr"
 )
 
@@ -64,14 +82,19 @@ func (i *Array) Get() any {
 
 // Flags contains a field for all the cli flags that can be set.
 type Flags struct {
-	Root                  string
+	Root                  Array
+	DiskImage             string
+	ImageTarball          string
 	ResultFile            string
 	Output                Array
 	ExtractorsToRun       string
 	DetectorsToRun        string
+	EnrichersToRun        string
+	TransformersToRun     string
 	FilesToExtract        []string
 	DirsToSkip            string
 	SkipDirRegex          string
+	IncludeDirRegex       string
 	GovulncheckDBPath     string
 	SPDXDocumentName      string
 	SPDXDocumentNamespace string
@@ -79,31 +102,190 @@ type Flags struct {
 	CDXComponentName      string
 	CDXComponentVersion   string
 	CDXAuthors            string
+	SBOMTimestamp         string
 	Verbose               bool
+	Quiet                 bool
+	TraceFileRequired     bool
+	ConfigFile            string
 	ExplicitExtractors    bool
 	FilterByCapabilities  bool
+	MaxMemoryClass        string
+	StandaloneConcurrency int
 	StoreAbsolutePath     bool
 	WindowsAllDrives      bool
+	VerifySBOM            string
+	LocationPrefixTrim    string
+	PosixPaths            bool
+	Baseline              string
+	PackageClass          string
+	ValidateOutput        bool
+	FailOn                string
+	CountOnly             bool
+	WhichExtractors       string
+	// If set, experimental extractors are also enabled by groups such as
+	// "misc" or "all" and not only when they're named explicitly.
+	IncludeExperimental bool
+	// If set, an invalid SkipDirRegex is ignored with a warning instead of
+	// failing the scan. Useful for programs that embed the scanner and set the
+	// regex without going through ValidateFlags. An invalid IncludeDirRegex is
+	// still an error since ignoring it would widen the scan.
+	IgnoreInvalidSkipDirRegex bool
+	// If set, FilesToExtract are scan result files to merge instead of
+	// running a scan.
+	Merge bool
+	// The format of the logs: "text" (the default) or "json".
+	LogFormat string
+	// If set, the logs are written to this file instead of stderr.
+	LogFile string
+	// If set, FilesToExtract are relative to each scan root instead of the
+	// working directory.
+	FilesRelativeToRoot bool
+	// If set, the scan fails if an extractor failed on any file.
+	Strict bool
+	// If set, findings reported by several detectors are merged.
+	DeduplicateFindings bool
+	// The maximum number of bytes read per second during the filesystem walk.
+	// 0 means unlimited.
+	MaxBytesPerSecond int64
+	// If set, the results of the filesystem extractors are cached in this
+	// directory and reused for files with the same content in later scans.
+	ResultCacheDir string
+	// The maximum size of the result cache. The least recently used results
+	// are removed once it's exceeded. 0 means unlimited.
+	ResultCacheMaxBytes int64
+	// If set, the JSON outputs are written without indentation.
+	CompactJSON bool
+	// If set, the CDX outputs are merged into the existing documents at the
+	// output paths instead of overwriting them.
+	AppendCDX bool
+	// If set, the number of files each extractor requires is printed instead
+	// of running a scan.
+	CoverageReport bool
+	// If set, only the files of the git work tree at the scan root that changed
+	// since this git ref are extracted. They're passed to the scan as
+	// FilesToExtractRelative. If no files changed, the list is empty and the
+	// scan shouldn't be run since it would walk the whole scan root, see
+	// NoChangedFiles.
+	SinceGitRef string
+	// Key-value pairs in the form key=value that are attached to the scan
+	// result, e.g. role=web.
+	Metadata Array
+	// If set, only the inventory of ecosystems that OSV has advisories for is
+	// written to the scan outputs.
+	OSVEcosystemsOnly bool
+	// Comma-separated list of the ecosystems kept by OSVEcosystemsOnly. If
+	// empty, DefaultOSVEcosystems are kept.
+	OSVEcosystems string
+	// Paths of executables implementing external extractors. They're started
+	// by GetScanConfig and can be enabled in ExtractorsToRun by the names they
+	// report.
+	ExtractorPlugins Array
+
+	// The started external extractors, keyed by lowercase name.
+	externalExtractors map[string]*external.Extractor
+}
+
+// Supported values of --log-format.
+const (
+	LogFormatText = "text"
+	LogFormatJSON = "json"
+)
+
+// DefaultOSVEcosystems are the ecosystems OSV has advisories for, see
+// https://ossf.github.io/osv-schema/#defined-ecosystems. Ecosystems with a
+// release suffix such as "Debian:12" match by the part before the colon.
+var DefaultOSVEcosystems = []string{
+	"AlmaLinux", "Alpine", "Android", "Bioconductor", "Bitnami", "Chainguard", "CRAN",
+	"crates.io", "Debian", "GHC", "GitHub Actions", "Go", "Hackage", "Hex", "Linux",
+	"Mageia", "Maven", "npm", "NuGet", "openEuler", "openSUSE", "OSS-Fuzz", "Packagist",
+	"Photon OS", "Pub", "PyPI", "Red Hat", "Rocky Linux", "RubyGems", "SUSE", "SwiftURL",
+	"Ubuntu", "Wolfi",
 }
 
 var supportedOutputFormats = []string{
-	"textproto", "binproto", "spdx23-tag-value", "spdx23-json", "spdx23-yaml", "cdx-json", "cdx-xml",
+	"textproto", "binproto", "spdx23-tag-value", "spdx23-json", "spdx23-yaml", "cdx-json", "cdx-xml", "purls",
 }
 
-// ValidateFlags validates the passed command line flags.
+// ValidateFlags validates the passed command line flags. List arguments that
+// refer to a file with "@" are replaced with the list read from the file.
 func ValidateFlags(flags *Flags) error {
-	if len(flags.ResultFile) == 0 && len(flags.Output) == 0 {
-		return errors.New("either --result or --o needs to be set")
+	if flags.Merge {
+		return validateMerge(flags)
 	}
-	if flags.Root != "" && flags.WindowsAllDrives {
+	if err := expandListArgs(flags); err != nil {
+		return err
+	}
+	if len(flags.WhichExtractors) > 0 {
+		if len(flags.ResultFile) > 0 || len(flags.Output) > 0 || len(flags.VerifySBOM) > 0 || flags.CountOnly || flags.CoverageReport {
+			return errors.New("--which-extractors cannot be used together with --result, --o, --verify-sbom, --count-only or --coverage-report")
+		}
+	} else if flags.CoverageReport {
+		if len(flags.ResultFile) > 0 || len(flags.Output) > 0 || len(flags.VerifySBOM) > 0 || flags.CountOnly {
+			return errors.New("--coverage-report cannot be used together with --result, --o, --verify-sbom or --count-only")
+		}
+	} else if flags.CountOnly {
+		if len(flags.ResultFile) > 0 || len(flags.Output) > 0 || len(flags.VerifySBOM) > 0 {
+			return errors.New("--count-only cannot be used together with --result, --o or --verify-sbom")
+		}
+	} else if len(flags.ResultFile) == 0 && len(flags.Output) == 0 && len(flags.VerifySBOM) == 0 {
+		return errors.New("either --result, --o, --verify-sbom, --count-only, --which-extractors or --coverage-report needs to be set")
+	}
+	if len(flags.Root) > 0 && flags.WindowsAllDrives {
 		return errors.New("--root and --windows-all-drives cannot be used together")
 	}
+	if len(flags.DiskImage) > 0 && (len(flags.Root) > 0 || flags.WindowsAllDrives) {
+		return errors.New("--disk-image cannot be used together with --root or --windows-all-drives")
+	}
+	if len(flags.ImageTarball) > 0 && (len(flags.Root) > 0 || flags.WindowsAllDrives || len(flags.DiskImage) > 0) {
+		return errors.New("--image-tarball cannot be used together with --root, --windows-all-drives or --disk-image")
+	}
+	if flags.Verbose && flags.Quiet {
+		return errors.New("--verbose and --quiet cannot be used together")
+	}
+	if err := validateLogFormat(flags.LogFormat); err != nil {
+		return fmt.Errorf("--log-format: %w", err)
+	}
+	if flags.MaxBytesPerSecond < 0 {
+		return errors.New("--max-bytes-per-second cannot be negative")
+	}
+	if flags.ResultCacheMaxBytes < 0 {
+		return errors.New("--result-cache-max-bytes cannot be negative")
+	}
+	if flags.AppendCDX {
+		if err := validateAppendCDX(flags.Output); err != nil {
+			return err
+		}
+	}
+	if err := validateSinceGitRef(flags); err != nil {
+		return err
+	}
+	if err := validateRoots(flags.Root); err != nil {
+		return fmt.Errorf("--root %w", err)
+	}
+	if err := validateImageFile(flags.DiskImage); err != nil {
+		return fmt.Errorf("--disk-image %w", err)
+	}
+	if err := validateImageFile(flags.ImageTarball); err != nil {
+		return fmt.Errorf("--image-tarball %w", err)
+	}
 	if err := validateResultPath(flags.ResultFile); err != nil {
 		return fmt.Errorf("--result %w", err)
 	}
 	if err := validateOutput(flags.Output); err != nil {
 		return fmt.Errorf("--o %w", err)
 	}
+	if err := validateMetadata(flags.Metadata); err != nil {
+		return fmt.Errorf("--metadata %w", err)
+	}
+	if err := validateExtractorPlugins(flags.ExtractorPlugins); err != nil {
+		return fmt.Errorf("--extractor-plugin %w", err)
+	}
+	if err := validateSBOMPath(flags.VerifySBOM); err != nil {
+		return fmt.Errorf("--verify-sbom %w", err)
+	}
+	if err := validateResultPath(flags.Baseline); err != nil {
+		return fmt.Errorf("--baseline %w", err)
+	}
 	// TODO(b/279413691): Use the Array struct to allow multiple occurrences of a list arg
 	// e.g. --extractors=ex1 --extractors=ex2.
 	if err := validateListArg(flags.ExtractorsToRun); err != nil {
@@ -112,18 +294,120 @@ func ValidateFlags(flags *Flags) error {
 	if err := validateListArg(flags.DetectorsToRun); err != nil {
 		return fmt.Errorf("--detectors: %w", err)
 	}
+	if err := validateListArg(flags.EnrichersToRun); err != nil {
+		return fmt.Errorf("--enrichers: %w", err)
+	}
+	if _, err := flags.enrichersToRun(); err != nil {
+		return fmt.Errorf("--enrichers: %w", err)
+	}
+	if err := validateListArg(flags.TransformersToRun); err != nil {
+		return fmt.Errorf("--transform: %w", err)
+	}
+	if _, err := flags.transformersToRun(); err != nil {
+		return fmt.Errorf("--transform: %w", err)
+	}
+	if _, err := parseFailOn(flags.FailOn); err != nil {
+		return fmt.Errorf("--fail-on: %w", err)
+	}
+	if flags.StandaloneConcurrency < 0 {
+		return errors.New("--standalone-concurrency can't be negative")
+	}
+	if err := validateMemoryClass(flags.MaxMemoryClass); err != nil {
+		return fmt.Errorf("--max-memory-class: %w", err)
+	}
+	if err := validatePackageClass(flags.PackageClass); err != nil {
+		return fmt.Errorf("--package-class: %w", err)
+	}
+	if len(flags.OSVEcosystems) > 0 {
+		if !flags.OSVEcosystemsOnly {
+			return errors.New("--osv-ecosystems can only be used together with --osv-ecosystems-only")
+		}
+		if err := validateListArg(flags.OSVEcosystems); err != nil {
+			return fmt.Errorf("--osv-ecosystems: %w", err)
+		}
+	}
+	if _, err := flags.sbomTimestamp(); err != nil {
+		return fmt.Errorf("--sbom-timestamp: %w", err)
+	}
 	if err := validateListArg(flags.DirsToSkip); err != nil {
 		return fmt.Errorf("--skip-dirs: %w", err)
 	}
-	if err := validateRegex(flags.SkipDirRegex); err != nil {
+	if _, err := CompileDirRegex(flags.SkipDirRegex); err != nil && !flags.IgnoreInvalidSkipDirRegex {
 		return fmt.Errorf("--skip-dir-regex: %w", err)
 	}
-	if err := validateDetectorDependency(flags.DetectorsToRun, flags.ExtractorsToRun, flags.ExplicitExtractors); err != nil {
+	if _, err := CompileDirRegex(flags.IncludeDirRegex); err != nil {
+		return fmt.Errorf("--include-dir-regex: %w", err)
+	}
+	if err := validateDetectorDependency(flags.DetectorsToRun, flags.ExtractorsToRun, flags.ExtractorPlugins, flags.ExplicitExtractors); err != nil {
 		return fmt.Errorf("--detectors: %w", err)
 	}
 	return nil
 }
 
+func validateMemoryClass(class string) error {
+	if len(class) == 0 {
+		return nil
+	}
+	_, err := plugin.ParseMemoryClass(class)
+	return err
+}
+
+// NewLogger returns the logger for the log format and verbosity set in the
+// flags, writing to w. For the text format, this redirects the output of the
+// DefaultLogger to w.
+func (f *Flags) NewLogger(w io.Writer) log.Logger {
+	if f.LogFormat == LogFormatJSON {
+		return log.NewJSONLogger(w, f.Verbose, f.Quiet)
+	}
+	log.SetOutput(w)
+	return &log.DefaultLogger{Verbose: f.Verbose, Quiet: f.Quiet}
+}
+
+func validateLogFormat(format string) error {
+	switch format {
+	case "", LogFormatText, LogFormatJSON:
+		return nil
+	default:
+		return fmt.Errorf("invalid log format %q, must be %s or %s", format, LogFormatText, LogFormatJSON)
+	}
+}
+
+func validatePackageClass(class string) error {
+	switch class {
+	case "", "all", string(extractor.PackageClassOS), string(extractor.PackageClassLanguage):
+		return nil
+	default:
+		return fmt.Errorf("invalid package class %q, must be one of os, language or all", class)
+	}
+}
+
+func validateRoots(roots []string) error {
+	var invalid []string
+	for _, root := range roots {
+		if info, err := os.Stat(root); err != nil || !info.IsDir() {
+			invalid = append(invalid, root)
+		}
+	}
+	if len(invalid) > 0 {
+		return fmt.Errorf("paths don't exist or aren't directories: %q", invalid)
+	}
+	return nil
+}
+
+func validateImageFile(path string) error {
+	if len(path) == 0 {
+		return nil
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	if info.IsDir() {
+		return fmt.Errorf("%q is a directory, use --root to scan it", path)
+	}
+	return nil
+}
+
 func validateResultPath(filePath string) error {
 	if len(filePath) == 0 {
 		return nil
@@ -131,11 +415,11 @@ func validateResultPath(filePath string) error {
 	if err := proto.ValidExtension(filePath); err != nil {
 		return err
 	}
-	return nil
+	return output.ValidatePath(filePath)
 }
 
-func validateOutput(output []string) error {
-	for _, item := range output {
+func validateOutput(outputs []string) error {
+	for _, item := range outputs {
 		o := strings.Split(item, "=")
 		if len(o) != 2 {
 			return fmt.Errorf("invalid output format, should follow a format like -o textproto=result.textproto -o spdx23-json=result.spdx.json")
@@ -144,10 +428,75 @@ func validateOutput(output []string) error {
 		if !slices.Contains(supportedOutputFormats, oFormat) {
 			return fmt.Errorf("output format %q not recognized, supported formats are %v", oFormat, supportedOutputFormats)
 		}
+		if err := output.ValidatePath(o[1]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validateMetadata(metadata []string) error {
+	keys := make(map[string]bool)
+	for _, item := range metadata {
+		key, _, found := strings.Cut(item, "=")
+		if !found || len(key) == 0 {
+			return fmt.Errorf("invalid metadata %q, should follow a format like --metadata role=web --metadata region=europe-west1", item)
+		}
+		if keys[key] {
+			return fmt.Errorf("duplicate metadata key %q", key)
+		}
+		keys[key] = true
 	}
 	return nil
 }
 
+// validateExtractorPlugins checks that the paths passed with
+// --extractor-plugin exist and aren't directories.
+func validateExtractorPlugins(paths []string) error {
+	for _, p := range paths {
+		info, err := os.Stat(p)
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return fmt.Errorf("%q is a directory", p)
+		}
+	}
+	return nil
+}
+
+// validateAppendCDX checks that the CDX outputs can be appended to, i.e. that
+// they're local files.
+func validateAppendCDX(outputs []string) error {
+	for _, item := range outputs {
+		oFormat, oPath, _ := strings.Cut(item, "=")
+		if strings.HasPrefix(oFormat, "cdx") && output.IsURL(oPath) {
+			return fmt.Errorf("--cdx-append: can't append to %s, only local files are supported", oPath)
+		}
+	}
+	return nil
+}
+
+func validateSBOMPath(filePath string) error {
+	if len(filePath) == 0 {
+		return nil
+	}
+	if isSPDXPath(filePath) {
+		return nil
+	}
+	switch strings.ToLower(filepath.Ext(filePath)) {
+	case ".json", ".xml":
+		return nil
+	}
+	return fmt.Errorf("invalid filename: %s is not an SPDX (*.spdx, *.spdx.json, *.spdx.yaml) or CDX (*.json, *.xml) file", filePath)
+}
+
+// isSPDXPath returns true if the file name marks the file as an SPDX document.
+// All other SBOMs are treated as CycloneDX.
+func isSPDXPath(filePath string) bool {
+	return strings.Contains(strings.ToLower(filepath.Base(filePath)), ".spdx")
+}
+
 func validateSPDXCreators(creators string) error {
 	if len(creators) == 0 {
 		return nil
@@ -173,18 +522,24 @@ func validateListArg(arg string) error {
 	return nil
 }
 
-func validateRegex(arg string) error {
-	if len(arg) == 0 {
-		return nil
+// CompileDirRegex compiles the value of a directory regex flag such as
+// --skip-dir-regex. Returns nil if the expression is empty.
+func CompileDirRegex(expr string) (*regexp.Regexp, error) {
+	if len(expr) == 0 {
+		return nil, nil
 	}
-	_, err := regexp.Compile(arg)
-	return err
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid regex %q: %w", expr, err)
+	}
+	return re, nil
 }
 
-func validateDetectorDependency(detectors string, extractors string, requireExtractors bool) error {
+func validateDetectorDependency(detectors string, extractors string, extractorPlugins []string, requireExtractors bool) error {
 	f := &Flags{
-		ExtractorsToRun: extractors,
-		DetectorsToRun:  detectors,
+		ExtractorsToRun:  extractors,
+		DetectorsToRun:   detectors,
+		ExtractorPlugins: extractorPlugins,
 	}
 	ex, stdex, err := f.extractorsToRun()
 	if err != nil {
@@ -214,28 +569,99 @@ func validateDetectorDependency(detectors string, extractors string, requireExtr
 }
 
 // GetScanConfig constructs a SCALIBR scan config from the provided CLI flags.
-func (f *Flags) GetScanConfig() (*scalibr.ScanConfig, error) {
+func (f *Flags) GetScanConfig() (cfg *scalibr.ScanConfig, err error) {
+	if err := f.startExternalExtractors(); err != nil {
+		return nil, fmt.Errorf("--extractor-plugin: %w", err)
+	}
+	// The external extractor processes are stopped once the scan is done, so
+	// they're stopped here if there's no scan.
+	defer func() {
+		if err != nil {
+			f.closeUnusedExternalExtractors(nil)
+		}
+	}()
 	extractors, standaloneExtractors, err := f.extractorsToRun()
 	if err != nil {
 		return nil, err
 	}
+	f.closeUnusedExternalExtractors(extractors)
 	detectors, err := f.detectorsToRun()
 	if err != nil {
 		return nil, err
 	}
-	capab := capabilities()
-	if f.FilterByCapabilities {
-		extractors, standaloneExtractors, detectors = filterByCapabilities(extractors, standaloneExtractors, detectors, capab)
+	enrichers, err := f.enrichersToRun()
+	if err != nil {
+		return nil, err
 	}
-	var skipDirRegex *regexp.Regexp
-	if f.SkipDirRegex != "" {
-		skipDirRegex, err = regexp.Compile(f.SkipDirRegex)
-		if err != nil {
+	transformers, err := f.transformersToRun()
+	if err != nil {
+		return nil, err
+	}
+	opts, err := f.pluginOptions()
+	if err != nil {
+		return nil, err
+	}
+	var plugins []plugin.Plugin
+	for _, e := range extractors {
+		plugins = append(plugins, e)
+	}
+	for _, e := range standaloneExtractors {
+		plugins = append(plugins, e)
+	}
+	for _, d := range detectors {
+		plugins = append(plugins, d)
+	}
+	for _, e := range enrichers {
+		plugins = append(plugins, e)
+	}
+	for _, t := range transformers {
+		plugins = append(plugins, t)
+	}
+	if err := configurePlugins(opts, plugins); err != nil {
+		return nil, err
+	}
+	capab := capabilities()
+	if len(f.MaxMemoryClass) > 0 {
+		if capab.Memory, err = plugin.ParseMemoryClass(f.MaxMemoryClass); err != nil {
 			return nil, err
 		}
 	}
+	if len(f.DiskImage) > 0 || len(f.ImageTarball) > 0 {
+		// The files of the image are read through a virtual filesystem and
+		// don't belong to the system SCALIBR is running on.
+		capab.DirectFS = false
+		capab.RunningSystem = false
+	}
+	if !f.FilterByCapabilities {
+		warnUnmetRequirements(plugins, capab)
+	}
+	skipDirRegex, err := CompileDirRegex(f.SkipDirRegex)
+	if err != nil {
+		if !f.IgnoreInvalidSkipDirRegex {
+			return nil, fmt.Errorf("--skip-dir-regex: %w", err)
+		}
+		log.Warnf("Ignoring --skip-dir-regex, no directories are skipped by regex: %v", err)
+	}
+	includeDirRegex, err := CompileDirRegex(f.IncludeDirRegex)
+	if err != nil {
+		return nil, fmt.Errorf("--include-dir-regex: %w", err)
+	}
 	var scanRoots []*scalibrfs.ScanRoot
-	if len(f.Root) == 0 {
+	if len(f.DiskImage) > 0 {
+		img, err := diskimage.Open(f.DiskImage)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open disk image: %w", err)
+		}
+		log.Infof("Scanning the %s filesystem of %s", img.Type(), f.DiskImage)
+		scanRoots = append(scanRoots, &scalibrfs.ScanRoot{FS: img})
+	} else if len(f.ImageTarball) > 0 {
+		img, err := tarball.Open(f.ImageTarball)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open image tarball: %w", err)
+		}
+		log.Infof("Scanning the %d composed layers of %s", img.LayerCount(), f.ImageTarball)
+		scanRoots = append(scanRoots, &scalibrfs.ScanRoot{FS: img})
+	} else if len(f.Root) == 0 {
 		var scanRootPaths []string
 		if scanRootPaths, err = platform.DefaultScanRoots(f.WindowsAllDrives); err != nil {
 			return nil, err
@@ -244,21 +670,76 @@ func (f *Flags) GetScanConfig() (*scalibr.ScanConfig, error) {
 			scanRoots = append(scanRoots, &scalibrfs.ScanRoot{FS: scalibrfs.DirFS(r), Path: r})
 		}
 	} else {
-		scanRoots = scalibrfs.RealFSScanRoots(f.Root)
+		for _, r := range f.Root {
+			scanRoots = append(scanRoots, scalibrfs.RealFSScanRoot(r))
+		}
+	}
+	// Locations relative to different roots can't be told apart.
+	storeAbsolutePath := f.StoreAbsolutePath || len(f.Root) > 1
+	filesToExtract, relativeFiles := f.FilesToExtract, []string(nil)
+	if f.FilesRelativeToRoot {
+		filesToExtract, relativeFiles = nil, f.FilesToExtract
+	}
+	if len(f.SinceGitRef) > 0 {
+		if len(f.Root) != 1 {
+			return nil, errors.New("--since-git-ref needs exactly one --root")
+		}
+		if relativeFiles, err = changedFiles(f.Root[0], f.SinceGitRef); err != nil {
+			return nil, fmt.Errorf("--since-git-ref: %w", err)
+		}
+		log.Infof("Extracting %d files changed since %s", len(relativeFiles), f.SinceGitRef)
+		filesToExtract = nil
+	}
+	var resultCache filesystem.ResultCache
+	if len(f.ResultCacheDir) > 0 {
+		c, err := resultcache.Open(f.ResultCacheDir, f.ResultCacheMaxBytes)
+		if err != nil {
+			return nil, fmt.Errorf("--result-cache-dir: %w", err)
+		}
+		resultCache = c
 	}
 	return &scalibr.ScanConfig{
-		ScanRoots:            scanRoots,
-		FilesystemExtractors: extractors,
-		StandaloneExtractors: standaloneExtractors,
-		Detectors:            detectors,
-		Capabilities:         capab,
-		FilesToExtract:       f.FilesToExtract,
-		DirsToSkip:           f.dirsToSkip(scanRoots),
-		SkipDirRegex:         skipDirRegex,
-		StoreAbsolutePath:    f.StoreAbsolutePath,
+		ScanRoots:              scanRoots,
+		FilesystemExtractors:   extractors,
+		StandaloneExtractors:   standaloneExtractors,
+		StandaloneConcurrency:  f.StandaloneConcurrency,
+		Detectors:              detectors,
+		Enrichers:              enrichers,
+		Transformers:           transformers,
+		Capabilities:           capab,
+		FilterByCapabilities:   f.FilterByCapabilities,
+		FilesToExtract:         filesToExtract,
+		FilesToExtractRelative: relativeFiles,
+		Strict:                 f.Strict,
+		DeduplicateFindings:    f.DeduplicateFindings,
+		MaxBytesPerSecond:      f.MaxBytesPerSecond,
+		ResultCache:            resultCache,
+		DirsToSkip:             f.dirsToSkip(scanRoots),
+		SkipDirRegex:           skipDirRegex,
+		IncludeDirRegex:        includeDirRegex,
+		StoreAbsolutePath:      storeAbsolutePath,
+		LocationPrefixTrim:     f.LocationPrefixTrim,
+		PosixPaths:             f.PosixPaths,
+		Quiet:                  f.Quiet,
+		TraceFileRequired:      f.TraceFileRequired,
+		Metadata:               f.scanMetadata(),
 	}, nil
 }
 
+// scanMetadata returns the key-value pairs passed with --metadata, or nil if
+// there are none.
+func (f *Flags) scanMetadata() map[string]string {
+	if len(f.Metadata) == 0 {
+		return nil
+	}
+	metadata := make(map[string]string, len(f.Metadata))
+	for _, item := range f.Metadata {
+		key, value, _ := strings.Cut(item, "=")
+		metadata[key] = value
+	}
+	return metadata
+}
+
 // GetSPDXConfig creates an SPDXConfig struct based on the CLI flags.
 func (f *Flags) GetSPDXConfig() converter.SPDXConfig {
 	creators := []common.Creator{}
@@ -277,6 +758,7 @@ func (f *Flags) GetSPDXConfig() converter.SPDXConfig {
 		DocumentName:      f.SPDXDocumentName,
 		DocumentNamespace: f.SPDXDocumentNamespace,
 		Creators:          creators,
+		Timestamp:         f.mustSBOMTimestamp(),
 	}
 }
 
@@ -286,11 +768,57 @@ func (f *Flags) GetCDXConfig() converter.CDXConfig {
 		ComponentName:    f.CDXComponentName,
 		ComponentVersion: f.CDXComponentVersion,
 		Authors:          strings.Split(f.CDXAuthors, ","),
+		Timestamp:        f.mustSBOMTimestamp(),
 	}
 }
 
+// sbomTimestamp returns the creation time to use for the SBOM outputs, or the
+// zero time if the outputs should use the current time.
+func (f *Flags) sbomTimestamp() (time.Time, error) {
+	value := f.SBOMTimestamp
+	if len(value) == 0 {
+		// https://reproducible-builds.org/specs/source-date-epoch/
+		value = os.Getenv("SOURCE_DATE_EPOCH")
+		if len(value) == 0 {
+			return time.Time{}, nil
+		}
+		if _, err := strconv.ParseInt(value, 10, 64); err != nil {
+			return time.Time{}, fmt.Errorf("invalid SOURCE_DATE_EPOCH %q: must be the seconds since the Unix epoch", value)
+		}
+	}
+	if secs, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return time.Unix(secs, 0).UTC(), nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%q is neither an RFC 3339 timestamp nor seconds since the Unix epoch", value)
+	}
+	return t.UTC(), nil
+}
+
+// mustSBOMTimestamp returns the SBOM creation time from sbomTimestamp. The
+// flags are expected to have been validated with ValidateFlags.
+func (f *Flags) mustSBOMTimestamp() time.Time {
+	t, err := f.sbomTimestamp()
+	if err != nil {
+		log.Warnf("Ignoring --sbom-timestamp: %v", err)
+		return time.Time{}
+	}
+	return t
+}
+
 // WriteScanResults writes SCALIBR scan results to files specified by the CLI flags.
+// If --baseline is set, only the inventory not present in the baseline is written.
+// If --package-class is set, only the inventory of the given class is written.
+// If --osv-ecosystems-only is set, only the inventory of OSV ecosystems is written.
 func (f *Flags) WriteScanResults(result *scalibr.ScanResult) error {
+	result = f.filterInventory(result)
+	if len(f.Baseline) > 0 {
+		var err error
+		if result, err = removeBaselineInventory(result, f.Baseline); err != nil {
+			return fmt.Errorf("--baseline %s: %w", f.Baseline, err)
+		}
+	}
 	if len(f.ResultFile) > 0 {
 		log.Infof("Writing scan results to %s", f.ResultFile)
 		resultProto, err := proto.ScanResultToProto(result)
@@ -316,13 +844,34 @@ func (f *Flags) WriteScanResults(result *scalibr.ScanResult) error {
 					return err
 				}
 			} else if strings.Contains(oFormat, "spdx23") {
-				doc := converter.ToSPDX23(result, f.GetSPDXConfig())
-				if err := spdx.Write23(doc, oPath, oFormat); err != nil {
+				doc, convErrs := converter.ToSPDX23WithErrors(result, f.GetSPDXConfig())
+				logConversionErrors(convErrs, len(result.Inventories), oFormat)
+				if f.ValidateOutput {
+					if err := spdx.Validate23(doc); err != nil {
+						return fmt.Errorf("not writing %s: %w", oPath, err)
+					}
+				}
+				write := spdx.Write23
+				if f.CompactJSON {
+					write = spdx.Write23Compact
+				}
+				if err := write(doc, oPath, oFormat); err != nil {
 					return err
 				}
 			} else if strings.Contains(oFormat, "cdx") {
-				doc := converter.ToCDX(result, f.GetCDXConfig())
-				if err := cdx.Write(doc, oPath, oFormat); err != nil {
+				doc, convErrs := converter.ToCDXWithErrors(result, f.GetCDXConfig())
+				logConversionErrors(convErrs, len(result.Inventories), oFormat)
+				if f.ValidateOutput {
+					if err := cdx.Validate(doc); err != nil {
+						return fmt.Errorf("not writing %s: %w", oPath, err)
+					}
+				}
+				opts := cdx.Options{Compact: f.CompactJSON, Append: f.AppendCDX}
+				if err := cdx.WriteWithOptions(doc, oPath, oFormat, opts); err != nil {
+					return err
+				}
+			} else if oFormat == "purls" {
+				if err := writePURLs(converter.ToPURLList(result), oPath); err != nil {
 					return err
 				}
 			}
@@ -331,6 +880,160 @@ func (f *Flags) WriteScanResults(result *scalibr.ScanResult) error {
 	return nil
 }
 
+// filterInventory returns the scan result with only the inventory selected by
+// --package-class and --osv-ecosystems-only.
+func (f *Flags) filterInventory(result *scalibr.ScanResult) *scalibr.ScanResult {
+	result = filterPackageClass(result, f.PackageClass)
+	if f.OSVEcosystemsOnly {
+		ecosystems := DefaultOSVEcosystems
+		if len(f.OSVEcosystems) > 0 {
+			ecosystems = strings.Split(f.OSVEcosystems, ",")
+		}
+		result = filterOSVEcosystems(result, ecosystems)
+	}
+	return result
+}
+
+// filterOSVEcosystems returns a copy of the scan result with only the
+// inventory of the given ecosystems. Ecosystems are compared without their
+// release suffix, e.g. "Debian:12" is kept for "Debian", and regardless of
+// case. Inventory without an ecosystem is removed.
+func filterOSVEcosystems(result *scalibr.ScanResult, ecosystems []string) *scalibr.ScanResult {
+	filtered := *result
+	filtered.Inventories = nil
+	dropped := make(map[string]int)
+	for _, i := range result.Inventories {
+		var ecosystem string
+		if i.Extractor != nil {
+			// Extractors that can't determine the ecosystem are treated as
+			// having none.
+			ecosystem, _ = i.Ecosystem()
+		}
+		base, _, _ := strings.Cut(ecosystem, ":")
+		if slices.ContainsFunc(ecosystems, func(e string) bool { return strings.EqualFold(strings.TrimSpace(e), base) }) {
+			filtered.Inventories = append(filtered.Inventories, i)
+			continue
+		}
+		if base == "" {
+			base = "none"
+		}
+		dropped[base]++
+	}
+	if n := len(result.Inventories) - len(filtered.Inventories); n > 0 {
+		var counts []string
+		for e, count := range dropped {
+			counts = append(counts, fmt.Sprintf("%s: %d", e, count))
+		}
+		slices.Sort(counts)
+		log.Infof("Removed %d inventory items of ecosystems not covered by OSV (%s)", n, strings.Join(counts, ", "))
+	}
+	return &filtered
+}
+
+// filterPackageClass returns a copy of the scan result with only the inventory
+// found by extractors of the given package class, see extractor.ClassOf. All
+// inventory is kept if the class is empty or "all".
+func filterPackageClass(result *scalibr.ScanResult, class string) *scalibr.ScanResult {
+	if class == "" || class == "all" {
+		return result
+	}
+	filtered := *result
+	filtered.Inventories = nil
+	for _, i := range result.Inventories {
+		if i.Extractor != nil && string(extractor.ClassOf(i.Extractor)) == class {
+			filtered.Inventories = append(filtered.Inventories, i)
+		}
+	}
+	log.Infof("Removed %d inventory items that aren't %s packages", len(result.Inventories)-len(filtered.Inventories), class)
+	return &filtered
+}
+
+// baselineKey identifies an inventory item found at a given location.
+type baselineKey struct {
+	purl     string
+	location string
+}
+
+// removeBaselineInventory returns a copy of the scan result without the
+// inventory that's present in the scan result stored in baselinePath. Items
+// count as present if the baseline contains their PURL at all of their
+// locations. Inventory without a PURL is always kept.
+func removeBaselineInventory(result *scalibr.ScanResult, baselinePath string) (*scalibr.ScanResult, error) {
+	baseline := &spb.ScanResult{}
+	if err := proto.Read(baselinePath, baseline); err != nil {
+		return nil, err
+	}
+	known := make(map[baselineKey]bool)
+	for _, i := range baseline.GetInventories() {
+		p := i.GetPurl().GetPurl()
+		if p == "" {
+			continue
+		}
+		for _, l := range i.GetLocations() {
+			known[baselineKey{purl: p, location: l}] = true
+		}
+	}
+
+	filtered := *result
+	filtered.Inventories = nil
+	for _, i := range result.Inventories {
+		if !isKnownInventory(i, known) {
+			filtered.Inventories = append(filtered.Inventories, i)
+		}
+	}
+	log.Infof("Removed %d inventory items present in baseline %s", len(result.Inventories)-len(filtered.Inventories), baselinePath)
+	return &filtered, nil
+}
+
+func isKnownInventory(i *extractor.Inventory, known map[baselineKey]bool) bool {
+	p, err := converter.ToPURL(i)
+	if err != nil || p == nil || len(i.Locations) == 0 {
+		return false
+	}
+	for _, l := range i.Locations {
+		if !known[baselineKey{purl: p.String(), location: l}] {
+			return false
+		}
+	}
+	return true
+}
+
+// WriteSBOMDiff compares the scan results with the SBOM specified by the
+// --verify-sbom flag and writes the added, removed and changed packages to w as JSON.
+func (f *Flags) WriteSBOMDiff(result *scalibr.ScanResult, w io.Writer) (*converter.SBOMDiff, error) {
+	sbomPURLs, err := readSBOMPURLs(f.VerifySBOM)
+	if err != nil {
+		return nil, fmt.Errorf("reading SBOM %s: %w", f.VerifySBOM, err)
+	}
+	diff := converter.DiffPURLs(sbomPURLs, converter.ScanResultPURLs(f.filterInventory(result)))
+	log.Infof("Compared scan results with %s: %d added, %d removed, %d changed packages",
+		f.VerifySBOM, len(diff.Added), len(diff.Removed), len(diff.Changed))
+
+	enc := json.NewEncoder(w)
+	if !f.CompactJSON {
+		enc.SetIndent("", "  ")
+	}
+	if err := enc.Encode(diff); err != nil {
+		return nil, err
+	}
+	return diff, nil
+}
+
+func readSBOMPURLs(filePath string) ([]*purl.PackageURL, error) {
+	if isSPDXPath(filePath) {
+		doc, err := spdx.Read23(filePath)
+		if err != nil {
+			return nil, err
+		}
+		return converter.PURLsFromSPDX23(doc), nil
+	}
+	doc, err := cdx.Read(filePath)
+	if err != nil {
+		return nil, err
+	}
+	return converter.PURLsFromCDX(doc), nil
+}
+
 // TODO(b/279413691): Allow commas in argument names.
 func (f *Flags) extractorsToRun() ([]filesystem.Extractor, []standalone.Extractor, error) {
 	if len(f.ExtractorsToRun) == 0 {
@@ -342,25 +1045,85 @@ func (f *Flags) extractorsToRun() ([]filesystem.Extractor, []standalone.Extracto
 
 	// We need to check extractors individually as they may be defined in one or both lists.
 	for _, name := range strings.Split(f.ExtractorsToRun, ",") {
+		if ext, ok := f.externalExtractors[strings.ToLower(name)]; ok {
+			fsExtractors = append(fsExtractors, ext)
+			continue
+		}
 		ex, err := el.ExtractorsFromNames([]string{name})
 		stex, sterr := sl.ExtractorsFromNames([]string{name})
 
 		if err != nil && sterr != nil { // both fails.
+			if len(f.ExtractorPlugins) > 0 && f.externalExtractors == nil {
+				// The external extractors aren't started during flag validation,
+				// so their names are only checked by GetScanConfig.
+				continue
+			}
 			return nil, nil, err
 		}
 
 		if err == nil {
-			fsExtractors = append(fsExtractors, ex...)
+			fsExtractors = append(fsExtractors, filterExperimental(f, name, ex)...)
 		}
 
 		if sterr == nil {
-			standaloneExtractors = append(standaloneExtractors, stex...)
+			standaloneExtractors = append(standaloneExtractors, filterExperimental(f, name, stex)...)
 		}
 	}
 
 	return fsExtractors, standaloneExtractors, nil
 }
 
+// startExternalExtractors starts the executables of --extractor-plugin. Their
+// names can't be the same as the names of built-in extractors.
+func (f *Flags) startExternalExtractors() error {
+	f.externalExtractors = make(map[string]*external.Extractor)
+	for _, path := range f.ExtractorPlugins {
+		cfg := external.DefaultConfig()
+		cfg.Command = path
+		ext, err := external.New(cfg)
+		if err != nil {
+			f.closeUnusedExternalExtractors(nil)
+			return err
+		}
+		name := strings.ToLower(ext.Name())
+		_, fsErr := el.ExtractorsFromNames([]string{name})
+		_, stErr := sl.ExtractorsFromNames([]string{name})
+		_, dup := f.externalExtractors[name]
+		if fsErr == nil || stErr == nil || dup {
+			ext.Close()
+			f.closeUnusedExternalExtractors(nil)
+			return fmt.Errorf("%s: there is already an extractor named %q", path, ext.Name())
+		}
+		log.Infof("Started external extractor %s from %s", ext.Name(), path)
+		f.externalExtractors[name] = ext
+	}
+	return nil
+}
+
+// closeUnusedExternalExtractors stops the external extractor processes that
+// aren't in the given extractors.
+func (f *Flags) closeUnusedExternalExtractors(used []filesystem.Extractor) {
+	for name, ext := range f.externalExtractors {
+		if slices.Contains(used, filesystem.Extractor(ext)) {
+			continue
+		}
+		if err := ext.Close(); err != nil {
+			log.Warnf("External extractor %s: %v", ext.Name(), err)
+		}
+		delete(f.externalExtractors, name)
+	}
+}
+
+// filterExperimental removes the experimental plugins enabled through the
+// given group name unless --include-experimental is set. Plugins enabled by
+// their own name are always kept.
+func filterExperimental[P plugin.Plugin](f *Flags, name string, plugins []P) []P {
+	if f.IncludeExperimental || (len(plugins) == 1 && strings.EqualFold(plugins[0].Name(), name)) {
+		return plugins
+	}
+	return plugin.WithoutExperimental(plugins)
+}
+
 func (f *Flags) detectorsToRun() ([]detector.Detector, error) {
 	if len(f.DetectorsToRun) == 0 {
 		return []detector.Detector{}, nil
@@ -369,49 +1132,115 @@ func (f *Flags) detectorsToRun() ([]detector.Detector, error) {
 	if err != nil {
 		return []detector.Detector{}, err
 	}
-	for _, d := range dets {
-		if d.Name() == binary.Name {
-			d.(*binary.Detector).OfflineVulnDBPath = f.GovulncheckDBPath
-		}
-	}
 	return dets, nil
 }
 
-// All capabilities are enabled when running SCALIBR as a binary.
-func capabilities() *plugin.Capabilities {
-	return &plugin.Capabilities{
-		OS:            platform.OS(),
-		Network:       true,
-		DirectFS:      true,
-		RunningSystem: true,
+func (f *Flags) enrichersToRun() ([]enricher.Enricher, error) {
+	if len(f.EnrichersToRun) == 0 {
+		return []enricher.Enricher{}, nil
 	}
+	return enl.EnrichersFromNames(strings.Split(f.EnrichersToRun, ","))
 }
 
-// Filters the specified list of plugins (filesystem extractors, standalone extractors, detectors)
-// by removing all plugins that don't satisfy the specified capabilities.
-func filterByCapabilities(
-	f []filesystem.Extractor, s []standalone.Extractor,
-	d []detector.Detector, capab *plugin.Capabilities) (
-	[]filesystem.Extractor, []standalone.Extractor, []detector.Detector) {
-	ff := make([]filesystem.Extractor, 0, len(f))
-	sf := make([]standalone.Extractor, 0, len(s))
-	df := make([]detector.Detector, 0, len(d))
-	for _, ex := range f {
-		if err := plugin.ValidateRequirements(ex, capab); err == nil {
-			ff = append(ff, ex)
+func (f *Flags) transformersToRun() ([]transformer.Transformer, error) {
+	if len(f.TransformersToRun) == 0 {
+		return []transformer.Transformer{}, nil
+	}
+	return tl.TransformersFromNames(strings.Split(f.TransformersToRun, ","))
+}
+
+// logConversionErrors logs how many inventory items couldn't be fully
+// represented in the given output format.
+func logConversionErrors(convErrs []*converter.ConversionError, total int, format string) {
+	if len(convErrs) == 0 {
+		return
+	}
+	log.Warnf("%d of %d inventory items couldn't be fully represented in the %s output", len(convErrs), total, format)
+}
+
+// writePURLs writes the package URLs to the file, one per line.
+func writePURLs(purls []string, filePath string) error {
+	var sb strings.Builder
+	for _, p := range purls {
+		sb.WriteString(p)
+		sb.WriteString("\n")
+	}
+	return output.WriteFile(filePath, []byte(sb.String()))
+}
+
+// scanConfigFile is the format of the YAML file passed through --config, e.g.
+//
+//	plugins:
+//	  govulncheck/binary:
+//	    offline_vuln_db_path: /path/to/db
+type scanConfigFile struct {
+	// Plugin names to the options passed to their Configure method.
+	Plugins map[string]map[string]any `yaml:"plugins"`
+}
+
+// pluginOptions returns the per-plugin options from the config file and the
+// plugin-specific CLI flags. The flags take precedence.
+func (f *Flags) pluginOptions() (map[string]map[string]any, error) {
+	opts := map[string]map[string]any{}
+	if len(f.ConfigFile) > 0 {
+		content, err := os.ReadFile(f.ConfigFile)
+		if err != nil {
+			return nil, err
+		}
+		cfg := &scanConfigFile{}
+		if err := yaml.UnmarshalStrict(content, cfg); err != nil {
+			return nil, fmt.Errorf("failed to parse config file %q: %w", f.ConfigFile, err)
+		}
+		for name, o := range cfg.Plugins {
+			opts[name] = o
+		}
+	}
+	if len(f.GovulncheckDBPath) > 0 {
+		if opts[binary.Name] == nil {
+			opts[binary.Name] = map[string]any{}
+		}
+		opts[binary.Name][binary.OfflineVulnDBPathOption] = f.GovulncheckDBPath
+	}
+	return opts, nil
+}
+
+// configurePlugins applies the per-plugin options to the enabled plugins.
+func configurePlugins(opts map[string]map[string]any, plugins []plugin.Plugin) error {
+	enabled := make(map[string]bool)
+	for _, p := range plugins {
+		if err := plugin.Configure(p, opts[p.Name()]); err != nil {
+			return err
 		}
+		enabled[p.Name()] = true
 	}
-	for _, ex := range s {
-		if err := plugin.ValidateRequirements(ex, capab); err == nil {
-			sf = append(sf, ex)
+	for name := range opts {
+		if !enabled[name] {
+			log.Warnf("Options were specified for plugin %q which is not enabled", name)
 		}
 	}
-	for _, det := range d {
-		if err := plugin.ValidateRequirements(det, capab); err == nil {
-			df = append(df, det)
+	return nil
+}
+
+// warnUnmetRequirements logs a warning for each plugin whose requirements
+// aren't satisfied by the scanning environment. Without filtering by
+// capabilities these plugins make the scan fail, so the warnings point users
+// to the cause before the scan starts.
+func warnUnmetRequirements(plugins []plugin.Plugin, capab *plugin.Capabilities) {
+	for _, p := range plugins {
+		if err := plugin.ValidateRequirements(p, capab); err != nil {
+			log.Warnf("%v. Enable --filter-by-capabilities to skip the plugin instead of failing the scan", err)
 		}
 	}
-	return ff, sf, df
+}
+
+// All capabilities are enabled when running SCALIBR as a binary.
+func capabilities() *plugin.Capabilities {
+	return &plugin.Capabilities{
+		OS:            platform.OS(),
+		Network:       true,
+		DirectFS:      true,
+		RunningSystem: true,
+	}
 }
 
 func (f *Flags) dirsToSkip(scanRoots []*scalibrfs.ScanRoot) []string {
@@ -423,7 +1252,8 @@ func (f *Flags) dirsToSkip(scanRoots []*scalibrfs.ScanRoot) []string {
 		paths = append(paths, strings.Split(f.DirsToSkip, ",")...)
 	}
 
-	// Ignore paths that are not under Root.
+	// Ignore paths that are not under Root. Relative patterns such as
+	// "**/node_modules" apply to all roots.
 	result := make([]string, 0, len(paths))
 	for _, root := range scanRoots {
 		path := root.Path
@@ -436,9 +1266,18 @@ func (f *Flags) dirsToSkip(scanRoots []*scalibrfs.ScanRoot) []string {
 			}
 		}
 	}
+	for _, p := range paths {
+		if isRelativeGlob(p) {
+			result = append(result, p)
+		}
+	}
 	return result
 }
 
+func isRelativeGlob(path string) bool {
+	return strings.Contains(path, "*") && !filepath.IsAbs(path)
+}
+
 func keys(m map[string][]string) []string {
 	ret := make([]string, 0, len(m))
 	for k := range m {
@@ -15,20 +15,59 @@
 package cli_test
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
 	"os"
 	"path/filepath"
 	"runtime"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/osv-scalibr/artifact/diskimage"
+	"github.com/google/osv-scalibr/artifact/image/tarball"
 	"github.com/google/osv-scalibr/binary/cli"
+	"github.com/google/osv-scalibr/binary/proto"
 	"github.com/google/osv-scalibr/detector/govulncheck/binary"
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem/external"
+	"github.com/google/osv-scalibr/extractor/filesystem/language/python/wheelegg"
+	"github.com/google/osv-scalibr/extractor/filesystem/misc/jenkinsplugin"
+	"github.com/google/osv-scalibr/extractor/filesystem/os/dpkg"
+	"github.com/google/osv-scalibr/extractor/filesystem/os/macapps"
+	"github.com/google/osv-scalibr/log"
 	"github.com/google/osv-scalibr/plugin"
 	scalibr "github.com/google/osv-scalibr"
+	fe "github.com/google/osv-scalibr/testing/fakeextractor"
 )
 
+// externalExtractorEnv makes the test binary act as an external extractor
+// instead of running the tests. It's set to the path of a file that's written
+// when the extractor exits.
+const externalExtractorEnv = "CLI_TEST_EXTERNAL_EXTRACTOR"
+
+func TestMain(m *testing.M) {
+	exitedFile := os.Getenv(externalExtractorEnv)
+	if exitedFile == "" {
+		os.Exit(m.Run())
+	}
+	ex := fe.New("test/external", 1, nil, nil)
+	if err := external.Serve(context.Background(), ex, os.Stdin, os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+	}
+	if err := os.WriteFile(exitedFile, nil, 0644); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	os.Exit(0)
+}
+
+var testDiskImage = filepath.FromSlash("../../artifact/diskimage/testdata/ext4.img")
+var testImageTarball = filepath.FromSlash("../../artifact/image/tarball/testdata/image.tar")
+
 func TestValidateFlags(t *testing.T) {
 	for _, tc := range []struct {
 		desc    string
@@ -38,7 +77,7 @@ func TestValidateFlags(t *testing.T) {
 		{
 			desc: "Valid config",
 			flags: &cli.Flags{
-				Root:            "/",
+				Root:            []string{"/"},
 				ResultFile:      "result.textproto",
 				Output:          []string{"textproto=result2.textproto", "spdx23-yaml=result.spdx.yaml"},
 				ExtractorsToRun: "java,python",
@@ -50,54 +89,298 @@ func TestValidateFlags(t *testing.T) {
 		},
 		{
 			desc:    "Either output flag missing",
-			flags:   &cli.Flags{Root: "/"},
+			flags:   &cli.Flags{Root: []string{"/"}},
+			wantErr: cmpopts.AnyError,
+		}, {
+			desc: "Count-only instead of output flags",
+			flags: &cli.Flags{
+				Root:      []string{"/"},
+				CountOnly: true,
+			},
+			wantErr: nil,
+		}, {
+			desc: "Count-only with output flag",
+			flags: &cli.Flags{
+				Root:       []string{"/"},
+				ResultFile: "result.textproto",
+				CountOnly:  true,
+			},
+			wantErr: cmpopts.AnyError,
+		}, {
+			desc: "Which-extractors instead of output flags",
+			flags: &cli.Flags{
+				Root:            []string{"/"},
+				WhichExtractors: "/package.json",
+			},
+			wantErr: nil,
+		}, {
+			desc: "Which-extractors with count-only",
+			flags: &cli.Flags{
+				Root:            []string{"/"},
+				WhichExtractors: "/package.json",
+				CountOnly:       true,
+			},
+			wantErr: cmpopts.AnyError,
+		}, {
+			desc: "Coverage report instead of output flags",
+			flags: &cli.Flags{
+				Root:           []string{"/"},
+				CoverageReport: true,
+			},
+			wantErr: nil,
+		}, {
+			desc: "Coverage report with count-only",
+			flags: &cli.Flags{
+				Root:           []string{"/"},
+				CoverageReport: true,
+				CountOnly:      true,
+			},
+			wantErr: cmpopts.AnyError,
+		}, {
+			desc: "Since git ref",
+			flags: &cli.Flags{
+				Root:        []string{"/"},
+				ResultFile:  "result.textproto",
+				SinceGitRef: "origin/main",
+			},
+			wantErr: nil,
+		}, {
+			desc: "Since git ref with several roots",
+			flags: &cli.Flags{
+				Root:        []string{"/", "/"},
+				ResultFile:  "result.textproto",
+				SinceGitRef: "origin/main",
+			},
+			wantErr: cmpopts.AnyError,
+		}, {
+			desc: "Since git ref with files to extract",
+			flags: &cli.Flags{
+				Root:           []string{"/"},
+				ResultFile:     "result.textproto",
+				SinceGitRef:    "origin/main",
+				FilesToExtract: []string{"/package.json"},
+			},
+			wantErr: cmpopts.AnyError,
+		}, {
+			desc: "Since git ref that looks like an option",
+			flags: &cli.Flags{
+				Root:        []string{"/"},
+				ResultFile:  "result.textproto",
+				SinceGitRef: "--output=/tmp/x",
+			},
+			wantErr: cmpopts.AnyError,
+		}, {
+			desc: "Transformers",
+			flags: &cli.Flags{
+				Root:              []string{"/"},
+				ResultFile:        "result.textproto",
+				TransformersToRun: "drop-dev,tag-internal",
+			},
+			wantErr: nil,
+		}, {
+			desc: "Nonexistent transformer",
+			flags: &cli.Flags{
+				Root:              []string{"/"},
+				ResultFile:        "result.textproto",
+				TransformersToRun: "nonexistent",
+			},
+			wantErr: cmpopts.AnyError,
+		}, {
+			desc: "Merge",
+			flags: &cli.Flags{
+				Merge:          true,
+				FilesToExtract: []string{"web-1.binproto", "web-2.textproto.gz"},
+				Output:         []string{"binproto=fleet.binproto"},
+			},
+			wantErr: nil,
+		}, {
+			desc: "Merge without result files",
+			flags: &cli.Flags{
+				Merge:      true,
+				ResultFile: "fleet.textproto",
+			},
+			wantErr: cmpopts.AnyError,
+		}, {
+			desc: "Merge with invalid result file",
+			flags: &cli.Flags{
+				Merge:          true,
+				FilesToExtract: []string{"web-1.json"},
+				ResultFile:     "fleet.textproto",
+			},
+			wantErr: cmpopts.AnyError,
+		}, {
+			desc: "Merge with SBOM output",
+			flags: &cli.Flags{
+				Merge:          true,
+				FilesToExtract: []string{"web-1.binproto"},
+				Output:         []string{"spdx23-json=fleet.spdx.json"},
+			},
+			wantErr: cmpopts.AnyError,
+		}, {
+			desc: "Merge with metadata",
+			flags: &cli.Flags{
+				Merge:          true,
+				FilesToExtract: []string{"web-1.binproto"},
+				ResultFile:     "fleet.textproto",
+				Metadata:       []string{"role=web"},
+			},
+			wantErr: cmpopts.AnyError,
+		}, {
+			desc: "Disk image",
+			flags: &cli.Flags{
+				DiskImage:  testDiskImage,
+				ResultFile: "result.textproto",
+			},
+			wantErr: nil,
+		}, {
+			desc: "Disk image with root",
+			flags: &cli.Flags{
+				Root:       []string{"/"},
+				DiskImage:  testDiskImage,
+				ResultFile: "result.textproto",
+			},
+			wantErr: cmpopts.AnyError,
+		}, {
+			desc: "Image tarball",
+			flags: &cli.Flags{
+				ImageTarball: testImageTarball,
+				ResultFile:   "result.textproto",
+			},
+			wantErr: nil,
+		}, {
+			desc: "Image tarball with disk image",
+			flags: &cli.Flags{
+				ImageTarball: testImageTarball,
+				DiskImage:    testDiskImage,
+				ResultFile:   "result.textproto",
+			},
+			wantErr: cmpopts.AnyError,
+		}, {
+			desc: "Nonexistent disk image",
+			flags: &cli.Flags{
+				DiskImage:  "/nonexistent/disk.img",
+				ResultFile: "result.textproto",
+			},
 			wantErr: cmpopts.AnyError,
 		}, {
 			desc: "Result flag present",
 			flags: &cli.Flags{
-				Root:       "/",
+				Root:       []string{"/"},
 				ResultFile: "result.textproto",
 			},
 			wantErr: nil,
 		}, {
 			desc: "Output flag present",
 			flags: &cli.Flags{
-				Root:   "/",
+				Root:   []string{"/"},
 				Output: []string{"textproto=result.textproto"},
 			},
 			wantErr: nil,
+		}, {
+			desc: "Verbose and quiet",
+			flags: &cli.Flags{
+				Root:       []string{"/"},
+				ResultFile: "result.textproto",
+				Verbose:    true,
+				Quiet:      true,
+			},
+			wantErr: cmpopts.AnyError,
 		}, {
 			desc: "Wrong result extension",
 			flags: &cli.Flags{
-				Root:       "/",
+				Root:       []string{"/"},
 				ResultFile: "result.png",
 			},
 			wantErr: cmpopts.AnyError,
 		}, {
 			desc: "Invalid output format",
 			flags: &cli.Flags{
-				Root:   "/",
+				Root:   []string{"/"},
 				Output: []string{"invalid"},
 			},
 			wantErr: cmpopts.AnyError,
 		}, {
 			desc: "Unknown output format",
 			flags: &cli.Flags{
-				Root:   "/",
+				Root:   []string{"/"},
 				Output: []string{"unknown=foo.bar"},
 			},
 			wantErr: cmpopts.AnyError,
 		}, {
 			desc: "Wrong output extension",
 			flags: &cli.Flags{
-				Root:   "/",
+				Root:   []string{"/"},
 				Output: []string{"proto=result.png"},
 			},
 			wantErr: cmpopts.AnyError,
+		}, {
+			desc: "Object storage outputs",
+			flags: &cli.Flags{
+				Root:       []string{"/"},
+				ResultFile: "gs://bucket/result.textproto",
+				Output:     []string{"spdx23-json=s3://bucket/result.spdx.json"},
+			},
+			wantErr: nil,
+		}, {
+			desc: "Metadata",
+			flags: &cli.Flags{
+				Root:       []string{"/"},
+				ResultFile: "result.textproto",
+				Metadata:   []string{"role=web", "build_url=https://ci.example.com/build?id=1", "empty="},
+			},
+			wantErr: nil,
+		}, {
+			desc: "Metadata without value",
+			flags: &cli.Flags{
+				Root:       []string{"/"},
+				ResultFile: "result.textproto",
+				Metadata:   []string{"role"},
+			},
+			wantErr: cmpopts.AnyError,
+		}, {
+			desc: "Metadata without key",
+			flags: &cli.Flags{
+				Root:       []string{"/"},
+				ResultFile: "result.textproto",
+				Metadata:   []string{"=web"},
+			},
+			wantErr: cmpopts.AnyError,
+		}, {
+			desc: "Duplicate metadata key",
+			flags: &cli.Flags{
+				Root:       []string{"/"},
+				ResultFile: "result.textproto",
+				Metadata:   []string{"role=web", "role=db"},
+			},
+			wantErr: cmpopts.AnyError,
+		}, {
+			desc: "External extractor enabled by name",
+			flags: &cli.Flags{
+				Root:             []string{"/"},
+				ResultFile:       "result.textproto",
+				ExtractorPlugins: []string{"cli_test.go"},
+				ExtractorsToRun:  "python,acme/manifest",
+			},
+			wantErr: nil,
+		}, {
+			desc: "Nonexistent extractor plugin",
+			flags: &cli.Flags{
+				Root:             []string{"/"},
+				ResultFile:       "result.textproto",
+				ExtractorPlugins: []string{"testdata/nonexistent"},
+			},
+			wantErr: cmpopts.AnyError,
+		}, {
+			desc: "Unsupported output URL scheme",
+			flags: &cli.Flags{
+				Root:   []string{"/"},
+				Output: []string{"cdx-json=ftp://host/result.cdx.json"},
+			},
+			wantErr: cmpopts.AnyError,
 		}, {
 			desc: "Invalid extractors",
 			flags: &cli.Flags{
-				Root:            "/",
+				Root:            []string{"/"},
 				ResultFile:      "result.textproto",
 				ExtractorsToRun: ",python",
 			},
@@ -106,7 +389,7 @@ func TestValidateFlags(t *testing.T) {
 		{
 			desc: "Nonexistent extractors",
 			flags: &cli.Flags{
-				Root:            "/",
+				Root:            []string{"/"},
 				ResultFile:      "result.textproto",
 				ExtractorsToRun: "asdf",
 			},
@@ -115,7 +398,7 @@ func TestValidateFlags(t *testing.T) {
 		{
 			desc: "Invalid detectors",
 			flags: &cli.Flags{
-				Root:           "/",
+				Root:           []string{"/"},
 				ResultFile:     "result.textproto",
 				DetectorsToRun: "cve,",
 			},
@@ -124,16 +407,34 @@ func TestValidateFlags(t *testing.T) {
 		{
 			desc: "Nonexistent detectors",
 			flags: &cli.Flags{
-				Root:           "/",
+				Root:           []string{"/"},
 				ResultFile:     "result.textproto",
 				DetectorsToRun: "asdf",
 			},
 			wantErr: cmpopts.AnyError,
 		},
+		{
+			desc: "Invalid include dir regex",
+			flags: &cli.Flags{
+				Root:            []string{"/"},
+				ResultFile:      "result.textproto",
+				IncludeDirRegex: "src/(",
+			},
+			wantErr: cmpopts.AnyError,
+		},
+		{
+			desc: "Nonexistent enrichers",
+			flags: &cli.Flags{
+				Root:           []string{"/"},
+				ResultFile:     "result.textproto",
+				EnrichersToRun: "default,asdf",
+			},
+			wantErr: cmpopts.AnyError,
+		},
 		{
 			desc: "Detector with missing extractor dependency when ExplicitExtractors",
 			flags: &cli.Flags{
-				Root:               "/",
+				Root:               []string{"/"},
 				ResultFile:         "result.textproto",
 				ExtractorsToRun:    "python,javascript",
 				DetectorsToRun:     "govulncheck", // Needs the Go binary extractor.
@@ -144,7 +445,7 @@ func TestValidateFlags(t *testing.T) {
 		{
 			desc: "Detector with missing extractor dependency (enabled automatically)",
 			flags: &cli.Flags{
-				Root:            "/",
+				Root:            []string{"/"},
 				ResultFile:      "result.textproto",
 				ExtractorsToRun: "python,javascript",
 				DetectorsToRun:  "govulncheck", // Needs the Go binary extractor.
@@ -154,20 +455,208 @@ func TestValidateFlags(t *testing.T) {
 		{
 			desc: "Invalid paths to skip",
 			flags: &cli.Flags{
-				Root:       "/",
+				Root:       []string{"/"},
 				ResultFile: "result.textproto",
 				DirsToSkip: "path1,,path3",
 			},
 			wantErr: cmpopts.AnyError,
 		},
+		{
+			desc: "SBOM verification without other outputs",
+			flags: &cli.Flags{
+				Root:       []string{"/"},
+				VerifySBOM: "sbom.spdx.json",
+			},
+			wantErr: nil,
+		},
+		{
+			desc: "Wrong SBOM extension",
+			flags: &cli.Flags{
+				Root:       []string{"/"},
+				VerifySBOM: "sbom.png",
+			},
+			wantErr: cmpopts.AnyError,
+		},
+		{
+			desc: "Wrong baseline extension",
+			flags: &cli.Flags{
+				Root:       []string{"/"},
+				ResultFile: "result.textproto",
+				Baseline:   "baseline.json",
+			},
+			wantErr: cmpopts.AnyError,
+		},
+		{
+			desc: "Invalid skip dir regex",
+			flags: &cli.Flags{
+				Root:         []string{"/"},
+				ResultFile:   "result.textproto",
+				SkipDirRegex: "node_modules(",
+			},
+			wantErr: cmpopts.AnyError,
+		},
+		{
+			desc: "Invalid skip dir regex ignored",
+			flags: &cli.Flags{
+				Root:                      []string{"/"},
+				ResultFile:                "result.textproto",
+				SkipDirRegex:              "node_modules(",
+				IgnoreInvalidSkipDirRegex: true,
+			},
+			wantErr: nil,
+		},
+		{
+			desc: "Invalid package class",
+			flags: &cli.Flags{
+				Root:         []string{"/"},
+				ResultFile:   "result.textproto",
+				PackageClass: "misc",
+			},
+			wantErr: cmpopts.AnyError,
+		},
+		{
+			desc: "Invalid log format",
+			flags: &cli.Flags{
+				Root:       []string{"/"},
+				ResultFile: "result.textproto",
+				LogFormat:  "xml",
+			},
+			wantErr: cmpopts.AnyError,
+		},
+		{
+			desc: "Negative read rate limit",
+			flags: &cli.Flags{
+				Root:              []string{"/"},
+				ResultFile:        "result.textproto",
+				MaxBytesPerSecond: -1,
+			},
+			wantErr: cmpopts.AnyError,
+		},
+		{
+			desc: "OSV ecosystems without OSV ecosystems only",
+			flags: &cli.Flags{
+				Root:          []string{"/"},
+				ResultFile:    "result.textproto",
+				OSVEcosystems: "PyPI",
+			},
+			wantErr: cmpopts.AnyError,
+		},
+		{
+			desc: "Empty OSV ecosystem",
+			flags: &cli.Flags{
+				Root:              []string{"/"},
+				ResultFile:        "result.textproto",
+				OSVEcosystemsOnly: true,
+				OSVEcosystems:     "PyPI,,npm",
+			},
+			wantErr: cmpopts.AnyError,
+		},
+		{
+			desc: "Negative result cache size",
+			flags: &cli.Flags{
+				Root:                []string{"/"},
+				ResultFile:          "result.textproto",
+				ResultCacheDir:      "/tmp/cache",
+				ResultCacheMaxBytes: -1,
+			},
+			wantErr: cmpopts.AnyError,
+		},
+		{
+			desc: "Appending to remote CDX output",
+			flags: &cli.Flags{
+				Root:      []string{"/"},
+				Output:    []string{"cdx-json=gs://bucket/sbom.cdx.json"},
+				AppendCDX: true,
+			},
+			wantErr: cmpopts.AnyError,
+		},
+		{
+			desc: "Appending to local CDX output",
+			flags: &cli.Flags{
+				Root:      []string{"/"},
+				Output:    []string{"cdx-json=sbom.cdx.json", "spdx23-json=gs://bucket/sbom.spdx.json"},
+				AppendCDX: true,
+			},
+			wantErr: nil,
+		},
+		{
+			desc: "Multiple roots",
+			flags: &cli.Flags{
+				Root:       []string{"/", "."},
+				ResultFile: "result.textproto",
+			},
+			wantErr: nil,
+		},
+		{
+			desc: "Nonexistent roots",
+			flags: &cli.Flags{
+				Root:       []string{"/", "/nonexistent/root1", "/nonexistent/root2"},
+				ResultFile: "result.textproto",
+			},
+			wantErr: cmpopts.AnyError,
+		},
+		{
+			desc: "Valid fail-on conditions",
+			flags: &cli.Flags{
+				Root:       []string{"/"},
+				ResultFile: "result.textproto",
+				FailOn:     "extractor-error,empty,finding:high",
+			},
+			wantErr: nil,
+		},
+		{
+			desc: "Unknown fail-on condition",
+			flags: &cli.Flags{
+				Root:       []string{"/"},
+				ResultFile: "result.textproto",
+				FailOn:     "extractor-error,warning",
+			},
+			wantErr: cmpopts.AnyError,
+		},
+		{
+			desc: "Unknown fail-on severity",
+			flags: &cli.Flags{
+				Root:       []string{"/"},
+				ResultFile: "result.textproto",
+				FailOn:     "finding:SEVERE",
+			},
+			wantErr: cmpopts.AnyError,
+		},
 		{
 			desc: "Invalid SPDX creator format",
 			flags: &cli.Flags{
-				Root:         "/",
+				Root:         []string{"/"},
 				SPDXCreators: "invalid:creator:format",
 			},
 			wantErr: cmpopts.AnyError,
 		},
+		{
+			desc: "Negative standalone concurrency",
+			flags: &cli.Flags{
+				Root:                  []string{"/"},
+				ResultFile:            "result.textproto",
+				StandaloneConcurrency: -1,
+			},
+			wantErr: cmpopts.AnyError,
+		},
+		{
+			desc: "Unknown memory class",
+			flags: &cli.Flags{
+				Root:           []string{"/"},
+				ResultFile:     "result.textproto",
+				MaxMemoryClass: "tiny",
+			},
+			wantErr: cmpopts.AnyError,
+		},
+		{
+			desc: "Invalid SBOM timestamp",
+			flags: &cli.Flags{
+				Root:          []string{"/"},
+				ResultFile:    "result.textproto",
+				SBOMTimestamp: "yesterday",
+			},
+			wantErr: cmpopts.AnyError,
+		},
 	} {
 		t.Run(tc.desc, func(t *testing.T) {
 			err := cli.ValidateFlags(tc.flags)
@@ -178,11 +667,66 @@ func TestValidateFlags(t *testing.T) {
 	}
 }
 
+func TestSBOMTimestamp(t *testing.T) {
+	for _, tc := range []struct {
+		desc            string
+		flag            string
+		sourceDateEpoch string
+		want            time.Time
+	}{
+		{
+			desc: "Not set",
+			want: time.Time{},
+		},
+		{
+			desc: "RFC 3339",
+			flag: "2024-03-01T10:00:00+02:00",
+			want: time.Date(2024, 3, 1, 8, 0, 0, 0, time.UTC),
+		},
+		{
+			desc: "Unix seconds",
+			flag: "1709280000",
+			want: time.Date(2024, 3, 1, 8, 0, 0, 0, time.UTC),
+		},
+		{
+			desc:            "SOURCE_DATE_EPOCH",
+			sourceDateEpoch: "1709280000",
+			want:            time.Date(2024, 3, 1, 8, 0, 0, 0, time.UTC),
+		},
+		{
+			desc:            "Flag overrides SOURCE_DATE_EPOCH",
+			flag:            "2020-01-01T00:00:00Z",
+			sourceDateEpoch: "1709280000",
+			want:            time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Setenv("SOURCE_DATE_EPOCH", tc.sourceDateEpoch)
+			flags := &cli.Flags{SBOMTimestamp: tc.flag}
+			if got := flags.GetSPDXConfig().Timestamp; !got.Equal(tc.want) {
+				t.Errorf("GetSPDXConfig().Timestamp: got %v, want %v", got, tc.want)
+			}
+			if got := flags.GetCDXConfig().Timestamp; !got.Equal(tc.want) {
+				t.Errorf("GetCDXConfig().Timestamp: got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestValidateFlags_InvalidSourceDateEpoch(t *testing.T) {
+	t.Setenv("SOURCE_DATE_EPOCH", "2024-03-01T08:00:00Z")
+	flags := &cli.Flags{Root: []string{"/"}, ResultFile: "result.textproto"}
+	if err := cli.ValidateFlags(flags); err == nil {
+		t.Errorf("cli.ValidateFlags(%v) with invalid SOURCE_DATE_EPOCH succeeded, want error", flags)
+	}
+}
+
 func TestGetScanConfig_ScanRoots(t *testing.T) {
 	for _, tc := range []struct {
-		desc          string
-		flags         map[string]*cli.Flags
-		wantScanRoots map[string][]string
+		desc                  string
+		flags                 map[string]*cli.Flags
+		wantScanRoots         map[string][]string
+		wantStoreAbsolutePath bool
 	}{
 		{
 			desc: "Default scan roots",
@@ -200,9 +744,9 @@ func TestGetScanConfig_ScanRoots(t *testing.T) {
 		{
 			desc: "Scan root are provided and used",
 			flags: map[string]*cli.Flags{
-				"darwin":  &cli.Flags{Root: "/root"},
-				"linux":   &cli.Flags{Root: "/root"},
-				"windows": &cli.Flags{Root: "C:\\myroot"},
+				"darwin":  &cli.Flags{Root: []string{"/root"}},
+				"linux":   &cli.Flags{Root: []string{"/root"}},
+				"windows": &cli.Flags{Root: []string{"C:\\myroot"}},
 			},
 			wantScanRoots: map[string][]string{
 				"darwin":  []string{"/root"},
@@ -210,6 +754,20 @@ func TestGetScanConfig_ScanRoots(t *testing.T) {
 				"windows": []string{"C:\\myroot"},
 			},
 		},
+		{
+			desc: "Multiple scan roots store absolute paths",
+			flags: map[string]*cli.Flags{
+				"darwin":  &cli.Flags{Root: []string{"/app", "/opt/tools"}},
+				"linux":   &cli.Flags{Root: []string{"/app", "/opt/tools"}},
+				"windows": &cli.Flags{Root: []string{"C:\\app", "D:\\tools"}},
+			},
+			wantScanRoots: map[string][]string{
+				"darwin":  []string{"/app", "/opt/tools"},
+				"linux":   []string{"/app", "/opt/tools"},
+				"windows": []string{"C:\\app", "D:\\tools"},
+			},
+			wantStoreAbsolutePath: true,
+		},
 	} {
 		t.Run(tc.desc, func(t *testing.T) {
 			wantScanRoots, ok := tc.wantScanRoots[runtime.GOOS]
@@ -233,6 +791,9 @@ func TestGetScanConfig_ScanRoots(t *testing.T) {
 			if diff := cmp.Diff(wantScanRoots, gotScanRoots); diff != "" {
 				t.Errorf("%v.GetScanConfig() ScanRoots got diff (-want +got):\n%s", flags, diff)
 			}
+			if cfg.StoreAbsolutePath != tc.wantStoreAbsolutePath {
+				t.Errorf("%v.GetScanConfig() StoreAbsolutePath got %v, want %v", flags, cfg.StoreAbsolutePath, tc.wantStoreAbsolutePath)
+			}
 		})
 	}
 }
@@ -246,9 +807,9 @@ func TestGetScanConfig_DirsToSkip(t *testing.T) {
 		{
 			desc: "Skip default dirs",
 			flags: map[string]*cli.Flags{
-				"darwin":  &cli.Flags{Root: "/"},
-				"linux":   &cli.Flags{Root: "/"},
-				"windows": &cli.Flags{Root: "C:\\"},
+				"darwin":  &cli.Flags{Root: []string{"/"}},
+				"linux":   &cli.Flags{Root: []string{"/"}},
+				"windows": &cli.Flags{Root: []string{"C:\\"}},
 			},
 			wantDirsToSkip: map[string][]string{
 				"darwin":  []string{"/dev", "/proc", "/sys"},
@@ -260,15 +821,15 @@ func TestGetScanConfig_DirsToSkip(t *testing.T) {
 			desc: "Skip additional dirs",
 			flags: map[string]*cli.Flags{
 				"darwin": &cli.Flags{
-					Root:       "/",
+					Root:       []string{"/"},
 					DirsToSkip: "/boot,/mnt,C:\\boot,C:\\mnt",
 				},
 				"linux": &cli.Flags{
-					Root:       "/",
+					Root:       []string{"/"},
 					DirsToSkip: "/boot,/mnt,C:\\boot,C:\\mnt",
 				},
 				"windows": &cli.Flags{
-					Root:       "C:\\",
+					Root:       []string{"C:\\"},
 					DirsToSkip: "C:\\boot,C:\\mnt",
 				},
 			},
@@ -278,19 +839,41 @@ func TestGetScanConfig_DirsToSkip(t *testing.T) {
 				"windows": []string{"C:\\Windows", "C:\\boot", "C:\\mnt"},
 			},
 		},
+		{
+			desc: "Keep relative wildcards",
+			flags: map[string]*cli.Flags{
+				"darwin": &cli.Flags{
+					Root:       []string{"/root"},
+					DirsToSkip: "**/node_modules,/root/*/cache,/other/**/cache",
+				},
+				"linux": &cli.Flags{
+					Root:       []string{"/root"},
+					DirsToSkip: "**/node_modules,/root/*/cache,/other/**/cache",
+				},
+				"windows": &cli.Flags{
+					Root:       []string{"C:\\root"},
+					DirsToSkip: "**/node_modules,C:\\root\\*\\cache,C:\\other\\**\\cache",
+				},
+			},
+			wantDirsToSkip: map[string][]string{
+				"darwin":  []string{"/root/*/cache", "**/node_modules"},
+				"linux":   []string{"/root/*/cache", "**/node_modules"},
+				"windows": []string{"C:\\root\\*\\cache", "**/node_modules"},
+			},
+		},
 		{
 			desc: "Ignore paths outside root",
 			flags: map[string]*cli.Flags{
 				"darwin": &cli.Flags{
-					Root:       "/root",
+					Root:       []string{"/root"},
 					DirsToSkip: "/root/dir1,/dir2",
 				},
 				"linux": &cli.Flags{
-					Root:       "/root",
+					Root:       []string{"/root"},
 					DirsToSkip: "/root/dir1,/dir2",
 				},
 				"windows": &cli.Flags{
-					Root:       "C:\\root",
+					Root:       []string{"C:\\root"},
 					DirsToSkip: "C:\\root\\dir1,c:\\dir2",
 				},
 			},
@@ -302,65 +885,345 @@ func TestGetScanConfig_DirsToSkip(t *testing.T) {
 		},
 	} {
 		t.Run(tc.desc, func(t *testing.T) {
-			wantDirsToSkip, ok := tc.wantDirsToSkip[runtime.GOOS]
-			if !ok {
-				t.Fatalf("Current system %q not supported, please add test cases", runtime.GOOS)
+			wantDirsToSkip, ok := tc.wantDirsToSkip[runtime.GOOS]
+			if !ok {
+				t.Fatalf("Current system %q not supported, please add test cases", runtime.GOOS)
+			}
+
+			flags, ok := tc.flags[runtime.GOOS]
+			if !ok {
+				t.Fatalf("Current system %q not supported, please add test cases", runtime.GOOS)
+			}
+
+			cfg, err := flags.GetScanConfig()
+			if err != nil {
+				t.Errorf("%v.GetScanConfig(): %v", flags, err)
+			}
+			if diff := cmp.Diff(wantDirsToSkip, cfg.DirsToSkip); diff != "" {
+				t.Errorf("%v.GetScanConfig() dirsToSkip got diff (-want +got):\n%s", flags, diff)
+			}
+		})
+	}
+}
+
+func TestGetScanConfig_SkipDirRegex(t *testing.T) {
+	for _, tc := range []struct {
+		desc             string
+		flags            *cli.Flags
+		wantSkipDirRegex string
+		wantNil          bool
+		wantErr          error
+	}{
+		{
+			desc: "simple regex",
+			flags: &cli.Flags{
+				Root:         []string{"/"},
+				SkipDirRegex: "asdf.*foo",
+			},
+			wantSkipDirRegex: "asdf.*foo",
+		},
+		{
+			desc: "no regex",
+			flags: &cli.Flags{
+				Root: []string{"/"},
+			},
+			wantNil: true,
+		},
+		{
+			desc: "invalid regex",
+			flags: &cli.Flags{
+				Root:         []string{"/"},
+				SkipDirRegex: "node_modules(",
+			},
+			wantErr: cmpopts.AnyError,
+		},
+		{
+			desc: "invalid regex ignored",
+			flags: &cli.Flags{
+				Root:                      []string{"/"},
+				SkipDirRegex:              "node_modules(",
+				IgnoreInvalidSkipDirRegex: true,
+			},
+			wantNil: true,
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			cfg, err := tc.flags.GetScanConfig()
+			if !cmp.Equal(tc.wantErr, err, cmpopts.EquateErrors()) {
+				t.Fatalf("%v.GetScanConfig() error got: %v, want: %v", tc.flags, err, tc.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if tc.wantNil && cfg.SkipDirRegex != nil {
+				t.Errorf("%v.GetScanConfig() SkipDirRegex got %q, want nil", tc.flags, cfg.SkipDirRegex)
+			}
+			if !tc.wantNil && tc.wantSkipDirRegex != cfg.SkipDirRegex.String() {
+				t.Errorf("%v.GetScanConfig() SkipDirRegex got %q, want %q", tc.flags, cfg.SkipDirRegex.String(), tc.wantSkipDirRegex)
+			}
+		})
+	}
+}
+
+func TestGetScanConfig_IncludeDirRegex(t *testing.T) {
+	for _, tc := range []struct {
+		desc                string
+		flags               *cli.Flags
+		wantIncludeDirRegex string
+		wantNil             bool
+	}{
+		{
+			desc: "simple regex",
+			flags: &cli.Flags{
+				Root:            []string{"/"},
+				IncludeDirRegex: "^src/",
+			},
+			wantIncludeDirRegex: "^src/",
+		},
+		{
+			desc: "no regex",
+			flags: &cli.Flags{
+				Root: []string{"/"},
+			},
+			wantNil: true,
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			cfg, err := tc.flags.GetScanConfig()
+			if err != nil {
+				t.Errorf("%v.GetScanConfig(): %v", tc.flags, err)
+			}
+			if tc.wantNil && cfg.IncludeDirRegex != nil {
+				t.Errorf("%v.GetScanConfig() IncludeDirRegex got %q, want nil", tc.flags, cfg.IncludeDirRegex)
+			}
+			if !tc.wantNil && tc.wantIncludeDirRegex != cfg.IncludeDirRegex.String() {
+				t.Errorf("%v.GetScanConfig() IncludeDirRegex got %q, want %q", tc.flags, cfg.IncludeDirRegex.String(), tc.wantIncludeDirRegex)
+			}
+		})
+	}
+}
+
+func TestGetScanConfig_FilesRelativeToRoot(t *testing.T) {
+	for _, tc := range []struct {
+		desc                string
+		filesRelativeToRoot bool
+		wantFiles           []string
+		wantRelativeFiles   []string
+	}{
+		{
+			desc:      "Files relative to the working directory",
+			wantFiles: []string{"requirements.txt"},
+		},
+		{
+			desc:                "Files relative to the scan roots",
+			filesRelativeToRoot: true,
+			wantRelativeFiles:   []string{"requirements.txt"},
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			flags := &cli.Flags{
+				Root:                []string{"."},
+				FilesToExtract:      []string{"requirements.txt"},
+				FilesRelativeToRoot: tc.filesRelativeToRoot,
 			}
-
-			flags, ok := tc.flags[runtime.GOOS]
-			if !ok {
-				t.Fatalf("Current system %q not supported, please add test cases", runtime.GOOS)
+			cfg, err := flags.GetScanConfig()
+			if err != nil {
+				t.Fatalf("%v.GetScanConfig(): %v", flags, err)
 			}
+			if diff := cmp.Diff(tc.wantFiles, cfg.FilesToExtract); diff != "" {
+				t.Errorf("%v.GetScanConfig() FilesToExtract unexpected diff (-want +got):\n%s", flags, diff)
+			}
+			if diff := cmp.Diff(tc.wantRelativeFiles, cfg.FilesToExtractRelative); diff != "" {
+				t.Errorf("%v.GetScanConfig() FilesToExtractRelative unexpected diff (-want +got):\n%s", flags, diff)
+			}
+		})
+	}
+}
 
+func TestGetScanConfig_Metadata(t *testing.T) {
+	for _, tc := range []struct {
+		desc     string
+		metadata []string
+		want     map[string]string
+	}{
+		{
+			desc: "No metadata",
+		},
+		{
+			desc:     "Values can contain =",
+			metadata: []string{"role=web", "build_url=https://ci.example.com/build?id=1", "empty="},
+			want:     map[string]string{"role": "web", "build_url": "https://ci.example.com/build?id=1", "empty": ""},
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			flags := &cli.Flags{
+				Root:     []string{"."},
+				Metadata: tc.metadata,
+			}
 			cfg, err := flags.GetScanConfig()
 			if err != nil {
-				t.Errorf("%v.GetScanConfig(): %v", flags, err)
+				t.Fatalf("%v.GetScanConfig(): %v", flags, err)
 			}
-			if diff := cmp.Diff(wantDirsToSkip, cfg.DirsToSkip); diff != "" {
-				t.Errorf("%v.GetScanConfig() dirsToSkip got diff (-want +got):\n%s", flags, diff)
+			if diff := cmp.Diff(tc.want, cfg.Metadata); diff != "" {
+				t.Errorf("%v.GetScanConfig() Metadata unexpected diff (-want +got):\n%s", flags, diff)
 			}
 		})
 	}
 }
 
-func TestGetScanConfig_SkipDirRegex(t *testing.T) {
+func TestGetScanConfig_InvalidExtractorPlugin(t *testing.T) {
+	// Not an executable.
+	flags := &cli.Flags{Root: []string{"."}, ExtractorPlugins: []string{"cli_test.go"}}
+	if _, err := flags.GetScanConfig(); err == nil {
+		t.Errorf("%v.GetScanConfig() succeeded with an invalid extractor plugin, want error", flags)
+	}
+}
+
+func TestGetScanConfig_ErrorStopsExternalExtractors(t *testing.T) {
+	exitedFile := filepath.Join(t.TempDir(), "exited")
+	t.Setenv(externalExtractorEnv, exitedFile)
+	flags := &cli.Flags{
+		Root:             []string{"."},
+		ExtractorPlugins: []string{os.Args[0]},
+		ExtractorsToRun:  "test/external",
+		DetectorsToRun:   "unknown-detector",
+	}
+	if _, err := flags.GetScanConfig(); err == nil {
+		t.Fatalf("%v.GetScanConfig() succeeded with an unknown detector, want error", flags)
+	}
+	if _, err := os.Stat(exitedFile); err != nil {
+		t.Errorf("%v.GetScanConfig() failed but the external extractor is still running: %v", flags, err)
+	}
+}
+
+func TestGetScanConfig_DiskImage(t *testing.T) {
+	flags := &cli.Flags{DiskImage: testDiskImage, FilterByCapabilities: true}
+	cfg, err := flags.GetScanConfig()
+	if err != nil {
+		t.Fatalf("%v.GetScanConfig(): %v", flags, err)
+	}
+	if len(cfg.ScanRoots) != 1 {
+		t.Fatalf("%v.GetScanConfig() returned %d scan roots, want 1", flags, len(cfg.ScanRoots))
+	}
+	root := cfg.ScanRoots[0]
+	img, ok := root.FS.(*diskimage.Image)
+	if !ok {
+		t.Fatalf("%v.GetScanConfig() scan root FS is a %T, want *diskimage.Image", flags, root.FS)
+	}
+	defer img.Close()
+	if !root.IsVirtual() {
+		t.Errorf("%v.GetScanConfig() scan root %q is not virtual", flags, root.Path)
+	}
+	if cfg.Capabilities.DirectFS || cfg.Capabilities.RunningSystem {
+		t.Errorf("%v.GetScanConfig() capabilities %+v allow direct FS access or the running system", flags, cfg.Capabilities)
+	}
+}
+
+// warnLogger records the warning logs.
+type warnLogger struct {
+	log.DefaultLogger
+	warnings []string
+}
+
+func (l *warnLogger) Warnf(format string, args ...any) {
+	l.warnings = append(l.warnings, fmt.Sprintf(format, args...))
+}
+
+func TestGetScanConfig_UnmetRequirements(t *testing.T) {
 	for _, tc := range []struct {
-		desc             string
-		flags            *cli.Flags
-		wantSkipDirRegex string
-		wantNil          bool
+		desc         string
+		filter       bool
+		wantWarnings int
 	}{
 		{
-			desc: "simple regex",
-			flags: &cli.Flags{
-				Root:         "/",
-				SkipDirRegex: "asdf.*foo",
-			},
-			wantSkipDirRegex: "asdf.*foo",
+			desc:         "Filtering disabled",
+			filter:       false,
+			wantWarnings: 1,
 		},
 		{
-			desc: "no regex",
-			flags: &cli.Flags{
-				Root: "/",
-			},
-			wantNil: true,
+			desc:         "Filtering enabled",
+			filter:       true,
+			wantWarnings: 0,
 		},
 	} {
 		t.Run(tc.desc, func(t *testing.T) {
-			cfg, err := tc.flags.GetScanConfig()
+			l := &warnLogger{}
+			log.SetLogger(l)
+			defer log.SetLogger(&log.DefaultLogger{})
+
+			// govulncheck needs direct filesystem access, which disk images don't provide.
+			flags := &cli.Flags{
+				DiskImage:            testDiskImage,
+				ExtractorsToRun:      "python/wheelegg",
+				DetectorsToRun:       binary.Name,
+				FilterByCapabilities: tc.filter,
+			}
+			cfg, err := flags.GetScanConfig()
 			if err != nil {
-				t.Errorf("%v.GetScanConfig(): %v", tc.flags, err)
+				t.Fatalf("%v.GetScanConfig(): %v", flags, err)
 			}
-			if tc.wantNil && cfg.SkipDirRegex != nil {
-				t.Errorf("%v.GetScanConfig() SkipDirRegex got %q, want nil", tc.flags, cfg.SkipDirRegex)
+			defer cfg.ScanRoots[0].FS.(*diskimage.Image).Close()
+
+			if len(l.warnings) != tc.wantWarnings {
+				t.Fatalf("%v.GetScanConfig() logged warnings %q, want %d", flags, l.warnings, tc.wantWarnings)
 			}
-			if !tc.wantNil && tc.wantSkipDirRegex != cfg.SkipDirRegex.String() {
-				t.Errorf("%v.GetScanConfig() SkipDirRegex got %q, want %q", tc.flags, cfg.SkipDirRegex.String(), tc.wantSkipDirRegex)
+			for _, w := range l.warnings {
+				if !strings.Contains(w, binary.Name) || !strings.Contains(w, "direct filesystem access") {
+					t.Errorf("%v.GetScanConfig() logged warning %q, want it to name the plugin and the missing capability", flags, w)
+				}
 			}
 		})
 	}
 }
 
+func TestGetScanConfig_ImageTarball(t *testing.T) {
+	flags := &cli.Flags{ImageTarball: testImageTarball, ExtractorsToRun: "javascript/packagejson", FilterByCapabilities: true}
+	cfg, err := flags.GetScanConfig()
+	if err != nil {
+		t.Fatalf("%v.GetScanConfig(): %v", flags, err)
+	}
+	if len(cfg.ScanRoots) != 1 {
+		t.Fatalf("%v.GetScanConfig() returned %d scan roots, want 1", flags, len(cfg.ScanRoots))
+	}
+	root := cfg.ScanRoots[0]
+	img, ok := root.FS.(*tarball.Image)
+	if !ok {
+		t.Fatalf("%v.GetScanConfig() scan root FS is a %T, want *tarball.Image", flags, root.FS)
+	}
+	defer img.Close()
+	if !root.IsVirtual() {
+		t.Errorf("%v.GetScanConfig() scan root %q is not virtual", flags, root.Path)
+	}
+
+	result := scalibr.New().Scan(context.Background(), cfg)
+	var got []string
+	for _, i := range result.Inventories {
+		got = append(got, i.Name+"@"+i.Locations[0])
+	}
+	// The package.json of the lower layer was deleted in the upper one.
+	if diff := cmp.Diff([]string{"web@srv/package.json"}, got); diff != "" {
+		t.Errorf("scalibr.Scan() unexpected inventory (-want +got):\n%s", diff)
+	}
+	want := &scalibr.ContainerImage{
+		Created: time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC),
+		Labels:  map[string]string{"org.opencontainers.image.source": "https://example.com/web"},
+	}
+	if diff := cmp.Diff(want, result.ContainerImage); diff != "" {
+		t.Errorf("scalibr.Scan() unexpected container image (-want +got):\n%s", diff)
+	}
+}
+
+func TestGetScanConfig_MaxMemoryClass(t *testing.T) {
+	flags := &cli.Flags{Root: []string{"/"}, ExtractorsToRun: "java", MaxMemoryClass: "file-size"}
+	cfg, err := flags.GetScanConfig()
+	if err != nil {
+		t.Fatalf("%v.GetScanConfig(): %v", flags, err)
+	}
+	if cfg.Capabilities.Memory != plugin.MemoryFileSize {
+		t.Errorf("%v.GetScanConfig() memory class: got %v, want %v", flags, cfg.Capabilities.Memory, plugin.MemoryFileSize)
+	}
+}
+
 func TestGetScanConfig_CreatePlugins(t *testing.T) {
 	for _, tc := range []struct {
 		desc               string
@@ -398,6 +1261,46 @@ func TestGetScanConfig_CreatePlugins(t *testing.T) {
 	}
 }
 
+func TestGetScanConfig_ExperimentalExtractors(t *testing.T) {
+	for _, tc := range []struct {
+		desc        string
+		flags       *cli.Flags
+		wantEnabled bool
+	}{
+		{
+			desc:        "Excluded from groups",
+			flags:       &cli.Flags{ExtractorsToRun: "misc"},
+			wantEnabled: false,
+		},
+		{
+			desc:        "Enabled by name",
+			flags:       &cli.Flags{ExtractorsToRun: "misc/browserext"},
+			wantEnabled: true,
+		},
+		{
+			desc:        "Included in groups with --include-experimental",
+			flags:       &cli.Flags{ExtractorsToRun: "misc", IncludeExperimental: true},
+			wantEnabled: true,
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			cfg, err := tc.flags.GetScanConfig()
+			if err != nil {
+				t.Fatalf("%v.GetScanConfig(): %v", tc.flags, err)
+			}
+			gotEnabled := false
+			for _, e := range cfg.FilesystemExtractors {
+				if e.Name() == "misc/browserext" {
+					gotEnabled = true
+				}
+			}
+			if gotEnabled != tc.wantEnabled {
+				t.Errorf("%v.GetScanConfig(): misc/browserext enabled: %t, want %t", tc.flags, gotEnabled, tc.wantEnabled)
+			}
+		})
+	}
+}
+
 func TestGetScanConfig_GovulncheckParams(t *testing.T) {
 	dbPath := "path/to/db"
 	flags := &cli.Flags{
@@ -419,6 +1322,82 @@ func TestGetScanConfig_GovulncheckParams(t *testing.T) {
 	}
 }
 
+func TestGetScanConfig_ConfigFile(t *testing.T) {
+	for _, tc := range []struct {
+		desc       string
+		config     string
+		flagDBPath string
+		wantDBPath string
+		wantErr    error
+	}{
+		{
+			desc:       "Options from config file",
+			config:     "plugins:\n  govulncheck/binary:\n    offline_vuln_db_path: path/from/config\n",
+			wantDBPath: "path/from/config",
+		},
+		{
+			desc:       "Flag takes precedence",
+			config:     "plugins:\n  govulncheck/binary:\n    offline_vuln_db_path: path/from/config\n",
+			flagDBPath: "path/from/flag",
+			wantDBPath: "path/from/flag",
+		},
+		{
+			desc:   "Options for plugin that's not enabled",
+			config: "plugins:\n  os/dpkg:\n    foo: bar\n",
+		},
+		{
+			desc:    "Unknown option",
+			config:  "plugins:\n  govulncheck/binary:\n    foo: bar\n",
+			wantErr: cmpopts.AnyError,
+		},
+		{
+			desc:    "Wrong option type",
+			config:  "plugins:\n  govulncheck/binary:\n    offline_vuln_db_path: [a, b]\n",
+			wantErr: cmpopts.AnyError,
+		},
+		{
+			desc:    "Plugin doesn't accept options",
+			config:  "plugins:\n  go/binary:\n    foo: bar\n",
+			wantErr: cmpopts.AnyError,
+		},
+		{
+			desc:    "Unknown top-level field",
+			config:  "detectors:\n  - foo\n",
+			wantErr: cmpopts.AnyError,
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			configPath := filepath.Join(t.TempDir(), "scan.yaml")
+			if err := os.WriteFile(configPath, []byte(tc.config), 0644); err != nil {
+				t.Fatalf("os.WriteFile(%s): %v", configPath, err)
+			}
+			flags := &cli.Flags{
+				ExtractorsToRun:   "go",
+				DetectorsToRun:    binary.Detector{}.Name(),
+				GovulncheckDBPath: tc.flagDBPath,
+				ConfigFile:        configPath,
+			}
+			cfg, err := flags.GetScanConfig()
+			if !cmp.Equal(err, tc.wantErr, cmpopts.EquateErrors()) {
+				t.Fatalf("%v.GetScanConfig() error got: %v, want: %v", flags, err, tc.wantErr)
+			}
+			if tc.wantErr != nil {
+				return
+			}
+			if len(cfg.Detectors) != 1 {
+				t.Fatalf("%v.GetScanConfig() want 1 detector got %d", flags, len(cfg.Detectors))
+			}
+			det := cfg.Detectors[0].(*binary.Detector)
+			got := det.OfflineVulnDBPath
+			// The detector instances are shared between tests so reset the DB path.
+			det.OfflineVulnDBPath = ""
+			if got != tc.wantDBPath {
+				t.Errorf("%v.GetScanConfig() want govulncheck detector with DB path %q got %q", flags, tc.wantDBPath, got)
+			}
+		})
+	}
+}
+
 func TestWriteScanResults(t *testing.T) {
 	testDirPath := t.TempDir()
 	result := &scalibr.ScanResult{
@@ -463,6 +1442,50 @@ func TestWriteScanResults(t *testing.T) {
 			wantFilename:      "result.cyclonedx.json",
 			wantContentPrefix: "{\n  \"$schema\": \"http://cyclonedx.org/schema/bom-1.6.schema.json\"",
 		},
+		{
+			desc: "Create compact CDX",
+			flags: &cli.Flags{
+				Output:      []string{"cdx-json=" + filepath.Join(testDirPath, "compact.cyclonedx.json")},
+				CompactJSON: true,
+			},
+			wantFilename:      "compact.cyclonedx.json",
+			wantContentPrefix: "{\"$schema\":\"http://cyclonedx.org/schema/bom-1.6.schema.json\"",
+		},
+		{
+			desc: "Create compact SPDX 2.3",
+			flags: &cli.Flags{
+				Output:      []string{"spdx23-json=" + filepath.Join(testDirPath, "compact.spdx.json")},
+				CompactJSON: true,
+			},
+			wantFilename:      "compact.spdx.json",
+			wantContentPrefix: "{\"spdxVersion\":\"SPDX-2.3\"",
+		},
+		{
+			desc: "Create validated SPDX 2.3",
+			flags: &cli.Flags{
+				Output:         []string{"spdx23-json=" + filepath.Join(testDirPath, "validated.spdx.json")},
+				ValidateOutput: true,
+			},
+			wantFilename:      "validated.spdx.json",
+			wantContentPrefix: "{",
+		},
+		{
+			desc: "Create validated CDX",
+			flags: &cli.Flags{
+				Output:         []string{"cdx-xml=" + filepath.Join(testDirPath, "validated.cyclonedx.xml")},
+				ValidateOutput: true,
+			},
+			wantFilename:      "validated.cyclonedx.xml",
+			wantContentPrefix: "<?xml",
+		},
+		{
+			desc: "Create PURL list",
+			flags: &cli.Flags{
+				Output: []string{"purls=" + filepath.Join(testDirPath, "result.purls.txt")},
+			},
+			wantFilename:      "result.purls.txt",
+			wantContentPrefix: "",
+		},
 	} {
 		t.Run(tc.desc, func(t *testing.T) {
 			if err := tc.flags.WriteScanResults(result); err != nil {
@@ -482,3 +1505,207 @@ func TestWriteScanResults(t *testing.T) {
 		})
 	}
 }
+
+func TestWriteScanResults_Baseline(t *testing.T) {
+	testDirPath := t.TempDir()
+	pipEx := wheelegg.New(wheelegg.DefaultConfig())
+	known := &extractor.Inventory{Name: "known", Version: "1.0", Locations: []string{"/file1"}, Extractor: pipEx}
+	baselinePath := filepath.Join(testDirPath, "baseline.binproto")
+	baseline, err := proto.ScanResultToProto(&scalibr.ScanResult{
+		Version:     "1.2.3",
+		Status:      &plugin.ScanStatus{Status: plugin.ScanStatusSucceeded},
+		Inventories: []*extractor.Inventory{known},
+	})
+	if err != nil {
+		t.Fatalf("proto.ScanResultToProto(): %v", err)
+	}
+	if err := proto.Write(baselinePath, baseline); err != nil {
+		t.Fatalf("proto.Write(%s): %v", baselinePath, err)
+	}
+
+	result := &scalibr.ScanResult{
+		Version: "1.2.3",
+		Status:  &plugin.ScanStatus{Status: plugin.ScanStatusSucceeded},
+		Inventories: []*extractor.Inventory{
+			known,
+			// Same package at a new location.
+			{Name: "known", Version: "1.0", Locations: []string{"/file2"}, Extractor: pipEx},
+			// New version at the same location.
+			{Name: "known", Version: "2.0", Locations: []string{"/file1"}, Extractor: pipEx},
+		},
+	}
+	outPath := filepath.Join(testDirPath, "result.purls.txt")
+	flags := &cli.Flags{Output: []string{"purls=" + outPath}, Baseline: baselinePath}
+	if err := flags.WriteScanResults(result); err != nil {
+		t.Fatalf("%v.WriteScanResults(%v): %v", flags, result, err)
+	}
+
+	got, err := os.ReadFile(outPath)
+	if err != nil {
+		t.Fatalf("error while reading %s: %v", outPath, err)
+	}
+	want := "pkg:pypi/known@1.0\npkg:pypi/known@2.0\n"
+	if diff := cmp.Diff(want, string(got)); diff != "" {
+		t.Errorf("%v.WriteScanResults(%v) unexpected output (-want +got):\n%s", flags, result, diff)
+	}
+	if len(result.Inventories) != 3 {
+		t.Errorf("%v.WriteScanResults(%v) modified the scan result", flags, result)
+	}
+}
+
+func TestWriteScanResults_PackageClass(t *testing.T) {
+	result := &scalibr.ScanResult{
+		Version: "1.2.3",
+		Status:  &plugin.ScanStatus{Status: plugin.ScanStatusSucceeded},
+		Inventories: []*extractor.Inventory{
+			{Name: "requests", Version: "2.0", Extractor: wheelegg.New(wheelegg.DefaultConfig())},
+			{
+				Name:      "bash",
+				Version:   "5.2",
+				Extractor: dpkg.New(dpkg.DefaultConfig()),
+				Metadata:  &dpkg.Metadata{PackageName: "bash", PackageVersion: "5.2", OSID: "debian"},
+			},
+			{
+				Name:      "git",
+				Version:   "5.2.1",
+				Extractor: jenkinsplugin.New(jenkinsplugin.DefaultConfig()),
+				Metadata:  &jenkinsplugin.Metadata{GroupID: "org.jenkins-ci.plugins"},
+			},
+		},
+	}
+	for _, tc := range []struct {
+		class string
+		want  string
+	}{
+		{
+			class: "os",
+			want:  "pkg:deb/debian/bash@5.2\n",
+		},
+		{
+			class: "language",
+			want:  "pkg:pypi/requests@2.0\n",
+		},
+		{
+			class: "all",
+			want:  "pkg:deb/debian/bash@5.2\npkg:maven/org.jenkins-ci.plugins/git@5.2.1\npkg:pypi/requests@2.0\n",
+		},
+	} {
+		t.Run(tc.class, func(t *testing.T) {
+			outPath := filepath.Join(t.TempDir(), "result.purls.txt")
+			flags := &cli.Flags{Output: []string{"purls=" + outPath}, PackageClass: tc.class}
+			if err := flags.WriteScanResults(result); err != nil {
+				t.Fatalf("%v.WriteScanResults(%v): %v", flags, result, err)
+			}
+			got, err := os.ReadFile(outPath)
+			if err != nil {
+				t.Fatalf("error while reading %s: %v", outPath, err)
+			}
+			if diff := cmp.Diff(tc.want, string(got)); diff != "" {
+				t.Errorf("%v.WriteScanResults(%v) unexpected output (-want +got):\n%s", flags, result, diff)
+			}
+		})
+	}
+}
+
+func TestWriteScanResults_OSVEcosystemsOnly(t *testing.T) {
+	result := &scalibr.ScanResult{
+		Version: "1.2.3",
+		Status:  &plugin.ScanStatus{Status: plugin.ScanStatusSucceeded},
+		Inventories: []*extractor.Inventory{
+			{Name: "requests", Version: "2.0", Extractor: wheelegg.New(wheelegg.DefaultConfig())},
+			{
+				Name:      "bash",
+				Version:   "5.2",
+				Extractor: dpkg.New(dpkg.DefaultConfig()),
+				Metadata:  &dpkg.Metadata{PackageName: "bash", PackageVersion: "5.2", OSID: "debian", OSVersionID: "12"},
+			},
+			{
+				Name:      "Slack",
+				Version:   "4.38.125",
+				Extractor: macapps.New(macapps.DefaultConfig()),
+				Metadata:  &macapps.Metadata{CFBundleName: "Slack"},
+			},
+		},
+	}
+	for _, tc := range []struct {
+		desc       string
+		ecosystems string
+		want       string
+	}{
+		{
+			desc: "Default ecosystems",
+			want: "pkg:deb/debian/bash@5.2?distro=12\npkg:pypi/requests@2.0\n",
+		},
+		{
+			desc:       "Custom ecosystems",
+			ecosystems: "pypi",
+			want:       "pkg:pypi/requests@2.0\n",
+		},
+		{
+			desc:       "Ecosystem with release",
+			ecosystems: "Debian,npm",
+			want:       "pkg:deb/debian/bash@5.2?distro=12\n",
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			outPath := filepath.Join(t.TempDir(), "result.purls.txt")
+			flags := &cli.Flags{Output: []string{"purls=" + outPath}, OSVEcosystemsOnly: true, OSVEcosystems: tc.ecosystems}
+			if err := flags.WriteScanResults(result); err != nil {
+				t.Fatalf("%v.WriteScanResults(%v): %v", flags, result, err)
+			}
+			got, err := os.ReadFile(outPath)
+			if err != nil {
+				t.Fatalf("error while reading %s: %v", outPath, err)
+			}
+			if diff := cmp.Diff(tc.want, string(got)); diff != "" {
+				t.Errorf("%v.WriteScanResults(%v) unexpected output (-want +got):\n%s", flags, result, diff)
+			}
+		})
+	}
+}
+
+func TestNewLogger_JSON(t *testing.T) {
+	var buf strings.Builder
+	flags := &cli.Flags{LogFormat: cli.LogFormatJSON, Quiet: true}
+	l := flags.NewLogger(&buf)
+	l.Infof("Scan roots: %s", "/")
+	l.Errorf("Scan wasn't successful: %s", "timeout")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 1 {
+		t.Fatalf("%v.NewLogger() wrote %d lines, want 1 (info logs are dropped in quiet mode):\n%s", flags, len(lines), buf.String())
+	}
+	var got map[string]string
+	if err := json.Unmarshal([]byte(lines[0]), &got); err != nil {
+		t.Fatalf("%v.NewLogger() wrote invalid JSON %q: %v", flags, lines[0], err)
+	}
+	if got["level"] != "ERROR" || got["msg"] != "Scan wasn't successful: timeout" {
+		t.Errorf("%v.NewLogger() wrote %v, want level ERROR and the message", flags, got)
+	}
+}
+
+func TestWriteSBOMDiff(t *testing.T) {
+	testDirPath := t.TempDir()
+	sbomPath := filepath.Join(testDirPath, "sbom.cyclonedx.json")
+	sbom := `{"bomFormat": "CycloneDX", "specVersion": "1.6", "components": [{"type": "library", "name": "removed", "purl": "pkg:pypi/removed@1.0"}]}`
+	if err := os.WriteFile(sbomPath, []byte(sbom), 0644); err != nil {
+		t.Fatalf("os.WriteFile(%s): %v", sbomPath, err)
+	}
+	result := &scalibr.ScanResult{
+		Version: "1.2.3",
+		Status:  &plugin.ScanStatus{Status: plugin.ScanStatusSucceeded},
+	}
+	flags := &cli.Flags{VerifySBOM: sbomPath}
+
+	out := &strings.Builder{}
+	diff, err := flags.WriteSBOMDiff(result, out)
+	if err != nil {
+		t.Fatalf("%v.WriteSBOMDiff(%v): %v", flags, result, err)
+	}
+	if diff := cmp.Diff([]string{"pkg:pypi/removed@1.0"}, diff.Removed); diff != "" {
+		t.Errorf("%v.WriteSBOMDiff(%v) unexpected removed packages (-want +got):\n%s", flags, result, diff)
+	}
+	if !strings.Contains(out.String(), `"removed": [`) {
+		t.Errorf("%v.WriteSBOMDiff(%v) wrote unexpected output: %q", flags, result, out.String())
+	}
+}
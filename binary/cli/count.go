@@ -0,0 +1,63 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"text/tabwriter"
+
+	scalibr "github.com/google/osv-scalibr"
+)
+
+// WriteInventoryCounts writes the number of inventory items each extractor
+// found to w, followed by the total. Used by --count-only instead of writing
+// the scan results. Enabled extractors that found nothing are listed with a
+// count of 0.
+func WriteInventoryCounts(cfg *scalibr.ScanConfig, result *scalibr.ScanResult, w io.Writer) error {
+	counts := map[string]int{}
+	for _, e := range cfg.FilesystemExtractors {
+		counts[e.Name()] = 0
+	}
+	for _, e := range cfg.StandaloneExtractors {
+		counts[e.Name()] = 0
+	}
+	for _, i := range result.Inventories {
+		// Inventory reused from a previous scan might not have an extractor.
+		name := "unknown"
+		if i.Extractor != nil {
+			name = i.Extractor.Name()
+		}
+		counts[name]++
+	}
+
+	names := make([]string, 0, len(counts))
+	for name := range counts {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	for _, name := range names {
+		if _, err := fmt.Fprintf(tw, "%s\t%d\n", name, counts[name]); err != nil {
+			return err
+		}
+	}
+	if _, err := fmt.Fprintf(tw, "total\t%d\n", len(result.Inventories)); err != nil {
+		return err
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,53 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/osv-scalibr/binary/cli"
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem"
+	"github.com/google/osv-scalibr/testing/fakeextractor"
+	scalibr "github.com/google/osv-scalibr"
+)
+
+func TestWriteInventoryCounts(t *testing.T) {
+	exA := fakeextractor.New("os/dpkg", 1, nil, nil)
+	exB := fakeextractor.New("python/wheelegg", 1, nil, nil)
+	exC := fakeextractor.New("javascript/packagejson", 1, nil, nil)
+	cfg := &scalibr.ScanConfig{FilesystemExtractors: []filesystem.Extractor{exA, exB, exC}}
+	result := &scalibr.ScanResult{Inventories: []*extractor.Inventory{
+		{Name: "a", Extractor: exA},
+		{Name: "b", Extractor: exA},
+		{Name: "c", Extractor: exB},
+		{Name: "d"},
+	}}
+
+	var buf bytes.Buffer
+	if err := cli.WriteInventoryCounts(cfg, result, &buf); err != nil {
+		t.Fatalf("WriteInventoryCounts(): %v", err)
+	}
+	want := "javascript/packagejson  0\n" +
+		"os/dpkg                 2\n" +
+		"python/wheelegg         1\n" +
+		"unknown                 1\n" +
+		"total                   4\n"
+	if diff := cmp.Diff(want, buf.String()); diff != "" {
+		t.Errorf("WriteInventoryCounts() unexpected diff (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,71 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"slices"
+	"text/tabwriter"
+
+	"github.com/google/osv-scalibr/extractor/filesystem"
+	"github.com/google/osv-scalibr/log"
+	scalibr "github.com/google/osv-scalibr"
+)
+
+// WriteCoverageReport walks the scan roots of cfg and writes the number of
+// files each enabled filesystem extractor requires to w, sorted by name and
+// followed by the total number of files. Used by --coverage-report instead of
+// running a scan. The files are never extracted.
+func WriteCoverageReport(ctx context.Context, cfg *scalibr.ScanConfig, w io.Writer) error {
+	report, err := filesystem.Coverage(ctx, &filesystem.Config{
+		Extractors:             cfg.FilesystemExtractors,
+		ScanRoots:              cfg.ScanRoots,
+		FilesToExtract:         cfg.FilesToExtract,
+		FilesToExtractRelative: cfg.FilesToExtractRelative,
+		DirsToSkip:             cfg.DirsToSkip,
+		SkipDirRegex:           cfg.SkipDirRegex,
+		IncludeDirRegex:        cfg.IncludeDirRegex,
+		ReadSymlinks:           cfg.ReadSymlinks,
+		MaxInodes:              cfg.MaxInodes,
+		MaxInodesBehavior:      cfg.MaxInodesBehavior,
+		Quiet:                  cfg.Quiet,
+		MaxBytesPerSecond:      cfg.MaxBytesPerSecond,
+	})
+	if err != nil {
+		return err
+	}
+	if report.Truncated {
+		log.Warnf("The coverage report is incomplete since the walk exceeded --max-inodes")
+	}
+
+	names := make([]string, 0, len(report.RequiredFiles))
+	for name := range report.RequiredFiles {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	for _, name := range names {
+		if _, err := fmt.Fprintf(tw, "%s\t%d\n", name, report.RequiredFiles[name]); err != nil {
+			return err
+		}
+	}
+	if _, err := fmt.Fprintf(tw, "total files\t%d\n", report.TotalFiles); err != nil {
+		return err
+	}
+	return tw.Flush()
+}
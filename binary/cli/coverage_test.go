@@ -0,0 +1,62 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli_test
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/osv-scalibr/binary/cli"
+	"github.com/google/osv-scalibr/extractor/filesystem"
+	"github.com/google/osv-scalibr/extractor/filesystem/language/javascript/packagejson"
+	"github.com/google/osv-scalibr/extractor/filesystem/language/python/requirements"
+	scalibrfs "github.com/google/osv-scalibr/fs"
+	scalibr "github.com/google/osv-scalibr"
+)
+
+func TestWriteCoverageReport(t *testing.T) {
+	dir := t.TempDir()
+	for _, f := range []string{"package.json", "lib/package.json", "readme.md"} {
+		p := filepath.Join(dir, f)
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte("{}"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	cfg := &scalibr.ScanConfig{
+		ScanRoots: []*scalibrfs.ScanRoot{scalibrfs.RealFSScanRoot(dir)},
+		FilesystemExtractors: []filesystem.Extractor{
+			packagejson.New(packagejson.DefaultConfig()),
+			requirements.New(requirements.DefaultConfig()),
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := cli.WriteCoverageReport(context.Background(), cfg, &buf); err != nil {
+		t.Fatalf("WriteCoverageReport(): %v", err)
+	}
+	want := "javascript/packagejson  2\n" +
+		"python/requirements     0\n" +
+		"total files             3\n"
+	if diff := cmp.Diff(want, buf.String()); diff != "" {
+		t.Errorf("WriteCoverageReport() unexpected diff (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,146 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/google/osv-scalibr/detector"
+	"github.com/google/osv-scalibr/plugin"
+	scalibr "github.com/google/osv-scalibr"
+)
+
+// Exit codes returned by the scalibr binary when a --fail-on condition is met.
+// If several conditions are met, the one with the lowest exit code is used.
+// Exit code 1 is used for scans that couldn't be completed and takes precedence
+// over all of them.
+const (
+	// ExitCodeFinding is returned for "finding" and "finding:<SEVERITY>".
+	ExitCodeFinding = 2
+	// ExitCodeExtractorError is returned for "extractor-error".
+	ExitCodeExtractorError = 3
+	// ExitCodeEmpty is returned for "empty".
+	ExitCodeEmpty = 4
+)
+
+var severityNames = map[string]detector.SeverityEnum{
+	"MINIMAL":  detector.SeverityMinimal,
+	"LOW":      detector.SeverityLow,
+	"MEDIUM":   detector.SeverityMedium,
+	"HIGH":     detector.SeverityHigh,
+	"CRITICAL": detector.SeverityCritical,
+}
+
+// failOnPolicy holds the conditions parsed from the --fail-on flag.
+type failOnPolicy struct {
+	extractorError bool
+	empty          bool
+	// findings is set if findings fail the scan.
+	findings bool
+	// minSeverity is the lowest severity of the findings that fail the scan.
+	// Findings with unspecified severity only fail it if no severity is given.
+	minSeverity detector.SeverityEnum
+}
+
+// parseFailOn parses a comma-separated list of --fail-on tokens.
+func parseFailOn(arg string) (*failOnPolicy, error) {
+	p := &failOnPolicy{}
+	if arg == "" {
+		return p, nil
+	}
+	for _, token := range strings.Split(arg, ",") {
+		token = strings.TrimSpace(token)
+		switch {
+		case token == "extractor-error":
+			p.extractorError = true
+		case token == "empty":
+			p.empty = true
+		case token == "finding":
+			p.addFindingSeverity(detector.SeverityUnspecified)
+		case strings.HasPrefix(token, "finding:"):
+			name := strings.TrimPrefix(token, "finding:")
+			sev, ok := severityNames[strings.ToUpper(name)]
+			if !ok {
+				return nil, fmt.Errorf("unknown severity %q, should be one of MINIMAL, LOW, MEDIUM, HIGH, CRITICAL", name)
+			}
+			p.addFindingSeverity(sev)
+		default:
+			return nil, fmt.Errorf("unknown condition %q, should be one of extractor-error, empty, finding, finding:<SEVERITY>", token)
+		}
+	}
+	return p, nil
+}
+
+// addFindingSeverity makes findings of the given or higher severity fail the
+// scan. If there are several finding conditions, the lowest severity is used.
+func (p *failOnPolicy) addFindingSeverity(sev detector.SeverityEnum) {
+	if !p.findings || sev < p.minSeverity {
+		p.minSeverity = sev
+	}
+	p.findings = true
+}
+
+// FailOnExitCode returns the exit code for the --fail-on conditions that the
+// scan result meets together with a description of the condition, or 0 if
+// none are met. The config is used to tell the extractors' statuses apart from
+// the detectors' ones.
+func (f *Flags) FailOnExitCode(cfg *scalibr.ScanConfig, result *scalibr.ScanResult) (int, string) {
+	p, err := parseFailOn(f.FailOn)
+	if err != nil {
+		// Already checked in ValidateFlags.
+		return 1, err.Error()
+	}
+
+	if p.findings {
+		for _, finding := range result.Findings {
+			if p.matchesFinding(finding) {
+				return ExitCodeFinding, "found " + findingName(finding)
+			}
+		}
+	}
+	if p.extractorError {
+		extractors := make(map[string]bool)
+		for _, e := range cfg.FilesystemExtractors {
+			extractors[e.Name()] = true
+		}
+		for _, e := range cfg.StandaloneExtractors {
+			extractors[e.Name()] = true
+		}
+		for _, s := range result.PluginStatus {
+			if extractors[s.Name] && s.Status.Status != plugin.ScanStatusSucceeded {
+				return ExitCodeExtractorError, fmt.Sprintf("extractor %s: %v", s.Name, s.Status)
+			}
+		}
+	}
+	if p.empty && len(result.Inventories) == 0 {
+		return ExitCodeEmpty, "no software inventory found"
+	}
+	return 0, ""
+}
+
+func (p *failOnPolicy) matchesFinding(f *detector.Finding) bool {
+	if p.minSeverity == detector.SeverityUnspecified {
+		return true
+	}
+	return f.Adv != nil && f.Adv.Sev != nil && f.Adv.Sev.Severity >= p.minSeverity
+}
+
+func findingName(f *detector.Finding) string {
+	if f.Adv == nil || f.Adv.ID == nil {
+		return "a finding"
+	}
+	return fmt.Sprintf("%s %s", f.Adv.ID.Publisher, f.Adv.ID.Reference)
+}
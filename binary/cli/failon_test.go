@@ -0,0 +1,137 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli_test
+
+import (
+	"testing"
+
+	"github.com/google/osv-scalibr/binary/cli"
+	"github.com/google/osv-scalibr/detector"
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem"
+	"github.com/google/osv-scalibr/plugin"
+	"github.com/google/osv-scalibr/testing/fakedetector"
+	"github.com/google/osv-scalibr/testing/fakeextractor"
+	scalibr "github.com/google/osv-scalibr"
+)
+
+func finding(sev detector.SeverityEnum) *detector.Finding {
+	return &detector.Finding{Adv: &detector.Advisory{
+		ID:  &detector.AdvisoryID{Publisher: "CVE", Reference: "CVE-2024-1234"},
+		Sev: &detector.Severity{Severity: sev},
+	}}
+}
+
+func TestFailOnExitCode(t *testing.T) {
+	cfg := &scalibr.ScanConfig{
+		FilesystemExtractors: []filesystem.Extractor{fakeextractor.New("ex", 1, nil, nil)},
+		Detectors:            []detector.Detector{fakedetector.New("det", 1, nil, nil)},
+	}
+	inventory := []*extractor.Inventory{{Name: "software", Version: "1.0"}}
+	succeeded := []*plugin.Status{
+		{Name: "ex", Version: 1, Status: &plugin.ScanStatus{Status: plugin.ScanStatusSucceeded}},
+		{Name: "det", Version: 1, Status: &plugin.ScanStatus{Status: plugin.ScanStatusSucceeded}},
+	}
+	extractorFailed := []*plugin.Status{
+		{Name: "ex", Version: 1, Status: &plugin.ScanStatus{Status: plugin.ScanStatusPartiallySucceeded, FailureReason: "error"}},
+		{Name: "det", Version: 1, Status: &plugin.ScanStatus{Status: plugin.ScanStatusSucceeded}},
+	}
+	detectorFailed := []*plugin.Status{
+		{Name: "ex", Version: 1, Status: &plugin.ScanStatus{Status: plugin.ScanStatusSucceeded}},
+		{Name: "det", Version: 1, Status: &plugin.ScanStatus{Status: plugin.ScanStatusFailed, FailureReason: "error"}},
+	}
+
+	for _, tc := range []struct {
+		desc     string
+		failOn   string
+		result   *scalibr.ScanResult
+		wantCode int
+	}{
+		{
+			desc:     "No conditions",
+			failOn:   "",
+			result:   &scalibr.ScanResult{PluginStatus: extractorFailed, Findings: []*detector.Finding{finding(detector.SeverityCritical)}},
+			wantCode: 0,
+		},
+		{
+			desc:     "No condition met",
+			failOn:   "extractor-error,empty,finding:HIGH",
+			result:   &scalibr.ScanResult{PluginStatus: succeeded, Inventories: inventory, Findings: []*detector.Finding{finding(detector.SeverityMedium)}},
+			wantCode: 0,
+		},
+		{
+			desc:     "Extractor error",
+			failOn:   "extractor-error",
+			result:   &scalibr.ScanResult{PluginStatus: extractorFailed, Inventories: inventory},
+			wantCode: cli.ExitCodeExtractorError,
+		},
+		{
+			desc:     "Detector errors aren't extractor errors",
+			failOn:   "extractor-error",
+			result:   &scalibr.ScanResult{PluginStatus: detectorFailed, Inventories: inventory},
+			wantCode: 0,
+		},
+		{
+			desc:     "Empty",
+			failOn:   "empty",
+			result:   &scalibr.ScanResult{PluginStatus: succeeded},
+			wantCode: cli.ExitCodeEmpty,
+		},
+		{
+			desc:     "Finding above severity",
+			failOn:   "finding:high",
+			result:   &scalibr.ScanResult{PluginStatus: succeeded, Findings: []*detector.Finding{finding(detector.SeverityCritical)}},
+			wantCode: cli.ExitCodeFinding,
+		},
+		{
+			desc:     "Finding without severity",
+			failOn:   "finding:LOW",
+			result:   &scalibr.ScanResult{PluginStatus: succeeded, Findings: []*detector.Finding{{Adv: &detector.Advisory{}}}},
+			wantCode: 0,
+		},
+		{
+			desc:     "Any finding",
+			failOn:   "finding",
+			result:   &scalibr.ScanResult{PluginStatus: succeeded, Findings: []*detector.Finding{{Adv: &detector.Advisory{}}}},
+			wantCode: cli.ExitCodeFinding,
+		},
+		{
+			desc:     "Lowest severity of several finding conditions",
+			failOn:   "finding:CRITICAL,finding:MEDIUM",
+			result:   &scalibr.ScanResult{PluginStatus: succeeded, Findings: []*detector.Finding{finding(detector.SeverityMedium)}},
+			wantCode: cli.ExitCodeFinding,
+		},
+		{
+			desc:     "Findings take precedence",
+			failOn:   "empty,extractor-error,finding:LOW",
+			result:   &scalibr.ScanResult{PluginStatus: extractorFailed, Findings: []*detector.Finding{finding(detector.SeverityLow)}},
+			wantCode: cli.ExitCodeFinding,
+		},
+		{
+			desc:     "Extractor errors take precedence over empty",
+			failOn:   "empty,extractor-error",
+			result:   &scalibr.ScanResult{PluginStatus: extractorFailed},
+			wantCode: cli.ExitCodeExtractorError,
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			flags := &cli.Flags{FailOn: tc.failOn}
+			gotCode, reason := flags.FailOnExitCode(cfg, tc.result)
+			if gotCode != tc.wantCode {
+				t.Errorf("%v.FailOnExitCode(%v) = %d (%s), want %d", flags, tc.result, gotCode, reason, tc.wantCode)
+			}
+		})
+	}
+}
@@ -0,0 +1,84 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	scalibr "github.com/google/osv-scalibr"
+)
+
+// validateSinceGitRef checks that --since-git-ref is used with a single
+// --root and without file arguments.
+func validateSinceGitRef(flags *Flags) error {
+	if len(flags.SinceGitRef) == 0 {
+		return nil
+	}
+	if strings.HasPrefix(flags.SinceGitRef, "-") {
+		return fmt.Errorf("--since-git-ref: invalid ref %q", flags.SinceGitRef)
+	}
+	if len(flags.Root) != 1 {
+		return errors.New("--since-git-ref needs exactly one --root")
+	}
+	if len(flags.FilesToExtract) > 0 {
+		return errors.New("--since-git-ref cannot be used together with files to extract")
+	}
+	return nil
+}
+
+// changedFiles returns the files of the git work tree at root that changed
+// between ref and the working copy, relative to root. Deleted files and files
+// outside of root are skipped. Untracked files aren't reported by git diff and
+// are thus skipped too.
+func changedFiles(root, ref string) ([]string, error) {
+	out, err := runGit(root, "rev-parse", "--is-inside-work-tree")
+	if err != nil || strings.TrimSpace(out) != "true" {
+		return nil, fmt.Errorf("%s is not a git work tree", root)
+	}
+	out, err = runGit(root, "diff", "--name-only", "-z", "--relative", "--diff-filter=d", ref, "--")
+	if err != nil {
+		return nil, err
+	}
+	var files []string
+	for _, f := range strings.Split(out, "\x00") {
+		if f != "" {
+			files = append(files, filepath.FromSlash(f))
+		}
+	}
+	return files, nil
+}
+
+// runGit runs the git command in dir and returns its stdout.
+func runGit(dir string, args ...string) (string, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command("git", append([]string{"-C", dir}, args...)...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("git %s: %w: %s", strings.Join(args, " "), err, strings.TrimSpace(stderr.String()))
+	}
+	return stdout.String(), nil
+}
+
+// NoChangedFiles returns whether --since-git-ref is set and no files changed
+// since the ref, in which case there's nothing to scan.
+func (f *Flags) NoChangedFiles(cfg *scalibr.ScanConfig) bool {
+	return len(f.SinceGitRef) > 0 && len(cfg.FilesToExtractRelative) == 0
+}
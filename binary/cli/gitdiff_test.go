@@ -0,0 +1,128 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli_test
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/osv-scalibr/binary/cli"
+)
+
+// git runs the git command in dir and fails the test on errors.
+func git(t *testing.T, dir string, args ...string) {
+	t.Helper()
+	cmd := exec.Command("git", append([]string{"-C", dir, "-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)...)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("git %v: %v\n%s", args, err, out)
+	}
+}
+
+func writeFile(t *testing.T, path string, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestGetScanConfig_SinceGitRef(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skipf("git not found: %v", err)
+	}
+	repo := t.TempDir()
+	git(t, repo, "init", "-q")
+	writeFile(t, filepath.Join(repo, "app", "package.json"), "{}")
+	writeFile(t, filepath.Join(repo, "app", "requirements.txt"), "requests==2.31.0")
+	writeFile(t, filepath.Join(repo, "app", "old.txt"), "old")
+	writeFile(t, filepath.Join(repo, "other", "go.mod"), "module other")
+	git(t, repo, "add", "-A")
+	git(t, repo, "commit", "-q", "-m", "base")
+	git(t, repo, "tag", "base")
+
+	writeFile(t, filepath.Join(repo, "app", "package.json"), `{"name": "app"}`)
+	writeFile(t, filepath.Join(repo, "app", "new", "package-lock.json"), "{}")
+	writeFile(t, filepath.Join(repo, "other", "go.mod"), "module other\n\ngo 1.22")
+	if err := os.Remove(filepath.Join(repo, "app", "old.txt")); err != nil {
+		t.Fatal(err)
+	}
+	git(t, repo, "add", "-A")
+	git(t, repo, "commit", "-q", "-m", "change")
+	// Untracked and uncommitted changes.
+	writeFile(t, filepath.Join(repo, "app", "untracked.txt"), "untracked")
+	writeFile(t, filepath.Join(repo, "app", "requirements.txt"), "requests==2.32.0")
+
+	testCases := []struct {
+		desc      string
+		root      string
+		ref       string
+		wantFiles []string
+		wantEmpty bool
+	}{
+		{
+			desc: "changes_in_subdirectory",
+			root: filepath.Join(repo, "app"),
+			ref:  "base",
+			wantFiles: []string{
+				filepath.Join("new", "package-lock.json"),
+				"package.json",
+				"requirements.txt",
+			},
+		},
+		{
+			desc:      "uncommitted_changes",
+			root:      filepath.Join(repo, "app"),
+			ref:       "HEAD",
+			wantFiles: []string{"requirements.txt"},
+		},
+		{
+			desc:      "no_changes",
+			root:      filepath.Join(repo, "other"),
+			ref:       "HEAD",
+			wantEmpty: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			flags := &cli.Flags{Root: []string{tc.root}, SinceGitRef: tc.ref}
+			cfg, err := flags.GetScanConfig()
+			if err != nil {
+				t.Fatalf("%v.GetScanConfig(): %v", flags, err)
+			}
+			if diff := cmp.Diff(tc.wantFiles, cfg.FilesToExtractRelative); diff != "" {
+				t.Errorf("%v.GetScanConfig() unexpected FilesToExtractRelative (-want +got):\n%s", flags, diff)
+			}
+			if got := flags.NoChangedFiles(cfg); got != tc.wantEmpty {
+				t.Errorf("%v.NoChangedFiles(): got %v, want %v", flags, got, tc.wantEmpty)
+			}
+		})
+	}
+}
+
+func TestGetScanConfig_SinceGitRefOutsideWorkTree(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skipf("git not found: %v", err)
+	}
+	flags := &cli.Flags{Root: []string{t.TempDir()}, SinceGitRef: "HEAD"}
+	if _, err := flags.GetScanConfig(); err == nil {
+		t.Errorf("%v.GetScanConfig(): got no error for a scan root that's not a git work tree", flags)
+	}
+}
@@ -0,0 +1,95 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// stdinListArg is the list argument value that reads the list from stdin.
+const stdinListArg = "@-"
+
+// expandListArgs replaces the list arguments that start with "@" with the
+// list read from the file they name, or from stdin for "@-". This allows
+// selecting more plugins than fit on the command line.
+func expandListArgs(flags *Flags) error {
+	args := []struct {
+		name  string
+		value *string
+	}{
+		{"--extractors", &flags.ExtractorsToRun},
+		{"--detectors", &flags.DetectorsToRun},
+		{"--skip-dirs", &flags.DirsToSkip},
+	}
+	readStdin := ""
+	for _, a := range args {
+		if *a.value == stdinListArg {
+			// Stdin can only be read once.
+			if readStdin != "" {
+				return fmt.Errorf("%s and %s cannot both be read from stdin", readStdin, a.name)
+			}
+			readStdin = a.name
+		}
+		list, err := readListArg(*a.value)
+		if err != nil {
+			return fmt.Errorf("%s: %w", a.name, err)
+		}
+		*a.value = list
+	}
+	return nil
+}
+
+// readListArg returns the comma-separated list the argument refers to. If
+// the argument starts with "@", the list is read from the named file (or
+// stdin for "@-") where entries are separated by newlines or commas. Empty
+// lines and lines starting with "#" are ignored. Other arguments are
+// returned as-is.
+func readListArg(arg string) (string, error) {
+	path, ok := strings.CutPrefix(arg, "@")
+	if !ok {
+		return arg, nil
+	}
+	if path == "" {
+		return "", errors.New("@ needs to be followed by a file path or - for stdin")
+	}
+	var content []byte
+	var err error
+	if arg == stdinListArg {
+		content, err = io.ReadAll(os.Stdin)
+	} else {
+		content, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	var items []string
+	for _, line := range strings.Split(string(content), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		for _, item := range strings.Split(line, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+	}
+	return strings.Join(items, ","), nil
+}
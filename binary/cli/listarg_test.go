@@ -0,0 +1,103 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/osv-scalibr/binary/cli"
+)
+
+func TestValidateFlags_ListArgFromFile(t *testing.T) {
+	dir := t.TempDir()
+	extractorsFile := filepath.Join(dir, "extractors.txt")
+	content := "# Language extractors\njava\npython/wheelegg, javascript/packagejson\n\n"
+	if err := os.WriteFile(extractorsFile, []byte(content), 0644); err != nil {
+		t.Fatalf("os.WriteFile(%q): %v", extractorsFile, err)
+	}
+	setStdin(t, "cis\n")
+
+	flags := &cli.Flags{
+		ResultFile:      "result.textproto",
+		ExtractorsToRun: "@" + extractorsFile,
+		DetectorsToRun:  "@-",
+		DirsToSkip:      "/tmp,/var",
+	}
+	if err := cli.ValidateFlags(flags); err != nil {
+		t.Fatalf("cli.ValidateFlags(%v): %v", flags, err)
+	}
+	if want := "java,python/wheelegg,javascript/packagejson"; flags.ExtractorsToRun != want {
+		t.Errorf("cli.ValidateFlags(): ExtractorsToRun got %q, want %q", flags.ExtractorsToRun, want)
+	}
+	if want := "cis"; flags.DetectorsToRun != want {
+		t.Errorf("cli.ValidateFlags(): DetectorsToRun got %q, want %q", flags.DetectorsToRun, want)
+	}
+	if want := "/tmp,/var"; flags.DirsToSkip != want {
+		t.Errorf("cli.ValidateFlags(): DirsToSkip got %q, want %q", flags.DirsToSkip, want)
+	}
+}
+
+func TestValidateFlags_ListArgErrors(t *testing.T) {
+	for _, tc := range []struct {
+		desc  string
+		flags *cli.Flags
+	}{
+		{
+			desc:  "No file name",
+			flags: &cli.Flags{ResultFile: "result.textproto", ExtractorsToRun: "@"},
+		},
+		{
+			desc:  "Nonexistent file",
+			flags: &cli.Flags{ResultFile: "result.textproto", DirsToSkip: "@" + filepath.Join(t.TempDir(), "nonexistent")},
+		},
+		{
+			desc:  "Stdin read twice",
+			flags: &cli.Flags{ResultFile: "result.textproto", ExtractorsToRun: "@-", DetectorsToRun: "@-"},
+		},
+		{
+			desc:  "Unknown extractor in stdin",
+			flags: &cli.Flags{ResultFile: "result.textproto", ExtractorsToRun: "@-"},
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			setStdin(t, "unknown-extractor\n")
+			if err := cli.ValidateFlags(tc.flags); err == nil {
+				t.Errorf("cli.ValidateFlags(%v): expected error, got nil", tc.flags)
+			}
+		})
+	}
+}
+
+// setStdin replaces os.Stdin with a file containing content for the duration
+// of the test.
+func setStdin(t *testing.T, content string) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "stdin")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("os.WriteFile(%q): %v", path, err)
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("os.Open(%q): %v", path, err)
+	}
+	stdin := os.Stdin
+	os.Stdin = f
+	t.Cleanup(func() {
+		os.Stdin = stdin
+		f.Close()
+	})
+}
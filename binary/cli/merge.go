@@ -0,0 +1,102 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/google/osv-scalibr/binary/proto"
+	"github.com/google/osv-scalibr/log"
+
+	spb "github.com/google/osv-scalibr/binary/proto/scan_result_go_proto"
+)
+
+// validateMerge checks that --merge is used with result files as arguments and
+// with proto outputs only. The merged result is only available as a proto so
+// it can't be converted into the other output formats.
+func validateMerge(flags *Flags) error {
+	if len(flags.FilesToExtract) == 0 {
+		return errors.New("--merge needs the scan result files to merge as arguments")
+	}
+	if flags.CountOnly || len(flags.VerifySBOM) > 0 || len(flags.Baseline) > 0 || len(flags.WhichExtractors) > 0 || flags.CoverageReport {
+		return errors.New("--merge cannot be used together with --count-only, --verify-sbom, --baseline, --which-extractors or --coverage-report")
+	}
+	if len(flags.ResultFile) == 0 && len(flags.Output) == 0 {
+		return errors.New("--merge needs --result or --o to be set")
+	}
+	if len(flags.Metadata) > 0 {
+		// The merged result keeps the metadata the merged results agree on.
+		return errors.New("--merge cannot be used together with --metadata")
+	}
+	for _, item := range flags.Output {
+		if oFormat, _, _ := strings.Cut(item, "="); !strings.Contains(oFormat, "proto") {
+			return fmt.Errorf("--merge only supports the textproto and binproto output formats, got %q", oFormat)
+		}
+	}
+	for _, r := range flags.FilesToExtract {
+		if err := validateResultPath(r); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// MergeScanResults reads the scan result files passed with --merge and merges
+// them into one. Results that don't specify the scanned host are attributed
+// to a host named after their file, e.g. "web-1" for web-1.binproto.
+func (f *Flags) MergeScanResults() (*spb.ScanResult, error) {
+	results := make([]*spb.ScanResult, 0, len(f.FilesToExtract))
+	for _, path := range f.FilesToExtract {
+		r := &spb.ScanResult{}
+		if err := proto.Read(path, r); err != nil {
+			return nil, fmt.Errorf("reading %s: %w", path, err)
+		}
+		if r.GetHost() == "" {
+			r.Host = hostFromPath(path)
+		}
+		results = append(results, r)
+	}
+	merged := proto.MergeScanResults(results)
+	log.Infof("Merged %d scan results into %d inventory items", len(results), len(merged.GetInventories()))
+	return merged, nil
+}
+
+// hostFromPath returns the file name without the result file extensions.
+func hostFromPath(path string) string {
+	name := strings.TrimSuffix(filepath.Base(path), ".gz")
+	return strings.TrimSuffix(name, filepath.Ext(name))
+}
+
+// WriteMergedResult writes a merged scan result to the proto outputs specified
+// by the CLI flags.
+func (f *Flags) WriteMergedResult(result *spb.ScanResult) error {
+	if len(f.ResultFile) > 0 {
+		log.Infof("Writing merged scan results to %s", f.ResultFile)
+		if err := proto.Write(f.ResultFile, result); err != nil {
+			return err
+		}
+	}
+	for _, item := range f.Output {
+		oFormat, oPath, _ := strings.Cut(item, "=")
+		log.Infof("Writing merged scan results to %s", oPath)
+		if err := proto.WriteWithFormat(oPath, result, oFormat); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+	"github.com/google/osv-scalibr/binary/cli"
+	"github.com/google/osv-scalibr/binary/proto"
+
+	spb "github.com/google/osv-scalibr/binary/proto/scan_result_go_proto"
+)
+
+func TestMergeScanResults(t *testing.T) {
+	dir := t.TempDir()
+	inv := func() *spb.Inventory {
+		return &spb.Inventory{
+			Name:      "curl",
+			Purl:      &spb.Purl{Purl: "pkg:deb/debian/curl@7.88.1"},
+			Locations: []string{"var/lib/dpkg/status"},
+		}
+	}
+	inputs := map[string]*spb.ScanResult{
+		"web-1.textproto": {
+			Status:      &spb.ScanStatus{Status: spb.ScanStatus_SUCCEEDED},
+			Inventories: []*spb.Inventory{inv()},
+		},
+		"web-2.binproto.gz": {
+			Status:      &spb.ScanStatus{Status: spb.ScanStatus_SUCCEEDED},
+			Inventories: []*spb.Inventory{inv()},
+		},
+		"other.binproto": {
+			Host:        "db-1",
+			Status:      &spb.ScanStatus{Status: spb.ScanStatus_SUCCEEDED},
+			Inventories: []*spb.Inventory{inv()},
+		},
+	}
+	var paths []string
+	for name, r := range inputs {
+		path := filepath.Join(dir, name)
+		if err := proto.Write(path, r); err != nil {
+			t.Fatalf("proto.Write(%s): %v", path, err)
+		}
+		paths = append(paths, path)
+	}
+
+	outPath := filepath.Join(dir, "fleet.binproto")
+	flags := &cli.Flags{Merge: true, FilesToExtract: paths, ResultFile: outPath}
+	merged, err := flags.MergeScanResults()
+	if err != nil {
+		t.Fatalf("%v.MergeScanResults(): %v", flags, err)
+	}
+	if err := flags.WriteMergedResult(merged); err != nil {
+		t.Fatalf("%v.WriteMergedResult(): %v", flags, err)
+	}
+
+	got := &spb.ScanResult{}
+	if err := proto.Read(outPath, got); err != nil {
+		t.Fatalf("proto.Read(%s): %v", outPath, err)
+	}
+	want := &spb.ScanResult{
+		Status: &spb.ScanStatus{Status: spb.ScanStatus_SUCCEEDED},
+		Inventories: []*spb.Inventory{{
+			Name:      "curl",
+			Purl:      &spb.Purl{Purl: "pkg:deb/debian/curl@7.88.1"},
+			Locations: []string{"var/lib/dpkg/status"},
+			Hosts:     []string{"db-1", "web-1", "web-2"},
+		}},
+	}
+	if diff := cmp.Diff(want, got, protocmp.Transform()); diff != "" {
+		t.Errorf("%v.MergeScanResults() unexpected merged result (-want +got):\n%s", flags, diff)
+	}
+}
@@ -0,0 +1,75 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/google/osv-scalibr/extractor/filesystem"
+	el "github.com/google/osv-scalibr/extractor/filesystem/list"
+	scalibr "github.com/google/osv-scalibr"
+)
+
+// WriteExtractorsRequiring writes the names of the registered filesystem
+// extractors whose FileRequired returns true for the file at path to w, sorted
+// by name. Used by --which-extractors instead of running a scan. Extractors
+// that aren't enabled in cfg are listed too and marked as such, since a
+// disabled extractor is a common reason for a file not being extracted.
+func WriteExtractorsRequiring(cfg *scalibr.ScanConfig, path string, w io.Writer) error {
+	enabled := map[string]bool{}
+	for _, e := range cfg.FilesystemExtractors {
+		enabled[e.Name()] = true
+	}
+	// Use the configured instances of the enabled extractors so that their
+	// plugin options are taken into account.
+	extractors := slices.Clone(cfg.FilesystemExtractors)
+	for _, e := range slices.Concat(el.All, el.Untested) {
+		if !enabled[e.Name()] {
+			extractors = append(extractors, e)
+		}
+	}
+
+	required, err := filesystem.ExtractorsRequiring(&filesystem.Config{
+		Extractors:   extractors,
+		ScanRoots:    cfg.ScanRoots,
+		ReadSymlinks: cfg.ReadSymlinks,
+	}, path)
+	if err != nil {
+		return err
+	}
+	if len(required) == 0 {
+		_, err := fmt.Fprintf(w, "No extractor requires %s\n", path)
+		return err
+	}
+	slices.SortFunc(required, func(a, b filesystem.Extractor) int {
+		return strings.Compare(a.Name(), b.Name())
+	})
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	for _, e := range required {
+		status := "enabled"
+		if !enabled[e.Name()] {
+			status = "not enabled"
+		}
+		if _, err := fmt.Fprintf(tw, "%s\t%s\n", e.Name(), status); err != nil {
+			return err
+		}
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,84 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/osv-scalibr/binary/cli"
+	"github.com/google/osv-scalibr/extractor/filesystem"
+	"github.com/google/osv-scalibr/extractor/filesystem/language/javascript/packagejson"
+	scalibrfs "github.com/google/osv-scalibr/fs"
+	scalibr "github.com/google/osv-scalibr"
+)
+
+func TestWriteExtractorsRequiring(t *testing.T) {
+	dir := t.TempDir()
+	for _, f := range []string{"package.json", "readme.md"} {
+		if err := os.WriteFile(filepath.Join(dir, f), []byte("{}"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	roots := []*scalibrfs.ScanRoot{scalibrfs.RealFSScanRoot(dir)}
+
+	testCases := []struct {
+		desc       string
+		extractors []filesystem.Extractor
+		path       string
+		want       string
+	}{
+		{
+			desc:       "enabled_extractor",
+			extractors: []filesystem.Extractor{packagejson.New(packagejson.DefaultConfig())},
+			path:       filepath.Join(dir, "package.json"),
+			want:       "javascript/packagejson  enabled\n",
+		},
+		{
+			desc: "disabled_extractor",
+			path: filepath.Join(dir, "package.json"),
+			want: "javascript/packagejson  not enabled\n",
+		},
+		{
+			desc: "no_extractor",
+			path: filepath.Join(dir, "readme.md"),
+			want: "No extractor requires " + filepath.Join(dir, "readme.md") + "\n",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			cfg := &scalibr.ScanConfig{ScanRoots: roots, FilesystemExtractors: tc.extractors}
+			var buf bytes.Buffer
+			if err := cli.WriteExtractorsRequiring(cfg, tc.path, &buf); err != nil {
+				t.Fatalf("WriteExtractorsRequiring(%s): %v", tc.path, err)
+			}
+			if diff := cmp.Diff(tc.want, buf.String()); diff != "" {
+				t.Errorf("WriteExtractorsRequiring(%s) unexpected diff (-want +got):\n%s", tc.path, diff)
+			}
+		})
+	}
+}
+
+func TestWriteExtractorsRequiring_FileOutsideScanRoot(t *testing.T) {
+	cfg := &scalibr.ScanConfig{ScanRoots: []*scalibrfs.ScanRoot{scalibrfs.RealFSScanRoot(t.TempDir())}}
+	var buf bytes.Buffer
+	if err := cli.WriteExtractorsRequiring(cfg, "/some/other/package.json", &buf); err == nil {
+		t.Errorf("WriteExtractorsRequiring() didn't return an error for a file outside the scan root")
+	}
+}
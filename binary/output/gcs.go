@@ -0,0 +1,107 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package output
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+)
+
+const (
+	gcsEndpoint = "https://storage.googleapis.com"
+	// The metadata server of GCE VMs and GKE pods, which provides the token of
+	// the attached service account.
+	gceMetadataHost = "metadata.google.internal"
+)
+
+func openGCS(ctx context.Context, u *url.URL) (io.WriteCloser, error) {
+	bucket, object := u.Host, strings.TrimPrefix(u.Path, "/")
+	return &uploadWriter{upload: func(data []byte) error {
+		if err := uploadGCS(ctx, bucket, object, data); err != nil {
+			return fmt.Errorf("failed to upload to %s: %w", u, err)
+		}
+		return nil
+	}}, nil
+}
+
+// uploadGCS uploads the data with the GCS JSON API. If STORAGE_EMULATOR_HOST
+// is set, the data is uploaded to the emulator without credentials.
+func uploadGCS(ctx context.Context, bucket, object string, data []byte) error {
+	endpoint := gcsEndpoint
+	var token string
+	if host := os.Getenv("STORAGE_EMULATOR_HOST"); host != "" {
+		endpoint = host
+		if !strings.Contains(host, "://") {
+			endpoint = "http://" + host
+		}
+	} else {
+		var err error
+		if token, err = gcsToken(ctx); err != nil {
+			return err
+		}
+	}
+
+	reqURL := fmt.Sprintf("%s/upload/storage/v1/b/%s/o?uploadType=media&name=%s",
+		strings.TrimSuffix(endpoint, "/"), url.PathEscape(bucket), url.QueryEscape(object))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, reqURL, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/octet-stream")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	return doRequest(req)
+}
+
+// gcsToken returns an OAuth2 access token from the GOOGLE_OAUTH_ACCESS_TOKEN
+// environment variable or from the metadata server.
+func gcsToken(ctx context.Context) (string, error) {
+	if token := os.Getenv("GOOGLE_OAUTH_ACCESS_TOKEN"); token != "" {
+		return token, nil
+	}
+	host := gceMetadataHost
+	if h := os.Getenv("GCE_METADATA_HOST"); h != "" {
+		host = h
+	}
+	reqURL := "http://" + host + "/computeMetadata/v1/instance/service-accounts/default/token"
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Metadata-Flavor", "Google")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("no GCS credentials found, set GOOGLE_OAUTH_ACCESS_TOKEN or run on GCE or GKE: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to get a token from the metadata server: %s", resp.Status)
+	}
+	var token struct {
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&token); err != nil {
+		return "", fmt.Errorf("failed to parse the token of the metadata server: %w", err)
+	}
+	return token.AccessToken, nil
+}
@@ -0,0 +1,147 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package output creates the files that the scan results are written to.
+// Local paths are written to the filesystem, while URLs such as
+// gs://bucket/object and s3://bucket/key are uploaded to object storage with
+// the backend registered for their scheme.
+package output
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// Opener opens a writer for the object at the given URL. The written data is
+// only guaranteed to be stored once Close returned without an error.
+type Opener func(ctx context.Context, u *url.URL) (io.WriteCloser, error)
+
+var openers = map[string]Opener{
+	"gs": openGCS,
+	"s3": openS3,
+}
+
+// Matches paths starting with a URL scheme, e.g. "gs://". Windows paths such
+// as "C:\dir" don't match.
+var urlRe = regexp.MustCompile(`^([a-zA-Z][a-zA-Z0-9+.-]*)://`)
+
+// Register makes an Opener available for the output URLs with the given
+// scheme, e.g. "azblob". Backends are usually registered from an init
+// function of the binary that wraps SCALIBR.
+func Register(scheme string, o Opener) error {
+	scheme = strings.ToLower(scheme)
+	if _, ok := openers[scheme]; ok {
+		return fmt.Errorf("there's already an output backend for the scheme %q", scheme)
+	}
+	openers[scheme] = o
+	return nil
+}
+
+// IsURL returns true if path is a URL rather than a local path.
+func IsURL(path string) bool {
+	return urlRe.MatchString(path)
+}
+
+// ValidatePath returns an error if path is a URL that can't be written to,
+// e.g. because there's no backend for its scheme.
+func ValidatePath(path string) error {
+	_, _, err := parseURL(path)
+	return err
+}
+
+// Create creates the file at path, truncating it if it already exists. If
+// path is a URL, the data is uploaded with the backend registered for its
+// scheme once the returned writer is closed. Credentials for the upload are
+// taken from the environment.
+func Create(path string) (io.WriteCloser, error) {
+	u, open, err := parseURL(path)
+	if err != nil {
+		return nil, err
+	}
+	if u == nil {
+		return os.Create(path)
+	}
+	return open(context.Background(), u)
+}
+
+// WriteFile writes data to the file at path, which can be a local path or a
+// URL as described in Create.
+func WriteFile(path string, data []byte) error {
+	w, err := Create(path)
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(data); err != nil {
+		w.Close()
+		return err
+	}
+	return w.Close()
+}
+
+// parseURL returns the URL and backend for path, or a nil URL if it's a local
+// path.
+func parseURL(path string) (*url.URL, Opener, error) {
+	m := urlRe.FindStringSubmatch(path)
+	if m == nil {
+		return nil, nil, nil
+	}
+	open, ok := openers[strings.ToLower(m[1])]
+	if !ok {
+		return nil, nil, fmt.Errorf("%s: no output backend for the URL scheme %q", path, m[1])
+	}
+	u, err := url.Parse(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if u.Host == "" || strings.Trim(u.Path, "/") == "" {
+		return nil, nil, fmt.Errorf("%s: URL should have the format %s://bucket/object", path, m[1])
+	}
+	return u, open, nil
+}
+
+// uploadWriter buffers the written data and uploads it on Close.
+type uploadWriter struct {
+	bytes.Buffer
+	upload func(data []byte) error
+	closed bool
+}
+
+func (w *uploadWriter) Close() error {
+	if w.closed {
+		return nil
+	}
+	w.closed = true
+	return w.upload(w.Bytes())
+}
+
+// doRequest sends the request and returns an error if it didn't succeed.
+func doRequest(req *http.Request) error {
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("%s %s: %s: %s", req.Method, req.URL.Redacted(), resp.Status, strings.TrimSpace(string(body)))
+	}
+	return nil
+}
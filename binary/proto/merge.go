@@ -0,0 +1,236 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proto
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+
+	spb "github.com/google/osv-scalibr/binary/proto/scan_result_go_proto"
+)
+
+// MergeScanResults combines the scan results of several hosts into one, e.g.
+// to build an inventory of a whole fleet. Inventory with the same PURL is
+// merged into a single entry which contains the locations from all hosts and
+// lists the hosts it was found on in Hosts. Plugin statuses are merged per
+// plugin name and version.
+//
+// Hosts are identified by the Host field of the results, results without one
+// are named after their position, e.g. "result-1". The Hosts of inventory in
+// already merged results are preserved so merged results can be merged again.
+// The PURL fingerprint is only kept if all results have the same one, and so
+// are the metadata entries.
+func MergeScanResults(results []*spb.ScanResult) *spb.ScanResult {
+	merged := &spb.ScanResult{
+		Status: &spb.ScanStatus{Status: spb.ScanStatus_SUCCEEDED},
+	}
+	var failures []string
+	statuses := make(map[pluginKey]*mergedStatus)
+	var pluginOrder []pluginKey
+	inventories := make(map[string]*spb.Inventory)
+	findings := make(map[string]bool)
+	mixedOSRelease := false
+	mixedPURLs := false
+
+	for idx, r := range results {
+		host := r.GetHost()
+		if host == "" {
+			host = fmt.Sprintf("result-%d", idx)
+		}
+		if merged.GetVersion() == "" {
+			merged.Version = r.GetVersion()
+		}
+		if r.GetStartTime() != nil && (merged.GetStartTime() == nil || r.GetStartTime().AsTime().Before(merged.GetStartTime().AsTime())) {
+			merged.StartTime = r.GetStartTime()
+		}
+		if r.GetEndTime() != nil && (merged.GetEndTime() == nil || r.GetEndTime().AsTime().After(merged.GetEndTime().AsTime())) {
+			merged.EndTime = r.GetEndTime()
+		}
+		if r.GetStatus().GetStatus() != spb.ScanStatus_SUCCEEDED {
+			failures = append(failures, fmt.Sprintf("%s: %s", host, r.GetStatus().GetFailureReason()))
+		}
+		if idx == 0 {
+			merged.OsRelease = r.GetOsRelease()
+			merged.PurlFingerprint = r.GetPurlFingerprint()
+			for k, v := range r.GetMetadata() {
+				if merged.Metadata == nil {
+					merged.Metadata = make(map[string]string)
+				}
+				merged.Metadata[k] = v
+			}
+		} else {
+			mixedOSRelease = mixedOSRelease || !proto.Equal(merged.GetOsRelease(), r.GetOsRelease())
+			mixedPURLs = mixedPURLs || merged.GetPurlFingerprint() != r.GetPurlFingerprint()
+			// Entries that differ between the hosts, e.g. their role, describe
+			// none of the merged result.
+			for k, v := range merged.GetMetadata() {
+				if other, ok := r.GetMetadata()[k]; !ok || other != v {
+					delete(merged.Metadata, k)
+				}
+			}
+		}
+
+		for _, s := range r.GetPluginStatus() {
+			key := pluginKey{name: s.GetName(), version: s.GetVersion()}
+			ms, ok := statuses[key]
+			if !ok {
+				ms = &mergedStatus{status: &spb.ScanStatus{}}
+				statuses[key] = ms
+				pluginOrder = append(pluginOrder, key)
+			}
+			ms.add(host, s.GetStatus())
+		}
+
+		for _, i := range r.GetInventories() {
+			hosts := i.GetHosts()
+			if len(hosts) == 0 {
+				hosts = []string{host}
+			}
+			key := inventoryKey(i)
+			m, ok := inventories[key]
+			if !ok {
+				m = proto.Clone(i).(*spb.Inventory)
+				m.Hosts = nil
+				inventories[key] = m
+				merged.Inventories = append(merged.Inventories, m)
+			}
+			if ok {
+				for _, l := range i.GetLocationDetails() {
+					if !slices.ContainsFunc(m.LocationDetails, func(ml *spb.Location) bool { return proto.Equal(ml, l) }) {
+						m.LocationDetails = append(m.LocationDetails, l)
+					}
+				}
+			}
+			m.Locations = appendMissing(m.Locations, i.GetLocations()...)
+			m.Hosts = appendMissing(m.Hosts, hosts...)
+		}
+
+		for _, f := range r.GetFindings() {
+			// Identical findings on several hosts are only reported once.
+			b, err := proto.MarshalOptions{Deterministic: true}.Marshal(f)
+			if err == nil && findings[string(b)] {
+				continue
+			}
+			findings[string(b)] = true
+			merged.Findings = append(merged.Findings, f)
+		}
+	}
+
+	if len(failures) > 0 {
+		merged.Status = &spb.ScanStatus{
+			Status:        spb.ScanStatus_FAILED,
+			FailureReason: strings.Join(failures, "\n"),
+		}
+	}
+	if mixedOSRelease {
+		// The hosts run different distributions.
+		merged.OsRelease = nil
+	}
+	if mixedPURLs {
+		// The hosts have different packages, so no single fingerprint applies.
+		merged.PurlFingerprint = ""
+	}
+	for _, key := range pluginOrder {
+		merged.PluginStatus = append(merged.PluginStatus, &spb.PluginStatus{
+			Name:    key.name,
+			Version: key.version,
+			Status:  statuses[key].result(),
+		})
+	}
+	for _, i := range merged.Inventories {
+		slices.Sort(i.Hosts)
+	}
+	return merged
+}
+
+type pluginKey struct {
+	name    string
+	version int32
+}
+
+// mergedStatus aggregates the statuses of a plugin on several hosts.
+type mergedStatus struct {
+	status         *spb.ScanStatus
+	runs           int
+	succeeded      int
+	partial        int
+	failed         int
+	skipped        int
+	failureReasons []string
+}
+
+func (m *mergedStatus) add(host string, s *spb.ScanStatus) {
+	m.runs++
+	switch s.GetStatus() {
+	case spb.ScanStatus_SUCCEEDED:
+		m.succeeded++
+	case spb.ScanStatus_PARTIALLY_SUCCEEDED:
+		m.partial++
+	case spb.ScanStatus_FAILED:
+		m.failed++
+	case spb.ScanStatus_SKIPPED:
+		m.skipped++
+	}
+	if s.GetFailureReason() != "" {
+		m.failureReasons = append(m.failureReasons, fmt.Sprintf("%s: %s", host, s.GetFailureReason()))
+	}
+	m.status.MalformedInputs += s.GetMalformedInputs()
+	m.status.Truncated = m.status.Truncated || s.GetTruncated()
+	m.status.DroppedInventory += s.GetDroppedInventory()
+	m.status.InventoryCount += s.GetInventoryCount()
+	m.status.TimedOutFiles += s.GetTimedOutFiles()
+	m.status.OversizedFiles += s.GetOversizedFiles()
+}
+
+// result returns the merged status. A plugin that failed on some hosts but not
+// on others partially succeeded. Skipped runs don't affect the status unless
+// the plugin was skipped everywhere.
+func (m *mergedStatus) result() *spb.ScanStatus {
+	switch {
+	case m.failed == m.runs:
+		m.status.Status = spb.ScanStatus_FAILED
+	case m.failed > 0 || m.partial > 0:
+		m.status.Status = spb.ScanStatus_PARTIALLY_SUCCEEDED
+	case m.succeeded > 0:
+		m.status.Status = spb.ScanStatus_SUCCEEDED
+	case m.skipped > 0:
+		m.status.Status = spb.ScanStatus_SKIPPED
+	}
+	m.status.FailureReason = strings.Join(m.failureReasons, "\n")
+	return m.status
+}
+
+// inventoryKey returns the key by which inventory is deduplicated. Inventory
+// without a PURL is only merged if it was found by the same extractor with the
+// same name and version.
+func inventoryKey(i *spb.Inventory) string {
+	if p := i.GetPurl().GetPurl(); p != "" {
+		return p
+	}
+	return strings.Join([]string{i.GetExtractor(), i.GetName(), i.GetVersion()}, "\x00")
+}
+
+// appendMissing appends the values to s that aren't already in it.
+func appendMissing(s []string, values ...string) []string {
+	for _, v := range values {
+		if !slices.Contains(s, v) {
+			s = append(s, v)
+		}
+	}
+	return s
+}
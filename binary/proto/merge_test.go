@@ -0,0 +1,186 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proto_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+	"github.com/google/osv-scalibr/binary/proto"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+	spb "github.com/google/osv-scalibr/binary/proto/scan_result_go_proto"
+)
+
+func TestMergeScanResults(t *testing.T) {
+	start := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	debian := &spb.OSRelease{Id: "debian", VersionId: "12"}
+	curl := func(locations ...string) *spb.Inventory {
+		return &spb.Inventory{
+			Name:      "curl",
+			Version:   "7.88.1",
+			Purl:      &spb.Purl{Purl: "pkg:deb/debian/curl@7.88.1", Type: "deb", Name: "curl"},
+			Extractor: "os/dpkg",
+			Locations: locations,
+		}
+	}
+	noPURL := &spb.Inventory{Name: "tool", Version: "1.0", Extractor: "misc/tool", Locations: []string{"opt/tool"}}
+	succeeded := func(count int32) *spb.ScanStatus {
+		return &spb.ScanStatus{Status: spb.ScanStatus_SUCCEEDED, InventoryCount: count}
+	}
+	finding := &spb.Finding{Adv: &spb.Advisory{Id: &spb.AdvisoryId{Reference: "CVE-2024-1234"}}}
+
+	tests := []struct {
+		desc    string
+		results []*spb.ScanResult
+		want    *spb.ScanResult
+	}{
+		{
+			desc:    "no results",
+			results: nil,
+			want:    &spb.ScanResult{Status: &spb.ScanStatus{Status: spb.ScanStatus_SUCCEEDED}},
+		},
+		{
+			desc: "inventory deduplicated by PURL",
+			results: []*spb.ScanResult{
+				{
+					Version:         "0.1.0",
+					Host:            "web-1",
+					StartTime:       timestamppb.New(start.Add(time.Minute)),
+					EndTime:         timestamppb.New(start.Add(2 * time.Minute)),
+					Status:          &spb.ScanStatus{Status: spb.ScanStatus_SUCCEEDED},
+					PluginStatus:    []*spb.PluginStatus{{Name: "os/dpkg", Version: 1, Status: succeeded(1)}},
+					Inventories:     []*spb.Inventory{curl("var/lib/dpkg/status")},
+					Findings:        []*spb.Finding{finding},
+					OsRelease:       debian,
+					PurlFingerprint: "c0b728d0",
+					Metadata:        map[string]string{"region": "europe-west1", "role": "primary"},
+				},
+				{
+					Version:         "0.1.0",
+					Host:            "web-2",
+					StartTime:       timestamppb.New(start),
+					EndTime:         timestamppb.New(start.Add(time.Minute)),
+					Status:          &spb.ScanStatus{Status: spb.ScanStatus_SUCCEEDED},
+					PluginStatus:    []*spb.PluginStatus{{Name: "os/dpkg", Version: 1, Status: succeeded(2)}},
+					Inventories:     []*spb.Inventory{curl("var/lib/dpkg/status"), noPURL},
+					Findings:        []*spb.Finding{finding},
+					OsRelease:       debian,
+					PurlFingerprint: "c0b728d0",
+					Metadata:        map[string]string{"region": "europe-west1", "role": "replica"},
+				},
+			},
+			want: &spb.ScanResult{
+				Version:      "0.1.0",
+				StartTime:    timestamppb.New(start),
+				EndTime:      timestamppb.New(start.Add(2 * time.Minute)),
+				Status:       &spb.ScanStatus{Status: spb.ScanStatus_SUCCEEDED},
+				PluginStatus: []*spb.PluginStatus{{Name: "os/dpkg", Version: 1, Status: succeeded(3)}},
+				Inventories: []*spb.Inventory{
+					{
+						Name:      "curl",
+						Version:   "7.88.1",
+						Purl:      &spb.Purl{Purl: "pkg:deb/debian/curl@7.88.1", Type: "deb", Name: "curl"},
+						Extractor: "os/dpkg",
+						Locations: []string{"var/lib/dpkg/status"},
+						Hosts:     []string{"web-1", "web-2"},
+					},
+					{Name: "tool", Version: "1.0", Extractor: "misc/tool", Locations: []string{"opt/tool"}, Hosts: []string{"web-2"}},
+				},
+				Findings:        []*spb.Finding{finding},
+				OsRelease:       debian,
+				PurlFingerprint: "c0b728d0",
+				// The role differs between the hosts.
+				Metadata: map[string]string{"region": "europe-west1"},
+			},
+		},
+		{
+			desc: "statuses merged per plugin",
+			results: []*spb.ScanResult{
+				{
+					Status: &spb.ScanStatus{Status: spb.ScanStatus_SUCCEEDED},
+					PluginStatus: []*spb.PluginStatus{
+						{Name: "os/dpkg", Version: 1, Status: &spb.ScanStatus{Status: spb.ScanStatus_FAILED, FailureReason: "permission denied"}},
+						{Name: "os/rpm", Version: 1, Status: &spb.ScanStatus{Status: spb.ScanStatus_SKIPPED, FailureReason: "needs to run on Linux"}},
+						{Name: "govulncheck", Version: 0, Status: &spb.ScanStatus{Status: spb.ScanStatus_FAILED, FailureReason: "no network"}},
+					},
+					OsRelease:       debian,
+					PurlFingerprint: "c0b728d0",
+				},
+				{
+					Status: &spb.ScanStatus{Status: spb.ScanStatus_FAILED, FailureReason: "no scan root specified"},
+					PluginStatus: []*spb.PluginStatus{
+						{Name: "os/dpkg", Version: 1, Status: &spb.ScanStatus{Status: spb.ScanStatus_SUCCEEDED, MalformedInputs: 2, Truncated: true}},
+						{Name: "os/rpm", Version: 1, Status: succeeded(0)},
+						{Name: "govulncheck", Version: 0, Status: &spb.ScanStatus{Status: spb.ScanStatus_FAILED, FailureReason: "no network"}},
+					},
+					OsRelease:       &spb.OSRelease{Id: "fedora"},
+					PurlFingerprint: "e3b0c442",
+				},
+			},
+			want: &spb.ScanResult{
+				Status: &spb.ScanStatus{Status: spb.ScanStatus_FAILED, FailureReason: "result-1: no scan root specified"},
+				PluginStatus: []*spb.PluginStatus{
+					{Name: "os/dpkg", Version: 1, Status: &spb.ScanStatus{
+						Status:          spb.ScanStatus_PARTIALLY_SUCCEEDED,
+						FailureReason:   "result-0: permission denied",
+						MalformedInputs: 2,
+						Truncated:       true,
+					}},
+					{Name: "os/rpm", Version: 1, Status: &spb.ScanStatus{
+						Status:        spb.ScanStatus_SUCCEEDED,
+						FailureReason: "result-0: needs to run on Linux",
+					}},
+					{Name: "govulncheck", Version: 0, Status: &spb.ScanStatus{
+						Status:        spb.ScanStatus_FAILED,
+						FailureReason: "result-0: no network\nresult-1: no network",
+					}},
+				},
+			},
+		},
+		{
+			desc: "hosts of merged results are kept",
+			results: []*spb.ScanResult{
+				{
+					Host:        "fleet-a",
+					Status:      &spb.ScanStatus{Status: spb.ScanStatus_SUCCEEDED},
+					Inventories: []*spb.Inventory{{Name: "curl", Purl: &spb.Purl{Purl: "pkg:deb/debian/curl"}, Hosts: []string{"web-2", "web-1"}}},
+				},
+				{
+					Host:        "db-1",
+					Status:      &spb.ScanStatus{Status: spb.ScanStatus_SUCCEEDED},
+					Inventories: []*spb.Inventory{{Name: "curl", Purl: &spb.Purl{Purl: "pkg:deb/debian/curl"}, Locations: []string{"status"}}},
+				},
+			},
+			want: &spb.ScanResult{
+				Status: &spb.ScanStatus{Status: spb.ScanStatus_SUCCEEDED},
+				Inventories: []*spb.Inventory{
+					{Name: "curl", Purl: &spb.Purl{Purl: "pkg:deb/debian/curl"}, Locations: []string{"status"}, Hosts: []string{"db-1", "web-1", "web-2"}},
+				},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := proto.MergeScanResults(tc.results)
+			if diff := cmp.Diff(tc.want, got, protocmp.Transform()); diff != "" {
+				t.Errorf("MergeScanResults() unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
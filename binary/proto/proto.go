@@ -16,29 +16,57 @@
 package proto
 
 import (
+	"bytes"
 	"compress/gzip"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"strings"
 
 	"google.golang.org/protobuf/encoding/prototext"
 	"google.golang.org/protobuf/proto"
+	"github.com/google/osv-scalibr/binary/output"
 	"github.com/google/osv-scalibr/converter"
 	"github.com/google/osv-scalibr/detector"
 	"github.com/google/osv-scalibr/log"
 
 	"github.com/google/osv-scalibr/extractor"
 	ctrdfs "github.com/google/osv-scalibr/extractor/filesystem/containers/containerd"
+	"github.com/google/osv-scalibr/extractor/filesystem/language/dotnet/nuget"
+	"github.com/google/osv-scalibr/extractor/filesystem/language/erlang/mixlock"
+	"github.com/google/osv-scalibr/extractor/filesystem/language/golang/gomod"
 	"github.com/google/osv-scalibr/extractor/filesystem/language/java/archive"
+	"github.com/google/osv-scalibr/extractor/filesystem/language/java/m2repo"
+	"github.com/google/osv-scalibr/extractor/filesystem/language/java/osgi"
 	"github.com/google/osv-scalibr/extractor/filesystem/language/javascript/packagejson"
+	"github.com/google/osv-scalibr/extractor/filesystem/language/perl/cpan"
+	"github.com/google/osv-scalibr/extractor/filesystem/language/python/pipfilelock"
 	"github.com/google/osv-scalibr/extractor/filesystem/language/python/requirements"
+	"github.com/google/osv-scalibr/extractor/filesystem/language/python/virtualenv"
 	"github.com/google/osv-scalibr/extractor/filesystem/language/python/wheelegg"
+	"github.com/google/osv-scalibr/extractor/filesystem/misc/androidapk"
+	"github.com/google/osv-scalibr/extractor/filesystem/misc/bazel"
+	"github.com/google/osv-scalibr/extractor/filesystem/misc/browserext"
+	"github.com/google/osv-scalibr/extractor/filesystem/misc/dockercompose"
+	"github.com/google/osv-scalibr/extractor/filesystem/misc/embeddedid"
+	"github.com/google/osv-scalibr/extractor/filesystem/misc/embeddedruntime"
+	"github.com/google/osv-scalibr/extractor/filesystem/misc/gitsubmodule"
+	"github.com/google/osv-scalibr/extractor/filesystem/misc/helm"
+	"github.com/google/osv-scalibr/extractor/filesystem/misc/jenkinsplugin"
+	"github.com/google/osv-scalibr/extractor/filesystem/misc/ocilayout"
+	"github.com/google/osv-scalibr/extractor/filesystem/misc/upx"
+	"github.com/google/osv-scalibr/extractor/filesystem/misc/wordpress"
+	"github.com/google/osv-scalibr/extractor/filesystem/misc/yoctomanifest"
 	"github.com/google/osv-scalibr/extractor/filesystem/os/apk"
 	"github.com/google/osv-scalibr/extractor/filesystem/os/cos"
 	"github.com/google/osv-scalibr/extractor/filesystem/os/dpkg"
 	"github.com/google/osv-scalibr/extractor/filesystem/os/flatpak"
+	"github.com/google/osv-scalibr/extractor/filesystem/os/kmod"
+	"github.com/google/osv-scalibr/extractor/filesystem/os/macapps"
+	"github.com/google/osv-scalibr/extractor/filesystem/os/macframeworks"
+	"github.com/google/osv-scalibr/extractor/filesystem/os/opkg"
 	"github.com/google/osv-scalibr/extractor/filesystem/os/rpm"
 	"github.com/google/osv-scalibr/extractor/filesystem/os/snap"
 	"github.com/google/osv-scalibr/extractor/filesystem/osv"
@@ -95,6 +123,7 @@ func ValidExtension(path string) error {
 
 // Write writes a proto message to a .textproto or .binproto file, based on the file extension.
 // If the file name additionally has the .gz suffix, it's zipped before writing.
+// The path can also be a URL such as gs://bucket/result.textproto, see output.Create.
 func Write(filePath string, outputProto proto.Message) error {
 	ft, err := typeForPath(filePath)
 	if err != nil {
@@ -126,23 +155,51 @@ func write(filePath string, outputProto proto.Message, ft *fileType) error {
 
 	log.Infof("Marshaled result proto has %d bytes", len(p))
 
-	f, err := os.Create(filePath)
-	if err != nil {
-		return err
-	}
-	defer f.Close()
 	if ft.isGZipped {
-		writer := gzip.NewWriter(f)
+		var buf bytes.Buffer
+		writer := gzip.NewWriter(&buf)
 		if _, err := writer.Write(p); err != nil {
 			return err
 		}
 		if err := writer.Close(); err != nil {
 			return err
 		}
-	} else if _, err := f.Write(p); err != nil {
+		p = buf.Bytes()
+	}
+	return output.WriteFile(filePath, p)
+}
+
+// Read reads a proto message from a .textproto or .binproto file, based on the
+// file extension. If the file name additionally has the .gz suffix, it's
+// unzipped before parsing.
+func Read(filePath string, outputProto proto.Message) error {
+	ft, err := typeForPath(filePath)
+	if err != nil {
+		return err
+	}
+	f, err := os.Open(filePath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	var reader io.Reader = f
+	if ft.isGZipped {
+		gz, err := gzip.NewReader(f)
+		if err != nil {
+			return err
+		}
+		defer gz.Close()
+		reader = gz
+	}
+	p, err := io.ReadAll(reader)
+	if err != nil {
 		return err
 	}
-	return nil
+	if ft.isBinProto {
+		return proto.Unmarshal(p, outputProto)
+	}
+	return prototext.Unmarshal(p, outputProto)
 }
 
 // ScanResultToProto converts a ScanResult go struct into the equivalent proto.
@@ -171,16 +228,43 @@ func ScanResultToProto(r *scalibr.ScanResult) (*spb.ScanResult, error) {
 	}
 
 	return &spb.ScanResult{
-		Version:      r.Version,
-		StartTime:    timestamppb.New(r.StartTime),
-		EndTime:      timestamppb.New(r.EndTime),
-		Status:       scanStatusToProto(r.Status),
-		PluginStatus: pluginStatus,
-		Inventories:  inventories,
-		Findings:     findings,
+		Version:         r.Version,
+		StartTime:       timestamppb.New(r.StartTime),
+		EndTime:         timestamppb.New(r.EndTime),
+		Status:          scanStatusToProto(r.Status),
+		PluginStatus:    pluginStatus,
+		OsRelease:       osReleaseToProto(r.OSRelease),
+		ContainerImage:  containerImageToProto(r.ContainerImage),
+		Inventories:     inventories,
+		Findings:        findings,
+		PurlFingerprint: r.PURLFingerprint,
+		Metadata:        r.Metadata,
 	}, nil
 }
 
+func containerImageToProto(i *scalibr.ContainerImage) *spb.ContainerImage {
+	if i == nil {
+		return nil
+	}
+	p := &spb.ContainerImage{Labels: i.Labels}
+	if !i.Created.IsZero() {
+		p.Created = timestamppb.New(i.Created)
+	}
+	return p
+}
+
+func osReleaseToProto(o *scalibr.OSRelease) *spb.OSRelease {
+	if o == nil {
+		return nil
+	}
+	return &spb.OSRelease{
+		Id:              o.ID,
+		VersionId:       o.VersionID,
+		VersionCodename: o.VersionCodename,
+		PrettyName:      o.PrettyName,
+	}
+}
+
 func scanStatusToProto(s *plugin.ScanStatus) *spb.ScanStatus {
 	var e spb.ScanStatus_ScanStatusEnum
 	switch s.Status {
@@ -190,10 +274,12 @@ func scanStatusToProto(s *plugin.ScanStatus) *spb.ScanStatus {
 		e = spb.ScanStatus_PARTIALLY_SUCCEEDED
 	case plugin.ScanStatusFailed:
 		e = spb.ScanStatus_FAILED
+	case plugin.ScanStatusSkipped:
+		e = spb.ScanStatus_SKIPPED
 	default:
 		e = spb.ScanStatus_UNSPECIFIED
 	}
-	return &spb.ScanStatus{Status: e, FailureReason: s.FailureReason}
+	return &spb.ScanStatus{Status: e, FailureReason: s.FailureReason, MalformedInputs: int32(s.MalformedInputs), Truncated: s.Truncated, DroppedInventory: int32(s.DroppedInventory), InventoryCount: int32(s.InventoryCount), TimedOutFiles: int32(s.TimedOutFiles), OversizedFiles: int32(s.OversizedFiles)}
 }
 
 func pluginStatusToProto(s *plugin.Status) *spb.PluginStatus {
@@ -221,15 +307,20 @@ func inventoryToProto(i *extractor.Inventory) (*spb.Inventory, error) {
 		return nil, err
 	}
 	inventoryProto := &spb.Inventory{
-		Name:        i.Name,
-		Version:     i.Version,
-		SourceCode:  sourceCodeIdentifierToProto(i.SourceCode),
-		Purl:        purlToProto(p),
-		Cpes:        cpes,
-		Ecosystem:   ecosystem,
-		Locations:   i.Locations,
-		Extractor:   i.Extractor.Name(),
-		Annotations: annotationsToProto(i.Annotations),
+		Name:            i.Name,
+		Version:         i.Version,
+		SourceCode:      sourceCodeIdentifierToProto(i.SourceCode),
+		Purl:            purlToProto(p),
+		Cpes:            cpes,
+		Ecosystem:       ecosystem,
+		Locations:       i.LocationPaths(),
+		LocationDetails: locationDetailsToProto(i.LocationDetails),
+		Extractor:       i.Extractor.Name(),
+		ScanRoot:        i.ScanRoot,
+		Annotations:     annotationsToProto(i.Annotations),
+		Relationships:   relationshipsToProto(i.Relationships),
+		Confidence:      confidenceToProto(i.Confidence),
+		Evidence:        i.Evidence,
 	}
 	setProtoMetadata(i.Metadata, inventoryProto)
 	return inventoryProto, nil
@@ -242,6 +333,7 @@ func setProtoMetadata(meta any, i *spb.Inventory) {
 			PythonMetadata: &spb.PythonPackageMetadata{
 				Author:      m.Author,
 				AuthorEmail: m.AuthorEmail,
+				Scope:       m.Scope,
 			},
 		}
 	case *packagejson.JavascriptPackageJSONMetadata:
@@ -279,6 +371,19 @@ func setProtoMetadata(meta any, i *spb.Inventory) {
 				Architecture:      m.Architecture,
 			},
 		}
+	case *opkg.Metadata:
+		i.Metadata = &spb.Inventory_OpkgMetadata{
+			OpkgMetadata: &spb.OpkgPackageMetadata{
+				PackageName:       m.PackageName,
+				PackageVersion:    m.PackageVersion,
+				Status:            m.Status,
+				Architecture:      m.Architecture,
+				AutoInstalled:     m.AutoInstalled,
+				OsId:              m.OSID,
+				OsVersionCodename: m.OSVersionCodename,
+				OsVersionId:       m.OSVersionID,
+			},
+		}
 	case *snap.Metadata:
 		i.Metadata = &spb.Inventory_SnapMetadata{
 			SnapMetadata: &spb.SNAPPackageMetadata{
@@ -292,6 +397,28 @@ func setProtoMetadata(meta any, i *spb.Inventory) {
 				OsVersionId:       m.OSVersionID,
 			},
 		}
+	case *macapps.Metadata:
+		i.Metadata = &spb.Inventory_MacAppsMetadata{
+			MacAppsMetadata: &spb.MacAppsMetadata{
+				BundleName:               m.CFBundleName,
+				BundleDisplayName:        m.CFBundleDisplayName,
+				BundleIdentifier:         m.CFBundleIdentifier,
+				BundleShortVersionString: m.CFBundleShortVersionString,
+				BundleVersion:            m.CFBundleVersion,
+				BundleExecutable:         m.CFBundleExecutable,
+			},
+		}
+	case *macframeworks.Metadata:
+		i.Metadata = &spb.Inventory_MacFrameworkMetadata{
+			MacFrameworkMetadata: &spb.MacFrameworkMetadata{
+				BundleName:               m.CFBundleName,
+				BundleIdentifier:         m.CFBundleIdentifier,
+				BundleShortVersionString: m.CFBundleShortVersionString,
+				BundleVersion:            m.CFBundleVersion,
+				BundleExecutable:         m.CFBundleExecutable,
+				BundlePath:               m.BundlePath,
+			},
+		}
 	case *rpm.Metadata:
 		i.Metadata = &spb.Inventory_RpmMetadata{
 			RpmMetadata: &spb.RPMPackageMetadata{
@@ -341,6 +468,128 @@ func setProtoMetadata(meta any, i *spb.Inventory) {
 				InitProcessPid: int32(m.InitProcessPID),
 			},
 		}
+	case *embeddedruntime.Metadata:
+		i.Metadata = &spb.Inventory_EmbeddedRuntimeMetadata{
+			EmbeddedRuntimeMetadata: &spb.EmbeddedRuntimeMetadata{
+				Evidence:   m.Evidence,
+				Confidence: string(m.Confidence),
+			},
+		}
+	case *browserext.Metadata:
+		i.Metadata = &spb.Inventory_BrowserExtensionMetadata{
+			BrowserExtensionMetadata: &spb.BrowserExtensionMetadata{
+				Id:              m.ID,
+				Browser:         m.Browser,
+				ManifestVersion: int32(m.ManifestVersion),
+			},
+		}
+	case *kmod.Metadata:
+		i.Metadata = &spb.Inventory_KernelModuleMetadata{
+			KernelModuleMetadata: &spb.KernelModuleMetadata{
+				KernelRelease: m.KernelRelease,
+				SrcVersion:    m.SrcVersion,
+				License:       m.License,
+				Description:   m.Description,
+				Depends:       m.Depends,
+			},
+		}
+	case *wordpress.Metadata:
+		i.Metadata = &spb.Inventory_WordpressMetadata{
+			WordpressMetadata: &spb.WordPressMetadata{
+				Type:              m.Type,
+				DisplayName:       m.DisplayName,
+				Author:            m.Author,
+				RequiresWordpress: m.RequiresWordPress,
+				RequiresPhp:       m.RequiresPHP,
+			},
+		}
+	case *yoctomanifest.Metadata:
+		i.Metadata = &spb.Inventory_YoctoPackageMetadata{
+			YoctoPackageMetadata: &spb.YoctoPackageMetadata{
+				RecipeName: m.RecipeName,
+				License:    m.License,
+			},
+		}
+	case *gitsubmodule.Metadata:
+		i.Metadata = &spb.Inventory_GitSubmoduleMetadata{
+			GitSubmoduleMetadata: &spb.GitSubmoduleMetadata{
+				Name:   m.Name,
+				Path:   m.Path,
+				Url:    m.URL,
+				Branch: m.Branch,
+			},
+		}
+	case *helm.Metadata:
+		i.Metadata = &spb.Inventory_HelmChartMetadata{
+			HelmChartMetadata: &spb.HelmChartMetadata{
+				AppVersion:  m.AppVersion,
+				ApiVersion:  m.APIVersion,
+				Repository:  m.Repository,
+				ParentChart: m.ParentChart,
+			},
+		}
+	case *embeddedid.Metadata:
+		i.Metadata = &spb.Inventory_EmbeddedIdMetadata{
+			EmbeddedIdMetadata: &spb.EmbeddedIdMetadata{
+				Purl: purlToProto(m.PURL),
+				Cpe:  m.CPE,
+			},
+		}
+	case *upx.Metadata:
+		i.Metadata = &spb.Inventory_UpxMetadata{
+			UpxMetadata: &spb.UPXMetadata{
+				Packed:        m.Packed,
+				HeaderMissing: m.HeaderMissing,
+				HeaderVersion: int32(m.HeaderVersion),
+				Format:        int32(m.Format),
+				Method:        m.Method,
+				Level:         int32(m.Level),
+			},
+		}
+	case *bazel.Metadata:
+		i.Metadata = &spb.Inventory_BazelDepMetadata{
+			BazelDepMetadata: &spb.BazelDepMetadata{
+				RepoName:      m.RepoName,
+				DevDependency: m.DevDependency,
+			},
+		}
+	case *dockercompose.Metadata:
+		i.Metadata = &spb.Inventory_DockerComposeMetadata{
+			DockerComposeMetadata: &spb.DockerComposeMetadata{
+				ServiceName:  m.ServiceName,
+				Repository:   m.Repository,
+				Tag:          m.Tag,
+				Digest:       m.Digest,
+				Interpolated: m.Interpolated,
+			},
+		}
+	case *androidapk.Metadata:
+		i.Metadata = &spb.Inventory_AndroidApkMetadata{
+			AndroidApkMetadata: &spb.AndroidAPKMetadata{
+				VersionName: m.VersionName,
+				VersionCode: m.VersionCode,
+			},
+		}
+	case *jenkinsplugin.Metadata:
+		i.Metadata = &spb.Inventory_JenkinsPluginMetadata{
+			JenkinsPluginMetadata: &spb.JenkinsPluginMetadata{
+				LongName:       m.LongName,
+				GroupId:        m.GroupID,
+				JenkinsVersion: m.JenkinsVersion,
+			},
+		}
+	case *ocilayout.Metadata:
+		i.Metadata = &spb.Inventory_OciLayoutImageMetadata{
+			OciLayoutImageMetadata: &spb.OCILayoutImageMetadata{
+				Repository:     m.Repository,
+				Tag:            m.Tag,
+				ManifestDigest: m.ManifestDigest,
+				IndexDigest:    m.IndexDigest,
+				Os:             m.OS,
+				Architecture:   m.Architecture,
+				Variant:        m.Variant,
+			},
+		}
 	case *ctrdruntime.Metadata:
 		i.Metadata = &spb.Inventory_ContainerdRuntimeContainerMetadata{
 			ContainerdRuntimeContainerMetadata: &spb.ContainerdRuntimeContainerMetadata{
@@ -368,6 +617,30 @@ func setProtoMetadata(meta any, i *spb.Inventory) {
 				Sha1:       m.SHA1,
 			},
 		}
+	case *m2repo.Metadata:
+		i.Metadata = &spb.Inventory_MavenRepositoryMetadata{
+			MavenRepositoryMetadata: &spb.MavenRepositoryMetadata{
+				GroupId:         m.GroupID,
+				ArtifactId:      m.ArtifactID,
+				Packaging:       m.Packaging,
+				SnapshotVersion: m.SnapshotVersion,
+				Classifiers:     m.Classifiers,
+			},
+		}
+	case *nuget.Metadata:
+		i.Metadata = &spb.Inventory_NugetMetadata{
+			NugetMetadata: &spb.NuGetMetadata{
+				DependencyType: m.DependencyType,
+			},
+		}
+	case *virtualenv.Metadata:
+		i.Metadata = &spb.Inventory_PythonVirtualenvMetadata{
+			PythonVirtualenvMetadata: &spb.PythonVirtualenvMetadata{
+				EnvironmentPath:           m.EnvironmentPath,
+				PythonVersion:             m.PythonVersion,
+				IncludeSystemSitePackages: m.IncludeSystemSitePackages,
+			},
+		}
 	case *osv.Metadata:
 		i.Metadata = &spb.Inventory_OsvMetadata{
 			OsvMetadata: &spb.OSVPackageMetadata{
@@ -377,6 +650,51 @@ func setProtoMetadata(meta any, i *spb.Inventory) {
 				CompareAs: m.CompareAs,
 			},
 		}
+	case *pipfilelock.Metadata:
+		i.Metadata = &spb.Inventory_PipfileLockMetadata{
+			PipfileLockMetadata: &spb.PipfileLockMetadata{
+				Sections: m.Sections,
+				Hashes:   m.Hashes,
+				Unpinned: m.Unpinned,
+			},
+		}
+	case *osgi.Metadata:
+		i.Metadata = &spb.Inventory_OsgiBundleMetadata{
+			OsgiBundleMetadata: &spb.OSGiBundleMetadata{
+				BundleName:   m.BundleName,
+				BundleVendor: m.BundleVendor,
+				Qualifier:    m.Qualifier,
+				FragmentHost: m.FragmentHost,
+			},
+		}
+	case *cpan.Metadata:
+		i.Metadata = &spb.Inventory_CpanMetadata{
+			CpanMetadata: &spb.CPANMetadata{
+				InstallDate:   m.InstallDate,
+				InstalledInto: m.InstalledInto,
+			},
+		}
+	case *gomod.Metadata:
+		i.Metadata = &spb.Inventory_GoModMetadata{
+			GoModMetadata: &spb.GoModMetadata{
+				Indirect:         m.Indirect,
+				RequiredPath:     m.RequiredPath,
+				RequiredVersion:  m.RequiredVersion,
+				ReplaceDir:       m.ReplaceDir,
+				Checksum:         m.Checksum,
+				MissingFromGoSum: m.MissingFromGoSum,
+			},
+		}
+	case *mixlock.Metadata:
+		i.Metadata = &spb.Inventory_MixLockMetadata{
+			MixLockMetadata: &spb.MixLockMetadata{
+				Source:   m.Source,
+				Repo:     m.Repo,
+				Checksum: m.Checksum,
+				GitUrl:   m.GitURL,
+				Commit:   m.Commit,
+			},
+		}
 	case *requirements.Metadata:
 		i.Metadata = &spb.Inventory_PythonRequirementsMetadata{
 			PythonRequirementsMetadata: &spb.PythonRequirementsMetadata{
@@ -430,12 +748,79 @@ func annotationToProto(s extractor.Annotation) spb.Inventory_AnnotationEnum {
 		e = spb.Inventory_INSIDE_OS_PACKAGE
 	case extractor.InsideCacheDir:
 		e = spb.Inventory_INSIDE_CACHE_DIR
+	case extractor.Internal:
+		e = spb.Inventory_INTERNAL
 	default:
 		e = spb.Inventory_UNSPECIFIED
 	}
 	return e
 }
 
+func confidenceToProto(c extractor.Confidence) spb.Inventory_ConfidenceEnum {
+	switch c {
+	case extractor.ConfidenceLow:
+		return spb.Inventory_CONFIDENCE_LOW
+	case extractor.ConfidenceMedium:
+		return spb.Inventory_CONFIDENCE_MEDIUM
+	case extractor.ConfidenceHigh:
+		return spb.Inventory_CONFIDENCE_HIGH
+	default:
+		return spb.Inventory_CONFIDENCE_UNSPECIFIED
+	}
+}
+
+func locationDetailsToProto(ls []*extractor.Location) []*spb.Location {
+	if ls == nil {
+		return nil
+	}
+	ps := []*spb.Location{}
+	for _, l := range ls {
+		ps = append(ps, &spb.Location{
+			Path:   l.Path,
+			Kind:   locationKindToProto(l.Kind),
+			Line:   int32(l.Line),
+			Offset: l.Offset,
+		})
+	}
+	return ps
+}
+
+func locationKindToProto(k extractor.LocationKind) spb.Location_LocationKind {
+	switch k {
+	case extractor.LocationKindManifest:
+		return spb.Location_MANIFEST
+	case extractor.LocationKindInstalled:
+		return spb.Location_INSTALLED
+	case extractor.LocationKindBinary:
+		return spb.Location_BINARY
+	default:
+		return spb.Location_UNSPECIFIED
+	}
+}
+
+func relationshipsToProto(rs []*extractor.Relationship) []*spb.Relationship {
+	if rs == nil {
+		return nil
+	}
+	ps := []*spb.Relationship{}
+	for _, r := range rs {
+		ps = append(ps, &spb.Relationship{
+			Type:       relationshipTypeToProto(r.Type),
+			TargetPurl: r.TargetPURL,
+		})
+	}
+	return ps
+}
+
+func relationshipTypeToProto(t extractor.RelationshipType) spb.Relationship_RelationshipType {
+	switch t {
+	case extractor.DependsOn:
+		return spb.Relationship_DEPENDS_ON
+	default:
+		return spb.Relationship_UNSPECIFIED
+	}
+}
+
 func sourceCodeIdentifierToProto(s *extractor.SourceCodeIdentifier) *spb.SourceCodeIdentifier {
 	if s == nil {
 		return nil
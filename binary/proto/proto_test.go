@@ -89,6 +89,27 @@ func TestWrite(t *testing.T) {
 	}
 }
 
+func TestRead(t *testing.T) {
+	testDirPath := t.TempDir()
+	want := &spb.ScanResult{Version: "1.0.0"}
+	for _, path := range []string{"output.textproto", "output.binproto", "output.binproto.gz"} {
+		t.Run(path, func(t *testing.T) {
+			fullPath := filepath.Join(testDirPath, path)
+			if err := proto.Write(fullPath, want); err != nil {
+				t.Fatalf("proto.Write(%s, %v) returned an error: %v", fullPath, want, err)
+			}
+
+			got := &spb.ScanResult{}
+			if err := proto.Read(fullPath, got); err != nil {
+				t.Fatalf("proto.Read(%s) returned an error: %v", fullPath, err)
+			}
+			if diff := cmp.Diff(want, got, protocmp.Transform()); diff != "" {
+				t.Errorf("proto.Read(%s) returned unexpected diff (-want +got):\n%s", fullPath, diff)
+			}
+		})
+	}
+}
+
 func TestWrite_InvalidFilename(t *testing.T) {
 	testDirPath := t.TempDir()
 	testPaths := []string{
@@ -183,10 +204,11 @@ func TestScanResultToProto(t *testing.T) {
 		Annotations: []extractor.Annotation{extractor.Transitional},
 	}
 	purlPythonInventory := &extractor.Inventory{
-		Name:      "software",
-		Version:   "1.0.0",
-		Locations: []string{"/file1"},
-		Extractor: wheelegg.New(wheelegg.DefaultConfig()),
+		Name:            "software",
+		Version:         "1.0.0",
+		Locations:       []string{"/file1"},
+		LocationDetails: []*extractor.Location{{Path: "/file1", Kind: extractor.LocationKindInstalled, Line: 3, Offset: 42}},
+		Extractor:       wheelegg.New(wheelegg.DefaultConfig()),
 		Metadata: &wheelegg.PythonPackageMetadata{
 			Author:      "author",
 			AuthorEmail: "author@corp.com",
@@ -288,9 +310,10 @@ func TestScanResultToProto(t *testing.T) {
 			Name:    "software",
 			Version: "1.0.0",
 		},
-		Ecosystem: "PyPI",
-		Locations: []string{"/file1"},
-		Extractor: "python/wheelegg",
+		Ecosystem:       "PyPI",
+		Locations:       []string{"/file1"},
+		LocationDetails: []*spb.Location{{Path: "/file1", Kind: spb.Location_INSTALLED, Line: 3, Offset: 42}},
+		Extractor:       "python/wheelegg",
 		Metadata: &spb.Inventory_PythonMetadata{
 			PythonMetadata: &spb.PythonPackageMetadata{
 				Author:      "author",
@@ -498,6 +521,16 @@ func TestScanResultToProto(t *testing.T) {
 						Status:  success,
 					},
 				},
+				OSRelease: &scalibr.OSRelease{
+					ID:              "debian",
+					VersionID:       "12",
+					VersionCodename: "bookworm",
+					PrettyName:      "Debian GNU/Linux 12 (bookworm)",
+				},
+				ContainerImage: &scalibr.ContainerImage{
+					Created: startTime,
+					Labels:  map[string]string{"maintainer": "team-a"},
+				},
 				Inventories: []*extractor.Inventory{
 					purlDPKGInventory,
 					purlDPKGAnnotationInventory,
@@ -548,6 +581,16 @@ func TestScanResultToProto(t *testing.T) {
 						Status:  successProto,
 					},
 				},
+				OsRelease: &spb.OSRelease{
+					Id:              "debian",
+					VersionId:       "12",
+					VersionCodename: "bookworm",
+					PrettyName:      "Debian GNU/Linux 12 (bookworm)",
+				},
+				ContainerImage: &spb.ContainerImage{
+					Created: timestamppb.New(startTime),
+					Labels:  map[string]string{"maintainer": "team-a"},
+				},
 				Inventories: []*spb.Inventory{
 					purlDPKGInventoryProto,
 					purlDPKGAnnotationInventoryProto,
@@ -13,6 +13,21 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
+//
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
 // Code generated by protoc-gen-go. DO NOT EDIT.
 // versions:
 // 	protoc-gen-go v1.33.0
@@ -43,6 +58,9 @@ const (
 	ScanStatus_SUCCEEDED           ScanStatus_ScanStatusEnum = 1
 	ScanStatus_PARTIALLY_SUCCEEDED ScanStatus_ScanStatusEnum = 2
 	ScanStatus_FAILED              ScanStatus_ScanStatusEnum = 3
+	// The plugin's requirements weren't satisfied by the scanning
+	// environment so it didn't run.
+	ScanStatus_SKIPPED ScanStatus_ScanStatusEnum = 4
 )
 
 // Enum value maps for ScanStatus_ScanStatusEnum.
@@ -52,12 +70,14 @@ var (
 		1: "SUCCEEDED",
 		2: "PARTIALLY_SUCCEEDED",
 		3: "FAILED",
+		4: "SKIPPED",
 	}
 	ScanStatus_ScanStatusEnum_value = map[string]int32{
 		"UNSPECIFIED":         0,
 		"SUCCEEDED":           1,
 		"PARTIALLY_SUCCEEDED": 2,
 		"FAILED":              3,
+		"SKIPPED":             4,
 	}
 )
 
@@ -85,7 +105,7 @@ func (x ScanStatus_ScanStatusEnum) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use ScanStatus_ScanStatusEnum.Descriptor instead.
 func (ScanStatus_ScanStatusEnum) EnumDescriptor() ([]byte, []int) {
-	return file_proto_scan_result_proto_rawDescGZIP(), []int{1, 0}
+	return file_proto_scan_result_proto_rawDescGZIP(), []int{3, 0}
 }
 
 type Inventory_AnnotationEnum int32
@@ -95,6 +115,7 @@ const (
 	Inventory_TRANSITIONAL      Inventory_AnnotationEnum = 1
 	Inventory_INSIDE_OS_PACKAGE Inventory_AnnotationEnum = 2
 	Inventory_INSIDE_CACHE_DIR  Inventory_AnnotationEnum = 3
+	Inventory_INTERNAL          Inventory_AnnotationEnum = 4
 )
 
 // Enum value maps for Inventory_AnnotationEnum.
@@ -104,12 +125,14 @@ var (
 		1: "TRANSITIONAL",
 		2: "INSIDE_OS_PACKAGE",
 		3: "INSIDE_CACHE_DIR",
+		4: "INTERNAL",
 	}
 	Inventory_AnnotationEnum_value = map[string]int32{
 		"UNSPECIFIED":       0,
 		"TRANSITIONAL":      1,
 		"INSIDE_OS_PACKAGE": 2,
 		"INSIDE_CACHE_DIR":  3,
+		"INTERNAL":          4,
 	}
 )
 
@@ -137,7 +160,157 @@ func (x Inventory_AnnotationEnum) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use Inventory_AnnotationEnum.Descriptor instead.
 func (Inventory_AnnotationEnum) EnumDescriptor() ([]byte, []int) {
-	return file_proto_scan_result_proto_rawDescGZIP(), []int{3, 0}
+	return file_proto_scan_result_proto_rawDescGZIP(), []int{5, 0}
+}
+
+type Inventory_ConfidenceEnum int32
+
+const (
+	Inventory_CONFIDENCE_UNSPECIFIED Inventory_ConfidenceEnum = 0
+	Inventory_CONFIDENCE_LOW         Inventory_ConfidenceEnum = 1
+	Inventory_CONFIDENCE_MEDIUM      Inventory_ConfidenceEnum = 2
+	Inventory_CONFIDENCE_HIGH        Inventory_ConfidenceEnum = 3
+)
+
+// Enum value maps for Inventory_ConfidenceEnum.
+var (
+	Inventory_ConfidenceEnum_name = map[int32]string{
+		0: "CONFIDENCE_UNSPECIFIED",
+		1: "CONFIDENCE_LOW",
+		2: "CONFIDENCE_MEDIUM",
+		3: "CONFIDENCE_HIGH",
+	}
+	Inventory_ConfidenceEnum_value = map[string]int32{
+		"CONFIDENCE_UNSPECIFIED": 0,
+		"CONFIDENCE_LOW":         1,
+		"CONFIDENCE_MEDIUM":      2,
+		"CONFIDENCE_HIGH":        3,
+	}
+)
+
+func (x Inventory_ConfidenceEnum) Enum() *Inventory_ConfidenceEnum {
+	p := new(Inventory_ConfidenceEnum)
+	*p = x
+	return p
+}
+
+func (x Inventory_ConfidenceEnum) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Inventory_ConfidenceEnum) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_scan_result_proto_enumTypes[2].Descriptor()
+}
+
+func (Inventory_ConfidenceEnum) Type() protoreflect.EnumType {
+	return &file_proto_scan_result_proto_enumTypes[2]
+}
+
+func (x Inventory_ConfidenceEnum) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Inventory_ConfidenceEnum.Descriptor instead.
+func (Inventory_ConfidenceEnum) EnumDescriptor() ([]byte, []int) {
+	return file_proto_scan_result_proto_rawDescGZIP(), []int{5, 1}
+}
+
+type Location_LocationKind int32
+
+const (
+	Location_UNSPECIFIED Location_LocationKind = 0
+	Location_MANIFEST    Location_LocationKind = 1
+	Location_INSTALLED   Location_LocationKind = 2
+	Location_BINARY      Location_LocationKind = 3
+)
+
+// Enum value maps for Location_LocationKind.
+var (
+	Location_LocationKind_name = map[int32]string{
+		0: "UNSPECIFIED",
+		1: "MANIFEST",
+		2: "INSTALLED",
+		3: "BINARY",
+	}
+	Location_LocationKind_value = map[string]int32{
+		"UNSPECIFIED": 0,
+		"MANIFEST":    1,
+		"INSTALLED":   2,
+		"BINARY":      3,
+	}
+)
+
+func (x Location_LocationKind) Enum() *Location_LocationKind {
+	p := new(Location_LocationKind)
+	*p = x
+	return p
+}
+
+func (x Location_LocationKind) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Location_LocationKind) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_scan_result_proto_enumTypes[3].Descriptor()
+}
+
+func (Location_LocationKind) Type() protoreflect.EnumType {
+	return &file_proto_scan_result_proto_enumTypes[3]
+}
+
+func (x Location_LocationKind) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Location_LocationKind.Descriptor instead.
+func (Location_LocationKind) EnumDescriptor() ([]byte, []int) {
+	return file_proto_scan_result_proto_rawDescGZIP(), []int{6, 0}
+}
+
+type Relationship_RelationshipType int32
+
+const (
+	Relationship_UNSPECIFIED Relationship_RelationshipType = 0
+	Relationship_DEPENDS_ON  Relationship_RelationshipType = 1
+)
+
+// Enum value maps for Relationship_RelationshipType.
+var (
+	Relationship_RelationshipType_name = map[int32]string{
+		0: "UNSPECIFIED",
+		1: "DEPENDS_ON",
+	}
+	Relationship_RelationshipType_value = map[string]int32{
+		"UNSPECIFIED": 0,
+		"DEPENDS_ON":  1,
+	}
+)
+
+func (x Relationship_RelationshipType) Enum() *Relationship_RelationshipType {
+	p := new(Relationship_RelationshipType)
+	*p = x
+	return p
+}
+
+func (x Relationship_RelationshipType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Relationship_RelationshipType) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_scan_result_proto_enumTypes[4].Descriptor()
+}
+
+func (Relationship_RelationshipType) Type() protoreflect.EnumType {
+	return &file_proto_scan_result_proto_enumTypes[4]
+}
+
+func (x Relationship_RelationshipType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Relationship_RelationshipType.Descriptor instead.
+func (Relationship_RelationshipType) EnumDescriptor() ([]byte, []int) {
+	return file_proto_scan_result_proto_rawDescGZIP(), []int{7, 0}
 }
 
 type Advisory_TypeEnum int32
@@ -173,11 +346,11 @@ func (x Advisory_TypeEnum) String() string {
 }
 
 func (Advisory_TypeEnum) Descriptor() protoreflect.EnumDescriptor {
-	return file_proto_scan_result_proto_enumTypes[2].Descriptor()
+	return file_proto_scan_result_proto_enumTypes[5].Descriptor()
 }
 
 func (Advisory_TypeEnum) Type() protoreflect.EnumType {
-	return &file_proto_scan_result_proto_enumTypes[2]
+	return &file_proto_scan_result_proto_enumTypes[5]
 }
 
 func (x Advisory_TypeEnum) Number() protoreflect.EnumNumber {
@@ -186,7 +359,7 @@ func (x Advisory_TypeEnum) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use Advisory_TypeEnum.Descriptor instead.
 func (Advisory_TypeEnum) EnumDescriptor() ([]byte, []int) {
-	return file_proto_scan_result_proto_rawDescGZIP(), []int{8, 0}
+	return file_proto_scan_result_proto_rawDescGZIP(), []int{12, 0}
 }
 
 type Severity_SeverityEnum int32
@@ -231,11 +404,11 @@ func (x Severity_SeverityEnum) String() string {
 }
 
 func (Severity_SeverityEnum) Descriptor() protoreflect.EnumDescriptor {
-	return file_proto_scan_result_proto_enumTypes[3].Descriptor()
+	return file_proto_scan_result_proto_enumTypes[6].Descriptor()
 }
 
 func (Severity_SeverityEnum) Type() protoreflect.EnumType {
-	return &file_proto_scan_result_proto_enumTypes[3]
+	return &file_proto_scan_result_proto_enumTypes[6]
 }
 
 func (x Severity_SeverityEnum) Number() protoreflect.EnumNumber {
@@ -244,7 +417,7 @@ func (x Severity_SeverityEnum) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use Severity_SeverityEnum.Descriptor instead.
 func (Severity_SeverityEnum) EnumDescriptor() ([]byte, []int) {
-	return file_proto_scan_result_proto_rawDescGZIP(), []int{10, 0}
+	return file_proto_scan_result_proto_rawDescGZIP(), []int{14, 0}
 }
 
 // The software inventory and security findings that a scan run found.
@@ -262,6 +435,18 @@ type ScanResult struct {
 	PluginStatus []*PluginStatus `protobuf:"bytes,5,rep,name=plugin_status,json=pluginStatus,proto3" json:"plugin_status,omitempty"`
 	Inventories  []*Inventory    `protobuf:"bytes,6,rep,name=inventories,proto3" json:"inventories,omitempty"`
 	Findings     []*Finding      `protobuf:"bytes,7,rep,name=findings,proto3" json:"findings,omitempty"`
+	// The distribution of the scanned system, if it has an os-release file.
+	OsRelease *OSRelease `protobuf:"bytes,8,opt,name=os_release,json=osRelease,proto3" json:"os_release,omitempty"`
+	// The name of the scanned host. Used to attribute the inventory to hosts
+	// when merging the scan results of several hosts.
+	Host string `protobuf:"bytes,9,opt,name=host,proto3" json:"host,omitempty"`
+	// The container image the scanned filesystem was composed from, if any.
+	ContainerImage *ContainerImage `protobuf:"bytes,10,opt,name=container_image,json=containerImage,proto3" json:"container_image,omitempty"`
+	// Hex-encoded SHA-256 hash of the sorted and deduplicated PURLs of the
+	// inventory. Equal fingerprints mean that the same packages were found.
+	PurlFingerprint string `protobuf:"bytes,11,opt,name=purl_fingerprint,json=purlFingerprint,proto3" json:"purl_fingerprint,omitempty"`
+	// Key-value pairs describing the scan, e.g. the role of the scanned host.
+	Metadata map[string]string `protobuf:"bytes,12,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
 }
 
 func (x *ScanResult) Reset() {
@@ -345,17 +530,54 @@ func (x *ScanResult) GetFindings() []*Finding {
 	return nil
 }
 
-type ScanStatus struct {
+func (x *ScanResult) GetOsRelease() *OSRelease {
+	if x != nil {
+		return x.OsRelease
+	}
+	return nil
+}
+
+func (x *ScanResult) GetHost() string {
+	if x != nil {
+		return x.Host
+	}
+	return ""
+}
+
+func (x *ScanResult) GetContainerImage() *ContainerImage {
+	if x != nil {
+		return x.ContainerImage
+	}
+	return nil
+}
+
+func (x *ScanResult) GetPurlFingerprint() string {
+	if x != nil {
+		return x.PurlFingerprint
+	}
+	return ""
+}
+
+func (x *ScanResult) GetMetadata() map[string]string {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+// A scanned container image, as described by its config.
+type ContainerImage struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Status        ScanStatus_ScanStatusEnum `protobuf:"varint,1,opt,name=status,proto3,enum=scalibr.ScanStatus_ScanStatusEnum" json:"status,omitempty"`
-	FailureReason string                    `protobuf:"bytes,2,opt,name=failure_reason,json=failureReason,proto3" json:"failure_reason,omitempty"`
+	Created *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=created,proto3" json:"created,omitempty"`
+	// e.g. "org.opencontainers.image.source"
+	Labels map[string]string `protobuf:"bytes,2,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
 }
 
-func (x *ScanStatus) Reset() {
-	*x = ScanStatus{}
+func (x *ContainerImage) Reset() {
+	*x = ContainerImage{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_proto_scan_result_proto_msgTypes[1]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -363,13 +585,13 @@ func (x *ScanStatus) Reset() {
 	}
 }
 
-func (x *ScanStatus) String() string {
+func (x *ContainerImage) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ScanStatus) ProtoMessage() {}
+func (*ContainerImage) ProtoMessage() {}
 
-func (x *ScanStatus) ProtoReflect() protoreflect.Message {
+func (x *ContainerImage) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_scan_result_proto_msgTypes[1]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -381,37 +603,43 @@ func (x *ScanStatus) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ScanStatus.ProtoReflect.Descriptor instead.
-func (*ScanStatus) Descriptor() ([]byte, []int) {
+// Deprecated: Use ContainerImage.ProtoReflect.Descriptor instead.
+func (*ContainerImage) Descriptor() ([]byte, []int) {
 	return file_proto_scan_result_proto_rawDescGZIP(), []int{1}
 }
 
-func (x *ScanStatus) GetStatus() ScanStatus_ScanStatusEnum {
+func (x *ContainerImage) GetCreated() *timestamppb.Timestamp {
 	if x != nil {
-		return x.Status
+		return x.Created
 	}
-	return ScanStatus_UNSPECIFIED
+	return nil
 }
 
-func (x *ScanStatus) GetFailureReason() string {
+func (x *ContainerImage) GetLabels() map[string]string {
 	if x != nil {
-		return x.FailureReason
+		return x.Labels
 	}
-	return ""
+	return nil
 }
 
-type PluginStatus struct {
+// The Linux distribution of the scanned system, read from /etc/os-release.
+type OSRelease struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Name    string      `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Version int32       `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
-	Status  *ScanStatus `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	// e.g. "debian"
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// e.g. "12"
+	VersionId string `protobuf:"bytes,2,opt,name=version_id,json=versionId,proto3" json:"version_id,omitempty"`
+	// e.g. "bookworm"
+	VersionCodename string `protobuf:"bytes,3,opt,name=version_codename,json=versionCodename,proto3" json:"version_codename,omitempty"`
+	// e.g. "Debian GNU/Linux 12 (bookworm)"
+	PrettyName string `protobuf:"bytes,4,opt,name=pretty_name,json=prettyName,proto3" json:"pretty_name,omitempty"`
 }
 
-func (x *PluginStatus) Reset() {
-	*x = PluginStatus{}
+func (x *OSRelease) Reset() {
+	*x = OSRelease{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_proto_scan_result_proto_msgTypes[2]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -419,13 +647,13 @@ func (x *PluginStatus) Reset() {
 	}
 }
 
-func (x *PluginStatus) String() string {
+func (x *OSRelease) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PluginStatus) ProtoMessage() {}
+func (*OSRelease) ProtoMessage() {}
 
-func (x *PluginStatus) ProtoReflect() protoreflect.Message {
+func (x *OSRelease) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_scan_result_proto_msgTypes[2]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -437,83 +665,68 @@ func (x *PluginStatus) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PluginStatus.ProtoReflect.Descriptor instead.
-func (*PluginStatus) Descriptor() ([]byte, []int) {
+// Deprecated: Use OSRelease.ProtoReflect.Descriptor instead.
+func (*OSRelease) Descriptor() ([]byte, []int) {
 	return file_proto_scan_result_proto_rawDescGZIP(), []int{2}
 }
 
-func (x *PluginStatus) GetName() string {
+func (x *OSRelease) GetId() string {
 	if x != nil {
-		return x.Name
+		return x.Id
 	}
 	return ""
 }
 
-func (x *PluginStatus) GetVersion() int32 {
+func (x *OSRelease) GetVersionId() string {
 	if x != nil {
-		return x.Version
+		return x.VersionId
 	}
-	return 0
+	return ""
 }
 
-func (x *PluginStatus) GetStatus() *ScanStatus {
+func (x *OSRelease) GetVersionCodename() string {
 	if x != nil {
-		return x.Status
+		return x.VersionCodename
 	}
-	return nil
+	return ""
 }
 
-// A software package or library found by an extractor.
-// PURL or CPE needs to be set, maybe both.
-type Inventory struct {
+func (x *OSRelease) GetPrettyName() string {
+	if x != nil {
+		return x.PrettyName
+	}
+	return ""
+}
+
+type ScanStatus struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// Human-readable name of the software, to be used for things like logging.
-	// For vuln matching, use the name from metadata.
-	Name string `protobuf:"bytes,11,opt,name=name,proto3" json:"name,omitempty"`
-	// Version of the package.
-	Version string `protobuf:"bytes,12,opt,name=version,proto3" json:"version,omitempty"`
-	// Source code level package identifiers.
-	SourceCode *SourceCodeIdentifier `protobuf:"bytes,26,opt,name=source_code,json=sourceCode,proto3" json:"source_code,omitempty"`
-	// Package URL of the software.
-	Purl *Purl `protobuf:"bytes,1,opt,name=purl,proto3" json:"purl,omitempty"`
-	// Common Platform Enumerator
-	// https://csrc.nist.gov/Projects/Security-Content-Automation-Protocol/Specifications/cpe
-	Cpes []string `protobuf:"bytes,4,rep,name=cpes,proto3" json:"cpes,omitempty"`
-	// Ecosystem - For software packages this corresponds to an OSV ecosystem
-	// value, e.g. PyPI.
-	Ecosystem string `protobuf:"bytes,27,opt,name=ecosystem,proto3" json:"ecosystem,omitempty"`
-	// Paths or source of files related to the package.
-	Locations []string `protobuf:"bytes,2,rep,name=locations,proto3" json:"locations,omitempty"`
-	// The name of the Extractor that found this software. Set by the
-	// core library.
-	Extractor string `protobuf:"bytes,10,opt,name=extractor,proto3" json:"extractor,omitempty"`
-	// The additional data found in the package.
-	//
-	// Types that are assignable to Metadata:
-	//
-	//	*Inventory_PythonMetadata
-	//	*Inventory_JavascriptMetadata
-	//	*Inventory_ApkMetadata
-	//	*Inventory_DpkgMetadata
-	//	*Inventory_RpmMetadata
-	//	*Inventory_CosMetadata
-	//	*Inventory_SpdxMetadata
-	//	*Inventory_JavaArchiveMetadata
-	//	*Inventory_OsvMetadata
-	//	*Inventory_PythonRequirementsMetadata
-	//	*Inventory_ContainerdContainerMetadata
-	//	*Inventory_SnapMetadata
-	//	*Inventory_FlatpakMetadata
-	//	*Inventory_ContainerdRuntimeContainerMetadata
-	Metadata    isInventory_Metadata       `protobuf_oneof:"metadata"`
-	Annotations []Inventory_AnnotationEnum `protobuf:"varint,28,rep,packed,name=annotations,proto3,enum=scalibr.Inventory_AnnotationEnum" json:"annotations,omitempty"`
+	Status        ScanStatus_ScanStatusEnum `protobuf:"varint,1,opt,name=status,proto3,enum=scalibr.ScanStatus_ScanStatusEnum" json:"status,omitempty"`
+	FailureReason string                    `protobuf:"bytes,2,opt,name=failure_reason,json=failureReason,proto3" json:"failure_reason,omitempty"`
+	// The number of files the plugin skipped because their content was
+	// malformed.
+	MalformedInputs int32 `protobuf:"varint,3,opt,name=malformed_inputs,json=malformedInputs,proto3" json:"malformed_inputs,omitempty"`
+	// Whether the plugin only saw part of its input, e.g. because the
+	// filesystem walk was stopped at the inode limit.
+	Truncated bool `protobuf:"varint,4,opt,name=truncated,proto3" json:"truncated,omitempty"`
+	// The number of inventory items dropped because the plugin exceeded the
+	// maximum inventory per extractor.
+	DroppedInventory int32 `protobuf:"varint,5,opt,name=dropped_inventory,json=droppedInventory,proto3" json:"dropped_inventory,omitempty"`
+	// The number of inventory items the plugin reported. Only set for
+	// extractors.
+	InventoryCount int32 `protobuf:"varint,6,opt,name=inventory_count,json=inventoryCount,proto3" json:"inventory_count,omitempty"`
+	// The number of files the extractor didn't finish extracting within its
+	// time limit.
+	TimedOutFiles int32 `protobuf:"varint,7,opt,name=timed_out_files,json=timedOutFiles,proto3" json:"timed_out_files,omitempty"`
+	// The number of files not passed to the extractor because they exceed its
+	// file size limit.
+	OversizedFiles int32 `protobuf:"varint,8,opt,name=oversized_files,json=oversizedFiles,proto3" json:"oversized_files,omitempty"`
 }
 
-func (x *Inventory) Reset() {
-	*x = Inventory{}
+func (x *ScanStatus) Reset() {
+	*x = ScanStatus{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_proto_scan_result_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -521,13 +734,13 @@ func (x *Inventory) Reset() {
 	}
 }
 
-func (x *Inventory) String() string {
+func (x *ScanStatus) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Inventory) ProtoMessage() {}
+func (*ScanStatus) ProtoMessage() {}
 
-func (x *Inventory) ProtoReflect() protoreflect.Message {
+func (x *ScanStatus) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_scan_result_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -539,49 +752,288 @@ func (x *Inventory) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Inventory.ProtoReflect.Descriptor instead.
-func (*Inventory) Descriptor() ([]byte, []int) {
+// Deprecated: Use ScanStatus.ProtoReflect.Descriptor instead.
+func (*ScanStatus) Descriptor() ([]byte, []int) {
 	return file_proto_scan_result_proto_rawDescGZIP(), []int{3}
 }
 
-func (x *Inventory) GetName() string {
+func (x *ScanStatus) GetStatus() ScanStatus_ScanStatusEnum {
 	if x != nil {
-		return x.Name
+		return x.Status
 	}
-	return ""
+	return ScanStatus_UNSPECIFIED
 }
 
-func (x *Inventory) GetVersion() string {
+func (x *ScanStatus) GetFailureReason() string {
 	if x != nil {
-		return x.Version
+		return x.FailureReason
 	}
 	return ""
 }
 
-func (x *Inventory) GetSourceCode() *SourceCodeIdentifier {
+func (x *ScanStatus) GetMalformedInputs() int32 {
 	if x != nil {
-		return x.SourceCode
+		return x.MalformedInputs
 	}
-	return nil
+	return 0
 }
 
-func (x *Inventory) GetPurl() *Purl {
+func (x *ScanStatus) GetTruncated() bool {
 	if x != nil {
-		return x.Purl
+		return x.Truncated
 	}
-	return nil
+	return false
 }
 
-func (x *Inventory) GetCpes() []string {
+func (x *ScanStatus) GetDroppedInventory() int32 {
 	if x != nil {
-		return x.Cpes
+		return x.DroppedInventory
 	}
-	return nil
+	return 0
 }
 
-func (x *Inventory) GetEcosystem() string {
+func (x *ScanStatus) GetInventoryCount() int32 {
 	if x != nil {
-		return x.Ecosystem
+		return x.InventoryCount
+	}
+	return 0
+}
+
+func (x *ScanStatus) GetTimedOutFiles() int32 {
+	if x != nil {
+		return x.TimedOutFiles
+	}
+	return 0
+}
+
+func (x *ScanStatus) GetOversizedFiles() int32 {
+	if x != nil {
+		return x.OversizedFiles
+	}
+	return 0
+}
+
+type PluginStatus struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name    string      `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Version int32       `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	Status  *ScanStatus `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+}
+
+func (x *PluginStatus) Reset() {
+	*x = PluginStatus{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_scan_result_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PluginStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PluginStatus) ProtoMessage() {}
+
+func (x *PluginStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_scan_result_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PluginStatus.ProtoReflect.Descriptor instead.
+func (*PluginStatus) Descriptor() ([]byte, []int) {
+	return file_proto_scan_result_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *PluginStatus) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *PluginStatus) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *PluginStatus) GetStatus() *ScanStatus {
+	if x != nil {
+		return x.Status
+	}
+	return nil
+}
+
+// A software package or library found by an extractor.
+// PURL or CPE needs to be set, maybe both.
+type Inventory struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Human-readable name of the software, to be used for things like logging.
+	// For vuln matching, use the name from metadata.
+	Name string `protobuf:"bytes,11,opt,name=name,proto3" json:"name,omitempty"`
+	// Version of the package.
+	Version string `protobuf:"bytes,12,opt,name=version,proto3" json:"version,omitempty"`
+	// Source code level package identifiers.
+	SourceCode *SourceCodeIdentifier `protobuf:"bytes,26,opt,name=source_code,json=sourceCode,proto3" json:"source_code,omitempty"`
+	// Package URL of the software.
+	Purl *Purl `protobuf:"bytes,1,opt,name=purl,proto3" json:"purl,omitempty"`
+	// Common Platform Enumerator
+	// https://csrc.nist.gov/Projects/Security-Content-Automation-Protocol/Specifications/cpe
+	Cpes []string `protobuf:"bytes,4,rep,name=cpes,proto3" json:"cpes,omitempty"`
+	// Ecosystem - For software packages this corresponds to an OSV ecosystem
+	// value, e.g. PyPI.
+	Ecosystem string `protobuf:"bytes,27,opt,name=ecosystem,proto3" json:"ecosystem,omitempty"`
+	// Paths or source of files related to the package.
+	Locations []string `protobuf:"bytes,2,rep,name=locations,proto3" json:"locations,omitempty"`
+	// Structured locations with the kind of evidence and the position of the
+	// package in the file. Their paths are also listed in locations.
+	LocationDetails []*Location `protobuf:"bytes,46,rep,name=location_details,json=locationDetails,proto3" json:"location_details,omitempty"`
+	// The name of the Extractor that found this software. Set by the
+	// core library.
+	Extractor string `protobuf:"bytes,10,opt,name=extractor,proto3" json:"extractor,omitempty"`
+	// The path of the scan root the software was found in. Empty for virtual
+	// scan roots and standalone extractors.
+	ScanRoot string `protobuf:"bytes,51,opt,name=scan_root,json=scanRoot,proto3" json:"scan_root,omitempty"`
+	// The additional data found in the package.
+	//
+	// Types that are assignable to Metadata:
+	//	*Inventory_PythonMetadata
+	//	*Inventory_JavascriptMetadata
+	//	*Inventory_ApkMetadata
+	//	*Inventory_DpkgMetadata
+	//	*Inventory_RpmMetadata
+	//	*Inventory_CosMetadata
+	//	*Inventory_SpdxMetadata
+	//	*Inventory_JavaArchiveMetadata
+	//	*Inventory_OsvMetadata
+	//	*Inventory_PythonRequirementsMetadata
+	//	*Inventory_ContainerdContainerMetadata
+	//	*Inventory_SnapMetadata
+	//	*Inventory_FlatpakMetadata
+	//	*Inventory_ContainerdRuntimeContainerMetadata
+	//	*Inventory_MacAppsMetadata
+	//	*Inventory_OciLayoutImageMetadata
+	//	*Inventory_MavenRepositoryMetadata
+	//	*Inventory_PythonVirtualenvMetadata
+	//	*Inventory_EmbeddedRuntimeMetadata
+	//	*Inventory_HelmChartMetadata
+	//	*Inventory_JenkinsPluginMetadata
+	//	*Inventory_AndroidApkMetadata
+	//	*Inventory_NugetMetadata
+	//	*Inventory_PipfileLockMetadata
+	//	*Inventory_OpkgMetadata
+	//	*Inventory_GitSubmoduleMetadata
+	//	*Inventory_BrowserExtensionMetadata
+	//	*Inventory_WordpressMetadata
+	//	*Inventory_KernelModuleMetadata
+	//	*Inventory_YoctoPackageMetadata
+	//	*Inventory_MixLockMetadata
+	//	*Inventory_DockerComposeMetadata
+	//	*Inventory_EmbeddedIdMetadata
+	//	*Inventory_CpanMetadata
+	//	*Inventory_BazelDepMetadata
+	//	*Inventory_OsgiBundleMetadata
+	//	*Inventory_UpxMetadata
+	//	*Inventory_GoModMetadata
+	//	*Inventory_MacFrameworkMetadata
+	Metadata    isInventory_Metadata       `protobuf_oneof:"metadata"`
+	Annotations []Inventory_AnnotationEnum `protobuf:"varint,28,rep,packed,name=annotations,proto3,enum=scalibr.Inventory_AnnotationEnum" json:"annotations,omitempty"`
+	// Relationships to other packages found in the same file.
+	Relationships []*Relationship `protobuf:"bytes,30,rep,name=relationships,proto3" json:"relationships,omitempty"`
+	// How reliable the extracted information, e.g. the version, is.
+	Confidence Inventory_ConfidenceEnum `protobuf:"varint,44,opt,name=confidence,proto3,enum=scalibr.Inventory_ConfidenceEnum" json:"confidence,omitempty"`
+	// What the extracted information was derived from.
+	Evidence string `protobuf:"bytes,45,opt,name=evidence,proto3" json:"evidence,omitempty"`
+	// The hosts whose scan results contain the package. Only set in merged
+	// scan results.
+	Hosts []string `protobuf:"bytes,38,rep,name=hosts,proto3" json:"hosts,omitempty"`
+}
+
+func (x *Inventory) Reset() {
+	*x = Inventory{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_scan_result_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Inventory) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Inventory) ProtoMessage() {}
+
+func (x *Inventory) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_scan_result_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Inventory.ProtoReflect.Descriptor instead.
+func (*Inventory) Descriptor() ([]byte, []int) {
+	return file_proto_scan_result_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Inventory) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Inventory) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *Inventory) GetSourceCode() *SourceCodeIdentifier {
+	if x != nil {
+		return x.SourceCode
+	}
+	return nil
+}
+
+func (x *Inventory) GetPurl() *Purl {
+	if x != nil {
+		return x.Purl
+	}
+	return nil
+}
+
+func (x *Inventory) GetCpes() []string {
+	if x != nil {
+		return x.Cpes
+	}
+	return nil
+}
+
+func (x *Inventory) GetEcosystem() string {
+	if x != nil {
+		return x.Ecosystem
 	}
 	return ""
 }
@@ -593,6 +1045,13 @@ func (x *Inventory) GetLocations() []string {
 	return nil
 }
 
+func (x *Inventory) GetLocationDetails() []*Location {
+	if x != nil {
+		return x.LocationDetails
+	}
+	return nil
+}
+
 func (x *Inventory) GetExtractor() string {
 	if x != nil {
 		return x.Extractor
@@ -600,6 +1059,13 @@ func (x *Inventory) GetExtractor() string {
 	return ""
 }
 
+func (x *Inventory) GetScanRoot() string {
+	if x != nil {
+		return x.ScanRoot
+	}
+	return ""
+}
+
 func (m *Inventory) GetMetadata() isInventory_Metadata {
 	if m != nil {
 		return m.Metadata
@@ -705,59 +1171,262 @@ func (x *Inventory) GetContainerdRuntimeContainerMetadata() *ContainerdRuntimeCo
 	return nil
 }
 
-func (x *Inventory) GetAnnotations() []Inventory_AnnotationEnum {
-	if x != nil {
-		return x.Annotations
+func (x *Inventory) GetMacAppsMetadata() *MacAppsMetadata {
+	if x, ok := x.GetMetadata().(*Inventory_MacAppsMetadata); ok {
+		return x.MacAppsMetadata
 	}
 	return nil
 }
 
-type isInventory_Metadata interface {
-	isInventory_Metadata()
+func (x *Inventory) GetOciLayoutImageMetadata() *OCILayoutImageMetadata {
+	if x, ok := x.GetMetadata().(*Inventory_OciLayoutImageMetadata); ok {
+		return x.OciLayoutImageMetadata
+	}
+	return nil
 }
 
-type Inventory_PythonMetadata struct {
-	PythonMetadata *PythonPackageMetadata `protobuf:"bytes,5,opt,name=python_metadata,json=pythonMetadata,proto3,oneof"`
+func (x *Inventory) GetMavenRepositoryMetadata() *MavenRepositoryMetadata {
+	if x, ok := x.GetMetadata().(*Inventory_MavenRepositoryMetadata); ok {
+		return x.MavenRepositoryMetadata
+	}
+	return nil
 }
 
-type Inventory_JavascriptMetadata struct {
-	JavascriptMetadata *JavascriptPackageJSONMetadata `protobuf:"bytes,6,opt,name=javascript_metadata,json=javascriptMetadata,proto3,oneof"`
+func (x *Inventory) GetPythonVirtualenvMetadata() *PythonVirtualenvMetadata {
+	if x, ok := x.GetMetadata().(*Inventory_PythonVirtualenvMetadata); ok {
+		return x.PythonVirtualenvMetadata
+	}
+	return nil
 }
 
-type Inventory_ApkMetadata struct {
-	ApkMetadata *APKPackageMetadata `protobuf:"bytes,7,opt,name=apk_metadata,json=apkMetadata,proto3,oneof"`
+func (x *Inventory) GetEmbeddedRuntimeMetadata() *EmbeddedRuntimeMetadata {
+	if x, ok := x.GetMetadata().(*Inventory_EmbeddedRuntimeMetadata); ok {
+		return x.EmbeddedRuntimeMetadata
+	}
+	return nil
 }
 
-type Inventory_DpkgMetadata struct {
-	DpkgMetadata *DPKGPackageMetadata `protobuf:"bytes,8,opt,name=dpkg_metadata,json=dpkgMetadata,proto3,oneof"`
+func (x *Inventory) GetHelmChartMetadata() *HelmChartMetadata {
+	if x, ok := x.GetMetadata().(*Inventory_HelmChartMetadata); ok {
+		return x.HelmChartMetadata
+	}
+	return nil
 }
 
-type Inventory_RpmMetadata struct {
-	RpmMetadata *RPMPackageMetadata `protobuf:"bytes,9,opt,name=rpm_metadata,json=rpmMetadata,proto3,oneof"`
+func (x *Inventory) GetJenkinsPluginMetadata() *JenkinsPluginMetadata {
+	if x, ok := x.GetMetadata().(*Inventory_JenkinsPluginMetadata); ok {
+		return x.JenkinsPluginMetadata
+	}
+	return nil
 }
 
-type Inventory_CosMetadata struct {
-	CosMetadata *COSPackageMetadata `protobuf:"bytes,13,opt,name=cos_metadata,json=cosMetadata,proto3,oneof"`
+func (x *Inventory) GetAndroidApkMetadata() *AndroidAPKMetadata {
+	if x, ok := x.GetMetadata().(*Inventory_AndroidApkMetadata); ok {
+		return x.AndroidApkMetadata
+	}
+	return nil
 }
 
-type Inventory_SpdxMetadata struct {
-	SpdxMetadata *SPDXPackageMetadata `protobuf:"bytes,14,opt,name=spdx_metadata,json=spdxMetadata,proto3,oneof"`
+func (x *Inventory) GetNugetMetadata() *NuGetMetadata {
+	if x, ok := x.GetMetadata().(*Inventory_NugetMetadata); ok {
+		return x.NugetMetadata
+	}
+	return nil
 }
 
-type Inventory_JavaArchiveMetadata struct {
-	JavaArchiveMetadata *JavaArchiveMetadata `protobuf:"bytes,15,opt,name=java_archive_metadata,json=javaArchiveMetadata,proto3,oneof"`
+func (x *Inventory) GetPipfileLockMetadata() *PipfileLockMetadata {
+	if x, ok := x.GetMetadata().(*Inventory_PipfileLockMetadata); ok {
+		return x.PipfileLockMetadata
+	}
+	return nil
 }
 
-type Inventory_OsvMetadata struct {
-	OsvMetadata *OSVPackageMetadata `protobuf:"bytes,16,opt,name=osv_metadata,json=osvMetadata,proto3,oneof"`
+func (x *Inventory) GetOpkgMetadata() *OpkgPackageMetadata {
+	if x, ok := x.GetMetadata().(*Inventory_OpkgMetadata); ok {
+		return x.OpkgMetadata
+	}
+	return nil
 }
 
-type Inventory_PythonRequirementsMetadata struct {
-	PythonRequirementsMetadata *PythonRequirementsMetadata `protobuf:"bytes,21,opt,name=python_requirements_metadata,json=pythonRequirementsMetadata,proto3,oneof"`
+func (x *Inventory) GetGitSubmoduleMetadata() *GitSubmoduleMetadata {
+	if x, ok := x.GetMetadata().(*Inventory_GitSubmoduleMetadata); ok {
+		return x.GitSubmoduleMetadata
+	}
+	return nil
 }
 
-type Inventory_ContainerdContainerMetadata struct {
-	ContainerdContainerMetadata *ContainerdContainerMetadata `protobuf:"bytes,22,opt,name=containerd_container_metadata,json=containerdContainerMetadata,proto3,oneof"`
+func (x *Inventory) GetBrowserExtensionMetadata() *BrowserExtensionMetadata {
+	if x, ok := x.GetMetadata().(*Inventory_BrowserExtensionMetadata); ok {
+		return x.BrowserExtensionMetadata
+	}
+	return nil
+}
+
+func (x *Inventory) GetWordpressMetadata() *WordPressMetadata {
+	if x, ok := x.GetMetadata().(*Inventory_WordpressMetadata); ok {
+		return x.WordpressMetadata
+	}
+	return nil
+}
+
+func (x *Inventory) GetKernelModuleMetadata() *KernelModuleMetadata {
+	if x, ok := x.GetMetadata().(*Inventory_KernelModuleMetadata); ok {
+		return x.KernelModuleMetadata
+	}
+	return nil
+}
+
+func (x *Inventory) GetYoctoPackageMetadata() *YoctoPackageMetadata {
+	if x, ok := x.GetMetadata().(*Inventory_YoctoPackageMetadata); ok {
+		return x.YoctoPackageMetadata
+	}
+	return nil
+}
+
+func (x *Inventory) GetMixLockMetadata() *MixLockMetadata {
+	if x, ok := x.GetMetadata().(*Inventory_MixLockMetadata); ok {
+		return x.MixLockMetadata
+	}
+	return nil
+}
+
+func (x *Inventory) GetDockerComposeMetadata() *DockerComposeMetadata {
+	if x, ok := x.GetMetadata().(*Inventory_DockerComposeMetadata); ok {
+		return x.DockerComposeMetadata
+	}
+	return nil
+}
+
+func (x *Inventory) GetEmbeddedIdMetadata() *EmbeddedIdMetadata {
+	if x, ok := x.GetMetadata().(*Inventory_EmbeddedIdMetadata); ok {
+		return x.EmbeddedIdMetadata
+	}
+	return nil
+}
+
+func (x *Inventory) GetCpanMetadata() *CPANMetadata {
+	if x, ok := x.GetMetadata().(*Inventory_CpanMetadata); ok {
+		return x.CpanMetadata
+	}
+	return nil
+}
+
+func (x *Inventory) GetBazelDepMetadata() *BazelDepMetadata {
+	if x, ok := x.GetMetadata().(*Inventory_BazelDepMetadata); ok {
+		return x.BazelDepMetadata
+	}
+	return nil
+}
+
+func (x *Inventory) GetOsgiBundleMetadata() *OSGiBundleMetadata {
+	if x, ok := x.GetMetadata().(*Inventory_OsgiBundleMetadata); ok {
+		return x.OsgiBundleMetadata
+	}
+	return nil
+}
+
+func (x *Inventory) GetUpxMetadata() *UPXMetadata {
+	if x, ok := x.GetMetadata().(*Inventory_UpxMetadata); ok {
+		return x.UpxMetadata
+	}
+	return nil
+}
+
+func (x *Inventory) GetGoModMetadata() *GoModMetadata {
+	if x, ok := x.GetMetadata().(*Inventory_GoModMetadata); ok {
+		return x.GoModMetadata
+	}
+	return nil
+}
+
+func (x *Inventory) GetMacFrameworkMetadata() *MacFrameworkMetadata {
+	if x, ok := x.GetMetadata().(*Inventory_MacFrameworkMetadata); ok {
+		return x.MacFrameworkMetadata
+	}
+	return nil
+}
+
+func (x *Inventory) GetAnnotations() []Inventory_AnnotationEnum {
+	if x != nil {
+		return x.Annotations
+	}
+	return nil
+}
+
+func (x *Inventory) GetRelationships() []*Relationship {
+	if x != nil {
+		return x.Relationships
+	}
+	return nil
+}
+
+func (x *Inventory) GetConfidence() Inventory_ConfidenceEnum {
+	if x != nil {
+		return x.Confidence
+	}
+	return Inventory_CONFIDENCE_UNSPECIFIED
+}
+
+func (x *Inventory) GetEvidence() string {
+	if x != nil {
+		return x.Evidence
+	}
+	return ""
+}
+
+func (x *Inventory) GetHosts() []string {
+	if x != nil {
+		return x.Hosts
+	}
+	return nil
+}
+
+type isInventory_Metadata interface {
+	isInventory_Metadata()
+}
+
+type Inventory_PythonMetadata struct {
+	PythonMetadata *PythonPackageMetadata `protobuf:"bytes,5,opt,name=python_metadata,json=pythonMetadata,proto3,oneof"`
+}
+
+type Inventory_JavascriptMetadata struct {
+	JavascriptMetadata *JavascriptPackageJSONMetadata `protobuf:"bytes,6,opt,name=javascript_metadata,json=javascriptMetadata,proto3,oneof"`
+}
+
+type Inventory_ApkMetadata struct {
+	ApkMetadata *APKPackageMetadata `protobuf:"bytes,7,opt,name=apk_metadata,json=apkMetadata,proto3,oneof"`
+}
+
+type Inventory_DpkgMetadata struct {
+	DpkgMetadata *DPKGPackageMetadata `protobuf:"bytes,8,opt,name=dpkg_metadata,json=dpkgMetadata,proto3,oneof"`
+}
+
+type Inventory_RpmMetadata struct {
+	RpmMetadata *RPMPackageMetadata `protobuf:"bytes,9,opt,name=rpm_metadata,json=rpmMetadata,proto3,oneof"`
+}
+
+type Inventory_CosMetadata struct {
+	CosMetadata *COSPackageMetadata `protobuf:"bytes,13,opt,name=cos_metadata,json=cosMetadata,proto3,oneof"`
+}
+
+type Inventory_SpdxMetadata struct {
+	SpdxMetadata *SPDXPackageMetadata `protobuf:"bytes,14,opt,name=spdx_metadata,json=spdxMetadata,proto3,oneof"`
+}
+
+type Inventory_JavaArchiveMetadata struct {
+	JavaArchiveMetadata *JavaArchiveMetadata `protobuf:"bytes,15,opt,name=java_archive_metadata,json=javaArchiveMetadata,proto3,oneof"`
+}
+
+type Inventory_OsvMetadata struct {
+	OsvMetadata *OSVPackageMetadata `protobuf:"bytes,16,opt,name=osv_metadata,json=osvMetadata,proto3,oneof"`
+}
+
+type Inventory_PythonRequirementsMetadata struct {
+	PythonRequirementsMetadata *PythonRequirementsMetadata `protobuf:"bytes,21,opt,name=python_requirements_metadata,json=pythonRequirementsMetadata,proto3,oneof"`
+}
+
+type Inventory_ContainerdContainerMetadata struct {
+	ContainerdContainerMetadata *ContainerdContainerMetadata `protobuf:"bytes,22,opt,name=containerd_container_metadata,json=containerdContainerMetadata,proto3,oneof"`
 }
 
 type Inventory_SnapMetadata struct {
@@ -772,6 +1441,106 @@ type Inventory_ContainerdRuntimeContainerMetadata struct {
 	ContainerdRuntimeContainerMetadata *ContainerdRuntimeContainerMetadata `protobuf:"bytes,25,opt,name=containerd_runtime_container_metadata,json=containerdRuntimeContainerMetadata,proto3,oneof"`
 }
 
+type Inventory_MacAppsMetadata struct {
+	MacAppsMetadata *MacAppsMetadata `protobuf:"bytes,29,opt,name=mac_apps_metadata,json=macAppsMetadata,proto3,oneof"`
+}
+
+type Inventory_OciLayoutImageMetadata struct {
+	OciLayoutImageMetadata *OCILayoutImageMetadata `protobuf:"bytes,31,opt,name=oci_layout_image_metadata,json=ociLayoutImageMetadata,proto3,oneof"`
+}
+
+type Inventory_MavenRepositoryMetadata struct {
+	MavenRepositoryMetadata *MavenRepositoryMetadata `protobuf:"bytes,32,opt,name=maven_repository_metadata,json=mavenRepositoryMetadata,proto3,oneof"`
+}
+
+type Inventory_PythonVirtualenvMetadata struct {
+	PythonVirtualenvMetadata *PythonVirtualenvMetadata `protobuf:"bytes,33,opt,name=python_virtualenv_metadata,json=pythonVirtualenvMetadata,proto3,oneof"`
+}
+
+type Inventory_EmbeddedRuntimeMetadata struct {
+	EmbeddedRuntimeMetadata *EmbeddedRuntimeMetadata `protobuf:"bytes,34,opt,name=embedded_runtime_metadata,json=embeddedRuntimeMetadata,proto3,oneof"`
+}
+
+type Inventory_HelmChartMetadata struct {
+	HelmChartMetadata *HelmChartMetadata `protobuf:"bytes,35,opt,name=helm_chart_metadata,json=helmChartMetadata,proto3,oneof"`
+}
+
+type Inventory_JenkinsPluginMetadata struct {
+	JenkinsPluginMetadata *JenkinsPluginMetadata `protobuf:"bytes,36,opt,name=jenkins_plugin_metadata,json=jenkinsPluginMetadata,proto3,oneof"`
+}
+
+type Inventory_AndroidApkMetadata struct {
+	AndroidApkMetadata *AndroidAPKMetadata `protobuf:"bytes,37,opt,name=android_apk_metadata,json=androidApkMetadata,proto3,oneof"`
+}
+
+type Inventory_NugetMetadata struct {
+	NugetMetadata *NuGetMetadata `protobuf:"bytes,39,opt,name=nuget_metadata,json=nugetMetadata,proto3,oneof"`
+}
+
+type Inventory_PipfileLockMetadata struct {
+	PipfileLockMetadata *PipfileLockMetadata `protobuf:"bytes,40,opt,name=pipfile_lock_metadata,json=pipfileLockMetadata,proto3,oneof"`
+}
+
+type Inventory_OpkgMetadata struct {
+	OpkgMetadata *OpkgPackageMetadata `protobuf:"bytes,41,opt,name=opkg_metadata,json=opkgMetadata,proto3,oneof"`
+}
+
+type Inventory_GitSubmoduleMetadata struct {
+	GitSubmoduleMetadata *GitSubmoduleMetadata `protobuf:"bytes,42,opt,name=git_submodule_metadata,json=gitSubmoduleMetadata,proto3,oneof"`
+}
+
+type Inventory_BrowserExtensionMetadata struct {
+	BrowserExtensionMetadata *BrowserExtensionMetadata `protobuf:"bytes,43,opt,name=browser_extension_metadata,json=browserExtensionMetadata,proto3,oneof"`
+}
+
+type Inventory_WordpressMetadata struct {
+	WordpressMetadata *WordPressMetadata `protobuf:"bytes,47,opt,name=wordpress_metadata,json=wordpressMetadata,proto3,oneof"`
+}
+
+type Inventory_KernelModuleMetadata struct {
+	KernelModuleMetadata *KernelModuleMetadata `protobuf:"bytes,48,opt,name=kernel_module_metadata,json=kernelModuleMetadata,proto3,oneof"`
+}
+
+type Inventory_YoctoPackageMetadata struct {
+	YoctoPackageMetadata *YoctoPackageMetadata `protobuf:"bytes,49,opt,name=yocto_package_metadata,json=yoctoPackageMetadata,proto3,oneof"`
+}
+
+type Inventory_MixLockMetadata struct {
+	MixLockMetadata *MixLockMetadata `protobuf:"bytes,50,opt,name=mix_lock_metadata,json=mixLockMetadata,proto3,oneof"`
+}
+
+type Inventory_DockerComposeMetadata struct {
+	DockerComposeMetadata *DockerComposeMetadata `protobuf:"bytes,52,opt,name=docker_compose_metadata,json=dockerComposeMetadata,proto3,oneof"`
+}
+
+type Inventory_EmbeddedIdMetadata struct {
+	EmbeddedIdMetadata *EmbeddedIdMetadata `protobuf:"bytes,53,opt,name=embedded_id_metadata,json=embeddedIdMetadata,proto3,oneof"`
+}
+
+type Inventory_CpanMetadata struct {
+	CpanMetadata *CPANMetadata `protobuf:"bytes,54,opt,name=cpan_metadata,json=cpanMetadata,proto3,oneof"`
+}
+
+type Inventory_BazelDepMetadata struct {
+	BazelDepMetadata *BazelDepMetadata `protobuf:"bytes,55,opt,name=bazel_dep_metadata,json=bazelDepMetadata,proto3,oneof"`
+}
+
+type Inventory_OsgiBundleMetadata struct {
+	OsgiBundleMetadata *OSGiBundleMetadata `protobuf:"bytes,56,opt,name=osgi_bundle_metadata,json=osgiBundleMetadata,proto3,oneof"`
+}
+
+type Inventory_UpxMetadata struct {
+	UpxMetadata *UPXMetadata `protobuf:"bytes,57,opt,name=upx_metadata,json=upxMetadata,proto3,oneof"`
+}
+
+type Inventory_GoModMetadata struct {
+	GoModMetadata *GoModMetadata `protobuf:"bytes,58,opt,name=go_mod_metadata,json=goModMetadata,proto3,oneof"`
+}
+
+type Inventory_MacFrameworkMetadata struct {
+	MacFrameworkMetadata *MacFrameworkMetadata `protobuf:"bytes,59,opt,name=mac_framework_metadata,json=macFrameworkMetadata,proto3,oneof"`
+}
+
 func (*Inventory_PythonMetadata) isInventory_Metadata() {}
 
 func (*Inventory_JavascriptMetadata) isInventory_Metadata() {}
@@ -800,33 +1569,2004 @@ func (*Inventory_FlatpakMetadata) isInventory_Metadata() {}
 
 func (*Inventory_ContainerdRuntimeContainerMetadata) isInventory_Metadata() {}
 
-// Additional identifiers for source code software packages (e.g. NPM).
-type SourceCodeIdentifier struct {
+func (*Inventory_MacAppsMetadata) isInventory_Metadata() {}
+
+func (*Inventory_OciLayoutImageMetadata) isInventory_Metadata() {}
+
+func (*Inventory_MavenRepositoryMetadata) isInventory_Metadata() {}
+
+func (*Inventory_PythonVirtualenvMetadata) isInventory_Metadata() {}
+
+func (*Inventory_EmbeddedRuntimeMetadata) isInventory_Metadata() {}
+
+func (*Inventory_HelmChartMetadata) isInventory_Metadata() {}
+
+func (*Inventory_JenkinsPluginMetadata) isInventory_Metadata() {}
+
+func (*Inventory_AndroidApkMetadata) isInventory_Metadata() {}
+
+func (*Inventory_NugetMetadata) isInventory_Metadata() {}
+
+func (*Inventory_PipfileLockMetadata) isInventory_Metadata() {}
+
+func (*Inventory_OpkgMetadata) isInventory_Metadata() {}
+
+func (*Inventory_GitSubmoduleMetadata) isInventory_Metadata() {}
+
+func (*Inventory_BrowserExtensionMetadata) isInventory_Metadata() {}
+
+func (*Inventory_WordpressMetadata) isInventory_Metadata() {}
+
+func (*Inventory_KernelModuleMetadata) isInventory_Metadata() {}
+
+func (*Inventory_YoctoPackageMetadata) isInventory_Metadata() {}
+
+func (*Inventory_MixLockMetadata) isInventory_Metadata() {}
+
+func (*Inventory_DockerComposeMetadata) isInventory_Metadata() {}
+
+func (*Inventory_EmbeddedIdMetadata) isInventory_Metadata() {}
+
+func (*Inventory_CpanMetadata) isInventory_Metadata() {}
+
+func (*Inventory_BazelDepMetadata) isInventory_Metadata() {}
+
+func (*Inventory_OsgiBundleMetadata) isInventory_Metadata() {}
+
+func (*Inventory_UpxMetadata) isInventory_Metadata() {}
+
+func (*Inventory_GoModMetadata) isInventory_Metadata() {}
+
+func (*Inventory_MacFrameworkMetadata) isInventory_Metadata() {}
+
+// A structured location of a package.
+type Location struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Path string                `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	Kind Location_LocationKind `protobuf:"varint,2,opt,name=kind,proto3,enum=scalibr.Location_LocationKind" json:"kind,omitempty"`
+	// The 1-based line of the package's entry in the file, 0 if unknown.
+	Line int32 `protobuf:"varint,3,opt,name=line,proto3" json:"line,omitempty"`
+	// The byte offset of the package's entry in the file.
+	Offset int64 `protobuf:"varint,4,opt,name=offset,proto3" json:"offset,omitempty"`
+}
+
+func (x *Location) Reset() {
+	*x = Location{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_scan_result_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Location) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Location) ProtoMessage() {}
+
+func (x *Location) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_scan_result_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Location.ProtoReflect.Descriptor instead.
+func (*Location) Descriptor() ([]byte, []int) {
+	return file_proto_scan_result_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *Location) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *Location) GetKind() Location_LocationKind {
+	if x != nil {
+		return x.Kind
+	}
+	return Location_UNSPECIFIED
+}
+
+func (x *Location) GetLine() int32 {
+	if x != nil {
+		return x.Line
+	}
+	return 0
+}
+
+func (x *Location) GetOffset() int64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+// A directed edge between two packages.
+type Relationship struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Type Relationship_RelationshipType `protobuf:"varint,1,opt,name=type,proto3,enum=scalibr.Relationship_RelationshipType" json:"type,omitempty"`
+	// Package URL of the target package.
+	TargetPurl string `protobuf:"bytes,2,opt,name=target_purl,json=targetPurl,proto3" json:"target_purl,omitempty"`
+}
+
+func (x *Relationship) Reset() {
+	*x = Relationship{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_scan_result_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Relationship) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Relationship) ProtoMessage() {}
+
+func (x *Relationship) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_scan_result_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Relationship.ProtoReflect.Descriptor instead.
+func (*Relationship) Descriptor() ([]byte, []int) {
+	return file_proto_scan_result_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *Relationship) GetType() Relationship_RelationshipType {
+	if x != nil {
+		return x.Type
+	}
+	return Relationship_UNSPECIFIED
+}
+
+func (x *Relationship) GetTargetPurl() string {
+	if x != nil {
+		return x.TargetPurl
+	}
+	return ""
+}
+
+// Additional identifiers for source code software packages (e.g. NPM).
+type SourceCodeIdentifier struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Repo   string `protobuf:"bytes,1,opt,name=repo,proto3" json:"repo,omitempty"`
+	Commit string `protobuf:"bytes,2,opt,name=commit,proto3" json:"commit,omitempty"`
+}
+
+func (x *SourceCodeIdentifier) Reset() {
+	*x = SourceCodeIdentifier{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_scan_result_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SourceCodeIdentifier) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SourceCodeIdentifier) ProtoMessage() {}
+
+func (x *SourceCodeIdentifier) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_scan_result_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SourceCodeIdentifier.ProtoReflect.Descriptor instead.
+func (*SourceCodeIdentifier) Descriptor() ([]byte, []int) {
+	return file_proto_scan_result_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *SourceCodeIdentifier) GetRepo() string {
+	if x != nil {
+		return x.Repo
+	}
+	return ""
+}
+
+func (x *SourceCodeIdentifier) GetCommit() string {
+	if x != nil {
+		return x.Commit
+	}
+	return ""
+}
+
+// Package URL, see https://github.com/package-url/purl-spec
+type Purl struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// String representation.
+	Purl string `protobuf:"bytes,1,opt,name=purl,proto3" json:"purl,omitempty"`
+	// Package type, e.g. "maven, npm, pypi".
+	Type string `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	// Package name.
+	Name string `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	// Package version.
+	Version string `protobuf:"bytes,4,opt,name=version,proto3" json:"version,omitempty"`
+	// Name prefix such as a Maven groupid, or Docker image owner.
+	Namespace string `protobuf:"bytes,5,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	// Extra qualifying data for a package such as an OS, architecture, etc.
+	Qualifiers []*Qualifier `protobuf:"bytes,6,rep,name=qualifiers,proto3" json:"qualifiers,omitempty"`
+	// Extra subpath within a package, relative to the package root.
+	Subpath string `protobuf:"bytes,7,opt,name=subpath,proto3" json:"subpath,omitempty"`
+}
+
+func (x *Purl) Reset() {
+	*x = Purl{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_scan_result_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Purl) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Purl) ProtoMessage() {}
+
+func (x *Purl) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_scan_result_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Purl.ProtoReflect.Descriptor instead.
+func (*Purl) Descriptor() ([]byte, []int) {
+	return file_proto_scan_result_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *Purl) GetPurl() string {
+	if x != nil {
+		return x.Purl
+	}
+	return ""
+}
+
+func (x *Purl) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Purl) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Purl) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *Purl) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *Purl) GetQualifiers() []*Qualifier {
+	if x != nil {
+		return x.Qualifiers
+	}
+	return nil
+}
+
+func (x *Purl) GetSubpath() string {
+	if x != nil {
+		return x.Subpath
+	}
+	return ""
+}
+
+type Qualifier struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Key   string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Value string `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+}
+
+func (x *Qualifier) Reset() {
+	*x = Qualifier{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_scan_result_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Qualifier) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Qualifier) ProtoMessage() {}
+
+func (x *Qualifier) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_scan_result_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Qualifier.ProtoReflect.Descriptor instead.
+func (*Qualifier) Descriptor() ([]byte, []int) {
+	return file_proto_scan_result_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *Qualifier) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *Qualifier) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+// A security finding found by a detector. It could describe things like a CVE
+// or a CIS non-compliance.
+type Finding struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Info specific to the finding. Should always be the same for the same type
+	// of finding.
+	Adv *Advisory `protobuf:"bytes,1,opt,name=adv,proto3" json:"adv,omitempty"`
+	// Instance-specific info such as location of the vulnerable files.
+	Target *TargetDetails `protobuf:"bytes,2,opt,name=target,proto3" json:"target,omitempty"`
+	// Additional free-text info.
+	Extra string `protobuf:"bytes,3,opt,name=extra,proto3" json:"extra,omitempty"`
+	// The name of the Detectors that found this finding. Set by the core library.
+	Detectors []string `protobuf:"bytes,4,rep,name=detectors,proto3" json:"detectors,omitempty"`
+}
+
+func (x *Finding) Reset() {
+	*x = Finding{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_scan_result_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Finding) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Finding) ProtoMessage() {}
+
+func (x *Finding) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_scan_result_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Finding.ProtoReflect.Descriptor instead.
+func (*Finding) Descriptor() ([]byte, []int) {
+	return file_proto_scan_result_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *Finding) GetAdv() *Advisory {
+	if x != nil {
+		return x.Adv
+	}
+	return nil
+}
+
+func (x *Finding) GetTarget() *TargetDetails {
+	if x != nil {
+		return x.Target
+	}
+	return nil
+}
+
+func (x *Finding) GetExtra() string {
+	if x != nil {
+		return x.Extra
+	}
+	return ""
+}
+
+func (x *Finding) GetDetectors() []string {
+	if x != nil {
+		return x.Detectors
+	}
+	return nil
+}
+
+type Advisory struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// A unique ID for the finding.
+	Id          *AdvisoryId       `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Type        Advisory_TypeEnum `protobuf:"varint,2,opt,name=type,proto3,enum=scalibr.Advisory_TypeEnum" json:"type,omitempty"`
+	Title       string            `protobuf:"bytes,3,opt,name=title,proto3" json:"title,omitempty"`
+	Description string            `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	// Remediation instructions, e.g. "update to latest version".
+	Recommendation string    `protobuf:"bytes,5,opt,name=recommendation,proto3" json:"recommendation,omitempty"`
+	Sev            *Severity `protobuf:"bytes,6,opt,name=sev,proto3" json:"sev,omitempty"`
+}
+
+func (x *Advisory) Reset() {
+	*x = Advisory{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_scan_result_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Advisory) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Advisory) ProtoMessage() {}
+
+func (x *Advisory) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_scan_result_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Advisory.ProtoReflect.Descriptor instead.
+func (*Advisory) Descriptor() ([]byte, []int) {
+	return file_proto_scan_result_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *Advisory) GetId() *AdvisoryId {
+	if x != nil {
+		return x.Id
+	}
+	return nil
+}
+
+func (x *Advisory) GetType() Advisory_TypeEnum {
+	if x != nil {
+		return x.Type
+	}
+	return Advisory_UNKNOWN
+}
+
+func (x *Advisory) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *Advisory) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Advisory) GetRecommendation() string {
+	if x != nil {
+		return x.Recommendation
+	}
+	return ""
+}
+
+func (x *Advisory) GetSev() *Severity {
+	if x != nil {
+		return x.Sev
+	}
+	return nil
+}
+
+// A unique identifier per advisory.
+type AdvisoryId struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Publisher string `protobuf:"bytes,1,opt,name=publisher,proto3" json:"publisher,omitempty"` // e.g. "CVE".
+	Reference string `protobuf:"bytes,2,opt,name=reference,proto3" json:"reference,omitempty"` // e.g. "CVE-2023-1234".
+}
+
+func (x *AdvisoryId) Reset() {
+	*x = AdvisoryId{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_scan_result_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AdvisoryId) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdvisoryId) ProtoMessage() {}
+
+func (x *AdvisoryId) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_scan_result_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AdvisoryId.ProtoReflect.Descriptor instead.
+func (*AdvisoryId) Descriptor() ([]byte, []int) {
+	return file_proto_scan_result_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *AdvisoryId) GetPublisher() string {
+	if x != nil {
+		return x.Publisher
+	}
+	return ""
+}
+
+func (x *AdvisoryId) GetReference() string {
+	if x != nil {
+		return x.Reference
+	}
+	return ""
+}
+
+type Severity struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Required severity enum. Can be used for e.g. prioritizing filed bugs.
+	Severity Severity_SeverityEnum `protobuf:"varint,1,opt,name=severity,proto3,enum=scalibr.Severity_SeverityEnum" json:"severity,omitempty"`
+	// Optional CVSS scores, only set for vulns with CVEs.
+	CvssV2 *CVSS `protobuf:"bytes,2,opt,name=cvss_v2,json=cvssV2,proto3" json:"cvss_v2,omitempty"`
+	CvssV3 *CVSS `protobuf:"bytes,3,opt,name=cvss_v3,json=cvssV3,proto3" json:"cvss_v3,omitempty"`
+}
+
+func (x *Severity) Reset() {
+	*x = Severity{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_scan_result_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Severity) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Severity) ProtoMessage() {}
+
+func (x *Severity) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_scan_result_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Severity.ProtoReflect.Descriptor instead.
+func (*Severity) Descriptor() ([]byte, []int) {
+	return file_proto_scan_result_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *Severity) GetSeverity() Severity_SeverityEnum {
+	if x != nil {
+		return x.Severity
+	}
+	return Severity_UNSPECIFIED
+}
+
+func (x *Severity) GetCvssV2() *CVSS {
+	if x != nil {
+		return x.CvssV2
+	}
+	return nil
+}
+
+func (x *Severity) GetCvssV3() *CVSS {
+	if x != nil {
+		return x.CvssV3
+	}
+	return nil
+}
+
+type CVSS struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	BaseScore          float32 `protobuf:"fixed32,1,opt,name=base_score,json=baseScore,proto3" json:"base_score,omitempty"`
+	TemporalScore      float32 `protobuf:"fixed32,2,opt,name=temporal_score,json=temporalScore,proto3" json:"temporal_score,omitempty"`
+	EnvironmentalScore float32 `protobuf:"fixed32,3,opt,name=environmental_score,json=environmentalScore,proto3" json:"environmental_score,omitempty"`
+}
+
+func (x *CVSS) Reset() {
+	*x = CVSS{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_scan_result_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CVSS) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CVSS) ProtoMessage() {}
+
+func (x *CVSS) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_scan_result_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CVSS.ProtoReflect.Descriptor instead.
+func (*CVSS) Descriptor() ([]byte, []int) {
+	return file_proto_scan_result_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *CVSS) GetBaseScore() float32 {
+	if x != nil {
+		return x.BaseScore
+	}
+	return 0
+}
+
+func (x *CVSS) GetTemporalScore() float32 {
+	if x != nil {
+		return x.TemporalScore
+	}
+	return 0
+}
+
+func (x *CVSS) GetEnvironmentalScore() float32 {
+	if x != nil {
+		return x.EnvironmentalScore
+	}
+	return 0
+}
+
+type TargetDetails struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The software affected by the finding.
+	Inventory *Inventory `protobuf:"bytes,1,opt,name=inventory,proto3" json:"inventory,omitempty"`
+	// Location of vulnerable files not related to the inventory,
+	// e.g. config files with misconfigurations.
+	Location []string `protobuf:"bytes,3,rep,name=location,proto3" json:"location,omitempty"`
+}
+
+func (x *TargetDetails) Reset() {
+	*x = TargetDetails{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_scan_result_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TargetDetails) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TargetDetails) ProtoMessage() {}
+
+func (x *TargetDetails) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_scan_result_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TargetDetails.ProtoReflect.Descriptor instead.
+func (*TargetDetails) Descriptor() ([]byte, []int) {
+	return file_proto_scan_result_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *TargetDetails) GetInventory() *Inventory {
+	if x != nil {
+		return x.Inventory
+	}
+	return nil
+}
+
+func (x *TargetDetails) GetLocation() []string {
+	if x != nil {
+		return x.Location
+	}
+	return nil
+}
+
+// The additional data found in python packages.
+type PythonPackageMetadata struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Author      string `protobuf:"bytes,1,opt,name=author,proto3" json:"author,omitempty"`
+	AuthorEmail string `protobuf:"bytes,2,opt,name=author_email,json=authorEmail,proto3" json:"author_email,omitempty"`
+	// "system", "user" or "virtualenv". Empty if the package isn't installed
+	// into a site-packages directory.
+	Scope string `protobuf:"bytes,3,opt,name=scope,proto3" json:"scope,omitempty"`
+}
+
+func (x *PythonPackageMetadata) Reset() {
+	*x = PythonPackageMetadata{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_scan_result_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PythonPackageMetadata) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PythonPackageMetadata) ProtoMessage() {}
+
+func (x *PythonPackageMetadata) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_scan_result_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PythonPackageMetadata.ProtoReflect.Descriptor instead.
+func (*PythonPackageMetadata) Descriptor() ([]byte, []int) {
+	return file_proto_scan_result_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *PythonPackageMetadata) GetAuthor() string {
+	if x != nil {
+		return x.Author
+	}
+	return ""
+}
+
+func (x *PythonPackageMetadata) GetAuthorEmail() string {
+	if x != nil {
+		return x.AuthorEmail
+	}
+	return ""
+}
+
+func (x *PythonPackageMetadata) GetScope() string {
+	if x != nil {
+		return x.Scope
+	}
+	return ""
+}
+
+// The additional data found in npm packages.
+type JavascriptPackageJSONMetadata struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Author       string   `protobuf:"bytes,1,opt,name=author,proto3" json:"author,omitempty"`
+	Maintainers  []string `protobuf:"bytes,2,rep,name=maintainers,proto3" json:"maintainers,omitempty"`
+	Contributors []string `protobuf:"bytes,3,rep,name=contributors,proto3" json:"contributors,omitempty"`
+}
+
+func (x *JavascriptPackageJSONMetadata) Reset() {
+	*x = JavascriptPackageJSONMetadata{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_scan_result_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *JavascriptPackageJSONMetadata) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JavascriptPackageJSONMetadata) ProtoMessage() {}
+
+func (x *JavascriptPackageJSONMetadata) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_scan_result_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JavascriptPackageJSONMetadata.ProtoReflect.Descriptor instead.
+func (*JavascriptPackageJSONMetadata) Descriptor() ([]byte, []int) {
+	return file_proto_scan_result_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *JavascriptPackageJSONMetadata) GetAuthor() string {
+	if x != nil {
+		return x.Author
+	}
+	return ""
+}
+
+func (x *JavascriptPackageJSONMetadata) GetMaintainers() []string {
+	if x != nil {
+		return x.Maintainers
+	}
+	return nil
+}
+
+func (x *JavascriptPackageJSONMetadata) GetContributors() []string {
+	if x != nil {
+		return x.Contributors
+	}
+	return nil
+}
+
+// The additional data found in APK packages.
+type APKPackageMetadata struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PackageName  string `protobuf:"bytes,1,opt,name=package_name,json=packageName,proto3" json:"package_name,omitempty"`
+	OriginName   string `protobuf:"bytes,2,opt,name=origin_name,json=originName,proto3" json:"origin_name,omitempty"`
+	OsId         string `protobuf:"bytes,3,opt,name=os_id,json=osId,proto3" json:"os_id,omitempty"`
+	OsVersionId  string `protobuf:"bytes,4,opt,name=os_version_id,json=osVersionId,proto3" json:"os_version_id,omitempty"`
+	Maintainer   string `protobuf:"bytes,5,opt,name=maintainer,proto3" json:"maintainer,omitempty"`
+	Architecture string `protobuf:"bytes,6,opt,name=architecture,proto3" json:"architecture,omitempty"`
+	License      string `protobuf:"bytes,7,opt,name=license,proto3" json:"license,omitempty"`
+}
+
+func (x *APKPackageMetadata) Reset() {
+	*x = APKPackageMetadata{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_scan_result_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *APKPackageMetadata) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*APKPackageMetadata) ProtoMessage() {}
+
+func (x *APKPackageMetadata) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_scan_result_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use APKPackageMetadata.ProtoReflect.Descriptor instead.
+func (*APKPackageMetadata) Descriptor() ([]byte, []int) {
+	return file_proto_scan_result_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *APKPackageMetadata) GetPackageName() string {
+	if x != nil {
+		return x.PackageName
+	}
+	return ""
+}
+
+func (x *APKPackageMetadata) GetOriginName() string {
+	if x != nil {
+		return x.OriginName
+	}
+	return ""
+}
+
+func (x *APKPackageMetadata) GetOsId() string {
+	if x != nil {
+		return x.OsId
+	}
+	return ""
+}
+
+func (x *APKPackageMetadata) GetOsVersionId() string {
+	if x != nil {
+		return x.OsVersionId
+	}
+	return ""
+}
+
+func (x *APKPackageMetadata) GetMaintainer() string {
+	if x != nil {
+		return x.Maintainer
+	}
+	return ""
+}
+
+func (x *APKPackageMetadata) GetArchitecture() string {
+	if x != nil {
+		return x.Architecture
+	}
+	return ""
+}
+
+func (x *APKPackageMetadata) GetLicense() string {
+	if x != nil {
+		return x.License
+	}
+	return ""
+}
+
+// The additional data found in DPKG packages.
+// Next ID: 11
+type DPKGPackageMetadata struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PackageName       string `protobuf:"bytes,1,opt,name=package_name,json=packageName,proto3" json:"package_name,omitempty"`
+	SourceName        string `protobuf:"bytes,2,opt,name=source_name,json=sourceName,proto3" json:"source_name,omitempty"`
+	SourceVersion     string `protobuf:"bytes,3,opt,name=source_version,json=sourceVersion,proto3" json:"source_version,omitempty"`
+	PackageVersion    string `protobuf:"bytes,4,opt,name=package_version,json=packageVersion,proto3" json:"package_version,omitempty"`
+	OsId              string `protobuf:"bytes,5,opt,name=os_id,json=osId,proto3" json:"os_id,omitempty"`
+	OsVersionCodename string `protobuf:"bytes,6,opt,name=os_version_codename,json=osVersionCodename,proto3" json:"os_version_codename,omitempty"`
+	OsVersionId       string `protobuf:"bytes,7,opt,name=os_version_id,json=osVersionId,proto3" json:"os_version_id,omitempty"`
+	Maintainer        string `protobuf:"bytes,8,opt,name=maintainer,proto3" json:"maintainer,omitempty"`
+	Architecture      string `protobuf:"bytes,9,opt,name=architecture,proto3" json:"architecture,omitempty"`
+	Status            string `protobuf:"bytes,10,opt,name=status,proto3" json:"status,omitempty"`
+}
+
+func (x *DPKGPackageMetadata) Reset() {
+	*x = DPKGPackageMetadata{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_scan_result_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DPKGPackageMetadata) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DPKGPackageMetadata) ProtoMessage() {}
+
+func (x *DPKGPackageMetadata) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_scan_result_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DPKGPackageMetadata.ProtoReflect.Descriptor instead.
+func (*DPKGPackageMetadata) Descriptor() ([]byte, []int) {
+	return file_proto_scan_result_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *DPKGPackageMetadata) GetPackageName() string {
+	if x != nil {
+		return x.PackageName
+	}
+	return ""
+}
+
+func (x *DPKGPackageMetadata) GetSourceName() string {
+	if x != nil {
+		return x.SourceName
+	}
+	return ""
+}
+
+func (x *DPKGPackageMetadata) GetSourceVersion() string {
+	if x != nil {
+		return x.SourceVersion
+	}
+	return ""
+}
+
+func (x *DPKGPackageMetadata) GetPackageVersion() string {
+	if x != nil {
+		return x.PackageVersion
+	}
+	return ""
+}
+
+func (x *DPKGPackageMetadata) GetOsId() string {
+	if x != nil {
+		return x.OsId
+	}
+	return ""
+}
+
+func (x *DPKGPackageMetadata) GetOsVersionCodename() string {
+	if x != nil {
+		return x.OsVersionCodename
+	}
+	return ""
+}
+
+func (x *DPKGPackageMetadata) GetOsVersionId() string {
+	if x != nil {
+		return x.OsVersionId
+	}
+	return ""
+}
+
+func (x *DPKGPackageMetadata) GetMaintainer() string {
+	if x != nil {
+		return x.Maintainer
+	}
+	return ""
+}
+
+func (x *DPKGPackageMetadata) GetArchitecture() string {
+	if x != nil {
+		return x.Architecture
+	}
+	return ""
+}
+
+func (x *DPKGPackageMetadata) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+// The additional data found in opkg packages.
+type OpkgPackageMetadata struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PackageName       string `protobuf:"bytes,1,opt,name=package_name,json=packageName,proto3" json:"package_name,omitempty"`
+	PackageVersion    string `protobuf:"bytes,2,opt,name=package_version,json=packageVersion,proto3" json:"package_version,omitempty"`
+	Status            string `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	Architecture      string `protobuf:"bytes,4,opt,name=architecture,proto3" json:"architecture,omitempty"`
+	AutoInstalled     bool   `protobuf:"varint,5,opt,name=auto_installed,json=autoInstalled,proto3" json:"auto_installed,omitempty"`
+	OsId              string `protobuf:"bytes,6,opt,name=os_id,json=osId,proto3" json:"os_id,omitempty"`
+	OsVersionCodename string `protobuf:"bytes,7,opt,name=os_version_codename,json=osVersionCodename,proto3" json:"os_version_codename,omitempty"`
+	OsVersionId       string `protobuf:"bytes,8,opt,name=os_version_id,json=osVersionId,proto3" json:"os_version_id,omitempty"`
+}
+
+func (x *OpkgPackageMetadata) Reset() {
+	*x = OpkgPackageMetadata{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_scan_result_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OpkgPackageMetadata) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OpkgPackageMetadata) ProtoMessage() {}
+
+func (x *OpkgPackageMetadata) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_scan_result_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OpkgPackageMetadata.ProtoReflect.Descriptor instead.
+func (*OpkgPackageMetadata) Descriptor() ([]byte, []int) {
+	return file_proto_scan_result_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *OpkgPackageMetadata) GetPackageName() string {
+	if x != nil {
+		return x.PackageName
+	}
+	return ""
+}
+
+func (x *OpkgPackageMetadata) GetPackageVersion() string {
+	if x != nil {
+		return x.PackageVersion
+	}
+	return ""
+}
+
+func (x *OpkgPackageMetadata) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *OpkgPackageMetadata) GetArchitecture() string {
+	if x != nil {
+		return x.Architecture
+	}
+	return ""
+}
+
+func (x *OpkgPackageMetadata) GetAutoInstalled() bool {
+	if x != nil {
+		return x.AutoInstalled
+	}
+	return false
+}
+
+func (x *OpkgPackageMetadata) GetOsId() string {
+	if x != nil {
+		return x.OsId
+	}
+	return ""
+}
+
+func (x *OpkgPackageMetadata) GetOsVersionCodename() string {
+	if x != nil {
+		return x.OsVersionCodename
+	}
+	return ""
+}
+
+func (x *OpkgPackageMetadata) GetOsVersionId() string {
+	if x != nil {
+		return x.OsVersionId
+	}
+	return ""
+}
+
+// The additional data found in RPM packages.
+type RPMPackageMetadata struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PackageName  string `protobuf:"bytes,1,opt,name=package_name,json=packageName,proto3" json:"package_name,omitempty"`
+	SourceRpm    string `protobuf:"bytes,2,opt,name=source_rpm,json=sourceRpm,proto3" json:"source_rpm,omitempty"`
+	Epoch        int32  `protobuf:"varint,3,opt,name=epoch,proto3" json:"epoch,omitempty"`
+	OsId         string `protobuf:"bytes,4,opt,name=os_id,json=osId,proto3" json:"os_id,omitempty"`
+	OsVersionId  string `protobuf:"bytes,5,opt,name=os_version_id,json=osVersionId,proto3" json:"os_version_id,omitempty"`
+	OsBuildId    string `protobuf:"bytes,6,opt,name=os_build_id,json=osBuildId,proto3" json:"os_build_id,omitempty"`
+	OsName       string `protobuf:"bytes,7,opt,name=os_name,json=osName,proto3" json:"os_name,omitempty"`
+	Vendor       string `protobuf:"bytes,8,opt,name=vendor,proto3" json:"vendor,omitempty"`
+	Architecture string `protobuf:"bytes,9,opt,name=architecture,proto3" json:"architecture,omitempty"`
+	License      string `protobuf:"bytes,10,opt,name=license,proto3" json:"license,omitempty"`
+}
+
+func (x *RPMPackageMetadata) Reset() {
+	*x = RPMPackageMetadata{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_scan_result_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RPMPackageMetadata) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RPMPackageMetadata) ProtoMessage() {}
+
+func (x *RPMPackageMetadata) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_scan_result_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RPMPackageMetadata.ProtoReflect.Descriptor instead.
+func (*RPMPackageMetadata) Descriptor() ([]byte, []int) {
+	return file_proto_scan_result_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *RPMPackageMetadata) GetPackageName() string {
+	if x != nil {
+		return x.PackageName
+	}
+	return ""
+}
+
+func (x *RPMPackageMetadata) GetSourceRpm() string {
+	if x != nil {
+		return x.SourceRpm
+	}
+	return ""
+}
+
+func (x *RPMPackageMetadata) GetEpoch() int32 {
+	if x != nil {
+		return x.Epoch
+	}
+	return 0
+}
+
+func (x *RPMPackageMetadata) GetOsId() string {
+	if x != nil {
+		return x.OsId
+	}
+	return ""
+}
+
+func (x *RPMPackageMetadata) GetOsVersionId() string {
+	if x != nil {
+		return x.OsVersionId
+	}
+	return ""
+}
+
+func (x *RPMPackageMetadata) GetOsBuildId() string {
+	if x != nil {
+		return x.OsBuildId
+	}
+	return ""
+}
+
+func (x *RPMPackageMetadata) GetOsName() string {
+	if x != nil {
+		return x.OsName
+	}
+	return ""
+}
+
+func (x *RPMPackageMetadata) GetVendor() string {
+	if x != nil {
+		return x.Vendor
+	}
+	return ""
+}
+
+func (x *RPMPackageMetadata) GetArchitecture() string {
+	if x != nil {
+		return x.Architecture
+	}
+	return ""
+}
+
+func (x *RPMPackageMetadata) GetLicense() string {
+	if x != nil {
+		return x.License
+	}
+	return ""
+}
+
+// The additional data found in COS packages.
+type COSPackageMetadata struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name        string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Version     string `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
+	Category    string `protobuf:"bytes,3,opt,name=category,proto3" json:"category,omitempty"`
+	OsVersion   string `protobuf:"bytes,4,opt,name=os_version,json=osVersion,proto3" json:"os_version,omitempty"`
+	OsVersionId string `protobuf:"bytes,5,opt,name=os_version_id,json=osVersionId,proto3" json:"os_version_id,omitempty"`
+}
+
+func (x *COSPackageMetadata) Reset() {
+	*x = COSPackageMetadata{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_scan_result_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *COSPackageMetadata) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*COSPackageMetadata) ProtoMessage() {}
+
+func (x *COSPackageMetadata) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_scan_result_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use COSPackageMetadata.ProtoReflect.Descriptor instead.
+func (*COSPackageMetadata) Descriptor() ([]byte, []int) {
+	return file_proto_scan_result_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *COSPackageMetadata) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *COSPackageMetadata) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *COSPackageMetadata) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *COSPackageMetadata) GetOsVersion() string {
+	if x != nil {
+		return x.OsVersion
+	}
+	return ""
+}
+
+func (x *COSPackageMetadata) GetOsVersionId() string {
+	if x != nil {
+		return x.OsVersionId
+	}
+	return ""
+}
+
+// The additional data found in SNAP packages.
+type SNAPPackageMetadata struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name              string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Version           string   `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
+	Grade             string   `protobuf:"bytes,3,opt,name=grade,proto3" json:"grade,omitempty"`
+	Type              string   `protobuf:"bytes,4,opt,name=type,proto3" json:"type,omitempty"`
+	Architectures     []string `protobuf:"bytes,5,rep,name=architectures,proto3" json:"architectures,omitempty"`
+	OsId              string   `protobuf:"bytes,6,opt,name=os_id,json=osId,proto3" json:"os_id,omitempty"`
+	OsVersionCodename string   `protobuf:"bytes,7,opt,name=os_version_codename,json=osVersionCodename,proto3" json:"os_version_codename,omitempty"`
+	OsVersionId       string   `protobuf:"bytes,8,opt,name=os_version_id,json=osVersionId,proto3" json:"os_version_id,omitempty"`
+}
+
+func (x *SNAPPackageMetadata) Reset() {
+	*x = SNAPPackageMetadata{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_scan_result_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SNAPPackageMetadata) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SNAPPackageMetadata) ProtoMessage() {}
+
+func (x *SNAPPackageMetadata) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_scan_result_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SNAPPackageMetadata.ProtoReflect.Descriptor instead.
+func (*SNAPPackageMetadata) Descriptor() ([]byte, []int) {
+	return file_proto_scan_result_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *SNAPPackageMetadata) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *SNAPPackageMetadata) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *SNAPPackageMetadata) GetGrade() string {
+	if x != nil {
+		return x.Grade
+	}
+	return ""
+}
+
+func (x *SNAPPackageMetadata) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *SNAPPackageMetadata) GetArchitectures() []string {
+	if x != nil {
+		return x.Architectures
+	}
+	return nil
+}
+
+func (x *SNAPPackageMetadata) GetOsId() string {
+	if x != nil {
+		return x.OsId
+	}
+	return ""
+}
+
+func (x *SNAPPackageMetadata) GetOsVersionCodename() string {
+	if x != nil {
+		return x.OsVersionCodename
+	}
+	return ""
+}
+
+func (x *SNAPPackageMetadata) GetOsVersionId() string {
+	if x != nil {
+		return x.OsVersionId
+	}
+	return ""
+}
+
+// The additional data found in Flatpak packages.
+type FlatpakPackageMetadata struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PackageName    string `protobuf:"bytes,1,opt,name=package_name,json=packageName,proto3" json:"package_name,omitempty"`
+	PackageId      string `protobuf:"bytes,2,opt,name=package_id,json=packageId,proto3" json:"package_id,omitempty"`
+	PackageVersion string `protobuf:"bytes,3,opt,name=package_version,json=packageVersion,proto3" json:"package_version,omitempty"`
+	ReleaseDate    string `protobuf:"bytes,4,opt,name=release_date,json=releaseDate,proto3" json:"release_date,omitempty"`
+	OsName         string `protobuf:"bytes,5,opt,name=os_name,json=osName,proto3" json:"os_name,omitempty"`
+	OsId           string `protobuf:"bytes,6,opt,name=os_id,json=osId,proto3" json:"os_id,omitempty"`
+	OsVersionId    string `protobuf:"bytes,7,opt,name=os_version_id,json=osVersionId,proto3" json:"os_version_id,omitempty"`
+	OsBuildId      string `protobuf:"bytes,8,opt,name=os_build_id,json=osBuildId,proto3" json:"os_build_id,omitempty"`
+	Developer      string `protobuf:"bytes,9,opt,name=developer,proto3" json:"developer,omitempty"`
+}
+
+func (x *FlatpakPackageMetadata) Reset() {
+	*x = FlatpakPackageMetadata{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_scan_result_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FlatpakPackageMetadata) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FlatpakPackageMetadata) ProtoMessage() {}
+
+func (x *FlatpakPackageMetadata) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_scan_result_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FlatpakPackageMetadata.ProtoReflect.Descriptor instead.
+func (*FlatpakPackageMetadata) Descriptor() ([]byte, []int) {
+	return file_proto_scan_result_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *FlatpakPackageMetadata) GetPackageName() string {
+	if x != nil {
+		return x.PackageName
+	}
+	return ""
+}
+
+func (x *FlatpakPackageMetadata) GetPackageId() string {
+	if x != nil {
+		return x.PackageId
+	}
+	return ""
+}
+
+func (x *FlatpakPackageMetadata) GetPackageVersion() string {
+	if x != nil {
+		return x.PackageVersion
+	}
+	return ""
+}
+
+func (x *FlatpakPackageMetadata) GetReleaseDate() string {
+	if x != nil {
+		return x.ReleaseDate
+	}
+	return ""
+}
+
+func (x *FlatpakPackageMetadata) GetOsName() string {
+	if x != nil {
+		return x.OsName
+	}
+	return ""
+}
+
+func (x *FlatpakPackageMetadata) GetOsId() string {
+	if x != nil {
+		return x.OsId
+	}
+	return ""
+}
+
+func (x *FlatpakPackageMetadata) GetOsVersionId() string {
+	if x != nil {
+		return x.OsVersionId
+	}
+	return ""
+}
+
+func (x *FlatpakPackageMetadata) GetOsBuildId() string {
+	if x != nil {
+		return x.OsBuildId
+	}
+	return ""
+}
+
+func (x *FlatpakPackageMetadata) GetDeveloper() string {
+	if x != nil {
+		return x.Developer
+	}
+	return ""
+}
+
+// The additional data found in macOS application bundles.
+type MacAppsMetadata struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	BundleName               string `protobuf:"bytes,1,opt,name=bundle_name,json=bundleName,proto3" json:"bundle_name,omitempty"`
+	BundleDisplayName        string `protobuf:"bytes,2,opt,name=bundle_display_name,json=bundleDisplayName,proto3" json:"bundle_display_name,omitempty"`
+	BundleIdentifier         string `protobuf:"bytes,3,opt,name=bundle_identifier,json=bundleIdentifier,proto3" json:"bundle_identifier,omitempty"`
+	BundleShortVersionString string `protobuf:"bytes,4,opt,name=bundle_short_version_string,json=bundleShortVersionString,proto3" json:"bundle_short_version_string,omitempty"`
+	BundleVersion            string `protobuf:"bytes,5,opt,name=bundle_version,json=bundleVersion,proto3" json:"bundle_version,omitempty"`
+	BundleExecutable         string `protobuf:"bytes,6,opt,name=bundle_executable,json=bundleExecutable,proto3" json:"bundle_executable,omitempty"`
+}
+
+func (x *MacAppsMetadata) Reset() {
+	*x = MacAppsMetadata{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_scan_result_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MacAppsMetadata) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MacAppsMetadata) ProtoMessage() {}
+
+func (x *MacAppsMetadata) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_scan_result_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MacAppsMetadata.ProtoReflect.Descriptor instead.
+func (*MacAppsMetadata) Descriptor() ([]byte, []int) {
+	return file_proto_scan_result_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *MacAppsMetadata) GetBundleName() string {
+	if x != nil {
+		return x.BundleName
+	}
+	return ""
+}
+
+func (x *MacAppsMetadata) GetBundleDisplayName() string {
+	if x != nil {
+		return x.BundleDisplayName
+	}
+	return ""
+}
+
+func (x *MacAppsMetadata) GetBundleIdentifier() string {
+	if x != nil {
+		return x.BundleIdentifier
+	}
+	return ""
+}
+
+func (x *MacAppsMetadata) GetBundleShortVersionString() string {
+	if x != nil {
+		return x.BundleShortVersionString
+	}
+	return ""
+}
+
+func (x *MacAppsMetadata) GetBundleVersion() string {
+	if x != nil {
+		return x.BundleVersion
+	}
+	return ""
+}
+
+func (x *MacAppsMetadata) GetBundleExecutable() string {
+	if x != nil {
+		return x.BundleExecutable
+	}
+	return ""
+}
+
+// The additional data found in frameworks embedded in macOS and iOS bundles.
+type MacFrameworkMetadata struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	BundleName               string `protobuf:"bytes,1,opt,name=bundle_name,json=bundleName,proto3" json:"bundle_name,omitempty"`
+	BundleIdentifier         string `protobuf:"bytes,2,opt,name=bundle_identifier,json=bundleIdentifier,proto3" json:"bundle_identifier,omitempty"`
+	BundleShortVersionString string `protobuf:"bytes,3,opt,name=bundle_short_version_string,json=bundleShortVersionString,proto3" json:"bundle_short_version_string,omitempty"`
+	BundleVersion            string `protobuf:"bytes,4,opt,name=bundle_version,json=bundleVersion,proto3" json:"bundle_version,omitempty"`
+	BundleExecutable         string `protobuf:"bytes,5,opt,name=bundle_executable,json=bundleExecutable,proto3" json:"bundle_executable,omitempty"`
+	// The path of the .app or .framework bundle that embeds the framework.
+	BundlePath string `protobuf:"bytes,6,opt,name=bundle_path,json=bundlePath,proto3" json:"bundle_path,omitempty"`
+}
+
+func (x *MacFrameworkMetadata) Reset() {
+	*x = MacFrameworkMetadata{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_scan_result_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MacFrameworkMetadata) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MacFrameworkMetadata) ProtoMessage() {}
+
+func (x *MacFrameworkMetadata) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_scan_result_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MacFrameworkMetadata.ProtoReflect.Descriptor instead.
+func (*MacFrameworkMetadata) Descriptor() ([]byte, []int) {
+	return file_proto_scan_result_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *MacFrameworkMetadata) GetBundleName() string {
+	if x != nil {
+		return x.BundleName
+	}
+	return ""
+}
+
+func (x *MacFrameworkMetadata) GetBundleIdentifier() string {
+	if x != nil {
+		return x.BundleIdentifier
+	}
+	return ""
+}
+
+func (x *MacFrameworkMetadata) GetBundleShortVersionString() string {
+	if x != nil {
+		return x.BundleShortVersionString
+	}
+	return ""
+}
+
+func (x *MacFrameworkMetadata) GetBundleVersion() string {
+	if x != nil {
+		return x.BundleVersion
+	}
+	return ""
+}
+
+func (x *MacFrameworkMetadata) GetBundleExecutable() string {
+	if x != nil {
+		return x.BundleExecutable
+	}
+	return ""
+}
+
+func (x *MacFrameworkMetadata) GetBundlePath() string {
+	if x != nil {
+		return x.BundlePath
+	}
+	return ""
+}
+
+// The additional data for packages extracted from SPDX files.
+type SPDXPackageMetadata struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Purl *Purl    `protobuf:"bytes,1,opt,name=purl,proto3" json:"purl,omitempty"`
+	Cpes []string `protobuf:"bytes,2,rep,name=cpes,proto3" json:"cpes,omitempty"`
+}
+
+func (x *SPDXPackageMetadata) Reset() {
+	*x = SPDXPackageMetadata{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_scan_result_proto_msgTypes[28]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SPDXPackageMetadata) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SPDXPackageMetadata) ProtoMessage() {}
+
+func (x *SPDXPackageMetadata) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_scan_result_proto_msgTypes[28]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SPDXPackageMetadata.ProtoReflect.Descriptor instead.
+func (*SPDXPackageMetadata) Descriptor() ([]byte, []int) {
+	return file_proto_scan_result_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *SPDXPackageMetadata) GetPurl() *Purl {
+	if x != nil {
+		return x.Purl
+	}
+	return nil
+}
+
+func (x *SPDXPackageMetadata) GetCpes() []string {
+	if x != nil {
+		return x.Cpes
+	}
+	return nil
+}
+
+// The additional data found in Java JAR packages.
+type JavaArchiveMetadata struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ArtifactId string `protobuf:"bytes,2,opt,name=artifact_id,json=artifactId,proto3" json:"artifact_id,omitempty"`
+	GroupId    string `protobuf:"bytes,3,opt,name=group_id,json=groupId,proto3" json:"group_id,omitempty"`
+	Sha1       string `protobuf:"bytes,4,opt,name=sha1,proto3" json:"sha1,omitempty"`
+}
+
+func (x *JavaArchiveMetadata) Reset() {
+	*x = JavaArchiveMetadata{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_scan_result_proto_msgTypes[29]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *JavaArchiveMetadata) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JavaArchiveMetadata) ProtoMessage() {}
+
+func (x *JavaArchiveMetadata) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_scan_result_proto_msgTypes[29]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JavaArchiveMetadata.ProtoReflect.Descriptor instead.
+func (*JavaArchiveMetadata) Descriptor() ([]byte, []int) {
+	return file_proto_scan_result_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *JavaArchiveMetadata) GetArtifactId() string {
+	if x != nil {
+		return x.ArtifactId
+	}
+	return ""
+}
+
+func (x *JavaArchiveMetadata) GetGroupId() string {
+	if x != nil {
+		return x.GroupId
+	}
+	return ""
+}
+
+func (x *JavaArchiveMetadata) GetSha1() string {
+	if x != nil {
+		return x.Sha1
+	}
+	return ""
+}
+
+// The additional data for artifacts found in local Maven repositories.
+type MavenRepositoryMetadata struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Repo   string `protobuf:"bytes,1,opt,name=repo,proto3" json:"repo,omitempty"`
-	Commit string `protobuf:"bytes,2,opt,name=commit,proto3" json:"commit,omitempty"`
+	GroupId         string   `protobuf:"bytes,1,opt,name=group_id,json=groupId,proto3" json:"group_id,omitempty"`
+	ArtifactId      string   `protobuf:"bytes,2,opt,name=artifact_id,json=artifactId,proto3" json:"artifact_id,omitempty"`
+	Packaging       string   `protobuf:"bytes,3,opt,name=packaging,proto3" json:"packaging,omitempty"`
+	SnapshotVersion string   `protobuf:"bytes,4,opt,name=snapshot_version,json=snapshotVersion,proto3" json:"snapshot_version,omitempty"`
+	Classifiers     []string `protobuf:"bytes,5,rep,name=classifiers,proto3" json:"classifiers,omitempty"`
 }
 
-func (x *SourceCodeIdentifier) Reset() {
-	*x = SourceCodeIdentifier{}
+func (x *MavenRepositoryMetadata) Reset() {
+	*x = MavenRepositoryMetadata{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_scan_result_proto_msgTypes[4]
+		mi := &file_proto_scan_result_proto_msgTypes[30]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *SourceCodeIdentifier) String() string {
+func (x *MavenRepositoryMetadata) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SourceCodeIdentifier) ProtoMessage() {}
+func (*MavenRepositoryMetadata) ProtoMessage() {}
 
-func (x *SourceCodeIdentifier) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_scan_result_proto_msgTypes[4]
+func (x *MavenRepositoryMetadata) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_scan_result_proto_msgTypes[30]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -837,64 +3577,75 @@ func (x *SourceCodeIdentifier) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SourceCodeIdentifier.ProtoReflect.Descriptor instead.
-func (*SourceCodeIdentifier) Descriptor() ([]byte, []int) {
-	return file_proto_scan_result_proto_rawDescGZIP(), []int{4}
+// Deprecated: Use MavenRepositoryMetadata.ProtoReflect.Descriptor instead.
+func (*MavenRepositoryMetadata) Descriptor() ([]byte, []int) {
+	return file_proto_scan_result_proto_rawDescGZIP(), []int{30}
 }
 
-func (x *SourceCodeIdentifier) GetRepo() string {
+func (x *MavenRepositoryMetadata) GetGroupId() string {
 	if x != nil {
-		return x.Repo
+		return x.GroupId
 	}
 	return ""
 }
 
-func (x *SourceCodeIdentifier) GetCommit() string {
+func (x *MavenRepositoryMetadata) GetArtifactId() string {
 	if x != nil {
-		return x.Commit
+		return x.ArtifactId
 	}
 	return ""
 }
 
-// Package URL, see https://github.com/package-url/purl-spec
-type Purl struct {
+func (x *MavenRepositoryMetadata) GetPackaging() string {
+	if x != nil {
+		return x.Packaging
+	}
+	return ""
+}
+
+func (x *MavenRepositoryMetadata) GetSnapshotVersion() string {
+	if x != nil {
+		return x.SnapshotVersion
+	}
+	return ""
+}
+
+func (x *MavenRepositoryMetadata) GetClassifiers() []string {
+	if x != nil {
+		return x.Classifiers
+	}
+	return nil
+}
+
+// The additional data for packages extracted by an OSV extractor wrapper.
+type OSVPackageMetadata struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// String representation.
-	Purl string `protobuf:"bytes,1,opt,name=purl,proto3" json:"purl,omitempty"`
-	// Package type, e.g. "maven, npm, pypi".
-	Type string `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
-	// Package name.
-	Name string `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
-	// Package version.
-	Version string `protobuf:"bytes,4,opt,name=version,proto3" json:"version,omitempty"`
-	// Name prefix such as a Maven groupid, or Docker image owner.
-	Namespace string `protobuf:"bytes,5,opt,name=namespace,proto3" json:"namespace,omitempty"`
-	// Extra qualifying data for a package such as an OS, architecture, etc.
-	Qualifiers []*Qualifier `protobuf:"bytes,6,rep,name=qualifiers,proto3" json:"qualifiers,omitempty"`
-	// Extra subpath within a package, relative to the package root.
-	Subpath string `protobuf:"bytes,7,opt,name=subpath,proto3" json:"subpath,omitempty"`
+	PurlType  string `protobuf:"bytes,1,opt,name=purl_type,json=purlType,proto3" json:"purl_type,omitempty"`
+	Commit    string `protobuf:"bytes,2,opt,name=commit,proto3" json:"commit,omitempty"`
+	Ecosystem string `protobuf:"bytes,3,opt,name=ecosystem,proto3" json:"ecosystem,omitempty"`
+	CompareAs string `protobuf:"bytes,4,opt,name=compare_as,json=compareAs,proto3" json:"compare_as,omitempty"`
 }
 
-func (x *Purl) Reset() {
-	*x = Purl{}
+func (x *OSVPackageMetadata) Reset() {
+	*x = OSVPackageMetadata{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_scan_result_proto_msgTypes[5]
+		mi := &file_proto_scan_result_proto_msgTypes[31]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *Purl) String() string {
+func (x *OSVPackageMetadata) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Purl) ProtoMessage() {}
+func (*OSVPackageMetadata) ProtoMessage() {}
 
-func (x *Purl) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_scan_result_proto_msgTypes[5]
+func (x *OSVPackageMetadata) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_scan_result_proto_msgTypes[31]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -905,86 +3656,130 @@ func (x *Purl) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Purl.ProtoReflect.Descriptor instead.
-func (*Purl) Descriptor() ([]byte, []int) {
-	return file_proto_scan_result_proto_rawDescGZIP(), []int{5}
+// Deprecated: Use OSVPackageMetadata.ProtoReflect.Descriptor instead.
+func (*OSVPackageMetadata) Descriptor() ([]byte, []int) {
+	return file_proto_scan_result_proto_rawDescGZIP(), []int{31}
 }
 
-func (x *Purl) GetPurl() string {
+func (x *OSVPackageMetadata) GetPurlType() string {
 	if x != nil {
-		return x.Purl
+		return x.PurlType
 	}
 	return ""
 }
 
-func (x *Purl) GetType() string {
+func (x *OSVPackageMetadata) GetCommit() string {
 	if x != nil {
-		return x.Type
+		return x.Commit
 	}
 	return ""
 }
 
-func (x *Purl) GetName() string {
+func (x *OSVPackageMetadata) GetEcosystem() string {
 	if x != nil {
-		return x.Name
+		return x.Ecosystem
 	}
 	return ""
 }
 
-func (x *Purl) GetVersion() string {
+func (x *OSVPackageMetadata) GetCompareAs() string {
 	if x != nil {
-		return x.Version
+		return x.CompareAs
 	}
 	return ""
 }
 
-func (x *Purl) GetNamespace() string {
+// The virtual environment that a Python package is installed in.
+type PythonVirtualenvMetadata struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The root directory of the environment, i.e. the one containing pyvenv.cfg.
+	EnvironmentPath           string `protobuf:"bytes,1,opt,name=environment_path,json=environmentPath,proto3" json:"environment_path,omitempty"`
+	PythonVersion             string `protobuf:"bytes,2,opt,name=python_version,json=pythonVersion,proto3" json:"python_version,omitempty"`
+	IncludeSystemSitePackages bool   `protobuf:"varint,3,opt,name=include_system_site_packages,json=includeSystemSitePackages,proto3" json:"include_system_site_packages,omitempty"`
+}
+
+func (x *PythonVirtualenvMetadata) Reset() {
+	*x = PythonVirtualenvMetadata{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_scan_result_proto_msgTypes[32]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PythonVirtualenvMetadata) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PythonVirtualenvMetadata) ProtoMessage() {}
+
+func (x *PythonVirtualenvMetadata) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_scan_result_proto_msgTypes[32]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PythonVirtualenvMetadata.ProtoReflect.Descriptor instead.
+func (*PythonVirtualenvMetadata) Descriptor() ([]byte, []int) {
+	return file_proto_scan_result_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *PythonVirtualenvMetadata) GetEnvironmentPath() string {
 	if x != nil {
-		return x.Namespace
+		return x.EnvironmentPath
 	}
 	return ""
 }
 
-func (x *Purl) GetQualifiers() []*Qualifier {
+func (x *PythonVirtualenvMetadata) GetPythonVersion() string {
 	if x != nil {
-		return x.Qualifiers
+		return x.PythonVersion
 	}
-	return nil
+	return ""
 }
 
-func (x *Purl) GetSubpath() string {
+func (x *PythonVirtualenvMetadata) GetIncludeSystemSitePackages() bool {
 	if x != nil {
-		return x.Subpath
+		return x.IncludeSystemSitePackages
 	}
-	return ""
+	return false
 }
 
-type Qualifier struct {
+type PythonRequirementsMetadata struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Key   string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
-	Value string `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	HashCheckingModeValues []string `protobuf:"bytes,1,rep,name=hash_checking_mode_values,json=hashCheckingModeValues,proto3" json:"hash_checking_mode_values,omitempty"`
+	VersionComparator      string   `protobuf:"bytes,2,opt,name=version_comparator,json=versionComparator,proto3" json:"version_comparator,omitempty"`
 }
 
-func (x *Qualifier) Reset() {
-	*x = Qualifier{}
+func (x *PythonRequirementsMetadata) Reset() {
+	*x = PythonRequirementsMetadata{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_scan_result_proto_msgTypes[6]
+		mi := &file_proto_scan_result_proto_msgTypes[33]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *Qualifier) String() string {
+func (x *PythonRequirementsMetadata) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Qualifier) ProtoMessage() {}
+func (*PythonRequirementsMetadata) ProtoMessage() {}
 
-func (x *Qualifier) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_scan_result_proto_msgTypes[6]
+func (x *PythonRequirementsMetadata) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_scan_result_proto_msgTypes[33]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -995,60 +3790,55 @@ func (x *Qualifier) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Qualifier.ProtoReflect.Descriptor instead.
-func (*Qualifier) Descriptor() ([]byte, []int) {
-	return file_proto_scan_result_proto_rawDescGZIP(), []int{6}
+// Deprecated: Use PythonRequirementsMetadata.ProtoReflect.Descriptor instead.
+func (*PythonRequirementsMetadata) Descriptor() ([]byte, []int) {
+	return file_proto_scan_result_proto_rawDescGZIP(), []int{33}
 }
 
-func (x *Qualifier) GetKey() string {
+func (x *PythonRequirementsMetadata) GetHashCheckingModeValues() []string {
 	if x != nil {
-		return x.Key
+		return x.HashCheckingModeValues
 	}
-	return ""
+	return nil
 }
 
-func (x *Qualifier) GetValue() string {
+func (x *PythonRequirementsMetadata) GetVersionComparator() string {
 	if x != nil {
-		return x.Value
+		return x.VersionComparator
 	}
 	return ""
 }
 
-// A security finding found by a detector. It could describe things like a CVE
-// or a CIS non-compliance.
-type Finding struct {
+// The additional data for packages found in Pipenv's Pipfile.lock files.
+type PipfileLockMetadata struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// Info specific to the finding. Should always be the same for the same type
-	// of finding.
-	Adv *Advisory `protobuf:"bytes,1,opt,name=adv,proto3" json:"adv,omitempty"`
-	// Instance-specific info such as location of the vulnerable files.
-	Target *TargetDetails `protobuf:"bytes,2,opt,name=target,proto3" json:"target,omitempty"`
-	// Additional free-text info.
-	Extra string `protobuf:"bytes,3,opt,name=extra,proto3" json:"extra,omitempty"`
-	// The name of the Detectors that found this finding. Set by the core library.
-	Detectors []string `protobuf:"bytes,4,rep,name=detectors,proto3" json:"detectors,omitempty"`
+	// "default" and/or "develop".
+	Sections []string `protobuf:"bytes,1,rep,name=sections,proto3" json:"sections,omitempty"`
+	Hashes   []string `protobuf:"bytes,2,rep,name=hashes,proto3" json:"hashes,omitempty"`
+	// Set for packages without a pinned version.
+	Unpinned bool `protobuf:"varint,3,opt,name=unpinned,proto3" json:"unpinned,omitempty"`
 }
 
-func (x *Finding) Reset() {
-	*x = Finding{}
+func (x *PipfileLockMetadata) Reset() {
+	*x = PipfileLockMetadata{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_scan_result_proto_msgTypes[7]
+		mi := &file_proto_scan_result_proto_msgTypes[34]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *Finding) String() string {
+func (x *PipfileLockMetadata) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Finding) ProtoMessage() {}
+func (*PipfileLockMetadata) ProtoMessage() {}
 
-func (x *Finding) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_scan_result_proto_msgTypes[7]
+func (x *PipfileLockMetadata) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_scan_result_proto_msgTypes[34]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1059,71 +3849,65 @@ func (x *Finding) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Finding.ProtoReflect.Descriptor instead.
-func (*Finding) Descriptor() ([]byte, []int) {
-	return file_proto_scan_result_proto_rawDescGZIP(), []int{7}
+// Deprecated: Use PipfileLockMetadata.ProtoReflect.Descriptor instead.
+func (*PipfileLockMetadata) Descriptor() ([]byte, []int) {
+	return file_proto_scan_result_proto_rawDescGZIP(), []int{34}
 }
 
-func (x *Finding) GetAdv() *Advisory {
+func (x *PipfileLockMetadata) GetSections() []string {
 	if x != nil {
-		return x.Adv
+		return x.Sections
 	}
 	return nil
 }
 
-func (x *Finding) GetTarget() *TargetDetails {
+func (x *PipfileLockMetadata) GetHashes() []string {
 	if x != nil {
-		return x.Target
+		return x.Hashes
 	}
 	return nil
 }
 
-func (x *Finding) GetExtra() string {
-	if x != nil {
-		return x.Extra
-	}
-	return ""
-}
-
-func (x *Finding) GetDetectors() []string {
+func (x *PipfileLockMetadata) GetUnpinned() bool {
 	if x != nil {
-		return x.Detectors
+		return x.Unpinned
 	}
-	return nil
+	return false
 }
 
-type Advisory struct {
+// The additional data for dependencies found in Elixir's mix.lock files.
+type MixLockMetadata struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// A unique ID for the finding.
-	Id          *AdvisoryId       `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Type        Advisory_TypeEnum `protobuf:"varint,2,opt,name=type,proto3,enum=scalibr.Advisory_TypeEnum" json:"type,omitempty"`
-	Title       string            `protobuf:"bytes,3,opt,name=title,proto3" json:"title,omitempty"`
-	Description string            `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
-	// Remediation instructions, e.g. "update to latest version".
-	Recommendation string    `protobuf:"bytes,5,opt,name=recommendation,proto3" json:"recommendation,omitempty"`
-	Sev            *Severity `protobuf:"bytes,6,opt,name=sev,proto3" json:"sev,omitempty"`
+	// "hex" or "git".
+	Source string `protobuf:"bytes,1,opt,name=source,proto3" json:"source,omitempty"`
+	// The Hex repository, e.g. "hexpm". Only set for Hex packages.
+	Repo     string `protobuf:"bytes,2,opt,name=repo,proto3" json:"repo,omitempty"`
+	Checksum string `protobuf:"bytes,3,opt,name=checksum,proto3" json:"checksum,omitempty"`
+	// Only set for git dependencies.
+	GitUrl string `protobuf:"bytes,4,opt,name=git_url,json=gitUrl,proto3" json:"git_url,omitempty"`
+	Commit string `protobuf:"bytes,5,opt,name=commit,proto3" json:"commit,omitempty"`
 }
 
-func (x *Advisory) Reset() {
-	*x = Advisory{}
+func (x *MixLockMetadata) Reset() {
+	*x = MixLockMetadata{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_scan_result_proto_msgTypes[8]
+		mi := &file_proto_scan_result_proto_msgTypes[35]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *Advisory) String() string {
+func (x *MixLockMetadata) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Advisory) ProtoMessage() {}
+func (*MixLockMetadata) ProtoMessage() {}
 
-func (x *Advisory) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_scan_result_proto_msgTypes[8]
+func (x *MixLockMetadata) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_scan_result_proto_msgTypes[35]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1134,80 +3918,77 @@ func (x *Advisory) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Advisory.ProtoReflect.Descriptor instead.
-func (*Advisory) Descriptor() ([]byte, []int) {
-	return file_proto_scan_result_proto_rawDescGZIP(), []int{8}
-}
-
-func (x *Advisory) GetId() *AdvisoryId {
-	if x != nil {
-		return x.Id
-	}
-	return nil
+// Deprecated: Use MixLockMetadata.ProtoReflect.Descriptor instead.
+func (*MixLockMetadata) Descriptor() ([]byte, []int) {
+	return file_proto_scan_result_proto_rawDescGZIP(), []int{35}
 }
 
-func (x *Advisory) GetType() Advisory_TypeEnum {
+func (x *MixLockMetadata) GetSource() string {
 	if x != nil {
-		return x.Type
+		return x.Source
 	}
-	return Advisory_UNKNOWN
+	return ""
 }
 
-func (x *Advisory) GetTitle() string {
+func (x *MixLockMetadata) GetRepo() string {
 	if x != nil {
-		return x.Title
+		return x.Repo
 	}
 	return ""
 }
 
-func (x *Advisory) GetDescription() string {
+func (x *MixLockMetadata) GetChecksum() string {
 	if x != nil {
-		return x.Description
+		return x.Checksum
 	}
 	return ""
 }
 
-func (x *Advisory) GetRecommendation() string {
+func (x *MixLockMetadata) GetGitUrl() string {
 	if x != nil {
-		return x.Recommendation
+		return x.GitUrl
 	}
 	return ""
 }
 
-func (x *Advisory) GetSev() *Severity {
+func (x *MixLockMetadata) GetCommit() string {
 	if x != nil {
-		return x.Sev
+		return x.Commit
 	}
-	return nil
+	return ""
 }
 
-// A unique identifier per advisory.
-type AdvisoryId struct {
+// The additional data for OSGi bundles, from their manifests.
+type OSGiBundleMetadata struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Publisher string `protobuf:"bytes,1,opt,name=publisher,proto3" json:"publisher,omitempty"` // e.g. "CVE".
-	Reference string `protobuf:"bytes,2,opt,name=reference,proto3" json:"reference,omitempty"` // e.g. "CVE-2023-1234".
+	BundleName   string `protobuf:"bytes,1,opt,name=bundle_name,json=bundleName,proto3" json:"bundle_name,omitempty"`
+	BundleVendor string `protobuf:"bytes,2,opt,name=bundle_vendor,json=bundleVendor,proto3" json:"bundle_vendor,omitempty"`
+	// The qualifier of the bundle version, e.g. "v20230726-0617".
+	Qualifier string `protobuf:"bytes,3,opt,name=qualifier,proto3" json:"qualifier,omitempty"`
+	// The symbolic name of the host bundle if the bundle is a fragment.
+	FragmentHost string `protobuf:"bytes,4,opt,name=fragment_host,json=fragmentHost,proto3" json:"fragment_host,omitempty"`
 }
 
-func (x *AdvisoryId) Reset() {
-	*x = AdvisoryId{}
+func (x *OSGiBundleMetadata) Reset() {
+	*x = OSGiBundleMetadata{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_scan_result_proto_msgTypes[9]
+		mi := &file_proto_scan_result_proto_msgTypes[36]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *AdvisoryId) String() string {
+func (x *OSGiBundleMetadata) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AdvisoryId) ProtoMessage() {}
+func (*OSGiBundleMetadata) ProtoMessage() {}
 
-func (x *AdvisoryId) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_scan_result_proto_msgTypes[9]
+func (x *OSGiBundleMetadata) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_scan_result_proto_msgTypes[36]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1215,57 +3996,70 @@ func (x *AdvisoryId) ProtoReflect() protoreflect.Message {
 		}
 		return ms
 	}
-	return mi.MessageOf(x)
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OSGiBundleMetadata.ProtoReflect.Descriptor instead.
+func (*OSGiBundleMetadata) Descriptor() ([]byte, []int) {
+	return file_proto_scan_result_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *OSGiBundleMetadata) GetBundleName() string {
+	if x != nil {
+		return x.BundleName
+	}
+	return ""
 }
 
-// Deprecated: Use AdvisoryId.ProtoReflect.Descriptor instead.
-func (*AdvisoryId) Descriptor() ([]byte, []int) {
-	return file_proto_scan_result_proto_rawDescGZIP(), []int{9}
+func (x *OSGiBundleMetadata) GetBundleVendor() string {
+	if x != nil {
+		return x.BundleVendor
+	}
+	return ""
 }
 
-func (x *AdvisoryId) GetPublisher() string {
+func (x *OSGiBundleMetadata) GetQualifier() string {
 	if x != nil {
-		return x.Publisher
+		return x.Qualifier
 	}
 	return ""
 }
 
-func (x *AdvisoryId) GetReference() string {
+func (x *OSGiBundleMetadata) GetFragmentHost() string {
 	if x != nil {
-		return x.Reference
+		return x.FragmentHost
 	}
 	return ""
 }
 
-type Severity struct {
+// The additional data for Perl modules recorded in perllocal.pod files.
+type CPANMetadata struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// Required severity enum. Can be used for e.g. prioritizing filed bugs.
-	Severity Severity_SeverityEnum `protobuf:"varint,1,opt,name=severity,proto3,enum=scalibr.Severity_SeverityEnum" json:"severity,omitempty"`
-	// Optional CVSS scores, only set for vulns with CVEs.
-	CvssV2 *CVSS `protobuf:"bytes,2,opt,name=cvss_v2,json=cvssV2,proto3" json:"cvss_v2,omitempty"`
-	CvssV3 *CVSS `protobuf:"bytes,3,opt,name=cvss_v3,json=cvssV3,proto3" json:"cvss_v3,omitempty"`
+	// The installation date as written by the installer.
+	InstallDate   string `protobuf:"bytes,1,opt,name=install_date,json=installDate,proto3" json:"install_date,omitempty"`
+	InstalledInto string `protobuf:"bytes,2,opt,name=installed_into,json=installedInto,proto3" json:"installed_into,omitempty"`
 }
 
-func (x *Severity) Reset() {
-	*x = Severity{}
+func (x *CPANMetadata) Reset() {
+	*x = CPANMetadata{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_scan_result_proto_msgTypes[10]
+		mi := &file_proto_scan_result_proto_msgTypes[37]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *Severity) String() string {
+func (x *CPANMetadata) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Severity) ProtoMessage() {}
+func (*CPANMetadata) ProtoMessage() {}
 
-func (x *Severity) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_scan_result_proto_msgTypes[10]
+func (x *CPANMetadata) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_scan_result_proto_msgTypes[37]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1276,59 +4070,57 @@ func (x *Severity) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Severity.ProtoReflect.Descriptor instead.
-func (*Severity) Descriptor() ([]byte, []int) {
-	return file_proto_scan_result_proto_rawDescGZIP(), []int{10}
-}
-
-func (x *Severity) GetSeverity() Severity_SeverityEnum {
-	if x != nil {
-		return x.Severity
-	}
-	return Severity_UNSPECIFIED
+// Deprecated: Use CPANMetadata.ProtoReflect.Descriptor instead.
+func (*CPANMetadata) Descriptor() ([]byte, []int) {
+	return file_proto_scan_result_proto_rawDescGZIP(), []int{37}
 }
 
-func (x *Severity) GetCvssV2() *CVSS {
+func (x *CPANMetadata) GetInstallDate() string {
 	if x != nil {
-		return x.CvssV2
+		return x.InstallDate
 	}
-	return nil
+	return ""
 }
 
-func (x *Severity) GetCvssV3() *CVSS {
+func (x *CPANMetadata) GetInstalledInto() string {
 	if x != nil {
-		return x.CvssV3
+		return x.InstalledInto
 	}
-	return nil
+	return ""
 }
 
-type CVSS struct {
+// The additional data for container images found in OCI image layouts.
+type OCILayoutImageMetadata struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	BaseScore          float32 `protobuf:"fixed32,1,opt,name=base_score,json=baseScore,proto3" json:"base_score,omitempty"`
-	TemporalScore      float32 `protobuf:"fixed32,2,opt,name=temporal_score,json=temporalScore,proto3" json:"temporal_score,omitempty"`
-	EnvironmentalScore float32 `protobuf:"fixed32,3,opt,name=environmental_score,json=environmentalScore,proto3" json:"environmental_score,omitempty"`
+	Repository     string `protobuf:"bytes,1,opt,name=repository,proto3" json:"repository,omitempty"`
+	Tag            string `protobuf:"bytes,2,opt,name=tag,proto3" json:"tag,omitempty"`
+	ManifestDigest string `protobuf:"bytes,3,opt,name=manifest_digest,json=manifestDigest,proto3" json:"manifest_digest,omitempty"`
+	IndexDigest    string `protobuf:"bytes,4,opt,name=index_digest,json=indexDigest,proto3" json:"index_digest,omitempty"`
+	Os             string `protobuf:"bytes,5,opt,name=os,proto3" json:"os,omitempty"`
+	Architecture   string `protobuf:"bytes,6,opt,name=architecture,proto3" json:"architecture,omitempty"`
+	Variant        string `protobuf:"bytes,7,opt,name=variant,proto3" json:"variant,omitempty"`
 }
 
-func (x *CVSS) Reset() {
-	*x = CVSS{}
+func (x *OCILayoutImageMetadata) Reset() {
+	*x = OCILayoutImageMetadata{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_scan_result_proto_msgTypes[11]
+		mi := &file_proto_scan_result_proto_msgTypes[38]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *CVSS) String() string {
+func (x *OCILayoutImageMetadata) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CVSS) ProtoMessage() {}
+func (*OCILayoutImageMetadata) ProtoMessage() {}
 
-func (x *CVSS) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_scan_result_proto_msgTypes[11]
+func (x *OCILayoutImageMetadata) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_scan_result_proto_msgTypes[38]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1339,61 +4131,89 @@ func (x *CVSS) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CVSS.ProtoReflect.Descriptor instead.
-func (*CVSS) Descriptor() ([]byte, []int) {
-	return file_proto_scan_result_proto_rawDescGZIP(), []int{11}
+// Deprecated: Use OCILayoutImageMetadata.ProtoReflect.Descriptor instead.
+func (*OCILayoutImageMetadata) Descriptor() ([]byte, []int) {
+	return file_proto_scan_result_proto_rawDescGZIP(), []int{38}
 }
 
-func (x *CVSS) GetBaseScore() float32 {
+func (x *OCILayoutImageMetadata) GetRepository() string {
 	if x != nil {
-		return x.BaseScore
+		return x.Repository
 	}
-	return 0
+	return ""
 }
 
-func (x *CVSS) GetTemporalScore() float32 {
+func (x *OCILayoutImageMetadata) GetTag() string {
 	if x != nil {
-		return x.TemporalScore
+		return x.Tag
 	}
-	return 0
+	return ""
 }
 
-func (x *CVSS) GetEnvironmentalScore() float32 {
+func (x *OCILayoutImageMetadata) GetManifestDigest() string {
 	if x != nil {
-		return x.EnvironmentalScore
+		return x.ManifestDigest
 	}
-	return 0
+	return ""
 }
 
-type TargetDetails struct {
+func (x *OCILayoutImageMetadata) GetIndexDigest() string {
+	if x != nil {
+		return x.IndexDigest
+	}
+	return ""
+}
+
+func (x *OCILayoutImageMetadata) GetOs() string {
+	if x != nil {
+		return x.Os
+	}
+	return ""
+}
+
+func (x *OCILayoutImageMetadata) GetArchitecture() string {
+	if x != nil {
+		return x.Architecture
+	}
+	return ""
+}
+
+func (x *OCILayoutImageMetadata) GetVariant() string {
+	if x != nil {
+		return x.Variant
+	}
+	return ""
+}
+
+// The additional data for language runtimes found embedded in binaries.
+type EmbeddedRuntimeMetadata struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// The software affected by the finding.
-	Inventory *Inventory `protobuf:"bytes,1,opt,name=inventory,proto3" json:"inventory,omitempty"`
-	// Location of vulnerable files not related to the inventory,
-	// e.g. config files with misconfigurations.
-	Location []string `protobuf:"bytes,3,rep,name=location,proto3" json:"location,omitempty"`
+	// The data the version was read from, e.g. the matched version string.
+	Evidence string `protobuf:"bytes,1,opt,name=evidence,proto3" json:"evidence,omitempty"`
+	// How reliable the version is, e.g. "high" or "medium".
+	Confidence string `protobuf:"bytes,2,opt,name=confidence,proto3" json:"confidence,omitempty"`
 }
 
-func (x *TargetDetails) Reset() {
-	*x = TargetDetails{}
+func (x *EmbeddedRuntimeMetadata) Reset() {
+	*x = EmbeddedRuntimeMetadata{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_scan_result_proto_msgTypes[12]
+		mi := &file_proto_scan_result_proto_msgTypes[39]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *TargetDetails) String() string {
+func (x *EmbeddedRuntimeMetadata) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*TargetDetails) ProtoMessage() {}
+func (*EmbeddedRuntimeMetadata) ProtoMessage() {}
 
-func (x *TargetDetails) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_scan_result_proto_msgTypes[12]
+func (x *EmbeddedRuntimeMetadata) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_scan_result_proto_msgTypes[39]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1404,52 +4224,53 @@ func (x *TargetDetails) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use TargetDetails.ProtoReflect.Descriptor instead.
-func (*TargetDetails) Descriptor() ([]byte, []int) {
-	return file_proto_scan_result_proto_rawDescGZIP(), []int{12}
+// Deprecated: Use EmbeddedRuntimeMetadata.ProtoReflect.Descriptor instead.
+func (*EmbeddedRuntimeMetadata) Descriptor() ([]byte, []int) {
+	return file_proto_scan_result_proto_rawDescGZIP(), []int{39}
 }
 
-func (x *TargetDetails) GetInventory() *Inventory {
+func (x *EmbeddedRuntimeMetadata) GetEvidence() string {
 	if x != nil {
-		return x.Inventory
+		return x.Evidence
 	}
-	return nil
+	return ""
 }
 
-func (x *TargetDetails) GetLocation() []string {
+func (x *EmbeddedRuntimeMetadata) GetConfidence() string {
 	if x != nil {
-		return x.Location
+		return x.Confidence
 	}
-	return nil
+	return ""
 }
 
-// The additional data found in python packages.
-type PythonPackageMetadata struct {
+// The additional data for PURLs and CPEs that vendors embed in their binaries.
+// Only one of the fields is set.
+type EmbeddedIdMetadata struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Author      string `protobuf:"bytes,1,opt,name=author,proto3" json:"author,omitempty"`
-	AuthorEmail string `protobuf:"bytes,2,opt,name=author_email,json=authorEmail,proto3" json:"author_email,omitempty"`
+	Purl *Purl  `protobuf:"bytes,1,opt,name=purl,proto3" json:"purl,omitempty"`
+	Cpe  string `protobuf:"bytes,2,opt,name=cpe,proto3" json:"cpe,omitempty"`
 }
 
-func (x *PythonPackageMetadata) Reset() {
-	*x = PythonPackageMetadata{}
+func (x *EmbeddedIdMetadata) Reset() {
+	*x = EmbeddedIdMetadata{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_scan_result_proto_msgTypes[13]
+		mi := &file_proto_scan_result_proto_msgTypes[40]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *PythonPackageMetadata) String() string {
+func (x *EmbeddedIdMetadata) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PythonPackageMetadata) ProtoMessage() {}
+func (*EmbeddedIdMetadata) ProtoMessage() {}
 
-func (x *PythonPackageMetadata) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_scan_result_proto_msgTypes[13]
+func (x *EmbeddedIdMetadata) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_scan_result_proto_msgTypes[40]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1460,53 +4281,56 @@ func (x *PythonPackageMetadata) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PythonPackageMetadata.ProtoReflect.Descriptor instead.
-func (*PythonPackageMetadata) Descriptor() ([]byte, []int) {
-	return file_proto_scan_result_proto_rawDescGZIP(), []int{13}
+// Deprecated: Use EmbeddedIdMetadata.ProtoReflect.Descriptor instead.
+func (*EmbeddedIdMetadata) Descriptor() ([]byte, []int) {
+	return file_proto_scan_result_proto_rawDescGZIP(), []int{40}
 }
 
-func (x *PythonPackageMetadata) GetAuthor() string {
+func (x *EmbeddedIdMetadata) GetPurl() *Purl {
 	if x != nil {
-		return x.Author
+		return x.Purl
 	}
-	return ""
+	return nil
 }
 
-func (x *PythonPackageMetadata) GetAuthorEmail() string {
+func (x *EmbeddedIdMetadata) GetCpe() string {
 	if x != nil {
-		return x.AuthorEmail
+		return x.Cpe
 	}
 	return ""
 }
 
-// The additional data found in npm packages.
-type JavascriptPackageJSONMetadata struct {
+// The additional data for Helm charts and their locked dependencies.
+type HelmChartMetadata struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Author       string   `protobuf:"bytes,1,opt,name=author,proto3" json:"author,omitempty"`
-	Maintainers  []string `protobuf:"bytes,2,rep,name=maintainers,proto3" json:"maintainers,omitempty"`
-	Contributors []string `protobuf:"bytes,3,rep,name=contributors,proto3" json:"contributors,omitempty"`
+	AppVersion string `protobuf:"bytes,1,opt,name=app_version,json=appVersion,proto3" json:"app_version,omitempty"`
+	ApiVersion string `protobuf:"bytes,2,opt,name=api_version,json=apiVersion,proto3" json:"api_version,omitempty"`
+	// The repository a locked dependency is fetched from.
+	Repository string `protobuf:"bytes,3,opt,name=repository,proto3" json:"repository,omitempty"`
+	// The name of the chart that depends on this one, if any.
+	ParentChart string `protobuf:"bytes,4,opt,name=parent_chart,json=parentChart,proto3" json:"parent_chart,omitempty"`
 }
 
-func (x *JavascriptPackageJSONMetadata) Reset() {
-	*x = JavascriptPackageJSONMetadata{}
+func (x *HelmChartMetadata) Reset() {
+	*x = HelmChartMetadata{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_scan_result_proto_msgTypes[14]
+		mi := &file_proto_scan_result_proto_msgTypes[41]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *JavascriptPackageJSONMetadata) String() string {
+func (x *HelmChartMetadata) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*JavascriptPackageJSONMetadata) ProtoMessage() {}
+func (*HelmChartMetadata) ProtoMessage() {}
 
-func (x *JavascriptPackageJSONMetadata) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_scan_result_proto_msgTypes[14]
+func (x *HelmChartMetadata) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_scan_result_proto_msgTypes[41]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1517,64 +4341,72 @@ func (x *JavascriptPackageJSONMetadata) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use JavascriptPackageJSONMetadata.ProtoReflect.Descriptor instead.
-func (*JavascriptPackageJSONMetadata) Descriptor() ([]byte, []int) {
-	return file_proto_scan_result_proto_rawDescGZIP(), []int{14}
+// Deprecated: Use HelmChartMetadata.ProtoReflect.Descriptor instead.
+func (*HelmChartMetadata) Descriptor() ([]byte, []int) {
+	return file_proto_scan_result_proto_rawDescGZIP(), []int{41}
 }
 
-func (x *JavascriptPackageJSONMetadata) GetAuthor() string {
+func (x *HelmChartMetadata) GetAppVersion() string {
 	if x != nil {
-		return x.Author
+		return x.AppVersion
 	}
 	return ""
 }
 
-func (x *JavascriptPackageJSONMetadata) GetMaintainers() []string {
+func (x *HelmChartMetadata) GetApiVersion() string {
 	if x != nil {
-		return x.Maintainers
+		return x.ApiVersion
 	}
-	return nil
+	return ""
 }
 
-func (x *JavascriptPackageJSONMetadata) GetContributors() []string {
+func (x *HelmChartMetadata) GetRepository() string {
 	if x != nil {
-		return x.Contributors
+		return x.Repository
 	}
-	return nil
+	return ""
 }
 
-// The additional data found in APK packages.
-type APKPackageMetadata struct {
+func (x *HelmChartMetadata) GetParentChart() string {
+	if x != nil {
+		return x.ParentChart
+	}
+	return ""
+}
+
+// The additional data for images of Docker Compose services.
+type DockerComposeMetadata struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	PackageName  string `protobuf:"bytes,1,opt,name=package_name,json=packageName,proto3" json:"package_name,omitempty"`
-	OriginName   string `protobuf:"bytes,2,opt,name=origin_name,json=originName,proto3" json:"origin_name,omitempty"`
-	OsId         string `protobuf:"bytes,3,opt,name=os_id,json=osId,proto3" json:"os_id,omitempty"`
-	OsVersionId  string `protobuf:"bytes,4,opt,name=os_version_id,json=osVersionId,proto3" json:"os_version_id,omitempty"`
-	Maintainer   string `protobuf:"bytes,5,opt,name=maintainer,proto3" json:"maintainer,omitempty"`
-	Architecture string `protobuf:"bytes,6,opt,name=architecture,proto3" json:"architecture,omitempty"`
-	License      string `protobuf:"bytes,7,opt,name=license,proto3" json:"license,omitempty"`
+	ServiceName string `protobuf:"bytes,1,opt,name=service_name,json=serviceName,proto3" json:"service_name,omitempty"`
+	// e.g. "docker.io/library/nginx".
+	Repository string `protobuf:"bytes,2,opt,name=repository,proto3" json:"repository,omitempty"`
+	Tag        string `protobuf:"bytes,3,opt,name=tag,proto3" json:"tag,omitempty"`
+	Digest     string `protobuf:"bytes,4,opt,name=digest,proto3" json:"digest,omitempty"`
+	// Set if the image name contains environment variables, which are left
+	// unresolved.
+	Interpolated bool `protobuf:"varint,5,opt,name=interpolated,proto3" json:"interpolated,omitempty"`
 }
 
-func (x *APKPackageMetadata) Reset() {
-	*x = APKPackageMetadata{}
+func (x *DockerComposeMetadata) Reset() {
+	*x = DockerComposeMetadata{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_scan_result_proto_msgTypes[15]
+		mi := &file_proto_scan_result_proto_msgTypes[42]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *APKPackageMetadata) String() string {
+func (x *DockerComposeMetadata) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*APKPackageMetadata) ProtoMessage() {}
+func (*DockerComposeMetadata) ProtoMessage() {}
 
-func (x *APKPackageMetadata) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_scan_result_proto_msgTypes[15]
+func (x *DockerComposeMetadata) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_scan_result_proto_msgTypes[42]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1585,216 +4417,172 @@ func (x *APKPackageMetadata) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use APKPackageMetadata.ProtoReflect.Descriptor instead.
-func (*APKPackageMetadata) Descriptor() ([]byte, []int) {
-	return file_proto_scan_result_proto_rawDescGZIP(), []int{15}
-}
-
-func (x *APKPackageMetadata) GetPackageName() string {
-	if x != nil {
-		return x.PackageName
-	}
-	return ""
-}
-
-func (x *APKPackageMetadata) GetOriginName() string {
-	if x != nil {
-		return x.OriginName
-	}
-	return ""
+// Deprecated: Use DockerComposeMetadata.ProtoReflect.Descriptor instead.
+func (*DockerComposeMetadata) Descriptor() ([]byte, []int) {
+	return file_proto_scan_result_proto_rawDescGZIP(), []int{42}
 }
 
-func (x *APKPackageMetadata) GetOsId() string {
+func (x *DockerComposeMetadata) GetServiceName() string {
 	if x != nil {
-		return x.OsId
+		return x.ServiceName
 	}
 	return ""
 }
 
-func (x *APKPackageMetadata) GetOsVersionId() string {
+func (x *DockerComposeMetadata) GetRepository() string {
 	if x != nil {
-		return x.OsVersionId
+		return x.Repository
 	}
 	return ""
 }
 
-func (x *APKPackageMetadata) GetMaintainer() string {
+func (x *DockerComposeMetadata) GetTag() string {
 	if x != nil {
-		return x.Maintainer
+		return x.Tag
 	}
 	return ""
 }
 
-func (x *APKPackageMetadata) GetArchitecture() string {
+func (x *DockerComposeMetadata) GetDigest() string {
 	if x != nil {
-		return x.Architecture
+		return x.Digest
 	}
 	return ""
 }
 
-func (x *APKPackageMetadata) GetLicense() string {
+func (x *DockerComposeMetadata) GetInterpolated() bool {
 	if x != nil {
-		return x.License
+		return x.Interpolated
 	}
-	return ""
+	return false
 }
 
-// The additional data found in DPKG packages.
-// Next ID: 11
-type DPKGPackageMetadata struct {
+// The additional data for modules required by go.mod files.
+type GoModMetadata struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	PackageName       string `protobuf:"bytes,1,opt,name=package_name,json=packageName,proto3" json:"package_name,omitempty"`
-	SourceName        string `protobuf:"bytes,2,opt,name=source_name,json=sourceName,proto3" json:"source_name,omitempty"`
-	SourceVersion     string `protobuf:"bytes,3,opt,name=source_version,json=sourceVersion,proto3" json:"source_version,omitempty"`
-	PackageVersion    string `protobuf:"bytes,4,opt,name=package_version,json=packageVersion,proto3" json:"package_version,omitempty"`
-	OsId              string `protobuf:"bytes,5,opt,name=os_id,json=osId,proto3" json:"os_id,omitempty"`
-	OsVersionCodename string `protobuf:"bytes,6,opt,name=os_version_codename,json=osVersionCodename,proto3" json:"os_version_codename,omitempty"`
-	OsVersionId       string `protobuf:"bytes,7,opt,name=os_version_id,json=osVersionId,proto3" json:"os_version_id,omitempty"`
-	Maintainer        string `protobuf:"bytes,8,opt,name=maintainer,proto3" json:"maintainer,omitempty"`
-	Architecture      string `protobuf:"bytes,9,opt,name=architecture,proto3" json:"architecture,omitempty"`
-	Status            string `protobuf:"bytes,10,opt,name=status,proto3" json:"status,omitempty"`
+	Indirect bool `protobuf:"varint,1,opt,name=indirect,proto3" json:"indirect,omitempty"`
+	// The module path and version of the require directive. Only set if a
+	// replace directive substitutes the module.
+	RequiredPath    string `protobuf:"bytes,2,opt,name=required_path,json=requiredPath,proto3" json:"required_path,omitempty"`
+	RequiredVersion string `protobuf:"bytes,3,opt,name=required_version,json=requiredVersion,proto3" json:"required_version,omitempty"`
+	// The local directory the module is replaced with.
+	ReplaceDir string `protobuf:"bytes,4,opt,name=replace_dir,json=replaceDir,proto3" json:"replace_dir,omitempty"`
+	// The "h1:" hash of the module in go.sum.
+	Checksum         string `protobuf:"bytes,5,opt,name=checksum,proto3" json:"checksum,omitempty"`
+	MissingFromGoSum bool   `protobuf:"varint,6,opt,name=missing_from_go_sum,json=missingFromGoSum,proto3" json:"missing_from_go_sum,omitempty"`
 }
 
-func (x *DPKGPackageMetadata) Reset() {
-	*x = DPKGPackageMetadata{}
+func (x *GoModMetadata) Reset() {
+	*x = GoModMetadata{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_scan_result_proto_msgTypes[16]
+		mi := &file_proto_scan_result_proto_msgTypes[43]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *DPKGPackageMetadata) String() string {
-	return protoimpl.X.MessageStringOf(x)
-}
-
-func (*DPKGPackageMetadata) ProtoMessage() {}
-
-func (x *DPKGPackageMetadata) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_scan_result_proto_msgTypes[16]
-	if protoimpl.UnsafeEnabled && x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
-	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use DPKGPackageMetadata.ProtoReflect.Descriptor instead.
-func (*DPKGPackageMetadata) Descriptor() ([]byte, []int) {
-	return file_proto_scan_result_proto_rawDescGZIP(), []int{16}
-}
-
-func (x *DPKGPackageMetadata) GetPackageName() string {
-	if x != nil {
-		return x.PackageName
-	}
-	return ""
-}
-
-func (x *DPKGPackageMetadata) GetSourceName() string {
-	if x != nil {
-		return x.SourceName
-	}
-	return ""
+func (x *GoModMetadata) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (x *DPKGPackageMetadata) GetSourceVersion() string {
-	if x != nil {
-		return x.SourceVersion
+func (*GoModMetadata) ProtoMessage() {}
+
+func (x *GoModMetadata) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_scan_result_proto_msgTypes[43]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return ""
+	return mi.MessageOf(x)
 }
 
-func (x *DPKGPackageMetadata) GetPackageVersion() string {
-	if x != nil {
-		return x.PackageVersion
-	}
-	return ""
+// Deprecated: Use GoModMetadata.ProtoReflect.Descriptor instead.
+func (*GoModMetadata) Descriptor() ([]byte, []int) {
+	return file_proto_scan_result_proto_rawDescGZIP(), []int{43}
 }
 
-func (x *DPKGPackageMetadata) GetOsId() string {
+func (x *GoModMetadata) GetIndirect() bool {
 	if x != nil {
-		return x.OsId
+		return x.Indirect
 	}
-	return ""
+	return false
 }
 
-func (x *DPKGPackageMetadata) GetOsVersionCodename() string {
+func (x *GoModMetadata) GetRequiredPath() string {
 	if x != nil {
-		return x.OsVersionCodename
+		return x.RequiredPath
 	}
 	return ""
 }
 
-func (x *DPKGPackageMetadata) GetOsVersionId() string {
+func (x *GoModMetadata) GetRequiredVersion() string {
 	if x != nil {
-		return x.OsVersionId
+		return x.RequiredVersion
 	}
 	return ""
 }
 
-func (x *DPKGPackageMetadata) GetMaintainer() string {
+func (x *GoModMetadata) GetReplaceDir() string {
 	if x != nil {
-		return x.Maintainer
+		return x.ReplaceDir
 	}
 	return ""
 }
 
-func (x *DPKGPackageMetadata) GetArchitecture() string {
+func (x *GoModMetadata) GetChecksum() string {
 	if x != nil {
-		return x.Architecture
+		return x.Checksum
 	}
 	return ""
 }
 
-func (x *DPKGPackageMetadata) GetStatus() string {
+func (x *GoModMetadata) GetMissingFromGoSum() bool {
 	if x != nil {
-		return x.Status
+		return x.MissingFromGoSum
 	}
-	return ""
+	return false
 }
 
-// The additional data found in RPM packages.
-type RPMPackageMetadata struct {
+// The additional data for binaries packed with UPX.
+type UPXMetadata struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	PackageName  string `protobuf:"bytes,1,opt,name=package_name,json=packageName,proto3" json:"package_name,omitempty"`
-	SourceRpm    string `protobuf:"bytes,2,opt,name=source_rpm,json=sourceRpm,proto3" json:"source_rpm,omitempty"`
-	Epoch        int32  `protobuf:"varint,3,opt,name=epoch,proto3" json:"epoch,omitempty"`
-	OsId         string `protobuf:"bytes,4,opt,name=os_id,json=osId,proto3" json:"os_id,omitempty"`
-	OsVersionId  string `protobuf:"bytes,5,opt,name=os_version_id,json=osVersionId,proto3" json:"os_version_id,omitempty"`
-	OsBuildId    string `protobuf:"bytes,6,opt,name=os_build_id,json=osBuildId,proto3" json:"os_build_id,omitempty"`
-	OsName       string `protobuf:"bytes,7,opt,name=os_name,json=osName,proto3" json:"os_name,omitempty"`
-	Vendor       string `protobuf:"bytes,8,opt,name=vendor,proto3" json:"vendor,omitempty"`
-	Architecture string `protobuf:"bytes,9,opt,name=architecture,proto3" json:"architecture,omitempty"`
-	License      string `protobuf:"bytes,10,opt,name=license,proto3" json:"license,omitempty"`
+	Packed bool `protobuf:"varint,1,opt,name=packed,proto3" json:"packed,omitempty"`
+	// Set if the binary has traces of UPX but no intact pack header.
+	HeaderMissing bool  `protobuf:"varint,2,opt,name=header_missing,json=headerMissing,proto3" json:"header_missing,omitempty"`
+	HeaderVersion int32 `protobuf:"varint,3,opt,name=header_version,json=headerVersion,proto3" json:"header_version,omitempty"`
+	// The UPX ID of the executable format.
+	Format int32 `protobuf:"varint,4,opt,name=format,proto3" json:"format,omitempty"`
+	// The compression method, e.g. "LZMA".
+	Method string `protobuf:"bytes,5,opt,name=method,proto3" json:"method,omitempty"`
+	Level  int32  `protobuf:"varint,6,opt,name=level,proto3" json:"level,omitempty"`
 }
 
-func (x *RPMPackageMetadata) Reset() {
-	*x = RPMPackageMetadata{}
+func (x *UPXMetadata) Reset() {
+	*x = UPXMetadata{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_scan_result_proto_msgTypes[17]
+		mi := &file_proto_scan_result_proto_msgTypes[44]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *RPMPackageMetadata) String() string {
+func (x *UPXMetadata) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RPMPackageMetadata) ProtoMessage() {}
+func (*UPXMetadata) ProtoMessage() {}
 
-func (x *RPMPackageMetadata) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_scan_result_proto_msgTypes[17]
+func (x *UPXMetadata) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_scan_result_proto_msgTypes[44]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1805,111 +4593,142 @@ func (x *RPMPackageMetadata) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RPMPackageMetadata.ProtoReflect.Descriptor instead.
-func (*RPMPackageMetadata) Descriptor() ([]byte, []int) {
-	return file_proto_scan_result_proto_rawDescGZIP(), []int{17}
+// Deprecated: Use UPXMetadata.ProtoReflect.Descriptor instead.
+func (*UPXMetadata) Descriptor() ([]byte, []int) {
+	return file_proto_scan_result_proto_rawDescGZIP(), []int{44}
 }
 
-func (x *RPMPackageMetadata) GetPackageName() string {
+func (x *UPXMetadata) GetPacked() bool {
 	if x != nil {
-		return x.PackageName
+		return x.Packed
 	}
-	return ""
+	return false
 }
 
-func (x *RPMPackageMetadata) GetSourceRpm() string {
+func (x *UPXMetadata) GetHeaderMissing() bool {
 	if x != nil {
-		return x.SourceRpm
+		return x.HeaderMissing
 	}
-	return ""
+	return false
 }
 
-func (x *RPMPackageMetadata) GetEpoch() int32 {
+func (x *UPXMetadata) GetHeaderVersion() int32 {
 	if x != nil {
-		return x.Epoch
+		return x.HeaderVersion
 	}
 	return 0
 }
 
-func (x *RPMPackageMetadata) GetOsId() string {
+func (x *UPXMetadata) GetFormat() int32 {
 	if x != nil {
-		return x.OsId
+		return x.Format
 	}
-	return ""
+	return 0
 }
 
-func (x *RPMPackageMetadata) GetOsVersionId() string {
+func (x *UPXMetadata) GetMethod() string {
 	if x != nil {
-		return x.OsVersionId
+		return x.Method
 	}
 	return ""
 }
 
-func (x *RPMPackageMetadata) GetOsBuildId() string {
+func (x *UPXMetadata) GetLevel() int32 {
 	if x != nil {
-		return x.OsBuildId
+		return x.Level
 	}
-	return ""
+	return 0
 }
 
-func (x *RPMPackageMetadata) GetOsName() string {
-	if x != nil {
-		return x.OsName
+// The additional data for the bazel_dep dependencies of Bazel modules.
+type BazelDepMetadata struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Only set if it differs from the module name.
+	RepoName      string `protobuf:"bytes,1,opt,name=repo_name,json=repoName,proto3" json:"repo_name,omitempty"`
+	DevDependency bool   `protobuf:"varint,2,opt,name=dev_dependency,json=devDependency,proto3" json:"dev_dependency,omitempty"`
+}
+
+func (x *BazelDepMetadata) Reset() {
+	*x = BazelDepMetadata{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_scan_result_proto_msgTypes[45]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
 	}
-	return ""
 }
 
-func (x *RPMPackageMetadata) GetVendor() string {
-	if x != nil {
-		return x.Vendor
+func (x *BazelDepMetadata) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BazelDepMetadata) ProtoMessage() {}
+
+func (x *BazelDepMetadata) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_scan_result_proto_msgTypes[45]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return ""
+	return mi.MessageOf(x)
 }
 
-func (x *RPMPackageMetadata) GetArchitecture() string {
+// Deprecated: Use BazelDepMetadata.ProtoReflect.Descriptor instead.
+func (*BazelDepMetadata) Descriptor() ([]byte, []int) {
+	return file_proto_scan_result_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *BazelDepMetadata) GetRepoName() string {
 	if x != nil {
-		return x.Architecture
+		return x.RepoName
 	}
 	return ""
 }
 
-func (x *RPMPackageMetadata) GetLicense() string {
+func (x *BazelDepMetadata) GetDevDependency() bool {
 	if x != nil {
-		return x.License
+		return x.DevDependency
 	}
-	return ""
+	return false
 }
 
-// The additional data found in COS packages.
-type COSPackageMetadata struct {
+// The additional data for git submodules.
+type GitSubmoduleMetadata struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Name        string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Version     string `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
-	Category    string `protobuf:"bytes,3,opt,name=category,proto3" json:"category,omitempty"`
-	OsVersion   string `protobuf:"bytes,4,opt,name=os_version,json=osVersion,proto3" json:"os_version,omitempty"`
-	OsVersionId string `protobuf:"bytes,5,opt,name=os_version_id,json=osVersionId,proto3" json:"os_version_id,omitempty"`
+	// The name of the submodule in the .gitmodules file.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// The path of the submodule relative to the root of the superproject.
+	Path string `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
+	Url  string `protobuf:"bytes,3,opt,name=url,proto3" json:"url,omitempty"`
+	// The branch the submodule tracks, if configured.
+	Branch string `protobuf:"bytes,4,opt,name=branch,proto3" json:"branch,omitempty"`
 }
 
-func (x *COSPackageMetadata) Reset() {
-	*x = COSPackageMetadata{}
+func (x *GitSubmoduleMetadata) Reset() {
+	*x = GitSubmoduleMetadata{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_scan_result_proto_msgTypes[18]
+		mi := &file_proto_scan_result_proto_msgTypes[46]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *COSPackageMetadata) String() string {
+func (x *GitSubmoduleMetadata) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*COSPackageMetadata) ProtoMessage() {}
+func (*GitSubmoduleMetadata) ProtoMessage() {}
 
-func (x *COSPackageMetadata) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_scan_result_proto_msgTypes[18]
+func (x *GitSubmoduleMetadata) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_scan_result_proto_msgTypes[46]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1920,79 +4739,72 @@ func (x *COSPackageMetadata) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use COSPackageMetadata.ProtoReflect.Descriptor instead.
-func (*COSPackageMetadata) Descriptor() ([]byte, []int) {
-	return file_proto_scan_result_proto_rawDescGZIP(), []int{18}
+// Deprecated: Use GitSubmoduleMetadata.ProtoReflect.Descriptor instead.
+func (*GitSubmoduleMetadata) Descriptor() ([]byte, []int) {
+	return file_proto_scan_result_proto_rawDescGZIP(), []int{46}
 }
 
-func (x *COSPackageMetadata) GetName() string {
+func (x *GitSubmoduleMetadata) GetName() string {
 	if x != nil {
 		return x.Name
 	}
 	return ""
 }
 
-func (x *COSPackageMetadata) GetVersion() string {
-	if x != nil {
-		return x.Version
-	}
-	return ""
-}
-
-func (x *COSPackageMetadata) GetCategory() string {
+func (x *GitSubmoduleMetadata) GetPath() string {
 	if x != nil {
-		return x.Category
+		return x.Path
 	}
 	return ""
 }
 
-func (x *COSPackageMetadata) GetOsVersion() string {
+func (x *GitSubmoduleMetadata) GetUrl() string {
 	if x != nil {
-		return x.OsVersion
+		return x.Url
 	}
 	return ""
 }
 
-func (x *COSPackageMetadata) GetOsVersionId() string {
+func (x *GitSubmoduleMetadata) GetBranch() string {
 	if x != nil {
-		return x.OsVersionId
+		return x.Branch
 	}
 	return ""
 }
 
-// The additional data found in SNAP packages.
-type SNAPPackageMetadata struct {
+// The additional data for Linux kernel modules, read from their modinfo.
+type KernelModuleMetadata struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Name              string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Version           string   `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
-	Grade             string   `protobuf:"bytes,3,opt,name=grade,proto3" json:"grade,omitempty"`
-	Type              string   `protobuf:"bytes,4,opt,name=type,proto3" json:"type,omitempty"`
-	Architectures     []string `protobuf:"bytes,5,rep,name=architectures,proto3" json:"architectures,omitempty"`
-	OsId              string   `protobuf:"bytes,6,opt,name=os_id,json=osId,proto3" json:"os_id,omitempty"`
-	OsVersionCodename string   `protobuf:"bytes,7,opt,name=os_version_codename,json=osVersionCodename,proto3" json:"os_version_codename,omitempty"`
-	OsVersionId       string   `protobuf:"bytes,8,opt,name=os_version_id,json=osVersionId,proto3" json:"os_version_id,omitempty"`
+	// The release of the kernel the module is built for, e.g. "6.1.0-18-amd64".
+	KernelRelease string `protobuf:"bytes,1,opt,name=kernel_release,json=kernelRelease,proto3" json:"kernel_release,omitempty"`
+	// The checksum of the module's source code.
+	SrcVersion  string `protobuf:"bytes,2,opt,name=src_version,json=srcVersion,proto3" json:"src_version,omitempty"`
+	License     string `protobuf:"bytes,3,opt,name=license,proto3" json:"license,omitempty"`
+	Description string `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	// The names of the modules the module depends on.
+	Depends []string `protobuf:"bytes,5,rep,name=depends,proto3" json:"depends,omitempty"`
 }
 
-func (x *SNAPPackageMetadata) Reset() {
-	*x = SNAPPackageMetadata{}
+func (x *KernelModuleMetadata) Reset() {
+	*x = KernelModuleMetadata{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_scan_result_proto_msgTypes[19]
+		mi := &file_proto_scan_result_proto_msgTypes[47]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *SNAPPackageMetadata) String() string {
+func (x *KernelModuleMetadata) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SNAPPackageMetadata) ProtoMessage() {}
+func (*KernelModuleMetadata) ProtoMessage() {}
 
-func (x *SNAPPackageMetadata) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_scan_result_proto_msgTypes[19]
+func (x *KernelModuleMetadata) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_scan_result_proto_msgTypes[47]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2003,101 +4815,79 @@ func (x *SNAPPackageMetadata) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SNAPPackageMetadata.ProtoReflect.Descriptor instead.
-func (*SNAPPackageMetadata) Descriptor() ([]byte, []int) {
-	return file_proto_scan_result_proto_rawDescGZIP(), []int{19}
+// Deprecated: Use KernelModuleMetadata.ProtoReflect.Descriptor instead.
+func (*KernelModuleMetadata) Descriptor() ([]byte, []int) {
+	return file_proto_scan_result_proto_rawDescGZIP(), []int{47}
 }
 
-func (x *SNAPPackageMetadata) GetName() string {
+func (x *KernelModuleMetadata) GetKernelRelease() string {
 	if x != nil {
-		return x.Name
+		return x.KernelRelease
 	}
 	return ""
 }
 
-func (x *SNAPPackageMetadata) GetVersion() string {
+func (x *KernelModuleMetadata) GetSrcVersion() string {
 	if x != nil {
-		return x.Version
+		return x.SrcVersion
 	}
 	return ""
 }
 
-func (x *SNAPPackageMetadata) GetGrade() string {
+func (x *KernelModuleMetadata) GetLicense() string {
 	if x != nil {
-		return x.Grade
+		return x.License
 	}
 	return ""
 }
 
-func (x *SNAPPackageMetadata) GetType() string {
+func (x *KernelModuleMetadata) GetDescription() string {
 	if x != nil {
-		return x.Type
+		return x.Description
 	}
 	return ""
 }
 
-func (x *SNAPPackageMetadata) GetArchitectures() []string {
+func (x *KernelModuleMetadata) GetDepends() []string {
 	if x != nil {
-		return x.Architectures
+		return x.Depends
 	}
 	return nil
 }
 
-func (x *SNAPPackageMetadata) GetOsId() string {
-	if x != nil {
-		return x.OsId
-	}
-	return ""
-}
-
-func (x *SNAPPackageMetadata) GetOsVersionCodename() string {
-	if x != nil {
-		return x.OsVersionCodename
-	}
-	return ""
-}
-
-func (x *SNAPPackageMetadata) GetOsVersionId() string {
-	if x != nil {
-		return x.OsVersionId
-	}
-	return ""
-}
-
-// The additional data found in Flatpak packages.
-type FlatpakPackageMetadata struct {
+// The additional data for installed WordPress plugins and themes.
+type WordPressMetadata struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	PackageName    string `protobuf:"bytes,1,opt,name=package_name,json=packageName,proto3" json:"package_name,omitempty"`
-	PackageId      string `protobuf:"bytes,2,opt,name=package_id,json=packageId,proto3" json:"package_id,omitempty"`
-	PackageVersion string `protobuf:"bytes,3,opt,name=package_version,json=packageVersion,proto3" json:"package_version,omitempty"`
-	ReleaseDate    string `protobuf:"bytes,4,opt,name=release_date,json=releaseDate,proto3" json:"release_date,omitempty"`
-	OsName         string `protobuf:"bytes,5,opt,name=os_name,json=osName,proto3" json:"os_name,omitempty"`
-	OsId           string `protobuf:"bytes,6,opt,name=os_id,json=osId,proto3" json:"os_id,omitempty"`
-	OsVersionId    string `protobuf:"bytes,7,opt,name=os_version_id,json=osVersionId,proto3" json:"os_version_id,omitempty"`
-	OsBuildId      string `protobuf:"bytes,8,opt,name=os_build_id,json=osBuildId,proto3" json:"os_build_id,omitempty"`
-	Developer      string `protobuf:"bytes,9,opt,name=developer,proto3" json:"developer,omitempty"`
+	// "plugin" or "theme".
+	Type        string `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	DisplayName string `protobuf:"bytes,2,opt,name=display_name,json=displayName,proto3" json:"display_name,omitempty"`
+	Author      string `protobuf:"bytes,3,opt,name=author,proto3" json:"author,omitempty"`
+	// The minimum WordPress version the plugin or theme requires.
+	RequiresWordpress string `protobuf:"bytes,4,opt,name=requires_wordpress,json=requiresWordpress,proto3" json:"requires_wordpress,omitempty"`
+	// The minimum PHP version the plugin or theme requires.
+	RequiresPhp string `protobuf:"bytes,5,opt,name=requires_php,json=requiresPhp,proto3" json:"requires_php,omitempty"`
 }
 
-func (x *FlatpakPackageMetadata) Reset() {
-	*x = FlatpakPackageMetadata{}
+func (x *WordPressMetadata) Reset() {
+	*x = WordPressMetadata{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_scan_result_proto_msgTypes[20]
+		mi := &file_proto_scan_result_proto_msgTypes[48]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *FlatpakPackageMetadata) String() string {
+func (x *WordPressMetadata) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*FlatpakPackageMetadata) ProtoMessage() {}
+func (*WordPressMetadata) ProtoMessage() {}
 
-func (x *FlatpakPackageMetadata) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_scan_result_proto_msgTypes[20]
+func (x *WordPressMetadata) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_scan_result_proto_msgTypes[48]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2108,101 +4898,135 @@ func (x *FlatpakPackageMetadata) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use FlatpakPackageMetadata.ProtoReflect.Descriptor instead.
-func (*FlatpakPackageMetadata) Descriptor() ([]byte, []int) {
-	return file_proto_scan_result_proto_rawDescGZIP(), []int{20}
+// Deprecated: Use WordPressMetadata.ProtoReflect.Descriptor instead.
+func (*WordPressMetadata) Descriptor() ([]byte, []int) {
+	return file_proto_scan_result_proto_rawDescGZIP(), []int{48}
 }
 
-func (x *FlatpakPackageMetadata) GetPackageName() string {
+func (x *WordPressMetadata) GetType() string {
 	if x != nil {
-		return x.PackageName
+		return x.Type
 	}
 	return ""
 }
 
-func (x *FlatpakPackageMetadata) GetPackageId() string {
+func (x *WordPressMetadata) GetDisplayName() string {
 	if x != nil {
-		return x.PackageId
+		return x.DisplayName
 	}
 	return ""
 }
 
-func (x *FlatpakPackageMetadata) GetPackageVersion() string {
+func (x *WordPressMetadata) GetAuthor() string {
 	if x != nil {
-		return x.PackageVersion
+		return x.Author
 	}
 	return ""
 }
 
-func (x *FlatpakPackageMetadata) GetReleaseDate() string {
+func (x *WordPressMetadata) GetRequiresWordpress() string {
 	if x != nil {
-		return x.ReleaseDate
+		return x.RequiresWordpress
 	}
 	return ""
 }
 
-func (x *FlatpakPackageMetadata) GetOsName() string {
+func (x *WordPressMetadata) GetRequiresPhp() string {
 	if x != nil {
-		return x.OsName
+		return x.RequiresPhp
 	}
 	return ""
 }
 
-func (x *FlatpakPackageMetadata) GetOsId() string {
-	if x != nil {
-		return x.OsId
+// The additional data for packages of Yocto/OpenEmbedded images, read from
+// the license.manifest of the image.
+type YoctoPackageMetadata struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The BitBake recipe that built the package, e.g. "glibc" for "libc6".
+	RecipeName string `protobuf:"bytes,1,opt,name=recipe_name,json=recipeName,proto3" json:"recipe_name,omitempty"`
+	// The license expression of the package, e.g. "GPL-2.0-only & MIT".
+	License string `protobuf:"bytes,2,opt,name=license,proto3" json:"license,omitempty"`
+}
+
+func (x *YoctoPackageMetadata) Reset() {
+	*x = YoctoPackageMetadata{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_scan_result_proto_msgTypes[49]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
 	}
-	return ""
 }
 
-func (x *FlatpakPackageMetadata) GetOsVersionId() string {
-	if x != nil {
-		return x.OsVersionId
+func (x *YoctoPackageMetadata) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*YoctoPackageMetadata) ProtoMessage() {}
+
+func (x *YoctoPackageMetadata) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_scan_result_proto_msgTypes[49]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return ""
+	return mi.MessageOf(x)
 }
 
-func (x *FlatpakPackageMetadata) GetOsBuildId() string {
+// Deprecated: Use YoctoPackageMetadata.ProtoReflect.Descriptor instead.
+func (*YoctoPackageMetadata) Descriptor() ([]byte, []int) {
+	return file_proto_scan_result_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *YoctoPackageMetadata) GetRecipeName() string {
 	if x != nil {
-		return x.OsBuildId
+		return x.RecipeName
 	}
 	return ""
 }
 
-func (x *FlatpakPackageMetadata) GetDeveloper() string {
+func (x *YoctoPackageMetadata) GetLicense() string {
 	if x != nil {
-		return x.Developer
+		return x.License
 	}
 	return ""
 }
 
-// The additional data for packages extracted from SPDX files.
-type SPDXPackageMetadata struct {
+// The additional data for installed browser extensions.
+type BrowserExtensionMetadata struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Purl *Purl    `protobuf:"bytes,1,opt,name=purl,proto3" json:"purl,omitempty"`
-	Cpes []string `protobuf:"bytes,2,rep,name=cpes,proto3" json:"cpes,omitempty"`
+	// The extension ID, e.g. "cjpalhdlnbpafiamejdnhcphjbkeiagm".
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// The browser the extension is installed in, e.g. "chrome" or "firefox".
+	Browser         string `protobuf:"bytes,2,opt,name=browser,proto3" json:"browser,omitempty"`
+	ManifestVersion int32  `protobuf:"varint,3,opt,name=manifest_version,json=manifestVersion,proto3" json:"manifest_version,omitempty"`
 }
 
-func (x *SPDXPackageMetadata) Reset() {
-	*x = SPDXPackageMetadata{}
+func (x *BrowserExtensionMetadata) Reset() {
+	*x = BrowserExtensionMetadata{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_scan_result_proto_msgTypes[21]
+		mi := &file_proto_scan_result_proto_msgTypes[50]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *SPDXPackageMetadata) String() string {
+func (x *BrowserExtensionMetadata) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SPDXPackageMetadata) ProtoMessage() {}
+func (*BrowserExtensionMetadata) ProtoMessage() {}
 
-func (x *SPDXPackageMetadata) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_scan_result_proto_msgTypes[21]
+func (x *BrowserExtensionMetadata) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_scan_result_proto_msgTypes[50]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2213,53 +5037,61 @@ func (x *SPDXPackageMetadata) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SPDXPackageMetadata.ProtoReflect.Descriptor instead.
-func (*SPDXPackageMetadata) Descriptor() ([]byte, []int) {
-	return file_proto_scan_result_proto_rawDescGZIP(), []int{21}
+// Deprecated: Use BrowserExtensionMetadata.ProtoReflect.Descriptor instead.
+func (*BrowserExtensionMetadata) Descriptor() ([]byte, []int) {
+	return file_proto_scan_result_proto_rawDescGZIP(), []int{50}
 }
 
-func (x *SPDXPackageMetadata) GetPurl() *Purl {
+func (x *BrowserExtensionMetadata) GetId() string {
 	if x != nil {
-		return x.Purl
+		return x.Id
 	}
-	return nil
+	return ""
 }
 
-func (x *SPDXPackageMetadata) GetCpes() []string {
+func (x *BrowserExtensionMetadata) GetBrowser() string {
 	if x != nil {
-		return x.Cpes
+		return x.Browser
 	}
-	return nil
+	return ""
 }
 
-// The additional data found in Java JAR packages.
-type JavaArchiveMetadata struct {
+func (x *BrowserExtensionMetadata) GetManifestVersion() int32 {
+	if x != nil {
+		return x.ManifestVersion
+	}
+	return 0
+}
+
+// The additional data for installed Jenkins plugins.
+type JenkinsPluginMetadata struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	ArtifactId string `protobuf:"bytes,2,opt,name=artifact_id,json=artifactId,proto3" json:"artifact_id,omitempty"`
-	GroupId    string `protobuf:"bytes,3,opt,name=group_id,json=groupId,proto3" json:"group_id,omitempty"`
-	Sha1       string `protobuf:"bytes,4,opt,name=sha1,proto3" json:"sha1,omitempty"`
+	LongName string `protobuf:"bytes,1,opt,name=long_name,json=longName,proto3" json:"long_name,omitempty"`
+	GroupId  string `protobuf:"bytes,2,opt,name=group_id,json=groupId,proto3" json:"group_id,omitempty"`
+	// The minimum Jenkins version the plugin requires.
+	JenkinsVersion string `protobuf:"bytes,3,opt,name=jenkins_version,json=jenkinsVersion,proto3" json:"jenkins_version,omitempty"`
 }
 
-func (x *JavaArchiveMetadata) Reset() {
-	*x = JavaArchiveMetadata{}
+func (x *JenkinsPluginMetadata) Reset() {
+	*x = JenkinsPluginMetadata{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_scan_result_proto_msgTypes[22]
+		mi := &file_proto_scan_result_proto_msgTypes[51]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *JavaArchiveMetadata) String() string {
+func (x *JenkinsPluginMetadata) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*JavaArchiveMetadata) ProtoMessage() {}
+func (*JenkinsPluginMetadata) ProtoMessage() {}
 
-func (x *JavaArchiveMetadata) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_scan_result_proto_msgTypes[22]
+func (x *JenkinsPluginMetadata) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_scan_result_proto_msgTypes[51]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2270,61 +5102,60 @@ func (x *JavaArchiveMetadata) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use JavaArchiveMetadata.ProtoReflect.Descriptor instead.
-func (*JavaArchiveMetadata) Descriptor() ([]byte, []int) {
-	return file_proto_scan_result_proto_rawDescGZIP(), []int{22}
+// Deprecated: Use JenkinsPluginMetadata.ProtoReflect.Descriptor instead.
+func (*JenkinsPluginMetadata) Descriptor() ([]byte, []int) {
+	return file_proto_scan_result_proto_rawDescGZIP(), []int{51}
 }
 
-func (x *JavaArchiveMetadata) GetArtifactId() string {
+func (x *JenkinsPluginMetadata) GetLongName() string {
 	if x != nil {
-		return x.ArtifactId
+		return x.LongName
 	}
 	return ""
 }
 
-func (x *JavaArchiveMetadata) GetGroupId() string {
+func (x *JenkinsPluginMetadata) GetGroupId() string {
 	if x != nil {
 		return x.GroupId
 	}
 	return ""
 }
 
-func (x *JavaArchiveMetadata) GetSha1() string {
+func (x *JenkinsPluginMetadata) GetJenkinsVersion() string {
 	if x != nil {
-		return x.Sha1
+		return x.JenkinsVersion
 	}
 	return ""
 }
 
-// The additional data for packages extracted by an OSV extractor wrapper.
-type OSVPackageMetadata struct {
+// The additional data for Android apps found in APK files.
+type AndroidAPKMetadata struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	PurlType  string `protobuf:"bytes,1,opt,name=purl_type,json=purlType,proto3" json:"purl_type,omitempty"`
-	Commit    string `protobuf:"bytes,2,opt,name=commit,proto3" json:"commit,omitempty"`
-	Ecosystem string `protobuf:"bytes,3,opt,name=ecosystem,proto3" json:"ecosystem,omitempty"`
-	CompareAs string `protobuf:"bytes,4,opt,name=compare_as,json=compareAs,proto3" json:"compare_as,omitempty"`
+	// The user-visible version, empty if it references a string resource.
+	VersionName string `protobuf:"bytes,1,opt,name=version_name,json=versionName,proto3" json:"version_name,omitempty"`
+	VersionCode string `protobuf:"bytes,2,opt,name=version_code,json=versionCode,proto3" json:"version_code,omitempty"`
 }
 
-func (x *OSVPackageMetadata) Reset() {
-	*x = OSVPackageMetadata{}
+func (x *AndroidAPKMetadata) Reset() {
+	*x = AndroidAPKMetadata{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_scan_result_proto_msgTypes[23]
+		mi := &file_proto_scan_result_proto_msgTypes[52]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *OSVPackageMetadata) String() string {
+func (x *AndroidAPKMetadata) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*OSVPackageMetadata) ProtoMessage() {}
+func (*AndroidAPKMetadata) ProtoMessage() {}
 
-func (x *OSVPackageMetadata) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_scan_result_proto_msgTypes[23]
+func (x *AndroidAPKMetadata) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_scan_result_proto_msgTypes[52]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2335,65 +5166,52 @@ func (x *OSVPackageMetadata) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use OSVPackageMetadata.ProtoReflect.Descriptor instead.
-func (*OSVPackageMetadata) Descriptor() ([]byte, []int) {
-	return file_proto_scan_result_proto_rawDescGZIP(), []int{23}
-}
-
-func (x *OSVPackageMetadata) GetPurlType() string {
-	if x != nil {
-		return x.PurlType
-	}
-	return ""
-}
-
-func (x *OSVPackageMetadata) GetCommit() string {
-	if x != nil {
-		return x.Commit
-	}
-	return ""
+// Deprecated: Use AndroidAPKMetadata.ProtoReflect.Descriptor instead.
+func (*AndroidAPKMetadata) Descriptor() ([]byte, []int) {
+	return file_proto_scan_result_proto_rawDescGZIP(), []int{52}
 }
 
-func (x *OSVPackageMetadata) GetEcosystem() string {
+func (x *AndroidAPKMetadata) GetVersionName() string {
 	if x != nil {
-		return x.Ecosystem
+		return x.VersionName
 	}
 	return ""
 }
 
-func (x *OSVPackageMetadata) GetCompareAs() string {
+func (x *AndroidAPKMetadata) GetVersionCode() string {
 	if x != nil {
-		return x.CompareAs
+		return x.VersionCode
 	}
 	return ""
 }
 
-type PythonRequirementsMetadata struct {
+// The additional data for NuGet packages found in .NET project files.
+type NuGetMetadata struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	HashCheckingModeValues []string `protobuf:"bytes,1,rep,name=hash_checking_mode_values,json=hashCheckingModeValues,proto3" json:"hash_checking_mode_values,omitempty"`
-	VersionComparator      string   `protobuf:"bytes,2,opt,name=version_comparator,json=versionComparator,proto3" json:"version_comparator,omitempty"`
+	// "direct" or "transitive", empty if the file doesn't tell.
+	DependencyType string `protobuf:"bytes,1,opt,name=dependency_type,json=dependencyType,proto3" json:"dependency_type,omitempty"`
 }
 
-func (x *PythonRequirementsMetadata) Reset() {
-	*x = PythonRequirementsMetadata{}
+func (x *NuGetMetadata) Reset() {
+	*x = NuGetMetadata{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_scan_result_proto_msgTypes[24]
+		mi := &file_proto_scan_result_proto_msgTypes[53]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *PythonRequirementsMetadata) String() string {
+func (x *NuGetMetadata) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PythonRequirementsMetadata) ProtoMessage() {}
+func (*NuGetMetadata) ProtoMessage() {}
 
-func (x *PythonRequirementsMetadata) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_scan_result_proto_msgTypes[24]
+func (x *NuGetMetadata) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_scan_result_proto_msgTypes[53]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2404,21 +5222,14 @@ func (x *PythonRequirementsMetadata) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PythonRequirementsMetadata.ProtoReflect.Descriptor instead.
-func (*PythonRequirementsMetadata) Descriptor() ([]byte, []int) {
-	return file_proto_scan_result_proto_rawDescGZIP(), []int{24}
-}
-
-func (x *PythonRequirementsMetadata) GetHashCheckingModeValues() []string {
-	if x != nil {
-		return x.HashCheckingModeValues
-	}
-	return nil
+// Deprecated: Use NuGetMetadata.ProtoReflect.Descriptor instead.
+func (*NuGetMetadata) Descriptor() ([]byte, []int) {
+	return file_proto_scan_result_proto_rawDescGZIP(), []int{53}
 }
 
-func (x *PythonRequirementsMetadata) GetVersionComparator() string {
+func (x *NuGetMetadata) GetDependencyType() string {
 	if x != nil {
-		return x.VersionComparator
+		return x.DependencyType
 	}
 	return ""
 }
@@ -2438,7 +5249,7 @@ type ContainerdContainerMetadata struct {
 func (x *ContainerdContainerMetadata) Reset() {
 	*x = ContainerdContainerMetadata{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_scan_result_proto_msgTypes[25]
+		mi := &file_proto_scan_result_proto_msgTypes[54]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2451,7 +5262,7 @@ func (x *ContainerdContainerMetadata) String() string {
 func (*ContainerdContainerMetadata) ProtoMessage() {}
 
 func (x *ContainerdContainerMetadata) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_scan_result_proto_msgTypes[25]
+	mi := &file_proto_scan_result_proto_msgTypes[54]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2464,7 +5275,7 @@ func (x *ContainerdContainerMetadata) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ContainerdContainerMetadata.ProtoReflect.Descriptor instead.
 func (*ContainerdContainerMetadata) Descriptor() ([]byte, []int) {
-	return file_proto_scan_result_proto_rawDescGZIP(), []int{25}
+	return file_proto_scan_result_proto_rawDescGZIP(), []int{54}
 }
 
 func (x *ContainerdContainerMetadata) GetNamespaceName() string {
@@ -2519,7 +5330,7 @@ type ContainerdRuntimeContainerMetadata struct {
 func (x *ContainerdRuntimeContainerMetadata) Reset() {
 	*x = ContainerdRuntimeContainerMetadata{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_scan_result_proto_msgTypes[26]
+		mi := &file_proto_scan_result_proto_msgTypes[55]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2532,7 +5343,7 @@ func (x *ContainerdRuntimeContainerMetadata) String() string {
 func (*ContainerdRuntimeContainerMetadata) ProtoMessage() {}
 
 func (x *ContainerdRuntimeContainerMetadata) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_scan_result_proto_msgTypes[26]
+	mi := &file_proto_scan_result_proto_msgTypes[55]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2545,7 +5356,7 @@ func (x *ContainerdRuntimeContainerMetadata) ProtoReflect() protoreflect.Message
 
 // Deprecated: Use ContainerdRuntimeContainerMetadata.ProtoReflect.Descriptor instead.
 func (*ContainerdRuntimeContainerMetadata) Descriptor() ([]byte, []int) {
-	return file_proto_scan_result_proto_rawDescGZIP(), []int{26}
+	return file_proto_scan_result_proto_rawDescGZIP(), []int{55}
 }
 
 func (x *ContainerdRuntimeContainerMetadata) GetNamespaceName() string {
@@ -2604,7 +5415,7 @@ var file_proto_scan_result_proto_rawDesc = []byte{
 	0x75, 0x6c, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x07, 0x73, 0x63, 0x61, 0x6c, 0x69,
 	0x62, 0x72, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f,
 	0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x22, 0xe5, 0x02, 0x0a, 0x0a, 0x53, 0x63, 0x61, 0x6e, 0x52, 0x65, 0x73, 0x75,
+	0x6f, 0x74, 0x6f, 0x22, 0x95, 0x05, 0x0a, 0x0a, 0x53, 0x63, 0x61, 0x6e, 0x52, 0x65, 0x73, 0x75,
 	0x6c, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20,
 	0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x39, 0x0a, 0x0a,
 	0x73, 0x74, 0x61, 0x72, 0x74, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
@@ -2626,127 +5437,357 @@ var file_proto_scan_result_proto_rawDesc = []byte{
 	0x52, 0x0b, 0x69, 0x6e, 0x76, 0x65, 0x6e, 0x74, 0x6f, 0x72, 0x69, 0x65, 0x73, 0x12, 0x2c, 0x0a,
 	0x08, 0x66, 0x69, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32,
 	0x10, 0x2e, 0x73, 0x63, 0x61, 0x6c, 0x69, 0x62, 0x72, 0x2e, 0x46, 0x69, 0x6e, 0x64, 0x69, 0x6e,
-	0x67, 0x52, 0x08, 0x66, 0x69, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x73, 0x22, 0xc6, 0x01, 0x0a, 0x0a,
-	0x53, 0x63, 0x61, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x3a, 0x0a, 0x06, 0x73, 0x74,
-	0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x22, 0x2e, 0x73, 0x63, 0x61,
-	0x6c, 0x69, 0x62, 0x72, 0x2e, 0x53, 0x63, 0x61, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x2e,
-	0x53, 0x63, 0x61, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x45, 0x6e, 0x75, 0x6d, 0x52, 0x06,
-	0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x25, 0x0a, 0x0e, 0x66, 0x61, 0x69, 0x6c, 0x75, 0x72,
-	0x65, 0x5f, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d,
-	0x66, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x22, 0x55, 0x0a,
-	0x0e, 0x53, 0x63, 0x61, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x45, 0x6e, 0x75, 0x6d, 0x12,
-	0x0f, 0x0a, 0x0b, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00,
-	0x12, 0x0d, 0x0a, 0x09, 0x53, 0x55, 0x43, 0x43, 0x45, 0x45, 0x44, 0x45, 0x44, 0x10, 0x01, 0x12,
-	0x17, 0x0a, 0x13, 0x50, 0x41, 0x52, 0x54, 0x49, 0x41, 0x4c, 0x4c, 0x59, 0x5f, 0x53, 0x55, 0x43,
-	0x43, 0x45, 0x45, 0x44, 0x45, 0x44, 0x10, 0x02, 0x12, 0x0a, 0x0a, 0x06, 0x46, 0x41, 0x49, 0x4c,
-	0x45, 0x44, 0x10, 0x03, 0x22, 0x69, 0x0a, 0x0c, 0x50, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x53, 0x74,
-	0x61, 0x74, 0x75, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x67, 0x52, 0x08, 0x66, 0x69, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x73, 0x12, 0x31, 0x0a, 0x0a, 0x6f,
+	0x73, 0x5f, 0x72, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x12, 0x2e, 0x73, 0x63, 0x61, 0x6c, 0x69, 0x62, 0x72, 0x2e, 0x4f, 0x53, 0x52, 0x65, 0x6c, 0x65,
+	0x61, 0x73, 0x65, 0x52, 0x09, 0x6f, 0x73, 0x52, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x12, 0x12,
+	0x0a, 0x04, 0x68, 0x6f, 0x73, 0x74, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x68, 0x6f,
+	0x73, 0x74, 0x12, 0x40, 0x0a, 0x0f, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x5f,
+	0x69, 0x6d, 0x61, 0x67, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x73, 0x63,
+	0x61, 0x6c, 0x69, 0x62, 0x72, 0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x49,
+	0x6d, 0x61, 0x67, 0x65, 0x52, 0x0e, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x49,
+	0x6d, 0x61, 0x67, 0x65, 0x12, 0x29, 0x0a, 0x10, 0x70, 0x75, 0x72, 0x6c, 0x5f, 0x66, 0x69, 0x6e,
+	0x67, 0x65, 0x72, 0x70, 0x72, 0x69, 0x6e, 0x74, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f,
+	0x70, 0x75, 0x72, 0x6c, 0x46, 0x69, 0x6e, 0x67, 0x65, 0x72, 0x70, 0x72, 0x69, 0x6e, 0x74, 0x12,
+	0x3d, 0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x0c, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x21, 0x2e, 0x73, 0x63, 0x61, 0x6c, 0x69, 0x62, 0x72, 0x2e, 0x53, 0x63, 0x61, 0x6e,
+	0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x2e, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x52, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x1a, 0x3b,
+	0x0a, 0x0d, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12,
+	0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65,
+	0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xbe, 0x01, 0x0a, 0x0e,
+	0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x12, 0x34,
+	0x0a, 0x07, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x07, 0x63, 0x72, 0x65,
+	0x61, 0x74, 0x65, 0x64, 0x12, 0x3b, 0x0a, 0x06, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x18, 0x02,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x73, 0x63, 0x61, 0x6c, 0x69, 0x62, 0x72, 0x2e, 0x43,
+	0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x2e, 0x4c, 0x61,
+	0x62, 0x65, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x06, 0x6c, 0x61, 0x62, 0x65, 0x6c,
+	0x73, 0x1a, 0x39, 0x0a, 0x0b, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79,
+	0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b,
+	0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x86, 0x01, 0x0a,
+	0x09, 0x4f, 0x53, 0x52, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x76, 0x65,
+	0x72, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09,
+	0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12, 0x29, 0x0a, 0x10, 0x76, 0x65, 0x72,
+	0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x64, 0x65,
+	0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x70, 0x72, 0x65, 0x74, 0x74, 0x79, 0x5f, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x70, 0x72, 0x65, 0x74, 0x74,
+	0x79, 0x4e, 0x61, 0x6d, 0x65, 0x22, 0xc3, 0x03, 0x0a, 0x0a, 0x53, 0x63, 0x61, 0x6e, 0x53, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x12, 0x3a, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0e, 0x32, 0x22, 0x2e, 0x73, 0x63, 0x61, 0x6c, 0x69, 0x62, 0x72, 0x2e, 0x53,
+	0x63, 0x61, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x2e, 0x53, 0x63, 0x61, 0x6e, 0x53, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x45, 0x6e, 0x75, 0x6d, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x12, 0x25, 0x0a, 0x0e, 0x66, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x5f, 0x72, 0x65, 0x61, 0x73,
+	0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x66, 0x61, 0x69, 0x6c, 0x75, 0x72,
+	0x65, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x12, 0x29, 0x0a, 0x10, 0x6d, 0x61, 0x6c, 0x66, 0x6f,
+	0x72, 0x6d, 0x65, 0x64, 0x5f, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x0f, 0x6d, 0x61, 0x6c, 0x66, 0x6f, 0x72, 0x6d, 0x65, 0x64, 0x49, 0x6e, 0x70, 0x75,
+	0x74, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x72, 0x75, 0x6e, 0x63, 0x61, 0x74, 0x65, 0x64, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x74, 0x72, 0x75, 0x6e, 0x63, 0x61, 0x74, 0x65, 0x64,
+	0x12, 0x2b, 0x0a, 0x11, 0x64, 0x72, 0x6f, 0x70, 0x70, 0x65, 0x64, 0x5f, 0x69, 0x6e, 0x76, 0x65,
+	0x6e, 0x74, 0x6f, 0x72, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x10, 0x64, 0x72, 0x6f,
+	0x70, 0x70, 0x65, 0x64, 0x49, 0x6e, 0x76, 0x65, 0x6e, 0x74, 0x6f, 0x72, 0x79, 0x12, 0x27, 0x0a,
+	0x0f, 0x69, 0x6e, 0x76, 0x65, 0x6e, 0x74, 0x6f, 0x72, 0x79, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0e, 0x69, 0x6e, 0x76, 0x65, 0x6e, 0x74, 0x6f, 0x72,
+	0x79, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x26, 0x0a, 0x0f, 0x74, 0x69, 0x6d, 0x65, 0x64, 0x5f,
+	0x6f, 0x75, 0x74, 0x5f, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x0d, 0x74, 0x69, 0x6d, 0x65, 0x64, 0x4f, 0x75, 0x74, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x12, 0x27,
+	0x0a, 0x0f, 0x6f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x7a, 0x65, 0x64, 0x5f, 0x66, 0x69, 0x6c, 0x65,
+	0x73, 0x18, 0x08, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0e, 0x6f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x7a,
+	0x65, 0x64, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x22, 0x62, 0x0a, 0x0e, 0x53, 0x63, 0x61, 0x6e, 0x53,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x45, 0x6e, 0x75, 0x6d, 0x12, 0x0f, 0x0a, 0x0b, 0x55, 0x4e, 0x53,
+	0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x0d, 0x0a, 0x09, 0x53, 0x55,
+	0x43, 0x43, 0x45, 0x45, 0x44, 0x45, 0x44, 0x10, 0x01, 0x12, 0x17, 0x0a, 0x13, 0x50, 0x41, 0x52,
+	0x54, 0x49, 0x41, 0x4c, 0x4c, 0x59, 0x5f, 0x53, 0x55, 0x43, 0x43, 0x45, 0x45, 0x44, 0x45, 0x44,
+	0x10, 0x02, 0x12, 0x0a, 0x0a, 0x06, 0x46, 0x41, 0x49, 0x4c, 0x45, 0x44, 0x10, 0x03, 0x12, 0x0b,
+	0x0a, 0x07, 0x53, 0x4b, 0x49, 0x50, 0x50, 0x45, 0x44, 0x10, 0x04, 0x22, 0x69, 0x0a, 0x0c, 0x50,
+	0x6c, 0x75, 0x67, 0x69, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12,
+	0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x2b, 0x0a, 0x06, 0x73, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x73, 0x63, 0x61, 0x6c,
+	0x69, 0x62, 0x72, 0x2e, 0x53, 0x63, 0x61, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06,
+	0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0xc7, 0x1f, 0x0a, 0x09, 0x49, 0x6e, 0x76, 0x65, 0x6e,
+	0x74, 0x6f, 0x72, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x0b, 0x20, 0x01,
 	0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73,
-	0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69,
-	0x6f, 0x6e, 0x12, 0x2b, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x03, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x13, 0x2e, 0x73, 0x63, 0x61, 0x6c, 0x69, 0x62, 0x72, 0x2e, 0x53, 0x63, 0x61,
-	0x6e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22,
-	0xcc, 0x0c, 0x0a, 0x09, 0x49, 0x6e, 0x76, 0x65, 0x6e, 0x74, 0x6f, 0x72, 0x79, 0x12, 0x12, 0x0a,
-	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d,
-	0x65, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x0c, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x3e, 0x0a, 0x0b, 0x73,
-	0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x1a, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x1d, 0x2e, 0x73, 0x63, 0x61, 0x6c, 0x69, 0x62, 0x72, 0x2e, 0x53, 0x6f, 0x75, 0x72, 0x63,
-	0x65, 0x43, 0x6f, 0x64, 0x65, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x69, 0x65, 0x72, 0x52,
-	0x0a, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x21, 0x0a, 0x04, 0x70,
-	0x75, 0x72, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x73, 0x63, 0x61, 0x6c,
-	0x69, 0x62, 0x72, 0x2e, 0x50, 0x75, 0x72, 0x6c, 0x52, 0x04, 0x70, 0x75, 0x72, 0x6c, 0x12, 0x12,
-	0x0a, 0x04, 0x63, 0x70, 0x65, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x63, 0x70,
-	0x65, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x65, 0x63, 0x6f, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x18,
-	0x1b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x65, 0x63, 0x6f, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d,
-	0x12, 0x1c, 0x0a, 0x09, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x02, 0x20,
-	0x03, 0x28, 0x09, 0x52, 0x09, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x1c,
-	0x0a, 0x09, 0x65, 0x78, 0x74, 0x72, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x18, 0x0a, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x09, 0x65, 0x78, 0x74, 0x72, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x12, 0x49, 0x0a, 0x0f,
-	0x70, 0x79, 0x74, 0x68, 0x6f, 0x6e, 0x5f, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18,
-	0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x73, 0x63, 0x61, 0x6c, 0x69, 0x62, 0x72, 0x2e,
-	0x50, 0x79, 0x74, 0x68, 0x6f, 0x6e, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x4d, 0x65, 0x74,
-	0x61, 0x64, 0x61, 0x74, 0x61, 0x48, 0x00, 0x52, 0x0e, 0x70, 0x79, 0x74, 0x68, 0x6f, 0x6e, 0x4d,
-	0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x59, 0x0a, 0x13, 0x6a, 0x61, 0x76, 0x61, 0x73,
-	0x63, 0x72, 0x69, 0x70, 0x74, 0x5f, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x06,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x26, 0x2e, 0x73, 0x63, 0x61, 0x6c, 0x69, 0x62, 0x72, 0x2e, 0x4a,
-	0x61, 0x76, 0x61, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65,
-	0x4a, 0x53, 0x4f, 0x4e, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x48, 0x00, 0x52, 0x12,
-	0x6a, 0x61, 0x76, 0x61, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61,
-	0x74, 0x61, 0x12, 0x40, 0x0a, 0x0c, 0x61, 0x70, 0x6b, 0x5f, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61,
-	0x74, 0x61, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x73, 0x63, 0x61, 0x6c, 0x69,
-	0x62, 0x72, 0x2e, 0x41, 0x50, 0x4b, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x4d, 0x65, 0x74,
-	0x61, 0x64, 0x61, 0x74, 0x61, 0x48, 0x00, 0x52, 0x0b, 0x61, 0x70, 0x6b, 0x4d, 0x65, 0x74, 0x61,
-	0x64, 0x61, 0x74, 0x61, 0x12, 0x43, 0x0a, 0x0d, 0x64, 0x70, 0x6b, 0x67, 0x5f, 0x6d, 0x65, 0x74,
-	0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x73, 0x63,
-	0x61, 0x6c, 0x69, 0x62, 0x72, 0x2e, 0x44, 0x50, 0x4b, 0x47, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67,
-	0x65, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x48, 0x00, 0x52, 0x0c, 0x64, 0x70, 0x6b,
-	0x67, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x40, 0x0a, 0x0c, 0x72, 0x70, 0x6d,
-	0x5f, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x1b, 0x2e, 0x73, 0x63, 0x61, 0x6c, 0x69, 0x62, 0x72, 0x2e, 0x52, 0x50, 0x4d, 0x50, 0x61, 0x63,
-	0x6b, 0x61, 0x67, 0x65, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x48, 0x00, 0x52, 0x0b,
-	0x72, 0x70, 0x6d, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x40, 0x0a, 0x0c, 0x63,
-	0x6f, 0x73, 0x5f, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x0d, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x1b, 0x2e, 0x73, 0x63, 0x61, 0x6c, 0x69, 0x62, 0x72, 0x2e, 0x43, 0x4f, 0x53, 0x50,
+	0x69, 0x6f, 0x6e, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69,
+	0x6f, 0x6e, 0x12, 0x3e, 0x0a, 0x0b, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x63, 0x6f, 0x64,
+	0x65, 0x18, 0x1a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x73, 0x63, 0x61, 0x6c, 0x69, 0x62,
+	0x72, 0x2e, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x43, 0x6f, 0x64, 0x65, 0x49, 0x64, 0x65, 0x6e,
+	0x74, 0x69, 0x66, 0x69, 0x65, 0x72, 0x52, 0x0a, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x43, 0x6f,
+	0x64, 0x65, 0x12, 0x21, 0x0a, 0x04, 0x70, 0x75, 0x72, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x0d, 0x2e, 0x73, 0x63, 0x61, 0x6c, 0x69, 0x62, 0x72, 0x2e, 0x50, 0x75, 0x72, 0x6c, 0x52,
+	0x04, 0x70, 0x75, 0x72, 0x6c, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x70, 0x65, 0x73, 0x18, 0x04, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x04, 0x63, 0x70, 0x65, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x65, 0x63, 0x6f,
+	0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x18, 0x1b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x65, 0x63,
+	0x6f, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x12, 0x1c, 0x0a, 0x09, 0x6c, 0x6f, 0x63, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x09, 0x6c, 0x6f, 0x63, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x3c, 0x0a, 0x10, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x5f, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x18, 0x2e, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x11, 0x2e, 0x73, 0x63, 0x61, 0x6c, 0x69, 0x62, 0x72, 0x2e, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x52, 0x0f, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x44, 0x65, 0x74, 0x61,
+	0x69, 0x6c, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x65, 0x78, 0x74, 0x72, 0x61, 0x63, 0x74, 0x6f, 0x72,
+	0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x65, 0x78, 0x74, 0x72, 0x61, 0x63, 0x74, 0x6f,
+	0x72, 0x12, 0x1b, 0x0a, 0x09, 0x73, 0x63, 0x61, 0x6e, 0x5f, 0x72, 0x6f, 0x6f, 0x74, 0x18, 0x33,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x73, 0x63, 0x61, 0x6e, 0x52, 0x6f, 0x6f, 0x74, 0x12, 0x49,
+	0x0a, 0x0f, 0x70, 0x79, 0x74, 0x68, 0x6f, 0x6e, 0x5f, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74,
+	0x61, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x73, 0x63, 0x61, 0x6c, 0x69, 0x62,
+	0x72, 0x2e, 0x50, 0x79, 0x74, 0x68, 0x6f, 0x6e, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x4d,
+	0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x48, 0x00, 0x52, 0x0e, 0x70, 0x79, 0x74, 0x68, 0x6f,
+	0x6e, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x59, 0x0a, 0x13, 0x6a, 0x61, 0x76,
+	0x61, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x5f, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x26, 0x2e, 0x73, 0x63, 0x61, 0x6c, 0x69, 0x62, 0x72,
+	0x2e, 0x4a, 0x61, 0x76, 0x61, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x50, 0x61, 0x63, 0x6b, 0x61,
+	0x67, 0x65, 0x4a, 0x53, 0x4f, 0x4e, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x48, 0x00,
+	0x52, 0x12, 0x6a, 0x61, 0x76, 0x61, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x4d, 0x65, 0x74, 0x61,
+	0x64, 0x61, 0x74, 0x61, 0x12, 0x40, 0x0a, 0x0c, 0x61, 0x70, 0x6b, 0x5f, 0x6d, 0x65, 0x74, 0x61,
+	0x64, 0x61, 0x74, 0x61, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x73, 0x63, 0x61,
+	0x6c, 0x69, 0x62, 0x72, 0x2e, 0x41, 0x50, 0x4b, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x4d,
+	0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x48, 0x00, 0x52, 0x0b, 0x61, 0x70, 0x6b, 0x4d, 0x65,
+	0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x43, 0x0a, 0x0d, 0x64, 0x70, 0x6b, 0x67, 0x5f, 0x6d,
+	0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e,
+	0x73, 0x63, 0x61, 0x6c, 0x69, 0x62, 0x72, 0x2e, 0x44, 0x50, 0x4b, 0x47, 0x50, 0x61, 0x63, 0x6b,
+	0x61, 0x67, 0x65, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x48, 0x00, 0x52, 0x0c, 0x64,
+	0x70, 0x6b, 0x67, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x40, 0x0a, 0x0c, 0x72,
+	0x70, 0x6d, 0x5f, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x09, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1b, 0x2e, 0x73, 0x63, 0x61, 0x6c, 0x69, 0x62, 0x72, 0x2e, 0x52, 0x50, 0x4d, 0x50,
 	0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x48, 0x00,
-	0x52, 0x0b, 0x63, 0x6f, 0x73, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x43, 0x0a,
-	0x0d, 0x73, 0x70, 0x64, 0x78, 0x5f, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x0e,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x73, 0x63, 0x61, 0x6c, 0x69, 0x62, 0x72, 0x2e, 0x53,
-	0x50, 0x44, 0x58, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61,
-	0x74, 0x61, 0x48, 0x00, 0x52, 0x0c, 0x73, 0x70, 0x64, 0x78, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61,
-	0x74, 0x61, 0x12, 0x52, 0x0a, 0x15, 0x6a, 0x61, 0x76, 0x61, 0x5f, 0x61, 0x72, 0x63, 0x68, 0x69,
-	0x76, 0x65, 0x5f, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x0f, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x1c, 0x2e, 0x73, 0x63, 0x61, 0x6c, 0x69, 0x62, 0x72, 0x2e, 0x4a, 0x61, 0x76, 0x61,
-	0x41, 0x72, 0x63, 0x68, 0x69, 0x76, 0x65, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x48,
-	0x00, 0x52, 0x13, 0x6a, 0x61, 0x76, 0x61, 0x41, 0x72, 0x63, 0x68, 0x69, 0x76, 0x65, 0x4d, 0x65,
-	0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x40, 0x0a, 0x0c, 0x6f, 0x73, 0x76, 0x5f, 0x6d, 0x65,
-	0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x10, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x73,
-	0x63, 0x61, 0x6c, 0x69, 0x62, 0x72, 0x2e, 0x4f, 0x53, 0x56, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67,
-	0x65, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x48, 0x00, 0x52, 0x0b, 0x6f, 0x73, 0x76,
-	0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x67, 0x0a, 0x1c, 0x70, 0x79, 0x74, 0x68,
-	0x6f, 0x6e, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x5f,
-	0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x15, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x23,
-	0x2e, 0x73, 0x63, 0x61, 0x6c, 0x69, 0x62, 0x72, 0x2e, 0x50, 0x79, 0x74, 0x68, 0x6f, 0x6e, 0x52,
+	0x52, 0x0b, 0x72, 0x70, 0x6d, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x40, 0x0a,
+	0x0c, 0x63, 0x6f, 0x73, 0x5f, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x0d, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x73, 0x63, 0x61, 0x6c, 0x69, 0x62, 0x72, 0x2e, 0x43, 0x4f,
+	0x53, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61,
+	0x48, 0x00, 0x52, 0x0b, 0x63, 0x6f, 0x73, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12,
+	0x43, 0x0a, 0x0d, 0x73, 0x70, 0x64, 0x78, 0x5f, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61,
+	0x18, 0x0e, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x73, 0x63, 0x61, 0x6c, 0x69, 0x62, 0x72,
+	0x2e, 0x53, 0x50, 0x44, 0x58, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x4d, 0x65, 0x74, 0x61,
+	0x64, 0x61, 0x74, 0x61, 0x48, 0x00, 0x52, 0x0c, 0x73, 0x70, 0x64, 0x78, 0x4d, 0x65, 0x74, 0x61,
+	0x64, 0x61, 0x74, 0x61, 0x12, 0x52, 0x0a, 0x15, 0x6a, 0x61, 0x76, 0x61, 0x5f, 0x61, 0x72, 0x63,
+	0x68, 0x69, 0x76, 0x65, 0x5f, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x0f, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x73, 0x63, 0x61, 0x6c, 0x69, 0x62, 0x72, 0x2e, 0x4a, 0x61,
+	0x76, 0x61, 0x41, 0x72, 0x63, 0x68, 0x69, 0x76, 0x65, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74,
+	0x61, 0x48, 0x00, 0x52, 0x13, 0x6a, 0x61, 0x76, 0x61, 0x41, 0x72, 0x63, 0x68, 0x69, 0x76, 0x65,
+	0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x40, 0x0a, 0x0c, 0x6f, 0x73, 0x76, 0x5f,
+	0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x10, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b,
+	0x2e, 0x73, 0x63, 0x61, 0x6c, 0x69, 0x62, 0x72, 0x2e, 0x4f, 0x53, 0x56, 0x50, 0x61, 0x63, 0x6b,
+	0x61, 0x67, 0x65, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x48, 0x00, 0x52, 0x0b, 0x6f,
+	0x73, 0x76, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x67, 0x0a, 0x1c, 0x70, 0x79,
+	0x74, 0x68, 0x6f, 0x6e, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x6d, 0x65, 0x6e, 0x74,
+	0x73, 0x5f, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x15, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x23, 0x2e, 0x73, 0x63, 0x61, 0x6c, 0x69, 0x62, 0x72, 0x2e, 0x50, 0x79, 0x74, 0x68, 0x6f,
+	0x6e, 0x52, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x4d, 0x65, 0x74,
+	0x61, 0x64, 0x61, 0x74, 0x61, 0x48, 0x00, 0x52, 0x1a, 0x70, 0x79, 0x74, 0x68, 0x6f, 0x6e, 0x52,
 	0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x4d, 0x65, 0x74, 0x61, 0x64,
-	0x61, 0x74, 0x61, 0x48, 0x00, 0x52, 0x1a, 0x70, 0x79, 0x74, 0x68, 0x6f, 0x6e, 0x52, 0x65, 0x71,
-	0x75, 0x69, 0x72, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74,
-	0x61, 0x12, 0x6a, 0x0a, 0x1d, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x64, 0x5f,
-	0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x5f, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61,
-	0x74, 0x61, 0x18, 0x16, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x73, 0x63, 0x61, 0x6c, 0x69,
-	0x62, 0x72, 0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x64, 0x43, 0x6f, 0x6e,
-	0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x48, 0x00,
-	0x52, 0x1b, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x64, 0x43, 0x6f, 0x6e, 0x74,
-	0x61, 0x69, 0x6e, 0x65, 0x72, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x43, 0x0a,
-	0x0d, 0x73, 0x6e, 0x61, 0x70, 0x5f, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x17,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x73, 0x63, 0x61, 0x6c, 0x69, 0x62, 0x72, 0x2e, 0x53,
-	0x4e, 0x41, 0x50, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61,
-	0x74, 0x61, 0x48, 0x00, 0x52, 0x0c, 0x73, 0x6e, 0x61, 0x70, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61,
-	0x74, 0x61, 0x12, 0x4c, 0x0a, 0x10, 0x66, 0x6c, 0x61, 0x74, 0x70, 0x61, 0x6b, 0x5f, 0x6d, 0x65,
-	0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x18, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x73,
-	0x63, 0x61, 0x6c, 0x69, 0x62, 0x72, 0x2e, 0x46, 0x6c, 0x61, 0x74, 0x70, 0x61, 0x6b, 0x50, 0x61,
-	0x63, 0x6b, 0x61, 0x67, 0x65, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x48, 0x00, 0x52,
-	0x0f, 0x66, 0x6c, 0x61, 0x74, 0x70, 0x61, 0x6b, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61,
-	0x12, 0x80, 0x01, 0x0a, 0x25, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x64, 0x5f,
-	0x72, 0x75, 0x6e, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65,
-	0x72, 0x5f, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x19, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x2b, 0x2e, 0x73, 0x63, 0x61, 0x6c, 0x69, 0x62, 0x72, 0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x61,
-	0x69, 0x6e, 0x65, 0x72, 0x64, 0x52, 0x75, 0x6e, 0x74, 0x69, 0x6d, 0x65, 0x43, 0x6f, 0x6e, 0x74,
-	0x61, 0x69, 0x6e, 0x65, 0x72, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x48, 0x00, 0x52,
-	0x22, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x64, 0x52, 0x75, 0x6e, 0x74, 0x69,
-	0x6d, 0x65, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x4d, 0x65, 0x74, 0x61, 0x64,
-	0x61, 0x74, 0x61, 0x12, 0x43, 0x0a, 0x0b, 0x61, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f,
-	0x6e, 0x73, 0x18, 0x1c, 0x20, 0x03, 0x28, 0x0e, 0x32, 0x21, 0x2e, 0x73, 0x63, 0x61, 0x6c, 0x69,
-	0x62, 0x72, 0x2e, 0x49, 0x6e, 0x76, 0x65, 0x6e, 0x74, 0x6f, 0x72, 0x79, 0x2e, 0x41, 0x6e, 0x6e,
-	0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x6e, 0x75, 0x6d, 0x52, 0x0b, 0x61, 0x6e, 0x6e,
-	0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x60, 0x0a, 0x0e, 0x41, 0x6e, 0x6e, 0x6f,
-	0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x6e, 0x75, 0x6d, 0x12, 0x0f, 0x0a, 0x0b, 0x55, 0x4e,
-	0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x10, 0x0a, 0x0c, 0x54,
-	0x52, 0x41, 0x4e, 0x53, 0x49, 0x54, 0x49, 0x4f, 0x4e, 0x41, 0x4c, 0x10, 0x01, 0x12, 0x15, 0x0a,
-	0x11, 0x49, 0x4e, 0x53, 0x49, 0x44, 0x45, 0x5f, 0x4f, 0x53, 0x5f, 0x50, 0x41, 0x43, 0x4b, 0x41,
-	0x47, 0x45, 0x10, 0x02, 0x12, 0x14, 0x0a, 0x10, 0x49, 0x4e, 0x53, 0x49, 0x44, 0x45, 0x5f, 0x43,
-	0x41, 0x43, 0x48, 0x45, 0x5f, 0x44, 0x49, 0x52, 0x10, 0x03, 0x42, 0x0a, 0x0a, 0x08, 0x6d, 0x65,
-	0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x4a, 0x04, 0x08, 0x03, 0x10, 0x04, 0x4a, 0x04, 0x08, 0x11,
-	0x10, 0x12, 0x4a, 0x04, 0x08, 0x12, 0x10, 0x13, 0x4a, 0x04, 0x08, 0x13, 0x10, 0x14, 0x22, 0x42,
+	0x61, 0x74, 0x61, 0x12, 0x6a, 0x0a, 0x1d, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72,
+	0x64, 0x5f, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x5f, 0x6d, 0x65, 0x74, 0x61,
+	0x64, 0x61, 0x74, 0x61, 0x18, 0x16, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x73, 0x63, 0x61,
+	0x6c, 0x69, 0x62, 0x72, 0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x64, 0x43,
+	0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61,
+	0x48, 0x00, 0x52, 0x1b, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x64, 0x43, 0x6f,
+	0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12,
+	0x43, 0x0a, 0x0d, 0x73, 0x6e, 0x61, 0x70, 0x5f, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61,
+	0x18, 0x17, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x73, 0x63, 0x61, 0x6c, 0x69, 0x62, 0x72,
+	0x2e, 0x53, 0x4e, 0x41, 0x50, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x4d, 0x65, 0x74, 0x61,
+	0x64, 0x61, 0x74, 0x61, 0x48, 0x00, 0x52, 0x0c, 0x73, 0x6e, 0x61, 0x70, 0x4d, 0x65, 0x74, 0x61,
+	0x64, 0x61, 0x74, 0x61, 0x12, 0x4c, 0x0a, 0x10, 0x66, 0x6c, 0x61, 0x74, 0x70, 0x61, 0x6b, 0x5f,
+	0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x18, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f,
+	0x2e, 0x73, 0x63, 0x61, 0x6c, 0x69, 0x62, 0x72, 0x2e, 0x46, 0x6c, 0x61, 0x74, 0x70, 0x61, 0x6b,
+	0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x48,
+	0x00, 0x52, 0x0f, 0x66, 0x6c, 0x61, 0x74, 0x70, 0x61, 0x6b, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61,
+	0x74, 0x61, 0x12, 0x80, 0x01, 0x0a, 0x25, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72,
+	0x64, 0x5f, 0x72, 0x75, 0x6e, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69,
+	0x6e, 0x65, 0x72, 0x5f, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x19, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x2b, 0x2e, 0x73, 0x63, 0x61, 0x6c, 0x69, 0x62, 0x72, 0x2e, 0x43, 0x6f, 0x6e,
+	0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x64, 0x52, 0x75, 0x6e, 0x74, 0x69, 0x6d, 0x65, 0x43, 0x6f,
+	0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x48,
+	0x00, 0x52, 0x22, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x64, 0x52, 0x75, 0x6e,
+	0x74, 0x69, 0x6d, 0x65, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x4d, 0x65, 0x74,
+	0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x46, 0x0a, 0x11, 0x6d, 0x61, 0x63, 0x5f, 0x61, 0x70, 0x70,
+	0x73, 0x5f, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x1d, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x18, 0x2e, 0x73, 0x63, 0x61, 0x6c, 0x69, 0x62, 0x72, 0x2e, 0x4d, 0x61, 0x63, 0x41, 0x70,
+	0x70, 0x73, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x48, 0x00, 0x52, 0x0f, 0x6d, 0x61,
+	0x63, 0x41, 0x70, 0x70, 0x73, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x5c, 0x0a,
+	0x19, 0x6f, 0x63, 0x69, 0x5f, 0x6c, 0x61, 0x79, 0x6f, 0x75, 0x74, 0x5f, 0x69, 0x6d, 0x61, 0x67,
+	0x65, 0x5f, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x1f, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1f, 0x2e, 0x73, 0x63, 0x61, 0x6c, 0x69, 0x62, 0x72, 0x2e, 0x4f, 0x43, 0x49, 0x4c, 0x61,
+	0x79, 0x6f, 0x75, 0x74, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74,
+	0x61, 0x48, 0x00, 0x52, 0x16, 0x6f, 0x63, 0x69, 0x4c, 0x61, 0x79, 0x6f, 0x75, 0x74, 0x49, 0x6d,
+	0x61, 0x67, 0x65, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x5e, 0x0a, 0x19, 0x6d,
+	0x61, 0x76, 0x65, 0x6e, 0x5f, 0x72, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x79, 0x5f,
+	0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x20, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20,
+	0x2e, 0x73, 0x63, 0x61, 0x6c, 0x69, 0x62, 0x72, 0x2e, 0x4d, 0x61, 0x76, 0x65, 0x6e, 0x52, 0x65,
+	0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x79, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61,
+	0x48, 0x00, 0x52, 0x17, 0x6d, 0x61, 0x76, 0x65, 0x6e, 0x52, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74,
+	0x6f, 0x72, 0x79, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x61, 0x0a, 0x1a, 0x70,
+	0x79, 0x74, 0x68, 0x6f, 0x6e, 0x5f, 0x76, 0x69, 0x72, 0x74, 0x75, 0x61, 0x6c, 0x65, 0x6e, 0x76,
+	0x5f, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x21, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x21, 0x2e, 0x73, 0x63, 0x61, 0x6c, 0x69, 0x62, 0x72, 0x2e, 0x50, 0x79, 0x74, 0x68, 0x6f, 0x6e,
+	0x56, 0x69, 0x72, 0x74, 0x75, 0x61, 0x6c, 0x65, 0x6e, 0x76, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61,
+	0x74, 0x61, 0x48, 0x00, 0x52, 0x18, 0x70, 0x79, 0x74, 0x68, 0x6f, 0x6e, 0x56, 0x69, 0x72, 0x74,
+	0x75, 0x61, 0x6c, 0x65, 0x6e, 0x76, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x5e,
+	0x0a, 0x19, 0x65, 0x6d, 0x62, 0x65, 0x64, 0x64, 0x65, 0x64, 0x5f, 0x72, 0x75, 0x6e, 0x74, 0x69,
+	0x6d, 0x65, 0x5f, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x22, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x20, 0x2e, 0x73, 0x63, 0x61, 0x6c, 0x69, 0x62, 0x72, 0x2e, 0x45, 0x6d, 0x62, 0x65,
+	0x64, 0x64, 0x65, 0x64, 0x52, 0x75, 0x6e, 0x74, 0x69, 0x6d, 0x65, 0x4d, 0x65, 0x74, 0x61, 0x64,
+	0x61, 0x74, 0x61, 0x48, 0x00, 0x52, 0x17, 0x65, 0x6d, 0x62, 0x65, 0x64, 0x64, 0x65, 0x64, 0x52,
+	0x75, 0x6e, 0x74, 0x69, 0x6d, 0x65, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x4c,
+	0x0a, 0x13, 0x68, 0x65, 0x6c, 0x6d, 0x5f, 0x63, 0x68, 0x61, 0x72, 0x74, 0x5f, 0x6d, 0x65, 0x74,
+	0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x23, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x73, 0x63,
+	0x61, 0x6c, 0x69, 0x62, 0x72, 0x2e, 0x48, 0x65, 0x6c, 0x6d, 0x43, 0x68, 0x61, 0x72, 0x74, 0x4d,
+	0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x48, 0x00, 0x52, 0x11, 0x68, 0x65, 0x6c, 0x6d, 0x43,
+	0x68, 0x61, 0x72, 0x74, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x58, 0x0a, 0x17,
+	0x6a, 0x65, 0x6e, 0x6b, 0x69, 0x6e, 0x73, 0x5f, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x5f, 0x6d,
+	0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x24, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e,
+	0x73, 0x63, 0x61, 0x6c, 0x69, 0x62, 0x72, 0x2e, 0x4a, 0x65, 0x6e, 0x6b, 0x69, 0x6e, 0x73, 0x50,
+	0x6c, 0x75, 0x67, 0x69, 0x6e, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x48, 0x00, 0x52,
+	0x15, 0x6a, 0x65, 0x6e, 0x6b, 0x69, 0x6e, 0x73, 0x50, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x4d, 0x65,
+	0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x4f, 0x0a, 0x14, 0x61, 0x6e, 0x64, 0x72, 0x6f, 0x69,
+	0x64, 0x5f, 0x61, 0x70, 0x6b, 0x5f, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x25,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x73, 0x63, 0x61, 0x6c, 0x69, 0x62, 0x72, 0x2e, 0x41,
+	0x6e, 0x64, 0x72, 0x6f, 0x69, 0x64, 0x41, 0x50, 0x4b, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74,
+	0x61, 0x48, 0x00, 0x52, 0x12, 0x61, 0x6e, 0x64, 0x72, 0x6f, 0x69, 0x64, 0x41, 0x70, 0x6b, 0x4d,
+	0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x3f, 0x0a, 0x0e, 0x6e, 0x75, 0x67, 0x65, 0x74,
+	0x5f, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x27, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x16, 0x2e, 0x73, 0x63, 0x61, 0x6c, 0x69, 0x62, 0x72, 0x2e, 0x4e, 0x75, 0x47, 0x65, 0x74, 0x4d,
+	0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x48, 0x00, 0x52, 0x0d, 0x6e, 0x75, 0x67, 0x65, 0x74,
+	0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x52, 0x0a, 0x15, 0x70, 0x69, 0x70, 0x66,
+	0x69, 0x6c, 0x65, 0x5f, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74,
+	0x61, 0x18, 0x28, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x73, 0x63, 0x61, 0x6c, 0x69, 0x62,
+	0x72, 0x2e, 0x50, 0x69, 0x70, 0x66, 0x69, 0x6c, 0x65, 0x4c, 0x6f, 0x63, 0x6b, 0x4d, 0x65, 0x74,
+	0x61, 0x64, 0x61, 0x74, 0x61, 0x48, 0x00, 0x52, 0x13, 0x70, 0x69, 0x70, 0x66, 0x69, 0x6c, 0x65,
+	0x4c, 0x6f, 0x63, 0x6b, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x43, 0x0a, 0x0d,
+	0x6f, 0x70, 0x6b, 0x67, 0x5f, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x29, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x73, 0x63, 0x61, 0x6c, 0x69, 0x62, 0x72, 0x2e, 0x4f, 0x70,
+	0x6b, 0x67, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74,
+	0x61, 0x48, 0x00, 0x52, 0x0c, 0x6f, 0x70, 0x6b, 0x67, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74,
+	0x61, 0x12, 0x55, 0x0a, 0x16, 0x67, 0x69, 0x74, 0x5f, 0x73, 0x75, 0x62, 0x6d, 0x6f, 0x64, 0x75,
+	0x6c, 0x65, 0x5f, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x2a, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1d, 0x2e, 0x73, 0x63, 0x61, 0x6c, 0x69, 0x62, 0x72, 0x2e, 0x47, 0x69, 0x74, 0x53,
+	0x75, 0x62, 0x6d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61,
+	0x48, 0x00, 0x52, 0x14, 0x67, 0x69, 0x74, 0x53, 0x75, 0x62, 0x6d, 0x6f, 0x64, 0x75, 0x6c, 0x65,
+	0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x61, 0x0a, 0x1a, 0x62, 0x72, 0x6f, 0x77,
+	0x73, 0x65, 0x72, 0x5f, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x6d, 0x65,
+	0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x2b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x73,
+	0x63, 0x61, 0x6c, 0x69, 0x62, 0x72, 0x2e, 0x42, 0x72, 0x6f, 0x77, 0x73, 0x65, 0x72, 0x45, 0x78,
+	0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x48,
+	0x00, 0x52, 0x18, 0x62, 0x72, 0x6f, 0x77, 0x73, 0x65, 0x72, 0x45, 0x78, 0x74, 0x65, 0x6e, 0x73,
+	0x69, 0x6f, 0x6e, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x4b, 0x0a, 0x12, 0x77,
+	0x6f, 0x72, 0x64, 0x70, 0x72, 0x65, 0x73, 0x73, 0x5f, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74,
+	0x61, 0x18, 0x2f, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x73, 0x63, 0x61, 0x6c, 0x69, 0x62,
+	0x72, 0x2e, 0x57, 0x6f, 0x72, 0x64, 0x50, 0x72, 0x65, 0x73, 0x73, 0x4d, 0x65, 0x74, 0x61, 0x64,
+	0x61, 0x74, 0x61, 0x48, 0x00, 0x52, 0x11, 0x77, 0x6f, 0x72, 0x64, 0x70, 0x72, 0x65, 0x73, 0x73,
+	0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x55, 0x0a, 0x16, 0x6b, 0x65, 0x72, 0x6e,
+	0x65, 0x6c, 0x5f, 0x6d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x5f, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61,
+	0x74, 0x61, 0x18, 0x30, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x73, 0x63, 0x61, 0x6c, 0x69,
+	0x62, 0x72, 0x2e, 0x4b, 0x65, 0x72, 0x6e, 0x65, 0x6c, 0x4d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x4d,
+	0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x48, 0x00, 0x52, 0x14, 0x6b, 0x65, 0x72, 0x6e, 0x65,
+	0x6c, 0x4d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12,
+	0x55, 0x0a, 0x16, 0x79, 0x6f, 0x63, 0x74, 0x6f, 0x5f, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65,
+	0x5f, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x31, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1d, 0x2e, 0x73, 0x63, 0x61, 0x6c, 0x69, 0x62, 0x72, 0x2e, 0x59, 0x6f, 0x63, 0x74, 0x6f, 0x50,
+	0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x48, 0x00,
+	0x52, 0x14, 0x79, 0x6f, 0x63, 0x74, 0x6f, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x4d, 0x65,
+	0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x46, 0x0a, 0x11, 0x6d, 0x69, 0x78, 0x5f, 0x6c, 0x6f,
+	0x63, 0x6b, 0x5f, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x32, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x18, 0x2e, 0x73, 0x63, 0x61, 0x6c, 0x69, 0x62, 0x72, 0x2e, 0x4d, 0x69, 0x78, 0x4c,
+	0x6f, 0x63, 0x6b, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x48, 0x00, 0x52, 0x0f, 0x6d,
+	0x69, 0x78, 0x4c, 0x6f, 0x63, 0x6b, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x58,
+	0x0a, 0x17, 0x64, 0x6f, 0x63, 0x6b, 0x65, 0x72, 0x5f, 0x63, 0x6f, 0x6d, 0x70, 0x6f, 0x73, 0x65,
+	0x5f, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x34, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1e, 0x2e, 0x73, 0x63, 0x61, 0x6c, 0x69, 0x62, 0x72, 0x2e, 0x44, 0x6f, 0x63, 0x6b, 0x65, 0x72,
+	0x43, 0x6f, 0x6d, 0x70, 0x6f, 0x73, 0x65, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x48,
+	0x00, 0x52, 0x15, 0x64, 0x6f, 0x63, 0x6b, 0x65, 0x72, 0x43, 0x6f, 0x6d, 0x70, 0x6f, 0x73, 0x65,
+	0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x4f, 0x0a, 0x14, 0x65, 0x6d, 0x62, 0x65,
+	0x64, 0x64, 0x65, 0x64, 0x5f, 0x69, 0x64, 0x5f, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61,
+	0x18, 0x35, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x73, 0x63, 0x61, 0x6c, 0x69, 0x62, 0x72,
+	0x2e, 0x45, 0x6d, 0x62, 0x65, 0x64, 0x64, 0x65, 0x64, 0x49, 0x64, 0x4d, 0x65, 0x74, 0x61, 0x64,
+	0x61, 0x74, 0x61, 0x48, 0x00, 0x52, 0x12, 0x65, 0x6d, 0x62, 0x65, 0x64, 0x64, 0x65, 0x64, 0x49,
+	0x64, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x3c, 0x0a, 0x0d, 0x63, 0x70, 0x61,
+	0x6e, 0x5f, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x36, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x15, 0x2e, 0x73, 0x63, 0x61, 0x6c, 0x69, 0x62, 0x72, 0x2e, 0x43, 0x50, 0x41, 0x4e, 0x4d,
+	0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x48, 0x00, 0x52, 0x0c, 0x63, 0x70, 0x61, 0x6e, 0x4d,
+	0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x49, 0x0a, 0x12, 0x62, 0x61, 0x7a, 0x65, 0x6c,
+	0x5f, 0x64, 0x65, 0x70, 0x5f, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x37, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x73, 0x63, 0x61, 0x6c, 0x69, 0x62, 0x72, 0x2e, 0x42, 0x61,
+	0x7a, 0x65, 0x6c, 0x44, 0x65, 0x70, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x48, 0x00,
+	0x52, 0x10, 0x62, 0x61, 0x7a, 0x65, 0x6c, 0x44, 0x65, 0x70, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61,
+	0x74, 0x61, 0x12, 0x4f, 0x0a, 0x14, 0x6f, 0x73, 0x67, 0x69, 0x5f, 0x62, 0x75, 0x6e, 0x64, 0x6c,
+	0x65, 0x5f, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x38, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1b, 0x2e, 0x73, 0x63, 0x61, 0x6c, 0x69, 0x62, 0x72, 0x2e, 0x4f, 0x53, 0x47, 0x69, 0x42,
+	0x75, 0x6e, 0x64, 0x6c, 0x65, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x48, 0x00, 0x52,
+	0x12, 0x6f, 0x73, 0x67, 0x69, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x4d, 0x65, 0x74, 0x61, 0x64,
+	0x61, 0x74, 0x61, 0x12, 0x39, 0x0a, 0x0c, 0x75, 0x70, 0x78, 0x5f, 0x6d, 0x65, 0x74, 0x61, 0x64,
+	0x61, 0x74, 0x61, 0x18, 0x39, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x73, 0x63, 0x61, 0x6c,
+	0x69, 0x62, 0x72, 0x2e, 0x55, 0x50, 0x58, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x48,
+	0x00, 0x52, 0x0b, 0x75, 0x70, 0x78, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x40,
+	0x0a, 0x0f, 0x67, 0x6f, 0x5f, 0x6d, 0x6f, 0x64, 0x5f, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74,
+	0x61, 0x18, 0x3a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x73, 0x63, 0x61, 0x6c, 0x69, 0x62,
+	0x72, 0x2e, 0x47, 0x6f, 0x4d, 0x6f, 0x64, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x48,
+	0x00, 0x52, 0x0d, 0x67, 0x6f, 0x4d, 0x6f, 0x64, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61,
+	0x12, 0x55, 0x0a, 0x16, 0x6d, 0x61, 0x63, 0x5f, 0x66, 0x72, 0x61, 0x6d, 0x65, 0x77, 0x6f, 0x72,
+	0x6b, 0x5f, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x3b, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1d, 0x2e, 0x73, 0x63, 0x61, 0x6c, 0x69, 0x62, 0x72, 0x2e, 0x4d, 0x61, 0x63, 0x46, 0x72,
+	0x61, 0x6d, 0x65, 0x77, 0x6f, 0x72, 0x6b, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x48,
+	0x00, 0x52, 0x14, 0x6d, 0x61, 0x63, 0x46, 0x72, 0x61, 0x6d, 0x65, 0x77, 0x6f, 0x72, 0x6b, 0x4d,
+	0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x43, 0x0a, 0x0b, 0x61, 0x6e, 0x6e, 0x6f, 0x74,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x1c, 0x20, 0x03, 0x28, 0x0e, 0x32, 0x21, 0x2e, 0x73,
+	0x63, 0x61, 0x6c, 0x69, 0x62, 0x72, 0x2e, 0x49, 0x6e, 0x76, 0x65, 0x6e, 0x74, 0x6f, 0x72, 0x79,
+	0x2e, 0x41, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x6e, 0x75, 0x6d, 0x52,
+	0x0b, 0x61, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x3b, 0x0a, 0x0d,
+	0x72, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x68, 0x69, 0x70, 0x73, 0x18, 0x1e, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x73, 0x63, 0x61, 0x6c, 0x69, 0x62, 0x72, 0x2e, 0x52, 0x65,
+	0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x68, 0x69, 0x70, 0x52, 0x0d, 0x72, 0x65, 0x6c, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x68, 0x69, 0x70, 0x73, 0x12, 0x41, 0x0a, 0x0a, 0x63, 0x6f, 0x6e,
+	0x66, 0x69, 0x64, 0x65, 0x6e, 0x63, 0x65, 0x18, 0x2c, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x21, 0x2e,
+	0x73, 0x63, 0x61, 0x6c, 0x69, 0x62, 0x72, 0x2e, 0x49, 0x6e, 0x76, 0x65, 0x6e, 0x74, 0x6f, 0x72,
+	0x79, 0x2e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x64, 0x65, 0x6e, 0x63, 0x65, 0x45, 0x6e, 0x75, 0x6d,
+	0x52, 0x0a, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x64, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x1a, 0x0a, 0x08,
+	0x65, 0x76, 0x69, 0x64, 0x65, 0x6e, 0x63, 0x65, 0x18, 0x2d, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
+	0x65, 0x76, 0x69, 0x64, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x68, 0x6f, 0x73, 0x74,
+	0x73, 0x18, 0x26, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x68, 0x6f, 0x73, 0x74, 0x73, 0x22, 0x6e,
+	0x0a, 0x0e, 0x41, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x6e, 0x75, 0x6d,
+	0x12, 0x0f, 0x0a, 0x0b, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10,
+	0x00, 0x12, 0x10, 0x0a, 0x0c, 0x54, 0x52, 0x41, 0x4e, 0x53, 0x49, 0x54, 0x49, 0x4f, 0x4e, 0x41,
+	0x4c, 0x10, 0x01, 0x12, 0x15, 0x0a, 0x11, 0x49, 0x4e, 0x53, 0x49, 0x44, 0x45, 0x5f, 0x4f, 0x53,
+	0x5f, 0x50, 0x41, 0x43, 0x4b, 0x41, 0x47, 0x45, 0x10, 0x02, 0x12, 0x14, 0x0a, 0x10, 0x49, 0x4e,
+	0x53, 0x49, 0x44, 0x45, 0x5f, 0x43, 0x41, 0x43, 0x48, 0x45, 0x5f, 0x44, 0x49, 0x52, 0x10, 0x03,
+	0x12, 0x0c, 0x0a, 0x08, 0x49, 0x4e, 0x54, 0x45, 0x52, 0x4e, 0x41, 0x4c, 0x10, 0x04, 0x22, 0x6c,
+	0x0a, 0x0e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x64, 0x65, 0x6e, 0x63, 0x65, 0x45, 0x6e, 0x75, 0x6d,
+	0x12, 0x1a, 0x0a, 0x16, 0x43, 0x4f, 0x4e, 0x46, 0x49, 0x44, 0x45, 0x4e, 0x43, 0x45, 0x5f, 0x55,
+	0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x12, 0x0a, 0x0e,
+	0x43, 0x4f, 0x4e, 0x46, 0x49, 0x44, 0x45, 0x4e, 0x43, 0x45, 0x5f, 0x4c, 0x4f, 0x57, 0x10, 0x01,
+	0x12, 0x15, 0x0a, 0x11, 0x43, 0x4f, 0x4e, 0x46, 0x49, 0x44, 0x45, 0x4e, 0x43, 0x45, 0x5f, 0x4d,
+	0x45, 0x44, 0x49, 0x55, 0x4d, 0x10, 0x02, 0x12, 0x13, 0x0a, 0x0f, 0x43, 0x4f, 0x4e, 0x46, 0x49,
+	0x44, 0x45, 0x4e, 0x43, 0x45, 0x5f, 0x48, 0x49, 0x47, 0x48, 0x10, 0x03, 0x42, 0x0a, 0x0a, 0x08,
+	0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x4a, 0x04, 0x08, 0x03, 0x10, 0x04, 0x4a, 0x04,
+	0x08, 0x11, 0x10, 0x12, 0x4a, 0x04, 0x08, 0x12, 0x10, 0x13, 0x4a, 0x04, 0x08, 0x13, 0x10, 0x14,
+	0x22, 0xc8, 0x01, 0x0a, 0x08, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a,
+	0x04, 0x70, 0x61, 0x74, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x74,
+	0x68, 0x12, 0x32, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32,
+	0x1e, 0x2e, 0x73, 0x63, 0x61, 0x6c, 0x69, 0x62, 0x72, 0x2e, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x2e, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4b, 0x69, 0x6e, 0x64, 0x52,
+	0x04, 0x6b, 0x69, 0x6e, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6c, 0x69, 0x6e, 0x65, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x04, 0x6c, 0x69, 0x6e, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x6f, 0x66, 0x66,
+	0x73, 0x65, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65,
+	0x74, 0x22, 0x48, 0x0a, 0x0c, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4b, 0x69, 0x6e,
+	0x64, 0x12, 0x0f, 0x0a, 0x0b, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44,
+	0x10, 0x00, 0x12, 0x0c, 0x0a, 0x08, 0x4d, 0x41, 0x4e, 0x49, 0x46, 0x45, 0x53, 0x54, 0x10, 0x01,
+	0x12, 0x0d, 0x0a, 0x09, 0x49, 0x4e, 0x53, 0x54, 0x41, 0x4c, 0x4c, 0x45, 0x44, 0x10, 0x02, 0x12,
+	0x0a, 0x0a, 0x06, 0x42, 0x49, 0x4e, 0x41, 0x52, 0x59, 0x10, 0x03, 0x22, 0xa0, 0x01, 0x0a, 0x0c,
+	0x52, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x68, 0x69, 0x70, 0x12, 0x3a, 0x0a, 0x04,
+	0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x26, 0x2e, 0x73, 0x63, 0x61,
+	0x6c, 0x69, 0x62, 0x72, 0x2e, 0x52, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x68, 0x69,
+	0x70, 0x2e, 0x52, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x68, 0x69, 0x70, 0x54, 0x79,
+	0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x74, 0x61, 0x72, 0x67,
+	0x65, 0x74, 0x5f, 0x70, 0x75, 0x72, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x74,
+	0x61, 0x72, 0x67, 0x65, 0x74, 0x50, 0x75, 0x72, 0x6c, 0x22, 0x33, 0x0a, 0x10, 0x52, 0x65, 0x6c,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x68, 0x69, 0x70, 0x54, 0x79, 0x70, 0x65, 0x12, 0x0f, 0x0a,
+	0x0b, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x0e,
+	0x0a, 0x0a, 0x44, 0x45, 0x50, 0x45, 0x4e, 0x44, 0x53, 0x5f, 0x4f, 0x4e, 0x10, 0x01, 0x22, 0x42,
 	0x0a, 0x14, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x43, 0x6f, 0x64, 0x65, 0x49, 0x64, 0x65, 0x6e,
 	0x74, 0x69, 0x66, 0x69, 0x65, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x65, 0x70, 0x6f, 0x18, 0x01,
 	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x72, 0x65, 0x70, 0x6f, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x6f,
@@ -2828,152 +5869,398 @@ var file_proto_scan_result_proto_rawDesc = []byte{
 	0x63, 0x61, 0x6c, 0x69, 0x62, 0x72, 0x2e, 0x49, 0x6e, 0x76, 0x65, 0x6e, 0x74, 0x6f, 0x72, 0x79,
 	0x52, 0x09, 0x69, 0x6e, 0x76, 0x65, 0x6e, 0x74, 0x6f, 0x72, 0x79, 0x12, 0x1a, 0x0a, 0x08, 0x6c,
 	0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x08, 0x6c,
-	0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x52, 0x0a, 0x15, 0x50, 0x79, 0x74, 0x68, 0x6f,
+	0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x68, 0x0a, 0x15, 0x50, 0x79, 0x74, 0x68, 0x6f,
 	0x6e, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61,
 	0x12, 0x16, 0x0a, 0x06, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
 	0x52, 0x06, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x12, 0x21, 0x0a, 0x0c, 0x61, 0x75, 0x74, 0x68,
 	0x6f, 0x72, 0x5f, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b,
-	0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x45, 0x6d, 0x61, 0x69, 0x6c, 0x22, 0x7d, 0x0a, 0x1d, 0x4a,
-	0x61, 0x76, 0x61, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65,
-	0x4a, 0x53, 0x4f, 0x4e, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x16, 0x0a, 0x06,
-	0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x61, 0x75,
-	0x74, 0x68, 0x6f, 0x72, 0x12, 0x20, 0x0a, 0x0b, 0x6d, 0x61, 0x69, 0x6e, 0x74, 0x61, 0x69, 0x6e,
-	0x65, 0x72, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0b, 0x6d, 0x61, 0x69, 0x6e, 0x74,
-	0x61, 0x69, 0x6e, 0x65, 0x72, 0x73, 0x12, 0x22, 0x0a, 0x0c, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x69,
-	0x62, 0x75, 0x74, 0x6f, 0x72, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0c, 0x63, 0x6f,
-	0x6e, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x6f, 0x72, 0x73, 0x22, 0xef, 0x01, 0x0a, 0x12, 0x41,
-	0x50, 0x4b, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74,
-	0x61, 0x12, 0x21, 0x0a, 0x0c, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x5f, 0x6e, 0x61, 0x6d,
-	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65,
-	0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x5f, 0x6e,
-	0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x6f, 0x72, 0x69, 0x67, 0x69,
-	0x6e, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x13, 0x0a, 0x05, 0x6f, 0x73, 0x5f, 0x69, 0x64, 0x18, 0x03,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6f, 0x73, 0x49, 0x64, 0x12, 0x22, 0x0a, 0x0d, 0x6f, 0x73,
-	0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x0b, 0x6f, 0x73, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12, 0x1e,
-	0x0a, 0x0a, 0x6d, 0x61, 0x69, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x18, 0x05, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x0a, 0x6d, 0x61, 0x69, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x12, 0x22,
-	0x0a, 0x0c, 0x61, 0x72, 0x63, 0x68, 0x69, 0x74, 0x65, 0x63, 0x74, 0x75, 0x72, 0x65, 0x18, 0x06,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x61, 0x72, 0x63, 0x68, 0x69, 0x74, 0x65, 0x63, 0x74, 0x75,
-	0x72, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6c, 0x69, 0x63, 0x65, 0x6e, 0x73, 0x65, 0x18, 0x07, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x07, 0x6c, 0x69, 0x63, 0x65, 0x6e, 0x73, 0x65, 0x22, 0xee, 0x02, 0x0a,
-	0x13, 0x44, 0x50, 0x4b, 0x47, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x4d, 0x65, 0x74, 0x61,
-	0x64, 0x61, 0x74, 0x61, 0x12, 0x21, 0x0a, 0x0c, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x5f,
-	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x70, 0x61, 0x63, 0x6b,
-	0x61, 0x67, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x6f, 0x75, 0x72, 0x63,
-	0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x73, 0x6f,
-	0x75, 0x72, 0x63, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x25, 0x0a, 0x0e, 0x73, 0x6f, 0x75, 0x72,
-	0x63, 0x65, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x0d, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12,
-	0x27, 0x0a, 0x0f, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69,
-	0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67,
-	0x65, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x13, 0x0a, 0x05, 0x6f, 0x73, 0x5f, 0x69,
-	0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6f, 0x73, 0x49, 0x64, 0x12, 0x2e, 0x0a,
-	0x13, 0x6f, 0x73, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x63, 0x6f, 0x64, 0x65,
-	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x11, 0x6f, 0x73, 0x56, 0x65,
-	0x72, 0x73, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x64, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x22, 0x0a,
-	0x0d, 0x6f, 0x73, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x07,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x6f, 0x73, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x49,
-	0x64, 0x12, 0x1e, 0x0a, 0x0a, 0x6d, 0x61, 0x69, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x18,
-	0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x6d, 0x61, 0x69, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65,
-	0x72, 0x12, 0x22, 0x0a, 0x0c, 0x61, 0x72, 0x63, 0x68, 0x69, 0x74, 0x65, 0x63, 0x74, 0x75, 0x72,
-	0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x61, 0x72, 0x63, 0x68, 0x69, 0x74, 0x65,
-	0x63, 0x74, 0x75, 0x72, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18,
-	0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0xb4, 0x02,
-	0x0a, 0x12, 0x52, 0x50, 0x4d, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x4d, 0x65, 0x74, 0x61,
-	0x64, 0x61, 0x74, 0x61, 0x12, 0x21, 0x0a, 0x0c, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x5f,
-	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x70, 0x61, 0x63, 0x6b,
-	0x61, 0x67, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x6f, 0x75, 0x72, 0x63,
-	0x65, 0x5f, 0x72, 0x70, 0x6d, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x6f, 0x75,
-	0x72, 0x63, 0x65, 0x52, 0x70, 0x6d, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x18,
-	0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x12, 0x13, 0x0a, 0x05,
-	0x6f, 0x73, 0x5f, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6f, 0x73, 0x49,
-	0x64, 0x12, 0x22, 0x0a, 0x0d, 0x6f, 0x73, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x5f,
-	0x69, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x6f, 0x73, 0x56, 0x65, 0x72, 0x73,
-	0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12, 0x1e, 0x0a, 0x0b, 0x6f, 0x73, 0x5f, 0x62, 0x75, 0x69, 0x6c,
-	0x64, 0x5f, 0x69, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6f, 0x73, 0x42, 0x75,
-	0x69, 0x6c, 0x64, 0x49, 0x64, 0x12, 0x17, 0x0a, 0x07, 0x6f, 0x73, 0x5f, 0x6e, 0x61, 0x6d, 0x65,
-	0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6f, 0x73, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x16,
-	0x0a, 0x06, 0x76, 0x65, 0x6e, 0x64, 0x6f, 0x72, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
-	0x76, 0x65, 0x6e, 0x64, 0x6f, 0x72, 0x12, 0x22, 0x0a, 0x0c, 0x61, 0x72, 0x63, 0x68, 0x69, 0x74,
-	0x65, 0x63, 0x74, 0x75, 0x72, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x61, 0x72,
-	0x63, 0x68, 0x69, 0x74, 0x65, 0x63, 0x74, 0x75, 0x72, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6c, 0x69,
-	0x63, 0x65, 0x6e, 0x73, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6c, 0x69, 0x63,
-	0x65, 0x6e, 0x73, 0x65, 0x22, 0xa1, 0x01, 0x0a, 0x12, 0x43, 0x4f, 0x53, 0x50, 0x61, 0x63, 0x6b,
-	0x61, 0x67, 0x65, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x12, 0x0a, 0x04, 0x6e,
-	0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12,
-	0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x61, 0x74,
-	0x65, 0x67, 0x6f, 0x72, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x61, 0x74,
-	0x65, 0x67, 0x6f, 0x72, 0x79, 0x12, 0x1d, 0x0a, 0x0a, 0x6f, 0x73, 0x5f, 0x76, 0x65, 0x72, 0x73,
-	0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6f, 0x73, 0x56, 0x65, 0x72,
-	0x73, 0x69, 0x6f, 0x6e, 0x12, 0x22, 0x0a, 0x0d, 0x6f, 0x73, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69,
-	0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x6f, 0x73, 0x56,
-	0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x22, 0xfc, 0x01, 0x0a, 0x13, 0x53, 0x4e, 0x41,
-	0x50, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61,
-	0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
-	0x6e, 0x61, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x14,
-	0x0a, 0x05, 0x67, 0x72, 0x61, 0x64, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x67,
-	0x72, 0x61, 0x64, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x04, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x24, 0x0a, 0x0d, 0x61, 0x72, 0x63, 0x68,
-	0x69, 0x74, 0x65, 0x63, 0x74, 0x75, 0x72, 0x65, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x09, 0x52,
-	0x0d, 0x61, 0x72, 0x63, 0x68, 0x69, 0x74, 0x65, 0x63, 0x74, 0x75, 0x72, 0x65, 0x73, 0x12, 0x13,
-	0x0a, 0x05, 0x6f, 0x73, 0x5f, 0x69, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6f,
+	0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x45, 0x6d, 0x61, 0x69, 0x6c, 0x12, 0x14, 0x0a, 0x05, 0x73,
+	0x63, 0x6f, 0x70, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x73, 0x63, 0x6f, 0x70,
+	0x65, 0x22, 0x7d, 0x0a, 0x1d, 0x4a, 0x61, 0x76, 0x61, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x50,
+	0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x4a, 0x53, 0x4f, 0x4e, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61,
+	0x74, 0x61, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x12, 0x20, 0x0a, 0x0b, 0x6d, 0x61,
+	0x69, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52,
+	0x0b, 0x6d, 0x61, 0x69, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x73, 0x12, 0x22, 0x0a, 0x0c,
+	0x63, 0x6f, 0x6e, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x6f, 0x72, 0x73, 0x18, 0x03, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x0c, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x6f, 0x72, 0x73,
+	0x22, 0xef, 0x01, 0x0a, 0x12, 0x41, 0x50, 0x4b, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x4d,
+	0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x21, 0x0a, 0x0c, 0x70, 0x61, 0x63, 0x6b, 0x61,
+	0x67, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x70,
+	0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x6f, 0x72,
+	0x69, 0x67, 0x69, 0x6e, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0a, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x13, 0x0a, 0x05, 0x6f,
+	0x73, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6f, 0x73, 0x49, 0x64,
+	0x12, 0x22, 0x0a, 0x0d, 0x6f, 0x73, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x69,
+	0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x6f, 0x73, 0x56, 0x65, 0x72, 0x73, 0x69,
+	0x6f, 0x6e, 0x49, 0x64, 0x12, 0x1e, 0x0a, 0x0a, 0x6d, 0x61, 0x69, 0x6e, 0x74, 0x61, 0x69, 0x6e,
+	0x65, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x6d, 0x61, 0x69, 0x6e, 0x74, 0x61,
+	0x69, 0x6e, 0x65, 0x72, 0x12, 0x22, 0x0a, 0x0c, 0x61, 0x72, 0x63, 0x68, 0x69, 0x74, 0x65, 0x63,
+	0x74, 0x75, 0x72, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x61, 0x72, 0x63, 0x68,
+	0x69, 0x74, 0x65, 0x63, 0x74, 0x75, 0x72, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6c, 0x69, 0x63, 0x65,
+	0x6e, 0x73, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6c, 0x69, 0x63, 0x65, 0x6e,
+	0x73, 0x65, 0x22, 0xee, 0x02, 0x0a, 0x13, 0x44, 0x50, 0x4b, 0x47, 0x50, 0x61, 0x63, 0x6b, 0x61,
+	0x67, 0x65, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x21, 0x0a, 0x0c, 0x70, 0x61,
+	0x63, 0x6b, 0x61, 0x67, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0b, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1f, 0x0a,
+	0x0b, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0a, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x25,
+	0x0a, 0x0e, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x56, 0x65,
+	0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x27, 0x0a, 0x0f, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65,
+	0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e,
+	0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x13,
+	0x0a, 0x05, 0x6f, 0x73, 0x5f, 0x69, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6f,
 	0x73, 0x49, 0x64, 0x12, 0x2e, 0x0a, 0x13, 0x6f, 0x73, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f,
-	0x6e, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09,
+	0x6e, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09,
 	0x52, 0x11, 0x6f, 0x73, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x64, 0x65, 0x6e,
 	0x61, 0x6d, 0x65, 0x12, 0x22, 0x0a, 0x0d, 0x6f, 0x73, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f,
-	0x6e, 0x5f, 0x69, 0x64, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x6f, 0x73, 0x56, 0x65,
-	0x72, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x22, 0xb6, 0x02, 0x0a, 0x16, 0x46, 0x6c, 0x61, 0x74,
-	0x70, 0x61, 0x6b, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61,
-	0x74, 0x61, 0x12, 0x21, 0x0a, 0x0c, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x5f, 0x6e, 0x61,
-	0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67,
-	0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65,
-	0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x61, 0x63, 0x6b, 0x61,
-	0x67, 0x65, 0x49, 0x64, 0x12, 0x27, 0x0a, 0x0f, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x5f,
-	0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x70,
-	0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x21, 0x0a,
-	0x0c, 0x72, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x5f, 0x64, 0x61, 0x74, 0x65, 0x18, 0x04, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x0b, 0x72, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x44, 0x61, 0x74, 0x65,
-	0x12, 0x17, 0x0a, 0x07, 0x6f, 0x73, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x06, 0x6f, 0x73, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x13, 0x0a, 0x05, 0x6f, 0x73, 0x5f,
-	0x69, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6f, 0x73, 0x49, 0x64, 0x12, 0x22,
-	0x0a, 0x0d, 0x6f, 0x73, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18,
-	0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x6f, 0x73, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e,
-	0x49, 0x64, 0x12, 0x1e, 0x0a, 0x0b, 0x6f, 0x73, 0x5f, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x5f, 0x69,
-	0x64, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6f, 0x73, 0x42, 0x75, 0x69, 0x6c, 0x64,
-	0x49, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x64, 0x65, 0x76, 0x65, 0x6c, 0x6f, 0x70, 0x65, 0x72, 0x18,
-	0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x64, 0x65, 0x76, 0x65, 0x6c, 0x6f, 0x70, 0x65, 0x72,
-	0x22, 0x4c, 0x0a, 0x13, 0x53, 0x50, 0x44, 0x58, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x4d,
-	0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x21, 0x0a, 0x04, 0x70, 0x75, 0x72, 0x6c, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x73, 0x63, 0x61, 0x6c, 0x69, 0x62, 0x72, 0x2e,
-	0x50, 0x75, 0x72, 0x6c, 0x52, 0x04, 0x70, 0x75, 0x72, 0x6c, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x70,
-	0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x63, 0x70, 0x65, 0x73, 0x22, 0x65,
-	0x0a, 0x13, 0x4a, 0x61, 0x76, 0x61, 0x41, 0x72, 0x63, 0x68, 0x69, 0x76, 0x65, 0x4d, 0x65, 0x74,
-	0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x1f, 0x0a, 0x0b, 0x61, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63,
-	0x74, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x61, 0x72, 0x74, 0x69,
-	0x66, 0x61, 0x63, 0x74, 0x49, 0x64, 0x12, 0x19, 0x0a, 0x08, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x5f,
-	0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x49,
-	0x64, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x68, 0x61, 0x31, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x04, 0x73, 0x68, 0x61, 0x31, 0x22, 0x86, 0x01, 0x0a, 0x12, 0x4f, 0x53, 0x56, 0x50, 0x61, 0x63,
-	0x6b, 0x61, 0x67, 0x65, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x1b, 0x0a, 0x09,
-	0x70, 0x75, 0x72, 0x6c, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x08, 0x70, 0x75, 0x72, 0x6c, 0x54, 0x79, 0x70, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x6f, 0x6d,
-	0x6d, 0x69, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x63, 0x6f, 0x6d, 0x6d, 0x69,
-	0x74, 0x12, 0x1c, 0x0a, 0x09, 0x65, 0x63, 0x6f, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x18, 0x03,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x65, 0x63, 0x6f, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x12,
-	0x1d, 0x0a, 0x0a, 0x63, 0x6f, 0x6d, 0x70, 0x61, 0x72, 0x65, 0x5f, 0x61, 0x73, 0x18, 0x04, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x6f, 0x6d, 0x70, 0x61, 0x72, 0x65, 0x41, 0x73, 0x22, 0x86,
-	0x01, 0x0a, 0x1a, 0x50, 0x79, 0x74, 0x68, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65,
-	0x6d, 0x65, 0x6e, 0x74, 0x73, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x39, 0x0a,
-	0x19, 0x68, 0x61, 0x73, 0x68, 0x5f, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x69, 0x6e, 0x67, 0x5f, 0x6d,
-	0x6f, 0x64, 0x65, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09,
-	0x52, 0x16, 0x68, 0x61, 0x73, 0x68, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x69, 0x6e, 0x67, 0x4d, 0x6f,
-	0x64, 0x65, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x12, 0x2d, 0x0a, 0x12, 0x76, 0x65, 0x72, 0x73,
-	0x69, 0x6f, 0x6e, 0x5f, 0x63, 0x6f, 0x6d, 0x70, 0x61, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x11, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x6d,
-	0x70, 0x61, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x22, 0xca, 0x01, 0x0a, 0x1b, 0x43, 0x6f, 0x6e, 0x74,
+	0x6e, 0x5f, 0x69, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x6f, 0x73, 0x56, 0x65,
+	0x72, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12, 0x1e, 0x0a, 0x0a, 0x6d, 0x61, 0x69, 0x6e, 0x74,
+	0x61, 0x69, 0x6e, 0x65, 0x72, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x6d, 0x61, 0x69,
+	0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x12, 0x22, 0x0a, 0x0c, 0x61, 0x72, 0x63, 0x68, 0x69,
+	0x74, 0x65, 0x63, 0x74, 0x75, 0x72, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x61,
+	0x72, 0x63, 0x68, 0x69, 0x74, 0x65, 0x63, 0x74, 0x75, 0x72, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x22, 0xad, 0x02, 0x0a, 0x13, 0x4f, 0x70, 0x6b, 0x67, 0x50, 0x61, 0x63, 0x6b,
+	0x61, 0x67, 0x65, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x21, 0x0a, 0x0c, 0x70,
+	0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0b, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x27,
+	0x0a, 0x0f, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f,
+	0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65,
+	0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12,
+	0x22, 0x0a, 0x0c, 0x61, 0x72, 0x63, 0x68, 0x69, 0x74, 0x65, 0x63, 0x74, 0x75, 0x72, 0x65, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x61, 0x72, 0x63, 0x68, 0x69, 0x74, 0x65, 0x63, 0x74,
+	0x75, 0x72, 0x65, 0x12, 0x25, 0x0a, 0x0e, 0x61, 0x75, 0x74, 0x6f, 0x5f, 0x69, 0x6e, 0x73, 0x74,
+	0x61, 0x6c, 0x6c, 0x65, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0d, 0x61, 0x75, 0x74,
+	0x6f, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x65, 0x64, 0x12, 0x13, 0x0a, 0x05, 0x6f, 0x73,
+	0x5f, 0x69, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6f, 0x73, 0x49, 0x64, 0x12,
+	0x2e, 0x0a, 0x13, 0x6f, 0x73, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x63, 0x6f,
+	0x64, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x11, 0x6f, 0x73,
+	0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x64, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x12,
+	0x22, 0x0a, 0x0d, 0x6f, 0x73, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64,
+	0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x6f, 0x73, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f,
+	0x6e, 0x49, 0x64, 0x22, 0xb4, 0x02, 0x0a, 0x12, 0x52, 0x50, 0x4d, 0x50, 0x61, 0x63, 0x6b, 0x61,
+	0x67, 0x65, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x21, 0x0a, 0x0c, 0x70, 0x61,
+	0x63, 0x6b, 0x61, 0x67, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0b, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1d, 0x0a,
+	0x0a, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x72, 0x70, 0x6d, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x09, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x70, 0x6d, 0x12, 0x14, 0x0a, 0x05,
+	0x65, 0x70, 0x6f, 0x63, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x65, 0x70, 0x6f,
+	0x63, 0x68, 0x12, 0x13, 0x0a, 0x05, 0x6f, 0x73, 0x5f, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x6f, 0x73, 0x49, 0x64, 0x12, 0x22, 0x0a, 0x0d, 0x6f, 0x73, 0x5f, 0x76, 0x65,
+	0x72, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b,
+	0x6f, 0x73, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12, 0x1e, 0x0a, 0x0b, 0x6f,
+	0x73, 0x5f, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x5f, 0x69, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x09, 0x6f, 0x73, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x49, 0x64, 0x12, 0x17, 0x0a, 0x07, 0x6f,
+	0x73, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6f, 0x73,
+	0x4e, 0x61, 0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x65, 0x6e, 0x64, 0x6f, 0x72, 0x18, 0x08,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x76, 0x65, 0x6e, 0x64, 0x6f, 0x72, 0x12, 0x22, 0x0a, 0x0c,
+	0x61, 0x72, 0x63, 0x68, 0x69, 0x74, 0x65, 0x63, 0x74, 0x75, 0x72, 0x65, 0x18, 0x09, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0c, 0x61, 0x72, 0x63, 0x68, 0x69, 0x74, 0x65, 0x63, 0x74, 0x75, 0x72, 0x65,
+	0x12, 0x18, 0x0a, 0x07, 0x6c, 0x69, 0x63, 0x65, 0x6e, 0x73, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x07, 0x6c, 0x69, 0x63, 0x65, 0x6e, 0x73, 0x65, 0x22, 0xa1, 0x01, 0x0a, 0x12, 0x43,
+	0x4f, 0x53, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74,
+	0x61, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12,
+	0x1a, 0x0a, 0x08, 0x63, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x08, 0x63, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x12, 0x1d, 0x0a, 0x0a, 0x6f,
+	0x73, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x09, 0x6f, 0x73, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x22, 0x0a, 0x0d, 0x6f, 0x73,
+	0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0b, 0x6f, 0x73, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x22, 0xfc,
+	0x01, 0x0a, 0x13, 0x53, 0x4e, 0x41, 0x50, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x4d, 0x65,
+	0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65,
+	0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x65, 0x72,
+	0x73, 0x69, 0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x67, 0x72, 0x61, 0x64, 0x65, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x05, 0x67, 0x72, 0x61, 0x64, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79,
+	0x70, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x24,
+	0x0a, 0x0d, 0x61, 0x72, 0x63, 0x68, 0x69, 0x74, 0x65, 0x63, 0x74, 0x75, 0x72, 0x65, 0x73, 0x18,
+	0x05, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0d, 0x61, 0x72, 0x63, 0x68, 0x69, 0x74, 0x65, 0x63, 0x74,
+	0x75, 0x72, 0x65, 0x73, 0x12, 0x13, 0x0a, 0x05, 0x6f, 0x73, 0x5f, 0x69, 0x64, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x6f, 0x73, 0x49, 0x64, 0x12, 0x2e, 0x0a, 0x13, 0x6f, 0x73, 0x5f,
+	0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x6e, 0x61, 0x6d, 0x65,
+	0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x11, 0x6f, 0x73, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f,
+	0x6e, 0x43, 0x6f, 0x64, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x22, 0x0a, 0x0d, 0x6f, 0x73, 0x5f,
+	0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0b, 0x6f, 0x73, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x22, 0xb6, 0x02,
+	0x0a, 0x16, 0x46, 0x6c, 0x61, 0x74, 0x70, 0x61, 0x6b, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65,
+	0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x21, 0x0a, 0x0c, 0x70, 0x61, 0x63, 0x6b,
+	0x61, 0x67, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b,
+	0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x70,
+	0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x09, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x49, 0x64, 0x12, 0x27, 0x0a, 0x0f, 0x70, 0x61,
+	0x63, 0x6b, 0x61, 0x67, 0x65, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0e, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x56, 0x65, 0x72, 0x73,
+	0x69, 0x6f, 0x6e, 0x12, 0x21, 0x0a, 0x0c, 0x72, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x5f, 0x64,
+	0x61, 0x74, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x72, 0x65, 0x6c, 0x65, 0x61,
+	0x73, 0x65, 0x44, 0x61, 0x74, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x6f, 0x73, 0x5f, 0x6e, 0x61, 0x6d,
+	0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6f, 0x73, 0x4e, 0x61, 0x6d, 0x65, 0x12,
+	0x13, 0x0a, 0x05, 0x6f, 0x73, 0x5f, 0x69, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x6f, 0x73, 0x49, 0x64, 0x12, 0x22, 0x0a, 0x0d, 0x6f, 0x73, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69,
+	0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x6f, 0x73, 0x56,
+	0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12, 0x1e, 0x0a, 0x0b, 0x6f, 0x73, 0x5f, 0x62,
+	0x75, 0x69, 0x6c, 0x64, 0x5f, 0x69, 0x64, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6f,
+	0x73, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x49, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x64, 0x65, 0x76, 0x65,
+	0x6c, 0x6f, 0x70, 0x65, 0x72, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x64, 0x65, 0x76,
+	0x65, 0x6c, 0x6f, 0x70, 0x65, 0x72, 0x22, 0xa2, 0x02, 0x0a, 0x0f, 0x4d, 0x61, 0x63, 0x41, 0x70,
+	0x70, 0x73, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x1f, 0x0a, 0x0b, 0x62, 0x75,
+	0x6e, 0x64, 0x6c, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0a, 0x62, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x2e, 0x0a, 0x13, 0x62,
+	0x75, 0x6e, 0x64, 0x6c, 0x65, 0x5f, 0x64, 0x69, 0x73, 0x70, 0x6c, 0x61, 0x79, 0x5f, 0x6e, 0x61,
+	0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x11, 0x62, 0x75, 0x6e, 0x64, 0x6c, 0x65,
+	0x44, 0x69, 0x73, 0x70, 0x6c, 0x61, 0x79, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x2b, 0x0a, 0x11, 0x62,
+	0x75, 0x6e, 0x64, 0x6c, 0x65, 0x5f, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x69, 0x65, 0x72,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x62, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x49, 0x64,
+	0x65, 0x6e, 0x74, 0x69, 0x66, 0x69, 0x65, 0x72, 0x12, 0x3d, 0x0a, 0x1b, 0x62, 0x75, 0x6e, 0x64,
+	0x6c, 0x65, 0x5f, 0x73, 0x68, 0x6f, 0x72, 0x74, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e,
+	0x5f, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x18, 0x62,
+	0x75, 0x6e, 0x64, 0x6c, 0x65, 0x53, 0x68, 0x6f, 0x72, 0x74, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f,
+	0x6e, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x12, 0x25, 0x0a, 0x0e, 0x62, 0x75, 0x6e, 0x64, 0x6c,
+	0x65, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0d, 0x62, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x2b,
+	0x0a, 0x11, 0x62, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x5f, 0x65, 0x78, 0x65, 0x63, 0x75, 0x74, 0x61,
+	0x62, 0x6c, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x62, 0x75, 0x6e, 0x64, 0x6c,
+	0x65, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x22, 0x98, 0x02, 0x0a, 0x14,
+	0x4d, 0x61, 0x63, 0x46, 0x72, 0x61, 0x6d, 0x65, 0x77, 0x6f, 0x72, 0x6b, 0x4d, 0x65, 0x74, 0x61,
+	0x64, 0x61, 0x74, 0x61, 0x12, 0x1f, 0x0a, 0x0b, 0x62, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x5f, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x62, 0x75, 0x6e, 0x64, 0x6c,
+	0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x2b, 0x0a, 0x11, 0x62, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x5f,
+	0x69, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x69, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x10, 0x62, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x69,
+	0x65, 0x72, 0x12, 0x3d, 0x0a, 0x1b, 0x62, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x5f, 0x73, 0x68, 0x6f,
+	0x72, 0x74, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x73, 0x74, 0x72, 0x69, 0x6e,
+	0x67, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x18, 0x62, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x53,
+	0x68, 0x6f, 0x72, 0x74, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x72, 0x69, 0x6e,
+	0x67, 0x12, 0x25, 0x0a, 0x0e, 0x62, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x5f, 0x76, 0x65, 0x72, 0x73,
+	0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x62, 0x75, 0x6e, 0x64, 0x6c,
+	0x65, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x2b, 0x0a, 0x11, 0x62, 0x75, 0x6e, 0x64,
+	0x6c, 0x65, 0x5f, 0x65, 0x78, 0x65, 0x63, 0x75, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x10, 0x62, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x45, 0x78, 0x65, 0x63, 0x75,
+	0x74, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x62, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x5f,
+	0x70, 0x61, 0x74, 0x68, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x62, 0x75, 0x6e, 0x64,
+	0x6c, 0x65, 0x50, 0x61, 0x74, 0x68, 0x22, 0x4c, 0x0a, 0x13, 0x53, 0x50, 0x44, 0x58, 0x50, 0x61,
+	0x63, 0x6b, 0x61, 0x67, 0x65, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x21, 0x0a,
+	0x04, 0x70, 0x75, 0x72, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x73, 0x63,
+	0x61, 0x6c, 0x69, 0x62, 0x72, 0x2e, 0x50, 0x75, 0x72, 0x6c, 0x52, 0x04, 0x70, 0x75, 0x72, 0x6c,
+	0x12, 0x12, 0x0a, 0x04, 0x63, 0x70, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04,
+	0x63, 0x70, 0x65, 0x73, 0x22, 0x65, 0x0a, 0x13, 0x4a, 0x61, 0x76, 0x61, 0x41, 0x72, 0x63, 0x68,
+	0x69, 0x76, 0x65, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x1f, 0x0a, 0x0b, 0x61,
+	0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0a, 0x61, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x49, 0x64, 0x12, 0x19, 0x0a, 0x08,
+	0x67, 0x72, 0x6f, 0x75, 0x70, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
+	0x67, 0x72, 0x6f, 0x75, 0x70, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x68, 0x61, 0x31, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x73, 0x68, 0x61, 0x31, 0x22, 0xc0, 0x01, 0x0a, 0x17,
+	0x4d, 0x61, 0x76, 0x65, 0x6e, 0x52, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x79, 0x4d,
+	0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x19, 0x0a, 0x08, 0x67, 0x72, 0x6f, 0x75, 0x70,
+	0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x67, 0x72, 0x6f, 0x75, 0x70,
+	0x49, 0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x61, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x5f, 0x69,
+	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x61, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63,
+	0x74, 0x49, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x69, 0x6e, 0x67,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x69, 0x6e,
+	0x67, 0x12, 0x29, 0x0a, 0x10, 0x73, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x5f, 0x76, 0x65,
+	0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x73, 0x6e, 0x61,
+	0x70, 0x73, 0x68, 0x6f, 0x74, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x20, 0x0a, 0x0b,
+	0x63, 0x6c, 0x61, 0x73, 0x73, 0x69, 0x66, 0x69, 0x65, 0x72, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28,
+	0x09, 0x52, 0x0b, 0x63, 0x6c, 0x61, 0x73, 0x73, 0x69, 0x66, 0x69, 0x65, 0x72, 0x73, 0x22, 0x86,
+	0x01, 0x0a, 0x12, 0x4f, 0x53, 0x56, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x4d, 0x65, 0x74,
+	0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x75, 0x72, 0x6c, 0x5f, 0x74, 0x79,
+	0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x75, 0x72, 0x6c, 0x54, 0x79,
+	0x70, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x65, 0x63,
+	0x6f, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x65,
+	0x63, 0x6f, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x6f, 0x6d, 0x70,
+	0x61, 0x72, 0x65, 0x5f, 0x61, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x6f,
+	0x6d, 0x70, 0x61, 0x72, 0x65, 0x41, 0x73, 0x22, 0xad, 0x01, 0x0a, 0x18, 0x50, 0x79, 0x74, 0x68,
+	0x6f, 0x6e, 0x56, 0x69, 0x72, 0x74, 0x75, 0x61, 0x6c, 0x65, 0x6e, 0x76, 0x4d, 0x65, 0x74, 0x61,
+	0x64, 0x61, 0x74, 0x61, 0x12, 0x29, 0x0a, 0x10, 0x65, 0x6e, 0x76, 0x69, 0x72, 0x6f, 0x6e, 0x6d,
+	0x65, 0x6e, 0x74, 0x5f, 0x70, 0x61, 0x74, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f,
+	0x65, 0x6e, 0x76, 0x69, 0x72, 0x6f, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x50, 0x61, 0x74, 0x68, 0x12,
+	0x25, 0x0a, 0x0e, 0x70, 0x79, 0x74, 0x68, 0x6f, 0x6e, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f,
+	0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x70, 0x79, 0x74, 0x68, 0x6f, 0x6e, 0x56,
+	0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x3f, 0x0a, 0x1c, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64,
+	0x65, 0x5f, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x5f, 0x73, 0x69, 0x74, 0x65, 0x5f, 0x70, 0x61,
+	0x63, 0x6b, 0x61, 0x67, 0x65, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x19, 0x69, 0x6e,
+	0x63, 0x6c, 0x75, 0x64, 0x65, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x53, 0x69, 0x74, 0x65, 0x50,
+	0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x73, 0x22, 0x86, 0x01, 0x0a, 0x1a, 0x50, 0x79, 0x74, 0x68,
+	0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x4d, 0x65,
+	0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x39, 0x0a, 0x19, 0x68, 0x61, 0x73, 0x68, 0x5f, 0x63,
+	0x68, 0x65, 0x63, 0x6b, 0x69, 0x6e, 0x67, 0x5f, 0x6d, 0x6f, 0x64, 0x65, 0x5f, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x16, 0x68, 0x61, 0x73, 0x68, 0x43,
+	0x68, 0x65, 0x63, 0x6b, 0x69, 0x6e, 0x67, 0x4d, 0x6f, 0x64, 0x65, 0x56, 0x61, 0x6c, 0x75, 0x65,
+	0x73, 0x12, 0x2d, 0x0a, 0x12, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x63, 0x6f, 0x6d,
+	0x70, 0x61, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x11, 0x76,
+	0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x72, 0x61, 0x74, 0x6f, 0x72,
+	0x22, 0x65, 0x0a, 0x13, 0x50, 0x69, 0x70, 0x66, 0x69, 0x6c, 0x65, 0x4c, 0x6f, 0x63, 0x6b, 0x4d,
+	0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x65, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x08, 0x73, 0x65, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x68, 0x61, 0x73, 0x68, 0x65, 0x73, 0x18, 0x02, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x06, 0x68, 0x61, 0x73, 0x68, 0x65, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x75,
+	0x6e, 0x70, 0x69, 0x6e, 0x6e, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x75,
+	0x6e, 0x70, 0x69, 0x6e, 0x6e, 0x65, 0x64, 0x22, 0x8a, 0x01, 0x0a, 0x0f, 0x4d, 0x69, 0x78, 0x4c,
+	0x6f, 0x63, 0x6b, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x16, 0x0a, 0x06, 0x73,
+	0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x6f, 0x75,
+	0x72, 0x63, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x65, 0x70, 0x6f, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x72, 0x65, 0x70, 0x6f, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x68, 0x65, 0x63, 0x6b,
+	0x73, 0x75, 0x6d, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x68, 0x65, 0x63, 0x6b,
+	0x73, 0x75, 0x6d, 0x12, 0x17, 0x0a, 0x07, 0x67, 0x69, 0x74, 0x5f, 0x75, 0x72, 0x6c, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x67, 0x69, 0x74, 0x55, 0x72, 0x6c, 0x12, 0x16, 0x0a, 0x06,
+	0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x63, 0x6f,
+	0x6d, 0x6d, 0x69, 0x74, 0x22, 0x9d, 0x01, 0x0a, 0x12, 0x4f, 0x53, 0x47, 0x69, 0x42, 0x75, 0x6e,
+	0x64, 0x6c, 0x65, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x1f, 0x0a, 0x0b, 0x62,
+	0x75, 0x6e, 0x64, 0x6c, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0a, 0x62, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x23, 0x0a, 0x0d,
+	0x62, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x5f, 0x76, 0x65, 0x6e, 0x64, 0x6f, 0x72, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0c, 0x62, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x56, 0x65, 0x6e, 0x64, 0x6f,
+	0x72, 0x12, 0x1c, 0x0a, 0x09, 0x71, 0x75, 0x61, 0x6c, 0x69, 0x66, 0x69, 0x65, 0x72, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x71, 0x75, 0x61, 0x6c, 0x69, 0x66, 0x69, 0x65, 0x72, 0x12,
+	0x23, 0x0a, 0x0d, 0x66, 0x72, 0x61, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x68, 0x6f, 0x73, 0x74,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x66, 0x72, 0x61, 0x67, 0x6d, 0x65, 0x6e, 0x74,
+	0x48, 0x6f, 0x73, 0x74, 0x22, 0x58, 0x0a, 0x0c, 0x43, 0x50, 0x41, 0x4e, 0x4d, 0x65, 0x74, 0x61,
+	0x64, 0x61, 0x74, 0x61, 0x12, 0x21, 0x0a, 0x0c, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x5f,
+	0x64, 0x61, 0x74, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x69, 0x6e, 0x73, 0x74,
+	0x61, 0x6c, 0x6c, 0x44, 0x61, 0x74, 0x65, 0x12, 0x25, 0x0a, 0x0e, 0x69, 0x6e, 0x73, 0x74, 0x61,
+	0x6c, 0x6c, 0x65, 0x64, 0x5f, 0x69, 0x6e, 0x74, 0x6f, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0d, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x65, 0x64, 0x49, 0x6e, 0x74, 0x6f, 0x22, 0xe4,
+	0x01, 0x0a, 0x16, 0x4f, 0x43, 0x49, 0x4c, 0x61, 0x79, 0x6f, 0x75, 0x74, 0x49, 0x6d, 0x61, 0x67,
+	0x65, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x1e, 0x0a, 0x0a, 0x72, 0x65, 0x70,
+	0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x72,
+	0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x74, 0x61, 0x67,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x74, 0x61, 0x67, 0x12, 0x27, 0x0a, 0x0f, 0x6d,
+	0x61, 0x6e, 0x69, 0x66, 0x65, 0x73, 0x74, 0x5f, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x6d, 0x61, 0x6e, 0x69, 0x66, 0x65, 0x73, 0x74, 0x44, 0x69,
+	0x67, 0x65, 0x73, 0x74, 0x12, 0x21, 0x0a, 0x0c, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x5f, 0x64, 0x69,
+	0x67, 0x65, 0x73, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x69, 0x6e, 0x64, 0x65,
+	0x78, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x6f, 0x73, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x02, 0x6f, 0x73, 0x12, 0x22, 0x0a, 0x0c, 0x61, 0x72, 0x63, 0x68, 0x69,
+	0x74, 0x65, 0x63, 0x74, 0x75, 0x72, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x61,
+	0x72, 0x63, 0x68, 0x69, 0x74, 0x65, 0x63, 0x74, 0x75, 0x72, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x76,
+	0x61, 0x72, 0x69, 0x61, 0x6e, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x61,
+	0x72, 0x69, 0x61, 0x6e, 0x74, 0x22, 0x55, 0x0a, 0x17, 0x45, 0x6d, 0x62, 0x65, 0x64, 0x64, 0x65,
+	0x64, 0x52, 0x75, 0x6e, 0x74, 0x69, 0x6d, 0x65, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61,
+	0x12, 0x1a, 0x0a, 0x08, 0x65, 0x76, 0x69, 0x64, 0x65, 0x6e, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x08, 0x65, 0x76, 0x69, 0x64, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x1e, 0x0a, 0x0a,
+	0x63, 0x6f, 0x6e, 0x66, 0x69, 0x64, 0x65, 0x6e, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0a, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x64, 0x65, 0x6e, 0x63, 0x65, 0x22, 0x49, 0x0a, 0x12,
+	0x45, 0x6d, 0x62, 0x65, 0x64, 0x64, 0x65, 0x64, 0x49, 0x64, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61,
+	0x74, 0x61, 0x12, 0x21, 0x0a, 0x04, 0x70, 0x75, 0x72, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x0d, 0x2e, 0x73, 0x63, 0x61, 0x6c, 0x69, 0x62, 0x72, 0x2e, 0x50, 0x75, 0x72, 0x6c, 0x52,
+	0x04, 0x70, 0x75, 0x72, 0x6c, 0x12, 0x10, 0x0a, 0x03, 0x63, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x03, 0x63, 0x70, 0x65, 0x22, 0x98, 0x01, 0x0a, 0x11, 0x48, 0x65, 0x6c, 0x6d,
+	0x43, 0x68, 0x61, 0x72, 0x74, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x1f, 0x0a,
+	0x0b, 0x61, 0x70, 0x70, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0a, 0x61, 0x70, 0x70, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x1f,
+	0x0a, 0x0b, 0x61, 0x70, 0x69, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0a, 0x61, 0x70, 0x69, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12,
+	0x1e, 0x0a, 0x0a, 0x72, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x79, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0a, 0x72, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x79, 0x12,
+	0x21, 0x0a, 0x0c, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x5f, 0x63, 0x68, 0x61, 0x72, 0x74, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x43, 0x68, 0x61,
+	0x72, 0x74, 0x22, 0xa8, 0x01, 0x0a, 0x15, 0x44, 0x6f, 0x63, 0x6b, 0x65, 0x72, 0x43, 0x6f, 0x6d,
+	0x70, 0x6f, 0x73, 0x65, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x21, 0x0a, 0x0c,
+	0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0b, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12,
+	0x1e, 0x0a, 0x0a, 0x72, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x79, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0a, 0x72, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x79, 0x12,
+	0x10, 0x0a, 0x03, 0x74, 0x61, 0x67, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x74, 0x61,
+	0x67, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x12, 0x22, 0x0a, 0x0c, 0x69, 0x6e, 0x74,
+	0x65, 0x72, 0x70, 0x6f, 0x6c, 0x61, 0x74, 0x65, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x0c, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x70, 0x6f, 0x6c, 0x61, 0x74, 0x65, 0x64, 0x22, 0xe7, 0x01,
+	0x0a, 0x0d, 0x47, 0x6f, 0x4d, 0x6f, 0x64, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12,
+	0x1a, 0x0a, 0x08, 0x69, 0x6e, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x08, 0x69, 0x6e, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x12, 0x23, 0x0a, 0x0d, 0x72,
+	0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x64, 0x5f, 0x70, 0x61, 0x74, 0x68, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0c, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x64, 0x50, 0x61, 0x74, 0x68,
+	0x12, 0x29, 0x0a, 0x10, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x64, 0x5f, 0x76, 0x65, 0x72,
+	0x73, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x72, 0x65, 0x71, 0x75,
+	0x69, 0x72, 0x65, 0x64, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x1f, 0x0a, 0x0b, 0x72,
+	0x65, 0x70, 0x6c, 0x61, 0x63, 0x65, 0x5f, 0x64, 0x69, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0a, 0x72, 0x65, 0x70, 0x6c, 0x61, 0x63, 0x65, 0x44, 0x69, 0x72, 0x12, 0x1a, 0x0a, 0x08,
+	0x63, 0x68, 0x65, 0x63, 0x6b, 0x73, 0x75, 0x6d, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
+	0x63, 0x68, 0x65, 0x63, 0x6b, 0x73, 0x75, 0x6d, 0x12, 0x2d, 0x0a, 0x13, 0x6d, 0x69, 0x73, 0x73,
+	0x69, 0x6e, 0x67, 0x5f, 0x66, 0x72, 0x6f, 0x6d, 0x5f, 0x67, 0x6f, 0x5f, 0x73, 0x75, 0x6d, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x10, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6e, 0x67, 0x46, 0x72,
+	0x6f, 0x6d, 0x47, 0x6f, 0x53, 0x75, 0x6d, 0x22, 0xb9, 0x01, 0x0a, 0x0b, 0x55, 0x50, 0x58, 0x4d,
+	0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x61, 0x63, 0x6b, 0x65,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x64, 0x12,
+	0x25, 0x0a, 0x0e, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x5f, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6e,
+	0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0d, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x4d,
+	0x69, 0x73, 0x73, 0x69, 0x6e, 0x67, 0x12, 0x25, 0x0a, 0x0e, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72,
+	0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0d,
+	0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x16, 0x0a,
+	0x06, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x66,
+	0x6f, 0x72, 0x6d, 0x61, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x12, 0x14, 0x0a,
+	0x05, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x6c, 0x65,
+	0x76, 0x65, 0x6c, 0x22, 0x56, 0x0a, 0x10, 0x42, 0x61, 0x7a, 0x65, 0x6c, 0x44, 0x65, 0x70, 0x4d,
+	0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x1b, 0x0a, 0x09, 0x72, 0x65, 0x70, 0x6f, 0x5f,
+	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x72, 0x65, 0x70, 0x6f,
+	0x4e, 0x61, 0x6d, 0x65, 0x12, 0x25, 0x0a, 0x0e, 0x64, 0x65, 0x76, 0x5f, 0x64, 0x65, 0x70, 0x65,
+	0x6e, 0x64, 0x65, 0x6e, 0x63, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0d, 0x64, 0x65,
+	0x76, 0x44, 0x65, 0x70, 0x65, 0x6e, 0x64, 0x65, 0x6e, 0x63, 0x79, 0x22, 0x68, 0x0a, 0x14, 0x47,
+	0x69, 0x74, 0x53, 0x75, 0x62, 0x6d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x4d, 0x65, 0x74, 0x61, 0x64,
+	0x61, 0x74, 0x61, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x12, 0x10, 0x0a, 0x03, 0x75,
+	0x72, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x72, 0x6c, 0x12, 0x16, 0x0a,
+	0x06, 0x62, 0x72, 0x61, 0x6e, 0x63, 0x68, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x62,
+	0x72, 0x61, 0x6e, 0x63, 0x68, 0x22, 0xb4, 0x01, 0x0a, 0x14, 0x4b, 0x65, 0x72, 0x6e, 0x65, 0x6c,
+	0x4d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x25,
+	0x0a, 0x0e, 0x6b, 0x65, 0x72, 0x6e, 0x65, 0x6c, 0x5f, 0x72, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x6b, 0x65, 0x72, 0x6e, 0x65, 0x6c, 0x52, 0x65,
+	0x6c, 0x65, 0x61, 0x73, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x72, 0x63, 0x5f, 0x76, 0x65, 0x72,
+	0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x73, 0x72, 0x63, 0x56,
+	0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x18, 0x0a, 0x07, 0x6c, 0x69, 0x63, 0x65, 0x6e, 0x73,
+	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6c, 0x69, 0x63, 0x65, 0x6e, 0x73, 0x65,
+	0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69,
+	0x6f, 0x6e, 0x12, 0x18, 0x0a, 0x07, 0x64, 0x65, 0x70, 0x65, 0x6e, 0x64, 0x73, 0x18, 0x05, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x07, 0x64, 0x65, 0x70, 0x65, 0x6e, 0x64, 0x73, 0x22, 0xb4, 0x01, 0x0a,
+	0x11, 0x57, 0x6f, 0x72, 0x64, 0x50, 0x72, 0x65, 0x73, 0x73, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61,
+	0x74, 0x61, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x64, 0x69, 0x73, 0x70, 0x6c, 0x61,
+	0x79, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x69,
+	0x73, 0x70, 0x6c, 0x61, 0x79, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x75, 0x74,
+	0x68, 0x6f, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x61, 0x75, 0x74, 0x68, 0x6f,
+	0x72, 0x12, 0x2d, 0x0a, 0x12, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x73, 0x5f, 0x77, 0x6f,
+	0x72, 0x64, 0x70, 0x72, 0x65, 0x73, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x11, 0x72,
+	0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x73, 0x57, 0x6f, 0x72, 0x64, 0x70, 0x72, 0x65, 0x73, 0x73,
+	0x12, 0x21, 0x0a, 0x0c, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x73, 0x5f, 0x70, 0x68, 0x70,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x73,
+	0x50, 0x68, 0x70, 0x22, 0x51, 0x0a, 0x14, 0x59, 0x6f, 0x63, 0x74, 0x6f, 0x50, 0x61, 0x63, 0x6b,
+	0x61, 0x67, 0x65, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x1f, 0x0a, 0x0b, 0x72,
+	0x65, 0x63, 0x69, 0x70, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0a, 0x72, 0x65, 0x63, 0x69, 0x70, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07,
+	0x6c, 0x69, 0x63, 0x65, 0x6e, 0x73, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6c,
+	0x69, 0x63, 0x65, 0x6e, 0x73, 0x65, 0x22, 0x6f, 0x0a, 0x18, 0x42, 0x72, 0x6f, 0x77, 0x73, 0x65,
+	0x72, 0x45, 0x78, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61,
+	0x74, 0x61, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02,
+	0x69, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x62, 0x72, 0x6f, 0x77, 0x73, 0x65, 0x72, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x07, 0x62, 0x72, 0x6f, 0x77, 0x73, 0x65, 0x72, 0x12, 0x29, 0x0a, 0x10,
+	0x6d, 0x61, 0x6e, 0x69, 0x66, 0x65, 0x73, 0x74, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0f, 0x6d, 0x61, 0x6e, 0x69, 0x66, 0x65, 0x73, 0x74,
+	0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x78, 0x0a, 0x15, 0x4a, 0x65, 0x6e, 0x6b, 0x69,
+	0x6e, 0x73, 0x50, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61,
+	0x12, 0x1b, 0x0a, 0x09, 0x6c, 0x6f, 0x6e, 0x67, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x08, 0x6c, 0x6f, 0x6e, 0x67, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x19, 0x0a,
+	0x08, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x07, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x49, 0x64, 0x12, 0x27, 0x0a, 0x0f, 0x6a, 0x65, 0x6e, 0x6b,
+	0x69, 0x6e, 0x73, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0e, 0x6a, 0x65, 0x6e, 0x6b, 0x69, 0x6e, 0x73, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f,
+	0x6e, 0x22, 0x5a, 0x0a, 0x12, 0x41, 0x6e, 0x64, 0x72, 0x6f, 0x69, 0x64, 0x41, 0x50, 0x4b, 0x4d,
+	0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x21, 0x0a, 0x0c, 0x76, 0x65, 0x72, 0x73, 0x69,
+	0x6f, 0x6e, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x76,
+	0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x76, 0x65,
+	0x72, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0b, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x64, 0x65, 0x22, 0x38, 0x0a,
+	0x0d, 0x4e, 0x75, 0x47, 0x65, 0x74, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x27,
+	0x0a, 0x0f, 0x64, 0x65, 0x70, 0x65, 0x6e, 0x64, 0x65, 0x6e, 0x63, 0x79, 0x5f, 0x74, 0x79, 0x70,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x64, 0x65, 0x70, 0x65, 0x6e, 0x64, 0x65,
+	0x6e, 0x63, 0x79, 0x54, 0x79, 0x70, 0x65, 0x22, 0xca, 0x01, 0x0a, 0x1b, 0x43, 0x6f, 0x6e, 0x74,
 	0x61, 0x69, 0x6e, 0x65, 0x72, 0x64, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x4d,
 	0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x25, 0x0a, 0x0e, 0x6e, 0x61, 0x6d, 0x65, 0x73,
 	0x70, 0x61, 0x63, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
@@ -3020,84 +6307,154 @@ func file_proto_scan_result_proto_rawDescGZIP() []byte {
 	return file_proto_scan_result_proto_rawDescData
 }
 
-var file_proto_scan_result_proto_enumTypes = make([]protoimpl.EnumInfo, 4)
-var file_proto_scan_result_proto_msgTypes = make([]protoimpl.MessageInfo, 27)
+var file_proto_scan_result_proto_enumTypes = make([]protoimpl.EnumInfo, 7)
+var file_proto_scan_result_proto_msgTypes = make([]protoimpl.MessageInfo, 58)
 var file_proto_scan_result_proto_goTypes = []interface{}{
 	(ScanStatus_ScanStatusEnum)(0),             // 0: scalibr.ScanStatus.ScanStatusEnum
 	(Inventory_AnnotationEnum)(0),              // 1: scalibr.Inventory.AnnotationEnum
-	(Advisory_TypeEnum)(0),                     // 2: scalibr.Advisory.TypeEnum
-	(Severity_SeverityEnum)(0),                 // 3: scalibr.Severity.SeverityEnum
-	(*ScanResult)(nil),                         // 4: scalibr.ScanResult
-	(*ScanStatus)(nil),                         // 5: scalibr.ScanStatus
-	(*PluginStatus)(nil),                       // 6: scalibr.PluginStatus
-	(*Inventory)(nil),                          // 7: scalibr.Inventory
-	(*SourceCodeIdentifier)(nil),               // 8: scalibr.SourceCodeIdentifier
-	(*Purl)(nil),                               // 9: scalibr.Purl
-	(*Qualifier)(nil),                          // 10: scalibr.Qualifier
-	(*Finding)(nil),                            // 11: scalibr.Finding
-	(*Advisory)(nil),                           // 12: scalibr.Advisory
-	(*AdvisoryId)(nil),                         // 13: scalibr.AdvisoryId
-	(*Severity)(nil),                           // 14: scalibr.Severity
-	(*CVSS)(nil),                               // 15: scalibr.CVSS
-	(*TargetDetails)(nil),                      // 16: scalibr.TargetDetails
-	(*PythonPackageMetadata)(nil),              // 17: scalibr.PythonPackageMetadata
-	(*JavascriptPackageJSONMetadata)(nil),      // 18: scalibr.JavascriptPackageJSONMetadata
-	(*APKPackageMetadata)(nil),                 // 19: scalibr.APKPackageMetadata
-	(*DPKGPackageMetadata)(nil),                // 20: scalibr.DPKGPackageMetadata
-	(*RPMPackageMetadata)(nil),                 // 21: scalibr.RPMPackageMetadata
-	(*COSPackageMetadata)(nil),                 // 22: scalibr.COSPackageMetadata
-	(*SNAPPackageMetadata)(nil),                // 23: scalibr.SNAPPackageMetadata
-	(*FlatpakPackageMetadata)(nil),             // 24: scalibr.FlatpakPackageMetadata
-	(*SPDXPackageMetadata)(nil),                // 25: scalibr.SPDXPackageMetadata
-	(*JavaArchiveMetadata)(nil),                // 26: scalibr.JavaArchiveMetadata
-	(*OSVPackageMetadata)(nil),                 // 27: scalibr.OSVPackageMetadata
-	(*PythonRequirementsMetadata)(nil),         // 28: scalibr.PythonRequirementsMetadata
-	(*ContainerdContainerMetadata)(nil),        // 29: scalibr.ContainerdContainerMetadata
-	(*ContainerdRuntimeContainerMetadata)(nil), // 30: scalibr.ContainerdRuntimeContainerMetadata
-	(*timestamppb.Timestamp)(nil),              // 31: google.protobuf.Timestamp
+	(Inventory_ConfidenceEnum)(0),              // 2: scalibr.Inventory.ConfidenceEnum
+	(Location_LocationKind)(0),                 // 3: scalibr.Location.LocationKind
+	(Relationship_RelationshipType)(0),         // 4: scalibr.Relationship.RelationshipType
+	(Advisory_TypeEnum)(0),                     // 5: scalibr.Advisory.TypeEnum
+	(Severity_SeverityEnum)(0),                 // 6: scalibr.Severity.SeverityEnum
+	(*ScanResult)(nil),                         // 7: scalibr.ScanResult
+	(*ContainerImage)(nil),                     // 8: scalibr.ContainerImage
+	(*OSRelease)(nil),                          // 9: scalibr.OSRelease
+	(*ScanStatus)(nil),                         // 10: scalibr.ScanStatus
+	(*PluginStatus)(nil),                       // 11: scalibr.PluginStatus
+	(*Inventory)(nil),                          // 12: scalibr.Inventory
+	(*Location)(nil),                           // 13: scalibr.Location
+	(*Relationship)(nil),                       // 14: scalibr.Relationship
+	(*SourceCodeIdentifier)(nil),               // 15: scalibr.SourceCodeIdentifier
+	(*Purl)(nil),                               // 16: scalibr.Purl
+	(*Qualifier)(nil),                          // 17: scalibr.Qualifier
+	(*Finding)(nil),                            // 18: scalibr.Finding
+	(*Advisory)(nil),                           // 19: scalibr.Advisory
+	(*AdvisoryId)(nil),                         // 20: scalibr.AdvisoryId
+	(*Severity)(nil),                           // 21: scalibr.Severity
+	(*CVSS)(nil),                               // 22: scalibr.CVSS
+	(*TargetDetails)(nil),                      // 23: scalibr.TargetDetails
+	(*PythonPackageMetadata)(nil),              // 24: scalibr.PythonPackageMetadata
+	(*JavascriptPackageJSONMetadata)(nil),      // 25: scalibr.JavascriptPackageJSONMetadata
+	(*APKPackageMetadata)(nil),                 // 26: scalibr.APKPackageMetadata
+	(*DPKGPackageMetadata)(nil),                // 27: scalibr.DPKGPackageMetadata
+	(*OpkgPackageMetadata)(nil),                // 28: scalibr.OpkgPackageMetadata
+	(*RPMPackageMetadata)(nil),                 // 29: scalibr.RPMPackageMetadata
+	(*COSPackageMetadata)(nil),                 // 30: scalibr.COSPackageMetadata
+	(*SNAPPackageMetadata)(nil),                // 31: scalibr.SNAPPackageMetadata
+	(*FlatpakPackageMetadata)(nil),             // 32: scalibr.FlatpakPackageMetadata
+	(*MacAppsMetadata)(nil),                    // 33: scalibr.MacAppsMetadata
+	(*MacFrameworkMetadata)(nil),               // 34: scalibr.MacFrameworkMetadata
+	(*SPDXPackageMetadata)(nil),                // 35: scalibr.SPDXPackageMetadata
+	(*JavaArchiveMetadata)(nil),                // 36: scalibr.JavaArchiveMetadata
+	(*MavenRepositoryMetadata)(nil),            // 37: scalibr.MavenRepositoryMetadata
+	(*OSVPackageMetadata)(nil),                 // 38: scalibr.OSVPackageMetadata
+	(*PythonVirtualenvMetadata)(nil),           // 39: scalibr.PythonVirtualenvMetadata
+	(*PythonRequirementsMetadata)(nil),         // 40: scalibr.PythonRequirementsMetadata
+	(*PipfileLockMetadata)(nil),                // 41: scalibr.PipfileLockMetadata
+	(*MixLockMetadata)(nil),                    // 42: scalibr.MixLockMetadata
+	(*OSGiBundleMetadata)(nil),                 // 43: scalibr.OSGiBundleMetadata
+	(*CPANMetadata)(nil),                       // 44: scalibr.CPANMetadata
+	(*OCILayoutImageMetadata)(nil),             // 45: scalibr.OCILayoutImageMetadata
+	(*EmbeddedRuntimeMetadata)(nil),            // 46: scalibr.EmbeddedRuntimeMetadata
+	(*EmbeddedIdMetadata)(nil),                 // 47: scalibr.EmbeddedIdMetadata
+	(*HelmChartMetadata)(nil),                  // 48: scalibr.HelmChartMetadata
+	(*DockerComposeMetadata)(nil),              // 49: scalibr.DockerComposeMetadata
+	(*GoModMetadata)(nil),                      // 50: scalibr.GoModMetadata
+	(*UPXMetadata)(nil),                        // 51: scalibr.UPXMetadata
+	(*BazelDepMetadata)(nil),                   // 52: scalibr.BazelDepMetadata
+	(*GitSubmoduleMetadata)(nil),               // 53: scalibr.GitSubmoduleMetadata
+	(*KernelModuleMetadata)(nil),               // 54: scalibr.KernelModuleMetadata
+	(*WordPressMetadata)(nil),                  // 55: scalibr.WordPressMetadata
+	(*YoctoPackageMetadata)(nil),               // 56: scalibr.YoctoPackageMetadata
+	(*BrowserExtensionMetadata)(nil),           // 57: scalibr.BrowserExtensionMetadata
+	(*JenkinsPluginMetadata)(nil),              // 58: scalibr.JenkinsPluginMetadata
+	(*AndroidAPKMetadata)(nil),                 // 59: scalibr.AndroidAPKMetadata
+	(*NuGetMetadata)(nil),                      // 60: scalibr.NuGetMetadata
+	(*ContainerdContainerMetadata)(nil),        // 61: scalibr.ContainerdContainerMetadata
+	(*ContainerdRuntimeContainerMetadata)(nil), // 62: scalibr.ContainerdRuntimeContainerMetadata
+	nil,                           // 63: scalibr.ScanResult.MetadataEntry
+	nil,                           // 64: scalibr.ContainerImage.LabelsEntry
+	(*timestamppb.Timestamp)(nil), // 65: google.protobuf.Timestamp
 }
 var file_proto_scan_result_proto_depIdxs = []int32{
-	31, // 0: scalibr.ScanResult.start_time:type_name -> google.protobuf.Timestamp
-	31, // 1: scalibr.ScanResult.end_time:type_name -> google.protobuf.Timestamp
-	5,  // 2: scalibr.ScanResult.status:type_name -> scalibr.ScanStatus
-	6,  // 3: scalibr.ScanResult.plugin_status:type_name -> scalibr.PluginStatus
-	7,  // 4: scalibr.ScanResult.inventories:type_name -> scalibr.Inventory
-	11, // 5: scalibr.ScanResult.findings:type_name -> scalibr.Finding
-	0,  // 6: scalibr.ScanStatus.status:type_name -> scalibr.ScanStatus.ScanStatusEnum
-	5,  // 7: scalibr.PluginStatus.status:type_name -> scalibr.ScanStatus
-	8,  // 8: scalibr.Inventory.source_code:type_name -> scalibr.SourceCodeIdentifier
-	9,  // 9: scalibr.Inventory.purl:type_name -> scalibr.Purl
-	17, // 10: scalibr.Inventory.python_metadata:type_name -> scalibr.PythonPackageMetadata
-	18, // 11: scalibr.Inventory.javascript_metadata:type_name -> scalibr.JavascriptPackageJSONMetadata
-	19, // 12: scalibr.Inventory.apk_metadata:type_name -> scalibr.APKPackageMetadata
-	20, // 13: scalibr.Inventory.dpkg_metadata:type_name -> scalibr.DPKGPackageMetadata
-	21, // 14: scalibr.Inventory.rpm_metadata:type_name -> scalibr.RPMPackageMetadata
-	22, // 15: scalibr.Inventory.cos_metadata:type_name -> scalibr.COSPackageMetadata
-	25, // 16: scalibr.Inventory.spdx_metadata:type_name -> scalibr.SPDXPackageMetadata
-	26, // 17: scalibr.Inventory.java_archive_metadata:type_name -> scalibr.JavaArchiveMetadata
-	27, // 18: scalibr.Inventory.osv_metadata:type_name -> scalibr.OSVPackageMetadata
-	28, // 19: scalibr.Inventory.python_requirements_metadata:type_name -> scalibr.PythonRequirementsMetadata
-	29, // 20: scalibr.Inventory.containerd_container_metadata:type_name -> scalibr.ContainerdContainerMetadata
-	23, // 21: scalibr.Inventory.snap_metadata:type_name -> scalibr.SNAPPackageMetadata
-	24, // 22: scalibr.Inventory.flatpak_metadata:type_name -> scalibr.FlatpakPackageMetadata
-	30, // 23: scalibr.Inventory.containerd_runtime_container_metadata:type_name -> scalibr.ContainerdRuntimeContainerMetadata
-	1,  // 24: scalibr.Inventory.annotations:type_name -> scalibr.Inventory.AnnotationEnum
-	10, // 25: scalibr.Purl.qualifiers:type_name -> scalibr.Qualifier
-	12, // 26: scalibr.Finding.adv:type_name -> scalibr.Advisory
-	16, // 27: scalibr.Finding.target:type_name -> scalibr.TargetDetails
-	13, // 28: scalibr.Advisory.id:type_name -> scalibr.AdvisoryId
-	2,  // 29: scalibr.Advisory.type:type_name -> scalibr.Advisory.TypeEnum
-	14, // 30: scalibr.Advisory.sev:type_name -> scalibr.Severity
-	3,  // 31: scalibr.Severity.severity:type_name -> scalibr.Severity.SeverityEnum
-	15, // 32: scalibr.Severity.cvss_v2:type_name -> scalibr.CVSS
-	15, // 33: scalibr.Severity.cvss_v3:type_name -> scalibr.CVSS
-	7,  // 34: scalibr.TargetDetails.inventory:type_name -> scalibr.Inventory
-	9,  // 35: scalibr.SPDXPackageMetadata.purl:type_name -> scalibr.Purl
-	36, // [36:36] is the sub-list for method output_type
-	36, // [36:36] is the sub-list for method input_type
-	36, // [36:36] is the sub-list for extension type_name
-	36, // [36:36] is the sub-list for extension extendee
-	0,  // [0:36] is the sub-list for field type_name
+	65, // 0: scalibr.ScanResult.start_time:type_name -> google.protobuf.Timestamp
+	65, // 1: scalibr.ScanResult.end_time:type_name -> google.protobuf.Timestamp
+	10, // 2: scalibr.ScanResult.status:type_name -> scalibr.ScanStatus
+	11, // 3: scalibr.ScanResult.plugin_status:type_name -> scalibr.PluginStatus
+	12, // 4: scalibr.ScanResult.inventories:type_name -> scalibr.Inventory
+	18, // 5: scalibr.ScanResult.findings:type_name -> scalibr.Finding
+	9,  // 6: scalibr.ScanResult.os_release:type_name -> scalibr.OSRelease
+	8,  // 7: scalibr.ScanResult.container_image:type_name -> scalibr.ContainerImage
+	63, // 8: scalibr.ScanResult.metadata:type_name -> scalibr.ScanResult.MetadataEntry
+	65, // 9: scalibr.ContainerImage.created:type_name -> google.protobuf.Timestamp
+	64, // 10: scalibr.ContainerImage.labels:type_name -> scalibr.ContainerImage.LabelsEntry
+	0,  // 11: scalibr.ScanStatus.status:type_name -> scalibr.ScanStatus.ScanStatusEnum
+	10, // 12: scalibr.PluginStatus.status:type_name -> scalibr.ScanStatus
+	15, // 13: scalibr.Inventory.source_code:type_name -> scalibr.SourceCodeIdentifier
+	16, // 14: scalibr.Inventory.purl:type_name -> scalibr.Purl
+	13, // 15: scalibr.Inventory.location_details:type_name -> scalibr.Location
+	24, // 16: scalibr.Inventory.python_metadata:type_name -> scalibr.PythonPackageMetadata
+	25, // 17: scalibr.Inventory.javascript_metadata:type_name -> scalibr.JavascriptPackageJSONMetadata
+	26, // 18: scalibr.Inventory.apk_metadata:type_name -> scalibr.APKPackageMetadata
+	27, // 19: scalibr.Inventory.dpkg_metadata:type_name -> scalibr.DPKGPackageMetadata
+	29, // 20: scalibr.Inventory.rpm_metadata:type_name -> scalibr.RPMPackageMetadata
+	30, // 21: scalibr.Inventory.cos_metadata:type_name -> scalibr.COSPackageMetadata
+	35, // 22: scalibr.Inventory.spdx_metadata:type_name -> scalibr.SPDXPackageMetadata
+	36, // 23: scalibr.Inventory.java_archive_metadata:type_name -> scalibr.JavaArchiveMetadata
+	38, // 24: scalibr.Inventory.osv_metadata:type_name -> scalibr.OSVPackageMetadata
+	40, // 25: scalibr.Inventory.python_requirements_metadata:type_name -> scalibr.PythonRequirementsMetadata
+	61, // 26: scalibr.Inventory.containerd_container_metadata:type_name -> scalibr.ContainerdContainerMetadata
+	31, // 27: scalibr.Inventory.snap_metadata:type_name -> scalibr.SNAPPackageMetadata
+	32, // 28: scalibr.Inventory.flatpak_metadata:type_name -> scalibr.FlatpakPackageMetadata
+	62, // 29: scalibr.Inventory.containerd_runtime_container_metadata:type_name -> scalibr.ContainerdRuntimeContainerMetadata
+	33, // 30: scalibr.Inventory.mac_apps_metadata:type_name -> scalibr.MacAppsMetadata
+	45, // 31: scalibr.Inventory.oci_layout_image_metadata:type_name -> scalibr.OCILayoutImageMetadata
+	37, // 32: scalibr.Inventory.maven_repository_metadata:type_name -> scalibr.MavenRepositoryMetadata
+	39, // 33: scalibr.Inventory.python_virtualenv_metadata:type_name -> scalibr.PythonVirtualenvMetadata
+	46, // 34: scalibr.Inventory.embedded_runtime_metadata:type_name -> scalibr.EmbeddedRuntimeMetadata
+	48, // 35: scalibr.Inventory.helm_chart_metadata:type_name -> scalibr.HelmChartMetadata
+	58, // 36: scalibr.Inventory.jenkins_plugin_metadata:type_name -> scalibr.JenkinsPluginMetadata
+	59, // 37: scalibr.Inventory.android_apk_metadata:type_name -> scalibr.AndroidAPKMetadata
+	60, // 38: scalibr.Inventory.nuget_metadata:type_name -> scalibr.NuGetMetadata
+	41, // 39: scalibr.Inventory.pipfile_lock_metadata:type_name -> scalibr.PipfileLockMetadata
+	28, // 40: scalibr.Inventory.opkg_metadata:type_name -> scalibr.OpkgPackageMetadata
+	53, // 41: scalibr.Inventory.git_submodule_metadata:type_name -> scalibr.GitSubmoduleMetadata
+	57, // 42: scalibr.Inventory.browser_extension_metadata:type_name -> scalibr.BrowserExtensionMetadata
+	55, // 43: scalibr.Inventory.wordpress_metadata:type_name -> scalibr.WordPressMetadata
+	54, // 44: scalibr.Inventory.kernel_module_metadata:type_name -> scalibr.KernelModuleMetadata
+	56, // 45: scalibr.Inventory.yocto_package_metadata:type_name -> scalibr.YoctoPackageMetadata
+	42, // 46: scalibr.Inventory.mix_lock_metadata:type_name -> scalibr.MixLockMetadata
+	49, // 47: scalibr.Inventory.docker_compose_metadata:type_name -> scalibr.DockerComposeMetadata
+	47, // 48: scalibr.Inventory.embedded_id_metadata:type_name -> scalibr.EmbeddedIdMetadata
+	44, // 49: scalibr.Inventory.cpan_metadata:type_name -> scalibr.CPANMetadata
+	52, // 50: scalibr.Inventory.bazel_dep_metadata:type_name -> scalibr.BazelDepMetadata
+	43, // 51: scalibr.Inventory.osgi_bundle_metadata:type_name -> scalibr.OSGiBundleMetadata
+	51, // 52: scalibr.Inventory.upx_metadata:type_name -> scalibr.UPXMetadata
+	50, // 53: scalibr.Inventory.go_mod_metadata:type_name -> scalibr.GoModMetadata
+	34, // 54: scalibr.Inventory.mac_framework_metadata:type_name -> scalibr.MacFrameworkMetadata
+	1,  // 55: scalibr.Inventory.annotations:type_name -> scalibr.Inventory.AnnotationEnum
+	14, // 56: scalibr.Inventory.relationships:type_name -> scalibr.Relationship
+	2,  // 57: scalibr.Inventory.confidence:type_name -> scalibr.Inventory.ConfidenceEnum
+	3,  // 58: scalibr.Location.kind:type_name -> scalibr.Location.LocationKind
+	4,  // 59: scalibr.Relationship.type:type_name -> scalibr.Relationship.RelationshipType
+	17, // 60: scalibr.Purl.qualifiers:type_name -> scalibr.Qualifier
+	19, // 61: scalibr.Finding.adv:type_name -> scalibr.Advisory
+	23, // 62: scalibr.Finding.target:type_name -> scalibr.TargetDetails
+	20, // 63: scalibr.Advisory.id:type_name -> scalibr.AdvisoryId
+	5,  // 64: scalibr.Advisory.type:type_name -> scalibr.Advisory.TypeEnum
+	21, // 65: scalibr.Advisory.sev:type_name -> scalibr.Severity
+	6,  // 66: scalibr.Severity.severity:type_name -> scalibr.Severity.SeverityEnum
+	22, // 67: scalibr.Severity.cvss_v2:type_name -> scalibr.CVSS
+	22, // 68: scalibr.Severity.cvss_v3:type_name -> scalibr.CVSS
+	12, // 69: scalibr.TargetDetails.inventory:type_name -> scalibr.Inventory
+	16, // 70: scalibr.SPDXPackageMetadata.purl:type_name -> scalibr.Purl
+	16, // 71: scalibr.EmbeddedIdMetadata.purl:type_name -> scalibr.Purl
+	72, // [72:72] is the sub-list for method output_type
+	72, // [72:72] is the sub-list for method input_type
+	72, // [72:72] is the sub-list for extension type_name
+	72, // [72:72] is the sub-list for extension extendee
+	0,  // [0:72] is the sub-list for field type_name
 }
 
 func init() { file_proto_scan_result_proto_init() }
@@ -3118,8 +6475,152 @@ func file_proto_scan_result_proto_init() {
 				return nil
 			}
 		}
-		file_proto_scan_result_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ScanStatus); i {
+		file_proto_scan_result_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ContainerImage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_scan_result_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OSRelease); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_scan_result_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ScanStatus); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_scan_result_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PluginStatus); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_scan_result_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Inventory); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_scan_result_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Location); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_scan_result_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Relationship); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_scan_result_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SourceCodeIdentifier); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_scan_result_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Purl); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_scan_result_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Qualifier); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_scan_result_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Finding); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_scan_result_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Advisory); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_scan_result_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AdvisoryId); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3130,8 +6631,8 @@ func file_proto_scan_result_proto_init() {
 				return nil
 			}
 		}
-		file_proto_scan_result_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PluginStatus); i {
+		file_proto_scan_result_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Severity); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3142,8 +6643,8 @@ func file_proto_scan_result_proto_init() {
 				return nil
 			}
 		}
-		file_proto_scan_result_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Inventory); i {
+		file_proto_scan_result_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CVSS); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3154,8 +6655,8 @@ func file_proto_scan_result_proto_init() {
 				return nil
 			}
 		}
-		file_proto_scan_result_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SourceCodeIdentifier); i {
+		file_proto_scan_result_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TargetDetails); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3166,8 +6667,8 @@ func file_proto_scan_result_proto_init() {
 				return nil
 			}
 		}
-		file_proto_scan_result_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Purl); i {
+		file_proto_scan_result_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PythonPackageMetadata); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3178,8 +6679,8 @@ func file_proto_scan_result_proto_init() {
 				return nil
 			}
 		}
-		file_proto_scan_result_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Qualifier); i {
+		file_proto_scan_result_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*JavascriptPackageJSONMetadata); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3190,8 +6691,8 @@ func file_proto_scan_result_proto_init() {
 				return nil
 			}
 		}
-		file_proto_scan_result_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Finding); i {
+		file_proto_scan_result_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*APKPackageMetadata); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3202,8 +6703,8 @@ func file_proto_scan_result_proto_init() {
 				return nil
 			}
 		}
-		file_proto_scan_result_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Advisory); i {
+		file_proto_scan_result_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DPKGPackageMetadata); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3214,8 +6715,8 @@ func file_proto_scan_result_proto_init() {
 				return nil
 			}
 		}
-		file_proto_scan_result_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*AdvisoryId); i {
+		file_proto_scan_result_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OpkgPackageMetadata); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3226,8 +6727,8 @@ func file_proto_scan_result_proto_init() {
 				return nil
 			}
 		}
-		file_proto_scan_result_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Severity); i {
+		file_proto_scan_result_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RPMPackageMetadata); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3238,8 +6739,8 @@ func file_proto_scan_result_proto_init() {
 				return nil
 			}
 		}
-		file_proto_scan_result_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CVSS); i {
+		file_proto_scan_result_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*COSPackageMetadata); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3250,8 +6751,8 @@ func file_proto_scan_result_proto_init() {
 				return nil
 			}
 		}
-		file_proto_scan_result_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*TargetDetails); i {
+		file_proto_scan_result_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SNAPPackageMetadata); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3262,8 +6763,8 @@ func file_proto_scan_result_proto_init() {
 				return nil
 			}
 		}
-		file_proto_scan_result_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PythonPackageMetadata); i {
+		file_proto_scan_result_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FlatpakPackageMetadata); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3274,8 +6775,8 @@ func file_proto_scan_result_proto_init() {
 				return nil
 			}
 		}
-		file_proto_scan_result_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*JavascriptPackageJSONMetadata); i {
+		file_proto_scan_result_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MacAppsMetadata); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3286,8 +6787,8 @@ func file_proto_scan_result_proto_init() {
 				return nil
 			}
 		}
-		file_proto_scan_result_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*APKPackageMetadata); i {
+		file_proto_scan_result_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MacFrameworkMetadata); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3298,8 +6799,8 @@ func file_proto_scan_result_proto_init() {
 				return nil
 			}
 		}
-		file_proto_scan_result_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DPKGPackageMetadata); i {
+		file_proto_scan_result_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SPDXPackageMetadata); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3310,8 +6811,8 @@ func file_proto_scan_result_proto_init() {
 				return nil
 			}
 		}
-		file_proto_scan_result_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RPMPackageMetadata); i {
+		file_proto_scan_result_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*JavaArchiveMetadata); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3322,8 +6823,8 @@ func file_proto_scan_result_proto_init() {
 				return nil
 			}
 		}
-		file_proto_scan_result_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*COSPackageMetadata); i {
+		file_proto_scan_result_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MavenRepositoryMetadata); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3334,8 +6835,8 @@ func file_proto_scan_result_proto_init() {
 				return nil
 			}
 		}
-		file_proto_scan_result_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SNAPPackageMetadata); i {
+		file_proto_scan_result_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OSVPackageMetadata); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3346,8 +6847,8 @@ func file_proto_scan_result_proto_init() {
 				return nil
 			}
 		}
-		file_proto_scan_result_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*FlatpakPackageMetadata); i {
+		file_proto_scan_result_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PythonVirtualenvMetadata); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3358,8 +6859,8 @@ func file_proto_scan_result_proto_init() {
 				return nil
 			}
 		}
-		file_proto_scan_result_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SPDXPackageMetadata); i {
+		file_proto_scan_result_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PythonRequirementsMetadata); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3370,8 +6871,8 @@ func file_proto_scan_result_proto_init() {
 				return nil
 			}
 		}
-		file_proto_scan_result_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*JavaArchiveMetadata); i {
+		file_proto_scan_result_proto_msgTypes[34].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PipfileLockMetadata); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3382,8 +6883,8 @@ func file_proto_scan_result_proto_init() {
 				return nil
 			}
 		}
-		file_proto_scan_result_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*OSVPackageMetadata); i {
+		file_proto_scan_result_proto_msgTypes[35].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MixLockMetadata); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3394,8 +6895,8 @@ func file_proto_scan_result_proto_init() {
 				return nil
 			}
 		}
-		file_proto_scan_result_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PythonRequirementsMetadata); i {
+		file_proto_scan_result_proto_msgTypes[36].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OSGiBundleMetadata); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3406,7 +6907,211 @@ func file_proto_scan_result_proto_init() {
 				return nil
 			}
 		}
-		file_proto_scan_result_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+		file_proto_scan_result_proto_msgTypes[37].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CPANMetadata); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_scan_result_proto_msgTypes[38].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OCILayoutImageMetadata); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_scan_result_proto_msgTypes[39].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EmbeddedRuntimeMetadata); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_scan_result_proto_msgTypes[40].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EmbeddedIdMetadata); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_scan_result_proto_msgTypes[41].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HelmChartMetadata); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_scan_result_proto_msgTypes[42].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DockerComposeMetadata); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_scan_result_proto_msgTypes[43].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GoModMetadata); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_scan_result_proto_msgTypes[44].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UPXMetadata); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_scan_result_proto_msgTypes[45].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BazelDepMetadata); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_scan_result_proto_msgTypes[46].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GitSubmoduleMetadata); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_scan_result_proto_msgTypes[47].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*KernelModuleMetadata); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_scan_result_proto_msgTypes[48].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WordPressMetadata); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_scan_result_proto_msgTypes[49].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*YoctoPackageMetadata); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_scan_result_proto_msgTypes[50].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BrowserExtensionMetadata); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_scan_result_proto_msgTypes[51].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*JenkinsPluginMetadata); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_scan_result_proto_msgTypes[52].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AndroidAPKMetadata); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_scan_result_proto_msgTypes[53].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NuGetMetadata); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_scan_result_proto_msgTypes[54].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*ContainerdContainerMetadata); i {
 			case 0:
 				return &v.state
@@ -3418,7 +7123,7 @@ func file_proto_scan_result_proto_init() {
 				return nil
 			}
 		}
-		file_proto_scan_result_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+		file_proto_scan_result_proto_msgTypes[55].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*ContainerdRuntimeContainerMetadata); i {
 			case 0:
 				return &v.state
@@ -3431,7 +7136,7 @@ func file_proto_scan_result_proto_init() {
 			}
 		}
 	}
-	file_proto_scan_result_proto_msgTypes[3].OneofWrappers = []interface{}{
+	file_proto_scan_result_proto_msgTypes[5].OneofWrappers = []interface{}{
 		(*Inventory_PythonMetadata)(nil),
 		(*Inventory_JavascriptMetadata)(nil),
 		(*Inventory_ApkMetadata)(nil),
@@ -3446,14 +7151,39 @@ func file_proto_scan_result_proto_init() {
 		(*Inventory_SnapMetadata)(nil),
 		(*Inventory_FlatpakMetadata)(nil),
 		(*Inventory_ContainerdRuntimeContainerMetadata)(nil),
+		(*Inventory_MacAppsMetadata)(nil),
+		(*Inventory_OciLayoutImageMetadata)(nil),
+		(*Inventory_MavenRepositoryMetadata)(nil),
+		(*Inventory_PythonVirtualenvMetadata)(nil),
+		(*Inventory_EmbeddedRuntimeMetadata)(nil),
+		(*Inventory_HelmChartMetadata)(nil),
+		(*Inventory_JenkinsPluginMetadata)(nil),
+		(*Inventory_AndroidApkMetadata)(nil),
+		(*Inventory_NugetMetadata)(nil),
+		(*Inventory_PipfileLockMetadata)(nil),
+		(*Inventory_OpkgMetadata)(nil),
+		(*Inventory_GitSubmoduleMetadata)(nil),
+		(*Inventory_BrowserExtensionMetadata)(nil),
+		(*Inventory_WordpressMetadata)(nil),
+		(*Inventory_KernelModuleMetadata)(nil),
+		(*Inventory_YoctoPackageMetadata)(nil),
+		(*Inventory_MixLockMetadata)(nil),
+		(*Inventory_DockerComposeMetadata)(nil),
+		(*Inventory_EmbeddedIdMetadata)(nil),
+		(*Inventory_CpanMetadata)(nil),
+		(*Inventory_BazelDepMetadata)(nil),
+		(*Inventory_OsgiBundleMetadata)(nil),
+		(*Inventory_UpxMetadata)(nil),
+		(*Inventory_GoModMetadata)(nil),
+		(*Inventory_MacFrameworkMetadata)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_proto_scan_result_proto_rawDesc,
-			NumEnums:      4,
-			NumMessages:   27,
+			NumEnums:      7,
+			NumMessages:   58,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -22,6 +22,7 @@ import (
 
 	"github.com/google/osv-scalibr/binary/cli"
 	"github.com/google/osv-scalibr/binary/scanrunner"
+	"github.com/google/osv-scalibr/extractor/standalone"
 	"github.com/google/osv-scalibr/log"
 )
 
@@ -31,14 +32,25 @@ func main() {
 }
 
 func parseFlags() *cli.Flags {
-	root := flag.String("root", "", `The root dir used by detectors and by file walking during extraction (e.g.: "/", "c:\" or ".")`)
-	resultFile := flag.String("result", "", "The path of the output scan result file")
+	var root cli.Array
+	flag.Var(&root, "root", `The root dir used by detectors and by file walking during extraction (e.g.: "/", "c:\" or "."). Can be repeated to scan several directories into one result, e.g. --root=/app --root=/opt/tools. With more than one root, the inventory locations are absolute paths.`)
+	diskImage := flag.String("disk-image", "", "Path to a raw disk image (e.g. created with dd) to scan instead of the local filesystem. The image can contain an ext2/3/4 filesystem or an MBR or GPT partition table with one ext2/3/4 partition. Other formats such as qcow2 and VMDK need to be converted to raw images first, e.g. with qemu-img convert -O raw.")
+	imageTarball := flag.String("image-tarball", "", "Path to a container image tarball created with docker save to scan instead of the local filesystem. The layers are composed in order, with files deleted in upper layers removed, so no container runtime is needed. The image's creation time and labels are recorded in the scan result.")
+	resultFile := flag.String("result", "", "The path of the output scan result file. Can also be a gs:// or s3:// URL.")
+	var metadata cli.Array
+	var extractorPlugins cli.Array
+	flag.Var(&extractorPlugins, "extractor-plugin", "The path of an executable implementing an external extractor, see extractor/filesystem/external for the protocol. Can be repeated. The executables are started before the scan and their extractors are enabled in --extractors by the names they report.")
+	flag.Var(&metadata, "metadata", "A key=value pair describing the scan, e.g. --metadata role=web --metadata region=europe-west1. Can be repeated with different keys. The pairs are written to the metadata field of the proto outputs, the creator comment of the SPDX outputs and the metadata properties of the CDX outputs.")
 	var output cli.Array
-	flag.Var(&output, "o", "The path of the scanner outputs in various formats, e.g. -o textproto=result.textproto -o spdx23-json=result.spdx.json -o cdx-json=result.cyclonedx.json")
-	extractorsToRun := flag.String("extractors", "default", "Comma-separated list of extractor plugins to run")
-	detectorsToRun := flag.String("detectors", "default", "Comma-separated list of detectors plugins to run")
-	dirsToSkip := flag.String("skip-dirs", "", "Comma-separated list of file paths to avoid traversing")
+	flag.Var(&output, "o", "The path of the scanner outputs in various formats, e.g. -o textproto=result.textproto -o spdx23-json=result.spdx.json -o cdx-json=result.cyclonedx.json -o purls=result.txt. Paths can also be gs:// and s3:// URLs to upload the outputs to object storage.")
+	extractorsToRun := flag.String("extractors", "default", "Comma-separated list of extractor plugins to run. Use @<file> to read the list from a file or @- to read it from stdin, with one or more comma-separated entries per line.")
+	detectorsToRun := flag.String("detectors", "default", "Comma-separated list of detectors plugins to run. Supports @<file> and @- like --extractors.")
+	enrichersToRun := flag.String("enrichers", "default", "Comma-separated list of enricher plugins to run on the extracted inventory, in the given order. SCALIBR doesn't include any enrichers, binaries that wrap it can add their own with enricher/list.Register.")
+	transformersToRun := flag.String("transform", "", "Comma-separated list of transformer plugins to run on the inventory before it's written, in the given order, e.g. drop-dev,tag-internal. Unlike enrichers, transformers can also remove inventory items. Options such as the name prefixes for tag-internal are set with --config.")
+	dirsToSkip := flag.String("skip-dirs", "", "Comma-separated list of file paths to avoid traversing. Entries can contain * and ** wildcards, e.g. **/node_modules. Supports @<file> and @- like --extractors.")
 	skipDirRegex := flag.String("skip-dir-regex", "", "If the regex matches a directory, it will be skipped. The regex is matched against the absolute file path.")
+	includeDirRegex := flag.String("include-dir-regex", "", "If set, only files in directories matching the regex (or inside a matching directory) are extracted, e.g. ^src/. The regex is matched against the path relative to the scan root. Directories leading to a match are still walked, and --skip-dirs and --skip-dir-regex take precedence.")
+	configFile := flag.String("config", "", "Path to a YAML file with per-plugin options, e.g. \"plugins: {govulncheck/binary: {offline_vuln_db_path: /path/to/db}}\"")
 	govulncheckDBPath := flag.String("govulncheck-db", "", "Path to the offline DB for the govulncheck detectors to use. Leave empty to run the detectors in online mode.")
 	spdxDocumentName := flag.String("spdx-document-name", "", "The 'name' field for the output SPDX document")
 	spdxDocumentNamespace := flag.String("spdx-document-namespace", "", "The 'documentNamespace' field for the output SPDX document")
@@ -46,34 +58,102 @@ func parseFlags() *cli.Flags {
 	cdxComponentName := flag.String("cdx-component-name", "", "The 'metadata.component.name' field for the output CDX document")
 	cdxComponentVersion := flag.String("cdx-component-version", "", "The 'metadata.component.version' field for the output CDX document")
 	cdxAuthors := flag.String("cdx-authors", "", "The 'authors' field for the output CDX document. Format is --cdx-authors=author1,author2")
+	sbomTimestamp := flag.String("sbom-timestamp", "", "The creation time of the SPDX and CDX outputs, as an RFC 3339 timestamp (e.g. 2024-01-01T00:00:00Z) or seconds since the Unix epoch. Defaults to $SOURCE_DATE_EPOCH if set, and to the current time otherwise. If set, the document IDs are derived from the scan results so that identical scans produce byte-identical SBOMs.")
+	logFormat := flag.String("log-format", "text", "The format of the logs, including the periodic status lines of the scan: text or json. With json, each log line is a JSON object with the time, level and message.")
+	logFile := flag.String("log-file", "", "If set, the logs are appended to this file instead of being written to stderr. The scan outputs written to stdout, e.g. with --count-only, are not affected.")
+	filesRelativeToRoot := flag.Bool("files-relative-to-root", false, "If set, the files to extract passed as arguments are relative to each --root, e.g. \"scalibr --root=/app --files-relative-to-root requirements.txt\" extracts /app/requirements.txt. Unlike absolute file paths, this can be used with several roots.")
+	strict := flag.Bool("strict", false, "If set, the scan status is failed and the exit code is 1 if an extractor failed on any of the files. The scan still completes and its results are written. Malformed files don't count as extractor failures.")
+	dedupFindings := flag.Bool("dedup-findings", false, "If set, the findings that several detectors reported for the same advisory and the same package (or the same locations) are merged into one finding that lists all of these detectors. By default each detector's findings are reported separately.")
+	maxBytesPerSecond := flag.Int64("max-bytes-per-second", 0, "If set, limits the number of bytes read per second while walking the filesystem, e.g. to run background scans on production hosts without saturating their disk IO. Opening a file counts as reading 4 KiB. 0 means unlimited.")
+	resultCacheDir := flag.String("result-cache-dir", "", "If set, the results of the filesystem extractors are cached in this directory, keyed by the SHA-256 of the extracted file and the extractor's name and version. Later scans reuse the cached results for files with the same content instead of extracting them again, e.g. for repeated scans of container image layers. Results of extractors that also read other files, e.g. a go.sum next to a go.mod, can be stale if only these files changed.")
+	resultCacheMaxBytes := flag.Int64("result-cache-max-bytes", 1<<30, "The maximum size of the --result-cache-dir directory in bytes. Once it's exceeded, the least recently used results are removed. 0 means unlimited.")
+	appendCDX := flag.Bool("cdx-append", false, "If set, the components of the cdx-json and cdx-xml outputs are merged into the CycloneDX documents that already exist at the output paths instead of overwriting them. Components are deduplicated by PURL and the documents keep their serial numbers. Only supported for local files.")
+	compactJSON := flag.Bool("compact-json", false, "If set, the JSON outputs (spdx23-json, cdx-json and the --verify-sbom diff) are written without indentation to save space. By default they're pretty-printed.")
 	verbose := flag.Bool("verbose", false, "Enable this to print debug logs")
+	quiet := flag.Bool("quiet", false, "Enable this to only print warnings, errors and the final scan summary")
+	traceFileRequired := flag.Bool("trace-file-required", false, "Enable this to log which extractors required each visited file. Useful for debugging why a file wasn't extracted, but produces a very large amount of logs.")
+	includeExperimental := flag.Bool("include-experimental", false, "If set, experimental extractors are also enabled by groups such as \"all\" in --extractors. Experimental extractors that are named explicitly are always enabled.")
 	explicitExtractors := flag.Bool("explicit-extractors", false, "If set, the program will exit with an error if not all extractors required by enabled detectors are explicitly enabled.")
-	filterByCapabilities := flag.Bool("filter-by-capabilities", true, "If set, plugins whose requirements (network access, OS, etc.) aren't satisfied by the scanning environment will be silently disabled instead of throwing a validation error.")
+	filterByCapabilities := flag.Bool("filter-by-capabilities", true, "If set, plugins whose requirements (network access, OS, etc.) aren't satisfied by the scanning environment are skipped and reported with a SKIPPED plugin status instead of throwing a validation error. If unset, a warning is logged for each of these plugins before the scan.")
+	maxMemoryClass := flag.String("max-memory-class", "", "The highest memory class of the plugins to run: low (memory usage independent of the input size), file-size (up to the size of the scanned file) or multiple-file-size (a multiple of the scanned file size, e.g. archive extractors). Plugins in higher classes are skipped, or cause an error if --filter-by-capabilities=false. Useful on hosts with little memory. Leave empty to run plugins regardless of their memory usage.")
+	standaloneConcurrency := flag.Int("standalone-concurrency", standalone.DefaultMaxConcurrency, "The maximum number of standalone extractors (e.g. the Windows registry extractors) that run at the same time. Set to 1 to run them one after the other.")
 	windowsAllDrives := flag.Bool("windows-all-drives", false, "Scan all drives on Windows")
+	locationPrefixTrim := flag.String("location-prefix-trim", "", "If set, this prefix is stripped from the reported inventory locations, e.g. to avoid leaking the directory structure of the scanned host into shared SBOMs.")
+	posixPaths := flag.Bool("posix-paths", false, "If set, the reported inventory locations use forward slashes as path separators regardless of the host OS, e.g. to compare the SBOMs of Windows and Unix hosts.")
+	packageClass := flag.String("package-class", "all", "The class of the packages to write to the scan outputs: os (OS packages, found by the extractors in extractor/filesystem/os and extractor/standalone/windows), language (application dependencies, found by the extractors in extractor/filesystem/language and the OSV lockfile extractors) or all. Inventory of other extractors, e.g. misc or sbom, is only written with all.")
+	osvEcosystemsOnly := flag.Bool("osv-ecosystems-only", false, "If set, only the inventory of ecosystems that OSV has advisories for (e.g. PyPI, npm, Go or Debian) is written to the scan outputs and compared by --verify-sbom. Inventory of other ecosystems and without an ecosystem, e.g. macOS apps or container images, is removed. The number of removed items per ecosystem is logged.")
+	osvEcosystems := flag.String("osv-ecosystems", "", "Comma-separated list of the ecosystems kept by --osv-ecosystems-only, e.g. PyPI,npm. Release suffixes such as the 12 of Debian:12 are ignored. Defaults to all ecosystems defined by the OSV schema.")
+	baseline := flag.String("baseline", "", "Path to the .textproto or .binproto result of a previous scan. If set, only inventory that's not present in the baseline (keyed by PURL and location) is written to the scan outputs.")
+	validateOutput := flag.Bool("validate-output", false, "If set, the SPDX and CDX outputs are checked against the format's JSON schema and not written if they're invalid.")
+	failOn := flag.String("fail-on", "", "Comma-separated list of conditions that make the scan exit with a non-zero code after writing its outputs: extractor-error (exit code 3), empty (no inventory found, exit code 4), finding or finding:<SEVERITY> (a finding of at least MINIMAL, LOW, MEDIUM, HIGH or CRITICAL severity, exit code 2). If several conditions are met, the lowest exit code is used.")
+	countOnly := flag.Bool("count-only", false, "If set, the number of inventory items found by each extractor is printed to stdout instead of writing any scan results. Useful for quickly checking a configuration. Can be combined with --fail-on.")
+	whichExtractors := flag.String("which-extractors", "", "Path to a file to check instead of running a scan. If set, the registered extractors that would extract the file are printed to stdout, including the ones not enabled by --extractors. Useful for debugging why a file isn't picked up. The path has to be inside the scan root.")
+	coverageReport := flag.Bool("coverage-report", false, "If set, the filesystem is walked without extracting any files and the number of files each enabled extractor would extract is printed to stdout, followed by the total number of files. Useful for evaluating which extractors are worth enabling for an environment.")
+	sinceGitRef := flag.String("since-git-ref", "", "A git ref such as a branch, tag or commit. If set, only the files that changed between the ref and the working copy of the git work tree at --root are extracted, e.g. --since-git-ref=origin/main for the files changed by a pull request. Deleted and untracked files are skipped. If no files changed, no scan is run and empty results are written. Needs exactly one --root.")
+	merge := flag.Bool("merge", false, "If set, the scan result files (.textproto or .binproto) passed as arguments are merged into one result instead of running a scan, e.g. --merge --result=fleet.binproto web-1.binproto web-2.binproto. Inventory with the same PURL is deduplicated and lists the hosts it was found on. Only the textproto and binproto output formats are supported.")
+	verifySBOM := flag.String("verify-sbom", "", "Path to a previously generated SPDX (*.spdx, *.spdx.json, *.spdx.yaml) or CDX (*.json, *.xml) SBOM. If set, the scan results are compared with the SBOM and the added, removed and changed packages are printed to stdout as JSON.")
 
 	flag.Parse()
 	filesToExtract := flag.Args()
 
 	flags := &cli.Flags{
-		Root:                  *root,
+		Root:                  root,
+		DiskImage:             *diskImage,
+		ImageTarball:          *imageTarball,
 		ResultFile:            *resultFile,
 		Output:                output,
 		ExtractorsToRun:       *extractorsToRun,
 		DetectorsToRun:        *detectorsToRun,
+		EnrichersToRun:        *enrichersToRun,
+		TransformersToRun:     *transformersToRun,
 		FilesToExtract:        filesToExtract,
 		DirsToSkip:            *dirsToSkip,
 		SkipDirRegex:          *skipDirRegex,
+		IncludeDirRegex:       *includeDirRegex,
 		GovulncheckDBPath:     *govulncheckDBPath,
+		ConfigFile:            *configFile,
 		SPDXDocumentName:      *spdxDocumentName,
 		SPDXDocumentNamespace: *spdxDocumentNamespace,
 		SPDXCreators:          *spdxCreators,
 		CDXComponentName:      *cdxComponentName,
 		CDXComponentVersion:   *cdxComponentVersion,
 		CDXAuthors:            *cdxAuthors,
+		SBOMTimestamp:         *sbomTimestamp,
 		Verbose:               *verbose,
+		Quiet:                 *quiet,
+		TraceFileRequired:     *traceFileRequired,
 		ExplicitExtractors:    *explicitExtractors,
+		IncludeExperimental:   *includeExperimental,
 		FilterByCapabilities:  *filterByCapabilities,
+		MaxMemoryClass:        *maxMemoryClass,
+		StandaloneConcurrency: *standaloneConcurrency,
 		WindowsAllDrives:      *windowsAllDrives,
+		VerifySBOM:            *verifySBOM,
+		LocationPrefixTrim:    *locationPrefixTrim,
+		PosixPaths:            *posixPaths,
+		Baseline:              *baseline,
+		PackageClass:          *packageClass,
+		OSVEcosystemsOnly:     *osvEcosystemsOnly,
+		OSVEcosystems:         *osvEcosystems,
+		ValidateOutput:        *validateOutput,
+		FailOn:                *failOn,
+		CountOnly:             *countOnly,
+		WhichExtractors:       *whichExtractors,
+		CoverageReport:        *coverageReport,
+		SinceGitRef:           *sinceGitRef,
+		Metadata:              metadata,
+		ExtractorPlugins:      extractorPlugins,
+		Merge:                 *merge,
+		LogFormat:             *logFormat,
+		LogFile:               *logFile,
+		FilesRelativeToRoot:   *filesRelativeToRoot,
+		Strict:                *strict,
+		DeduplicateFindings:   *dedupFindings,
+		MaxBytesPerSecond:     *maxBytesPerSecond,
+		ResultCacheDir:        *resultCacheDir,
+		ResultCacheMaxBytes:   *resultCacheMaxBytes,
+		CompactJSON:           *compactJSON,
+		AppendCDX:             *appendCDX,
 	}
 	if err := cli.ValidateFlags(flags); err != nil {
 		log.Errorf("Error parsing CLI args: %v", err)
@@ -17,8 +17,16 @@ package scanrunner
 
 import (
 	"context"
+	"io"
+	"os"
+	"slices"
+	"time"
 
 	"github.com/google/osv-scalibr/binary/cli"
+	"github.com/google/osv-scalibr/detector"
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem"
+	scalibrfs "github.com/google/osv-scalibr/fs"
 	"github.com/google/osv-scalibr/log"
 	"github.com/google/osv-scalibr/plugin"
 	scalibr "github.com/google/osv-scalibr"
@@ -27,8 +35,15 @@ import (
 // RunScan executes the scan with the given CLI flags
 // and returns the exit code passed to os.Exit() in the main binary.
 func RunScan(flags *cli.Flags) int {
-	if flags.Verbose {
-		log.SetLogger(&log.DefaultLogger{Verbose: true})
+	closeLogFile, err := setUpLogging(flags)
+	if err != nil {
+		log.Errorf("Error opening the log file: %v", err)
+		return 1
+	}
+	defer closeLogFile()
+
+	if flags.Merge {
+		return runMerge(flags)
 	}
 
 	cfg, err := flags.GetScanConfig()
@@ -36,6 +51,24 @@ func RunScan(flags *cli.Flags) int {
 		log.Errorf("%v.GetScanConfig(): %v", flags, err)
 		return 1
 	}
+	defer closeScanRoots(cfg.ScanRoots)
+	defer closeExtractors(cfg.FilesystemExtractors)
+
+	if len(flags.WhichExtractors) > 0 {
+		if err := cli.WriteExtractorsRequiring(cfg, flags.WhichExtractors, os.Stdout); err != nil {
+			log.Errorf("Error checking which extractors require %s: %v", flags.WhichExtractors, err)
+			return 1
+		}
+		return 0
+	}
+
+	if flags.CoverageReport {
+		if err := cli.WriteCoverageReport(context.Background(), cfg, os.Stdout); err != nil {
+			log.Errorf("Error writing the coverage report: %v", err)
+			return 1
+		}
+		return 0
+	}
 
 	log.Infof(
 		"Running scan with %d extractors and %d detectors",
@@ -45,20 +78,137 @@ func RunScan(flags *cli.Flags) int {
 	if len(cfg.FilesToExtract) > 0 {
 		log.Infof("Files to extract: %s", cfg.FilesToExtract)
 	}
-	result := scalibr.New().Scan(context.Background(), cfg)
+	var result *scalibr.ScanResult
+	if flags.NoChangedFiles(cfg) {
+		log.Infof("No files changed since %s, skipping the scan", flags.SinceGitRef)
+		result = emptyScanResult(cfg.Metadata)
+	} else {
+		result = scalibr.New().Scan(context.Background(), cfg)
+	}
 
-	log.Infof("Scan status: %v", result.Status)
-	log.Infof("Found %d software inventories, %d security findings", len(result.Inventories), len(result.Findings))
+	log.Summaryf("Scan status: %v", result.Status)
+	log.Summaryf("Found %d software inventories, %d security findings", len(result.Inventories), len(result.Findings))
+	log.Summaryf("PURL fingerprint: %s", result.PURLFingerprint)
+	if len(cfg.ScanRoots) > 1 {
+		logInventoryCountByScanRoot(result)
+	}
 
-	if err := flags.WriteScanResults(result); err != nil {
+	if flags.CountOnly {
+		if err := cli.WriteInventoryCounts(cfg, result, os.Stdout); err != nil {
+			log.Errorf("Error writing inventory counts: %v", err)
+			return 1
+		}
+	} else if err := flags.WriteScanResults(result); err != nil {
 		log.Errorf("Error writing scan results: %v", err)
 		return 1
 	}
 
+	if len(flags.VerifySBOM) > 0 {
+		if _, err := flags.WriteSBOMDiff(result, os.Stdout); err != nil {
+			log.Errorf("Error verifying SBOM: %v", err)
+			return 1
+		}
+	}
+
 	if result.Status.Status != plugin.ScanStatusSucceeded {
 		log.Errorf("Scan wasn't successful: %s", result.Status.FailureReason)
 		return 1
 	}
 
+	if code, reason := flags.FailOnExitCode(cfg, result); code != 0 {
+		log.Errorf("Failing the scan because of --fail-on=%s: %s", flags.FailOn, reason)
+		return code
+	}
+
+	return 0
+}
+
+// setUpLogging sets the logger configured by the flags. Returns a function
+// that closes the log file, if any.
+func setUpLogging(flags *cli.Flags) (func(), error) {
+	if len(flags.LogFile) == 0 {
+		log.SetLogger(flags.NewLogger(os.Stderr))
+		return func() {}, nil
+	}
+	f, err := os.OpenFile(flags.LogFile, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, err
+	}
+	log.SetLogger(flags.NewLogger(f))
+	return func() {
+		log.SetLogger(flags.NewLogger(os.Stderr))
+		if err := f.Close(); err != nil {
+			log.Warnf("Failed to close the log file: %v", err)
+		}
+	}, nil
+}
+
+// logInventoryCountByScanRoot logs the number of inventory items found in each
+// scan root, e.g. each drive of a Windows scan.
+func logInventoryCountByScanRoot(result *scalibr.ScanResult) {
+	counts := scalibr.InventoryCountByScanRoot(result.Inventories)
+	roots := make([]string, 0, len(counts))
+	for root := range counts {
+		roots = append(roots, root)
+	}
+	slices.Sort(roots)
+	for _, root := range roots {
+		if root == "" {
+			log.Summaryf("Found %d software inventories outside of the scan roots", counts[root])
+			continue
+		}
+		log.Summaryf("Found %d software inventories in %s", counts[root], root)
+	}
+}
+
+// emptyScanResult returns the result of a successful scan that found nothing.
+func emptyScanResult(metadata map[string]string) *scalibr.ScanResult {
+	now := time.Now()
+	return &scalibr.ScanResult{
+		StartTime:       now,
+		EndTime:         now,
+		Status:          &plugin.ScanStatus{Status: plugin.ScanStatusSucceeded},
+		Inventories:     []*extractor.Inventory{},
+		Findings:        []*detector.Finding{},
+		PURLFingerprint: scalibr.PURLFingerprint(nil),
+		Metadata:        metadata,
+	}
+}
+
+// runMerge merges the scan results passed with --merge instead of scanning.
+func runMerge(flags *cli.Flags) int {
+	result, err := flags.MergeScanResults()
+	if err != nil {
+		log.Errorf("Error merging scan results: %v", err)
+		return 1
+	}
+	if err := flags.WriteMergedResult(result); err != nil {
+		log.Errorf("Error writing merged scan results: %v", err)
+		return 1
+	}
 	return 0
 }
+
+// closeExtractors stops the extractors that hold resources for the duration
+// of the scan, e.g. the processes of external extractors.
+func closeExtractors(extractors []filesystem.Extractor) {
+	for _, e := range extractors {
+		if c, ok := e.(io.Closer); ok {
+			if err := c.Close(); err != nil {
+				log.Warnf("Failed to close extractor %s: %v", e.Name(), err)
+			}
+		}
+	}
+}
+
+// closeScanRoots closes the filesystems of the scan roots that hold open
+// files, e.g. disk images.
+func closeScanRoots(roots []*scalibrfs.ScanRoot) {
+	for _, r := range roots {
+		if c, ok := r.FS.(io.Closer); ok {
+			if err := c.Close(); err != nil {
+				log.Warnf("Failed to close scan root: %v", err)
+			}
+		}
+	}
+}
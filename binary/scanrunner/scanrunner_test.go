@@ -123,7 +123,7 @@ func TestRunScan(t *testing.T) {
 
 			dir := tc.setupFunc(t)
 			resultFile := filepath.Join(dir, "result.textproto")
-			tc.flags.Root = dir
+			tc.flags.Root = []string{dir}
 			tc.flags.ResultFile = resultFile
 
 			if gotExit := scanrunner.RunScan(tc.flags); gotExit != 0 {
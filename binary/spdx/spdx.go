@@ -12,21 +12,28 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-// Package spdx provides utilities for writing SPDX documents to the filesystem.
+// Package spdx provides utilities for reading and writing SPDX documents on the filesystem.
 package spdx
 
 import (
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
+	"strings"
 
 	"github.com/spdx/tools-golang/json"
 	"github.com/spdx/tools-golang/spdx/v2/v2_3"
 	"github.com/spdx/tools-golang/tagvalue"
 	"github.com/spdx/tools-golang/yaml"
+	"github.com/google/osv-scalibr/binary/output"
 )
 
-type writeFun func(doc *v2_3.Document, w io.Writer) error
+type readFun func(r io.Reader) (*v2_3.Document, error)
+
+// writeFun writes the document to w. If compact is true, formats that support
+// it are written without indentation.
+type writeFun func(doc *v2_3.Document, w io.Writer, compact bool) error
 
 // Writer functions associated with SPDX v2.3 extensions.
 var spdx23Writers = map[string]writeFun{
@@ -35,32 +42,70 @@ var spdx23Writers = map[string]writeFun{
 	"spdx23-yaml":      writeSPDX23YAML,
 }
 
-// Write23 writes an SPDX v2.3 document into a file in the tag value format.
+// Write23 writes an SPDX v2.3 document into a file in the chosen format.
+// JSON documents are indented for readability.
+// The path can also be a URL such as gs://bucket/result.spdx, see output.Create.
 func Write23(doc *v2_3.Document, path string, format string) error {
+	return write23(doc, path, format, false)
+}
+
+// Write23Compact is like Write23 but writes JSON documents without
+// indentation to save space.
+func Write23Compact(doc *v2_3.Document, path string, format string) error {
+	return write23(doc, path, format, true)
+}
+
+func write23(doc *v2_3.Document, path string, format string, compact bool) error {
 	writeFun, ok := spdx23Writers[format]
 	if !ok {
 		return fmt.Errorf("%s has an invalid SPDX format or not supported by SCALIBR", path)
 	}
 
-	f, err := os.Create(path)
+	f, err := output.Create(path)
 	if err != nil {
 		return err
 	}
-	defer f.Close()
-	if err = writeFun(doc, f); err != nil {
+	if err = writeFun(doc, f, compact); err != nil {
+		f.Close()
 		return err
 	}
-	return nil
+	return f.Close()
+}
+
+// Read23 reads an SPDX v2.3 document from a file. The format (JSON, YAML or
+// tag-value) is determined by the file extension.
+func Read23(path string) (*v2_3.Document, error) {
+	var readFun readFun
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		readFun = json.Read
+	case ".yaml", ".yml":
+		readFun = yaml.Read
+	case ".spdx":
+		readFun = tagvalue.Read
+	default:
+		return nil, fmt.Errorf("%s has an invalid SPDX format or not supported by SCALIBR", path)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return readFun(f)
 }
 
-func writeSPDX23TagValue(doc *v2_3.Document, w io.Writer) error {
+func writeSPDX23TagValue(doc *v2_3.Document, w io.Writer, _ bool) error {
 	return tagvalue.Write(doc, w)
 }
 
-func writeSPDX23YAML(doc *v2_3.Document, w io.Writer) error {
+func writeSPDX23YAML(doc *v2_3.Document, w io.Writer, _ bool) error {
 	return yaml.Write(doc, w)
 }
 
-func writeSPDX23JSON(doc *v2_3.Document, w io.Writer) error {
-	return json.Write(doc, w)
+func writeSPDX23JSON(doc *v2_3.Document, w io.Writer, compact bool) error {
+	if compact {
+		return json.Write(doc, w)
+	}
+	return json.Write(doc, w, json.Indent("  "))
 }
@@ -22,6 +22,7 @@ import (
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/spdx/tools-golang/spdx/v2/common"
 	"github.com/spdx/tools-golang/spdx/v2/v2_3"
 	"github.com/google/osv-scalibr/binary/spdx"
 )
@@ -90,6 +91,26 @@ func TestWrite23(t *testing.T) {
 	}
 }
 
+func TestWrite23Compact(t *testing.T) {
+	fullPath := filepath.Join(t.TempDir(), "output")
+	format := "spdx23-json"
+	if err := spdx.Write23Compact(doc, fullPath, format); err != nil {
+		t.Fatalf("spdx.Write23Compact(%v, %s, %s) returned an error: %v", doc, fullPath, format, err)
+	}
+
+	got, err := os.ReadFile(fullPath)
+	if err != nil {
+		t.Fatalf("error while reading %s: %v", fullPath, err)
+	}
+	want, err := os.ReadFile("testdata/json-compact-format.spdx.json")
+	if err != nil {
+		t.Fatalf("error while reading testdata/json-compact-format.spdx.json: %v", err)
+	}
+	if diff := cmp.Diff(strings.TrimSpace(string(want)), strings.TrimSpace(string(got))); diff != "" {
+		t.Errorf("spdx.Write23Compact(%v, %s, %s) produced unexpected results, diff (-want +got):\n%s", doc, fullPath, format, diff)
+	}
+}
+
 func TestWrite_InvalidFormat(t *testing.T) {
 	testDirPath := t.TempDir()
 	fullPath := filepath.Join(testDirPath, "output")
@@ -99,3 +120,107 @@ func TestWrite_InvalidFormat(t *testing.T) {
 		t.Errorf("spdx.Write23(%s, %s) didn't return an invalid extension error: %v", fullPath, format, err)
 	}
 }
+
+func TestValidate23(t *testing.T) {
+	validDoc := func() *v2_3.Document {
+		return &v2_3.Document{
+			SPDXVersion:       "SPDX-2.3",
+			DataLicense:       "CC0-1.0",
+			SPDXIdentifier:    "DOCUMENT",
+			DocumentName:      "Document name",
+			DocumentNamespace: "https://spdx.google/document",
+			CreationInfo: &v2_3.CreationInfo{
+				Creators: []common.Creator{{CreatorType: "Tool", Creator: "SCALIBR"}},
+				Created:  "2006-01-02T15:04:05Z",
+			},
+			Packages: []*v2_3.Package{{
+				PackageName:             "software",
+				PackageSPDXIdentifier:   "Package-software",
+				PackageDownloadLocation: "NOASSERTION",
+			}},
+			Relationships: []*v2_3.Relationship{{
+				RefA:         common.MakeDocElementID("", "DOCUMENT"),
+				RefB:         common.MakeDocElementID("", "Package-software"),
+				Relationship: "DESCRIBES",
+			}},
+		}
+	}
+	testCases := []struct {
+		desc        string
+		modify      func(doc *v2_3.Document)
+		wantErrText string
+	}{
+		{
+			desc:   "valid document",
+			modify: func(doc *v2_3.Document) {},
+		},
+		{
+			desc:        "missing creators",
+			modify:      func(doc *v2_3.Document) { doc.CreationInfo.Creators = nil },
+			wantErrText: "creators",
+		},
+		{
+			desc:        "invalid relationship type",
+			modify:      func(doc *v2_3.Document) { doc.Relationships[0].Relationship = "DEPENDS_ON_SOMETHING" },
+			wantErrText: "relationshipType",
+		},
+		{
+			desc:        "wrong SPDX version",
+			modify:      func(doc *v2_3.Document) { doc.SPDXVersion = "SPDX-2.2" },
+			wantErrText: "spdxVersion",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			doc := validDoc()
+			tc.modify(doc)
+			err := spdx.Validate23(doc)
+			if tc.wantErrText == "" {
+				if err != nil {
+					t.Errorf("spdx.Validate23(%v): %v", doc, err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.wantErrText) {
+				t.Errorf("spdx.Validate23(%v) returned %v, want error mentioning %q", doc, err, tc.wantErrText)
+			}
+		})
+	}
+}
+
+func TestRead23(t *testing.T) {
+	testCases := []struct {
+		desc string
+		path string
+	}{
+		{
+			desc: "tag-value",
+			path: "testdata/tag-value-format.spdx",
+		},
+		{
+			desc: "json",
+			path: "testdata/json-format.spdx.json",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := spdx.Read23(tc.path)
+			if err != nil {
+				t.Fatalf("spdx.Read23(%s) returned an error: %v", tc.path, err)
+			}
+			if diff := cmp.Diff(doc.DocumentName, got.DocumentName); diff != "" {
+				t.Errorf("spdx.Read23(%s) produced unexpected document name, diff (-want +got):\n%s", tc.path, diff)
+			}
+		})
+	}
+}
+
+func TestRead23_InvalidFormat(t *testing.T) {
+	path := "testdata/yaml-format"
+	if _, err := spdx.Read23(path); err == nil ||
+		!strings.Contains(err.Error(), "invalid SPDX format") {
+		t.Errorf("spdx.Read23(%s) didn't return an invalid extension error: %v", path, err)
+	}
+}
@@ -0,0 +1,73 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spdx
+
+import (
+	"bytes"
+	_ "embed" // Needed for go:embed
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/spdx/tools-golang/json"
+	"github.com/spdx/tools-golang/spdx/v2/v2_3"
+	"github.com/xeipuuv/gojsonschema"
+)
+
+// maxReportedSchemaErrors limits how many schema violations are listed in the
+// validation error.
+const maxReportedSchemaErrors = 10
+
+//go:embed schema/spdx-2.3.schema.json
+var spdx23Schema []byte
+
+var compileSPDX23Schema = sync.OnceValues(func() (*gojsonschema.Schema, error) {
+	return gojsonschema.NewSchema(gojsonschema.NewBytesLoader(spdx23Schema))
+})
+
+// Validate23 checks the SPDX v2.3 document against the SPDX 2.3 JSON schema
+// and returns an error describing the violations if it doesn't match. The
+// document is checked in its JSON encoding, independent of the format it's
+// written in.
+func Validate23(doc *v2_3.Document) error {
+	schema, err := compileSPDX23Schema()
+	if err != nil {
+		return fmt.Errorf("failed to compile the SPDX 2.3 JSON schema: %w", err)
+	}
+	var buf bytes.Buffer
+	if err := json.Write(doc, &buf); err != nil {
+		return fmt.Errorf("failed to encode the SPDX document as JSON: %w", err)
+	}
+	result, err := schema.Validate(gojsonschema.NewBytesLoader(buf.Bytes()))
+	if err != nil {
+		return fmt.Errorf("failed to validate the SPDX document: %w", err)
+	}
+	if result.Valid() {
+		return nil
+	}
+	return fmt.Errorf("SPDX document doesn't match the SPDX 2.3 JSON schema: %s", formatSchemaErrors(result.Errors()))
+}
+
+func formatSchemaErrors(errs []gojsonschema.ResultError) string {
+	msgs := make([]string, 0, min(len(errs), maxReportedSchemaErrors))
+	for _, e := range errs {
+		if len(msgs) == maxReportedSchemaErrors {
+			msgs = append(msgs, fmt.Sprintf("and %d more", len(errs)-maxReportedSchemaErrors))
+			break
+		}
+		msgs = append(msgs, e.String())
+	}
+	return strings.Join(msgs, "; ")
+}
@@ -0,0 +1,209 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package converter
+
+import (
+	"slices"
+
+	"github.com/CycloneDX/cyclonedx-go"
+	"github.com/google/uuid"
+	"github.com/google/osv-scalibr/purl"
+)
+
+// MergeCDX merges the components of the CycloneDX document update into base,
+// e.g. to add the packages found by a later stage of a pipeline to an existing
+// SBOM. Components are identified by their normalized PURL, or by their name
+// and version if they have no PURL. Components already present in base get the
+// evidence occurrences of update added, the others are appended.
+//
+// The merged document keeps the serial number and metadata of base. If it
+// differs from base, its version is incremented and the timestamp and tools of
+// update are added to the metadata. base and update aren't modified.
+func MergeCDX(base, update *cyclonedx.BOM) *cyclonedx.BOM {
+	merged := *base
+	var comps []cyclonedx.Component
+	if base.Components != nil {
+		comps = slices.Clone(*base.Components)
+	}
+	index := make(map[string]int)
+	usedRefs := make(map[string]bool)
+	for i, c := range comps {
+		if _, ok := index[cdxComponentKey(c)]; !ok {
+			index[cdxComponentKey(c)] = i
+		}
+		usedRefs[c.BOMRef] = true
+	}
+
+	// The BOM refs of update's components in the merged document.
+	refs := make(map[string]string)
+	changed := false
+	if update.Components != nil {
+		for _, c := range *update.Components {
+			if i, ok := index[cdxComponentKey(c)]; ok {
+				if c.BOMRef != "" {
+					refs[c.BOMRef] = comps[i].BOMRef
+				}
+				if mergeOccurrences(&comps[i], c) {
+					changed = true
+				}
+				continue
+			}
+			if c.BOMRef != "" {
+				ref := c.BOMRef
+				if usedRefs[ref] {
+					ref = uuid.New().String()
+				}
+				refs[c.BOMRef] = ref
+				usedRefs[ref] = true
+				c.BOMRef = ref
+			}
+			index[cdxComponentKey(c)] = len(comps)
+			comps = append(comps, c)
+			changed = true
+		}
+	}
+	merged.Components = &comps
+
+	deps, depsChanged := mergeDependencies(base.Dependencies, update.Dependencies, refs)
+	merged.Dependencies = deps
+	if !changed && !depsChanged {
+		return &merged
+	}
+	merged.Version = base.Version + 1
+	merged.Metadata = mergeCDXMetadata(base.Metadata, update.Metadata)
+	return &merged
+}
+
+// cdxComponentKey returns the key that identifies the package of a component.
+func cdxComponentKey(c cyclonedx.Component) string {
+	if c.PackageURL == "" {
+		return "name:" + c.Name + "@" + c.Version
+	}
+	if p, err := purl.FromString(c.PackageURL); err == nil {
+		return "purl:" + purl.Normalize(&p).String()
+	}
+	return "purl:" + c.PackageURL
+}
+
+// mergeOccurrences adds the evidence occurrences of src with new locations to
+// dst. Returns true if occurrences were added.
+func mergeOccurrences(dst *cyclonedx.Component, src cyclonedx.Component) bool {
+	if src.Evidence == nil || src.Evidence.Occurrences == nil {
+		return false
+	}
+	var occ []cyclonedx.EvidenceOccurrence
+	if dst.Evidence != nil && dst.Evidence.Occurrences != nil {
+		occ = slices.Clone(*dst.Evidence.Occurrences)
+	}
+	n := len(occ)
+	for _, o := range *src.Evidence.Occurrences {
+		if !slices.ContainsFunc(occ, func(e cyclonedx.EvidenceOccurrence) bool { return e.Location == o.Location }) {
+			occ = append(occ, o)
+		}
+	}
+	if len(occ) == n {
+		return false
+	}
+	// Copy the evidence since it's shared with the original component.
+	var evidence cyclonedx.Evidence
+	if dst.Evidence != nil {
+		evidence = *dst.Evidence
+	}
+	evidence.Occurrences = &occ
+	dst.Evidence = &evidence
+	return true
+}
+
+// mergeDependencies adds the dependencies of update to base, with the BOM
+// refs of update translated through refs. Returns true if dependencies were
+// added.
+func mergeDependencies(base, update *[]cyclonedx.Dependency, refs map[string]string) (*[]cyclonedx.Dependency, bool) {
+	if update == nil || len(*update) == 0 {
+		return base, false
+	}
+	var deps []cyclonedx.Dependency
+	if base != nil {
+		deps = slices.Clone(*base)
+	}
+	index := make(map[string]int)
+	for i, d := range deps {
+		index[d.Ref] = i
+	}
+	changed := false
+	for _, d := range *update {
+		ref := refs[d.Ref]
+		if ref == "" || d.Dependencies == nil {
+			continue
+		}
+		i, ok := index[ref]
+		if !ok {
+			i = len(deps)
+			index[ref] = i
+			deps = append(deps, cyclonedx.Dependency{Ref: ref})
+		}
+		var targets []string
+		if deps[i].Dependencies != nil {
+			targets = slices.Clone(*deps[i].Dependencies)
+		}
+		n := len(targets)
+		for _, t := range *d.Dependencies {
+			if t = refs[t]; t != "" && !slices.Contains(targets, t) {
+				targets = append(targets, t)
+			}
+		}
+		if len(targets) > n {
+			deps[i].Dependencies = &targets
+			changed = true
+		}
+	}
+	if !changed {
+		return base, false
+	}
+	return &deps, true
+}
+
+// mergeCDXMetadata returns the metadata of base with the timestamp of update
+// and the tools of update that base doesn't list yet.
+func mergeCDXMetadata(base, update *cyclonedx.Metadata) *cyclonedx.Metadata {
+	if base == nil {
+		return update
+	}
+	if update == nil {
+		return base
+	}
+	merged := *base
+	if update.Timestamp != "" {
+		merged.Timestamp = update.Timestamp
+	}
+	if update.Tools == nil || update.Tools.Components == nil {
+		return &merged
+	}
+	var tools cyclonedx.ToolsChoice
+	var comps []cyclonedx.Component
+	if base.Tools != nil {
+		tools = *base.Tools
+		if base.Tools.Components != nil {
+			comps = slices.Clone(*base.Tools.Components)
+		}
+	}
+	for _, t := range *update.Tools.Components {
+		if !slices.ContainsFunc(comps, func(c cyclonedx.Component) bool { return c.Name == t.Name && c.Version == t.Version }) {
+			comps = append(comps, t)
+		}
+	}
+	tools.Components = &comps
+	merged.Tools = &tools
+	return &merged
+}
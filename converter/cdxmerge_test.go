@@ -0,0 +1,137 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package converter_test
+
+import (
+	"testing"
+
+	"github.com/CycloneDX/cyclonedx-go"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/osv-scalibr/converter"
+)
+
+func occurrences(locations ...string) *cyclonedx.Evidence {
+	occ := make([]cyclonedx.EvidenceOccurrence, 0, len(locations))
+	for _, l := range locations {
+		occ = append(occ, cyclonedx.EvidenceOccurrence{Location: l})
+	}
+	return &cyclonedx.Evidence{Occurrences: &occ}
+}
+
+func scalibrTool(version string) *cyclonedx.ToolsChoice {
+	return &cyclonedx.ToolsChoice{
+		Components: &[]cyclonedx.Component{{Type: cyclonedx.ComponentTypeApplication, Name: "SCALIBR", Version: version}},
+	}
+}
+
+func TestMergeCDX(t *testing.T) {
+	base := &cyclonedx.BOM{
+		SerialNumber: "urn:uuid:3e671687-395b-41f5-a30f-a58921a69b79",
+		Version:      1,
+		Metadata: &cyclonedx.Metadata{
+			Timestamp: "2024-01-01T00:00:00Z",
+			Component: &cyclonedx.Component{Name: "pipeline", BOMRef: "root"},
+			Tools:     scalibrTool("1.0"),
+		},
+		Components: &[]cyclonedx.Component{
+			{BOMRef: "a", Name: "Flask", Version: "2.0", PackageURL: "pkg:pypi/Flask@2.0", Evidence: occurrences("app/requirements.txt")},
+			{BOMRef: "b", Name: "no-purl", Version: "1.0"},
+		},
+		Dependencies: &[]cyclonedx.Dependency{{Ref: "a", Dependencies: &[]string{"b"}}},
+	}
+	update := &cyclonedx.BOM{
+		SerialNumber: "urn:uuid:00000000-0000-0000-0000-000000000000",
+		Version:      1,
+		Metadata: &cyclonedx.Metadata{
+			Timestamp: "2024-02-01T00:00:00Z",
+			Component: &cyclonedx.Component{Name: "stage 2", BOMRef: "root2"},
+			Tools:     scalibrTool("1.1"),
+		},
+		Components: &[]cyclonedx.Component{
+			// Same package with a different PURL spelling and a new location.
+			{BOMRef: "x", Name: "flask", Version: "2.0", PackageURL: "pkg:pypi/flask@2.0", Evidence: occurrences("app/requirements.txt", "lib/site-packages")},
+			{BOMRef: "y", Name: "no-purl", Version: "1.0"},
+			// New package whose BOM ref is already used in base.
+			{BOMRef: "a", Name: "requests", Version: "2.31.0", PackageURL: "pkg:pypi/requests@2.31.0"},
+		},
+		Dependencies: &[]cyclonedx.Dependency{{Ref: "x", Dependencies: &[]string{"a"}}},
+	}
+
+	got := converter.MergeCDX(base, update)
+
+	if got.SerialNumber != base.SerialNumber {
+		t.Errorf("MergeCDX() serial number: got %q, want %q", got.SerialNumber, base.SerialNumber)
+	}
+	if got.Version != 2 {
+		t.Errorf("MergeCDX() version: got %d, want 2", got.Version)
+	}
+	if got.Metadata.Timestamp != "2024-02-01T00:00:00Z" {
+		t.Errorf("MergeCDX() timestamp: got %q, want the one of the update", got.Metadata.Timestamp)
+	}
+	if got.Metadata.Component.Name != "pipeline" {
+		t.Errorf("MergeCDX() metadata component: got %q, want the one of the base document", got.Metadata.Component.Name)
+	}
+	if diff := cmp.Diff(&[]cyclonedx.Component{(*scalibrTool("1.0").Components)[0], (*scalibrTool("1.1").Components)[0]}, got.Metadata.Tools.Components); diff != "" {
+		t.Errorf("MergeCDX() unexpected tools (-want +got):\n%s", diff)
+	}
+
+	comps := *got.Components
+	if len(comps) != 3 {
+		t.Fatalf("MergeCDX() returned %d components, want 3: %v", len(comps), comps)
+	}
+	newRef := comps[2].BOMRef
+	if newRef == "" || newRef == "a" || newRef == "b" {
+		t.Errorf("MergeCDX() new component has BOM ref %q, want a new unique ref", newRef)
+	}
+	wantComps := []cyclonedx.Component{
+		{BOMRef: "a", Name: "Flask", Version: "2.0", PackageURL: "pkg:pypi/Flask@2.0", Evidence: occurrences("app/requirements.txt", "lib/site-packages")},
+		{BOMRef: "b", Name: "no-purl", Version: "1.0"},
+		{BOMRef: newRef, Name: "requests", Version: "2.31.0", PackageURL: "pkg:pypi/requests@2.31.0"},
+	}
+	if diff := cmp.Diff(wantComps, comps); diff != "" {
+		t.Errorf("MergeCDX() unexpected components (-want +got):\n%s", diff)
+	}
+	wantDeps := &[]cyclonedx.Dependency{{Ref: "a", Dependencies: &[]string{"b", newRef}}}
+	if diff := cmp.Diff(wantDeps, got.Dependencies); diff != "" {
+		t.Errorf("MergeCDX() unexpected dependencies (-want +got):\n%s", diff)
+	}
+
+	// The input documents aren't modified.
+	if diff := cmp.Diff(occurrences("app/requirements.txt"), (*base.Components)[0].Evidence); diff != "" {
+		t.Errorf("MergeCDX() modified the base document (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(&[]string{"b"}, (*base.Dependencies)[0].Dependencies); diff != "" {
+		t.Errorf("MergeCDX() modified the base dependencies (-want +got):\n%s", diff)
+	}
+}
+
+func TestMergeCDX_NothingNew(t *testing.T) {
+	base := &cyclonedx.BOM{
+		Version:    3,
+		Metadata:   &cyclonedx.Metadata{Timestamp: "2024-01-01T00:00:00Z"},
+		Components: &[]cyclonedx.Component{{BOMRef: "a", Name: "Flask", Version: "2.0", PackageURL: "pkg:pypi/flask@2.0"}},
+	}
+	update := &cyclonedx.BOM{
+		Version:    1,
+		Metadata:   &cyclonedx.Metadata{Timestamp: "2024-02-01T00:00:00Z"},
+		Components: &[]cyclonedx.Component{{BOMRef: "x", Name: "Flask", Version: "2.0", PackageURL: "pkg:pypi/flask@2.0"}},
+	}
+
+	got := converter.MergeCDX(base, update)
+
+	if diff := cmp.Diff(base, got); diff != "" {
+		t.Errorf("MergeCDX() changed a document without new components (-want +got):\n%s", diff)
+	}
+}
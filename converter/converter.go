@@ -17,8 +17,13 @@
 package converter
 
 import (
+	"crypto/sha256"
+	"errors"
 	"fmt"
 	"regexp"
+	"slices"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/CycloneDX/cyclonedx-go"
@@ -41,9 +46,67 @@ const (
 // spdx_id must only contain letters, numbers, "." and "-"
 var spdxIDInvalidCharRe = regexp.MustCompile(`[^a-zA-Z0-9.-]`)
 
-// ToPURL converts a SCALIBR inventory structure into a package URL.
+var (
+	// ErrNoPURL is returned if an extractor returns no package URL for an inventory item.
+	ErrNoPURL = errors.New("extractor returned no PURL")
+	// ErrEmptyPURLNameOrVersion is returned if the package URL of an inventory
+	// item has no name or version.
+	ErrEmptyPURLNameOrVersion = errors.New("PURL name or version empty")
+)
+
+// ConversionError describes an inventory item that couldn't be fully
+// represented in a converted output format.
+type ConversionError struct {
+	Inventory *extractor.Inventory
+	Err       error
+}
+
+func (e *ConversionError) Error() string {
+	extractorName := "<none>"
+	if e.Inventory.Extractor != nil {
+		extractorName = e.Inventory.Extractor.Name()
+	}
+	location := "<none>"
+	if l := firstLocation(e.Inventory); l != "" {
+		location = l
+	}
+	return fmt.Sprintf("inventory %q (extractor %s, location %s): %v", e.Inventory.Name, extractorName, location, e.Err)
+}
+
+func (e *ConversionError) Unwrap() error { return e.Err }
+
+// ToPURL converts a SCALIBR inventory structure into a normalized package URL.
 func ToPURL(i *extractor.Inventory) (*purl.PackageURL, error) {
-	return i.Extractor.ToPURL(i)
+	p, err := i.Extractor.ToPURL(i)
+	if err != nil {
+		return nil, err
+	}
+	return purl.Normalize(p), nil
+}
+
+// toPURLOrError converts the inventory into a package URL and returns a
+// ConversionError if the conversion failed or resulted in no package URL.
+func toPURLOrError(i *extractor.Inventory) (*purl.PackageURL, *ConversionError) {
+	p, err := ToPURL(i)
+	if err != nil {
+		return nil, &ConversionError{Inventory: i, Err: err}
+	}
+	if p == nil {
+		return nil, &ConversionError{Inventory: i, Err: ErrNoPURL}
+	}
+	return p, nil
+}
+
+// ToPURLList returns the sorted and deduplicated string representations of
+// the package URLs of the inventory found in a scan. Inventory that can't be
+// converted into a PURL is logged and skipped.
+func ToPURLList(r *scalibr.ScanResult) []string {
+	result := []string{}
+	for _, p := range ScanResultPURLs(r) {
+		result = append(result, p.String())
+	}
+	slices.Sort(result)
+	return slices.Compact(result)
 }
 
 // ToCPEs converts a SCALIBR inventory structure into CPEs, if they're present in the inventory.
@@ -56,14 +119,32 @@ type SPDXConfig struct {
 	DocumentName      string
 	DocumentNamespace string
 	Creators          []common.Creator
+	// Optional: The creation time of the document. Defaults to the current
+	// time. If set, the IDs in the document are derived from the scan results
+	// instead of being random, so that identical scan results produce
+	// byte-identical documents.
+	Timestamp time.Time
 }
 
 // ToSPDX23 converts the SCALIBR scan results into an SPDX v2.3 document.
+// Inventory items that can't be converted are logged and skipped, use
+// ToSPDX23WithErrors to get the list of skipped items.
 func ToSPDX23(r *scalibr.ScanResult, c SPDXConfig) *v2_3.Document {
+	doc, _ := ToSPDX23WithErrors(r, c)
+	return doc
+}
+
+// ToSPDX23WithErrors converts the SCALIBR scan results into an SPDX v2.3
+// document. It also returns the inventory items that were skipped because they
+// couldn't be converted into a package URL with a name and version.
+func ToSPDX23WithErrors(r *scalibr.ScanResult, c SPDXConfig) (*v2_3.Document, []*ConversionError) {
+	var convErrs []*ConversionError
 	packages := make([]*v2_3.Package, 0, len(r.Inventories)+1)
+	ids := newUUIDGenerator(r, c.Timestamp)
+	created := creationTime(c.Timestamp)
 
 	// Add a main package that contains all other top-level packages.
-	mainPackageID := SPDXRefPrefix + "Package-main-" + uuid.New().String()
+	mainPackageID := SPDXRefPrefix + "Package-main-" + ids.next()
 	packages = append(packages, &v2_3.Package{
 		PackageName:               "main",
 		PackageSPDXIdentifier:     common.ElementID(mainPackageID),
@@ -73,32 +154,31 @@ func ToSPDX23(r *scalibr.ScanResult, c SPDXConfig) *v2_3.Document {
 	})
 
 	relationships := make([]*v2_3.Relationship, 0, 2*len(r.Inventories))
+	refs := newPackageRefs()
 
 	for _, i := range r.Inventories {
-		p, err := ToPURL(i)
-		if err != nil {
-			log.Warnf("ToPURL(%v): %v, skipping", i, err)
-			continue
+		p, convErr := toPURLOrError(i)
+		if convErr == nil && (p.Name == "" || p.Version == "") {
+			convErr = &ConversionError{Inventory: i, Err: ErrEmptyPURLNameOrVersion}
 		}
-		if p == nil {
-			log.Warnf("Inventory %v has no PURL, skipping", i)
+		if convErr != nil {
+			log.Warnf("Skipping SPDX package for %v", convErr)
+			convErrs = append(convErrs, convErr)
 			continue
 		}
 		pName := p.Name
 		pVersion := p.Version
-		if pName == "" || pVersion == "" {
-			log.Warnf("Inventory %v PURL name or version empty, skipping", i)
-			continue
-		}
-		pID := SPDXRefPrefix + "Package-" + replaceSPDXIDInvalidChars(pName) + "-" + uuid.New().String()
+		pID := SPDXRefPrefix + "Package-" + replaceSPDXIDInvalidChars(pName) + "-" + ids.next()
+		refs.add(i, p, pID)
 		pSourceInfo := fmt.Sprintf("Identified by the %s extractor", i.Extractor.Name())
-		if len(i.Locations) == 1 {
-			pSourceInfo += fmt.Sprintf(" from %s", i.Locations[0])
-		} else if l := len(i.Locations); l > 1 {
-			pSourceInfo += fmt.Sprintf(" from %d locations, including %s and %s", l, i.Locations[0], i.Locations[1])
+		locs := i.AllLocations()
+		if len(locs) == 1 {
+			pSourceInfo += fmt.Sprintf(" from %s", locs[0])
+		} else if l := len(locs); l > 1 {
+			pSourceInfo += fmt.Sprintf(" from %d locations, including %s and %s", l, locs[0], locs[1])
 		}
 
-		packages = append(packages, &v2_3.Package{
+		pkg := &v2_3.Package{
 			PackageName:           pName,
 			PackageSPDXIdentifier: common.ElementID(pID),
 			PackageVersion:        pVersion,
@@ -116,7 +196,17 @@ func ToSPDX23(r *scalibr.ScanResult, c SPDXConfig) *v2_3.Document {
 					Locator:  p.String(),
 				},
 			},
-		})
+		}
+		if comment := confidenceComment(i); comment != "" {
+			pkg.Annotations = []v2_3.Annotation{{
+				Annotator:                common.Annotator{AnnotatorType: "Tool", Annotator: "SCALIBR"},
+				AnnotationDate:           created,
+				AnnotationType:           "OTHER",
+				AnnotationSPDXIdentifier: toDocElementID(pID),
+				AnnotationComment:        comment,
+			}}
+		}
+		packages = append(packages, pkg)
 		// TODO(b/313658493): Add a DESCRIBES relationship or a DocumentDescribes field.
 		relationships = append(relationships, &v2_3.Relationship{
 			RefA:         toDocElementID(mainPackageID),
@@ -129,13 +219,26 @@ func ToSPDX23(r *scalibr.ScanResult, c SPDXConfig) *v2_3.Document {
 			Relationship: "CONTAINS",
 		})
 	}
+	for _, i := range r.Inventories {
+		pID, ok := refs.byInventory[i]
+		if !ok {
+			continue
+		}
+		for _, target := range refs.dependsOn(i) {
+			relationships = append(relationships, &v2_3.Relationship{
+				RefA:         toDocElementID(pID),
+				RefB:         toDocElementID(target),
+				Relationship: "DEPENDS_ON",
+			})
+		}
+	}
 	name := c.DocumentName
 	if name == "" {
 		name = "SCALIBR-generated SPDX"
 	}
 	namespace := c.DocumentNamespace
 	if namespace == "" {
-		namespace = "https://spdx.google/" + uuid.New().String()
+		namespace = "https://spdx.google/" + ids.next()
 	}
 	creators := []common.Creator{
 		common.Creator{
@@ -151,12 +254,229 @@ func ToSPDX23(r *scalibr.ScanResult, c SPDXConfig) *v2_3.Document {
 		DocumentName:      name,
 		DocumentNamespace: namespace,
 		CreationInfo: &v2_3.CreationInfo{
-			Creators: creators,
-			Created:  time.Now().UTC().Format("2006-01-02T15:04:05Z"),
+			Creators:       creators,
+			Created:        created,
+			CreatorComment: creatorComment(r),
 		},
 		Packages:      packages,
 		Relationships: relationships,
+	}, convErrs
+}
+
+// confidenceComment describes the confidence of an inventory item in an SPDX
+// annotation, e.g. "Confidence: low. Evidence: version from the installation
+// path". Returns an empty string if the extractor didn't set one.
+func confidenceComment(i *extractor.Inventory) string {
+	var parts []string
+	if i.Confidence != extractor.ConfidenceUnspecified {
+		parts = append(parts, "Confidence: "+i.Confidence.String())
+	}
+	if i.Evidence != "" {
+		parts = append(parts, "Evidence: "+i.Evidence)
 	}
+	return strings.Join(parts, ". ")
+}
+
+// inventoryProperties returns the confidence and the scan root of an inventory
+// item as CDX component properties.
+func inventoryProperties(i *extractor.Inventory) []cyclonedx.Property {
+	var props []cyclonedx.Property
+	if i.Confidence != extractor.ConfidenceUnspecified {
+		props = append(props, cyclonedx.Property{Name: "scalibr:confidence", Value: i.Confidence.String()})
+	}
+	if i.Evidence != "" {
+		props = append(props, cyclonedx.Property{Name: "scalibr:evidence", Value: i.Evidence})
+	}
+	if i.ScanRoot != "" {
+		props = append(props, cyclonedx.Property{Name: "scalibr:scan-root", Value: i.ScanRoot})
+	}
+	return props
+}
+
+// creationTime formats the creation time of a document, which defaults to
+// the current time.
+func creationTime(t time.Time) string {
+	if t.IsZero() {
+		t = time.Now()
+	}
+	return t.UTC().Format("2006-01-02T15:04:05Z")
+}
+
+// uuidGenerator generates the UUIDs used as IDs in a converted document.
+type uuidGenerator struct {
+	// Namespace of the name-based UUIDs. If nil, random UUIDs are generated.
+	ns *uuid.UUID
+	n  int
+}
+
+// newUUIDGenerator returns a generator of random UUIDs, or of UUIDs derived
+// from the scan results and the creation time if the creation time is fixed.
+func newUUIDGenerator(r *scalibr.ScanResult, created time.Time) *uuidGenerator {
+	if created.IsZero() {
+		return &uuidGenerator{}
+	}
+	h := sha256.New()
+	fmt.Fprintln(h, created.UTC().Format(time.RFC3339Nano))
+	for _, i := range r.Inventories {
+		var ex string
+		if i.Extractor != nil {
+			ex = i.Extractor.Name()
+		}
+		fmt.Fprintf(h, "%q %q %q %q\n", ex, i.Name, i.Version, i.Locations)
+	}
+	ns := uuid.NewHash(sha256.New(), uuid.NameSpaceOID, h.Sum(nil), 5)
+	return &uuidGenerator{ns: &ns}
+}
+
+func (g *uuidGenerator) next() string {
+	if g.ns == nil {
+		return uuid.New().String()
+	}
+	g.n++
+	return uuid.NewSHA1(*g.ns, []byte(strconv.Itoa(g.n))).String()
+}
+
+// creatorComment describes the scanned system and the scan metadata, one per
+// line.
+func creatorComment(r *scalibr.ScanResult) string {
+	var lines []string
+	for _, l := range []string{osReleaseComment(r.OSRelease), metadataComment(r.Metadata)} {
+		if l != "" {
+			lines = append(lines, l)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// metadataComment lists the scan metadata sorted by key, e.g.
+// "Scan metadata: region=europe-west1, role=web".
+func metadataComment(metadata map[string]string) string {
+	if len(metadata) == 0 {
+		return ""
+	}
+	parts := make([]string, 0, len(metadata))
+	for _, k := range sortedKeys(metadata) {
+		parts = append(parts, k+"="+metadata[k])
+	}
+	return "Scan metadata: " + strings.Join(parts, ", ")
+}
+
+// metadataProperties returns the scan metadata sorted by key as CDX metadata
+// properties.
+func metadataProperties(metadata map[string]string) []cyclonedx.Property {
+	props := make([]cyclonedx.Property, 0, len(metadata))
+	for _, k := range sortedKeys(metadata) {
+		props = append(props, cyclonedx.Property{Name: "scalibr:metadata:" + k, Value: metadata[k]})
+	}
+	return props
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+// osReleaseComment describes the distribution of the scanned system, e.g.
+// "Scanned system: Debian GNU/Linux 12 (bookworm), ID=debian, VERSION_ID=12".
+func osReleaseComment(o *scalibr.OSRelease) string {
+	if o == nil {
+		return ""
+	}
+	var parts []string
+	if o.PrettyName != "" {
+		parts = append(parts, o.PrettyName)
+	}
+	if o.ID != "" {
+		parts = append(parts, "ID="+o.ID)
+	}
+	if o.VersionID != "" {
+		parts = append(parts, "VERSION_ID="+o.VersionID)
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	return "Scanned system: " + strings.Join(parts, ", ")
+}
+
+// osReleaseProperties returns the distribution of the scanned system as CDX
+// metadata properties.
+func osReleaseProperties(o *scalibr.OSRelease) []cyclonedx.Property {
+	if o == nil {
+		return nil
+	}
+	var props []cyclonedx.Property
+	for _, p := range []cyclonedx.Property{
+		{Name: "scalibr:os-release:id", Value: o.ID},
+		{Name: "scalibr:os-release:version_id", Value: o.VersionID},
+		{Name: "scalibr:os-release:version_codename", Value: o.VersionCodename},
+		{Name: "scalibr:os-release:pretty_name", Value: o.PrettyName},
+	} {
+		if p.Value != "" {
+			props = append(props, p)
+		}
+	}
+	return props
+}
+
+// packageRefs maps inventory items to their ID in the converted document so
+// that relationships between them can be resolved.
+type packageRefs struct {
+	byInventory map[*extractor.Inventory]string
+	// Keyed by the location and PURL of the package, as relationship targets
+	// are found in the same file as the source.
+	byLocationAndPURL map[locationAndPURL]string
+}
+
+type locationAndPURL struct {
+	location string
+	purl     string
+}
+
+func newPackageRefs() *packageRefs {
+	return &packageRefs{
+		byInventory:       map[*extractor.Inventory]string{},
+		byLocationAndPURL: map[locationAndPURL]string{},
+	}
+}
+
+func (r *packageRefs) add(i *extractor.Inventory, p *purl.PackageURL, ref string) {
+	r.byInventory[i] = ref
+	r.byLocationAndPURL[locationAndPURL{firstLocation(i), p.String()}] = ref
+}
+
+// dependsOn returns the IDs of the packages the given inventory item depends
+// on. Targets that weren't added to the document are logged and skipped.
+func (r *packageRefs) dependsOn(i *extractor.Inventory) []string {
+	var result []string
+	for _, rel := range i.Relationships {
+		if rel.Type != extractor.DependsOn {
+			continue
+		}
+		target := rel.TargetPURL
+		// Normalize the PURL so that it matches the one generated by the extractor.
+		if p, err := purl.FromString(target); err == nil {
+			target = purl.Normalize(&p).String()
+		}
+		ref, ok := r.byLocationAndPURL[locationAndPURL{firstLocation(i), target}]
+		if !ok {
+			log.Warnf("Skipping dependency of %q on %q: target not found in %s", i.Name, rel.TargetPURL, firstLocation(i))
+			continue
+		}
+		result = append(result, ref)
+	}
+	return result
+}
+
+func firstLocation(i *extractor.Inventory) string {
+	paths := i.LocationPaths()
+	if len(paths) == 0 {
+		return ""
+	}
+	return paths[0]
 }
 
 func replaceSPDXIDInvalidChars(id string) string {
@@ -179,17 +499,35 @@ type CDXConfig struct {
 	ComponentName    string
 	ComponentVersion string
 	Authors          []string
+	// Optional: The creation time of the document. Defaults to the current
+	// time. If set, the BOM refs are derived from the scan results instead of
+	// being random, so that identical scan results produce byte-identical
+	// documents.
+	Timestamp time.Time
 }
 
 // ToCDX converts the SCALIBR scan results into a CycloneDX document.
+// Inventory items that can't be converted into a package URL are logged and
+// added without one, use ToCDXWithErrors to get the list of these items.
 func ToCDX(r *scalibr.ScanResult, c CDXConfig) *cyclonedx.BOM {
+	bom, _ := ToCDXWithErrors(r, c)
+	return bom
+}
+
+// ToCDXWithErrors converts the SCALIBR scan results into a CycloneDX document.
+// It also returns the inventory items that couldn't be converted into a
+// package URL. These are still added to the document, without a PURL.
+func ToCDXWithErrors(r *scalibr.ScanResult, c CDXConfig) (*cyclonedx.BOM, []*ConversionError) {
+	var convErrs []*ConversionError
+	ids := newUUIDGenerator(r, c.Timestamp)
 	bom := cyclonedx.NewBOM()
 	bom.Metadata = &cyclonedx.Metadata{
-		Timestamp: time.Now().UTC().Format("2006-01-02T15:04:05Z"),
+		Timestamp: creationTime(c.Timestamp),
 		Component: &cyclonedx.Component{
+			Type:    cyclonedx.ComponentTypeApplication,
 			Name:    c.ComponentName,
 			Version: c.ComponentVersion,
-			BOMRef:  uuid.New().String(),
+			BOMRef:  ids.next(),
 		},
 		Tools: &cyclonedx.ToolsChoice{
 			Components: &[]cyclonedx.Component{
@@ -215,35 +553,75 @@ func ToCDX(r *scalibr.ScanResult, c CDXConfig) *cyclonedx.BOM {
 		}
 		bom.Metadata.Authors = &authors
 	}
+	if props := slices.Concat(osReleaseProperties(r.OSRelease), metadataProperties(r.Metadata)); len(props) > 0 {
+		bom.Metadata.Properties = &props
+	}
 
 	comps := make([]cyclonedx.Component, 0, len(r.Inventories))
+	refs := newPackageRefs()
 	for _, i := range r.Inventories {
 		pkg := cyclonedx.Component{
-			BOMRef:  uuid.New().String(),
+			BOMRef:  ids.next(),
 			Type:    cyclonedx.ComponentTypeLibrary,
 			Name:    (*i).Name,
 			Version: (*i).Version,
 		}
-		if p, err := ToPURL(i); err == nil {
+		if p, convErr := toPURLOrError(i); convErr == nil {
 			pkg.PackageURL = p.String()
+			refs.add(i, p, pkg.BOMRef)
+		} else {
+			log.Warnf("No PURL for CDX component of %v", convErr)
+			convErrs = append(convErrs, convErr)
 		}
 		if cpes, err := ToCPEs(i); err == nil && len(cpes) > 0 {
 			pkg.CPE = cpes[0]
 		}
-		if len((*i).Locations) > 0 {
-			occ := make([]cyclonedx.EvidenceOccurrence, 0, len(((*i).Locations)))
-			for _, loc := range (*i).Locations {
-				occ = append(occ, cyclonedx.EvidenceOccurrence{
-					Location: loc,
-				})
+		if locs := i.AllLocations(); len(locs) > 0 {
+			occ := make([]cyclonedx.EvidenceOccurrence, 0, len(locs))
+			for _, loc := range locs {
+				occ = append(occ, toEvidenceOccurrence(loc))
 			}
 			pkg.Evidence = &cyclonedx.Evidence{
 				Occurrences: &occ,
 			}
 		}
+		if props := inventoryProperties(i); len(props) > 0 {
+			pkg.Properties = &props
+		}
 		comps = append(comps, pkg)
 	}
 	bom.Components = &comps
 
-	return bom
+	var deps []cyclonedx.Dependency
+	for _, i := range r.Inventories {
+		ref, ok := refs.byInventory[i]
+		if !ok {
+			continue
+		}
+		if targets := refs.dependsOn(i); len(targets) > 0 {
+			deps = append(deps, cyclonedx.Dependency{Ref: ref, Dependencies: &targets})
+		}
+	}
+	if len(deps) > 0 {
+		bom.Dependencies = &deps
+	}
+
+	return bom, convErrs
+}
+
+// toEvidenceOccurrence converts a location into a CDX evidence occurrence.
+func toEvidenceOccurrence(l *extractor.Location) cyclonedx.EvidenceOccurrence {
+	occ := cyclonedx.EvidenceOccurrence{Location: l.Path}
+	if l.Line > 0 {
+		line := l.Line
+		occ.Line = &line
+	}
+	if l.Offset > 0 {
+		offset := int(l.Offset)
+		occ.Offset = &offset
+	}
+	if l.Kind != extractor.LocationKindUnspecified {
+		occ.AdditionalContext = "Location kind: " + l.Kind.String()
+	}
+	return occ
 }
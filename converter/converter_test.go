@@ -15,9 +15,11 @@
 package converter_test
 
 import (
+	"errors"
 	"math/rand"
 	"runtime"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/CycloneDX/cyclonedx-go"
@@ -27,6 +29,7 @@ import (
 	"github.com/google/osv-scalibr/converter"
 	"github.com/google/osv-scalibr/extractor"
 	"github.com/google/osv-scalibr/extractor/filesystem/language/python/wheelegg"
+	"github.com/google/osv-scalibr/extractor/filesystem/osv"
 	"github.com/google/osv-scalibr/extractor/filesystem/sbom/spdx"
 	"github.com/google/osv-scalibr/extractor/standalone/windows/dismpatch"
 	"github.com/google/osv-scalibr/purl"
@@ -499,6 +502,7 @@ func TestToCDX(t *testing.T) {
 			want: &cyclonedx.BOM{
 				Metadata: &cyclonedx.Metadata{
 					Component: &cyclonedx.Component{
+						Type:    cyclonedx.ComponentTypeApplication,
 						Name:    "sbom-1",
 						Version: "1.0.0",
 						BOMRef:  "52fdfc07-2182-454f-963f-5f0f9a621d72",
@@ -548,6 +552,217 @@ func TestToCDX(t *testing.T) {
 	}
 }
 
+func TestFixedTimestamp(t *testing.T) {
+	pipEx := wheelegg.New(wheelegg.DefaultConfig())
+	newResult := func() *scalibr.ScanResult {
+		return &scalibr.ScanResult{
+			Inventories: []*extractor.Inventory{
+				&extractor.Inventory{Name: "a", Version: "1.0", Extractor: pipEx, Locations: []string{"a/METADATA"}},
+				&extractor.Inventory{Name: "b", Version: "2.0", Extractor: pipEx, Locations: []string{"b/METADATA"}},
+			},
+		}
+	}
+	ts := time.Date(2024, 3, 1, 8, 0, 0, 0, time.UTC)
+	spdxConfig := converter.SPDXConfig{Timestamp: ts}
+	cdxConfig := converter.CDXConfig{Timestamp: ts}
+
+	spdx1 := converter.ToSPDX23(newResult(), spdxConfig)
+	spdx2 := converter.ToSPDX23(newResult(), spdxConfig)
+	if diff := cmp.Diff(spdx1, spdx2, cmp.AllowUnexported(v2_3.Package{})); diff != "" {
+		t.Errorf("converter.ToSPDX23() with fixed timestamp not deterministic, diff (-first +second):\n%s", diff)
+	}
+	if want := "2024-03-01T08:00:00Z"; spdx1.CreationInfo.Created != want {
+		t.Errorf("converter.ToSPDX23() Created: got %q, want %q", spdx1.CreationInfo.Created, want)
+	}
+
+	cdx1 := converter.ToCDX(newResult(), cdxConfig)
+	cdx2 := converter.ToCDX(newResult(), cdxConfig)
+	if diff := cmp.Diff(cdx1, cdx2); diff != "" {
+		t.Errorf("converter.ToCDX() with fixed timestamp not deterministic, diff (-first +second):\n%s", diff)
+	}
+	if want := "2024-03-01T08:00:00Z"; cdx1.Metadata.Timestamp != want {
+		t.Errorf("converter.ToCDX() Timestamp: got %q, want %q", cdx1.Metadata.Timestamp, want)
+	}
+
+	// Different scan results should still get different IDs.
+	other := newResult()
+	other.Inventories[1].Version = "2.1"
+	if spdx3 := converter.ToSPDX23(other, spdxConfig); spdx3.DocumentNamespace == spdx1.DocumentNamespace {
+		t.Errorf("converter.ToSPDX23() returned the same namespace %q for different scan results", spdx1.DocumentNamespace)
+	}
+}
+
+func TestOSRelease(t *testing.T) {
+	testCases := []struct {
+		desc            string
+		osRelease       *scalibr.OSRelease
+		wantSPDXComment string
+		wantCDXProps    *[]cyclonedx.Property
+	}{
+		{
+			desc: "No os-release",
+		},
+		{
+			desc: "Full os-release",
+			osRelease: &scalibr.OSRelease{
+				ID:              "debian",
+				VersionID:       "12",
+				VersionCodename: "bookworm",
+				PrettyName:      "Debian GNU/Linux 12 (bookworm)",
+			},
+			wantSPDXComment: "Scanned system: Debian GNU/Linux 12 (bookworm), ID=debian, VERSION_ID=12",
+			wantCDXProps: &[]cyclonedx.Property{
+				{Name: "scalibr:os-release:id", Value: "debian"},
+				{Name: "scalibr:os-release:version_id", Value: "12"},
+				{Name: "scalibr:os-release:version_codename", Value: "bookworm"},
+				{Name: "scalibr:os-release:pretty_name", Value: "Debian GNU/Linux 12 (bookworm)"},
+			},
+		},
+		{
+			desc:            "Rolling release",
+			osRelease:       &scalibr.OSRelease{ID: "arch"},
+			wantSPDXComment: "Scanned system: ID=arch",
+			wantCDXProps:    &[]cyclonedx.Property{{Name: "scalibr:os-release:id", Value: "arch"}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			r := &scalibr.ScanResult{OSRelease: tc.osRelease}
+			doc := converter.ToSPDX23(r, converter.SPDXConfig{})
+			if got := doc.CreationInfo.CreatorComment; got != tc.wantSPDXComment {
+				t.Errorf("converter.ToSPDX23(%v) creator comment: got %q, want %q", r, got, tc.wantSPDXComment)
+			}
+			bom := converter.ToCDX(r, converter.CDXConfig{})
+			if diff := cmp.Diff(tc.wantCDXProps, bom.Metadata.Properties); diff != "" {
+				t.Errorf("converter.ToCDX(%v) metadata properties: unexpected diff (-want +got):\n%s", r, diff)
+			}
+		})
+	}
+}
+
+func TestScanMetadata(t *testing.T) {
+	r := &scalibr.ScanResult{
+		OSRelease: &scalibr.OSRelease{ID: "arch"},
+		Metadata:  map[string]string{"role": "web", "build_id": "1234"},
+	}
+
+	doc := converter.ToSPDX23(r, converter.SPDXConfig{})
+	wantComment := "Scanned system: ID=arch\nScan metadata: build_id=1234, role=web"
+	if got := doc.CreationInfo.CreatorComment; got != wantComment {
+		t.Errorf("converter.ToSPDX23(%v) creator comment: got %q, want %q", r, got, wantComment)
+	}
+
+	bom := converter.ToCDX(r, converter.CDXConfig{})
+	wantProps := &[]cyclonedx.Property{
+		{Name: "scalibr:os-release:id", Value: "arch"},
+		{Name: "scalibr:metadata:build_id", Value: "1234"},
+		{Name: "scalibr:metadata:role", Value: "web"},
+	}
+	if diff := cmp.Diff(wantProps, bom.Metadata.Properties); diff != "" {
+		t.Errorf("converter.ToCDX(%v) metadata properties: unexpected diff (-want +got):\n%s", r, diff)
+	}
+}
+
+func TestConfidence(t *testing.T) {
+	pipEx := wheelegg.New(wheelegg.DefaultConfig())
+	testCases := []struct {
+		desc            string
+		inv             *extractor.Inventory
+		wantSPDXComment string
+		wantCDXProps    *[]cyclonedx.Property
+	}{
+		{
+			desc: "No confidence",
+			inv:  &extractor.Inventory{Name: "a", Version: "1.0", Extractor: pipEx},
+		},
+		{
+			desc: "Confidence and evidence",
+			inv: &extractor.Inventory{
+				Name:       "a",
+				Version:    "1.0",
+				Extractor:  pipEx,
+				Confidence: extractor.ConfidenceLow,
+				Evidence:   "version from the installation path",
+			},
+			wantSPDXComment: "Confidence: low. Evidence: version from the installation path",
+			wantCDXProps: &[]cyclonedx.Property{
+				{Name: "scalibr:confidence", Value: "low"},
+				{Name: "scalibr:evidence", Value: "version from the installation path"},
+			},
+		},
+		{
+			desc:            "Confidence only",
+			inv:             &extractor.Inventory{Name: "a", Version: "1.0", Extractor: pipEx, Confidence: extractor.ConfidenceHigh},
+			wantSPDXComment: "Confidence: high",
+			wantCDXProps:    &[]cyclonedx.Property{{Name: "scalibr:confidence", Value: "high"}},
+		},
+		{
+			desc:         "Scan root",
+			inv:          &extractor.Inventory{Name: "a", Version: "1.0", Extractor: pipEx, ScanRoot: "D:\\"},
+			wantCDXProps: &[]cyclonedx.Property{{Name: "scalibr:scan-root", Value: "D:\\"}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			r := &scalibr.ScanResult{Inventories: []*extractor.Inventory{tc.inv}}
+			doc := converter.ToSPDX23(r, converter.SPDXConfig{})
+			// The first package is the main package.
+			annotations := doc.Packages[1].Annotations
+			if tc.wantSPDXComment == "" {
+				if len(annotations) != 0 {
+					t.Errorf("converter.ToSPDX23(%v) package annotations: got %v, want none", r, annotations)
+				}
+			} else {
+				if len(annotations) != 1 {
+					t.Fatalf("converter.ToSPDX23(%v) package annotations: got %v, want 1", r, annotations)
+				}
+				if got := annotations[0].AnnotationComment; got != tc.wantSPDXComment {
+					t.Errorf("converter.ToSPDX23(%v) annotation comment: got %q, want %q", r, got, tc.wantSPDXComment)
+				}
+				if got := annotations[0].AnnotationType; got != "OTHER" {
+					t.Errorf("converter.ToSPDX23(%v) annotation type: got %q, want OTHER", r, got)
+				}
+			}
+			bom := converter.ToCDX(r, converter.CDXConfig{})
+			if diff := cmp.Diff(tc.wantCDXProps, (*bom.Components)[0].Properties); diff != "" {
+				t.Errorf("converter.ToCDX(%v) component properties: unexpected diff (-want +got):\n%s", r, diff)
+			}
+		})
+	}
+}
+
+func TestLocationDetails(t *testing.T) {
+	inv := &extractor.Inventory{
+		Name:      "a",
+		Version:   "1.0",
+		Extractor: wheelegg.New(wheelegg.DefaultConfig()),
+		Locations: []string{"site-packages/a-1.0.dist-info/METADATA"},
+		LocationDetails: []*extractor.Location{
+			{Path: "requirements.txt", Kind: extractor.LocationKindManifest, Line: 3, Offset: 20},
+		},
+	}
+	r := &scalibr.ScanResult{Inventories: []*extractor.Inventory{inv}}
+
+	doc := converter.ToSPDX23(r, converter.SPDXConfig{})
+	wantSourceInfo := "Identified by the python/wheelegg extractor from 2 locations, including site-packages/a-1.0.dist-info/METADATA and requirements.txt:3"
+	// The first package is the main package.
+	if got := doc.Packages[1].PackageSourceInfo; got != wantSourceInfo {
+		t.Errorf("converter.ToSPDX23(%v) package source info: got %q, want %q", r, got, wantSourceInfo)
+	}
+
+	bom := converter.ToCDX(r, converter.CDXConfig{})
+	line, offset := 3, 20
+	want := &[]cyclonedx.EvidenceOccurrence{
+		{Location: "site-packages/a-1.0.dist-info/METADATA"},
+		{Location: "requirements.txt", Line: &line, Offset: &offset, AdditionalContext: "Location kind: manifest"},
+	}
+	if diff := cmp.Diff(want, (*bom.Components)[0].Evidence.Occurrences); diff != "" {
+		t.Errorf("converter.ToCDX(%v) evidence occurrences: unexpected diff (-want +got):\n%s", r, diff)
+	}
+}
+
 func TestToPURL(t *testing.T) {
 	pipEx := wheelegg.New(wheelegg.DefaultConfig())
 	tests := []struct {
@@ -571,6 +786,21 @@ func TestToPURL(t *testing.T) {
 				Version: "1.0.0",
 			},
 		},
+		{
+			desc: "PURL is normalized",
+			inventory: &extractor.Inventory{
+				Name:      "Typing_Extensions",
+				Version:   "4.12.2",
+				Locations: []string{"poetry.lock"},
+				Metadata:  &osv.Metadata{PURLType: purl.TypePyPi},
+				Extractor: osv.Wrapper{ExtractorName: "python/poetry", PURLType: purl.TypePyPi},
+			},
+			want: &purl.PackageURL{
+				Type:    purl.TypePyPi,
+				Name:    "typing-extensions",
+				Version: "4.12.2",
+			},
+		},
 		{
 			desc: "Windows-only returns error on Linux",
 			inventory: &extractor.Inventory{
@@ -606,6 +836,118 @@ func TestToPURL(t *testing.T) {
 	}
 }
 
+func TestConversionErrors(t *testing.T) {
+	pipEx := wheelegg.New(wheelegg.DefaultConfig())
+	valid := &extractor.Inventory{Name: "software", Version: "1.0.0", Locations: []string{"/file1"}, Extractor: pipEx}
+	noPURL := &extractor.Inventory{Name: "no-purl", Locations: []string{"/file2"}, Extractor: &spdx.Extractor{}, Metadata: &spdx.Metadata{}}
+	noVersion := &extractor.Inventory{Name: "no-version", Locations: []string{"/file3"}, Extractor: pipEx}
+	result := &scalibr.ScanResult{Inventories: []*extractor.Inventory{valid, noPURL, noVersion}}
+
+	t.Run("SPDX", func(t *testing.T) {
+		doc, convErrs := converter.ToSPDX23WithErrors(result, converter.SPDXConfig{})
+		// The main package and the valid package.
+		if len(doc.Packages) != 2 {
+			t.Errorf("converter.ToSPDX23WithErrors(%v): got %d packages, want 2", result, len(doc.Packages))
+		}
+		if len(convErrs) != 2 {
+			t.Fatalf("converter.ToSPDX23WithErrors(%v): got %d errors, want 2: %v", result, len(convErrs), convErrs)
+		}
+		if convErrs[0].Inventory != noPURL || !errors.Is(convErrs[0], converter.ErrNoPURL) {
+			t.Errorf("converter.ToSPDX23WithErrors(%v): got error %v, want ErrNoPURL for %v", result, convErrs[0], noPURL)
+		}
+		if convErrs[1].Inventory != noVersion || !errors.Is(convErrs[1], converter.ErrEmptyPURLNameOrVersion) {
+			t.Errorf("converter.ToSPDX23WithErrors(%v): got error %v, want ErrEmptyPURLNameOrVersion for %v", result, convErrs[1], noVersion)
+		}
+	})
+
+	t.Run("CDX", func(t *testing.T) {
+		bom, convErrs := converter.ToCDXWithErrors(result, converter.CDXConfig{})
+		if len(*bom.Components) != 3 {
+			t.Errorf("converter.ToCDXWithErrors(%v): got %d components, want 3", result, len(*bom.Components))
+		}
+		if len(convErrs) != 1 {
+			t.Fatalf("converter.ToCDXWithErrors(%v): got %d errors, want 1: %v", result, len(convErrs), convErrs)
+		}
+		if convErrs[0].Inventory != noPURL || !errors.Is(convErrs[0], converter.ErrNoPURL) {
+			t.Errorf("converter.ToCDXWithErrors(%v): got error %v, want ErrNoPURL for %v", result, convErrs[0], noPURL)
+		}
+	})
+}
+
+func TestRelationships(t *testing.T) {
+	pipEx := wheelegg.New(wheelegg.DefaultConfig())
+	app := &extractor.Inventory{
+		Name: "app", Version: "1.0.0", Locations: []string{"/lockfile"}, Extractor: pipEx,
+		Relationships: []*extractor.Relationship{
+			{Type: extractor.DependsOn, TargetPURL: "pkg:pypi/lib@2.0.0"},
+			// Not found in the same file.
+			{Type: extractor.DependsOn, TargetPURL: "pkg:pypi/other@3.0.0"},
+		},
+	}
+	lib := &extractor.Inventory{Name: "lib", Version: "2.0.0", Locations: []string{"/lockfile"}, Extractor: pipEx}
+	other := &extractor.Inventory{Name: "other", Version: "3.0.0", Locations: []string{"/other-lockfile"}, Extractor: pipEx}
+	result := &scalibr.ScanResult{Inventories: []*extractor.Inventory{app, lib, other}}
+
+	t.Run("SPDX", func(t *testing.T) {
+		doc := converter.ToSPDX23(result, converter.SPDXConfig{})
+		ids := map[string]common.ElementID{}
+		for _, p := range doc.Packages {
+			ids[p.PackageName] = p.PackageSPDXIdentifier
+		}
+		var got []*v2_3.Relationship
+		for _, r := range doc.Relationships {
+			if r.Relationship == "DEPENDS_ON" {
+				got = append(got, r)
+			}
+		}
+		want := []*v2_3.Relationship{{
+			RefA:         common.DocElementID{ElementRefID: ids["app"]},
+			RefB:         common.DocElementID{ElementRefID: ids["lib"]},
+			Relationship: "DEPENDS_ON",
+		}}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("converter.ToSPDX23(%v): unexpected DEPENDS_ON relationships (-want +got):\n%s", result, diff)
+		}
+	})
+
+	t.Run("CDX", func(t *testing.T) {
+		bom := converter.ToCDX(result, converter.CDXConfig{})
+		refs := map[string]string{}
+		for _, c := range *bom.Components {
+			refs[c.Name] = c.BOMRef
+		}
+		want := &[]cyclonedx.Dependency{{
+			Ref:          refs["app"],
+			Dependencies: &[]string{refs["lib"]},
+		}}
+		if diff := cmp.Diff(want, bom.Dependencies); diff != "" {
+			t.Errorf("converter.ToCDX(%v): unexpected dependencies (-want +got):\n%s", result, diff)
+		}
+	})
+}
+
+func TestToPURLList(t *testing.T) {
+	pipEx := wheelegg.New(wheelegg.DefaultConfig())
+	result := &scalibr.ScanResult{
+		Inventories: []*extractor.Inventory{
+			{Name: "software", Version: "1.0.0", Locations: []string{"/file1"}, Extractor: pipEx},
+			{Name: "other", Version: "2.0.0", Locations: []string{"/file2"}, Extractor: pipEx},
+			{Name: "software", Version: "1.0.0", Locations: []string{"/file3"}, Extractor: pipEx},
+			{Name: "software", Version: "0.9.0", Locations: []string{"/file4"}, Extractor: pipEx},
+		},
+	}
+	want := []string{
+		"pkg:pypi/other@2.0.0",
+		"pkg:pypi/software@0.9.0",
+		"pkg:pypi/software@1.0.0",
+	}
+
+	got := converter.ToPURLList(result)
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("converter.ToPURLList(%v) returned unexpected diff (-want +got):\n%s", result, diff)
+	}
+}
+
 func TestToCPEs(t *testing.T) {
 	tests := []struct {
 		desc      string
@@ -0,0 +1,172 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package converter
+
+import (
+	"slices"
+
+	"github.com/CycloneDX/cyclonedx-go"
+	"github.com/spdx/tools-golang/spdx/v2/v2_3"
+	"github.com/google/osv-scalibr/log"
+	"github.com/google/osv-scalibr/purl"
+	scalibr "github.com/google/osv-scalibr"
+)
+
+// SBOMDiff describes how the packages of a scanned system changed compared to an SBOM.
+// Packages are identified by their PURL without the version and qualifiers.
+type SBOMDiff struct {
+	// PURLs of packages present in the new scan but not in the SBOM.
+	Added []string `json:"added"`
+	// PURLs of packages present in the SBOM but not in the new scan.
+	Removed []string `json:"removed"`
+	// Packages whose version changed between the SBOM and the new scan.
+	Changed []*ChangedPackage `json:"changed"`
+}
+
+// ChangedPackage is a package whose PURL differs between the SBOM and the new scan.
+type ChangedPackage struct {
+	Old string `json:"old"`
+	New string `json:"new"`
+}
+
+// IsEmpty returns true if the scanned system matches the SBOM.
+func (d *SBOMDiff) IsEmpty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0
+}
+
+// PURLsFromSPDX23 returns the package URLs referenced by the packages of an SPDX v2.3 document.
+// This is the reverse of ToSPDX23. Packages without a PURL are skipped. The PURLs
+// are normalized so that they can be compared with the ones from ScanResultPURLs.
+func PURLsFromSPDX23(doc *v2_3.Document) []*purl.PackageURL {
+	var result []*purl.PackageURL
+	for _, p := range doc.Packages {
+		for _, ref := range p.PackageExternalReferences {
+			if ref.RefType != "purl" && ref.RefType != "http://spdx.org/rdf/references/purl" {
+				continue
+			}
+			pu, err := purl.FromString(ref.Locator)
+			if err != nil {
+				log.Warnf("Invalid PURL %q in SPDX package %q: %v", ref.Locator, p.PackageName, err)
+				continue
+			}
+			result = append(result, purl.Normalize(&pu))
+		}
+	}
+	return result
+}
+
+// PURLsFromCDX returns the package URLs of the components of a CycloneDX document.
+// This is the reverse of ToCDX. Components without a PURL are skipped. The PURLs
+// are normalized so that they can be compared with the ones from ScanResultPURLs.
+func PURLsFromCDX(bom *cyclonedx.BOM) []*purl.PackageURL {
+	var result []*purl.PackageURL
+	if bom.Components == nil {
+		return result
+	}
+	for _, c := range *bom.Components {
+		if c.PackageURL == "" {
+			continue
+		}
+		pu, err := purl.FromString(c.PackageURL)
+		if err != nil {
+			log.Warnf("Invalid PURL %q in CDX component %q: %v", c.PackageURL, c.Name, err)
+			continue
+		}
+		result = append(result, purl.Normalize(&pu))
+	}
+	return result
+}
+
+// ScanResultPURLs returns the package URLs of the inventory found in a scan.
+// Inventory that can't be converted into a PURL is skipped.
+func ScanResultPURLs(r *scalibr.ScanResult) []*purl.PackageURL {
+	var result []*purl.PackageURL
+	for _, i := range r.Inventories {
+		p, convErr := toPURLOrError(i)
+		if convErr != nil {
+			log.Warnf("Skipping %v", convErr)
+			continue
+		}
+		result = append(result, p)
+	}
+	return result
+}
+
+// DiffPURLs compares the package URLs of an SBOM with the ones from a new scan.
+// PURLs are keyed by their type, namespace and name. If a package has exactly one
+// differing PURL on each side it's reported as changed, otherwise as added or removed.
+func DiffPURLs(sbom []*purl.PackageURL, scan []*purl.PackageURL) *SBOMDiff {
+	oldPURLs := groupPURLs(sbom)
+	newPURLs := groupPURLs(scan)
+	keys := make(map[string]struct{})
+	for k := range oldPURLs {
+		keys[k] = struct{}{}
+	}
+	for k := range newPURLs {
+		keys[k] = struct{}{}
+	}
+
+	diff := &SBOMDiff{Added: []string{}, Removed: []string{}, Changed: []*ChangedPackage{}}
+	for k := range keys {
+		removed := setDifference(oldPURLs[k], newPURLs[k])
+		added := setDifference(newPURLs[k], oldPURLs[k])
+		if len(removed) == 1 && len(added) == 1 {
+			diff.Changed = append(diff.Changed, &ChangedPackage{Old: removed[0], New: added[0]})
+			continue
+		}
+		diff.Removed = append(diff.Removed, removed...)
+		diff.Added = append(diff.Added, added...)
+	}
+
+	// Sort for deterministic output.
+	slices.Sort(diff.Added)
+	slices.Sort(diff.Removed)
+	slices.SortFunc(diff.Changed, func(a, b *ChangedPackage) int { return cmpString(a.Old, b.Old) })
+	return diff
+}
+
+// groupPURLs maps the version-less PURL of each package to the set of full PURL strings.
+func groupPURLs(purls []*purl.PackageURL) map[string]map[string]struct{} {
+	result := make(map[string]map[string]struct{})
+	for _, p := range purls {
+		key := (&purl.PackageURL{Type: p.Type, Namespace: p.Namespace, Name: p.Name}).String()
+		if _, ok := result[key]; !ok {
+			result[key] = make(map[string]struct{})
+		}
+		result[key][p.String()] = struct{}{}
+	}
+	return result
+}
+
+// setDifference returns the sorted elements of a that are not in b.
+func setDifference(a, b map[string]struct{}) []string {
+	var result []string
+	for s := range a {
+		if _, ok := b[s]; !ok {
+			result = append(result, s)
+		}
+	}
+	slices.Sort(result)
+	return result
+}
+
+func cmpString(a, b string) int {
+	if a < b {
+		return -1
+	} else if a > b {
+		return 1
+	}
+	return 0
+}
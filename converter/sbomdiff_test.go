@@ -0,0 +1,129 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package converter_test
+
+import (
+	"testing"
+
+	"github.com/CycloneDX/cyclonedx-go"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/osv-scalibr/converter"
+	"github.com/google/osv-scalibr/purl"
+	"github.com/spdx/tools-golang/spdx/v2/v2_3"
+)
+
+func mustPURL(t *testing.T, s string) *purl.PackageURL {
+	t.Helper()
+	p, err := purl.FromString(s)
+	if err != nil {
+		t.Fatalf("purl.FromString(%q): %v", s, err)
+	}
+	return &p
+}
+
+func TestPURLsFromSPDX23(t *testing.T) {
+	doc := &v2_3.Document{
+		Packages: []*v2_3.Package{
+			&v2_3.Package{PackageName: "main"},
+			&v2_3.Package{
+				PackageName: "software",
+				PackageExternalReferences: []*v2_3.PackageExternalReference{
+					&v2_3.PackageExternalReference{Category: "PACKAGE-MANAGER", RefType: "purl", Locator: "pkg:pypi/software@1.2.3"},
+				},
+			},
+			&v2_3.Package{
+				PackageName: "cpe-only",
+				PackageExternalReferences: []*v2_3.PackageExternalReference{
+					&v2_3.PackageExternalReference{Category: "SECURITY", RefType: "cpe23Type", Locator: "cpe:2.3:a:vendor:product:1.0:*:*:*:*:*:*:*"},
+				},
+			},
+		},
+	}
+	want := []*purl.PackageURL{mustPURL(t, "pkg:pypi/software@1.2.3")}
+	got := converter.PURLsFromSPDX23(doc)
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("converter.PURLsFromSPDX23() returned unexpected result (-want +got):\n%s", diff)
+	}
+}
+
+func TestPURLsFromCDX(t *testing.T) {
+	bom := cyclonedx.NewBOM()
+	bom.Components = &[]cyclonedx.Component{
+		{Name: "software", PackageURL: "pkg:npm/software@1.0.0"},
+		{Name: "no-purl"},
+	}
+	want := []*purl.PackageURL{mustPURL(t, "pkg:npm/software@1.0.0")}
+	got := converter.PURLsFromCDX(bom)
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("converter.PURLsFromCDX() returned unexpected result (-want +got):\n%s", diff)
+	}
+}
+
+func TestDiffPURLs(t *testing.T) {
+	testCases := []struct {
+		desc      string
+		sbom      []string
+		scan      []string
+		want      *converter.SBOMDiff
+		wantEmpty bool
+	}{
+		{
+			desc:      "No changes",
+			sbom:      []string{"pkg:pypi/a@1.0", "pkg:npm/b@2.0"},
+			scan:      []string{"pkg:npm/b@2.0", "pkg:pypi/a@1.0"},
+			want:      &converter.SBOMDiff{Added: []string{}, Removed: []string{}, Changed: []*converter.ChangedPackage{}},
+			wantEmpty: true,
+		},
+		{
+			desc: "Added, removed and changed packages",
+			sbom: []string{"pkg:pypi/a@1.0", "pkg:npm/b@2.0", "pkg:npm/removed@1.0"},
+			scan: []string{"pkg:pypi/a@1.1", "pkg:npm/b@2.0", "pkg:golang/added@0.1.0"},
+			want: &converter.SBOMDiff{
+				Added:   []string{"pkg:golang/added@0.1.0"},
+				Removed: []string{"pkg:npm/removed@1.0"},
+				Changed: []*converter.ChangedPackage{{Old: "pkg:pypi/a@1.0", New: "pkg:pypi/a@1.1"}},
+			},
+		},
+		{
+			desc: "Same package in several versions",
+			sbom: []string{"pkg:pypi/a@1.0", "pkg:pypi/a@2.0"},
+			scan: []string{"pkg:pypi/a@2.0", "pkg:pypi/a@3.0", "pkg:pypi/a@4.0"},
+			want: &converter.SBOMDiff{
+				Added:   []string{"pkg:pypi/a@3.0", "pkg:pypi/a@4.0"},
+				Removed: []string{"pkg:pypi/a@1.0"},
+				Changed: []*converter.ChangedPackage{},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			var sbom, scan []*purl.PackageURL
+			for _, s := range tc.sbom {
+				sbom = append(sbom, mustPURL(t, s))
+			}
+			for _, s := range tc.scan {
+				scan = append(scan, mustPURL(t, s))
+			}
+			got := converter.DiffPURLs(sbom, scan)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("converter.DiffPURLs(%v, %v) returned unexpected result (-want +got):\n%s", tc.sbom, tc.scan, diff)
+			}
+			if got.IsEmpty() != tc.wantEmpty {
+				t.Errorf("converter.DiffPURLs(%v, %v).IsEmpty(): got %v, want %v", tc.sbom, tc.scan, got.IsEmpty(), tc.wantEmpty)
+			}
+		})
+	}
+}
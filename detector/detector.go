@@ -19,6 +19,8 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/google/osv-scalibr/extractor"
@@ -150,6 +152,80 @@ func Run(ctx context.Context, c stats.Collector, detectors []Detector, scanRoot
 	return findings, status, nil
 }
 
+// Deduplicate merges the findings that several detectors reported for the
+// same issue, i.e. with the same advisory ID and the same affected inventory.
+// Findings without inventory are matched by their locations instead. The
+// merged finding lists all detectors that reported it, the union of their
+// locations and their distinct extra info. Findings are returned in the order
+// they were first reported. The passed findings aren't modified.
+func Deduplicate(findings []*Finding) []*Finding {
+	result := make([]*Finding, 0, len(findings))
+	merged := make(map[string]*Finding)
+	for _, f := range findings {
+		if f.Adv == nil || f.Adv.ID == nil {
+			result = append(result, f)
+			continue
+		}
+		key := findingKey(f)
+		m, ok := merged[key]
+		if !ok {
+			m = copyFinding(f)
+			merged[key] = m
+			result = append(result, m)
+			continue
+		}
+		for _, d := range f.Detectors {
+			if !slices.Contains(m.Detectors, d) {
+				m.Detectors = append(m.Detectors, d)
+			}
+		}
+		if f.Target != nil {
+			if m.Target == nil {
+				m.Target = &TargetDetails{}
+			}
+			for _, l := range f.Target.Location {
+				if !slices.Contains(m.Target.Location, l) {
+					m.Target.Location = append(m.Target.Location, l)
+				}
+			}
+		}
+		if f.Extra != "" && !slices.Contains(strings.Split(m.Extra, "\n"), f.Extra) {
+			if m.Extra != "" {
+				m.Extra += "\n"
+			}
+			m.Extra += f.Extra
+		}
+	}
+	return result
+}
+
+// findingKey returns the key by which duplicate findings are matched.
+func findingKey(f *Finding) string {
+	key := f.Adv.ID.Publisher + "\x00" + f.Adv.ID.Reference
+	if f.Target == nil {
+		return key
+	}
+	if inv := f.Target.Inventory; inv != nil {
+		return fmt.Sprintf("%s\x00inv\x00%s\x00%s\x00%v", key, inv.Name, inv.Version, inv.Locations)
+	}
+	locations := slices.Clone(f.Target.Location)
+	slices.Sort(locations)
+	return fmt.Sprintf("%s\x00loc\x00%v", key, locations)
+}
+
+// copyFinding returns a copy of the finding whose detectors and locations can
+// be extended without modifying the original.
+func copyFinding(f *Finding) *Finding {
+	c := *f
+	c.Detectors = slices.Clone(f.Detectors)
+	if f.Target != nil {
+		t := *f.Target
+		t.Location = slices.Clone(f.Target.Location)
+		c.Target = &t
+	}
+	return &c
+}
+
 func validateAdvisories(findings []*Finding) error {
 	// Check that findings with the same advisory ID have identical advisories.
 	ids := make(map[AdvisoryID]Advisory)
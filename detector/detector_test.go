@@ -17,6 +17,7 @@ package detector_test
 import (
 	"context"
 	"errors"
+	"slices"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -173,3 +174,93 @@ func withDetectorName(f *detector.Finding, det string) *detector.Finding {
 	copy.Detectors = []string{det}
 	return &copy
 }
+
+func TestDeduplicate(t *testing.T) {
+	adv1 := &detector.Advisory{ID: &detector.AdvisoryID{Publisher: "CVE", Reference: "CVE-1234"}}
+	adv2 := &detector.Advisory{ID: &detector.AdvisoryID{Publisher: "CVE", Reference: "CVE-5678"}}
+	inv1 := &extractor.Inventory{Name: "openssh", Version: "9.1", Locations: []string{"usr/sbin/sshd"}}
+	inv2 := &extractor.Inventory{Name: "openssl", Version: "3.0.1", Locations: []string{"usr/lib/libssl.so.3"}}
+
+	testCases := []struct {
+		desc     string
+		findings []*detector.Finding
+		want     []*detector.Finding
+	}{
+		{
+			desc: "Same advisory and inventory merged",
+			findings: []*detector.Finding{
+				{Adv: adv1, Target: &detector.TargetDetails{Inventory: inv1, Location: []string{"etc/ssh/sshd_config"}}, Extra: "found by version", Detectors: []string{"det1"}},
+				{Adv: adv1, Target: &detector.TargetDetails{Inventory: inv1, Location: []string{"usr/sbin/sshd"}}, Extra: "found by probe", Detectors: []string{"det2"}},
+				{Adv: adv1, Target: &detector.TargetDetails{Inventory: inv1}, Extra: "found by version", Detectors: []string{"det3"}},
+			},
+			want: []*detector.Finding{
+				{
+					Adv:       adv1,
+					Target:    &detector.TargetDetails{Inventory: inv1, Location: []string{"etc/ssh/sshd_config", "usr/sbin/sshd"}},
+					Extra:     "found by version\nfound by probe",
+					Detectors: []string{"det1", "det2", "det3"},
+				},
+			},
+		},
+		{
+			desc: "Different inventory not merged",
+			findings: []*detector.Finding{
+				{Adv: adv1, Target: &detector.TargetDetails{Inventory: inv1}, Detectors: []string{"det1"}},
+				{Adv: adv1, Target: &detector.TargetDetails{Inventory: inv2}, Detectors: []string{"det2"}},
+			},
+			want: []*detector.Finding{
+				{Adv: adv1, Target: &detector.TargetDetails{Inventory: inv1}, Detectors: []string{"det1"}},
+				{Adv: adv1, Target: &detector.TargetDetails{Inventory: inv2}, Detectors: []string{"det2"}},
+			},
+		},
+		{
+			desc: "Different advisories not merged",
+			findings: []*detector.Finding{
+				{Adv: adv1, Target: &detector.TargetDetails{Inventory: inv1}, Detectors: []string{"det1"}},
+				{Adv: adv2, Target: &detector.TargetDetails{Inventory: inv1}, Detectors: []string{"det1"}},
+			},
+			want: []*detector.Finding{
+				{Adv: adv1, Target: &detector.TargetDetails{Inventory: inv1}, Detectors: []string{"det1"}},
+				{Adv: adv2, Target: &detector.TargetDetails{Inventory: inv1}, Detectors: []string{"det1"}},
+			},
+		},
+		{
+			desc: "Findings without inventory matched by locations",
+			findings: []*detector.Finding{
+				{Adv: adv1, Target: &detector.TargetDetails{Location: []string{"b", "a"}}, Detectors: []string{"det1"}},
+				{Adv: adv1, Target: &detector.TargetDetails{Location: []string{"a", "b"}}, Detectors: []string{"det2"}},
+				{Adv: adv1, Target: &detector.TargetDetails{Location: []string{"c"}}, Detectors: []string{"det2"}},
+				{Adv: adv1, Detectors: []string{"det1"}},
+				{Adv: adv1, Detectors: []string{"det2"}},
+			},
+			want: []*detector.Finding{
+				{Adv: adv1, Target: &detector.TargetDetails{Location: []string{"b", "a"}}, Detectors: []string{"det1", "det2"}},
+				{Adv: adv1, Target: &detector.TargetDetails{Location: []string{"c"}}, Detectors: []string{"det2"}},
+				{Adv: adv1, Detectors: []string{"det1", "det2"}},
+			},
+		},
+		{
+			desc:     "No findings",
+			findings: []*detector.Finding{},
+			want:     []*detector.Finding{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			inputDetectors := make([][]string, 0, len(tc.findings))
+			for _, f := range tc.findings {
+				inputDetectors = append(inputDetectors, slices.Clone(f.Detectors))
+			}
+			got := detector.Deduplicate(tc.findings)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("detector.Deduplicate(%v): unexpected findings (-want +got):\n%s", tc.findings, diff)
+			}
+			for i, f := range tc.findings {
+				if diff := cmp.Diff(inputDetectors[i], f.Detectors); diff != "" {
+					t.Errorf("detector.Deduplicate(%v) modified the detectors of finding %d (-want +got):\n%s", tc.findings, i, diff)
+				}
+			}
+		})
+	}
+}
@@ -37,6 +37,10 @@ import (
 const (
 	// Name is the unique name of this detector.
 	Name = "govulncheck/binary"
+
+	// OfflineVulnDBPathOption is the name of the Configure option that sets
+	// OfflineVulnDBPath.
+	OfflineVulnDBPathOption = "offline_vuln_db_path"
 )
 
 // Detector is a SCALIBR Detector that uses govulncheck to scan for vulns on Go binaries found
@@ -56,6 +60,24 @@ func (d Detector) Requirements() *plugin.Capabilities {
 	return &plugin.Capabilities{Network: d.OfflineVulnDBPath == "", DirectFS: true}
 }
 
+// Configure sets the detector options. Supported options:
+// * offline_vuln_db_path (string): Path to the offline vuln DB.
+func (d *Detector) Configure(options map[string]any) error {
+	for k, v := range options {
+		switch k {
+		case OfflineVulnDBPathOption:
+			path, ok := v.(string)
+			if !ok {
+				return fmt.Errorf("option %q must be a string, got %T", k, v)
+			}
+			d.OfflineVulnDBPath = path
+		default:
+			return fmt.Errorf("unknown option %q", k)
+		}
+	}
+	return nil
+}
+
 // RequiredExtractors returns the go binary extractor.
 func (Detector) RequiredExtractors() []string {
 	return []string{gobinary.Name}
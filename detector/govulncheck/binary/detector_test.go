@@ -30,6 +30,7 @@ import (
 	"github.com/google/osv-scalibr/extractor/filesystem/language/golang/gobinary"
 	scalibrfs "github.com/google/osv-scalibr/fs"
 	"github.com/google/osv-scalibr/inventoryindex"
+	"github.com/google/osv-scalibr/plugin"
 )
 
 const binaryName = "semaphore-demo-go"
@@ -114,6 +115,19 @@ func TestScanErrorInGovulncheck(t *testing.T) {
 	}
 }
 
+func TestRequirementsWithoutNetwork(t *testing.T) {
+	capabs := &plugin.Capabilities{DirectFS: true}
+	// Without network access the detector can only run with an offline vuln DB.
+	det := &binary.Detector{}
+	if err := plugin.ValidateRequirements(det, capabs); err == nil {
+		t.Errorf("plugin.ValidateRequirements(%v, %v): Expected an error, got none", det, capabs)
+	}
+	det = &binary.Detector{OfflineVulnDBPath: "testdata/vulndb"}
+	if err := plugin.ValidateRequirements(det, capabs); err != nil {
+		t.Errorf("plugin.ValidateRequirements(%v, %v): %v", det, capabs, err)
+	}
+}
+
 func setupInventoryIndex(names []string) *inventoryindex.InventoryIndex {
 	invs := []*extractor.Inventory{}
 	for _, n := range names {
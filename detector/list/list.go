@@ -90,6 +90,11 @@ func FromCapabilities(capabs *plugin.Capabilities) []detector.Detector {
 	return result
 }
 
+// DetectorInfos describes all available detectors, sorted by name.
+func DetectorInfos() []*plugin.Info {
+	return plugin.Infos(All, Default, detectorNames)
+}
+
 // DetectorsFromNames returns a deduplicated list of detectors from a list of names.
 func DetectorsFromNames(names []string) ([]detector.Detector, error) {
 	resultMap := make(map[string]detector.Detector)
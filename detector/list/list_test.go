@@ -42,6 +42,26 @@ func TestFromCapabilities(t *testing.T) {
 	}
 }
 
+func TestDetectorInfos(t *testing.T) {
+	infos := dl.DetectorInfos()
+	if len(infos) != len(dl.All) {
+		t.Fatalf("dl.DetectorInfos(): got %d detectors, want %d", len(infos), len(dl.All))
+	}
+	for _, info := range infos {
+		if info.Name != "govulncheck/binary" {
+			continue
+		}
+		if !info.Requirements.DirectFS {
+			t.Errorf("dl.DetectorInfos(): govulncheck/binary requirements %+v, want DirectFS", info.Requirements)
+		}
+		if diff := cmp.Diff([]string{"all", "govulncheck"}, info.Groups); diff != "" {
+			t.Errorf("dl.DetectorInfos(): govulncheck/binary groups (-want +got):\n%s", diff)
+		}
+		return
+	}
+	t.Errorf("dl.DetectorInfos(): govulncheck/binary not found")
+}
+
 func TestDetectorsFromNames(t *testing.T) {
 	testCases := []struct {
 		desc     string
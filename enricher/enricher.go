@@ -0,0 +1,53 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package enricher provides the interface for enrichment plugins, which add
+// external metadata to the inventory found by the extractors.
+package enricher
+
+import (
+	"context"
+
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/plugin"
+)
+
+// Enricher is the interface for a plugin that runs once on all of the
+// extracted inventory, e.g. to look up the owners of each package in a package
+// catalog. Unlike extractors it doesn't read any files from the scanned
+// system, and unlike detectors it doesn't report findings.
+type Enricher interface {
+	plugin.Plugin
+	// Enrich adds information to the inventory. Implementations may modify
+	// the inventory items in place, e.g. by setting or replacing their
+	// Metadata, but can't add or remove items.
+	Enrich(ctx context.Context, inventory []*extractor.Inventory) error
+}
+
+// Run runs the specified enrichers on the inventory in the order they're
+// listed, so each enricher sees the changes made by the ones before it. An
+// enricher failing doesn't stop the others from running but any changes it
+// made before failing are kept. Returns whether the plugin runs completed
+// successfully.
+func Run(ctx context.Context, enrichers []Enricher, inventory []*extractor.Inventory) ([]*plugin.Status, error) {
+	status := []*plugin.Status{}
+	for _, e := range enrichers {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		err := e.Enrich(ctx, inventory)
+		status = append(status, plugin.StatusFromErr(e, false, err))
+	}
+	return status, nil
+}
@@ -0,0 +1,125 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enricher_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/osv-scalibr/enricher"
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/plugin"
+	fen "github.com/google/osv-scalibr/testing/fakeenricher"
+)
+
+// appendMetadata returns an EnrichFunc that appends s to the string metadata
+// of all inventory items.
+func appendMetadata(s string) fen.EnrichFunc {
+	return func(ctx context.Context, inventory []*extractor.Inventory) error {
+		for _, i := range inventory {
+			m, _ := i.Metadata.(string)
+			i.Metadata = m + s
+		}
+		return nil
+	}
+}
+
+func TestRun(t *testing.T) {
+	success := &plugin.ScanStatus{Status: plugin.ScanStatusSucceeded}
+	failing := func(ctx context.Context, inventory []*extractor.Inventory) error {
+		for _, i := range inventory {
+			i.Metadata = "partial"
+		}
+		return errors.New("catalog unavailable")
+	}
+
+	testCases := []struct {
+		desc         string
+		enrichers    []enricher.Enricher
+		wantMetadata []any
+		wantStatus   []*plugin.Status
+	}{
+		{
+			desc:         "No enrichers",
+			wantMetadata: []any{nil, nil},
+			wantStatus:   []*plugin.Status{},
+		},
+		{
+			desc: "Enrichers run in order",
+			enrichers: []enricher.Enricher{
+				fen.New("first", 1, appendMetadata("a")),
+				fen.New("second", 2, appendMetadata("b")),
+			},
+			wantMetadata: []any{"ab", "ab"},
+			wantStatus: []*plugin.Status{
+				{Name: "first", Version: 1, Status: success},
+				{Name: "second", Version: 2, Status: success},
+			},
+		},
+		{
+			desc: "Failing enricher doesn't stop the others",
+			enrichers: []enricher.Enricher{
+				fen.New("failing", 1, failing),
+				fen.New("second", 2, appendMetadata("b")),
+			},
+			wantMetadata: []any{"partialb", "partialb"},
+			wantStatus: []*plugin.Status{
+				{Name: "failing", Version: 1, Status: &plugin.ScanStatus{
+					Status:        plugin.ScanStatusFailed,
+					FailureReason: "catalog unavailable",
+				}},
+				{Name: "second", Version: 2, Status: success},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			inventory := []*extractor.Inventory{{Name: "software1"}, {Name: "software2"}}
+			gotStatus, err := enricher.Run(context.Background(), tc.enrichers, inventory)
+			if err != nil {
+				t.Fatalf("enricher.Run(%v): %v", tc.enrichers, err)
+			}
+			if diff := cmp.Diff(tc.wantStatus, gotStatus); diff != "" {
+				t.Errorf("enricher.Run(%v): unexpected status (-want +got):\n%s", tc.enrichers, diff)
+			}
+			gotMetadata := []any{}
+			for _, i := range inventory {
+				gotMetadata = append(gotMetadata, i.Metadata)
+			}
+			if diff := cmp.Diff(tc.wantMetadata, gotMetadata); diff != "" {
+				t.Errorf("enricher.Run(%v): unexpected metadata (-want +got):\n%s", tc.enrichers, diff)
+			}
+		})
+	}
+}
+
+func TestRunCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	enrichers := []enricher.Enricher{fen.New("enricher", 1, appendMetadata("a"))}
+	inventory := []*extractor.Inventory{{Name: "software"}}
+
+	_, err := enricher.Run(ctx, enrichers, inventory)
+	if diff := cmp.Diff(context.Canceled, err, cmpopts.EquateErrors()); diff != "" {
+		t.Errorf("enricher.Run(%v) error got diff (-want +got):\n%s", enrichers, diff)
+	}
+	if inventory[0].Metadata != nil {
+		t.Errorf("enricher.Run(%v) ran the enricher after the context was canceled", enrichers)
+	}
+}
@@ -0,0 +1,73 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package list provides the registry of enrichment plugins that can be
+// enabled by name.
+package list
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/google/osv-scalibr/enricher"
+)
+
+// Default enrichers that are recommended to be enabled. SCALIBR doesn't
+// include any enrichers, so by default the inventory is left unchanged.
+var Default []enricher.Enricher = []enricher.Enricher{}
+
+// All enrichers, including the ones added through Register.
+var All []enricher.Enricher = []enricher.Enricher{}
+
+var enricherNames = map[string][]enricher.Enricher{
+	"default": Default,
+}
+
+// Register makes an enricher available under its name, e.g. so that it can be
+// enabled from the command line. Enrichers are usually registered from an init
+// function of the binary that wraps SCALIBR.
+func Register(e enricher.Enricher) error {
+	name := strings.ToLower(e.Name())
+	if _, ok := enricherNames[name]; ok || name == "all" {
+		return fmt.Errorf("there's already an enricher with the name %q", e.Name())
+	}
+	enricherNames[name] = []enricher.Enricher{e}
+	All = append(All, e)
+	return nil
+}
+
+// EnrichersFromNames returns a deduplicated list of enrichers from a list of
+// names. The enrichers are returned in the order they're listed in, which is
+// also the order they run in.
+func EnrichersFromNames(names []string) ([]enricher.Enricher, error) {
+	result := []enricher.Enricher{}
+	seen := make(map[string]bool)
+	for _, n := range names {
+		name := strings.ToLower(n)
+		es, ok := enricherNames[name]
+		if name == "all" {
+			es, ok = All, true
+		}
+		if !ok {
+			return nil, fmt.Errorf("unknown enricher %s", n)
+		}
+		for _, e := range es {
+			if !seen[e.Name()] {
+				seen[e.Name()] = true
+				result = append(result, e)
+			}
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,96 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package list_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	enl "github.com/google/osv-scalibr/enricher/list"
+	fen "github.com/google/osv-scalibr/testing/fakeenricher"
+)
+
+func init() {
+	for _, name := range []string{"owners", "catalog/Teams"} {
+		if err := enl.Register(fen.New(name, 1, nil)); err != nil {
+			panic(err)
+		}
+	}
+}
+
+func TestRegisterDuplicate(t *testing.T) {
+	for _, name := range []string{"owners", "OWNERS", "default", "all"} {
+		if err := enl.Register(fen.New(name, 2, nil)); err == nil {
+			t.Errorf("enl.Register(%q) succeeded, want error", name)
+		}
+	}
+}
+
+func TestEnrichersFromNames(t *testing.T) {
+	testCases := []struct {
+		desc      string
+		names     []string
+		wantNames []string
+		wantErr   error
+	}{
+		{
+			desc:      "Default enrichers",
+			names:     []string{"default"},
+			wantNames: []string{},
+		},
+		{
+			desc:      "Keeps the order of the names",
+			names:     []string{"catalog/Teams", "owners"},
+			wantNames: []string{"catalog/Teams", "owners"},
+		},
+		{
+			desc:      "Case-insensitive",
+			names:     []string{"CATALOG/teams"},
+			wantNames: []string{"catalog/Teams"},
+		},
+		{
+			desc:      "All registered enrichers",
+			names:     []string{"all"},
+			wantNames: []string{"owners", "catalog/Teams"},
+		},
+		{
+			desc:      "Remove duplicates",
+			names:     []string{"owners", "all"},
+			wantNames: []string{"owners", "catalog/Teams"},
+		},
+		{
+			desc:    "Nonexistent plugin",
+			names:   []string{"nonexistent"},
+			wantErr: cmpopts.AnyError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := enl.EnrichersFromNames(tc.names)
+			if diff := cmp.Diff(tc.wantErr, err, cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("enl.EnrichersFromNames(%v) error got diff (-want +got):\n%s", tc.names, diff)
+			}
+			var gotNames []string
+			for _, e := range got {
+				gotNames = append(gotNames, e.Name())
+			}
+			if diff := cmp.Diff(tc.wantNames, gotNames, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("enl.EnrichersFromNames(%v): got diff (-want +got):\n%s", tc.names, diff)
+			}
+		})
+	}
+}
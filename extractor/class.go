@@ -0,0 +1,77 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package extractor
+
+import (
+	"reflect"
+	"strings"
+)
+
+// PackageClass is the category of the packages an extractor finds.
+type PackageClass string
+
+const (
+	// PackageClassOS is the class of OS packages, e.g. dpkg or RPM packages.
+	PackageClassOS PackageClass = "os"
+	// PackageClassLanguage is the class of language packages, e.g. the
+	// dependencies of an application.
+	PackageClassLanguage PackageClass = "language"
+	// PackageClassOther is the class of everything else, e.g. SBOMs, container
+	// images or browser extensions.
+	PackageClassOther PackageClass = "other"
+)
+
+// Classified is an optional interface for extractors whose package class
+// isn't derived correctly from the Go package they're implemented in.
+type Classified interface {
+	// PackageClass returns the class of the packages the extractor finds.
+	PackageClass() PackageClass
+}
+
+// classPackagePrefixes maps the Go package directories of the SCALIBR
+// extractors to the class of the packages they find.
+var classPackagePrefixes = []struct {
+	prefix string
+	class  PackageClass
+}{
+	{"github.com/google/osv-scalibr/extractor/filesystem/os/", PackageClassOS},
+	{"github.com/google/osv-scalibr/extractor/standalone/windows/", PackageClassOS},
+	{"github.com/google/osv-scalibr/extractor/filesystem/language/", PackageClassLanguage},
+	// The OSV lockfile extractors.
+	{"github.com/google/osv-scalibr/extractor/filesystem/osv/", PackageClassLanguage},
+}
+
+// ClassOf returns the class of the packages the extractor finds. Extractors
+// implemented under extractor/filesystem/os and extractor/standalone/windows
+// find OS packages, the ones under extractor/filesystem/language and the OSV
+// lockfile extractors find language packages. All other extractors, including
+// the ones implemented outside of SCALIBR, have the class "other" unless they
+// implement Classified.
+func ClassOf(ex Extractor) PackageClass {
+	if c, ok := ex.(Classified); ok {
+		return c.PackageClass()
+	}
+	t := reflect.TypeOf(ex)
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	pkg := t.PkgPath() + "/"
+	for _, p := range classPackagePrefixes {
+		if strings.HasPrefix(pkg, p.prefix) {
+			return p.class
+		}
+	}
+	return PackageClassOther
+}
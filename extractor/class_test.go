@@ -0,0 +1,80 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package extractor_test
+
+import (
+	"testing"
+
+	"github.com/google/osv-scanner/pkg/lockfile"
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem/language/python/wheelegg"
+	"github.com/google/osv-scalibr/extractor/filesystem/misc/jenkinsplugin"
+	"github.com/google/osv-scalibr/extractor/filesystem/os/dpkg"
+	"github.com/google/osv-scalibr/extractor/filesystem/osv"
+	"github.com/google/osv-scalibr/extractor/standalone/windows/dismpatch"
+	"github.com/google/osv-scalibr/purl"
+)
+
+// classifiedExtractor overrides the package class of a language extractor.
+type classifiedExtractor struct {
+	*wheelegg.Extractor
+}
+
+func (classifiedExtractor) PackageClass() extractor.PackageClass { return extractor.PackageClassOS }
+
+func TestClassOf(t *testing.T) {
+	for _, tc := range []struct {
+		desc string
+		ex   extractor.Extractor
+		want extractor.PackageClass
+	}{
+		{
+			desc: "OS extractor",
+			ex:   dpkg.New(dpkg.DefaultConfig()),
+			want: extractor.PackageClassOS,
+		},
+		{
+			desc: "Windows standalone extractor",
+			ex:   dismpatch.Extractor{},
+			want: extractor.PackageClassOS,
+		},
+		{
+			desc: "Language extractor",
+			ex:   wheelegg.New(wheelegg.DefaultConfig()),
+			want: extractor.PackageClassLanguage,
+		},
+		{
+			desc: "OSV lockfile extractor",
+			ex:   osv.Wrapper{ExtractorName: "rust/cargo", PURLType: purl.TypeCargo, Extractor: lockfile.CargoLockExtractor{}},
+			want: extractor.PackageClassLanguage,
+		},
+		{
+			desc: "Misc extractor",
+			ex:   jenkinsplugin.New(jenkinsplugin.DefaultConfig()),
+			want: extractor.PackageClassOther,
+		},
+		{
+			desc: "Class overridden by the extractor",
+			ex:   classifiedExtractor{wheelegg.New(wheelegg.DefaultConfig())},
+			want: extractor.PackageClassOS,
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			if got := extractor.ClassOf(tc.ex); got != tc.want {
+				t.Errorf("extractor.ClassOf(%s) = %q, want %q", tc.ex.Name(), got, tc.want)
+			}
+		})
+	}
+}
@@ -16,10 +16,20 @@
 package extractor
 
 import (
+	"errors"
+	"fmt"
+	"slices"
+
 	"github.com/google/osv-scalibr/plugin"
 	"github.com/google/osv-scalibr/purl"
 )
 
+// ErrMalformedInput should be wrapped by the errors extractors return for
+// files they can't parse because of their content, e.g. corrupt archives.
+// Such errors are reported as a count of malformed files in the plugin status
+// instead of as extractor failures.
+var ErrMalformedInput = errors.New("malformed input")
+
 // Extractor is the common interface of inventory extraction plugins..
 type Extractor interface {
 	plugin.Plugin
@@ -54,14 +64,141 @@ type Inventory struct {
 	// Source code level package identifiers.
 	SourceCode *SourceCodeIdentifier
 
-	// Paths or source of files related to the package.
+	// Paths or source of files related to the package. Use LocationPaths() to
+	// also get the paths of the LocationDetails.
 	Locations []string
+	// Optional: Structured locations with the kind of evidence the package was
+	// found through and the position inside the file, e.g. the line of a
+	// lockfile entry. Their paths don't need to be repeated in Locations, the
+	// core library adds them after extraction.
+	LocationDetails []*Location
 	// The Extractor that found this software instance. Set by the core library.
 	Extractor Extractor
+	// The path of the scan root the package was found in, e.g. "D:\" in a scan
+	// of all drives. Set by the core library for filesystem extractors. Empty
+	// for virtual scan roots, e.g. container images.
+	ScanRoot string
 	// The additional data found in the package.
 	Metadata any
 
 	Annotations []Annotation
+
+	// Optional: How reliable the extracted information, e.g. the version, is.
+	// Extractors that infer information heuristically, e.g. from file paths,
+	// should set it so that consumers can down-weight their results.
+	Confidence Confidence
+	// Optional: What the extracted information was derived from, e.g.
+	// "version from the installation path".
+	Evidence string
+
+	// Optional: Relationships between this package and other packages found by
+	// the same extractor in the same file, e.g. dependency edges from lockfiles.
+	Relationships []*Relationship
+}
+
+// RelationshipType is the type of a relationship between two inventory items.
+type RelationshipType int64
+
+const (
+	// UnknownRelationship is the default value for the relationship type.
+	UnknownRelationship RelationshipType = iota
+	// DependsOn is set if the source package depends on the target package.
+	DependsOn
+)
+
+// Relationship is a directed edge from an inventory item to another one.
+type Relationship struct {
+	Type RelationshipType
+	// The package URL of the target inventory item. The target needs to have
+	// been found in the same file as the source inventory item.
+	TargetPURL string
+}
+
+// LocationKind is the kind of evidence a package was found through.
+type LocationKind int64
+
+const (
+	// LocationKindUnspecified is the default value for the location kind.
+	LocationKindUnspecified LocationKind = iota
+	// LocationKindManifest is set for packages declared in a manifest or
+	// lockfile, e.g. a package-lock.json entry.
+	LocationKindManifest
+	// LocationKindInstalled is set for packages found through the files or
+	// metadata of their installation, e.g. the dpkg status file.
+	LocationKindInstalled
+	// LocationKindBinary is set for packages embedded in a binary or archive,
+	// e.g. the build info of a Go binary.
+	LocationKindBinary
+)
+
+// String returns a string representation of the location kind.
+func (k LocationKind) String() string {
+	switch k {
+	case LocationKindManifest:
+		return "manifest"
+	case LocationKindInstalled:
+		return "installed"
+	case LocationKindBinary:
+		return "binary"
+	default:
+		return "unspecified"
+	}
+}
+
+// Location is a structured location of an inventory item.
+type Location struct {
+	// The path of the file, in the same format as Inventory.Locations.
+	Path string
+	// Optional: How the package was found at the location.
+	Kind LocationKind
+	// Optional: The 1-based line of the package's entry in the file.
+	Line int
+	// Optional: The byte offset of the package's entry in the file.
+	Offset int64
+}
+
+// String returns the path of the location, followed by the line if it's set.
+func (l *Location) String() string {
+	if l.Line > 0 {
+		return fmt.Sprintf("%s:%d", l.Path, l.Line)
+	}
+	return l.Path
+}
+
+// LocationPaths returns the Locations of the inventory followed by the paths of
+// its LocationDetails that aren't already in Locations.
+func (i *Inventory) LocationPaths() []string {
+	paths := slices.Clone(i.Locations)
+	for _, l := range i.LocationDetails {
+		if !slices.Contains(paths, l.Path) {
+			paths = append(paths, l.Path)
+		}
+	}
+	return paths
+}
+
+// AllLocations returns the structured locations of the inventory. Paths that
+// are only in Locations are returned with an unspecified kind.
+func (i *Inventory) AllLocations() []*Location {
+	var result []*Location
+	for _, p := range i.Locations {
+		found := false
+		for _, l := range i.LocationDetails {
+			if l.Path == p {
+				result = append(result, l)
+				found = true
+			}
+		}
+		if !found {
+			result = append(result, &Location{Path: p})
+		}
+	}
+	for _, l := range i.LocationDetails {
+		if !slices.Contains(i.Locations, l.Path) {
+			result = append(result, l)
+		}
+	}
+	return result
 }
 
 // Annotation are additional information about the inventory.
@@ -79,8 +216,43 @@ const (
 	// InsideCacheDir is set for packages that are found inside a cache directory.
 	// TODO(b/364539671): Annotation for packages inside cache directories.
 	InsideCacheDir
+	// Internal is set for packages developed by the scanning organization,
+	// e.g. by the tag-internal transformer.
+	Internal
 )
 
+// Confidence is how reliable the information of an inventory item is.
+type Confidence int64
+
+const (
+	// ConfidenceUnspecified is the default value. Most extractors read
+	// structured package metadata and don't set a confidence.
+	ConfidenceUnspecified Confidence = iota
+	// ConfidenceLow is set for information inferred heuristically, e.g. a
+	// version parsed from a directory name.
+	ConfidenceLow
+	// ConfidenceMedium is set for information found by searching unstructured
+	// data, e.g. a version string in a binary.
+	ConfidenceMedium
+	// ConfidenceHigh is set for information read from structured package
+	// metadata.
+	ConfidenceHigh
+)
+
+// String returns a string representation of the confidence.
+func (c Confidence) String() string {
+	switch c {
+	case ConfidenceLow:
+		return "low"
+	case ConfidenceMedium:
+		return "medium"
+	case ConfidenceHigh:
+		return "high"
+	default:
+		return "unspecified"
+	}
+}
+
 // Ecosystem returns the Ecosystem of the inventory. For software packages this corresponds
 // to an OSV ecosystem value, e.g. PyPI.
 func (i *Inventory) Ecosystem() (string, error) {
@@ -0,0 +1,70 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filesystem
+
+import (
+	"bufio"
+	"errors"
+	"io"
+)
+
+// bufferedReader buffers the reads of the wrapped file so that extractors
+// doing many small reads, e.g. when streaming a file line by line, cause fewer
+// reads of the underlying file.
+type bufferedReader struct {
+	*bufio.Reader
+	r io.Reader
+}
+
+// newBufferedReader wraps r in a buffer of the given size. Readers
+// implementing io.ReaderAt keep doing so since some extractors rely on random
+// access.
+func newBufferedReader(r io.Reader, size int) io.Reader {
+	br := &bufferedReader{Reader: bufio.NewReaderSize(r, size), r: r}
+	if _, ok := r.(io.ReaderAt); ok {
+		return &bufferedReaderAt{bufferedReader: br}
+	}
+	return br
+}
+
+// Seek seeks the wrapped file if it implements io.Seeker and discards the
+// buffered data.
+func (b *bufferedReader) Seek(offset int64, whence int) (int64, error) {
+	s, ok := b.r.(io.Seeker)
+	if !ok {
+		return 0, errors.New("seek not supported")
+	}
+	if whence == io.SeekCurrent {
+		// The wrapped file is ahead of the reader by the buffered bytes.
+		offset -= int64(b.Buffered())
+	}
+	n, err := s.Seek(offset, whence)
+	if err != nil {
+		return n, err
+	}
+	b.Reset(b.r)
+	return n, nil
+}
+
+// bufferedReaderAt is a bufferedReader for files implementing io.ReaderAt.
+// Random access reads bypass the buffer and don't affect the position of the
+// sequential reads.
+type bufferedReaderAt struct {
+	*bufferedReader
+}
+
+func (b *bufferedReaderAt) ReadAt(p []byte, off int64) (int, error) {
+	return b.r.(io.ReaderAt).ReadAt(p, off)
+}
@@ -0,0 +1,219 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filesystem
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/log"
+)
+
+// ResultCache stores the inventory that extractors found in files, keyed by
+// the content of the files. With a cache, files whose content was already
+// extracted in a previous scan aren't passed to Extract again, which speeds
+// up scans of many similar hosts or images. See Config.ResultCache.
+//
+// The scan stores deep copies of the extracted inventory and reports deep
+// copies of the cached inventory, so modifying reported inventory, e.g. in a
+// transformer, doesn't change the cache or the results of other files.
+// Implementations can thus return the same entry from several Get calls, but
+// mustn't modify entries passed to Put.
+//
+// Implementations need to be safe for concurrent use.
+type ResultCache interface {
+	// Get returns the entry stored for the key, or false if there is none.
+	Get(key *ResultCacheKey) (*ResultCacheEntry, bool)
+	// Put stores the entry for the key.
+	Put(key *ResultCacheKey, entry *ResultCacheEntry) error
+}
+
+// ResultCacheKey identifies the results of an Extract call.
+type ResultCacheKey struct {
+	// The hex-encoded SHA-256 digest of the extracted file.
+	FileSHA256 string
+	// The name and version of the extractor. The version invalidates the
+	// entries of extractors whose behavior changed.
+	ExtractorName    string
+	ExtractorVersion int
+	// The os-release fields passed to the extractor, which some extractors
+	// include in their results.
+	OSRelease map[string]string
+}
+
+// Hash returns the hex-encoded SHA-256 digest of all fields of the key, e.g.
+// for use as a file name.
+func (k *ResultCacheKey) Hash() string {
+	h := sha256.New()
+	fmt.Fprintf(h, "%s\x00%s\x00%d", k.FileSHA256, k.ExtractorName, k.ExtractorVersion)
+	keys := make([]string, 0, len(k.OSRelease))
+	for key := range k.OSRelease {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	for _, key := range keys {
+		fmt.Fprintf(h, "\x00%s=%s", key, k.OSRelease[key])
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// ResultCacheEntry is the result of an Extract call.
+type ResultCacheEntry struct {
+	// The path of the file that was extracted, relative to the scan root. Files
+	// with the same content at other paths reuse the entry with their own path
+	// in the inventory locations.
+	Path      string
+	Inventory []*extractor.Inventory
+}
+
+// cachedResults returns the cached inventory for the key with its locations
+// moved to the file at path, or false if there is none.
+func (wc *walkContext) cachedResults(key *ResultCacheKey, path string) ([]*extractor.Inventory, bool) {
+	entry, ok := wc.resultCache.Get(key)
+	if !ok {
+		return nil, false
+	}
+	return copyInventory(entry.Inventory, func(l string) string { return relocate(l, entry.Path, path) }), true
+}
+
+// cacheResults stores the results of an Extract call on the file at path.
+func (wc *walkContext) cacheResults(key *ResultCacheKey, path string, results []*extractor.Inventory) {
+	inventory := copyInventory(results, func(l string) string { return l })
+	if err := wc.resultCache.Put(key, &ResultCacheEntry{Path: path, Inventory: inventory}); err != nil {
+		log.Debugf("%s: failed to cache the results for %s: %v", key.ExtractorName, path, err)
+	}
+}
+
+// copyInventory returns deep copies of the inventory with the locations mapped
+// by f. Reported inventory is modified, e.g. its locations are converted, so
+// cached results mustn't share any data with the reported ones. Only the
+// extractor is shared.
+func copyInventory(inventory []*extractor.Inventory, f func(string) string) []*extractor.Inventory {
+	res := make([]*extractor.Inventory, 0, len(inventory))
+	for _, inv := range inventory {
+		c := *inv
+		if inv.SourceCode != nil {
+			sc := *inv.SourceCode
+			c.SourceCode = &sc
+		}
+		c.Locations = nil
+		for _, l := range inv.Locations {
+			c.Locations = append(c.Locations, f(l))
+		}
+		c.LocationDetails = nil
+		for _, l := range inv.LocationDetails {
+			lc := *l
+			lc.Path = f(l.Path)
+			c.LocationDetails = append(c.LocationDetails, &lc)
+		}
+		c.Metadata = copyValue(inv.Metadata)
+		c.Annotations = slices.Clone(inv.Annotations)
+		c.Relationships = nil
+		for _, r := range inv.Relationships {
+			rc := *r
+			c.Relationships = append(c.Relationships, &rc)
+		}
+		res = append(res, &c)
+	}
+	return res
+}
+
+// copyValue returns a deep copy of v, e.g. of inventory metadata. Pointers,
+// slices, maps and exported struct fields are copied recursively. Unexported
+// struct fields are copied shallowly, e.g. the location of a time.Time.
+func copyValue(v any) any {
+	if v == nil {
+		return nil
+	}
+	return deepCopy(reflect.ValueOf(v), make(map[uintptr]reflect.Value)).Interface()
+}
+
+// deepCopy returns a deep copy of v. copies maps the pointers copied so far to
+// their copies, so that shared and cyclic pointers stay that way.
+func deepCopy(v reflect.Value, copies map[uintptr]reflect.Value) reflect.Value {
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() {
+			return v
+		}
+		if c, ok := copies[v.Pointer()]; ok && c.Type() == v.Type() {
+			return c
+		}
+		c := reflect.New(v.Type().Elem())
+		copies[v.Pointer()] = c
+		c.Elem().Set(deepCopy(v.Elem(), copies))
+		return c
+	case reflect.Interface:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.New(v.Type()).Elem()
+		c.Set(deepCopy(v.Elem(), copies))
+		return c
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := range v.Len() {
+			c.Index(i).Set(deepCopy(v.Index(i), copies))
+		}
+		return c
+	case reflect.Array:
+		c := reflect.New(v.Type()).Elem()
+		for i := range v.Len() {
+			c.Index(i).Set(deepCopy(v.Index(i), copies))
+		}
+		return c
+	case reflect.Map:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.MakeMapWithSize(v.Type(), v.Len())
+		for it := v.MapRange(); it.Next(); {
+			c.SetMapIndex(it.Key(), deepCopy(it.Value(), copies))
+		}
+		return c
+	case reflect.Struct:
+		c := reflect.New(v.Type()).Elem()
+		c.Set(v)
+		for i := range v.NumField() {
+			if f := c.Field(i); f.CanSet() {
+				f.Set(deepCopy(v.Field(i), copies))
+			}
+		}
+		return c
+	default:
+		return v
+	}
+}
+
+// relocate replaces the path of the cached file in the location l with the
+// path of the file the results are reused for. Locations inside the file, e.g.
+// "app.jar:lib/dep.jar", keep their suffix. Other locations aren't changed.
+func relocate(l, oldPath, newPath string) string {
+	if l == oldPath {
+		return newPath
+	}
+	if rest, ok := strings.CutPrefix(l, oldPath); ok && (rest[0] == ':' || rest[0] == '/') {
+		return newPath + rest
+	}
+	return l
+}
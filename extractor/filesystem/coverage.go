@@ -0,0 +1,112 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filesystem
+
+import (
+	"context"
+	"io/fs"
+	"path/filepath"
+	"time"
+
+	"github.com/google/osv-scalibr/extractor/filesystem/internal"
+	"github.com/google/osv-scalibr/log"
+	"github.com/google/osv-scalibr/stats"
+)
+
+// CoverageReport holds the number of files each extractor requires in a
+// filesystem walk.
+type CoverageReport struct {
+	// The number of files that were passed to the extractors' FileRequired.
+	// Skipped directories and non-regular files aren't counted.
+	TotalFiles int
+	// Extractor name to the number of files it requires. Extractors that don't
+	// require any file are included with a count of 0.
+	RequiredFiles map[string]int
+	// Whether the walk stopped early because MaxInodes was exceeded.
+	Truncated bool
+}
+
+// Coverage walks the scan roots like Run but only calls the extractors'
+// FileRequired on each file and counts the files each of them requires. Files
+// are never extracted, so it's much faster than a scan and useful for
+// evaluating which extractors are relevant for a given filesystem. Extractors
+// that implement FileRequiredWithFS may still peek into the files.
+// The settings of config that affect the walk, e.g. DirsToSkip, FilesToExtract
+// and MaxInodes, are applied. The ones about extraction results are ignored.
+func Coverage(ctx context.Context, config *Config) (*CoverageReport, error) {
+	report := &CoverageReport{RequiredFiles: make(map[string]int)}
+	for _, ex := range config.Extractors {
+		report.RequiredFiles[ex.Name()] = 0
+	}
+
+	c := *config
+	if c.Stats == nil {
+		c.Stats = stats.NoopCollector{}
+	}
+	scanRoots, err := expandAllAbsolutePaths(c.ScanRoots)
+	if err != nil {
+		return nil, err
+	}
+	wc, err := InitWalkContext(ctx, &c, scanRoots)
+	if err != nil {
+		return nil, err
+	}
+	wc.coverageOnly = true
+	wc.requiredFiles = report.RequiredFiles
+
+	start := time.Now()
+	for _, root := range scanRoots {
+		abs := ""
+		if !root.IsVirtual() {
+			if abs, err = filepath.Abs(root.Path); err != nil {
+				return nil, err
+			}
+		}
+		if err := wc.UpdateScanRoot(abs, root.FS); err != nil {
+			return nil, err
+		}
+		log.Infof("Starting coverage walk for root: %v", wc.scanRoot)
+		if len(wc.filesToExtract) > 0 {
+			err = walkIndividualFiles(wc.fs, wc.filesToExtract, wc.handleFile)
+		} else {
+			err = internal.WalkDirUnsorted(wc.fs, ".", wc.handleFile)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if wc.truncated {
+			break
+		}
+	}
+	log.Summaryf("End status: %d inodes visited, %d files checked, %d file opens, %s elapsed",
+		wc.inodesVisited, wc.filesChecked, wc.fileOpens, time.Since(start))
+
+	report.TotalFiles = wc.filesChecked
+	report.Truncated = wc.truncated
+	return report, nil
+}
+
+// countRequired counts the file for each extractor that requires it.
+func (wc *walkContext) countRequired(path string, fileinfo fs.FileInfo) {
+	wc.filesChecked++
+	file := NewPeekableFile(wc.fs, path)
+	for _, ex := range wc.extractorIndex.extractorsFor(path) {
+		if fileRequired(ex, path, fileinfo, file) {
+			wc.requiredFiles[ex.Name()]++
+		}
+	}
+	file.Close()
+	wc.fileOpens += file.opens
+}
@@ -0,0 +1,93 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filesystem_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/osv-scalibr/extractor/filesystem"
+	"github.com/google/osv-scalibr/extractor/filesystem/language/javascript/packagejson"
+	scalibrfs "github.com/google/osv-scalibr/fs"
+	"github.com/google/osv-scalibr/stats"
+	fe "github.com/google/osv-scalibr/testing/fakeextractor"
+	"github.com/google/osv-scalibr/testing/testcollector"
+)
+
+func TestCoverage(t *testing.T) {
+	files := map[string][]byte{
+		"package.json":                  []byte(`{"name": "app", "version": "1.0.0"}`),
+		"node_modules/lib/package.json": []byte(`{"name": "lib", "version": "2.0.0"}`),
+		"skipped/package.json":          []byte(`{"name": "skipped", "version": "3.0.0"}`),
+		"readme.md":                     []byte("# app"),
+	}
+	collector := testcollector.New()
+	cfg := &filesystem.Config{
+		Extractors: []filesystem.Extractor{
+			packagejson.New(packagejson.Config{Stats: collector}),
+			fe.New("ex1", 1, []string{"readme.md"}, nil),
+			fe.New("ex2", 1, []string{"other.txt"}, nil),
+		},
+		ScanRoots:  scalibrfs.MapFSScanRoots(files),
+		DirsToSkip: []string{"skipped"},
+	}
+
+	got, err := filesystem.Coverage(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("Coverage(): %v", err)
+	}
+	want := &filesystem.CoverageReport{
+		TotalFiles: 3,
+		RequiredFiles: map[string]int{
+			packagejson.Name: 2,
+			"ex1":            1,
+			"ex2":            0,
+		},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Coverage() unexpected diff (-want +got):\n%s", diff)
+	}
+	// The required files are only counted, not extracted.
+	var noResult stats.FileExtractedResult
+	if got := collector.FileExtractedResult("package.json"); got != noResult {
+		t.Errorf("Coverage() extracted package.json, got result metric %v", got)
+	}
+}
+
+func TestCoverage_MaxInodesTruncate(t *testing.T) {
+	files := map[string][]byte{
+		"a/package.json": []byte(`{}`),
+		"b/package.json": []byte(`{}`),
+		"c/package.json": []byte(`{}`),
+	}
+	cfg := &filesystem.Config{
+		Extractors:        []filesystem.Extractor{packagejson.New(packagejson.DefaultConfig())},
+		ScanRoots:         scalibrfs.MapFSScanRoots(files),
+		MaxInodes:         3,
+		MaxInodesBehavior: filesystem.MaxInodesTruncate,
+	}
+
+	got, err := filesystem.Coverage(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("Coverage(): %v", err)
+	}
+	if !got.Truncated {
+		t.Errorf("Coverage() didn't mark the report as truncated")
+	}
+	if got.TotalFiles >= len(files) {
+		t.Errorf("Coverage() checked %d files, want fewer than %d", got.TotalFiles, len(files))
+	}
+}
@@ -17,6 +17,7 @@ package filesystem
 import (
 	"errors"
 
+	"github.com/google/osv-scalibr/extractor"
 	"github.com/google/osv-scalibr/stats"
 )
 
@@ -34,6 +35,8 @@ func ExtractorErrorToFileExtractedResult(err error) stats.FileExtractedResult {
 		return stats.FileExtractedResultSuccess
 	} else if errors.Is(err, ErrExtractorMemoryLimitExceeded) {
 		return stats.FileExtractedResultErrorMemoryLimitExceeded
+	} else if errors.Is(err, extractor.ErrMalformedInput) {
+		return stats.FileExtractedResultErrorMalformedInput
 	}
 	return stats.FileExtractedResultErrorUnknown
 }
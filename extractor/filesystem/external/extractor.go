@@ -0,0 +1,375 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package external runs filesystem extractors that are implemented by
+// separate programs, e.g. proprietary extractors that can't be added to
+// SCALIBR. The programs communicate with SCALIBR over their stdin and stdout,
+// see Request and Response for the protocol.
+package external
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem"
+	"github.com/google/osv-scalibr/extractor/filesystem/internal/units"
+	"github.com/google/osv-scalibr/log"
+	"github.com/google/osv-scalibr/plugin"
+	"github.com/google/osv-scalibr/purl"
+	"github.com/google/osv-scalibr/stats"
+)
+
+const (
+	// defaultMaxFileSizeBytes is the maximum size of the files passed to the
+	// external extractor. The whole file is sent in a single message.
+	defaultMaxFileSizeBytes = 100 * units.MiB
+
+	// maxResponseBytes is the maximum length of a response line.
+	maxResponseBytes = 64 * 1024 * 1024
+
+	// closeTimeout is how long Close waits for the process to exit after
+	// closing its stdin before killing it.
+	closeTimeout = 5 * time.Second
+)
+
+// Config is the configuration for the Extractor.
+type Config struct {
+	// The path of the executable implementing the external extractor.
+	Command string
+	// Optional: Arguments passed to the executable.
+	Args []string
+	// Stats is a stats collector for reporting metrics.
+	Stats stats.Collector
+	// MaxFileSizeBytes is the maximum size of the files passed to the external
+	// extractor. If `FileRequired` gets a bigger file, it will return false.
+	MaxFileSizeBytes int64
+}
+
+// DefaultConfig returns the default configuration for the extractor. The
+// command needs to be set before calling New.
+func DefaultConfig() Config {
+	return Config{
+		Stats:            nil,
+		MaxFileSizeBytes: defaultMaxFileSizeBytes,
+	}
+}
+
+// Extractor forwards FileRequired and Extract calls to an external extractor
+// process.
+type Extractor struct {
+	stats            stats.Collector
+	maxFileSizeBytes int64
+	info             *Info
+	fileNames        map[string]bool
+	fileExtensions   map[string]bool
+
+	cmd *exec.Cmd
+	// Guards the fields below, only one request is sent at a time.
+	mu     sync.Mutex
+	stdin  io.WriteCloser
+	stdout *bufio.Scanner
+	// Set once the process can no longer be used, e.g. because it exited.
+	err error
+}
+
+// New starts the external extractor process and asks it for its name and
+// version. Close needs to be called to stop the process once the scan is done.
+func New(cfg Config) (*Extractor, error) {
+	if cfg.Command == "" {
+		return nil, errors.New("no command set for the external extractor")
+	}
+	cmd := exec.Command(cfg.Command, cfg.Args...)
+	cmd.Stderr = os.Stderr
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("failed to start external extractor %q: %w", cfg.Command, err)
+	}
+	s := bufio.NewScanner(stdout)
+	s.Buffer(nil, maxResponseBytes)
+	e := &Extractor{
+		stats:            cfg.Stats,
+		maxFileSizeBytes: cfg.MaxFileSizeBytes,
+		cmd:              cmd,
+		stdin:            stdin,
+		stdout:           s,
+	}
+
+	resp, err := e.call(context.Background(), &Request{Method: MethodInfo})
+	if err == nil && (resp.Info == nil || resp.Info.Name == "") {
+		err = errors.New("no extractor name returned")
+	}
+	if err != nil {
+		cmd.Process.Kill()
+		e.Close()
+		return nil, fmt.Errorf("external extractor %q: %w", cfg.Command, err)
+	}
+	e.info = resp.Info
+	e.fileNames = lowerSet(resp.Info.FileNames)
+	e.fileExtensions = lowerSet(resp.Info.FileExtensions)
+	return e, nil
+}
+
+func lowerSet(values []string) map[string]bool {
+	res := make(map[string]bool, len(values))
+	for _, v := range values {
+		res[strings.ToLower(v)] = true
+	}
+	return res
+}
+
+// Close stops the external extractor process by closing its stdin and waits
+// for it to exit. The process is killed if it doesn't exit in time.
+func (e *Extractor) Close() error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.err == nil {
+		e.err = errors.New("external extractor closed")
+	}
+	e.stdin.Close()
+	done := make(chan error, 1)
+	go func() { done <- e.cmd.Wait() }()
+	select {
+	case err := <-done:
+		return err
+	case <-time.After(closeTimeout):
+		e.cmd.Process.Kill()
+		return <-done
+	}
+}
+
+// Config returns the configuration of the extractor.
+func (e *Extractor) Config() Config {
+	return Config{
+		Command:          e.cmd.Path,
+		Args:             slices.Clone(e.cmd.Args[1:]),
+		Stats:            e.stats,
+		MaxFileSizeBytes: e.maxFileSizeBytes,
+	}
+}
+
+// Name of the extractor, as returned by the external extractor.
+func (e *Extractor) Name() string { return e.info.Name }
+
+// Version of the extractor, as returned by the external extractor.
+func (e *Extractor) Version() int { return e.info.Version }
+
+// Requirements of the extractor.
+func (e *Extractor) Requirements() *plugin.Capabilities { return &plugin.Capabilities{} }
+
+// FileRequired asks the external extractor whether the specified file is
+// required. Files that don't match the file names and extensions declared by
+// the external extractor are skipped without asking it.
+func (e *Extractor) FileRequired(path string, fileinfo fs.FileInfo) bool {
+	if len(e.fileNames) > 0 || len(e.fileExtensions) > 0 {
+		base := strings.ToLower(filepath.Base(path))
+		if !e.fileNames[base] && !e.fileExtensions[filepath.Ext(base)] {
+			return false
+		}
+	}
+
+	resp, err := e.call(context.Background(), &Request{
+		Method: MethodFileRequired,
+		Path:   filepath.ToSlash(path),
+		Size:   fileinfo.Size(),
+		Mode:   fileinfo.Mode(),
+	})
+	if err != nil {
+		log.Debugf("%s: FileRequired(%s): %v", e.Name(), path, err)
+		return false
+	}
+	if !resp.Required {
+		return false
+	}
+
+	if e.maxFileSizeBytes > 0 && fileinfo.Size() > e.maxFileSizeBytes {
+		e.reportFileRequired(path, fileinfo.Size(), stats.FileRequiredResultSizeLimitExceeded)
+		return false
+	}
+
+	e.reportFileRequired(path, fileinfo.Size(), stats.FileRequiredResultOK)
+	return true
+}
+
+func (e *Extractor) reportFileRequired(path string, fileSizeBytes int64, result stats.FileRequiredResult) {
+	if e.stats == nil {
+		return
+	}
+	e.stats.AfterFileRequired(e.Name(), &stats.FileRequiredStats{
+		Path:          path,
+		Result:        result,
+		FileSizeBytes: fileSizeBytes,
+	})
+}
+
+// Extract sends the file passed through the scan input to the external
+// extractor and returns the inventory it found.
+func (e *Extractor) Extract(ctx context.Context, input *filesystem.ScanInput) ([]*extractor.Inventory, error) {
+	inventory, err := e.extractFromInput(ctx, input)
+	if e.stats != nil {
+		var fileSizeBytes int64
+		if input.Info != nil {
+			fileSizeBytes = input.Info.Size()
+		}
+		e.stats.AfterFileExtracted(e.Name(), &stats.FileExtractedStats{
+			Path:          input.Path,
+			Result:        filesystem.ExtractorErrorToFileExtractedResult(err),
+			FileSizeBytes: fileSizeBytes,
+		})
+	}
+	return inventory, err
+}
+
+func (e *Extractor) extractFromInput(ctx context.Context, input *filesystem.ScanInput) ([]*extractor.Inventory, error) {
+	content, err := io.ReadAll(input.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %q: %w", input.Path, err)
+	}
+	req := &Request{
+		Method:    MethodExtract,
+		Path:      filepath.ToSlash(input.Path),
+		Root:      input.Root,
+		Content:   content,
+		OSRelease: input.OSRelease,
+	}
+	if input.Info != nil {
+		req.Size = input.Info.Size()
+		req.Mode = input.Info.Mode()
+	}
+	resp, err := e.call(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to extract %q: %w", e.Name(), input.Path, err)
+	}
+
+	var inventory []*extractor.Inventory
+	for _, i := range resp.Inventory {
+		m := &Metadata{CPEs: i.CPEs, Ecosystem: i.Ecosystem}
+		if i.PURL != "" {
+			p, err := purl.FromString(i.PURL)
+			if err != nil {
+				return nil, fmt.Errorf("%s: invalid PURL %q for %q: %w", e.Name(), i.PURL, input.Path, err)
+			}
+			m.PURL = &p
+		}
+		locations := i.Locations
+		if len(locations) == 0 {
+			locations = []string{input.Path}
+		}
+		inventory = append(inventory, &extractor.Inventory{
+			Name:      i.Name,
+			Version:   i.Version,
+			Locations: locations,
+			Metadata:  m,
+		})
+	}
+	return inventory, nil
+}
+
+// call sends the request to the external extractor and returns its response.
+// If the context is cancelled before the response arrives, the process is
+// killed since it can't be reused.
+func (e *Extractor) call(ctx context.Context, req *Request) (*Response, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.err != nil {
+		return nil, e.err
+	}
+
+	type result struct {
+		resp *Response
+		err  error
+	}
+	done := make(chan result, 1)
+	go func() {
+		resp, err := e.roundTrip(req)
+		done <- result{resp, err}
+	}()
+	var r result
+	select {
+	case r = <-done:
+	case <-ctx.Done():
+		e.err = fmt.Errorf("external extractor killed: %w", ctx.Err())
+		e.cmd.Process.Kill()
+		<-done
+		return nil, e.err
+	}
+	if r.err != nil {
+		// The process is out of sync with the requests or exited.
+		e.err = r.err
+		return nil, r.err
+	}
+	if r.resp.Error != "" {
+		if r.resp.Malformed {
+			return nil, fmt.Errorf("%w: %s", extractor.ErrMalformedInput, r.resp.Error)
+		}
+		return nil, errors.New(r.resp.Error)
+	}
+	return r.resp, nil
+}
+
+func (e *Extractor) roundTrip(req *Request) (*Response, error) {
+	data, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := e.stdin.Write(append(data, '\n')); err != nil {
+		return nil, fmt.Errorf("failed to send %s request: %w", req.Method, err)
+	}
+	if !e.stdout.Scan() {
+		err := e.stdout.Err()
+		if err == nil {
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, fmt.Errorf("failed to read %s response: %w", req.Method, err)
+	}
+	resp := &Response{}
+	if err := json.Unmarshal(e.stdout.Bytes(), resp); err != nil {
+		return nil, fmt.Errorf("invalid %s response: %w", req.Method, err)
+	}
+	return resp, nil
+}
+
+// ToPURL returns the PURL reported by the external extractor.
+func (e *Extractor) ToPURL(i *extractor.Inventory) (*purl.PackageURL, error) {
+	return i.Metadata.(*Metadata).PURL, nil
+}
+
+// ToCPEs returns the CPEs reported by the external extractor.
+func (e *Extractor) ToCPEs(i *extractor.Inventory) ([]string, error) {
+	return i.Metadata.(*Metadata).CPEs, nil
+}
+
+// Ecosystem returns the OSV Ecosystem reported by the external extractor.
+func (e *Extractor) Ecosystem(i *extractor.Inventory) (string, error) {
+	return i.Metadata.(*Metadata).Ecosystem, nil
+}
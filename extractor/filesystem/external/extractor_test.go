@@ -0,0 +1,224 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package external_test
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem"
+	"github.com/google/osv-scalibr/extractor/filesystem/external"
+	"github.com/google/osv-scalibr/extractor/filesystem/language/erlang/mixlock"
+	"github.com/google/osv-scalibr/purl"
+	"github.com/google/osv-scalibr/testing/fakefs"
+)
+
+// helperEnv makes the test binary act as an external extractor instead of
+// running the tests.
+const helperEnv = "EXTERNAL_EXTRACTOR_TEST_HELPER"
+
+func TestMain(m *testing.M) {
+	switch os.Getenv(helperEnv) {
+	case "":
+		os.Exit(m.Run())
+	case "mixlock":
+		if err := external.Serve(context.Background(), mixlock.New(mixlock.DefaultConfig()), os.Stdin, os.Stdout); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+	case "invalid":
+		fmt.Println("not json")
+	case "hang":
+		// Answer the info request, then never respond again.
+		s := bufio.NewScanner(os.Stdin)
+		s.Scan()
+		fmt.Println(`{"info":{"name":"test/hang"}}`)
+		for s.Scan() {
+		}
+	}
+	os.Exit(0)
+}
+
+func newExtractor(t *testing.T, helper string) (*external.Extractor, error) {
+	t.Helper()
+	t.Setenv(helperEnv, helper)
+	cfg := external.DefaultConfig()
+	cfg.Command = os.Args[0]
+	e, err := external.New(cfg)
+	if err == nil {
+		t.Cleanup(func() { e.Close() })
+	}
+	return e, err
+}
+
+func TestNew(t *testing.T) {
+	e, err := newExtractor(t, "mixlock")
+	if err != nil {
+		t.Fatalf("external.New(): %v", err)
+	}
+	if got, want := e.Name(), mixlock.Name; got != want {
+		t.Errorf("Name(): got %q, want %q", got, want)
+	}
+	if got, want := e.Version(), 0; got != want {
+		t.Errorf("Version(): got %d, want %d", got, want)
+	}
+}
+
+func TestNew_InvalidInfo(t *testing.T) {
+	if _, err := newExtractor(t, "invalid"); err == nil {
+		t.Error("external.New() succeeded for an extractor with an invalid info response, want error")
+	}
+}
+
+func TestNew_NoCommand(t *testing.T) {
+	if _, err := external.New(external.DefaultConfig()); err == nil {
+		t.Error("external.New() succeeded without a command, want error")
+	}
+}
+
+func TestFileRequired(t *testing.T) {
+	e, err := newExtractor(t, "mixlock")
+	if err != nil {
+		t.Fatalf("external.New(): %v", err)
+	}
+	tests := []struct {
+		path string
+		want bool
+	}{
+		{path: "project/mix.lock", want: true},
+		{path: "project/MIX.LOCK", want: false},
+		{path: "project/mix.exs", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			got := e.FileRequired(tt.path, fakefs.FakeFileInfo{FileName: "mix.lock", FileMode: fs.ModePerm, FileSize: 100})
+			if got != tt.want {
+				t.Errorf("FileRequired(%s): got %v, want %v", tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExtract(t *testing.T) {
+	e, err := newExtractor(t, "mixlock")
+	if err != nil {
+		t.Fatalf("external.New(): %v", err)
+	}
+	const path = "project/mix.lock"
+	tests := []struct {
+		name          string
+		file          string
+		wantInventory []*extractor.Inventory
+		wantErr       error
+	}{
+		{
+			name: "lockfile",
+			file: "testdata/mix.lock",
+			wantInventory: []*extractor.Inventory{
+				{
+					Name:    "jason",
+					Version: "1.4.1",
+					Metadata: &external.Metadata{
+						PURL:      &purl.PackageURL{Type: purl.TypeHex, Name: "jason", Version: "1.4.1"},
+						CPEs:      []string{},
+						Ecosystem: "Hex",
+					},
+					Locations: []string{path},
+				},
+				{
+					Name:    "plug",
+					Version: "1.15.3",
+					Metadata: &external.Metadata{
+						PURL:      &purl.PackageURL{Type: purl.TypeHex, Name: "plug", Version: "1.15.3"},
+						CPEs:      []string{},
+						Ecosystem: "Hex",
+					},
+					Locations: []string{path},
+				},
+			},
+		},
+		{
+			name:    "malformed lockfile",
+			file:    "testdata/invalid.lock",
+			wantErr: extractor.ErrMalformedInput,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := os.Open(tt.file)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer r.Close()
+			info, err := r.Stat()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := e.Extract(context.Background(), &filesystem.ScanInput{Path: path, Reader: r, Info: info})
+			if !cmp.Equal(err, tt.wantErr, cmpopts.EquateErrors()) {
+				t.Fatalf("Extract(%s) error: got %v, want %v", tt.file, err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.wantInventory, got, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("Extract(%s) (-want +got):\n%s", tt.file, diff)
+			}
+		})
+	}
+}
+
+func TestExtract_ContextCancelled(t *testing.T) {
+	e, err := newExtractor(t, "hang")
+	if err != nil {
+		t.Fatalf("external.New(): %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	r, err := os.Open("testdata/mix.lock")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	_, err = e.Extract(ctx, &filesystem.ScanInput{Path: "mix.lock", Reader: r})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Extract() with cancelled context: got error %v, want %v", err, context.Canceled)
+	}
+	// The killed process isn't asked again.
+	if e.FileRequired("mix.lock", fakefs.FakeFileInfo{FileName: "mix.lock"}) {
+		t.Error("FileRequired() after the process was killed: got true, want false")
+	}
+}
+
+func TestToPURL(t *testing.T) {
+	e := &external.Extractor{}
+	p := &purl.PackageURL{Type: purl.TypeGeneric, Namespace: "acme", Name: "tool", Version: "1.2.3"}
+	i := &extractor.Inventory{Name: "tool", Version: "1.2.3", Metadata: &external.Metadata{PURL: p}}
+	got, err := e.ToPURL(i)
+	if err != nil {
+		t.Fatalf("ToPURL(%v): %v", i, err)
+	}
+	if diff := cmp.Diff(p, got); diff != "" {
+		t.Errorf("ToPURL(%v) (-want +got):\n%s", i, diff)
+	}
+}
@@ -0,0 +1,106 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package external
+
+import (
+	"io/fs"
+)
+
+// The protocol between SCALIBR and an external extractor is a sequence of
+// newline-delimited JSON messages over the stdin and stdout of the extractor's
+// process. SCALIBR starts the process once per scan, writes a Request to its
+// stdin and reads exactly one Response from its stdout for each request.
+// Requests are sent one at a time. The process should only log to stderr,
+// which is forwarded to SCALIBR's stderr, and exit once its stdin is closed.
+//
+// The first request is always MethodInfo. It's followed by a MethodFileRequired
+// request for each file of the filesystem walk and a MethodExtract request for
+// each file the extractor required. Extractors written in Go can implement the
+// protocol by passing a filesystem.Extractor to Serve.
+const (
+	// MethodInfo asks for the name, version and file filters of the extractor,
+	// returned in Response.Info.
+	MethodInfo = "info"
+	// MethodFileRequired asks whether the extractor needs to extract the file
+	// described by the request, returned in Response.Required.
+	MethodFileRequired = "file_required"
+	// MethodExtract passes the content of a required file to the extractor,
+	// which returns the inventory found in it in Response.Inventory.
+	MethodExtract = "extract"
+)
+
+// Request is a message from SCALIBR to the external extractor.
+type Request struct {
+	// One of the Method* constants.
+	Method string `json:"method"`
+	// The path of the file relative to the scan root, with forward slashes.
+	// Set for MethodFileRequired and MethodExtract.
+	Path string `json:"path,omitempty"`
+	// The size of the file in bytes. Set for MethodFileRequired and MethodExtract.
+	Size int64 `json:"size,omitempty"`
+	// The mode and permission bits of the file. Set for MethodFileRequired and
+	// MethodExtract.
+	Mode fs.FileMode `json:"mode,omitempty"`
+	// The scan root the path is relative to. Set for MethodExtract.
+	Root string `json:"root,omitempty"`
+	// The content of the file, base64-encoded in the JSON message. Set for
+	// MethodExtract.
+	Content []byte `json:"content,omitempty"`
+	// The fields of the os-release file of the scanned system, if known. Set
+	// for MethodExtract.
+	OSRelease map[string]string `json:"os_release,omitempty"`
+}
+
+// Response is a message from the external extractor to SCALIBR.
+type Response struct {
+	// Set if the request failed.
+	Error string `json:"error,omitempty"`
+	// Set together with Error if the file couldn't be extracted because of its
+	// content, e.g. a corrupt archive. Such files are counted as malformed in
+	// the plugin status instead of failing the extractor.
+	Malformed bool `json:"malformed,omitempty"`
+	// The response to MethodInfo.
+	Info *Info `json:"info,omitempty"`
+	// The response to MethodFileRequired.
+	Required bool `json:"required,omitempty"`
+	// The response to MethodExtract.
+	Inventory []*Inventory `json:"inventory,omitempty"`
+}
+
+// Info describes the external extractor.
+type Info struct {
+	// The unique name of the extractor, used to enable it, e.g. "acme/manifest".
+	Name string `json:"name"`
+	Version int `json:"version"`
+	// Optional: The base names and extensions (including the leading dot) of
+	// the files the extractor can require. If any are set, MethodFileRequired
+	// is only sent for files matching one of them, which saves a round trip
+	// for most files of the walk. The comparison is case-insensitive.
+	FileNames      []string `json:"file_names,omitempty"`
+	FileExtensions []string `json:"file_extensions,omitempty"`
+}
+
+// Inventory is a software package found by the external extractor.
+type Inventory struct {
+	Name    string `json:"name"`
+	Version string `json:"version,omitempty"`
+	// The paths of the files the package was found in, relative to the scan
+	// root. Defaults to the path of the extracted file.
+	Locations []string `json:"locations,omitempty"`
+	// The package URL, e.g. "pkg:generic/acme/tool@1.2.3".
+	PURL      string   `json:"purl,omitempty"`
+	CPEs      []string `json:"cpes,omitempty"`
+	Ecosystem string   `json:"ecosystem,omitempty"`
+}
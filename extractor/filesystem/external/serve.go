@@ -0,0 +1,135 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package external
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/fs"
+	"path"
+	"time"
+
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem"
+)
+
+// Serve implements the external extractor protocol for the given extractor:
+// It reads requests from r and writes the responses to w until r is closed.
+// Extractors written in Go can be turned into an external extractor with a
+// main function like:
+// ```
+// external.Serve(context.Background(), myextractor.New(), os.Stdin, os.Stdout)
+// ```
+// The extractor's RequiredFileNames and RequiredFileExtensions are passed to
+// SCALIBR if it implements filesystem.FileNameFilter or
+// filesystem.FileExtensionFilter. The extractor gets no FS in its ScanInput.
+func Serve(ctx context.Context, ex filesystem.Extractor, r io.Reader, w io.Writer) error {
+	s := bufio.NewScanner(r)
+	s.Buffer(nil, int(maxRequestBytes))
+	enc := json.NewEncoder(w)
+	for s.Scan() {
+		req := &Request{}
+		var resp *Response
+		if err := json.Unmarshal(s.Bytes(), req); err != nil {
+			resp = &Response{Error: "invalid request: " + err.Error()}
+		} else {
+			resp = serveRequest(ctx, ex, req)
+		}
+		if err := enc.Encode(resp); err != nil {
+			return err
+		}
+	}
+	return s.Err()
+}
+
+// maxRequestBytes is the maximum length of a request line. It fits the
+// base64-encoded content of the largest files sent by default.
+const maxRequestBytes = 2 * defaultMaxFileSizeBytes
+
+func serveRequest(ctx context.Context, ex filesystem.Extractor, req *Request) *Response {
+	switch req.Method {
+	case MethodInfo:
+		info := &Info{Name: ex.Name(), Version: ex.Version()}
+		if f, ok := ex.(filesystem.FileNameFilter); ok {
+			info.FileNames = f.RequiredFileNames()
+		}
+		if f, ok := ex.(filesystem.FileExtensionFilter); ok {
+			info.FileExtensions = f.RequiredFileExtensions()
+		}
+		return &Response{Info: info}
+	case MethodFileRequired:
+		return &Response{Required: ex.FileRequired(req.Path, newFileInfo(req))}
+	case MethodExtract:
+		inv, err := ex.Extract(ctx, &filesystem.ScanInput{
+			Path:      req.Path,
+			Root:      req.Root,
+			Info:      newFileInfo(req),
+			Reader:    bytes.NewReader(req.Content),
+			OSRelease: req.OSRelease,
+		})
+		if err != nil {
+			return &Response{Error: err.Error(), Malformed: errors.Is(err, extractor.ErrMalformedInput)}
+		}
+		return &Response{Inventory: toWireInventory(ex, inv)}
+	default:
+		return &Response{Error: "unknown method: " + req.Method}
+	}
+}
+
+func toWireInventory(ex filesystem.Extractor, inventory []*extractor.Inventory) []*Inventory {
+	res := make([]*Inventory, 0, len(inventory))
+	for _, i := range inventory {
+		w := &Inventory{
+			Name:      i.Name,
+			Version:   i.Version,
+			Locations: i.Locations,
+		}
+		if p, err := ex.ToPURL(i); err == nil && p != nil {
+			w.PURL = p.String()
+		}
+		if cpes, err := ex.ToCPEs(i); err == nil {
+			w.CPEs = cpes
+		}
+		if eco, err := ex.Ecosystem(i); err == nil {
+			w.Ecosystem = eco
+		}
+		res = append(res, w)
+	}
+	return res
+}
+
+// fileInfo describes the file of a request.
+type fileInfo struct {
+	name string
+	size int64
+	mode fs.FileMode
+}
+
+func newFileInfo(req *Request) fileInfo {
+	return fileInfo{name: path.Base(req.Path), size: req.Size, mode: req.Mode}
+}
+
+func (i fileInfo) Name() string       { return i.name }
+func (i fileInfo) Size() int64        { return i.size }
+func (i fileInfo) Mode() fs.FileMode  { return i.mode }
+func (i fileInfo) ModTime() time.Time { return time.Time{} }
+func (i fileInfo) IsDir() bool        { return i.mode.IsDir() }
+func (i fileInfo) Sys() any           { return nil }
+
+var _ fs.FileInfo = fileInfo{}
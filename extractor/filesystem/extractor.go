@@ -17,17 +17,22 @@ package filesystem
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"io/fs"
 	"os"
 	"path/filepath"
 	"regexp"
+	"regexp/syntax"
+	"slices"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/google/osv-scalibr/extractor"
 	"github.com/google/osv-scalibr/extractor/filesystem/internal"
+	"github.com/google/osv-scalibr/extractor/filesystem/os/osrelease"
 	scalibrfs "github.com/google/osv-scalibr/fs"
 	"github.com/google/osv-scalibr/log"
 	"github.com/google/osv-scalibr/plugin"
@@ -38,6 +43,9 @@ var (
 	// ErrNotRelativeToScanRoots is returned when one of the file or directory to be retrieved or
 	// skipped is not relative to any of the scan roots.
 	ErrNotRelativeToScanRoots = fmt.Errorf("path not relative to any of the scan roots")
+	// ErrExtractorsFailed is returned in strict mode if extractors failed on
+	// some of the files. See Config.Strict.
+	ErrExtractorsFailed = errors.New("extractors failed")
 )
 
 // Extractor is the filesystem-based inventory extraction plugin, used to extract inventory data
@@ -65,6 +73,36 @@ type ScanInput struct {
 	// A reader for accessing contents of the file.
 	// Note that the file is closed by the core library, not the plugin.
 	Reader io.Reader
+	// Optional: The fields of the os-release file of the scanned system, e.g.
+	// "ID" and "VERSION_ID". Set by the core library, which reads the file once
+	// per scan root instead of once per extracted file. Extractors should use
+	// GetOSRelease to fall back to reading it from FS if it's not set.
+	OSRelease map[string]string
+}
+
+// GetOSRelease returns the fields of the os-release file of the scanned
+// system, e.g. to include the distro version in the OSV ecosystem.
+func (i *ScanInput) GetOSRelease() (map[string]string, error) {
+	if i.OSRelease != nil {
+		return i.OSRelease, nil
+	}
+	return osrelease.GetOSRelease(i.FS)
+}
+
+// SectionReader returns a reader for the n bytes of the file starting at
+// offset off, e.g. to read an SBOM embedded in a section of a binary without
+// reading the whole file. Reads from it don't affect the position of Reader.
+//
+// Returns nil if the file doesn't support random access, i.e. Reader is not an
+// io.ReaderAt. This is the case for some virtual filesystems and for files
+// nested inside archives. Extractors need to fall back to reading Reader
+// sequentially in that case.
+func (i *ScanInput) SectionReader(off int64, n int64) *io.SectionReader {
+	ra, ok := i.Reader.(io.ReaderAt)
+	if !ok {
+		return nil
+	}
+	return io.NewSectionReader(ra, off, n)
 }
 
 // Config stores the config settings for an extraction run.
@@ -76,24 +114,147 @@ type Config struct {
 	// Note that these are not relative to the ScanRoots and thus need to be
 	// sub-directories of one of the ScanRoots.
 	FilesToExtract []string
+	// Optional: Like FilesToExtract but relative to each of the ScanRoots, e.g.
+	// "requirements.txt" is extracted from <root>/requirements.txt. Files that
+	// don't exist in a scan root are skipped. Can be combined with
+	// FilesToExtract.
+	FilesToExtractRelative []string
 	// Optional: Directories that the file system walk should ignore.
 	// Note that these are not relative to the ScanRoots and thus need to be
 	// sub-directories of one of the ScanRoots.
-	// TODO(b/279413691): Also skip local paths, e.g. "Skip all .git dirs"
+	// Entries containing "*" are glob patterns: "*" matches within a single
+	// directory name and "**" matches any number of directories. Relative
+	// patterns such as "**/node_modules" are matched against the path relative
+	// to each scan root, absolute ones need to start with one of the ScanRoots.
 	DirsToSkip []string
-	// Optional: If the regex matches a directory, it will be skipped.
+	// Optional: If the regex matches a directory, it will be skipped. A directory
+	// is skipped if it matches either DirsToSkip or SkipDirRegex, neither
+	// takes precedence over the other.
 	SkipDirRegex *regexp.Regexp
+	// Optional: If set, only files in directories that match the regex (or that
+	// are inside a matching directory) are extracted. Like SkipDirRegex it's
+	// matched against the slash-separated path relative to the scan root, e.g.
+	// "src/app".
+	// Directories that don't match are still walked since they might contain a
+	// matching directory, e.g. "src" is walked to reach "src/app". If the regex
+	// is anchored with "^" and starts with a literal such as "^src/app", the
+	// walk skips the directories that can't lead to a match. Otherwise the
+	// whole filesystem is still walked, even though fewer files are extracted.
+	// The skip rules take precedence: A directory matched by DirsToSkip or
+	// SkipDirRegex is skipped even if it matches IncludeDirRegex.
+	IncludeDirRegex *regexp.Regexp
 	// Optional: stats allows to enter a metric hook. If left nil, no metrics will be recorded.
 	Stats stats.Collector
 	// Optional: Whether to read symlinks.
 	ReadSymlinks bool
 	// Optional: Limit for visited inodes. If 0, no limit is applied.
 	MaxInodes int
+	// Optional: What to do once MaxInodes is exceeded. By default the walk is
+	// aborted with an error.
+	MaxInodesBehavior MaxInodesBehavior
+	// Optional: Limit for the inventory items reported by each extractor. Once an
+	// extractor reaches it, its further results are dropped and counted in its
+	// plugin status, while the other extractors keep running. If 0, no limit is
+	// applied.
+	MaxInventoryPerExtractor int
+	// Optional: Limits for individual extractors, keyed by extractor name. Useful
+	// for keeping a slow extractor enabled while bounding its runtime more
+	// tightly than the others'.
+	ExtractorOverrides map[string]ExtractorOverride
 	// Optional: By default, inventories stores a path relative to the scan root. If StoreAbsolutePath
 	// is set, the absolute path is stored instead.
 	StoreAbsolutePath bool
+	// Optional: If set, this prefix is stripped from the reported inventory locations,
+	// e.g. "/home/build/123" turns "/home/build/123/app" into "app". Applied after
+	// the decision whether to store absolute paths.
+	LocationPrefixTrim string
+	// Optional: If true, the reported inventory locations use forward slashes as
+	// path separators regardless of the host OS, e.g. "C:/app/go.mod" instead of
+	// "C:\app\go.mod" on Windows. Applied after LocationPrefixTrim. By default
+	// the locations use the separator of the host OS.
+	PosixPaths bool
+	// Optional: If true, files that no extractor requires are counted by file
+	// extension and the resulting histogram is logged and reported to Stats
+	// after the walk. Useful for finding gaps in extractor coverage.
+	ReportUnmatched bool
+	// Optional: If true, the periodic status lines aren't logged during the walk.
+	// The final summary is still logged.
+	Quiet bool
+	// Optional: The os-release fields passed to the extractors, e.g.
+	// {"ID": "debian", "VERSION_ID": "12"}. Useful if the scanned filesystem
+	// doesn't contain the os-release file. By default the file is read from
+	// each scan root.
+	OSRelease map[string]string
+	// Optional: If true, the FileRequired decision of every extractor for every
+	// file is logged at trace level. Useful for finding out why a file wasn't
+	// extracted, but extremely high-volume. Extractors skipped because of their
+	// FileExtensionFilter or FileNameFilter declarations aren't logged.
+	TraceFileRequired bool
+	// Optional: If set, files last modified before this time are not extracted.
+	// Directories are still traversed regardless of their modification time.
+	// Useful for incremental scans together with PreviousInventory.
+	Since time.Time
+	// Optional: The inventory of a previous scan. Entries found in files that
+	// were skipped because they're older than Since are added to the results.
+	// Entries are matched to files by their first location, which is expected
+	// to use the same StoreAbsolutePath, LocationPrefixTrim and PosixPaths
+	// settings.
+	PreviousInventory []*extractor.Inventory
+	// Optional: If true, Run and RunFS return an ErrExtractorsFailed error if an
+	// extractor failed on any of the files. The walk is still completed and the
+	// inventory and plugin statuses are returned alongside the error, so both
+	// walk errors and extractor errors are reported through the returned error.
+	// Malformed files (extractor.ErrMalformedInput) aren't extractor errors.
+	// By default, extractor errors are only reported in the plugin statuses.
+	Strict bool
+	// Optional: The maximum number of bytes read per second across the whole
+	// walk, including all scan roots. Each file open is also charged a few
+	// kilobytes so that walks over many small files are paced too. Useful to
+	// run background scans without saturating the disk IO of the host.
+	// 0 means unlimited.
+	MaxBytesPerSecond int64
+	// Optional: The size of the buffer the files are read through by the
+	// extractors. Larger buffers make extractors that stream files in small
+	// reads, e.g. line by line, issue fewer reads of the underlying file, at
+	// the cost of the memory for one buffer per Extract call. Random access
+	// reads through io.ReaderAt aren't buffered. If 0, the files are passed to
+	// the extractors unbuffered.
+	ReadBufferSize int
+	// Optional: A cache for the results of the extractors, keyed by the content
+	// of the extracted files. On a cache hit, Extract isn't called and the
+	// cached inventory is reported instead. Each required file is read once
+	// more to compute its digest. Only successful Extract calls are cached.
+	// Extractors whose results depend on other files than the extracted one,
+	// e.g. files next to it that they read through ScanInput.FS, can report
+	// stale results if only the other files changed. If nil, no cache is used.
+	ResultCache ResultCache
+}
+
+// ExtractorOverride limits the resources a single extractor can use. Files
+// the extractor skips because of the limits are counted in its plugin status
+// instead of failing the extractor.
+type ExtractorOverride struct {
+	// Optional: Time limit for each Extract call. Once it's exceeded, the
+	// context passed to Extract is canceled and the results of the call are
+	// dropped. Extractors that don't check the context are only stopped once
+	// they return. If 0, no limit is applied.
+	Timeout time.Duration
+	// Optional: Files bigger than this aren't passed to the extractor, even if
+	// it requires them. If 0, no limit is applied.
+	MaxFileSizeBytes int64
 }
 
+// MaxInodesBehavior determines what happens when the filesystem walk exceeds MaxInodes.
+type MaxInodesBehavior int
+
+const (
+	// MaxInodesError aborts the walk and returns an error.
+	MaxInodesError MaxInodesBehavior = iota
+	// MaxInodesTruncate stops the walk and returns the inventory found so far.
+	// The plugin statuses are marked as truncated.
+	MaxInodesTruncate
+)
+
 // Run runs the specified extractors and returns their extraction results,
 // as well as info about whether the plugin runs completed successfully.
 func Run(ctx context.Context, config *Config) ([]*extractor.Inventory, []*plugin.Status, error) {
@@ -115,16 +276,42 @@ func Run(ctx context.Context, config *Config) ([]*extractor.Inventory, []*plugin
 	var status []*plugin.Status
 
 	for _, root := range scanRoots {
-		inv, st, err := runOnScanRoot(ctx, config, root, wc)
+		// The walk context accumulates the inventory and plugin statuses of all
+		// scan roots, so only the results of the last one are kept.
+		inventory, status, err = runOnScanRoot(ctx, config, root, wc)
+		if errors.Is(err, ErrExtractorsFailed) {
+			// Strict mode: The remaining scan roots are still scanned.
+			continue
+		}
 		if err != nil {
 			return nil, nil, err
 		}
-
-		inventory = append(inventory, inv...)
-		status = append(status, st...)
 	}
 
-	return inventory, status, nil
+	return inventory, status, err
+}
+
+// ScanFS runs the specified extractors on the provided filesystem and returns
+// their extraction results, as well as info about whether the plugin runs
+// completed successfully.
+// Unlike Run, fsys is treated as a virtual scan root with no location on the
+// host's disk: No absolute path expansion is done and inventory locations are
+// always relative to the root of fsys. This is the supported entrypoint for
+// scanning in-memory or remote filesystems when using SCALIBR as a library.
+// config is optional and can be used to set additional settings such as
+// DirsToSkip or Stats. Its Extractors and ScanRoots fields are ignored. Paths
+// in FilesToExtract and DirsToSkip are expected to be relative to fsys.
+func ScanFS(ctx context.Context, fsys fs.FS, extractors []Extractor, config *Config) ([]*extractor.Inventory, []*plugin.Status, error) {
+	c := Config{}
+	if config != nil {
+		c = *config
+	}
+	c.Extractors = extractors
+	c.ScanRoots = []*scalibrfs.ScanRoot{{FS: scalibrfs.FromFS(fsys)}}
+	if c.Stats == nil {
+		c.Stats = stats.NoopCollector{}
+	}
+	return Run(ctx, &c)
 }
 
 func runOnScanRoot(ctx context.Context, config *Config, scanRoot *scalibrfs.ScanRoot, wc *walkContext) ([]*extractor.Inventory, []*plugin.Status, error) {
@@ -151,28 +338,73 @@ func InitWalkContext(ctx context.Context, config *Config, absScanRoots []*scalib
 	if err != nil {
 		return nil, err
 	}
-	dirsToSkip, err := stripAllPathPrefixes(config.DirsToSkip, absScanRoots)
+	relativeFiles, err := cleanRelativePaths(config.FilesToExtractRelative)
+	if err != nil {
+		return nil, err
+	}
+	filesToExtract = appendMissing(filesToExtract, relativeFiles...)
+	exactDirsToSkip, dirGlobsToSkip := splitGlobs(config.DirsToSkip)
+	dirsToSkip, err := stripAllPathPrefixes(exactDirsToSkip, absScanRoots)
+	if err != nil {
+		return nil, err
+	}
+	dirGlobsToSkip, err = stripGlobPrefixes(dirGlobsToSkip, absScanRoots)
 	if err != nil {
 		return nil, err
 	}
 
+	var includePrefix string
+	var includeAnchored bool
+	if config.IncludeDirRegex != nil {
+		includePrefix, includeAnchored = anchoredLiteralPrefix(config.IncludeDirRegex)
+	}
+	// The limiter is shared by the walks of all scan roots.
+	var limiter *rateLimiter
+	if config.MaxBytesPerSecond > 0 {
+		limiter = newRateLimiter(config.MaxBytesPerSecond)
+	}
+
 	return &walkContext{
-		ctx:               ctx,
-		stats:             config.Stats,
-		extractors:        config.Extractors,
-		filesToExtract:    filesToExtract,
-		dirsToSkip:        pathStringListToMap(dirsToSkip),
-		skipDirRegex:      config.SkipDirRegex,
-		readSymlinks:      config.ReadSymlinks,
-		maxInodes:         config.MaxInodes,
-		inodesVisited:     0,
-		storeAbsolutePath: config.StoreAbsolutePath,
+		ctx:                      ctx,
+		stats:                    config.Stats,
+		extractorIndex:           newExtractorIndex(config.Extractors),
+		filesToExtract:           filesToExtract,
+		dirsToSkip:               pathStringListToMap(dirsToSkip),
+		dirGlobsToSkip:           dirGlobsToSkip,
+		skipDirRegex:             config.SkipDirRegex,
+		includeDirRegex:          config.IncludeDirRegex,
+		includeDirPrefix:         includePrefix,
+		includeDirAnchored:       includeAnchored,
+		readSymlinks:             config.ReadSymlinks,
+		maxInodes:                config.MaxInodes,
+		maxInodesBehavior:        config.MaxInodesBehavior,
+		maxInventoryPerExtractor: config.MaxInventoryPerExtractor,
+		extractorOverrides:       config.ExtractorOverrides,
+		inodesVisited:            0,
+		storeAbsolutePath:        config.StoreAbsolutePath,
+		reportUnmatched:          config.ReportUnmatched,
+		locationPrefix:           config.LocationPrefixTrim,
+		posixPaths:               config.PosixPaths,
+		quiet:                    config.Quiet,
+		traceFileRequired:        config.TraceFileRequired,
+		configOSRelease:          config.OSRelease,
+		since:                    config.Since,
+		previousInventory:        indexByLocation(config.PreviousInventory),
+		strict:                   config.Strict,
+		limiter:                  limiter,
+		readBufferSize:           config.ReadBufferSize,
+		resultCache:              config.ResultCache,
 
 		lastStatus: time.Now(),
 
-		inventory: []*extractor.Inventory{},
-		errors:    make(map[string]error),
-		foundInv:  make(map[string]bool),
+		inventory:        []*extractor.Inventory{},
+		errors:           make(map[string]error),
+		malformedInputs:  make(map[string]int),
+		foundInv:         make(map[string]bool),
+		inventoryCount:   make(map[string]int),
+		droppedInventory: make(map[string]int),
+		timedOutFiles:    make(map[string]int),
+		oversizedFiles:   make(map[string]int),
 	}, nil
 }
 
@@ -194,39 +426,130 @@ func RunFS(ctx context.Context, config *Config, wc *walkContext) ([]*extractor.I
 		err = internal.WalkDirUnsorted(wc.fs, ".", wc.handleFile)
 	}
 
-	log.Infof("End status: %d inodes visited, %d Extract calls, %s elapsed",
-		wc.inodesVisited, wc.extractCalls, time.Since(start))
+	log.Summaryf("End status: %d inodes visited, %d Extract calls, %d file opens, %s elapsed",
+		wc.inodesVisited, wc.extractCalls, wc.fileOpens, time.Since(start))
+	if wc.resultCache != nil {
+		log.Infof("Result cache: %d hits", wc.cacheHits)
+	}
+	if wc.reportUnmatched {
+		wc.reportUnmatchedFiles()
+	}
+
+	status := wc.extractorStatus(config.Extractors)
+	if wc.truncated {
+		for _, s := range status {
+			s.Status.Truncated = true
+		}
+	}
+	if err == nil && wc.strict {
+		err = wc.extractorErrors()
+	}
+	return wc.inventory, status, err
+}
 
-	return wc.inventory, errToExtractorStatus(config.Extractors, wc.foundInv, wc.errors), err
+// extractorErrors returns an ErrExtractorsFailed error naming the extractors
+// that failed so far, or nil if none did. The individual errors are in the
+// plugin statuses.
+func (wc *walkContext) extractorErrors() error {
+	if len(wc.errors) == 0 {
+		return nil
+	}
+	names := make([]string, 0, len(wc.errors))
+	for name := range wc.errors {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return fmt.Errorf("%w: %s", ErrExtractorsFailed, strings.Join(names, ", "))
 }
 
 type walkContext struct {
-	ctx               context.Context
-	stats             stats.Collector
-	extractors        []Extractor
-	fs                scalibrfs.FS
-	scanRoot          string
-	filesToExtract    []string
-	dirsToSkip        map[string]bool // Anything under these paths should be skipped.
-	skipDirRegex      *regexp.Regexp
-	maxInodes         int
-	inodesVisited     int
-	storeAbsolutePath bool
+	ctx                      context.Context
+	stats                    stats.Collector
+	extractorIndex           *extractorIndex
+	fs                       scalibrfs.FS
+	scanRoot                 string
+	filesToExtract           []string
+	dirsToSkip               map[string]bool // Anything under these paths should be skipped.
+	dirGlobsToSkip           []string        // Slash-separated patterns relative to the scan root.
+	skipDirRegex             *regexp.Regexp
+	includeDirRegex          *regexp.Regexp
+	maxInodes                int
+	maxInodesBehavior        MaxInodesBehavior
+	maxInventoryPerExtractor int
+	extractorOverrides       map[string]ExtractorOverride
+	inodesVisited            int
+	storeAbsolutePath        bool
+	reportUnmatched          bool
+	locationPrefix           string
+	posixPaths               bool
+	quiet                    bool
+	traceFileRequired        bool
+	// The os-release fields from the config, and the ones passed to the
+	// extractors for the current scan root.
+	configOSRelease map[string]string
+	osRelease       map[string]string
+	since           time.Time
+	// Location of the files to the inventory found in them in a previous scan.
+	previousInventory map[string][]*extractor.Inventory
+	strict            bool
+	// Limits the read rate of the walk, nil if unlimited.
+	limiter *rateLimiter
+	// The size of the buffer around the files passed to Extract, 0 if unbuffered.
+	readBufferSize int
+	// Nil if results aren't cached.
+	resultCache ResultCache
 
 	// Inventories found.
 	inventory []*extractor.Inventory
 	// Extractor name to runtime errors.
 	errors map[string]error
+	// Extractor name to the number of files it skipped as malformed.
+	malformedInputs map[string]int
 	// Whether an extractor found any inventory.
 	foundInv map[string]bool
+	// Extractor name to the number of inventory items it reported.
+	inventoryCount map[string]int
+	// Extractor name to the number of inventory items dropped because it
+	// exceeded maxInventoryPerExtractor.
+	droppedInventory map[string]int
+	// Extractor name to the number of Extract calls that exceeded the
+	// extractor's timeout override.
+	timedOutFiles map[string]int
+	// Extractor name to the number of required files that exceeded the
+	// extractor's file size override.
+	oversizedFiles map[string]int
+	// Literal prefix of all paths includeDirRegex matches, if it's anchored.
+	includeDirPrefix   string
+	includeDirAnchored bool
+	// The outermost directory that matched includeDirRegex in the current
+	// subtree of the walk, and the last directory found not to be included.
+	includedDir     string
+	lastExcludedDir string
+	// Whether the walk stopped early because maxInodes was exceeded.
+	truncated bool
 	// Whether to read symlinks.
 	readSymlinks bool
+	// File extension to the number of files no extractor required.
+	unmatchedFiles map[string]int
+	// If set, FileRequired is called on each file but the files aren't
+	// extracted. See Coverage.
+	coverageOnly bool
+	// Extractor name to the number of files it required in coverageOnly mode.
+	requiredFiles map[string]int
+	// The number of files passed to FileRequired in coverageOnly mode.
+	filesChecked int
 
 	// Data for status printing.
 	lastStatus   time.Time
 	lastInodes   int
 	extractCalls int
 	lastExtracts int
+	// The number of Extract calls skipped because their results were cached.
+	cacheHits int
+	// The number of times files were opened for FileRequiredWithFS and
+	// Extract calls. Files required by several extractors are only opened
+	// once if they can be rewound.
+	fileOpens int
 }
 
 func walkIndividualFiles(fsys scalibrfs.FS, paths []string, fn fs.WalkDirFunc) error {
@@ -237,6 +560,9 @@ func walkIndividualFiles(fsys scalibrfs.FS, paths []string, fn fs.WalkDirFunc) e
 		} else {
 			err = fn(p, fs.FileInfoToDirEntry(info), nil)
 		}
+		if err == fs.SkipAll {
+			return nil
+		}
 		if err != nil {
 			return err
 		}
@@ -249,6 +575,13 @@ func (wc *walkContext) handleFile(path string, d fs.DirEntry, fserr error) error
 
 	wc.inodesVisited++
 	if wc.maxInodes > 0 && wc.inodesVisited > wc.maxInodes {
+		if wc.maxInodesBehavior == MaxInodesTruncate {
+			if !wc.truncated {
+				log.Warnf("maxInodes (%d) exceeded, returning incomplete inventory", wc.maxInodes)
+			}
+			wc.truncated = true
+			return fs.SkipAll
+		}
 		return fmt.Errorf("maxInodes (%d) exceeded", wc.maxInodes)
 	}
 
@@ -283,14 +616,34 @@ func (wc *walkContext) handleFile(path string, d fs.DirEntry, fserr error) error
 			return nil
 		}
 	}
+	if !wc.isIncluded(parentDir(path)) {
+		return nil
+	}
 	fileinfo, err := fs.Stat(wc.fs, path)
 	if err != nil {
 		log.Warnf("os.Stat(%s): %v", path, err)
 		return nil
 	}
+	if wc.coverageOnly {
+		wc.countRequired(path, fileinfo)
+		return nil
+	}
+	if !wc.since.IsZero() && fileinfo.ModTime().Before(wc.since) {
+		wc.reusePreviousInventory(path)
+		return nil
+	}
 
-	for _, ex := range wc.extractors {
-		wc.runExtractor(ex, path, fileinfo)
+	file := NewPeekableFile(wc.fs, path)
+	matched := false
+	for _, ex := range wc.extractorIndex.extractorsFor(path) {
+		if wc.runExtractor(ex, path, fileinfo, file) {
+			matched = true
+		}
+	}
+	file.Close()
+	wc.fileOpens += file.opens
+	if !matched && wc.reportUnmatched {
+		wc.unmatchedFiles[unmatchedFileKey(path)]++
 	}
 	return nil
 }
@@ -299,61 +652,322 @@ func (wc *walkContext) shouldSkipDir(path string) bool {
 	if _, ok := wc.dirsToSkip[path]; ok {
 		return true
 	}
-	if wc.skipDirRegex != nil {
-		return wc.skipDirRegex.MatchString(path)
+	for _, g := range wc.dirGlobsToSkip {
+		if internal.MatchGlob(g, path) {
+			return true
+		}
+	}
+	if wc.skipDirRegex != nil && wc.skipDirRegex.MatchString(path) {
+		return true
+	}
+	return !wc.isIncluded(path) && !wc.mayContainIncludedDir(path)
+}
+
+// isIncluded returns whether files in dir should be extracted, i.e. whether
+// dir or one of its parents matches includeDirRegex.
+func (wc *walkContext) isIncluded(dir string) bool {
+	if wc.includeDirRegex == nil {
+		return true
+	}
+	if wc.includedDir != "" && isSubdir(dir, wc.includedDir) {
+		return true
+	}
+	if dir == wc.lastExcludedDir {
+		return false
+	}
+	// Check the parents first so that includedDir is the outermost match.
+	for _, d := range parentDirs(dir) {
+		if wc.includeDirRegex.MatchString(d) {
+			wc.includedDir = d
+			return true
+		}
 	}
+	wc.lastExcludedDir = dir
 	return false
 }
 
-func (wc *walkContext) runExtractor(ex Extractor, path string, fileinfo fs.FileInfo) {
-	if !ex.FileRequired(path, fileinfo) {
-		return
+// mayContainIncludedDir returns whether dir can have a subdirectory that
+// matches includeDirRegex.
+func (wc *walkContext) mayContainIncludedDir(dir string) bool {
+	if !wc.includeDirAnchored || dir == "." {
+		return true
+	}
+	return strings.HasPrefix(wc.includeDirPrefix, dir) || strings.HasPrefix(dir, wc.includeDirPrefix)
+}
+
+// anchoredLiteralPrefix returns the literal text that all matches of re start
+// with, e.g. "src/" for `^src/(app|lib)`. anchored is false if re isn't
+// anchored at the beginning of the text, in which case matches can start
+// anywhere.
+func anchoredLiteralPrefix(re *regexp.Regexp) (prefix string, anchored bool) {
+	r, err := syntax.Parse(re.String(), syntax.Perl)
+	if err != nil {
+		return "", false
+	}
+	r = r.Simplify()
+	subs := []*syntax.Regexp{r}
+	if r.Op == syntax.OpConcat {
+		subs = r.Sub
+	}
+	if len(subs) == 0 || subs[0].Op != syntax.OpBeginText {
+		return "", false
+	}
+	var sb strings.Builder
+	for _, sub := range subs[1:] {
+		if sub.Op != syntax.OpLiteral || sub.Flags&syntax.FoldCase != 0 {
+			break
+		}
+		sb.WriteString(string(sub.Rune))
+	}
+	return sb.String(), true
+}
+
+// parentDir returns the directory of the slash-separated path, "." for paths
+// directly in the scan root.
+func parentDir(path string) string {
+	i := strings.LastIndex(path, "/")
+	if i < 0 {
+		return "."
+	}
+	return path[:i]
+}
+
+// parentDirs returns dir and all of its parents, starting with the scan root,
+// e.g. [".", "a", "a/b"] for "a/b".
+func parentDirs(dir string) []string {
+	dirs := []string{"."}
+	if dir == "." {
+		return dirs
+	}
+	for i := 0; i < len(dir); i++ {
+		if dir[i] == '/' {
+			dirs = append(dirs, dir[:i])
+		}
+	}
+	return append(dirs, dir)
+}
+
+// isSubdir returns whether dir is parent or inside it.
+func isSubdir(dir, parent string) bool {
+	return parent == "." || dir == parent || strings.HasPrefix(dir, parent+"/")
+}
+
+// runExtractor runs the extractor on the given file if the extractor requires it.
+// Returns whether the file was required.
+func (wc *walkContext) runExtractor(ex Extractor, path string, fileinfo fs.FileInfo, file *PeekableFile) bool {
+	required := fileRequired(ex, path, fileinfo, file)
+	if wc.traceFileRequired {
+		log.Tracef("%s: FileRequired(%s) = %t", ex.Name(), path, required)
+	}
+	if !required {
+		return false
+	}
+	override := wc.extractorOverrides[ex.Name()]
+	if override.MaxFileSizeBytes > 0 && fileinfo.Size() > override.MaxFileSizeBytes {
+		log.Debugf("%s: skipping %s, its size %d exceeds the limit of %d bytes", ex.Name(), path, fileinfo.Size(), override.MaxFileSizeBytes)
+		wc.oversizedFiles[ex.Name()]++
+		return true
+	}
+	var cacheKey *ResultCacheKey
+	if wc.resultCache != nil {
+		digest, err := file.sha256()
+		if err != nil {
+			addErrToMap(wc.errors, ex.Name(), fmt.Errorf("Open(%s): %v", path, err))
+			return true
+		}
+		cacheKey = &ResultCacheKey{
+			FileSHA256:       digest,
+			ExtractorName:    ex.Name(),
+			ExtractorVersion: ex.Version(),
+			OSRelease:        wc.osRelease,
+		}
+		if results, ok := wc.cachedResults(cacheKey, path); ok {
+			wc.cacheHits++
+			wc.addResults(ex, results)
+			return true
+		}
 	}
-	rc, err := wc.fs.Open(path)
+	// Reuse the file if it was already opened for peeking or for another
+	// extractor.
+	rc, err := file.reader()
 	if err != nil {
 		addErrToMap(wc.errors, ex.Name(), fmt.Errorf("Open(%s): %v", path, err))
-		return
+		return true
 	}
-	defer rc.Close()
 
 	info, err := rc.Stat()
 	if err != nil {
 		addErrToMap(wc.errors, ex.Name(), fmt.Errorf("stat(%s): %v", path, err))
-		return
+		return true
 	}
 
 	wc.extractCalls++
 
+	ctx := wc.ctx
+	if override.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(wc.ctx, override.Timeout)
+		defer cancel()
+	}
+
+	var reader io.Reader = rc
+	if wc.readBufferSize > 0 {
+		reader = newBufferedReader(rc, wc.readBufferSize)
+	}
+
 	start := time.Now()
-	results, err := ex.Extract(wc.ctx, &ScanInput{
-		FS:     wc.fs,
-		Path:   path,
-		Root:   wc.scanRoot,
-		Info:   info,
-		Reader: rc,
+	results, err := ex.Extract(ctx, &ScanInput{
+		FS:        wc.fs,
+		Path:      path,
+		Root:      wc.scanRoot,
+		Info:      info,
+		Reader:    reader,
+		OSRelease: wc.osRelease,
 	})
 	wc.stats.AfterExtractorRun(ex.Name(), time.Since(start), err)
-	if err != nil {
+	if wc.ctx.Err() == nil && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		// The results of a canceled call might be incomplete.
+		log.Warnf("%s: extracting %s took longer than %v, dropping its results", ex.Name(), path, override.Timeout)
+		wc.timedOutFiles[ex.Name()]++
+		return true
+	}
+	if errors.Is(err, extractor.ErrMalformedInput) {
+		// Malformed files are a data quality issue, not an extractor failure.
+		log.Debugf("%s: skipping malformed file %s: %v", ex.Name(), path, err)
+		wc.malformedInputs[ex.Name()]++
+	} else if err != nil {
 		addErrToMap(wc.errors, ex.Name(), fmt.Errorf("%s: %w", path, err))
+	} else if cacheKey != nil {
+		wc.cacheResults(cacheKey, path, results)
 	}
 
+	wc.addResults(ex, results)
+	return true
+}
+
+// addResults adds the inventory found by ex to the results of the walk.
+func (wc *walkContext) addResults(ex Extractor, results []*extractor.Inventory) {
 	if len(results) > 0 {
 		wc.foundInv[ex.Name()] = true
 		for _, r := range results {
-			r.Extractor = ex
-			if wc.storeAbsolutePath {
-				r.Locations = expandAbsolutePath(wc.scanRoot, r.Locations)
+			if !wc.countInventory(ex.Name()) {
+				continue
 			}
+			r.Extractor = ex
+			r.ScanRoot = wc.scanRoot
+			wc.convertLocations(r)
 			wc.inventory = append(wc.inventory, r)
 		}
 	}
 }
 
+// countInventory counts an inventory item reported by the given extractor.
+// It returns false if the item is dropped because the extractor already
+// reported MaxInventoryPerExtractor items.
+func (wc *walkContext) countInventory(extractorName string) bool {
+	if wc.maxInventoryPerExtractor > 0 && wc.inventoryCount[extractorName] >= wc.maxInventoryPerExtractor {
+		if wc.droppedInventory[extractorName] == 0 {
+			log.Warnf("%s reported more than %d inventory items, dropping the rest", extractorName, wc.maxInventoryPerExtractor)
+		}
+		wc.droppedInventory[extractorName]++
+		return false
+	}
+	wc.inventoryCount[extractorName]++
+	return true
+}
+
+func fileRequired(ex Extractor, path string, fileinfo fs.FileInfo, file *PeekableFile) bool {
+	if cex, ok := ex.(FileRequiredWithFS); ok {
+		return cex.FileRequiredWithFS(path, fileinfo, file)
+	}
+	return ex.FileRequired(path, fileinfo)
+}
+
+// toLocations converts paths relative to the scan root into the format
+// used for reporting inventory locations.
+func (wc *walkContext) toLocations(paths []string) []string {
+	if wc.storeAbsolutePath {
+		paths = expandAbsolutePath(wc.scanRoot, paths)
+	}
+	if wc.locationPrefix != "" {
+		paths = trimLocationPrefix(wc.locationPrefix, paths)
+	}
+	if wc.posixPaths {
+		paths = toSlash(paths)
+	}
+	return paths
+}
+
+// convertLocations converts the locations and location details of the given
+// inventory into the format used for reporting and adds the paths of the
+// location details to its locations.
+func (wc *walkContext) convertLocations(inv *extractor.Inventory) {
+	inv.Locations = wc.toLocations(inv.LocationPaths())
+	for _, l := range inv.LocationDetails {
+		l.Path = wc.toLocations([]string{l.Path})[0]
+	}
+}
+
+// reusePreviousInventory adds the inventory found in the given unchanged
+// file during a previous scan to the results. It's counted against the
+// MaxInventoryPerExtractor limit like newly extracted inventory.
+func (wc *walkContext) reusePreviousInventory(path string) {
+	if len(wc.previousInventory) == 0 {
+		return
+	}
+	for _, inv := range wc.previousInventory[wc.toLocations([]string{path})[0]] {
+		if inv.Extractor != nil {
+			wc.foundInv[inv.Extractor.Name()] = true
+			if !wc.countInventory(inv.Extractor.Name()) {
+				continue
+			}
+		}
+		inv.ScanRoot = wc.scanRoot
+		wc.inventory = append(wc.inventory, inv)
+	}
+}
+
+// indexByLocation maps the first location of each inventory and all of its
+// parent paths to the inventory. This way inventory with locations inside a
+// file (e.g. a JAR nested in an archive) is matched to the file itself.
+func indexByLocation(inventory []*extractor.Inventory) map[string][]*extractor.Inventory {
+	result := make(map[string][]*extractor.Inventory)
+	for _, inv := range inventory {
+		if len(inv.Locations) == 0 {
+			continue
+		}
+		for l := inv.Locations[0]; ; {
+			result[l] = append(result[l], inv)
+			parent := filepath.Dir(l)
+			if parent == l || parent == "." {
+				break
+			}
+			l = parent
+		}
+	}
+	return result
+}
+
 // UpdateScanRoot updates the scan root and the filesystem to use for the filesystem walk.
 // currentRoot is expected to be an absolute path.
 func (wc *walkContext) UpdateScanRoot(absRoot string, fs scalibrfs.FS) error {
 	wc.scanRoot = absRoot
+	if wc.limiter != nil {
+		fs = newThrottledFS(wc.ctx, fs, wc.limiter)
+	}
 	wc.fs = fs
+	wc.osRelease = wc.configOSRelease
+	if wc.osRelease == nil {
+		m, err := osrelease.GetOSRelease(fs)
+		if err == nil {
+			wc.osRelease = m
+		} else if !errors.Is(err, os.ErrNotExist) {
+			log.Debugf("Failed to read the os-release file of %s: %v", absRoot, err)
+		}
+	}
+	wc.unmatchedFiles = make(map[string]int)
+	wc.includedDir = ""
+	wc.lastExcludedDir = ""
 	return nil
 }
 
@@ -365,6 +979,36 @@ func expandAbsolutePath(scanRoot string, paths []string) []string {
 	return locations
 }
 
+// toSlash replaces the path separators of the host OS in all paths with
+// forward slashes.
+func toSlash(paths []string) []string {
+	var locations []string
+	for _, l := range paths {
+		locations = append(locations, filepath.ToSlash(l))
+	}
+	return locations
+}
+
+// trimLocationPrefix strips prefix from all paths that start with it. Only full
+// path components are stripped, e.g. the prefix "/a/b" doesn't apply to "/a/bc".
+func trimLocationPrefix(prefix string, paths []string) []string {
+	prefix = strings.TrimRight(prefix, `/\`)
+	var locations []string
+	for _, l := range paths {
+		rest, found := strings.CutPrefix(l, prefix)
+		if !found || (rest != "" && !os.IsPathSeparator(rest[0]) && rest[0] != '/') {
+			locations = append(locations, l)
+			continue
+		}
+		rest = strings.TrimLeft(rest, `/\`)
+		if rest == "" {
+			rest = "."
+		}
+		locations = append(locations, rest)
+	}
+	return locations
+}
+
 func expandAllAbsolutePaths(scanRoots []*scalibrfs.ScanRoot) ([]*scalibrfs.ScanRoot, error) {
 	var result []*scalibrfs.ScanRoot
 	for _, r := range scanRoots {
@@ -400,6 +1044,60 @@ func stripAllPathPrefixes(paths []string, scanRoots []*scalibrfs.ScanRoot) ([]st
 	return result, nil
 }
 
+// cleanRelativePaths cleans paths that are relative to the scan roots.
+// Returns ErrNotRelativeToScanRoots for absolute paths and for paths outside
+// of the scan roots, e.g. "../file".
+func cleanRelativePaths(paths []string) ([]string, error) {
+	result := make([]string, 0, len(paths))
+	for _, p := range paths {
+		c := filepath.Clean(p)
+		if filepath.IsAbs(c) || c == ".." || strings.HasPrefix(c, ".."+string(filepath.Separator)) {
+			return nil, fmt.Errorf("%q: %w", p, ErrNotRelativeToScanRoots)
+		}
+		result = append(result, c)
+	}
+	return result, nil
+}
+
+// appendMissing appends the paths that aren't in list yet.
+func appendMissing(list []string, paths ...string) []string {
+	for _, p := range paths {
+		if !slices.Contains(list, p) {
+			list = append(list, p)
+		}
+	}
+	return list
+}
+
+// splitGlobs separates the paths containing wildcards from the exact paths.
+func splitGlobs(paths []string) (exact []string, globs []string) {
+	for _, p := range paths {
+		if internal.IsGlob(p) {
+			globs = append(globs, p)
+		} else {
+			exact = append(exact, p)
+		}
+	}
+	return exact, globs
+}
+
+// stripGlobPrefixes makes absolute glob patterns relative to the scan root
+// they're in. Relative patterns apply to all scan roots and are kept as is.
+func stripGlobPrefixes(patterns []string, scanRoots []*scalibrfs.ScanRoot) ([]string, error) {
+	result := make([]string, 0, len(patterns))
+	for _, p := range patterns {
+		if filepath.IsAbs(p) && (len(scanRoots) == 0 || !scanRoots[0].IsVirtual()) {
+			rp, err := stripFromAtLeastOnePrefix(p, scanRoots)
+			if err != nil {
+				return nil, err
+			}
+			p = rp
+		}
+		result = append(result, filepath.ToSlash(p))
+	}
+	return result, nil
+}
+
 // stripFromAtLeastOnePrefix returns the path relative to the first prefix it is relative to.
 // If the path is not relative to any of the prefixes, an error is returned.
 // The path is expected to be absolute.
@@ -435,16 +1133,56 @@ func addErrToMap(errors map[string]error, key string, err error) {
 	}
 }
 
-func errToExtractorStatus(extractors []Extractor, foundInv map[string]bool, errors map[string]error) []*plugin.Status {
+func (wc *walkContext) extractorStatus(extractors []Extractor) []*plugin.Status {
 	result := make([]*plugin.Status, 0, len(extractors))
 	for _, ex := range extractors {
-		result = append(result, plugin.StatusFromErr(ex, foundInv[ex.Name()], errors[ex.Name()]))
+		s := plugin.StatusFromErr(ex, wc.foundInv[ex.Name()], wc.errors[ex.Name()])
+		s.Status.MalformedInputs = wc.malformedInputs[ex.Name()]
+		s.Status.InventoryCount = wc.inventoryCount[ex.Name()]
+		s.Status.DroppedInventory = wc.droppedInventory[ex.Name()]
+		s.Status.TimedOutFiles = wc.timedOutFiles[ex.Name()]
+		s.Status.OversizedFiles = wc.oversizedFiles[ex.Name()]
+		result = append(result, s)
 	}
 	return result
 }
 
+// unmatchedFileKey returns the histogram bucket for a file that no extractor required.
+func unmatchedFileKey(path string) string {
+	ext := strings.ToLower(filepath.Ext(path))
+	if ext == "" {
+		return "<none>"
+	}
+	return ext
+}
+
+func (wc *walkContext) reportUnmatchedFiles() {
+	total := 0
+	exts := make([]string, 0, len(wc.unmatchedFiles))
+	for ext, count := range wc.unmatchedFiles {
+		total += count
+		exts = append(exts, ext)
+	}
+	// Most common extensions first.
+	sort.Slice(exts, func(i, j int) bool {
+		ci, cj := wc.unmatchedFiles[exts[i]], wc.unmatchedFiles[exts[j]]
+		if ci != cj {
+			return ci > cj
+		}
+		return exts[i] < exts[j]
+	})
+	log.Infof("Files not required by any extractor: %d", total)
+	for _, ext := range exts {
+		log.Infof("  %s: %d", ext, wc.unmatchedFiles[ext])
+	}
+	wc.stats.AfterFilesUnmatched(&stats.UnmatchedFilesStats{
+		Total:       total,
+		ByExtension: wc.unmatchedFiles,
+	})
+}
+
 func (wc *walkContext) printStatus(path string) {
-	if time.Since(wc.lastStatus) < 2*time.Second {
+	if wc.quiet || time.Since(wc.lastStatus) < 2*time.Second {
 		return
 	}
 	log.Infof("Status: new inodes: %d, %.1f inodes/s, new extract calls: %d, path: %q\n",
@@ -35,6 +35,7 @@ import (
 	"github.com/google/osv-scalibr/extractor"
 	"github.com/google/osv-scalibr/extractor/filesystem"
 	scalibrfs "github.com/google/osv-scalibr/fs"
+	"github.com/google/osv-scalibr/log"
 	"github.com/google/osv-scalibr/plugin"
 	"github.com/google/osv-scalibr/stats"
 	fe "github.com/google/osv-scalibr/testing/fakeextractor"
@@ -65,6 +66,7 @@ func TestInitWalkContext(t *testing.T) {
 		desc           string
 		scanRoots      map[string][]string
 		filesToExtract map[string][]string
+		relativeFiles  []string
 		dirsToSkip     map[string][]string
 		wantErr        error
 	}{
@@ -110,6 +112,26 @@ func TestInitWalkContext(t *testing.T) {
 			},
 			wantErr: filesystem.ErrNotRelativeToScanRoots,
 		},
+		{
+			desc: "relative files raise no error",
+			scanRoots: map[string][]string{
+				"darwin":  []string{"/scanroot/", "/someotherroot/"},
+				"linux":   []string{"/scanroot/", "/someotherroot/"},
+				"windows": []string{"C:\\scanroot\\", "D:\\someotherroot\\"},
+			},
+			relativeFiles: []string{"file1.txt", "mydir/../file2.txt"},
+			wantErr:       nil,
+		},
+		{
+			desc: "relative file outside of the roots raises error",
+			scanRoots: map[string][]string{
+				"darwin":  []string{"/scanroot/"},
+				"linux":   []string{"/scanroot/"},
+				"windows": []string{"C:\\scanroot\\"},
+			},
+			relativeFiles: []string{"file1.txt", "mydir/../../file2.txt"},
+			wantErr:       filesystem.ErrNotRelativeToScanRoots,
+		},
 		{
 			desc: "dirsToSkip not relative to any root raises error",
 			scanRoots: map[string][]string{
@@ -133,8 +155,9 @@ func TestInitWalkContext(t *testing.T) {
 				t.Fatalf("system %q not defined in test, please extend the tests", os)
 			}
 			config := &filesystem.Config{
-				FilesToExtract: tc.filesToExtract[os],
-				DirsToSkip:     tc.dirsToSkip[os],
+				FilesToExtract:         tc.filesToExtract[os],
+				FilesToExtractRelative: tc.relativeFiles,
+				DirsToSkip:             tc.dirsToSkip[os],
 			}
 			scanRoots := []*scalibrfs.ScanRoot{}
 			for _, p := range tc.scanRoots[os] {
@@ -152,6 +175,7 @@ func TestInitWalkContext(t *testing.T) {
 
 func TestRunFS(t *testing.T) {
 	success := &plugin.ScanStatus{Status: plugin.ScanStatusSucceeded}
+	successWithInv := &plugin.ScanStatus{Status: plugin.ScanStatusSucceeded, InventoryCount: 1}
 	path1 := "dir1/file1.txt"
 	path2 := "dir2/sub/file2.txt"
 	fsys := pathsMapFS{
@@ -170,6 +194,14 @@ func TestRunFS(t *testing.T) {
 	fakeEx2 := fe.New("ex2", 2, []string{path2}, map[string]fe.NamesErr{path2: {Names: []string{name2}, Err: nil}})
 	fakeEx2WithInv1 := fe.New("ex2", 2, []string{path2}, map[string]fe.NamesErr{path2: {Names: []string{name1}, Err: nil}})
 	fakeExWithPartialResult := fe.New("ex1", 1, []string{path1}, map[string]fe.NamesErr{path1: {Names: []string{name1}, Err: errors.New("extraction failed")}})
+	fakeExWithMalformedInput := fe.New("ex1", 1, []string{path1, path2}, map[string]fe.NamesErr{
+		path1: {Names: []string{name1}, Err: nil},
+		path2: {Names: nil, Err: fmt.Errorf("corrupt archive: %w", extractor.ErrMalformedInput)},
+	})
+	fakeExWithManyResults := fe.New("ex1", 1, []string{path1, path2}, map[string]fe.NamesErr{
+		path1: {Names: []string{name1}, Err: nil},
+		path2: {Names: []string{name2, "software3"}, Err: nil},
+	})
 
 	cwd, err := os.Getwd()
 	if err != nil {
@@ -177,17 +209,22 @@ func TestRunFS(t *testing.T) {
 	}
 
 	testCases := []struct {
-		desc           string
-		ex             []filesystem.Extractor
-		filesToExtract []string
-		dirsToSkip     []string
-		skipDirRegex   string
-		storeAbsPath   bool
-		maxInodes      int
-		wantErr        error
-		wantInv        []*extractor.Inventory
-		wantStatus     []*plugin.Status
-		wantInodeCount int
+		desc            string
+		ex              []filesystem.Extractor
+		filesToExtract  []string
+		dirsToSkip      []string
+		skipDirRegex    string
+		includeDirRegex string
+		storeAbsPath    bool
+		prefixTrim      string
+		posixPaths      bool
+		maxInodes       int
+		inodesBehavior  filesystem.MaxInodesBehavior
+		maxInventory    int
+		wantErr         error
+		wantInv         []*extractor.Inventory
+		wantStatus      []*plugin.Status
+		wantInodeCount  int
 	}{
 		{
 			desc: "Extractors successful",
@@ -205,8 +242,8 @@ func TestRunFS(t *testing.T) {
 				},
 			},
 			wantStatus: []*plugin.Status{
-				&plugin.Status{Name: "ex1", Version: 1, Status: success},
-				&plugin.Status{Name: "ex2", Version: 2, Status: success},
+				&plugin.Status{Name: "ex1", Version: 1, Status: successWithInv},
+				&plugin.Status{Name: "ex2", Version: 2, Status: successWithInv},
 			},
 			wantInodeCount: 6,
 		},
@@ -224,7 +261,7 @@ func TestRunFS(t *testing.T) {
 			},
 			wantStatus: []*plugin.Status{
 				&plugin.Status{Name: "ex1", Version: 1, Status: success},
-				&plugin.Status{Name: "ex2", Version: 2, Status: success},
+				&plugin.Status{Name: "ex2", Version: 2, Status: successWithInv},
 			},
 			wantInodeCount: 5,
 		},
@@ -241,10 +278,44 @@ func TestRunFS(t *testing.T) {
 			},
 			wantStatus: []*plugin.Status{
 				&plugin.Status{Name: "ex1", Version: 1, Status: success},
+				&plugin.Status{Name: "ex2", Version: 2, Status: successWithInv},
+			},
+			wantInodeCount: 5,
+		},
+		{
+			desc:       "Dir skipped using wildcard",
+			ex:         []filesystem.Extractor{fakeEx1, fakeEx2},
+			dirsToSkip: []string{"**/sub"},
+			wantInv: []*extractor.Inventory{
+				&extractor.Inventory{
+					Name:      name1,
+					Locations: []string{path1},
+					Extractor: fakeEx1,
+				},
+			},
+			wantStatus: []*plugin.Status{
+				&plugin.Status{Name: "ex1", Version: 1, Status: successWithInv},
 				&plugin.Status{Name: "ex2", Version: 2, Status: success},
 			},
 			wantInodeCount: 5,
 		},
+		{
+			desc:       "Dir skipped using wildcard with absolute path",
+			ex:         []filesystem.Extractor{fakeEx1, fakeEx2},
+			dirsToSkip: []string{path.Join(cwd, "*1")},
+			wantInv: []*extractor.Inventory{
+				&extractor.Inventory{
+					Name:      name2,
+					Locations: []string{path2},
+					Extractor: fakeEx2,
+				},
+			},
+			wantStatus: []*plugin.Status{
+				&plugin.Status{Name: "ex1", Version: 1, Status: success},
+				&plugin.Status{Name: "ex2", Version: 2, Status: successWithInv},
+			},
+			wantInodeCount: 5,
+		},
 		{
 			desc:         "Dir skipped using regex",
 			ex:           []filesystem.Extractor{fakeEx1, fakeEx2},
@@ -258,7 +329,7 @@ func TestRunFS(t *testing.T) {
 			},
 			wantStatus: []*plugin.Status{
 				&plugin.Status{Name: "ex1", Version: 1, Status: success},
-				&plugin.Status{Name: "ex2", Version: 2, Status: success},
+				&plugin.Status{Name: "ex2", Version: 2, Status: successWithInv},
 			},
 			wantInodeCount: 5,
 		},
@@ -274,11 +345,75 @@ func TestRunFS(t *testing.T) {
 				},
 			},
 			wantStatus: []*plugin.Status{
-				&plugin.Status{Name: "ex1", Version: 1, Status: success},
+				&plugin.Status{Name: "ex1", Version: 1, Status: successWithInv},
 				&plugin.Status{Name: "ex2", Version: 2, Status: success},
 			},
 			wantInodeCount: 5,
 		},
+		{
+			desc:            "Only files in included dirs extracted",
+			ex:              []filesystem.Extractor{fakeEx1, fakeEx2},
+			includeDirRegex: "sub",
+			wantInv: []*extractor.Inventory{
+				&extractor.Inventory{
+					Name:      name2,
+					Locations: []string{path2},
+					Extractor: fakeEx2,
+				},
+			},
+			wantStatus: []*plugin.Status{
+				&plugin.Status{Name: "ex1", Version: 1, Status: success},
+				&plugin.Status{Name: "ex2", Version: 2, Status: successWithInv},
+			},
+			// Unanchored regexes can match anywhere so all dirs are walked.
+			wantInodeCount: 6,
+		},
+		{
+			desc:            "Anchored include regex skips dirs that can't lead to a match",
+			ex:              []filesystem.Extractor{fakeEx1, fakeEx2},
+			includeDirRegex: "^dir2/sub$",
+			wantInv: []*extractor.Inventory{
+				&extractor.Inventory{
+					Name:      name2,
+					Locations: []string{path2},
+					Extractor: fakeEx2,
+				},
+			},
+			wantStatus: []*plugin.Status{
+				&plugin.Status{Name: "ex1", Version: 1, Status: success},
+				&plugin.Status{Name: "ex2", Version: 2, Status: successWithInv},
+			},
+			wantInodeCount: 5,
+		},
+		{
+			desc:            "Files in subdirs of included dir extracted",
+			ex:              []filesystem.Extractor{fakeEx1, fakeEx2},
+			includeDirRegex: "^dir2$",
+			wantInv: []*extractor.Inventory{
+				&extractor.Inventory{
+					Name:      name2,
+					Locations: []string{path2},
+					Extractor: fakeEx2,
+				},
+			},
+			wantStatus: []*plugin.Status{
+				&plugin.Status{Name: "ex1", Version: 1, Status: success},
+				&plugin.Status{Name: "ex2", Version: 2, Status: successWithInv},
+			},
+			wantInodeCount: 5,
+		},
+		{
+			desc:            "Skip regex takes precedence over include regex",
+			ex:              []filesystem.Extractor{fakeEx1, fakeEx2},
+			includeDirRegex: "^dir2",
+			skipDirRegex:    "sub",
+			wantInv:         []*extractor.Inventory{},
+			wantStatus: []*plugin.Status{
+				&plugin.Status{Name: "ex1", Version: 1, Status: success},
+				&plugin.Status{Name: "ex2", Version: 2, Status: success},
+			},
+			wantInodeCount: 4,
+		},
 		{
 			desc:         "skip regex set but not match",
 			ex:           []filesystem.Extractor{fakeEx1, fakeEx2},
@@ -296,8 +431,8 @@ func TestRunFS(t *testing.T) {
 				},
 			},
 			wantStatus: []*plugin.Status{
-				&plugin.Status{Name: "ex1", Version: 1, Status: success},
-				&plugin.Status{Name: "ex2", Version: 2, Status: success},
+				&plugin.Status{Name: "ex1", Version: 1, Status: successWithInv},
+				&plugin.Status{Name: "ex2", Version: 2, Status: successWithInv},
 			},
 			wantInodeCount: 6,
 		},
@@ -317,8 +452,8 @@ func TestRunFS(t *testing.T) {
 				},
 			},
 			wantStatus: []*plugin.Status{
-				&plugin.Status{Name: "ex1", Version: 1, Status: success},
-				&plugin.Status{Name: "ex2", Version: 2, Status: success},
+				&plugin.Status{Name: "ex1", Version: 1, Status: successWithInv},
+				&plugin.Status{Name: "ex2", Version: 2, Status: successWithInv},
 			},
 			wantInodeCount: 6,
 		},
@@ -336,7 +471,7 @@ func TestRunFS(t *testing.T) {
 			},
 			wantStatus: []*plugin.Status{
 				&plugin.Status{Name: "ex1", Version: 1, Status: success},
-				&plugin.Status{Name: "ex2", Version: 2, Status: success},
+				&plugin.Status{Name: "ex2", Version: 2, Status: successWithInv},
 			},
 			wantInodeCount: 1,
 		},
@@ -353,7 +488,7 @@ func TestRunFS(t *testing.T) {
 			},
 			wantStatus: []*plugin.Status{
 				&plugin.Status{Name: "ex1", Version: 1, Status: success},
-				&plugin.Status{Name: "ex2", Version: 2, Status: success},
+				&plugin.Status{Name: "ex2", Version: 2, Status: successWithInv},
 			},
 			wantInodeCount: 1,
 		},
@@ -382,6 +517,7 @@ func TestRunFS(t *testing.T) {
 			wantStatus: []*plugin.Status{
 				&plugin.Status{Name: "ex1", Version: 1, Status: &plugin.ScanStatus{
 					Status: plugin.ScanStatusPartiallySucceeded, FailureReason: fmt.Sprintf("%s: extraction failed", path1),
+					InventoryCount: 1,
 				}},
 			},
 			wantInodeCount: 6,
@@ -416,6 +552,25 @@ func TestRunFS(t *testing.T) {
 			},
 			wantInodeCount: 6,
 		},
+		{
+			desc: "Malformed files are counted instead of failing the extractor",
+			ex:   []filesystem.Extractor{fakeExWithMalformedInput},
+			wantInv: []*extractor.Inventory{
+				&extractor.Inventory{
+					Name:      name1,
+					Locations: []string{path1},
+					Extractor: fakeExWithMalformedInput,
+				},
+			},
+			wantStatus: []*plugin.Status{
+				&plugin.Status{Name: "ex1", Version: 1, Status: &plugin.ScanStatus{
+					Status:          plugin.ScanStatusSucceeded,
+					MalformedInputs: 1,
+					InventoryCount:  1,
+				}},
+			},
+			wantInodeCount: 6,
+		},
 		{
 			desc:      "More inodes visited than limit, Error",
 			ex:        []filesystem.Extractor{fakeEx1, fakeEx2},
@@ -428,6 +583,18 @@ func TestRunFS(t *testing.T) {
 			wantInodeCount: 2,
 			wantErr:        cmpopts.AnyError,
 		},
+		{
+			desc:           "More inodes visited than limit, Truncate",
+			ex:             []filesystem.Extractor{fakeEx1, fakeEx2},
+			maxInodes:      2,
+			inodesBehavior: filesystem.MaxInodesTruncate,
+			wantInv:        []*extractor.Inventory{},
+			wantStatus: []*plugin.Status{
+				&plugin.Status{Name: "ex1", Version: 1, Status: &plugin.ScanStatus{Status: plugin.ScanStatusSucceeded, Truncated: true}},
+				&plugin.Status{Name: "ex2", Version: 2, Status: &plugin.ScanStatus{Status: plugin.ScanStatusSucceeded, Truncated: true}},
+			},
+			wantInodeCount: 2,
+		},
 		{
 			desc:      "Less inodes visited than limit, no Error",
 			ex:        []filesystem.Extractor{fakeEx1, fakeEx2},
@@ -445,8 +612,34 @@ func TestRunFS(t *testing.T) {
 				},
 			},
 			wantStatus: []*plugin.Status{
-				&plugin.Status{Name: "ex1", Version: 1, Status: success},
-				&plugin.Status{Name: "ex2", Version: 2, Status: success},
+				&plugin.Status{Name: "ex1", Version: 1, Status: successWithInv},
+				&plugin.Status{Name: "ex2", Version: 2, Status: successWithInv},
+			},
+			wantInodeCount: 6,
+		},
+		{
+			desc:         "More inventory than limit, rest dropped",
+			ex:           []filesystem.Extractor{fakeExWithManyResults, fakeEx2WithInv1},
+			maxInventory: 1,
+			wantInv: []*extractor.Inventory{
+				&extractor.Inventory{
+					Name:      name1,
+					Locations: []string{path1},
+					Extractor: fakeExWithManyResults,
+				},
+				&extractor.Inventory{
+					Name:      name1,
+					Locations: []string{path2},
+					Extractor: fakeEx2WithInv1,
+				},
+			},
+			wantStatus: []*plugin.Status{
+				&plugin.Status{Name: "ex1", Version: 1, Status: &plugin.ScanStatus{
+					Status:           plugin.ScanStatusSucceeded,
+					DroppedInventory: 2,
+					InventoryCount:   1,
+				}},
+				&plugin.Status{Name: "ex2", Version: 2, Status: successWithInv},
 			},
 			wantInodeCount: 6,
 		},
@@ -467,8 +660,76 @@ func TestRunFS(t *testing.T) {
 			},
 			storeAbsPath: true,
 			wantStatus: []*plugin.Status{
-				&plugin.Status{Name: "ex1", Version: 1, Status: success},
-				&plugin.Status{Name: "ex2", Version: 2, Status: success},
+				&plugin.Status{Name: "ex1", Version: 1, Status: successWithInv},
+				&plugin.Status{Name: "ex2", Version: 2, Status: successWithInv},
+			},
+			wantInodeCount: 6,
+		},
+		{
+			desc: "Location prefix trimmed from absolute path",
+			ex:   []filesystem.Extractor{fakeEx1, fakeEx2},
+			wantInv: []*extractor.Inventory{
+				&extractor.Inventory{
+					Name:      name1,
+					Locations: []string{filepath.Join(filepath.Base(cwd), path1)},
+					Extractor: fakeEx1,
+				},
+				&extractor.Inventory{
+					Name:      name2,
+					Locations: []string{filepath.Join(filepath.Base(cwd), path2)},
+					Extractor: fakeEx2,
+				},
+			},
+			storeAbsPath: true,
+			prefixTrim:   filepath.Dir(cwd),
+			wantStatus: []*plugin.Status{
+				&plugin.Status{Name: "ex1", Version: 1, Status: successWithInv},
+				&plugin.Status{Name: "ex2", Version: 2, Status: successWithInv},
+			},
+			wantInodeCount: 6,
+		},
+		{
+			desc: "Absolute path stored with forward slashes",
+			ex:   []filesystem.Extractor{fakeEx1, fakeEx2},
+			wantInv: []*extractor.Inventory{
+				&extractor.Inventory{
+					Name:      name1,
+					Locations: []string{filepath.ToSlash(filepath.Join(cwd, path1))},
+					Extractor: fakeEx1,
+				},
+				&extractor.Inventory{
+					Name:      name2,
+					Locations: []string{filepath.ToSlash(filepath.Join(cwd, path2))},
+					Extractor: fakeEx2,
+				},
+			},
+			storeAbsPath: true,
+			posixPaths:   true,
+			wantStatus: []*plugin.Status{
+				&plugin.Status{Name: "ex1", Version: 1, Status: successWithInv},
+				&plugin.Status{Name: "ex2", Version: 2, Status: successWithInv},
+			},
+			wantInodeCount: 6,
+		},
+		{
+			desc: "Location prefix trimmed from relative path",
+			ex:   []filesystem.Extractor{fakeEx1, fakeEx2},
+			wantInv: []*extractor.Inventory{
+				&extractor.Inventory{
+					Name:      name1,
+					Locations: []string{"file1.txt"},
+					Extractor: fakeEx1,
+				},
+				&extractor.Inventory{
+					Name:      name2,
+					Locations: []string{path2},
+					Extractor: fakeEx2,
+				},
+			},
+			prefixTrim: "dir1",
+			wantStatus: []*plugin.Status{
+				&plugin.Status{Name: "ex1", Version: 1, Status: successWithInv},
+				&plugin.Status{Name: "ex2", Version: 2, Status: successWithInv},
 			},
 			wantInodeCount: 6,
 		},
@@ -481,17 +742,26 @@ func TestRunFS(t *testing.T) {
 			if tc.skipDirRegex != "" {
 				skipDirRegex = regexp.MustCompile(tc.skipDirRegex)
 			}
+			var includeDirRegex *regexp.Regexp
+			if tc.includeDirRegex != "" {
+				includeDirRegex = regexp.MustCompile(tc.includeDirRegex)
+			}
 			config := &filesystem.Config{
-				Extractors:     tc.ex,
-				FilesToExtract: tc.filesToExtract,
-				DirsToSkip:     tc.dirsToSkip,
-				SkipDirRegex:   skipDirRegex,
-				MaxInodes:      tc.maxInodes,
+				Extractors:               tc.ex,
+				FilesToExtract:           tc.filesToExtract,
+				DirsToSkip:               tc.dirsToSkip,
+				SkipDirRegex:             skipDirRegex,
+				IncludeDirRegex:          includeDirRegex,
+				MaxInodes:                tc.maxInodes,
+				MaxInodesBehavior:        tc.inodesBehavior,
+				MaxInventoryPerExtractor: tc.maxInventory,
 				ScanRoots: []*scalibrfs.ScanRoot{&scalibrfs.ScanRoot{
 					FS: fsys, Path: ".",
 				}},
-				Stats:             fc,
-				StoreAbsolutePath: tc.storeAbsPath,
+				Stats:              fc,
+				StoreAbsolutePath:  tc.storeAbsPath,
+				LocationPrefixTrim: tc.prefixTrim,
+				PosixPaths:         tc.posixPaths,
 			}
 			wc, err := filesystem.InitWalkContext(
 				context.Background(), config, []*scalibrfs.ScanRoot{&scalibrfs.ScanRoot{
@@ -518,7 +788,9 @@ func TestRunFS(t *testing.T) {
 				sort.Strings(i.Locations)
 			}
 
-			if diff := cmp.Diff(tc.wantInv, gotInv, cmpopts.SortSlices(invLess), fe.AllowUnexported, cmpopts.EquateErrors()); diff != "" {
+			// The scan root is tested in TestRun_ScanRoot.
+			ignoreScanRoot := cmpopts.IgnoreFields(extractor.Inventory{}, "ScanRoot")
+			if diff := cmp.Diff(tc.wantInv, gotInv, cmpopts.SortSlices(invLess), fe.AllowUnexported, cmpopts.EquateErrors(), ignoreScanRoot); diff != "" {
 				t.Errorf("extractor.Run(%v): unexpected findings (-want +got):\n%s", tc.ex, diff)
 			}
 
@@ -536,10 +808,15 @@ func TestRunFS(t *testing.T) {
 type fakeCollector struct {
 	stats.NoopCollector
 	AfterInodeVisitedCount int
+	UnmatchedFiles         *stats.UnmatchedFilesStats
 }
 
 func (c *fakeCollector) AfterInodeVisited(path string) { c.AfterInodeVisitedCount++ }
 
+func (c *fakeCollector) AfterFilesUnmatched(filestats *stats.UnmatchedFilesStats) {
+	c.UnmatchedFiles = filestats
+}
+
 func invLess(i1, i2 *extractor.Inventory) bool {
 	if i1.Name != i2.Name {
 		return i1.Name < i2.Name
@@ -645,3 +922,1051 @@ func TestRunFS_ReadError(t *testing.T) {
 		t.Errorf("extractor.Run(%v): unexpected status (-want +got):\n%s", ex, diff)
 	}
 }
+
+func TestRunFS_ReportUnmatched(t *testing.T) {
+	fsys := pathsMapFS{
+		mapfs: fstest.MapFS{
+			".":                {Mode: fs.ModeDir},
+			"dir":              {Mode: fs.ModeDir},
+			"dir/required.txt": {Data: []byte("Content")},
+			"dir/other.txt":    {Data: []byte("Content")},
+			"dir/lib.so":       {Data: []byte("Content")},
+			"dir/LIB2.SO":      {Data: []byte("Content")},
+			"dir/Makefile":     {Data: []byte("Content")},
+		},
+	}
+	ex := []filesystem.Extractor{
+		fe.New("ex1", 1, []string{"dir/required.txt"},
+			map[string]fe.NamesErr{"dir/required.txt": {Names: []string{"software"}, Err: nil}}),
+	}
+	want := &stats.UnmatchedFilesStats{
+		Total: 4,
+		ByExtension: map[string]int{
+			".so":    2,
+			".txt":   1,
+			"<none>": 1,
+		},
+	}
+
+	fc := &fakeCollector{}
+	config := &filesystem.Config{
+		Extractors: ex,
+		ScanRoots: []*scalibrfs.ScanRoot{&scalibrfs.ScanRoot{
+			FS: fsys, Path: ".",
+		}},
+		Stats:           fc,
+		ReportUnmatched: true,
+	}
+	wc, err := filesystem.InitWalkContext(context.Background(), config, config.ScanRoots)
+	if err != nil {
+		t.Fatalf("filesystem.InitializeWalkContext(%v): %v", config, err)
+	}
+	if err := wc.UpdateScanRoot(".", fsys); err != nil {
+		t.Fatalf("wc.UpdateScanRoot(%v): %v", config, err)
+	}
+	if _, _, err := filesystem.RunFS(context.Background(), config, wc); err != nil {
+		t.Fatalf("extractor.Run(%v): %v", ex, err)
+	}
+
+	if diff := cmp.Diff(want, fc.UnmatchedFiles); diff != "" {
+		t.Errorf("extractor.Run(%v): unexpected unmatched files (-want +got):\n%s", ex, diff)
+	}
+}
+
+func TestScanFS_Since(t *testing.T) {
+	since := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	old := since.Add(-time.Hour)
+	recent := since.Add(time.Hour)
+	fsys := fstest.MapFS{
+		"olddir":           {Mode: fs.ModeDir, ModTime: old},
+		"olddir/new.txt":   {Data: []byte("Content"), ModTime: recent},
+		"olddir/old.txt":   {Data: []byte("Content"), ModTime: old},
+		"olddir/other.txt": {Data: []byte("Content"), ModTime: old},
+	}
+	fakeEx := fe.New("ex", 1, []string{"olddir/new.txt", "olddir/old.txt"}, map[string]fe.NamesErr{
+		"olddir/new.txt": {Names: []string{"new-software"}, Err: nil},
+		"olddir/old.txt": {Names: []string{"old-software"}, Err: nil},
+	})
+	previous := []*extractor.Inventory{
+		// Unchanged file, should be reused.
+		&extractor.Inventory{Name: "old-software-from-previous-scan", Locations: []string{"olddir/old.txt"}, Extractor: fakeEx},
+		// Inside an unchanged file, should be reused.
+		&extractor.Inventory{Name: "nested-software", Locations: []string{"olddir/old.txt/inner.jar"}, Extractor: fakeEx},
+		// Changed file, should be replaced by the new results.
+		&extractor.Inventory{Name: "stale-software", Locations: []string{"olddir/new.txt"}, Extractor: fakeEx},
+		// Deleted file, should be dropped.
+		&extractor.Inventory{Name: "deleted-software", Locations: []string{"olddir/deleted.txt"}, Extractor: fakeEx},
+	}
+
+	testCases := []struct {
+		desc        string
+		config      *filesystem.Config
+		wantInv     []*extractor.Inventory
+		wantDropped int
+	}{
+		{
+			desc:   "no previous inventory",
+			config: &filesystem.Config{Since: since},
+			wantInv: []*extractor.Inventory{
+				&extractor.Inventory{Name: "new-software", Locations: []string{"olddir/new.txt"}, Extractor: fakeEx},
+			},
+		},
+		{
+			desc:   "merged with previous inventory",
+			config: &filesystem.Config{Since: since, PreviousInventory: previous},
+			wantInv: []*extractor.Inventory{
+				&extractor.Inventory{Name: "new-software", Locations: []string{"olddir/new.txt"}, Extractor: fakeEx},
+				&extractor.Inventory{Name: "old-software-from-previous-scan", Locations: []string{"olddir/old.txt"}, Extractor: fakeEx},
+				&extractor.Inventory{Name: "nested-software", Locations: []string{"olddir/old.txt/inner.jar"}, Extractor: fakeEx},
+			},
+		},
+		{
+			desc:   "previous inventory counted against the limit",
+			config: &filesystem.Config{Since: since, PreviousInventory: previous, MaxInventoryPerExtractor: 2},
+			wantInv: []*extractor.Inventory{
+				&extractor.Inventory{Name: "new-software", Locations: []string{"olddir/new.txt"}, Extractor: fakeEx},
+				&extractor.Inventory{Name: "old-software-from-previous-scan", Locations: []string{"olddir/old.txt"}, Extractor: fakeEx},
+			},
+			wantDropped: 1,
+		},
+		{
+			desc:   "since not set",
+			config: &filesystem.Config{PreviousInventory: previous},
+			wantInv: []*extractor.Inventory{
+				&extractor.Inventory{Name: "new-software", Locations: []string{"olddir/new.txt"}, Extractor: fakeEx},
+				&extractor.Inventory{Name: "old-software", Locations: []string{"olddir/old.txt"}, Extractor: fakeEx},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			ex := []filesystem.Extractor{fakeEx}
+			gotInv, gotStatus, err := filesystem.ScanFS(context.Background(), fsys, ex, tc.config)
+			if err != nil {
+				t.Fatalf("filesystem.ScanFS(%v): %v", ex, err)
+			}
+			if diff := cmp.Diff(tc.wantInv, gotInv, cmpopts.SortSlices(invLess), fe.AllowUnexported); diff != "" {
+				t.Errorf("filesystem.ScanFS(%v) unexpected inventory (-want +got):\n%s", ex, diff)
+			}
+			wantStatus := []*plugin.Status{&plugin.Status{Name: "ex", Version: 1, Status: &plugin.ScanStatus{
+				Status:           plugin.ScanStatusSucceeded,
+				InventoryCount:   len(tc.wantInv),
+				DroppedInventory: tc.wantDropped,
+			}}}
+			if diff := cmp.Diff(wantStatus, gotStatus); diff != "" {
+				t.Errorf("filesystem.ScanFS(%v) unexpected status (-want +got):\n%s", ex, diff)
+			}
+		})
+	}
+}
+
+func TestScanFS(t *testing.T) {
+	success := &plugin.ScanStatus{Status: plugin.ScanStatusSucceeded}
+	successWithInv := &plugin.ScanStatus{Status: plugin.ScanStatusSucceeded, InventoryCount: 1}
+	path1 := "dir1/file1.txt"
+	path2 := "dir2/file2.txt"
+	// A plain fs.FS that doesn't implement ReadDirFS or StatFS.
+	fsys := openOnlyFS{fstest.MapFS{
+		path1: {Data: []byte("Content 1")},
+		path2: {Data: []byte("Content 2")},
+	}}
+	fakeEx1 := fe.New("ex1", 1, []string{path1}, map[string]fe.NamesErr{path1: {Names: []string{"software1"}, Err: nil}})
+	fakeEx2 := fe.New("ex2", 2, []string{path2}, map[string]fe.NamesErr{path2: {Names: []string{"software2"}, Err: nil}})
+
+	testCases := []struct {
+		desc       string
+		config     *filesystem.Config
+		wantInv    []*extractor.Inventory
+		wantStatus []*plugin.Status
+	}{
+		{
+			desc: "nil config",
+			wantInv: []*extractor.Inventory{
+				&extractor.Inventory{Name: "software1", Locations: []string{path1}, Extractor: fakeEx1},
+				&extractor.Inventory{Name: "software2", Locations: []string{path2}, Extractor: fakeEx2},
+			},
+			wantStatus: []*plugin.Status{
+				&plugin.Status{Name: "ex1", Version: 1, Status: successWithInv},
+				&plugin.Status{Name: "ex2", Version: 2, Status: successWithInv},
+			},
+		},
+		{
+			desc:   "Dir skipped relative to fsys",
+			config: &filesystem.Config{DirsToSkip: []string{"dir1"}},
+			wantInv: []*extractor.Inventory{
+				&extractor.Inventory{Name: "software2", Locations: []string{path2}, Extractor: fakeEx2},
+			},
+			wantStatus: []*plugin.Status{
+				&plugin.Status{Name: "ex1", Version: 1, Status: success},
+				&plugin.Status{Name: "ex2", Version: 2, Status: successWithInv},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			ex := []filesystem.Extractor{fakeEx1, fakeEx2}
+			gotInv, gotStatus, err := filesystem.ScanFS(context.Background(), fsys, ex, tc.config)
+			if err != nil {
+				t.Fatalf("filesystem.ScanFS(%v): %v", ex, err)
+			}
+			if diff := cmp.Diff(tc.wantInv, gotInv, cmpopts.SortSlices(invLess), fe.AllowUnexported); diff != "" {
+				t.Errorf("filesystem.ScanFS(%v): unexpected findings (-want +got):\n%s", ex, diff)
+			}
+			sortStatus := func(s1, s2 *plugin.Status) bool {
+				return s1.Name < s2.Name
+			}
+			if diff := cmp.Diff(tc.wantStatus, gotStatus, cmpopts.SortSlices(sortStatus)); diff != "" {
+				t.Errorf("filesystem.ScanFS(%v): unexpected status (-want +got):\n%s", ex, diff)
+			}
+		})
+	}
+}
+
+func TestRun_FilesToExtractRelative(t *testing.T) {
+	path1 := filepath.FromSlash("dir1/file1.txt")
+	path2 := filepath.FromSlash("dir2/file2.txt")
+	root1 := t.TempDir()
+	root2 := t.TempDir()
+	for _, p := range []string{filepath.Join(root1, path1), filepath.Join(root1, path2), filepath.Join(root2, path2)} {
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatalf("os.MkdirAll(%s): %v", filepath.Dir(p), err)
+		}
+		if err := os.WriteFile(p, []byte("Content"), 0644); err != nil {
+			t.Fatalf("os.WriteFile(%s): %v", p, err)
+		}
+	}
+	fakeEx1 := fe.New("ex1", 1, []string{"dir1/file1.txt"}, map[string]fe.NamesErr{"dir1/file1.txt": {Names: []string{"software1"}}})
+	fakeEx2 := fe.New("ex2", 2, []string{"dir2/file2.txt"}, map[string]fe.NamesErr{"dir2/file2.txt": {Names: []string{"software2"}}})
+
+	config := &filesystem.Config{
+		Extractors: []filesystem.Extractor{fakeEx1, fakeEx2},
+		ScanRoots: []*scalibrfs.ScanRoot{
+			{FS: scalibrfs.DirFS(root1), Path: root1},
+			{FS: scalibrfs.DirFS(root2), Path: root2},
+		},
+		// The file only exists in root1 and is skipped in root2.
+		FilesToExtractRelative: []string{"dir1/file1.txt"},
+		StoreAbsolutePath:      true,
+		Stats:                  stats.NoopCollector{},
+	}
+	gotInv, gotStatus, err := filesystem.Run(context.Background(), config)
+	if err != nil {
+		t.Fatalf("filesystem.Run(%v): %v", config, err)
+	}
+	wantInv := []*extractor.Inventory{
+		&extractor.Inventory{Name: "software1", Locations: []string{filepath.Join(root1, path1)}, Extractor: fakeEx1, ScanRoot: root1},
+	}
+	if diff := cmp.Diff(wantInv, gotInv, fe.AllowUnexported); diff != "" {
+		t.Errorf("filesystem.Run(%v): unexpected inventory (-want +got):\n%s", config, diff)
+	}
+	wantStatus := []*plugin.Status{
+		&plugin.Status{Name: "ex1", Version: 1, Status: &plugin.ScanStatus{Status: plugin.ScanStatusSucceeded, InventoryCount: 1}},
+		&plugin.Status{Name: "ex2", Version: 2, Status: &plugin.ScanStatus{Status: plugin.ScanStatusSucceeded}},
+	}
+	sortStatus := func(s1, s2 *plugin.Status) bool {
+		return s1.Name < s2.Name
+	}
+	if diff := cmp.Diff(wantStatus, gotStatus, cmpopts.SortSlices(sortStatus)); diff != "" {
+		t.Errorf("filesystem.Run(%v): unexpected status (-want +got):\n%s", config, diff)
+	}
+}
+
+func TestRun_ScanRoot(t *testing.T) {
+	path1 := "dir1/file1.txt"
+	root1 := t.TempDir()
+	root2 := t.TempDir()
+	for _, root := range []string{root1, root2} {
+		p := filepath.Join(root, filepath.FromSlash(path1))
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatalf("os.MkdirAll(%s): %v", filepath.Dir(p), err)
+		}
+		if err := os.WriteFile(p, []byte("Content"), 0644); err != nil {
+			t.Fatalf("os.WriteFile(%s): %v", p, err)
+		}
+	}
+	fakeEx := fe.New("ex1", 1, []string{path1}, map[string]fe.NamesErr{path1: {Names: []string{"software1"}}})
+
+	config := &filesystem.Config{
+		Extractors: []filesystem.Extractor{fakeEx},
+		ScanRoots: []*scalibrfs.ScanRoot{
+			{FS: scalibrfs.DirFS(root1), Path: root1},
+			{FS: scalibrfs.DirFS(root2), Path: root2},
+		},
+		Stats: stats.NoopCollector{},
+	}
+	gotInv, _, err := filesystem.Run(context.Background(), config)
+	if err != nil {
+		t.Fatalf("filesystem.Run(%v): %v", config, err)
+	}
+	// The relative locations are the same, only the scan root tells them apart.
+	wantInv := []*extractor.Inventory{
+		&extractor.Inventory{Name: "software1", Locations: []string{path1}, Extractor: fakeEx, ScanRoot: root1},
+		&extractor.Inventory{Name: "software1", Locations: []string{path1}, Extractor: fakeEx, ScanRoot: root2},
+	}
+	if diff := cmp.Diff(wantInv, gotInv, fe.AllowUnexported); diff != "" {
+		t.Errorf("filesystem.Run(%v): unexpected inventory (-want +got):\n%s", config, diff)
+	}
+}
+
+func TestScanFS_Strict(t *testing.T) {
+	path1 := "dir1/file1.txt"
+	path2 := "dir2/file2.txt"
+	fsys := fstest.MapFS{
+		path1: {Data: []byte("Content 1")},
+		path2: {Data: []byte("Content 2")},
+	}
+	fakeEx1 := fe.New("ex1", 1, []string{path1}, map[string]fe.NamesErr{path1: {Names: nil, Err: errors.New("extraction failed")}})
+	fakeEx2 := fe.New("ex2", 2, []string{path2}, map[string]fe.NamesErr{path2: {Names: []string{"software2"}, Err: nil}})
+	ex := []filesystem.Extractor{fakeEx1, fakeEx2}
+	wantInv := []*extractor.Inventory{
+		&extractor.Inventory{Name: "software2", Locations: []string{path2}, Extractor: fakeEx2},
+	}
+	wantStatus := []*plugin.Status{
+		&plugin.Status{Name: "ex1", Version: 1, Status: &plugin.ScanStatus{
+			Status: plugin.ScanStatusFailed, FailureReason: fmt.Sprintf("%s: extraction failed", path1),
+		}},
+		&plugin.Status{Name: "ex2", Version: 2, Status: &plugin.ScanStatus{Status: plugin.ScanStatusSucceeded, InventoryCount: 1}},
+	}
+	sortStatus := func(s1, s2 *plugin.Status) bool {
+		return s1.Name < s2.Name
+	}
+
+	testCases := []struct {
+		desc    string
+		strict  bool
+		wantErr error
+	}{
+		{
+			desc:    "extractor errors only reported in the statuses",
+			strict:  false,
+			wantErr: nil,
+		},
+		{
+			desc:    "strict mode returns an error",
+			strict:  true,
+			wantErr: filesystem.ErrExtractorsFailed,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			config := &filesystem.Config{Strict: tc.strict}
+			gotInv, gotStatus, err := filesystem.ScanFS(context.Background(), fsys, ex, config)
+			if diff := cmp.Diff(tc.wantErr, err, cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("filesystem.ScanFS(%v) unexpected error (-want +got):\n%s", ex, diff)
+			}
+			// The results are returned even if the scan failed.
+			if diff := cmp.Diff(wantInv, gotInv, fe.AllowUnexported); diff != "" {
+				t.Errorf("filesystem.ScanFS(%v): unexpected inventory (-want +got):\n%s", ex, diff)
+			}
+			if diff := cmp.Diff(wantStatus, gotStatus, cmpopts.SortSlices(sortStatus)); diff != "" {
+				t.Errorf("filesystem.ScanFS(%v): unexpected status (-want +got):\n%s", ex, diff)
+			}
+		})
+	}
+}
+
+// slowExtractor blocks on slowPath until the context is canceled and
+// delegates the other files to the wrapped extractor.
+type slowExtractor struct {
+	filesystem.Extractor
+	slowPath string
+}
+
+func (e *slowExtractor) Extract(ctx context.Context, input *filesystem.ScanInput) ([]*extractor.Inventory, error) {
+	if input.Path != e.slowPath {
+		return e.Extractor.Extract(ctx, input)
+	}
+	<-ctx.Done()
+	return []*extractor.Inventory{&extractor.Inventory{Name: "partial", Locations: []string{input.Path}}}, ctx.Err()
+}
+
+// locationDetailsExtractor reports the lockfile entry of each inventory item
+// found by the wrapped extractor as a location detail.
+type locationDetailsExtractor struct {
+	filesystem.Extractor
+}
+
+func (e *locationDetailsExtractor) Extract(ctx context.Context, input *filesystem.ScanInput) ([]*extractor.Inventory, error) {
+	inv, err := e.Extractor.Extract(ctx, input)
+	for _, i := range inv {
+		i.LocationDetails = []*extractor.Location{{Path: "dir1/package-lock.json", Kind: extractor.LocationKindManifest, Line: 12}}
+	}
+	return inv, err
+}
+
+func TestScanFS_LocationDetails(t *testing.T) {
+	path := "dir1/file1.txt"
+	fsys := fstest.MapFS{path: {Data: []byte("Content")}}
+	ex := &locationDetailsExtractor{fe.New("ex", 1, []string{path}, map[string]fe.NamesErr{path: {Names: []string{"software"}}})}
+	config := &filesystem.Config{LocationPrefixTrim: "dir1"}
+
+	gotInv, _, err := filesystem.ScanFS(context.Background(), fsys, []filesystem.Extractor{ex}, config)
+	if err != nil {
+		t.Fatalf("filesystem.ScanFS(%v): %v", ex, err)
+	}
+	want := []*extractor.Inventory{{
+		Name:            "software",
+		Locations:       []string{"file1.txt", "package-lock.json"},
+		LocationDetails: []*extractor.Location{{Path: "package-lock.json", Kind: extractor.LocationKindManifest, Line: 12}},
+		Extractor:       ex,
+	}}
+	if diff := cmp.Diff(want, gotInv, fe.AllowUnexported, cmp.AllowUnexported(locationDetailsExtractor{})); diff != "" {
+		t.Errorf("filesystem.ScanFS(%v): unexpected findings (-want +got):\n%s", ex, diff)
+	}
+}
+
+func TestScanFS_ExtractorOverrides(t *testing.T) {
+	fsys := fstest.MapFS{
+		"small.txt": {Data: []byte("small")},
+		"big.txt":   {Data: []byte("this file is too big")},
+		"slow.txt":  {Data: []byte("slow")},
+	}
+	files := []string{"small.txt", "big.txt", "slow.txt"}
+	namesErr := map[string]fe.NamesErr{
+		"small.txt": {Names: []string{"software1"}},
+		"big.txt":   {Names: []string{"software2"}},
+	}
+	bounded := &slowExtractor{Extractor: fe.New("bounded", 1, files, namesErr), slowPath: "slow.txt"}
+	other := fe.New("other", 1, []string{"big.txt"}, namesErr)
+	ex := []filesystem.Extractor{bounded, other}
+	config := &filesystem.Config{
+		ExtractorOverrides: map[string]filesystem.ExtractorOverride{
+			"bounded": {Timeout: 10 * time.Millisecond, MaxFileSizeBytes: 10},
+		},
+	}
+
+	gotInv, gotStatus, err := filesystem.ScanFS(context.Background(), fsys, ex, config)
+	if err != nil {
+		t.Fatalf("filesystem.ScanFS(%v): %v", ex, err)
+	}
+	wantInv := []*extractor.Inventory{
+		&extractor.Inventory{Name: "software1", Locations: []string{"small.txt"}, Extractor: bounded},
+		&extractor.Inventory{Name: "software2", Locations: []string{"big.txt"}, Extractor: other},
+	}
+	if diff := cmp.Diff(wantInv, gotInv, cmpopts.SortSlices(invLess), fe.AllowUnexported, cmp.AllowUnexported(slowExtractor{})); diff != "" {
+		t.Errorf("filesystem.ScanFS(%v): unexpected findings (-want +got):\n%s", ex, diff)
+	}
+	wantStatus := []*plugin.Status{
+		&plugin.Status{Name: "bounded", Version: 1, Status: &plugin.ScanStatus{
+			Status:         plugin.ScanStatusSucceeded,
+			InventoryCount: 1,
+			TimedOutFiles:  1,
+			OversizedFiles: 1,
+		}},
+		&plugin.Status{Name: "other", Version: 1, Status: &plugin.ScanStatus{Status: plugin.ScanStatusSucceeded, InventoryCount: 1}},
+	}
+	if diff := cmp.Diff(wantStatus, gotStatus); diff != "" {
+		t.Errorf("filesystem.ScanFS(%v): unexpected status (-want +got):\n%s", ex, diff)
+	}
+}
+
+// osReleaseExtractor records the os-release fields passed to it.
+type osReleaseExtractor struct {
+	filesystem.Extractor
+	got map[string]string
+}
+
+func (e *osReleaseExtractor) Extract(ctx context.Context, input *filesystem.ScanInput) ([]*extractor.Inventory, error) {
+	e.got = input.OSRelease
+	return nil, nil
+}
+
+func TestScanFS_OSRelease(t *testing.T) {
+	osRelease := "ID=debian\nVERSION_ID=\"12\"\n"
+	for _, tc := range []struct {
+		desc   string
+		fsys   fstest.MapFS
+		config *filesystem.Config
+		want   map[string]string
+	}{
+		{
+			desc: "Read from the scan root",
+			fsys: fstest.MapFS{
+				"etc/os-release": {Data: []byte(osRelease)},
+				"file":           {Data: []byte("content")},
+			},
+			want: map[string]string{"ID": "debian", "VERSION_ID": "12"},
+		},
+		{
+			desc: "No os-release file",
+			fsys: fstest.MapFS{"file": {Data: []byte("content")}},
+		},
+		{
+			desc: "Set in the config",
+			fsys: fstest.MapFS{
+				"etc/os-release": {Data: []byte(osRelease)},
+				"file":           {Data: []byte("content")},
+			},
+			config: &filesystem.Config{OSRelease: map[string]string{"ID": "alpine"}},
+			want:   map[string]string{"ID": "alpine"},
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			ex := &osReleaseExtractor{Extractor: fe.New("ex", 1, []string{"file"}, nil)}
+			if _, _, err := filesystem.ScanFS(context.Background(), tc.fsys, []filesystem.Extractor{ex}, tc.config); err != nil {
+				t.Fatalf("filesystem.ScanFS(): %v", err)
+			}
+			if diff := cmp.Diff(tc.want, ex.got); diff != "" {
+				t.Errorf("filesystem.ScanFS(): unexpected os-release passed to extractor (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+// traceLogger records the trace logs.
+type traceLogger struct {
+	log.DefaultLogger
+	traces []string
+}
+
+func (l *traceLogger) Tracef(format string, args ...any) {
+	l.traces = append(l.traces, fmt.Sprintf(format, args...))
+}
+
+func TestScanFS_TraceFileRequired(t *testing.T) {
+	fsys := fstest.MapFS{
+		"a.txt": {Data: []byte("a")},
+		"b.txt": {Data: []byte("b")},
+	}
+	ex := []filesystem.Extractor{fe.New("ex1", 1, []string{"a.txt"}, nil)}
+
+	for _, tc := range []struct {
+		desc       string
+		trace      bool
+		wantTraces []string
+	}{
+		{
+			desc:       "Tracing enabled",
+			trace:      true,
+			wantTraces: []string{"ex1: FileRequired(a.txt) = true", "ex1: FileRequired(b.txt) = false"},
+		},
+		{
+			desc:  "Tracing disabled",
+			trace: false,
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			l := &traceLogger{}
+			log.SetLogger(l)
+			defer log.SetLogger(&log.DefaultLogger{})
+
+			config := &filesystem.Config{TraceFileRequired: tc.trace}
+			if _, _, err := filesystem.ScanFS(context.Background(), fsys, ex, config); err != nil {
+				t.Fatalf("filesystem.ScanFS(%v): %v", ex, err)
+			}
+			if diff := cmp.Diff(tc.wantTraces, l.traces, cmpopts.SortSlices(func(a, b string) bool { return a < b })); diff != "" {
+				t.Errorf("filesystem.ScanFS(%v): unexpected trace logs (-want +got):\n%s", ex, diff)
+			}
+		})
+	}
+}
+
+// countingFS counts how often each file is opened. If unseekable is set, the
+// opened files can only be read sequentially.
+type countingFS struct {
+	fstest.MapFS
+	unseekable bool
+	opens      map[string]int
+}
+
+func (c *countingFS) Open(name string) (fs.File, error) {
+	f, err := c.MapFS.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	if info, err := f.Stat(); err != nil || info.IsDir() {
+		return f, err
+	}
+	c.opens[name]++
+	if c.unseekable {
+		return sequentialFile{f}, nil
+	}
+	return f, nil
+}
+
+// sequentialFile hides the io.Seeker and io.ReaderAt methods of the file.
+type sequentialFile struct {
+	f fs.File
+}
+
+func (s sequentialFile) Stat() (fs.FileInfo, error) { return s.f.Stat() }
+func (s sequentialFile) Read(b []byte) (int, error) { return s.f.Read(b) }
+func (s sequentialFile) Close() error               { return s.f.Close() }
+
+// contentExtractor reports the content of the extracted files as the
+// inventory name.
+type contentExtractor struct {
+	filesystem.Extractor
+}
+
+func (e *contentExtractor) Extract(ctx context.Context, input *filesystem.ScanInput) ([]*extractor.Inventory, error) {
+	content, err := io.ReadAll(input.Reader)
+	if err != nil {
+		return nil, err
+	}
+	return []*extractor.Inventory{&extractor.Inventory{Name: string(content), Locations: []string{input.Path}}}, nil
+}
+
+func TestScanFS_FileOpenedOnce(t *testing.T) {
+	path := "package.json"
+	for _, tc := range []struct {
+		desc       string
+		unseekable bool
+		wantOpens  int
+	}{
+		{
+			desc:      "Seekable file is shared",
+			wantOpens: 1,
+		},
+		{
+			desc:       "Unseekable file is opened for each extractor",
+			unseekable: true,
+			wantOpens:  2,
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			fsys := &countingFS{
+				MapFS:      fstest.MapFS{path: {Data: []byte("content")}},
+				unseekable: tc.unseekable,
+				opens:      map[string]int{},
+			}
+			ex1 := &contentExtractor{fe.New("ex1", 1, []string{path}, nil)}
+			ex2 := &contentExtractor{fe.New("ex2", 1, []string{path}, nil)}
+			ex := []filesystem.Extractor{ex1, ex2}
+
+			gotInv, _, err := filesystem.ScanFS(context.Background(), fsys, ex, nil)
+			if err != nil {
+				t.Fatalf("filesystem.ScanFS(%v): %v", ex, err)
+			}
+			// Both extractors read the file from the start.
+			for _, i := range gotInv {
+				if i.Name != "content" {
+					t.Errorf("filesystem.ScanFS(%v): %s read %q, want %q", ex, i.Extractor.Name(), i.Name, "content")
+				}
+			}
+			if len(gotInv) != 2 {
+				t.Errorf("filesystem.ScanFS(%v): got %d inventory items, want 2", ex, len(gotInv))
+			}
+			if got := fsys.opens[path]; got != tc.wantOpens {
+				t.Errorf("filesystem.ScanFS(%v): %s opened %d times, want %d", ex, path, got, tc.wantOpens)
+			}
+		})
+	}
+}
+
+// sizeExtractor reports the number of bytes read from the extracted files and
+// whether their reader implements io.ReaderAt as the inventory name.
+type sizeExtractor struct {
+	filesystem.Extractor
+}
+
+func (e *sizeExtractor) Extract(ctx context.Context, input *filesystem.ScanInput) ([]*extractor.Inventory, error) {
+	content, err := io.ReadAll(input.Reader)
+	if err != nil {
+		return nil, err
+	}
+	_, readerAt := input.Reader.(io.ReaderAt)
+	name := fmt.Sprintf("%d bytes, ReaderAt: %t", len(content), readerAt)
+	return []*extractor.Inventory{&extractor.Inventory{Name: name, Locations: []string{input.Path}}}, nil
+}
+
+func TestScanFS_MaxBytesPerSecond(t *testing.T) {
+	path := "file.bin"
+	fsys := fstest.MapFS{path: {Data: make([]byte, 150*1024)}}
+	ex := []filesystem.Extractor{&sizeExtractor{fe.New("ex1", 1, []string{path}, nil)}}
+	wantName := "153600 bytes, ReaderAt: true"
+
+	for _, tc := range []struct {
+		desc              string
+		maxBytesPerSecond int64
+		wantMinDuration   time.Duration
+	}{
+		{
+			desc:            "Unlimited",
+			wantMinDuration: 0,
+		},
+		{
+			// One second worth of bytes is read without waiting, the remaining
+			// 50 KiB and the cost of opening the file take about half a second.
+			desc:              "Limited",
+			maxBytesPerSecond: 100 * 1024,
+			wantMinDuration:   400 * time.Millisecond,
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			config := &filesystem.Config{MaxBytesPerSecond: tc.maxBytesPerSecond}
+			start := time.Now()
+			gotInv, _, err := filesystem.ScanFS(context.Background(), fsys, ex, config)
+			elapsed := time.Since(start)
+			if err != nil {
+				t.Fatalf("filesystem.ScanFS(%v): %v", ex, err)
+			}
+			if len(gotInv) != 1 || gotInv[0].Name != wantName {
+				t.Errorf("filesystem.ScanFS(%v): got %v, want one inventory item named %q", ex, gotInv, wantName)
+			}
+			if elapsed < tc.wantMinDuration {
+				t.Errorf("filesystem.ScanFS(%v) took %v, want at least %v", ex, elapsed, tc.wantMinDuration)
+			}
+		})
+	}
+}
+
+func TestScanFS_MaxBytesPerSecondCanceled(t *testing.T) {
+	path := "file.bin"
+	fsys := fstest.MapFS{path: {Data: make([]byte, 1024*1024)}}
+	ex := []filesystem.Extractor{&sizeExtractor{fe.New("ex1", 1, []string{path}, nil)}}
+	// Reading the file would take about 17 minutes.
+	config := &filesystem.Config{MaxBytesPerSecond: 1024}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, _, err := filesystem.ScanFS(ctx, fsys, ex, config)
+	if elapsed := time.Since(start); elapsed > 10*time.Second {
+		t.Errorf("filesystem.ScanFS(%v) took %v after the context was canceled", ex, elapsed)
+	}
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("filesystem.ScanFS(%v): got error %v, want %v", ex, err, context.DeadlineExceeded)
+	}
+}
+
+// readCountingFS counts the reads of the opened files.
+type readCountingFS struct {
+	fstest.MapFS
+	reads int
+}
+
+func (c *readCountingFS) Open(name string) (fs.File, error) {
+	f, err := c.MapFS.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	if info, err := f.Stat(); err != nil || info.IsDir() {
+		return f, err
+	}
+	return &readCountingFile{File: f, fsys: c}, nil
+}
+
+type readCountingFile struct {
+	fs.File
+	fsys *readCountingFS
+}
+
+func (f *readCountingFile) Read(b []byte) (int, error) {
+	f.fsys.reads++
+	return f.File.Read(b)
+}
+
+// lineExtractor reads the extracted files in small chunks, like extractors
+// that parse files line by line without buffering them, and reports the
+// number of bytes read as the inventory name.
+type lineExtractor struct {
+	filesystem.Extractor
+}
+
+func (e *lineExtractor) Extract(ctx context.Context, input *filesystem.ScanInput) ([]*extractor.Inventory, error) {
+	buf := make([]byte, 16)
+	var n int
+	for {
+		read, err := input.Reader.Read(buf)
+		n += read
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return []*extractor.Inventory{&extractor.Inventory{Name: fmt.Sprintf("%d bytes", n), Locations: []string{input.Path}}}, nil
+}
+
+func TestScanFS_ReadBufferSize(t *testing.T) {
+	path := "file.txt"
+	for _, tc := range []struct {
+		desc           string
+		readBufferSize int
+		wantReads      int
+	}{
+		{
+			// One read per 16 bytes and one for the EOF.
+			desc:      "Unbuffered",
+			wantReads: 257,
+		},
+		{
+			desc:           "Buffered",
+			readBufferSize: 1024,
+			wantReads:      5,
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			fsys := &readCountingFS{MapFS: fstest.MapFS{path: {Data: make([]byte, 4096)}}}
+			ex := []filesystem.Extractor{&lineExtractor{fe.New("ex1", 1, []string{path}, nil)}}
+			config := &filesystem.Config{ReadBufferSize: tc.readBufferSize}
+
+			gotInv, _, err := filesystem.ScanFS(context.Background(), fsys, ex, config)
+			if err != nil {
+				t.Fatalf("filesystem.ScanFS(%v): %v", ex, err)
+			}
+			if len(gotInv) != 1 || gotInv[0].Name != "4096 bytes" {
+				t.Errorf("filesystem.ScanFS(%v): got %v, want one inventory item named %q", ex, gotInv, "4096 bytes")
+			}
+			if fsys.reads != tc.wantReads {
+				t.Errorf("filesystem.ScanFS(%v): file read %d times, want %d", ex, fsys.reads, tc.wantReads)
+			}
+		})
+	}
+}
+
+func TestScanFS_ReadBufferSizeKeepsReaderAt(t *testing.T) {
+	path := "file.bin"
+	for _, tc := range []struct {
+		desc       string
+		unseekable bool
+		wantName   string
+	}{
+		{
+			desc:     "File with random access",
+			wantName: "4096 bytes, ReaderAt: true",
+		},
+		{
+			desc:       "Sequential file",
+			unseekable: true,
+			wantName:   "4096 bytes, ReaderAt: false",
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			fsys := &countingFS{
+				MapFS:      fstest.MapFS{path: {Data: make([]byte, 4096)}},
+				unseekable: tc.unseekable,
+				opens:      map[string]int{},
+			}
+			// The second extractor reads the shared file from the start too.
+			ex := []filesystem.Extractor{
+				&sizeExtractor{fe.New("ex1", 1, []string{path}, nil)},
+				&sizeExtractor{fe.New("ex2", 1, []string{path}, nil)},
+			}
+			config := &filesystem.Config{ReadBufferSize: 1024}
+
+			gotInv, _, err := filesystem.ScanFS(context.Background(), fsys, ex, config)
+			if err != nil {
+				t.Fatalf("filesystem.ScanFS(%v): %v", ex, err)
+			}
+			if len(gotInv) != 2 {
+				t.Fatalf("filesystem.ScanFS(%v): got %d inventory items, want 2", ex, len(gotInv))
+			}
+			for _, i := range gotInv {
+				if i.Name != tc.wantName {
+					t.Errorf("filesystem.ScanFS(%v): %s reported %q, want %q", ex, i.Extractor.Name(), i.Name, tc.wantName)
+				}
+			}
+		})
+	}
+}
+
+// BenchmarkScanFS_ReadBufferSize compares extracting a file from disk in small
+// reads with and without a read buffer.
+func BenchmarkScanFS_ReadBufferSize(b *testing.B) {
+	dir := b.TempDir()
+	path := "file.txt"
+	if err := os.WriteFile(filepath.Join(dir, path), make([]byte, 1024*1024), 0644); err != nil {
+		b.Fatalf("os.WriteFile(%s): %v", path, err)
+	}
+	fsys := scalibrfs.DirFS(dir)
+	ex := []filesystem.Extractor{&lineExtractor{fe.New("ex1", 1, []string{path}, nil)}}
+
+	for _, size := range []int{0, 4096, 64 * 1024} {
+		b.Run(fmt.Sprintf("buffer=%d", size), func(b *testing.B) {
+			config := &filesystem.Config{ReadBufferSize: size}
+			for range b.N {
+				if _, _, err := filesystem.ScanFS(context.Background(), fsys, ex, config); err != nil {
+					b.Fatalf("filesystem.ScanFS(): %v", err)
+				}
+			}
+		})
+	}
+}
+
+// openOnlyFS hides every method of the underlying FS except Open.
+type openOnlyFS struct {
+	fsys fs.FS
+}
+
+func (o openOnlyFS) Open(name string) (fs.File, error) { return o.fsys.Open(name) }
+
+func TestScanInputSectionReader(t *testing.T) {
+	fsys := fstest.MapFS{"file.bin": {Data: []byte("header|embedded sbom|footer")}}
+	f, err := fsys.Open("file.bin")
+	if err != nil {
+		t.Fatalf("Open(file.bin): %v", err)
+	}
+	defer f.Close()
+
+	input := &filesystem.ScanInput{Path: "file.bin", Reader: f}
+	r := input.SectionReader(7, 13)
+	if r == nil {
+		t.Fatalf("SectionReader(7, 13) returned nil, want a reader")
+	}
+	got, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatalf("ReadAll(SectionReader(7, 13)): %v", err)
+	}
+	if string(got) != "embedded sbom" {
+		t.Errorf("SectionReader(7, 13) read %q, want %q", got, "embedded sbom")
+	}
+
+	// The sequential reader is unaffected.
+	buf := make([]byte, 6)
+	if _, err := io.ReadFull(input.Reader, buf); err != nil {
+		t.Fatalf("ReadFull(Reader): %v", err)
+	}
+	if string(buf) != "header" {
+		t.Errorf("Reader read %q after SectionReader(), want %q", buf, "header")
+	}
+
+	// Readers without random access have no section reader.
+	input = &filesystem.ScanInput{Path: "file.bin", Reader: struct{ io.Reader }{f}}
+	if r := input.SectionReader(7, 13); r != nil {
+		t.Errorf("SectionReader(7, 13) for non-io.ReaderAt reader returned %v, want nil", r)
+	}
+}
+
+// mapResultCache is an in-memory filesystem.ResultCache.
+type mapResultCache map[string]*filesystem.ResultCacheEntry
+
+func (c mapResultCache) Get(key *filesystem.ResultCacheKey) (*filesystem.ResultCacheEntry, bool) {
+	e, ok := c[key.Hash()]
+	return e, ok
+}
+
+func (c mapResultCache) Put(key *filesystem.ResultCacheKey, e *filesystem.ResultCacheEntry) error {
+	c[key.Hash()] = e
+	return nil
+}
+
+// countingContentExtractor is a contentExtractor that counts its Extract calls.
+type countingContentExtractor struct {
+	contentExtractor
+	calls int
+}
+
+func (e *countingContentExtractor) Extract(ctx context.Context, input *filesystem.ScanInput) ([]*extractor.Inventory, error) {
+	e.calls++
+	return e.contentExtractor.Extract(ctx, input)
+}
+
+func TestScanFS_ResultCache(t *testing.T) {
+	paths := []string{"a/package.json", "b/package.json", "c/package.json"}
+	fsys := fstest.MapFS{
+		paths[0]: {Data: []byte("same")},
+		paths[1]: {Data: []byte("same")},
+		paths[2]: {Data: []byte("other")},
+	}
+	cache := mapResultCache{}
+	config := &filesystem.Config{ResultCache: cache}
+	wantInv := []*extractor.Inventory{
+		{Name: "same", Locations: []string{paths[0]}},
+		{Name: "same", Locations: []string{paths[1]}},
+		{Name: "other", Locations: []string{paths[2]}},
+	}
+
+	for _, tc := range []struct {
+		desc      string
+		wantCalls int
+	}{
+		{
+			// The second file with the same content is read from the cache.
+			desc:      "Empty cache",
+			wantCalls: 2,
+		},
+		{
+			desc:      "Populated cache",
+			wantCalls: 0,
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			ex := &countingContentExtractor{contentExtractor: contentExtractor{fe.New("ex1", 1, paths, nil)}}
+			exs := []filesystem.Extractor{ex}
+
+			gotInv, _, err := filesystem.ScanFS(context.Background(), fsys, exs, config)
+			if err != nil {
+				t.Fatalf("filesystem.ScanFS(%v): %v", exs, err)
+			}
+			for _, i := range gotInv {
+				if i.Extractor != ex {
+					t.Errorf("filesystem.ScanFS(%v): %v reported by %v, want %v", exs, i, i.Extractor, ex)
+				}
+			}
+			if diff := cmp.Diff(wantInv, gotInv, cmpopts.IgnoreFields(extractor.Inventory{}, "Extractor")); diff != "" {
+				t.Errorf("filesystem.ScanFS(%v): unexpected inventory (-want +got):\n%s", exs, diff)
+			}
+			if ex.calls != tc.wantCalls {
+				t.Errorf("filesystem.ScanFS(%v): Extract called %d times, want %d", exs, ex.calls, tc.wantCalls)
+			}
+		})
+	}
+	if len(cache) != 2 {
+		t.Errorf("filesystem.ScanFS(): %d cache entries, want 2", len(cache))
+	}
+}
+
+type cachedMetadata struct {
+	Tags   []string
+	Nested *cachedMetadata
+}
+
+// metadataExtractor reports an inventory item with metadata and relationships
+// for each file.
+type metadataExtractor struct {
+	filesystem.Extractor
+}
+
+func (e *metadataExtractor) Extract(ctx context.Context, input *filesystem.ScanInput) ([]*extractor.Inventory, error) {
+	return []*extractor.Inventory{&extractor.Inventory{
+		Name:          "software",
+		Locations:     []string{input.Path},
+		Metadata:      &cachedMetadata{Tags: []string{"tag"}, Nested: &cachedMetadata{Tags: []string{"nested"}}},
+		Annotations:   []extractor.Annotation{extractor.Transitional},
+		Relationships: []*extractor.Relationship{&extractor.Relationship{Type: extractor.DependsOn, TargetPURL: "pkg:npm/dep@1.0.0"}},
+	}}, nil
+}
+
+func TestScanFS_ResultCacheReturnsCopies(t *testing.T) {
+	paths := []string{"a/package.json", "b/package.json"}
+	fsys := fstest.MapFS{
+		paths[0]: {Data: []byte("same")},
+		paths[1]: {Data: []byte("same")},
+	}
+	config := &filesystem.Config{ResultCache: mapResultCache{}}
+	ex := &metadataExtractor{fe.New("ex1", 1, paths, nil)}
+	exs := []filesystem.Extractor{ex}
+	want := func(path string) *extractor.Inventory {
+		inv, _ := ex.Extract(context.Background(), &filesystem.ScanInput{Path: path})
+		inv[0].Extractor = ex
+		return inv[0]
+	}
+	wantInv := []*extractor.Inventory{want(paths[0]), want(paths[1])}
+
+	// The second file is read from the cache in the first scan, both of them in
+	// the second scan.
+	for i := range 2 {
+		gotInv, _, err := filesystem.ScanFS(context.Background(), fsys, exs, config)
+		if err != nil {
+			t.Fatalf("filesystem.ScanFS(%v): %v", exs, err)
+		}
+		if diff := cmp.Diff(wantInv, gotInv, fe.AllowUnexported); diff != "" {
+			t.Errorf("filesystem.ScanFS(%v) #%d: unexpected inventory (-want +got):\n%s", exs, i, diff)
+		}
+		// Modifying the results doesn't affect the cache.
+		for _, inv := range gotInv {
+			m := inv.Metadata.(*cachedMetadata)
+			m.Tags[0] = "modified"
+			m.Nested.Tags = append(m.Nested.Tags, "modified")
+			inv.Annotations[0] = extractor.Unknown
+			inv.Relationships[0].TargetPURL = "pkg:npm/modified@1.0.0"
+			inv.Locations[0] = "modified"
+		}
+	}
+}
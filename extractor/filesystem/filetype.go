@@ -0,0 +1,222 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filesystem
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"io/fs"
+
+	scalibrfs "github.com/google/osv-scalibr/fs"
+)
+
+// FileType is a file format detected from the leading bytes of a file.
+type FileType int
+
+// FileType values.
+const (
+	FileTypeUnknown FileType = iota
+	FileTypeELF
+	FileTypePE
+	FileTypeMachO
+	FileTypeZip
+	FileTypeGzip
+	FileTypeAr
+	FileTypeRPM
+)
+
+// SniffSize is the number of leading bytes DetectFileType needs to recognize
+// all supported file types.
+const SniffSize = 8
+
+var magicBytes = []struct {
+	magic    []byte
+	fileType FileType
+}{
+	{[]byte("\x7fELF"), FileTypeELF},
+	{[]byte("MZ"), FileTypePE},
+	// 32 and 64 bit Mach-O in both byte orders, and fat binaries.
+	{[]byte{0xfe, 0xed, 0xfa, 0xce}, FileTypeMachO},
+	{[]byte{0xfe, 0xed, 0xfa, 0xcf}, FileTypeMachO},
+	{[]byte{0xce, 0xfa, 0xed, 0xfe}, FileTypeMachO},
+	{[]byte{0xcf, 0xfa, 0xed, 0xfe}, FileTypeMachO},
+	{[]byte{0xca, 0xfe, 0xba, 0xbe}, FileTypeMachO},
+	// Regular, empty and spanned zip archives.
+	{[]byte("PK\x03\x04"), FileTypeZip},
+	{[]byte("PK\x05\x06"), FileTypeZip},
+	{[]byte("PK\x07\x08"), FileTypeZip},
+	{[]byte{0x1f, 0x8b}, FileTypeGzip},
+	{[]byte("!<arch>\n"), FileTypeAr},
+	{[]byte{0xed, 0xab, 0xee, 0xdb}, FileTypeRPM},
+}
+
+// DetectFileType returns the type of a file based on its leading bytes. At
+// least SniffSize bytes should be passed unless the file is shorter.
+func DetectFileType(header []byte) FileType {
+	for _, m := range magicBytes {
+		if bytes.HasPrefix(header, m.magic) {
+			return m.fileType
+		}
+	}
+	return FileTypeUnknown
+}
+
+// FileRequiredWithFS is an optional interface for extractors whose decision to
+// extract a file depends on its content, e.g. on its magic bytes. If an
+// extractor implements it, it's called instead of FileRequired.
+type FileRequiredWithFS interface {
+	// FileRequiredWithFS should return true if the file described by path, file
+	// info and content is relevant for the extractor. Checks on the path and
+	// file info should be done first to avoid reading files needlessly.
+	FileRequiredWithFS(path string, fileinfo fs.FileInfo, file *PeekableFile) bool
+}
+
+// PeekableFile gives content-aware FileRequiredWithFS implementations access
+// to the leading bytes of a file. The file is only opened once it's peeked
+// into and the same open file is reused for the following Extract calls of all
+// extractors that require it. Peeking doesn't consume the file, Extract still
+// reads it from the start.
+type PeekableFile struct {
+	fsys   scalibrfs.FS
+	path   string
+	file   fs.File
+	header []byte
+	err    error
+	// Set if the file might not be positioned at its start.
+	consumed bool
+	// The number of times the file was opened.
+	opens int
+	// The hex-encoded SHA-256 digest of the content, once computed.
+	digest string
+}
+
+// NewPeekableFile returns a PeekableFile for the file at path in fsys.
+func NewPeekableFile(fsys scalibrfs.FS, path string) *PeekableFile {
+	return &PeekableFile{fsys: fsys, path: path}
+}
+
+// Peek returns up to n leading bytes of the file. Fewer bytes are returned if
+// the file is shorter.
+func (f *PeekableFile) Peek(n int) ([]byte, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	if len(f.header) >= n {
+		return f.header[:n], nil
+	}
+	if f.err = f.open(); f.err != nil {
+		return nil, f.err
+	}
+	buf := make([]byte, n)
+	var read int
+	var err error
+	if ra, ok := f.file.(io.ReaderAt); ok {
+		read, err = ra.ReadAt(buf, 0)
+	} else {
+		read, err = io.ReadFull(f.file, buf)
+		f.consumed = true
+		f.rewind()
+	}
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+		f.err = err
+		return nil, err
+	}
+	f.header = buf[:read]
+	return f.header, nil
+}
+
+// FileType returns the type of the file as detected by DetectFileType.
+func (f *PeekableFile) FileType() (FileType, error) {
+	header, err := f.Peek(SniffSize)
+	if err != nil {
+		return FileTypeUnknown, err
+	}
+	return DetectFileType(header), nil
+}
+
+// open opens the file if it's not open yet and makes sure that it's read from
+// the start next.
+func (f *PeekableFile) open() error {
+	f.rewind()
+	if f.file != nil {
+		return nil
+	}
+	file, err := f.fsys.Open(f.path)
+	if err != nil {
+		return err
+	}
+	f.file = file
+	f.consumed = false
+	f.opens++
+	return nil
+}
+
+// rewind seeks the open file back to its start if it might have been read.
+// Files that can't be rewound are closed so that they're opened again.
+func (f *PeekableFile) rewind() {
+	if f.file == nil || !f.consumed {
+		return
+	}
+	if s, ok := f.file.(io.Seeker); ok {
+		if _, err := s.Seek(0, io.SeekStart); err == nil {
+			f.consumed = false
+			return
+		}
+	}
+	f.Close()
+}
+
+// reader returns the open file positioned at its start for an Extract call.
+// The file stays owned by the PeekableFile so that the following extractors
+// can reuse it.
+func (f *PeekableFile) reader() (fs.File, error) {
+	if err := f.open(); err != nil {
+		return nil, err
+	}
+	// The extractor can read the file up to any position.
+	f.consumed = true
+	return f.file, nil
+}
+
+// sha256 returns the hex-encoded SHA-256 digest of the file's content. It's
+// only computed once for all extractors that require the file.
+func (f *PeekableFile) sha256() (string, error) {
+	if f.digest != "" {
+		return f.digest, nil
+	}
+	r, err := f.reader()
+	if err != nil {
+		return "", err
+	}
+	h := sha256.New()
+	if _, err := io.Copy(h, r); err != nil {
+		return "", err
+	}
+	f.digest = hex.EncodeToString(h.Sum(nil))
+	return f.digest, nil
+}
+
+// Close closes the file if it's still owned by the PeekableFile.
+func (f *PeekableFile) Close() error {
+	if f.file == nil {
+		return nil
+	}
+	err := f.file.Close()
+	f.file = nil
+	return err
+}
@@ -0,0 +1,164 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filesystem_test
+
+import (
+	"context"
+	"io"
+	"io/fs"
+	"testing"
+	"testing/fstest"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem"
+	scalibrfs "github.com/google/osv-scalibr/fs"
+	"github.com/google/osv-scalibr/plugin"
+	"github.com/google/osv-scalibr/purl"
+)
+
+func TestDetectFileType(t *testing.T) {
+	tests := []struct {
+		desc   string
+		header []byte
+		want   filesystem.FileType
+	}{
+		{desc: "ELF", header: []byte("\x7fELF\x02\x01\x01\x00"), want: filesystem.FileTypeELF},
+		{desc: "PE", header: []byte("MZ\x90\x00\x03\x00\x00\x00"), want: filesystem.FileTypePE},
+		{desc: "Mach-O 64 bit", header: []byte{0xcf, 0xfa, 0xed, 0xfe, 0x07, 0x00, 0x00, 0x01}, want: filesystem.FileTypeMachO},
+		{desc: "zip", header: []byte("PK\x03\x04\x14\x00\x00\x00"), want: filesystem.FileTypeZip},
+		{desc: "gzip", header: []byte{0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00}, want: filesystem.FileTypeGzip},
+		{desc: "ar", header: []byte("!<arch>\n"), want: filesystem.FileTypeAr},
+		{desc: "RPM", header: []byte{0xed, 0xab, 0xee, 0xdb, 0x03, 0x00, 0x00, 0x00}, want: filesystem.FileTypeRPM},
+		{desc: "text", header: []byte("#!/bin/sh"), want: filesystem.FileTypeUnknown},
+		{desc: "truncated magic", header: []byte("\x7fEL"), want: filesystem.FileTypeUnknown},
+		{desc: "empty", header: []byte{}, want: filesystem.FileTypeUnknown},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			if got := filesystem.DetectFileType(tc.header); got != tc.want {
+				t.Errorf("DetectFileType(%q): got %v, want %v", tc.header, got, tc.want)
+			}
+		})
+	}
+}
+
+// nonSeekableFS wraps the files of a MapFS so that they can only be read sequentially.
+type nonSeekableFS struct {
+	fstest.MapFS
+}
+
+type nonSeekableFile struct {
+	fs.File
+}
+
+func (fsys nonSeekableFS) Open(name string) (fs.File, error) {
+	f, err := fsys.MapFS.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	return nonSeekableFile{f}, nil
+}
+
+func TestPeekableFile(t *testing.T) {
+	content := "\x7fELF and some more content"
+	mapFS := fstest.MapFS{"file": {Data: []byte(content)}}
+
+	for _, fsys := range []scalibrfs.FS{mapFS, nonSeekableFS{mapFS}} {
+		f := filesystem.NewPeekableFile(fsys, "file")
+		defer f.Close()
+
+		got, err := f.FileType()
+		if err != nil {
+			t.Fatalf("FileType(): %v", err)
+		}
+		if got != filesystem.FileTypeELF {
+			t.Errorf("FileType(): got %v, want %v", got, filesystem.FileTypeELF)
+		}
+		header, err := f.Peek(100)
+		if err != nil {
+			t.Fatalf("Peek(100): %v", err)
+		}
+		if diff := cmp.Diff(content, string(header)); diff != "" {
+			t.Errorf("Peek(100) unexpected diff (-want +got):\n%s", diff)
+		}
+	}
+}
+
+// openCountingFS counts how often each file is opened.
+type openCountingFS struct {
+	scalibrfs.FS
+	opens map[string]int
+}
+
+func (fsys *openCountingFS) Open(name string) (fs.File, error) {
+	fsys.opens[name]++
+	return fsys.FS.Open(name)
+}
+
+// elfExtractor is a content-aware extractor that returns the content of ELF files.
+type elfExtractor struct{}
+
+func (elfExtractor) Name() string                       { return "elf" }
+func (elfExtractor) Version() int                       { return 0 }
+func (elfExtractor) Requirements() *plugin.Capabilities { return &plugin.Capabilities{} }
+func (elfExtractor) FileRequired(path string, fileinfo fs.FileInfo) bool {
+	return true
+}
+func (elfExtractor) FileRequiredWithFS(path string, fileinfo fs.FileInfo, file *filesystem.PeekableFile) bool {
+	t, err := file.FileType()
+	return err == nil && t == filesystem.FileTypeELF
+}
+func (elfExtractor) Extract(ctx context.Context, input *filesystem.ScanInput) ([]*extractor.Inventory, error) {
+	content, err := io.ReadAll(input.Reader)
+	if err != nil {
+		return nil, err
+	}
+	return []*extractor.Inventory{{Name: string(content), Locations: []string{input.Path}}}, nil
+}
+func (elfExtractor) ToPURL(i *extractor.Inventory) (*purl.PackageURL, error) { return nil, nil }
+func (elfExtractor) ToCPEs(i *extractor.Inventory) ([]string, error)         { return nil, nil }
+func (elfExtractor) Ecosystem(i *extractor.Inventory) (string, error)        { return "", nil }
+
+func TestScanFS_FileRequiredWithFS(t *testing.T) {
+	fsys := &openCountingFS{
+		FS: fstest.MapFS{
+			"binary": {Data: []byte("\x7fELF binary")},
+			"text":   {Data: []byte("text")},
+		},
+		opens: map[string]int{},
+	}
+	ex := elfExtractor{}
+	inv, _, err := filesystem.ScanFS(context.Background(), fsys, []filesystem.Extractor{ex}, &filesystem.Config{})
+	if err != nil {
+		t.Fatalf("filesystem.ScanFS(): %v", err)
+	}
+
+	// The extractor should get the whole content even though it was peeked into.
+	want := []*extractor.Inventory{{Name: "\x7fELF binary", Locations: []string{"binary"}, Extractor: ex}}
+	if diff := cmp.Diff(want, inv); diff != "" {
+		t.Errorf("filesystem.ScanFS(): unexpected inventory (-want +got):\n%s", diff)
+	}
+	// The file opened for peeking should be reused for the extraction.
+	delete(fsys.opens, ".")
+	// The os-release files are looked up once per scan root.
+	delete(fsys.opens, "etc/os-release")
+	delete(fsys.opens, "usr/lib/os-release")
+	wantOpens := map[string]int{"binary": 1, "text": 1}
+	if diff := cmp.Diff(wantOpens, fsys.opens); diff != "" {
+		t.Errorf("filesystem.ScanFS(): unexpected file opens (-want +got):\n%s", diff)
+	}
+}
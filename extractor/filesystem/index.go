@@ -0,0 +1,114 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filesystem
+
+import (
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// FileExtensionFilter is an optional interface for extractors that only
+// require files with certain extensions. The core library doesn't call
+// FileRequired for files with other extensions, which speeds up the
+// filesystem walk when many extractors are enabled.
+type FileExtensionFilter interface {
+	// RequiredFileExtensions returns the extensions of the files that
+	// FileRequired can return true for, including the leading dot, e.g. ".jar".
+	// The extension is the suffix starting at the last dot of the file name, as
+	// returned by filepath.Ext. The comparison is case-insensitive.
+	RequiredFileExtensions() []string
+}
+
+// FileNameFilter is an optional interface for extractors that only require
+// files with certain base names, e.g. "package.json". The comparison is
+// case-insensitive. If an extractor implements both FileNameFilter and
+// FileExtensionFilter, FileRequired is called for files matching either.
+type FileNameFilter interface {
+	// RequiredFileNames returns the base names of the files that FileRequired
+	// can return true for.
+	RequiredFileNames() []string
+}
+
+// extractorIndex maps the lowercase extensions and base names of files to the
+// extractors whose FileRequired needs to be called for them. The extractor
+// lists keep the configured order of the extractors.
+type extractorIndex struct {
+	byExtension map[string][]Extractor
+	// Also contains the extractors matching the extension of the name.
+	byName map[string][]Extractor
+	// Extractors that implement neither FileExtensionFilter nor FileNameFilter.
+	unfiltered []Extractor
+}
+
+func newExtractorIndex(extractors []Extractor) *extractorIndex {
+	extIdx := map[string][]int{}
+	nameIdx := map[string][]int{}
+	var unfilteredIdx []int
+	for i, ex := range extractors {
+		exts, hasExts := ex.(FileExtensionFilter)
+		names, hasNames := ex.(FileNameFilter)
+		if !hasExts && !hasNames {
+			unfilteredIdx = append(unfilteredIdx, i)
+			continue
+		}
+		if hasExts {
+			for _, ext := range exts.RequiredFileExtensions() {
+				extIdx[strings.ToLower(ext)] = append(extIdx[strings.ToLower(ext)], i)
+			}
+		}
+		if hasNames {
+			for _, name := range names.RequiredFileNames() {
+				nameIdx[strings.ToLower(name)] = append(nameIdx[strings.ToLower(name)], i)
+			}
+		}
+	}
+
+	pick := func(idx ...[]int) []Extractor {
+		merged := slices.Concat(idx...)
+		slices.Sort(merged)
+		merged = slices.Compact(merged)
+		result := make([]Extractor, 0, len(merged))
+		for _, i := range merged {
+			result = append(result, extractors[i])
+		}
+		return result
+	}
+	ix := &extractorIndex{
+		byExtension: make(map[string][]Extractor, len(extIdx)),
+		byName:      make(map[string][]Extractor, len(nameIdx)),
+		unfiltered:  pick(unfilteredIdx),
+	}
+	for ext, idx := range extIdx {
+		ix.byExtension[ext] = pick(idx, unfilteredIdx)
+	}
+	for name, idx := range nameIdx {
+		ix.byName[name] = pick(idx, extIdx[filepath.Ext(name)], unfilteredIdx)
+	}
+	return ix
+}
+
+// extractorsFor returns the extractors whose FileRequired needs to be called
+// for the file at path.
+func (ix *extractorIndex) extractorsFor(path string) []Extractor {
+	base := strings.ToLower(filepath.Base(path))
+	if exs, ok := ix.byName[base]; ok {
+		return exs
+	}
+	if exs, ok := ix.byExtension[filepath.Ext(base)]; ok {
+		return exs
+	}
+	return ix.unfiltered
+}
@@ -0,0 +1,122 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filesystem_test
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"testing"
+	"testing/fstest"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/osv-scalibr/extractor/filesystem"
+	el "github.com/google/osv-scalibr/extractor/filesystem/list"
+	fe "github.com/google/osv-scalibr/testing/fakeextractor"
+)
+
+// recordingExtractor records the files its FileRequired was called for.
+type recordingExtractor struct {
+	filesystem.Extractor
+	calls map[string][]string
+}
+
+func (e recordingExtractor) FileRequired(path string, fileinfo fs.FileInfo) bool {
+	e.calls[path] = append(e.calls[path], e.Name())
+	return e.Extractor.FileRequired(path, fileinfo)
+}
+
+// filteredExtractor is a recordingExtractor that declares the extensions and
+// names of the files it requires.
+type filteredExtractor struct {
+	recordingExtractor
+	exts  []string
+	names []string
+}
+
+func (e filteredExtractor) RequiredFileExtensions() []string { return e.exts }
+func (e filteredExtractor) RequiredFileNames() []string      { return e.names }
+
+func TestScanFS_ExtractorIndex(t *testing.T) {
+	fsys := fstest.MapFS{
+		"a/package.json": {Data: []byte("content")},
+		"b/lib.JAR":      {Data: []byte("content")},
+		"c/readme.md":    {Data: []byte("content")},
+		"d/Pipfile.lock": {Data: []byte("content")},
+		"e/noext":        {Data: []byte("content")},
+	}
+	calls := map[string][]string{}
+	record := func(name string) recordingExtractor {
+		return recordingExtractor{Extractor: fe.New(name, 1, nil, nil), calls: calls}
+	}
+	extractors := []filesystem.Extractor{
+		filteredExtractor{recordingExtractor: record("byext"), exts: []string{".jar"}},
+		record("unfiltered"),
+		filteredExtractor{recordingExtractor: record("byname"), names: []string{"package.json"}},
+		filteredExtractor{recordingExtractor: record("both"), exts: []string{".json"}, names: []string{"pipfile.lock"}},
+	}
+	if _, _, err := filesystem.ScanFS(context.Background(), fsys, extractors, &filesystem.Config{}); err != nil {
+		t.Fatalf("filesystem.ScanFS(): %v", err)
+	}
+
+	want := map[string][]string{
+		"a/package.json": {"unfiltered", "byname", "both"},
+		"b/lib.JAR":      {"byext", "unfiltered"},
+		"c/readme.md":    {"unfiltered"},
+		"d/Pipfile.lock": {"unfiltered", "both"},
+		"e/noext":        {"unfiltered"},
+	}
+	if diff := cmp.Diff(want, calls); diff != "" {
+		t.Errorf("filesystem.ScanFS(): unexpected FileRequired calls (-want +got):\n%s", diff)
+	}
+}
+
+// unindexedExtractor hides the FileExtensionFilter and FileNameFilter
+// implementations of the wrapped extractor.
+type unindexedExtractor struct {
+	filesystem.Extractor
+}
+
+// BenchmarkScanFS_ExtractorIndex compares the walk over a source tree with all
+// extractors enabled with and without the extension and name index.
+func BenchmarkScanFS_ExtractorIndex(b *testing.B) {
+	fsys := fstest.MapFS{}
+	exts := []string{".go", ".c", ".h", ".py", ".js", ".md", ".txt", ".html", ".png", ""}
+	for i := range 10000 {
+		path := fmt.Sprintf("dir%d/file%d%s", i%10, i, exts[i%len(exts)])
+		fsys[path] = &fstest.MapFile{Data: []byte("content")}
+	}
+	indexed := el.All
+	var unindexed []filesystem.Extractor
+	for _, ex := range indexed {
+		unindexed = append(unindexed, unindexedExtractor{ex})
+	}
+
+	for _, bc := range []struct {
+		name       string
+		extractors []filesystem.Extractor
+	}{
+		{"indexed", indexed},
+		{"unindexed", unindexed},
+	} {
+		b.Run(bc.name, func(b *testing.B) {
+			for range b.N {
+				if _, _, err := filesystem.ScanFS(context.Background(), fsys, bc.extractors, &filesystem.Config{}); err != nil {
+					b.Fatalf("filesystem.ScanFS(): %v", err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,61 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// Package chunkscan searches files that are too large to read at once in
+// overlapping chunks.
+package chunkscan
+
+import (
+	"context"
+	"io"
+
+	"github.com/google/osv-scalibr/extractor/filesystem/internal/units"
+)
+
+// chunkSize is the number of bytes searched at once.
+const chunkSize = 1 * units.MiB
+
+// Func is called on each chunk. The first kept bytes of the chunk are the end
+// of the previous chunk. atEOF is true for the last chunk.
+//
+// A match that ends at the end of a chunk other than the last might be cut
+// off. It can be skipped since it's matched again in the next chunk.
+type Func func(chunk []byte, kept int, atEOF bool)
+
+// Scan reads r in chunks and calls fn on each one. The last overlap bytes of
+// a chunk are kept at the start of the next one so that matches spanning two
+// chunks are found. overlap needs to be larger than the longest match.
+func Scan(ctx context.Context, r io.Reader, overlap int, fn Func) error {
+	return scan(ctx, r, int(chunkSize), overlap, fn)
+}
+
+func scan(ctx context.Context, r io.Reader, size, overlap int, fn Func) error {
+	buf := make([]byte, overlap+size)
+	kept := 0
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		n, err := io.ReadFull(r, buf[kept:])
+		if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+			return err
+		}
+		chunk := buf[:kept+n]
+		atEOF := err != nil
+		fn(chunk, kept, atEOF)
+		if atEOF {
+			return nil
+		}
+		kept = copy(buf, chunk[len(chunk)-overlap:])
+	}
+}
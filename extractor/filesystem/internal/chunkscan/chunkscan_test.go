@@ -0,0 +1,91 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package chunkscan
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+type chunk struct {
+	data  string
+	kept  int
+	atEOF bool
+}
+
+func TestScan(t *testing.T) {
+	tests := []struct {
+		desc    string
+		input   string
+		size    int
+		overlap int
+		want    []chunk
+	}{
+		{
+			desc:    "empty",
+			input:   "",
+			size:    4,
+			overlap: 2,
+			want:    []chunk{{data: "", atEOF: true}},
+		},
+		{
+			desc:    "single chunk",
+			input:   "abc",
+			size:    4,
+			overlap: 2,
+			want:    []chunk{{data: "abc", atEOF: true}},
+		},
+		{
+			desc:    "overlapping chunks",
+			input:   "abcdefghij",
+			size:    4,
+			overlap: 2,
+			want: []chunk{
+				{data: "abcdef"},
+				{data: "efghij", kept: 2},
+				{data: "ij", kept: 2, atEOF: true},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			var got []chunk
+			err := scan(context.Background(), strings.NewReader(tt.input), tt.size, tt.overlap, func(c []byte, kept int, atEOF bool) {
+				got = append(got, chunk{data: string(c), kept: kept, atEOF: atEOF})
+			})
+			if err != nil {
+				t.Fatalf("scan(%q): %v", tt.input, err)
+			}
+			if diff := cmp.Diff(tt.want, got, cmp.AllowUnexported(chunk{})); diff != "" {
+				t.Errorf("scan(%q) unexpected chunks (-want +got):\n%s", tt.input, diff)
+			}
+		})
+	}
+}
+
+func TestScanCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err := Scan(ctx, strings.NewReader("abc"), 2, func([]byte, int, bool) {
+		t.Error("Scan() called fn after the context was cancelled")
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Scan() returned %v, want %v", err, context.Canceled)
+	}
+}
@@ -0,0 +1,46 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import "testing"
+
+func TestMatchGlob(t *testing.T) {
+	tests := []struct {
+		pattern string
+		name    string
+		want    bool
+	}{
+		{pattern: "node_modules", name: "node_modules", want: true},
+		{pattern: "node_modules", name: "a/node_modules", want: false},
+		{pattern: "*/node_modules", name: "a/node_modules", want: true},
+		{pattern: "*/node_modules", name: "a/b/node_modules", want: false},
+		{pattern: "**/node_modules", name: "node_modules", want: true},
+		{pattern: "**/node_modules", name: "a/b/node_modules", want: true},
+		{pattern: "**/node_modules", name: "a/node_modules/b", want: false},
+		{pattern: "a/**/c", name: "a/c", want: true},
+		{pattern: "a/**/c", name: "a/b1/b2/c", want: true},
+		{pattern: "a/**/c", name: "x/a/b/c", want: false},
+		{pattern: "a/**", name: "a/b/c", want: true},
+		{pattern: "**/.cache*", name: "home/user/.cache-old", want: true},
+		{pattern: "**/build-*/out", name: "src/build-x86/out", want: true},
+		{pattern: "**/[", name: "a/[", want: false},
+	}
+
+	for _, tt := range tests {
+		if got := MatchGlob(tt.pattern, tt.name); got != tt.want {
+			t.Errorf("MatchGlob(%q, %q): got %v, want %v", tt.pattern, tt.name, got, tt.want)
+		}
+	}
+}
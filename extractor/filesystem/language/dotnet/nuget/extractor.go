@@ -0,0 +1,335 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package nuget extracts the NuGet packages used by .NET projects from
+// packages.config, *.csproj and project.assets.json files.
+package nuget
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem"
+	"github.com/google/osv-scalibr/extractor/filesystem/internal/units"
+	"github.com/google/osv-scalibr/log"
+	"github.com/google/osv-scalibr/plugin"
+	"github.com/google/osv-scalibr/purl"
+	"github.com/google/osv-scalibr/stats"
+)
+
+const (
+	// Name is the unique name of this extractor.
+	Name = "dotnet/nuget"
+
+	// defaultMaxFileSizeBytes is the maximum file size an extractor will unmarshal.
+	// If Extract gets a bigger file, it will return an error.
+	defaultMaxFileSizeBytes = 10 * units.MiB
+
+	packagesConfigFileName = "packages.config"
+	projectAssetsFileName  = "project.assets.json"
+	csprojExtension        = ".csproj"
+)
+
+// packagesConfig is the packages.config file used by projects that don't use
+// PackageReference entries, see
+// https://learn.microsoft.com/en-us/nuget/reference/packages-config
+type packagesConfig struct {
+	Packages []struct {
+		ID      string `xml:"id,attr"`
+		Version string `xml:"version,attr"`
+	} `xml:"package"`
+}
+
+// csproj holds the PackageReference entries of a C# project file. The version
+// can either be an attribute or a child element.
+type csproj struct {
+	ItemGroups []struct {
+		PackageReferences []struct {
+			Include        string `xml:"Include,attr"`
+			VersionAttr    string `xml:"Version,attr"`
+			VersionElement string `xml:"Version"`
+		} `xml:"PackageReference"`
+	} `xml:"ItemGroup"`
+}
+
+// projectAssets is the project.assets.json file written to the obj directory
+// by `dotnet restore`.
+type projectAssets struct {
+	// Libraries is keyed by "<name>/<version>" and lists all resolved packages
+	// and project references.
+	Libraries map[string]struct {
+		Type string `json:"type"`
+	} `json:"libraries"`
+	// Project is the restored project. The dependencies of its target
+	// frameworks are the direct dependencies.
+	Project *struct {
+		Frameworks map[string]struct {
+			Dependencies map[string]json.RawMessage `json:"dependencies"`
+		} `json:"frameworks"`
+	} `json:"project"`
+}
+
+// Config is the configuration for the Extractor.
+type Config struct {
+	// Stats is a stats collector for reporting metrics.
+	Stats stats.Collector
+	// MaxFileSizeBytes is the maximum file size this extractor will unmarshal.
+	// If `FileRequired` gets a bigger file, it will return false.
+	MaxFileSizeBytes int64
+}
+
+// DefaultConfig returns the default configuration for the extractor.
+func DefaultConfig() Config {
+	return Config{
+		Stats:            nil,
+		MaxFileSizeBytes: defaultMaxFileSizeBytes,
+	}
+}
+
+// Extractor extracts NuGet packages from .NET project files.
+type Extractor struct {
+	stats            stats.Collector
+	maxFileSizeBytes int64
+}
+
+// New returns a NuGet extractor.
+//
+// For most use cases, initialize with:
+// ```
+// e := New(DefaultConfig())
+// ```
+func New(cfg Config) *Extractor {
+	return &Extractor{
+		stats:            cfg.Stats,
+		maxFileSizeBytes: cfg.MaxFileSizeBytes,
+	}
+}
+
+// Config returns the configuration of the extractor.
+func (e Extractor) Config() Config {
+	return Config{
+		Stats:            e.stats,
+		MaxFileSizeBytes: e.maxFileSizeBytes,
+	}
+}
+
+// Name of the extractor.
+func (e Extractor) Name() string { return Name }
+
+// Version of the extractor.
+func (e Extractor) Version() int { return 0 }
+
+// Requirements of the extractor.
+func (e Extractor) Requirements() *plugin.Capabilities { return &plugin.Capabilities{} }
+
+// RequiredFileNames returns the names of the package manifests the extractor requires.
+func (e Extractor) RequiredFileNames() []string {
+	return []string{packagesConfigFileName, projectAssetsFileName}
+}
+
+// RequiredFileExtensions returns the extension of the project files the extractor requires.
+func (e Extractor) RequiredFileExtensions() []string {
+	return []string{csprojExtension}
+}
+
+// FileRequired returns true if the specified file is a packages.config,
+// project.assets.json or *.csproj file.
+func (e Extractor) FileRequired(path string, fileinfo fs.FileInfo) bool {
+	base := filepath.Base(path)
+	if base != packagesConfigFileName && base != projectAssetsFileName && filepath.Ext(base) != csprojExtension {
+		return false
+	}
+
+	if e.maxFileSizeBytes > 0 && fileinfo.Size() > e.maxFileSizeBytes {
+		e.reportFileRequired(path, fileinfo.Size(), stats.FileRequiredResultSizeLimitExceeded)
+		return false
+	}
+
+	e.reportFileRequired(path, fileinfo.Size(), stats.FileRequiredResultOK)
+	return true
+}
+
+func (e Extractor) reportFileRequired(path string, fileSizeBytes int64, result stats.FileRequiredResult) {
+	if e.stats == nil {
+		return
+	}
+	e.stats.AfterFileRequired(e.Name(), &stats.FileRequiredStats{
+		Path:          path,
+		Result:        result,
+		FileSizeBytes: fileSizeBytes,
+	})
+}
+
+// Extract extracts the NuGet packages from the file passed through the scan input.
+func (e Extractor) Extract(ctx context.Context, input *filesystem.ScanInput) ([]*extractor.Inventory, error) {
+	inventory, err := e.extractFromInput(input)
+	if e.stats != nil {
+		var fileSizeBytes int64
+		if input.Info != nil {
+			fileSizeBytes = input.Info.Size()
+		}
+		e.stats.AfterFileExtracted(e.Name(), &stats.FileExtractedStats{
+			Path:          input.Path,
+			Result:        filesystem.ExtractorErrorToFileExtractedResult(err),
+			FileSizeBytes: fileSizeBytes,
+		})
+	}
+	return inventory, err
+}
+
+func (e Extractor) extractFromInput(input *filesystem.ScanInput) ([]*extractor.Inventory, error) {
+	switch base := filepath.Base(input.Path); {
+	case base == packagesConfigFileName:
+		return extractPackagesConfig(input)
+	case base == projectAssetsFileName:
+		return extractProjectAssets(input)
+	case filepath.Ext(base) == csprojExtension:
+		return extractCSProj(input)
+	}
+	return nil, fmt.Errorf("unsupported file %q", input.Path)
+}
+
+func extractPackagesConfig(input *filesystem.ScanInput) ([]*extractor.Inventory, error) {
+	var c packagesConfig
+	if err := xml.NewDecoder(input.Reader).Decode(&c); err != nil {
+		return nil, fmt.Errorf("failed to parse %q: %w: %w", input.Path, extractor.ErrMalformedInput, err)
+	}
+	var res []*extractor.Inventory
+	for _, p := range c.Packages {
+		if p.ID == "" || p.Version == "" {
+			continue
+		}
+		// packages.config lists the direct and transitive dependencies without
+		// telling them apart.
+		res = append(res, newInventory(p.ID, p.Version, "", input.Path))
+	}
+	return res, nil
+}
+
+func extractCSProj(input *filesystem.ScanInput) ([]*extractor.Inventory, error) {
+	var c csproj
+	if err := xml.NewDecoder(input.Reader).Decode(&c); err != nil {
+		return nil, fmt.Errorf("failed to parse %q: %w: %w", input.Path, extractor.ErrMalformedInput, err)
+	}
+	var res []*extractor.Inventory
+	for _, g := range c.ItemGroups {
+		for _, r := range g.PackageReferences {
+			v := r.VersionAttr
+			if v == "" {
+				v = r.VersionElement
+			}
+			version, ok := exactVersion(v)
+			if r.Include == "" || !ok {
+				// The version is managed centrally or resolved at restore time.
+				log.Debugf("Skipping PackageReference %q with version %q in %q", r.Include, v, input.Path)
+				continue
+			}
+			res = append(res, newInventory(r.Include, version, DependencyTypeDirect, input.Path))
+		}
+	}
+	return res, nil
+}
+
+func extractProjectAssets(input *filesystem.ScanInput) ([]*extractor.Inventory, error) {
+	var a projectAssets
+	if err := json.NewDecoder(input.Reader).Decode(&a); err != nil {
+		return nil, fmt.Errorf("failed to parse %q: %w: %w", input.Path, extractor.ErrMalformedInput, err)
+	}
+
+	// NuGet package IDs are case insensitive.
+	var direct map[string]bool
+	if a.Project != nil && a.Project.Frameworks != nil {
+		direct = make(map[string]bool)
+		for _, f := range a.Project.Frameworks {
+			for name := range f.Dependencies {
+				direct[strings.ToLower(name)] = true
+			}
+		}
+	}
+
+	keys := make([]string, 0, len(a.Libraries))
+	for key, lib := range a.Libraries {
+		// Skip project references.
+		if lib.Type == "package" {
+			keys = append(keys, key)
+		}
+	}
+	slices.Sort(keys)
+
+	var res []*extractor.Inventory
+	for _, key := range keys {
+		name, version, ok := strings.Cut(key, "/")
+		if !ok || name == "" || version == "" {
+			log.Debugf("Skipping invalid library %q in %q", key, input.Path)
+			continue
+		}
+		var depType string
+		if direct != nil {
+			depType = DependencyTypeTransitive
+			if direct[strings.ToLower(name)] {
+				depType = DependencyTypeDirect
+			}
+		}
+		res = append(res, newInventory(name, version, depType, input.Path))
+	}
+	return res, nil
+}
+
+func newInventory(name, version, depType, path string) *extractor.Inventory {
+	return &extractor.Inventory{
+		Name:      name,
+		Version:   version,
+		Metadata:  &Metadata{DependencyType: depType},
+		Locations: []string{path},
+	}
+}
+
+// exactVersion returns the version a PackageReference resolves to, or false if
+// it can't be determined from the project file alone. A plain version such as
+// "1.2.3" is the minimum version, which NuGet resolves to if it's available.
+func exactVersion(v string) (string, bool) {
+	v = strings.TrimSpace(v)
+	if v == "" || strings.Contains(v, "$(") || strings.ContainsAny(v, "*,") {
+		return "", false
+	}
+	if strings.HasPrefix(v, "[") && strings.HasSuffix(v, "]") {
+		return strings.TrimSpace(v[1 : len(v)-1]), true
+	}
+	if strings.ContainsAny(v, "[]()") {
+		return "", false
+	}
+	return v, true
+}
+
+// ToPURL converts an inventory created by this extractor into a PURL.
+func (e Extractor) ToPURL(i *extractor.Inventory) (*purl.PackageURL, error) {
+	return &purl.PackageURL{
+		Type:    purl.TypeNuget,
+		Name:    i.Name,
+		Version: i.Version,
+	}, nil
+}
+
+// ToCPEs is not applicable as this extractor does not infer CPEs from the Inventory.
+func (e Extractor) ToCPEs(i *extractor.Inventory) ([]string, error) { return []string{}, nil }
+
+// Ecosystem returns the OSV Ecosystem of the software extracted by this extractor.
+func (Extractor) Ecosystem(i *extractor.Inventory) (string, error) { return "NuGet", nil }
@@ -0,0 +1,256 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nuget_test
+
+import (
+	"context"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem"
+	"github.com/google/osv-scalibr/extractor/filesystem/internal/units"
+	"github.com/google/osv-scalibr/extractor/filesystem/language/dotnet/nuget"
+	scalibrfs "github.com/google/osv-scalibr/fs"
+	"github.com/google/osv-scalibr/purl"
+	"github.com/google/osv-scalibr/stats"
+	"github.com/google/osv-scalibr/testing/fakefs"
+	"github.com/google/osv-scalibr/testing/testcollector"
+)
+
+func TestFileRequired(t *testing.T) {
+	tests := []struct {
+		name             string
+		path             string
+		fileSizeBytes    int64
+		maxFileSizeBytes int64
+		wantRequired     bool
+		wantResultMetric stats.FileRequiredResult
+	}{
+		{
+			name:             "packages.config",
+			path:             "project/packages.config",
+			wantRequired:     true,
+			wantResultMetric: stats.FileRequiredResultOK,
+		},
+		{
+			name:             "project.assets.json",
+			path:             "project/obj/project.assets.json",
+			wantRequired:     true,
+			wantResultMetric: stats.FileRequiredResultOK,
+		},
+		{
+			name:             "csproj",
+			path:             "project/App.csproj",
+			wantRequired:     true,
+			wantResultMetric: stats.FileRequiredResultOK,
+		},
+		{
+			name:         "other project file",
+			path:         "project/App.fsproj",
+			wantRequired: false,
+		},
+		{
+			name:         "other config file",
+			path:         "project/web.config",
+			wantRequired: false,
+		},
+		{
+			name:         "packages.lock.json",
+			path:         "project/packages.lock.json",
+			wantRequired: false,
+		},
+		{
+			name:             "file size < max file size",
+			path:             "project/packages.config",
+			fileSizeBytes:    100 * units.KiB,
+			maxFileSizeBytes: 1000 * units.KiB,
+			wantRequired:     true,
+			wantResultMetric: stats.FileRequiredResultOK,
+		},
+		{
+			name:             "file size > max file size",
+			path:             "project/packages.config",
+			fileSizeBytes:    1000 * units.KiB,
+			maxFileSizeBytes: 100 * units.KiB,
+			wantRequired:     false,
+			wantResultMetric: stats.FileRequiredResultSizeLimitExceeded,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			collector := testcollector.New()
+			var e filesystem.Extractor = nuget.New(nuget.Config{
+				Stats:            collector,
+				MaxFileSizeBytes: test.maxFileSizeBytes,
+			})
+
+			// Set default size if not provided.
+			fileSizeBytes := test.fileSizeBytes
+			if fileSizeBytes == 0 {
+				fileSizeBytes = 100 * units.KiB
+			}
+
+			isRequired := e.FileRequired(test.path, fakefs.FakeFileInfo{
+				FileName: filepath.Base(test.path),
+				FileMode: fs.ModePerm,
+				FileSize: fileSizeBytes,
+			})
+			if isRequired != test.wantRequired {
+				t.Fatalf("FileRequired(%s): got %v, want %v", test.path, isRequired, test.wantRequired)
+			}
+
+			gotResultMetric := collector.FileRequiredResult(test.path)
+			if gotResultMetric != test.wantResultMetric {
+				t.Errorf("FileRequired(%s) recorded result metric %v, want result metric %v", test.path, gotResultMetric, test.wantResultMetric)
+			}
+		})
+	}
+}
+
+func TestExtract(t *testing.T) {
+	inv := func(name, version, depType, path string) *extractor.Inventory {
+		return &extractor.Inventory{
+			Name:      name,
+			Version:   version,
+			Metadata:  &nuget.Metadata{DependencyType: depType},
+			Locations: []string{path},
+		}
+	}
+	const (
+		packagesConfig  = "testdata/packagesconfig/packages.config"
+		csproj          = "testdata/csproj/App.csproj"
+		assets          = "testdata/assets/project.assets.json"
+		assetsNoProject = "testdata/assetsnoproject/project.assets.json"
+	)
+
+	tests := []struct {
+		name             string
+		path             string
+		wantInventory    []*extractor.Inventory
+		wantErr          error
+		wantResultMetric stats.FileExtractedResult
+	}{
+		{
+			name: "packages.config",
+			path: packagesConfig,
+			wantInventory: []*extractor.Inventory{
+				inv("Newtonsoft.Json", "13.0.1", "", packagesConfig),
+				inv("Microsoft.AspNet.Mvc", "5.2.7", "", packagesConfig),
+				inv("Microsoft.AspNet.Razor", "3.2.7", "", packagesConfig),
+				inv("Microsoft.CodeDom.Providers.DotNetCompilerPlatform", "2.0.1", "", packagesConfig),
+			},
+			wantResultMetric: stats.FileExtractedResultSuccess,
+		},
+		{
+			name: "csproj with unresolvable versions",
+			path: csproj,
+			wantInventory: []*extractor.Inventory{
+				inv("Newtonsoft.Json", "13.0.3", nuget.DependencyTypeDirect, csproj),
+				inv("Microsoft.Extensions.Logging", "8.0.0", nuget.DependencyTypeDirect, csproj),
+				inv("Grpc.Net.Client", "2.60.0", nuget.DependencyTypeDirect, csproj),
+			},
+			wantResultMetric: stats.FileExtractedResultSuccess,
+		},
+		{
+			name: "project.assets.json",
+			path: assets,
+			wantInventory: []*extractor.Inventory{
+				inv("Microsoft.Extensions.Logging.Abstractions", "8.0.0", nuget.DependencyTypeTransitive, assets),
+				inv("Microsoft.Extensions.Logging", "8.0.0", nuget.DependencyTypeDirect, assets),
+				inv("Newtonsoft.Json", "13.0.3", nuget.DependencyTypeDirect, assets),
+			},
+			wantResultMetric: stats.FileExtractedResultSuccess,
+		},
+		{
+			name: "project.assets.json without project",
+			path: assetsNoProject,
+			wantInventory: []*extractor.Inventory{
+				inv("Newtonsoft.Json", "13.0.3", "", assetsNoProject),
+			},
+			wantResultMetric: stats.FileExtractedResultSuccess,
+		},
+		{
+			name:             "invalid packages.config",
+			path:             "testdata/invalid/packages.config",
+			wantErr:          extractor.ErrMalformedInput,
+			wantResultMetric: stats.FileExtractedResultErrorMalformedInput,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			collector := testcollector.New()
+			var e filesystem.Extractor = nuget.New(nuget.Config{Stats: collector})
+
+			r, err := os.Open(test.path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer r.Close()
+
+			info, err := os.Stat(test.path)
+			if err != nil {
+				t.Fatalf("Failed to stat test file: %v", err)
+			}
+
+			input := &filesystem.ScanInput{
+				FS:     scalibrfs.DirFS("."),
+				Path:   test.path,
+				Reader: r,
+				Info:   info,
+			}
+			got, err := e.Extract(context.Background(), input)
+			if !cmp.Equal(err, test.wantErr, cmpopts.EquateErrors()) {
+				t.Fatalf("Extract(%s) error: got %v, want %v", test.path, err, test.wantErr)
+			}
+
+			if diff := cmp.Diff(test.wantInventory, got); diff != "" {
+				t.Errorf("Extract(%s) (-want +got):\n%s", test.path, diff)
+			}
+
+			gotResultMetric := collector.FileExtractedResult(test.path)
+			if gotResultMetric != test.wantResultMetric {
+				t.Errorf("Extract(%s) recorded result metric %v, want result metric %v", test.path, gotResultMetric, test.wantResultMetric)
+			}
+		})
+	}
+}
+
+func TestToPURL(t *testing.T) {
+	e := nuget.Extractor{}
+	i := &extractor.Inventory{
+		Name:      "Newtonsoft.Json",
+		Version:   "13.0.3",
+		Locations: []string{"location"},
+	}
+	want := &purl.PackageURL{
+		Type:    purl.TypeNuget,
+		Name:    "Newtonsoft.Json",
+		Version: "13.0.3",
+	}
+	got, err := e.ToPURL(i)
+	if err != nil {
+		t.Fatalf("ToPURL(%v): %v", i, err)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ToPURL(%v) (-want +got):\n%s", i, diff)
+	}
+}
@@ -0,0 +1,32 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nuget
+
+// Dependency types of the packages found in project.assets.json and *.csproj files.
+const (
+	// DependencyTypeDirect is set for packages that the project references.
+	DependencyTypeDirect = "direct"
+	// DependencyTypeTransitive is set for packages that are only pulled in by
+	// other packages.
+	DependencyTypeTransitive = "transitive"
+)
+
+// Metadata holds parsing information for a NuGet package.
+type Metadata struct {
+	// DependencyType is DependencyTypeDirect or DependencyTypeTransitive. Empty
+	// if the file doesn't tell, as for packages.config which lists both kinds
+	// of dependencies.
+	DependencyType string
+}
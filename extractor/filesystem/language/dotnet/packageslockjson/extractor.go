@@ -96,6 +96,9 @@ func (e Extractor) Version() int { return 0 }
 // Requirements of the extractor.
 func (e Extractor) Requirements() *plugin.Capabilities { return &plugin.Capabilities{} }
 
+// RequiredFileNames returns the name of the lockfiles the extractor requires.
+func (e Extractor) RequiredFileNames() []string { return []string{"packages.lock.json"} }
+
 // FileRequired returns true if the specified file is marked executable.
 func (e Extractor) FileRequired(path string, fileinfo fs.FileInfo) bool {
 	if filepath.Base(path) != "packages.lock.json" {
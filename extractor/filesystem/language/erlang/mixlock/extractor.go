@@ -0,0 +1,284 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package mixlock extracts the dependencies pinned in Elixir's mix.lock files.
+package mixlock
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem"
+	"github.com/google/osv-scalibr/plugin"
+	"github.com/google/osv-scalibr/purl"
+	"github.com/google/osv-scalibr/stats"
+)
+
+const (
+	// Name is the unique name of this extractor.
+	Name = "erlang/mixlock"
+
+	// SourceHex is the source of dependencies fetched from a Hex repository.
+	SourceHex = "hex"
+	// SourceGit is the source of dependencies fetched from a git repository.
+	SourceGit = "git"
+
+	// Mix writes each dependency on a single line, but the lists of build
+	// tools and transitive dependencies in it can make it long.
+	maxLineBytes = 1024 * 1024
+)
+
+// A mix.lock is an Elixir map from the dependency names to tuples describing
+// the locked dependency, one entry per line, e.g.
+//
+//	%{
+//	  "plug": {:hex, :plug, "1.15.3", "<checksum>", [:mix], [<deps>], "hexpm", "<checksum>"},
+//	  "my_dep": {:git, "https://github.com/me/my_dep.git", "<commit>", [branch: "main"]},
+//	}
+//
+// Instead of evaluating the Elixir terms, the fields that identify the
+// dependency are matched with regular expressions.
+var (
+	// The dependency name and the source (the first atom of the tuple).
+	reEntry = regexp.MustCompile(`^\s*"([^"]+)":\s*\{:(\w+),\s*(.*)$`)
+	// The Hex package name, version and optionally the inner checksum.
+	reHex = regexp.MustCompile(`^:"?([^",]+?)"?,\s*"([^"]+)"(?:,\s*"([0-9a-fA-F]*)")?`)
+	// The Hex repository and the outer checksum at the end of the tuple, after
+	// the list of dependencies. Not present in lockfiles of old Mix versions.
+	reHexRepo = regexp.MustCompile(`\],\s*"([^"]+)"(?:,\s*"([0-9a-fA-F]+)")?\s*\},?\s*$`)
+	// The git repository URL and the locked commit.
+	reGit = regexp.MustCompile(`^"([^"]+)",\s*"([^"]+)"`)
+)
+
+// Config is the configuration for the Extractor.
+type Config struct {
+	// Stats is a stats collector for reporting metrics.
+	Stats stats.Collector
+	// MaxFileSizeBytes is the maximum file size this extractor will unmarshal. If
+	// `FileRequired` gets a bigger file, it will return false.
+	MaxFileSizeBytes int64
+}
+
+// DefaultConfig returns the default configuration for the extractor.
+func DefaultConfig() Config {
+	return Config{
+		Stats:            nil,
+		MaxFileSizeBytes: 0,
+	}
+}
+
+// Extractor extracts Hex and git dependencies from mix.lock files.
+type Extractor struct {
+	stats            stats.Collector
+	maxFileSizeBytes int64
+}
+
+// New returns a mix.lock extractor.
+//
+// For most use cases, initialize with:
+// ```
+// e := New(DefaultConfig())
+// ```
+func New(cfg Config) *Extractor {
+	return &Extractor{
+		stats:            cfg.Stats,
+		maxFileSizeBytes: cfg.MaxFileSizeBytes,
+	}
+}
+
+// Config returns the configuration of the extractor.
+func (e Extractor) Config() Config {
+	return Config{
+		Stats:            e.stats,
+		MaxFileSizeBytes: e.maxFileSizeBytes,
+	}
+}
+
+// Name of the extractor.
+func (e Extractor) Name() string { return Name }
+
+// Version of the extractor.
+func (e Extractor) Version() int { return 0 }
+
+// Requirements of the extractor.
+func (e Extractor) Requirements() *plugin.Capabilities { return &plugin.Capabilities{} }
+
+// RequiredFileNames returns the name of the lockfiles the extractor requires.
+func (e Extractor) RequiredFileNames() []string { return []string{"mix.lock"} }
+
+// FileRequired returns true if the specified file is a mix.lock.
+func (e Extractor) FileRequired(path string, fileinfo fs.FileInfo) bool {
+	if filepath.Base(path) != "mix.lock" {
+		return false
+	}
+
+	if e.maxFileSizeBytes > 0 && fileinfo.Size() > e.maxFileSizeBytes {
+		e.reportFileRequired(path, fileinfo.Size(), stats.FileRequiredResultSizeLimitExceeded)
+		return false
+	}
+
+	e.reportFileRequired(path, fileinfo.Size(), stats.FileRequiredResultOK)
+	return true
+}
+
+func (e Extractor) reportFileRequired(path string, fileSizeBytes int64, result stats.FileRequiredResult) {
+	if e.stats == nil {
+		return
+	}
+	e.stats.AfterFileRequired(e.Name(), &stats.FileRequiredStats{
+		Path:          path,
+		Result:        result,
+		FileSizeBytes: fileSizeBytes,
+	})
+}
+
+// Extract extracts dependencies from the mix.lock passed through the scan input.
+func (e Extractor) Extract(ctx context.Context, input *filesystem.ScanInput) ([]*extractor.Inventory, error) {
+	inventory, err := e.extractFromInput(input)
+	if e.stats != nil {
+		var fileSizeBytes int64
+		if input.Info != nil {
+			fileSizeBytes = input.Info.Size()
+		}
+		e.stats.AfterFileExtracted(e.Name(), &stats.FileExtractedStats{
+			Path:          input.Path,
+			Result:        filesystem.ExtractorErrorToFileExtractedResult(err),
+			FileSizeBytes: fileSizeBytes,
+		})
+	}
+	return inventory, err
+}
+
+func (e Extractor) extractFromInput(input *filesystem.ScanInput) ([]*extractor.Inventory, error) {
+	deps, err := parse(input.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %q: %w", input.Path, err)
+	}
+	for _, d := range deps {
+		d.Locations = []string{input.Path}
+	}
+	return deps, nil
+}
+
+// parse returns the Hex and git dependencies of the mix.lock. Dependencies
+// from other sources are skipped.
+func parse(r io.Reader) ([]*extractor.Inventory, error) {
+	s := bufio.NewScanner(r)
+	s.Buffer(nil, maxLineBytes)
+	foundMap := false
+	var res []*extractor.Inventory
+	for s.Scan() {
+		line := strings.TrimSpace(s.Text())
+		if line == "" {
+			continue
+		}
+		if !foundMap {
+			if !strings.HasPrefix(line, "%{") {
+				return nil, fmt.Errorf("%w: not an Elixir map", extractor.ErrMalformedInput)
+			}
+			foundMap = true
+			// An empty lockfile is written as "%{}".
+			line = strings.TrimSpace(strings.TrimPrefix(line, "%{"))
+		}
+
+		m := reEntry.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		name, source, rest := m[1], m[2], m[3]
+		var inv *extractor.Inventory
+		switch source {
+		case SourceHex:
+			inv = parseHex(rest)
+		case SourceGit:
+			inv = parseGit(name, rest)
+		}
+		if inv != nil {
+			res = append(res, inv)
+		}
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+	if !foundMap {
+		return nil, fmt.Errorf("%w: empty file", extractor.ErrMalformedInput)
+	}
+	return res, nil
+}
+
+// parseHex parses the fields of a Hex dependency following the :hex atom.
+// The package name can differ from the dependency name if the dependency
+// uses the :hex option to alias the package.
+func parseHex(fields string) *extractor.Inventory {
+	m := reHex.FindStringSubmatch(fields)
+	if m == nil {
+		return nil
+	}
+	md := &Metadata{Source: SourceHex}
+	if rm := reHexRepo.FindStringSubmatch(fields); rm != nil {
+		md.Repo = rm[1]
+		md.Checksum = rm[2]
+	}
+	return &extractor.Inventory{
+		Name:     m[1],
+		Version:  m[2],
+		Metadata: md,
+	}
+}
+
+// parseGit parses the fields of a git dependency following the :git atom.
+// Git dependencies have no version, only a locked commit.
+func parseGit(name string, fields string) *extractor.Inventory {
+	m := reGit.FindStringSubmatch(fields)
+	if m == nil {
+		return nil
+	}
+	return &extractor.Inventory{
+		Name: name,
+		Metadata: &Metadata{
+			Source: SourceGit,
+			GitURL: m[1],
+			Commit: m[2],
+		},
+	}
+}
+
+// ToPURL converts an inventory created by this extractor into a PURL. Packages
+// from private Hex organizations use the organization as the namespace.
+func (e Extractor) ToPURL(i *extractor.Inventory) (*purl.PackageURL, error) {
+	p := &purl.PackageURL{
+		Type:    purl.TypeHex,
+		Name:    strings.ToLower(i.Name),
+		Version: i.Version,
+	}
+	if m, ok := i.Metadata.(*Metadata); ok {
+		if org, ok := strings.CutPrefix(m.Repo, "hexpm:"); ok {
+			p.Namespace = strings.ToLower(org)
+		}
+	}
+	return p, nil
+}
+
+// ToCPEs is not applicable as this extractor does not infer CPEs from the Inventory.
+func (e Extractor) ToCPEs(i *extractor.Inventory) ([]string, error) { return []string{}, nil }
+
+// Ecosystem returns the OSV Ecosystem of the software extracted by this extractor.
+func (Extractor) Ecosystem(i *extractor.Inventory) (string, error) { return "Hex", nil }
@@ -0,0 +1,296 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mixlock_test
+
+import (
+	"context"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem"
+	"github.com/google/osv-scalibr/extractor/filesystem/internal/units"
+	"github.com/google/osv-scalibr/extractor/filesystem/language/erlang/mixlock"
+	scalibrfs "github.com/google/osv-scalibr/fs"
+	"github.com/google/osv-scalibr/purl"
+	"github.com/google/osv-scalibr/stats"
+	"github.com/google/osv-scalibr/testing/fakefs"
+	"github.com/google/osv-scalibr/testing/testcollector"
+)
+
+func TestFileRequired(t *testing.T) {
+	tests := []struct {
+		name             string
+		path             string
+		fileSizeBytes    int64
+		maxFileSizeBytes int64
+		wantRequired     bool
+		wantResultMetric stats.FileRequiredResult
+	}{
+		{
+			name:             "mix.lock",
+			path:             "project/mix.lock",
+			wantRequired:     true,
+			wantResultMetric: stats.FileRequiredResultOK,
+		},
+		{
+			name:         "mix.exs",
+			path:         "project/mix.exs",
+			wantRequired: false,
+		},
+		{
+			name:         "mix.lock suffix",
+			path:         "project/old-mix.lock",
+			wantRequired: false,
+		},
+		{
+			name:             "mix.lock required if file size < max file size",
+			path:             "project/mix.lock",
+			fileSizeBytes:    100 * units.KiB,
+			maxFileSizeBytes: 1000 * units.KiB,
+			wantRequired:     true,
+			wantResultMetric: stats.FileRequiredResultOK,
+		},
+		{
+			name:             "mix.lock not required if file size > max file size",
+			path:             "project/mix.lock",
+			fileSizeBytes:    1000 * units.KiB,
+			maxFileSizeBytes: 100 * units.KiB,
+			wantRequired:     false,
+			wantResultMetric: stats.FileRequiredResultSizeLimitExceeded,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			collector := testcollector.New()
+			var e filesystem.Extractor = mixlock.New(mixlock.Config{
+				Stats:            collector,
+				MaxFileSizeBytes: test.maxFileSizeBytes,
+			})
+
+			// Set default size if not provided.
+			fileSizeBytes := test.fileSizeBytes
+			if fileSizeBytes == 0 {
+				fileSizeBytes = 100 * units.KiB
+			}
+
+			isRequired := e.FileRequired(test.path, fakefs.FakeFileInfo{
+				FileName: filepath.Base(test.path),
+				FileMode: fs.ModePerm,
+				FileSize: fileSizeBytes,
+			})
+			if isRequired != test.wantRequired {
+				t.Fatalf("FileRequired(%s): got %v, want %v", test.path, isRequired, test.wantRequired)
+			}
+
+			gotResultMetric := collector.FileRequiredResult(test.path)
+			if gotResultMetric != test.wantResultMetric {
+				t.Errorf("FileRequired(%s) recorded result metric %v, want result metric %v", test.path, gotResultMetric, test.wantResultMetric)
+			}
+		})
+	}
+}
+
+func TestExtract(t *testing.T) {
+	const mixedPath = "testdata/mixed/mix.lock"
+	tests := []struct {
+		name             string
+		path             string
+		wantInventory    []*extractor.Inventory
+		wantErr          error
+		wantResultMetric stats.FileExtractedResult
+	}{
+		{
+			name: "hex and git dependencies",
+			path: mixedPath,
+			wantInventory: []*extractor.Inventory{
+				{
+					Name:    "castore",
+					Version: "1.0.5",
+					Metadata: &mixlock.Metadata{
+						Source:   mixlock.SourceHex,
+						Repo:     "hexpm",
+						Checksum: "8d7c597c3e4a64c395980882d4bca3cebb8d74197c590dc272cfd3b6a6310578",
+					},
+					Locations: []string{mixedPath},
+				},
+				{
+					Name:    "jason",
+					Version: "1.4.1",
+					Metadata: &mixlock.Metadata{
+						Source:   mixlock.SourceHex,
+						Repo:     "hexpm",
+						Checksum: "fbb01ecdfd565b56261302f7e1fcc27c4fb8f32d56eab74db621fc154604a7a1",
+					},
+					Locations: []string{mixedPath},
+				},
+				{
+					Name: "my_lib",
+					Metadata: &mixlock.Metadata{
+						Source: mixlock.SourceGit,
+						GitURL: "https://github.com/example/my_lib.git",
+						Commit: "5f4f3a7b2b8a9c4ee0bb4b1e8b0e6a0f6d3c1b2a",
+					},
+					Locations: []string{mixedPath},
+				},
+				{
+					Name:    "plug",
+					Version: "1.15.3",
+					Metadata: &mixlock.Metadata{
+						Source:   mixlock.SourceHex,
+						Repo:     "hexpm",
+						Checksum: "cc4365a3c010a56af402e0809208873d113e9c38c401cabd88027ef4f5c01fd2",
+					},
+					Locations: []string{mixedPath},
+				},
+				{
+					Name:    "private_lib",
+					Version: "0.3.0",
+					Metadata: &mixlock.Metadata{
+						Source:   mixlock.SourceHex,
+						Repo:     "hexpm:acme",
+						Checksum: "1c2d3e4f5a6b7c8d9e0f1a2b3c4d5e6f7a8b9c0d1e2f3a4b5c6d7e8f9a0b1c2d",
+					},
+					Locations: []string{mixedPath},
+				},
+				{
+					// Locked by an old Mix version without the outer checksum.
+					Name:    "ranch",
+					Version: "1.8.0",
+					Metadata: &mixlock.Metadata{
+						Source: mixlock.SourceHex,
+						Repo:   "hexpm",
+					},
+					Locations: []string{mixedPath},
+				},
+				{
+					Name: "telemetry",
+					Metadata: &mixlock.Metadata{
+						Source: mixlock.SourceGit,
+						GitURL: "https://github.com/beam-telemetry/telemetry.git",
+						Commit: "e0d5d5b8a2c5f2e7c9e8a6f0a1b2c3d4e5f6a7b8",
+					},
+					Locations: []string{mixedPath},
+				},
+			},
+			wantResultMetric: stats.FileExtractedResultSuccess,
+		},
+		{
+			name:             "empty lockfile",
+			path:             "testdata/empty/mix.lock",
+			wantResultMetric: stats.FileExtractedResultSuccess,
+		},
+		{
+			name:             "not a lockfile",
+			path:             "testdata/invalid/mix.lock",
+			wantErr:          extractor.ErrMalformedInput,
+			wantResultMetric: stats.FileExtractedResultErrorMalformedInput,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			collector := testcollector.New()
+			var e filesystem.Extractor = mixlock.New(mixlock.Config{Stats: collector})
+
+			r, err := os.Open(test.path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer r.Close()
+
+			info, err := os.Stat(test.path)
+			if err != nil {
+				t.Fatalf("Failed to stat test file: %v", err)
+			}
+
+			input := &filesystem.ScanInput{
+				FS:     scalibrfs.DirFS("."),
+				Path:   test.path,
+				Reader: r,
+				Info:   info,
+			}
+			got, err := e.Extract(context.Background(), input)
+			if !cmp.Equal(err, test.wantErr, cmpopts.EquateErrors()) {
+				t.Fatalf("Extract(%s) error: got %v, want %v", test.path, err, test.wantErr)
+			}
+
+			if diff := cmp.Diff(test.wantInventory, got); diff != "" {
+				t.Errorf("Extract(%s) (-want +got):\n%s", test.path, diff)
+			}
+
+			gotResultMetric := collector.FileExtractedResult(test.path)
+			if gotResultMetric != test.wantResultMetric {
+				t.Errorf("Extract(%s) recorded result metric %v, want result metric %v", test.path, gotResultMetric, test.wantResultMetric)
+			}
+		})
+	}
+}
+
+func TestToPURL(t *testing.T) {
+	e := mixlock.Extractor{}
+	tests := []struct {
+		name string
+		inv  *extractor.Inventory
+		want *purl.PackageURL
+	}{
+		{
+			name: "hex package",
+			inv: &extractor.Inventory{
+				Name:      "plug",
+				Version:   "1.15.3",
+				Metadata:  &mixlock.Metadata{Source: mixlock.SourceHex, Repo: "hexpm"},
+				Locations: []string{"location"},
+			},
+			want: &purl.PackageURL{Type: purl.TypeHex, Name: "plug", Version: "1.15.3"},
+		},
+		{
+			name: "package of a private organization",
+			inv: &extractor.Inventory{
+				Name:      "private_lib",
+				Version:   "0.3.0",
+				Metadata:  &mixlock.Metadata{Source: mixlock.SourceHex, Repo: "hexpm:Acme"},
+				Locations: []string{"location"},
+			},
+			want: &purl.PackageURL{Type: purl.TypeHex, Namespace: "acme", Name: "private_lib", Version: "0.3.0"},
+		},
+		{
+			name: "git dependency",
+			inv: &extractor.Inventory{
+				Name:      "my_lib",
+				Metadata:  &mixlock.Metadata{Source: mixlock.SourceGit, GitURL: "https://github.com/example/my_lib.git"},
+				Locations: []string{"location"},
+			},
+			want: &purl.PackageURL{Type: purl.TypeHex, Name: "my_lib"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := e.ToPURL(tt.inv)
+			if err != nil {
+				t.Fatalf("ToPURL(%v): %v", tt.inv, err)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("ToPURL(%v) (-want +got):\n%s", tt.inv, diff)
+			}
+		})
+	}
+}
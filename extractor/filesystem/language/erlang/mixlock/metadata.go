@@ -0,0 +1,31 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mixlock
+
+// Metadata contains additional information about a dependency in a mix.lock.
+type Metadata struct {
+	// Where the dependency is fetched from, SourceHex or SourceGit.
+	Source string
+	// The Hex repository of the package, e.g. "hexpm" or "hexpm:<organization>"
+	// for private packages. Only set for Hex packages.
+	Repo string
+	// The sha256 checksum of the Hex package tarball. Only set for Hex packages
+	// locked by Mix 1.10 or later.
+	Checksum string
+	// The URL of the git repository. Only set for git dependencies.
+	GitURL string
+	// The locked commit. Only set for git dependencies.
+	Commit string
+}
@@ -0,0 +1,270 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package gomod extracts the modules required by the go.mod files of Go
+// source trees.
+package gomod
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/mod/modfile"
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem"
+	"github.com/google/osv-scalibr/extractor/filesystem/internal/units"
+	"github.com/google/osv-scalibr/plugin"
+	"github.com/google/osv-scalibr/purl"
+	"github.com/google/osv-scalibr/stats"
+)
+
+const (
+	// Name is the unique name of this extractor.
+	Name = "go/gomod"
+
+	// defaultMaxFileSizeBytes is the maximum file size an extractor will unmarshal.
+	// If Extract gets a bigger file, it will return an error.
+	defaultMaxFileSizeBytes = 10 * units.MiB
+
+	// The suffix of the go.sum entries holding the hash of a module's go.mod
+	// instead of the hash of the whole module.
+	goModHashSuffix = "/go.mod"
+)
+
+// Config is the configuration for the Extractor.
+type Config struct {
+	// Stats is a stats collector for reporting metrics.
+	Stats stats.Collector
+	// MaxFileSizeBytes is the maximum file size this extractor will unmarshal. If
+	// `FileRequired` gets a bigger file, it will return false.
+	MaxFileSizeBytes int64
+}
+
+// DefaultConfig returns the default configuration for the extractor.
+func DefaultConfig() Config {
+	return Config{
+		Stats:            nil,
+		MaxFileSizeBytes: defaultMaxFileSizeBytes,
+	}
+}
+
+// Extractor extracts the required modules from go.mod files.
+type Extractor struct {
+	stats            stats.Collector
+	maxFileSizeBytes int64
+}
+
+// New returns a go.mod extractor.
+//
+// For most use cases, initialize with:
+// ```
+// e := New(DefaultConfig())
+// ```
+func New(cfg Config) *Extractor {
+	return &Extractor{
+		stats:            cfg.Stats,
+		maxFileSizeBytes: cfg.MaxFileSizeBytes,
+	}
+}
+
+// Config returns the configuration of the extractor.
+func (e Extractor) Config() Config {
+	return Config{
+		Stats:            e.stats,
+		MaxFileSizeBytes: e.maxFileSizeBytes,
+	}
+}
+
+// Name of the extractor.
+func (e Extractor) Name() string { return Name }
+
+// Version of the extractor.
+func (e Extractor) Version() int { return 0 }
+
+// Requirements of the extractor.
+func (e Extractor) Requirements() *plugin.Capabilities { return &plugin.Capabilities{} }
+
+// RequiredFileNames returns the name of the files the extractor requires.
+func (e Extractor) RequiredFileNames() []string { return []string{"go.mod"} }
+
+// FileRequired returns true if the specified file is a go.mod.
+func (e Extractor) FileRequired(path string, fileinfo fs.FileInfo) bool {
+	if filepath.Base(path) != "go.mod" {
+		return false
+	}
+
+	if e.maxFileSizeBytes > 0 && fileinfo.Size() > e.maxFileSizeBytes {
+		e.reportFileRequired(path, fileinfo.Size(), stats.FileRequiredResultSizeLimitExceeded)
+		return false
+	}
+
+	e.reportFileRequired(path, fileinfo.Size(), stats.FileRequiredResultOK)
+	return true
+}
+
+func (e Extractor) reportFileRequired(path string, fileSizeBytes int64, result stats.FileRequiredResult) {
+	if e.stats == nil {
+		return
+	}
+	e.stats.AfterFileRequired(e.Name(), &stats.FileRequiredStats{
+		Path:          path,
+		Result:        result,
+		FileSizeBytes: fileSizeBytes,
+	})
+}
+
+// Extract extracts the required modules from the go.mod passed through the
+// scan input.
+func (e Extractor) Extract(ctx context.Context, input *filesystem.ScanInput) ([]*extractor.Inventory, error) {
+	inventory, err := e.extractFromInput(input)
+	if e.stats != nil {
+		var fileSizeBytes int64
+		if input.Info != nil {
+			fileSizeBytes = input.Info.Size()
+		}
+		e.stats.AfterFileExtracted(e.Name(), &stats.FileExtractedStats{
+			Path:          input.Path,
+			Result:        filesystem.ExtractorErrorToFileExtractedResult(err),
+			FileSizeBytes: fileSizeBytes,
+		})
+	}
+	return inventory, err
+}
+
+func (e Extractor) extractFromInput(input *filesystem.ScanInput) ([]*extractor.Inventory, error) {
+	data, err := io.ReadAll(input.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %q: %w", input.Path, err)
+	}
+	f, err := modfile.Parse(input.Path, data, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %q: %w: %w", input.Path, extractor.ErrMalformedInput, err)
+	}
+
+	sumPath := path.Join(path.Dir(input.Path), "go.sum")
+	sums, err := readGoSum(input.FS, sumPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %q: %w", sumPath, err)
+	}
+
+	var inventory []*extractor.Inventory
+	for _, r := range f.Require {
+		m := &Metadata{Indirect: r.Indirect}
+		name, version := r.Mod.Path, r.Mod.Version
+		if rep := findReplace(f.Replace, name, version); rep != nil {
+			if rep.New.Version == "" {
+				// Replaced with a local directory, which has no version.
+				m.ReplaceDir = rep.New.Path
+				version = ""
+			} else {
+				m.RequiredPath, m.RequiredVersion = name, version
+				name, version = rep.New.Path, rep.New.Version
+			}
+		}
+		if sums != nil && version != "" {
+			h, ok := sums[name+" "+version]
+			m.Checksum = h
+			m.MissingFromGoSum = !ok
+		}
+		inventory = append(inventory, &extractor.Inventory{
+			Name:      name,
+			Version:   strings.TrimPrefix(version, "v"),
+			Metadata:  m,
+			Locations: []string{input.Path},
+		})
+	}
+	return inventory, nil
+}
+
+// findReplace returns the replace directive that applies to the given module
+// version. A replace directive for the specific version takes precedence over
+// one that replaces all versions of the module.
+func findReplace(replaces []*modfile.Replace, modPath, version string) *modfile.Replace {
+	var res *modfile.Replace
+	for _, r := range replaces {
+		if r.Old.Path != modPath {
+			continue
+		}
+		if r.Old.Version == version {
+			return r
+		}
+		if r.Old.Version == "" {
+			res = r
+		}
+	}
+	return res
+}
+
+// readGoSum returns the module hashes of the go.sum at the given path, keyed
+// by "<module path> <version>". Modules for which go.sum only lists the hash
+// of their go.mod are included with an empty hash. Returns nil if there is no
+// go.sum.
+func readGoSum(fsys fs.FS, sumPath string) (map[string]string, error) {
+	if fsys == nil {
+		return nil, nil
+	}
+	f, err := fsys.Open(sumPath)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	sums := make(map[string]string)
+	s := bufio.NewScanner(f)
+	for s.Scan() {
+		// Each line is "<module path> <version>[/go.mod] <hash>".
+		fields := strings.Fields(s.Text())
+		if len(fields) != 3 {
+			continue
+		}
+		modPath, version, hash := fields[0], fields[1], fields[2]
+		key := modPath + " " + strings.TrimSuffix(version, goModHashSuffix)
+		if strings.HasSuffix(version, goModHashSuffix) {
+			if _, ok := sums[key]; !ok {
+				sums[key] = ""
+			}
+			continue
+		}
+		sums[key] = hash
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+	return sums, nil
+}
+
+// ToPURL converts an inventory created by this extractor into a PURL.
+func (e Extractor) ToPURL(i *extractor.Inventory) (*purl.PackageURL, error) {
+	return &purl.PackageURL{
+		Type:    purl.TypeGolang,
+		Name:    i.Name,
+		Version: i.Version,
+	}, nil
+}
+
+// ToCPEs is not applicable as this extractor does not infer CPEs from the Inventory.
+func (e Extractor) ToCPEs(i *extractor.Inventory) ([]string, error) { return []string{}, nil }
+
+// Ecosystem returns the OSV Ecosystem of the software extracted by this extractor.
+func (Extractor) Ecosystem(i *extractor.Inventory) (string, error) { return "Go", nil }
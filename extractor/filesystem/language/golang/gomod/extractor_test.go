@@ -0,0 +1,285 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gomod_test
+
+import (
+	"context"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem"
+	"github.com/google/osv-scalibr/extractor/filesystem/internal/units"
+	"github.com/google/osv-scalibr/extractor/filesystem/language/golang/gomod"
+	scalibrfs "github.com/google/osv-scalibr/fs"
+	"github.com/google/osv-scalibr/purl"
+	"github.com/google/osv-scalibr/stats"
+	"github.com/google/osv-scalibr/testing/fakefs"
+	"github.com/google/osv-scalibr/testing/testcollector"
+)
+
+func TestFileRequired(t *testing.T) {
+	tests := []struct {
+		name             string
+		path             string
+		fileSizeBytes    int64
+		maxFileSizeBytes int64
+		wantRequired     bool
+		wantResultMetric stats.FileRequiredResult
+	}{
+		{
+			name:             "go.mod",
+			path:             "project/go.mod",
+			wantRequired:     true,
+			wantResultMetric: stats.FileRequiredResultOK,
+		},
+		{
+			name:         "go.sum",
+			path:         "project/go.sum",
+			wantRequired: false,
+		},
+		{
+			name:         "go.mod suffix",
+			path:         "project/old-go.mod",
+			wantRequired: false,
+		},
+		{
+			name:             "go.mod required if file size < max file size",
+			path:             "project/go.mod",
+			fileSizeBytes:    100 * units.KiB,
+			maxFileSizeBytes: 1000 * units.KiB,
+			wantRequired:     true,
+			wantResultMetric: stats.FileRequiredResultOK,
+		},
+		{
+			name:             "go.mod not required if file size > max file size",
+			path:             "project/go.mod",
+			fileSizeBytes:    1000 * units.KiB,
+			maxFileSizeBytes: 100 * units.KiB,
+			wantRequired:     false,
+			wantResultMetric: stats.FileRequiredResultSizeLimitExceeded,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			collector := testcollector.New()
+			var e filesystem.Extractor = gomod.New(gomod.Config{
+				Stats:            collector,
+				MaxFileSizeBytes: test.maxFileSizeBytes,
+			})
+
+			// Set default size if not provided.
+			fileSizeBytes := test.fileSizeBytes
+			if fileSizeBytes == 0 {
+				fileSizeBytes = 100 * units.KiB
+			}
+
+			isRequired := e.FileRequired(test.path, fakefs.FakeFileInfo{
+				FileName: filepath.Base(test.path),
+				FileMode: fs.ModePerm,
+				FileSize: fileSizeBytes,
+			})
+			if isRequired != test.wantRequired {
+				t.Fatalf("FileRequired(%s): got %v, want %v", test.path, isRequired, test.wantRequired)
+			}
+
+			gotResultMetric := collector.FileRequiredResult(test.path)
+			if gotResultMetric != test.wantResultMetric {
+				t.Errorf("FileRequired(%s) recorded result metric %v, want result metric %v", test.path, gotResultMetric, test.wantResultMetric)
+			}
+		})
+	}
+}
+
+func TestExtract(t *testing.T) {
+	const (
+		replacesPath = "testdata/replaces/go.mod"
+		noGoSumPath  = "testdata/nogosum/go.mod"
+	)
+	tests := []struct {
+		name             string
+		path             string
+		wantInventory    []*extractor.Inventory
+		wantErr          error
+		wantResultMetric stats.FileExtractedResult
+	}{
+		{
+			name: "replaces and indirect dependencies",
+			path: replacesPath,
+			wantInventory: []*extractor.Inventory{
+				{
+					Name:    "github.com/google/uuid",
+					Version: "1.6.0",
+					Metadata: &gomod.Metadata{
+						Checksum: "h1:NIvaJDMOsjHA8n1jAhLSgzrAzy1Hgr+hNrb57e+94F0=",
+					},
+					Locations: []string{replacesPath},
+				},
+				{
+					Name:    "github.com/BurntSushi/toml",
+					Version: "1.3.2",
+					Metadata: &gomod.Metadata{
+						Checksum: "h1:o7IhLm0Msx3BaB+n3Ag7L8EVlByGnpq14C4YWiu/gL8=",
+					},
+					Locations: []string{replacesPath},
+				},
+				{
+					Name:    "github.com/example/mux",
+					Version: "1.8.1",
+					Metadata: &gomod.Metadata{
+						RequiredPath:    "github.com/gorilla/mux",
+						RequiredVersion: "v1.8.0",
+						Checksum:        "h1:TuBL49tXwgrFYWhqrNgrUNEY92u81SPhu7sTdzQEiWY=",
+					},
+					Locations: []string{replacesPath},
+				},
+				{
+					Name:    "golang.org/x/net",
+					Version: "0.25.0",
+					Metadata: &gomod.Metadata{
+						RequiredPath:    "golang.org/x/net",
+						RequiredVersion: "v0.23.0",
+						Checksum:        "h1:d/OCCoBEUq33pjydKrGQhw7IlUPI2Oylr+8qLx49kac=",
+					},
+					Locations: []string{replacesPath},
+				},
+				{
+					Name:      "example.com/internal/lib",
+					Metadata:  &gomod.Metadata{ReplaceDir: "../lib"},
+					Locations: []string{replacesPath},
+				},
+				{
+					// go.sum only has the hash of the go.mod.
+					Name:      "github.com/kr/text",
+					Version:   "0.2.0",
+					Metadata:  &gomod.Metadata{Indirect: true},
+					Locations: []string{replacesPath},
+				},
+				{
+					// Only v0.13.0 is replaced.
+					Name:    "golang.org/x/text",
+					Version: "0.14.0",
+					Metadata: &gomod.Metadata{
+						Indirect: true,
+						Checksum: "h1:ScX5w1eTa3QqT8oi6+ziP7dTV1S2+ALU0bI+0zXKWiQ=",
+					},
+					Locations: []string{replacesPath},
+				},
+				{
+					Name:    "gopkg.in/yaml.v3",
+					Version: "3.0.1",
+					Metadata: &gomod.Metadata{
+						Indirect:         true,
+						MissingFromGoSum: true,
+					},
+					Locations: []string{replacesPath},
+				},
+			},
+			wantResultMetric: stats.FileExtractedResultSuccess,
+		},
+		{
+			name: "no go.sum",
+			path: noGoSumPath,
+			wantInventory: []*extractor.Inventory{
+				{
+					Name:      "github.com/spf13/cobra",
+					Version:   "1.8.0",
+					Metadata:  &gomod.Metadata{},
+					Locations: []string{noGoSumPath},
+				},
+				{
+					Name:      "github.com/inconshreveable/mousetrap",
+					Version:   "1.1.0",
+					Metadata:  &gomod.Metadata{Indirect: true},
+					Locations: []string{noGoSumPath},
+				},
+				{
+					Name:      "github.com/spf13/pflag",
+					Version:   "1.0.5",
+					Metadata:  &gomod.Metadata{Indirect: true},
+					Locations: []string{noGoSumPath},
+				},
+			},
+			wantResultMetric: stats.FileExtractedResultSuccess,
+		},
+		{
+			name:             "invalid go.mod",
+			path:             "testdata/invalid/go.mod",
+			wantErr:          extractor.ErrMalformedInput,
+			wantResultMetric: stats.FileExtractedResultErrorMalformedInput,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			collector := testcollector.New()
+			var e filesystem.Extractor = gomod.New(gomod.Config{Stats: collector})
+
+			r, err := os.Open(test.path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer r.Close()
+
+			info, err := os.Stat(test.path)
+			if err != nil {
+				t.Fatalf("Failed to stat test file: %v", err)
+			}
+
+			input := &filesystem.ScanInput{
+				FS:     scalibrfs.DirFS("."),
+				Path:   test.path,
+				Reader: r,
+				Info:   info,
+			}
+			got, err := e.Extract(context.Background(), input)
+			if !cmp.Equal(err, test.wantErr, cmpopts.EquateErrors()) {
+				t.Fatalf("Extract(%s) error: got %v, want %v", test.path, err, test.wantErr)
+			}
+
+			if diff := cmp.Diff(test.wantInventory, got); diff != "" {
+				t.Errorf("Extract(%s) (-want +got):\n%s", test.path, diff)
+			}
+
+			gotResultMetric := collector.FileExtractedResult(test.path)
+			if gotResultMetric != test.wantResultMetric {
+				t.Errorf("Extract(%s) recorded result metric %v, want result metric %v", test.path, gotResultMetric, test.wantResultMetric)
+			}
+		})
+	}
+}
+
+func TestToPURL(t *testing.T) {
+	e := gomod.Extractor{}
+	i := &extractor.Inventory{
+		Name:      "github.com/google/uuid",
+		Version:   "1.6.0",
+		Metadata:  &gomod.Metadata{},
+		Locations: []string{"location"},
+	}
+	want := &purl.PackageURL{Type: purl.TypeGolang, Name: "github.com/google/uuid", Version: "1.6.0"}
+	got, err := e.ToPURL(i)
+	if err != nil {
+		t.Fatalf("ToPURL(%v): %v", i, err)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ToPURL(%v) (-want +got):\n%s", i, diff)
+	}
+}
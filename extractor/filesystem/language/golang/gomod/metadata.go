@@ -0,0 +1,36 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gomod
+
+// Metadata contains additional information about a module required by a
+// go.mod.
+type Metadata struct {
+	// Set if the require directive is marked with an "// indirect" comment,
+	// i.e. no package of the main module imports the module.
+	Indirect bool
+	// The module path and version of the require directive. Only set if a
+	// replace directive substitutes the required module with another module
+	// or version.
+	RequiredPath    string
+	RequiredVersion string
+	// The directory a replace directive substitutes the required module with.
+	// The inventory has no version then.
+	ReplaceDir string
+	// The "h1:" hash of the module in the go.sum next to the go.mod. Empty if
+	// there is no go.sum or it only lists the hash of the module's go.mod.
+	Checksum string
+	// Set if there is a go.sum next to the go.mod which doesn't list the module.
+	MissingFromGoSum bool
+}
@@ -0,0 +1,5 @@
+module example.com/broken
+
+require (
+	github.com/spf13/cobra
+)
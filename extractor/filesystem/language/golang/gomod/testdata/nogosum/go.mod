@@ -0,0 +1,9 @@
+module example.com/tool
+
+go 1.21
+
+require (
+	github.com/spf13/cobra v1.8.0
+	github.com/inconshreveable/mousetrap v1.1.0 // indirect
+	github.com/spf13/pflag v1.0.5 // indirect
+)
@@ -0,0 +1,28 @@
+module example.com/app
+
+go 1.22
+
+require github.com/google/uuid v1.6.0
+
+require (
+	github.com/BurntSushi/toml v1.3.2
+	github.com/gorilla/mux v1.8.0
+	golang.org/x/net v0.23.0
+	example.com/internal/lib v0.1.0
+)
+
+require (
+	github.com/kr/text v0.2.0 // indirect
+	golang.org/x/text v0.14.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect; used by tests
+)
+
+// A fork with a security fix.
+replace github.com/gorilla/mux => github.com/example/mux v1.8.1
+
+// Only replaces the required version of golang.org/x/net.
+replace golang.org/x/net v0.23.0 => golang.org/x/net v0.25.0
+
+replace golang.org/x/text v0.13.0 => golang.org/x/text v0.15.0
+
+replace example.com/internal/lib => ../lib
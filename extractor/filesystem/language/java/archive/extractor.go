@@ -128,8 +128,14 @@ func (e Extractor) Name() string { return Name }
 // Version of the extractor.
 func (e Extractor) Version() int { return 0 }
 
-// Requirements of the extractor.
-func (e Extractor) Requirements() *plugin.Capabilities { return &plugin.Capabilities{} }
+// Requirements of the extractor. Nested archives are read into memory, so the
+// extractor can need several times the size of the scanned archive in RAM.
+func (e Extractor) Requirements() *plugin.Capabilities {
+	return &plugin.Capabilities{Memory: plugin.MemoryMultipleFileSize}
+}
+
+// RequiredFileExtensions returns the extensions of the archives the extractor requires.
+func (e Extractor) RequiredFileExtensions() []string { return archiveExtensions }
 
 // FileRequired returns true if the specified file matches java archive file patterns.
 func (e Extractor) FileRequired(path string, fileinfo fs.FileInfo) bool {
@@ -232,7 +238,7 @@ func (e Extractor) extractWithMax(ctx context.Context, input *filesystem.ScanInp
 	// Unzip Jar
 	zipReader, err := zip.NewReader(r, l)
 	if err != nil {
-		return nil, openedBytes, fmt.Errorf("%s invalid archive at %q: %w", e.Name(), input.Path, err)
+		return nil, openedBytes, fmt.Errorf("%s invalid archive at %q: %w: %w", e.Name(), input.Path, extractor.ErrMalformedInput, err)
 	}
 
 	log.Debugf("extract jar archive: %s", input.Path)
@@ -0,0 +1,323 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package m2repo extracts the artifacts installed in local Maven repositories,
+// e.g. ~/.m2/repository.
+package m2repo
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io/fs"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem"
+	"github.com/google/osv-scalibr/extractor/filesystem/internal/units"
+	"github.com/google/osv-scalibr/log"
+	"github.com/google/osv-scalibr/plugin"
+	"github.com/google/osv-scalibr/purl"
+	"github.com/google/osv-scalibr/stats"
+)
+
+const (
+	// Name is the unique name of this extractor.
+	Name = "java/m2repo"
+
+	// defaultMaxFileSizeBytes is the maximum POM file size an extractor will unmarshal.
+	// If Extract gets a bigger file, it will return an error.
+	defaultMaxFileSizeBytes = 1 * units.MiB
+
+	snapshotSuffix = "-SNAPSHOT"
+	// repositoryDirName is the name of the local repository directory, used to
+	// find the groupId if the POM doesn't specify it.
+	repositoryDirName = "repository"
+)
+
+// pom holds the POM fields used to confirm the coordinates derived from the
+// repository layout.
+type pom struct {
+	GroupID    string `xml:"groupId"`
+	ArtifactID string `xml:"artifactId"`
+	Version    string `xml:"version"`
+	Packaging  string `xml:"packaging"`
+	Parent     struct {
+		GroupID string `xml:"groupId"`
+	} `xml:"parent"`
+}
+
+// Config is the configuration for the Extractor.
+type Config struct {
+	// Stats is a stats collector for reporting metrics.
+	Stats stats.Collector
+	// MaxFileSizeBytes is the maximum POM file size this extractor will
+	// unmarshal. If `FileRequired` gets a bigger file, it will return false.
+	MaxFileSizeBytes int64
+}
+
+// DefaultConfig returns the default configuration for the extractor.
+func DefaultConfig() Config {
+	return Config{
+		MaxFileSizeBytes: defaultMaxFileSizeBytes,
+		Stats:            nil,
+	}
+}
+
+// Extractor extracts Maven artifacts from the POM files in local Maven repositories.
+type Extractor struct {
+	stats            stats.Collector
+	maxFileSizeBytes int64
+}
+
+// New returns a local Maven repository extractor.
+//
+// For most use cases, initialize with:
+// ```
+// e := New(DefaultConfig())
+// ```
+func New(cfg Config) *Extractor {
+	return &Extractor{
+		stats:            cfg.Stats,
+		maxFileSizeBytes: cfg.MaxFileSizeBytes,
+	}
+}
+
+// Config returns the configuration of the extractor.
+func (e Extractor) Config() Config {
+	return Config{
+		Stats:            e.stats,
+		MaxFileSizeBytes: e.maxFileSizeBytes,
+	}
+}
+
+// Name of the extractor.
+func (e Extractor) Name() string { return Name }
+
+// Version of the extractor.
+func (e Extractor) Version() int { return 0 }
+
+// Requirements of the extractor.
+func (e Extractor) Requirements() *plugin.Capabilities { return &plugin.Capabilities{} }
+
+// layout holds the coordinates derived from the path of a POM file in a
+// Maven repository, i.e. <groupId path>/<artifactId>/<version>/<artifactId>-<fileVersion>.pom
+type layout struct {
+	// groupDirs are the directories before the artifactId directory.
+	groupDirs  []string
+	artifactID string
+	version    string
+	// fileVersion is the version in the file names. Differs from version for
+	// timestamped snapshots.
+	fileVersion string
+}
+
+// parseLayout returns the coordinates encoded in the POM's path, or false if
+// the path doesn't follow the Maven repository layout.
+func parseLayout(p string) (*layout, bool) {
+	p = filepath.ToSlash(p)
+	if path.Ext(p) != ".pom" {
+		return nil, false
+	}
+	dirs := strings.Split(p, "/")
+	// At least one group directory, the artifactId, the version and the file.
+	if len(dirs) < 4 {
+		return nil, false
+	}
+	fileName := dirs[len(dirs)-1]
+	version := dirs[len(dirs)-2]
+	artifactID := dirs[len(dirs)-3]
+
+	fileVersion, ok := strings.CutPrefix(strings.TrimSuffix(fileName, ".pom"), artifactID+"-")
+	if !ok || fileVersion == "" {
+		return nil, false
+	}
+	if fileVersion != version {
+		// Remote snapshots are stored with the timestamp and build number instead
+		// of the SNAPSHOT suffix, e.g. 1.0-SNAPSHOT/foo-1.0-20240315.101530-3.pom.
+		base, isSnapshot := strings.CutSuffix(version, snapshotSuffix)
+		if !isSnapshot || !strings.HasPrefix(fileVersion, base+"-") {
+			return nil, false
+		}
+	}
+	return &layout{
+		groupDirs:   dirs[:len(dirs)-3],
+		artifactID:  artifactID,
+		version:     version,
+		fileVersion: fileVersion,
+	}, true
+}
+
+// FileRequired returns true if the specified file is a POM file stored in the
+// Maven repository layout. The artifact files next to it aren't required so
+// that every artifact is only reported once.
+func (e Extractor) FileRequired(path string, fileinfo fs.FileInfo) bool {
+	if _, ok := parseLayout(path); !ok {
+		return false
+	}
+
+	if e.maxFileSizeBytes > 0 && fileinfo.Size() > e.maxFileSizeBytes {
+		e.reportFileRequired(path, fileinfo.Size(), stats.FileRequiredResultSizeLimitExceeded)
+		return false
+	}
+
+	e.reportFileRequired(path, fileinfo.Size(), stats.FileRequiredResultOK)
+	return true
+}
+
+func (e Extractor) reportFileRequired(path string, fileSizeBytes int64, result stats.FileRequiredResult) {
+	if e.stats == nil {
+		return
+	}
+	e.stats.AfterFileRequired(e.Name(), &stats.FileRequiredStats{
+		Path:          path,
+		Result:        result,
+		FileSizeBytes: fileSizeBytes,
+	})
+}
+
+// Extract extracts the Maven artifact from the POM file passed through the scan input.
+func (e Extractor) Extract(ctx context.Context, input *filesystem.ScanInput) ([]*extractor.Inventory, error) {
+	inventory, err := e.extractFromInput(input)
+	if e.stats != nil {
+		var fileSizeBytes int64
+		if input.Info != nil {
+			fileSizeBytes = input.Info.Size()
+		}
+		e.stats.AfterFileExtracted(e.Name(), &stats.FileExtractedStats{
+			Path:          input.Path,
+			Result:        filesystem.ExtractorErrorToFileExtractedResult(err),
+			FileSizeBytes: fileSizeBytes,
+		})
+	}
+	return inventory, err
+}
+
+func (e Extractor) extractFromInput(input *filesystem.ScanInput) ([]*extractor.Inventory, error) {
+	l, ok := parseLayout(input.Path)
+	if !ok {
+		return nil, fmt.Errorf("%q is not in a Maven repository", input.Path)
+	}
+
+	var p pom
+	if err := xml.NewDecoder(input.Reader).Decode(&p); err != nil {
+		return nil, fmt.Errorf("failed to parse POM %q: %w: %w", input.Path, extractor.ErrMalformedInput, err)
+	}
+
+	// The repository layout is authoritative, the POM is only used to confirm it.
+	// Fields with unresolved properties such as ${revision} can't be compared.
+	if isSet(p.ArtifactID) && p.ArtifactID != l.artifactID {
+		log.Debugf("Skipping %q: artifactId %q doesn't match its path", input.Path, p.ArtifactID)
+		return nil, nil
+	}
+	if isSet(p.Version) && p.Version != l.version {
+		log.Debugf("Skipping %q: version %q doesn't match its path", input.Path, p.Version)
+		return nil, nil
+	}
+	groupID := p.GroupID
+	if !isSet(groupID) {
+		groupID = p.Parent.GroupID
+	}
+	if isSet(groupID) {
+		if !slices.Equal(strings.Split(groupID, "."), tail(l.groupDirs, strings.Count(groupID, ".")+1)) {
+			log.Debugf("Skipping %q: groupId %q doesn't match its path", input.Path, groupID)
+			return nil, nil
+		}
+	} else {
+		i := slices.Index(l.groupDirs, repositoryDirName)
+		if i < 0 || i == len(l.groupDirs)-1 {
+			return nil, fmt.Errorf("failed to determine the groupId of %q: %w", input.Path, extractor.ErrMalformedInput)
+		}
+		groupID = strings.Join(l.groupDirs[i+1:], ".")
+	}
+
+	var snapshotVersion string
+	if l.fileVersion != l.version {
+		snapshotVersion = l.fileVersion
+	}
+	classifiers, err := classifiers(input.FS, path.Dir(filepath.ToSlash(input.Path)), l.artifactID+"-"+l.fileVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	return []*extractor.Inventory{&extractor.Inventory{
+		Name:    l.artifactID,
+		Version: l.version,
+		Metadata: &Metadata{
+			GroupID:         groupID,
+			ArtifactID:      l.artifactID,
+			Packaging:       p.Packaging,
+			SnapshotVersion: snapshotVersion,
+			Classifiers:     classifiers,
+		},
+		Locations: []string{input.Path},
+	}}, nil
+}
+
+// classifiers returns the sorted classifiers of the artifact files in dir,
+// e.g. "sources" for guava-33.0.0-jre-sources.jar.
+func classifiers(fsys fs.FS, dir string, prefix string) ([]string, error) {
+	if fsys == nil {
+		return nil, nil
+	}
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list %q: %w", dir, err)
+	}
+	var result []string
+	for _, entry := range entries {
+		rest, ok := strings.CutPrefix(entry.Name(), prefix+"-")
+		if !ok || entry.IsDir() {
+			continue
+		}
+		classifier, _, _ := strings.Cut(rest, ".")
+		if classifier != "" && !slices.Contains(result, classifier) {
+			result = append(result, classifier)
+		}
+	}
+	slices.Sort(result)
+	return result, nil
+}
+
+// isSet returns whether a POM field has a value that's not a property reference.
+func isSet(value string) bool {
+	return value != "" && !strings.Contains(value, "${")
+}
+
+func tail(s []string, n int) []string {
+	if n > len(s) {
+		return nil
+	}
+	return s[len(s)-n:]
+}
+
+// ToPURL converts an inventory created by this extractor into a PURL.
+func (e Extractor) ToPURL(i *extractor.Inventory) (*purl.PackageURL, error) {
+	m := i.Metadata.(*Metadata)
+	return &purl.PackageURL{
+		Type:      purl.TypeMaven,
+		Namespace: strings.ToLower(m.GroupID),
+		Name:      strings.ToLower(m.ArtifactID),
+		Version:   i.Version,
+	}, nil
+}
+
+// ToCPEs is not applicable as this extractor does not infer CPEs from the Inventory.
+func (e Extractor) ToCPEs(i *extractor.Inventory) ([]string, error) { return []string{}, nil }
+
+// Ecosystem returns the OSV Ecosystem of the software extracted by this extractor.
+func (Extractor) Ecosystem(i *extractor.Inventory) (string, error) { return "Maven", nil }
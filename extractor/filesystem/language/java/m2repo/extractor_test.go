@@ -0,0 +1,312 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package m2repo_test
+
+import (
+	"context"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem"
+	"github.com/google/osv-scalibr/extractor/filesystem/internal/units"
+	"github.com/google/osv-scalibr/extractor/filesystem/language/java/m2repo"
+	scalibrfs "github.com/google/osv-scalibr/fs"
+	"github.com/google/osv-scalibr/purl"
+	"github.com/google/osv-scalibr/stats"
+	"github.com/google/osv-scalibr/testing/fakefs"
+	"github.com/google/osv-scalibr/testing/testcollector"
+)
+
+func TestFileRequired(t *testing.T) {
+	tests := []struct {
+		name             string
+		path             string
+		fileSizeBytes    int64
+		maxFileSizeBytes int64
+		wantRequired     bool
+		wantResultMetric stats.FileRequiredResult
+	}{
+		{
+			name:             "release POM",
+			path:             "home/user/.m2/repository/com/google/guava/guava/33.0.0-jre/guava-33.0.0-jre.pom",
+			wantRequired:     true,
+			wantResultMetric: stats.FileRequiredResultOK,
+		}, {
+			name:             "timestamped snapshot POM",
+			path:             "repository/org/example/app/1.0-SNAPSHOT/app-1.0-20240315.101530-3.pom",
+			wantRequired:     true,
+			wantResultMetric: stats.FileRequiredResultOK,
+		}, {
+			name:             "local snapshot POM",
+			path:             "repository/org/example/app/1.0-SNAPSHOT/app-1.0-SNAPSHOT.pom",
+			wantRequired:     true,
+			wantResultMetric: stats.FileRequiredResultOK,
+		}, {
+			name:         "jar",
+			path:         "repository/com/google/guava/guava/33.0.0-jre/guava-33.0.0-jre.jar",
+			wantRequired: false,
+		}, {
+			name:         "POM checksum",
+			path:         "repository/com/google/guava/guava/33.0.0-jre/guava-33.0.0-jre.pom.sha1",
+			wantRequired: false,
+		}, {
+			name:         "POM of a different artifact",
+			path:         "repository/com/google/guava/guava/33.0.0-jre/guava-parent-33.0.0-jre.pom",
+			wantRequired: false,
+		}, {
+			name:         "POM with a different version",
+			path:         "repository/com/google/guava/guava/33.0.0-jre/guava-32.0.0-jre.pom",
+			wantRequired: false,
+		}, {
+			name:         "timestamped POM of a release",
+			path:         "repository/org/example/app/1.0/app-1.0-20240315.101530-3.pom",
+			wantRequired: false,
+		}, {
+			name:         "POM outside of a repository",
+			path:         "project/pom.pom",
+			wantRequired: false,
+		}, {
+			name:         "pom.xml",
+			path:         "project/app/1.0/pom.xml",
+			wantRequired: false,
+		}, {
+			name:             "file size limit exceeded",
+			path:             "repository/com/google/guava/guava/33.0.0-jre/guava-33.0.0-jre.pom",
+			fileSizeBytes:    1 * units.MiB,
+			maxFileSizeBytes: 1000,
+			wantRequired:     false,
+			wantResultMetric: stats.FileRequiredResultSizeLimitExceeded,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			collector := testcollector.New()
+			var e filesystem.Extractor = m2repo.New(m2repo.Config{
+				Stats:            collector,
+				MaxFileSizeBytes: tt.maxFileSizeBytes,
+			})
+
+			fileSizeBytes := tt.fileSizeBytes
+			if fileSizeBytes == 0 {
+				fileSizeBytes = 1000
+			}
+			isRequired := e.FileRequired(tt.path, fakefs.FakeFileInfo{
+				FileName: filepath.Base(tt.path),
+				FileMode: fs.ModePerm,
+				FileSize: fileSizeBytes,
+			})
+			if isRequired != tt.wantRequired {
+				t.Fatalf("FileRequired(%s): got %v, want %v", tt.path, isRequired, tt.wantRequired)
+			}
+
+			gotResultMetric := collector.FileRequiredResult(tt.path)
+			if tt.wantResultMetric != "" && gotResultMetric != tt.wantResultMetric {
+				t.Errorf("FileRequired(%s) recorded result metric %v, want result metric %v", tt.path, gotResultMetric, tt.wantResultMetric)
+			}
+		})
+	}
+}
+
+func TestExtract(t *testing.T) {
+	tests := []struct {
+		name             string
+		path             string
+		wantInventory    []*extractor.Inventory
+		wantErr          error
+		wantResultMetric stats.FileExtractedResult
+	}{
+		{
+			name: "groupId from parent",
+			path: "repository/com/google/guava/guava/33.0.0-jre/guava-33.0.0-jre.pom",
+			wantInventory: []*extractor.Inventory{
+				{
+					Name:    "guava",
+					Version: "33.0.0-jre",
+					Metadata: &m2repo.Metadata{
+						GroupID:     "com.google.guava",
+						ArtifactID:  "guava",
+						Packaging:   "bundle",
+						Classifiers: []string{"sources"},
+					},
+					Locations: []string{"repository/com/google/guava/guava/33.0.0-jre/guava-33.0.0-jre.pom"},
+				},
+			},
+			wantResultMetric: stats.FileExtractedResultSuccess,
+		}, {
+			name: "parent POM",
+			path: "repository/com/google/guava/guava-parent/33.0.0-jre/guava-parent-33.0.0-jre.pom",
+			wantInventory: []*extractor.Inventory{
+				{
+					Name:    "guava-parent",
+					Version: "33.0.0-jre",
+					Metadata: &m2repo.Metadata{
+						GroupID:    "com.google.guava",
+						ArtifactID: "guava-parent",
+						Packaging:  "pom",
+					},
+					Locations: []string{"repository/com/google/guava/guava-parent/33.0.0-jre/guava-parent-33.0.0-jre.pom"},
+				},
+			},
+			wantResultMetric: stats.FileExtractedResultSuccess,
+		}, {
+			name: "timestamped snapshot with classifiers in deep group",
+			path: "repository/org/example/platform/internal/tools/codegen/codegen-core/2.1-SNAPSHOT/codegen-core-2.1-20240315.101530-3.pom",
+			wantInventory: []*extractor.Inventory{
+				{
+					Name:    "codegen-core",
+					Version: "2.1-SNAPSHOT",
+					Metadata: &m2repo.Metadata{
+						GroupID:         "org.example.platform.internal.tools.codegen",
+						ArtifactID:      "codegen-core",
+						SnapshotVersion: "2.1-20240315.101530-3",
+						Classifiers:     []string{"linux-x86_64", "tests"},
+					},
+					Locations: []string{"repository/org/example/platform/internal/tools/codegen/codegen-core/2.1-SNAPSHOT/codegen-core-2.1-20240315.101530-3.pom"},
+				},
+			},
+			wantResultMetric: stats.FileExtractedResultSuccess,
+		}, {
+			name: "locally installed snapshot",
+			path: "repository/org/example/app/1.0-SNAPSHOT/app-1.0-SNAPSHOT.pom",
+			wantInventory: []*extractor.Inventory{
+				{
+					Name:    "app",
+					Version: "1.0-SNAPSHOT",
+					Metadata: &m2repo.Metadata{
+						GroupID:    "org.example",
+						ArtifactID: "app",
+					},
+					Locations: []string{"repository/org/example/app/1.0-SNAPSHOT/app-1.0-SNAPSHOT.pom"},
+				},
+			},
+			wantResultMetric: stats.FileExtractedResultSuccess,
+		}, {
+			name: "version property uses path",
+			path: "repository/org/example/property-version/1.4.0/property-version-1.4.0.pom",
+			wantInventory: []*extractor.Inventory{
+				{
+					Name:    "property-version",
+					Version: "1.4.0",
+					Metadata: &m2repo.Metadata{
+						GroupID:    "org.example",
+						ArtifactID: "property-version",
+					},
+					Locations: []string{"repository/org/example/property-version/1.4.0/property-version-1.4.0.pom"},
+				},
+			},
+			wantResultMetric: stats.FileExtractedResultSuccess,
+		}, {
+			name: "groupId from path after repository dir",
+			path: "repository/org/example/no-group/1.0/no-group-1.0.pom",
+			wantInventory: []*extractor.Inventory{
+				{
+					Name:    "no-group",
+					Version: "1.0",
+					Metadata: &m2repo.Metadata{
+						GroupID:    "org.example",
+						ArtifactID: "no-group",
+					},
+					Locations: []string{"repository/org/example/no-group/1.0/no-group-1.0.pom"},
+				},
+			},
+			wantResultMetric: stats.FileExtractedResultSuccess,
+		}, {
+			name:             "groupId doesn't match path",
+			path:             "repository/org/example/relocated/3.0/relocated-3.0.pom",
+			wantInventory:    nil,
+			wantResultMetric: stats.FileExtractedResultSuccess,
+		}, {
+			name:             "invalid POM",
+			path:             "repository/org/example/broken/1.0/broken-1.0.pom",
+			wantErr:          extractor.ErrMalformedInput,
+			wantResultMetric: stats.FileExtractedResultErrorMalformedInput,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			collector := testcollector.New()
+			var e filesystem.Extractor = m2repo.New(m2repo.Config{
+				Stats:            collector,
+				MaxFileSizeBytes: 1 * units.MiB,
+			})
+
+			d := "testdata"
+			r, err := os.Open(filepath.Join(d, tt.path))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer r.Close()
+			info, err := r.Stat()
+			if err != nil {
+				t.Fatalf("Stat(): %v", err)
+			}
+
+			input := &filesystem.ScanInput{
+				FS:     scalibrfs.DirFS(d),
+				Path:   tt.path,
+				Reader: r,
+				Root:   d,
+				Info:   info,
+			}
+			got, err := e.Extract(context.Background(), input)
+			if !cmp.Equal(err, tt.wantErr, cmpopts.EquateErrors()) {
+				t.Fatalf("Extract(%+v) error: got %v, want %v\n", tt.path, err, tt.wantErr)
+			}
+
+			if diff := cmp.Diff(tt.wantInventory, got); diff != "" {
+				t.Errorf("Extract(%s) (-want +got):\n%s", tt.path, diff)
+			}
+
+			gotResultMetric := collector.FileExtractedResult(tt.path)
+			if gotResultMetric != tt.wantResultMetric {
+				t.Errorf("Extract(%s) recorded result metric %v, want result metric %v", tt.path, gotResultMetric, tt.wantResultMetric)
+			}
+		})
+	}
+}
+
+func TestToPURL(t *testing.T) {
+	e := m2repo.New(m2repo.DefaultConfig())
+	i := &extractor.Inventory{
+		Name:    "codegen-core",
+		Version: "2.1-SNAPSHOT",
+		Metadata: &m2repo.Metadata{
+			GroupID:         "org.Example.codegen",
+			ArtifactID:      "codegen-core",
+			SnapshotVersion: "2.1-20240315.101530-3",
+		},
+		Locations: []string{"location"},
+	}
+	want := &purl.PackageURL{
+		Type:      purl.TypeMaven,
+		Namespace: "org.example.codegen",
+		Name:      "codegen-core",
+		Version:   "2.1-SNAPSHOT",
+	}
+	got, err := e.ToPURL(i)
+	if err != nil {
+		t.Fatalf("ToPURL(%v): %v", i, err)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ToPURL(%v) (-want +got):\n%s", i, diff)
+	}
+}
@@ -0,0 +1,29 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package m2repo
+
+// Metadata holds parsing information for an artifact in a local Maven repository.
+type Metadata struct {
+	GroupID    string
+	ArtifactID string
+	// Packaging from the POM, e.g. "jar" or "pom".
+	Packaging string
+	// The timestamped version of the installed snapshot, e.g.
+	// "1.0-20240315.101530-3" for version "1.0-SNAPSHOT". Empty for releases and
+	// for snapshots that were installed locally.
+	SnapshotVersion string
+	// Classifiers of the artifact files next to the POM, e.g. "sources".
+	Classifiers []string
+}
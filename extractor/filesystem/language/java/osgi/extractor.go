@@ -0,0 +1,327 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package osgi extracts OSGi bundles from the Bundle-SymbolicName and
+// Bundle-Version headers of their manifests, e.g. the plugins of Eclipse
+// installations and the bundles deployed to Apache Karaf.
+package osgi
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"path/filepath"
+	"strings"
+
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem"
+	"github.com/google/osv-scalibr/extractor/filesystem/internal/units"
+	"github.com/google/osv-scalibr/plugin"
+	"github.com/google/osv-scalibr/purl"
+	"github.com/google/osv-scalibr/stats"
+)
+
+const (
+	// Name is the unique name of this extractor.
+	Name = "java/osgi"
+
+	// defaultMaxFileSizeBytes is the maximum file size an extractor will unmarshal.
+	// If Extract gets a bigger file, it will return an error.
+	defaultMaxFileSizeBytes = 100 * units.MiB
+
+	// maxManifestSizeBytes caps the size of the decompressed manifest of a jar.
+	maxManifestSizeBytes = 10 * units.MiB
+
+	manifestPath = "META-INF/MANIFEST.MF"
+
+	// defaultVersion is the version of bundles without a Bundle-Version header,
+	// see the OSGi core specification, section 3.2.1.
+	defaultVersion = "0.0.0"
+)
+
+// Config is the configuration for the Extractor.
+type Config struct {
+	// Stats is a stats collector for reporting metrics.
+	Stats stats.Collector
+	// MaxFileSizeBytes is the maximum file size this extractor will unmarshal. If
+	// `FileRequired` gets a bigger file, it will return false.
+	MaxFileSizeBytes int64
+}
+
+// DefaultConfig returns the default configuration for the extractor.
+func DefaultConfig() Config {
+	return Config{
+		Stats:            nil,
+		MaxFileSizeBytes: defaultMaxFileSizeBytes,
+	}
+}
+
+// Extractor extracts OSGi bundles from loose manifests and .jar files.
+type Extractor struct {
+	stats            stats.Collector
+	maxFileSizeBytes int64
+}
+
+// New returns an OSGi bundle extractor.
+//
+// For most use cases, initialize with:
+// ```
+// e := New(DefaultConfig())
+// ```
+func New(cfg Config) *Extractor {
+	return &Extractor{
+		stats:            cfg.Stats,
+		maxFileSizeBytes: cfg.MaxFileSizeBytes,
+	}
+}
+
+// Config returns the configuration of the extractor.
+func (e Extractor) Config() Config {
+	return Config{
+		Stats:            e.stats,
+		MaxFileSizeBytes: e.maxFileSizeBytes,
+	}
+}
+
+// Name of the extractor.
+func (e Extractor) Name() string { return Name }
+
+// Version of the extractor.
+func (e Extractor) Version() int { return 0 }
+
+// Requirements of the extractor.
+func (e Extractor) Requirements() *plugin.Capabilities { return &plugin.Capabilities{} }
+
+// FileRequired returns true if the specified file is a manifest, i.e.
+// META-INF/MANIFEST.MF, or a .jar file.
+func (e Extractor) FileRequired(path string, fileinfo fs.FileInfo) bool {
+	if !isManifest(path) && !isJar(path) {
+		return false
+	}
+
+	if e.maxFileSizeBytes > 0 && fileinfo.Size() > e.maxFileSizeBytes {
+		e.reportFileRequired(path, fileinfo.Size(), stats.FileRequiredResultSizeLimitExceeded)
+		return false
+	}
+
+	e.reportFileRequired(path, fileinfo.Size(), stats.FileRequiredResultOK)
+	return true
+}
+
+func isManifest(path string) bool {
+	return strings.HasSuffix(filepath.ToSlash(path), manifestPath)
+}
+
+func isJar(path string) bool {
+	return strings.ToLower(filepath.Ext(path)) == ".jar"
+}
+
+func (e Extractor) reportFileRequired(path string, fileSizeBytes int64, result stats.FileRequiredResult) {
+	if e.stats == nil {
+		return
+	}
+	e.stats.AfterFileRequired(e.Name(), &stats.FileRequiredStats{
+		Path:          path,
+		Result:        result,
+		FileSizeBytes: fileSizeBytes,
+	})
+}
+
+// Extract returns the OSGi bundle described by the manifest or by the manifest
+// inside of the jar passed through the scan input. Manifests without a
+// Bundle-SymbolicName header don't belong to OSGi bundles and are skipped.
+func (e Extractor) Extract(ctx context.Context, input *filesystem.ScanInput) ([]*extractor.Inventory, error) {
+	inventory, err := e.extractFromInput(input)
+	if e.stats != nil {
+		var fileSizeBytes int64
+		if input.Info != nil {
+			fileSizeBytes = input.Info.Size()
+		}
+		e.stats.AfterFileExtracted(e.Name(), &stats.FileExtractedStats{
+			Path:          input.Path,
+			Result:        filesystem.ExtractorErrorToFileExtractedResult(err),
+			FileSizeBytes: fileSizeBytes,
+		})
+	}
+	return inventory, err
+}
+
+func (e Extractor) extractFromInput(input *filesystem.ScanInput) ([]*extractor.Inventory, error) {
+	r := input.Reader
+	if !isManifest(input.Path) {
+		zr, err := e.openZip(input)
+		if err != nil {
+			return nil, err
+		}
+		b, err := readManifest(zr)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %q: %w", e.Name(), input.Path, err)
+		}
+		if b == nil {
+			return nil, nil
+		}
+		r = bytes.NewReader(b)
+	}
+
+	attrs, err := parseMainSection(r)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to parse the manifest of %q: %w", e.Name(), input.Path, err)
+	}
+	name := symbolicName(attrs["Bundle-SymbolicName"])
+	if name == "" {
+		return nil, nil
+	}
+	version := strings.TrimSpace(attrs["Bundle-Version"])
+	if version == "" {
+		version = defaultVersion
+	}
+	return []*extractor.Inventory{{
+		Name:    name,
+		Version: version,
+		Metadata: &Metadata{
+			BundleName:   attrs["Bundle-Name"],
+			BundleVendor: attrs["Bundle-Vendor"],
+			Qualifier:    qualifier(version),
+			FragmentHost: symbolicName(attrs["Fragment-Host"]),
+		},
+		Locations: []string{input.Path},
+	}}, nil
+}
+
+// openZip opens the jar without reading all of it into memory if the file
+// supports random access.
+func (e Extractor) openZip(input *filesystem.ScanInput) (*zip.Reader, error) {
+	var size int64
+	if input.Info != nil {
+		size = input.Info.Size()
+	}
+	var r io.ReaderAt
+	if sr := input.SectionReader(0, size); sr != nil && size > 0 {
+		r = sr
+	} else {
+		b, err := io.ReadAll(input.Reader)
+		if err != nil {
+			return nil, fmt.Errorf("%s: failed to read %q: %w", e.Name(), input.Path, err)
+		}
+		r = bytes.NewReader(b)
+		size = int64(len(b))
+	}
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, fmt.Errorf("%s: invalid archive at %q: %w: %w", e.Name(), input.Path, extractor.ErrMalformedInput, err)
+	}
+	return zr, nil
+}
+
+// readManifest returns the content of the jar's manifest, or nil if it has
+// none.
+func readManifest(zr *zip.Reader) ([]byte, error) {
+	for _, f := range zr.File {
+		if f.Name != manifestPath {
+			continue
+		}
+		if f.UncompressedSize64 > uint64(maxManifestSizeBytes) {
+			return nil, fmt.Errorf("%s too large: %d bytes", manifestPath, f.UncompressedSize64)
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return nil, fmt.Errorf("failed to open %s: %w: %w", manifestPath, extractor.ErrMalformedInput, err)
+		}
+		defer rc.Close()
+		b, err := io.ReadAll(io.LimitReader(rc, maxManifestSizeBytes))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w: %w", manifestPath, extractor.ErrMalformedInput, err)
+		}
+		return b, nil
+	}
+	return nil, nil
+}
+
+// parseMainSection returns the attributes of the main section of a manifest,
+// i.e. the ones before the first empty line. Lines longer than 72 bytes are
+// folded by continuing them on the next line after a single space, e.g.
+//
+//	Bundle-SymbolicName: org.eclipse.equinox.p2.metadata.repository;singl
+//	 eton:=true
+//
+// Unlike in MIME headers, the space isn't part of the value.
+func parseMainSection(r io.Reader) (map[string]string, error) {
+	attrs := map[string]string{}
+	var key string
+	s := bufio.NewScanner(r)
+	for s.Scan() {
+		line := strings.TrimSuffix(s.Text(), "\r")
+		if line == "" {
+			break
+		}
+		if strings.HasPrefix(line, " ") {
+			if key == "" {
+				return nil, fmt.Errorf("continuation line without an attribute: %w", extractor.ErrMalformedInput)
+			}
+			attrs[key] += line[1:]
+			continue
+		}
+		k, v, ok := strings.Cut(line, ":")
+		if !ok {
+			return nil, fmt.Errorf("invalid manifest line %q: %w", line, extractor.ErrMalformedInput)
+		}
+		key = k
+		attrs[key] = strings.TrimPrefix(v, " ")
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+	return attrs, nil
+}
+
+// symbolicName returns the symbolic name without its directives and
+// attributes, e.g. "org.eclipse.core.runtime" for
+// "org.eclipse.core.runtime; singleton:=true".
+func symbolicName(header string) string {
+	name, _, _ := strings.Cut(header, ";")
+	return strings.TrimSpace(name)
+}
+
+// qualifier returns the qualifier of an OSGi version, i.e. the part after the
+// major, minor and micro version, e.g. "v20230726-0617" for
+// "3.29.0.v20230726-0617". It's often a build timestamp.
+func qualifier(version string) string {
+	parts := strings.SplitN(version, ".", 4)
+	if len(parts) < 4 {
+		return ""
+	}
+	return parts[3]
+}
+
+// ToPURL converts an inventory created by this extractor into a PURL. Bundle
+// symbolic names don't necessarily correspond to Maven coordinates so a
+// generic PURL is used.
+func (e Extractor) ToPURL(i *extractor.Inventory) (*purl.PackageURL, error) {
+	return &purl.PackageURL{
+		Type:      purl.TypeGeneric,
+		Namespace: "osgi",
+		Name:      i.Name,
+		Version:   i.Version,
+	}, nil
+}
+
+// ToCPEs is not applicable as this extractor does not infer CPEs from the Inventory.
+func (e Extractor) ToCPEs(i *extractor.Inventory) ([]string, error) { return []string{}, nil }
+
+// Ecosystem returns no ecosystem since OSV does not support OSGi bundles.
+func (Extractor) Ecosystem(i *extractor.Inventory) (string, error) { return "", nil }
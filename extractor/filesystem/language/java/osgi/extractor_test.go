@@ -0,0 +1,256 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package osgi_test
+
+import (
+	"context"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem"
+	"github.com/google/osv-scalibr/extractor/filesystem/internal/units"
+	"github.com/google/osv-scalibr/extractor/filesystem/language/java/osgi"
+	scalibrfs "github.com/google/osv-scalibr/fs"
+	"github.com/google/osv-scalibr/purl"
+	"github.com/google/osv-scalibr/stats"
+	"github.com/google/osv-scalibr/testing/fakefs"
+	"github.com/google/osv-scalibr/testing/testcollector"
+)
+
+func TestFileRequired(t *testing.T) {
+	tests := []struct {
+		name             string
+		path             string
+		fileSizeBytes    int64
+		maxFileSizeBytes int64
+		wantRequired     bool
+		wantResultMetric stats.FileRequiredResult
+	}{
+		{
+			name:             "loose manifest",
+			path:             "opt/eclipse/plugins/org.eclipse.core.runtime_3.29.0/META-INF/MANIFEST.MF",
+			wantRequired:     true,
+			wantResultMetric: stats.FileRequiredResultOK,
+		},
+		{
+			name:             "jar",
+			path:             "opt/karaf/system/org/apache/karaf/shell/core/4.4.5/org.apache.karaf.shell.core-4.4.5.jar",
+			wantRequired:     true,
+			wantResultMetric: stats.FileRequiredResultOK,
+		},
+		{
+			name:             "upper case jar extension",
+			path:             "plugins/BUNDLE.JAR",
+			wantRequired:     true,
+			wantResultMetric: stats.FileRequiredResultOK,
+		},
+		{
+			name:         "manifest outside of META-INF",
+			path:         "src/MANIFEST.MF",
+			wantRequired: false,
+		},
+		{
+			name:         "other file in META-INF",
+			path:         "plugins/foo/META-INF/p2.inf",
+			wantRequired: false,
+		},
+		{
+			name:         "war",
+			path:         "webapps/app.war",
+			wantRequired: false,
+		},
+		{
+			name:             "jar required if file size < max file size",
+			path:             "plugins/bundle.jar",
+			fileSizeBytes:    100 * units.KiB,
+			maxFileSizeBytes: 1000 * units.KiB,
+			wantRequired:     true,
+			wantResultMetric: stats.FileRequiredResultOK,
+		},
+		{
+			name:             "jar not required if file size > max file size",
+			path:             "plugins/bundle.jar",
+			fileSizeBytes:    1000 * units.KiB,
+			maxFileSizeBytes: 100 * units.KiB,
+			wantRequired:     false,
+			wantResultMetric: stats.FileRequiredResultSizeLimitExceeded,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			collector := testcollector.New()
+			var e filesystem.Extractor = osgi.New(osgi.Config{
+				Stats:            collector,
+				MaxFileSizeBytes: test.maxFileSizeBytes,
+			})
+
+			// Set default size if not provided.
+			fileSizeBytes := test.fileSizeBytes
+			if fileSizeBytes == 0 {
+				fileSizeBytes = 100 * units.KiB
+			}
+
+			isRequired := e.FileRequired(test.path, fakefs.FakeFileInfo{
+				FileName: filepath.Base(test.path),
+				FileMode: fs.ModePerm,
+				FileSize: fileSizeBytes,
+			})
+			if isRequired != test.wantRequired {
+				t.Fatalf("FileRequired(%s): got %v, want %v", test.path, isRequired, test.wantRequired)
+			}
+
+			gotResultMetric := collector.FileRequiredResult(test.path)
+			if gotResultMetric != test.wantResultMetric {
+				t.Errorf("FileRequired(%s) recorded result metric %v, want result metric %v", test.path, gotResultMetric, test.wantResultMetric)
+			}
+		})
+	}
+}
+
+func TestExtract(t *testing.T) {
+	tests := []struct {
+		name             string
+		path             string
+		wantInventory    []*extractor.Inventory
+		wantErr          error
+		wantResultMetric stats.FileExtractedResult
+	}{
+		{
+			name: "loose manifest with folded lines",
+			path: "testdata/loose/META-INF/MANIFEST.MF",
+			wantInventory: []*extractor.Inventory{{
+				Name:    "org.eclipse.equinox.p2.metadata.repository",
+				Version: "1.5.100.v20230517-1510",
+				Metadata: &osgi.Metadata{
+					BundleName:   "%pluginName",
+					BundleVendor: "%providerName",
+					Qualifier:    "v20230517-1510",
+				},
+				Locations: []string{"testdata/loose/META-INF/MANIFEST.MF"},
+			}},
+			wantResultMetric: stats.FileExtractedResultSuccess,
+		},
+		{
+			name: "bundle jar",
+			path: "testdata/bundle.jar",
+			wantInventory: []*extractor.Inventory{{
+				Name:    "org.apache.karaf.shell.core",
+				Version: "4.4.5",
+				Metadata: &osgi.Metadata{
+					BundleName:   "Apache Karaf :: Shell :: Core",
+					BundleVendor: "The Apache Software Foundation",
+				},
+				Locations: []string{"testdata/bundle.jar"},
+			}},
+			wantResultMetric: stats.FileExtractedResultSuccess,
+		},
+		{
+			name: "fragment without version",
+			path: "testdata/fragment.jar",
+			wantInventory: []*extractor.Inventory{{
+				Name:    "org.eclipse.swt.gtk.linux.x86_64",
+				Version: "0.0.0",
+				Metadata: &osgi.Metadata{
+					FragmentHost: "org.eclipse.swt",
+				},
+				Locations: []string{"testdata/fragment.jar"},
+			}},
+			wantResultMetric: stats.FileExtractedResultSuccess,
+		},
+		{
+			name:             "jar that is not a bundle",
+			path:             "testdata/plain.jar",
+			wantResultMetric: stats.FileExtractedResultSuccess,
+		},
+		{
+			name:             "jar without manifest",
+			path:             "testdata/nomanifest.jar",
+			wantResultMetric: stats.FileExtractedResultSuccess,
+		},
+		{
+			name:             "invalid jar",
+			path:             "testdata/notzip.jar",
+			wantErr:          extractor.ErrMalformedInput,
+			wantResultMetric: stats.FileExtractedResultErrorMalformedInput,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			collector := testcollector.New()
+			var e filesystem.Extractor = osgi.New(osgi.Config{Stats: collector})
+
+			r, err := os.Open(test.path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer r.Close()
+
+			info, err := os.Stat(test.path)
+			if err != nil {
+				t.Fatalf("Failed to stat test file: %v", err)
+			}
+
+			input := &filesystem.ScanInput{
+				FS:     scalibrfs.DirFS("."),
+				Path:   test.path,
+				Reader: r,
+				Info:   info,
+			}
+			got, err := e.Extract(context.Background(), input)
+			if !cmp.Equal(err, test.wantErr, cmpopts.EquateErrors()) {
+				t.Fatalf("Extract(%s) error: got %v, want %v", test.path, err, test.wantErr)
+			}
+
+			if diff := cmp.Diff(test.wantInventory, got, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("Extract(%s) (-want +got):\n%s", test.path, diff)
+			}
+
+			gotResultMetric := collector.FileExtractedResult(test.path)
+			if gotResultMetric != test.wantResultMetric {
+				t.Errorf("Extract(%s) recorded result metric %v, want result metric %v", test.path, gotResultMetric, test.wantResultMetric)
+			}
+		})
+	}
+}
+
+func TestToPURL(t *testing.T) {
+	e := osgi.Extractor{}
+	inv := &extractor.Inventory{
+		Name:      "org.apache.karaf.shell.core",
+		Version:   "4.4.5",
+		Metadata:  &osgi.Metadata{},
+		Locations: []string{"location"},
+	}
+	want := &purl.PackageURL{
+		Type:      purl.TypeGeneric,
+		Namespace: "osgi",
+		Name:      "org.apache.karaf.shell.core",
+		Version:   "4.4.5",
+	}
+	got, err := e.ToPURL(inv)
+	if err != nil {
+		t.Fatalf("ToPURL(%v): %v", inv, err)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ToPURL(%v) (-want +got):\n%s", inv, diff)
+	}
+}
@@ -0,0 +1,28 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package osgi
+
+// Metadata holds additional information from the manifest of an OSGi bundle.
+type Metadata struct {
+	// The human-readable name of the bundle, from the Bundle-Name header.
+	BundleName string
+	// The vendor of the bundle, from the Bundle-Vendor header.
+	BundleVendor string
+	// The qualifier of the bundle version, e.g. "v20230726-0617" for
+	// "3.29.0.v20230726-0617". Empty if the version has none.
+	Qualifier string
+	// The symbolic name of the host bundle if the bundle is a fragment.
+	FragmentHost string
+}
@@ -103,6 +103,9 @@ func (e Extractor) Version() int { return 0 }
 // Requirements of the extractor.
 func (e Extractor) Requirements() *plugin.Capabilities { return &plugin.Capabilities{} }
 
+// RequiredFileNames returns the name of the package manifests the extractor requires.
+func (e Extractor) RequiredFileNames() []string { return []string{"package.json"} }
+
 // FileRequired returns true if the specified file matches javascript Metadata file
 // patterns.
 func (e Extractor) FileRequired(path string, fileinfo fs.FileInfo) bool {
@@ -16,8 +16,10 @@
 package packagelockjson
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"io"
 	"io/fs"
 	"path/filepath"
 	"slices"
@@ -27,6 +29,7 @@ import (
 	"github.com/google/osv-scalibr/extractor"
 	"github.com/google/osv-scalibr/extractor/filesystem"
 	"github.com/google/osv-scalibr/extractor/filesystem/osv"
+	"github.com/google/osv-scalibr/log"
 	"github.com/google/osv-scalibr/plugin"
 	"github.com/google/osv-scalibr/purl"
 	"github.com/google/osv-scalibr/stats"
@@ -77,6 +80,9 @@ func (e Extractor) Version() int { return 0 }
 // Requirements of the extractor.
 func (e Extractor) Requirements() *plugin.Capabilities { return &plugin.Capabilities{} }
 
+// RequiredFileNames returns the name of the lockfiles the extractor requires.
+func (e Extractor) RequiredFileNames() []string { return []string{"package-lock.json"} }
+
 // FileRequired returns true if the specified file matches javascript Metadata file
 // patterns.
 func (e Extractor) FileRequired(path string, fileinfo fs.FileInfo) bool {
@@ -130,19 +136,43 @@ func (e Extractor) Extract(ctx context.Context, input *filesystem.ScanInput) ([]
 }
 
 func (e Extractor) extractFromInput(ctx context.Context, input *filesystem.ScanInput) ([]*extractor.Inventory, error) {
+	// The content is parsed twice: By the OSV extractor for the packages and
+	// by packagePositions for the lines of their entries.
+	content, err := io.ReadAll(input.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %q: %w", input.Path, err)
+	}
+	osvInput := *input
+	osvInput.Reader = bytes.NewReader(content)
 	osve := lockfile.NpmLockExtractor{}
-	osvpkgs, err := osve.Extract(osv.WrapInput(input))
+	osvpkgs, err := osve.Extract(osv.WrapInput(&osvInput))
 	if err != nil {
 		return nil, fmt.Errorf("NpmLockExtractor.Extract(): %w", err)
 	}
 
+	// The positions are only evidence, so the packages are still reported if
+	// they can't be determined.
+	positions, err := packagePositions(content)
+	if err != nil {
+		log.Debugf("%s: failed to determine the package lines of %q: %v", e.Name(), input.Path, err)
+	}
+
 	r := []*extractor.Inventory{}
 	for _, p := range osvpkgs {
-		r = append(r, &extractor.Inventory{
+		inv := &extractor.Inventory{
 			Name:      p.Name,
 			Version:   p.Version,
 			Locations: []string{input.Path},
-		})
+		}
+		if pos, ok := positions[positionKey(p.Name, p.Version)]; ok {
+			inv.LocationDetails = []*extractor.Location{{
+				Path:   input.Path,
+				Kind:   extractor.LocationKindManifest,
+				Line:   pos.line,
+				Offset: pos.offset,
+			}}
+		}
+		r = append(r, inv)
 	}
 
 	return r, nil
@@ -152,11 +152,63 @@ func TestExtract(t *testing.T) {
 					Name:      "wrappy",
 					Version:   "1.0.2",
 					Locations: []string{"testdata/package-lock.v1.json"},
+					LocationDetails: []*extractor.Location{{
+						Path:   "testdata/package-lock.v1.json",
+						Kind:   extractor.LocationKindManifest,
+						Line:   5,
+						Offset: 70,
+					}},
 				},
 				&extractor.Inventory{
 					Name:      "supports-color",
 					Version:   "5.5.0",
 					Locations: []string{"testdata/package-lock.v1.json"},
+					LocationDetails: []*extractor.Location{{
+						Path:   "testdata/package-lock.v1.json",
+						Kind:   extractor.LocationKindManifest,
+						Line:   10,
+						Offset: 248,
+					}},
+				},
+			},
+			wantResultMetric: stats.FileExtractedResultSuccess,
+		},
+		{
+			name: "package-lock.v1 with nested dependencies",
+			path: "testdata/package-lock.v1.nested.json",
+			wantInventory: []*extractor.Inventory{
+				&extractor.Inventory{
+					Name:      "chalk",
+					Version:   "2.4.2",
+					Locations: []string{"testdata/package-lock.v1.nested.json"},
+					LocationDetails: []*extractor.Location{{
+						Path:   "testdata/package-lock.v1.nested.json",
+						Kind:   extractor.LocationKindManifest,
+						Line:   5,
+						Offset: 70,
+					}},
+				},
+				&extractor.Inventory{
+					Name:      "supports-color",
+					Version:   "5.5.0",
+					Locations: []string{"testdata/package-lock.v1.nested.json"},
+					LocationDetails: []*extractor.Location{{
+						Path:   "testdata/package-lock.v1.nested.json",
+						Kind:   extractor.LocationKindManifest,
+						Line:   11,
+						Offset: 203,
+					}},
+				},
+				&extractor.Inventory{
+					Name:      "supports-color",
+					Version:   "7.2.0",
+					Locations: []string{"testdata/package-lock.v1.nested.json"},
+					LocationDetails: []*extractor.Location{{
+						Path:   "testdata/package-lock.v1.nested.json",
+						Kind:   extractor.LocationKindManifest,
+						Line:   19,
+						Offset: 351,
+					}},
 				},
 			},
 			wantResultMetric: stats.FileExtractedResultSuccess,
@@ -169,11 +221,23 @@ func TestExtract(t *testing.T) {
 					Name:      "wrappy",
 					Version:   "1.0.2",
 					Locations: []string{"testdata/package-lock.v2.json"},
+					LocationDetails: []*extractor.Location{{
+						Path:   "testdata/package-lock.v2.json",
+						Kind:   extractor.LocationKindManifest,
+						Line:   13,
+						Offset: 231,
+					}},
 				},
 				&extractor.Inventory{
 					Name:      "supports-color",
 					Version:   "5.5.0",
 					Locations: []string{"testdata/package-lock.v2.json"},
+					LocationDetails: []*extractor.Location{{
+						Path:   "testdata/package-lock.v2.json",
+						Kind:   extractor.LocationKindManifest,
+						Line:   18,
+						Offset: 422,
+					}},
 				},
 			},
 			wantResultMetric: stats.FileExtractedResultSuccess,
@@ -218,7 +282,12 @@ func TestExtract(t *testing.T) {
 				t.Fatalf("Extract(%+v) error: got %v, want %v\n", tt.name, err, tt.wantErr)
 			}
 
-			sort := func(a, b *extractor.Inventory) bool { return a.Name < b.Name }
+			sort := func(a, b *extractor.Inventory) bool {
+				if a.Name != b.Name {
+					return a.Name < b.Name
+				}
+				return a.Version < b.Version
+			}
 			if diff := cmp.Diff(tt.wantInventory, got, cmpopts.SortSlices(sort)); diff != "" {
 				t.Errorf("Extract(%s) (-want +got):\n%s", tt.path, diff)
 			}
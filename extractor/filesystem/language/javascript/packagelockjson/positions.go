@@ -0,0 +1,165 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package packagelockjson
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// position is the position of a package entry in a package-lock.json.
+type position struct {
+	// The 1-based line of the entry's key.
+	line int
+	// The byte offset of the entry's key.
+	offset int64
+}
+
+// packagePositions returns the positions of the package entries in the
+// package-lock.json content, keyed by name and version. Lockfiles since
+// version 2 list the packages by their path in the "packages" object, older
+// ones nest them in "dependencies" objects. If a package is listed several
+// times, the first entry is used.
+func packagePositions(content []byte) (map[string]position, error) {
+	idx := &positionIndex{
+		content:   content,
+		positions: make(map[string]position),
+	}
+	for i, b := range content {
+		if b == '\n' {
+			idx.newlines = append(idx.newlines, int64(i))
+		}
+	}
+	err := walkObject(content, 0, func(key string, _ int64, value []byte, valueOffset int64) error {
+		switch key {
+		case "packages":
+			return walkObject(value, valueOffset, idx.addPackage)
+		case "dependencies":
+			return walkObject(value, valueOffset, idx.addDependency)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return idx.positions, nil
+}
+
+type positionIndex struct {
+	content []byte
+	// The offsets of the newlines in content.
+	newlines  []int64
+	positions map[string]position
+}
+
+func positionKey(name, version string) string { return name + "@" + version }
+
+func (idx *positionIndex) add(name, version string, offset int64) {
+	key := positionKey(name, version)
+	if _, ok := idx.positions[key]; ok {
+		return
+	}
+	line, _ := slices.BinarySearch(idx.newlines, offset)
+	idx.positions[key] = position{line: line + 1, offset: offset}
+}
+
+// addPackage adds an entry of the "packages" object, e.g.
+// "node_modules/a/node_modules/b": {"version": "1.0.0"}. The root project is
+// listed with an empty path and skipped.
+func (idx *positionIndex) addPackage(path string, offset int64, value []byte, _ int64) error {
+	if path == "" {
+		return nil
+	}
+	var entry struct {
+		Name    string `json:"name"`
+		Version string `json:"version"`
+	}
+	if err := json.Unmarshal(value, &entry); err != nil {
+		return err
+	}
+	name := entry.Name
+	if name == "" {
+		name = path
+		if i := strings.LastIndex(path, "node_modules/"); i >= 0 {
+			name = path[i+len("node_modules/"):]
+		}
+	}
+	idx.add(name, entry.Version, offset)
+	return nil
+}
+
+// addDependency adds an entry of a "dependencies" object, e.g.
+// "a": {"version": "1.0.0", "dependencies": {...}}, and the entries nested in
+// it.
+func (idx *positionIndex) addDependency(name string, offset int64, value []byte, valueOffset int64) error {
+	var entry struct {
+		Version string `json:"version"`
+	}
+	if err := json.Unmarshal(value, &entry); err != nil {
+		return err
+	}
+	idx.add(name, entry.Version, offset)
+	return walkObject(value, valueOffset, func(key string, _ int64, value []byte, valueOffset int64) error {
+		if key != "dependencies" {
+			return nil
+		}
+		return walkObject(value, valueOffset, idx.addDependency)
+	})
+}
+
+// walkObject calls fn for each member of the JSON object in data with the
+// offsets of the member's key and value. base is the offset of data in the
+// whole file.
+func walkObject(data []byte, base int64, fn func(key string, keyOffset int64, value []byte, valueOffset int64) error) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	if tok, err := dec.Token(); err != nil {
+		return err
+	} else if tok != json.Delim('{') {
+		return fmt.Errorf("expected an object, got %v", tok)
+	}
+	for dec.More() {
+		keyOffset := skipSeparators(data, dec.InputOffset())
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		key, ok := tok.(string)
+		if !ok {
+			return fmt.Errorf("expected an object key, got %v", tok)
+		}
+		valueOffset := skipSeparators(data, dec.InputOffset())
+		var value json.RawMessage
+		if err := dec.Decode(&value); err != nil {
+			return err
+		}
+		if err := fn(key, base+keyOffset, value, base+valueOffset); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// skipSeparators returns the offset of the next token in data at or after
+// offset, skipping whitespace, commas and colons. The decoder's offset is
+// right after the previous token.
+func skipSeparators(data []byte, offset int64) int64 {
+	for offset < int64(len(data)) && strings.IndexByte(" \t\r\n,:", data[offset]) >= 0 {
+		offset++
+	}
+	return offset
+}
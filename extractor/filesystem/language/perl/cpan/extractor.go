@@ -0,0 +1,259 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package cpan extracts the Perl modules recorded in perllocal.pod files by
+// CPAN installers.
+package cpan
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem"
+	"github.com/google/osv-scalibr/plugin"
+	"github.com/google/osv-scalibr/purl"
+	"github.com/google/osv-scalibr/stats"
+)
+
+const (
+	// Name is the unique name of this extractor.
+	Name = "perl/cpan"
+
+	perllocalFileName = "perllocal.pod"
+)
+
+// ExtUtils::MakeMaker and Module::Build append an entry to perllocal.pod for
+// each installation, e.g.
+//
+//	=head2 Mon Jan  8 10:21:03 2024: C<Module> L<Try::Tiny|Try::Tiny>
+//
+//	=over 4
+//
+//	=item *
+//
+//	C<installed into: /usr/local/share/perl/5.36.0>
+//
+//	=item *
+//
+//	C<VERSION: 0.31>
+//
+//	=back
+var (
+	// The installation date and the module name. Old installers write the
+	// link without the "|" separated text.
+	reHeader = regexp.MustCompile(`^=head2\s+(.*?):\s+C<Module>\s+L<([^|>]+)(?:\|[^>]*)?>`)
+	// A "key: value" item of the entry.
+	reItem = regexp.MustCompile(`^C<([^:>]+):\s*(.*)>$`)
+)
+
+// Config is the configuration for the Extractor.
+type Config struct {
+	// Stats is a stats collector for reporting metrics.
+	Stats stats.Collector
+	// MaxFileSizeBytes is the maximum file size this extractor will unmarshal. If
+	// `FileRequired` gets a bigger file, it will return false.
+	MaxFileSizeBytes int64
+}
+
+// DefaultConfig returns the default configuration for the extractor.
+func DefaultConfig() Config {
+	return Config{
+		Stats:            nil,
+		MaxFileSizeBytes: 0,
+	}
+}
+
+// Extractor extracts CPAN modules from perllocal.pod files.
+type Extractor struct {
+	stats            stats.Collector
+	maxFileSizeBytes int64
+}
+
+// New returns a CPAN extractor.
+//
+// For most use cases, initialize with:
+// ```
+// e := New(DefaultConfig())
+// ```
+func New(cfg Config) *Extractor {
+	return &Extractor{
+		stats:            cfg.Stats,
+		maxFileSizeBytes: cfg.MaxFileSizeBytes,
+	}
+}
+
+// Config returns the configuration of the extractor.
+func (e Extractor) Config() Config {
+	return Config{
+		Stats:            e.stats,
+		MaxFileSizeBytes: e.maxFileSizeBytes,
+	}
+}
+
+// Name of the extractor.
+func (e Extractor) Name() string { return Name }
+
+// Version of the extractor.
+func (e Extractor) Version() int { return 0 }
+
+// Requirements of the extractor.
+func (e Extractor) Requirements() *plugin.Capabilities { return &plugin.Capabilities{} }
+
+// RequiredFileNames returns the name of the files the extractor requires.
+func (e Extractor) RequiredFileNames() []string { return []string{perllocalFileName} }
+
+// FileRequired returns true if the specified file is a perllocal.pod.
+func (e Extractor) FileRequired(path string, fileinfo fs.FileInfo) bool {
+	if filepath.Base(path) != perllocalFileName {
+		return false
+	}
+
+	if e.maxFileSizeBytes > 0 && fileinfo.Size() > e.maxFileSizeBytes {
+		e.reportFileRequired(path, fileinfo.Size(), stats.FileRequiredResultSizeLimitExceeded)
+		return false
+	}
+
+	e.reportFileRequired(path, fileinfo.Size(), stats.FileRequiredResultOK)
+	return true
+}
+
+func (e Extractor) reportFileRequired(path string, fileSizeBytes int64, result stats.FileRequiredResult) {
+	if e.stats == nil {
+		return
+	}
+	e.stats.AfterFileRequired(e.Name(), &stats.FileRequiredStats{
+		Path:          path,
+		Result:        result,
+		FileSizeBytes: fileSizeBytes,
+	})
+}
+
+// Extract extracts modules from the perllocal.pod passed through the scan input.
+func (e Extractor) Extract(ctx context.Context, input *filesystem.ScanInput) ([]*extractor.Inventory, error) {
+	inventory, err := e.extractFromInput(input)
+	if e.stats != nil {
+		var fileSizeBytes int64
+		if input.Info != nil {
+			fileSizeBytes = input.Info.Size()
+		}
+		e.stats.AfterFileExtracted(e.Name(), &stats.FileExtractedStats{
+			Path:          input.Path,
+			Result:        filesystem.ExtractorErrorToFileExtractedResult(err),
+			FileSizeBytes: fileSizeBytes,
+		})
+	}
+	return inventory, err
+}
+
+func (e Extractor) extractFromInput(input *filesystem.ScanInput) ([]*extractor.Inventory, error) {
+	modules, err := parse(input.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %q: %w", input.Path, err)
+	}
+	for _, m := range modules {
+		m.Locations = []string{input.Path}
+	}
+	return modules, nil
+}
+
+// parse returns the modules recorded in the perllocal.pod, sorted by name.
+// Entries are appended on each installation, so for modules installed more
+// than once the last entry describes the installed version. Entries without
+// a version are skipped.
+func parse(r io.Reader) ([]*extractor.Inventory, error) {
+	modules := map[string]*extractor.Inventory{}
+	var cur *extractor.Inventory
+	s := bufio.NewScanner(r)
+	for s.Scan() {
+		line := strings.TrimSpace(s.Text())
+		if strings.HasPrefix(line, "=head2") {
+			cur = nil
+			m := reHeader.FindStringSubmatch(line)
+			if m == nil {
+				continue
+			}
+			cur = &extractor.Inventory{
+				Name:     strings.TrimSpace(m[2]),
+				Metadata: &Metadata{InstallDate: m[1]},
+			}
+			continue
+		}
+		if cur == nil {
+			continue
+		}
+		m := reItem.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		value := strings.TrimSpace(m[2])
+		switch m[1] {
+		case "installed into":
+			cur.Metadata.(*Metadata).InstalledInto = value
+		case "VERSION":
+			cur.Version = unquote(value)
+			if cur.Version != "" {
+				modules[cur.Name] = cur
+			}
+		}
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(modules))
+	for name := range modules {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	res := make([]*extractor.Inventory, 0, len(names))
+	for _, name := range names {
+		res = append(res, modules[name])
+	}
+	return res, nil
+}
+
+// unquote removes the quotes around versions that modules declare as strings,
+// e.g. our $VERSION = '1.002003'.
+func unquote(version string) string {
+	if len(version) >= 2 {
+		if q := version[0]; (q == '\'' || q == '"') && version[len(version)-1] == q {
+			return version[1 : len(version)-1]
+		}
+	}
+	return version
+}
+
+// ToPURL converts an inventory created by this extractor into a PURL. The name
+// is the module name, e.g. "Try::Tiny".
+func (e Extractor) ToPURL(i *extractor.Inventory) (*purl.PackageURL, error) {
+	return &purl.PackageURL{
+		Type:    purl.TypeCPAN,
+		Name:    i.Name,
+		Version: i.Version,
+	}, nil
+}
+
+// ToCPEs is not applicable as this extractor does not infer CPEs from the Inventory.
+func (e Extractor) ToCPEs(i *extractor.Inventory) ([]string, error) { return []string{}, nil }
+
+// Ecosystem returns no ecosystem since OSV does not support CPAN modules.
+func (Extractor) Ecosystem(i *extractor.Inventory) (string, error) { return "", nil }
@@ -0,0 +1,228 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cpan_test
+
+import (
+	"context"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem"
+	"github.com/google/osv-scalibr/extractor/filesystem/internal/units"
+	"github.com/google/osv-scalibr/extractor/filesystem/language/perl/cpan"
+	scalibrfs "github.com/google/osv-scalibr/fs"
+	"github.com/google/osv-scalibr/purl"
+	"github.com/google/osv-scalibr/stats"
+	"github.com/google/osv-scalibr/testing/fakefs"
+	"github.com/google/osv-scalibr/testing/testcollector"
+)
+
+func TestFileRequired(t *testing.T) {
+	tests := []struct {
+		name             string
+		path             string
+		fileSizeBytes    int64
+		maxFileSizeBytes int64
+		wantRequired     bool
+		wantResultMetric stats.FileRequiredResult
+	}{
+		{
+			name:             "perllocal.pod",
+			path:             "usr/lib/x86_64-linux-gnu/perl/5.36/perllocal.pod",
+			wantRequired:     true,
+			wantResultMetric: stats.FileRequiredResultOK,
+		},
+		{
+			name:         "other pod file",
+			path:         "usr/share/perl/5.36/pod/perlfunc.pod",
+			wantRequired: false,
+		},
+		{
+			name:         "perllocal.pod suffix",
+			path:         "usr/lib/perl5/old-perllocal.pod",
+			wantRequired: false,
+		},
+		{
+			name:             "perllocal.pod required if file size < max file size",
+			path:             "usr/lib/perl5/perllocal.pod",
+			fileSizeBytes:    100 * units.KiB,
+			maxFileSizeBytes: 1000 * units.KiB,
+			wantRequired:     true,
+			wantResultMetric: stats.FileRequiredResultOK,
+		},
+		{
+			name:             "perllocal.pod not required if file size > max file size",
+			path:             "usr/lib/perl5/perllocal.pod",
+			fileSizeBytes:    1000 * units.KiB,
+			maxFileSizeBytes: 100 * units.KiB,
+			wantRequired:     false,
+			wantResultMetric: stats.FileRequiredResultSizeLimitExceeded,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			collector := testcollector.New()
+			var e filesystem.Extractor = cpan.New(cpan.Config{
+				Stats:            collector,
+				MaxFileSizeBytes: test.maxFileSizeBytes,
+			})
+
+			// Set default size if not provided.
+			fileSizeBytes := test.fileSizeBytes
+			if fileSizeBytes == 0 {
+				fileSizeBytes = 100 * units.KiB
+			}
+
+			isRequired := e.FileRequired(test.path, fakefs.FakeFileInfo{
+				FileName: filepath.Base(test.path),
+				FileMode: fs.ModePerm,
+				FileSize: fileSizeBytes,
+			})
+			if isRequired != test.wantRequired {
+				t.Fatalf("FileRequired(%s): got %v, want %v", test.path, isRequired, test.wantRequired)
+			}
+
+			gotResultMetric := collector.FileRequiredResult(test.path)
+			if gotResultMetric != test.wantResultMetric {
+				t.Errorf("FileRequired(%s) recorded result metric %v, want result metric %v", test.path, gotResultMetric, test.wantResultMetric)
+			}
+		})
+	}
+}
+
+func TestExtract(t *testing.T) {
+	const sitePath = "testdata/site/perllocal.pod"
+	const siteLib = "/usr/local/share/perl/5.36.0"
+	tests := []struct {
+		name             string
+		path             string
+		wantInventory    []*extractor.Inventory
+		wantErr          error
+		wantResultMetric stats.FileExtractedResult
+	}{
+		{
+			name: "modules installed over time",
+			path: sitePath,
+			wantInventory: []*extractor.Inventory{
+				{
+					// Declared as a string in the module.
+					Name:    "Data::Dumper::Concise",
+					Version: "2.023",
+					Metadata: &cpan.Metadata{
+						InstallDate:   "Wed Jun  7 16:40:19 2023",
+						InstalledInto: siteLib,
+					},
+					Locations: []string{sitePath},
+				},
+				{
+					Name:    "JSON",
+					Version: "4.10",
+					Metadata: &cpan.Metadata{
+						InstallDate:   "Tue Mar 14 09:13:02 2023",
+						InstalledInto: siteLib,
+					},
+					Locations: []string{sitePath},
+				},
+				{
+					Name:    "Moose",
+					Version: "2.2207",
+					Metadata: &cpan.Metadata{
+						InstallDate:   "Thu Feb 22 08:05:51 2024",
+						InstalledInto: "/home/dev/perl5/lib/perl5",
+					},
+					Locations: []string{sitePath},
+				},
+				{
+					// Upgraded from 0.30.
+					Name:    "Try::Tiny",
+					Version: "0.31",
+					Metadata: &cpan.Metadata{
+						InstallDate:   "Mon Jan  8 10:21:03 2024",
+						InstalledInto: siteLib,
+					},
+					Locations: []string{sitePath},
+				},
+			},
+			wantResultMetric: stats.FileExtractedResultSuccess,
+		},
+		{
+			name:             "empty file",
+			path:             "testdata/empty/perllocal.pod",
+			wantResultMetric: stats.FileExtractedResultSuccess,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			collector := testcollector.New()
+			var e filesystem.Extractor = cpan.New(cpan.Config{Stats: collector})
+
+			r, err := os.Open(test.path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer r.Close()
+
+			info, err := os.Stat(test.path)
+			if err != nil {
+				t.Fatalf("Failed to stat test file: %v", err)
+			}
+
+			input := &filesystem.ScanInput{
+				FS:     scalibrfs.DirFS("."),
+				Path:   test.path,
+				Reader: r,
+				Info:   info,
+			}
+			got, err := e.Extract(context.Background(), input)
+			if !cmp.Equal(err, test.wantErr, cmpopts.EquateErrors()) {
+				t.Fatalf("Extract(%s) error: got %v, want %v", test.path, err, test.wantErr)
+			}
+
+			if diff := cmp.Diff(test.wantInventory, got, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("Extract(%s) (-want +got):\n%s", test.path, diff)
+			}
+
+			gotResultMetric := collector.FileExtractedResult(test.path)
+			if gotResultMetric != test.wantResultMetric {
+				t.Errorf("Extract(%s) recorded result metric %v, want result metric %v", test.path, gotResultMetric, test.wantResultMetric)
+			}
+		})
+	}
+}
+
+func TestToPURL(t *testing.T) {
+	e := cpan.Extractor{}
+	inv := &extractor.Inventory{
+		Name:      "Try::Tiny",
+		Version:   "0.31",
+		Metadata:  &cpan.Metadata{},
+		Locations: []string{"location"},
+	}
+	want := &purl.PackageURL{Type: purl.TypeCPAN, Name: "Try::Tiny", Version: "0.31"}
+	got, err := e.ToPURL(inv)
+	if err != nil {
+		t.Fatalf("ToPURL(%v): %v", inv, err)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ToPURL(%v) (-want +got):\n%s", inv, diff)
+	}
+}
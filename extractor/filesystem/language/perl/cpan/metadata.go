@@ -0,0 +1,24 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cpan
+
+// Metadata contains additional information about a module installed from CPAN.
+type Metadata struct {
+	// The date of the installation as written by the installer, e.g.
+	// "Mon Jan  8 10:21:03 2024".
+	InstallDate string
+	// The library directory the module was installed into.
+	InstalledInto string
+}
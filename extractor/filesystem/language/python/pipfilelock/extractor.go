@@ -0,0 +1,231 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package pipfilelock extracts the packages pinned in Pipenv's Pipfile.lock files.
+package pipfilelock
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem"
+	"github.com/google/osv-scalibr/plugin"
+	"github.com/google/osv-scalibr/purl"
+	"github.com/google/osv-scalibr/stats"
+)
+
+const (
+	// Name is the unique name of this extractor.
+	Name = "python/pipfilelock"
+
+	// SectionDefault is the section of the packages needed to run the project.
+	SectionDefault = "default"
+	// SectionDevelop is the section of the development-only packages.
+	SectionDevelop = "develop"
+)
+
+// pipfileLock is the structure of a Pipfile.lock, see
+// https://pipenv.pypa.io/en/latest/pipfile.html#pipfile-lock
+type pipfileLock struct {
+	Default map[string]lockedPackage `json:"default"`
+	Develop map[string]lockedPackage `json:"develop"`
+}
+
+type lockedPackage struct {
+	// The pinned version, e.g. "==2.31.0", or "*" if the package isn't pinned.
+	// Empty for packages installed from a VCS or a local path.
+	Version string   `json:"version"`
+	Hashes  []string `json:"hashes"`
+}
+
+// Config is the configuration for the Extractor.
+type Config struct {
+	// Stats is a stats collector for reporting metrics.
+	Stats stats.Collector
+	// MaxFileSizeBytes is the maximum file size this extractor will unmarshal. If
+	// `FileRequired` gets a bigger file, it will return false.
+	MaxFileSizeBytes int64
+}
+
+// DefaultConfig returns the default configuration for the extractor.
+func DefaultConfig() Config {
+	return Config{
+		Stats:            nil,
+		MaxFileSizeBytes: 0,
+	}
+}
+
+// Extractor extracts Python packages from Pipfile.lock files.
+type Extractor struct {
+	stats            stats.Collector
+	maxFileSizeBytes int64
+}
+
+// New returns a Pipfile.lock extractor.
+//
+// For most use cases, initialize with:
+// ```
+// e := New(DefaultConfig())
+// ```
+func New(cfg Config) *Extractor {
+	return &Extractor{
+		stats:            cfg.Stats,
+		maxFileSizeBytes: cfg.MaxFileSizeBytes,
+	}
+}
+
+// Config returns the configuration of the extractor.
+func (e Extractor) Config() Config {
+	return Config{
+		Stats:            e.stats,
+		MaxFileSizeBytes: e.maxFileSizeBytes,
+	}
+}
+
+// Name of the extractor.
+func (e Extractor) Name() string { return Name }
+
+// Version of the extractor.
+func (e Extractor) Version() int { return 0 }
+
+// Requirements of the extractor.
+func (e Extractor) Requirements() *plugin.Capabilities { return &plugin.Capabilities{} }
+
+// RequiredFileNames returns the name of the lockfiles the extractor requires.
+func (e Extractor) RequiredFileNames() []string { return []string{"Pipfile.lock"} }
+
+// FileRequired returns true if the specified file is a Pipfile.lock.
+func (e Extractor) FileRequired(path string, fileinfo fs.FileInfo) bool {
+	if filepath.Base(path) != "Pipfile.lock" {
+		return false
+	}
+
+	if e.maxFileSizeBytes > 0 && fileinfo.Size() > e.maxFileSizeBytes {
+		e.reportFileRequired(path, fileinfo.Size(), stats.FileRequiredResultSizeLimitExceeded)
+		return false
+	}
+
+	e.reportFileRequired(path, fileinfo.Size(), stats.FileRequiredResultOK)
+	return true
+}
+
+func (e Extractor) reportFileRequired(path string, fileSizeBytes int64, result stats.FileRequiredResult) {
+	if e.stats == nil {
+		return
+	}
+	e.stats.AfterFileRequired(e.Name(), &stats.FileRequiredStats{
+		Path:          path,
+		Result:        result,
+		FileSizeBytes: fileSizeBytes,
+	})
+}
+
+// Extract extracts packages from the Pipfile.lock passed through the scan input.
+func (e Extractor) Extract(ctx context.Context, input *filesystem.ScanInput) ([]*extractor.Inventory, error) {
+	inventory, err := e.extractFromInput(input)
+	if e.stats != nil {
+		var fileSizeBytes int64
+		if input.Info != nil {
+			fileSizeBytes = input.Info.Size()
+		}
+		e.stats.AfterFileExtracted(e.Name(), &stats.FileExtractedStats{
+			Path:          input.Path,
+			Result:        filesystem.ExtractorErrorToFileExtractedResult(err),
+			FileSizeBytes: fileSizeBytes,
+		})
+	}
+	return inventory, err
+}
+
+func (e Extractor) extractFromInput(input *filesystem.ScanInput) ([]*extractor.Inventory, error) {
+	var lock pipfileLock
+	if err := json.NewDecoder(input.Reader).Decode(&lock); err != nil {
+		return nil, fmt.Errorf("failed to parse %q: %w: %w", input.Path, extractor.ErrMalformedInput, err)
+	}
+
+	var res []*extractor.Inventory
+	// Packages listed in both sections with the same version are reported once.
+	found := make(map[string]*extractor.Inventory)
+	for _, section := range []struct {
+		name     string
+		packages map[string]lockedPackage
+	}{
+		{SectionDefault, lock.Default},
+		{SectionDevelop, lock.Develop},
+	} {
+		names := make([]string, 0, len(section.packages))
+		for name := range section.packages {
+			names = append(names, name)
+		}
+		slices.Sort(names)
+
+		for _, name := range names {
+			p := section.packages[name]
+			version, pinned := strings.CutPrefix(p.Version, "==")
+			if !pinned {
+				version = ""
+			}
+			key := name + "@" + version
+			if inv, ok := found[key]; ok {
+				m := inv.Metadata.(*Metadata)
+				m.Sections = append(m.Sections, section.name)
+				continue
+			}
+			inv := &extractor.Inventory{
+				Name:    name,
+				Version: version,
+				Metadata: &Metadata{
+					Sections: []string{section.name},
+					Hashes:   sha256Hashes(p.Hashes),
+					Unpinned: !pinned,
+				},
+				Locations: []string{input.Path},
+			}
+			found[key] = inv
+			res = append(res, inv)
+		}
+	}
+	return res, nil
+}
+
+func sha256Hashes(hashes []string) []string {
+	var res []string
+	for _, h := range hashes {
+		if strings.HasPrefix(h, "sha256:") {
+			res = append(res, h)
+		}
+	}
+	return res
+}
+
+// ToPURL converts an inventory created by this extractor into a PURL.
+func (e Extractor) ToPURL(i *extractor.Inventory) (*purl.PackageURL, error) {
+	return &purl.PackageURL{
+		Type:    purl.TypePyPi,
+		Name:    strings.ToLower(i.Name),
+		Version: i.Version,
+	}, nil
+}
+
+// ToCPEs is not applicable as this extractor does not infer CPEs from the Inventory.
+func (e Extractor) ToCPEs(i *extractor.Inventory) ([]string, error) { return []string{}, nil }
+
+// Ecosystem returns the OSV Ecosystem of the software extracted by this extractor.
+func (Extractor) Ecosystem(i *extractor.Inventory) (string, error) { return "PyPI", nil }
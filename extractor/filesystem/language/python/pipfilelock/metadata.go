@@ -0,0 +1,28 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pipfilelock
+
+// Metadata contains additional information about a package in a Pipfile.lock.
+type Metadata struct {
+	// The sections of the lockfile that list the package, "default" and/or
+	// "develop".
+	Sections []string
+	// The sha256 hashes of the package's distributions, e.g. "sha256:abcd...".
+	Hashes []string
+	// Whether the package isn't pinned to a version, i.e. its version is "*"
+	// or it's installed from a VCS or local path. Such packages have no
+	// version.
+	Unpinned bool
+}
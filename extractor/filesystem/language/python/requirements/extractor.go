@@ -78,6 +78,9 @@ func (e Extractor) Version() int { return 0 }
 // Requirements of the extractor.
 func (e Extractor) Requirements() *plugin.Capabilities { return &plugin.Capabilities{} }
 
+// RequiredFileExtensions returns the extension of the requirements files the extractor requires.
+func (e Extractor) RequiredFileExtensions() []string { return []string{".txt"} }
+
 // FileRequired returns true if the specified file matches python Metadata file
 // patterns.
 func (e Extractor) FileRequired(path string, fileinfo fs.FileInfo) bool {
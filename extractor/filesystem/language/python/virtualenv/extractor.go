@@ -0,0 +1,309 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package virtualenv extracts the Python packages installed in virtual
+// environments, grouped by the environment's pyvenv.cfg file.
+package virtualenv
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io/fs"
+	"net/textproto"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem"
+	"github.com/google/osv-scalibr/extractor/filesystem/internal/units"
+	"github.com/google/osv-scalibr/log"
+	"github.com/google/osv-scalibr/plugin"
+	"github.com/google/osv-scalibr/purl"
+	"github.com/google/osv-scalibr/stats"
+)
+
+const (
+	// Name is the unique name of this extractor.
+	Name = "python/virtualenv"
+
+	// defaultMaxFileSizeBytes is the maximum pyvenv.cfg file size an extractor
+	// will parse. If Extract gets a bigger file, it will return an error.
+	defaultMaxFileSizeBytes = 1 * units.MiB
+
+	configFileName = "pyvenv.cfg"
+)
+
+// sitePackagesPatterns are the locations of the site-packages directories
+// relative to the environment root on POSIX and Windows systems.
+var sitePackagesPatterns = []string{
+	"lib/python*/site-packages",
+	"Lib/site-packages",
+}
+
+// Config is the configuration for the Extractor.
+type Config struct {
+	// Stats is a stats collector for reporting metrics.
+	Stats stats.Collector
+	// MaxFileSizeBytes is the maximum pyvenv.cfg file size this extractor will
+	// parse. If `FileRequired` gets a bigger file, it will return false.
+	MaxFileSizeBytes int64
+}
+
+// DefaultConfig returns the default configuration for the extractor.
+func DefaultConfig() Config {
+	return Config{
+		MaxFileSizeBytes: defaultMaxFileSizeBytes,
+		Stats:            nil,
+	}
+}
+
+// Extractor extracts the packages installed in Python virtual environments.
+type Extractor struct {
+	stats            stats.Collector
+	maxFileSizeBytes int64
+}
+
+// New returns a Python virtualenv extractor.
+//
+// For most use cases, initialize with:
+// ```
+// e := New(DefaultConfig())
+// ```
+func New(cfg Config) *Extractor {
+	return &Extractor{
+		stats:            cfg.Stats,
+		maxFileSizeBytes: cfg.MaxFileSizeBytes,
+	}
+}
+
+// Config returns the configuration of the extractor.
+func (e Extractor) Config() Config {
+	return Config{
+		Stats:            e.stats,
+		MaxFileSizeBytes: e.maxFileSizeBytes,
+	}
+}
+
+// Name of the extractor.
+func (e Extractor) Name() string { return Name }
+
+// Version of the extractor.
+func (e Extractor) Version() int { return 0 }
+
+// Requirements of the extractor.
+func (e Extractor) Requirements() *plugin.Capabilities { return &plugin.Capabilities{} }
+
+// RequiredFileNames returns the name of the virtualenv config files the extractor requires.
+func (e Extractor) RequiredFileNames() []string { return []string{configFileName} }
+
+// FileRequired returns true if the specified file is the pyvenv.cfg file at
+// the root of a virtual environment.
+func (e Extractor) FileRequired(path string, fileinfo fs.FileInfo) bool {
+	if filepath.Base(path) != configFileName {
+		return false
+	}
+
+	if e.maxFileSizeBytes > 0 && fileinfo.Size() > e.maxFileSizeBytes {
+		e.reportFileRequired(path, fileinfo.Size(), stats.FileRequiredResultSizeLimitExceeded)
+		return false
+	}
+
+	e.reportFileRequired(path, fileinfo.Size(), stats.FileRequiredResultOK)
+	return true
+}
+
+func (e Extractor) reportFileRequired(path string, fileSizeBytes int64, result stats.FileRequiredResult) {
+	if e.stats == nil {
+		return
+	}
+	e.stats.AfterFileRequired(e.Name(), &stats.FileRequiredStats{
+		Path:          path,
+		Result:        result,
+		FileSizeBytes: fileSizeBytes,
+	})
+}
+
+// Extract extracts the packages installed in the virtual environment whose
+// pyvenv.cfg is passed through the scan input.
+func (e Extractor) Extract(ctx context.Context, input *filesystem.ScanInput) ([]*extractor.Inventory, error) {
+	inventory, err := e.extractFromInput(ctx, input)
+	if e.stats != nil {
+		var fileSizeBytes int64
+		if input.Info != nil {
+			fileSizeBytes = input.Info.Size()
+		}
+		e.stats.AfterFileExtracted(e.Name(), &stats.FileExtractedStats{
+			Path:          input.Path,
+			Result:        filesystem.ExtractorErrorToFileExtractedResult(err),
+			FileSizeBytes: fileSizeBytes,
+		})
+	}
+	return inventory, err
+}
+
+func (e Extractor) extractFromInput(ctx context.Context, input *filesystem.ScanInput) ([]*extractor.Inventory, error) {
+	cfg, err := parseConfig(input)
+	if err != nil {
+		return nil, err
+	}
+	if input.FS == nil {
+		return nil, fmt.Errorf("no filesystem to list the packages of %q", input.Path)
+	}
+
+	envPath := path.Dir(filepath.ToSlash(input.Path))
+	var inventory []*extractor.Inventory
+	for _, pattern := range sitePackagesPatterns {
+		dirs, err := fs.Glob(input.FS, path.Join(envPath, pattern))
+		if err != nil {
+			return nil, fmt.Errorf("failed to find the site-packages of %q: %w", envPath, err)
+		}
+		for _, dir := range dirs {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+			inv, err := sitePackages(input.FS, dir)
+			if err != nil {
+				return nil, err
+			}
+			inventory = append(inventory, inv...)
+		}
+	}
+
+	for _, i := range inventory {
+		i.Metadata = &Metadata{
+			EnvironmentPath:           envPath,
+			PythonVersion:             cfg.pythonVersion,
+			IncludeSystemSitePackages: cfg.includeSystemSitePackages,
+		}
+		i.Locations = append(i.Locations, input.Path)
+	}
+	return inventory, nil
+}
+
+// config holds the pyvenv.cfg values used by the extractor.
+type config struct {
+	pythonVersion             string
+	includeSystemSitePackages bool
+}
+
+// parseConfig parses the "key = value" lines of a pyvenv.cfg file. Lines
+// without a "=" are ignored, the same way the Python interpreter does.
+func parseConfig(input *filesystem.ScanInput) (*config, error) {
+	cfg := &config{}
+	var home, versionInfo string
+	s := bufio.NewScanner(input.Reader)
+	for s.Scan() {
+		key, value, ok := strings.Cut(s.Text(), "=")
+		if !ok {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		switch strings.ToLower(strings.TrimSpace(key)) {
+		case "home":
+			home = value
+		case "version":
+			cfg.pythonVersion = value
+		case "version_info":
+			versionInfo = value
+		case "include-system-site-packages":
+			cfg.includeSystemSitePackages = strings.EqualFold(value, "true")
+		}
+	}
+	if err := s.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read %q: %w", input.Path, err)
+	}
+	if home == "" {
+		return nil, fmt.Errorf("%q has no home key: %w", input.Path, extractor.ErrMalformedInput)
+	}
+	if cfg.pythonVersion == "" {
+		cfg.pythonVersion = trimVersionInfo(versionInfo)
+	}
+	return cfg, nil
+}
+
+// trimVersionInfo returns the release part of the version_info key written by
+// virtualenv and uv, e.g. "3.11.4" for "3.11.4.final.0".
+func trimVersionInfo(versionInfo string) string {
+	parts := strings.Split(versionInfo, ".")
+	if len(parts) > 3 {
+		parts = parts[:3]
+	}
+	return strings.Join(parts, ".")
+}
+
+// sitePackages returns the packages with a *.dist-info directory in the
+// site-packages directory dir.
+func sitePackages(fsys fs.FS, dir string) ([]*extractor.Inventory, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list %q: %w", dir, err)
+	}
+	var inventory []*extractor.Inventory
+	for _, entry := range entries {
+		if !entry.IsDir() || !strings.HasSuffix(entry.Name(), ".dist-info") {
+			continue
+		}
+		metadataPath := path.Join(dir, entry.Name(), "METADATA")
+		name, version, err := parseMetadata(fsys, metadataPath)
+		if err != nil {
+			// A single broken package shouldn't hide the rest of the environment.
+			log.Warnf("Skipping %q: %v", metadataPath, err)
+			continue
+		}
+		inventory = append(inventory, &extractor.Inventory{
+			Name:      name,
+			Version:   version,
+			Locations: []string{metadataPath},
+		})
+	}
+	return inventory, nil
+}
+
+// parseMetadata returns the name and version from the headers of a
+// dist-info METADATA file.
+func parseMetadata(fsys fs.FS, metadataPath string) (string, string, error) {
+	f, err := fsys.Open(metadataPath)
+	if err != nil {
+		return "", "", err
+	}
+	defer f.Close()
+
+	h, err := textproto.NewReader(bufio.NewReader(f)).ReadMIMEHeader()
+	name := h.Get("Name")
+	version := h.Get("Version")
+	if name == "" || version == "" {
+		if err != nil {
+			return "", "", fmt.Errorf("ReadMIMEHeader(): %w", err)
+		}
+		return "", "", fmt.Errorf("Name or version is empty (name: %q, version: %q)", name, version)
+	}
+	return name, version, nil
+}
+
+// ToPURL converts an inventory created by this extractor into a PURL.
+func (e Extractor) ToPURL(i *extractor.Inventory) (*purl.PackageURL, error) {
+	return &purl.PackageURL{
+		Type:    purl.TypePyPi,
+		Name:    strings.ToLower(i.Name),
+		Version: i.Version,
+	}, nil
+}
+
+// ToCPEs is not applicable as this extractor does not infer CPEs from the Inventory.
+func (e Extractor) ToCPEs(i *extractor.Inventory) ([]string, error) { return []string{}, nil }
+
+// Ecosystem returns the OSV Ecosystem of the software extracted by this extractor.
+func (Extractor) Ecosystem(i *extractor.Inventory) (string, error) { return "PyPI", nil }
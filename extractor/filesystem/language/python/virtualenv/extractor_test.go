@@ -0,0 +1,206 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package virtualenv_test
+
+import (
+	"context"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem"
+	"github.com/google/osv-scalibr/extractor/filesystem/internal/units"
+	"github.com/google/osv-scalibr/extractor/filesystem/language/python/virtualenv"
+	scalibrfs "github.com/google/osv-scalibr/fs"
+	"github.com/google/osv-scalibr/purl"
+	"github.com/google/osv-scalibr/stats"
+	"github.com/google/osv-scalibr/testing/extracttest"
+	"github.com/google/osv-scalibr/testing/fakefs"
+	"github.com/google/osv-scalibr/testing/testcollector"
+)
+
+func TestFileRequired(t *testing.T) {
+	tests := []struct {
+		name             string
+		path             string
+		fileSizeBytes    int64
+		maxFileSizeBytes int64
+		wantRequired     bool
+		wantResultMetric stats.FileRequiredResult
+	}{
+		{
+			name:             "pyvenv.cfg",
+			path:             "home/user/project/.venv/pyvenv.cfg",
+			wantRequired:     true,
+			wantResultMetric: stats.FileRequiredResultOK,
+		}, {
+			name:             "pyvenv.cfg at root",
+			path:             "pyvenv.cfg",
+			wantRequired:     true,
+			wantResultMetric: stats.FileRequiredResultOK,
+		}, {
+			name:         "dist-info METADATA",
+			path:         "project/.venv/lib/python3.11/site-packages/requests-2.31.0.dist-info/METADATA",
+			wantRequired: false,
+		}, {
+			name:         "similar name",
+			path:         "project/.venv/pyvenv.cfg.bak",
+			wantRequired: false,
+		}, {
+			name:             "file size limit exceeded",
+			path:             "project/.venv/pyvenv.cfg",
+			fileSizeBytes:    1 * units.MiB,
+			maxFileSizeBytes: 1000,
+			wantRequired:     false,
+			wantResultMetric: stats.FileRequiredResultSizeLimitExceeded,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			collector := testcollector.New()
+			var e filesystem.Extractor = virtualenv.New(virtualenv.Config{
+				Stats:            collector,
+				MaxFileSizeBytes: tt.maxFileSizeBytes,
+			})
+
+			fileSizeBytes := tt.fileSizeBytes
+			if fileSizeBytes == 0 {
+				fileSizeBytes = 1000
+			}
+			isRequired := e.FileRequired(tt.path, fakefs.FakeFileInfo{
+				FileName: filepath.Base(tt.path),
+				FileMode: fs.ModePerm,
+				FileSize: fileSizeBytes,
+			})
+			if isRequired != tt.wantRequired {
+				t.Fatalf("FileRequired(%s): got %v, want %v", tt.path, isRequired, tt.wantRequired)
+			}
+
+			gotResultMetric := collector.FileRequiredResult(tt.path)
+			if tt.wantResultMetric != "" && gotResultMetric != tt.wantResultMetric {
+				t.Errorf("FileRequired(%s) recorded result metric %v, want result metric %v", tt.path, gotResultMetric, tt.wantResultMetric)
+			}
+		})
+	}
+}
+
+func TestExtract(t *testing.T) {
+	tests := []struct {
+		name             string
+		path             string
+		wantGolden       string
+		wantErr          error
+		wantResultMetric stats.FileExtractedResult
+	}{
+		{
+			name:             "venv skips broken package",
+			path:             "venv/pyvenv.cfg",
+			wantGolden:       "venv.golden.json",
+			wantResultMetric: stats.FileExtractedResultSuccess,
+		}, {
+			name:             "virtualenv with system site packages",
+			path:             "virtualenv/pyvenv.cfg",
+			wantGolden:       "virtualenv.golden.json",
+			wantResultMetric: stats.FileExtractedResultSuccess,
+		}, {
+			name:             "Windows layout",
+			path:             "windows/pyvenv.cfg",
+			wantGolden:       "windows.golden.json",
+			wantResultMetric: stats.FileExtractedResultSuccess,
+		}, {
+			name:             "no site-packages",
+			path:             "empty/pyvenv.cfg",
+			wantGolden:       "empty.golden.json",
+			wantResultMetric: stats.FileExtractedResultSuccess,
+		}, {
+			name:             "invalid pyvenv.cfg",
+			path:             "invalid/pyvenv.cfg",
+			wantErr:          extractor.ErrMalformedInput,
+			wantResultMetric: stats.FileExtractedResultErrorMalformedInput,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			collector := testcollector.New()
+			var e filesystem.Extractor = virtualenv.New(virtualenv.Config{
+				Stats:            collector,
+				MaxFileSizeBytes: 1 * units.MiB,
+			})
+
+			d := "testdata"
+			r, err := os.Open(filepath.Join(d, tt.path))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer r.Close()
+			info, err := r.Stat()
+			if err != nil {
+				t.Fatalf("Stat(): %v", err)
+			}
+
+			input := &filesystem.ScanInput{
+				FS:     scalibrfs.DirFS(d),
+				Path:   tt.path,
+				Reader: r,
+				Root:   d,
+				Info:   info,
+			}
+			got, err := e.Extract(context.Background(), input)
+			if !cmp.Equal(err, tt.wantErr, cmpopts.EquateErrors()) {
+				t.Fatalf("Extract(%+v) error: got %v, want %v\n", tt.path, err, tt.wantErr)
+			}
+
+			if tt.wantGolden != "" {
+				extracttest.CompareGolden(t, filepath.Join(d, tt.wantGolden), got)
+			}
+
+			gotResultMetric := collector.FileExtractedResult(tt.path)
+			if gotResultMetric != tt.wantResultMetric {
+				t.Errorf("Extract(%s) recorded result metric %v, want result metric %v", tt.path, gotResultMetric, tt.wantResultMetric)
+			}
+		})
+	}
+}
+
+func TestToPURL(t *testing.T) {
+	e := virtualenv.New(virtualenv.DefaultConfig())
+	i := &extractor.Inventory{
+		Name:    "PyYAML",
+		Version: "6.0.1",
+		Metadata: &virtualenv.Metadata{
+			EnvironmentPath: "venv",
+			PythonVersion:   "3.10.12",
+		},
+		Locations: []string{"location"},
+	}
+	want := &purl.PackageURL{
+		Type:    purl.TypePyPi,
+		Name:    "pyyaml",
+		Version: "6.0.1",
+	}
+	got, err := e.ToPURL(i)
+	if err != nil {
+		t.Fatalf("ToPURL(%v): %v", i, err)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ToPURL(%v) (-want +got):\n%s", i, diff)
+	}
+}
@@ -0,0 +1,28 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package virtualenv
+
+// Metadata holds the virtual environment a Python package is installed in.
+type Metadata struct {
+	// EnvironmentPath is the root directory of the virtual environment, i.e.
+	// the directory containing pyvenv.cfg.
+	EnvironmentPath string
+	// PythonVersion is the version of the environment's interpreter, e.g. "3.11.4".
+	// Empty if pyvenv.cfg doesn't record it.
+	PythonVersion string
+	// IncludeSystemSitePackages is set if the environment can also import the
+	// packages installed for the base interpreter.
+	IncludeSystemSitePackages bool
+}
@@ -24,7 +24,6 @@ import (
 	"fmt"
 	"io"
 	"io/fs"
-	"net/textproto"
 	"path/filepath"
 	"strings"
 
@@ -102,6 +101,12 @@ var (
 	}
 )
 
+// RequiredFileNames returns the names of the metadata files the extractor requires.
+func (e Extractor) RequiredFileNames() []string { return []string{"PKG-INFO", "METADATA"} }
+
+// RequiredFileExtensions returns the extensions of the egg files the extractor requires.
+func (e Extractor) RequiredFileExtensions() []string { return []string{".egg-info", ".egg"} }
+
 // FileRequired returns true if the specified file matches python Metadata file
 // patterns.
 func (e Extractor) FileRequired(path string, fileinfo fs.FileInfo) bool {
@@ -147,6 +152,7 @@ func (e Extractor) Extract(ctx context.Context, input *filesystem.ScanInput) (in
 			inventory = []*extractor.Inventory{i}
 		}
 	}
+	setInstallScope(input, inventory)
 
 	if e.stats != nil {
 		var fileSizeBytes int64
@@ -177,7 +183,7 @@ func (e Extractor) extractZip(ctx context.Context, input *filesystem.ScanInput)
 	s := input.Info.Size()
 	zr, err := zip.NewReader(r, s)
 	if err != nil {
-		return nil, fmt.Errorf("zip.NewReader(%s): %w", input.Path, err)
+		return nil, fmt.Errorf("zip.NewReader(%s): %w: %w", input.Path, extractor.ErrMalformedInput, err)
 	}
 	inventory := []*extractor.Inventory{}
 	for _, f := range zr.File {
@@ -240,16 +246,13 @@ func (e Extractor) extractSingleFile(r io.Reader, path string) (*extractor.Inven
 }
 
 func parse(r io.Reader) (*extractor.Inventory, error) {
-	rd := textproto.NewReader(bufio.NewReader(r))
-	h, err := rd.ReadMIMEHeader()
-	name := h.Get("Name")
-	version := h.Get("version")
+	h, err := parseHeaders(r)
+	if err != nil {
+		return nil, fmt.Errorf("parseHeaders(): %w", err)
+	}
+	name := h["name"]
+	version := h["version"]
 	if name == "" || version == "" {
-		// In case we got name and version but also an error, we ignore the error. This can happen in
-		// malformed files like passlib 1.7.4.
-		if err != nil {
-			return nil, fmt.Errorf("ReadMIMEHeader(): %w %s %s", err, h.Get("Name"), h.Get("version"))
-		}
 		return nil, fmt.Errorf("Name or version is empty (name: %q, version: %q)", name, version)
 	}
 
@@ -257,12 +260,62 @@ func parse(r io.Reader) (*extractor.Inventory, error) {
 		Name:    name,
 		Version: version,
 		Metadata: &PythonPackageMetadata{
-			Author:      h.Get("Author"),
-			AuthorEmail: h.Get("Author-email"),
+			Author:      h["author"],
+			AuthorEmail: h["author-email"],
 		},
 	}, nil
 }
 
+// parseHeaders parses the RFC 822-style headers of a PKG-INFO or METADATA
+// file into a map keyed by the lowercased header names. Only the first value
+// of repeated headers is kept.
+//
+// Continuation lines, i.e. lines starting with whitespace, are appended to the
+// previous header's value with a space. setuptools prefixes them with "|",
+// which is removed. Unlike net/textproto, lines that aren't headers are
+// skipped instead of failing the parsing (e.g. in passlib 1.7.4), and an empty
+// line only ends the headers once the name and version have been found: older
+// versions of distutils indented the empty lines of multi-line descriptions
+// with whitespace, which is lost if the file was reformatted.
+func parseHeaders(r io.Reader) (map[string]string, error) {
+	h := map[string]string{}
+	br := bufio.NewReader(r)
+	// The header that continuation lines are appended to. Empty if the previous
+	// line wasn't part of a kept header.
+	last := ""
+	for {
+		line, err := br.ReadString('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return nil, err
+		}
+		line = strings.TrimRight(line, "\r\n")
+		switch {
+		case line == "":
+			if h["name"] != "" && h["version"] != "" {
+				return h, nil
+			}
+			last = ""
+		case strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t"):
+			if last != "" {
+				cont := strings.TrimPrefix(strings.TrimSpace(line), "|")
+				h[last] = strings.TrimSpace(h[last] + " " + cont)
+			}
+		default:
+			key, value, ok := strings.Cut(line, ":")
+			key = strings.ToLower(strings.TrimSpace(key))
+			if _, exists := h[key]; !ok || exists {
+				last = ""
+				break
+			}
+			h[key] = strings.TrimSpace(value)
+			last = key
+		}
+		if err != nil {
+			return h, nil
+		}
+	}
+}
+
 // ToPURL converts an inventory created by this extractor into a PURL.
 func (e Extractor) ToPURL(i *extractor.Inventory) (*purl.PackageURL, error) {
 	return &purl.PackageURL{
@@ -21,6 +21,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"testing/fstest"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
@@ -204,6 +205,32 @@ func TestExtract(t *testing.T) {
 			},
 			},
 		},
+		{
+			name: "PKG-INFO with folded headers and an empty line in the description",
+			path: "testdata/pkginfo_folded",
+			wantInventory: []*extractor.Inventory{{
+				Name:      "python-dateutil",
+				Version:   "1.5",
+				Locations: []string{"testdata/pkginfo_folded"},
+				Metadata: &wheelegg.PythonPackageMetadata{
+					Author:      "Gustavo Niemeyer, Tomi Pievilainen",
+					AuthorEmail: "gustavo@niemeyer.net",
+				},
+			}},
+		},
+		{
+			name: "METADATA with setuptools continuation lines",
+			path: "testdata/distinfo_meta_folded",
+			wantInventory: []*extractor.Inventory{{
+				Name:      "zope.interface",
+				Version:   "5.4.0",
+				Locations: []string{"testdata/distinfo_meta_folded"},
+				Metadata: &wheelegg.PythonPackageMetadata{
+					Author:      "Zope Foundation and Contributors",
+					AuthorEmail: "zope-dev@zope.org",
+				},
+			}},
+		},
 		{
 			name: "malformed PKG-INFO",
 			path: "testdata/malformed_pkginfo",
@@ -377,8 +404,8 @@ func TestExtractErrorsWithFakeFiles(t *testing.T) {
 				FileSize: 1000,
 			},
 			fakeFileBytes:    []byte("invalid zip file"),
-			wantErr:          cmpopts.AnyError,
-			wantResultMetric: stats.FileExtractedResultErrorUnknown,
+			wantErr:          extractor.ErrMalformedInput,
+			wantResultMetric: stats.FileExtractedResultErrorMalformedInput,
 		},
 	}
 
@@ -417,6 +444,91 @@ func TestExtractErrorsWithFakeFiles(t *testing.T) {
 	}
 }
 
+func TestExtractScope(t *testing.T) {
+	metadata, err := os.ReadFile("testdata/distinfo_meta")
+	if err != nil {
+		t.Fatal(err)
+	}
+	systemPath := "usr/lib/python3/dist-packages/pip-22.2.2.dist-info/METADATA"
+	userPath := "home/user/.local/lib/python3.11/site-packages/pip-22.2.2.dist-info/METADATA"
+	venvPath := "home/user/venv/lib/python3.11/site-packages/pip-22.2.2.dist-info/METADATA"
+	windowsVenvPath := "project/.venv/Lib/site-packages/pip-22.2.2.dist-info/METADATA"
+	windowsUserPath := "Users/user/AppData/Roaming/Python/Python311/site-packages/pip-22.2.2.dist-info/METADATA"
+	macUserPath := "Users/user/Library/Python/3.9/lib/python/site-packages/pip-22.2.2.dist-info/METADATA"
+	projectPath := "project/src/pip.egg-info/PKG-INFO"
+	fsys := fstest.MapFS{
+		systemPath:                  {Data: metadata},
+		userPath:                    {Data: metadata},
+		venvPath:                    {Data: metadata},
+		"home/user/venv/pyvenv.cfg": {Data: []byte("home = /usr/bin")},
+		windowsVenvPath:             {Data: metadata},
+		"project/.venv/pyvenv.cfg":  {Data: []byte("home = C:\\Python311")},
+		windowsUserPath:             {Data: metadata},
+		macUserPath:                 {Data: metadata},
+		projectPath:                 {Data: metadata},
+	}
+
+	tests := []struct {
+		name      string
+		path      string
+		wantScope string
+	}{
+		{
+			name:      "system dist-packages",
+			path:      systemPath,
+			wantScope: wheelegg.ScopeSystem,
+		},
+		{
+			name:      "user site-packages",
+			path:      userPath,
+			wantScope: wheelegg.ScopeUser,
+		},
+		{
+			name:      "virtualenv",
+			path:      venvPath,
+			wantScope: wheelegg.ScopeVirtualenv,
+		},
+		{
+			name:      "Windows virtualenv",
+			path:      windowsVenvPath,
+			wantScope: wheelegg.ScopeVirtualenv,
+		},
+		{
+			name:      "Windows user site-packages",
+			path:      windowsUserPath,
+			wantScope: wheelegg.ScopeUser,
+		},
+		{
+			name:      "macOS user site-packages",
+			path:      macUserPath,
+			wantScope: wheelegg.ScopeUser,
+		},
+		{
+			name:      "not installed",
+			path:      projectPath,
+			wantScope: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var e filesystem.Extractor = wheelegg.New(wheelegg.DefaultConfig())
+			input := &filesystem.ScanInput{FS: fsys, Path: tt.path, Reader: bytes.NewReader(metadata)}
+			got, err := e.Extract(context.Background(), input)
+			if err != nil {
+				t.Fatalf("Extract(%s): %v", tt.path, err)
+			}
+			if len(got) != 1 {
+				t.Fatalf("Extract(%s): got %d inventory items, want 1", tt.path, len(got))
+			}
+			gotScope := got[0].Metadata.(*wheelegg.PythonPackageMetadata).Scope
+			if gotScope != tt.wantScope {
+				t.Errorf("Extract(%s) scope: got %q, want %q", tt.path, gotScope, tt.wantScope)
+			}
+		})
+	}
+}
+
 type reader struct {
 	f fs.File
 }
@@ -18,4 +18,8 @@ package wheelegg
 type PythonPackageMetadata struct {
 	Author      string `json:"author"`
 	AuthorEmail string `json:"authorEmail"`
+	// Where the package is installed, ScopeSystem, ScopeUser or
+	// ScopeVirtualenv. Empty if the package isn't installed into a
+	// site-packages or dist-packages directory.
+	Scope string `json:"scope"`
 }
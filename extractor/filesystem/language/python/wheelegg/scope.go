@@ -0,0 +1,96 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wheelegg
+
+import (
+	"io/fs"
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem"
+)
+
+// Installation scopes of Python packages, see PythonPackageMetadata.Scope.
+const (
+	// ScopeSystem is the scope of packages installed into the site-packages
+	// or dist-packages directory of a Python installation.
+	ScopeSystem = "system"
+	// ScopeUser is the scope of packages installed into a user's site-packages
+	// directory, e.g. with "pip install --user".
+	ScopeUser = "user"
+	// ScopeVirtualenv is the scope of packages installed into a virtual
+	// environment.
+	ScopeVirtualenv = "virtualenv"
+)
+
+// reUserSite matches the parent directory of the user site-packages directory
+// on Linux (~/.local/lib/pythonX.Y), macOS (~/Library/Python/X.Y/lib/python)
+// and Windows (%APPDATA%\Python\PythonXY).
+var reUserSite = regexp.MustCompile(`(^|/)(\.local/lib/python[^/]*|Library/Python/[^/]+/lib/python|AppData/Roaming/Python/Python[^/]*)$`)
+
+// setInstallScope sets the installation scope of the packages extracted from
+// the scan input in their metadata.
+func setInstallScope(input *filesystem.ScanInput, inventory []*extractor.Inventory) {
+	if len(inventory) == 0 {
+		return
+	}
+	scope := installScope(input.FS, input.Path)
+	for _, i := range inventory {
+		if m, ok := i.Metadata.(*PythonPackageMetadata); ok {
+			m.Scope = scope
+		}
+	}
+}
+
+// installScope infers the installation scope of a package from the path of its
+// metadata file relative to the scan root. Virtual environments are detected
+// through the pyvenv.cfg file in the environment's directory. Returns an empty
+// string for packages outside of a site-packages or dist-packages directory,
+// e.g. egg-info directories in a project's source tree.
+func installScope(fsys fs.FS, p string) string {
+	parts := strings.Split(filepath.ToSlash(p), "/")
+	i := slices.IndexFunc(parts, func(part string) bool {
+		return part == "site-packages" || part == "dist-packages"
+	})
+	if i < 0 {
+		return ""
+	}
+
+	// Virtual environments have their packages in <env>/lib/pythonX.Y/site-packages,
+	// or <env>/Lib/site-packages on Windows.
+	if fsys != nil {
+		for _, depth := range []int{2, 1} {
+			if i < depth {
+				continue
+			}
+			env := path.Join(parts[:i-depth]...)
+			if env == "" {
+				env = "."
+			}
+			if _, err := fs.Stat(fsys, path.Join(env, "pyvenv.cfg")); err == nil {
+				return ScopeVirtualenv
+			}
+		}
+	}
+
+	if reUserSite.MatchString(strings.Join(parts[:i], "/")) {
+		return ScopeUser
+	}
+	return ScopeSystem
+}
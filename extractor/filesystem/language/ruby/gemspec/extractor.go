@@ -23,6 +23,7 @@ import (
 	"io/fs"
 	"path/filepath"
 	"regexp"
+	"strings"
 
 	"github.com/google/osv-scalibr/extractor"
 	"github.com/google/osv-scalibr/extractor/filesystem"
@@ -34,9 +35,10 @@ import (
 
 // Regex expressions used for extracting gemspec package name and version.
 var (
-	reSpec = regexp.MustCompile(`^Gem::Specification\.new`)
-	reName = regexp.MustCompile(`\s*\w+\.name\s*=\s*["']([^"']+)["']`)
-	reVer  = regexp.MustCompile(`\s*\w+\.version\s*=\s*["']([^"']+)["']`)
+	reSpec     = regexp.MustCompile(`^\s*Gem::Specification\.new`)
+	reName     = regexp.MustCompile(`\s*\w+\.name\s*=\s*["']([^"']+)["']`)
+	reVer      = regexp.MustCompile(`\s*\w+\.version\s*=\s*["']([^"']+)["']`)
+	reVerConst = regexp.MustCompile(`\s*\w+\.version\s*=\s*[A-Z]\w*(::\w+)*`)
 )
 
 // Config is the configuration for the Extractor.
@@ -84,8 +86,12 @@ func (e Extractor) Version() int { return 0 }
 // Requirements of the extractor.
 func (e Extractor) Requirements() *plugin.Capabilities { return &plugin.Capabilities{} }
 
+// RequiredFileExtensions returns the extension of the gemspec files the extractor requires.
+func (e Extractor) RequiredFileExtensions() []string { return []string{".gemspec"} }
+
 // FileRequired return true if the specified file matched the .gemspec file
-// pattern.
+// pattern. This covers both installed gems (specifications/*.gemspec) and
+// vendored gem sources (e.g. vendor/bundle/ruby/*/gems/*/*.gemspec).
 func (e Extractor) FileRequired(path string, fileinfo fs.FileInfo) bool {
 	if filepath.Ext(path) != ".gemspec" {
 		return false
@@ -148,6 +154,7 @@ func extract(path string, r io.Reader) (*extractor.Inventory, error) {
 	buf := bufio.NewScanner(r)
 	gemName, gemVer := "", ""
 	foundStart := false
+	constVer := false
 
 	for buf.Scan() {
 		line := buf.Text()
@@ -175,6 +182,9 @@ func extract(path string, r io.Reader) (*extractor.Inventory, error) {
 				gemVer = verArr[1]
 				continue
 			}
+			if reVerConst.MatchString(line) {
+				constVer = true
+			}
 		}
 	}
 
@@ -188,6 +198,12 @@ func extract(path string, r io.Reader) (*extractor.Inventory, error) {
 		return nil, nil
 	}
 
+	// The version is set through a constant reference (e.g. MyGem::VERSION)
+	// which we can't evaluate. Fall back to the versioned file or directory name.
+	if gemVer == "" && constVer && gemName != "" {
+		gemVer = versionFromPath(path, gemName)
+	}
+
 	if gemName == "" || gemVer == "" {
 		return nil, fmt.Errorf("failed to parse gemspec name (%v) and version (%v)", gemName, gemVer)
 	}
@@ -198,6 +214,23 @@ func extract(path string, r io.Reader) (*extractor.Inventory, error) {
 	}, nil
 }
 
+// versionFromPath infers the gem version from a "<name>-<version>" file or
+// directory name, as used by installed gems (specifications/<name>-<version>.gemspec)
+// and vendored gem sources (gems/<name>-<version>/<name>.gemspec).
+func versionFromPath(path string, gemName string) string {
+	prefix := gemName + "-"
+	candidates := []string{
+		strings.TrimSuffix(filepath.Base(path), ".gemspec"),
+		filepath.Base(filepath.Dir(path)),
+	}
+	for _, c := range candidates {
+		if strings.HasPrefix(c, prefix) && len(c) > len(prefix) {
+			return strings.TrimPrefix(c, prefix)
+		}
+	}
+	return ""
+}
+
 // ToPURL converts an inventory created by this extractor into a PURL.
 func (e Extractor) ToPURL(i *extractor.Inventory) (*purl.PackageURL, error) {
 	return &purl.PackageURL{
@@ -49,6 +49,18 @@ func TestFileRequired(t *testing.T) {
 			wantRequired:     true,
 			wantResultMetric: stats.FileRequiredResultOK,
 		},
+		{
+			name:             "installed gem specification",
+			path:             "usr/lib/ruby/gems/3.2.0/specifications/rack-3.0.8.gemspec",
+			wantRequired:     true,
+			wantResultMetric: stats.FileRequiredResultOK,
+		},
+		{
+			name:             "vendored bundle gemspec",
+			path:             "app/vendor/bundle/ruby/3.2.0/gems/mygem-1.4.0/mygem.gemspec",
+			wantRequired:     true,
+			wantResultMetric: stats.FileRequiredResultOK,
+		},
 		{
 			name:         "ruby file",
 			path:         "testdata/test.rb",
@@ -153,6 +165,36 @@ func TestExtract(t *testing.T) {
 			},
 			wantResultMetric: stats.FileExtractedResultSuccess,
 		},
+		{
+			name: "multi-line gemspec with version constraints",
+			path: "testdata/specifications/rack-3.0.8.gemspec",
+			wantInventory: []*extractor.Inventory{
+				&extractor.Inventory{
+					Name:      "rack",
+					Version:   "3.0.8",
+					Locations: []string{"testdata/specifications/rack-3.0.8.gemspec"},
+				},
+			},
+			wantResultMetric: stats.FileExtractedResultSuccess,
+		},
+		{
+			name: "constant version falls back to directory name",
+			path: "testdata/vendor/bundle/ruby/3.2.0/gems/mygem-1.4.0/mygem.gemspec",
+			wantInventory: []*extractor.Inventory{
+				&extractor.Inventory{
+					Name:      "mygem",
+					Version:   "1.4.0",
+					Locations: []string{"testdata/vendor/bundle/ruby/3.2.0/gems/mygem-1.4.0/mygem.gemspec"},
+				},
+			},
+			wantResultMetric: stats.FileExtractedResultSuccess,
+		},
+		{
+			name:             "constant version without versioned path",
+			path:             "testdata/constversion.gemspec",
+			wantErr:          cmpopts.AnyError,
+			wantResultMetric: stats.FileExtractedResultErrorUnknown,
+		},
 		{
 			name:             "invalid gemspec",
 			path:             "testdata/invalid.gemspec",
@@ -26,22 +26,50 @@ import (
 
 	// SCALIBR internal extractors.
 
+	"github.com/google/osv-scalibr/extractor"
 	"github.com/google/osv-scalibr/extractor/filesystem/containers/containerd"
 	"github.com/google/osv-scalibr/extractor/filesystem"
+	"github.com/google/osv-scalibr/extractor/filesystem/language/dotnet/nuget"
 	"github.com/google/osv-scalibr/extractor/filesystem/language/dotnet/packageslockjson"
+	"github.com/google/osv-scalibr/extractor/filesystem/language/erlang/mixlock"
 	"github.com/google/osv-scalibr/extractor/filesystem/language/golang/gobinary"
+	"github.com/google/osv-scalibr/extractor/filesystem/language/golang/gomod"
 	javaarchive "github.com/google/osv-scalibr/extractor/filesystem/language/java/archive"
+	"github.com/google/osv-scalibr/extractor/filesystem/language/java/m2repo"
+	"github.com/google/osv-scalibr/extractor/filesystem/language/java/osgi"
 	"github.com/google/osv-scalibr/extractor/filesystem/language/javascript/packagejson"
 	"github.com/google/osv-scalibr/extractor/filesystem/language/javascript/packagelockjson"
+	"github.com/google/osv-scalibr/extractor/filesystem/language/perl/cpan"
+	"github.com/google/osv-scalibr/extractor/filesystem/language/python/pipfilelock"
 	"github.com/google/osv-scalibr/extractor/filesystem/language/python/requirements"
+	"github.com/google/osv-scalibr/extractor/filesystem/language/python/virtualenv"
 	"github.com/google/osv-scalibr/extractor/filesystem/language/python/wheelegg"
 	"github.com/google/osv-scalibr/extractor/filesystem/language/ruby/gemspec"
+	"github.com/google/osv-scalibr/extractor/filesystem/misc/androidapk"
+	"github.com/google/osv-scalibr/extractor/filesystem/misc/bazel"
+	"github.com/google/osv-scalibr/extractor/filesystem/misc/browserext"
+	"github.com/google/osv-scalibr/extractor/filesystem/misc/dockercompose"
+	"github.com/google/osv-scalibr/extractor/filesystem/misc/embeddedid"
+	"github.com/google/osv-scalibr/extractor/filesystem/misc/embeddedruntime"
+	"github.com/google/osv-scalibr/extractor/filesystem/misc/gitsubmodule"
+	"github.com/google/osv-scalibr/extractor/filesystem/misc/helm"
+	"github.com/google/osv-scalibr/extractor/filesystem/misc/jenkinsplugin"
+	"github.com/google/osv-scalibr/extractor/filesystem/misc/ocilayout"
+	"github.com/google/osv-scalibr/extractor/filesystem/misc/upx"
+	"github.com/google/osv-scalibr/extractor/filesystem/misc/wordpress"
+	"github.com/google/osv-scalibr/extractor/filesystem/misc/yoctomanifest"
 	"github.com/google/osv-scalibr/extractor/filesystem/os/apk"
 	"github.com/google/osv-scalibr/extractor/filesystem/os/cos"
+	"github.com/google/osv-scalibr/extractor/filesystem/os/debfile"
 	"github.com/google/osv-scalibr/extractor/filesystem/os/dpkg"
 	"github.com/google/osv-scalibr/extractor/filesystem/os/flatpak"
 	"github.com/google/osv-scalibr/extractor/filesystem/os/homebrew"
+	"github.com/google/osv-scalibr/extractor/filesystem/os/kmod"
+	"github.com/google/osv-scalibr/extractor/filesystem/os/macapps"
+	"github.com/google/osv-scalibr/extractor/filesystem/os/macframeworks"
+	"github.com/google/osv-scalibr/extractor/filesystem/os/opkg"
 	"github.com/google/osv-scalibr/extractor/filesystem/os/rpm"
+	"github.com/google/osv-scalibr/extractor/filesystem/os/rpmfile"
 	"github.com/google/osv-scalibr/extractor/filesystem/os/snap"
 	"github.com/google/osv-scalibr/extractor/filesystem/osv"
 	"github.com/google/osv-scalibr/extractor/filesystem/sbom/spdx"
@@ -56,21 +84,33 @@ var (
 	// Language extractors.
 
 	// Java extractors.
-	Java []filesystem.Extractor = []filesystem.Extractor{javaarchive.New(javaarchive.DefaultConfig())}
+	Java []filesystem.Extractor = []filesystem.Extractor{javaarchive.New(javaarchive.DefaultConfig()), m2repo.New(m2repo.DefaultConfig())}
+	// OSGi bundle extractors. Not part of Default as bundle jars are already
+	// reported by their Maven coordinates by the Java archive extractor.
+	JavaOSGi []filesystem.Extractor = []filesystem.Extractor{osgi.New(osgi.DefaultConfig())}
 	// Javascript extractors.
 	Javascript []filesystem.Extractor = []filesystem.Extractor{packagejson.New(packagejson.DefaultConfig()), packagelockjson.New(packagelockjson.DefaultConfig())}
 	// Python extractors.
-	Python []filesystem.Extractor = []filesystem.Extractor{wheelegg.New(wheelegg.DefaultConfig()), requirements.New(requirements.DefaultConfig())}
+	Python []filesystem.Extractor = []filesystem.Extractor{wheelegg.New(wheelegg.DefaultConfig()), requirements.New(requirements.DefaultConfig()), pipfilelock.New(pipfilelock.DefaultConfig())}
+	// Python virtualenv extractors. Not part of Default as the installed
+	// packages are already reported individually by the wheel/egg extractor.
+	PythonVirtualenv []filesystem.Extractor = []filesystem.Extractor{virtualenv.New(virtualenv.DefaultConfig())}
 	// Go extractors.
-	Go []filesystem.Extractor = []filesystem.Extractor{gobinary.New(gobinary.DefaultConfig())}
+	Go []filesystem.Extractor = []filesystem.Extractor{gobinary.New(gobinary.DefaultConfig()), gomod.New(gomod.DefaultConfig())}
 	// Ruby extractors.
 	Ruby []filesystem.Extractor = []filesystem.Extractor{gemspec.New(gemspec.DefaultConfig())}
+	// Erlang and Elixir extractors.
+	Erlang []filesystem.Extractor = []filesystem.Extractor{mixlock.New(mixlock.DefaultConfig())}
+	// Perl extractors.
+	Perl []filesystem.Extractor = []filesystem.Extractor{cpan.New(cpan.DefaultConfig())}
 	// SBOM extractors.
 	SBOM []filesystem.Extractor = []filesystem.Extractor{&spdx.Extractor{}}
 	// Dotnet (.NET) extractors.
-	Dotnet []filesystem.Extractor = []filesystem.Extractor{packageslockjson.New(packageslockjson.DefaultConfig())}
+	Dotnet []filesystem.Extractor = []filesystem.Extractor{packageslockjson.New(packageslockjson.DefaultConfig()), nuget.New(nuget.DefaultConfig())}
 	// Containers extractors.
 	Containers []filesystem.Extractor = []filesystem.Extractor{containerd.New(containerd.DefaultConfig())}
+	// Misc extractors.
+	Misc []filesystem.Extractor = []filesystem.Extractor{ocilayout.New(ocilayout.DefaultConfig()), embeddedruntime.New(embeddedruntime.DefaultConfig()), helm.New(helm.DefaultConfig()), jenkinsplugin.New(jenkinsplugin.DefaultConfig()), androidapk.New(androidapk.DefaultConfig()), gitsubmodule.New(gitsubmodule.DefaultConfig()), browserext.New(browserext.DefaultConfig()), wordpress.New(wordpress.DefaultConfig()), yoctomanifest.New(yoctomanifest.DefaultConfig()), dockercompose.New(dockercompose.DefaultConfig()), embeddedid.New(embeddedid.DefaultConfig()), bazel.New(bazel.DefaultConfig()), upx.New(upx.DefaultConfig())}
 
 	// OS extractors.
 	OS []filesystem.Extractor = []filesystem.Extractor{
@@ -79,11 +119,18 @@ var (
 		rpm.New(rpm.DefaultConfig()),
 		cos.New(cos.DefaultConfig()),
 		snap.New(snap.DefaultConfig()),
-		flatpak.New(flatpak.DefaultConfig())}
+		flatpak.New(flatpak.DefaultConfig()),
+		opkg.New(opkg.DefaultConfig())}
+	// Extractors for loose OS package files (e.g. in artifact repositories).
+	PackageFiles []filesystem.Extractor = []filesystem.Extractor{
+		debfile.New(debfile.DefaultConfig()),
+		rpmfile.New(rpmfile.DefaultConfig())}
 
 	ALLOS []filesystem.Extractor = slices.Concat(
-		// Homebrew for MacOS
-		[]filesystem.Extractor{homebrew.Extractor{}},
+		// Homebrew, .app bundles and their embedded frameworks for MacOS
+		[]filesystem.Extractor{homebrew.Extractor{}, macapps.New(macapps.DefaultConfig()), macframeworks.New(macframeworks.DefaultConfig())},
+		// Linux kernel modules
+		[]filesystem.Extractor{kmod.New(kmod.DefaultConfig())},
 		// Default OS Extractors
 		OS,
 	)
@@ -95,14 +142,20 @@ var (
 	// All extractors available from SCALIBR. These don't include the untested extractors which can be enabled manually.
 	All []filesystem.Extractor = slices.Concat(
 		Java,
+		JavaOSGi,
 		Javascript,
 		Python,
+		PythonVirtualenv,
 		Go,
 		Ruby,
+		Erlang,
+		Perl,
 		Dotnet,
 		SBOM,
 		// Default OS and Other OS
 		ALLOS,
+		PackageFiles,
+		Misc,
 		// Containers,
 	)
 
@@ -111,7 +164,6 @@ var (
 	Untested []filesystem.Extractor = []filesystem.Extractor{
 		osv.Wrapper{ExtractorName: "cpp/conan", ExtractorVersion: 0, PURLType: purl.TypeConan, Extractor: lockfile.ConanLockExtractor{}},
 		osv.Wrapper{ExtractorName: "dart/pubspec", ExtractorVersion: 0, PURLType: purl.TypePub, Extractor: lockfile.PubspecLockExtractor{}},
-		osv.Wrapper{ExtractorName: "go/gomod", ExtractorVersion: 0, PURLType: purl.TypeGolang, Extractor: lockfile.GoLockExtractor{}},
 		osv.Wrapper{ExtractorName: "java/gradle", ExtractorVersion: 0, PURLType: purl.TypeMaven, Extractor: lockfile.GradleLockExtractor{}},
 		osv.Wrapper{ExtractorName: "java/pomxml", ExtractorVersion: 0, PURLType: purl.TypeMaven, Extractor: lockfile.MavenLockExtractor{}},
 		osv.Wrapper{ExtractorName: "javascript/pnpm", ExtractorVersion: 0, PURLType: purl.TypeNPM, Extractor: lockfile.PnpmLockExtractor{}},
@@ -130,11 +182,15 @@ var (
 		"python":     Python,
 		"go":         Go,
 		"ruby":       Ruby,
+		"erlang":     Erlang,
+		"perl":       Perl,
 		"dotnet":     Dotnet,
 
-		"sbom":       SBOM,
-		"os":         OS,
-		"containers": Containers,
+		"sbom":         SBOM,
+		"os":           OS,
+		"packagefiles": PackageFiles,
+		"containers":   Containers,
+		"misc":         Misc,
 
 		// Collections.
 		"default":  Default,
@@ -173,6 +229,16 @@ func FromCapabilities(capabs *plugin.Capabilities) []filesystem.Extractor {
 	return result
 }
 
+// ExtractorInfos describes all available extractors, including the untested
+// ones, sorted by name.
+func ExtractorInfos() []*plugin.Info {
+	infos := plugin.Infos(slices.Concat(All, Untested), Default, extractorNames)
+	for _, info := range infos {
+		info.PackageClass = string(extractor.ClassOf(extractorNames[strings.ToLower(info.Name)][0]))
+	}
+	return infos
+}
+
 // ExtractorsFromNames returns a deduplicated list of extractors from a list of names.
 func ExtractorsFromNames(names []string) ([]filesystem.Extractor, error) {
 	resultMap := make(map[string]filesystem.Extractor)
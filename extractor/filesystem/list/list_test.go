@@ -42,6 +42,29 @@ func TestFromCapabilities(t *testing.T) {
 	}
 }
 
+func TestExtractorInfos(t *testing.T) {
+	infos := el.ExtractorInfos()
+	var got *plugin.Info
+	for _, info := range infos {
+		if info.Name == "python/wheelegg" {
+			got = info
+		}
+	}
+	want := &plugin.Info{
+		Name:         "python/wheelegg",
+		Requirements: &plugin.Capabilities{},
+		Default:      true,
+		PackageClass: "language",
+		Groups:       []string{"all", "default", "python"},
+	}
+	if diff := cmp.Diff(want, got, cmpopts.IgnoreFields(plugin.Info{}, "Version")); diff != "" {
+		t.Errorf("el.ExtractorInfos(): unexpected python/wheelegg info (-want +got):\n%s", diff)
+	}
+	if len(infos) != len(el.All)+len(el.Untested) {
+		t.Errorf("el.ExtractorInfos(): got %d extractors, want %d", len(infos), len(el.All)+len(el.Untested))
+	}
+}
+
 func TestExtractorsFromNames(t *testing.T) {
 	testCases := []struct {
 		desc     string
@@ -52,17 +75,17 @@ func TestExtractorsFromNames(t *testing.T) {
 		{
 			desc:     "Find all extractors of a type",
 			names:    []string{"python"},
-			wantExts: []string{"python/wheelegg", "python/requirements"},
+			wantExts: []string{"python/wheelegg", "python/requirements", "python/pipfilelock"},
 		},
 		{
 			desc:     "Case-insensitive",
 			names:    []string{"Python"},
-			wantExts: []string{"python/wheelegg", "python/requirements"},
+			wantExts: []string{"python/wheelegg", "python/requirements", "python/pipfilelock"},
 		},
 		{
 			desc:     "Remove duplicates",
 			names:    []string{"python", "python"},
-			wantExts: []string{"python/wheelegg", "python/requirements"},
+			wantExts: []string{"python/wheelegg", "python/requirements", "python/pipfilelock"},
 		},
 		{
 			desc:     "Nonexistent plugin",
@@ -0,0 +1,279 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package androidapk
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strconv"
+	"unicode/utf16"
+)
+
+// Chunk types of the Android binary XML format, see
+// frameworks/base/libs/androidfw/include/androidfw/ResourceTypes.h in AOSP.
+const (
+	chunkStringPool   = 0x0001
+	chunkXML          = 0x0003
+	chunkResourceMap  = 0x0180
+	chunkStartElement = 0x0102
+)
+
+// Types of typed attribute values.
+const (
+	typeString = 0x03
+	typeIntDec = 0x10
+	typeIntHex = 0x11
+)
+
+// Resource IDs of the android: attributes we're interested in. Shrinkers can
+// strip the attribute names so they're matched by ID if the name is missing.
+const (
+	resVersionCode = 0x0101021b
+	resVersionName = 0x0101021c
+)
+
+const (
+	utf8Flag  = 1 << 8
+	noIndex   = 0xffffffff
+	chunkHeaderSize = 8
+)
+
+var errNotBinaryXML = errors.New("not an Android binary XML file")
+
+// manifest contains the attributes of the <manifest> element of an
+// AndroidManifest.xml.
+type manifest struct {
+	pkg         string
+	versionName string
+	versionCode string
+}
+
+// parseManifest decodes the <manifest> element of a binary AndroidManifest.xml.
+// Attribute values that reference resources (e.g. versionName="@string/ver")
+// are left empty since resolving them needs resources.arsc.
+func parseManifest(b []byte) (*manifest, error) {
+	if len(b) < chunkHeaderSize || binary.LittleEndian.Uint16(b) != chunkXML {
+		return nil, errNotBinaryXML
+	}
+	size := int(binary.LittleEndian.Uint32(b[4:]))
+	if size < chunkHeaderSize || size > len(b) {
+		return nil, fmt.Errorf("invalid XML chunk size %d for file size %d", size, len(b))
+	}
+	b = b[:size]
+
+	var pool []string
+	var resourceIDs []uint32
+	off := int(binary.LittleEndian.Uint16(b[2:]))
+	for off+chunkHeaderSize <= len(b) {
+		typ := binary.LittleEndian.Uint16(b[off:])
+		chunkLen := int(binary.LittleEndian.Uint32(b[off+4:]))
+		if chunkLen < chunkHeaderSize || off+chunkLen > len(b) {
+			return nil, fmt.Errorf("invalid chunk size %d at offset %d", chunkLen, off)
+		}
+		chunk := b[off : off+chunkLen]
+		off += chunkLen
+
+		switch typ {
+		case chunkStringPool:
+			var err error
+			if pool, err = parseStringPool(chunk); err != nil {
+				return nil, err
+			}
+		case chunkResourceMap:
+			headerLen := int(binary.LittleEndian.Uint16(chunk[2:]))
+			for i := headerLen; i+4 <= len(chunk); i += 4 {
+				resourceIDs = append(resourceIDs, binary.LittleEndian.Uint32(chunk[i:]))
+			}
+		case chunkStartElement:
+			e, err := parseStartElement(chunk, pool, resourceIDs)
+			if err != nil {
+				return nil, err
+			}
+			// The first element of a manifest is always <manifest>.
+			if e.name != "manifest" {
+				return nil, fmt.Errorf("root element is <%s>, want <manifest>", e.name)
+			}
+			return e.manifest(), nil
+		}
+	}
+	return nil, errors.New("no <manifest> element found")
+}
+
+// parseStringPool returns the strings of a string pool chunk.
+func parseStringPool(chunk []byte) ([]string, error) {
+	if len(chunk) < 28 {
+		return nil, errors.New("string pool header too short")
+	}
+	headerLen := int(binary.LittleEndian.Uint16(chunk[2:]))
+	count := int(binary.LittleEndian.Uint32(chunk[8:]))
+	flags := binary.LittleEndian.Uint32(chunk[16:])
+	stringsStart := int(binary.LittleEndian.Uint32(chunk[20:]))
+	if headerLen+4*count > len(chunk) || stringsStart > len(chunk) {
+		return nil, fmt.Errorf("string pool with %d strings exceeds its chunk", count)
+	}
+
+	pool := make([]string, count)
+	for i := range pool {
+		off := stringsStart + int(binary.LittleEndian.Uint32(chunk[headerLen+4*i:]))
+		if off >= len(chunk) {
+			return nil, fmt.Errorf("string %d out of bounds", i)
+		}
+		var s string
+		var err error
+		if flags&utf8Flag != 0 {
+			s, err = decodeUTF8String(chunk[off:])
+		} else {
+			s, err = decodeUTF16String(chunk[off:])
+		}
+		if err != nil {
+			return nil, fmt.Errorf("string %d: %w", i, err)
+		}
+		pool[i] = s
+	}
+	return pool, nil
+}
+
+// decodeUTF8String decodes a string that's prefixed with its length in UTF-16
+// code units and in bytes. Both lengths take one byte, or two if the high bit
+// of the first byte is set.
+func decodeUTF8String(b []byte) (string, error) {
+	_, n, err := decodeLength8(b)
+	if err != nil {
+		return "", err
+	}
+	b = b[n:]
+	byteLen, n, err := decodeLength8(b)
+	if err != nil {
+		return "", err
+	}
+	b = b[n:]
+	if byteLen > len(b) {
+		return "", errors.New("string exceeds string pool")
+	}
+	return string(b[:byteLen]), nil
+}
+
+func decodeLength8(b []byte) (length int, n int, err error) {
+	if len(b) < 1 {
+		return 0, 0, errors.New("string length out of bounds")
+	}
+	if b[0]&0x80 == 0 {
+		return int(b[0]), 1, nil
+	}
+	if len(b) < 2 {
+		return 0, 0, errors.New("string length out of bounds")
+	}
+	return int(b[0]&0x7f)<<8 | int(b[1]), 2, nil
+}
+
+// decodeUTF16String decodes a string that's prefixed with its length in UTF-16
+// code units. The length takes one code unit, or two if the high bit of the
+// first one is set.
+func decodeUTF16String(b []byte) (string, error) {
+	if len(b) < 2 {
+		return "", errors.New("string length out of bounds")
+	}
+	length := int(binary.LittleEndian.Uint16(b))
+	b = b[2:]
+	if length&0x8000 != 0 {
+		if len(b) < 2 {
+			return "", errors.New("string length out of bounds")
+		}
+		length = (length&0x7fff)<<16 | int(binary.LittleEndian.Uint16(b))
+		b = b[2:]
+	}
+	if 2*length > len(b) {
+		return "", errors.New("string exceeds string pool")
+	}
+	units := make([]uint16, length)
+	for i := range units {
+		units[i] = binary.LittleEndian.Uint16(b[2*i:])
+	}
+	return string(utf16.Decode(units)), nil
+}
+
+type attribute struct {
+	name       string
+	resourceID uint32
+	value      string
+}
+
+type element struct {
+	name  string
+	attrs []attribute
+}
+
+// parseStartElement decodes a start element chunk. The chunk starts with a
+// 16 byte node header followed by the element's name and attribute layout.
+func parseStartElement(chunk []byte, pool []string, resourceIDs []uint32) (*element, error) {
+	headerLen := int(binary.LittleEndian.Uint16(chunk[2:]))
+	if headerLen+20 > len(chunk) {
+		return nil, errors.New("start element chunk too short")
+	}
+	ext := chunk[headerLen:]
+	e := &element{name: poolString(pool, binary.LittleEndian.Uint32(ext[4:]))}
+	attrStart := int(binary.LittleEndian.Uint16(ext[8:]))
+	attrSize := int(binary.LittleEndian.Uint16(ext[10:]))
+	attrCount := int(binary.LittleEndian.Uint16(ext[12:]))
+	if attrSize < 20 || attrStart+attrCount*attrSize > len(ext) {
+		return nil, fmt.Errorf("attributes of <%s> exceed their chunk", e.name)
+	}
+
+	for i := 0; i < attrCount; i++ {
+		a := ext[attrStart+i*attrSize:]
+		nameIdx := binary.LittleEndian.Uint32(a[4:])
+		rawValue := binary.LittleEndian.Uint32(a[8:])
+		dataType := a[15]
+		data := binary.LittleEndian.Uint32(a[16:])
+
+		attr := attribute{name: poolString(pool, nameIdx)}
+		if int64(nameIdx) < int64(len(resourceIDs)) {
+			attr.resourceID = resourceIDs[nameIdx]
+		}
+		switch {
+		case dataType == typeString:
+			attr.value = poolString(pool, data)
+		case rawValue != noIndex:
+			attr.value = poolString(pool, rawValue)
+		case dataType == typeIntDec || dataType == typeIntHex:
+			attr.value = strconv.FormatInt(int64(int32(data)), 10)
+		}
+		e.attrs = append(e.attrs, attr)
+	}
+	return e, nil
+}
+
+func (e *element) manifest() *manifest {
+	m := &manifest{}
+	for _, a := range e.attrs {
+		switch {
+		case a.name == "package" && a.resourceID == 0:
+			m.pkg = a.value
+		case a.resourceID == resVersionName || a.name == "versionName":
+			m.versionName = a.value
+		case a.resourceID == resVersionCode || a.name == "versionCode":
+			m.versionCode = a.value
+		}
+	}
+	return m
+}
+
+func poolString(pool []string, idx uint32) string {
+	if int64(idx) >= int64(len(pool)) {
+		return ""
+	}
+	return pool[idx]
+}
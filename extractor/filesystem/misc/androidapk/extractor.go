@@ -0,0 +1,268 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package androidapk extracts the package name and version of Android apps
+// from the AndroidManifest.xml inside of APK files.
+package androidapk
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"path/filepath"
+	"strings"
+
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem"
+	"github.com/google/osv-scalibr/extractor/filesystem/internal/units"
+	"github.com/google/osv-scalibr/plugin"
+	"github.com/google/osv-scalibr/purl"
+	"github.com/google/osv-scalibr/stats"
+)
+
+const (
+	// Name is the unique name of this extractor.
+	Name = "misc/androidapk"
+
+	// defaultMaxFileSizeBytes is the maximum file size an extractor will unmarshal.
+	// If Extract gets a bigger file, it will return an error.
+	defaultMaxFileSizeBytes = 500 * units.MiB
+
+	// maxManifestSizeBytes caps the size of the decompressed AndroidManifest.xml.
+	maxManifestSizeBytes = 10 * units.MiB
+
+	manifestName = "AndroidManifest.xml"
+)
+
+// Config is the configuration for the Extractor.
+type Config struct {
+	// Stats is a stats collector for reporting metrics.
+	Stats stats.Collector
+	// MaxFileSizeBytes is the maximum file size this extractor will unmarshal. If
+	// `FileRequired` gets a bigger file, it will return false,
+	MaxFileSizeBytes int64
+}
+
+// DefaultConfig returns the default configuration for the extractor.
+func DefaultConfig() Config {
+	return Config{
+		MaxFileSizeBytes: defaultMaxFileSizeBytes,
+		Stats:            nil,
+	}
+}
+
+// Extractor extracts Android apps from APK files.
+type Extractor struct {
+	stats            stats.Collector
+	maxFileSizeBytes int64
+}
+
+// New returns an Android APK extractor.
+//
+// For most use cases, initialize with:
+// ```
+// e := New(DefaultConfig())
+// ```
+func New(cfg Config) *Extractor {
+	return &Extractor{
+		stats:            cfg.Stats,
+		maxFileSizeBytes: cfg.MaxFileSizeBytes,
+	}
+}
+
+// Config returns the configuration of the extractor.
+func (e Extractor) Config() Config {
+	return Config{
+		Stats:            e.stats,
+		MaxFileSizeBytes: e.maxFileSizeBytes,
+	}
+}
+
+// Name of the extractor.
+func (e Extractor) Name() string { return Name }
+
+// Version of the extractor.
+func (e Extractor) Version() int { return 0 }
+
+// Requirements of the extractor. APKs are read into memory if the scanned
+// filesystem doesn't support random access.
+func (e Extractor) Requirements() *plugin.Capabilities {
+	return &plugin.Capabilities{Memory: plugin.MemoryFileSize}
+}
+
+// RequiredFileExtensions returns the extension of the APK files the extractor requires.
+func (e Extractor) RequiredFileExtensions() []string { return []string{".apk"} }
+
+// FileRequired returns true if the specified file has an .apk extension. The
+// zip magic bytes are checked during extraction.
+func (e Extractor) FileRequired(path string, fileinfo fs.FileInfo) bool {
+	return e.fileRequired(path, fileinfo, nil)
+}
+
+// FileRequiredWithFS returns true if the specified file has an .apk extension
+// and starts with the zip magic bytes.
+func (e Extractor) FileRequiredWithFS(path string, fileinfo fs.FileInfo, file *filesystem.PeekableFile) bool {
+	return e.fileRequired(path, fileinfo, file)
+}
+
+func (e Extractor) fileRequired(path string, fileinfo fs.FileInfo, file *filesystem.PeekableFile) bool {
+	if strings.ToLower(filepath.Ext(path)) != ".apk" {
+		return false
+	}
+	if !fileinfo.Mode().IsRegular() {
+		return false
+	}
+
+	if e.maxFileSizeBytes > 0 && fileinfo.Size() > e.maxFileSizeBytes {
+		e.reportFileRequired(path, fileinfo.Size(), stats.FileRequiredResultSizeLimitExceeded)
+		return false
+	}
+
+	if file != nil {
+		if t, err := file.FileType(); err != nil || t != filesystem.FileTypeZip {
+			return false
+		}
+	}
+
+	e.reportFileRequired(path, fileinfo.Size(), stats.FileRequiredResultOK)
+	return true
+}
+
+func (e Extractor) reportFileRequired(path string, fileSizeBytes int64, result stats.FileRequiredResult) {
+	if e.stats == nil {
+		return
+	}
+	e.stats.AfterFileRequired(e.Name(), &stats.FileRequiredStats{
+		Path:          path,
+		Result:        result,
+		FileSizeBytes: fileSizeBytes,
+	})
+}
+
+// Extract returns the Android app described by the manifest of an APK.
+func (e Extractor) Extract(ctx context.Context, input *filesystem.ScanInput) ([]*extractor.Inventory, error) {
+	inventory, err := e.extractFromInput(input)
+	if e.stats != nil {
+		var fileSizeBytes int64
+		if input.Info != nil {
+			fileSizeBytes = input.Info.Size()
+		}
+		e.stats.AfterFileExtracted(e.Name(), &stats.FileExtractedStats{
+			Path:          input.Path,
+			Result:        filesystem.ExtractorErrorToFileExtractedResult(err),
+			FileSizeBytes: fileSizeBytes,
+		})
+	}
+	return inventory, err
+}
+
+func (e Extractor) extractFromInput(input *filesystem.ScanInput) ([]*extractor.Inventory, error) {
+	zr, err := e.openZip(input)
+	if err != nil {
+		return nil, err
+	}
+	b, err := readManifest(zr)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %q: %w", e.Name(), input.Path, err)
+	}
+	m, err := parseManifest(b)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to parse %s of %q: %w: %w", e.Name(), manifestName, input.Path, extractor.ErrMalformedInput, err)
+	}
+
+	// versionName is meant for display and optional, versionCode is what the
+	// Play Store uses to order releases.
+	version := m.versionName
+	if version == "" {
+		version = m.versionCode
+	}
+	if m.pkg == "" || version == "" {
+		return nil, fmt.Errorf("%s: %s of %q lacks package name or version (package: %q, version: %q): %w",
+			e.Name(), manifestName, input.Path, m.pkg, version, extractor.ErrMalformedInput)
+	}
+	return []*extractor.Inventory{{
+		Name:    m.pkg,
+		Version: version,
+		Metadata: &Metadata{
+			VersionName: m.versionName,
+			VersionCode: m.versionCode,
+		},
+		Locations: []string{input.Path},
+	}}, nil
+}
+
+// openZip opens the APK without reading all of it into memory if the file
+// supports random access.
+func (e Extractor) openZip(input *filesystem.ScanInput) (*zip.Reader, error) {
+	var size int64
+	if input.Info != nil {
+		size = input.Info.Size()
+	}
+	var r io.ReaderAt
+	if sr := input.SectionReader(0, size); sr != nil && size > 0 {
+		r = sr
+	} else {
+		b, err := io.ReadAll(input.Reader)
+		if err != nil {
+			return nil, fmt.Errorf("%s: failed to read %q: %w", e.Name(), input.Path, err)
+		}
+		r = bytes.NewReader(b)
+		size = int64(len(b))
+	}
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, fmt.Errorf("%s: invalid archive at %q: %w: %w", e.Name(), input.Path, extractor.ErrMalformedInput, err)
+	}
+	return zr, nil
+}
+
+func readManifest(zr *zip.Reader) ([]byte, error) {
+	for _, f := range zr.File {
+		if f.Name != manifestName {
+			continue
+		}
+		if f.UncompressedSize64 > uint64(maxManifestSizeBytes) {
+			return nil, fmt.Errorf("%s too large: %d bytes", manifestName, f.UncompressedSize64)
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return nil, fmt.Errorf("failed to open %s: %w: %w", manifestName, extractor.ErrMalformedInput, err)
+		}
+		defer rc.Close()
+		b, err := io.ReadAll(io.LimitReader(rc, maxManifestSizeBytes))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w: %w", manifestName, extractor.ErrMalformedInput, err)
+		}
+		return b, nil
+	}
+	return nil, fmt.Errorf("no %s found: %w", manifestName, extractor.ErrMalformedInput)
+}
+
+// ToPURL converts an inventory created by this extractor into a PURL.
+func (e Extractor) ToPURL(i *extractor.Inventory) (*purl.PackageURL, error) {
+	return &purl.PackageURL{
+		Type:    purl.TypeAndroid,
+		Name:    i.Name,
+		Version: i.Version,
+	}, nil
+}
+
+// ToCPEs is not applicable as this extractor does not infer CPEs from the Inventory.
+func (e Extractor) ToCPEs(i *extractor.Inventory) ([]string, error) { return []string{}, nil }
+
+// Ecosystem returns no ecosystem since OSV does not support Android apps yet.
+func (Extractor) Ecosystem(i *extractor.Inventory) (string, error) { return "", nil }
@@ -0,0 +1,301 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package androidapk_test
+
+import (
+	"context"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem"
+	"github.com/google/osv-scalibr/extractor/filesystem/internal/units"
+	"github.com/google/osv-scalibr/extractor/filesystem/misc/androidapk"
+	scalibrfs "github.com/google/osv-scalibr/fs"
+	"github.com/google/osv-scalibr/purl"
+	"github.com/google/osv-scalibr/stats"
+	"github.com/google/osv-scalibr/testing/fakefs"
+	"github.com/google/osv-scalibr/testing/testcollector"
+)
+
+func TestFileRequired(t *testing.T) {
+	tests := []struct {
+		name             string
+		path             string
+		fileSizeBytes    int64
+		maxFileSizeBytes int64
+		wantRequired     bool
+		wantResultMetric stats.FileRequiredResult
+	}{
+		{
+			name:             "apk file",
+			path:             "sdcard/Download/app-release.apk",
+			wantRequired:     true,
+			wantResultMetric: stats.FileRequiredResultOK,
+		}, {
+			name:             "uppercase extension",
+			path:             "tmp/APP.APK",
+			wantRequired:     true,
+			wantResultMetric: stats.FileRequiredResultOK,
+		}, {
+			name:         "app bundle",
+			path:         "tmp/app-release.aab",
+			wantRequired: false,
+		}, {
+			name:         "apk suffix without extension",
+			path:         "tmp/myapk",
+			wantRequired: false,
+		}, {
+			name:             "file size limit exceeded",
+			path:             "tmp/app.apk",
+			fileSizeBytes:    1000 * units.KiB,
+			maxFileSizeBytes: 100 * units.KiB,
+			wantRequired:     false,
+			wantResultMetric: stats.FileRequiredResultSizeLimitExceeded,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			collector := testcollector.New()
+			var e filesystem.Extractor = androidapk.New(androidapk.Config{
+				Stats:            collector,
+				MaxFileSizeBytes: tt.maxFileSizeBytes,
+			})
+
+			fileSizeBytes := tt.fileSizeBytes
+			if fileSizeBytes == 0 {
+				fileSizeBytes = 1000
+			}
+			isRequired := e.FileRequired(tt.path, fakefs.FakeFileInfo{
+				FileName: filepath.Base(tt.path),
+				FileMode: fs.ModePerm,
+				FileSize: fileSizeBytes,
+			})
+			if isRequired != tt.wantRequired {
+				t.Fatalf("FileRequired(%s): got %v, want %v", tt.path, isRequired, tt.wantRequired)
+			}
+
+			gotResultMetric := collector.FileRequiredResult(tt.path)
+			if tt.wantResultMetric != "" && gotResultMetric != tt.wantResultMetric {
+				t.Errorf("FileRequired(%s) recorded result metric %v, want result metric %v", tt.path, gotResultMetric, tt.wantResultMetric)
+			}
+		})
+	}
+}
+
+func TestFileRequiredWithFS(t *testing.T) {
+	tests := []struct {
+		name         string
+		path         string
+		wantRequired bool
+	}{
+		{
+			name:         "valid apk",
+			path:         "app.apk",
+			wantRequired: true,
+		}, {
+			name:         "wrong magic bytes",
+			path:         "notzip.apk",
+			wantRequired: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := androidapk.New(androidapk.DefaultConfig())
+			fsys := scalibrfs.DirFS("testdata")
+			info, err := fs.Stat(fsys, tt.path)
+			if err != nil {
+				t.Fatalf("fs.Stat(%s): %v", tt.path, err)
+			}
+			file := filesystem.NewPeekableFile(fsys, tt.path)
+			defer file.Close()
+
+			if got := e.FileRequiredWithFS(tt.path, info, file); got != tt.wantRequired {
+				t.Errorf("FileRequiredWithFS(%s): got %v, want %v", tt.path, got, tt.wantRequired)
+			}
+		})
+	}
+}
+
+func TestExtract(t *testing.T) {
+	tests := []struct {
+		name string
+		path string
+		// Hides the io.ReaderAt implementation of the file.
+		sequentialReader bool
+		wantInventory    []*extractor.Inventory
+		wantErr          error
+		wantResultMetric stats.FileExtractedResult
+	}{
+		{
+			name: "UTF-16 string pool",
+			path: "app.apk",
+			wantInventory: []*extractor.Inventory{
+				{
+					Name:    "com.example.app",
+					Version: "1.4.2",
+					Metadata: &androidapk.Metadata{
+						VersionName: "1.4.2",
+						VersionCode: "42",
+					},
+					Locations: []string{"app.apk"},
+				},
+			},
+			wantResultMetric: stats.FileExtractedResultSuccess,
+		}, {
+			name:             "reader without random access",
+			path:             "app.apk",
+			sequentialReader: true,
+			wantInventory: []*extractor.Inventory{
+				{
+					Name:    "com.example.app",
+					Version: "1.4.2",
+					Metadata: &androidapk.Metadata{
+						VersionName: "1.4.2",
+						VersionCode: "42",
+					},
+					Locations: []string{"app.apk"},
+				},
+			},
+			wantResultMetric: stats.FileExtractedResultSuccess,
+		}, {
+			name: "UTF-8 string pool with stripped attribute names",
+			path: "utf8.apk",
+			wantInventory: []*extractor.Inventory{
+				{
+					Name:    "org.example.utf8",
+					Version: "2.0-beta",
+					Metadata: &androidapk.Metadata{
+						VersionName: "2.0-beta",
+						VersionCode: "16",
+					},
+					Locations: []string{"utf8.apk"},
+				},
+			},
+			wantResultMetric: stats.FileExtractedResultSuccess,
+		}, {
+			name: "versionName is a resource reference",
+			path: "refversion.apk",
+			wantInventory: []*extractor.Inventory{
+				{
+					Name:    "com.example.ref",
+					Version: "7",
+					Metadata: &androidapk.Metadata{
+						VersionCode: "7",
+					},
+					Locations: []string{"refversion.apk"},
+				},
+			},
+			wantResultMetric: stats.FileExtractedResultSuccess,
+		}, {
+			name:             "missing package name",
+			path:             "nopackage.apk",
+			wantErr:          extractor.ErrMalformedInput,
+			wantResultMetric: stats.FileExtractedResultErrorMalformedInput,
+		}, {
+			name:             "no manifest",
+			path:             "nomanifest.apk",
+			wantErr:          extractor.ErrMalformedInput,
+			wantResultMetric: stats.FileExtractedResultErrorMalformedInput,
+		}, {
+			name:             "plain text manifest",
+			path:             "textmanifest.apk",
+			wantErr:          extractor.ErrMalformedInput,
+			wantResultMetric: stats.FileExtractedResultErrorMalformedInput,
+		}, {
+			name:             "not a zip file",
+			path:             "notzip.apk",
+			wantErr:          extractor.ErrMalformedInput,
+			wantResultMetric: stats.FileExtractedResultErrorMalformedInput,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			collector := testcollector.New()
+			var e filesystem.Extractor = androidapk.New(androidapk.Config{
+				Stats:            collector,
+				MaxFileSizeBytes: 1 * units.MiB,
+			})
+
+			d := "testdata"
+			f, err := os.Open(filepath.Join(d, tt.path))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer f.Close()
+			info, err := f.Stat()
+			if err != nil {
+				t.Fatalf("Stat(): %v", err)
+			}
+			var r io.Reader = f
+			if tt.sequentialReader {
+				r = struct{ io.Reader }{f}
+			}
+
+			input := &filesystem.ScanInput{
+				FS:     scalibrfs.DirFS(d),
+				Path:   tt.path,
+				Reader: r,
+				Root:   d,
+				Info:   info,
+			}
+			got, err := e.Extract(context.Background(), input)
+			if !cmp.Equal(err, tt.wantErr, cmpopts.EquateErrors()) {
+				t.Fatalf("Extract(%+v) error: got %v, want %v\n", tt.path, err, tt.wantErr)
+			}
+
+			if diff := cmp.Diff(tt.wantInventory, got); diff != "" {
+				t.Errorf("Extract(%s) (-want +got):\n%s", tt.path, diff)
+			}
+
+			gotResultMetric := collector.FileExtractedResult(tt.path)
+			if gotResultMetric != tt.wantResultMetric {
+				t.Errorf("Extract(%s) recorded result metric %v, want result metric %v", tt.path, gotResultMetric, tt.wantResultMetric)
+			}
+		})
+	}
+}
+
+func TestToPURL(t *testing.T) {
+	e := androidapk.New(androidapk.DefaultConfig())
+	i := &extractor.Inventory{
+		Name:    "com.example.App",
+		Version: "1.4.2",
+		Metadata: &androidapk.Metadata{
+			VersionName: "1.4.2",
+			VersionCode: "42",
+		},
+	}
+	want := &purl.PackageURL{
+		Type:    purl.TypeAndroid,
+		Name:    "com.example.App",
+		Version: "1.4.2",
+	}
+	got, err := e.ToPURL(i)
+	if err != nil {
+		t.Fatalf("ToPURL(%v): %v", i, err)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ToPURL(%v) (-want +got):\n%s", i, diff)
+	}
+}
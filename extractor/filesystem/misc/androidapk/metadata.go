@@ -0,0 +1,24 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package androidapk
+
+// Metadata holds parsing information for an Android app.
+type Metadata struct {
+	// The user-visible version, e.g. "1.4.2". Empty if the manifest references
+	// a string resource for it.
+	VersionName string
+	// The internal version number, e.g. "42".
+	VersionCode string
+}
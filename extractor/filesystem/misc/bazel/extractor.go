@@ -0,0 +1,323 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package bazel extracts the external dependencies declared with bazel_dep in
+// the MODULE.bazel files of Bazel modules.
+package bazel
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem"
+	"github.com/google/osv-scalibr/extractor/filesystem/internal/units"
+	"github.com/google/osv-scalibr/plugin"
+	"github.com/google/osv-scalibr/purl"
+	"github.com/google/osv-scalibr/stats"
+)
+
+const (
+	// Name is the unique name of this extractor.
+	Name = "misc/bazel"
+
+	moduleFileName = "MODULE.bazel"
+
+	// defaultMaxFileSizeBytes is the maximum file size an extractor will unmarshal.
+	// If Extract gets a bigger file, it will return an error.
+	defaultMaxFileSizeBytes = 1 * units.MiB
+)
+
+// MODULE.bazel files are written in Starlark. Instead of evaluating the file,
+// the bazel_dep calls are located and their keyword arguments are read if
+// they're literals, e.g.
+//
+//	bazel_dep(name = "rules_go", version = "0.46.0", repo_name = "io_bazel_rules_go")
+//	bazel_dep(name = "gazelle", version = "0.35.0", dev_dependency = True)
+//
+// The start of a bazel_dep call, not preceded by an identifier character or
+// a "." so that e.g. my_bazel_dep( and ext.bazel_dep( don't match.
+var reBazelDep = regexp.MustCompile(`(?:^|[^\w.])bazel_dep\s*\(`)
+
+// Config is the configuration for the Extractor.
+type Config struct {
+	// Stats is a stats collector for reporting metrics.
+	Stats stats.Collector
+	// MaxFileSizeBytes is the maximum file size this extractor will unmarshal. If
+	// `FileRequired` gets a bigger file, it will return false.
+	MaxFileSizeBytes int64
+}
+
+// DefaultConfig returns the default configuration for the extractor.
+func DefaultConfig() Config {
+	return Config{
+		Stats:            nil,
+		MaxFileSizeBytes: defaultMaxFileSizeBytes,
+	}
+}
+
+// Extractor extracts the bazel_dep dependencies of MODULE.bazel files.
+type Extractor struct {
+	stats            stats.Collector
+	maxFileSizeBytes int64
+}
+
+// New returns a Bazel module extractor.
+//
+// For most use cases, initialize with:
+// ```
+// e := New(DefaultConfig())
+// ```
+func New(cfg Config) *Extractor {
+	return &Extractor{
+		stats:            cfg.Stats,
+		maxFileSizeBytes: cfg.MaxFileSizeBytes,
+	}
+}
+
+// Config returns the configuration of the extractor.
+func (e Extractor) Config() Config {
+	return Config{
+		Stats:            e.stats,
+		MaxFileSizeBytes: e.maxFileSizeBytes,
+	}
+}
+
+// Name of the extractor.
+func (e Extractor) Name() string { return Name }
+
+// Version of the extractor.
+func (e Extractor) Version() int { return 0 }
+
+// Requirements of the extractor.
+func (e Extractor) Requirements() *plugin.Capabilities { return &plugin.Capabilities{} }
+
+// RequiredFileNames returns the name of the files the extractor requires.
+func (e Extractor) RequiredFileNames() []string { return []string{moduleFileName} }
+
+// FileRequired returns true if the specified file is a MODULE.bazel file.
+func (e Extractor) FileRequired(path string, fileinfo fs.FileInfo) bool {
+	if filepath.Base(path) != moduleFileName {
+		return false
+	}
+
+	if e.maxFileSizeBytes > 0 && fileinfo.Size() > e.maxFileSizeBytes {
+		e.reportFileRequired(path, fileinfo.Size(), stats.FileRequiredResultSizeLimitExceeded)
+		return false
+	}
+
+	e.reportFileRequired(path, fileinfo.Size(), stats.FileRequiredResultOK)
+	return true
+}
+
+func (e Extractor) reportFileRequired(path string, fileSizeBytes int64, result stats.FileRequiredResult) {
+	if e.stats == nil {
+		return
+	}
+	e.stats.AfterFileRequired(e.Name(), &stats.FileRequiredStats{
+		Path:          path,
+		Result:        result,
+		FileSizeBytes: fileSizeBytes,
+	})
+}
+
+// Extract extracts the dependencies from the MODULE.bazel passed through the
+// scan input.
+func (e Extractor) Extract(ctx context.Context, input *filesystem.ScanInput) ([]*extractor.Inventory, error) {
+	inventory, err := e.extractFromInput(input)
+	if e.stats != nil {
+		var fileSizeBytes int64
+		if input.Info != nil {
+			fileSizeBytes = input.Info.Size()
+		}
+		e.stats.AfterFileExtracted(e.Name(), &stats.FileExtractedStats{
+			Path:          input.Path,
+			Result:        filesystem.ExtractorErrorToFileExtractedResult(err),
+			FileSizeBytes: fileSizeBytes,
+		})
+	}
+	return inventory, err
+}
+
+func (e Extractor) extractFromInput(input *filesystem.ScanInput) ([]*extractor.Inventory, error) {
+	content, err := io.ReadAll(input.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %q: %w", input.Path, err)
+	}
+	deps, err := parse(string(content))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %q: %w", input.Path, err)
+	}
+	for _, d := range deps {
+		d.Locations = []string{input.Path}
+	}
+	return deps, nil
+}
+
+// parse returns the dependencies declared by the bazel_dep calls in the
+// MODULE.bazel content. Calls whose name isn't a string literal are skipped.
+func parse(content string) ([]*extractor.Inventory, error) {
+	src, err := stripComments(content)
+	if err != nil {
+		return nil, err
+	}
+	var res []*extractor.Inventory
+	for _, loc := range reBazelDep.FindAllStringIndex(src, -1) {
+		args, err := callArgs(src, loc[1])
+		if err != nil {
+			return nil, err
+		}
+		kwargs := map[string]string{}
+		for _, arg := range args {
+			key, value, found := strings.Cut(arg, "=")
+			if !found {
+				continue
+			}
+			kwargs[strings.TrimSpace(key)] = strings.TrimSpace(value)
+		}
+		name, ok := stringLiteral(kwargs["name"])
+		if !ok || name == "" {
+			continue
+		}
+		// Dependencies without a version get it from an override.
+		version, _ := stringLiteral(kwargs["version"])
+		repoName, _ := stringLiteral(kwargs["repo_name"])
+		res = append(res, &extractor.Inventory{
+			Name:    name,
+			Version: version,
+			Metadata: &Metadata{
+				RepoName:      repoName,
+				DevDependency: kwargs["dev_dependency"] == "True",
+			},
+		})
+	}
+	return res, nil
+}
+
+// stripComments replaces the comments and triple-quoted strings such as
+// docstrings in the Starlark source with spaces so that calls mentioned in them
+// are ignored. Returns an error for unterminated strings.
+func stripComments(src string) (string, error) {
+	b := []byte(src)
+	var quote byte
+	for i := 0; i < len(b); i++ {
+		c := b[i]
+		switch {
+		case quote == 0 && (strings.HasPrefix(src[i:], `"""`) || strings.HasPrefix(src[i:], "'''")):
+			// Triple-quoted strings can span several lines.
+			end := strings.Index(src[i+3:], src[i:i+3])
+			if end < 0 {
+				return "", fmt.Errorf("%w: unterminated string", extractor.ErrMalformedInput)
+			}
+			end += i + 6
+			for ; i < end; i++ {
+				if b[i] != '\n' {
+					b[i] = ' '
+				}
+			}
+			i--
+		case quote != 0:
+			switch c {
+			case '\\':
+				i++
+			case quote:
+				quote = 0
+			case '\n':
+				return "", fmt.Errorf("%w: unterminated string", extractor.ErrMalformedInput)
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '#':
+			for ; i < len(b) && b[i] != '\n'; i++ {
+				b[i] = ' '
+			}
+		}
+	}
+	if quote != 0 {
+		return "", fmt.Errorf("%w: unterminated string", extractor.ErrMalformedInput)
+	}
+	return string(b), nil
+}
+
+// callArgs returns the arguments of the call whose argument list starts at
+// start, i.e. after the opening parenthesis, split at the top-level commas.
+func callArgs(src string, start int) ([]string, error) {
+	var args []string
+	var quote byte
+	depth := 0
+	argStart := start
+	for i := start; i < len(src); i++ {
+		c := src[i]
+		if quote != 0 {
+			switch c {
+			case '\\':
+				i++
+			case quote:
+				quote = 0
+			}
+			continue
+		}
+		switch c {
+		case '"', '\'':
+			quote = c
+		case '(', '[', '{':
+			depth++
+		case ')', ']', '}':
+			if depth > 0 {
+				depth--
+				continue
+			}
+			if arg := strings.TrimSpace(src[argStart:i]); arg != "" {
+				args = append(args, arg)
+			}
+			return args, nil
+		case ',':
+			if depth == 0 {
+				args = append(args, strings.TrimSpace(src[argStart:i]))
+				argStart = i + 1
+			}
+		}
+	}
+	return nil, fmt.Errorf("%w: unterminated bazel_dep call", extractor.ErrMalformedInput)
+}
+
+// stringLiteral returns the value of a single or double quoted Starlark string
+// literal. ok is false if v is not a string literal, e.g. a variable.
+func stringLiteral(v string) (s string, ok bool) {
+	if len(v) < 2 || (v[0] != '"' && v[0] != '\'') || v[len(v)-1] != v[0] {
+		return "", false
+	}
+	return v[1 : len(v)-1], true
+}
+
+// ToPURL converts an inventory created by this extractor into a PURL.
+func (e Extractor) ToPURL(i *extractor.Inventory) (*purl.PackageURL, error) {
+	return &purl.PackageURL{
+		Type:      purl.TypeGeneric,
+		Namespace: "bazel",
+		Name:      i.Name,
+		Version:   i.Version,
+	}, nil
+}
+
+// ToCPEs is not applicable as this extractor does not infer CPEs from the Inventory.
+func (e Extractor) ToCPEs(i *extractor.Inventory) ([]string, error) { return []string{}, nil }
+
+// Ecosystem returns no ecosystem since OSV does not support Bazel modules.
+func (Extractor) Ecosystem(i *extractor.Inventory) (string, error) { return "", nil }
@@ -0,0 +1,243 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bazel_test
+
+import (
+	"context"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem"
+	"github.com/google/osv-scalibr/extractor/filesystem/internal/units"
+	"github.com/google/osv-scalibr/extractor/filesystem/misc/bazel"
+	scalibrfs "github.com/google/osv-scalibr/fs"
+	"github.com/google/osv-scalibr/purl"
+	"github.com/google/osv-scalibr/stats"
+	"github.com/google/osv-scalibr/testing/fakefs"
+	"github.com/google/osv-scalibr/testing/testcollector"
+)
+
+func TestFileRequired(t *testing.T) {
+	tests := []struct {
+		name             string
+		path             string
+		fileSizeBytes    int64
+		maxFileSizeBytes int64
+		wantRequired     bool
+		wantResultMetric stats.FileRequiredResult
+	}{
+		{
+			name:             "MODULE.bazel",
+			path:             "project/MODULE.bazel",
+			wantRequired:     true,
+			wantResultMetric: stats.FileRequiredResultOK,
+		},
+		{
+			name:         "lockfile",
+			path:         "project/MODULE.bazel.lock",
+			wantRequired: false,
+		},
+		{
+			name:         "BUILD file",
+			path:         "project/BUILD.bazel",
+			wantRequired: false,
+		},
+		{
+			name:             "MODULE.bazel required if file size < max file size",
+			path:             "project/MODULE.bazel",
+			fileSizeBytes:    100 * units.KiB,
+			maxFileSizeBytes: 1000 * units.KiB,
+			wantRequired:     true,
+			wantResultMetric: stats.FileRequiredResultOK,
+		},
+		{
+			name:             "MODULE.bazel not required if file size > max file size",
+			path:             "project/MODULE.bazel",
+			fileSizeBytes:    1000 * units.KiB,
+			maxFileSizeBytes: 100 * units.KiB,
+			wantRequired:     false,
+			wantResultMetric: stats.FileRequiredResultSizeLimitExceeded,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			collector := testcollector.New()
+			var e filesystem.Extractor = bazel.New(bazel.Config{
+				Stats:            collector,
+				MaxFileSizeBytes: test.maxFileSizeBytes,
+			})
+
+			// Set default size if not provided.
+			fileSizeBytes := test.fileSizeBytes
+			if fileSizeBytes == 0 {
+				fileSizeBytes = 100 * units.KiB
+			}
+
+			isRequired := e.FileRequired(test.path, fakefs.FakeFileInfo{
+				FileName: filepath.Base(test.path),
+				FileMode: fs.ModePerm,
+				FileSize: fileSizeBytes,
+			})
+			if isRequired != test.wantRequired {
+				t.Fatalf("FileRequired(%s): got %v, want %v", test.path, isRequired, test.wantRequired)
+			}
+
+			gotResultMetric := collector.FileRequiredResult(test.path)
+			if gotResultMetric != test.wantResultMetric {
+				t.Errorf("FileRequired(%s) recorded result metric %v, want result metric %v", test.path, gotResultMetric, test.wantResultMetric)
+			}
+		})
+	}
+}
+
+func TestExtract(t *testing.T) {
+	const modulesPath = "testdata/modules/MODULE.bazel"
+	tests := []struct {
+		name             string
+		path             string
+		wantInventory    []*extractor.Inventory
+		wantErr          error
+		wantResultMetric stats.FileExtractedResult
+	}{
+		{
+			name: "several dependencies",
+			path: modulesPath,
+			wantInventory: []*extractor.Inventory{
+				{
+					Name:      "rules_go",
+					Version:   "0.46.0",
+					Metadata:  &bazel.Metadata{RepoName: "io_bazel_rules_go"},
+					Locations: []string{modulesPath},
+				},
+				{
+					Name:      "gazelle",
+					Version:   "0.35.0",
+					Metadata:  &bazel.Metadata{},
+					Locations: []string{modulesPath},
+				},
+				{
+					Name:      "protobuf",
+					Version:   "21.7",
+					Metadata:  &bazel.Metadata{RepoName: "com_google_protobuf"},
+					Locations: []string{modulesPath},
+				},
+				{
+					Name:      "abseil-cpp",
+					Version:   "20230802.0",
+					Metadata:  &bazel.Metadata{},
+					Locations: []string{modulesPath},
+				},
+				{
+					Name:      "platforms",
+					Version:   "0.0.8",
+					Metadata:  &bazel.Metadata{},
+					Locations: []string{modulesPath},
+				},
+				{
+					Name:      "googletest",
+					Version:   "1.14.0",
+					Metadata:  &bazel.Metadata{DevDependency: true},
+					Locations: []string{modulesPath},
+				},
+				{
+					Name:      "buildifier_prebuilt",
+					Version:   "6.4.0",
+					Metadata:  &bazel.Metadata{DevDependency: true},
+					Locations: []string{modulesPath},
+				},
+				{
+					// The version is set by an override.
+					Name:      "rules_cc",
+					Metadata:  &bazel.Metadata{},
+					Locations: []string{modulesPath},
+				},
+			},
+			wantResultMetric: stats.FileExtractedResultSuccess,
+		},
+		{
+			name:             "unterminated call",
+			path:             "testdata/invalid/MODULE.bazel",
+			wantErr:          extractor.ErrMalformedInput,
+			wantResultMetric: stats.FileExtractedResultErrorMalformedInput,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			collector := testcollector.New()
+			var e filesystem.Extractor = bazel.New(bazel.Config{Stats: collector})
+
+			r, err := os.Open(test.path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer r.Close()
+
+			info, err := os.Stat(test.path)
+			if err != nil {
+				t.Fatalf("Failed to stat test file: %v", err)
+			}
+
+			input := &filesystem.ScanInput{
+				FS:     scalibrfs.DirFS("."),
+				Path:   test.path,
+				Reader: r,
+				Info:   info,
+			}
+			got, err := e.Extract(context.Background(), input)
+			if !cmp.Equal(err, test.wantErr, cmpopts.EquateErrors()) {
+				t.Fatalf("Extract(%s) error: got %v, want %v", test.path, err, test.wantErr)
+			}
+
+			if diff := cmp.Diff(test.wantInventory, got); diff != "" {
+				t.Errorf("Extract(%s) (-want +got):\n%s", test.path, diff)
+			}
+
+			gotResultMetric := collector.FileExtractedResult(test.path)
+			if gotResultMetric != test.wantResultMetric {
+				t.Errorf("Extract(%s) recorded result metric %v, want result metric %v", test.path, gotResultMetric, test.wantResultMetric)
+			}
+		})
+	}
+}
+
+func TestToPURL(t *testing.T) {
+	e := bazel.Extractor{}
+	inv := &extractor.Inventory{
+		Name:      "rules_go",
+		Version:   "0.46.0",
+		Metadata:  &bazel.Metadata{RepoName: "io_bazel_rules_go"},
+		Locations: []string{"location"},
+	}
+	want := &purl.PackageURL{
+		Type:      purl.TypeGeneric,
+		Namespace: "bazel",
+		Name:      "rules_go",
+		Version:   "0.46.0",
+	}
+	got, err := e.ToPURL(inv)
+	if err != nil {
+		t.Fatalf("ToPURL(%v): %v", inv, err)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ToPURL(%v) (-want +got):\n%s", inv, diff)
+	}
+}
@@ -0,0 +1,25 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bazel
+
+// Metadata holds information about a bazel_dep dependency of a Bazel module.
+type Metadata struct {
+	// The name of the repository the dependency is visible as in the module,
+	// if it differs from the module name.
+	RepoName string
+	// Whether the dependency is only used for developing the module itself and
+	// is ignored if the module is a dependency of another module.
+	DevDependency bool
+}
@@ -0,0 +1,326 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package browserext extracts the browser extensions installed in the user
+// profiles of Chromium-based browsers (Chrome, Edge, Brave) and Firefox from
+// their manifest.json files.
+//
+// Firefox add-ons that are only installed as packed .xpi archives aren't
+// extracted.
+package browserext
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem"
+	"github.com/google/osv-scalibr/extractor/filesystem/internal/units"
+	"github.com/google/osv-scalibr/log"
+	"github.com/google/osv-scalibr/plugin"
+	"github.com/google/osv-scalibr/purl"
+	"github.com/google/osv-scalibr/stats"
+)
+
+const (
+	// Name is the unique name of this extractor.
+	Name = "misc/browserext"
+
+	// defaultMaxFileSizeBytes is the maximum file size an extractor will unmarshal.
+	// If Extract gets a bigger file, it will return an error.
+	defaultMaxFileSizeBytes = 1 * units.MiB
+
+	manifestFileName = "manifest.json"
+	// maxMessagesSizeBytes is the maximum size of the messages.json file the
+	// localized extension name is read from.
+	maxMessagesSizeBytes = 1 * units.MiB
+)
+
+var (
+	// Chromium extension IDs are 32 characters in the range a-p.
+	chromiumIDRegex = regexp.MustCompile(`^[a-p]{32}$`)
+	// Firefox add-on IDs are either email-like or GUIDs in braces.
+	firefoxIDRegex = regexp.MustCompile(`^(?:[^@/]*@[^@/]+|\{[0-9A-Fa-f-]{36}\})$`)
+)
+
+// manifest holds the fields of an extension manifest that are relevant for
+// the inventory.
+type manifest struct {
+	Name            string `json:"name"`
+	Version         string `json:"version"`
+	ManifestVersion int    `json:"manifest_version"`
+	DefaultLocale   string `json:"default_locale"`
+	// Firefox add-ons can declare their ID in browser_specific_settings or,
+	// in older manifests, in applications.
+	BrowserSpecificSettings *browserSettings `json:"browser_specific_settings"`
+	Applications            *browserSettings `json:"applications"`
+}
+
+type browserSettings struct {
+	Gecko *struct {
+		ID string `json:"id"`
+	} `json:"gecko"`
+}
+
+// Config is the configuration for the Extractor.
+type Config struct {
+	// Stats is a stats collector for reporting metrics.
+	Stats stats.Collector
+	// MaxFileSizeBytes is the maximum file size this extractor will unmarshal. If
+	// `FileRequired` gets a bigger file, it will return false,
+	MaxFileSizeBytes int64
+}
+
+// DefaultConfig returns the default configuration for the extractor.
+func DefaultConfig() Config {
+	return Config{
+		MaxFileSizeBytes: defaultMaxFileSizeBytes,
+		Stats:            nil,
+	}
+}
+
+// Extractor extracts installed browser extensions.
+type Extractor struct {
+	stats            stats.Collector
+	maxFileSizeBytes int64
+}
+
+// New returns a browser extension extractor.
+//
+// For most use cases, initialize with:
+// ```
+// e := New(DefaultConfig())
+// ```
+func New(cfg Config) *Extractor {
+	return &Extractor{
+		stats:            cfg.Stats,
+		maxFileSizeBytes: cfg.MaxFileSizeBytes,
+	}
+}
+
+// Config returns the configuration of the extractor.
+func (e Extractor) Config() Config {
+	return Config{
+		Stats:            e.stats,
+		MaxFileSizeBytes: e.maxFileSizeBytes,
+	}
+}
+
+// Name of the extractor.
+func (e Extractor) Name() string { return Name }
+
+// Version of the extractor.
+func (e Extractor) Version() int { return 0 }
+
+// Requirements of the extractor.
+func (e Extractor) Requirements() *plugin.Capabilities { return &plugin.Capabilities{} }
+
+// Experimental returns true since the extractor doesn't cover all the
+// extension stores and profile layouts yet.
+func (e Extractor) Experimental() bool { return true }
+
+// RequiredFileNames returns the name of the extension manifests.
+func (e Extractor) RequiredFileNames() []string { return []string{manifestFileName} }
+
+// FileRequired returns true if the specified file is the manifest of an
+// installed extension, i.e. Extensions/<id>/<version>/manifest.json for
+// Chromium-based browsers or extensions/<id>/manifest.json for Firefox.
+func (e Extractor) FileRequired(path string, fileinfo fs.FileInfo) bool {
+	if _, _, ok := parseExtensionPath(path); !ok {
+		return false
+	}
+
+	if e.maxFileSizeBytes > 0 && fileinfo.Size() > e.maxFileSizeBytes {
+		e.reportFileRequired(path, fileinfo.Size(), stats.FileRequiredResultSizeLimitExceeded)
+		return false
+	}
+
+	e.reportFileRequired(path, fileinfo.Size(), stats.FileRequiredResultOK)
+	return true
+}
+
+// parseExtensionPath returns the extension ID and the browser of an extension
+// manifest path.
+func parseExtensionPath(p string) (id string, browser string, ok bool) {
+	parts := strings.Split(filepath.ToSlash(p), "/")
+	n := len(parts)
+	if n < 3 || parts[n-1] != manifestFileName {
+		return "", "", false
+	}
+	if n >= 4 && parts[n-4] == "Extensions" && chromiumIDRegex.MatchString(parts[n-3]) {
+		return parts[n-3], chromiumBrowser(parts[:n-4]), true
+	}
+	if parts[n-3] == "extensions" && firefoxIDRegex.MatchString(parts[n-2]) {
+		return parts[n-2], "firefox", true
+	}
+	return "", "", false
+}
+
+// chromiumBrowser returns the Chromium-based browser a user data directory
+// belongs to, e.g. ".config/google-chrome/Default" or
+// "AppData/Local/Microsoft/Edge/User Data/Default".
+func chromiumBrowser(profileDir []string) string {
+	dir := strings.ToLower(strings.Join(profileDir, "/"))
+	switch {
+	case strings.Contains(dir, "edge"):
+		return "edge"
+	case strings.Contains(dir, "brave"):
+		return "brave"
+	case strings.Contains(dir, "chrome"):
+		return "chrome"
+	default:
+		return "chromium"
+	}
+}
+
+func (e Extractor) reportFileRequired(path string, fileSizeBytes int64, result stats.FileRequiredResult) {
+	if e.stats == nil {
+		return
+	}
+	e.stats.AfterFileRequired(e.Name(), &stats.FileRequiredStats{
+		Path:          path,
+		Result:        result,
+		FileSizeBytes: fileSizeBytes,
+	})
+}
+
+// Extract returns the browser extension described by the manifest.
+func (e Extractor) Extract(ctx context.Context, input *filesystem.ScanInput) ([]*extractor.Inventory, error) {
+	inventory, err := e.extractFromInput(input)
+	if e.stats != nil {
+		var fileSizeBytes int64
+		if input.Info != nil {
+			fileSizeBytes = input.Info.Size()
+		}
+		e.stats.AfterFileExtracted(e.Name(), &stats.FileExtractedStats{
+			Path:          input.Path,
+			Result:        filesystem.ExtractorErrorToFileExtractedResult(err),
+			FileSizeBytes: fileSizeBytes,
+		})
+	}
+	return inventory, err
+}
+
+func (e Extractor) extractFromInput(input *filesystem.ScanInput) ([]*extractor.Inventory, error) {
+	id, browser, ok := parseExtensionPath(input.Path)
+	if !ok {
+		return nil, fmt.Errorf("%q is not an extension manifest", input.Path)
+	}
+	var m manifest
+	if err := json.NewDecoder(input.Reader).Decode(&m); err != nil {
+		return nil, fmt.Errorf("failed to parse %q: %w: %w", input.Path, extractor.ErrMalformedInput, err)
+	}
+	if m.Version == "" {
+		return nil, fmt.Errorf("missing extension version in %q: %w", input.Path, extractor.ErrMalformedInput)
+	}
+	if browser == "firefox" {
+		if geckoID := m.geckoID(); geckoID != "" {
+			id = geckoID
+		}
+	}
+
+	name := m.Name
+	if isMessagePlaceholder(name) {
+		name = localizedName(input.FS, path.Dir(filepath.ToSlash(input.Path)), m.DefaultLocale, name)
+	}
+	if name == "" || isMessagePlaceholder(name) {
+		name = id
+	}
+	return []*extractor.Inventory{{
+		Name:    name,
+		Version: m.Version,
+		Metadata: &Metadata{
+			ID:              id,
+			Browser:         browser,
+			ManifestVersion: m.ManifestVersion,
+		},
+		Locations: []string{input.Path},
+	}}, nil
+}
+
+func (m *manifest) geckoID() string {
+	for _, s := range []*browserSettings{m.BrowserSpecificSettings, m.Applications} {
+		if s != nil && s.Gecko != nil && s.Gecko.ID != "" {
+			return s.Gecko.ID
+		}
+	}
+	return ""
+}
+
+// isMessagePlaceholder returns true for localized strings such as
+// "__MSG_appName__".
+func isMessagePlaceholder(s string) bool {
+	return len(s) > len("__MSG___") && strings.HasPrefix(s, "__MSG_") && strings.HasSuffix(s, "__")
+}
+
+// localizedName resolves a "__MSG_<key>__" placeholder with the messages of
+// the extension's default locale. Returns the placeholder if the message
+// can't be found.
+func localizedName(fsys fs.FS, extensionDir, locale, placeholder string) string {
+	if locale == "" || strings.ContainsAny(locale, "/\\") {
+		return placeholder
+	}
+	messagesPath := path.Join(extensionDir, "_locales", locale, "messages.json")
+	f, err := fsys.Open(messagesPath)
+	if err != nil {
+		if !errors.Is(err, fs.ErrNotExist) {
+			log.Debugf("Failed to open %q: %v", messagesPath, err)
+		}
+		return placeholder
+	}
+	defer f.Close()
+	if info, err := f.Stat(); err != nil || info.Size() > maxMessagesSizeBytes {
+		return placeholder
+	}
+	var messages map[string]struct {
+		Message string `json:"message"`
+	}
+	if err := json.NewDecoder(f).Decode(&messages); err != nil {
+		log.Debugf("Failed to parse %q: %v", messagesPath, err)
+		return placeholder
+	}
+	// Message keys are case-insensitive.
+	key := strings.TrimSuffix(strings.TrimPrefix(placeholder, "__MSG_"), "__")
+	for k, v := range messages {
+		if strings.EqualFold(k, key) && v.Message != "" {
+			return v.Message
+		}
+	}
+	return placeholder
+}
+
+// ToPURL converts an inventory created by this extractor into a PURL. The
+// extensions are identified by their ID and namespaced by the browser.
+func (e Extractor) ToPURL(i *extractor.Inventory) (*purl.PackageURL, error) {
+	m := i.Metadata.(*Metadata)
+	return &purl.PackageURL{
+		Type:      purl.TypeGeneric,
+		Namespace: m.Browser,
+		Name:      m.ID,
+		Version:   i.Version,
+	}, nil
+}
+
+// ToCPEs is not applicable as this extractor does not infer CPEs from the Inventory.
+func (e Extractor) ToCPEs(i *extractor.Inventory) ([]string, error) { return []string{}, nil }
+
+// Ecosystem returns no ecosystem since OSV does not support browser extensions.
+func (Extractor) Ecosystem(i *extractor.Inventory) (string, error) { return "", nil }
@@ -0,0 +1,305 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package browserext_test
+
+import (
+	"context"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem"
+	"github.com/google/osv-scalibr/extractor/filesystem/internal/units"
+	"github.com/google/osv-scalibr/extractor/filesystem/misc/browserext"
+	scalibrfs "github.com/google/osv-scalibr/fs"
+	"github.com/google/osv-scalibr/purl"
+	"github.com/google/osv-scalibr/stats"
+	"github.com/google/osv-scalibr/testing/fakefs"
+	"github.com/google/osv-scalibr/testing/testcollector"
+)
+
+func TestFileRequired(t *testing.T) {
+	tests := []struct {
+		name             string
+		path             string
+		fileSizeBytes    int64
+		maxFileSizeBytes int64
+		wantRequired     bool
+		wantResultMetric stats.FileRequiredResult
+	}{
+		{
+			name:             "Chrome on Linux",
+			path:             "home/user/.config/google-chrome/Default/Extensions/cjpalhdlnbpafiamejdnhcphjbkeiagm/1.57.0_0/manifest.json",
+			wantRequired:     true,
+			wantResultMetric: stats.FileRequiredResultOK,
+		}, {
+			name:             "Edge on Windows",
+			path:             "Users/user/AppData/Local/Microsoft/Edge/User Data/Profile 1/Extensions/odfafepnkmbhccpbejgmiehpchacaeak/1.57.0_0/manifest.json",
+			wantRequired:     true,
+			wantResultMetric: stats.FileRequiredResultOK,
+		}, {
+			name:             "Firefox add-on with email-like ID",
+			path:             "home/user/.mozilla/firefox/abcd1234.default-release/extensions/uBlock0@raymondhill.net/manifest.json",
+			wantRequired:     true,
+			wantResultMetric: stats.FileRequiredResultOK,
+		}, {
+			name:             "Firefox add-on with GUID",
+			path:             "home/user/.mozilla/firefox/abcd1234.default-release/extensions/{446900e4-71c2-419f-a6a7-df9c091e268b}/manifest.json",
+			wantRequired:     true,
+			wantResultMetric: stats.FileRequiredResultOK,
+		}, {
+			name:         "web app manifest",
+			path:         "var/www/html/manifest.json",
+			wantRequired: false,
+		}, {
+			name:         "invalid Chromium extension ID",
+			path:         "home/user/.config/google-chrome/Default/Extensions/Temp/1.0_0/manifest.json",
+			wantRequired: false,
+		}, {
+			name:         "manifest in a subdirectory of an extension",
+			path:         "home/user/.config/google-chrome/Default/Extensions/cjpalhdlnbpafiamejdnhcphjbkeiagm/1.57.0_0/lib/manifest.json",
+			wantRequired: false,
+		}, {
+			name:         "invalid Firefox add-on ID",
+			path:         "home/user/.mozilla/firefox/abcd1234.default-release/extensions/staged/manifest.json",
+			wantRequired: false,
+		}, {
+			name:             "file size limit exceeded",
+			path:             "home/user/.config/google-chrome/Default/Extensions/cjpalhdlnbpafiamejdnhcphjbkeiagm/1.57.0_0/manifest.json",
+			fileSizeBytes:    1 * units.MiB,
+			maxFileSizeBytes: 1000,
+			wantRequired:     false,
+			wantResultMetric: stats.FileRequiredResultSizeLimitExceeded,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			collector := testcollector.New()
+			var e filesystem.Extractor = browserext.New(browserext.Config{
+				Stats:            collector,
+				MaxFileSizeBytes: tt.maxFileSizeBytes,
+			})
+
+			fileSizeBytes := tt.fileSizeBytes
+			if fileSizeBytes == 0 {
+				fileSizeBytes = 1000
+			}
+			isRequired := e.FileRequired(tt.path, fakefs.FakeFileInfo{
+				FileName: filepath.Base(tt.path),
+				FileMode: fs.ModePerm,
+				FileSize: fileSizeBytes,
+			})
+			if isRequired != tt.wantRequired {
+				t.Fatalf("FileRequired(%s): got %v, want %v", tt.path, isRequired, tt.wantRequired)
+			}
+
+			gotResultMetric := collector.FileRequiredResult(tt.path)
+			if tt.wantResultMetric != "" && gotResultMetric != tt.wantResultMetric {
+				t.Errorf("FileRequired(%s) recorded result metric %v, want result metric %v", tt.path, gotResultMetric, tt.wantResultMetric)
+			}
+		})
+	}
+}
+
+func TestExtract(t *testing.T) {
+	tests := []struct {
+		name             string
+		path             string
+		wantInventory    []*extractor.Inventory
+		wantErr          error
+		wantResultMetric stats.FileExtractedResult
+	}{
+		{
+			name: "Chrome extension",
+			path: "google-chrome/Default/Extensions/cjpalhdlnbpafiamejdnhcphjbkeiagm/1.57.0_0/manifest.json",
+			wantInventory: []*extractor.Inventory{
+				{
+					Name:    "uBlock Origin",
+					Version: "1.57.0",
+					Metadata: &browserext.Metadata{
+						ID:              "cjpalhdlnbpafiamejdnhcphjbkeiagm",
+						Browser:         "chrome",
+						ManifestVersion: 2,
+					},
+					Locations: []string{"google-chrome/Default/Extensions/cjpalhdlnbpafiamejdnhcphjbkeiagm/1.57.0_0/manifest.json"},
+				},
+			},
+			wantResultMetric: stats.FileExtractedResultSuccess,
+		}, {
+			name: "localized name",
+			path: "google-chrome/Default/Extensions/aapbdbdomjkkjkaonfhkkikfgjllcleb/2.0.13_0/manifest.json",
+			wantInventory: []*extractor.Inventory{
+				{
+					Name:    "Google Translate",
+					Version: "2.0.13",
+					Metadata: &browserext.Metadata{
+						ID:              "aapbdbdomjkkjkaonfhkkikfgjllcleb",
+						Browser:         "chrome",
+						ManifestVersion: 3,
+					},
+					Locations: []string{"google-chrome/Default/Extensions/aapbdbdomjkkjkaonfhkkikfgjllcleb/2.0.13_0/manifest.json"},
+				},
+			},
+			wantResultMetric: stats.FileExtractedResultSuccess,
+		}, {
+			name: "localized name without messages falls back to the ID",
+			path: "google-chrome/Default/Extensions/ghbmnnjooekpmoecnnnilnnbdlolhkhi/1.73.1_0/manifest.json",
+			wantInventory: []*extractor.Inventory{
+				{
+					Name:    "ghbmnnjooekpmoecnnnilnnbdlolhkhi",
+					Version: "1.73.1",
+					Metadata: &browserext.Metadata{
+						ID:              "ghbmnnjooekpmoecnnnilnnbdlolhkhi",
+						Browser:         "chrome",
+						ManifestVersion: 3,
+					},
+					Locations: []string{"google-chrome/Default/Extensions/ghbmnnjooekpmoecnnnilnnbdlolhkhi/1.73.1_0/manifest.json"},
+				},
+			},
+			wantResultMetric: stats.FileExtractedResultSuccess,
+		}, {
+			name: "Edge extension",
+			path: "microsoft-edge/Default/Extensions/odfafepnkmbhccpbejgmiehpchacaeak/1.57.0_0/manifest.json",
+			wantInventory: []*extractor.Inventory{
+				{
+					Name:    "uBlock Origin",
+					Version: "1.57.0",
+					Metadata: &browserext.Metadata{
+						ID:              "odfafepnkmbhccpbejgmiehpchacaeak",
+						Browser:         "edge",
+						ManifestVersion: 2,
+					},
+					Locations: []string{"microsoft-edge/Default/Extensions/odfafepnkmbhccpbejgmiehpchacaeak/1.57.0_0/manifest.json"},
+				},
+			},
+			wantResultMetric: stats.FileExtractedResultSuccess,
+		}, {
+			name: "Firefox add-on",
+			path: "mozilla/firefox/abcd1234.default-release/extensions/uBlock0@raymondhill.net/manifest.json",
+			wantInventory: []*extractor.Inventory{
+				{
+					Name:    "uBlock Origin",
+					Version: "1.57.0",
+					Metadata: &browserext.Metadata{
+						ID:              "uBlock0@raymondhill.net",
+						Browser:         "firefox",
+						ManifestVersion: 2,
+					},
+					Locations: []string{"mozilla/firefox/abcd1234.default-release/extensions/uBlock0@raymondhill.net/manifest.json"},
+				},
+			},
+			wantResultMetric: stats.FileExtractedResultSuccess,
+		}, {
+			name: "Firefox add-on with localized name",
+			path: "mozilla/firefox/abcd1234.default-release/extensions/{446900e4-71c2-419f-a6a7-df9c091e268b}/manifest.json",
+			wantInventory: []*extractor.Inventory{
+				{
+					Name:    "Bitwarden - Free Password Manager",
+					Version: "2024.2.1",
+					Metadata: &browserext.Metadata{
+						ID:              "{446900e4-71c2-419f-a6a7-df9c091e268b}",
+						Browser:         "firefox",
+						ManifestVersion: 2,
+					},
+					Locations: []string{"mozilla/firefox/abcd1234.default-release/extensions/{446900e4-71c2-419f-a6a7-df9c091e268b}/manifest.json"},
+				},
+			},
+			wantResultMetric: stats.FileExtractedResultSuccess,
+		}, {
+			name:             "invalid manifest",
+			path:             "google-chrome/Default/Extensions/ghbmnnjooekpmoecnnnilnnbdlolhkhi/invalid_0/manifest.json",
+			wantErr:          extractor.ErrMalformedInput,
+			wantResultMetric: stats.FileExtractedResultErrorMalformedInput,
+		}, {
+			name:             "manifest without version",
+			path:             "google-chrome/Default/Extensions/ghbmnnjooekpmoecnnnilnnbdlolhkhi/noversion_0/manifest.json",
+			wantErr:          extractor.ErrMalformedInput,
+			wantResultMetric: stats.FileExtractedResultErrorMalformedInput,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			collector := testcollector.New()
+			var e filesystem.Extractor = browserext.New(browserext.Config{
+				Stats:            collector,
+				MaxFileSizeBytes: 1 * units.MiB,
+			})
+
+			d := "testdata"
+			r, err := os.Open(filepath.Join(d, tt.path))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer r.Close()
+			info, err := r.Stat()
+			if err != nil {
+				t.Fatalf("Stat(): %v", err)
+			}
+
+			input := &filesystem.ScanInput{
+				FS:     scalibrfs.DirFS(d),
+				Path:   tt.path,
+				Reader: r,
+				Root:   d,
+				Info:   info,
+			}
+			got, err := e.Extract(context.Background(), input)
+			if !cmp.Equal(err, tt.wantErr, cmpopts.EquateErrors()) {
+				t.Fatalf("Extract(%+v) error: got %v, want %v\n", tt.path, err, tt.wantErr)
+			}
+
+			if diff := cmp.Diff(tt.wantInventory, got); diff != "" {
+				t.Errorf("Extract(%s) (-want +got):\n%s", tt.path, diff)
+			}
+
+			gotResultMetric := collector.FileExtractedResult(tt.path)
+			if gotResultMetric != tt.wantResultMetric {
+				t.Errorf("Extract(%s) recorded result metric %v, want result metric %v", tt.path, gotResultMetric, tt.wantResultMetric)
+			}
+		})
+	}
+}
+
+func TestToPURL(t *testing.T) {
+	e := browserext.New(browserext.DefaultConfig())
+	i := &extractor.Inventory{
+		Name:    "uBlock Origin",
+		Version: "1.57.0",
+		Metadata: &browserext.Metadata{
+			ID:              "cjpalhdlnbpafiamejdnhcphjbkeiagm",
+			Browser:         "chrome",
+			ManifestVersion: 2,
+		},
+	}
+	want := &purl.PackageURL{
+		Type:      purl.TypeGeneric,
+		Namespace: "chrome",
+		Name:      "cjpalhdlnbpafiamejdnhcphjbkeiagm",
+		Version:   "1.57.0",
+	}
+	got, err := e.ToPURL(i)
+	if err != nil {
+		t.Fatalf("ToPURL(%v): %v", i, err)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ToPURL(%v) (-want +got):\n%s", i, diff)
+	}
+}
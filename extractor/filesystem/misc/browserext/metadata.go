@@ -0,0 +1,27 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package browserext
+
+// Metadata holds additional information about an installed browser extension.
+type Metadata struct {
+	// ID of the extension, e.g. "cjpalhdlnbpafiamejdnhcphjbkeiagm" for Chrome
+	// extensions or "uBlock0@raymondhill.net" for Firefox add-ons.
+	ID string
+	// Browser the extension is installed in: "chrome", "edge", "brave",
+	// "chromium" or "firefox".
+	Browser string
+	// ManifestVersion is the version of the manifest format, e.g. 3.
+	ManifestVersion int
+}
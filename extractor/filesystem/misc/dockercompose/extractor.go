@@ -0,0 +1,259 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package dockercompose extracts the container images used by the services
+// of Docker Compose files.
+package dockercompose
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/go-yaml/yaml"
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem"
+	"github.com/google/osv-scalibr/extractor/filesystem/internal/units"
+	"github.com/google/osv-scalibr/plugin"
+	"github.com/google/osv-scalibr/purl"
+	"github.com/google/osv-scalibr/stats"
+)
+
+const (
+	// Name is the unique name of this extractor.
+	Name = "misc/dockercompose"
+
+	// defaultMaxFileSizeBytes is the maximum file size an extractor will unmarshal.
+	// If Extract gets a bigger file, it will return an error.
+	defaultMaxFileSizeBytes = 1 * units.MiB
+
+	// defaultTag is the tag Docker uses for images without a tag or digest.
+	defaultTag = "latest"
+	// dockerHub is the registry of images without a registry host.
+	dockerHub = "docker.io"
+)
+
+// composeFileNames are the default names of Compose files, see
+// https://docs.docker.com/compose/intro/compose-application-model/#the-compose-file
+var composeFileNames = []string{"compose.yaml", "compose.yml", "docker-compose.yaml", "docker-compose.yml"}
+
+// composeFile holds the fields of a Compose file that are relevant for the
+// inventory.
+type composeFile struct {
+	Services map[string]*service `yaml:"services"`
+}
+
+// service is a service of a Compose file. Services without an image are built
+// from a Dockerfile and skipped.
+type service struct {
+	Image string `yaml:"image"`
+}
+
+// Config is the configuration for the Extractor.
+type Config struct {
+	// Stats is a stats collector for reporting metrics.
+	Stats stats.Collector
+	// MaxFileSizeBytes is the maximum file size this extractor will unmarshal. If
+	// `FileRequired` gets a bigger file, it will return false,
+	MaxFileSizeBytes int64
+}
+
+// DefaultConfig returns the default configuration for the extractor.
+func DefaultConfig() Config {
+	return Config{
+		MaxFileSizeBytes: defaultMaxFileSizeBytes,
+		Stats:            nil,
+	}
+}
+
+// Extractor extracts the images of the services in Docker Compose files.
+type Extractor struct {
+	stats            stats.Collector
+	maxFileSizeBytes int64
+}
+
+// New returns a Docker Compose extractor.
+//
+// For most use cases, initialize with:
+// ```
+// e := New(DefaultConfig())
+// ```
+func New(cfg Config) *Extractor {
+	return &Extractor{
+		stats:            cfg.Stats,
+		maxFileSizeBytes: cfg.MaxFileSizeBytes,
+	}
+}
+
+// Config returns the configuration of the extractor.
+func (e Extractor) Config() Config {
+	return Config{
+		Stats:            e.stats,
+		MaxFileSizeBytes: e.maxFileSizeBytes,
+	}
+}
+
+// Name of the extractor.
+func (e Extractor) Name() string { return Name }
+
+// Version of the extractor.
+func (e Extractor) Version() int { return 0 }
+
+// Requirements of the extractor.
+func (e Extractor) Requirements() *plugin.Capabilities { return &plugin.Capabilities{} }
+
+// RequiredFileNames returns the names of the Compose files the extractor requires.
+func (e Extractor) RequiredFileNames() []string { return composeFileNames }
+
+// FileRequired returns true if the specified file is a Compose file with one
+// of the default names.
+func (e Extractor) FileRequired(path string, fileinfo fs.FileInfo) bool {
+	if !slices.Contains(composeFileNames, filepath.Base(path)) {
+		return false
+	}
+
+	if e.maxFileSizeBytes > 0 && fileinfo.Size() > e.maxFileSizeBytes {
+		e.reportFileRequired(path, fileinfo.Size(), stats.FileRequiredResultSizeLimitExceeded)
+		return false
+	}
+
+	e.reportFileRequired(path, fileinfo.Size(), stats.FileRequiredResultOK)
+	return true
+}
+
+func (e Extractor) reportFileRequired(path string, fileSizeBytes int64, result stats.FileRequiredResult) {
+	if e.stats == nil {
+		return
+	}
+	e.stats.AfterFileRequired(e.Name(), &stats.FileRequiredStats{
+		Path:          path,
+		Result:        result,
+		FileSizeBytes: fileSizeBytes,
+	})
+}
+
+// Extract returns the images of the services in the Compose file passed
+// through the scan input.
+func (e Extractor) Extract(ctx context.Context, input *filesystem.ScanInput) ([]*extractor.Inventory, error) {
+	inventory, err := e.extractFromInput(input)
+	if e.stats != nil {
+		var fileSizeBytes int64
+		if input.Info != nil {
+			fileSizeBytes = input.Info.Size()
+		}
+		e.stats.AfterFileExtracted(e.Name(), &stats.FileExtractedStats{
+			Path:          input.Path,
+			Result:        filesystem.ExtractorErrorToFileExtractedResult(err),
+			FileSizeBytes: fileSizeBytes,
+		})
+	}
+	return inventory, err
+}
+
+func (e Extractor) extractFromInput(input *filesystem.ScanInput) ([]*extractor.Inventory, error) {
+	var c composeFile
+	if err := yaml.NewDecoder(input.Reader).Decode(&c); err != nil {
+		return nil, fmt.Errorf("failed to parse %q: %w: %w", input.Path, extractor.ErrMalformedInput, err)
+	}
+
+	names := make([]string, 0, len(c.Services))
+	for name := range c.Services {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	var inventory []*extractor.Inventory
+	for _, name := range names {
+		s := c.Services[name]
+		if s == nil || s.Image == "" {
+			continue
+		}
+		m := parseImage(s.Image)
+		m.ServiceName = name
+		version := m.Digest
+		if version == "" {
+			version = m.Tag
+		}
+		inventory = append(inventory, &extractor.Inventory{
+			Name:      m.Repository,
+			Version:   version,
+			Metadata:  m,
+			Locations: []string{input.Path},
+		})
+	}
+	return inventory, nil
+}
+
+// parseImage splits an image reference such as "postgres:16@sha256:..." into
+// the normalized repository, tag and digest. Images containing environment
+// variables are left as-is.
+func parseImage(image string) *Metadata {
+	if strings.Contains(image, "$") {
+		return &Metadata{Repository: image, Interpolated: true}
+	}
+	name, digest, _ := strings.Cut(image, "@")
+	repository, tag := name, ""
+	if i := strings.LastIndex(name, ":"); i > strings.LastIndex(name, "/") {
+		repository, tag = name[:i], name[i+1:]
+	}
+	if tag == "" && digest == "" {
+		tag = defaultTag
+	}
+	return &Metadata{
+		Repository: normalizeRepository(repository),
+		Tag:        tag,
+		Digest:     digest,
+	}
+}
+
+// normalizeRepository adds the registry and the "library" namespace that
+// Docker implies for short image names, e.g. "nginx" is normalized to
+// "docker.io/library/nginx". The first path component is a registry host if it
+// contains a "." or ":" or is "localhost".
+func normalizeRepository(repository string) string {
+	host, rest, found := strings.Cut(repository, "/")
+	if !found || !(strings.ContainsAny(host, ".:") || host == "localhost") {
+		host, rest = dockerHub, repository
+	}
+	if host == dockerHub && !strings.Contains(rest, "/") {
+		rest = "library/" + rest
+	}
+	return host + "/" + rest
+}
+
+// ToPURL converts an inventory created by this extractor into a PURL. The
+// version of OCI PURLs is the image digest, so it's only set for pinned images.
+func (e Extractor) ToPURL(i *extractor.Inventory) (*purl.PackageURL, error) {
+	m := i.Metadata.(*Metadata)
+	qualifiers := map[string]string{purl.RepositoryURL: m.Repository}
+	if m.Tag != "" {
+		qualifiers[purl.Tag] = m.Tag
+	}
+	return &purl.PackageURL{
+		Type:       purl.TypeOCI,
+		Name:       strings.ToLower(path.Base(m.Repository)),
+		Version:    m.Digest,
+		Qualifiers: purl.QualifiersFromMap(qualifiers),
+	}, nil
+}
+
+// ToCPEs is not applicable as this extractor does not infer CPEs from the Inventory.
+func (e Extractor) ToCPEs(i *extractor.Inventory) ([]string, error) { return []string{}, nil }
+
+// Ecosystem returns no ecosystem since OSV does not support container images.
+func (Extractor) Ecosystem(i *extractor.Inventory) (string, error) { return "", nil }
@@ -0,0 +1,291 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dockercompose_test
+
+import (
+	"context"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem"
+	"github.com/google/osv-scalibr/extractor/filesystem/internal/units"
+	"github.com/google/osv-scalibr/extractor/filesystem/misc/dockercompose"
+	scalibrfs "github.com/google/osv-scalibr/fs"
+	"github.com/google/osv-scalibr/purl"
+	"github.com/google/osv-scalibr/stats"
+	"github.com/google/osv-scalibr/testing/fakefs"
+	"github.com/google/osv-scalibr/testing/testcollector"
+)
+
+func TestFileRequired(t *testing.T) {
+	tests := []struct {
+		name             string
+		path             string
+		fileSizeBytes    int64
+		maxFileSizeBytes int64
+		wantRequired     bool
+		wantResultMetric stats.FileRequiredResult
+	}{
+		{
+			name:             "docker-compose.yml",
+			path:             "project/docker-compose.yml",
+			wantRequired:     true,
+			wantResultMetric: stats.FileRequiredResultOK,
+		},
+		{
+			name:             "compose.yaml",
+			path:             "project/compose.yaml",
+			wantRequired:     true,
+			wantResultMetric: stats.FileRequiredResultOK,
+		},
+		{
+			name:         "override file",
+			path:         "project/docker-compose.override.yml",
+			wantRequired: false,
+		},
+		{
+			name:         "other YAML file",
+			path:         "project/config.yaml",
+			wantRequired: false,
+		},
+		{
+			name:             "compose.yaml required if file size < max file size",
+			path:             "project/compose.yaml",
+			fileSizeBytes:    100 * units.KiB,
+			maxFileSizeBytes: 1000 * units.KiB,
+			wantRequired:     true,
+			wantResultMetric: stats.FileRequiredResultOK,
+		},
+		{
+			name:             "compose.yaml not required if file size > max file size",
+			path:             "project/compose.yaml",
+			fileSizeBytes:    1000 * units.KiB,
+			maxFileSizeBytes: 100 * units.KiB,
+			wantRequired:     false,
+			wantResultMetric: stats.FileRequiredResultSizeLimitExceeded,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			collector := testcollector.New()
+			var e filesystem.Extractor = dockercompose.New(dockercompose.Config{
+				Stats:            collector,
+				MaxFileSizeBytes: test.maxFileSizeBytes,
+			})
+
+			// Set default size if not provided.
+			fileSizeBytes := test.fileSizeBytes
+			if fileSizeBytes == 0 {
+				fileSizeBytes = 100 * units.KiB
+			}
+
+			isRequired := e.FileRequired(test.path, fakefs.FakeFileInfo{
+				FileName: filepath.Base(test.path),
+				FileMode: fs.ModePerm,
+				FileSize: fileSizeBytes,
+			})
+			if isRequired != test.wantRequired {
+				t.Fatalf("FileRequired(%s): got %v, want %v", test.path, isRequired, test.wantRequired)
+			}
+
+			gotResultMetric := collector.FileRequiredResult(test.path)
+			if gotResultMetric != test.wantResultMetric {
+				t.Errorf("FileRequired(%s) recorded result metric %v, want result metric %v", test.path, gotResultMetric, test.wantResultMetric)
+			}
+		})
+	}
+}
+
+func TestExtract(t *testing.T) {
+	const multiPath = "testdata/multi/docker-compose.yml"
+	tests := []struct {
+		name             string
+		path             string
+		wantInventory    []*extractor.Inventory
+		wantErr          error
+		wantResultMetric stats.FileExtractedResult
+	}{
+		{
+			name: "multiple services",
+			path: multiPath,
+			wantInventory: []*extractor.Inventory{
+				{
+					Name:    "docker.io/library/redis",
+					Version: "sha256:e422889e156ebea83856b6ff973bfe0c86bce867d80def228044eeecf925592b",
+					Metadata: &dockercompose.Metadata{
+						ServiceName: "cache",
+						Repository:  "docker.io/library/redis",
+						Tag:         "7.2",
+						Digest:      "sha256:e422889e156ebea83856b6ff973bfe0c86bce867d80def228044eeecf925592b",
+					},
+					Locations: []string{multiPath},
+				},
+				{
+					Name:    "docker.io/library/postgres",
+					Version: "16.2",
+					Metadata: &dockercompose.Metadata{
+						ServiceName: "db",
+						Repository:  "docker.io/library/postgres",
+						Tag:         "16.2",
+					},
+					Locations: []string{multiPath},
+				},
+				{
+					Name:    "docker.io/prom/prometheus",
+					Version: "sha256:5a6c9c1a2d0f9b6f7b3c0c9a8e1f2d3c4b5a69788796a5b4c3d2e1f0a9b8c7d6",
+					Metadata: &dockercompose.Metadata{
+						ServiceName: "metrics",
+						Repository:  "docker.io/prom/prometheus",
+						Digest:      "sha256:5a6c9c1a2d0f9b6f7b3c0c9a8e1f2d3c4b5a69788796a5b4c3d2e1f0a9b8c7d6",
+					},
+					Locations: []string{multiPath},
+				},
+				{
+					Name: "${REGISTRY:-ghcr.io}/shop/proxy:${PROXY_TAG:-latest}",
+					Metadata: &dockercompose.Metadata{
+						ServiceName:  "proxy",
+						Repository:   "${REGISTRY:-ghcr.io}/shop/proxy:${PROXY_TAG:-latest}",
+						Interpolated: true,
+					},
+					Locations: []string{multiPath},
+				},
+				{
+					Name:    "docker.io/library/nginx",
+					Version: "latest",
+					Metadata: &dockercompose.Metadata{
+						ServiceName: "web",
+						Repository:  "docker.io/library/nginx",
+						Tag:         "latest",
+					},
+					Locations: []string{multiPath},
+				},
+				{
+					// Built from a Dockerfile and tagged with the image name.
+					Name:    "registry.example.com:5000/shop/worker",
+					Version: "1.4.0",
+					Metadata: &dockercompose.Metadata{
+						ServiceName: "worker",
+						Repository:  "registry.example.com:5000/shop/worker",
+						Tag:         "1.4.0",
+					},
+					Locations: []string{multiPath},
+				},
+			},
+			wantResultMetric: stats.FileExtractedResultSuccess,
+		},
+		{
+			name:             "services aren't a map",
+			path:             "testdata/invalid/compose.yaml",
+			wantErr:          extractor.ErrMalformedInput,
+			wantResultMetric: stats.FileExtractedResultErrorMalformedInput,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			collector := testcollector.New()
+			var e filesystem.Extractor = dockercompose.New(dockercompose.Config{Stats: collector})
+
+			r, err := os.Open(test.path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer r.Close()
+
+			info, err := os.Stat(test.path)
+			if err != nil {
+				t.Fatalf("Failed to stat test file: %v", err)
+			}
+
+			input := &filesystem.ScanInput{
+				FS:     scalibrfs.DirFS("."),
+				Path:   test.path,
+				Reader: r,
+				Info:   info,
+			}
+			got, err := e.Extract(context.Background(), input)
+			if !cmp.Equal(err, test.wantErr, cmpopts.EquateErrors()) {
+				t.Fatalf("Extract(%s) error: got %v, want %v", test.path, err, test.wantErr)
+			}
+
+			if diff := cmp.Diff(test.wantInventory, got); diff != "" {
+				t.Errorf("Extract(%s) (-want +got):\n%s", test.path, diff)
+			}
+
+			gotResultMetric := collector.FileExtractedResult(test.path)
+			if gotResultMetric != test.wantResultMetric {
+				t.Errorf("Extract(%s) recorded result metric %v, want result metric %v", test.path, gotResultMetric, test.wantResultMetric)
+			}
+		})
+	}
+}
+
+func TestToPURL(t *testing.T) {
+	e := dockercompose.Extractor{}
+	tests := []struct {
+		name string
+		inv  *extractor.Inventory
+		want *purl.PackageURL
+	}{
+		{
+			name: "tagged image",
+			inv: &extractor.Inventory{
+				Name:     "docker.io/library/postgres",
+				Version:  "16.2",
+				Metadata: &dockercompose.Metadata{Repository: "docker.io/library/postgres", Tag: "16.2"},
+			},
+			want: &purl.PackageURL{
+				Type: purl.TypeOCI,
+				Name: "postgres",
+				Qualifiers: purl.QualifiersFromMap(map[string]string{
+					purl.RepositoryURL: "docker.io/library/postgres",
+					purl.Tag:           "16.2",
+				}),
+			},
+		},
+		{
+			name: "pinned image",
+			inv: &extractor.Inventory{
+				Name:     "docker.io/prom/prometheus",
+				Version:  "sha256:5a6c",
+				Metadata: &dockercompose.Metadata{Repository: "docker.io/prom/prometheus", Digest: "sha256:5a6c"},
+			},
+			want: &purl.PackageURL{
+				Type:       purl.TypeOCI,
+				Name:       "prometheus",
+				Version:    "sha256:5a6c",
+				Qualifiers: purl.QualifiersFromMap(map[string]string{purl.RepositoryURL: "docker.io/prom/prometheus"}),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := e.ToPURL(tt.inv)
+			if err != nil {
+				t.Fatalf("ToPURL(%v): %v", tt.inv, err)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("ToPURL(%v) (-want +got):\n%s", tt.inv, diff)
+			}
+		})
+	}
+}
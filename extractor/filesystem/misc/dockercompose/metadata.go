@@ -0,0 +1,33 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dockercompose
+
+// Metadata holds information about the image of a Docker Compose service.
+type Metadata struct {
+	// The name of the service using the image.
+	ServiceName string
+	// Repository of the image, e.g. "docker.io/library/nginx". Images on
+	// Docker Hub are normalized to their full name. Set to the image as written
+	// in the file if it's interpolated.
+	Repository string
+	// The tag of the image, "latest" if neither a tag nor a digest is set.
+	Tag string
+	// The digest the image is pinned to, if any.
+	Digest string
+	// Whether the image name contains environment variables, e.g.
+	// "${REGISTRY}/app:${TAG}". Such images are reported as written since
+	// the variables are only resolved by Docker Compose.
+	Interpolated bool
+}
@@ -0,0 +1,361 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package embeddedid extracts the PURL and CPE strings that vendors embed in
+// their binaries to identify the software, e.g. "pkg:generic/acme/agent@2.1.0"
+// or "cpe:2.3:a:acme:agent:2.1.0:*:*:*:*:*:*:*".
+//
+// The binaries are searched for the strings, so the results are heuristic. To
+// avoid reporting coincidental byte sequences, only strings that are valid
+// PURLs or CPEs with a name and a version are reported.
+package embeddedid
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem"
+	"github.com/google/osv-scalibr/extractor/filesystem/internal/chunkscan"
+	"github.com/google/osv-scalibr/extractor/filesystem/internal/units"
+	"github.com/google/osv-scalibr/log"
+	"github.com/google/osv-scalibr/plugin"
+	"github.com/google/osv-scalibr/purl"
+	"github.com/google/osv-scalibr/stats"
+)
+
+const (
+	// Name is the unique name of this extractor.
+	Name = "misc/embeddedid"
+
+	// defaultMaxFileSizeBytes is the maximum binary size the extractor will search.
+	defaultMaxFileSizeBytes = 500 * units.MiB
+
+	// chunkOverlap is kept from the previous chunk so that strings spanning two
+	// chunks are found. It's larger than the longest match of any pattern.
+	chunkOverlap = 2048
+)
+
+var (
+	// libraryExtensions are the extensions of shared libraries, which aren't
+	// necessarily marked executable.
+	libraryExtensions = []string{".exe", ".dll", ".so", ".dylib"}
+
+	// Fixed parts of the patterns, to skip chunks without a match.
+	purlPrefix = []byte("pkg:")
+	cpePrefix  = []byte("cpe:2.3:")
+	// A PURL made of the characters allowed in its components. The string has
+	// to start at a word boundary so that e.g. "xpkg:" isn't matched.
+	purlRe = regexp.MustCompile(`(?:^|[^0-9A-Za-z])(pkg:[A-Za-z][0-9A-Za-z.+-]{0,31}/[0-9A-Za-z._~%!$&'()*+,;=:@/?#-]{1,512})`)
+	// A CPE 2.3 formatted string: the part followed by the 10 attributes vendor,
+	// product, version, update, edition, language, sw_edition, target_sw,
+	// target_hw and other. Attributes consist of printable ASCII characters
+	// apart from ":" and "\", which can be escaped with "\".
+	cpeRe = regexp.MustCompile(`(?:^|[^0-9A-Za-z])(cpe:2\.3:[aho](?::(?:\\[!-~]|[!-9;-\[\]-~]){1,96}){10})`)
+	// Punctuation that ends the sentence the PURL is in rather than the PURL.
+	purlTrailer = ".,;:)'"
+)
+
+// Config is the configuration for the Extractor.
+type Config struct {
+	// Stats is a stats collector for reporting metrics.
+	Stats stats.Collector
+	// MaxFileSizeBytes is the maximum size of a binary this extractor will
+	// search. If `FileRequired` gets a bigger file, it will return false.
+	MaxFileSizeBytes int64
+}
+
+// DefaultConfig returns the default configuration for the extractor.
+func DefaultConfig() Config {
+	return Config{
+		MaxFileSizeBytes: defaultMaxFileSizeBytes,
+		Stats:            nil,
+	}
+}
+
+// Extractor extracts the PURLs and CPEs embedded in binaries.
+type Extractor struct {
+	stats            stats.Collector
+	maxFileSizeBytes int64
+}
+
+// New returns an embedded PURL and CPE extractor.
+//
+// For most use cases, initialize with:
+// ```
+// e := New(DefaultConfig())
+// ```
+func New(cfg Config) *Extractor {
+	return &Extractor{
+		stats:            cfg.Stats,
+		maxFileSizeBytes: cfg.MaxFileSizeBytes,
+	}
+}
+
+// Config returns the configuration of the extractor.
+func (e Extractor) Config() Config {
+	return Config{
+		Stats:            e.stats,
+		MaxFileSizeBytes: e.maxFileSizeBytes,
+	}
+}
+
+// Name of the extractor.
+func (e Extractor) Name() string { return Name }
+
+// Version of the extractor.
+func (e Extractor) Version() int { return 0 }
+
+// Requirements of the extractor.
+func (e Extractor) Requirements() *plugin.Capabilities { return &plugin.Capabilities{} }
+
+// FileRequired returns true if the specified file is marked executable or is
+// a shared library. The magic bytes are checked during extraction.
+func (e Extractor) FileRequired(path string, fileinfo fs.FileInfo) bool {
+	return e.fileRequired(path, fileinfo, nil)
+}
+
+// FileRequiredWithFS returns true if the specified file is marked executable
+// or is a shared library, and starts with the magic bytes of an ELF, PE or
+// Mach-O binary.
+func (e Extractor) FileRequiredWithFS(path string, fileinfo fs.FileInfo, file *filesystem.PeekableFile) bool {
+	return e.fileRequired(path, fileinfo, file)
+}
+
+func (e Extractor) fileRequired(path string, fileinfo fs.FileInfo, file *filesystem.PeekableFile) bool {
+	if !fileinfo.Mode().IsRegular() {
+		// Includes dirs, symlinks, sockets, pipes...
+		return false
+	}
+	if fileinfo.Mode()&0111 == 0 && !isLibrary(path) {
+		return false
+	}
+
+	if e.maxFileSizeBytes > 0 && fileinfo.Size() > e.maxFileSizeBytes {
+		e.reportFileRequired(path, fileinfo.Size(), stats.FileRequiredResultSizeLimitExceeded)
+		return false
+	}
+
+	if file != nil {
+		if t, err := file.FileType(); err != nil || !isBinary(t) {
+			return false
+		}
+	}
+
+	e.reportFileRequired(path, fileinfo.Size(), stats.FileRequiredResultOK)
+	return true
+}
+
+// isLibrary returns true if the path has the extension of a Windows
+// executable or of a shared library, including versioned ones such as
+// libfoo.so.1.2.
+func isLibrary(path string) bool {
+	base := strings.ToLower(filepath.Base(path))
+	for _, ext := range libraryExtensions {
+		if strings.HasSuffix(base, ext) {
+			return true
+		}
+	}
+	return strings.Contains(base, ".so.")
+}
+
+func isBinary(t filesystem.FileType) bool {
+	return t == filesystem.FileTypeELF || t == filesystem.FileTypePE || t == filesystem.FileTypeMachO
+}
+
+func (e Extractor) reportFileRequired(path string, fileSizeBytes int64, result stats.FileRequiredResult) {
+	if e.stats == nil {
+		return
+	}
+	e.stats.AfterFileRequired(e.Name(), &stats.FileRequiredStats{
+		Path:          path,
+		Result:        result,
+		FileSizeBytes: fileSizeBytes,
+	})
+}
+
+// Extract returns the PURLs and CPEs embedded in the binary passed through
+// the scan input.
+func (e Extractor) Extract(ctx context.Context, input *filesystem.ScanInput) ([]*extractor.Inventory, error) {
+	inventory, err := e.extractFromInput(ctx, input)
+	if e.stats != nil {
+		var fileSizeBytes int64
+		if input.Info != nil {
+			fileSizeBytes = input.Info.Size()
+		}
+		e.stats.AfterFileExtracted(e.Name(), &stats.FileExtractedStats{
+			Path:          input.Path,
+			Result:        filesystem.ExtractorErrorToFileExtractedResult(err),
+			FileSizeBytes: fileSizeBytes,
+		})
+	}
+	return inventory, err
+}
+
+func (e Extractor) extractFromInput(ctx context.Context, input *filesystem.ScanInput) ([]*extractor.Inventory, error) {
+	header := make([]byte, filesystem.SniffSize)
+	n, err := io.ReadFull(input.Reader, header)
+	if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+		return nil, fmt.Errorf("failed to read %q: %w", input.Path, err)
+	}
+	header = header[:n]
+	if !isBinary(filesystem.DetectFileType(header)) {
+		return nil, nil
+	}
+
+	inventory, err := search(ctx, io.MultiReader(bytes.NewReader(header), input.Reader))
+	if err != nil {
+		return nil, fmt.Errorf("failed to search %q: %w", input.Path, err)
+	}
+	for _, i := range inventory {
+		i.Locations = []string{input.Path}
+	}
+	return inventory, nil
+}
+
+// search returns the valid PURLs and CPEs found in r, in the order of their
+// first occurrence.
+func search(ctx context.Context, r io.Reader) ([]*extractor.Inventory, error) {
+	var result []*extractor.Inventory
+	seen := make(map[string]bool)
+	add := func(id string, parse func(string) *extractor.Inventory) {
+		if seen[id] {
+			return
+		}
+		seen[id] = true
+		if i := parse(id); i != nil {
+			result = append(result, i)
+		} else {
+			log.Debugf("Ignoring invalid embedded identifier %q", id)
+		}
+	}
+
+	err := chunkscan.Scan(ctx, r, chunkOverlap, func(chunk []byte, kept int, atEOF bool) {
+		// The regular expressions are slow, so they only run on chunks that
+		// contain their fixed part.
+		for _, p := range []struct {
+			prefix []byte
+			re     *regexp.Regexp
+			parse  func(string) *extractor.Inventory
+		}{
+			{purlPrefix, purlRe, parsePURL},
+			{cpePrefix, cpeRe, parseCPE},
+		} {
+			if !bytes.Contains(chunk, p.prefix) {
+				continue
+			}
+			for _, m := range p.re.FindAllSubmatchIndex(chunk, -1) {
+				if m[1] == len(chunk) && !atEOF {
+					// Might be cut off, see chunkscan.Func.
+					continue
+				}
+				if m[2] == 0 && kept > 0 {
+					// Already matched in the previous chunk, where the byte before
+					// it was known.
+					continue
+				}
+				add(string(chunk[m[2]:m[3]]), p.parse)
+			}
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// parsePURL returns the inventory for an embedded PURL, or nil if it's not a
+// valid PURL of a known type with a name and a version.
+func parsePURL(s string) *extractor.Inventory {
+	s = strings.TrimRight(s, purlTrailer)
+	p, err := purl.FromString(s)
+	if err != nil || p.Name == "" || p.Version == "" {
+		return nil
+	}
+	return &extractor.Inventory{
+		Name:     p.Name,
+		Version:  p.Version,
+		Metadata: &Metadata{PURL: &p},
+	}
+}
+
+// parseCPE returns the inventory for an embedded CPE, or nil if the CPE
+// doesn't name a specific vendor, product and version.
+func parseCPE(s string) *extractor.Inventory {
+	attrs := splitCPE(s)
+	// cpe, 2.3, part, vendor, product, version, ...
+	vendor, product, version := attrs[3], attrs[4], attrs[5]
+	for _, a := range []string{vendor, product, version} {
+		if a == "*" || a == "-" {
+			return nil
+		}
+	}
+	return &extractor.Inventory{
+		Name:     unescapeCPE(product),
+		Version:  unescapeCPE(version),
+		Metadata: &Metadata{CPE: s},
+	}
+}
+
+// splitCPE splits a CPE formatted string at the colons that aren't escaped.
+func splitCPE(s string) []string {
+	var attrs []string
+	start := 0
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case ':':
+			attrs = append(attrs, s[start:i])
+			start = i + 1
+		}
+	}
+	return append(attrs, s[start:])
+}
+
+// unescapeCPE removes the escaping backslashes of a CPE attribute, e.g.
+// "1\.0" becomes "1.0".
+func unescapeCPE(attr string) string {
+	var b strings.Builder
+	for i := 0; i < len(attr); i++ {
+		if attr[i] == '\\' && i+1 < len(attr) {
+			i++
+		}
+		b.WriteByte(attr[i])
+	}
+	return b.String()
+}
+
+// ToPURL returns the embedded PURL. Inventory found through a CPE has none.
+func (e Extractor) ToPURL(i *extractor.Inventory) (*purl.PackageURL, error) {
+	return i.Metadata.(*Metadata).PURL, nil
+}
+
+// ToCPEs returns the embedded CPE. Inventory found through a PURL has none.
+func (e Extractor) ToCPEs(i *extractor.Inventory) ([]string, error) {
+	if cpe := i.Metadata.(*Metadata).CPE; cpe != "" {
+		return []string{cpe}, nil
+	}
+	return []string{}, nil
+}
+
+// Ecosystem returns no ecosystem since the software isn't necessarily
+// distributed through a package manager.
+func (Extractor) Ecosystem(i *extractor.Inventory) (string, error) { return "", nil }
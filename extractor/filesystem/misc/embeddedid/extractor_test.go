@@ -0,0 +1,337 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package embeddedid_test
+
+import (
+	"bytes"
+	"context"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem"
+	"github.com/google/osv-scalibr/extractor/filesystem/internal/units"
+	"github.com/google/osv-scalibr/extractor/filesystem/misc/embeddedid"
+	scalibrfs "github.com/google/osv-scalibr/fs"
+	"github.com/google/osv-scalibr/purl"
+	"github.com/google/osv-scalibr/stats"
+	"github.com/google/osv-scalibr/testing/fakefs"
+	"github.com/google/osv-scalibr/testing/testcollector"
+)
+
+func TestFileRequired(t *testing.T) {
+	tests := []struct {
+		name             string
+		path             string
+		mode             fs.FileMode
+		fileSizeBytes    int64
+		maxFileSizeBytes int64
+		wantRequired     bool
+		wantResultMetric stats.FileRequiredResult
+	}{
+		{
+			name:             "executable",
+			path:             "opt/acme/bin/agent",
+			mode:             0755,
+			wantRequired:     true,
+			wantResultMetric: stats.FileRequiredResultOK,
+		}, {
+			name:             "Windows library",
+			path:             "Program Files/Acme/acme.dll",
+			mode:             0644,
+			wantRequired:     true,
+			wantResultMetric: stats.FileRequiredResultOK,
+		}, {
+			name:             "versioned shared library",
+			path:             "usr/lib/libacme.so.2.1",
+			mode:             0644,
+			wantRequired:     true,
+			wantResultMetric: stats.FileRequiredResultOK,
+		}, {
+			name:         "not executable",
+			path:         "usr/share/doc/acme/README",
+			mode:         0644,
+			wantRequired: false,
+		}, {
+			name:         "directory",
+			path:         "opt/acme/bin",
+			mode:         fs.ModeDir | 0755,
+			wantRequired: false,
+		}, {
+			name:             "file size limit exceeded",
+			path:             "opt/acme/bin/agent",
+			mode:             0755,
+			fileSizeBytes:    1 * units.GiB,
+			maxFileSizeBytes: 1 * units.MiB,
+			wantRequired:     false,
+			wantResultMetric: stats.FileRequiredResultSizeLimitExceeded,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			collector := testcollector.New()
+			var e filesystem.Extractor = embeddedid.New(embeddedid.Config{
+				Stats:            collector,
+				MaxFileSizeBytes: tt.maxFileSizeBytes,
+			})
+
+			fileSizeBytes := tt.fileSizeBytes
+			if fileSizeBytes == 0 {
+				fileSizeBytes = 1000
+			}
+			isRequired := e.FileRequired(tt.path, fakefs.FakeFileInfo{
+				FileName: filepath.Base(tt.path),
+				FileMode: tt.mode,
+				FileSize: fileSizeBytes,
+			})
+			if isRequired != tt.wantRequired {
+				t.Fatalf("FileRequired(%s): got %v, want %v", tt.path, isRequired, tt.wantRequired)
+			}
+
+			gotResultMetric := collector.FileRequiredResult(tt.path)
+			if tt.wantResultMetric != "" && gotResultMetric != tt.wantResultMetric {
+				t.Errorf("FileRequired(%s) recorded result metric %v, want result metric %v", tt.path, gotResultMetric, tt.wantResultMetric)
+			}
+		})
+	}
+}
+
+func TestFileRequiredWithFS(t *testing.T) {
+	fsys := fstest.MapFS{
+		"bin/elf":         {Data: []byte("\x7fELF\x02\x01\x01\x00"), Mode: 0755},
+		"bin/script":      {Data: []byte("#!/bin/sh\n"), Mode: 0755},
+		"lib/libacme.so":  {Data: []byte("\x7fELF\x02\x01\x01\x00"), Mode: 0644},
+		"lib/fake.dll":    {Data: []byte("not a binary"), Mode: 0644},
+		"share/elf-data":  {Data: []byte("\x7fELF\x02\x01\x01\x00"), Mode: 0644},
+		"bin/empty":       {Data: []byte{}, Mode: 0755},
+		"bin/macho-fat":   {Data: []byte{0xca, 0xfe, 0xba, 0xbe, 0, 0, 0, 2}, Mode: 0755},
+		"bin/windows.exe": {Data: []byte("MZ\x90\x00"), Mode: 0644},
+	}
+	tests := []struct {
+		path         string
+		wantRequired bool
+	}{
+		{path: "bin/elf", wantRequired: true},
+		{path: "bin/script", wantRequired: false},
+		{path: "lib/libacme.so", wantRequired: true},
+		{path: "lib/fake.dll", wantRequired: false},
+		{path: "share/elf-data", wantRequired: false},
+		{path: "bin/empty", wantRequired: false},
+		{path: "bin/macho-fat", wantRequired: true},
+		{path: "bin/windows.exe", wantRequired: true},
+	}
+
+	e := embeddedid.New(embeddedid.DefaultConfig())
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			info, err := fs.Stat(fsys, tt.path)
+			if err != nil {
+				t.Fatalf("fs.Stat(%s): %v", tt.path, err)
+			}
+			file := filesystem.NewPeekableFile(scalibrfs.FromFS(fsys), tt.path)
+			defer file.Close()
+
+			if got := e.FileRequiredWithFS(tt.path, info, file); got != tt.wantRequired {
+				t.Errorf("FileRequiredWithFS(%s): got %v, want %v", tt.path, got, tt.wantRequired)
+			}
+		})
+	}
+}
+
+func TestExtract(t *testing.T) {
+	tests := []struct {
+		name          string
+		path          string
+		wantInventory []*extractor.Inventory
+	}{
+		{
+			name: "ELF with PURLs and CPEs",
+			path: "agent",
+			wantInventory: []*extractor.Inventory{
+				{
+					Name:    "agent",
+					Version: "2.1.0",
+					Metadata: &embeddedid.Metadata{
+						PURL: &purl.PackageURL{
+							Type:       purl.TypeGeneric,
+							Namespace:  "acme",
+							Name:       "agent",
+							Version:    "2.1.0",
+							Qualifiers: purl.QualifiersFromMap(map[string]string{"arch": "x86_64"}),
+						},
+					},
+					Locations: []string{"agent"},
+				},
+				{
+					Name:    "left-pad",
+					Version: "1.3.0",
+					Metadata: &embeddedid.Metadata{
+						PURL: &purl.PackageURL{Type: purl.TypeNPM, Name: "left-pad", Version: "1.3.0"},
+					},
+					Locations: []string{"agent"},
+				},
+				{
+					Name:      "agent",
+					Version:   "2.1.0",
+					Metadata:  &embeddedid.Metadata{CPE: "cpe:2.3:a:acme:agent:2.1.0:*:*:*:*:*:*:*"},
+					Locations: []string{"agent"},
+				},
+				{
+					Name:      "agent_tools",
+					Version:   "1.0",
+					Metadata:  &embeddedid.Metadata{CPE: `cpe:2.3:a:acme:agent_tools:1\.0:*:*:*:*:*:*:*`},
+					Locations: []string{"agent"},
+				},
+			},
+		}, {
+			name: "PE",
+			path: "acme.dll",
+			wantInventory: []*extractor.Inventory{
+				{
+					Name:    "Acme.Core",
+					Version: "4.2.1",
+					Metadata: &embeddedid.Metadata{
+						PURL: &purl.PackageURL{Type: purl.TypeNuget, Name: "Acme.Core", Version: "4.2.1"},
+					},
+					Locations: []string{"acme.dll"},
+				},
+			},
+		}, {
+			name:          "script isn't a binary",
+			path:          "script.sh",
+			wantInventory: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			collector := testcollector.New()
+			var e filesystem.Extractor = embeddedid.New(embeddedid.Config{
+				Stats:            collector,
+				MaxFileSizeBytes: 100 * units.MiB,
+			})
+
+			r, err := os.Open(filepath.Join("testdata", tt.path))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer r.Close()
+			info, err := r.Stat()
+			if err != nil {
+				t.Fatalf("Stat(): %v", err)
+			}
+
+			input := &filesystem.ScanInput{
+				FS:     scalibrfs.DirFS("testdata"),
+				Path:   tt.path,
+				Reader: r,
+				Root:   "testdata",
+				Info:   info,
+			}
+			got, err := e.Extract(context.Background(), input)
+			if err != nil {
+				t.Fatalf("Extract(%s): %v", tt.path, err)
+			}
+
+			if diff := cmp.Diff(tt.wantInventory, got, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("Extract(%s) (-want +got):\n%s", tt.path, diff)
+			}
+
+			gotResultMetric := collector.FileExtractedResult(tt.path)
+			if gotResultMetric != stats.FileExtractedResultSuccess {
+				t.Errorf("Extract(%s) recorded result metric %v, want result metric %v", tt.path, gotResultMetric, stats.FileExtractedResultSuccess)
+			}
+		})
+	}
+}
+
+func TestExtractAcrossChunks(t *testing.T) {
+	// Place the PURL across the boundary of the 1 MiB search chunks.
+	id := []byte("pkg:generic/acme/agent@2.1.0")
+	content := make([]byte, 3*units.MiB)
+	copy(content, "\x7fELF")
+	copy(content[units.MiB-10:], id)
+
+	e := embeddedid.New(embeddedid.DefaultConfig())
+	got, err := e.Extract(context.Background(), &filesystem.ScanInput{
+		Path:   "agent",
+		Reader: bytes.NewReader(content),
+	})
+	if err != nil {
+		t.Fatalf("Extract(): %v", err)
+	}
+	want := []*extractor.Inventory{
+		{
+			Name:    "agent",
+			Version: "2.1.0",
+			Metadata: &embeddedid.Metadata{
+				PURL: &purl.PackageURL{Type: purl.TypeGeneric, Namespace: "acme", Name: "agent", Version: "2.1.0"},
+			},
+			Locations: []string{"agent"},
+		},
+	}
+	if diff := cmp.Diff(want, got, cmpopts.EquateEmpty()); diff != "" {
+		t.Errorf("Extract() (-want +got):\n%s", diff)
+	}
+}
+
+func TestToPURLAndCPEs(t *testing.T) {
+	p := &purl.PackageURL{Type: purl.TypeGeneric, Namespace: "acme", Name: "agent", Version: "2.1.0"}
+	cpe := "cpe:2.3:a:acme:agent:2.1.0:*:*:*:*:*:*:*"
+	tests := []struct {
+		name      string
+		inventory *extractor.Inventory
+		wantPURL  *purl.PackageURL
+		wantCPEs  []string
+	}{
+		{
+			name:      "PURL",
+			inventory: &extractor.Inventory{Name: "agent", Version: "2.1.0", Metadata: &embeddedid.Metadata{PURL: p}},
+			wantPURL:  p,
+			wantCPEs:  []string{},
+		}, {
+			name:      "CPE",
+			inventory: &extractor.Inventory{Name: "agent", Version: "2.1.0", Metadata: &embeddedid.Metadata{CPE: cpe}},
+			wantPURL:  nil,
+			wantCPEs:  []string{cpe},
+		},
+	}
+
+	e := embeddedid.New(embeddedid.DefaultConfig())
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotPURL, err := e.ToPURL(tt.inventory)
+			if err != nil {
+				t.Fatalf("ToPURL(%v): %v", tt.inventory, err)
+			}
+			if diff := cmp.Diff(tt.wantPURL, gotPURL); diff != "" {
+				t.Errorf("ToPURL(%v) (-want +got):\n%s", tt.inventory, diff)
+			}
+			gotCPEs, err := e.ToCPEs(tt.inventory)
+			if err != nil {
+				t.Fatalf("ToCPEs(%v): %v", tt.inventory, err)
+			}
+			if diff := cmp.Diff(tt.wantCPEs, gotCPEs); diff != "" {
+				t.Errorf("ToCPEs(%v) (-want +got):\n%s", tt.inventory, diff)
+			}
+		})
+	}
+}
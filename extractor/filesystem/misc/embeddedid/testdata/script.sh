@@ -0,0 +1,3 @@
+#!/bin/sh
+# pkg:generic/acme/script@1.0.0
+echo hello
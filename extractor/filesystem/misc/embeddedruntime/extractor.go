@@ -0,0 +1,378 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package embeddedruntime extracts the versions of language runtimes that are
+// statically linked into executables, e.g. the Go runtime of a Go binary or
+// the Node.js runtime of a single executable application.
+//
+// Apart from the Go build info, the versions are found by searching for
+// version strings in the binary, so the results are heuristic. To keep false
+// positives low, only patterns with fixed context around the version are used.
+package embeddedruntime
+
+import (
+	"bytes"
+	"context"
+	"debug/buildinfo"
+	"fmt"
+	"io"
+	"io/fs"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem"
+	"github.com/google/osv-scalibr/extractor/filesystem/internal/chunkscan"
+	"github.com/google/osv-scalibr/extractor/filesystem/internal/units"
+	"github.com/google/osv-scalibr/log"
+	"github.com/google/osv-scalibr/plugin"
+	"github.com/google/osv-scalibr/purl"
+	"github.com/google/osv-scalibr/stats"
+)
+
+const (
+	// Name is the unique name of this extractor.
+	Name = "misc/embeddedruntime"
+
+	// defaultMaxFileSizeBytes is the maximum binary size the extractor will search.
+	defaultMaxFileSizeBytes = 500 * units.MiB
+
+	// chunkOverlap is kept from the previous chunk so that matches spanning two
+	// chunks are found. It's larger than the longest match of any pattern.
+	chunkOverlap = 512
+)
+
+// Names of the runtimes reported by the extractor.
+const (
+	RuntimeGo   = "go"
+	RuntimeNode = "node"
+	RuntimeRust = "rust"
+)
+
+var (
+	// executableMagics are the file headers of ELF, PE and Mach-O binaries.
+	executableMagics = [][]byte{
+		[]byte("\x7fELF"),
+		[]byte("MZ"),
+		{0xfe, 0xed, 0xfa, 0xce}, {0xce, 0xfa, 0xed, 0xfe}, // 32-bit Mach-O
+		{0xfe, 0xed, 0xfa, 0xcf}, {0xcf, 0xfa, 0xed, 0xfe}, // 64-bit Mach-O
+		{0xca, 0xfe, 0xba, 0xbe}, // Universal Mach-O
+	}
+
+	// goMarker is the name of a runtime function that's kept in the function
+	// table of every Go binary, even stripped ones. The Go version string is
+	// only trusted if the marker is present.
+	goMarker = []byte("runtime.goexit")
+	// Fixed parts of the version patterns, to skip chunks without a match.
+	goPrefix   = []byte("go1.")
+	nodePrefix = []byte("nodejs.org/download/release/")
+	rustPrefix = []byte("rustc version ")
+	// The value of runtime.buildVersion, e.g. "go1.12.17". The byte after the
+	// match is checked separately so that adjacent strings are all matched.
+	goVersionRe = regexp.MustCompile(`(?:^|[^0-9A-Za-z_./-])go(1\.[0-9]{1,2}(?:\.[0-9]{1,2})?(?:(?:beta|rc)[0-9]{1,2})?)`)
+	// The headers URL of process.release, e.g.
+	// https://nodejs.org/download/release/v20.11.0/node-v20.11.0-headers.tar.gz
+	nodeVersionRe = regexp.MustCompile(`https://nodejs\.org/download/release/v([0-9]+\.[0-9]+\.[0-9]+)/node-v([0-9]+\.[0-9]+\.[0-9]+)-headers\.tar\.gz`)
+	// The compiler identification in the .comment section, e.g.
+	// "rustc version 1.75.0 (82e1608df 2023-12-21)".
+	rustVersionRe = regexp.MustCompile(`rustc version ([0-9]+\.[0-9]+\.[0-9]+(?:-(?:beta|nightly)(?:\.[0-9]+)?)?) \([0-9a-f]{7,40} [0-9]{4}-[0-9]{2}-[0-9]{2}\)`)
+)
+
+// Config is the configuration for the Extractor.
+type Config struct {
+	// Stats is a stats collector for reporting metrics.
+	Stats stats.Collector
+	// MaxFileSizeBytes is the maximum size of a binary this extractor will
+	// search. If `FileRequired` gets a bigger file, it will return false.
+	MaxFileSizeBytes int64
+}
+
+// DefaultConfig returns the default configuration for the extractor.
+func DefaultConfig() Config {
+	return Config{
+		MaxFileSizeBytes: defaultMaxFileSizeBytes,
+		Stats:            nil,
+	}
+}
+
+// Extractor extracts the versions of the language runtimes embedded in binaries.
+type Extractor struct {
+	stats            stats.Collector
+	maxFileSizeBytes int64
+}
+
+// New returns an embedded runtime extractor.
+//
+// For most use cases, initialize with:
+// ```
+// e := New(DefaultConfig())
+// ```
+func New(cfg Config) *Extractor {
+	return &Extractor{
+		stats:            cfg.Stats,
+		maxFileSizeBytes: cfg.MaxFileSizeBytes,
+	}
+}
+
+// Config returns the configuration of the extractor.
+func (e Extractor) Config() Config {
+	return Config{
+		Stats:            e.stats,
+		MaxFileSizeBytes: e.maxFileSizeBytes,
+	}
+}
+
+// Name of the extractor.
+func (e Extractor) Name() string { return Name }
+
+// Version of the extractor.
+func (e Extractor) Version() int { return 0 }
+
+// Requirements of the extractor.
+func (e Extractor) Requirements() *plugin.Capabilities { return &plugin.Capabilities{} }
+
+// FileRequired returns true if the specified file is marked executable. The
+// file header is checked for the magic bytes of an executable format in Extract.
+func (e Extractor) FileRequired(path string, fileinfo fs.FileInfo) bool {
+	if !fileinfo.Mode().IsRegular() {
+		// Includes dirs, symlinks, sockets, pipes...
+		return false
+	}
+
+	// Either windows .exe or unix executable bit should be set.
+	if filepath.Ext(path) != ".exe" && fileinfo.Mode()&0111 == 0 {
+		return false
+	}
+
+	if e.maxFileSizeBytes > 0 && fileinfo.Size() > e.maxFileSizeBytes {
+		e.reportFileRequired(path, fileinfo.Size(), stats.FileRequiredResultSizeLimitExceeded)
+		return false
+	}
+
+	e.reportFileRequired(path, fileinfo.Size(), stats.FileRequiredResultOK)
+	return true
+}
+
+func (e Extractor) reportFileRequired(path string, fileSizeBytes int64, result stats.FileRequiredResult) {
+	if e.stats == nil {
+		return
+	}
+	e.stats.AfterFileRequired(e.Name(), &stats.FileRequiredStats{
+		Path:          path,
+		Result:        result,
+		FileSizeBytes: fileSizeBytes,
+	})
+}
+
+// Extract returns the language runtimes embedded in the binary passed through
+// the scan input.
+func (e Extractor) Extract(ctx context.Context, input *filesystem.ScanInput) ([]*extractor.Inventory, error) {
+	inventory, err := e.extractFromInput(ctx, input)
+	if e.stats != nil {
+		var fileSizeBytes int64
+		if input.Info != nil {
+			fileSizeBytes = input.Info.Size()
+		}
+		e.stats.AfterFileExtracted(e.Name(), &stats.FileExtractedStats{
+			Path:          input.Path,
+			Result:        filesystem.ExtractorErrorToFileExtractedResult(err),
+			FileSizeBytes: fileSizeBytes,
+		})
+	}
+	return inventory, err
+}
+
+func (e Extractor) extractFromInput(ctx context.Context, input *filesystem.ScanInput) ([]*extractor.Inventory, error) {
+	header := make([]byte, 4)
+	n, err := io.ReadFull(input.Reader, header)
+	if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+		return nil, fmt.Errorf("failed to read %q: %w", input.Path, err)
+	}
+	header = header[:n]
+	if !isExecutable(header) {
+		return nil, nil
+	}
+
+	versions, err := searchVersions(ctx, io.MultiReader(bytes.NewReader(header), input.Reader))
+	if err != nil {
+		return nil, fmt.Errorf("failed to search %q: %w", input.Path, err)
+	}
+
+	// The build info is more reliable than the version string if it's present.
+	if ra, ok := input.Reader.(io.ReaderAt); ok {
+		if binfo, err := buildinfo.Read(ra); err == nil && strings.HasPrefix(binfo.GoVersion, "go") {
+			// Development versions have a suffix, e.g. "go1.20-pre3 +a813be86df".
+			version := strings.TrimPrefix(strings.Fields(binfo.GoVersion)[0], "go")
+			versions[RuntimeGo] = &match{version: version, metadata: &Metadata{Evidence: "Go build info", Confidence: ConfidenceHigh}}
+		}
+	}
+
+	var inventory []*extractor.Inventory
+	for _, runtime := range []string{RuntimeGo, RuntimeNode, RuntimeRust} {
+		m, ok := versions[runtime]
+		if !ok {
+			continue
+		}
+		log.Debugf("Found %s %s in %q: %s", runtime, m.version, input.Path, m.metadata.Evidence)
+		inventory = append(inventory, &extractor.Inventory{
+			Name:      runtime,
+			Version:   m.version,
+			Metadata:  m.metadata,
+			Locations: []string{input.Path},
+		})
+	}
+	return inventory, nil
+}
+
+func isExecutable(header []byte) bool {
+	for _, magic := range executableMagics {
+		if bytes.HasPrefix(header, magic) {
+			return true
+		}
+	}
+	return false
+}
+
+// match is a runtime version found in a binary.
+type match struct {
+	version  string
+	metadata *Metadata
+}
+
+// searchVersions returns the version strings found for each runtime.
+func searchVersions(ctx context.Context, r io.Reader) (map[string]*match, error) {
+	result := make(map[string]*match)
+	// Go binaries such as the go command also contain the versions of older
+	// releases, so the newest one is used.
+	var goVersion string
+	foundGoMarker := false
+
+	err := chunkscan.Scan(ctx, r, chunkOverlap, func(chunk []byte, _ int, atEOF bool) {
+		if !foundGoMarker {
+			foundGoMarker = bytes.Contains(chunk, goMarker)
+		}
+		// The regular expressions are slow, so they only run on chunks that
+		// contain their fixed part.
+		if bytes.Contains(chunk, goPrefix) {
+			for _, m := range goVersionRe.FindAllSubmatchIndex(chunk, -1) {
+				if m[1] == len(chunk) && !atEOF {
+					// Might be cut off, see chunkscan.Func.
+					continue
+				}
+				if m[1] < len(chunk) && (chunk[m[1]] == '.' || isDigit(chunk[m[1]])) {
+					// Part of a longer version, e.g. go1.123.
+					continue
+				}
+				if v := string(chunk[m[2]:m[3]]); goVersion == "" || compareGoVersions(v, goVersion) > 0 {
+					goVersion = v
+				}
+			}
+		}
+		if _, ok := result[RuntimeNode]; !ok && bytes.Contains(chunk, nodePrefix) {
+			for _, m := range nodeVersionRe.FindAllSubmatch(chunk, -1) {
+				// Both versions in the URL need to match.
+				if bytes.Equal(m[1], m[2]) {
+					result[RuntimeNode] = stringMatch(m[1], string(m[0]))
+					break
+				}
+			}
+		}
+		if _, ok := result[RuntimeRust]; !ok && bytes.Contains(chunk, rustPrefix) {
+			if m := rustVersionRe.FindSubmatch(chunk); m != nil {
+				result[RuntimeRust] = stringMatch(m[1], string(m[0]))
+			}
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if foundGoMarker && goVersion != "" {
+		result[RuntimeGo] = stringMatch([]byte(goVersion), "go"+goVersion)
+	}
+	return result, nil
+}
+
+// compareGoVersions compares Go versions such as "1.21", "1.21.5" and
+// "1.22rc1". Pre-releases are older than the release they precede.
+func compareGoVersions(a, b string) int {
+	pa, pb := goVersionParts(a), goVersionParts(b)
+	for i := range pa {
+		if pa[i] != pb[i] {
+			return pa[i] - pb[i]
+		}
+	}
+	return 0
+}
+
+// goVersionParts returns the minor version, the pre-release rank and the
+// patch version of a Go version, in comparison order. Releases rank above
+// release candidates, which rank above betas.
+func goVersionParts(v string) [3]int {
+	minor, patch, _ := strings.Cut(strings.TrimPrefix(v, "1."), ".")
+	rank := 300
+	if m, n, ok := strings.Cut(minor, "rc"); ok {
+		minor, rank = m, 200+atoi(n)
+	} else if m, n, ok := strings.Cut(minor, "beta"); ok {
+		minor, rank = m, 100+atoi(n)
+	}
+	return [3]int{atoi(minor), rank, atoi(patch)}
+}
+
+func isDigit(b byte) bool {
+	return '0' <= b && b <= '9'
+}
+
+func atoi(s string) int {
+	// The version regex only matches digits here.
+	n, _ := strconv.Atoi(s)
+	return n
+}
+
+func stringMatch(version []byte, evidence string) *match {
+	return &match{
+		version:  string(version),
+		metadata: &Metadata{Evidence: evidence, Confidence: ConfidenceMedium},
+	}
+}
+
+// ToPURL converts an inventory created by this extractor into a PURL.
+func (e Extractor) ToPURL(i *extractor.Inventory) (*purl.PackageURL, error) {
+	if i.Name == RuntimeGo {
+		return &purl.PackageURL{
+			Type:    purl.TypeGolang,
+			Name:    i.Name,
+			Version: i.Version,
+		}, nil
+	}
+	return &purl.PackageURL{
+		Type:    purl.TypeGeneric,
+		Name:    i.Name,
+		Version: i.Version,
+	}, nil
+}
+
+// ToCPEs is not applicable as this extractor does not infer CPEs from the Inventory.
+func (e Extractor) ToCPEs(i *extractor.Inventory) ([]string, error) { return []string{}, nil }
+
+// Ecosystem returns the OSV Ecosystem of the software extracted by this extractor.
+// Only the Go runtime has one.
+func (Extractor) Ecosystem(i *extractor.Inventory) (string, error) {
+	if i.Name == RuntimeGo {
+		return "Go", nil
+	}
+	return "", nil
+}
@@ -0,0 +1,306 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package embeddedruntime_test
+
+import (
+	"bytes"
+	"context"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem"
+	"github.com/google/osv-scalibr/extractor/filesystem/internal/units"
+	"github.com/google/osv-scalibr/extractor/filesystem/misc/embeddedruntime"
+	scalibrfs "github.com/google/osv-scalibr/fs"
+	"github.com/google/osv-scalibr/purl"
+	"github.com/google/osv-scalibr/stats"
+	"github.com/google/osv-scalibr/testing/fakefs"
+	"github.com/google/osv-scalibr/testing/testcollector"
+)
+
+func TestFileRequired(t *testing.T) {
+	tests := []struct {
+		name             string
+		path             string
+		mode             fs.FileMode
+		fileSizeBytes    int64
+		maxFileSizeBytes int64
+		wantRequired     bool
+		wantResultMetric stats.FileRequiredResult
+	}{
+		{
+			name:             "executable",
+			path:             "usr/local/bin/app",
+			mode:             0755,
+			wantRequired:     true,
+			wantResultMetric: stats.FileRequiredResultOK,
+		}, {
+			name:             "Windows executable",
+			path:             "Program Files/app/app.exe",
+			mode:             0644,
+			wantRequired:     true,
+			wantResultMetric: stats.FileRequiredResultOK,
+		}, {
+			name:         "not executable",
+			path:         "usr/share/doc/app/README",
+			mode:         0644,
+			wantRequired: false,
+		}, {
+			name:         "directory",
+			path:         "usr/local/bin",
+			mode:         fs.ModeDir | 0755,
+			wantRequired: false,
+		}, {
+			name:             "file size limit exceeded",
+			path:             "usr/local/bin/app",
+			mode:             0755,
+			fileSizeBytes:    1 * units.GiB,
+			maxFileSizeBytes: 1 * units.MiB,
+			wantRequired:     false,
+			wantResultMetric: stats.FileRequiredResultSizeLimitExceeded,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			collector := testcollector.New()
+			var e filesystem.Extractor = embeddedruntime.New(embeddedruntime.Config{
+				Stats:            collector,
+				MaxFileSizeBytes: tt.maxFileSizeBytes,
+			})
+
+			fileSizeBytes := tt.fileSizeBytes
+			if fileSizeBytes == 0 {
+				fileSizeBytes = 1000
+			}
+			isRequired := e.FileRequired(tt.path, fakefs.FakeFileInfo{
+				FileName: filepath.Base(tt.path),
+				FileMode: tt.mode,
+				FileSize: fileSizeBytes,
+			})
+			if isRequired != tt.wantRequired {
+				t.Fatalf("FileRequired(%s): got %v, want %v", tt.path, isRequired, tt.wantRequired)
+			}
+
+			gotResultMetric := collector.FileRequiredResult(tt.path)
+			if tt.wantResultMetric != "" && gotResultMetric != tt.wantResultMetric {
+				t.Errorf("FileRequired(%s) recorded result metric %v, want result metric %v", tt.path, gotResultMetric, tt.wantResultMetric)
+			}
+		})
+	}
+}
+
+func TestExtract(t *testing.T) {
+	// Go binaries with build info are checked in for the Go binary extractor.
+	goBinaryTestdata := "../../language/golang/gobinary/testdata"
+
+	tests := []struct {
+		name          string
+		dir           string
+		path          string
+		wantInventory []*extractor.Inventory
+	}{
+		{
+			name: "Go build info",
+			dir:  goBinaryTestdata,
+			path: "binary_with_module_replacement-linux-amd64",
+			wantInventory: []*extractor.Inventory{
+				{
+					Name:    "go",
+					Version: "1.22.0",
+					Metadata: &embeddedruntime.Metadata{
+						Evidence:   "Go build info",
+						Confidence: embeddedruntime.ConfidenceHigh,
+					},
+					Locations: []string{"binary_with_module_replacement-linux-amd64"},
+				},
+			},
+		}, {
+			name: "Go version string uses newest version",
+			path: "go_stripped",
+			wantInventory: []*extractor.Inventory{
+				{
+					Name:    "go",
+					Version: "1.12.17",
+					Metadata: &embeddedruntime.Metadata{
+						Evidence:   "go1.12.17",
+						Confidence: embeddedruntime.ConfidenceMedium,
+					},
+					Locations: []string{"go_stripped"},
+				},
+			},
+		}, {
+			name:          "Go version string without Go runtime",
+			path:          "go_without_runtime",
+			wantInventory: nil,
+		}, {
+			name: "Node.js release URL",
+			path: "node_macho",
+			wantInventory: []*extractor.Inventory{
+				{
+					Name:    "node",
+					Version: "20.11.0",
+					Metadata: &embeddedruntime.Metadata{
+						Evidence:   "https://nodejs.org/download/release/v20.11.0/node-v20.11.0-headers.tar.gz",
+						Confidence: embeddedruntime.ConfidenceMedium,
+					},
+					Locations: []string{"node_macho"},
+				},
+			},
+		}, {
+			name:          "Node.js release URL with different versions",
+			path:          "node_version_mismatch",
+			wantInventory: nil,
+		}, {
+			name: "rustc version comment",
+			path: "rust.exe",
+			wantInventory: []*extractor.Inventory{
+				{
+					Name:    "rust",
+					Version: "1.75.0",
+					Metadata: &embeddedruntime.Metadata{
+						Evidence:   "rustc version 1.75.0 (82e1608df 2023-12-21)",
+						Confidence: embeddedruntime.ConfidenceMedium,
+					},
+					Locations: []string{"rust.exe"},
+				},
+			},
+		}, {
+			name:          "script isn't a binary",
+			path:          "script.sh",
+			wantInventory: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			collector := testcollector.New()
+			var e filesystem.Extractor = embeddedruntime.New(embeddedruntime.Config{
+				Stats:            collector,
+				MaxFileSizeBytes: 100 * units.MiB,
+			})
+
+			d := tt.dir
+			if d == "" {
+				d = "testdata"
+			}
+			r, err := os.Open(filepath.Join(d, tt.path))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer r.Close()
+			info, err := r.Stat()
+			if err != nil {
+				t.Fatalf("Stat(): %v", err)
+			}
+
+			input := &filesystem.ScanInput{
+				FS:     scalibrfs.DirFS(d),
+				Path:   tt.path,
+				Reader: r,
+				Root:   d,
+				Info:   info,
+			}
+			got, err := e.Extract(context.Background(), input)
+			if err != nil {
+				t.Fatalf("Extract(%s): %v", tt.path, err)
+			}
+
+			if diff := cmp.Diff(tt.wantInventory, got); diff != "" {
+				t.Errorf("Extract(%s) (-want +got):\n%s", tt.path, diff)
+			}
+
+			gotResultMetric := collector.FileExtractedResult(tt.path)
+			if gotResultMetric != stats.FileExtractedResultSuccess {
+				t.Errorf("Extract(%s) recorded result metric %v, want result metric %v", tt.path, gotResultMetric, stats.FileExtractedResultSuccess)
+			}
+		})
+	}
+}
+
+func TestExtractVersionAcrossChunks(t *testing.T) {
+	// Place the version string across the boundary of the 1 MiB search chunks.
+	version := []byte("rustc version 1.75.0 (82e1608df 2023-12-21)")
+	content := make([]byte, 3*units.MiB)
+	copy(content, "\x7fELF")
+	copy(content[units.MiB-10:], version)
+
+	e := embeddedruntime.New(embeddedruntime.DefaultConfig())
+	got, err := e.Extract(context.Background(), &filesystem.ScanInput{
+		Path:   "app",
+		Reader: bytes.NewReader(content),
+	})
+	if err != nil {
+		t.Fatalf("Extract(): %v", err)
+	}
+	want := []*extractor.Inventory{
+		{
+			Name:    "rust",
+			Version: "1.75.0",
+			Metadata: &embeddedruntime.Metadata{
+				Evidence:   string(version),
+				Confidence: embeddedruntime.ConfidenceMedium,
+			},
+			Locations: []string{"app"},
+		},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Extract() (-want +got):\n%s", diff)
+	}
+}
+
+func TestToPURL(t *testing.T) {
+	tests := []struct {
+		name          string
+		inventory     *extractor.Inventory
+		want          *purl.PackageURL
+		wantEcosystem string
+	}{
+		{
+			name:          "Go",
+			inventory:     &extractor.Inventory{Name: "go", Version: "1.22.0"},
+			want:          &purl.PackageURL{Type: purl.TypeGolang, Name: "go", Version: "1.22.0"},
+			wantEcosystem: "Go",
+		}, {
+			name:      "Node.js",
+			inventory: &extractor.Inventory{Name: "node", Version: "20.11.0"},
+			want:      &purl.PackageURL{Type: purl.TypeGeneric, Name: "node", Version: "20.11.0"},
+		},
+	}
+
+	e := embeddedruntime.New(embeddedruntime.DefaultConfig())
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := e.ToPURL(tt.inventory)
+			if err != nil {
+				t.Fatalf("ToPURL(%v): %v", tt.inventory, err)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("ToPURL(%v) (-want +got):\n%s", tt.inventory, diff)
+			}
+			gotEcosystem, err := e.Ecosystem(tt.inventory)
+			if err != nil {
+				t.Fatalf("Ecosystem(%v): %v", tt.inventory, err)
+			}
+			if gotEcosystem != tt.wantEcosystem {
+				t.Errorf("Ecosystem(%v) = %q, want %q", tt.inventory, gotEcosystem, tt.wantEcosystem)
+			}
+		})
+	}
+}
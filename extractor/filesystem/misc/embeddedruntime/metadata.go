@@ -0,0 +1,35 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package embeddedruntime
+
+// Confidence is how reliable a runtime version found in a binary is.
+type Confidence string
+
+const (
+	// ConfidenceHigh is set for versions read from structured data, e.g. the
+	// Go build info.
+	ConfidenceHigh Confidence = "high"
+	// ConfidenceMedium is set for versions found by searching the binary for
+	// version strings.
+	ConfidenceMedium Confidence = "medium"
+)
+
+// Metadata holds how the version of an embedded runtime was found.
+type Metadata struct {
+	// Evidence is the data the version was read from, e.g. the matched version
+	// string.
+	Evidence   string
+	Confidence Confidence
+}
@@ -0,0 +1,3 @@
+#!/bin/sh
+# runtime.goexit go1.21.5
+exec ./app "$@"
@@ -0,0 +1,501 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package gitsubmodule extracts the git submodules of source trees and the
+// commits they're pinned to.
+package gitsubmodule
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/url"
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem"
+	"github.com/google/osv-scalibr/extractor/filesystem/internal/units"
+	"github.com/google/osv-scalibr/log"
+	"github.com/google/osv-scalibr/plugin"
+	"github.com/google/osv-scalibr/purl"
+	"github.com/google/osv-scalibr/stats"
+)
+
+const (
+	// Name is the unique name of this extractor.
+	Name = "misc/gitsubmodule"
+
+	// defaultMaxFileSizeBytes is the maximum file size an extractor will unmarshal.
+	// If Extract gets a bigger file, it will return an error.
+	defaultMaxFileSizeBytes = 1 * units.MiB
+
+	gitmodulesFileName = ".gitmodules"
+	// maxRefFileSizeBytes is the maximum size of the HEAD, ref and gitdir files
+	// read to find the checked out commit of a submodule.
+	maxRefFileSizeBytes = 4 * units.KiB
+	// maxPackedRefsSizeBytes is the maximum size of a packed-refs file.
+	maxPackedRefsSizeBytes = 10 * units.MiB
+)
+
+var (
+	sectionRegex = regexp.MustCompile(`^\[\s*([A-Za-z0-9.-]+)(?:\s+"((?:[^"\\]|\\.)*)")?\s*\]$`)
+	commitRegex  = regexp.MustCompile(`^[0-9a-f]{40}$`)
+)
+
+// Config is the configuration for the Extractor.
+type Config struct {
+	// Stats is a stats collector for reporting metrics.
+	Stats stats.Collector
+	// MaxFileSizeBytes is the maximum file size this extractor will unmarshal. If
+	// `FileRequired` gets a bigger file, it will return false,
+	MaxFileSizeBytes int64
+}
+
+// DefaultConfig returns the default configuration for the extractor.
+func DefaultConfig() Config {
+	return Config{
+		MaxFileSizeBytes: defaultMaxFileSizeBytes,
+		Stats:            nil,
+	}
+}
+
+// Extractor extracts git submodules from .gitmodules files.
+type Extractor struct {
+	stats            stats.Collector
+	maxFileSizeBytes int64
+}
+
+// New returns a git submodule extractor.
+//
+// For most use cases, initialize with:
+// ```
+// e := New(DefaultConfig())
+// ```
+func New(cfg Config) *Extractor {
+	return &Extractor{
+		stats:            cfg.Stats,
+		maxFileSizeBytes: cfg.MaxFileSizeBytes,
+	}
+}
+
+// Config returns the configuration of the extractor.
+func (e Extractor) Config() Config {
+	return Config{
+		Stats:            e.stats,
+		MaxFileSizeBytes: e.maxFileSizeBytes,
+	}
+}
+
+// Name of the extractor.
+func (e Extractor) Name() string { return Name }
+
+// Version of the extractor.
+func (e Extractor) Version() int { return 0 }
+
+// Requirements of the extractor.
+func (e Extractor) Requirements() *plugin.Capabilities { return &plugin.Capabilities{} }
+
+// RequiredFileNames returns the name of the .gitmodules file.
+func (e Extractor) RequiredFileNames() []string { return []string{gitmodulesFileName} }
+
+// FileRequired returns true if the specified file is a .gitmodules file.
+func (e Extractor) FileRequired(path string, fileinfo fs.FileInfo) bool {
+	if filepath.Base(path) != gitmodulesFileName {
+		return false
+	}
+
+	if e.maxFileSizeBytes > 0 && fileinfo.Size() > e.maxFileSizeBytes {
+		e.reportFileRequired(path, fileinfo.Size(), stats.FileRequiredResultSizeLimitExceeded)
+		return false
+	}
+
+	e.reportFileRequired(path, fileinfo.Size(), stats.FileRequiredResultOK)
+	return true
+}
+
+func (e Extractor) reportFileRequired(path string, fileSizeBytes int64, result stats.FileRequiredResult) {
+	if e.stats == nil {
+		return
+	}
+	e.stats.AfterFileRequired(e.Name(), &stats.FileRequiredStats{
+		Path:          path,
+		Result:        result,
+		FileSizeBytes: fileSizeBytes,
+	})
+}
+
+// Extract returns the submodules configured in a .gitmodules file. The
+// commits the submodules are pinned to are read from the gitlink entries of
+// the superproject's git index, or from the HEAD of the checked out
+// submodules if there's no index. Submodules whose commit can't be found,
+// e.g. because the .git directory wasn't copied into the scanned tree, are
+// reported without a version.
+func (e Extractor) Extract(ctx context.Context, input *filesystem.ScanInput) ([]*extractor.Inventory, error) {
+	inventory, err := e.extractFromInput(ctx, input)
+	if e.stats != nil {
+		var fileSizeBytes int64
+		if input.Info != nil {
+			fileSizeBytes = input.Info.Size()
+		}
+		e.stats.AfterFileExtracted(e.Name(), &stats.FileExtractedStats{
+			Path:          input.Path,
+			Result:        filesystem.ExtractorErrorToFileExtractedResult(err),
+			FileSizeBytes: fileSizeBytes,
+		})
+	}
+	return inventory, err
+}
+
+func (e Extractor) extractFromInput(ctx context.Context, input *filesystem.ScanInput) ([]*extractor.Inventory, error) {
+	submodules, err := parseGitmodules(input.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %q: %w: %w", input.Path, extractor.ErrMalformedInput, err)
+	}
+	if len(submodules) == 0 {
+		return nil, nil
+	}
+
+	root := path.Dir(filepath.ToSlash(input.Path))
+	gitDir := findGitDir(input.FS, root)
+	var pinned map[string]string
+	if gitDir != "" {
+		paths := make([]string, 0, len(submodules))
+		for _, s := range submodules {
+			paths = append(paths, s.Path)
+		}
+		if pinned, err = readIndexGitlinks(ctx, input.FS, path.Join(gitDir, "index"), paths); err != nil {
+			if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+				return nil, err
+			}
+			if !errors.Is(err, fs.ErrNotExist) {
+				log.Warnf("%s: failed to read the git index of %q: %v", e.Name(), root, err)
+			}
+		}
+	}
+
+	inventory := make([]*extractor.Inventory, 0, len(submodules))
+	for _, s := range submodules {
+		commit, ok := pinned[s.Path]
+		if !ok {
+			commit = submoduleHead(input.FS, root, gitDir, s)
+		}
+		i := &extractor.Inventory{
+			Name:      repoName(s),
+			Version:   commit,
+			Metadata:  s,
+			Locations: []string{input.Path},
+		}
+		if s.URL != "" || commit != "" {
+			i.SourceCode = &extractor.SourceCodeIdentifier{Repo: s.URL, Commit: commit}
+		}
+		inventory = append(inventory, i)
+	}
+	return inventory, nil
+}
+
+// parseGitmodules parses the submodule sections of a .gitmodules file, which
+// uses the git-config syntax. Submodules without a path are ignored, like git
+// does.
+func parseGitmodules(r io.Reader) ([]*Metadata, error) {
+	var result []*Metadata
+	var current *Metadata
+	s := bufio.NewScanner(r)
+	for lineNum := 1; s.Scan(); lineNum++ {
+		line := strings.TrimSpace(s.Text())
+		if line == "" || line[0] == '#' || line[0] == ';' {
+			continue
+		}
+		if line[0] == '[' {
+			m := sectionRegex.FindStringSubmatch(line)
+			if m == nil {
+				return nil, fmt.Errorf("invalid section header on line %d", lineNum)
+			}
+			current = nil
+			if strings.EqualFold(m[1], "submodule") && m[2] != "" {
+				current = &Metadata{Name: unescape(m[2])}
+				result = append(result, current)
+			}
+			continue
+		}
+		key, value, found := strings.Cut(line, "=")
+		key = strings.ToLower(strings.TrimSpace(key))
+		if key == "" || strings.ContainsAny(key, " \t") {
+			return nil, fmt.Errorf("invalid entry on line %d", lineNum)
+		}
+		if current == nil || !found {
+			continue
+		}
+		switch key {
+		case "path":
+			current.Path = path.Clean(parseValue(value))
+		case "url":
+			current.URL = parseValue(value)
+		case "branch":
+			current.Branch = parseValue(value)
+		}
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+	return slices.DeleteFunc(result, func(m *Metadata) bool { return m.Path == "" || m.Path == "." }), nil
+}
+
+// parseValue returns a git-config value without its surrounding quotes and
+// trailing comments.
+func parseValue(v string) string {
+	var b strings.Builder
+	quoted := false
+	for i := 0; i < len(v); i++ {
+		switch c := v[i]; {
+		case c == '"':
+			quoted = !quoted
+		case c == '\\' && i+1 < len(v):
+			i++
+			switch v[i] {
+			case 'n':
+				b.WriteByte('\n')
+			case 't':
+				b.WriteByte('\t')
+			default:
+				b.WriteByte(v[i])
+			}
+		case (c == '#' || c == ';') && !quoted:
+			return strings.TrimSpace(b.String())
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return strings.TrimSpace(b.String())
+}
+
+// unescape removes the escaping backslashes from a git-config subsection name.
+func unescape(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+1 < len(s) {
+			i++
+		}
+		b.WriteByte(s[i])
+	}
+	return b.String()
+}
+
+// findGitDir returns the git directory of the superproject rooted at root, or
+// an empty string if there is none.
+func findGitDir(fsys fs.FS, root string) string {
+	dotGit := path.Join(root, ".git")
+	info, err := fs.Stat(fsys, dotGit)
+	if err != nil {
+		return ""
+	}
+	if info.IsDir() {
+		return dotGit
+	}
+	// Worktrees and superprojects that are submodules themselves have a .git
+	// file that points to the actual git directory.
+	return readGitdirFile(fsys, dotGit)
+}
+
+// readGitdirFile returns the git directory a "gitdir: <path>" file points to,
+// or an empty string if it can't be resolved within fsys.
+func readGitdirFile(fsys fs.FS, file string) string {
+	content, err := readSmallFile(fsys, file, maxRefFileSizeBytes)
+	if err != nil {
+		return ""
+	}
+	dir, ok := strings.CutPrefix(strings.TrimSpace(content), "gitdir:")
+	if !ok {
+		return ""
+	}
+	dir = strings.TrimSpace(dir)
+	if path.IsAbs(dir) {
+		// Absolute paths refer to the scanned host, not necessarily to the
+		// scanned filesystem.
+		log.Debugf("Ignoring absolute gitdir %q in %q", dir, file)
+		return ""
+	}
+	dir = path.Join(path.Dir(file), dir)
+	if !fs.ValidPath(dir) {
+		return ""
+	}
+	return dir
+}
+
+// submoduleHead returns the commit checked out in a submodule, or an empty
+// string if the submodule isn't checked out.
+func submoduleHead(fsys fs.FS, root, gitDir string, s *Metadata) string {
+	var candidates []string
+	if gitDir != "" {
+		candidates = append(candidates, path.Join(gitDir, "modules", s.Name))
+	}
+	dotGit := path.Join(root, s.Path, ".git")
+	if info, err := fs.Stat(fsys, dotGit); err == nil {
+		if info.IsDir() {
+			// Submodules cloned with old git versions have their own .git directory.
+			candidates = append(candidates, dotGit)
+		} else if dir := readGitdirFile(fsys, dotGit); dir != "" {
+			candidates = append(candidates, dir)
+		}
+	}
+	for _, dir := range candidates {
+		if commit := resolveHead(fsys, dir); commit != "" {
+			return commit
+		}
+	}
+	return ""
+}
+
+// resolveHead returns the commit the HEAD of a git directory points to, or an
+// empty string if it can't be resolved.
+func resolveHead(fsys fs.FS, gitDir string) string {
+	head, err := readSmallFile(fsys, path.Join(gitDir, "HEAD"), maxRefFileSizeBytes)
+	if err != nil {
+		return ""
+	}
+	head = strings.TrimSpace(head)
+	if commitRegex.MatchString(head) {
+		return head
+	}
+	ref, ok := strings.CutPrefix(head, "ref:")
+	if !ok {
+		return ""
+	}
+	ref = strings.TrimSpace(ref)
+	if !strings.HasPrefix(ref, "refs/") || !fs.ValidPath(path.Join(gitDir, ref)) {
+		return ""
+	}
+	if commit, err := readSmallFile(fsys, path.Join(gitDir, ref), maxRefFileSizeBytes); err == nil {
+		if commit = strings.TrimSpace(commit); commitRegex.MatchString(commit) {
+			return commit
+		}
+	}
+	packed, err := readSmallFile(fsys, path.Join(gitDir, "packed-refs"), maxPackedRefsSizeBytes)
+	if err != nil {
+		return ""
+	}
+	for _, line := range strings.Split(packed, "\n") {
+		commit, name, found := strings.Cut(strings.TrimSpace(line), " ")
+		if found && name == ref && commitRegex.MatchString(commit) {
+			return commit
+		}
+	}
+	return ""
+}
+
+func readSmallFile(fsys fs.FS, name string, maxSize int64) (string, error) {
+	f, err := fsys.Open(name)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	b, err := io.ReadAll(io.LimitReader(f, maxSize+1))
+	if err != nil {
+		return "", err
+	}
+	if int64(len(b)) > maxSize {
+		return "", fmt.Errorf("%q is larger than %d bytes", name, maxSize)
+	}
+	return string(b), nil
+}
+
+// repoURL is the host and path of a repository URL.
+type repoURL struct {
+	host string
+	// path without leading slashes and the .git suffix, e.g. "google/osv-scalibr".
+	path string
+}
+
+// parseRepoURL parses URLs such as https://github.com/google/osv-scalibr.git
+// and scp-like git URLs such as git@github.com:google/osv-scalibr.git.
+// Relative and local URLs are returned without a host.
+func parseRepoURL(rawURL string) repoURL {
+	var r repoURL
+	if strings.Contains(rawURL, "://") {
+		u, err := url.Parse(rawURL)
+		if err != nil {
+			return repoURL{path: rawURL}
+		}
+		r = repoURL{host: strings.ToLower(u.Hostname()), path: u.Path}
+	} else if userHost, p, found := strings.Cut(rawURL, ":"); found && !strings.Contains(userHost, "/") && len(userHost) > 1 {
+		// Single letters before the colon are Windows drive letters.
+		_, host, _ := strings.Cut(userHost, "@")
+		if host == "" {
+			host = userHost
+		}
+		r = repoURL{host: strings.ToLower(host), path: p}
+	} else {
+		r = repoURL{path: rawURL}
+	}
+	r.path = strings.TrimSuffix(strings.Trim(r.path, "/"), ".git")
+	return r
+}
+
+// repoName returns the name of the submodule's repository, falling back to
+// the name of the submodule if the URL doesn't contain one.
+func repoName(s *Metadata) string {
+	p := parseRepoURL(s.URL).path
+	if name := path.Base(p); p != "" && name != "." && name != ".." && name != "/" {
+		return name
+	}
+	return path.Base(s.Name)
+}
+
+// ToPURL converts an inventory created by this extractor into a PURL.
+// Submodules hosted on GitHub are reported as github PURLs, all others as
+// generic PURLs with the repository URL as the vcs_url qualifier.
+func (e Extractor) ToPURL(i *extractor.Inventory) (*purl.PackageURL, error) {
+	m := i.Metadata.(*Metadata)
+	u := parseRepoURL(m.URL)
+	if owner, repo, found := strings.Cut(u.path, "/"); u.host == "github.com" && found && !strings.Contains(repo, "/") {
+		return &purl.PackageURL{
+			Type:      purl.TypeGithub,
+			Namespace: owner,
+			Name:      repo,
+			Version:   i.Version,
+		}, nil
+	}
+	qualifiers := map[string]string{}
+	// Relative URLs depend on the URL of the superproject.
+	if u.host != "" {
+		vcsURL := "git+" + m.URL
+		if !strings.Contains(m.URL, "://") {
+			// Convert scp-like URLs to ssh:// URLs.
+			vcsURL = "git+ssh://" + strings.Replace(m.URL, ":", "/", 1)
+		}
+		if i.Version != "" {
+			vcsURL += "@" + i.Version
+		}
+		qualifiers[purl.VCSURL] = vcsURL
+	}
+	return &purl.PackageURL{
+		Type:       purl.TypeGeneric,
+		Name:       i.Name,
+		Version:    i.Version,
+		Qualifiers: purl.QualifiersFromMap(qualifiers),
+	}, nil
+}
+
+// ToCPEs is not applicable as this extractor does not infer CPEs from the Inventory.
+func (e Extractor) ToCPEs(i *extractor.Inventory) ([]string, error) { return []string{}, nil }
+
+// Ecosystem returns no ecosystem since OSV doesn't support git repositories
+// as ecosystems.
+func (Extractor) Ecosystem(i *extractor.Inventory) (string, error) { return "", nil }
@@ -0,0 +1,475 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gitsubmodule_test
+
+import (
+	"context"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem"
+	"github.com/google/osv-scalibr/extractor/filesystem/internal/units"
+	"github.com/google/osv-scalibr/extractor/filesystem/misc/gitsubmodule"
+	"github.com/google/osv-scalibr/purl"
+	"github.com/google/osv-scalibr/stats"
+	"github.com/google/osv-scalibr/testing/fakefs"
+	"github.com/google/osv-scalibr/testing/testcollector"
+)
+
+const (
+	googletestCommit = "ff36f7da91e5022cd51425f2af97267c6c37770b"
+	abseilCommit     = "79e5434a9cffa5361013fe2c9e4a463ba19065dd"
+	libfooCommit     = "d09145fd504a342be799454739f5f68600220ec9"
+	internalCommit   = "9f33a7c798af6fd6abb28049d9c1b3edfa2fd24a"
+	headCommit       = "2b1e0a0c4f1d6b3e5a7c9d8e0f1a2b3c4d5e6f70"
+)
+
+func TestFileRequired(t *testing.T) {
+	tests := []struct {
+		name             string
+		path             string
+		fileSizeBytes    int64
+		maxFileSizeBytes int64
+		wantRequired     bool
+		wantResultMetric stats.FileRequiredResult
+	}{
+		{
+			name:             ".gitmodules",
+			path:             ".gitmodules",
+			wantRequired:     true,
+			wantResultMetric: stats.FileRequiredResultOK,
+		}, {
+			name:             ".gitmodules in subdirectory",
+			path:             "src/project/.gitmodules",
+			wantRequired:     true,
+			wantResultMetric: stats.FileRequiredResultOK,
+		}, {
+			name:         "git config",
+			path:         "project/.git/config",
+			wantRequired: false,
+		}, {
+			name:         ".gitmodules suffix",
+			path:         "project/old.gitmodules",
+			wantRequired: false,
+		}, {
+			name:             "file size limit exceeded",
+			path:             ".gitmodules",
+			fileSizeBytes:    1 * units.MiB,
+			maxFileSizeBytes: 1000,
+			wantRequired:     false,
+			wantResultMetric: stats.FileRequiredResultSizeLimitExceeded,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			collector := testcollector.New()
+			var e filesystem.Extractor = gitsubmodule.New(gitsubmodule.Config{
+				Stats:            collector,
+				MaxFileSizeBytes: tt.maxFileSizeBytes,
+			})
+
+			fileSizeBytes := tt.fileSizeBytes
+			if fileSizeBytes == 0 {
+				fileSizeBytes = 1000
+			}
+			isRequired := e.FileRequired(tt.path, fakefs.FakeFileInfo{
+				FileName: filepath.Base(tt.path),
+				FileMode: fs.ModePerm,
+				FileSize: fileSizeBytes,
+			})
+			if isRequired != tt.wantRequired {
+				t.Fatalf("FileRequired(%s): got %v, want %v", tt.path, isRequired, tt.wantRequired)
+			}
+
+			gotResultMetric := collector.FileRequiredResult(tt.path)
+			if tt.wantResultMetric != "" && gotResultMetric != tt.wantResultMetric {
+				t.Errorf("FileRequired(%s) recorded result metric %v, want result metric %v", tt.path, gotResultMetric, tt.wantResultMetric)
+			}
+		})
+	}
+}
+
+// submodules returns the inventory of testdata/gitmodules found at path with
+// the given commits.
+func submodules(path string, googletest, abseil, libfoo, internal string) []*extractor.Inventory {
+	inv := func(name, version string, m *gitsubmodule.Metadata) *extractor.Inventory {
+		return &extractor.Inventory{
+			Name:       name,
+			Version:    version,
+			Metadata:   m,
+			SourceCode: &extractor.SourceCodeIdentifier{Repo: m.URL, Commit: version},
+			Locations:  []string{path},
+		}
+	}
+	return []*extractor.Inventory{
+		inv("googletest", googletest, &gitsubmodule.Metadata{
+			Name:   "googletest",
+			Path:   "third_party/googletest",
+			URL:    "https://github.com/google/googletest.git",
+			Branch: "main",
+		}),
+		inv("abseil-cpp", abseil, &gitsubmodule.Metadata{
+			Name: "abseil",
+			Path: "third_party/abseil-cpp",
+			URL:  "git@github.com:abseil/abseil-cpp.git",
+		}),
+		inv("libfoo", libfoo, &gitsubmodule.Metadata{
+			Name: "libfoo",
+			Path: "vendor/libfoo",
+			URL:  "https://gitlab.com/foo/libfoo.git",
+		}),
+		inv("internal", internal, &gitsubmodule.Metadata{
+			Name: "internal",
+			Path: "lib/internal",
+			URL:  "../internal.git",
+		}),
+	}
+}
+
+func TestExtract(t *testing.T) {
+	tests := []struct {
+		name string
+		// The path of the .gitmodules file.
+		path string
+		// The testdata file the .gitmodules file is read from.
+		gitmodules string
+		// Additional files of the scanned filesystem. Values starting with
+		// "testdata/" are read from the testdata directory.
+		files            map[string]string
+		wantInventory    []*extractor.Inventory
+		wantErr          error
+		wantResultMetric stats.FileExtractedResult
+	}{
+		{
+			name:       "commits from index v2",
+			path:       ".gitmodules",
+			gitmodules: "gitmodules",
+			files: map[string]string{
+				".git/index": "testdata/index-v2",
+			},
+			wantInventory:    submodules(".gitmodules", googletestCommit, abseilCommit, libfooCommit, internalCommit),
+			wantResultMetric: stats.FileExtractedResultSuccess,
+		}, {
+			name:       "commits from index v3 with extended flags",
+			path:       ".gitmodules",
+			gitmodules: "gitmodules",
+			files: map[string]string{
+				".git/index": "testdata/index-v3",
+			},
+			wantInventory:    submodules(".gitmodules", googletestCommit, abseilCommit, libfooCommit, internalCommit),
+			wantResultMetric: stats.FileExtractedResultSuccess,
+		}, {
+			name:       "commits from prefix-compressed index v4",
+			path:       "src/project/.gitmodules",
+			gitmodules: "gitmodules",
+			files: map[string]string{
+				"src/project/.git/index": "testdata/index-v4",
+			},
+			wantInventory:    submodules("src/project/.gitmodules", googletestCommit, abseilCommit, libfooCommit, internalCommit),
+			wantResultMetric: stats.FileExtractedResultSuccess,
+		}, {
+			name:       "no .git directory",
+			path:       ".gitmodules",
+			gitmodules: "gitmodules",
+			wantInventory: []*extractor.Inventory{
+				{
+					Name: "googletest",
+					Metadata: &gitsubmodule.Metadata{
+						Name:   "googletest",
+						Path:   "third_party/googletest",
+						URL:    "https://github.com/google/googletest.git",
+						Branch: "main",
+					},
+					SourceCode: &extractor.SourceCodeIdentifier{Repo: "https://github.com/google/googletest.git"},
+					Locations:  []string{".gitmodules"},
+				},
+				{
+					Name: "abseil-cpp",
+					Metadata: &gitsubmodule.Metadata{
+						Name: "abseil",
+						Path: "third_party/abseil-cpp",
+						URL:  "git@github.com:abseil/abseil-cpp.git",
+					},
+					SourceCode: &extractor.SourceCodeIdentifier{Repo: "git@github.com:abseil/abseil-cpp.git"},
+					Locations:  []string{".gitmodules"},
+				},
+				{
+					Name: "libfoo",
+					Metadata: &gitsubmodule.Metadata{
+						Name: "libfoo",
+						Path: "vendor/libfoo",
+						URL:  "https://gitlab.com/foo/libfoo.git",
+					},
+					SourceCode: &extractor.SourceCodeIdentifier{Repo: "https://gitlab.com/foo/libfoo.git"},
+					Locations:  []string{".gitmodules"},
+				},
+				{
+					Name: "internal",
+					Metadata: &gitsubmodule.Metadata{
+						Name: "internal",
+						Path: "lib/internal",
+						URL:  "../internal.git",
+					},
+					SourceCode: &extractor.SourceCodeIdentifier{Repo: "../internal.git"},
+					Locations:  []string{".gitmodules"},
+				},
+			},
+			wantResultMetric: stats.FileExtractedResultSuccess,
+		}, {
+			name:       "commits from submodule HEADs",
+			path:       ".gitmodules",
+			gitmodules: "gitmodules",
+			files: map[string]string{
+				".git/HEAD": "ref: refs/heads/main\n",
+				// Detached HEAD.
+				".git/modules/googletest/HEAD": googletestCommit + "\n",
+				// Loose branch ref.
+				".git/modules/abseil/HEAD":              "ref: refs/heads/master\n",
+				".git/modules/abseil/refs/heads/main":   headCommit + "\n",
+				".git/modules/abseil/refs/heads/master": abseilCommit + "\n",
+				// Packed branch ref.
+				".git/modules/libfoo/HEAD": "ref: refs/heads/main\n",
+				".git/modules/libfoo/packed-refs": "# pack-refs with: peeled fully-peeled sorted\n" +
+					headCommit + " refs/heads/dev\n" +
+					libfooCommit + " refs/heads/main\n",
+				// Submodule cloned with an old git version.
+				"lib/internal/.git/HEAD": internalCommit + "\n",
+			},
+			wantInventory:    submodules(".gitmodules", googletestCommit, abseilCommit, libfooCommit, internalCommit),
+			wantResultMetric: stats.FileExtractedResultSuccess,
+		}, {
+			name:       "superproject is a worktree",
+			path:       "worktree/.gitmodules",
+			gitmodules: "gitmodules",
+			files: map[string]string{
+				"worktree/.git":                      "gitdir: ../repo/.git/worktrees/worktree\n",
+				"repo/.git/worktrees/worktree/index": "testdata/index-v2",
+			},
+			wantInventory:    submodules("worktree/.gitmodules", googletestCommit, abseilCommit, libfooCommit, internalCommit),
+			wantResultMetric: stats.FileExtractedResultSuccess,
+		}, {
+			name:       "index takes precedence over the submodule HEADs",
+			path:       ".gitmodules",
+			gitmodules: "gitmodules",
+			files: map[string]string{
+				".git/index":                              "testdata/index-v2",
+				"third_party/googletest/.git":             "gitdir: ../../.git/modules/googletest\n",
+				".git/modules/googletest/HEAD":            headCommit + "\n",
+				".git/modules/googletest/refs/heads/main": headCommit + "\n",
+			},
+			wantInventory:    submodules(".gitmodules", googletestCommit, abseilCommit, libfooCommit, internalCommit),
+			wantResultMetric: stats.FileExtractedResultSuccess,
+		}, {
+			name:       "corrupt index",
+			path:       ".gitmodules",
+			gitmodules: "gitmodules",
+			files: map[string]string{
+				".git/index":                   "DIRC\x00\x00\x00\x02\x00\x00\x00\x09truncated",
+				".git/modules/googletest/HEAD": googletestCommit + "\n",
+			},
+			wantInventory: func() []*extractor.Inventory {
+				inv := submodules(".gitmodules", googletestCommit, "", "", "")
+				for _, i := range inv[1:] {
+					i.SourceCode.Commit = ""
+				}
+				return inv
+			}(),
+			wantResultMetric: stats.FileExtractedResultSuccess,
+		}, {
+			name:             "empty",
+			path:             ".gitmodules",
+			gitmodules:       "empty",
+			wantInventory:    nil,
+			wantResultMetric: stats.FileExtractedResultSuccess,
+		}, {
+			name:             "invalid",
+			path:             ".gitmodules",
+			gitmodules:       "invalid",
+			wantErr:          extractor.ErrMalformedInput,
+			wantResultMetric: stats.FileExtractedResultErrorMalformedInput,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			collector := testcollector.New()
+			var e filesystem.Extractor = gitsubmodule.New(gitsubmodule.Config{
+				Stats:            collector,
+				MaxFileSizeBytes: 1 * units.MiB,
+			})
+
+			fsys := fstest.MapFS{tt.path: &fstest.MapFile{Data: readTestdata(t, tt.gitmodules)}}
+			for path, content := range tt.files {
+				data := []byte(content)
+				if name, ok := strings.CutPrefix(content, "testdata/"); ok {
+					data = readTestdata(t, name)
+				}
+				fsys[path] = &fstest.MapFile{Data: data}
+			}
+			r, err := fsys.Open(tt.path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer r.Close()
+			info, err := r.Stat()
+			if err != nil {
+				t.Fatalf("Stat(): %v", err)
+			}
+
+			input := &filesystem.ScanInput{
+				FS:     fsys,
+				Path:   tt.path,
+				Reader: r,
+				Root:   "/",
+				Info:   info,
+			}
+			got, err := e.Extract(context.Background(), input)
+			if !cmp.Equal(err, tt.wantErr, cmpopts.EquateErrors()) {
+				t.Fatalf("Extract(%+v) error: got %v, want %v\n", tt.path, err, tt.wantErr)
+			}
+
+			if diff := cmp.Diff(tt.wantInventory, got); diff != "" {
+				t.Errorf("Extract(%s) (-want +got):\n%s", tt.path, diff)
+			}
+
+			gotResultMetric := collector.FileExtractedResult(tt.path)
+			if gotResultMetric != tt.wantResultMetric {
+				t.Errorf("Extract(%s) recorded result metric %v, want result metric %v", tt.path, gotResultMetric, tt.wantResultMetric)
+			}
+		})
+	}
+}
+
+func readTestdata(t *testing.T, name string) []byte {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+func TestToPURL(t *testing.T) {
+	e := gitsubmodule.New(gitsubmodule.DefaultConfig())
+	tests := []struct {
+		name string
+		inv  *extractor.Inventory
+		want *purl.PackageURL
+	}{
+		{
+			name: "GitHub HTTPS URL",
+			inv: &extractor.Inventory{
+				Name:     "googletest",
+				Version:  googletestCommit,
+				Metadata: &gitsubmodule.Metadata{URL: "https://github.com/google/googletest.git"},
+			},
+			want: &purl.PackageURL{
+				Type:      purl.TypeGithub,
+				Namespace: "google",
+				Name:      "googletest",
+				Version:   googletestCommit,
+			},
+		}, {
+			name: "GitHub scp-like URL",
+			inv: &extractor.Inventory{
+				Name:     "abseil-cpp",
+				Version:  abseilCommit,
+				Metadata: &gitsubmodule.Metadata{URL: "git@github.com:abseil/abseil-cpp.git"},
+			},
+			want: &purl.PackageURL{
+				Type:      purl.TypeGithub,
+				Namespace: "abseil",
+				Name:      "abseil-cpp",
+				Version:   abseilCommit,
+			},
+		}, {
+			name: "other host",
+			inv: &extractor.Inventory{
+				Name:     "libfoo",
+				Version:  libfooCommit,
+				Metadata: &gitsubmodule.Metadata{URL: "https://gitlab.com/foo/libfoo.git"},
+			},
+			want: &purl.PackageURL{
+				Type:    purl.TypeGeneric,
+				Name:    "libfoo",
+				Version: libfooCommit,
+				Qualifiers: purl.QualifiersFromMap(map[string]string{
+					purl.VCSURL: "git+https://gitlab.com/foo/libfoo.git@" + libfooCommit,
+				}),
+			},
+		}, {
+			name: "other host with scp-like URL",
+			inv: &extractor.Inventory{
+				Name:     "libfoo",
+				Version:  libfooCommit,
+				Metadata: &gitsubmodule.Metadata{URL: "git@gitlab.com:foo/libfoo.git"},
+			},
+			want: &purl.PackageURL{
+				Type:    purl.TypeGeneric,
+				Name:    "libfoo",
+				Version: libfooCommit,
+				Qualifiers: purl.QualifiersFromMap(map[string]string{
+					purl.VCSURL: "git+ssh://git@gitlab.com/foo/libfoo.git@" + libfooCommit,
+				}),
+			},
+		}, {
+			name: "relative URL",
+			inv: &extractor.Inventory{
+				Name:     "internal",
+				Version:  internalCommit,
+				Metadata: &gitsubmodule.Metadata{URL: "../internal.git"},
+			},
+			want: &purl.PackageURL{
+				Type:       purl.TypeGeneric,
+				Name:       "internal",
+				Version:    internalCommit,
+				Qualifiers: purl.QualifiersFromMap(map[string]string{}),
+			},
+		}, {
+			name: "unknown commit",
+			inv: &extractor.Inventory{
+				Name:     "libfoo",
+				Metadata: &gitsubmodule.Metadata{URL: "https://gitlab.com/foo/libfoo.git"},
+			},
+			want: &purl.PackageURL{
+				Type: purl.TypeGeneric,
+				Name: "libfoo",
+				Qualifiers: purl.QualifiersFromMap(map[string]string{
+					purl.VCSURL: "git+https://gitlab.com/foo/libfoo.git",
+				}),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := e.ToPURL(tt.inv)
+			if err != nil {
+				t.Fatalf("ToPURL(%v): %v", tt.inv, err)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("ToPURL(%v) (-want +got):\n%s", tt.inv, diff)
+			}
+		})
+	}
+}
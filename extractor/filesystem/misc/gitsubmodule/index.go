@@ -0,0 +1,166 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gitsubmodule
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+)
+
+const (
+	// gitlinkMode is the file mode of the index entries of submodules.
+	gitlinkMode = 0o160000
+	// indexEntryHeaderSize is the size of an index entry before its flags:
+	// 10 32-bit stat fields and a SHA-1 hash.
+	indexEntryHeaderSize = 10*4 + 20
+	extendedFlag         = 0x4000
+	stageMask            = 0x3000
+)
+
+// indexEntry is the fixed-size part of a git index entry, see
+// https://git-scm.com/docs/index-format.
+type indexEntry struct {
+	CTimeSec, CTimeNsec uint32
+	MTimeSec, MTimeNsec uint32
+	Dev, Ino            uint32
+	Mode                uint32
+	UID, GID            uint32
+	Size                uint32
+	Hash                [20]byte
+	Flags               uint16
+}
+
+// readIndexGitlinks returns the commits that the gitlink entries for the
+// given paths are pinned to in a git index file, keyed by path. Only SHA-1
+// repositories and index versions 2 to 4 are supported.
+func readIndexGitlinks(ctx context.Context, fsys fs.FS, indexPath string, paths []string) (map[string]string, error) {
+	f, err := fsys.Open(indexPath)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	wanted := make(map[string]bool, len(paths))
+	for _, p := range paths {
+		wanted[p] = true
+	}
+	r := bufio.NewReader(f)
+	var header struct {
+		Signature [4]byte
+		Version   uint32
+		Entries   uint32
+	}
+	if err := binary.Read(r, binary.BigEndian, &header); err != nil {
+		return nil, fmt.Errorf("failed to read the index header: %w", err)
+	}
+	if string(header.Signature[:]) != "DIRC" {
+		return nil, errors.New("not a git index file")
+	}
+	if header.Version < 2 || header.Version > 4 {
+		return nil, fmt.Errorf("unsupported index version %d", header.Version)
+	}
+
+	result := make(map[string]string)
+	var prevName string
+	for i := uint32(0); i < header.Entries && len(result) < len(wanted); i++ {
+		if i%1000 == 0 {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+		}
+		var e indexEntry
+		if err := binary.Read(r, binary.BigEndian, &e); err != nil {
+			return nil, fmt.Errorf("failed to read index entry %d: %w", i, err)
+		}
+		entrySize := indexEntryHeaderSize + 2
+		if e.Flags&extendedFlag != 0 {
+			if header.Version < 3 {
+				return nil, fmt.Errorf("index entry %d has extended flags in a version %d index", i, header.Version)
+			}
+			if _, err := r.Discard(2); err != nil {
+				return nil, err
+			}
+			entrySize += 2
+		}
+
+		var name string
+		if header.Version == 4 {
+			// The path is prefix-compressed relative to the previous entry.
+			strip, err := readOffsetVarint(r)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read the path of index entry %d: %w", i, err)
+			}
+			if strip > uint64(len(prevName)) {
+				return nil, fmt.Errorf("index entry %d strips %d bytes from a %d byte path", i, strip, len(prevName))
+			}
+			suffix, err := readNullTerminated(r)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read the path of index entry %d: %w", i, err)
+			}
+			name = prevName[:uint64(len(prevName))-strip] + suffix
+		} else {
+			if name, err = readNullTerminated(r); err != nil {
+				return nil, fmt.Errorf("failed to read the path of index entry %d: %w", i, err)
+			}
+			// Entries are padded with 1 to 8 NUL bytes to a multiple of 8 bytes,
+			// one of which terminates the path.
+			padded := (entrySize + len(name) + 8) &^ 7
+			if _, err := r.Discard(padded - entrySize - len(name) - 1); err != nil {
+				return nil, err
+			}
+		}
+		prevName = name
+
+		// Entries with a non-zero stage are unresolved merge conflicts.
+		if e.Mode == gitlinkMode && e.Flags&stageMask == 0 && wanted[name] {
+			result[name] = hex.EncodeToString(e.Hash[:])
+		}
+	}
+	return result, nil
+}
+
+func readNullTerminated(r *bufio.Reader) (string, error) {
+	s, err := r.ReadString(0)
+	if err != nil {
+		return "", err
+	}
+	return s[:len(s)-1], nil
+}
+
+// readOffsetVarint reads the variable-length integers of index version 4,
+// which use the "offset" encoding of git's varint.c.
+func readOffsetVarint(r io.ByteReader) (uint64, error) {
+	c, err := r.ReadByte()
+	if err != nil {
+		return 0, err
+	}
+	val := uint64(c & 0x7f)
+	for c&0x80 != 0 {
+		if c, err = r.ReadByte(); err != nil {
+			return 0, err
+		}
+		if val > (1<<57)-1 {
+			return 0, errors.New("varint overflow")
+		}
+		val = ((val + 1) << 7) | uint64(c&0x7f)
+	}
+	return val, nil
+}
@@ -0,0 +1,28 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gitsubmodule
+
+// Metadata holds information about a git submodule.
+type Metadata struct {
+	// Name of the submodule in the .gitmodules file.
+	Name string
+	// Path of the submodule relative to the root of the superproject.
+	Path string
+	// URL of the submodule's repository as configured in .gitmodules. Can be
+	// relative to the URL of the superproject, e.g. "../lib.git".
+	URL string
+	// Branch the submodule tracks, if configured.
+	Branch string
+}
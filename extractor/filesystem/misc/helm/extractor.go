@@ -0,0 +1,287 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package helm extracts Helm charts and the chart dependencies pinned in their
+// lock files.
+package helm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-yaml/yaml"
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem"
+	"github.com/google/osv-scalibr/extractor/filesystem/internal/units"
+	"github.com/google/osv-scalibr/log"
+	"github.com/google/osv-scalibr/plugin"
+	"github.com/google/osv-scalibr/purl"
+	"github.com/google/osv-scalibr/stats"
+)
+
+const (
+	// Name is the unique name of this extractor.
+	Name = "misc/helm"
+
+	// defaultMaxFileSizeBytes is the maximum file size an extractor will unmarshal.
+	// If Extract gets a bigger file, it will return an error.
+	defaultMaxFileSizeBytes = 1 * units.MiB
+
+	chartFileName = "Chart.yaml"
+	// chartLockFileName is the lock file of Helm 3 charts.
+	chartLockFileName = "Chart.lock"
+	// requirementsLockFileName is the lock file of Helm 2 charts.
+	requirementsLockFileName = "requirements.lock"
+	// subchartsDirName is the directory unpacked dependencies are stored in.
+	subchartsDirName = "charts"
+)
+
+// chart holds the fields of a Chart.yaml file that are relevant for the inventory.
+type chart struct {
+	APIVersion string `yaml:"apiVersion"`
+	Name       string `yaml:"name"`
+	Version    string `yaml:"version"`
+	AppVersion string `yaml:"appVersion"`
+}
+
+// lock is a Chart.lock or requirements.lock file.
+type lock struct {
+	Dependencies []dependency `yaml:"dependencies"`
+}
+
+type dependency struct {
+	Name       string `yaml:"name"`
+	Version    string `yaml:"version"`
+	Repository string `yaml:"repository"`
+}
+
+// Config is the configuration for the Extractor.
+type Config struct {
+	// Stats is a stats collector for reporting metrics.
+	Stats stats.Collector
+	// MaxFileSizeBytes is the maximum file size this extractor will unmarshal. If
+	// `FileRequired` gets a bigger file, it will return false,
+	MaxFileSizeBytes int64
+}
+
+// DefaultConfig returns the default configuration for the extractor.
+func DefaultConfig() Config {
+	return Config{
+		MaxFileSizeBytes: defaultMaxFileSizeBytes,
+		Stats:            nil,
+	}
+}
+
+// Extractor extracts Helm charts and their locked dependencies.
+type Extractor struct {
+	stats            stats.Collector
+	maxFileSizeBytes int64
+}
+
+// New returns a Helm chart extractor.
+//
+// For most use cases, initialize with:
+// ```
+// e := New(DefaultConfig())
+// ```
+func New(cfg Config) *Extractor {
+	return &Extractor{
+		stats:            cfg.Stats,
+		maxFileSizeBytes: cfg.MaxFileSizeBytes,
+	}
+}
+
+// Config returns the configuration of the extractor.
+func (e Extractor) Config() Config {
+	return Config{
+		Stats:            e.stats,
+		MaxFileSizeBytes: e.maxFileSizeBytes,
+	}
+}
+
+// Name of the extractor.
+func (e Extractor) Name() string { return Name }
+
+// Version of the extractor.
+func (e Extractor) Version() int { return 0 }
+
+// Requirements of the extractor.
+func (e Extractor) Requirements() *plugin.Capabilities { return &plugin.Capabilities{} }
+
+// RequiredFileNames returns the names of the chart files the extractor requires.
+func (e Extractor) RequiredFileNames() []string {
+	return []string{chartFileName, chartLockFileName, requirementsLockFileName}
+}
+
+// FileRequired returns true if the specified file is a Chart.yaml, Chart.lock
+// or requirements.lock file.
+func (e Extractor) FileRequired(path string, fileinfo fs.FileInfo) bool {
+	switch filepath.Base(path) {
+	case chartFileName, chartLockFileName, requirementsLockFileName:
+	default:
+		return false
+	}
+
+	if e.maxFileSizeBytes > 0 && fileinfo.Size() > e.maxFileSizeBytes {
+		e.reportFileRequired(path, fileinfo.Size(), stats.FileRequiredResultSizeLimitExceeded)
+		return false
+	}
+
+	e.reportFileRequired(path, fileinfo.Size(), stats.FileRequiredResultOK)
+	return true
+}
+
+func (e Extractor) reportFileRequired(path string, fileSizeBytes int64, result stats.FileRequiredResult) {
+	if e.stats == nil {
+		return
+	}
+	e.stats.AfterFileRequired(e.Name(), &stats.FileRequiredStats{
+		Path:          path,
+		Result:        result,
+		FileSizeBytes: fileSizeBytes,
+	})
+}
+
+// Extract returns the chart described by a Chart.yaml file, or the
+// dependencies pinned in a Chart.lock or requirements.lock file.
+func (e Extractor) Extract(ctx context.Context, input *filesystem.ScanInput) ([]*extractor.Inventory, error) {
+	inventory, err := e.extractFromInput(ctx, input)
+	if e.stats != nil {
+		var fileSizeBytes int64
+		if input.Info != nil {
+			fileSizeBytes = input.Info.Size()
+		}
+		e.stats.AfterFileExtracted(e.Name(), &stats.FileExtractedStats{
+			Path:          input.Path,
+			Result:        filesystem.ExtractorErrorToFileExtractedResult(err),
+			FileSizeBytes: fileSizeBytes,
+		})
+	}
+	return inventory, err
+}
+
+func (e Extractor) extractFromInput(ctx context.Context, input *filesystem.ScanInput) ([]*extractor.Inventory, error) {
+	chartDir := path.Dir(filepath.ToSlash(input.Path))
+	if filepath.Base(input.Path) == chartFileName {
+		return e.extractChart(input, chartDir)
+	}
+	return e.extractLock(ctx, input, chartDir)
+}
+
+// extractChart returns the chart described by a Chart.yaml file. Subcharts
+// unpacked into the charts/ directory of another chart are reported with the
+// name of that parent chart.
+func (e Extractor) extractChart(input *filesystem.ScanInput, chartDir string) ([]*extractor.Inventory, error) {
+	var c chart
+	if err := yaml.NewDecoder(input.Reader).Decode(&c); err != nil {
+		return nil, fmt.Errorf("failed to parse %q: %w: %w", input.Path, extractor.ErrMalformedInput, err)
+	}
+	if c.Name == "" || c.Version == "" {
+		return nil, fmt.Errorf("missing chart name or version in %q: %w", input.Path, extractor.ErrMalformedInput)
+	}
+
+	var parentChart string
+	if parentDir := path.Dir(chartDir); chartDir != "." && path.Base(parentDir) == subchartsDirName {
+		parentChart = e.chartName(input.FS, path.Dir(parentDir), input.Root)
+	}
+	return []*extractor.Inventory{{
+		Name:    c.Name,
+		Version: c.Version,
+		Metadata: &Metadata{
+			AppVersion:  c.AppVersion,
+			APIVersion:  c.APIVersion,
+			ParentChart: parentChart,
+		},
+		Locations: []string{input.Path},
+	}}, nil
+}
+
+// extractLock returns the dependencies pinned in a Chart.lock or
+// requirements.lock file.
+func (e Extractor) extractLock(ctx context.Context, input *filesystem.ScanInput, chartDir string) ([]*extractor.Inventory, error) {
+	var l lock
+	if err := yaml.NewDecoder(input.Reader).Decode(&l); err != nil {
+		return nil, fmt.Errorf("failed to parse %q: %w: %w", input.Path, extractor.ErrMalformedInput, err)
+	}
+
+	parentChart := e.chartName(input.FS, chartDir, input.Root)
+	var inventory []*extractor.Inventory
+	for _, d := range l.Dependencies {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		if d.Name == "" || d.Version == "" {
+			return nil, fmt.Errorf("missing dependency name or version in %q: %w", input.Path, extractor.ErrMalformedInput)
+		}
+		inventory = append(inventory, &extractor.Inventory{
+			Name:    d.Name,
+			Version: d.Version,
+			Metadata: &Metadata{
+				Repository:  d.Repository,
+				ParentChart: parentChart,
+			},
+			Locations: []string{input.Path},
+		})
+	}
+	return inventory, nil
+}
+
+// chartName returns the name of the chart in dir, falling back to the name of
+// the directory if its Chart.yaml is missing or invalid.
+func (e Extractor) chartName(fsys fs.FS, dir, root string) string {
+	f, err := fsys.Open(path.Join(dir, chartFileName))
+	if err == nil {
+		defer f.Close()
+		var c chart
+		err = yaml.NewDecoder(f).Decode(&c)
+		if err == nil && c.Name != "" {
+			return c.Name
+		}
+	}
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		log.Debugf("Failed to read the chart name from %q: %v", path.Join(dir, chartFileName), err)
+	}
+	if dir == "." {
+		return filepath.Base(root)
+	}
+	return path.Base(dir)
+}
+
+// ToPURL converts an inventory created by this extractor into a PURL.
+func (e Extractor) ToPURL(i *extractor.Inventory) (*purl.PackageURL, error) {
+	m := i.Metadata.(*Metadata)
+	qualifiers := map[string]string{}
+	// Local dependencies (file://../mychart) don't identify where the chart is
+	// published.
+	if m.Repository != "" && !strings.HasPrefix(m.Repository, "file://") {
+		qualifiers[purl.RepositoryURL] = m.Repository
+	}
+	return &purl.PackageURL{
+		Type:       purl.TypeHelm,
+		Name:       i.Name,
+		Version:    i.Version,
+		Qualifiers: purl.QualifiersFromMap(qualifiers),
+	}, nil
+}
+
+// ToCPEs is not applicable as this extractor does not infer CPEs from the Inventory.
+func (e Extractor) ToCPEs(i *extractor.Inventory) ([]string, error) { return []string{}, nil }
+
+// Ecosystem returns no ecosystem since OSV does not support Helm charts.
+func (Extractor) Ecosystem(i *extractor.Inventory) (string, error) { return "", nil }
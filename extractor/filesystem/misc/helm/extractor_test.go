@@ -0,0 +1,368 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helm_test
+
+import (
+	"context"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem"
+	"github.com/google/osv-scalibr/extractor/filesystem/internal/units"
+	"github.com/google/osv-scalibr/extractor/filesystem/misc/helm"
+	scalibrfs "github.com/google/osv-scalibr/fs"
+	"github.com/google/osv-scalibr/purl"
+	"github.com/google/osv-scalibr/stats"
+	"github.com/google/osv-scalibr/testing/fakefs"
+	"github.com/google/osv-scalibr/testing/testcollector"
+)
+
+func TestFileRequired(t *testing.T) {
+	tests := []struct {
+		name             string
+		path             string
+		fileSizeBytes    int64
+		maxFileSizeBytes int64
+		wantRequired     bool
+		wantResultMetric stats.FileRequiredResult
+	}{
+		{
+			name:             "Chart.yaml",
+			path:             "deploy/mychart/Chart.yaml",
+			wantRequired:     true,
+			wantResultMetric: stats.FileRequiredResultOK,
+		}, {
+			name:             "Chart.lock",
+			path:             "deploy/mychart/Chart.lock",
+			wantRequired:     true,
+			wantResultMetric: stats.FileRequiredResultOK,
+		}, {
+			name:             "requirements.lock",
+			path:             "deploy/mychart/requirements.lock",
+			wantRequired:     true,
+			wantResultMetric: stats.FileRequiredResultOK,
+		}, {
+			name:             "subchart",
+			path:             "deploy/mychart/charts/redis/Chart.yaml",
+			wantRequired:     true,
+			wantResultMetric: stats.FileRequiredResultOK,
+		}, {
+			name:         "values file",
+			path:         "deploy/mychart/values.yaml",
+			wantRequired: false,
+		}, {
+			name:         "Chart.yaml suffix",
+			path:         "deploy/mychart/OldChart.yaml",
+			wantRequired: false,
+		}, {
+			name:             "file size limit exceeded",
+			path:             "deploy/mychart/Chart.lock",
+			fileSizeBytes:    1 * units.MiB,
+			maxFileSizeBytes: 1000,
+			wantRequired:     false,
+			wantResultMetric: stats.FileRequiredResultSizeLimitExceeded,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			collector := testcollector.New()
+			var e filesystem.Extractor = helm.New(helm.Config{
+				Stats:            collector,
+				MaxFileSizeBytes: tt.maxFileSizeBytes,
+			})
+
+			fileSizeBytes := tt.fileSizeBytes
+			if fileSizeBytes == 0 {
+				fileSizeBytes = 1000
+			}
+			isRequired := e.FileRequired(tt.path, fakefs.FakeFileInfo{
+				FileName: filepath.Base(tt.path),
+				FileMode: fs.ModePerm,
+				FileSize: fileSizeBytes,
+			})
+			if isRequired != tt.wantRequired {
+				t.Fatalf("FileRequired(%s): got %v, want %v", tt.path, isRequired, tt.wantRequired)
+			}
+
+			gotResultMetric := collector.FileRequiredResult(tt.path)
+			if tt.wantResultMetric != "" && gotResultMetric != tt.wantResultMetric {
+				t.Errorf("FileRequired(%s) recorded result metric %v, want result metric %v", tt.path, gotResultMetric, tt.wantResultMetric)
+			}
+		})
+	}
+}
+
+func TestExtract(t *testing.T) {
+	tests := []struct {
+		name             string
+		path             string
+		wantInventory    []*extractor.Inventory
+		wantErr          error
+		wantResultMetric stats.FileExtractedResult
+	}{
+		{
+			name: "chart",
+			path: "mychart/Chart.yaml",
+			wantInventory: []*extractor.Inventory{
+				{
+					Name:    "mychart",
+					Version: "1.2.3",
+					Metadata: &helm.Metadata{
+						AppVersion: "1.16.0",
+						APIVersion: "v2",
+					},
+					Locations: []string{"mychart/Chart.yaml"},
+				},
+			},
+			wantResultMetric: stats.FileExtractedResultSuccess,
+		}, {
+			name: "chart lock",
+			path: "mychart/Chart.lock",
+			wantInventory: []*extractor.Inventory{
+				{
+					Name:    "postgresql",
+					Version: "12.5.6",
+					Metadata: &helm.Metadata{
+						Repository:  "https://charts.bitnami.com/bitnami",
+						ParentChart: "mychart",
+					},
+					Locations: []string{"mychart/Chart.lock"},
+				},
+				{
+					Name:    "redis",
+					Version: "17.11.3",
+					Metadata: &helm.Metadata{
+						Repository:  "oci://registry-1.docker.io/bitnamicharts",
+						ParentChart: "mychart",
+					},
+					Locations: []string{"mychart/Chart.lock"},
+				},
+				{
+					Name:    "common",
+					Version: "0.1.0",
+					Metadata: &helm.Metadata{
+						Repository:  "file://../common",
+						ParentChart: "mychart",
+					},
+					Locations: []string{"mychart/Chart.lock"},
+				},
+			},
+			wantResultMetric: stats.FileExtractedResultSuccess,
+		}, {
+			name: "subchart",
+			path: "mychart/charts/redis/Chart.yaml",
+			wantInventory: []*extractor.Inventory{
+				{
+					Name:    "redis",
+					Version: "17.11.3",
+					Metadata: &helm.Metadata{
+						AppVersion:  "7.0.11",
+						APIVersion:  "v2",
+						ParentChart: "mychart",
+					},
+					Locations: []string{"mychart/charts/redis/Chart.yaml"},
+				},
+			},
+			wantResultMetric: stats.FileExtractedResultSuccess,
+		}, {
+			name: "Helm 2 chart with unquoted appVersion",
+			path: "legacy/Chart.yaml",
+			wantInventory: []*extractor.Inventory{
+				{
+					Name:    "legacy",
+					Version: "0.3.0",
+					Metadata: &helm.Metadata{
+						AppVersion: "2.4",
+						APIVersion: "v1",
+					},
+					Locations: []string{"legacy/Chart.yaml"},
+				},
+			},
+			wantResultMetric: stats.FileExtractedResultSuccess,
+		}, {
+			name: "Helm 2 requirements.lock",
+			path: "legacy/requirements.lock",
+			wantInventory: []*extractor.Inventory{
+				{
+					Name:    "mariadb",
+					Version: "5.11.3",
+					Metadata: &helm.Metadata{
+						Repository:  "https://kubernetes-charts.storage.googleapis.com/",
+						ParentChart: "legacy",
+					},
+					Locations: []string{"legacy/requirements.lock"},
+				},
+			},
+			wantResultMetric: stats.FileExtractedResultSuccess,
+		}, {
+			name: "chart without lock file",
+			path: "nolock/Chart.yaml",
+			wantInventory: []*extractor.Inventory{
+				{
+					Name:    "nolock",
+					Version: "0.1.0",
+					Metadata: &helm.Metadata{
+						APIVersion: "v2",
+					},
+					Locations: []string{"nolock/Chart.yaml"},
+				},
+			},
+			wantResultMetric: stats.FileExtractedResultSuccess,
+		}, {
+			name: "lock file without Chart.yaml uses the directory name",
+			path: "orphan-lock/Chart.lock",
+			wantInventory: []*extractor.Inventory{
+				{
+					Name:    "nginx",
+					Version: "15.1.0",
+					Metadata: &helm.Metadata{
+						Repository:  "https://charts.bitnami.com/bitnami",
+						ParentChart: "orphan-lock",
+					},
+					Locations: []string{"orphan-lock/Chart.lock"},
+				},
+			},
+			wantResultMetric: stats.FileExtractedResultSuccess,
+		}, {
+			name:             "chart without version",
+			path:             "invalid/Chart.yaml",
+			wantErr:          extractor.ErrMalformedInput,
+			wantResultMetric: stats.FileExtractedResultErrorMalformedInput,
+		}, {
+			name:             "invalid lock file",
+			path:             "invalid-lock/Chart.lock",
+			wantErr:          extractor.ErrMalformedInput,
+			wantResultMetric: stats.FileExtractedResultErrorMalformedInput,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			collector := testcollector.New()
+			var e filesystem.Extractor = helm.New(helm.Config{
+				Stats:            collector,
+				MaxFileSizeBytes: 1 * units.MiB,
+			})
+
+			d := "testdata"
+			r, err := os.Open(filepath.Join(d, tt.path))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer r.Close()
+			info, err := r.Stat()
+			if err != nil {
+				t.Fatalf("Stat(): %v", err)
+			}
+
+			input := &filesystem.ScanInput{
+				FS:     scalibrfs.DirFS(d),
+				Path:   tt.path,
+				Reader: r,
+				Root:   d,
+				Info:   info,
+			}
+			got, err := e.Extract(context.Background(), input)
+			if !cmp.Equal(err, tt.wantErr, cmpopts.EquateErrors()) {
+				t.Fatalf("Extract(%+v) error: got %v, want %v\n", tt.path, err, tt.wantErr)
+			}
+
+			if diff := cmp.Diff(tt.wantInventory, got); diff != "" {
+				t.Errorf("Extract(%s) (-want +got):\n%s", tt.path, diff)
+			}
+
+			gotResultMetric := collector.FileExtractedResult(tt.path)
+			if gotResultMetric != tt.wantResultMetric {
+				t.Errorf("Extract(%s) recorded result metric %v, want result metric %v", tt.path, gotResultMetric, tt.wantResultMetric)
+			}
+		})
+	}
+}
+
+func TestToPURL(t *testing.T) {
+	e := helm.New(helm.DefaultConfig())
+	tests := []struct {
+		name string
+		inv  *extractor.Inventory
+		want *purl.PackageURL
+	}{
+		{
+			name: "chart",
+			inv: &extractor.Inventory{
+				Name:     "mychart",
+				Version:  "1.2.3",
+				Metadata: &helm.Metadata{AppVersion: "1.16.0", APIVersion: "v2"},
+			},
+			want: &purl.PackageURL{
+				Type:       purl.TypeHelm,
+				Name:       "mychart",
+				Version:    "1.2.3",
+				Qualifiers: purl.QualifiersFromMap(map[string]string{}),
+			},
+		}, {
+			name: "dependency from repository",
+			inv: &extractor.Inventory{
+				Name:    "postgresql",
+				Version: "12.5.6",
+				Metadata: &helm.Metadata{
+					Repository:  "https://charts.bitnami.com/bitnami",
+					ParentChart: "mychart",
+				},
+			},
+			want: &purl.PackageURL{
+				Type:    purl.TypeHelm,
+				Name:    "postgresql",
+				Version: "12.5.6",
+				Qualifiers: purl.QualifiersFromMap(map[string]string{
+					purl.RepositoryURL: "https://charts.bitnami.com/bitnami",
+				}),
+			},
+		}, {
+			name: "local dependency",
+			inv: &extractor.Inventory{
+				Name:    "common",
+				Version: "0.1.0",
+				Metadata: &helm.Metadata{
+					Repository:  "file://../common",
+					ParentChart: "mychart",
+				},
+			},
+			want: &purl.PackageURL{
+				Type:       purl.TypeHelm,
+				Name:       "common",
+				Version:    "0.1.0",
+				Qualifiers: purl.QualifiersFromMap(map[string]string{}),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := e.ToPURL(tt.inv)
+			if err != nil {
+				t.Fatalf("ToPURL(%v): %v", tt.inv, err)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("ToPURL(%v) (-want +got):\n%s", tt.inv, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,29 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helm
+
+// Metadata holds information about a Helm chart or a chart dependency.
+type Metadata struct {
+	// AppVersion is the version of the application the chart deploys. Only set
+	// for charts read from a Chart.yaml file.
+	AppVersion string
+	// APIVersion is the chart API version, "v2" for Helm 3 charts.
+	APIVersion string
+	// Repository the dependency is fetched from, e.g.
+	// "https://charts.bitnami.com/bitnami". Only set for locked dependencies.
+	Repository string
+	// ParentChart is the name of the chart that depends on this one, if any.
+	ParentChart string
+}
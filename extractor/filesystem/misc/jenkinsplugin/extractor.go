@@ -0,0 +1,229 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package jenkinsplugin extracts the Jenkins plugins installed on a Jenkins
+// server from the manifests of their unpacked plugins/<name>/ directories.
+//
+// The packed .hpi and .jpi plugin archives are already covered by the
+// java/archive extractor.
+package jenkinsplugin
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"strings"
+
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem"
+	"github.com/google/osv-scalibr/extractor/filesystem/internal/units"
+	"github.com/google/osv-scalibr/plugin"
+	"github.com/google/osv-scalibr/purl"
+	"github.com/google/osv-scalibr/stats"
+)
+
+const (
+	// Name is the unique name of this extractor.
+	Name = "misc/jenkinsplugin"
+
+	// defaultMaxFileSizeBytes is the maximum file size an extractor will unmarshal.
+	// If Extract gets a bigger file, it will return an error.
+	defaultMaxFileSizeBytes = 1 * units.MiB
+)
+
+// Config is the configuration for the Extractor.
+type Config struct {
+	// Stats is a stats collector for reporting metrics.
+	Stats stats.Collector
+	// MaxFileSizeBytes is the maximum file size this extractor will unmarshal. If
+	// `FileRequired` gets a bigger file, it will return false,
+	MaxFileSizeBytes int64
+}
+
+// DefaultConfig returns the default configuration for the extractor.
+func DefaultConfig() Config {
+	return Config{
+		MaxFileSizeBytes: defaultMaxFileSizeBytes,
+		Stats:            nil,
+	}
+}
+
+// Extractor extracts installed Jenkins plugins.
+type Extractor struct {
+	stats            stats.Collector
+	maxFileSizeBytes int64
+}
+
+// New returns a Jenkins plugin extractor.
+//
+// For most use cases, initialize with:
+// ```
+// e := New(DefaultConfig())
+// ```
+func New(cfg Config) *Extractor {
+	return &Extractor{
+		stats:            cfg.Stats,
+		maxFileSizeBytes: cfg.MaxFileSizeBytes,
+	}
+}
+
+// Config returns the configuration of the extractor.
+func (e Extractor) Config() Config {
+	return Config{
+		Stats:            e.stats,
+		MaxFileSizeBytes: e.maxFileSizeBytes,
+	}
+}
+
+// Name of the extractor.
+func (e Extractor) Name() string { return Name }
+
+// Version of the extractor.
+func (e Extractor) Version() int { return 0 }
+
+// Requirements of the extractor.
+func (e Extractor) Requirements() *plugin.Capabilities { return &plugin.Capabilities{} }
+
+// RequiredFileNames returns the name of the plugin manifests the extractor requires.
+func (e Extractor) RequiredFileNames() []string { return []string{"MANIFEST.MF"} }
+
+// FileRequired returns true if the specified file is the manifest of an
+// unpacked plugin, i.e. plugins/<name>/META-INF/MANIFEST.MF.
+func (e Extractor) FileRequired(path string, fileinfo fs.FileInfo) bool {
+	parts := strings.Split(filepath.ToSlash(path), "/")
+	n := len(parts)
+	if n < 4 || parts[n-1] != "MANIFEST.MF" || parts[n-2] != "META-INF" || parts[n-4] != "plugins" {
+		return false
+	}
+
+	if e.maxFileSizeBytes > 0 && fileinfo.Size() > e.maxFileSizeBytes {
+		e.reportFileRequired(path, fileinfo.Size(), stats.FileRequiredResultSizeLimitExceeded)
+		return false
+	}
+
+	e.reportFileRequired(path, fileinfo.Size(), stats.FileRequiredResultOK)
+	return true
+}
+
+func (e Extractor) reportFileRequired(path string, fileSizeBytes int64, result stats.FileRequiredResult) {
+	if e.stats == nil {
+		return
+	}
+	e.stats.AfterFileRequired(e.Name(), &stats.FileRequiredStats{
+		Path:          path,
+		Result:        result,
+		FileSizeBytes: fileSizeBytes,
+	})
+}
+
+// Extract returns the Jenkins plugin described by the manifest.
+func (e Extractor) Extract(ctx context.Context, input *filesystem.ScanInput) ([]*extractor.Inventory, error) {
+	inventory, err := e.extractFromInput(input)
+	if e.stats != nil {
+		var fileSizeBytes int64
+		if input.Info != nil {
+			fileSizeBytes = input.Info.Size()
+		}
+		e.stats.AfterFileExtracted(e.Name(), &stats.FileExtractedStats{
+			Path:          input.Path,
+			Result:        filesystem.ExtractorErrorToFileExtractedResult(err),
+			FileSizeBytes: fileSizeBytes,
+		})
+	}
+	return inventory, err
+}
+
+func (e Extractor) extractFromInput(input *filesystem.ScanInput) ([]*extractor.Inventory, error) {
+	attrs, err := parseMainSection(input)
+	if err != nil {
+		return nil, err
+	}
+
+	name := attrs["Short-Name"]
+	if name == "" {
+		name = attrs["Implementation-Title"]
+	}
+	version := attrs["Plugin-Version"]
+	if name == "" || version == "" {
+		return nil, fmt.Errorf("missing plugin name or version in %q: %w", input.Path, extractor.ErrMalformedInput)
+	}
+	return []*extractor.Inventory{{
+		Name:    name,
+		Version: version,
+		Metadata: &Metadata{
+			LongName:       attrs["Long-Name"],
+			GroupID:        attrs["Group-Id"],
+			JenkinsVersion: attrs["Jenkins-Version"],
+		},
+		Locations: []string{input.Path},
+	}}, nil
+}
+
+// parseMainSection returns the attributes of the main section of a manifest,
+// i.e. the ones before the first empty line. Lines longer than 72 bytes are
+// folded by continuing them on the next line after a single space, e.g.
+//
+//	Plugin-Dependencies: credentials:1311.vcf0a_900b_37c2,git-client:4.6
+//	 .0,scm-api:683.vb_16722fb_b_80b_
+//
+// Unlike in MIME headers, the space isn't part of the value.
+func parseMainSection(input *filesystem.ScanInput) (map[string]string, error) {
+	attrs := map[string]string{}
+	var key string
+	s := bufio.NewScanner(input.Reader)
+	for s.Scan() {
+		line := strings.TrimSuffix(s.Text(), "\r")
+		if line == "" {
+			break
+		}
+		if strings.HasPrefix(line, " ") {
+			if key == "" {
+				return nil, fmt.Errorf("continuation line without an attribute in %q: %w", input.Path, extractor.ErrMalformedInput)
+			}
+			attrs[key] += line[1:]
+			continue
+		}
+		k, v, ok := strings.Cut(line, ":")
+		if !ok {
+			return nil, fmt.Errorf("invalid manifest line %q in %q: %w", line, input.Path, extractor.ErrMalformedInput)
+		}
+		key = k
+		attrs[key] = strings.TrimPrefix(v, " ")
+	}
+	if err := s.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read %q: %w", input.Path, err)
+	}
+	return attrs, nil
+}
+
+// ToPURL converts an inventory created by this extractor into a PURL.
+// Jenkins plugins are published as Maven artifacts named after their short
+// name.
+func (e Extractor) ToPURL(i *extractor.Inventory) (*purl.PackageURL, error) {
+	m := i.Metadata.(*Metadata)
+	return &purl.PackageURL{
+		Type:      purl.TypeMaven,
+		Namespace: strings.ToLower(m.GroupID),
+		Name:      strings.ToLower(i.Name),
+		Version:   i.Version,
+	}, nil
+}
+
+// ToCPEs is not applicable as this extractor does not infer CPEs from the Inventory.
+func (e Extractor) ToCPEs(i *extractor.Inventory) ([]string, error) { return []string{}, nil }
+
+// Ecosystem returns the OSV Ecosystem of the software extracted by this extractor.
+func (Extractor) Ecosystem(i *extractor.Inventory) (string, error) { return "Maven", nil }
@@ -0,0 +1,232 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jenkinsplugin_test
+
+import (
+	"context"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem"
+	"github.com/google/osv-scalibr/extractor/filesystem/internal/units"
+	"github.com/google/osv-scalibr/extractor/filesystem/misc/jenkinsplugin"
+	scalibrfs "github.com/google/osv-scalibr/fs"
+	"github.com/google/osv-scalibr/purl"
+	"github.com/google/osv-scalibr/stats"
+	"github.com/google/osv-scalibr/testing/fakefs"
+	"github.com/google/osv-scalibr/testing/testcollector"
+)
+
+func TestFileRequired(t *testing.T) {
+	tests := []struct {
+		name             string
+		path             string
+		fileSizeBytes    int64
+		maxFileSizeBytes int64
+		wantRequired     bool
+		wantResultMetric stats.FileRequiredResult
+	}{
+		{
+			name:             "plugin manifest",
+			path:             "var/jenkins_home/plugins/git/META-INF/MANIFEST.MF",
+			wantRequired:     true,
+			wantResultMetric: stats.FileRequiredResultOK,
+		}, {
+			name:             "relative plugins dir",
+			path:             "plugins/git/META-INF/MANIFEST.MF",
+			wantRequired:     true,
+			wantResultMetric: stats.FileRequiredResultOK,
+		}, {
+			name:         "manifest outside of a plugins dir",
+			path:         "opt/app/META-INF/MANIFEST.MF",
+			wantRequired: false,
+		}, {
+			name:         "manifest nested in a plugin",
+			path:         "plugins/git/WEB-INF/lib/META-INF/MANIFEST.MF",
+			wantRequired: false,
+		}, {
+			name:         "plugin archive",
+			path:         "var/jenkins_home/plugins/git.jpi",
+			wantRequired: false,
+		}, {
+			name:             "file size limit exceeded",
+			path:             "plugins/git/META-INF/MANIFEST.MF",
+			fileSizeBytes:    1 * units.MiB,
+			maxFileSizeBytes: 1000,
+			wantRequired:     false,
+			wantResultMetric: stats.FileRequiredResultSizeLimitExceeded,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			collector := testcollector.New()
+			var e filesystem.Extractor = jenkinsplugin.New(jenkinsplugin.Config{
+				Stats:            collector,
+				MaxFileSizeBytes: tt.maxFileSizeBytes,
+			})
+
+			fileSizeBytes := tt.fileSizeBytes
+			if fileSizeBytes == 0 {
+				fileSizeBytes = 1000
+			}
+			isRequired := e.FileRequired(tt.path, fakefs.FakeFileInfo{
+				FileName: filepath.Base(tt.path),
+				FileMode: fs.ModePerm,
+				FileSize: fileSizeBytes,
+			})
+			if isRequired != tt.wantRequired {
+				t.Fatalf("FileRequired(%s): got %v, want %v", tt.path, isRequired, tt.wantRequired)
+			}
+
+			gotResultMetric := collector.FileRequiredResult(tt.path)
+			if tt.wantResultMetric != "" && gotResultMetric != tt.wantResultMetric {
+				t.Errorf("FileRequired(%s) recorded result metric %v, want result metric %v", tt.path, gotResultMetric, tt.wantResultMetric)
+			}
+		})
+	}
+}
+
+func TestExtract(t *testing.T) {
+	tests := []struct {
+		name             string
+		path             string
+		wantInventory    []*extractor.Inventory
+		wantErr          error
+		wantResultMetric stats.FileExtractedResult
+	}{
+		{
+			name: "manifest with folded lines",
+			path: "plugins/git/META-INF/MANIFEST.MF",
+			wantInventory: []*extractor.Inventory{
+				{
+					Name:    "git",
+					Version: "5.2.1",
+					Metadata: &jenkinsplugin.Metadata{
+						LongName:       "Jenkins Git plugin, integrating Git repositories with Jenkins pipelines and freestyle jobs",
+						GroupID:        "org.jenkins-ci.plugins",
+						JenkinsVersion: "2.387.3",
+					},
+					Locations: []string{"plugins/git/META-INF/MANIFEST.MF"},
+				},
+			},
+			wantResultMetric: stats.FileExtractedResultSuccess,
+		}, {
+			name: "name from Implementation-Title",
+			path: "plugins/legacy/META-INF/MANIFEST.MF",
+			wantInventory: []*extractor.Inventory{
+				{
+					Name:    "legacy-notifier",
+					Version: "1.4",
+					Metadata: &jenkinsplugin.Metadata{
+						LongName: "Legacy Notifier",
+					},
+					Locations: []string{"plugins/legacy/META-INF/MANIFEST.MF"},
+				},
+			},
+			wantResultMetric: stats.FileExtractedResultSuccess,
+		}, {
+			name: "per-entry sections are ignored",
+			path: "plugins/sections/META-INF/MANIFEST.MF",
+			wantInventory: []*extractor.Inventory{
+				{
+					Name:    "sections",
+					Version: "2.0.0",
+					Metadata: &jenkinsplugin.Metadata{
+						GroupID: "io.jenkins.plugins",
+					},
+					Locations: []string{"plugins/sections/META-INF/MANIFEST.MF"},
+				},
+			},
+			wantResultMetric: stats.FileExtractedResultSuccess,
+		}, {
+			name:             "missing version",
+			path:             "plugins/invalid/META-INF/MANIFEST.MF",
+			wantErr:          extractor.ErrMalformedInput,
+			wantResultMetric: stats.FileExtractedResultErrorMalformedInput,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			collector := testcollector.New()
+			var e filesystem.Extractor = jenkinsplugin.New(jenkinsplugin.Config{
+				Stats:            collector,
+				MaxFileSizeBytes: 1 * units.MiB,
+			})
+
+			d := "testdata"
+			r, err := os.Open(filepath.Join(d, tt.path))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer r.Close()
+			info, err := r.Stat()
+			if err != nil {
+				t.Fatalf("Stat(): %v", err)
+			}
+
+			input := &filesystem.ScanInput{
+				FS:     scalibrfs.DirFS(d),
+				Path:   tt.path,
+				Reader: r,
+				Root:   d,
+				Info:   info,
+			}
+			got, err := e.Extract(context.Background(), input)
+			if !cmp.Equal(err, tt.wantErr, cmpopts.EquateErrors()) {
+				t.Fatalf("Extract(%+v) error: got %v, want %v\n", tt.path, err, tt.wantErr)
+			}
+
+			if diff := cmp.Diff(tt.wantInventory, got); diff != "" {
+				t.Errorf("Extract(%s) (-want +got):\n%s", tt.path, diff)
+			}
+
+			gotResultMetric := collector.FileExtractedResult(tt.path)
+			if gotResultMetric != tt.wantResultMetric {
+				t.Errorf("Extract(%s) recorded result metric %v, want result metric %v", tt.path, gotResultMetric, tt.wantResultMetric)
+			}
+		})
+	}
+}
+
+func TestToPURL(t *testing.T) {
+	e := jenkinsplugin.New(jenkinsplugin.DefaultConfig())
+	i := &extractor.Inventory{
+		Name:    "Git",
+		Version: "5.2.1",
+		Metadata: &jenkinsplugin.Metadata{
+			GroupID: "org.jenkins-ci.plugins",
+		},
+	}
+	want := &purl.PackageURL{
+		Type:      purl.TypeMaven,
+		Namespace: "org.jenkins-ci.plugins",
+		Name:      "git",
+		Version:   "5.2.1",
+	}
+	got, err := e.ToPURL(i)
+	if err != nil {
+		t.Fatalf("ToPURL(%v): %v", i, err)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ToPURL(%v) (-want +got):\n%s", i, diff)
+	}
+}
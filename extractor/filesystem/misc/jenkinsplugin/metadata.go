@@ -0,0 +1,26 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jenkinsplugin
+
+// Metadata holds additional information about an installed Jenkins plugin.
+type Metadata struct {
+	// LongName is the display name of the plugin, e.g. "Git plugin".
+	LongName string
+	// GroupID is the Maven group ID the plugin is published under, e.g.
+	// "org.jenkins-ci.plugins".
+	GroupID string
+	// JenkinsVersion is the minimum Jenkins version the plugin requires.
+	JenkinsVersion string
+}
@@ -0,0 +1,431 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package ocilayout extracts the container images stored in OCI image layout
+// directories, e.g. the on-disk copy of an air-gapped registry.
+package ocilayout
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem"
+	"github.com/google/osv-scalibr/extractor/filesystem/internal/units"
+	"github.com/google/osv-scalibr/log"
+	"github.com/google/osv-scalibr/plugin"
+	"github.com/google/osv-scalibr/purl"
+	"github.com/google/osv-scalibr/stats"
+)
+
+const (
+	// Name is the unique name of this extractor.
+	Name = "misc/ocilayout"
+
+	// defaultMaxFileSizeBytes is the maximum size of the index.json and of the
+	// blobs it references that the extractor will unmarshal.
+	defaultMaxFileSizeBytes = 10 * units.MiB
+
+	// maxIndexDepth limits how deep image indexes can be nested.
+	maxIndexDepth = 8
+
+	layoutFileName = "oci-layout"
+	indexFileName  = "index.json"
+	blobsDirName   = "blobs"
+
+	mediaTypeOCIIndex           = "application/vnd.oci.image.index.v1+json"
+	mediaTypeOCIManifest        = "application/vnd.oci.image.manifest.v1+json"
+	mediaTypeDockerManifestList = "application/vnd.docker.distribution.manifest.list.v2+json"
+	mediaTypeDockerManifest     = "application/vnd.docker.distribution.manifest.v2+json"
+
+	annotationRefName        = "org.opencontainers.image.ref.name"
+	annotationContainerdName = "io.containerd.image.name"
+	annotationDockerRefType  = "vnd.docker.reference.type"
+	dockerRefTypeAttestation = "attestation-manifest"
+	// unknownPlatform is the OS and architecture of BuildKit's attestation manifests.
+	unknownPlatform = "unknown"
+)
+
+// digestRegex matches valid OCI digests. Validating them also makes sure that
+// the blob paths constructed from them don't escape the layout directory.
+var digestRegex = regexp.MustCompile(`^[a-z0-9]+(?:[.+_-][a-z0-9]+)*:[a-zA-Z0-9=_-]+$`)
+
+// descriptor references content in the layout's blob store.
+type descriptor struct {
+	MediaType   string            `json:"mediaType"`
+	Digest      string            `json:"digest"`
+	Annotations map[string]string `json:"annotations"`
+	Platform    *platform         `json:"platform"`
+}
+
+type platform struct {
+	Architecture string `json:"architecture"`
+	OS           string `json:"os"`
+	Variant      string `json:"variant"`
+}
+
+// index is an image index, i.e. the index.json or a multi-platform image.
+type index struct {
+	Manifests []descriptor `json:"manifests"`
+}
+
+type manifest struct {
+	Config descriptor `json:"config"`
+}
+
+// imageRef is the name an image is stored under in the layout.
+type imageRef struct {
+	repository string
+	tag        string
+}
+
+// Config is the configuration for the Extractor.
+type Config struct {
+	// Stats is a stats collector for reporting metrics.
+	Stats stats.Collector
+	// MaxFileSizeBytes is the maximum size of the index.json and of the blobs it
+	// references that this extractor will unmarshal. If `FileRequired` gets a
+	// bigger file, it will return false.
+	MaxFileSizeBytes int64
+}
+
+// DefaultConfig returns the default configuration for the extractor.
+func DefaultConfig() Config {
+	return Config{
+		MaxFileSizeBytes: defaultMaxFileSizeBytes,
+		Stats:            nil,
+	}
+}
+
+// Extractor extracts the images stored in OCI image layouts.
+type Extractor struct {
+	stats            stats.Collector
+	maxFileSizeBytes int64
+}
+
+// New returns an OCI image layout extractor.
+//
+// For most use cases, initialize with:
+// ```
+// e := New(DefaultConfig())
+// ```
+func New(cfg Config) *Extractor {
+	return &Extractor{
+		stats:            cfg.Stats,
+		maxFileSizeBytes: cfg.MaxFileSizeBytes,
+	}
+}
+
+// Config returns the configuration of the extractor.
+func (e Extractor) Config() Config {
+	return Config{
+		Stats:            e.stats,
+		MaxFileSizeBytes: e.maxFileSizeBytes,
+	}
+}
+
+// Name of the extractor.
+func (e Extractor) Name() string { return Name }
+
+// Version of the extractor.
+func (e Extractor) Version() int { return 0 }
+
+// Requirements of the extractor.
+func (e Extractor) Requirements() *plugin.Capabilities { return &plugin.Capabilities{} }
+
+// RequiredFileNames returns the name of the OCI image index files the extractor requires.
+func (e Extractor) RequiredFileNames() []string { return []string{indexFileName} }
+
+// FileRequired returns true if the specified file is named index.json. Extract
+// then checks for the oci-layout file next to it, since FileRequired doesn't
+// have access to the rest of the directory.
+func (e Extractor) FileRequired(path string, fileinfo fs.FileInfo) bool {
+	if filepath.Base(path) != indexFileName {
+		return false
+	}
+
+	if e.maxFileSizeBytes > 0 && fileinfo.Size() > e.maxFileSizeBytes {
+		e.reportFileRequired(path, fileinfo.Size(), stats.FileRequiredResultSizeLimitExceeded)
+		return false
+	}
+
+	e.reportFileRequired(path, fileinfo.Size(), stats.FileRequiredResultOK)
+	return true
+}
+
+func (e Extractor) reportFileRequired(path string, fileSizeBytes int64, result stats.FileRequiredResult) {
+	if e.stats == nil {
+		return
+	}
+	e.stats.AfterFileRequired(e.Name(), &stats.FileRequiredStats{
+		Path:          path,
+		Result:        result,
+		FileSizeBytes: fileSizeBytes,
+	})
+}
+
+// Extract returns one Inventory per platform-specific image manifest that's
+// referenced by the index.json of an OCI image layout.
+func (e Extractor) Extract(ctx context.Context, input *filesystem.ScanInput) ([]*extractor.Inventory, error) {
+	inventory, err := e.extractFromInput(ctx, input)
+	if e.stats != nil {
+		var fileSizeBytes int64
+		if input.Info != nil {
+			fileSizeBytes = input.Info.Size()
+		}
+		e.stats.AfterFileExtracted(e.Name(), &stats.FileExtractedStats{
+			Path:          input.Path,
+			Result:        filesystem.ExtractorErrorToFileExtractedResult(err),
+			FileSizeBytes: fileSizeBytes,
+		})
+	}
+	return inventory, err
+}
+
+func (e Extractor) extractFromInput(ctx context.Context, input *filesystem.ScanInput) ([]*extractor.Inventory, error) {
+	layoutDir := path.Dir(filepath.ToSlash(input.Path))
+	if _, err := fs.Stat(input.FS, path.Join(layoutDir, layoutFileName)); err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			// Some other index.json file.
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to stat %s next to %q: %w", layoutFileName, input.Path, err)
+	}
+
+	var idx index
+	if err := json.NewDecoder(input.Reader).Decode(&idx); err != nil {
+		return nil, fmt.Errorf("failed to parse %q: %w: %w", input.Path, extractor.ErrMalformedInput, err)
+	}
+
+	w := &layoutWalker{
+		e:          e,
+		fsys:       input.FS,
+		layoutDir:  layoutDir,
+		layoutName: layoutName(layoutDir, input.Root),
+		location:   input.Path,
+	}
+	var inventory []*extractor.Inventory
+	for _, desc := range idx.Manifests {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		inv, err := w.walk(desc, imageRef{}, "", 0)
+		if err != nil {
+			return nil, err
+		}
+		inventory = append(inventory, inv...)
+	}
+	return inventory, nil
+}
+
+// layoutWalker follows the descriptors of a single OCI image layout.
+type layoutWalker struct {
+	e    Extractor
+	fsys fs.FS
+	// layoutDir is the directory containing the index.json, relative to the scan root.
+	layoutDir string
+	// layoutName is used as the repository for images that are only tagged.
+	layoutName string
+	// location is the path of the index.json.
+	location string
+}
+
+// walk returns the images referenced by desc. ref and indexDigest are
+// inherited from the image index desc was found in, if any.
+func (w *layoutWalker) walk(desc descriptor, ref imageRef, indexDigest string, depth int) ([]*extractor.Inventory, error) {
+	if r, ok := refFromAnnotations(desc.Annotations, w.layoutName); ok {
+		ref = r
+	}
+
+	switch desc.MediaType {
+	case mediaTypeOCIIndex, mediaTypeDockerManifestList:
+		if depth >= maxIndexDepth {
+			return nil, fmt.Errorf("image indexes in %q are nested more than %d levels deep: %w", w.location, maxIndexDepth, extractor.ErrMalformedInput)
+		}
+		var idx index
+		if err := w.readBlob(desc.Digest, &idx); err != nil {
+			return nil, err
+		}
+		var inventory []*extractor.Inventory
+		for _, m := range idx.Manifests {
+			if m.Annotations[annotationDockerRefType] == dockerRefTypeAttestation {
+				continue
+			}
+			if m.Platform != nil && m.Platform.OS == unknownPlatform && m.Platform.Architecture == unknownPlatform {
+				continue
+			}
+			inv, err := w.walk(m, ref, desc.Digest, depth+1)
+			if err != nil {
+				return nil, err
+			}
+			inventory = append(inventory, inv...)
+		}
+		return inventory, nil
+	case mediaTypeOCIManifest, mediaTypeDockerManifest:
+		p, err := w.platform(desc)
+		if err != nil {
+			return nil, err
+		}
+		return []*extractor.Inventory{w.toInventory(desc, ref, indexDigest, p)}, nil
+	default:
+		// Non-image artifacts such as signatures or Helm charts.
+		log.Debugf("Skipping descriptor %s with media type %q in %q", desc.Digest, desc.MediaType, w.location)
+		return nil, nil
+	}
+}
+
+// platform returns the platform of the image manifest desc, reading it from the
+// image config if the descriptor doesn't specify it.
+func (w *layoutWalker) platform(desc descriptor) (platform, error) {
+	if desc.Platform != nil {
+		return *desc.Platform, nil
+	}
+	var m manifest
+	if err := w.readBlob(desc.Digest, &m); err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			// Layouts can be partial copies of a registry, the image is still present.
+			log.Debugf("Manifest blob %s referenced in %q is missing", desc.Digest, w.location)
+			return platform{}, nil
+		}
+		return platform{}, err
+	}
+	var p platform
+	if err := w.readBlob(m.Config.Digest, &p); err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			log.Debugf("Config blob %s referenced in %q is missing", m.Config.Digest, w.location)
+			return platform{}, nil
+		}
+		return platform{}, err
+	}
+	return p, nil
+}
+
+// readBlob unmarshals the JSON blob with the given digest into v.
+func (w *layoutWalker) readBlob(digest string, v any) error {
+	if !digestRegex.MatchString(digest) {
+		return fmt.Errorf("invalid digest %q in %q: %w", digest, w.location, extractor.ErrMalformedInput)
+	}
+	algorithm, encoded, _ := strings.Cut(digest, ":")
+	blobPath := path.Join(w.layoutDir, blobsDirName, algorithm, encoded)
+
+	f, err := w.fsys.Open(blobPath)
+	if err != nil {
+		return fmt.Errorf("failed to open blob %s: %w", digest, err)
+	}
+	defer f.Close()
+	if w.e.maxFileSizeBytes > 0 {
+		info, err := f.Stat()
+		if err != nil {
+			return fmt.Errorf("failed to stat blob %s: %w", digest, err)
+		}
+		if info.Size() > w.e.maxFileSizeBytes {
+			return fmt.Errorf("blob %s is %d bytes, bigger than the limit of %d bytes", digest, info.Size(), w.e.maxFileSizeBytes)
+		}
+	}
+	if err := json.NewDecoder(f).Decode(v); err != nil {
+		return fmt.Errorf("failed to parse blob %s: %w: %w", digest, extractor.ErrMalformedInput, err)
+	}
+	return nil
+}
+
+func (w *layoutWalker) toInventory(desc descriptor, ref imageRef, indexDigest string, p platform) *extractor.Inventory {
+	repository := ref.repository
+	if repository == "" {
+		repository = w.layoutName
+	}
+	return &extractor.Inventory{
+		Name:    repository,
+		Version: desc.Digest,
+		Metadata: &Metadata{
+			Repository:     repository,
+			Tag:            ref.tag,
+			ManifestDigest: desc.Digest,
+			IndexDigest:    indexDigest,
+			OS:             p.OS,
+			Architecture:   p.Architecture,
+			Variant:        p.Variant,
+		},
+		Locations: []string{w.location},
+	}
+}
+
+// refFromAnnotations returns the image name from the descriptor annotations.
+// containerd stores the full image name, while other tools such as skopeo only
+// store the tag and use the layout directory's name as the repository.
+func refFromAnnotations(annotations map[string]string, layoutName string) (imageRef, bool) {
+	if name := annotations[annotationContainerdName]; name != "" {
+		return parseImageName(name), true
+	}
+	name := annotations[annotationRefName]
+	if name == "" {
+		return imageRef{}, false
+	}
+	// Tags can't contain any of these characters.
+	if strings.ContainsAny(name, "/:@") {
+		return parseImageName(name), true
+	}
+	return imageRef{repository: layoutName, tag: name}, true
+}
+
+// parseImageName splits an image name such as "gcr.io/distroless/static:nonroot"
+// into repository and tag.
+func parseImageName(name string) imageRef {
+	name, _, _ = strings.Cut(name, "@")
+	if i := strings.LastIndex(name, ":"); i > strings.LastIndex(name, "/") {
+		return imageRef{repository: name[:i], tag: name[i+1:]}
+	}
+	return imageRef{repository: name}
+}
+
+// layoutName returns the name of the layout directory.
+func layoutName(layoutDir, root string) string {
+	if layoutDir == "." {
+		return filepath.Base(root)
+	}
+	return path.Base(layoutDir)
+}
+
+// ToPURL converts an inventory created by this extractor into a PURL.
+func (e Extractor) ToPURL(i *extractor.Inventory) (*purl.PackageURL, error) {
+	m := i.Metadata.(*Metadata)
+	qualifiers := map[string]string{}
+	if strings.Contains(m.Repository, "/") {
+		qualifiers[purl.RepositoryURL] = m.Repository
+	}
+	if m.Tag != "" {
+		qualifiers[purl.Tag] = m.Tag
+	}
+	if m.Architecture != "" {
+		qualifiers[purl.Arch] = m.Architecture
+	}
+	return &purl.PackageURL{
+		Type:       purl.TypeOCI,
+		Name:       strings.ToLower(path.Base(m.Repository)),
+		Version:    i.Version,
+		Qualifiers: purl.QualifiersFromMap(qualifiers),
+	}, nil
+}
+
+// ToCPEs is not applicable as this extractor does not infer CPEs from the Inventory.
+func (e Extractor) ToCPEs(i *extractor.Inventory) ([]string, error) { return []string{}, nil }
+
+// Ecosystem returns no ecosystem since OSV does not support container images.
+func (Extractor) Ecosystem(i *extractor.Inventory) (string, error) { return "", nil }
@@ -0,0 +1,315 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ocilayout_test
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem"
+	"github.com/google/osv-scalibr/extractor/filesystem/internal/units"
+	"github.com/google/osv-scalibr/extractor/filesystem/misc/ocilayout"
+	scalibrfs "github.com/google/osv-scalibr/fs"
+	"github.com/google/osv-scalibr/purl"
+	"github.com/google/osv-scalibr/stats"
+	"github.com/google/osv-scalibr/testing/fakefs"
+	"github.com/google/osv-scalibr/testing/testcollector"
+)
+
+const (
+	amd64Digest     = "sha256:35b6a6f09fb9557e7da6c168abfe1c86318fc0a6c559f9eaff6da06e745c85ca"
+	arm64Digest     = "sha256:46458b7fce0dbb4fddd6461ddde72953696cb29030d2613bc3a2d9f98f4cd9dc"
+	distrolessIndex = "sha256:7a2ddb9cc5d756e6cc9fd78c5846d77ec8bc3c83909c2722cc51d1f17c511ef6"
+)
+
+func TestFileRequired(t *testing.T) {
+	tests := []struct {
+		name             string
+		path             string
+		fileSizeBytes    int64
+		maxFileSizeBytes int64
+		wantRequired     bool
+		wantResultMetric stats.FileRequiredResult
+	}{
+		{
+			name:             "index.json",
+			path:             "registry/alpine/index.json",
+			wantRequired:     true,
+			wantResultMetric: stats.FileRequiredResultOK,
+		}, {
+			name:         "other file in layout",
+			path:         "registry/alpine/oci-layout",
+			wantRequired: false,
+		}, {
+			name:         "index.json suffix",
+			path:         "registry/alpine/search-index.json",
+			wantRequired: false,
+		}, {
+			name:             "file size limit exceeded",
+			path:             "registry/alpine/index.json",
+			fileSizeBytes:    1 * units.MiB,
+			maxFileSizeBytes: 1000,
+			wantRequired:     false,
+			wantResultMetric: stats.FileRequiredResultSizeLimitExceeded,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			collector := testcollector.New()
+			var e filesystem.Extractor = ocilayout.New(ocilayout.Config{
+				Stats:            collector,
+				MaxFileSizeBytes: tt.maxFileSizeBytes,
+			})
+
+			fileSizeBytes := tt.fileSizeBytes
+			if fileSizeBytes == 0 {
+				fileSizeBytes = 1000
+			}
+			isRequired := e.FileRequired(tt.path, fakefs.FakeFileInfo{
+				FileName: filepath.Base(tt.path),
+				FileMode: fs.ModePerm,
+				FileSize: fileSizeBytes,
+			})
+			if isRequired != tt.wantRequired {
+				t.Fatalf("FileRequired(%s): got %v, want %v", tt.path, isRequired, tt.wantRequired)
+			}
+
+			gotResultMetric := collector.FileRequiredResult(tt.path)
+			if tt.wantResultMetric != "" && gotResultMetric != tt.wantResultMetric {
+				t.Errorf("FileRequired(%s) recorded result metric %v, want result metric %v", tt.path, gotResultMetric, tt.wantResultMetric)
+			}
+		})
+	}
+}
+
+func TestExtract(t *testing.T) {
+	tests := []struct {
+		name             string
+		path             string
+		wantInventory    []*extractor.Inventory
+		wantErr          error
+		wantResultMetric stats.FileExtractedResult
+	}{
+		{
+			name: "single-platform image tagged by skopeo",
+			path: "alpine/index.json",
+			wantInventory: []*extractor.Inventory{
+				{
+					Name:    "alpine",
+					Version: amd64Digest,
+					Metadata: &ocilayout.Metadata{
+						Repository:     "alpine",
+						Tag:            "3.19",
+						ManifestDigest: amd64Digest,
+						OS:             "linux",
+						Architecture:   "amd64",
+					},
+					Locations: []string{"alpine/index.json"},
+				},
+			},
+			wantResultMetric: stats.FileExtractedResultSuccess,
+		}, {
+			name: "multi-platform image exported by containerd",
+			path: "multiarch/index.json",
+			wantInventory: []*extractor.Inventory{
+				{
+					Name:    "gcr.io/distroless/static",
+					Version: amd64Digest,
+					Metadata: &ocilayout.Metadata{
+						Repository:     "gcr.io/distroless/static",
+						Tag:            "nonroot",
+						ManifestDigest: amd64Digest,
+						IndexDigest:    distrolessIndex,
+						OS:             "linux",
+						Architecture:   "amd64",
+					},
+					Locations: []string{"multiarch/index.json"},
+				},
+				{
+					Name:    "gcr.io/distroless/static",
+					Version: arm64Digest,
+					Metadata: &ocilayout.Metadata{
+						Repository:     "gcr.io/distroless/static",
+						Tag:            "nonroot",
+						ManifestDigest: arm64Digest,
+						IndexDigest:    distrolessIndex,
+						OS:             "linux",
+						Architecture:   "arm64",
+						Variant:        "v8",
+					},
+					Locations: []string{"multiarch/index.json"},
+				},
+			},
+			wantResultMetric: stats.FileExtractedResultSuccess,
+		}, {
+			name: "missing manifest blob",
+			path: "missing-blob/index.json",
+			wantInventory: []*extractor.Inventory{
+				{
+					Name:    "docker.io/library/nginx",
+					Version: "sha256:2222222222222222222222222222222222222222222222222222222222222222",
+					Metadata: &ocilayout.Metadata{
+						Repository:     "docker.io/library/nginx",
+						Tag:            "1.25",
+						ManifestDigest: "sha256:2222222222222222222222222222222222222222222222222222222222222222",
+					},
+					Locations: []string{"missing-blob/index.json"},
+				},
+			},
+			wantResultMetric: stats.FileExtractedResultSuccess,
+		}, {
+			name:             "no oci-layout file",
+			path:             "no-layout/index.json",
+			wantInventory:    nil,
+			wantResultMetric: stats.FileExtractedResultSuccess,
+		}, {
+			name:             "invalid index.json",
+			path:             "invalid/index.json",
+			wantErr:          extractor.ErrMalformedInput,
+			wantResultMetric: stats.FileExtractedResultErrorMalformedInput,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			collector := testcollector.New()
+			var e filesystem.Extractor = ocilayout.New(ocilayout.Config{
+				Stats:            collector,
+				MaxFileSizeBytes: 1 * units.MiB,
+			})
+
+			d := "testdata"
+			r, err := os.Open(filepath.Join(d, tt.path))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer r.Close()
+			info, err := r.Stat()
+			if err != nil {
+				t.Fatalf("Stat(): %v", err)
+			}
+
+			input := &filesystem.ScanInput{
+				FS:     scalibrfs.DirFS(d),
+				Path:   tt.path,
+				Reader: r,
+				Root:   d,
+				Info:   info,
+			}
+			got, err := e.Extract(context.Background(), input)
+			if !cmp.Equal(err, tt.wantErr, cmpopts.EquateErrors()) {
+				t.Fatalf("Extract(%+v) error: got %v, want %v\n", tt.path, err, tt.wantErr)
+			}
+
+			if diff := cmp.Diff(tt.wantInventory, got); diff != "" {
+				t.Errorf("Extract(%s) (-want +got):\n%s", tt.path, diff)
+			}
+
+			gotResultMetric := collector.FileExtractedResult(tt.path)
+			if gotResultMetric != tt.wantResultMetric {
+				t.Errorf("Extract(%s) recorded result metric %v, want result metric %v", tt.path, gotResultMetric, tt.wantResultMetric)
+			}
+		})
+	}
+}
+
+func TestExtractBlobSizeLimit(t *testing.T) {
+	path := "multiarch/index.json"
+	r, err := os.Open(filepath.Join("testdata", path))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+
+	// The image index blob is bigger than 100 bytes.
+	e := ocilayout.New(ocilayout.Config{MaxFileSizeBytes: 100})
+	input := &filesystem.ScanInput{FS: scalibrfs.DirFS("testdata"), Path: path, Reader: r, Root: "testdata"}
+	_, err = e.Extract(context.Background(), input)
+	if err == nil {
+		t.Fatalf("Extract(%s) succeeded, want error", path)
+	}
+	if errors.Is(err, extractor.ErrMalformedInput) {
+		t.Errorf("Extract(%s) returned %v, want an error that's not extractor.ErrMalformedInput", path, err)
+	}
+}
+
+func TestToPURL(t *testing.T) {
+	e := ocilayout.New(ocilayout.DefaultConfig())
+	tests := []struct {
+		name string
+		inv  *extractor.Inventory
+		want *purl.PackageURL
+	}{
+		{
+			name: "image with registry",
+			inv: &extractor.Inventory{
+				Name:    "gcr.io/distroless/static",
+				Version: arm64Digest,
+				Metadata: &ocilayout.Metadata{
+					Repository:     "gcr.io/distroless/static",
+					Tag:            "nonroot",
+					ManifestDigest: arm64Digest,
+					Architecture:   "arm64",
+				},
+			},
+			want: &purl.PackageURL{
+				Type:    purl.TypeOCI,
+				Name:    "static",
+				Version: arm64Digest,
+				Qualifiers: purl.QualifiersFromMap(map[string]string{
+					purl.RepositoryURL: "gcr.io/distroless/static",
+					purl.Tag:           "nonroot",
+					purl.Arch:          "arm64",
+				}),
+			},
+		}, {
+			name: "layout directory name only",
+			inv: &extractor.Inventory{
+				Name:    "Alpine",
+				Version: amd64Digest,
+				Metadata: &ocilayout.Metadata{
+					Repository:     "Alpine",
+					ManifestDigest: amd64Digest,
+				},
+			},
+			want: &purl.PackageURL{
+				Type:       purl.TypeOCI,
+				Name:       "alpine",
+				Version:    amd64Digest,
+				Qualifiers: purl.QualifiersFromMap(map[string]string{}),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := e.ToPURL(tt.inv)
+			if err != nil {
+				t.Fatalf("ToPURL(%v): %v", tt.inv, err)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("ToPURL(%v) (-want +got):\n%s", tt.inv, diff)
+			}
+		})
+	}
+}
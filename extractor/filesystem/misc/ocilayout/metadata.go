@@ -0,0 +1,30 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ocilayout
+
+// Metadata holds information about a single-platform image found in an OCI image layout.
+type Metadata struct {
+	// Repository of the image, e.g. "gcr.io/distroless/static".
+	Repository string
+	Tag        string
+	// Digest of the image manifest.
+	ManifestDigest string
+	// Digest of the image index the manifest belongs to, if it's part of a
+	// multi-platform image.
+	IndexDigest  string
+	OS           string
+	Architecture string
+	Variant      string
+}
@@ -0,0 +1,325 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package upx detects binaries packed with the UPX executable packer and
+// extracts the packer metadata from their UPX headers. The binaries aren't
+// unpacked, so the software inside of them is not reported.
+package upx
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem"
+	"github.com/google/osv-scalibr/extractor/filesystem/internal/chunkscan"
+	"github.com/google/osv-scalibr/extractor/filesystem/internal/units"
+	"github.com/google/osv-scalibr/plugin"
+	"github.com/google/osv-scalibr/purl"
+	"github.com/google/osv-scalibr/stats"
+)
+
+const (
+	// Name is the unique name of this extractor.
+	Name = "misc/upx"
+
+	// defaultMaxFileSizeBytes is the maximum binary size the extractor will search.
+	defaultMaxFileSizeBytes = 500 * units.MiB
+
+	// chunkOverlap is kept from the previous chunk so that signatures spanning
+	// two chunks are found. It's larger than the longest signature.
+	chunkOverlap = 256
+
+	// packHeaderSize is the size of the fields of the pack header that are
+	// parsed: the magic, the header version, the format, the compression
+	// method and the compression level.
+	packHeaderSize = 8
+)
+
+var (
+	// libraryExtensions are the extensions of shared libraries, which aren't
+	// necessarily marked executable.
+	libraryExtensions = []string{".exe", ".dll", ".so", ".dylib"}
+
+	// The magic of the pack header, which UPX needs to unpack the binary.
+	packMagic = []byte("UPX!")
+	// The copyright notice of the UPX loader, containing the UPX release.
+	idRe = regexp.MustCompile(`\$Id: UPX (\d+\.\d+(?:\.\d+)?)`)
+	// The names UPX gives to the sections of packed PE files. They're often
+	// kept if the pack header is corrupted to prevent unpacking.
+	sectionNames = [][]byte{[]byte("UPX0\x00\x00\x00\x00"), []byte("UPX1\x00\x00\x00\x00")}
+
+	// The names of the compression methods, by their ID in the pack header.
+	methodNames = map[byte]string{
+		2: "NRV2B", 3: "NRV2B", 4: "NRV2B",
+		5: "NRV2D", 6: "NRV2D", 7: "NRV2D",
+		8: "NRV2E", 9: "NRV2E", 10: "NRV2E",
+		11: "CL1B", 12: "CL1B", 13: "CL1B",
+		14: "LZMA",
+		15: "DEFLATE",
+	}
+)
+
+// Config is the configuration for the Extractor.
+type Config struct {
+	// Stats is a stats collector for reporting metrics.
+	Stats stats.Collector
+	// MaxFileSizeBytes is the maximum size of a binary this extractor will
+	// search. If `FileRequired` gets a bigger file, it will return false.
+	MaxFileSizeBytes int64
+}
+
+// DefaultConfig returns the default configuration for the extractor.
+func DefaultConfig() Config {
+	return Config{
+		MaxFileSizeBytes: defaultMaxFileSizeBytes,
+		Stats:            nil,
+	}
+}
+
+// Extractor detects UPX-packed binaries.
+type Extractor struct {
+	stats            stats.Collector
+	maxFileSizeBytes int64
+}
+
+// New returns a UPX extractor.
+//
+// For most use cases, initialize with:
+// ```
+// e := New(DefaultConfig())
+// ```
+func New(cfg Config) *Extractor {
+	return &Extractor{
+		stats:            cfg.Stats,
+		maxFileSizeBytes: cfg.MaxFileSizeBytes,
+	}
+}
+
+// Config returns the configuration of the extractor.
+func (e Extractor) Config() Config {
+	return Config{
+		Stats:            e.stats,
+		MaxFileSizeBytes: e.maxFileSizeBytes,
+	}
+}
+
+// Name of the extractor.
+func (e Extractor) Name() string { return Name }
+
+// Version of the extractor.
+func (e Extractor) Version() int { return 0 }
+
+// Requirements of the extractor.
+func (e Extractor) Requirements() *plugin.Capabilities { return &plugin.Capabilities{} }
+
+// FileRequired returns true if the specified file is marked executable or is
+// a shared library. The magic bytes are checked during extraction.
+func (e Extractor) FileRequired(path string, fileinfo fs.FileInfo) bool {
+	return e.fileRequired(path, fileinfo, nil)
+}
+
+// FileRequiredWithFS returns true if the specified file is marked executable
+// or is a shared library, and starts with the magic bytes of an ELF, PE or
+// Mach-O binary.
+func (e Extractor) FileRequiredWithFS(path string, fileinfo fs.FileInfo, file *filesystem.PeekableFile) bool {
+	return e.fileRequired(path, fileinfo, file)
+}
+
+func (e Extractor) fileRequired(path string, fileinfo fs.FileInfo, file *filesystem.PeekableFile) bool {
+	if !fileinfo.Mode().IsRegular() {
+		// Includes dirs, symlinks, sockets, pipes...
+		return false
+	}
+	if fileinfo.Mode()&0111 == 0 && !isLibrary(path) {
+		return false
+	}
+
+	if e.maxFileSizeBytes > 0 && fileinfo.Size() > e.maxFileSizeBytes {
+		e.reportFileRequired(path, fileinfo.Size(), stats.FileRequiredResultSizeLimitExceeded)
+		return false
+	}
+
+	if file != nil {
+		if t, err := file.FileType(); err != nil || !isBinary(t) {
+			return false
+		}
+	}
+
+	e.reportFileRequired(path, fileinfo.Size(), stats.FileRequiredResultOK)
+	return true
+}
+
+// isLibrary returns true if the path has the extension of a Windows
+// executable or of a shared library, including versioned ones such as
+// libfoo.so.1.2.
+func isLibrary(path string) bool {
+	base := strings.ToLower(filepath.Base(path))
+	for _, ext := range libraryExtensions {
+		if strings.HasSuffix(base, ext) {
+			return true
+		}
+	}
+	return strings.Contains(base, ".so.")
+}
+
+func isBinary(t filesystem.FileType) bool {
+	return t == filesystem.FileTypeELF || t == filesystem.FileTypePE || t == filesystem.FileTypeMachO
+}
+
+func (e Extractor) reportFileRequired(path string, fileSizeBytes int64, result stats.FileRequiredResult) {
+	if e.stats == nil {
+		return
+	}
+	e.stats.AfterFileRequired(e.Name(), &stats.FileRequiredStats{
+		Path:          path,
+		Result:        result,
+		FileSizeBytes: fileSizeBytes,
+	})
+}
+
+// Extract returns the UPX packer of the binary passed through the scan input,
+// if it's packed.
+func (e Extractor) Extract(ctx context.Context, input *filesystem.ScanInput) ([]*extractor.Inventory, error) {
+	inventory, err := e.extractFromInput(ctx, input)
+	if e.stats != nil {
+		var fileSizeBytes int64
+		if input.Info != nil {
+			fileSizeBytes = input.Info.Size()
+		}
+		e.stats.AfterFileExtracted(e.Name(), &stats.FileExtractedStats{
+			Path:          input.Path,
+			Result:        filesystem.ExtractorErrorToFileExtractedResult(err),
+			FileSizeBytes: fileSizeBytes,
+		})
+	}
+	return inventory, err
+}
+
+func (e Extractor) extractFromInput(ctx context.Context, input *filesystem.ScanInput) ([]*extractor.Inventory, error) {
+	header := make([]byte, filesystem.SniffSize)
+	n, err := io.ReadFull(input.Reader, header)
+	if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+		return nil, fmt.Errorf("failed to read %q: %w", input.Path, err)
+	}
+	header = header[:n]
+	if !isBinary(filesystem.DetectFileType(header)) {
+		return nil, nil
+	}
+
+	s, err := search(ctx, io.MultiReader(bytes.NewReader(header), input.Reader))
+	if err != nil {
+		return nil, fmt.Errorf("failed to search %q: %w", input.Path, err)
+	}
+	if s.header == nil && s.version == "" && !s.sections {
+		return nil, nil
+	}
+
+	m := &Metadata{Packed: true}
+	if h := s.header; h != nil {
+		m.HeaderVersion = int(h[4])
+		m.Format = int(h[5])
+		m.Method = methodNames[h[6]]
+		m.Level = int(h[7])
+	} else {
+		m.HeaderMissing = true
+	}
+	return []*extractor.Inventory{{
+		Name:      "upx",
+		Version:   s.version,
+		Metadata:  m,
+		Locations: []string{input.Path},
+	}}, nil
+}
+
+// signatures are the traces of UPX found in a binary.
+type signatures struct {
+	// The last valid pack header, nil if there's none.
+	header []byte
+	// The UPX release from the loader's copyright notice.
+	version string
+	// Whether the binary has the section names of a UPX-packed PE file.
+	sections bool
+}
+
+// search returns the UPX signatures found in r.
+func search(ctx context.Context, r io.Reader) (*signatures, error) {
+	s := &signatures{}
+	err := chunkscan.Scan(ctx, r, chunkOverlap, func(chunk []byte, _ int, _ bool) {
+		// The loader contains an l_info struct with the same magic, followed by
+		// fields that don't pass as a pack header. The pack header is written
+		// after it, so the last valid match wins.
+		for off := 0; ; {
+			i := bytes.Index(chunk[off:], packMagic)
+			if i < 0 {
+				break
+			}
+			off += i
+			if off+packHeaderSize > len(chunk) {
+				// Might be cut off, see chunkscan.Func.
+				break
+			}
+			if h := chunk[off : off+packHeaderSize]; validPackHeader(h) {
+				s.header = bytes.Clone(h)
+			}
+			off++
+		}
+		if s.version == "" {
+			if m := idRe.FindSubmatch(chunk); m != nil {
+				s.version = string(m[1])
+			}
+		}
+		if !s.sections {
+			for _, name := range sectionNames {
+				if bytes.Contains(chunk, name) {
+					s.sections = true
+				}
+			}
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// validPackHeader returns true if the bytes following the magic are a pack
+// header version, an executable format, a known compression method and a
+// compression level between 1 and 10.
+func validPackHeader(h []byte) bool {
+	version, format, method, level := h[4], h[5], h[6], h[7]
+	_, knownMethod := methodNames[method]
+	return version > 0 && version <= 20 && format > 0 && knownMethod && level >= 1 && level <= 10
+}
+
+// ToPURL converts an inventory created by this extractor into a PURL.
+func (e Extractor) ToPURL(i *extractor.Inventory) (*purl.PackageURL, error) {
+	return &purl.PackageURL{
+		Type:    purl.TypeGeneric,
+		Name:    i.Name,
+		Version: i.Version,
+	}, nil
+}
+
+// ToCPEs is not applicable as this extractor does not infer CPEs from the Inventory.
+func (e Extractor) ToCPEs(i *extractor.Inventory) ([]string, error) { return []string{}, nil }
+
+// Ecosystem returns no ecosystem since OSV does not support executable packers.
+func (Extractor) Ecosystem(i *extractor.Inventory) (string, error) { return "", nil }
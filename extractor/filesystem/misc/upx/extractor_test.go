@@ -0,0 +1,284 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package upx_test
+
+import (
+	"bytes"
+	"context"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem"
+	"github.com/google/osv-scalibr/extractor/filesystem/internal/units"
+	"github.com/google/osv-scalibr/extractor/filesystem/misc/upx"
+	scalibrfs "github.com/google/osv-scalibr/fs"
+	"github.com/google/osv-scalibr/purl"
+	"github.com/google/osv-scalibr/stats"
+	"github.com/google/osv-scalibr/testing/fakefs"
+	"github.com/google/osv-scalibr/testing/testcollector"
+)
+
+func TestFileRequired(t *testing.T) {
+	tests := []struct {
+		name             string
+		path             string
+		mode             fs.FileMode
+		fileSizeBytes    int64
+		maxFileSizeBytes int64
+		wantRequired     bool
+		wantResultMetric stats.FileRequiredResult
+	}{
+		{
+			name:             "executable",
+			path:             "tmp/dropper",
+			mode:             0755,
+			wantRequired:     true,
+			wantResultMetric: stats.FileRequiredResultOK,
+		}, {
+			name:             "Windows executable",
+			path:             "Users/Public/invoice.exe",
+			mode:             0644,
+			wantRequired:     true,
+			wantResultMetric: stats.FileRequiredResultOK,
+		}, {
+			name:         "not executable",
+			path:         "tmp/notes.txt",
+			mode:         0644,
+			wantRequired: false,
+		}, {
+			name:         "directory",
+			path:         "usr/bin",
+			mode:         fs.ModeDir | 0755,
+			wantRequired: false,
+		}, {
+			name:             "file size limit exceeded",
+			path:             "tmp/dropper",
+			mode:             0755,
+			fileSizeBytes:    1 * units.GiB,
+			maxFileSizeBytes: 1 * units.MiB,
+			wantRequired:     false,
+			wantResultMetric: stats.FileRequiredResultSizeLimitExceeded,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			collector := testcollector.New()
+			var e filesystem.Extractor = upx.New(upx.Config{
+				Stats:            collector,
+				MaxFileSizeBytes: tt.maxFileSizeBytes,
+			})
+
+			fileSizeBytes := tt.fileSizeBytes
+			if fileSizeBytes == 0 {
+				fileSizeBytes = 1000
+			}
+			isRequired := e.FileRequired(tt.path, fakefs.FakeFileInfo{
+				FileName: filepath.Base(tt.path),
+				FileMode: tt.mode,
+				FileSize: fileSizeBytes,
+			})
+			if isRequired != tt.wantRequired {
+				t.Fatalf("FileRequired(%s): got %v, want %v", tt.path, isRequired, tt.wantRequired)
+			}
+
+			gotResultMetric := collector.FileRequiredResult(tt.path)
+			if tt.wantResultMetric != "" && gotResultMetric != tt.wantResultMetric {
+				t.Errorf("FileRequired(%s) recorded result metric %v, want result metric %v", tt.path, gotResultMetric, tt.wantResultMetric)
+			}
+		})
+	}
+}
+
+func TestFileRequiredWithFS(t *testing.T) {
+	fsys := fstest.MapFS{
+		"bin/elf":         {Data: []byte("\x7fELF\x02\x01\x01\x00"), Mode: 0755},
+		"bin/script":      {Data: []byte("#!/bin/sh\n"), Mode: 0755},
+		"lib/fake.dll":    {Data: []byte("not a binary"), Mode: 0644},
+		"share/elf-data":  {Data: []byte("\x7fELF\x02\x01\x01\x00"), Mode: 0644},
+		"bin/windows.exe": {Data: []byte("MZ\x90\x00"), Mode: 0644},
+	}
+	tests := []struct {
+		path         string
+		wantRequired bool
+	}{
+		{path: "bin/elf", wantRequired: true},
+		{path: "bin/script", wantRequired: false},
+		{path: "lib/fake.dll", wantRequired: false},
+		{path: "share/elf-data", wantRequired: false},
+		{path: "bin/windows.exe", wantRequired: true},
+	}
+
+	e := upx.New(upx.DefaultConfig())
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			info, err := fs.Stat(fsys, tt.path)
+			if err != nil {
+				t.Fatalf("fs.Stat(%s): %v", tt.path, err)
+			}
+			file := filesystem.NewPeekableFile(scalibrfs.FromFS(fsys), tt.path)
+			defer file.Close()
+
+			if got := e.FileRequiredWithFS(tt.path, info, file); got != tt.wantRequired {
+				t.Errorf("FileRequiredWithFS(%s): got %v, want %v", tt.path, got, tt.wantRequired)
+			}
+		})
+	}
+}
+
+func TestExtract(t *testing.T) {
+	tests := []struct {
+		name          string
+		path          string
+		wantInventory []*extractor.Inventory
+	}{
+		{
+			name: "packed ELF",
+			path: "packed",
+			wantInventory: []*extractor.Inventory{
+				{
+					Name:    "upx",
+					Version: "4.22",
+					Metadata: &upx.Metadata{
+						Packed:        true,
+						HeaderVersion: 14,
+						Format:        22,
+						Method:        "LZMA",
+						Level:         10,
+					},
+					Locations: []string{"packed"},
+				},
+			},
+		}, {
+			name: "PE with scrubbed pack header",
+			path: "scrubbed.exe",
+			wantInventory: []*extractor.Inventory{
+				{
+					Name: "upx",
+					Metadata: &upx.Metadata{
+						Packed:        true,
+						HeaderMissing: true,
+					},
+					Locations: []string{"scrubbed.exe"},
+				},
+			},
+		}, {
+			name:          "ELF that isn't packed",
+			path:          "plain",
+			wantInventory: nil,
+		}, {
+			name:          "script isn't a binary",
+			path:          "script.sh",
+			wantInventory: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			collector := testcollector.New()
+			var e filesystem.Extractor = upx.New(upx.Config{
+				Stats:            collector,
+				MaxFileSizeBytes: 100 * units.MiB,
+			})
+
+			r, err := os.Open(filepath.Join("testdata", tt.path))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer r.Close()
+			info, err := r.Stat()
+			if err != nil {
+				t.Fatalf("Stat(): %v", err)
+			}
+
+			input := &filesystem.ScanInput{
+				FS:     scalibrfs.DirFS("testdata"),
+				Path:   tt.path,
+				Reader: r,
+				Root:   "testdata",
+				Info:   info,
+			}
+			got, err := e.Extract(context.Background(), input)
+			if err != nil {
+				t.Fatalf("Extract(%s): %v", tt.path, err)
+			}
+
+			if diff := cmp.Diff(tt.wantInventory, got, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("Extract(%s) (-want +got):\n%s", tt.path, diff)
+			}
+
+			gotResultMetric := collector.FileExtractedResult(tt.path)
+			if gotResultMetric != stats.FileExtractedResultSuccess {
+				t.Errorf("Extract(%s) recorded result metric %v, want result metric %v", tt.path, gotResultMetric, stats.FileExtractedResultSuccess)
+			}
+		})
+	}
+}
+
+func TestExtractAcrossChunks(t *testing.T) {
+	// Place the pack header across the boundary of the 1 MiB search chunks.
+	content := make([]byte, 3*units.MiB)
+	copy(content, "\x7fELF")
+	copy(content[units.MiB-5:], "UPX!\x0e\x16\x02\x09")
+
+	e := upx.New(upx.DefaultConfig())
+	got, err := e.Extract(context.Background(), &filesystem.ScanInput{
+		Path:   "packed",
+		Reader: bytes.NewReader(content),
+	})
+	if err != nil {
+		t.Fatalf("Extract(): %v", err)
+	}
+	want := []*extractor.Inventory{
+		{
+			Name: "upx",
+			Metadata: &upx.Metadata{
+				Packed:        true,
+				HeaderVersion: 14,
+				Format:        22,
+				Method:        "NRV2B",
+				Level:         9,
+			},
+			Locations: []string{"packed"},
+		},
+	}
+	if diff := cmp.Diff(want, got, cmpopts.EquateEmpty()); diff != "" {
+		t.Errorf("Extract() (-want +got):\n%s", diff)
+	}
+}
+
+func TestToPURL(t *testing.T) {
+	e := upx.Extractor{}
+	inv := &extractor.Inventory{
+		Name:      "upx",
+		Version:   "4.22",
+		Metadata:  &upx.Metadata{Packed: true},
+		Locations: []string{"location"},
+	}
+	want := &purl.PackageURL{Type: purl.TypeGeneric, Name: "upx", Version: "4.22"}
+	got, err := e.ToPURL(inv)
+	if err != nil {
+		t.Fatalf("ToPURL(%v): %v", inv, err)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ToPURL(%v) (-want +got):\n%s", inv, diff)
+	}
+}
@@ -0,0 +1,33 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package upx
+
+// Metadata describes how a binary was packed with UPX.
+type Metadata struct {
+	// Always true, the extractor only reports packed binaries.
+	Packed bool
+	// Set if the binary has traces of UPX, e.g. its PE section names, but no
+	// intact pack header. Malware often corrupts the header so that the binary
+	// can't be unpacked with `upx -d`. The header fields below are unset then.
+	HeaderMissing bool
+	// The version of the pack header format.
+	HeaderVersion int
+	// The UPX ID of the executable format, e.g. 9 for 32-bit PE files.
+	Format int
+	// The compression method, e.g. "NRV2B" or "LZMA".
+	Method string
+	// The compression level from 1 to 10.
+	Level int
+}
@@ -0,0 +1,2 @@
+#!/bin/sh
+echo "$Id: UPX 4.22 $"
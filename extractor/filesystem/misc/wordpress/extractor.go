@@ -0,0 +1,267 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package wordpress extracts the versions of the WordPress plugins and themes
+// installed in wp-content directories from the header comments of the plugin
+// main files and of the theme stylesheets.
+//
+// Drupal modules and themes aren't extracted.
+package wordpress
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem"
+	"github.com/google/osv-scalibr/plugin"
+	"github.com/google/osv-scalibr/purl"
+	"github.com/google/osv-scalibr/stats"
+)
+
+const (
+	// Name is the unique name of this extractor.
+	Name = "misc/wordpress"
+
+	// defaultMaxFileSizeBytes is 0 (no limit) as only the header of the files
+	// is read.
+	defaultMaxFileSizeBytes = 0
+
+	// maxHeaderBytes is how much of a file WordPress itself reads to find the
+	// header comment.
+	maxHeaderBytes = 8 * 1024
+
+	// TypePlugin is the Metadata.Type of WordPress plugins.
+	TypePlugin = "plugin"
+	// TypeTheme is the Metadata.Type of WordPress themes.
+	TypeTheme = "theme"
+)
+
+// Config is the configuration for the Extractor.
+type Config struct {
+	// Stats is a stats collector for reporting metrics.
+	Stats stats.Collector
+	// MaxFileSizeBytes is the maximum file size this extractor will read. If
+	// `FileRequired` gets a bigger file, it will return false,
+	MaxFileSizeBytes int64
+}
+
+// DefaultConfig returns the default configuration for the extractor.
+func DefaultConfig() Config {
+	return Config{
+		MaxFileSizeBytes: defaultMaxFileSizeBytes,
+		Stats:            nil,
+	}
+}
+
+// Extractor extracts WordPress plugins and themes.
+type Extractor struct {
+	stats            stats.Collector
+	maxFileSizeBytes int64
+}
+
+// New returns a WordPress extractor.
+//
+// For most use cases, initialize with:
+// ```
+// e := New(DefaultConfig())
+// ```
+func New(cfg Config) *Extractor {
+	return &Extractor{
+		stats:            cfg.Stats,
+		maxFileSizeBytes: cfg.MaxFileSizeBytes,
+	}
+}
+
+// Config returns the configuration of the extractor.
+func (e Extractor) Config() Config {
+	return Config{
+		Stats:            e.stats,
+		MaxFileSizeBytes: e.maxFileSizeBytes,
+	}
+}
+
+// Name of the extractor.
+func (e Extractor) Name() string { return Name }
+
+// Version of the extractor.
+func (e Extractor) Version() int { return 0 }
+
+// Requirements of the extractor.
+func (e Extractor) Requirements() *plugin.Capabilities { return &plugin.Capabilities{} }
+
+// FileRequired returns true if the specified file can be the main file of a
+// plugin, i.e. wp-content/plugins/<slug>/*.php or a single-file plugin in
+// wp-content/plugins/, or the stylesheet of a theme, i.e.
+// wp-content/themes/<slug>/style.css.
+func (e Extractor) FileRequired(path string, fileinfo fs.FileInfo) bool {
+	if _, _, ok := parseComponentPath(path); !ok {
+		return false
+	}
+
+	if e.maxFileSizeBytes > 0 && fileinfo.Size() > e.maxFileSizeBytes {
+		e.reportFileRequired(path, fileinfo.Size(), stats.FileRequiredResultSizeLimitExceeded)
+		return false
+	}
+
+	e.reportFileRequired(path, fileinfo.Size(), stats.FileRequiredResultOK)
+	return true
+}
+
+// parseComponentPath returns the slug and the type of the plugin or theme a
+// file belongs to.
+func parseComponentPath(p string) (slug string, typ string, ok bool) {
+	parts := strings.Split(filepath.ToSlash(p), "/")
+	n := len(parts)
+	if n < 3 {
+		return "", "", false
+	}
+	if path.Ext(parts[n-1]) == ".php" {
+		if parts[n-3] == "wp-content" && parts[n-2] == "plugins" {
+			return strings.TrimSuffix(parts[n-1], ".php"), TypePlugin, true
+		}
+		if n >= 4 && parts[n-4] == "wp-content" && parts[n-3] == "plugins" {
+			return parts[n-2], TypePlugin, true
+		}
+		return "", "", false
+	}
+	if n >= 4 && parts[n-1] == "style.css" && parts[n-4] == "wp-content" && parts[n-3] == "themes" {
+		return parts[n-2], TypeTheme, true
+	}
+	return "", "", false
+}
+
+func (e Extractor) reportFileRequired(path string, fileSizeBytes int64, result stats.FileRequiredResult) {
+	if e.stats == nil {
+		return
+	}
+	e.stats.AfterFileRequired(e.Name(), &stats.FileRequiredStats{
+		Path:          path,
+		Result:        result,
+		FileSizeBytes: fileSizeBytes,
+	})
+}
+
+// Extract returns the plugin or theme described by the file header. PHP files
+// without a plugin header, e.g. the other source files of a plugin, don't
+// produce any inventory.
+func (e Extractor) Extract(ctx context.Context, input *filesystem.ScanInput) ([]*extractor.Inventory, error) {
+	inventory, err := e.extractFromInput(input)
+	if e.stats != nil {
+		var fileSizeBytes int64
+		if input.Info != nil {
+			fileSizeBytes = input.Info.Size()
+		}
+		e.stats.AfterFileExtracted(e.Name(), &stats.FileExtractedStats{
+			Path:          input.Path,
+			Result:        filesystem.ExtractorErrorToFileExtractedResult(err),
+			FileSizeBytes: fileSizeBytes,
+		})
+	}
+	return inventory, err
+}
+
+func (e Extractor) extractFromInput(input *filesystem.ScanInput) ([]*extractor.Inventory, error) {
+	slug, typ, ok := parseComponentPath(input.Path)
+	if !ok {
+		return nil, fmt.Errorf("%q is not a WordPress plugin or theme file", input.Path)
+	}
+	headers, err := parseHeaders(input.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %q: %w", input.Path, err)
+	}
+
+	displayName := headers["plugin name"]
+	if typ == TypeTheme {
+		displayName = headers["theme name"]
+		if displayName == "" {
+			return nil, fmt.Errorf("missing theme name in %q: %w", input.Path, extractor.ErrMalformedInput)
+		}
+	} else if displayName == "" {
+		return nil, nil
+	}
+
+	// The version header is optional, e.g. for custom plugins that are never
+	// published, so the plugin is still reported without a version.
+	return []*extractor.Inventory{{
+		Name:    slug,
+		Version: headers["version"],
+		Metadata: &Metadata{
+			Type:              typ,
+			DisplayName:       displayName,
+			Author:            headers["author"],
+			RequiresWordPress: headers["requires at least"],
+			RequiresPHP:       headers["requires php"],
+		},
+		Locations: []string{input.Path},
+	}}, nil
+}
+
+// parseHeaders returns the "Key: Value" lines of the header comment at the top
+// of the file, keyed by the lowercase key. Like WordPress, only the first
+// occurrence of each key in the first 8 KiB of the file is used.
+func parseHeaders(r io.Reader) (map[string]string, error) {
+	data, err := io.ReadAll(io.LimitReader(r, maxHeaderBytes))
+	if err != nil {
+		return nil, err
+	}
+	headers := map[string]string{}
+	content := strings.ReplaceAll(string(data), "\r", "\n")
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimLeft(line, " \t")
+		line = strings.TrimPrefix(line, "<?php")
+		line = strings.TrimLeft(line, " \t/*#@")
+		key, value, ok := strings.Cut(line, ":")
+		if !ok || key == "" || strings.ContainsAny(key, "\t=;(){}\"'$") {
+			continue
+		}
+		key = strings.ToLower(strings.TrimSpace(key))
+		if _, exists := headers[key]; exists {
+			continue
+		}
+		// Strip the end of the comment or of the PHP code block.
+		if i := strings.Index(value, "*/"); i >= 0 {
+			value = value[:i]
+		}
+		if i := strings.Index(value, "?>"); i >= 0 {
+			value = value[:i]
+		}
+		headers[key] = strings.TrimSpace(value)
+	}
+	return headers, nil
+}
+
+// ToPURL converts an inventory created by this extractor into a PURL. The
+// plugins and themes are identified by their slug, which is also used in the
+// wordpress.org directories.
+func (e Extractor) ToPURL(i *extractor.Inventory) (*purl.PackageURL, error) {
+	m := i.Metadata.(*Metadata)
+	return &purl.PackageURL{
+		Type:      purl.TypeGeneric,
+		Namespace: "wordpress-" + m.Type,
+		Name:      strings.ToLower(i.Name),
+		Version:   i.Version,
+	}, nil
+}
+
+// ToCPEs is not applicable as this extractor does not infer CPEs from the Inventory.
+func (e Extractor) ToCPEs(i *extractor.Inventory) ([]string, error) { return []string{}, nil }
+
+// Ecosystem returns no ecosystem since OSV does not support WordPress.
+func (Extractor) Ecosystem(i *extractor.Inventory) (string, error) { return "", nil }
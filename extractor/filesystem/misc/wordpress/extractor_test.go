@@ -0,0 +1,265 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wordpress_test
+
+import (
+	"context"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem"
+	"github.com/google/osv-scalibr/extractor/filesystem/internal/units"
+	"github.com/google/osv-scalibr/extractor/filesystem/misc/wordpress"
+	scalibrfs "github.com/google/osv-scalibr/fs"
+	"github.com/google/osv-scalibr/purl"
+	"github.com/google/osv-scalibr/stats"
+	"github.com/google/osv-scalibr/testing/fakefs"
+	"github.com/google/osv-scalibr/testing/testcollector"
+)
+
+func TestFileRequired(t *testing.T) {
+	tests := []struct {
+		name             string
+		path             string
+		fileSizeBytes    int64
+		maxFileSizeBytes int64
+		wantRequired     bool
+		wantResultMetric stats.FileRequiredResult
+	}{
+		{
+			name:             "plugin file",
+			path:             "var/www/html/wp-content/plugins/akismet/akismet.php",
+			wantRequired:     true,
+			wantResultMetric: stats.FileRequiredResultOK,
+		}, {
+			name:             "single-file plugin",
+			path:             "wp-content/plugins/hello.php",
+			wantRequired:     true,
+			wantResultMetric: stats.FileRequiredResultOK,
+		}, {
+			name:             "theme stylesheet",
+			path:             "var/www/html/wp-content/themes/twentytwentyfour/style.css",
+			wantRequired:     true,
+			wantResultMetric: stats.FileRequiredResultOK,
+		}, {
+			name:         "nested plugin file",
+			path:         "wp-content/plugins/akismet/views/config.php",
+			wantRequired: false,
+		}, {
+			name:         "other theme stylesheet",
+			path:         "wp-content/themes/twentytwentyfour/assets/editor.css",
+			wantRequired: false,
+		}, {
+			name:         "PHP file outside of wp-content",
+			path:         "var/www/html/plugins/akismet/akismet.php",
+			wantRequired: false,
+		}, {
+			name:         "plugin readme",
+			path:         "wp-content/plugins/akismet/readme.txt",
+			wantRequired: false,
+		}, {
+			name:             "file size limit exceeded",
+			path:             "wp-content/plugins/akismet/akismet.php",
+			fileSizeBytes:    1 * units.MiB,
+			maxFileSizeBytes: 1000,
+			wantRequired:     false,
+			wantResultMetric: stats.FileRequiredResultSizeLimitExceeded,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			collector := testcollector.New()
+			var e filesystem.Extractor = wordpress.New(wordpress.Config{
+				Stats:            collector,
+				MaxFileSizeBytes: tt.maxFileSizeBytes,
+			})
+
+			fileSizeBytes := tt.fileSizeBytes
+			if fileSizeBytes == 0 {
+				fileSizeBytes = 1000
+			}
+
+			isRequired := e.FileRequired(tt.path, fakefs.FakeFileInfo{
+				FileName: filepath.Base(tt.path),
+				FileMode: fs.ModePerm,
+				FileSize: fileSizeBytes,
+			})
+			if isRequired != tt.wantRequired {
+				t.Fatalf("FileRequired(%s): got %v, want %v", tt.path, isRequired, tt.wantRequired)
+			}
+
+			gotResultMetric := collector.FileRequiredResult(tt.path)
+			if tt.wantResultMetric != "" && gotResultMetric != tt.wantResultMetric {
+				t.Errorf("FileRequired(%s) recorded result metric %v, want result metric %v", tt.path, gotResultMetric, tt.wantResultMetric)
+			}
+		})
+	}
+}
+
+func TestExtract(t *testing.T) {
+	tests := []struct {
+		name             string
+		path             string
+		wantInventory    []*extractor.Inventory
+		wantErr          error
+		wantResultMetric stats.FileExtractedResult
+	}{
+		{
+			name: "plugin main file",
+			path: "wp-content/plugins/akismet/akismet.php",
+			wantInventory: []*extractor.Inventory{
+				{
+					Name:    "akismet",
+					Version: "5.3",
+					Metadata: &wordpress.Metadata{
+						Type:              wordpress.TypePlugin,
+						DisplayName:       "Akismet Anti-spam: Spam Protection",
+						Author:            "Automattic - Anti-spam Team",
+						RequiresWordPress: "5.8",
+						RequiresPHP:       "5.6.20",
+					},
+					Locations: []string{"wp-content/plugins/akismet/akismet.php"},
+				},
+			},
+			wantResultMetric: stats.FileExtractedResultSuccess,
+		}, {
+			name:             "PHP file without a plugin header",
+			path:             "wp-content/plugins/akismet/class.akismet.php",
+			wantResultMetric: stats.FileExtractedResultSuccess,
+		}, {
+			name: "single-file plugin with CRLF line endings",
+			path: "wp-content/plugins/hello.php",
+			wantInventory: []*extractor.Inventory{
+				{
+					Name:    "hello",
+					Version: "1.7.2",
+					Metadata: &wordpress.Metadata{
+						Type:        wordpress.TypePlugin,
+						DisplayName: "Hello Dolly",
+						Author:      "Matt Mullenweg",
+					},
+					Locations: []string{"wp-content/plugins/hello.php"},
+				},
+			},
+			wantResultMetric: stats.FileExtractedResultSuccess,
+		}, {
+			name: "plugin without a version header",
+			path: "wp-content/plugins/internal-tools/internal-tools.php",
+			wantInventory: []*extractor.Inventory{
+				{
+					Name: "internal-tools",
+					Metadata: &wordpress.Metadata{
+						Type:        wordpress.TypePlugin,
+						DisplayName: "Internal Tools",
+						Author:      "Web Team",
+					},
+					Locations: []string{"wp-content/plugins/internal-tools/internal-tools.php"},
+				},
+			},
+			wantResultMetric: stats.FileExtractedResultSuccess,
+		}, {
+			name: "theme stylesheet",
+			path: "wp-content/themes/twentytwentyfour/style.css",
+			wantInventory: []*extractor.Inventory{
+				{
+					Name:    "twentytwentyfour",
+					Version: "1.1",
+					Metadata: &wordpress.Metadata{
+						Type:              wordpress.TypeTheme,
+						DisplayName:       "Twenty Twenty-Four",
+						Author:            "the WordPress team",
+						RequiresWordPress: "6.4",
+					},
+					Locations: []string{"wp-content/themes/twentytwentyfour/style.css"},
+				},
+			},
+			wantResultMetric: stats.FileExtractedResultSuccess,
+		}, {
+			name:             "theme stylesheet without a header",
+			path:             "wp-content/themes/broken/style.css",
+			wantErr:          extractor.ErrMalformedInput,
+			wantResultMetric: stats.FileExtractedResultErrorMalformedInput,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			collector := testcollector.New()
+			var e filesystem.Extractor = wordpress.New(wordpress.Config{
+				Stats: collector,
+			})
+
+			d := "testdata"
+			r, err := os.Open(filepath.Join(d, tt.path))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer r.Close()
+			info, err := r.Stat()
+			if err != nil {
+				t.Fatalf("Stat(): %v", err)
+			}
+
+			input := &filesystem.ScanInput{
+				FS:     scalibrfs.DirFS(d),
+				Path:   tt.path,
+				Reader: r,
+				Root:   d,
+				Info:   info,
+			}
+			got, err := e.Extract(context.Background(), input)
+			if !cmp.Equal(err, tt.wantErr, cmpopts.EquateErrors()) {
+				t.Fatalf("Extract(%+v) error: got %v, want %v\n", tt.path, err, tt.wantErr)
+			}
+
+			if diff := cmp.Diff(tt.wantInventory, got); diff != "" {
+				t.Errorf("Extract(%s) (-want +got):\n%s", tt.path, diff)
+			}
+
+			gotResultMetric := collector.FileExtractedResult(tt.path)
+			if gotResultMetric != tt.wantResultMetric {
+				t.Errorf("Extract(%s) recorded result metric %v, want result metric %v", tt.path, gotResultMetric, tt.wantResultMetric)
+			}
+		})
+	}
+}
+
+func TestToPURL(t *testing.T) {
+	e := wordpress.New(wordpress.DefaultConfig())
+	i := &extractor.Inventory{
+		Name:     "Akismet",
+		Version:  "5.3",
+		Metadata: &wordpress.Metadata{Type: wordpress.TypePlugin},
+	}
+	want := &purl.PackageURL{
+		Type:      purl.TypeGeneric,
+		Namespace: "wordpress-plugin",
+		Name:      "akismet",
+		Version:   "5.3",
+	}
+	got, err := e.ToPURL(i)
+	if err != nil {
+		t.Fatalf("ToPURL(%v): %v", i, err)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ToPURL(%v) (-want +got):\n%s", i, diff)
+	}
+}
@@ -0,0 +1,33 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wordpress
+
+// Metadata holds additional information about an installed WordPress plugin
+// or theme.
+type Metadata struct {
+	// Type is "plugin" or "theme".
+	Type string
+	// DisplayName is the "Plugin Name" or "Theme Name" header, e.g. "Akismet
+	// Anti-spam: Spam Protection".
+	DisplayName string
+	// Author is the "Author" header.
+	Author string
+	// RequiresWordPress is the minimum WordPress version the plugin or theme
+	// requires ("Requires at least" header).
+	RequiresWordPress string
+	// RequiresPHP is the minimum PHP version the plugin or theme requires
+	// ("Requires PHP" header).
+	RequiresPHP string
+}
@@ -0,0 +1,6 @@
+<?php
+
+// Version: 1.0 of the class, not a plugin header.
+class Akismet {
+	const API_HOST = 'rest.akismet.com';
+}
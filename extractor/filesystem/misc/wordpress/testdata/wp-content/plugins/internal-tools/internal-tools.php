@@ -0,0 +1,8 @@
+<?php
+/**
+ * Plugin Name: Internal Tools
+ * Description: Helpers for the editorial team.
+ * Author: Web Team
+ */
+
+add_action( 'init', 'internal_tools_init' );
@@ -0,0 +1,219 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package yoctomanifest_test
+
+import (
+	"context"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem"
+	"github.com/google/osv-scalibr/extractor/filesystem/internal/units"
+	"github.com/google/osv-scalibr/extractor/filesystem/misc/yoctomanifest"
+	scalibrfs "github.com/google/osv-scalibr/fs"
+	"github.com/google/osv-scalibr/purl"
+	"github.com/google/osv-scalibr/stats"
+	"github.com/google/osv-scalibr/testing/fakefs"
+	"github.com/google/osv-scalibr/testing/testcollector"
+)
+
+func TestFileRequired(t *testing.T) {
+	tests := []struct {
+		name             string
+		path             string
+		fileSizeBytes    int64
+		maxFileSizeBytes int64
+		wantRequired     bool
+		wantResultMetric stats.FileRequiredResult
+	}{
+		{
+			name:             "license manifest",
+			path:             "build/tmp/deploy/licenses/core-image-minimal-qemux86-64/license.manifest",
+			wantRequired:     true,
+			wantResultMetric: stats.FileRequiredResultOK,
+		}, {
+			name:         "image license manifest",
+			path:         "build/tmp/deploy/licenses/core-image-minimal-qemux86-64/image_license.manifest",
+			wantRequired: false,
+		}, {
+			name:         "package manifest",
+			path:         "build/tmp/deploy/images/qemux86-64/core-image-minimal-qemux86-64.manifest",
+			wantRequired: false,
+		}, {
+			name:             "file size limit exceeded",
+			path:             "license.manifest",
+			fileSizeBytes:    1 * units.MiB,
+			maxFileSizeBytes: 1000,
+			wantRequired:     false,
+			wantResultMetric: stats.FileRequiredResultSizeLimitExceeded,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			collector := testcollector.New()
+			var e filesystem.Extractor = yoctomanifest.New(yoctomanifest.Config{
+				Stats:            collector,
+				MaxFileSizeBytes: tt.maxFileSizeBytes,
+			})
+
+			fileSizeBytes := tt.fileSizeBytes
+			if fileSizeBytes == 0 {
+				fileSizeBytes = 1000
+			}
+
+			isRequired := e.FileRequired(tt.path, fakefs.FakeFileInfo{
+				FileName: filepath.Base(tt.path),
+				FileMode: fs.ModePerm,
+				FileSize: fileSizeBytes,
+			})
+			if isRequired != tt.wantRequired {
+				t.Fatalf("FileRequired(%s): got %v, want %v", tt.path, isRequired, tt.wantRequired)
+			}
+
+			gotResultMetric := collector.FileRequiredResult(tt.path)
+			if tt.wantResultMetric != "" && gotResultMetric != tt.wantResultMetric {
+				t.Errorf("FileRequired(%s) recorded result metric %v, want result metric %v", tt.path, gotResultMetric, tt.wantResultMetric)
+			}
+		})
+	}
+}
+
+func TestExtract(t *testing.T) {
+	imageManifest := "tmp/deploy/licenses/core-image-minimal-qemux86-64/license.manifest"
+	truncatedManifest := "truncated/license.manifest"
+	inv := func(path, name, version, recipe, license string) *extractor.Inventory {
+		return &extractor.Inventory{
+			Name:      name,
+			Version:   version,
+			Metadata:  &yoctomanifest.Metadata{RecipeName: recipe, License: license},
+			Locations: []string{path},
+		}
+	}
+	tests := []struct {
+		name             string
+		path             string
+		wantInventory    []*extractor.Inventory
+		wantResultMetric stats.FileExtractedResult
+	}{
+		{
+			name: "image manifest",
+			path: imageManifest,
+			wantInventory: []*extractor.Inventory{
+				inv(imageManifest, "base-files", "3.0.14", "base-files", "GPL-2.0-only"),
+				inv(imageManifest, "base-passwd", "3.5.29", "base-passwd", "GPL-2.0-only"),
+				inv(imageManifest, "busybox", "1.35.0", "busybox", "GPL-2.0-only & bzip2-1.0.4"),
+				inv(imageManifest, "busybox-hwclock", "1.35.0", "busybox", "GPL-2.0-only & bzip2-1.0.4"),
+				inv(imageManifest, "busybox-syslog", "1.35.0", "busybox", "GPL-2.0-only & bzip2-1.0.4"),
+				inv(imageManifest, "busybox-udhcpc", "1.35.0", "busybox", "GPL-2.0-only & bzip2-1.0.4"),
+				inv(imageManifest, "eudev", "3.2.10", "eudev", "GPL-2.0-only & LGPL-2.1-only"),
+				inv(imageManifest, "init-ifupdown", "1.0", "init-ifupdown", "MIT"),
+				inv(imageManifest, "initscripts", "1.0", "initscripts", "GPL-2.0-only"),
+				inv(imageManifest, "kernel-6.1.38-yocto-standard", "6.1.38+git0+d62bfbd59e_a3c5e2a3e8", "linux-yocto", "GPL-2.0-only"),
+				inv(imageManifest, "ldconfig", "2.35", "glibc", "GPL-2.0-only & LGPL-2.1-only"),
+				inv(imageManifest, "libc6", "2.35", "glibc", "GPL-2.0-only & LGPL-2.1-only"),
+				inv(imageManifest, "libkmod2", "29", "kmod", "LGPL-2.1-or-later"),
+				inv(imageManifest, "libz1", "1.2.13", "zlib", "Zlib"),
+				inv(imageManifest, "netbase", "6.3", "netbase", "GPL-2.0-only"),
+				inv(imageManifest, "packagegroup-core-boot", "1.0", "packagegroup-core-boot", "MIT"),
+				inv(imageManifest, "run-postinsts", "1.0", "run-postinsts", "MIT"),
+				inv(imageManifest, "sysvinit", "3.01", "sysvinit", "GPL-2.0-or-later"),
+				inv(imageManifest, "update-alternatives-opkg", "0.5.0", "opkg-utils", "GPL-2.0-or-later"),
+				inv(imageManifest, "update-rc.d", "0.8", "update-rc.d", "GPL-2.0-or-later"),
+			},
+			wantResultMetric: stats.FileExtractedResultSuccess,
+		}, {
+			name: "truncated manifest without empty lines between all stanzas",
+			path: truncatedManifest,
+			wantInventory: []*extractor.Inventory{
+				inv(truncatedManifest, "base-files", "3.0.14", "base-files", "GPL-2.0-only"),
+				inv(truncatedManifest, "busybox", "1.35.0", "busybox", "GPL-2.0-only & bzip2-1.0.4"),
+				inv(truncatedManifest, "netbase", "6.3", "", ""),
+			},
+			wantResultMetric: stats.FileExtractedResultSuccess,
+		}, {
+			name:             "not a license manifest",
+			path:             "notmanifest/license.manifest",
+			wantInventory:    []*extractor.Inventory{},
+			wantResultMetric: stats.FileExtractedResultSuccess,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			collector := testcollector.New()
+			var e filesystem.Extractor = yoctomanifest.New(yoctomanifest.Config{
+				Stats: collector,
+			})
+
+			d := "testdata"
+			r, err := os.Open(filepath.Join(d, tt.path))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer r.Close()
+			info, err := r.Stat()
+			if err != nil {
+				t.Fatalf("Stat(): %v", err)
+			}
+
+			input := &filesystem.ScanInput{
+				FS:     scalibrfs.DirFS(d),
+				Path:   tt.path,
+				Reader: r,
+				Root:   d,
+				Info:   info,
+			}
+			got, err := e.Extract(context.Background(), input)
+			if err != nil {
+				t.Fatalf("Extract(%s): %v", tt.path, err)
+			}
+
+			if diff := cmp.Diff(tt.wantInventory, got); diff != "" {
+				t.Errorf("Extract(%s) (-want +got):\n%s", tt.path, diff)
+			}
+
+			gotResultMetric := collector.FileExtractedResult(tt.path)
+			if gotResultMetric != tt.wantResultMetric {
+				t.Errorf("Extract(%s) recorded result metric %v, want result metric %v", tt.path, gotResultMetric, tt.wantResultMetric)
+			}
+		})
+	}
+}
+
+func TestToPURL(t *testing.T) {
+	e := yoctomanifest.New(yoctomanifest.DefaultConfig())
+	i := &extractor.Inventory{
+		Name:    "busybox",
+		Version: "1.35.0",
+	}
+	want := &purl.PackageURL{
+		Type:      purl.TypeGeneric,
+		Namespace: "yocto",
+		Name:      "busybox",
+		Version:   "1.35.0",
+	}
+	got, err := e.ToPURL(i)
+	if err != nil {
+		t.Fatalf("ToPURL(%v): %v", i, err)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ToPURL(%v) (-want +got):\n%s", i, diff)
+	}
+}
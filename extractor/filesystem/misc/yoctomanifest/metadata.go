@@ -0,0 +1,25 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package yoctomanifest
+
+// Metadata holds additional information about a package of a Yocto image.
+type Metadata struct {
+	// RecipeName is the name of the BitBake recipe that built the package,
+	// e.g. "glibc" for the "libc6" package.
+	RecipeName string
+	// License is the license expression of the package, e.g.
+	// "GPL-2.0-only & LGPL-2.1-only".
+	License string
+}
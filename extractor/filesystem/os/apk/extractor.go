@@ -28,7 +28,6 @@ import (
 
 	"github.com/google/osv-scalibr/extractor"
 	"github.com/google/osv-scalibr/extractor/filesystem"
-	"github.com/google/osv-scalibr/extractor/filesystem/os/osrelease"
 	"github.com/google/osv-scalibr/log"
 	"github.com/google/osv-scalibr/plugin"
 	"github.com/google/osv-scalibr/purl"
@@ -85,6 +84,9 @@ func (e Extractor) Version() int { return 0 }
 // Requirements of the extractor.
 func (e Extractor) Requirements() *plugin.Capabilities { return &plugin.Capabilities{} }
 
+// RequiredFileNames returns the name of the apk status file the extractor requires.
+func (e Extractor) RequiredFileNames() []string { return []string{"installed"} }
+
 // FileRequired returns true if the specified file matches apk status file pattern.
 func (e Extractor) FileRequired(path string, fileinfo fs.FileInfo) bool {
 	// Should match the status file.
@@ -130,9 +132,9 @@ func (e Extractor) Extract(ctx context.Context, input *filesystem.ScanInput) ([]
 }
 
 func (e Extractor) extractFromInput(ctx context.Context, input *filesystem.ScanInput) ([]*extractor.Inventory, error) {
-	m, err := osrelease.GetOSRelease(input.FS)
+	m, err := input.GetOSRelease()
 	if err != nil {
-		log.Errorf("osrelease.ParseOsRelease(): %v", err)
+		log.Errorf("GetOSRelease(): %v", err)
 	}
 
 	rd := textproto.NewReader(bufio.NewReader(input.Reader))
@@ -186,7 +188,7 @@ func (e Extractor) extractFromInput(ctx context.Context, input *filesystem.ScanI
 				License:      license,
 			},
 			SourceCode: sourceCode,
-			Locations: []string{input.Path},
+			Locations:  []string{input.Path},
 		})
 	}
 	return pkgs, nil
@@ -24,7 +24,6 @@ import (
 
 	"github.com/google/osv-scalibr/extractor"
 	"github.com/google/osv-scalibr/extractor/filesystem"
-	"github.com/google/osv-scalibr/extractor/filesystem/os/osrelease"
 	"github.com/google/osv-scalibr/log"
 	"github.com/google/osv-scalibr/plugin"
 	"github.com/google/osv-scalibr/purl"
@@ -95,6 +94,9 @@ func (e Extractor) Version() int { return 0 }
 // Requirements of the extractor.
 func (e Extractor) Requirements() *plugin.Capabilities { return &plugin.Capabilities{} }
 
+// RequiredFileNames returns the name of the COS package info file the extractor requires.
+func (e Extractor) RequiredFileNames() []string { return []string{"cos-package-info.json"} }
+
 // FileRequired returns true if the specified file matches cos package info file pattern.
 func (e Extractor) FileRequired(path string, fileinfo fs.FileInfo) bool {
 	if filepath.ToSlash(path) != "etc/cos-package-info.json" {
@@ -139,9 +141,9 @@ func (e Extractor) Extract(ctx context.Context, input *filesystem.ScanInput) ([]
 }
 
 func (e Extractor) extractFromInput(ctx context.Context, input *filesystem.ScanInput) ([]*extractor.Inventory, error) {
-	m, err := osrelease.GetOSRelease(input.FS)
+	m, err := input.GetOSRelease()
 	if err != nil {
-		log.Errorf("osrelease.ParseOsRelease(): %v", err)
+		log.Errorf("GetOSRelease(): %v", err)
 	}
 	dec := json.NewDecoder(input.Reader)
 	var packages cosPackageInfo
@@ -0,0 +1,344 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package debfile extracts package metadata from .deb package files on disk.
+package debfile
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/textproto"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/ulikunitz/xz"
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem"
+	"github.com/google/osv-scalibr/extractor/filesystem/internal/units"
+	"github.com/google/osv-scalibr/extractor/filesystem/os/dpkg"
+	"github.com/google/osv-scalibr/plugin"
+	"github.com/google/osv-scalibr/purl"
+	"github.com/google/osv-scalibr/stats"
+)
+
+const (
+	// Name is the unique name of this extractor.
+	Name = "os/debfile"
+
+	// defaultMaxFileSizeBytes is the maximum file size an extractor will unmarshal.
+	// If Extract gets a bigger file, it will return an error.
+	defaultMaxFileSizeBytes = 500 * units.MiB
+
+	// maxControlSizeBytes caps the size of the decompressed control file.
+	maxControlSizeBytes = 1 * units.MiB
+
+	arMagic         = "!<arch>\n"
+	arHeaderSize    = 60
+	controlTarAlias = "control.tar"
+)
+
+var (
+	// errNotDeb is returned for files that don't carry the ar magic of a .deb package.
+	errNotDeb = fmt.Errorf("not a .deb archive: %w", extractor.ErrMalformedInput)
+	// errNoControl is returned when the archive has no control.tar member.
+	errNoControl = fmt.Errorf("no control.tar member found: %w", extractor.ErrMalformedInput)
+)
+
+// Config is the configuration for the Extractor.
+type Config struct {
+	// Stats is a stats collector for reporting metrics.
+	Stats stats.Collector
+	// MaxFileSizeBytes is the maximum file size this extractor will unmarshal. If
+	// `FileRequired` gets a bigger file, it will return false,
+	MaxFileSizeBytes int64
+}
+
+// DefaultConfig returns the default configuration for the .deb file extractor.
+func DefaultConfig() Config {
+	return Config{
+		MaxFileSizeBytes: defaultMaxFileSizeBytes,
+	}
+}
+
+// Extractor extracts package metadata from .deb files.
+type Extractor struct {
+	stats            stats.Collector
+	maxFileSizeBytes int64
+}
+
+// New returns a .deb file extractor.
+//
+// For most use cases, initialize with:
+// ```
+// e := New(DefaultConfig())
+// ```
+func New(cfg Config) *Extractor {
+	return &Extractor{
+		stats:            cfg.Stats,
+		maxFileSizeBytes: cfg.MaxFileSizeBytes,
+	}
+}
+
+// Config returns the configuration of the extractor.
+func (e Extractor) Config() Config {
+	return Config{
+		Stats:            e.stats,
+		MaxFileSizeBytes: e.maxFileSizeBytes,
+	}
+}
+
+// Name of the extractor.
+func (e Extractor) Name() string { return Name }
+
+// Version of the extractor.
+func (e Extractor) Version() int { return 0 }
+
+// Requirements of the extractor.
+func (e Extractor) Requirements() *plugin.Capabilities { return &plugin.Capabilities{} }
+
+// RequiredFileExtensions returns the extension of the Debian packages the extractor requires.
+func (e Extractor) RequiredFileExtensions() []string { return []string{".deb"} }
+
+// FileRequired returns true if the specified file has a .deb extension. The
+// ar magic bytes are checked during extraction.
+func (e Extractor) FileRequired(path string, fileinfo fs.FileInfo) bool {
+	return e.fileRequired(path, fileinfo, nil)
+}
+
+// FileRequiredWithFS returns true if the specified file has a .deb extension
+// and starts with the ar magic bytes.
+func (e Extractor) FileRequiredWithFS(path string, fileinfo fs.FileInfo, file *filesystem.PeekableFile) bool {
+	return e.fileRequired(path, fileinfo, file)
+}
+
+func (e Extractor) fileRequired(path string, fileinfo fs.FileInfo, file *filesystem.PeekableFile) bool {
+	if strings.ToLower(filepath.Ext(path)) != ".deb" {
+		return false
+	}
+	if !fileinfo.Mode().IsRegular() {
+		return false
+	}
+
+	if e.maxFileSizeBytes > 0 && fileinfo.Size() > e.maxFileSizeBytes {
+		e.reportFileRequired(path, fileinfo.Size(), stats.FileRequiredResultSizeLimitExceeded)
+		return false
+	}
+
+	if file != nil {
+		if t, err := file.FileType(); err != nil || t != filesystem.FileTypeAr {
+			return false
+		}
+	}
+
+	e.reportFileRequired(path, fileinfo.Size(), stats.FileRequiredResultOK)
+	return true
+}
+
+func (e Extractor) reportFileRequired(path string, fileSizeBytes int64, result stats.FileRequiredResult) {
+	if e.stats == nil {
+		return
+	}
+	e.stats.AfterFileRequired(e.Name(), &stats.FileRequiredStats{
+		Path:          path,
+		Result:        result,
+		FileSizeBytes: fileSizeBytes,
+	})
+}
+
+// Extract extracts the package described by the control file of a .deb archive.
+func (e Extractor) Extract(ctx context.Context, input *filesystem.ScanInput) ([]*extractor.Inventory, error) {
+	inventory, err := e.extractFromInput(ctx, input)
+	if e.stats != nil {
+		var fileSizeBytes int64
+		if input.Info != nil {
+			fileSizeBytes = input.Info.Size()
+		}
+		e.stats.AfterFileExtracted(e.Name(), &stats.FileExtractedStats{
+			Path:          input.Path,
+			Result:        filesystem.ExtractorErrorToFileExtractedResult(err),
+			FileSizeBytes: fileSizeBytes,
+		})
+	}
+	return inventory, err
+}
+
+func (e Extractor) extractFromInput(ctx context.Context, input *filesystem.ScanInput) ([]*extractor.Inventory, error) {
+	control, err := readControl(ctx, input.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %q: %w", e.Name(), input.Path, err)
+	}
+
+	h, err := textproto.NewReader(bufio.NewReader(bytes.NewReader(control))).ReadMIMEHeader()
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("%s: failed to parse control file of %q: %w: %w", e.Name(), input.Path, extractor.ErrMalformedInput, err)
+	}
+
+	pkgName := h.Get("Package")
+	pkgVersion := h.Get("Version")
+	if pkgName == "" || pkgVersion == "" {
+		return nil, fmt.Errorf("%s: control file of %q lacks package name or version (name: %q, version: %q): %w", e.Name(), input.Path, pkgName, pkgVersion, extractor.ErrMalformedInput)
+	}
+
+	m := &dpkg.Metadata{
+		PackageName:    pkgName,
+		PackageVersion: pkgVersion,
+		Maintainer:     h.Get("Maintainer"),
+		Architecture:   h.Get("Architecture"),
+	}
+	if source := strings.TrimSpace(h.Get("Source")); source != "" {
+		// Source field format: "source-name (source-version)", the version is optional.
+		name, version, found := strings.Cut(source, " ")
+		m.SourceName = name
+		if found {
+			m.SourceVersion = strings.Trim(strings.TrimSpace(version), "()")
+		}
+	}
+
+	return []*extractor.Inventory{{
+		Name:      pkgName,
+		Version:   pkgVersion,
+		Metadata:  m,
+		Locations: []string{input.Path},
+	}}, nil
+}
+
+// readControl walks the ar members of a .deb archive and returns the contents
+// of the ./control file found in its control.tar member.
+func readControl(ctx context.Context, r io.Reader) ([]byte, error) {
+	br := bufio.NewReader(r)
+	magic := make([]byte, len(arMagic))
+	if _, err := io.ReadFull(br, magic); err != nil || string(magic) != arMagic {
+		return nil, errNotDeb
+	}
+
+	header := make([]byte, arHeaderSize)
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		if _, err := io.ReadFull(br, header); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil, errNoControl
+			}
+			return nil, fmt.Errorf("failed to read ar header: %w", err)
+		}
+		name := strings.TrimSuffix(strings.TrimSpace(string(header[0:16])), "/")
+		size, err := strconv.ParseInt(strings.TrimSpace(string(header[48:58])), 10, 64)
+		if err != nil || size < 0 {
+			return nil, fmt.Errorf("invalid ar member size for %q", name)
+		}
+		member := io.LimitReader(br, size)
+
+		if name == controlTarAlias || strings.HasPrefix(name, controlTarAlias+".") {
+			return readControlTar(name, member)
+		}
+		if _, err := io.Copy(io.Discard, member); err != nil {
+			return nil, fmt.Errorf("failed to skip ar member %q: %w", name, err)
+		}
+		// ar members are aligned to 2 bytes.
+		if size%2 == 1 {
+			if _, err := br.Discard(1); err != nil {
+				return nil, fmt.Errorf("failed to skip ar padding: %w", err)
+			}
+		}
+	}
+}
+
+func readControlTar(name string, r io.Reader) ([]byte, error) {
+	var tr io.Reader
+	switch path.Ext(name) {
+	case ".tar":
+		tr = r
+	case ".gz":
+		gr, err := gzip.NewReader(r)
+		if err != nil {
+			return nil, fmt.Errorf("gzip.NewReader(%q): %w", name, err)
+		}
+		defer gr.Close()
+		tr = gr
+	case ".xz":
+		xr, err := xz.NewReader(r)
+		if err != nil {
+			return nil, fmt.Errorf("xz.NewReader(%q): %w", name, err)
+		}
+		tr = xr
+	case ".zst":
+		zr, err := zstd.NewReader(r)
+		if err != nil {
+			return nil, fmt.Errorf("zstd.NewReader(%q): %w", name, err)
+		}
+		defer zr.Close()
+		tr = zr
+	default:
+		return nil, fmt.Errorf("unsupported control archive compression %q", name)
+	}
+
+	t := tar.NewReader(tr)
+	for {
+		hdr, err := t.Next()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil, fmt.Errorf("no control file in %q", name)
+			}
+			return nil, fmt.Errorf("failed to read %q: %w", name, err)
+		}
+		if path.Clean(hdr.Name) != "control" {
+			continue
+		}
+		if hdr.Size > maxControlSizeBytes {
+			return nil, fmt.Errorf("control file too large: %d bytes", hdr.Size)
+		}
+		return io.ReadAll(t)
+	}
+}
+
+// ToPURL converts an inventory created by this extractor into a PURL.
+// Loose .deb files carry no information about the distribution they were
+// built for so the namespace is always "linux".
+func (e Extractor) ToPURL(i *extractor.Inventory) (*purl.PackageURL, error) {
+	m := i.Metadata.(*dpkg.Metadata)
+	q := map[string]string{}
+	if m.Architecture != "" {
+		q[purl.Arch] = m.Architecture
+	}
+	if m.SourceName != "" {
+		q[purl.Source] = m.SourceName
+	}
+	if m.SourceVersion != "" {
+		q[purl.SourceVersion] = m.SourceVersion
+	}
+	return &purl.PackageURL{
+		Type:       purl.TypeDebian,
+		Namespace:  "linux",
+		Name:       m.PackageName,
+		Version:    i.Version,
+		Qualifiers: purl.QualifiersFromMap(q),
+	}, nil
+}
+
+// ToCPEs is not applicable as this extractor does not infer CPEs from the Inventory.
+func (e Extractor) ToCPEs(i *extractor.Inventory) ([]string, error) { return []string{}, nil }
+
+// Ecosystem returns the OSV Ecosystem of the software extracted by this extractor.
+func (Extractor) Ecosystem(i *extractor.Inventory) (string, error) { return "Linux", nil }
@@ -0,0 +1,293 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package debfile_test
+
+import (
+	"context"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem"
+	"github.com/google/osv-scalibr/extractor/filesystem/internal/units"
+	"github.com/google/osv-scalibr/extractor/filesystem/os/debfile"
+	"github.com/google/osv-scalibr/extractor/filesystem/os/dpkg"
+	scalibrfs "github.com/google/osv-scalibr/fs"
+	"github.com/google/osv-scalibr/purl"
+	"github.com/google/osv-scalibr/stats"
+	"github.com/google/osv-scalibr/testing/fakefs"
+	"github.com/google/osv-scalibr/testing/testcollector"
+)
+
+func TestFileRequired(t *testing.T) {
+	tests := []struct {
+		name             string
+		path             string
+		fileSizeBytes    int64
+		maxFileSizeBytes int64
+		wantRequired     bool
+		wantResultMetric stats.FileRequiredResult
+	}{
+		{
+			name:             "deb file",
+			path:             "tmp/hello_2.10-3_amd64.deb",
+			wantRequired:     true,
+			wantResultMetric: stats.FileRequiredResultOK,
+		},
+		{
+			name:             "uppercase extension",
+			path:             "tmp/HELLO.DEB",
+			wantRequired:     true,
+			wantResultMetric: stats.FileRequiredResultOK,
+		},
+		{
+			name:         "other extension",
+			path:         "tmp/hello.tar.gz",
+			wantRequired: false,
+		},
+		{
+			name:         "deb suffix without extension",
+			path:         "tmp/hellodeb",
+			wantRequired: false,
+		},
+		{
+			name:             "file not required if file size > max file size",
+			path:             "tmp/hello.deb",
+			fileSizeBytes:    1000 * units.KiB,
+			maxFileSizeBytes: 100 * units.KiB,
+			wantRequired:     false,
+			wantResultMetric: stats.FileRequiredResultSizeLimitExceeded,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			collector := testcollector.New()
+			var e filesystem.Extractor = debfile.New(debfile.Config{
+				Stats:            collector,
+				MaxFileSizeBytes: tt.maxFileSizeBytes,
+			})
+
+			// Set a default file size if not specified.
+			fileSizeBytes := tt.fileSizeBytes
+			if fileSizeBytes == 0 {
+				fileSizeBytes = 1000
+			}
+
+			isRequired := e.FileRequired(tt.path, fakefs.FakeFileInfo{
+				FileName: filepath.Base(tt.path),
+				FileMode: fs.ModePerm,
+				FileSize: fileSizeBytes,
+			})
+			if isRequired != tt.wantRequired {
+				t.Fatalf("FileRequired(%s): got %v, want %v", tt.path, isRequired, tt.wantRequired)
+			}
+
+			gotResultMetric := collector.FileRequiredResult(tt.path)
+			if tt.wantResultMetric != "" && gotResultMetric != tt.wantResultMetric {
+				t.Errorf("FileRequired(%s) recorded result metric %v, want result metric %v", tt.path, gotResultMetric, tt.wantResultMetric)
+			}
+		})
+	}
+}
+
+func TestFileRequiredWithFS(t *testing.T) {
+	tests := []struct {
+		name         string
+		path         string
+		wantRequired bool
+	}{
+		{
+			name:         "valid package",
+			path:         "hello_2.10-3_amd64.deb",
+			wantRequired: true,
+		}, {
+			name:         "wrong magic bytes",
+			path:         "notadeb.deb",
+			wantRequired: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := debfile.New(debfile.DefaultConfig())
+			fsys := scalibrfs.DirFS("testdata")
+			info, err := fs.Stat(fsys, tt.path)
+			if err != nil {
+				t.Fatalf("fs.Stat(%s): %v", tt.path, err)
+			}
+			file := filesystem.NewPeekableFile(fsys, tt.path)
+			defer file.Close()
+
+			if got := e.FileRequiredWithFS(tt.path, info, file); got != tt.wantRequired {
+				t.Errorf("FileRequiredWithFS(%s): got %v, want %v", tt.path, got, tt.wantRequired)
+			}
+		})
+	}
+}
+
+func TestExtract(t *testing.T) {
+	tests := []struct {
+		name             string
+		path             string
+		wantInventory    []*extractor.Inventory
+		wantErr          error
+		wantResultMetric stats.FileExtractedResult
+	}{
+		{
+			name: "gzip control archive",
+			path: "testdata/hello_2.10-3_amd64.deb",
+			wantInventory: []*extractor.Inventory{
+				{
+					Name:    "hello",
+					Version: "2.10-3",
+					Metadata: &dpkg.Metadata{
+						PackageName:    "hello",
+						PackageVersion: "2.10-3",
+						Maintainer:     "Santiago Vila <sanvila@debian.org>",
+						Architecture:   "amd64",
+					},
+					Locations: []string{"testdata/hello_2.10-3_amd64.deb"},
+				},
+			},
+			wantResultMetric: stats.FileExtractedResultSuccess,
+		},
+		{
+			name: "xz control archive with source",
+			path: "testdata/libfoo1_1.2.3-1_arm64.deb",
+			wantInventory: []*extractor.Inventory{
+				{
+					Name:    "libfoo1",
+					Version: "1:1.2.3-1+b1",
+					Metadata: &dpkg.Metadata{
+						PackageName:    "libfoo1",
+						PackageVersion: "1:1.2.3-1+b1",
+						SourceName:     "foo",
+						SourceVersion:  "1.2.3-1",
+						Maintainer:     "Jane Doe <jane@example.com>",
+						Architecture:   "arm64",
+					},
+					Locations: []string{"testdata/libfoo1_1.2.3-1_arm64.deb"},
+				},
+			},
+			wantResultMetric: stats.FileExtractedResultSuccess,
+		},
+		{
+			name: "zstd control archive",
+			path: "testdata/bar_0.1_all.deb",
+			wantInventory: []*extractor.Inventory{
+				{
+					Name:    "bar",
+					Version: "0.1",
+					Metadata: &dpkg.Metadata{
+						PackageName:    "bar",
+						PackageVersion: "0.1",
+						Architecture:   "all",
+					},
+					Locations: []string{"testdata/bar_0.1_all.deb"},
+				},
+			},
+			wantResultMetric: stats.FileExtractedResultSuccess,
+		},
+		{
+			name:             "missing version",
+			path:             "testdata/noversion.deb",
+			wantErr:          extractor.ErrMalformedInput,
+			wantResultMetric: stats.FileExtractedResultErrorMalformedInput,
+		},
+		{
+			name:             "missing control archive",
+			path:             "testdata/nocontrol.deb",
+			wantErr:          extractor.ErrMalformedInput,
+			wantResultMetric: stats.FileExtractedResultErrorMalformedInput,
+		},
+		{
+			name:             "not an ar archive",
+			path:             "testdata/notadeb.deb",
+			wantErr:          extractor.ErrMalformedInput,
+			wantResultMetric: stats.FileExtractedResultErrorMalformedInput,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			collector := testcollector.New()
+			cfg := debfile.DefaultConfig()
+			cfg.Stats = collector
+
+			r, err := os.Open(tt.path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer r.Close()
+			info, err := os.Stat(tt.path)
+			if err != nil {
+				t.Fatalf("Failed to stat test file: %v", err)
+			}
+
+			input := &filesystem.ScanInput{Path: tt.path, Reader: r, Info: info}
+			got, err := debfile.New(cfg).Extract(context.Background(), input)
+			if !cmp.Equal(err, tt.wantErr, cmpopts.EquateErrors()) {
+				t.Fatalf("Extract(%s) error: got %v, want %v", tt.path, err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.wantInventory, got); diff != "" {
+				t.Errorf("Extract(%s) (-want +got):\n%s", tt.path, diff)
+			}
+
+			gotResultMetric := collector.FileExtractedResult(tt.path)
+			if gotResultMetric != tt.wantResultMetric {
+				t.Errorf("Extract(%s) recorded result metric %v, want result metric %v", tt.path, gotResultMetric, tt.wantResultMetric)
+			}
+		})
+	}
+}
+
+func TestToPURL(t *testing.T) {
+	e := debfile.New(debfile.DefaultConfig())
+	i := &extractor.Inventory{
+		Name:    "libfoo1",
+		Version: "1:1.2.3-1+b1",
+		Metadata: &dpkg.Metadata{
+			PackageName:    "libfoo1",
+			PackageVersion: "1:1.2.3-1+b1",
+			SourceName:     "foo",
+			SourceVersion:  "1.2.3-1",
+			Architecture:   "arm64",
+		},
+		Locations: []string{"tmp/libfoo1_1.2.3-1_arm64.deb"},
+	}
+	want := &purl.PackageURL{
+		Type:      purl.TypeDebian,
+		Namespace: "linux",
+		Name:      "libfoo1",
+		Version:   "1:1.2.3-1+b1",
+		Qualifiers: purl.QualifiersFromMap(map[string]string{
+			purl.Arch:          "arm64",
+			purl.Source:        "foo",
+			purl.SourceVersion: "1.2.3-1",
+		}),
+	}
+	got, err := e.ToPURL(i)
+	if err != nil {
+		t.Fatalf("ToPURL(%v): %v", i, err)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ToPURL(%v) (-want +got):\n%s", i, diff)
+	}
+}
@@ -16,13 +16,11 @@
 package dpkg
 
 import (
-	"bufio"
 	"context"
 	"errors"
 	"fmt"
 	"io"
 	"io/fs"
-	"net/textproto"
 	"path/filepath"
 	"strings"
 
@@ -31,7 +29,7 @@ import (
 	"github.com/google/osv-scalibr/extractor"
 	"github.com/google/osv-scalibr/extractor/filesystem"
 	"github.com/google/osv-scalibr/extractor/filesystem/internal/units"
-	"github.com/google/osv-scalibr/extractor/filesystem/os/osrelease"
+	"github.com/google/osv-scalibr/extractor/filesystem/os/internal/controlfile"
 	"github.com/google/osv-scalibr/log"
 	"github.com/google/osv-scalibr/plugin"
 	"github.com/google/osv-scalibr/purl"
@@ -155,32 +153,29 @@ func (e Extractor) Extract(ctx context.Context, input *filesystem.ScanInput) ([]
 }
 
 func (e Extractor) extractFromInput(ctx context.Context, input *filesystem.ScanInput) ([]*extractor.Inventory, error) {
-	m, err := osrelease.GetOSRelease(input.FS)
+	m, err := input.GetOSRelease()
 	if err != nil {
-		log.Errorf("osrelease.ParseOsRelease(): %v", err)
+		log.Errorf("GetOSRelease(): %v", err)
 	}
 
-	rd := textproto.NewReader(bufio.NewReader(input.Reader))
+	rd := controlfile.NewReader(input.Reader)
 	pkgs := []*extractor.Inventory{}
-	for eof := false; !eof; {
+	for {
 		// Return if canceled or exceeding deadline.
 		if err := ctx.Err(); err != nil {
 			return pkgs, fmt.Errorf("%s halted at %q because of context error: %v", e.Name(), input.Path, err)
 		}
 
-		h, err := rd.ReadMIMEHeader()
+		h, err := rd.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
 		if err != nil {
-			if errors.Is(err, io.EOF) {
-				// We might still have one more line of data
-				// so return only after it's been parsed.
-				eof = true
-			} else {
-				if strings.Contains(input.Path, "status.d") {
-					log.Warnf("Failed to read MIME header from %q: %v", input.Path, err)
-					return []*extractor.Inventory{}, nil
-				}
-				return pkgs, err
+			if strings.Contains(input.Path, "status.d") {
+				log.Warnf("Failed to read MIME header from %q: %v", input.Path, err)
+				return []*extractor.Inventory{}, nil
 			}
+			return pkgs, err
 		}
 
 		// Distroless distributions have their packages in status.d, which does not contain the Status
@@ -190,9 +185,9 @@ func (e Extractor) extractFromInput(ctx context.Context, input *filesystem.ScanI
 				log.Warnf("Package %q has no status field", h.Get("Package"))
 				continue
 			}
-			installed, err := statusInstalled(h.Get("Status"))
+			installed, err := controlfile.StatusInstalled(h.Get("Status"))
 			if err != nil {
-				return pkgs, fmt.Errorf("statusInstalled(%q): %w", h.Get("Status"), err)
+				return pkgs, fmt.Errorf("StatusInstalled(%q): %w", h.Get("Status"), err)
 			}
 			if !installed {
 				continue
@@ -202,9 +197,7 @@ func (e Extractor) extractFromInput(ctx context.Context, input *filesystem.ScanI
 		pkgName := h.Get("Package")
 		pkgVersion := h.Get("Version")
 		if pkgName == "" || pkgVersion == "" {
-			if !eof { // Expected when reaching the last line.
-				log.Warnf("DPKG package name or version is empty (name: %q, version: %q)", pkgName, pkgVersion)
-			}
+			log.Warnf("DPKG package name or version is empty (name: %q, version: %q)", pkgName, pkgVersion)
 			continue
 		}
 
@@ -243,17 +236,6 @@ func (e Extractor) extractFromInput(ctx context.Context, input *filesystem.ScanI
 	return pkgs, nil
 }
 
-func statusInstalled(status string) (bool, error) {
-	// Status field format: "want flag status", e.g. "install ok installed"
-	// The package is currently installed if the status field is set to installed.
-	// Other fields just show the intent of the package manager but not the current state.
-	parts := strings.Split(status, " ")
-	if len(parts) != 3 {
-		return false, fmt.Errorf("invalid DPKG Status field %q", status)
-	}
-	return parts[2] == "installed", nil
-}
-
 func parseSourceNameVersion(source string) (string, string, error) {
 	if source == "" {
 		return "", "", nil
@@ -773,6 +773,41 @@ func TestExtractNonexistentOSRelease(t *testing.T) {
 	}
 }
 
+func TestExtractOSReleaseFromInput(t *testing.T) {
+	path := "testdata/single"
+	r, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The os-release fields are passed by the caller, the FS doesn't contain the file.
+	input := &filesystem.ScanInput{
+		FS: scalibrfs.DirFS(t.TempDir()), Path: path, Info: info, Reader: r,
+		OSRelease: map[string]string{"ID": "debian", "VERSION_ID": "12", "VERSION_CODENAME": "bookworm"},
+	}
+
+	e := dpkg.New(dpkg.DefaultConfig())
+	got, err := e.Extract(context.Background(), input)
+	if err != nil {
+		t.Fatalf("Extract(%s) error: %v", path, err)
+	}
+	if len(got) != 1 {
+		t.Fatalf("Extract(%s): got %d inventory items, want 1", path, len(got))
+	}
+	eco, err := e.Ecosystem(got[0])
+	if err != nil {
+		t.Fatalf("Ecosystem(%v): %v", got[0], err)
+	}
+	if want := "Debian:12"; eco != want {
+		t.Errorf("Ecosystem(%v): got %q, want %q", got[0], eco, want)
+	}
+}
+
 func TestToPURL(t *testing.T) {
 	pkgname := "pkgname"
 	sourcename := "sourcename"
@@ -24,7 +24,6 @@ import (
 
 	"github.com/google/osv-scalibr/extractor"
 	"github.com/google/osv-scalibr/extractor/filesystem"
-	"github.com/google/osv-scalibr/extractor/filesystem/os/osrelease"
 	"github.com/google/osv-scalibr/log"
 	"github.com/google/osv-scalibr/plugin"
 	"github.com/google/osv-scalibr/purl"
@@ -158,9 +157,9 @@ func (e Extractor) Extract(ctx context.Context, input *filesystem.ScanInput) ([]
 }
 
 func (e Extractor) extractFromInput(input *filesystem.ScanInput) (*extractor.Inventory, error) {
-	m, err := osrelease.GetOSRelease(input.FS)
+	m, err := input.GetOSRelease()
 	if err != nil {
-		log.Errorf("osrelease.ParseOsRelease(): %v", err)
+		log.Errorf("GetOSRelease(): %v", err)
 	}
 
 	var f Metainfo
@@ -85,6 +85,10 @@ func (e Extractor) Extract(ctx context.Context, input *filesystem.ScanInput) ([]
 			Name:      p.AppName,
 			Version:   p.AppVersion,
 			Locations: []string{input.Path},
+			// The version is taken from the Cellar/Caskroom directory name, which
+			// doesn't always match the installed version.
+			Confidence: extractor.ConfidenceLow,
+			Evidence:   "version from the installation path",
 		},
 	}, nil
 }
@@ -90,9 +90,11 @@ func TestExtract(t *testing.T) {
 			path: "testdata/Cellar/rclone/1.67.0/INSTALL_RECEIPT.json",
 			wantInventory: []*extractor.Inventory{
 				{
-					Name:      "rclone",
-					Version:   "1.67.0",
-					Locations: []string{"testdata/Cellar/rclone/1.67.0/INSTALL_RECEIPT.json"},
+					Name:       "rclone",
+					Version:    "1.67.0",
+					Locations:  []string{"testdata/Cellar/rclone/1.67.0/INSTALL_RECEIPT.json"},
+					Confidence: extractor.ConfidenceLow,
+					Evidence:   "version from the installation path",
 				},
 			},
 		},
@@ -101,9 +103,11 @@ func TestExtract(t *testing.T) {
 			path: "testdata/Caskroom/testapp/1.1.1/testapp.wrapper.sh",
 			wantInventory: []*extractor.Inventory{
 				{
-					Name:      "testapp",
-					Version:   "1.1.1",
-					Locations: []string{"testdata/Caskroom/testapp/1.1.1/testapp.wrapper.sh"},
+					Name:       "testapp",
+					Version:    "1.1.1",
+					Locations:  []string{"testdata/Caskroom/testapp/1.1.1/testapp.wrapper.sh"},
+					Confidence: extractor.ConfidenceLow,
+					Evidence:   "version from the installation path",
 				},
 			},
 		},
@@ -0,0 +1,71 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package controlfile parses the Debian control file format that the dpkg and
+// opkg package databases use to store the fields of the installed packages.
+package controlfile
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"net/textproto"
+	"strings"
+)
+
+// Reader reads the paragraphs of a control file. Each paragraph contains the
+// "Name: value" fields of one package and ends with an empty line.
+type Reader struct {
+	rd  *textproto.Reader
+	eof bool
+}
+
+// NewReader returns a Reader that reads the paragraphs from r.
+func NewReader(r io.Reader) *Reader {
+	return &Reader{rd: textproto.NewReader(bufio.NewReader(r))}
+}
+
+// Next returns the fields of the next paragraph, keyed by their canonical
+// names, e.g. "Package". Continuation lines are joined with the first line of
+// the field. Empty paragraphs are skipped. Returns io.EOF once all paragraphs
+// have been read.
+func (r *Reader) Next() (textproto.MIMEHeader, error) {
+	for !r.eof {
+		h, err := r.rd.ReadMIMEHeader()
+		if errors.Is(err, io.EOF) {
+			// The last paragraph is returned together with io.EOF.
+			r.eof = true
+		} else if err != nil {
+			return nil, err
+		}
+		if len(h) > 0 {
+			return h, nil
+		}
+	}
+	return nil, io.EOF
+}
+
+// StatusInstalled returns whether a Status field, e.g. "install ok installed",
+// says that the package is currently installed.
+func StatusInstalled(status string) (bool, error) {
+	// Status field format: "want flag status".
+	// Only the status shows the current state of the package, the other parts
+	// just show the intent of the package manager.
+	parts := strings.Split(status, " ")
+	if len(parts) != 3 {
+		return false, fmt.Errorf("invalid Status field %q", status)
+	}
+	return parts[2] == "installed", nil
+}
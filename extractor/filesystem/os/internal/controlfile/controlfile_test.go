@@ -0,0 +1,114 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controlfile_test
+
+import (
+	"errors"
+	"io"
+	"net/textproto"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/osv-scalibr/extractor/filesystem/os/internal/controlfile"
+)
+
+func TestNext(t *testing.T) {
+	for _, tc := range []struct {
+		desc    string
+		content string
+		want    []textproto.MIMEHeader
+		wantErr bool
+	}{
+		{
+			desc:    "empty",
+			content: "",
+		},
+		{
+			desc:    "several paragraphs",
+			content: "Package: a\nVersion: 1.0\n\nPackage: b\nVersion: 2.0\n",
+			want: []textproto.MIMEHeader{
+				{"Package": {"a"}, "Version": {"1.0"}},
+				{"Package": {"b"}, "Version": {"2.0"}},
+			},
+		},
+		{
+			desc:    "extra empty lines",
+			content: "\nPackage: a\n\n\n\nPackage: b\n\n\n",
+			want: []textproto.MIMEHeader{
+				{"Package": {"a"}},
+				{"Package": {"b"}},
+			},
+		},
+		{
+			desc:    "continuation lines",
+			content: "Package: a\nConffiles:\n /etc/a.conf 1234\n /etc/b.conf 5678\n",
+			want: []textproto.MIMEHeader{
+				{"Package": {"a"}, "Conffiles": {"/etc/a.conf 1234 /etc/b.conf 5678"}},
+			},
+		},
+		{
+			desc:    "malformed line",
+			content: "Package: a\nnot a field\n",
+			wantErr: true,
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			r := controlfile.NewReader(strings.NewReader(tc.content))
+			var got []textproto.MIMEHeader
+			var err error
+			for {
+				var h textproto.MIMEHeader
+				if h, err = r.Next(); err != nil {
+					break
+				}
+				got = append(got, h)
+			}
+			if gotErr := !errors.Is(err, io.EOF); gotErr != tc.wantErr {
+				t.Fatalf("Next(): got error %v, want error: %t", err, tc.wantErr)
+			}
+			if tc.wantErr {
+				return
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("Next(): unexpected paragraphs (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestStatusInstalled(t *testing.T) {
+	for _, tc := range []struct {
+		status  string
+		want    bool
+		wantErr bool
+	}{
+		{status: "install ok installed", want: true},
+		{status: "install user installed", want: true},
+		{status: "deinstall ok config-files", want: false},
+		{status: "install ok not-installed", want: false},
+		{status: "installed", wantErr: true},
+	} {
+		t.Run(tc.status, func(t *testing.T) {
+			got, err := controlfile.StatusInstalled(tc.status)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("StatusInstalled(%q): got error %v, want error: %t", tc.status, err, tc.wantErr)
+			}
+			if got != tc.want {
+				t.Errorf("StatusInstalled(%q): got %t, want %t", tc.status, got, tc.want)
+			}
+		})
+	}
+}
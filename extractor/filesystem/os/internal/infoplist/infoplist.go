@@ -0,0 +1,60 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package infoplist decodes the Info.plist manifests of macOS and iOS bundles
+// such as applications and frameworks.
+package infoplist
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+
+	"github.com/google/osv-scalibr/extractor"
+	"howett.net/plist"
+)
+
+// Info holds the Info.plist keys that identify a bundle.
+type Info struct {
+	CFBundleName               string `plist:"CFBundleName"`
+	CFBundleDisplayName        string `plist:"CFBundleDisplayName"`
+	CFBundleIdentifier         string `plist:"CFBundleIdentifier"`
+	CFBundleShortVersionString string `plist:"CFBundleShortVersionString"`
+	CFBundleVersion            string `plist:"CFBundleVersion"`
+	CFBundleExecutable         string `plist:"CFBundleExecutable"`
+}
+
+// Decode decodes an Info.plist in either the XML or the binary encoding.
+// Errors for invalid plists wrap extractor.ErrMalformedInput.
+func Decode(r io.Reader) (*Info, error) {
+	// The plist decoder needs to seek to detect the encoding.
+	content, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	var info Info
+	if err := plist.NewDecoder(bytes.NewReader(content)).Decode(&info); err != nil {
+		return nil, fmt.Errorf("%w: %w", extractor.ErrMalformedInput, err)
+	}
+	return &info, nil
+}
+
+// Version returns the user-visible version of the bundle, falling back to the
+// build number if the bundle has none.
+func (i *Info) Version() string {
+	if i.CFBundleShortVersionString != "" {
+		return i.CFBundleShortVersionString
+	}
+	return i.CFBundleVersion
+}
@@ -0,0 +1,79 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package infoplist_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem/os/internal/infoplist"
+)
+
+const xmlPlist = `<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">
+<plist version="1.0">
+<dict>
+	<key>CFBundleIdentifier</key>
+	<string>org.sparkle-project.Sparkle</string>
+	<key>CFBundleName</key>
+	<string>Sparkle</string>
+	<key>CFBundleVersion</key>
+	<string>2017</string>
+</dict>
+</plist>
+`
+
+func TestDecode(t *testing.T) {
+	tests := []struct {
+		desc        string
+		content     string
+		want        *infoplist.Info
+		wantVersion string
+		wantErr     error
+	}{
+		{
+			desc:    "XML plist",
+			content: xmlPlist,
+			want: &infoplist.Info{
+				CFBundleName:       "Sparkle",
+				CFBundleIdentifier: "org.sparkle-project.Sparkle",
+				CFBundleVersion:    "2017",
+			},
+			wantVersion: "2017",
+		},
+		{
+			desc:    "Invalid plist",
+			content: "not a plist",
+			wantErr: extractor.ErrMalformedInput,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := infoplist.Decode(strings.NewReader(tc.content))
+			if !cmp.Equal(err, tc.wantErr, cmpopts.EquateErrors()) {
+				t.Fatalf("Decode(): got error %v, want %v", err, tc.wantErr)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("Decode(): unexpected result (-want +got):\n%s", diff)
+			}
+			if got != nil && got.Version() != tc.wantVersion {
+				t.Errorf("Decode().Version(): got %q, want %q", got.Version(), tc.wantVersion)
+			}
+		})
+	}
+}
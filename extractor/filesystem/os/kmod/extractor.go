@@ -0,0 +1,289 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package kmod extracts the Linux kernel modules installed in
+// lib/modules/<kernel release> from the .modinfo section of the .ko files.
+//
+// modules.dep isn't read since the dependencies of a module are also recorded
+// in its modinfo. Modules compressed with zstd or gzip aren't extracted.
+package kmod
+
+import (
+	"bytes"
+	"context"
+	"debug/elf"
+	"fmt"
+	"io"
+	"io/fs"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/ulikunitz/xz"
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem"
+	"github.com/google/osv-scalibr/extractor/filesystem/internal/units"
+	"github.com/google/osv-scalibr/plugin"
+	"github.com/google/osv-scalibr/purl"
+	"github.com/google/osv-scalibr/stats"
+)
+
+const (
+	// Name is the unique name of this extractor.
+	Name = "os/kmod"
+
+	// defaultMaxFileSizeBytes is the maximum file size an extractor will unmarshal.
+	// If Extract gets a bigger file, it will return an error.
+	defaultMaxFileSizeBytes = 100 * units.MiB
+
+	modinfoSection = ".modinfo"
+)
+
+// Config is the configuration for the Extractor.
+type Config struct {
+	// Stats is a stats collector for reporting metrics.
+	Stats stats.Collector
+	// MaxFileSizeBytes is the maximum file size this extractor will unmarshal. If
+	// `FileRequired` gets a bigger file, it will return false,
+	MaxFileSizeBytes int64
+}
+
+// DefaultConfig returns the default configuration for the extractor.
+func DefaultConfig() Config {
+	return Config{
+		MaxFileSizeBytes: defaultMaxFileSizeBytes,
+		Stats:            nil,
+	}
+}
+
+// Extractor extracts kernel modules.
+type Extractor struct {
+	stats            stats.Collector
+	maxFileSizeBytes int64
+}
+
+// New returns a kernel module extractor.
+//
+// For most use cases, initialize with:
+// ```
+// e := New(DefaultConfig())
+// ```
+func New(cfg Config) *Extractor {
+	return &Extractor{
+		stats:            cfg.Stats,
+		maxFileSizeBytes: cfg.MaxFileSizeBytes,
+	}
+}
+
+// Config returns the configuration of the extractor.
+func (e Extractor) Config() Config {
+	return Config{
+		Stats:            e.stats,
+		MaxFileSizeBytes: e.maxFileSizeBytes,
+	}
+}
+
+// Name of the extractor.
+func (e Extractor) Name() string { return Name }
+
+// Version of the extractor.
+func (e Extractor) Version() int { return 0 }
+
+// Requirements of the extractor. Compressed modules are decompressed in
+// memory.
+func (e Extractor) Requirements() *plugin.Capabilities {
+	return &plugin.Capabilities{OS: plugin.OSLinux, Memory: plugin.MemoryMultipleFileSize}
+}
+
+// FileRequired returns true if the specified file is a kernel module, i.e. a
+// .ko or .ko.xz file.
+func (e Extractor) FileRequired(path string, fileinfo fs.FileInfo) bool {
+	if !strings.HasSuffix(path, ".ko") && !strings.HasSuffix(path, ".ko.xz") {
+		return false
+	}
+
+	if e.maxFileSizeBytes > 0 && fileinfo.Size() > e.maxFileSizeBytes {
+		e.reportFileRequired(path, fileinfo.Size(), stats.FileRequiredResultSizeLimitExceeded)
+		return false
+	}
+
+	e.reportFileRequired(path, fileinfo.Size(), stats.FileRequiredResultOK)
+	return true
+}
+
+func (e Extractor) reportFileRequired(path string, fileSizeBytes int64, result stats.FileRequiredResult) {
+	if e.stats == nil {
+		return
+	}
+	e.stats.AfterFileRequired(e.Name(), &stats.FileRequiredStats{
+		Path:          path,
+		Result:        result,
+		FileSizeBytes: fileSizeBytes,
+	})
+}
+
+// Extract returns the kernel module described by the modinfo of the file.
+func (e Extractor) Extract(ctx context.Context, input *filesystem.ScanInput) ([]*extractor.Inventory, error) {
+	inventory, err := e.extractFromInput(input)
+	if e.stats != nil {
+		var fileSizeBytes int64
+		if input.Info != nil {
+			fileSizeBytes = input.Info.Size()
+		}
+		e.stats.AfterFileExtracted(e.Name(), &stats.FileExtractedStats{
+			Path:          input.Path,
+			Result:        filesystem.ExtractorErrorToFileExtractedResult(err),
+			FileSizeBytes: fileSizeBytes,
+		})
+	}
+	return inventory, err
+}
+
+func (e Extractor) extractFromInput(input *filesystem.ScanInput) ([]*extractor.Inventory, error) {
+	r, err := e.moduleReader(input)
+	if err != nil {
+		return nil, err
+	}
+	f, err := elf.NewFile(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %q as ELF: %w: %w", input.Path, extractor.ErrMalformedInput, err)
+	}
+	defer f.Close()
+	section := f.Section(modinfoSection)
+	if section == nil {
+		return nil, fmt.Errorf("no %s section in %q: %w", modinfoSection, input.Path, extractor.ErrMalformedInput)
+	}
+	data, err := section.Data()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the %s section of %q: %w: %w", modinfoSection, input.Path, extractor.ErrMalformedInput, err)
+	}
+	info := parseModinfo(data)
+
+	name := info["name"]
+	if name == "" {
+		name = moduleNameFromPath(input.Path)
+	}
+	kernelRelease, _, _ := strings.Cut(info["vermagic"], " ")
+	if kernelRelease == "" {
+		kernelRelease = kernelReleaseFromPath(input.Path)
+	}
+	// In-tree modules usually don't have a version of their own and are
+	// versioned with the kernel they're built for.
+	version := info["version"]
+	if version == "" {
+		version = kernelRelease
+	}
+	var depends []string
+	if info["depends"] != "" {
+		depends = strings.Split(info["depends"], ",")
+	}
+	return []*extractor.Inventory{{
+		Name:    name,
+		Version: version,
+		Metadata: &Metadata{
+			KernelRelease: kernelRelease,
+			SrcVersion:    info["srcversion"],
+			License:       info["license"],
+			Description:   info["description"],
+			Depends:       depends,
+		},
+		Locations: []string{input.Path},
+	}}, nil
+}
+
+// moduleReader returns random access to the uncompressed module.
+func (e Extractor) moduleReader(input *filesystem.ScanInput) (io.ReaderAt, error) {
+	if strings.HasSuffix(input.Path, ".xz") {
+		xr, err := xz.NewReader(input.Reader)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open %q as xz: %w: %w", input.Path, extractor.ErrMalformedInput, err)
+		}
+		return e.readAll(input.Path, xr)
+	}
+	if input.Info != nil {
+		if r := input.SectionReader(0, input.Info.Size()); r != nil {
+			return r, nil
+		}
+	}
+	return e.readAll(input.Path, input.Reader)
+}
+
+// readAll reads the module into memory, up to the maximum file size.
+func (e Extractor) readAll(path string, r io.Reader) (io.ReaderAt, error) {
+	if e.maxFileSizeBytes > 0 {
+		r = io.LimitReader(r, e.maxFileSizeBytes+1)
+	}
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %q: %w", path, err)
+	}
+	if e.maxFileSizeBytes > 0 && int64(len(data)) > e.maxFileSizeBytes {
+		return nil, fmt.Errorf("%q is larger than %d bytes uncompressed", path, e.maxFileSizeBytes)
+	}
+	return bytes.NewReader(data), nil
+}
+
+// parseModinfo parses the NUL-separated "key=value" entries of a .modinfo
+// section. Keys that are repeated, e.g. "alias", keep their first value.
+func parseModinfo(data []byte) map[string]string {
+	info := map[string]string{}
+	for _, entry := range bytes.Split(data, []byte{0}) {
+		k, v, ok := strings.Cut(string(entry), "=")
+		if !ok {
+			continue
+		}
+		if _, exists := info[k]; !exists {
+			info[k] = v
+		}
+	}
+	return info
+}
+
+// moduleNameFromPath returns the name of the module from its file name, e.g.
+// "snd_hda_intel" for "snd-hda-intel.ko.xz". Like modprobe, dashes are
+// normalized to underscores.
+func moduleNameFromPath(p string) string {
+	name := path.Base(filepath.ToSlash(p))
+	name = strings.TrimSuffix(name, ".xz")
+	name = strings.TrimSuffix(name, ".ko")
+	return strings.ReplaceAll(name, "-", "_")
+}
+
+// kernelReleaseFromPath returns the kernel release of the modules in
+// lib/modules/<kernel release>/.
+func kernelReleaseFromPath(p string) string {
+	parts := strings.Split(filepath.ToSlash(p), "/")
+	for i := 0; i+2 < len(parts); i++ {
+		if parts[i] == "lib" && parts[i+1] == "modules" {
+			return parts[i+2]
+		}
+	}
+	return ""
+}
+
+// ToPURL converts an inventory created by this extractor into a PURL.
+func (e Extractor) ToPURL(i *extractor.Inventory) (*purl.PackageURL, error) {
+	return &purl.PackageURL{
+		Type:      purl.TypeGeneric,
+		Namespace: "linux-kernel-module",
+		Name:      i.Name,
+		Version:   i.Version,
+	}, nil
+}
+
+// ToCPEs is not applicable as this extractor does not infer CPEs from the Inventory.
+func (e Extractor) ToCPEs(i *extractor.Inventory) ([]string, error) { return []string{}, nil }
+
+// Ecosystem returns no ecosystem since OSV does not support kernel modules.
+func (Extractor) Ecosystem(i *extractor.Inventory) (string, error) { return "", nil }
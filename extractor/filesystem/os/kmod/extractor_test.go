@@ -0,0 +1,243 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kmod_test
+
+import (
+	"context"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem"
+	"github.com/google/osv-scalibr/extractor/filesystem/internal/units"
+	"github.com/google/osv-scalibr/extractor/filesystem/os/kmod"
+	scalibrfs "github.com/google/osv-scalibr/fs"
+	"github.com/google/osv-scalibr/purl"
+	"github.com/google/osv-scalibr/stats"
+	"github.com/google/osv-scalibr/testing/fakefs"
+	"github.com/google/osv-scalibr/testing/testcollector"
+)
+
+func TestFileRequired(t *testing.T) {
+	tests := []struct {
+		name             string
+		path             string
+		fileSizeBytes    int64
+		maxFileSizeBytes int64
+		wantRequired     bool
+		wantResultMetric stats.FileRequiredResult
+	}{
+		{
+			name:             "module",
+			path:             "lib/modules/6.1.0-18-amd64/kernel/drivers/net/e1000e.ko",
+			wantRequired:     true,
+			wantResultMetric: stats.FileRequiredResultOK,
+		}, {
+			name:             "xz-compressed module",
+			path:             "usr/lib/modules/6.1.0-18-amd64/updates/dkms/nvidia.ko.xz",
+			wantRequired:     true,
+			wantResultMetric: stats.FileRequiredResultOK,
+		}, {
+			name:         "zstd-compressed module",
+			path:         "lib/modules/6.8.0-31-generic/kernel/drivers/net/e1000e.ko.zst",
+			wantRequired: false,
+		}, {
+			name:         "modules.dep",
+			path:         "lib/modules/6.1.0-18-amd64/modules.dep",
+			wantRequired: false,
+		}, {
+			name:         "kernel object in the middle of the name",
+			path:         "lib/modules/6.1.0-18-amd64/e1000e.ko.sig",
+			wantRequired: false,
+		}, {
+			name:             "file size limit exceeded",
+			path:             "lib/modules/6.1.0-18-amd64/kernel/drivers/net/e1000e.ko",
+			fileSizeBytes:    1 * units.MiB,
+			maxFileSizeBytes: 1000,
+			wantRequired:     false,
+			wantResultMetric: stats.FileRequiredResultSizeLimitExceeded,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			collector := testcollector.New()
+			var e filesystem.Extractor = kmod.New(kmod.Config{
+				Stats:            collector,
+				MaxFileSizeBytes: tt.maxFileSizeBytes,
+			})
+
+			fileSizeBytes := tt.fileSizeBytes
+			if fileSizeBytes == 0 {
+				fileSizeBytes = 1000
+			}
+
+			isRequired := e.FileRequired(tt.path, fakefs.FakeFileInfo{
+				FileName: filepath.Base(tt.path),
+				FileMode: fs.ModePerm,
+				FileSize: fileSizeBytes,
+			})
+			if isRequired != tt.wantRequired {
+				t.Fatalf("FileRequired(%s): got %v, want %v", tt.path, isRequired, tt.wantRequired)
+			}
+
+			gotResultMetric := collector.FileRequiredResult(tt.path)
+			if tt.wantResultMetric != "" && gotResultMetric != tt.wantResultMetric {
+				t.Errorf("FileRequired(%s) recorded result metric %v, want result metric %v", tt.path, gotResultMetric, tt.wantResultMetric)
+			}
+		})
+	}
+}
+
+// sequentialReader hides the io.ReaderAt implementation of the wrapped reader.
+type sequentialReader struct {
+	io.Reader
+}
+
+func TestExtract(t *testing.T) {
+	e1000e := []*extractor.Inventory{
+		{
+			Name:    "e1000e",
+			Version: "6.1.0-18-amd64",
+			Metadata: &kmod.Metadata{
+				KernelRelease: "6.1.0-18-amd64",
+				SrcVersion:    "6BC4A6D5B6C4A1E0C9F6E3D",
+				License:       "GPL v2",
+				Description:   "Intel(R) PRO/1000 Network Driver",
+				Depends:       []string{"ptp"},
+			},
+			Locations: []string{"lib/modules/6.1.0-18-amd64/kernel/drivers/net/e1000e.ko"},
+		},
+	}
+	tests := []struct {
+		name             string
+		path             string
+		sequential       bool
+		wantInventory    []*extractor.Inventory
+		wantErr          error
+		wantResultMetric stats.FileExtractedResult
+	}{
+		{
+			name:             "in-tree module",
+			path:             "lib/modules/6.1.0-18-amd64/kernel/drivers/net/e1000e.ko",
+			wantInventory:    e1000e,
+			wantResultMetric: stats.FileExtractedResultSuccess,
+		}, {
+			name:             "in-tree module without random access",
+			path:             "lib/modules/6.1.0-18-amd64/kernel/drivers/net/e1000e.ko",
+			sequential:       true,
+			wantInventory:    e1000e,
+			wantResultMetric: stats.FileExtractedResultSuccess,
+		}, {
+			name: "xz-compressed out-of-tree module",
+			path: "lib/modules/6.1.0-18-amd64/updates/dkms/nvidia.ko.xz",
+			wantInventory: []*extractor.Inventory{
+				{
+					Name:    "nvidia",
+					Version: "535.161.07",
+					Metadata: &kmod.Metadata{
+						KernelRelease: "6.1.0-18-amd64",
+						SrcVersion:    "A1D6B7F3E2C4D5E6F7A8B9C",
+						License:       "NVIDIA",
+					},
+					Locations: []string{"lib/modules/6.1.0-18-amd64/updates/dkms/nvidia.ko.xz"},
+				},
+			},
+			wantResultMetric: stats.FileExtractedResultSuccess,
+		}, {
+			name:             "ELF file without modinfo",
+			path:             "lib/modules/6.1.0-18-amd64/kernel/drivers/net/broken.ko",
+			wantErr:          extractor.ErrMalformedInput,
+			wantResultMetric: stats.FileExtractedResultErrorMalformedInput,
+		}, {
+			name:             "not an ELF file",
+			path:             "lib/modules/6.1.0-18-amd64/kernel/drivers/net/notelf.ko",
+			wantErr:          extractor.ErrMalformedInput,
+			wantResultMetric: stats.FileExtractedResultErrorMalformedInput,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			collector := testcollector.New()
+			var e filesystem.Extractor = kmod.New(kmod.Config{
+				Stats:            collector,
+				MaxFileSizeBytes: 1 * units.MiB,
+			})
+
+			d := "testdata"
+			f, err := os.Open(filepath.Join(d, tt.path))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer f.Close()
+			info, err := f.Stat()
+			if err != nil {
+				t.Fatalf("Stat(): %v", err)
+			}
+			var r io.Reader = f
+			if tt.sequential {
+				r = sequentialReader{f}
+			}
+
+			input := &filesystem.ScanInput{
+				FS:     scalibrfs.DirFS(d),
+				Path:   tt.path,
+				Reader: r,
+				Root:   d,
+				Info:   info,
+			}
+			got, err := e.Extract(context.Background(), input)
+			if !cmp.Equal(err, tt.wantErr, cmpopts.EquateErrors()) {
+				t.Fatalf("Extract(%+v) error: got %v, want %v\n", tt.path, err, tt.wantErr)
+			}
+
+			if diff := cmp.Diff(tt.wantInventory, got); diff != "" {
+				t.Errorf("Extract(%s) (-want +got):\n%s", tt.path, diff)
+			}
+
+			gotResultMetric := collector.FileExtractedResult(tt.path)
+			if gotResultMetric != tt.wantResultMetric {
+				t.Errorf("Extract(%s) recorded result metric %v, want result metric %v", tt.path, gotResultMetric, tt.wantResultMetric)
+			}
+		})
+	}
+}
+
+func TestToPURL(t *testing.T) {
+	e := kmod.New(kmod.DefaultConfig())
+	i := &extractor.Inventory{
+		Name:    "nvidia",
+		Version: "535.161.07",
+	}
+	want := &purl.PackageURL{
+		Type:      purl.TypeGeneric,
+		Namespace: "linux-kernel-module",
+		Name:      "nvidia",
+		Version:   "535.161.07",
+	}
+	got, err := e.ToPURL(i)
+	if err != nil {
+		t.Fatalf("ToPURL(%v): %v", i, err)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ToPURL(%v) (-want +got):\n%s", i, diff)
+	}
+}
@@ -0,0 +1,30 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kmod
+
+// Metadata holds additional information about a kernel module.
+type Metadata struct {
+	// KernelRelease is the release of the kernel the module is built for, e.g.
+	// "6.1.0-18-amd64".
+	KernelRelease string
+	// SrcVersion is the checksum of the module's source code.
+	SrcVersion string
+	// License of the module, e.g. "GPL v2".
+	License string
+	// Description of the module.
+	Description string
+	// Depends lists the names of the modules the module depends on.
+	Depends []string
+}
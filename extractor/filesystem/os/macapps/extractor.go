@@ -0,0 +1,207 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package macapps extracts applications installed as .app bundles on macOS.
+package macapps
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"strings"
+
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem"
+	"github.com/google/osv-scalibr/extractor/filesystem/internal/units"
+	"github.com/google/osv-scalibr/extractor/filesystem/os/internal/infoplist"
+	"github.com/google/osv-scalibr/plugin"
+	"github.com/google/osv-scalibr/purl"
+	"github.com/google/osv-scalibr/stats"
+)
+
+const (
+	// Name is the unique name of this extractor.
+	Name = "os/macapps"
+
+	// defaultMaxFileSizeBytes is the maximum file size an extractor will unmarshal.
+	// If Extract gets a bigger file, it will return an error.
+	defaultMaxFileSizeBytes = 1 * units.MiB
+
+	// infoPlistSuffix is the path of the bundle manifest relative to the parent
+	// directory of the .app bundle.
+	infoPlistSuffix = ".app/Contents/Info.plist"
+)
+
+// Config is the configuration for the Extractor.
+type Config struct {
+	// Stats is a stats collector for reporting metrics.
+	Stats stats.Collector
+	// MaxFileSizeBytes is the maximum file size this extractor will unmarshal. If
+	// `FileRequired` gets a bigger file, it will return false,
+	MaxFileSizeBytes int64
+}
+
+// DefaultConfig returns the default configuration for the extractor.
+func DefaultConfig() Config {
+	return Config{
+		MaxFileSizeBytes: defaultMaxFileSizeBytes,
+		Stats:            nil,
+	}
+}
+
+// Extractor extracts macOS applications from the Info.plist of .app bundles.
+type Extractor struct {
+	stats            stats.Collector
+	maxFileSizeBytes int64
+}
+
+// New returns a macOS app extractor.
+//
+// For most use cases, initialize with:
+// ```
+// e := New(DefaultConfig())
+// ```
+func New(cfg Config) *Extractor {
+	return &Extractor{
+		stats:            cfg.Stats,
+		maxFileSizeBytes: cfg.MaxFileSizeBytes,
+	}
+}
+
+// Config returns the configuration of the extractor.
+func (e Extractor) Config() Config {
+	return Config{
+		Stats:            e.stats,
+		MaxFileSizeBytes: e.maxFileSizeBytes,
+	}
+}
+
+// Name of the extractor.
+func (e Extractor) Name() string { return Name }
+
+// Version of the extractor.
+func (e Extractor) Version() int { return 0 }
+
+// Requirements of the extractor.
+func (e Extractor) Requirements() *plugin.Capabilities {
+	return &plugin.Capabilities{OS: plugin.OSMac}
+}
+
+// RequiredFileNames returns the name of the app bundle info files the extractor requires.
+func (e Extractor) RequiredFileNames() []string { return []string{"Info.plist"} }
+
+// FileRequired returns true if the specified file is the Info.plist of an .app
+// bundle, i.e. matches the pattern *.app/Contents/Info.plist.
+func (e Extractor) FileRequired(path string, fileinfo fs.FileInfo) bool {
+	path = filepath.ToSlash(path)
+	// macOS file systems are case-insensitive by default.
+	if !strings.HasSuffix(strings.ToLower(path), strings.ToLower(infoPlistSuffix)) {
+		return false
+	}
+	// Don't match a bare ".app" directory without a bundle name.
+	if len(path) == len(infoPlistSuffix) || strings.HasSuffix(path[:len(path)-len(infoPlistSuffix)], "/") {
+		return false
+	}
+
+	if e.maxFileSizeBytes > 0 && fileinfo.Size() > e.maxFileSizeBytes {
+		e.reportFileRequired(path, fileinfo.Size(), stats.FileRequiredResultSizeLimitExceeded)
+		return false
+	}
+
+	e.reportFileRequired(path, fileinfo.Size(), stats.FileRequiredResultOK)
+	return true
+}
+
+func (e Extractor) reportFileRequired(path string, fileSizeBytes int64, result stats.FileRequiredResult) {
+	if e.stats == nil {
+		return
+	}
+	e.stats.AfterFileRequired(e.Name(), &stats.FileRequiredStats{
+		Path:          path,
+		Result:        result,
+		FileSizeBytes: fileSizeBytes,
+	})
+}
+
+// Extract extracts the app's name and version from the Info.plist passed
+// through the scan input.
+func (e Extractor) Extract(ctx context.Context, input *filesystem.ScanInput) ([]*extractor.Inventory, error) {
+	inventory, err := e.extractFromInput(input)
+	if e.stats != nil {
+		var fileSizeBytes int64
+		if input.Info != nil {
+			fileSizeBytes = input.Info.Size()
+		}
+		e.stats.AfterFileExtracted(e.Name(), &stats.FileExtractedStats{
+			Path:          input.Path,
+			Result:        filesystem.ExtractorErrorToFileExtractedResult(err),
+			FileSizeBytes: fileSizeBytes,
+		})
+	}
+	return inventory, err
+}
+
+func (e Extractor) extractFromInput(input *filesystem.ScanInput) ([]*extractor.Inventory, error) {
+	p, err := infoplist.Decode(input.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to plist decode %q: %w", input.Path, err)
+	}
+
+	name := p.CFBundleName
+	if name == "" {
+		name = p.CFBundleDisplayName
+	}
+	if name == "" {
+		name = p.CFBundleExecutable
+	}
+	if name == "" {
+		return nil, fmt.Errorf("missing bundle name from %q: %w", input.Path, extractor.ErrMalformedInput)
+	}
+
+	version := p.Version()
+	if version == "" {
+		return nil, fmt.Errorf("missing bundle version from %q: %w", input.Path, extractor.ErrMalformedInput)
+	}
+
+	inventory := &extractor.Inventory{
+		Name:    name,
+		Version: version,
+		Metadata: &Metadata{
+			CFBundleName:               p.CFBundleName,
+			CFBundleDisplayName:        p.CFBundleDisplayName,
+			CFBundleIdentifier:         p.CFBundleIdentifier,
+			CFBundleShortVersionString: p.CFBundleShortVersionString,
+			CFBundleVersion:            p.CFBundleVersion,
+			CFBundleExecutable:         p.CFBundleExecutable,
+		},
+		Locations: []string{input.Path},
+	}
+	return []*extractor.Inventory{inventory}, nil
+}
+
+// ToPURL converts an inventory created by this extractor into a PURL.
+func (e Extractor) ToPURL(i *extractor.Inventory) (*purl.PackageURL, error) {
+	return &purl.PackageURL{
+		Type:    purl.TypeMacApps,
+		Name:    i.Name,
+		Version: i.Version,
+	}, nil
+}
+
+// ToCPEs is not applicable as this extractor does not infer CPEs from the Inventory.
+func (e Extractor) ToCPEs(i *extractor.Inventory) ([]string, error) { return []string{}, nil }
+
+// Ecosystem returns no ecosystem since OSV does not support macOS apps yet.
+func (Extractor) Ecosystem(i *extractor.Inventory) (string, error) { return "", nil }
@@ -0,0 +1,260 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package macapps_test
+
+import (
+	"context"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem"
+	"github.com/google/osv-scalibr/extractor/filesystem/internal/units"
+	"github.com/google/osv-scalibr/extractor/filesystem/os/macapps"
+	"github.com/google/osv-scalibr/purl"
+	"github.com/google/osv-scalibr/stats"
+	"github.com/google/osv-scalibr/testing/fakefs"
+	"github.com/google/osv-scalibr/testing/testcollector"
+)
+
+func TestFileRequired(t *testing.T) {
+	tests := []struct {
+		name             string
+		path             string
+		fileSizeBytes    int64
+		maxFileSizeBytes int64
+		wantRequired     bool
+		wantResultMetric stats.FileRequiredResult
+	}{
+		{
+			name:             "app bundle Info.plist",
+			path:             "Applications/Google Chrome.app/Contents/Info.plist",
+			wantRequired:     true,
+			wantResultMetric: stats.FileRequiredResultOK,
+		}, {
+			name:             "different case",
+			path:             "Applications/Slack.APP/contents/info.plist",
+			wantRequired:     true,
+			wantResultMetric: stats.FileRequiredResultOK,
+		}, {
+			name:         "Info.plist outside of an app bundle",
+			path:         "Library/Frameworks/Foo.framework/Resources/Info.plist",
+			wantRequired: false,
+		}, {
+			name:         "other file in app bundle",
+			path:         "Applications/Slack.app/Contents/version.plist",
+			wantRequired: false,
+		}, {
+			name:         "Info.plist in app bundle subdirectory",
+			path:         "Applications/Slack.app/Contents/Resources/Info.plist",
+			wantRequired: false,
+		}, {
+			name:         "bundle without name",
+			path:         "Applications/.app/Contents/Info.plist",
+			wantRequired: false,
+		}, {
+			name:             "Info.plist required if file size < max file size",
+			path:             "Applications/Slack.app/Contents/Info.plist",
+			fileSizeBytes:    100 * units.KiB,
+			maxFileSizeBytes: 1000 * units.KiB,
+			wantRequired:     true,
+			wantResultMetric: stats.FileRequiredResultOK,
+		}, {
+			name:             "Info.plist not required if file size > max file size",
+			path:             "Applications/Slack.app/Contents/Info.plist",
+			fileSizeBytes:    1000 * units.KiB,
+			maxFileSizeBytes: 100 * units.KiB,
+			wantRequired:     false,
+			wantResultMetric: stats.FileRequiredResultSizeLimitExceeded,
+		}, {
+			name:             "Info.plist required if max file size set to 0",
+			path:             "Applications/Slack.app/Contents/Info.plist",
+			fileSizeBytes:    100 * units.KiB,
+			maxFileSizeBytes: 0,
+			wantRequired:     true,
+			wantResultMetric: stats.FileRequiredResultOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			collector := testcollector.New()
+			var e filesystem.Extractor = macapps.New(macapps.Config{
+				Stats:            collector,
+				MaxFileSizeBytes: tt.maxFileSizeBytes,
+			})
+
+			// Set a default file size if not specified.
+			fileSizeBytes := tt.fileSizeBytes
+			if fileSizeBytes == 0 {
+				fileSizeBytes = 1000
+			}
+
+			isRequired := e.FileRequired(tt.path, fakefs.FakeFileInfo{
+				FileName: filepath.Base(tt.path),
+				FileMode: fs.ModePerm,
+				FileSize: fileSizeBytes,
+			})
+			if isRequired != tt.wantRequired {
+				t.Fatalf("FileRequired(%s): got %v, want %v", tt.path, isRequired, tt.wantRequired)
+			}
+
+			gotResultMetric := collector.FileRequiredResult(tt.path)
+			if tt.wantResultMetric != "" && gotResultMetric != tt.wantResultMetric {
+				t.Errorf("FileRequired(%s) recorded result metric %v, want result metric %v", tt.path, gotResultMetric, tt.wantResultMetric)
+			}
+		})
+	}
+}
+
+func TestExtract(t *testing.T) {
+	tests := []struct {
+		name             string
+		path             string
+		wantInventory    []*extractor.Inventory
+		wantErr          error
+		wantResultMetric stats.FileExtractedResult
+	}{
+		{
+			name: "XML plist",
+			path: "testdata/xml.plist",
+			wantInventory: []*extractor.Inventory{
+				&extractor.Inventory{
+					Name:      "Chrome",
+					Version:   "124.0.6367.91",
+					Locations: []string{"testdata/xml.plist"},
+					Metadata: &macapps.Metadata{
+						CFBundleName:               "Chrome",
+						CFBundleDisplayName:        "Google Chrome",
+						CFBundleIdentifier:         "com.google.Chrome",
+						CFBundleShortVersionString: "124.0.6367.91",
+						CFBundleVersion:            "6367.91",
+						CFBundleExecutable:         "Google Chrome",
+					},
+				},
+			},
+			wantResultMetric: stats.FileExtractedResultSuccess,
+		},
+		{
+			name: "binary plist",
+			path: "testdata/binary.plist",
+			wantInventory: []*extractor.Inventory{
+				&extractor.Inventory{
+					Name:      "Slack",
+					Version:   "4.38.125",
+					Locations: []string{"testdata/binary.plist"},
+					Metadata: &macapps.Metadata{
+						CFBundleName:               "Slack",
+						CFBundleIdentifier:         "com.tinyspeck.slackmacgap",
+						CFBundleShortVersionString: "4.38.125",
+						CFBundleVersion:            "438125",
+						CFBundleExecutable:         "Slack",
+					},
+				},
+			},
+			wantResultMetric: stats.FileExtractedResultSuccess,
+		},
+		{
+			name: "fall back to executable name and bundle version",
+			path: "testdata/no-short-version.plist",
+			wantInventory: []*extractor.Inventory{
+				&extractor.Inventory{
+					Name:      "Helper",
+					Version:   "42",
+					Locations: []string{"testdata/no-short-version.plist"},
+					Metadata: &macapps.Metadata{
+						CFBundleIdentifier: "com.example.Helper",
+						CFBundleVersion:    "42",
+						CFBundleExecutable: "Helper",
+					},
+				},
+			},
+			wantResultMetric: stats.FileExtractedResultSuccess,
+		},
+		{
+			name:             "missing version",
+			path:             "testdata/missing-version.plist",
+			wantErr:          extractor.ErrMalformedInput,
+			wantResultMetric: stats.FileExtractedResultErrorMalformedInput,
+		},
+		{
+			name:             "invalid",
+			path:             "testdata/invalid.plist",
+			wantErr:          extractor.ErrMalformedInput,
+			wantResultMetric: stats.FileExtractedResultErrorMalformedInput,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			collector := testcollector.New()
+			var e filesystem.Extractor = macapps.New(macapps.Config{
+				Stats: collector,
+			})
+
+			r, err := os.Open(tt.path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer r.Close()
+
+			info, err := os.Stat(tt.path)
+			if err != nil {
+				t.Fatalf("Failed to stat test file: %v", err)
+			}
+
+			input := &filesystem.ScanInput{Path: tt.path, Reader: r, Info: info}
+			got, err := e.Extract(context.Background(), input)
+			if !cmp.Equal(err, tt.wantErr, cmpopts.EquateErrors()) {
+				t.Fatalf("Extract(%+v) error: got %v, want %v\n", tt.path, err, tt.wantErr)
+			}
+
+			if diff := cmp.Diff(tt.wantInventory, got); diff != "" {
+				t.Errorf("Extract(%s) (-want +got):\n%s", tt.path, diff)
+			}
+
+			gotResultMetric := collector.FileExtractedResult(tt.path)
+			if tt.wantResultMetric != "" && gotResultMetric != tt.wantResultMetric {
+				t.Errorf("Extract(%s) recorded result metric %v, want result metric %v", tt.path, gotResultMetric, tt.wantResultMetric)
+			}
+		})
+	}
+}
+
+func TestToPURL(t *testing.T) {
+	e := macapps.Extractor{}
+	i := &extractor.Inventory{
+		Name:      "Slack",
+		Version:   "4.38.125",
+		Metadata:  &macapps.Metadata{CFBundleName: "Slack"},
+		Locations: []string{"location"},
+	}
+	want := &purl.PackageURL{
+		Type:    purl.TypeMacApps,
+		Name:    "Slack",
+		Version: "4.38.125",
+	}
+	got, err := e.ToPURL(i)
+	if err != nil {
+		t.Fatalf("ToPURL(%v): %v", i, err)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ToPURL(%v) (-want +got):\n%s", i, diff)
+	}
+}
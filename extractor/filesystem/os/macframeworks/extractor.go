@@ -0,0 +1,252 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package macframeworks extracts the frameworks embedded in macOS and iOS
+// application and framework bundles.
+package macframeworks
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem"
+	"github.com/google/osv-scalibr/extractor/filesystem/internal/units"
+	"github.com/google/osv-scalibr/extractor/filesystem/os/internal/infoplist"
+	"github.com/google/osv-scalibr/plugin"
+	"github.com/google/osv-scalibr/purl"
+	"github.com/google/osv-scalibr/stats"
+)
+
+const (
+	// Name is the unique name of this extractor.
+	Name = "os/macframeworks"
+
+	// defaultMaxFileSizeBytes is the maximum file size an extractor will unmarshal.
+	// If Extract gets a bigger file, it will return an error.
+	defaultMaxFileSizeBytes = 1 * units.MiB
+
+	frameworkSuffix = ".framework"
+)
+
+// Config is the configuration for the Extractor.
+type Config struct {
+	// Stats is a stats collector for reporting metrics.
+	Stats stats.Collector
+	// MaxFileSizeBytes is the maximum file size this extractor will unmarshal. If
+	// `FileRequired` gets a bigger file, it will return false,
+	MaxFileSizeBytes int64
+}
+
+// DefaultConfig returns the default configuration for the extractor.
+func DefaultConfig() Config {
+	return Config{
+		MaxFileSizeBytes: defaultMaxFileSizeBytes,
+		Stats:            nil,
+	}
+}
+
+// Extractor extracts embedded frameworks from their Info.plist.
+type Extractor struct {
+	stats            stats.Collector
+	maxFileSizeBytes int64
+}
+
+// New returns an embedded framework extractor.
+//
+// For most use cases, initialize with:
+// ```
+// e := New(DefaultConfig())
+// ```
+func New(cfg Config) *Extractor {
+	return &Extractor{
+		stats:            cfg.Stats,
+		maxFileSizeBytes: cfg.MaxFileSizeBytes,
+	}
+}
+
+// Config returns the configuration of the extractor.
+func (e Extractor) Config() Config {
+	return Config{
+		Stats:            e.stats,
+		MaxFileSizeBytes: e.maxFileSizeBytes,
+	}
+}
+
+// Name of the extractor.
+func (e Extractor) Name() string { return Name }
+
+// Version of the extractor.
+func (e Extractor) Version() int { return 0 }
+
+// Requirements of the extractor.
+func (e Extractor) Requirements() *plugin.Capabilities {
+	return &plugin.Capabilities{OS: plugin.OSMac}
+}
+
+// RequiredFileNames returns the name of the framework info files the extractor requires.
+func (e Extractor) RequiredFileNames() []string { return []string{"Info.plist"} }
+
+// FileRequired returns true if the specified file is the Info.plist of a
+// framework embedded in an .app or .framework bundle.
+func (e Extractor) FileRequired(path string, fileinfo fs.FileInfo) bool {
+	if _, _, ok := parsePath(filepath.ToSlash(path)); !ok {
+		return false
+	}
+
+	if e.maxFileSizeBytes > 0 && fileinfo.Size() > e.maxFileSizeBytes {
+		e.reportFileRequired(path, fileinfo.Size(), stats.FileRequiredResultSizeLimitExceeded)
+		return false
+	}
+
+	e.reportFileRequired(path, fileinfo.Size(), stats.FileRequiredResultOK)
+	return true
+}
+
+// parsePath returns the paths of the framework and of the bundle that embeds
+// it if p is the Info.plist of an embedded framework. iOS bundles are flat,
+// e.g. "App.app/Frameworks/Lib.framework/Info.plist", while macOS bundles
+// keep their content in versioned subdirectories, e.g.
+// "App.app/Contents/Frameworks/Lib.framework/Versions/A/Resources/Info.plist".
+// Frameworks outside of bundles, such as the system frameworks in
+// /System/Library/Frameworks, aren't matched.
+func parsePath(p string) (framework string, bundle string, ok bool) {
+	// macOS file systems are case-insensitive by default.
+	dir, file := path.Split(p)
+	if !strings.EqualFold(file, "Info.plist") {
+		return "", "", false
+	}
+	framework = path.Clean(dir)
+	if strings.EqualFold(path.Base(framework), "Resources") {
+		framework = trimVersion(path.Dir(framework))
+	}
+	name := path.Base(framework)
+	if len(name) <= len(frameworkSuffix) || !strings.EqualFold(name[len(name)-len(frameworkSuffix):], frameworkSuffix) {
+		return "", "", false
+	}
+
+	frameworks := path.Dir(framework)
+	if !strings.EqualFold(path.Base(frameworks), "Frameworks") {
+		return "", "", false
+	}
+	bundle = trimVersion(path.Dir(frameworks))
+	if strings.EqualFold(path.Base(bundle), "Contents") {
+		bundle = path.Dir(bundle)
+	}
+	ext := strings.ToLower(path.Ext(bundle))
+	if (ext != ".app" && ext != frameworkSuffix) || path.Base(bundle) == ext {
+		return "", "", false
+	}
+	return framework, bundle, true
+}
+
+// trimVersion returns the path of the framework that contains the version
+// directory p, e.g. "Lib.framework" for "Lib.framework/Versions/A", or p if
+// it's not a version directory.
+func trimVersion(p string) string {
+	versions := path.Dir(p)
+	if !strings.EqualFold(path.Base(versions), "Versions") {
+		return p
+	}
+	return path.Dir(versions)
+}
+
+func (e Extractor) reportFileRequired(path string, fileSizeBytes int64, result stats.FileRequiredResult) {
+	if e.stats == nil {
+		return
+	}
+	e.stats.AfterFileRequired(e.Name(), &stats.FileRequiredStats{
+		Path:          path,
+		Result:        result,
+		FileSizeBytes: fileSizeBytes,
+	})
+}
+
+// Extract extracts the framework's name and version from the Info.plist
+// passed through the scan input.
+func (e Extractor) Extract(ctx context.Context, input *filesystem.ScanInput) ([]*extractor.Inventory, error) {
+	inventory, err := e.extractFromInput(input)
+	if e.stats != nil {
+		var fileSizeBytes int64
+		if input.Info != nil {
+			fileSizeBytes = input.Info.Size()
+		}
+		e.stats.AfterFileExtracted(e.Name(), &stats.FileExtractedStats{
+			Path:          input.Path,
+			Result:        filesystem.ExtractorErrorToFileExtractedResult(err),
+			FileSizeBytes: fileSizeBytes,
+		})
+	}
+	return inventory, err
+}
+
+func (e Extractor) extractFromInput(input *filesystem.ScanInput) ([]*extractor.Inventory, error) {
+	framework, bundle, ok := parsePath(filepath.ToSlash(input.Path))
+	if !ok {
+		return nil, fmt.Errorf("%q is not the Info.plist of an embedded framework", input.Path)
+	}
+
+	p, err := infoplist.Decode(input.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to plist decode %q: %w", input.Path, err)
+	}
+
+	// Frameworks are loaded by the name of their directory, so it's a
+	// reasonable fallback for the rarely missing bundle name.
+	name := p.CFBundleName
+	if name == "" {
+		name = strings.TrimSuffix(path.Base(framework), path.Ext(framework))
+	}
+	version := p.Version()
+	if version == "" {
+		return nil, fmt.Errorf("missing bundle version from %q: %w", input.Path, extractor.ErrMalformedInput)
+	}
+
+	inventory := &extractor.Inventory{
+		Name:    name,
+		Version: version,
+		Metadata: &Metadata{
+			CFBundleName:               p.CFBundleName,
+			CFBundleIdentifier:         p.CFBundleIdentifier,
+			CFBundleShortVersionString: p.CFBundleShortVersionString,
+			CFBundleVersion:            p.CFBundleVersion,
+			CFBundleExecutable:         p.CFBundleExecutable,
+			BundlePath:                 bundle,
+		},
+		Locations: []string{input.Path},
+	}
+	return []*extractor.Inventory{inventory}, nil
+}
+
+// ToPURL converts an inventory created by this extractor into a generic PURL.
+// Frameworks are distributed through various package managers (CocoaPods,
+// Carthage, Swift packages) or vendored directly, so the package manager
+// can't be inferred from the bundle.
+func (e Extractor) ToPURL(i *extractor.Inventory) (*purl.PackageURL, error) {
+	return &purl.PackageURL{
+		Type:    purl.TypeGeneric,
+		Name:    i.Name,
+		Version: i.Version,
+	}, nil
+}
+
+// ToCPEs is not applicable as this extractor does not infer CPEs from the Inventory.
+func (e Extractor) ToCPEs(i *extractor.Inventory) ([]string, error) { return []string{}, nil }
+
+// Ecosystem returns no ecosystem since OSV does not support embedded frameworks.
+func (Extractor) Ecosystem(i *extractor.Inventory) (string, error) { return "", nil }
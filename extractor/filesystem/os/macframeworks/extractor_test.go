@@ -0,0 +1,259 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package macframeworks_test
+
+import (
+	"context"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem"
+	"github.com/google/osv-scalibr/extractor/filesystem/internal/units"
+	"github.com/google/osv-scalibr/extractor/filesystem/os/macframeworks"
+	"github.com/google/osv-scalibr/purl"
+	"github.com/google/osv-scalibr/stats"
+	"github.com/google/osv-scalibr/testing/fakefs"
+	"github.com/google/osv-scalibr/testing/testcollector"
+)
+
+func TestFileRequired(t *testing.T) {
+	tests := []struct {
+		name             string
+		path             string
+		fileSizeBytes    int64
+		maxFileSizeBytes int64
+		wantRequired     bool
+		wantResultMetric stats.FileRequiredResult
+	}{
+		{
+			name:             "framework in iOS app",
+			path:             "Payload/Weather.app/Frameworks/Lottie.framework/Info.plist",
+			wantRequired:     true,
+			wantResultMetric: stats.FileRequiredResultOK,
+		}, {
+			name:             "framework in macOS app",
+			path:             "Applications/Notes.app/Contents/Frameworks/Sparkle.framework/Versions/B/Resources/Info.plist",
+			wantRequired:     true,
+			wantResultMetric: stats.FileRequiredResultOK,
+		}, {
+			name:             "framework in macOS framework",
+			path:             "Applications/Notes.app/Contents/Frameworks/Sparkle.framework/Versions/B/Frameworks/Updater.framework/Versions/A/Resources/Info.plist",
+			wantRequired:     true,
+			wantResultMetric: stats.FileRequiredResultOK,
+		}, {
+			name:             "framework in iOS framework",
+			path:             "Payload/Weather.app/Frameworks/Lottie.framework/Frameworks/Core.framework/Info.plist",
+			wantRequired:     true,
+			wantResultMetric: stats.FileRequiredResultOK,
+		}, {
+			name:             "different case",
+			path:             "Applications/Notes.APP/contents/frameworks/Sparkle.FRAMEWORK/versions/b/resources/info.plist",
+			wantRequired:     true,
+			wantResultMetric: stats.FileRequiredResultOK,
+		}, {
+			name:         "Info.plist of the app",
+			path:         "Applications/Notes.app/Contents/Info.plist",
+			wantRequired: false,
+		}, {
+			name:         "system framework",
+			path:         "System/Library/Frameworks/AppKit.framework/Versions/C/Resources/Info.plist",
+			wantRequired: false,
+		}, {
+			name:         "framework outside of Frameworks directory",
+			path:         "Applications/Notes.app/Contents/PlugIns/Sparkle.framework/Info.plist",
+			wantRequired: false,
+		}, {
+			name:         "other file in framework",
+			path:         "Payload/Weather.app/Frameworks/Lottie.framework/version.plist",
+			wantRequired: false,
+		}, {
+			name:         "framework without name",
+			path:         "Payload/Weather.app/Frameworks/.framework/Info.plist",
+			wantRequired: false,
+		}, {
+			name:         "app without name",
+			path:         "Payload/.app/Frameworks/Lottie.framework/Info.plist",
+			wantRequired: false,
+		}, {
+			name:             "Info.plist required if file size < max file size",
+			path:             "Payload/Weather.app/Frameworks/Lottie.framework/Info.plist",
+			fileSizeBytes:    100 * units.KiB,
+			maxFileSizeBytes: 1000 * units.KiB,
+			wantRequired:     true,
+			wantResultMetric: stats.FileRequiredResultOK,
+		}, {
+			name:             "Info.plist not required if file size > max file size",
+			path:             "Payload/Weather.app/Frameworks/Lottie.framework/Info.plist",
+			fileSizeBytes:    1000 * units.KiB,
+			maxFileSizeBytes: 100 * units.KiB,
+			wantRequired:     false,
+			wantResultMetric: stats.FileRequiredResultSizeLimitExceeded,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			collector := testcollector.New()
+			var e filesystem.Extractor = macframeworks.New(macframeworks.Config{
+				Stats:            collector,
+				MaxFileSizeBytes: tt.maxFileSizeBytes,
+			})
+
+			// Set a default file size if not specified.
+			fileSizeBytes := tt.fileSizeBytes
+			if fileSizeBytes == 0 {
+				fileSizeBytes = 1000
+			}
+
+			isRequired := e.FileRequired(tt.path, fakefs.FakeFileInfo{
+				FileName: filepath.Base(tt.path),
+				FileMode: fs.ModePerm,
+				FileSize: fileSizeBytes,
+			})
+			if isRequired != tt.wantRequired {
+				t.Fatalf("FileRequired(%s): got %v, want %v", tt.path, isRequired, tt.wantRequired)
+			}
+
+			gotResultMetric := collector.FileRequiredResult(tt.path)
+			if tt.wantResultMetric != "" && gotResultMetric != tt.wantResultMetric {
+				t.Errorf("FileRequired(%s) recorded result metric %v, want result metric %v", tt.path, gotResultMetric, tt.wantResultMetric)
+			}
+		})
+	}
+}
+
+func TestExtract(t *testing.T) {
+	tests := []struct {
+		name             string
+		path             string
+		wantInventory    []*extractor.Inventory
+		wantErr          error
+		wantResultMetric stats.FileExtractedResult
+	}{
+		{
+			name: "XML plist in iOS app",
+			path: "testdata/Weather.app/Frameworks/Lottie.framework/Info.plist",
+			wantInventory: []*extractor.Inventory{
+				&extractor.Inventory{
+					Name:      "Lottie",
+					Version:   "4.4.3",
+					Locations: []string{"testdata/Weather.app/Frameworks/Lottie.framework/Info.plist"},
+					Metadata: &macframeworks.Metadata{
+						CFBundleName:               "Lottie",
+						CFBundleIdentifier:         "com.airbnb.Lottie",
+						CFBundleShortVersionString: "4.4.3",
+						CFBundleVersion:            "1",
+						CFBundleExecutable:         "Lottie",
+						BundlePath:                 "testdata/Weather.app",
+					},
+				},
+			},
+			wantResultMetric: stats.FileExtractedResultSuccess,
+		},
+		{
+			name: "binary plist in macOS app without bundle name",
+			path: "testdata/Notes.app/Contents/Frameworks/Sparkle.framework/Versions/B/Resources/Info.plist",
+			wantInventory: []*extractor.Inventory{
+				&extractor.Inventory{
+					Name:      "Sparkle",
+					Version:   "2.6.4",
+					Locations: []string{"testdata/Notes.app/Contents/Frameworks/Sparkle.framework/Versions/B/Resources/Info.plist"},
+					Metadata: &macframeworks.Metadata{
+						CFBundleIdentifier:         "org.sparkle-project.Sparkle",
+						CFBundleShortVersionString: "2.6.4",
+						CFBundleVersion:            "2035",
+						CFBundleExecutable:         "Sparkle",
+						BundlePath:                 "testdata/Notes.app",
+					},
+				},
+			},
+			wantResultMetric: stats.FileExtractedResultSuccess,
+		},
+		{
+			name:             "missing version",
+			path:             "testdata/Unversioned.app/Frameworks/Unversioned.framework/Info.plist",
+			wantErr:          extractor.ErrMalformedInput,
+			wantResultMetric: stats.FileExtractedResultErrorMalformedInput,
+		},
+		{
+			name:             "invalid",
+			path:             "testdata/Broken.app/Frameworks/Broken.framework/Info.plist",
+			wantErr:          extractor.ErrMalformedInput,
+			wantResultMetric: stats.FileExtractedResultErrorMalformedInput,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			collector := testcollector.New()
+			var e filesystem.Extractor = macframeworks.New(macframeworks.Config{
+				Stats: collector,
+			})
+
+			r, err := os.Open(tt.path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer r.Close()
+
+			info, err := os.Stat(tt.path)
+			if err != nil {
+				t.Fatalf("Failed to stat test file: %v", err)
+			}
+
+			input := &filesystem.ScanInput{Path: tt.path, Reader: r, Info: info}
+			got, err := e.Extract(context.Background(), input)
+			if !cmp.Equal(err, tt.wantErr, cmpopts.EquateErrors()) {
+				t.Fatalf("Extract(%+v) error: got %v, want %v\n", tt.path, err, tt.wantErr)
+			}
+
+			if diff := cmp.Diff(tt.wantInventory, got); diff != "" {
+				t.Errorf("Extract(%s) (-want +got):\n%s", tt.path, diff)
+			}
+
+			gotResultMetric := collector.FileExtractedResult(tt.path)
+			if tt.wantResultMetric != "" && gotResultMetric != tt.wantResultMetric {
+				t.Errorf("Extract(%s) recorded result metric %v, want result metric %v", tt.path, gotResultMetric, tt.wantResultMetric)
+			}
+		})
+	}
+}
+
+func TestToPURL(t *testing.T) {
+	e := macframeworks.Extractor{}
+	i := &extractor.Inventory{
+		Name:      "Lottie",
+		Version:   "4.4.3",
+		Metadata:  &macframeworks.Metadata{CFBundleIdentifier: "com.airbnb.Lottie"},
+		Locations: []string{"location"},
+	}
+	want := &purl.PackageURL{
+		Type:    purl.TypeGeneric,
+		Name:    "Lottie",
+		Version: "4.4.3",
+	}
+	got, err := e.ToPURL(i)
+	if err != nil {
+		t.Fatalf("ToPURL(%v): %v", i, err)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ToPURL(%v) (-want +got):\n%s", i, diff)
+	}
+}
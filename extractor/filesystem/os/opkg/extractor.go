@@ -0,0 +1,258 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package opkg extracts packages from the opkg database of OpenWrt and other
+// embedded Linux distributions.
+package opkg
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"path/filepath"
+	"strings"
+
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem"
+	"github.com/google/osv-scalibr/extractor/filesystem/internal/units"
+	"github.com/google/osv-scalibr/extractor/filesystem/os/internal/controlfile"
+	"github.com/google/osv-scalibr/log"
+	"github.com/google/osv-scalibr/plugin"
+	"github.com/google/osv-scalibr/purl"
+	"github.com/google/osv-scalibr/stats"
+)
+
+const (
+	// Name is the unique name of this extractor.
+	Name = "os/opkg"
+
+	// defaultMaxFileSizeBytes is the maximum file size an extractor will unmarshal.
+	// If Extract gets a bigger file, it will return an error.
+	defaultMaxFileSizeBytes = 100 * units.MiB
+)
+
+// The locations of the opkg status file. OpenWrt uses the first one, other
+// distributions the opkg default.
+var statusFiles = []string{"usr/lib/opkg/status", "var/lib/opkg/status"}
+
+// Config is the configuration for the Extractor.
+type Config struct {
+	// Stats is a stats collector for reporting metrics.
+	Stats stats.Collector
+	// MaxFileSizeBytes is the maximum file size this extractor will unmarshal. If
+	// `FileRequired` gets a bigger file, it will return false,
+	MaxFileSizeBytes int64
+}
+
+// DefaultConfig returns the default configuration for the opkg extractor.
+func DefaultConfig() Config {
+	return Config{
+		MaxFileSizeBytes: defaultMaxFileSizeBytes,
+	}
+}
+
+// Extractor extracts packages from opkg status files.
+type Extractor struct {
+	stats            stats.Collector
+	maxFileSizeBytes int64
+}
+
+// New returns an opkg extractor.
+//
+// For most use cases, initialize with:
+// ```
+// e := New(DefaultConfig())
+// ```
+func New(cfg Config) *Extractor {
+	return &Extractor{
+		stats:            cfg.Stats,
+		maxFileSizeBytes: cfg.MaxFileSizeBytes,
+	}
+}
+
+// Config returns the configuration of the extractor.
+func (e Extractor) Config() Config {
+	return Config{
+		Stats:            e.stats,
+		MaxFileSizeBytes: e.maxFileSizeBytes,
+	}
+}
+
+// Name of the extractor.
+func (e Extractor) Name() string { return Name }
+
+// Version of the extractor.
+func (e Extractor) Version() int { return 0 }
+
+// Requirements of the extractor.
+func (e Extractor) Requirements() *plugin.Capabilities { return &plugin.Capabilities{} }
+
+// RequiredFileNames returns the name of the opkg status file the extractor requires.
+func (e Extractor) RequiredFileNames() []string { return []string{"status"} }
+
+// FileRequired returns true if the specified file is an opkg status file.
+// The per-package control files in the info/ directory aren't required since
+// the status file contains the same packages.
+func (e Extractor) FileRequired(path string, fileinfo fs.FileInfo) bool {
+	normalized := filepath.ToSlash(path)
+	found := false
+	for _, f := range statusFiles {
+		if normalized == f {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return false
+	}
+
+	if e.maxFileSizeBytes > 0 && fileinfo.Size() > e.maxFileSizeBytes {
+		e.reportFileRequired(path, fileinfo.Size(), stats.FileRequiredResultSizeLimitExceeded)
+		return false
+	}
+
+	e.reportFileRequired(path, fileinfo.Size(), stats.FileRequiredResultOK)
+	return true
+}
+
+func (e Extractor) reportFileRequired(path string, fileSizeBytes int64, result stats.FileRequiredResult) {
+	if e.stats == nil {
+		return
+	}
+	e.stats.AfterFileRequired(e.Name(), &stats.FileRequiredStats{
+		Path:          path,
+		Result:        result,
+		FileSizeBytes: fileSizeBytes,
+	})
+}
+
+// Extract extracts packages from opkg status files passed through the scan input.
+func (e Extractor) Extract(ctx context.Context, input *filesystem.ScanInput) ([]*extractor.Inventory, error) {
+	inventory, err := e.extractFromInput(ctx, input)
+	if e.stats != nil {
+		var fileSizeBytes int64
+		if input.Info != nil {
+			fileSizeBytes = input.Info.Size()
+		}
+		e.stats.AfterFileExtracted(e.Name(), &stats.FileExtractedStats{
+			Path:          input.Path,
+			Result:        filesystem.ExtractorErrorToFileExtractedResult(err),
+			FileSizeBytes: fileSizeBytes,
+		})
+	}
+	return inventory, err
+}
+
+func (e Extractor) extractFromInput(ctx context.Context, input *filesystem.ScanInput) ([]*extractor.Inventory, error) {
+	m, err := input.GetOSRelease()
+	if err != nil {
+		log.Errorf("GetOSRelease(): %v", err)
+	}
+
+	rd := controlfile.NewReader(input.Reader)
+	pkgs := []*extractor.Inventory{}
+	for {
+		// Return if canceled or exceeding deadline.
+		if err := ctx.Err(); err != nil {
+			return pkgs, fmt.Errorf("%s halted at %q because of context error: %v", e.Name(), input.Path, err)
+		}
+
+		h, err := rd.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return pkgs, fmt.Errorf("failed to parse %q: %w: %w", input.Path, extractor.ErrMalformedInput, err)
+		}
+
+		pkgName := h.Get("Package")
+		pkgVersion := h.Get("Version")
+		if pkgName == "" || pkgVersion == "" {
+			log.Warnf("opkg package name or version is empty (name: %q, version: %q)", pkgName, pkgVersion)
+			continue
+		}
+		status := h.Get("Status")
+		if status == "" {
+			log.Warnf("Package %q has no status field", pkgName)
+			continue
+		}
+		installed, err := controlfile.StatusInstalled(status)
+		if err != nil {
+			return pkgs, fmt.Errorf("failed to parse %q: %w: %w", input.Path, extractor.ErrMalformedInput, err)
+		}
+		if !installed {
+			continue
+		}
+
+		pkgs = append(pkgs, &extractor.Inventory{
+			Name:    pkgName,
+			Version: pkgVersion,
+			Metadata: &Metadata{
+				PackageName:       pkgName,
+				PackageVersion:    pkgVersion,
+				Status:            status,
+				Architecture:      h.Get("Architecture"),
+				AutoInstalled:     strings.EqualFold(h.Get("Auto-Installed"), "yes"),
+				OSID:              m["ID"],
+				OSVersionCodename: m["VERSION_CODENAME"],
+				OSVersionID:       m["VERSION_ID"],
+			},
+			Locations: []string{input.Path},
+		})
+	}
+	return pkgs, nil
+}
+
+func toNamespace(m *Metadata) string {
+	if m.OSID != "" {
+		return m.OSID
+	}
+	log.Errorf("os-release[ID] not set, fallback to 'linux'")
+	return "linux"
+}
+
+func toDistro(m *Metadata) string {
+	// e.g. 23.05.3, OpenWrt doesn't set a codename.
+	if m.OSVersionID != "" {
+		return m.OSVersionID
+	}
+	return m.OSVersionCodename
+}
+
+// ToPURL converts an inventory created by this extractor into a PURL.
+func (e Extractor) ToPURL(i *extractor.Inventory) (*purl.PackageURL, error) {
+	m := i.Metadata.(*Metadata)
+	q := map[string]string{}
+	if distro := toDistro(m); distro != "" {
+		q[purl.Distro] = distro
+	}
+	if m.Architecture != "" {
+		q[purl.Arch] = m.Architecture
+	}
+	return &purl.PackageURL{
+		Type:       purl.TypeOpkg,
+		Namespace:  toNamespace(m),
+		Name:       m.PackageName,
+		Version:    i.Version,
+		Qualifiers: purl.QualifiersFromMap(q),
+	}, nil
+}
+
+// ToCPEs is not applicable as this extractor does not infer CPEs from the Inventory.
+func (e Extractor) ToCPEs(i *extractor.Inventory) ([]string, error) { return []string{}, nil }
+
+// Ecosystem returns no ecosystem since OSV does not support OpenWrt packages yet.
+func (Extractor) Ecosystem(i *extractor.Inventory) (string, error) { return "", nil }
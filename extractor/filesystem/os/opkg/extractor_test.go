@@ -0,0 +1,251 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opkg_test
+
+import (
+	"context"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem"
+	"github.com/google/osv-scalibr/extractor/filesystem/internal/units"
+	"github.com/google/osv-scalibr/extractor/filesystem/os/opkg"
+	scalibrfs "github.com/google/osv-scalibr/fs"
+	"github.com/google/osv-scalibr/purl"
+	"github.com/google/osv-scalibr/stats"
+	"github.com/google/osv-scalibr/testing/fakefs"
+	"github.com/google/osv-scalibr/testing/testcollector"
+)
+
+func TestFileRequired(t *testing.T) {
+	tests := []struct {
+		name             string
+		path             string
+		fileSizeBytes    int64
+		maxFileSizeBytes int64
+		wantRequired     bool
+		wantResultMetric stats.FileRequiredResult
+	}{
+		{
+			name:             "OpenWrt status file",
+			path:             "usr/lib/opkg/status",
+			wantRequired:     true,
+			wantResultMetric: stats.FileRequiredResultOK,
+		},
+		{
+			name:             "default status file",
+			path:             "var/lib/opkg/status",
+			wantRequired:     true,
+			wantResultMetric: stats.FileRequiredResultOK,
+		},
+		{
+			name:         "control file",
+			path:         "usr/lib/opkg/info/busybox.control",
+			wantRequired: false,
+		},
+		{
+			name:         "dpkg status file",
+			path:         "var/lib/dpkg/status",
+			wantRequired: false,
+		},
+		{
+			name:         "status file in subdirectory",
+			path:         "rootfs/usr/lib/opkg/status",
+			wantRequired: false,
+		},
+		{
+			name:             "status file not required if file size > max file size",
+			path:             "usr/lib/opkg/status",
+			fileSizeBytes:    1000 * units.KiB,
+			maxFileSizeBytes: 100 * units.KiB,
+			wantRequired:     false,
+			wantResultMetric: stats.FileRequiredResultSizeLimitExceeded,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			collector := testcollector.New()
+			var e filesystem.Extractor = opkg.New(opkg.Config{
+				Stats:            collector,
+				MaxFileSizeBytes: test.maxFileSizeBytes,
+			})
+
+			// Set default size if not provided.
+			fileSizeBytes := test.fileSizeBytes
+			if fileSizeBytes == 0 {
+				fileSizeBytes = 100 * units.KiB
+			}
+
+			isRequired := e.FileRequired(test.path, fakefs.FakeFileInfo{
+				FileName: filepath.Base(test.path),
+				FileMode: fs.ModePerm,
+				FileSize: fileSizeBytes,
+			})
+			if isRequired != test.wantRequired {
+				t.Fatalf("FileRequired(%s): got %v, want %v", test.path, isRequired, test.wantRequired)
+			}
+
+			gotResultMetric := collector.FileRequiredResult(test.path)
+			if gotResultMetric != test.wantResultMetric {
+				t.Errorf("FileRequired(%s) recorded result metric %v, want result metric %v", test.path, gotResultMetric, test.wantResultMetric)
+			}
+		})
+	}
+}
+
+func TestExtract(t *testing.T) {
+	openWrt := map[string]string{"ID": "openwrt", "VERSION_ID": "23.05.3"}
+	pkg := func(path, name, version, status, arch string, auto bool) *extractor.Inventory {
+		return &extractor.Inventory{
+			Name:    name,
+			Version: version,
+			Metadata: &opkg.Metadata{
+				PackageName:    name,
+				PackageVersion: version,
+				Status:         status,
+				Architecture:   arch,
+				AutoInstalled:  auto,
+				OSID:           "openwrt",
+				OSVersionID:    "23.05.3",
+			},
+			Locations: []string{path},
+		}
+	}
+	const (
+		okInstalled   = "install ok installed"
+		userInstalled = "install user installed"
+	)
+	tests := []struct {
+		name             string
+		path             string
+		wantInventory    []*extractor.Inventory
+		wantErr          error
+		wantResultMetric stats.FileExtractedResult
+	}{
+		{
+			name: "OpenWrt status file",
+			path: "testdata/status",
+			wantInventory: []*extractor.Inventory{
+				pkg("testdata/status", "libgcc1", "12.3.0-4", okInstalled, "mips_24kc", true),
+				pkg("testdata/status", "libc", "1.2.4-4", okInstalled, "mips_24kc", true),
+				pkg("testdata/status", "base-files", "1554-r23809-234f1a2efa", userInstalled, "mips_24kc", false),
+				pkg("testdata/status", "busybox", "1.36.1-1", userInstalled, "mips_24kc", false),
+				pkg("testdata/status", "dropbear", "2022.82-6", userInstalled, "mips_24kc", false),
+				pkg("testdata/status", "kernel", "5.15.150-1-d8ecb5ad2ad08ca2b5c41f1dd7b2d3c4", userInstalled, "mips_24kc", false),
+				pkg("testdata/status", "luci-app-firewall", "git-24.073.29889-61c1fa4", userInstalled, "all", false),
+			},
+			wantResultMetric: stats.FileExtractedResultSuccess,
+		},
+		{
+			name: "not installed packages are skipped",
+			path: "testdata/notinstalled",
+			wantInventory: []*extractor.Inventory{
+				pkg("testdata/notinstalled", "curl", "8.6.0-1", userInstalled, "mips_24kc", false),
+			},
+			wantResultMetric: stats.FileExtractedResultSuccess,
+		},
+		{
+			name:             "empty",
+			path:             "testdata/empty",
+			wantInventory:    []*extractor.Inventory{},
+			wantResultMetric: stats.FileExtractedResultSuccess,
+		},
+		{
+			name:             "invalid",
+			path:             "testdata/invalid",
+			wantInventory:    []*extractor.Inventory{},
+			wantErr:          extractor.ErrMalformedInput,
+			wantResultMetric: stats.FileExtractedResultErrorMalformedInput,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			collector := testcollector.New()
+			var e filesystem.Extractor = opkg.New(opkg.Config{Stats: collector})
+
+			r, err := os.Open(test.path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer r.Close()
+
+			info, err := os.Stat(test.path)
+			if err != nil {
+				t.Fatalf("Failed to stat test file: %v", err)
+			}
+
+			input := &filesystem.ScanInput{
+				FS:        scalibrfs.DirFS("."),
+				Path:      test.path,
+				Reader:    r,
+				Info:      info,
+				OSRelease: openWrt,
+			}
+			got, err := e.Extract(context.Background(), input)
+			if !cmp.Equal(err, test.wantErr, cmpopts.EquateErrors()) {
+				t.Fatalf("Extract(%s) error: got %v, want %v", test.path, err, test.wantErr)
+			}
+
+			if diff := cmp.Diff(test.wantInventory, got); diff != "" {
+				t.Errorf("Extract(%s) (-want +got):\n%s", test.path, diff)
+			}
+
+			gotResultMetric := collector.FileExtractedResult(test.path)
+			if gotResultMetric != test.wantResultMetric {
+				t.Errorf("Extract(%s) recorded result metric %v, want result metric %v", test.path, gotResultMetric, test.wantResultMetric)
+			}
+		})
+	}
+}
+
+func TestToPURL(t *testing.T) {
+	e := opkg.Extractor{}
+	i := &extractor.Inventory{
+		Name:    "busybox",
+		Version: "1.36.1-1",
+		Metadata: &opkg.Metadata{
+			PackageName:    "busybox",
+			PackageVersion: "1.36.1-1",
+			Architecture:   "mips_24kc",
+			OSID:           "openwrt",
+			OSVersionID:    "23.05.3",
+		},
+		Locations: []string{"usr/lib/opkg/status"},
+	}
+	want := &purl.PackageURL{
+		Type:      purl.TypeOpkg,
+		Namespace: "openwrt",
+		Name:      "busybox",
+		Version:   "1.36.1-1",
+		Qualifiers: purl.QualifiersFromMap(map[string]string{
+			purl.Distro: "23.05.3",
+			purl.Arch:   "mips_24kc",
+		}),
+	}
+	got, err := e.ToPURL(i)
+	if err != nil {
+		t.Fatalf("ToPURL(%v): %v", i, err)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ToPURL(%v) (-want +got):\n%s", i, diff)
+	}
+}
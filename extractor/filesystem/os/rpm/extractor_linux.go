@@ -31,7 +31,6 @@ import (
 	"golang.org/x/text/language"
 	"github.com/google/osv-scalibr/extractor"
 	"github.com/google/osv-scalibr/extractor/filesystem"
-	"github.com/google/osv-scalibr/extractor/filesystem/os/osrelease"
 	"github.com/google/osv-scalibr/log"
 	"github.com/google/osv-scalibr/plugin"
 	"github.com/google/osv-scalibr/purl"
@@ -113,6 +112,9 @@ func (e Extractor) Version() int { return 0 }
 // Requirements of the extractor.
 func (e Extractor) Requirements() *plugin.Capabilities { return &plugin.Capabilities{DirectFS: true} }
 
+// RequiredFileNames returns the names of the RPM databases the extractor requires.
+func (e Extractor) RequiredFileNames() []string { return requiredFilename }
+
 // FileRequired returns true if the specified file matches rpm status file pattern.
 func (e Extractor) FileRequired(path string, fileinfo fs.FileInfo) bool {
 	dir, filename := filepath.Split(filepath.ToSlash(path))
@@ -164,9 +166,9 @@ func (e Extractor) extractFromInput(ctx context.Context, input *filesystem.ScanI
 		return nil, fmt.Errorf("ParseRPMDB(%s): %w", absPath, err)
 	}
 
-	m, err := osrelease.GetOSRelease(input.FS)
+	m, err := input.GetOSRelease()
 	if err != nil {
-		log.Errorf("osrelease.ParseOsRelease(): %v", err)
+		log.Errorf("GetOSRelease(): %v", err)
 	}
 
 	pkgs := []*extractor.Inventory{}
@@ -0,0 +1,225 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package rpmfile extracts package metadata from .rpm package files on disk.
+package rpmfile
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem"
+	"github.com/google/osv-scalibr/extractor/filesystem/internal/units"
+	"github.com/google/osv-scalibr/extractor/filesystem/os/rpm"
+	"github.com/google/osv-scalibr/plugin"
+	"github.com/google/osv-scalibr/purl"
+	"github.com/google/osv-scalibr/stats"
+)
+
+const (
+	// Name is the unique name of this extractor.
+	Name = "os/rpmfile"
+
+	// defaultMaxFileSizeBytes is the maximum file size an extractor will unmarshal.
+	// If Extract gets a bigger file, it will return an error.
+	defaultMaxFileSizeBytes = 500 * units.MiB
+)
+
+// Config is the configuration for the Extractor.
+type Config struct {
+	// Stats is a stats collector for reporting metrics.
+	Stats stats.Collector
+	// MaxFileSizeBytes is the maximum file size this extractor will unmarshal. If
+	// `FileRequired` gets a bigger file, it will return false,
+	MaxFileSizeBytes int64
+}
+
+// DefaultConfig returns the default configuration for the .rpm file extractor.
+func DefaultConfig() Config {
+	return Config{
+		MaxFileSizeBytes: defaultMaxFileSizeBytes,
+	}
+}
+
+// Extractor extracts package metadata from .rpm files.
+type Extractor struct {
+	stats            stats.Collector
+	maxFileSizeBytes int64
+}
+
+// New returns a .rpm file extractor.
+//
+// For most use cases, initialize with:
+// ```
+// e := New(DefaultConfig())
+// ```
+func New(cfg Config) *Extractor {
+	return &Extractor{
+		stats:            cfg.Stats,
+		maxFileSizeBytes: cfg.MaxFileSizeBytes,
+	}
+}
+
+// Config returns the configuration of the extractor.
+func (e Extractor) Config() Config {
+	return Config{
+		Stats:            e.stats,
+		MaxFileSizeBytes: e.maxFileSizeBytes,
+	}
+}
+
+// Name of the extractor.
+func (e Extractor) Name() string { return Name }
+
+// Version of the extractor.
+func (e Extractor) Version() int { return 0 }
+
+// Requirements of the extractor.
+func (e Extractor) Requirements() *plugin.Capabilities { return &plugin.Capabilities{} }
+
+// RequiredFileExtensions returns the extension of the RPM packages the extractor requires.
+func (e Extractor) RequiredFileExtensions() []string { return []string{".rpm"} }
+
+// FileRequired returns true if the specified file has a .rpm extension. The
+// RPM lead magic bytes are checked during extraction.
+func (e Extractor) FileRequired(path string, fileinfo fs.FileInfo) bool {
+	return e.fileRequired(path, fileinfo, nil)
+}
+
+// FileRequiredWithFS returns true if the specified file has a .rpm extension
+// and starts with the RPM lead magic bytes.
+func (e Extractor) FileRequiredWithFS(path string, fileinfo fs.FileInfo, file *filesystem.PeekableFile) bool {
+	return e.fileRequired(path, fileinfo, file)
+}
+
+func (e Extractor) fileRequired(path string, fileinfo fs.FileInfo, file *filesystem.PeekableFile) bool {
+	if strings.ToLower(filepath.Ext(path)) != ".rpm" {
+		return false
+	}
+	if !fileinfo.Mode().IsRegular() {
+		return false
+	}
+
+	if e.maxFileSizeBytes > 0 && fileinfo.Size() > e.maxFileSizeBytes {
+		e.reportFileRequired(path, fileinfo.Size(), stats.FileRequiredResultSizeLimitExceeded)
+		return false
+	}
+
+	if file != nil {
+		if t, err := file.FileType(); err != nil || t != filesystem.FileTypeRPM {
+			return false
+		}
+	}
+
+	e.reportFileRequired(path, fileinfo.Size(), stats.FileRequiredResultOK)
+	return true
+}
+
+func (e Extractor) reportFileRequired(path string, fileSizeBytes int64, result stats.FileRequiredResult) {
+	if e.stats == nil {
+		return
+	}
+	e.stats.AfterFileRequired(e.Name(), &stats.FileRequiredStats{
+		Path:          path,
+		Result:        result,
+		FileSizeBytes: fileSizeBytes,
+	})
+}
+
+// Extract extracts the package described by the header of a .rpm file.
+func (e Extractor) Extract(ctx context.Context, input *filesystem.ScanInput) ([]*extractor.Inventory, error) {
+	inventory, err := e.extractFromInput(ctx, input)
+	if e.stats != nil {
+		var fileSizeBytes int64
+		if input.Info != nil {
+			fileSizeBytes = input.Info.Size()
+		}
+		e.stats.AfterFileExtracted(e.Name(), &stats.FileExtractedStats{
+			Path:          input.Path,
+			Result:        filesystem.ExtractorErrorToFileExtractedResult(err),
+			FileSizeBytes: fileSizeBytes,
+		})
+	}
+	return inventory, err
+}
+
+func (e Extractor) extractFromInput(ctx context.Context, input *filesystem.ScanInput) ([]*extractor.Inventory, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("%s halted at %q because of context error: %v", e.Name(), input.Path, err)
+	}
+
+	h, err := readHeader(input.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %q: %w", e.Name(), input.Path, err)
+	}
+
+	name := h.String(tagName)
+	version := h.String(tagVersion)
+	release := h.String(tagRelease)
+	if name == "" || version == "" {
+		return nil, fmt.Errorf("%s: header of %q lacks package name or version (name: %q, version: %q): %w", e.Name(), input.Path, name, version, extractor.ErrMalformedInput)
+	}
+	if release != "" {
+		version = fmt.Sprintf("%s-%s", version, release)
+	}
+
+	return []*extractor.Inventory{{
+		Name:    name,
+		Version: version,
+		Metadata: &rpm.Metadata{
+			PackageName:  name,
+			SourceRPM:    h.String(tagSourceRPM),
+			Epoch:        h.Int(tagEpoch),
+			Vendor:       h.String(tagVendor),
+			Architecture: h.String(tagArch),
+			License:      h.String(tagLicense),
+		},
+		Locations: []string{input.Path},
+	}}, nil
+}
+
+// ToPURL converts an inventory created by this extractor into a PURL.
+// Loose .rpm files carry no reliable information about the distribution they
+// were built for so the namespace is always "linux".
+func (e Extractor) ToPURL(i *extractor.Inventory) (*purl.PackageURL, error) {
+	m := i.Metadata.(*rpm.Metadata)
+	q := map[string]string{}
+	if m.Epoch > 0 {
+		q[purl.Epoch] = strconv.Itoa(m.Epoch)
+	}
+	if m.SourceRPM != "" {
+		q[purl.SourceRPM] = m.SourceRPM
+	}
+	if m.Architecture != "" {
+		q[purl.Arch] = m.Architecture
+	}
+	return &purl.PackageURL{
+		Type:       purl.TypeRPM,
+		Namespace:  "linux",
+		Name:       i.Name,
+		Version:    i.Version,
+		Qualifiers: purl.QualifiersFromMap(q),
+	}, nil
+}
+
+// ToCPEs is not applicable as this extractor does not infer CPEs from the Inventory.
+func (e Extractor) ToCPEs(i *extractor.Inventory) ([]string, error) { return []string{}, nil }
+
+// Ecosystem returns the OSV Ecosystem of the software extracted by this extractor.
+func (Extractor) Ecosystem(i *extractor.Inventory) (string, error) { return "Linux", nil }
@@ -0,0 +1,270 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rpmfile_test
+
+import (
+	"context"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem"
+	"github.com/google/osv-scalibr/extractor/filesystem/internal/units"
+	"github.com/google/osv-scalibr/extractor/filesystem/os/rpm"
+	"github.com/google/osv-scalibr/extractor/filesystem/os/rpmfile"
+	scalibrfs "github.com/google/osv-scalibr/fs"
+	"github.com/google/osv-scalibr/purl"
+	"github.com/google/osv-scalibr/stats"
+	"github.com/google/osv-scalibr/testing/fakefs"
+	"github.com/google/osv-scalibr/testing/testcollector"
+)
+
+func TestFileRequired(t *testing.T) {
+	tests := []struct {
+		name             string
+		path             string
+		fileSizeBytes    int64
+		maxFileSizeBytes int64
+		wantRequired     bool
+		wantResultMetric stats.FileRequiredResult
+	}{
+		{
+			name:             "rpm file",
+			path:             "tmp/hello-2.12.1-1.fc40.x86_64.rpm",
+			wantRequired:     true,
+			wantResultMetric: stats.FileRequiredResultOK,
+		},
+		{
+			name:             "uppercase extension",
+			path:             "tmp/HELLO.RPM",
+			wantRequired:     true,
+			wantResultMetric: stats.FileRequiredResultOK,
+		},
+		{
+			name:         "other extension",
+			path:         "tmp/hello.tar.gz",
+			wantRequired: false,
+		},
+		{
+			name:         "rpm suffix without extension",
+			path:         "tmp/hellorpm",
+			wantRequired: false,
+		},
+		{
+			name:             "file not required if file size > max file size",
+			path:             "tmp/hello.rpm",
+			fileSizeBytes:    1000 * units.KiB,
+			maxFileSizeBytes: 100 * units.KiB,
+			wantRequired:     false,
+			wantResultMetric: stats.FileRequiredResultSizeLimitExceeded,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			collector := testcollector.New()
+			var e filesystem.Extractor = rpmfile.New(rpmfile.Config{
+				Stats:            collector,
+				MaxFileSizeBytes: tt.maxFileSizeBytes,
+			})
+
+			// Set a default file size if not specified.
+			fileSizeBytes := tt.fileSizeBytes
+			if fileSizeBytes == 0 {
+				fileSizeBytes = 1000
+			}
+
+			isRequired := e.FileRequired(tt.path, fakefs.FakeFileInfo{
+				FileName: filepath.Base(tt.path),
+				FileMode: fs.ModePerm,
+				FileSize: fileSizeBytes,
+			})
+			if isRequired != tt.wantRequired {
+				t.Fatalf("FileRequired(%s): got %v, want %v", tt.path, isRequired, tt.wantRequired)
+			}
+
+			gotResultMetric := collector.FileRequiredResult(tt.path)
+			if tt.wantResultMetric != "" && gotResultMetric != tt.wantResultMetric {
+				t.Errorf("FileRequired(%s) recorded result metric %v, want result metric %v", tt.path, gotResultMetric, tt.wantResultMetric)
+			}
+		})
+	}
+}
+
+func TestFileRequiredWithFS(t *testing.T) {
+	tests := []struct {
+		name         string
+		path         string
+		wantRequired bool
+	}{
+		{
+			name:         "valid package",
+			path:         "hello-2.12.1-1.fc40.x86_64.rpm",
+			wantRequired: true,
+		}, {
+			name:         "wrong magic bytes",
+			path:         "notanrpm.rpm",
+			wantRequired: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := rpmfile.New(rpmfile.DefaultConfig())
+			fsys := scalibrfs.DirFS("testdata")
+			info, err := fs.Stat(fsys, tt.path)
+			if err != nil {
+				t.Fatalf("fs.Stat(%s): %v", tt.path, err)
+			}
+			file := filesystem.NewPeekableFile(fsys, tt.path)
+			defer file.Close()
+
+			if got := e.FileRequiredWithFS(tt.path, info, file); got != tt.wantRequired {
+				t.Errorf("FileRequiredWithFS(%s): got %v, want %v", tt.path, got, tt.wantRequired)
+			}
+		})
+	}
+}
+
+func TestExtract(t *testing.T) {
+	tests := []struct {
+		name             string
+		path             string
+		wantInventory    []*extractor.Inventory
+		wantErr          error
+		wantResultMetric stats.FileExtractedResult
+	}{
+		{
+			name: "valid rpm",
+			path: "testdata/hello-2.12.1-1.fc40.x86_64.rpm",
+			wantInventory: []*extractor.Inventory{
+				{
+					Name:    "hello",
+					Version: "2.12.1-1.fc40",
+					Metadata: &rpm.Metadata{
+						PackageName:  "hello",
+						SourceRPM:    "hello-2.12.1-1.fc40.src.rpm",
+						Vendor:       "Fedora Project",
+						Architecture: "x86_64",
+						License:      "GPL-3.0-or-later",
+					},
+					Locations: []string{"testdata/hello-2.12.1-1.fc40.x86_64.rpm"},
+				},
+			},
+			wantResultMetric: stats.FileExtractedResultSuccess,
+		},
+		{
+			name: "rpm with epoch",
+			path: "testdata/perl-Time-HiRes-1.9764-462.el9.x86_64.rpm",
+			wantInventory: []*extractor.Inventory{
+				{
+					Name:    "perl-Time-HiRes",
+					Version: "1.9764-462.el9",
+					Metadata: &rpm.Metadata{
+						PackageName:  "perl-Time-HiRes",
+						SourceRPM:    "perl-5.32.1-462.el9.src.rpm",
+						Epoch:        4,
+						Vendor:       "Rocky Enterprise Software Foundation",
+						Architecture: "x86_64",
+						License:      "GPL+ or Artistic",
+					},
+					Locations: []string{"testdata/perl-Time-HiRes-1.9764-462.el9.x86_64.rpm"},
+				},
+			},
+			wantResultMetric: stats.FileExtractedResultSuccess,
+		},
+		{
+			name:             "missing name",
+			path:             "testdata/noname.rpm",
+			wantErr:          extractor.ErrMalformedInput,
+			wantResultMetric: stats.FileExtractedResultErrorMalformedInput,
+		},
+		{
+			name:             "not an rpm",
+			path:             "testdata/notanrpm.rpm",
+			wantErr:          extractor.ErrMalformedInput,
+			wantResultMetric: stats.FileExtractedResultErrorMalformedInput,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			collector := testcollector.New()
+			cfg := rpmfile.DefaultConfig()
+			cfg.Stats = collector
+
+			r, err := os.Open(tt.path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer r.Close()
+			info, err := os.Stat(tt.path)
+			if err != nil {
+				t.Fatalf("Failed to stat test file: %v", err)
+			}
+
+			input := &filesystem.ScanInput{Path: tt.path, Reader: r, Info: info}
+			got, err := rpmfile.New(cfg).Extract(context.Background(), input)
+			if !cmp.Equal(err, tt.wantErr, cmpopts.EquateErrors()) {
+				t.Fatalf("Extract(%s) error: got %v, want %v", tt.path, err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.wantInventory, got); diff != "" {
+				t.Errorf("Extract(%s) (-want +got):\n%s", tt.path, diff)
+			}
+
+			gotResultMetric := collector.FileExtractedResult(tt.path)
+			if gotResultMetric != tt.wantResultMetric {
+				t.Errorf("Extract(%s) recorded result metric %v, want result metric %v", tt.path, gotResultMetric, tt.wantResultMetric)
+			}
+		})
+	}
+}
+
+func TestToPURL(t *testing.T) {
+	e := rpmfile.New(rpmfile.DefaultConfig())
+	i := &extractor.Inventory{
+		Name:    "perl-Time-HiRes",
+		Version: "1.9764-462.el9",
+		Metadata: &rpm.Metadata{
+			PackageName:  "perl-Time-HiRes",
+			SourceRPM:    "perl-5.32.1-462.el9.src.rpm",
+			Epoch:        4,
+			Architecture: "x86_64",
+		},
+		Locations: []string{"tmp/perl-Time-HiRes-1.9764-462.el9.x86_64.rpm"},
+	}
+	want := &purl.PackageURL{
+		Type:      purl.TypeRPM,
+		Namespace: "linux",
+		Name:      "perl-Time-HiRes",
+		Version:   "1.9764-462.el9",
+		Qualifiers: purl.QualifiersFromMap(map[string]string{
+			purl.Epoch:     "4",
+			purl.SourceRPM: "perl-5.32.1-462.el9.src.rpm",
+			purl.Arch:      "x86_64",
+		}),
+	}
+	got, err := e.ToPURL(i)
+	if err != nil {
+		t.Fatalf("ToPURL(%v): %v", i, err)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ToPURL(%v) (-want +got):\n%s", i, diff)
+	}
+}
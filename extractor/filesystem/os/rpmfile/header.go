@@ -0,0 +1,159 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rpmfile
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+
+	"github.com/google/osv-scalibr/extractor"
+)
+
+// RPM file layout: a 96 byte lead, followed by the signature header (padded to
+// a multiple of 8 bytes), followed by the main header and the payload. Only the
+// main header is parsed.
+// See https://rpm-software-management.github.io/rpm/manual/format.html
+const (
+	leadSize = 96
+
+	// maxIndexEntries and maxStoreSize bound the header allocation for
+	// corrupt or malicious files.
+	maxIndexEntries = 1 << 16
+	maxStoreSize    = 64 << 20
+
+	typeInt32       = 4
+	typeString      = 6
+	typeStringArray = 8
+	typeI18NString  = 9
+
+	tagName      = 1000
+	tagVersion   = 1001
+	tagRelease   = 1002
+	tagEpoch     = 1003
+	tagVendor    = 1011
+	tagLicense   = 1014
+	tagArch      = 1022
+	tagSourceRPM = 1044
+)
+
+var (
+	leadMagic   = []byte{0xed, 0xab, 0xee, 0xdb}
+	headerMagic = []byte{0x8e, 0xad, 0xe8, 0x01}
+
+	// errNotRPM is returned for files that don't carry the RPM lead magic.
+	errNotRPM = fmt.Errorf("not an .rpm file: %w", extractor.ErrMalformedInput)
+)
+
+type indexEntry struct {
+	Tag    int32
+	Type   uint32
+	Offset int32
+	Count  uint32
+}
+
+// header is a parsed RPM header structure.
+type header struct {
+	entries map[int32]indexEntry
+	store   []byte
+}
+
+// readHeader parses the main header of the RPM file read from r.
+func readHeader(r io.Reader) (*header, error) {
+	br := bufio.NewReader(r)
+	lead := make([]byte, leadSize)
+	if _, err := io.ReadFull(br, lead); err != nil || !bytes.Equal(lead[:4], leadMagic) {
+		return nil, errNotRPM
+	}
+
+	_, size, err := readHeaderStructure(br)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read signature header: %w", err)
+	}
+	if pad := (8 - size%8) % 8; pad > 0 {
+		if _, err := br.Discard(pad); err != nil {
+			return nil, fmt.Errorf("failed to skip signature padding: %w", err)
+		}
+	}
+
+	h, _, err := readHeaderStructure(br)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read main header: %w", err)
+	}
+	return h, nil
+}
+
+// readHeaderStructure reads a single header structure and returns it along
+// with its size in bytes.
+func readHeaderStructure(r io.Reader) (*header, int, error) {
+	intro := make([]byte, 16)
+	if _, err := io.ReadFull(r, intro); err != nil {
+		return nil, 0, err
+	}
+	if !bytes.Equal(intro[:4], headerMagic) {
+		return nil, 0, fmt.Errorf("invalid header magic: %w", extractor.ErrMalformedInput)
+	}
+	nindex := binary.BigEndian.Uint32(intro[8:12])
+	hsize := binary.BigEndian.Uint32(intro[12:16])
+	if nindex > maxIndexEntries || hsize > maxStoreSize {
+		return nil, 0, fmt.Errorf("header too large (%d entries, %d bytes)", nindex, hsize)
+	}
+
+	index := make([]indexEntry, nindex)
+	if err := binary.Read(r, binary.BigEndian, index); err != nil {
+		return nil, 0, fmt.Errorf("failed to read index: %w", err)
+	}
+	store := make([]byte, hsize)
+	if _, err := io.ReadFull(r, store); err != nil {
+		return nil, 0, fmt.Errorf("failed to read store: %w", err)
+	}
+
+	h := &header{entries: make(map[int32]indexEntry, nindex), store: store}
+	for _, e := range index {
+		h.entries[e.Tag] = e
+	}
+	return h, len(intro) + int(nindex)*16 + int(hsize), nil
+}
+
+// String returns the value of a string tag, or the first value of a string
+// array tag. Returns an empty string if the tag is missing or malformed.
+func (h *header) String(tag int32) string {
+	e, ok := h.entries[tag]
+	if !ok || e.Offset < 0 || int(e.Offset) >= len(h.store) {
+		return ""
+	}
+	switch e.Type {
+	case typeString, typeStringArray, typeI18NString:
+	default:
+		return ""
+	}
+	data := h.store[e.Offset:]
+	if end := bytes.IndexByte(data, 0); end >= 0 {
+		data = data[:end]
+	}
+	return string(data)
+}
+
+// Int returns the first value of an int32 tag, or 0 if the tag is missing or
+// malformed.
+func (h *header) Int(tag int32) int {
+	e, ok := h.entries[tag]
+	if !ok || e.Type != typeInt32 || e.Offset < 0 || int(e.Offset)+4 > len(h.store) {
+		return 0
+	}
+	return int(int32(binary.BigEndian.Uint32(h.store[e.Offset:])))
+}
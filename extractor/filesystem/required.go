@@ -0,0 +1,119 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filesystem
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+
+	scalibrfs "github.com/google/osv-scalibr/fs"
+)
+
+// ErrFileSkipped is returned by ExtractorsRequiring for files that the
+// filesystem walk doesn't pass to any extractor, e.g. directories.
+var ErrFileSkipped = errors.New("file is skipped by the filesystem walk")
+
+// ExtractorsRequiring returns the extractors of config.Extractors whose
+// FileRequired returns true for the file at path, in their configured order.
+// FileRequired is called with the same path and file info the filesystem walk
+// of Run uses, which makes this useful for debugging why a file isn't
+// extracted. path is resolved like config.FilesToExtract, i.e. relative to the
+// scan root for virtual scan roots. Only config.Extractors, config.ScanRoots
+// and config.ReadSymlinks are used.
+func ExtractorsRequiring(config *Config, path string) ([]Extractor, error) {
+	scanRoots, err := expandAllAbsolutePaths(config.ScanRoots)
+	if err != nil {
+		return nil, err
+	}
+	root, rel, err := scanRootOf(path, scanRoots)
+	if err != nil {
+		return nil, err
+	}
+	if rel == "." {
+		return nil, fmt.Errorf("%s is the scan root: %w", path, ErrFileSkipped)
+	}
+
+	// The walk decides whether to visit a file based on its directory entry,
+	// which describes symlinks instead of their targets.
+	d, err := dirEntry(root.FS, rel)
+	if err != nil {
+		return nil, err
+	}
+	if d.IsDir() {
+		return nil, fmt.Errorf("%s is a directory: %w", path, ErrFileSkipped)
+	}
+	if !d.Type().IsRegular() {
+		if (d.Type() & fs.ModeType) != fs.ModeSymlink {
+			return nil, fmt.Errorf("%s is not a regular file: %w", path, ErrFileSkipped)
+		}
+		if !config.ReadSymlinks {
+			return nil, fmt.Errorf("%s is a symlink and reading symlinks is disabled: %w", path, ErrFileSkipped)
+		}
+	}
+	fileinfo, err := fs.Stat(root.FS, rel)
+	if err != nil {
+		return nil, err
+	}
+
+	file := NewPeekableFile(root.FS, rel)
+	defer file.Close()
+	var result []Extractor
+	for _, ex := range config.Extractors {
+		if fileRequired(ex, rel, fileinfo, file) {
+			result = append(result, ex)
+		}
+	}
+	return result, nil
+}
+
+// scanRootOf returns the scan root that contains path and the slash-separated
+// path relative to it.
+func scanRootOf(path string, scanRoots []*scalibrfs.ScanRoot) (*scalibrfs.ScanRoot, string, error) {
+	if len(scanRoots) == 0 {
+		return nil, "", ErrNotRelativeToScanRoots
+	}
+	if scanRoots[0].IsVirtual() {
+		return scanRoots[0], filepath.ToSlash(filepath.Clean(path)), nil
+	}
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return nil, "", err
+	}
+	for _, r := range scanRoots {
+		rel, err := stripFromAtLeastOnePrefix(abs, []*scalibrfs.ScanRoot{r})
+		if err != nil {
+			continue
+		}
+		return r, filepath.ToSlash(rel), nil
+	}
+	return nil, "", ErrNotRelativeToScanRoots
+}
+
+// dirEntry returns the entry of the file at path in its parent directory.
+func dirEntry(fsys scalibrfs.FS, path string) (fs.DirEntry, error) {
+	entries, err := fsys.ReadDir(parentDir(path))
+	if err != nil {
+		return nil, err
+	}
+	name := filepath.Base(path)
+	for _, e := range entries {
+		if e.Name() == name {
+			return e, nil
+		}
+	}
+	return nil, &fs.PathError{Op: "stat", Path: path, Err: fs.ErrNotExist}
+}
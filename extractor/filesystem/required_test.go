@@ -0,0 +1,129 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filesystem_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/osv-scalibr/extractor/filesystem"
+	scalibrfs "github.com/google/osv-scalibr/fs"
+	fe "github.com/google/osv-scalibr/testing/fakeextractor"
+)
+
+func TestExtractorsRequiring(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "sub"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "sub", "a.txt"), []byte("content"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(filepath.Join(dir, "sub", "a.txt"), filepath.Join(dir, "link.txt")); err != nil {
+		t.Skipf("os.Symlink(): %v", err)
+	}
+	extractors := []filesystem.Extractor{
+		fe.New("ex1", 1, []string{"sub/a.txt", "link.txt"}, nil),
+		fe.New("ex2", 1, []string{"other.txt"}, nil),
+		fe.New("ex3", 1, []string{"sub/a.txt"}, nil),
+	}
+
+	testCases := []struct {
+		desc         string
+		path         string
+		readSymlinks bool
+		want         []string
+		wantErr      error
+	}{
+		{
+			desc: "regular_file",
+			path: filepath.Join(dir, "sub", "a.txt"),
+			want: []string{"ex1", "ex3"},
+		},
+		{
+			desc:    "symlinks_disabled",
+			path:    filepath.Join(dir, "link.txt"),
+			wantErr: filesystem.ErrFileSkipped,
+		},
+		{
+			desc:         "symlinks_enabled",
+			path:         filepath.Join(dir, "link.txt"),
+			readSymlinks: true,
+			want:         []string{"ex1"},
+		},
+		{
+			desc:    "directory",
+			path:    filepath.Join(dir, "sub"),
+			wantErr: filesystem.ErrFileSkipped,
+		},
+		{
+			desc:    "missing_file",
+			path:    filepath.Join(dir, "missing.txt"),
+			wantErr: os.ErrNotExist,
+		},
+		{
+			desc:    "outside_scan_root",
+			path:    filepath.Dir(dir),
+			wantErr: filesystem.ErrNotRelativeToScanRoots,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			config := &filesystem.Config{
+				Extractors:   extractors,
+				ScanRoots:    []*scalibrfs.ScanRoot{scalibrfs.RealFSScanRoot(dir)},
+				ReadSymlinks: tc.readSymlinks,
+			}
+			got, err := filesystem.ExtractorsRequiring(config, tc.path)
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("filesystem.ExtractorsRequiring(%s): got error %v, want %v", tc.path, err, tc.wantErr)
+			}
+			if diff := cmp.Diff(tc.want, names(got)); diff != "" {
+				t.Errorf("filesystem.ExtractorsRequiring(%s) unexpected diff (-want +got):\n%s", tc.path, diff)
+			}
+		})
+	}
+}
+
+func TestExtractorsRequiring_VirtualScanRoot(t *testing.T) {
+	fsys := fstest.MapFS{"sub/a.txt": {Data: []byte("content")}}
+	config := &filesystem.Config{
+		Extractors: []filesystem.Extractor{
+			fe.New("ex1", 1, []string{"sub/a.txt"}, nil),
+			fe.New("ex2", 1, []string{"other.txt"}, nil),
+		},
+		ScanRoots: []*scalibrfs.ScanRoot{&scalibrfs.ScanRoot{FS: scalibrfs.FromFS(fsys)}},
+	}
+	got, err := filesystem.ExtractorsRequiring(config, "sub/a.txt")
+	if err != nil {
+		t.Fatalf("filesystem.ExtractorsRequiring(): %v", err)
+	}
+	if diff := cmp.Diff([]string{"ex1"}, names(got)); diff != "" {
+		t.Errorf("filesystem.ExtractorsRequiring() unexpected diff (-want +got):\n%s", diff)
+	}
+}
+
+func names(extractors []filesystem.Extractor) []string {
+	var result []string
+	for _, ex := range extractors {
+		result = append(result, ex.Name())
+	}
+	return result
+}
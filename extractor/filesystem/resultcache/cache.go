@@ -0,0 +1,223 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package resultcache provides an on-disk filesystem.ResultCache.
+//
+// Entries are gob-encoded files named after the hash of their key. The
+// inventory metadata is stored with its concrete type, which gob only decodes
+// once the type has been registered in the current process. The types are
+// registered when inventory with them is first stored, so until an extractor
+// has stored results in a process, its entries are treated as misses and
+// overwritten with the results of the Extract call.
+package resultcache
+
+import (
+	"bytes"
+	"encoding/gob"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem"
+	"github.com/google/osv-scalibr/log"
+)
+
+const (
+	entrySuffix = ".gob"
+	tempPattern = "tmp-*"
+)
+
+// Cache is a filesystem.ResultCache that stores the entries in a directory.
+// Several processes can share the directory: Entries are written atomically,
+// but the size limit is only enforced for the entries a process knows of.
+type Cache struct {
+	dir          string
+	maxSizeBytes int64
+
+	mu sync.Mutex
+	// The total size of the entries in the directory.
+	sizeBytes int64
+}
+
+// Open returns a cache that stores its entries in dir, which is created if it
+// doesn't exist. Once the entries exceed maxSizeBytes, the least recently
+// used ones are removed. If maxSizeBytes is 0, the size isn't limited.
+func Open(dir string, maxSizeBytes int64) (*Cache, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	c := &Cache{dir: dir, maxSizeBytes: maxSizeBytes}
+	entries, err := c.entries()
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range entries {
+		c.sizeBytes += e.size
+	}
+	return c, nil
+}
+
+// entry is the encoded form of a filesystem.ResultCacheEntry.
+type entry struct {
+	Path      string
+	Inventory []*extractor.Inventory
+}
+
+// Get returns the entry stored for the key.
+func (c *Cache) Get(key *filesystem.ResultCacheKey) (*filesystem.ResultCacheEntry, bool) {
+	path := c.path(key)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, false
+	}
+	var e entry
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&e); err != nil {
+		log.Debugf("Result cache: failed to decode %s: %v", path, err)
+		return nil, false
+	}
+	// Mark the entry as recently used.
+	now := time.Now()
+	if err := os.Chtimes(path, now, now); err != nil {
+		log.Debugf("Result cache: %v", err)
+	}
+	return &filesystem.ResultCacheEntry{Path: e.Path, Inventory: e.Inventory}, true
+}
+
+// Put stores the entry for the key.
+func (c *Cache) Put(key *filesystem.ResultCacheKey, e *filesystem.ResultCacheEntry) error {
+	for _, inv := range e.Inventory {
+		if inv.Metadata != nil {
+			if err := register(inv.Metadata); err != nil {
+				return err
+			}
+		}
+	}
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(&entry{Path: e.Path, Inventory: e.Inventory}); err != nil {
+		return err
+	}
+
+	path := c.path(key)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), tempPattern)
+	if err != nil {
+		return err
+	}
+	_, err = tmp.Write(buf.Bytes())
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var oldSize int64
+	if info, err := os.Stat(path); err == nil {
+		oldSize = info.Size()
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	c.sizeBytes += int64(buf.Len()) - oldSize
+	if c.maxSizeBytes > 0 && c.sizeBytes > c.maxSizeBytes {
+		c.evict()
+	}
+	return nil
+}
+
+// register registers the concrete type of the metadata with gob so that it
+// can be encoded in the interface field of the inventory.
+func register(metadata any) (err error) {
+	defer func() {
+		// Register panics if another type was registered under the same name.
+		if r := recover(); r != nil {
+			err = fmt.Errorf("can't register metadata type %T: %v", metadata, r)
+		}
+	}()
+	gob.Register(metadata)
+	return nil
+}
+
+// path returns the path of the entry for the key. The entries are spread
+// over subdirectories named after the first two characters of their hash.
+func (c *Cache) path(key *filesystem.ResultCacheKey) string {
+	h := key.Hash()
+	return filepath.Join(c.dir, h[:2], h+entrySuffix)
+}
+
+type entryInfo struct {
+	path    string
+	size    int64
+	modTime time.Time
+}
+
+// entries returns the entries in the cache directory.
+func (c *Cache) entries() ([]entryInfo, error) {
+	var res []entryInfo
+	err := filepath.WalkDir(c.dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || !strings.HasSuffix(path, entrySuffix) {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			// Removed in the meantime.
+			return nil
+		}
+		res = append(res, entryInfo{path: path, size: info.Size(), modTime: info.ModTime()})
+		return nil
+	})
+	return res, err
+}
+
+// evict removes the least recently used entries until the cache is within
+// its size limit. Needs to be called with c.mu held.
+func (c *Cache) evict() {
+	entries, err := c.entries()
+	if err != nil {
+		log.Warnf("Result cache: failed to list entries: %v", err)
+		return
+	}
+	slices.SortFunc(entries, func(a, b entryInfo) int { return a.modTime.Compare(b.modTime) })
+	c.sizeBytes = 0
+	for _, e := range entries {
+		c.sizeBytes += e.size
+	}
+	for _, e := range entries {
+		if c.sizeBytes <= c.maxSizeBytes {
+			break
+		}
+		if err := os.Remove(e.path); err != nil && !os.IsNotExist(err) {
+			log.Warnf("Result cache: %v", err)
+			continue
+		}
+		c.sizeBytes -= e.size
+	}
+}
+
+var _ filesystem.ResultCache = &Cache{}
@@ -0,0 +1,135 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resultcache_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem"
+	"github.com/google/osv-scalibr/extractor/filesystem/resultcache"
+)
+
+type testMetadata struct {
+	Checksum string
+}
+
+func key(content string) *filesystem.ResultCacheKey {
+	return &filesystem.ResultCacheKey{
+		FileSHA256:       content,
+		ExtractorName:    "ex",
+		ExtractorVersion: 1,
+		OSRelease:        map[string]string{"ID": "debian"},
+	}
+}
+
+func TestPutGet(t *testing.T) {
+	c, err := resultcache.Open(t.TempDir(), 0)
+	if err != nil {
+		t.Fatalf("resultcache.Open(): %v", err)
+	}
+	want := &filesystem.ResultCacheEntry{
+		Path: "app/package-lock.json",
+		Inventory: []*extractor.Inventory{{
+			Name:            "left-pad",
+			Version:         "1.3.0",
+			Locations:       []string{"app/package-lock.json"},
+			LocationDetails: []*extractor.Location{{Path: "app/package-lock.json", Line: 3}},
+			Metadata:        &testMetadata{Checksum: "sha512-abc"},
+		}},
+	}
+	if err := c.Put(key("1"), want); err != nil {
+		t.Fatalf("Put(): %v", err)
+	}
+
+	got, ok := c.Get(key("1"))
+	if !ok {
+		t.Fatalf("Get(): no entry, want %v", want)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Get(): unexpected entry (-want +got):\n%s", diff)
+	}
+
+	for _, k := range []*filesystem.ResultCacheKey{
+		key("2"),
+		{FileSHA256: "1", ExtractorName: "ex", ExtractorVersion: 2, OSRelease: map[string]string{"ID": "debian"}},
+		{FileSHA256: "1", ExtractorName: "ex", ExtractorVersion: 1},
+	} {
+		if got, ok := c.Get(k); ok {
+			t.Errorf("Get(%+v): got %v, want no entry", k, got)
+		}
+	}
+}
+
+func TestEviction(t *testing.T) {
+	dir := t.TempDir()
+	entry := &filesystem.ResultCacheEntry{
+		Path:      "go.mod",
+		Inventory: []*extractor.Inventory{{Name: "golang.org/x/mod", Version: "0.17.0"}},
+	}
+	// Measure the size of an entry to set the limit to two entries.
+	c, err := resultcache.Open(dir, 0)
+	if err != nil {
+		t.Fatalf("resultcache.Open(): %v", err)
+	}
+	if err := c.Put(key("size"), entry); err != nil {
+		t.Fatalf("Put(): %v", err)
+	}
+	matches, err := filepath.Glob(filepath.Join(dir, "*", "*.gob"))
+	if err != nil || len(matches) != 1 {
+		t.Fatalf("filepath.Glob(): %v, %v, want 1 entry", matches, err)
+	}
+	info, err := os.Stat(matches[0])
+	if err != nil {
+		t.Fatalf("os.Stat(): %v", err)
+	}
+	if err := os.Remove(matches[0]); err != nil {
+		t.Fatalf("os.Remove(): %v", err)
+	}
+
+	c, err = resultcache.Open(dir, 2*info.Size())
+	if err != nil {
+		t.Fatalf("resultcache.Open(): %v", err)
+	}
+	// Give the entries distinct modification times.
+	start := time.Now().Add(-time.Hour)
+	for i, k := range []string{"1", "2"} {
+		if err := c.Put(key(k), entry); err != nil {
+			t.Fatalf("Put(%s): %v", k, err)
+		}
+		matches, _ := filepath.Glob(filepath.Join(dir, "*", key(k).Hash()+".gob"))
+		mtime := start.Add(time.Duration(i) * time.Minute)
+		if err := os.Chtimes(matches[0], mtime, mtime); err != nil {
+			t.Fatalf("os.Chtimes(): %v", err)
+		}
+	}
+	// Reading the oldest entry marks it as recently used.
+	if _, ok := c.Get(key("1")); !ok {
+		t.Fatalf("Get(1): no entry")
+	}
+	if err := c.Put(key("3"), entry); err != nil {
+		t.Fatalf("Put(3): %v", err)
+	}
+
+	for k, want := range map[string]bool{"1": true, "2": false, "3": true} {
+		if _, ok := c.Get(key(k)); ok != want {
+			t.Errorf("Get(%s): got entry %t, want %t", k, ok, want)
+		}
+	}
+}
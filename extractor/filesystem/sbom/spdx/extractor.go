@@ -58,6 +58,11 @@ var extensionHandlers = map[string]extractFunc{
 	// No support for .xsl files because those are too ambiguous and could be many other things.
 }
 
+// RequiredFileExtensions returns the last extensions of the SPDX files the extractor requires.
+func (e Extractor) RequiredFileExtensions() []string {
+	return []string{".json", ".spdx", ".yml", ".rdf"}
+}
+
 // FileRequired returns true if the specified file is a supported spdx file.
 func (e Extractor) FileRequired(path string, _ fs.FileInfo) bool {
 	_, isSupported := findExtractor(path)
@@ -0,0 +1,159 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filesystem
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/fs"
+	"sync"
+	"time"
+
+	scalibrfs "github.com/google/osv-scalibr/fs"
+)
+
+// openCostBytes is the number of bytes each file open is charged against the
+// read rate limit, roughly one filesystem block. This paces the walk even if
+// the extractors only read small parts of the files.
+const openCostBytes = 4096
+
+// rateLimiter is a token bucket limiting the number of bytes read per second.
+// The bucket holds up to one second worth of bytes, so short bursts aren't
+// slowed down. It's safe for concurrent use.
+type rateLimiter struct {
+	bytesPerSecond int64
+
+	mu sync.Mutex
+	// The time at which the bytes read so far are paid off. Reads that would
+	// move it further than the burst into the future wait.
+	paidUntil time.Time
+	// Replaced in tests.
+	now func() time.Time
+}
+
+func newRateLimiter(bytesPerSecond int64) *rateLimiter {
+	return &rateLimiter{bytesPerSecond: bytesPerSecond, now: time.Now}
+}
+
+// reserve charges n bytes to the limiter and returns how long the caller has
+// to wait to stay below the rate limit.
+func (l *rateLimiter) reserve(n int) time.Duration {
+	if n <= 0 {
+		return 0
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := l.now()
+	// Unused bytes accumulate for at most one second.
+	if earliest := now.Add(-time.Second); l.paidUntil.Before(earliest) {
+		l.paidUntil = earliest
+	}
+	l.paidUntil = l.paidUntil.Add(time.Duration(float64(n) / float64(l.bytesPerSecond) * float64(time.Second)))
+	return l.paidUntil.Sub(now)
+}
+
+// wait charges n bytes to the limiter and blocks until they're within the
+// rate limit or the context is canceled.
+func (l *rateLimiter) wait(ctx context.Context, n int) error {
+	d := l.reserve(n)
+	if d <= 0 {
+		return nil
+	}
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
+
+// throttledFS rate-limits the file opens and reads of the wrapped filesystem.
+type throttledFS struct {
+	scalibrfs.FS
+	ctx     context.Context
+	limiter *rateLimiter
+}
+
+func newThrottledFS(ctx context.Context, fsys scalibrfs.FS, limiter *rateLimiter) scalibrfs.FS {
+	return &throttledFS{FS: fsys, ctx: ctx, limiter: limiter}
+}
+
+// Open opens the file after waiting for its open cost to be within the rate
+// limit. Files implementing io.ReaderAt keep doing so.
+func (t *throttledFS) Open(name string) (fs.File, error) {
+	if err := t.limiter.wait(t.ctx, openCostBytes); err != nil {
+		return nil, err
+	}
+	f, err := t.FS.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	tf := &throttledFile{File: f, ctx: t.ctx, limiter: t.limiter}
+	if _, ok := f.(io.ReaderAt); ok {
+		return &throttledReaderAtFile{throttledFile: tf}, nil
+	}
+	return tf, nil
+}
+
+// throttledFile rate-limits the reads of the wrapped file. The bytes are
+// charged after they're read, so a single large read isn't delayed but the
+// next one waits accordingly.
+type throttledFile struct {
+	fs.File
+	ctx     context.Context
+	limiter *rateLimiter
+}
+
+func (f *throttledFile) Read(p []byte) (int, error) {
+	n, err := f.File.Read(p)
+	if werr := f.limiter.wait(f.ctx, n); werr != nil && err == nil {
+		err = werr
+	}
+	return n, err
+}
+
+// Seek seeks the wrapped file if it implements io.Seeker.
+func (f *throttledFile) Seek(offset int64, whence int) (int64, error) {
+	s, ok := f.File.(io.Seeker)
+	if !ok {
+		return 0, errors.New("seek not supported")
+	}
+	return s.Seek(offset, whence)
+}
+
+// ReadDir lists the directory if the wrapped file is a directory.
+func (f *throttledFile) ReadDir(n int) ([]fs.DirEntry, error) {
+	d, ok := f.File.(fs.ReadDirFile)
+	if !ok {
+		return nil, errors.New("not a directory")
+	}
+	return d.ReadDir(n)
+}
+
+// throttledReaderAtFile is a throttledFile for files implementing io.ReaderAt.
+type throttledReaderAtFile struct {
+	*throttledFile
+}
+
+func (f *throttledReaderAtFile) ReadAt(p []byte, off int64) (int, error) {
+	n, err := f.File.(io.ReaderAt).ReadAt(p, off)
+	if werr := f.limiter.wait(f.ctx, n); werr != nil && err == nil {
+		err = werr
+	}
+	return n, err
+}
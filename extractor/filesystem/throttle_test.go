@@ -0,0 +1,46 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package filesystem
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRateLimiterReserve(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	now := start
+	l := newRateLimiter(1000)
+	l.now = func() time.Time { return now }
+
+	for _, step := range []struct {
+		desc    string
+		elapsed time.Duration
+		n       int
+		want    time.Duration
+	}{
+		{desc: "nothing read", n: 0, want: 0},
+		{desc: "burst", n: 1000, want: 0},
+		{desc: "beyond burst", n: 500, want: 500 * time.Millisecond},
+		{desc: "partially paid off", elapsed: 250 * time.Millisecond, n: 250, want: 500 * time.Millisecond},
+		{desc: "paid off", elapsed: 750 * time.Millisecond, n: 0, want: 0},
+		{desc: "refilled burst", elapsed: 2 * time.Second, n: 1000, want: 0},
+		{desc: "refill capped at one second", n: 1, want: time.Millisecond},
+	} {
+		now = now.Add(step.elapsed)
+		if got := l.reserve(step.n); got != step.want {
+			t.Errorf("%s: reserve(%d) at %v = %v, want %v", step.desc, step.n, now.Sub(start), got, step.want)
+		}
+	}
+}
@@ -18,6 +18,7 @@ package standalone
 import (
 	"context"
 	"path/filepath"
+	"sync"
 
 	"github.com/google/osv-scalibr/extractor"
 	scalibrfs "github.com/google/osv-scalibr/fs"
@@ -32,10 +33,19 @@ type Extractor interface {
 	Extract(ctx context.Context, input *ScanInput) ([]*extractor.Inventory, error)
 }
 
+// DefaultMaxConcurrency is the number of standalone extractors that run at the
+// same time if Config.MaxConcurrency isn't set.
+const DefaultMaxConcurrency = 4
+
 // Config for running standalone extractors.
 type Config struct {
 	Extractors []Extractor
 	ScanRoot   *scalibrfs.ScanRoot
+	// Optional: The maximum number of extractors that run at the same time.
+	// Defaults to DefaultMaxConcurrency. Set to 1 to run the extractors one
+	// after the other, e.g. on hosts where reading the registry or running
+	// commands in parallel is too expensive.
+	MaxConcurrency int
 }
 
 // ScanInput provides information for the extractor about the scan.
@@ -46,11 +56,12 @@ type ScanInput struct {
 	Root string
 }
 
-// Run the extractors that are specified in the config.
+// Run the extractors that are specified in the config, at most
+// config.MaxConcurrency of them at the same time. The results are returned in
+// the order of the extractors in the config. If the context is canceled, no
+// further extractors are started and the results of the ones that ran are
+// returned together with the context's error.
 func Run(ctx context.Context, config *Config) ([]*extractor.Inventory, []*plugin.Status, error) {
-	var inventories []*extractor.Inventory
-	var statuses []*plugin.Status
-
 	if !config.ScanRoot.IsVirtual() {
 		p, err := filepath.Abs(config.ScanRoot.Path)
 		if err != nil {
@@ -64,19 +75,66 @@ func Run(ctx context.Context, config *Config) ([]*extractor.Inventory, []*plugin
 		Root: config.ScanRoot.Path,
 	}
 
-	for _, extractor := range config.Extractors {
-		inv, err := extractor.Extract(ctx, scanInput)
-		if err != nil {
-			statuses = append(statuses, plugin.StatusFromErr(extractor, false, err))
-			continue
+	maxConcurrency := config.MaxConcurrency
+	if maxConcurrency <= 0 {
+		maxConcurrency = DefaultMaxConcurrency
+	}
+	results := make([]*result, len(config.Extractors))
+	sem := make(chan struct{}, maxConcurrency)
+	var wg sync.WaitGroup
+	var err error
+	for idx, ex := range config.Extractors {
+		if err = ctx.Err(); err != nil {
+			break
 		}
-		for _, i := range inv {
-			i.Extractor = extractor
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			err = ctx.Err()
 		}
+		if err != nil {
+			break
+		}
+		wg.Add(1)
+		go func() {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			results[idx] = runExtractor(ctx, ex, scanInput)
+		}()
+	}
+	wg.Wait()
 
-		inventories = append(inventories, inv...)
-		statuses = append(statuses, plugin.StatusFromErr(extractor, false, nil))
+	var inventories []*extractor.Inventory
+	var statuses []*plugin.Status
+	for _, r := range results {
+		if r == nil {
+			// The extractor wasn't started because the context was canceled.
+			continue
+		}
+		inventories = append(inventories, r.inventory...)
+		statuses = append(statuses, r.status)
 	}
+	return inventories, statuses, err
+}
 
-	return inventories, statuses, nil
+// result is the result of running a single standalone extractor.
+type result struct {
+	inventory []*extractor.Inventory
+	status    *plugin.Status
+}
+
+func runExtractor(ctx context.Context, ex Extractor, input *ScanInput) *result {
+	inv, err := ex.Extract(ctx, input)
+	if err != nil {
+		return &result{status: plugin.StatusFromErr(ex, false, err)}
+	}
+	for _, i := range inv {
+		i.Extractor = ex
+		i.Locations = i.LocationPaths()
+	}
+	status := plugin.StatusFromErr(ex, false, nil)
+	status.Status.InventoryCount = len(inv)
+	return &result{inventory: inv, status: status}
 }
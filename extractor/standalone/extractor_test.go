@@ -0,0 +1,204 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standalone_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/standalone"
+	scalibrfs "github.com/google/osv-scalibr/fs"
+	"github.com/google/osv-scalibr/plugin"
+	"github.com/google/osv-scalibr/purl"
+)
+
+// fakeExtractor returns an inventory item with its own name, or err. It
+// tracks the number of extractors running at the same time in running.
+type fakeExtractor struct {
+	name    string
+	err     error
+	delay   time.Duration
+	running *concurrencyTracker
+}
+
+func (e *fakeExtractor) Name() string                       { return e.name }
+func (e *fakeExtractor) Version() int                       { return 0 }
+func (e *fakeExtractor) Requirements() *plugin.Capabilities { return &plugin.Capabilities{} }
+func (e *fakeExtractor) ToPURL(i *extractor.Inventory) (*purl.PackageURL, error) {
+	return &purl.PackageURL{Type: purl.TypeGeneric, Name: i.Name}, nil
+}
+func (e *fakeExtractor) ToCPEs(i *extractor.Inventory) ([]string, error) { return nil, nil }
+func (e *fakeExtractor) Ecosystem(i *extractor.Inventory) (string, error) {
+	return "", nil
+}
+
+func (e *fakeExtractor) Extract(ctx context.Context, input *standalone.ScanInput) ([]*extractor.Inventory, error) {
+	if e.running != nil {
+		e.running.start()
+		defer e.running.stop()
+	}
+	select {
+	case <-time.After(e.delay):
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	if e.err != nil {
+		return nil, e.err
+	}
+	return []*extractor.Inventory{{Name: e.name}}, nil
+}
+
+// concurrencyTracker records the maximum number of concurrent calls.
+type concurrencyTracker struct {
+	mu      sync.Mutex
+	current int
+	max     int
+}
+
+func (c *concurrencyTracker) start() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.current++
+	c.max = max(c.max, c.current)
+}
+
+func (c *concurrencyTracker) stop() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.current--
+}
+
+func scanRoot(t *testing.T) *scalibrfs.ScanRoot {
+	t.Helper()
+	return scalibrfs.RealFSScanRoot(t.TempDir())
+}
+
+func TestRun(t *testing.T) {
+	errFailed := errors.New("failed")
+	extractors := []standalone.Extractor{
+		&fakeExtractor{name: "ex1", delay: 20 * time.Millisecond},
+		&fakeExtractor{name: "ex2", err: errFailed},
+		&fakeExtractor{name: "ex3"},
+	}
+	inv, statuses, err := standalone.Run(context.Background(), &standalone.Config{
+		Extractors: extractors,
+		ScanRoot:   scanRoot(t),
+	})
+	if err != nil {
+		t.Fatalf("standalone.Run(): %v", err)
+	}
+
+	// Results are reported in the order of the extractors, regardless of which
+	// extractor finished first.
+	var gotNames []string
+	for _, i := range inv {
+		gotNames = append(gotNames, i.Name)
+		if i.Extractor.Name() != i.Name {
+			t.Errorf("standalone.Run() inventory %q has extractor %q", i.Name, i.Extractor.Name())
+		}
+	}
+	if diff := cmp.Diff([]string{"ex1", "ex3"}, gotNames); diff != "" {
+		t.Errorf("standalone.Run() inventory names (-want +got):\n%s", diff)
+	}
+	wantStatuses := []*plugin.Status{
+		{Name: "ex1", Status: &plugin.ScanStatus{Status: plugin.ScanStatusSucceeded, InventoryCount: 1}},
+		{Name: "ex2", Status: &plugin.ScanStatus{Status: plugin.ScanStatusFailed, FailureReason: "failed"}},
+		{Name: "ex3", Status: &plugin.ScanStatus{Status: plugin.ScanStatusSucceeded, InventoryCount: 1}},
+	}
+	if diff := cmp.Diff(wantStatuses, statuses); diff != "" {
+		t.Errorf("standalone.Run() statuses (-want +got):\n%s", diff)
+	}
+}
+
+func TestRunMaxConcurrency(t *testing.T) {
+	for _, tc := range []struct {
+		maxConcurrency int
+		want           int
+	}{
+		{maxConcurrency: 1, want: 1},
+		{maxConcurrency: 3, want: 3},
+		{maxConcurrency: 0, want: standalone.DefaultMaxConcurrency},
+	} {
+		t.Run(fmt.Sprintf("max %d", tc.maxConcurrency), func(t *testing.T) {
+			tracker := &concurrencyTracker{}
+			var extractors []standalone.Extractor
+			for i := 0; i < 10; i++ {
+				extractors = append(extractors, &fakeExtractor{
+					name:    fmt.Sprintf("ex%d", i),
+					delay:   10 * time.Millisecond,
+					running: tracker,
+				})
+			}
+			inv, _, err := standalone.Run(context.Background(), &standalone.Config{
+				Extractors:     extractors,
+				ScanRoot:       scanRoot(t),
+				MaxConcurrency: tc.maxConcurrency,
+			})
+			if err != nil {
+				t.Fatalf("standalone.Run(): %v", err)
+			}
+			if len(inv) != len(extractors) {
+				t.Errorf("standalone.Run() returned %d inventory items, want %d", len(inv), len(extractors))
+			}
+			if tracker.max > tc.want {
+				t.Errorf("standalone.Run() ran %d extractors at the same time, want at most %d", tracker.max, tc.want)
+			}
+		})
+	}
+}
+
+func TestRunCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	var started atomic.Int32
+	extractors := []standalone.Extractor{
+		&cancelingExtractor{cancel: cancel, started: &started},
+		&cancelingExtractor{cancel: cancel, started: &started},
+		&cancelingExtractor{cancel: cancel, started: &started},
+	}
+	_, statuses, err := standalone.Run(ctx, &standalone.Config{
+		Extractors:     extractors,
+		ScanRoot:       scanRoot(t),
+		MaxConcurrency: 1,
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("standalone.Run() error: got %v, want %v", err, context.Canceled)
+	}
+	if got := started.Load(); got != 1 {
+		t.Errorf("standalone.Run() started %d extractors after the context was canceled, want 1", got)
+	}
+	if len(statuses) != 1 {
+		t.Errorf("standalone.Run() returned %d statuses, want 1", len(statuses))
+	}
+}
+
+// cancelingExtractor cancels the scan's context when it runs.
+type cancelingExtractor struct {
+	fakeExtractor
+	cancel  context.CancelFunc
+	started *atomic.Int32
+}
+
+func (e *cancelingExtractor) Extract(ctx context.Context, input *standalone.ScanInput) ([]*extractor.Inventory, error) {
+	e.started.Add(1)
+	e.cancel()
+	return nil, nil
+}
@@ -21,6 +21,7 @@ import (
 	"slices"
 	"strings"
 
+	"github.com/google/osv-scalibr/extractor"
 	"github.com/google/osv-scalibr/extractor/standalone/containers/containerd"
 	"github.com/google/osv-scalibr/extractor/standalone"
 	"github.com/google/osv-scalibr/extractor/standalone/windows/dismpatch"
@@ -95,6 +96,15 @@ func FromCapabilities(capabs *plugin.Capabilities) []standalone.Extractor {
 	return result
 }
 
+// ExtractorInfos describes all available extractors, sorted by name.
+func ExtractorInfos() []*plugin.Info {
+	infos := plugin.Infos(All, Default, extractorNames)
+	for _, info := range infos {
+		info.PackageClass = string(extractor.ClassOf(extractorNames[strings.ToLower(info.Name)][0]))
+	}
+	return infos
+}
+
 // ExtractorFromName returns a single extractor based on its exact name.
 func ExtractorFromName(name string) (standalone.Extractor, error) {
 	es, ok := extractorNames[strings.ToLower(name)]
@@ -64,6 +64,24 @@ func (r *ScanRoot) WithAbsolutePath() (*ScanRoot, error) {
 	return &ScanRoot{FS: r.FS, Path: absroot}, nil
 }
 
+// FromFS returns an FS backed by the given io/fs filesystem. If fsys already
+// implements FS it's returned as-is, otherwise ReadDir and Stat are provided
+// through the fs.ReadDir and fs.Stat helpers. This allows scanning in-memory
+// or remote filesystems that only implement Open.
+func FromFS(fsys fs.FS) FS {
+	if f, ok := fsys.(FS); ok {
+		return f
+	}
+	return wrappedFS{fsys}
+}
+
+type wrappedFS struct {
+	fs.FS
+}
+
+func (w wrappedFS) ReadDir(name string) ([]fs.DirEntry, error) { return fs.ReadDir(w.FS, name) }
+func (w wrappedFS) Stat(name string) (fs.FileInfo, error)      { return fs.Stat(w.FS, name) }
+
 // DirFS returns an FS implementation that accesses the real filesystem at the given root.
 func DirFS(root string) FS {
 	return os.DirFS(root).(FS)
@@ -0,0 +1,71 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fs
+
+import (
+	"io/fs"
+	"path"
+	"path/filepath"
+	"strings"
+	"testing/fstest"
+)
+
+// MapFS returns an in-memory FS containing the given files, keyed by their
+// path relative to the root of the filesystem. Parent directories are created
+// implicitly. This allows running extractors on file contents that aren't on
+// the disk, e.g. to reproduce an issue from files attached to a bug report.
+func MapFS(files map[string][]byte) FS {
+	m := make(fstest.MapFS, len(files))
+	for p, content := range files {
+		m[cleanMapPath(p)] = &fstest.MapFile{Data: content, Mode: 0444}
+	}
+	return memFS{m}
+}
+
+// MapFSScanRoots returns a one-element ScanRoot array representing an
+// in-memory filesystem with the given files. See MapFS for details.
+func MapFSScanRoots(files map[string][]byte) []*ScanRoot {
+	return []*ScanRoot{{FS: MapFS(files)}}
+}
+
+// cleanMapPath converts p into the unrooted, slash-separated form that io/fs
+// paths use.
+func cleanMapPath(p string) string {
+	p = path.Clean("/" + filepath.ToSlash(p))
+	if p == "/" {
+		return "."
+	}
+	return strings.TrimPrefix(p, "/")
+}
+
+// memFS wraps fstest.MapFS to also accept paths with OS-specific separators.
+type memFS struct {
+	m fstest.MapFS
+}
+
+// Open opens the named file.
+func (f memFS) Open(name string) (fs.File, error) {
+	return f.m.Open(filepath.ToSlash(name))
+}
+
+// ReadDir reads the named directory and returns its entries sorted by name.
+func (f memFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	return f.m.ReadDir(filepath.ToSlash(name))
+}
+
+// Stat returns a FileInfo describing the named file.
+func (f memFS) Stat(name string) (fs.FileInfo, error) {
+	return f.m.Stat(filepath.ToSlash(name))
+}
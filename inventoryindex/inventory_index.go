@@ -86,5 +86,9 @@ func (ix *InventoryIndex) GetSpecific(name string, packageType string) []*extrac
 }
 
 func toPURL(i *extractor.Inventory) (*purl.PackageURL, error) {
-	return i.Extractor.ToPURL(i)
+	p, err := i.Extractor.ToPURL(i)
+	if err != nil {
+		return nil, err
+	}
+	return purl.Normalize(p), nil
 }
@@ -0,0 +1,126 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+)
+
+// Levels of the JSONLogger in addition to the slog ones.
+const (
+	levelTrace   = slog.LevelDebug - 4
+	levelSummary = slog.LevelInfo + 2
+)
+
+// JSONLogger is a Logger that writes one JSON object per line with the time,
+// level and message of each log, e.g.
+// {"time":"2024-01-01T00:00:00Z","level":"INFO","msg":"Scan roots: [/]"}
+// It's meant for ingestion by log pipelines.
+type JSONLogger struct {
+	Verbose bool // Whether debug logs should be written.
+	Quiet   bool // Whether info and debug logs should be dropped. Summaries are still written.
+	logger  *slog.Logger
+}
+
+// NewJSONLogger returns a JSONLogger that writes to w.
+func NewJSONLogger(w io.Writer, verbose bool, quiet bool) *JSONLogger {
+	h := slog.NewJSONHandler(w, &slog.HandlerOptions{
+		// Filtering is done by the JSONLogger itself.
+		Level:       levelTrace,
+		ReplaceAttr: replaceLevelName,
+	})
+	return &JSONLogger{Verbose: verbose, Quiet: quiet, logger: slog.New(h)}
+}
+
+// replaceLevelName names the levels that slog doesn't know about.
+func replaceLevelName(groups []string, a slog.Attr) slog.Attr {
+	if a.Key != slog.LevelKey || len(groups) > 0 {
+		return a
+	}
+	switch a.Value.Any().(slog.Level) {
+	case levelTrace:
+		a.Value = slog.StringValue("TRACE")
+	case levelSummary:
+		a.Value = slog.StringValue("SUMMARY")
+	}
+	return a
+}
+
+func (l *JSONLogger) log(level slog.Level, msg string) {
+	// Unformatted logs and some formatted ones end with a newline.
+	l.logger.Log(context.Background(), level, strings.TrimSuffix(msg, "\n"))
+}
+
+// Errorf is the formatted error logging function.
+func (l *JSONLogger) Errorf(format string, args ...any) {
+	l.log(slog.LevelError, fmt.Sprintf(format, args...))
+}
+
+// Warnf is the formatted warning logging function.
+func (l *JSONLogger) Warnf(format string, args ...any) {
+	l.log(slog.LevelWarn, fmt.Sprintf(format, args...))
+}
+
+// Infof is the formatted info logging function.
+func (l *JSONLogger) Infof(format string, args ...any) {
+	if !l.Quiet {
+		l.log(slog.LevelInfo, fmt.Sprintf(format, args...))
+	}
+}
+
+// Summaryf is the formatted summary logging function.
+func (l *JSONLogger) Summaryf(format string, args ...any) {
+	l.log(levelSummary, fmt.Sprintf(format, args...))
+}
+
+// Tracef is the formatted trace logging function.
+func (l *JSONLogger) Tracef(format string, args ...any) {
+	l.log(levelTrace, fmt.Sprintf(format, args...))
+}
+
+// Debugf is the formatted debug logging function.
+func (l *JSONLogger) Debugf(format string, args ...any) {
+	if l.Verbose && !l.Quiet {
+		l.log(slog.LevelDebug, fmt.Sprintf(format, args...))
+	}
+}
+
+// Error is the error logging function.
+func (l *JSONLogger) Error(args ...any) {
+	l.log(slog.LevelError, fmt.Sprintln(args...))
+}
+
+// Warn is the warning logging function.
+func (l *JSONLogger) Warn(args ...any) {
+	l.log(slog.LevelWarn, fmt.Sprintln(args...))
+}
+
+// Info is the info logging function.
+func (l *JSONLogger) Info(args ...any) {
+	if !l.Quiet {
+		l.log(slog.LevelInfo, fmt.Sprintln(args...))
+	}
+}
+
+// Debug is the debug logging function.
+func (l *JSONLogger) Debug(args ...any) {
+	if l.Verbose && !l.Quiet {
+		l.log(slog.LevelDebug, fmt.Sprintln(args...))
+	}
+}
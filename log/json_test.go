@@ -0,0 +1,79 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/osv-scalibr/log"
+)
+
+func TestJSONLogger(t *testing.T) {
+	tests := []struct {
+		name       string
+		verbose    bool
+		quiet      bool
+		wantLevels []string
+	}{
+		{
+			name:       "default",
+			wantLevels: []string{"ERROR", "WARN", "INFO", "SUMMARY", "TRACE"},
+		},
+		{
+			name:       "verbose",
+			verbose:    true,
+			wantLevels: []string{"ERROR", "WARN", "INFO", "SUMMARY", "TRACE", "DEBUG"},
+		},
+		{
+			name:       "quiet",
+			quiet:      true,
+			wantLevels: []string{"ERROR", "WARN", "SUMMARY", "TRACE"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf strings.Builder
+			l := log.NewJSONLogger(&buf, tt.verbose, tt.quiet)
+			l.Errorf("error %d", 1)
+			l.Warn("warning", 2)
+			l.Infof("Status: path: %q\n", "a")
+			l.Summaryf("summary")
+			l.Tracef("trace")
+			l.Debug("debug")
+
+			var gotLevels []string
+			for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+				var entry map[string]string
+				if err := json.Unmarshal([]byte(line), &entry); err != nil {
+					t.Fatalf("json.Unmarshal(%q): %v", line, err)
+				}
+				if entry["time"] == "" {
+					t.Errorf("log line %q has no time", line)
+				}
+				if strings.HasSuffix(entry["msg"], "\n") {
+					t.Errorf("log message %q ends with a newline", entry["msg"])
+				}
+				gotLevels = append(gotLevels, entry["level"])
+			}
+			if diff := cmp.Diff(tt.wantLevels, gotLevels); diff != "" {
+				t.Errorf("JSONLogger logged unexpected levels (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
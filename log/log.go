@@ -16,7 +16,10 @@
 // but it can be replaced with user-defined loggers.
 package log
 
-import "log"
+import (
+	"io"
+	"log"
+)
 
 // Logger is SCALIBR's logging interface.
 type Logger interface {
@@ -36,6 +39,10 @@ var logger Logger = &DefaultLogger{}
 // SetLogger overwrites the default SCALIBR logger with a user specified one.
 func SetLogger(l Logger) { logger = l }
 
+// SetOutput sets the destination of the DefaultLogger's logs. Defaults to
+// stderr.
+func SetOutput(w io.Writer) { log.SetOutput(w) }
+
 // Errorf is the static formatted error logging function.
 func Errorf(format string, args ...any) {
 	logger.Errorf(format, args...)
@@ -76,10 +83,47 @@ func Debug(args ...any) {
 	logger.Debug(args...)
 }
 
+// summaryLogger is implemented by loggers that treat summary lines differently
+// from regular info logs.
+type summaryLogger interface {
+	Summaryf(format string, args ...any)
+}
+
+// Summaryf logs a summary line, e.g. the final status of a scan. It's logged
+// at info level unless the logger handles summaries separately (e.g. the
+// DefaultLogger still shows them in quiet mode).
+func Summaryf(format string, args ...any) {
+	if l, ok := logger.(summaryLogger); ok {
+		l.Summaryf(format, args...)
+		return
+	}
+	logger.Infof(format, args...)
+}
+
+// traceLogger is implemented by loggers that have a dedicated level for
+// high-volume trace logs.
+type traceLogger interface {
+	Tracef(format string, args ...any)
+}
+
+// Tracef logs a trace line. Trace logs are extremely high-volume, so callers
+// only log them if tracing was explicitly enabled, e.g. through
+// filesystem.Config.TraceFileRequired. They're logged at debug level unless
+// the logger handles them separately (e.g. the DefaultLogger shows them
+// regardless of its verbosity).
+func Tracef(format string, args ...any) {
+	if l, ok := logger.(traceLogger); ok {
+		l.Tracef(format, args...)
+		return
+	}
+	logger.Debugf(format, args...)
+}
+
 // DefaultLogger is the Logger implementation used by default.
 // It just logs to stderr using the default Go logger.
 type DefaultLogger struct {
 	Verbose bool // Whether debug logs should be shown.
+	Quiet   bool // Whether info and debug logs should be hidden. Summaries are still shown.
 }
 
 // Errorf is the formatted error logging function.
@@ -93,13 +137,25 @@ func (DefaultLogger) Warnf(format string, args ...any) {
 }
 
 // Infof is the formatted info logging function.
-func (DefaultLogger) Infof(format string, args ...any) {
+func (l *DefaultLogger) Infof(format string, args ...any) {
+	if !l.Quiet {
+		log.Printf(format, args...)
+	}
+}
+
+// Summaryf is the formatted summary logging function.
+func (DefaultLogger) Summaryf(format string, args ...any) {
 	log.Printf(format, args...)
 }
 
+// Tracef is the formatted trace logging function.
+func (DefaultLogger) Tracef(format string, args ...any) {
+	log.Printf("TRACE: "+format, args...)
+}
+
 // Debugf is the formatted debug logging function.
 func (l *DefaultLogger) Debugf(format string, args ...any) {
-	if l.Verbose {
+	if l.Verbose && !l.Quiet {
 		log.Printf(format, args...)
 	}
 }
@@ -115,13 +171,15 @@ func (DefaultLogger) Warn(args ...any) {
 }
 
 // Info is the info logging function.
-func (DefaultLogger) Info(args ...any) {
-	log.Println(args...)
+func (l *DefaultLogger) Info(args ...any) {
+	if !l.Quiet {
+		log.Println(args...)
+	}
 }
 
 // Debug is the debug logging function.
 func (l *DefaultLogger) Debug(args ...any) {
-	if l.Verbose {
+	if l.Verbose && !l.Quiet {
 		log.Println(args...)
 	}
 }
@@ -0,0 +1,72 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugin
+
+import (
+	"slices"
+	"strings"
+)
+
+// Info describes an available plugin, e.g. for rendering a plugin picker.
+type Info struct {
+	Name    string
+	Version int
+	// Requirements about the scanning environment.
+	Requirements *Capabilities
+	// Whether the plugin is enabled by default.
+	Default bool
+	// Whether the plugin is experimental, see the Experimental interface.
+	Experimental bool
+	// For extractors, the class of the packages they find: "os", "language"
+	// or "other". Empty for other plugins.
+	PackageClass string
+	// The names of the groups that enable the plugin when they're passed to
+	// the plugin list's FromNames functions, e.g. "python" or "all". Sorted.
+	Groups []string
+}
+
+// Infos returns the Info of the plugins, sorted by name. groups maps the
+// (lowercase) names that plugins can be enabled with to the plugins they
+// enable. Entries for the plugins' own names aren't reported as groups.
+func Infos[P Plugin](plugins []P, defaults []P, groups map[string][]P) []*Info {
+	isDefault := make(map[string]bool)
+	for _, p := range defaults {
+		isDefault[p.Name()] = true
+	}
+	pluginGroups := make(map[string][]string)
+	for group, ps := range groups {
+		for _, p := range ps {
+			if group != strings.ToLower(p.Name()) {
+				pluginGroups[p.Name()] = append(pluginGroups[p.Name()], group)
+			}
+		}
+	}
+
+	result := make([]*Info, 0, len(plugins))
+	for _, p := range plugins {
+		g := pluginGroups[p.Name()]
+		slices.Sort(g)
+		result = append(result, &Info{
+			Name:         p.Name(),
+			Version:      p.Version(),
+			Requirements: p.Requirements(),
+			Default:      isDefault[p.Name()],
+			Experimental: IsExperimental(p),
+			Groups:       g,
+		})
+	}
+	slices.SortFunc(result, func(a, b *Info) int { return strings.Compare(a.Name, b.Name) })
+	return result
+}
@@ -0,0 +1,68 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugin_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/osv-scalibr/plugin"
+)
+
+type namedPlugin struct {
+	name         string
+	reqs         *plugin.Capabilities
+	experimental bool
+}
+
+func (p namedPlugin) Name() string                       { return p.name }
+func (namedPlugin) Version() int                         { return 2 }
+func (p namedPlugin) Requirements() *plugin.Capabilities { return p.reqs }
+func (p namedPlugin) Experimental() bool                 { return p.experimental }
+
+func TestInfos(t *testing.T) {
+	py := namedPlugin{name: "python/wheelegg", reqs: &plugin.Capabilities{}}
+	deb := namedPlugin{name: "os/dpkg", reqs: &plugin.Capabilities{OS: plugin.OSLinux}, experimental: true}
+	all := []namedPlugin{py, deb}
+	groups := map[string][]namedPlugin{
+		"python":          {py},
+		"os":              {deb},
+		"default":         {py},
+		"all":             all,
+		"python/wheelegg": {py},
+		"os/dpkg":         {deb},
+	}
+
+	got := plugin.Infos(all, []namedPlugin{py}, groups)
+	want := []*plugin.Info{
+		{
+			Name:         "os/dpkg",
+			Version:      2,
+			Requirements: &plugin.Capabilities{OS: plugin.OSLinux},
+			Experimental: true,
+			Groups:       []string{"all", "os"},
+		},
+		{
+			Name:         "python/wheelegg",
+			Version:      2,
+			Requirements: &plugin.Capabilities{},
+			Default:      true,
+			Groups:       []string{"all", "default", "python"},
+		},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("plugin.Infos(): unexpected diff (-want +got):\n%s", diff)
+	}
+}
@@ -18,6 +18,8 @@ package plugin
 import (
 	"fmt"
 	"strings"
+
+	"github.com/google/osv-scalibr/log"
 )
 
 // OS is the OS the scanner is running on, or a specific OS type a Plugin needs to be run on.
@@ -34,6 +36,53 @@ const (
 	OSUnix OS = iota
 )
 
+// MemoryClass is a hint about how much memory a plugin can consume.
+type MemoryClass int
+
+// MemoryClass values, in increasing order of memory usage.
+const (
+	// MemoryAny means that the plugin has no particular memory needs when used
+	// in requirements, and that the scanning environment has no memory
+	// constraints when used in capabilities.
+	MemoryAny MemoryClass = iota
+	// MemoryLow means that the memory usage of the plugin doesn't grow with the
+	// size of its input, e.g. because it streams the files it parses.
+	MemoryLow
+	// MemoryFileSize means that the plugin can need up to the size of the file
+	// it processes in RAM, e.g. because it reads the whole file into memory.
+	MemoryFileSize
+	// MemoryMultipleFileSize means that the plugin can need a multiple of the
+	// size of the file it processes in RAM, e.g. because it decompresses nested
+	// archives in memory.
+	MemoryMultipleFileSize
+)
+
+// String returns a string representation of the memory class.
+func (m MemoryClass) String() string {
+	switch m {
+	case MemoryAny:
+		return "any"
+	case MemoryLow:
+		return "low"
+	case MemoryFileSize:
+		return "file-size"
+	case MemoryMultipleFileSize:
+		return "multiple-file-size"
+	default:
+		return fmt.Sprintf("MemoryClass(%d)", int(m))
+	}
+}
+
+// ParseMemoryClass parses the string representation of a memory class.
+func ParseMemoryClass(s string) (MemoryClass, error) {
+	for m := MemoryAny; m <= MemoryMultipleFileSize; m++ {
+		if m.String() == s {
+			return m, nil
+		}
+	}
+	return MemoryAny, fmt.Errorf("unknown memory class %q", s)
+}
+
 // Capabilities lists capabilities that the scanning environment provides for the plugins.
 // A plugin can't be enabled if it has more requirements than what the scanning environment provides.
 type Capabilities struct {
@@ -52,6 +101,10 @@ type Capabilities struct {
 	// * We're scanning a virtual filesystem unrelated to the host where SCALIBR is running.
 	// * We're scanning a real filesystem of e.g. a container image that's mounted somewhere on disk.
 	RunningSystem bool
+	// How much memory the plugin can consume. In the capabilities of the
+	// scanning environment, this is the highest memory class it can afford.
+	// Plugins in higher classes are skipped e.g. on small hosts.
+	Memory MemoryClass
 }
 
 // Plugin is the part of the plugin interface that's shared between extractors and detectors.
@@ -64,6 +117,84 @@ type Plugin interface {
 	Requirements() *Capabilities
 }
 
+// Configurable is an optional interface for plugins that accept user-supplied
+// options, e.g. from a scan config file. The options are keyed by option name.
+type Configurable interface {
+	// Configure applies the given options to the plugin. It should return an
+	// error for unknown option names or values of the wrong type.
+	Configure(options map[string]any) error
+}
+
+// Experimental is an optional interface for plugins that are new or known to
+// produce noisy results. Experimental plugins aren't enabled by groups of
+// plugins such as "all" in the scalibr binary unless --include-experimental is
+// set, but they're still run if they're enabled by name.
+type Experimental interface {
+	// Experimental returns whether the plugin is experimental.
+	Experimental() bool
+}
+
+// IsExperimental returns whether the plugin implements Experimental and
+// reports itself as experimental.
+func IsExperimental(p Plugin) bool {
+	e, ok := p.(Experimental)
+	return ok && e.Experimental()
+}
+
+// WithoutExperimental returns the plugins that aren't experimental.
+func WithoutExperimental[P Plugin](plugins []P) []P {
+	result := make([]P, 0, len(plugins))
+	for _, p := range plugins {
+		if !IsExperimental(p) {
+			result = append(result, p)
+		}
+	}
+	return result
+}
+
+// Cleaner is an optional interface for plugins that hold resources across
+// their runs, e.g. temporary files or spawned processes. The scan calls Cleanup
+// once all plugins ran, even if the scan failed. Plugins are cleaned up in the
+// reverse order of their registration in the scan config, i.e. transformers
+// first and filesystem extractors last.
+type Cleaner interface {
+	// Cleanup releases the resources of the plugin. Errors are logged but don't
+	// fail the scan.
+	Cleanup() error
+}
+
+// Cleanup calls Cleanup on the plugins that implement Cleaner, in the reverse
+// order of plugins. Errors are logged and the remaining plugins are still
+// cleaned up.
+func Cleanup(plugins []Plugin) {
+	for i := len(plugins) - 1; i >= 0; i-- {
+		p := plugins[i]
+		c, ok := p.(Cleaner)
+		if !ok {
+			continue
+		}
+		if err := c.Cleanup(); err != nil {
+			log.Warnf("Failed to clean up plugin %q: %v", p.Name(), err)
+		}
+	}
+}
+
+// Configure applies the options to the plugin. Returns an error if options are
+// specified for a plugin that doesn't implement Configurable.
+func Configure(p Plugin, options map[string]any) error {
+	if len(options) == 0 {
+		return nil
+	}
+	c, ok := p.(Configurable)
+	if !ok {
+		return fmt.Errorf("plugin %q doesn't accept options", p.Name())
+	}
+	if err := c.Configure(options); err != nil {
+		return fmt.Errorf("plugin %q: %w", p.Name(), err)
+	}
+	return nil
+}
+
 // LINT.IfChange
 
 // Status contains the status and version of the inventory+vuln plugins that ran.
@@ -77,6 +208,23 @@ type Status struct {
 type ScanStatus struct {
 	Status        ScanStatusEnum
 	FailureReason string
+	// The number of files the plugin skipped because their content was malformed.
+	MalformedInputs int
+	// Whether the plugin only saw part of its input, e.g. because the
+	// filesystem walk stopped after visiting the maximum number of inodes.
+	Truncated bool
+	// The number of inventory items that were dropped because the plugin
+	// exceeded the maximum inventory per extractor.
+	DroppedInventory int
+	// The number of inventory items the plugin reported. Only set for
+	// extractors. Zero means the extractor ran but found nothing.
+	InventoryCount int
+	// The number of files the extractor didn't finish extracting within the
+	// time limit set for it. Their results were dropped.
+	TimedOutFiles int
+	// The number of files that weren't passed to the extractor because they
+	// exceed the file size limit set for it.
+	OversizedFiles int
 }
 
 // ScanStatusEnum is the enum for the scan status.
@@ -88,6 +236,9 @@ const (
 	ScanStatusSucceeded
 	ScanStatusPartiallySucceeded
 	ScanStatusFailed
+	// The plugin didn't run because the scanning environment doesn't satisfy
+	// its requirements. FailureReason contains the unsatisfied requirements.
+	ScanStatusSkipped
 )
 
 // LINT.ThenChange(/binary/proto/scan_result.proto)
@@ -95,23 +246,27 @@ const (
 // ValidateRequirements checks that the specified  scanning capabilities satisfy
 // the requirements of a given plugin.
 func ValidateRequirements(p Plugin, capabs *Capabilities) error {
+	reqs := p.Requirements()
 	errs := []string{}
-	if p.Requirements().OS == OSUnix {
+	if reqs.OS == OSUnix {
 		if capabs.OS != OSLinux && capabs.OS != OSMac {
 			errs = append(errs, "needs to run on Unix system but scan environment is non-Unix")
 		}
-	} else if p.Requirements().OS != OSAny && p.Requirements().OS != capabs.OS {
+	} else if reqs.OS != OSAny && reqs.OS != capabs.OS {
 		errs = append(errs, "needs to run on a different OS than that of the scan environment")
 	}
-	if p.Requirements().Network && !capabs.Network {
+	if reqs.Network && !capabs.Network {
 		errs = append(errs, "needs network access but scan environment doesn't provide it")
 	}
-	if p.Requirements().DirectFS && !capabs.DirectFS {
+	if reqs.DirectFS && !capabs.DirectFS {
 		errs = append(errs, "needs direct filesystem access but scan environment doesn't provide it")
 	}
-	if p.Requirements().RunningSystem && !capabs.RunningSystem {
+	if reqs.RunningSystem && !capabs.RunningSystem {
 		errs = append(errs, "scanner isn't scanning the host it's run from directly")
 	}
+	if reqs.Memory != MemoryAny && capabs.Memory != MemoryAny && reqs.Memory > capabs.Memory {
+		errs = append(errs, fmt.Sprintf("needs %s memory but scan environment only affords %s memory", reqs.Memory, capabs.Memory))
+	}
 	if len(errs) == 0 {
 		return nil
 	}
@@ -138,15 +293,49 @@ func StatusFromErr(p Plugin, partial bool, err error) *Status {
 	}
 }
 
+// SkippedStatus returns the scan status of a plugin that wasn't run because
+// its requirements weren't satisfied. err is the error returned by
+// ValidateRequirements.
+func SkippedStatus(p Plugin, err error) *Status {
+	return &Status{
+		Name:    p.Name(),
+		Version: p.Version(),
+		Status: &ScanStatus{
+			Status:        ScanStatusSkipped,
+			FailureReason: err.Error(),
+		},
+	}
+}
+
 // String returns a string representation of the scan status.
 func (s *ScanStatus) String() string {
+	var status string
 	switch s.Status {
 	case ScanStatusSucceeded:
-		return "SUCCEEDED"
+		status = "SUCCEEDED"
 	case ScanStatusPartiallySucceeded:
-		return "PARTIALLY_SUCCEEDED"
+		status = "PARTIALLY_SUCCEEDED"
 	case ScanStatusFailed:
-		return fmt.Sprintf("FAILED: %s", s.FailureReason)
+		status = fmt.Sprintf("FAILED: %s", s.FailureReason)
+	case ScanStatusSkipped:
+		status = fmt.Sprintf("SKIPPED: %s", s.FailureReason)
+	default:
+		status = "UNSPECIFIED"
+	}
+	if s.MalformedInputs > 0 {
+		status += fmt.Sprintf(" (%d files were malformed)", s.MalformedInputs)
+	}
+	if s.Truncated {
+		status += " (truncated)"
+	}
+	if s.DroppedInventory > 0 {
+		status += fmt.Sprintf(" (%d inventory items dropped)", s.DroppedInventory)
+	}
+	if s.TimedOutFiles > 0 {
+		status += fmt.Sprintf(" (%d files timed out)", s.TimedOutFiles)
+	}
+	if s.OversizedFiles > 0 {
+		status += fmt.Sprintf(" (%d files exceeded the size limit)", s.OversizedFiles)
 	}
-	return "UNSPECIFIED"
+	return status
 }
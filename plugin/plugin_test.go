@@ -15,6 +15,7 @@
 package plugin_test
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -79,6 +80,30 @@ func TestValidateRequirements(t *testing.T) {
 			capabs:     &plugin.Capabilities{OS: plugin.OSMac},
 			wantErr:    nil,
 		},
+		{
+			desc:       "No memory constraints",
+			pluginReqs: &plugin.Capabilities{Memory: plugin.MemoryMultipleFileSize},
+			capabs:     &plugin.Capabilities{},
+			wantErr:    nil,
+		},
+		{
+			desc:       "Memory class satisfied",
+			pluginReqs: &plugin.Capabilities{Memory: plugin.MemoryFileSize},
+			capabs:     &plugin.Capabilities{Memory: plugin.MemoryFileSize},
+			wantErr:    nil,
+		},
+		{
+			desc:       "No memory requirements",
+			pluginReqs: &plugin.Capabilities{},
+			capabs:     &plugin.Capabilities{Memory: plugin.MemoryLow},
+			wantErr:    nil,
+		},
+		{
+			desc:       "Memory class not satisfied",
+			pluginReqs: &plugin.Capabilities{Memory: plugin.MemoryMultipleFileSize},
+			capabs:     &plugin.Capabilities{Memory: plugin.MemoryFileSize},
+			wantErr:    cmpopts.AnyError,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -92,6 +117,21 @@ func TestValidateRequirements(t *testing.T) {
 	}
 }
 
+func TestParseMemoryClass(t *testing.T) {
+	for _, m := range []plugin.MemoryClass{plugin.MemoryAny, plugin.MemoryLow, plugin.MemoryFileSize, plugin.MemoryMultipleFileSize} {
+		got, err := plugin.ParseMemoryClass(m.String())
+		if err != nil {
+			t.Errorf("plugin.ParseMemoryClass(%q): %v", m.String(), err)
+		}
+		if got != m {
+			t.Errorf("plugin.ParseMemoryClass(%q) = %v, want %v", m.String(), got, m)
+		}
+	}
+	if _, err := plugin.ParseMemoryClass("huge"); err == nil {
+		t.Error("plugin.ParseMemoryClass(\"huge\") succeeded, want error")
+	}
+}
+
 func TestString(t *testing.T) {
 	testCases := []struct {
 		desc string
@@ -113,6 +153,31 @@ func TestString(t *testing.T) {
 			s:    &plugin.ScanStatus{Status: plugin.ScanStatusFailed, FailureReason: "failure"},
 			want: "FAILED: failure",
 		},
+		{
+			desc: "Skipped plugin",
+			s:    &plugin.ScanStatus{Status: plugin.ScanStatusSkipped, FailureReason: "needs network access"},
+			want: "SKIPPED: needs network access",
+		},
+		{
+			desc: "Successful scan with malformed files",
+			s:    &plugin.ScanStatus{Status: plugin.ScanStatusSucceeded, MalformedInputs: 3},
+			want: "SUCCEEDED (3 files were malformed)",
+		},
+		{
+			desc: "Truncated scan",
+			s:    &plugin.ScanStatus{Status: plugin.ScanStatusSucceeded, Truncated: true},
+			want: "SUCCEEDED (truncated)",
+		},
+		{
+			desc: "Scan with dropped inventory",
+			s:    &plugin.ScanStatus{Status: plugin.ScanStatusSucceeded, DroppedInventory: 5},
+			want: "SUCCEEDED (5 inventory items dropped)",
+		},
+		{
+			desc: "Scan with files over the extractor's limits",
+			s:    &plugin.ScanStatus{Status: plugin.ScanStatusSucceeded, TimedOutFiles: 1, OversizedFiles: 2},
+			want: "SUCCEEDED (1 files timed out) (2 files exceeded the size limit)",
+		},
 		{
 			desc: "Unspecified status",
 			s:    &plugin.ScanStatus{},
@@ -129,3 +194,47 @@ func TestString(t *testing.T) {
 		})
 	}
 }
+
+func TestWithoutExperimental(t *testing.T) {
+	stable := namedPlugin{name: "stable"}
+	experimental := namedPlugin{name: "experimental", experimental: true}
+
+	got := plugin.WithoutExperimental([]namedPlugin{stable, experimental})
+	want := []namedPlugin{stable}
+	if diff := cmp.Diff(want, got, cmp.AllowUnexported(namedPlugin{})); diff != "" {
+		t.Errorf("plugin.WithoutExperimental(): unexpected diff (-want +got):\n%s", diff)
+	}
+	if plugin.IsExperimental(fakePlugin{}) {
+		t.Errorf("plugin.IsExperimental(%v): got true, want false for plugins that don't implement Experimental", fakePlugin{})
+	}
+}
+
+// cleanupPlugin records the order in which the plugins are cleaned up.
+type cleanupPlugin struct {
+	namedPlugin
+	cleaned *[]string
+	err     error
+}
+
+func (p cleanupPlugin) Cleanup() error {
+	*p.cleaned = append(*p.cleaned, p.name)
+	return p.err
+}
+
+func TestCleanup(t *testing.T) {
+	var cleaned []string
+	plugins := []plugin.Plugin{
+		cleanupPlugin{namedPlugin: namedPlugin{name: "first"}, cleaned: &cleaned},
+		namedPlugin{name: "no-cleanup"},
+		cleanupPlugin{namedPlugin: namedPlugin{name: "failing"}, cleaned: &cleaned, err: errors.New("cleanup failed")},
+		cleanupPlugin{namedPlugin: namedPlugin{name: "last"}, cleaned: &cleaned},
+	}
+
+	plugin.Cleanup(plugins)
+
+	// Plugins are cleaned up in reverse order, even after a failed cleanup.
+	want := []string{"last", "failing", "first"}
+	if diff := cmp.Diff(want, cleaned); diff != "" {
+		t.Errorf("plugin.Cleanup(): unexpected cleanup order (-want +got):\n%s", diff)
+	}
+}
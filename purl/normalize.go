@@ -0,0 +1,98 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package purl
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/package-url/packageurl-go"
+)
+
+// caseInsensitiveTypes are the types whose namespace and name are
+// case-insensitive and thus lowercased in their canonical form.
+var caseInsensitiveTypes = map[string]bool{
+	TypeAlpm:      true,
+	TypeApk:       true,
+	TypeBitbucket: true,
+	TypeComposer:  true,
+	TypeDebian:    true,
+	TypeGithub:    true,
+	TypeHex:       true,
+	// Maven coordinates are case-sensitive, but SCALIBR has always reported
+	// them in lowercase.
+	TypeMaven: true,
+	TypeNPM:   true,
+	TypePyPi:  true,
+}
+
+// pypiSeparators matches runs of the characters PyPI treats as equivalent in
+// package names, see https://peps.python.org/pep-0503/#normalized-names.
+var pypiSeparators = regexp.MustCompile(`[-_.]+`)
+
+// Normalize returns the canonical form of a package URL so that the same
+// package reported by different extractors has the same string representation:
+//   - The type and the qualifier keys are lowercased.
+//   - The namespace and name are lowercased for types that are
+//     case-insensitive, and PyPI names use "-" as the only separator.
+//   - Empty, "." and ".." namespace and subpath segments are removed.
+//   - Qualifiers with empty values are removed and the rest are sorted by key.
+//
+// p isn't modified. Returns nil if p is nil.
+func Normalize(p *PackageURL) *PackageURL {
+	if p == nil {
+		return nil
+	}
+	n := &PackageURL{
+		Type:      strings.ToLower(p.Type),
+		Namespace: normalizeSegments(p.Namespace),
+		Name:      p.Name,
+		Version:   p.Version,
+		Subpath:   normalizeSegments(p.Subpath),
+	}
+	if caseInsensitiveTypes[n.Type] {
+		n.Namespace = strings.ToLower(n.Namespace)
+		n.Name = strings.ToLower(n.Name)
+	}
+	if n.Type == TypePyPi {
+		n.Name = pypiSeparators.ReplaceAllString(n.Name, "-")
+	}
+
+	if p.Qualifiers != nil {
+		n.Qualifiers = Qualifiers{}
+	}
+	for _, q := range p.Qualifiers {
+		if q.Value == "" {
+			continue
+		}
+		n.Qualifiers = append(n.Qualifiers, packageurl.Qualifier{Key: strings.ToLower(q.Key), Value: q.Value})
+	}
+	slices.SortStableFunc(n.Qualifiers, func(a, b packageurl.Qualifier) int { return strings.Compare(a.Key, b.Key) })
+	return n
+}
+
+// normalizeSegments removes leading, trailing and repeated slashes from a
+// namespace or subpath, as well as "." and ".." segments.
+func normalizeSegments(s string) string {
+	var segments []string
+	for _, seg := range strings.Split(s, "/") {
+		if seg == "" || seg == "." || seg == ".." {
+			continue
+		}
+		segments = append(segments, seg)
+	}
+	return strings.Join(segments, "/")
+}
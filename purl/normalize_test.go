@@ -0,0 +1,167 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package purl_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/osv-scalibr/purl"
+)
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		name string
+		purl *purl.PackageURL
+		want *purl.PackageURL
+	}{
+		{
+			name: "nil",
+			purl: nil,
+			want: nil,
+		}, {
+			name: "already normalized",
+			purl: &purl.PackageURL{
+				Type:       purl.TypeDebian,
+				Namespace:  "debian",
+				Name:       "curl",
+				Version:    "7.50.3-1",
+				Qualifiers: purl.QualifiersFromMap(map[string]string{"arch": "i386", "distro": "jessie"}),
+			},
+			want: &purl.PackageURL{
+				Type:       purl.TypeDebian,
+				Namespace:  "debian",
+				Name:       "curl",
+				Version:    "7.50.3-1",
+				Qualifiers: purl.QualifiersFromMap(map[string]string{"arch": "i386", "distro": "jessie"}),
+			},
+		}, {
+			name: "type is lowercased",
+			purl: &purl.PackageURL{Type: "Cargo", Name: "rand", Version: "0.7.2"},
+			want: &purl.PackageURL{Type: purl.TypeCargo, Name: "rand", Version: "0.7.2"},
+		}, {
+			name: "qualifiers are sorted",
+			purl: &purl.PackageURL{
+				Type:    purl.TypeDebian,
+				Name:    "curl",
+				Version: "7.50.3-1",
+				Qualifiers: purl.Qualifiers{
+					{Key: "distro", Value: "jessie"},
+					{Key: "arch", Value: "i386"},
+				},
+			},
+			want: &purl.PackageURL{
+				Type:       purl.TypeDebian,
+				Name:       "curl",
+				Version:    "7.50.3-1",
+				Qualifiers: purl.QualifiersFromMap(map[string]string{"arch": "i386", "distro": "jessie"}),
+			},
+		}, {
+			name: "qualifier keys are lowercased and empty values removed",
+			purl: &purl.PackageURL{
+				Type:    purl.TypeRPM,
+				Name:    "openssl",
+				Version: "3.0.7",
+				Qualifiers: purl.Qualifiers{
+					{Key: "Arch", Value: "x86_64"},
+					{Key: "epoch", Value: ""},
+				},
+			},
+			want: &purl.PackageURL{
+				Type:       purl.TypeRPM,
+				Name:       "openssl",
+				Version:    "3.0.7",
+				Qualifiers: purl.QualifiersFromMap(map[string]string{"arch": "x86_64"}),
+			},
+		}, {
+			name: "maven coordinates from a lockfile are lowercased",
+			purl: &purl.PackageURL{Type: purl.TypeMaven, Namespace: "org.apache.Logging.log4j", Name: "log4j-Core", Version: "2.17.1"},
+			want: &purl.PackageURL{Type: purl.TypeMaven, Namespace: "org.apache.logging.log4j", Name: "log4j-core", Version: "2.17.1"},
+		}, {
+			name: "pypi names use dashes",
+			purl: &purl.PackageURL{Type: purl.TypePyPi, Name: "Typing_Extensions", Version: "4.12.2"},
+			want: &purl.PackageURL{Type: purl.TypePyPi, Name: "typing-extensions", Version: "4.12.2"},
+		}, {
+			name: "repeated pypi separators",
+			purl: &purl.PackageURL{Type: purl.TypePyPi, Name: "zope.._interface", Version: "6.0"},
+			want: &purl.PackageURL{Type: purl.TypePyPi, Name: "zope-interface", Version: "6.0"},
+		}, {
+			name: "npm scope and name are lowercased",
+			purl: &purl.PackageURL{Type: purl.TypeNPM, Namespace: "@Babel", Name: "Core", Version: "7.24.0"},
+			want: &purl.PackageURL{Type: purl.TypeNPM, Namespace: "@babel", Name: "core", Version: "7.24.0"},
+		}, {
+			name: "case-sensitive types are kept",
+			purl: &purl.PackageURL{Type: purl.TypeGolang, Namespace: "github.com/BurntSushi", Name: "toml", Version: "v1.3.2"},
+			want: &purl.PackageURL{Type: purl.TypeGolang, Namespace: "github.com/BurntSushi", Name: "toml", Version: "v1.3.2"},
+		}, {
+			name: "namespace segments",
+			purl: &purl.PackageURL{Type: purl.TypeGolang, Namespace: "/github.com//google/", Name: "uuid", Version: "v1.6.0"},
+			want: &purl.PackageURL{Type: purl.TypeGolang, Namespace: "github.com/google", Name: "uuid", Version: "v1.6.0"},
+		}, {
+			name: "subpath segments",
+			purl: &purl.PackageURL{Type: purl.TypeGithub, Namespace: "package-url", Name: "purl-spec", Version: "244fd47e07d1004", Subpath: "/everybody/./loves//dogs/"},
+			want: &purl.PackageURL{Type: purl.TypeGithub, Namespace: "package-url", Name: "purl-spec", Version: "244fd47e07d1004", Subpath: "everybody/loves/dogs"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := purl.Normalize(tt.purl)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("Normalize(%v) returned unexpected diff (-want +got):\n%s", tt.purl, diff)
+			}
+		})
+	}
+}
+
+func TestNormalizeDoesNotModifyInput(t *testing.T) {
+	p := &purl.PackageURL{
+		Type:       purl.TypePyPi,
+		Name:       "Typing_Extensions",
+		Version:    "4.12.2",
+		Qualifiers: purl.Qualifiers{{Key: "b", Value: "2"}, {Key: "a", Value: "1"}},
+	}
+	want := &purl.PackageURL{
+		Type:       purl.TypePyPi,
+		Name:       "Typing_Extensions",
+		Version:    "4.12.2",
+		Qualifiers: purl.Qualifiers{{Key: "b", Value: "2"}, {Key: "a", Value: "1"}},
+	}
+	purl.Normalize(p)
+	if diff := cmp.Diff(want, p); diff != "" {
+		t.Errorf("Normalize() modified its input (-want +got):\n%s", diff)
+	}
+}
+
+func TestNormalizeString(t *testing.T) {
+	// The same package reported by different extractors.
+	a := &purl.PackageURL{
+		Type:       purl.TypeMaven,
+		Namespace:  "com.Google.guava",
+		Name:       "Guava",
+		Version:    "33.0.0-jre",
+		Qualifiers: purl.Qualifiers{{Key: "type", Value: "jar"}, {Key: "classifier", Value: "sources"}},
+	}
+	b := &purl.PackageURL{
+		Type:       "MAVEN",
+		Namespace:  "com.google.guava",
+		Name:       "guava",
+		Version:    "33.0.0-jre",
+		Qualifiers: purl.QualifiersFromMap(map[string]string{"type": "jar", "classifier": "sources"}),
+	}
+	if got, want := purl.Normalize(a).String(), purl.Normalize(b).String(); got != want {
+		t.Errorf("Normalize(%v).String() = %q, want %q", a, got, want)
+	}
+}
@@ -29,6 +29,9 @@ import (
 const (
 	// TypeAlpm is a pkg:alpm purl.
 	TypeAlpm = "alpm"
+	// TypeAndroid is a pkg:android purl. Not part of the spec, used for Android
+	// apps.
+	TypeAndroid = "android"
 	// TypeApk is a pkg:apk purl.
 	TypeApk = "apk"
 	// TypeBitbucket is a pkg:bitbucket purl.
@@ -47,6 +50,8 @@ const (
 	TypeConda = "conda"
 	// COS is the pkg:cos purl
 	TypeCOS = "cos"
+	// TypeCPAN is a pkg:cpan purl.
+	TypeCPAN = "cpan"
 	// TypeCran is a pkg:cran purl.
 	TypeCran = "cran"
 	// TypeDebian is a pkg:deb purl.
@@ -65,8 +70,13 @@ const (
 	TypeGolang = "golang"
 	// TypeHackage is a pkg:hackage purl.
 	TypeHackage = "hackage"
+	// TypeHelm is a pkg:helm purl. Not part of the spec, used for Helm charts.
+	TypeHelm = "helm"
 	// TypeHex is a pkg:hex purl.
 	TypeHex = "hex"
+	// TypeMacApps is a pkg:macapps purl. Not part of the spec, used for macOS
+	// application bundles.
+	TypeMacApps = "macapps"
 	// TypeMaven is a pkg:maven purl.
 	TypeMaven = "maven"
 	// TypeNPM is a pkg:npm purl.
@@ -75,6 +85,9 @@ const (
 	TypeNuget = "nuget"
 	// TypeOCI is a pkg:oci purl
 	TypeOCI = "oci"
+	// TypeOpkg is a pkg:opkg purl. Not part of the spec, used for the opkg
+	// packages of OpenWrt and other embedded Linux distributions.
+	TypeOpkg = "opkg"
 	// TypePub is a pkg:pub purl.
 	TypePub = "pub"
 	// TypePyPi is a pkg:pypi purl.
@@ -147,6 +160,7 @@ func FromString(purl string) (PackageURL, error) {
 func validType(t string) bool {
 	types := map[string]bool{
 		TypeAlpm:      true,
+		TypeAndroid:   true,
 		TypeApk:       true,
 		TypeBitbucket: true,
 		TypeBrew:      true,
@@ -156,6 +170,7 @@ func validType(t string) bool {
 		TypeConan:     true,
 		TypeConda:     true,
 		TypeCOS:       true,
+		TypeCPAN:      true,
 		TypeCran:      true,
 		TypeDebian:    true,
 		TypeDocker:    true,
@@ -165,11 +180,14 @@ func validType(t string) bool {
 		TypeGithub:    true,
 		TypeGolang:    true,
 		TypeHackage:   true,
+		TypeHelm:      true,
 		TypeHex:       true,
+		TypeMacApps:   true,
 		TypeMaven:     true,
 		TypeNPM:       true,
 		TypeNuget:     true,
 		TypeOCI:       true,
+		TypeOpkg:      true,
 		TypePub:       true,
 		TypePyPi:      true,
 		TypeRPM:       true,
@@ -193,4 +211,7 @@ const (
 	SourceVersion = "sourceversion"
 	SourceRPM     = "sourcerpm"
 	BuildNumber   = "buildnumber"
+	RepositoryURL = "repository_url"
+	Tag           = "tag"
+	VCSURL        = "vcs_url"
 )
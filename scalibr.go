@@ -18,21 +18,30 @@ package scalibr
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"fmt"
+	"os"
 	"regexp"
 	"slices"
 	"sort"
 	"time"
 
+	v1 "github.com/google/go-containerregistry/pkg/v1"
 	"github.com/google/osv-scalibr/detector"
+	"github.com/google/osv-scalibr/enricher"
 	"github.com/google/osv-scalibr/extractor"
 	"github.com/google/osv-scalibr/extractor/filesystem"
+	"github.com/google/osv-scalibr/extractor/filesystem/os/osrelease"
 	"github.com/google/osv-scalibr/extractor/standalone"
 	scalibrfs "github.com/google/osv-scalibr/fs"
 	"github.com/google/osv-scalibr/inventoryindex"
+	"github.com/google/osv-scalibr/log"
 	"github.com/google/osv-scalibr/plugin"
+	"github.com/google/osv-scalibr/purl"
 	"github.com/google/osv-scalibr/stats"
+	"github.com/google/osv-scalibr/transformer"
 
 	el "github.com/google/osv-scalibr/extractor/filesystem/list"
 	sl "github.com/google/osv-scalibr/extractor/standalone/list"
@@ -55,9 +64,23 @@ type ScanConfig struct {
 	FilesystemExtractors []filesystem.Extractor
 	StandaloneExtractors []standalone.Extractor
 	Detectors            []detector.Detector
+	// Optional: The maximum number of standalone extractors that run at the
+	// same time. Defaults to standalone.DefaultMaxConcurrency.
+	StandaloneConcurrency int
+	// Optional: Enrichers to run on the extracted inventory before the
+	// detectors. They run in the order they're listed in.
+	Enrichers []enricher.Enricher
+	// Optional: Transformers to run on the inventory after the detectors, e.g.
+	// to remove or annotate some of the inventory items before they're
+	// reported. They run in the order they're listed in.
+	Transformers []transformer.Transformer
 	// Capabilities that the scanning environment satisfies, e.g. whether there's
 	// network access. Some plugins can only run if certain requirements are met.
 	Capabilities *plugin.Capabilities
+	// Optional: If true, plugins whose requirements aren't satisfied by
+	// Capabilities are removed from the config and reported with a skipped
+	// status instead of failing the scan.
+	FilterByCapabilities bool
 	// ScanRoots contain the list of root dir used by file walking during extraction.
 	// All extractors and detectors will assume files are relative to these dirs.
 	// Example use case: Scanning a container image or source code repo that is
@@ -68,22 +91,109 @@ type ScanConfig struct {
 	// Note that on real filesystems these are not relative to the ScanRoots and
 	// thus need to be in sub-directories of one of the ScanRoots.
 	FilesToExtract []string
+	// Optional: Individual files to extract inventory from, relative to each
+	// of the ScanRoots. E.g. "requirements.txt" is extracted from
+	// <root>/requirements.txt. Unlike FilesToExtract, this can be used with
+	// several ScanRoots.
+	FilesToExtractRelative []string
 	// Optional: Directories that the file system walk should ignore.
 	// Note that on real filesystems these are not relative to the ScanRoots and
 	// thus need to be in sub-directories of one of the ScanRoots.
-	// TODO(b/279413691): Also skip local paths, e.g. "Skip all .git dirs"
+	// Entries containing "*" are glob patterns, e.g. "**/node_modules" skips
+	// node_modules directories anywhere under the scan roots. See
+	// filesystem.Config for details.
 	DirsToSkip []string
-	// Optional: If the regex matches a directory, it will be skipped.
+	// Optional: If the regex matches a directory, it will be skipped. This is
+	// applied in addition to DirsToSkip.
 	SkipDirRegex *regexp.Regexp
+	// Optional: If set, only files in directories matching the regex (or
+	// inside a matching directory) are extracted. The skip rules take
+	// precedence. See filesystem.Config for details.
+	IncludeDirRegex *regexp.Regexp
 	// Optional: stats allows to enter a metric hook. If left nil, no metrics will be recorded.
 	Stats stats.Collector
 	// Optional: Whether to read symlinks.
 	ReadSymlinks bool
 	// Optional: Limit for visited inodes. If 0, no limit is applied.
 	MaxInodes int
+	// Optional: What to do once MaxInodes is exceeded. By default the scan fails.
+	MaxInodesBehavior filesystem.MaxInodesBehavior
+	// Optional: Limit for the inventory items reported by each filesystem
+	// extractor. Further results are dropped. If 0, no limit is applied.
+	MaxInventoryPerExtractor int
+	// Optional: Limits for individual filesystem extractors, keyed by extractor
+	// name.
+	ExtractorOverrides map[string]filesystem.ExtractorOverride
 	// Optional: By default, inventories stores a path relative to the scan root. If StoreAbsolutePath
 	// is set, the absolute path is stored instead.
 	StoreAbsolutePath bool
+	// Optional: If set, this prefix is stripped from the reported inventory locations.
+	// Useful for producing SBOMs that don't reveal the scan host's directory structure.
+	LocationPrefixTrim string
+	// Optional: If true, the reported inventory locations use forward slashes as
+	// path separators on all OSes. Useful for comparing the SBOMs of Windows and
+	// Unix hosts.
+	PosixPaths bool
+	// Optional: If true, the findings that several detectors reported for the
+	// same advisory and target are merged into one finding that lists all of
+	// these detectors. By default each detector's findings are reported
+	// separately. See detector.Deduplicate for details.
+	DeduplicateFindings bool
+	// Optional: If true, a histogram of files that no extractor required is
+	// logged and reported to Stats. Useful for analyzing extractor coverage.
+	ReportUnmatched bool
+	// Optional: If true, the periodic status lines aren't logged during the
+	// filesystem walk.
+	Quiet bool
+	// Optional: If true, the FileRequired decision of every filesystem
+	// extractor for every file is logged at trace level.
+	TraceFileRequired bool
+	// Optional: If set, files last modified before this time are not extracted.
+	// Useful for incremental scans together with PreviousInventory.
+	Since time.Time
+	// Optional: The inventory of a previous scan. Entries found in files that
+	// were skipped because they're older than Since are added to the results.
+	// Every entry needs to have its Extractor set.
+	PreviousInventory []*extractor.Inventory
+	// Optional: If true, the scan fails if a filesystem extractor failed on any
+	// file. The rest of the scan still runs, so the result contains the
+	// inventory and findings alongside the failed status. See
+	// filesystem.Config.Strict for details.
+	Strict bool
+	// Optional: The maximum number of bytes the filesystem extractors read per
+	// second, to limit the scan's impact on the host. 0 means unlimited. See
+	// filesystem.Config.MaxBytesPerSecond for details.
+	MaxBytesPerSecond int64
+	// Optional: The size of the buffer the filesystem extractors read files
+	// through. 0 means unbuffered. See filesystem.Config.ReadBufferSize for
+	// details.
+	ReadBufferSize int
+	// Optional: A cache for the results of the filesystem extractors, keyed by
+	// the content of the extracted files. Extractors are skipped for files
+	// whose results are cached. See filesystem.Config.ResultCache for details.
+	ResultCache filesystem.ResultCache
+	// Optional: Key-value pairs describing the scan, e.g. the role or region of
+	// the scanned host. They aren't interpreted by the scanner, only copied to
+	// ScanResult.Metadata.
+	Metadata map[string]string
+}
+
+// InMemoryScanConfig returns a config for running the given filesystem
+// extractors on in-memory files, keyed by their path relative to the scan
+// root. Since there's no real filesystem or running system to scan, plugins
+// that need them are skipped.
+func InMemoryScanConfig(files map[string][]byte, extractors []filesystem.Extractor) *ScanConfig {
+	return &ScanConfig{
+		FilesystemExtractors: extractors,
+		Capabilities: &plugin.Capabilities{
+			OS:            plugin.OSAny,
+			Network:       false,
+			DirectFS:      false,
+			RunningSystem: false,
+		},
+		FilterByCapabilities: true,
+		ScanRoots:            scalibrfs.MapFSScanRoots(files),
+	}
 }
 
 // EnableRequiredExtractors adds those extractors to the config that are required by enabled
@@ -118,10 +228,31 @@ func (cfg *ScanConfig) EnableRequiredExtractors() error {
 	return nil
 }
 
-// ValidatePluginRequirements checks that the scanning environment's capabilities satisfy
-// the requirements of all enabled plugin.
-func (cfg *ScanConfig) ValidatePluginRequirements() error {
-	plugins := make([]plugin.Plugin, 0, len(cfg.FilesystemExtractors)+len(cfg.StandaloneExtractors)+len(cfg.Detectors))
+// FilterPluginsByCapabilities removes the plugins from the config whose
+// requirements aren't satisfied by the scanning environment's capabilities.
+// Returns a skipped status for each removed plugin.
+func (cfg *ScanConfig) FilterPluginsByCapabilities() []*plugin.Status {
+	var skipped []*plugin.Status
+	keep := func(p plugin.Plugin) bool {
+		if err := plugin.ValidateRequirements(p, cfg.Capabilities); err != nil {
+			skipped = append(skipped, plugin.SkippedStatus(p, err))
+			return false
+		}
+		return true
+	}
+	cfg.FilesystemExtractors = filterPlugins(cfg.FilesystemExtractors, keep)
+	cfg.StandaloneExtractors = filterPlugins(cfg.StandaloneExtractors, keep)
+	cfg.Detectors = filterPlugins(cfg.Detectors, keep)
+	cfg.Enrichers = filterPlugins(cfg.Enrichers, keep)
+	cfg.Transformers = filterPlugins(cfg.Transformers, keep)
+	return skipped
+}
+
+// plugins returns all enabled plugins in the order of their registration:
+// filesystem extractors, standalone extractors, detectors, enrichers and
+// transformers.
+func (cfg *ScanConfig) plugins() []plugin.Plugin {
+	plugins := make([]plugin.Plugin, 0, len(cfg.FilesystemExtractors)+len(cfg.StandaloneExtractors)+len(cfg.Detectors)+len(cfg.Enrichers)+len(cfg.Transformers))
 	for _, p := range cfg.FilesystemExtractors {
 		plugins = append(plugins, p)
 	}
@@ -131,8 +262,30 @@ func (cfg *ScanConfig) ValidatePluginRequirements() error {
 	for _, p := range cfg.Detectors {
 		plugins = append(plugins, p)
 	}
-	errs := []error{}
+	for _, p := range cfg.Enrichers {
+		plugins = append(plugins, p)
+	}
+	for _, p := range cfg.Transformers {
+		plugins = append(plugins, p)
+	}
+	return plugins
+}
+
+func filterPlugins[P plugin.Plugin](plugins []P, keep func(plugin.Plugin) bool) []P {
+	result := make([]P, 0, len(plugins))
 	for _, p := range plugins {
+		if keep(p) {
+			result = append(result, p)
+		}
+	}
+	return result
+}
+
+// ValidatePluginRequirements checks that the scanning environment's capabilities satisfy
+// the requirements of all enabled plugin.
+func (cfg *ScanConfig) ValidatePluginRequirements() error {
+	errs := []error{}
+	for _, p := range cfg.plugins() {
 		if err := plugin.ValidateRequirements(p, cfg.Capabilities); err != nil {
 			errs = append(errs, err)
 		}
@@ -151,12 +304,64 @@ type ScanResult struct {
 	Status *plugin.ScanStatus
 	// Status and versions of the inventory+vuln plugins that ran.
 	PluginStatus []*plugin.Status
-	Inventories  []*extractor.Inventory
-	Findings     []*detector.Finding
+	// The distribution of the scanned system. Nil if the (first) scan root
+	// doesn't have an os-release file, e.g. because it's not a Linux system.
+	OSRelease *OSRelease
+	// The container image the scanned filesystem was composed from. Nil if
+	// the (first) scan root isn't a container image.
+	ContainerImage *ContainerImage
+	Inventories    []*extractor.Inventory
+	Findings       []*detector.Finding
+	// Hex-encoded SHA-256 hash of the sorted and deduplicated PURLs of the
+	// inventory. Two scans found the same set of packages if their
+	// fingerprints are equal. See PURLFingerprint for details.
+	PURLFingerprint string
+	// The key-value pairs describing the scan from ScanConfig.Metadata.
+	Metadata map[string]string
+}
+
+// OSRelease identifies the Linux distribution of the scanned system, as read
+// from its /etc/os-release file. The OS package ecosystems of the inventory
+// depend on it, e.g. the same dpkg package version can be vulnerable on one
+// Debian release and not on another.
+type OSRelease struct {
+	// ID of the distribution, e.g. "debian".
+	ID string
+	// Version of the distribution, e.g. "12". Empty for rolling releases.
+	VersionID string
+	// Release codename, e.g. "bookworm".
+	VersionCodename string
+	// Human-readable name, e.g. "Debian GNU/Linux 12 (bookworm)".
+	PrettyName string
+}
+
+// ContainerImage describes a scanned container image, as read from its config.
+type ContainerImage struct {
+	// When the image was created. Zero if the config doesn't specify it.
+	Created time.Time
+	// The labels of the image, e.g. "org.opencontainers.image.source".
+	Labels map[string]string
 }
 
 // LINT.ThenChange(/binary/proto/scan_result.proto)
 
+// imageConfigFile is implemented by the filesystems of container images, e.g.
+// the ones opened with tarball.Open.
+type imageConfigFile interface {
+	ConfigFile() *v1.ConfigFile
+}
+
+// validatePreviousInventory checks that the inventory of a previous scan can
+// be added to the results, i.e. that it has the extractor that found it.
+func validatePreviousInventory(inventory []*extractor.Inventory) error {
+	for _, i := range inventory {
+		if i.Extractor == nil {
+			return fmt.Errorf("previous inventory %q at %v has no extractor", i.Name, i.Locations)
+		}
+	}
+	return nil
+}
+
 // Scan executes the extraction and detection using the provided scan config.
 func (Scanner) Scan(ctx context.Context, config *ScanConfig) (sr *ScanResult) {
 	if config.Stats == nil {
@@ -164,13 +369,22 @@ func (Scanner) Scan(ctx context.Context, config *ScanConfig) (sr *ScanResult) {
 	}
 	defer func() {
 		config.Stats.AfterScan(time.Since(sr.StartTime), sr.Status)
+		config.Stats.Flush()
 	}()
+	// Plugins that hold resources such as temporary files are cleaned up once
+	// the scan is done, including when it failed. See plugin.Cleaner.
+	defer func() { plugin.Cleanup(config.plugins()) }()
 	sro := &newScanResultOptions{
 		StartTime:   time.Now(),
+		Metadata:    config.Metadata,
 		Inventories: []*extractor.Inventory{},
 		Findings:    []*detector.Finding{},
 	}
-	if err := config.EnableRequiredExtractors(); err != nil {
+	err := config.EnableRequiredExtractors()
+	if err == nil && config.FilterByCapabilities {
+		sro.SkippedStatus = config.FilterPluginsByCapabilities()
+	}
+	if err != nil {
 		sro.Err = err
 	} else if err := config.ValidatePluginRequirements(); err != nil {
 		sro.Err = err
@@ -178,23 +392,48 @@ func (Scanner) Scan(ctx context.Context, config *ScanConfig) (sr *ScanResult) {
 		sro.Err = errNoScanRoot
 	} else if len(config.FilesToExtract) > 0 && len(config.ScanRoots) > 1 {
 		sro.Err = errFilesWithSeveralRoots
+	} else if err := validatePreviousInventory(config.PreviousInventory); err != nil {
+		sro.Err = err
 	}
 	if sro.Err != nil {
 		sro.EndTime = time.Now()
 		return newScanResult(sro)
 	}
+	sro.OSRelease = readOSRelease(config.ScanRoots[0])
+	sro.ContainerImage = readContainerImage(config.ScanRoots[0])
 	extractorConfig := &filesystem.Config{
-		Stats:             config.Stats,
-		ReadSymlinks:      config.ReadSymlinks,
-		Extractors:        config.FilesystemExtractors,
-		FilesToExtract:    config.FilesToExtract,
-		DirsToSkip:        config.DirsToSkip,
-		SkipDirRegex:      config.SkipDirRegex,
-		ScanRoots:         config.ScanRoots,
-		MaxInodes:         config.MaxInodes,
-		StoreAbsolutePath: config.StoreAbsolutePath,
+		Stats:                    config.Stats,
+		ReadSymlinks:             config.ReadSymlinks,
+		Extractors:               config.FilesystemExtractors,
+		FilesToExtract:           config.FilesToExtract,
+		FilesToExtractRelative:   config.FilesToExtractRelative,
+		DirsToSkip:               config.DirsToSkip,
+		SkipDirRegex:             config.SkipDirRegex,
+		IncludeDirRegex:          config.IncludeDirRegex,
+		ScanRoots:                config.ScanRoots,
+		MaxInodes:                config.MaxInodes,
+		MaxInodesBehavior:        config.MaxInodesBehavior,
+		MaxInventoryPerExtractor: config.MaxInventoryPerExtractor,
+		ExtractorOverrides:       config.ExtractorOverrides,
+		StoreAbsolutePath:        config.StoreAbsolutePath,
+		ReportUnmatched:          config.ReportUnmatched,
+		LocationPrefixTrim:       config.LocationPrefixTrim,
+		PosixPaths:               config.PosixPaths,
+		Quiet:                    config.Quiet,
+		TraceFileRequired:        config.TraceFileRequired,
+		Since:                    config.Since,
+		PreviousInventory:        config.PreviousInventory,
+		Strict:                   config.Strict,
+		MaxBytesPerSecond:        config.MaxBytesPerSecond,
+		ReadBufferSize:           config.ReadBufferSize,
+		ResultCache:              config.ResultCache,
 	}
 	inventories, extractorStatus, err := filesystem.Run(ctx, extractorConfig)
+	// In strict mode, failed extractors only fail the scan once it's complete.
+	var extractorErr error
+	if errors.Is(err, filesystem.ErrExtractorsFailed) {
+		extractorErr, err = err, nil
+	}
 	if err != nil {
 		sro.Err = err
 		sro.EndTime = time.Now()
@@ -205,8 +444,9 @@ func (Scanner) Scan(ctx context.Context, config *ScanConfig) (sr *ScanResult) {
 	sro.ExtractorStatus = extractorStatus
 	sysroot := config.ScanRoots[0]
 	standaloneCfg := &standalone.Config{
-		Extractors: config.StandaloneExtractors,
-		ScanRoot:   &scalibrfs.ScanRoot{FS: sysroot.FS, Path: sysroot.Path},
+		Extractors:     config.StandaloneExtractors,
+		ScanRoot:       &scalibrfs.ScanRoot{FS: sysroot.FS, Path: sysroot.Path},
+		MaxConcurrency: config.StandaloneConcurrency,
 	}
 	standaloneInv, standaloneStatus, err := standalone.Run(ctx, standaloneCfg)
 	if err != nil {
@@ -218,6 +458,14 @@ func (Scanner) Scan(ctx context.Context, config *ScanConfig) (sr *ScanResult) {
 	sro.Inventories = append(sro.Inventories, standaloneInv...)
 	sro.ExtractorStatus = append(sro.ExtractorStatus, standaloneStatus...)
 
+	enricherStatus, err := enricher.Run(ctx, config.Enrichers, sro.Inventories)
+	sro.EnricherStatus = enricherStatus
+	if err != nil {
+		sro.Err = err
+		sro.EndTime = time.Now()
+		return newScanResult(sro)
+	}
+
 	ix, err := inventoryindex.New(sro.Inventories)
 	if err != nil {
 		sro.Err = err
@@ -228,24 +476,74 @@ func (Scanner) Scan(ctx context.Context, config *ScanConfig) (sr *ScanResult) {
 	findings, detectorStatus, err := detector.Run(
 		ctx, config.Stats, config.Detectors, &scalibrfs.ScanRoot{FS: sysroot.FS, Path: sysroot.Path}, ix,
 	)
+	if config.DeduplicateFindings {
+		findings = detector.Deduplicate(findings)
+	}
 	sro.Findings = findings
 	sro.DetectorStatus = detectorStatus
 	if err != nil {
 		sro.Err = err
+		sro.EndTime = time.Now()
+		return newScanResult(sro)
+	}
+
+	sro.Inventories, sro.TransformerStatus, err = transformer.Run(ctx, config.Transformers, sro.Inventories)
+	if err != nil {
+		sro.Err = err
+	} else if extractorErr != nil {
+		sro.Err = extractorErr
 	}
 
 	sro.EndTime = time.Now()
 	return newScanResult(sro)
 }
 
+// readOSRelease returns the distribution of the system in the scan root, or
+// nil if it doesn't have an os-release file.
+func readOSRelease(root *scalibrfs.ScanRoot) *OSRelease {
+	m, err := osrelease.GetOSRelease(root.FS)
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			log.Warnf("Failed to read the os-release file of %s: %v", root.Path, err)
+		}
+		return nil
+	}
+	return &OSRelease{
+		ID:              m["ID"],
+		VersionID:       m["VERSION_ID"],
+		VersionCodename: m["VERSION_CODENAME"],
+		PrettyName:      m["PRETTY_NAME"],
+	}
+}
+
+// readContainerImage returns the container image that the filesystem of the
+// scan root was composed from, or nil if it's not a container image.
+func readContainerImage(root *scalibrfs.ScanRoot) *ContainerImage {
+	img, ok := root.FS.(imageConfigFile)
+	if !ok || img.ConfigFile() == nil {
+		return nil
+	}
+	cfg := img.ConfigFile()
+	return &ContainerImage{
+		Created: cfg.Created.Time,
+		Labels:  cfg.Config.Labels,
+	}
+}
+
 type newScanResultOptions struct {
-	StartTime       time.Time
-	EndTime         time.Time
-	ExtractorStatus []*plugin.Status
-	Inventories     []*extractor.Inventory
-	DetectorStatus  []*plugin.Status
-	Findings        []*detector.Finding
-	Err             error
+	StartTime         time.Time
+	EndTime           time.Time
+	OSRelease         *OSRelease
+	ContainerImage    *ContainerImage
+	Metadata          map[string]string
+	ExtractorStatus   []*plugin.Status
+	Inventories       []*extractor.Inventory
+	EnricherStatus    []*plugin.Status
+	DetectorStatus    []*plugin.Status
+	TransformerStatus []*plugin.Status
+	SkippedStatus     []*plugin.Status
+	Findings          []*detector.Finding
+	Err               error
 }
 
 func newScanResult(o *newScanResultOptions) *ScanResult {
@@ -257,22 +555,63 @@ func newScanResult(o *newScanResultOptions) *ScanResult {
 		status.Status = plugin.ScanStatusSucceeded
 	}
 	r := &ScanResult{
-		StartTime:    o.StartTime,
-		EndTime:      o.EndTime,
-		Status:       status,
-		PluginStatus: append(o.ExtractorStatus, o.DetectorStatus...),
-		Inventories:  o.Inventories,
-		Findings:     o.Findings,
+		StartTime:      o.StartTime,
+		EndTime:        o.EndTime,
+		Status:         status,
+		PluginStatus:   slices.Concat(o.ExtractorStatus, o.EnricherStatus, o.DetectorStatus, o.TransformerStatus, o.SkippedStatus),
+		OSRelease:      o.OSRelease,
+		ContainerImage: o.ContainerImage,
+		Inventories:    o.Inventories,
+		Findings:       o.Findings,
+		Metadata:       o.Metadata,
 	}
 
 	// Sort results for better diffing.
 	sortResults(r)
+	r.PURLFingerprint = PURLFingerprint(r.Inventories)
 	return r
 }
 
+// InventoryCountByScanRoot returns the number of inventory items found in each
+// scan root. Inventory without a scan root, e.g. from standalone extractors,
+// is counted under the empty string.
+func InventoryCountByScanRoot(inventories []*extractor.Inventory) map[string]int {
+	counts := make(map[string]int)
+	for _, i := range inventories {
+		counts[i.ScanRoot]++
+	}
+	return counts
+}
+
+// PURLFingerprint returns the hex-encoded SHA-256 hash of the normalized PURLs
+// of the inventory, sorted, deduplicated and each followed by a newline. This
+// is the hash of the "purls" output of a scan without filters such as
+// --package-class. Inventory that can't be converted into a PURL is skipped.
+func PURLFingerprint(inventories []*extractor.Inventory) string {
+	purls := make([]string, 0, len(inventories))
+	for _, i := range inventories {
+		if i.Extractor == nil {
+			continue
+		}
+		p, err := i.Extractor.ToPURL(i)
+		if err != nil || p == nil {
+			continue
+		}
+		purls = append(purls, purl.Normalize(p).String())
+	}
+	slices.Sort(purls)
+	purls = slices.Compact(purls)
+
+	h := sha256.New()
+	for _, p := range purls {
+		h.Write([]byte(p + "\n"))
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
 func hasFailedPlugins(statuses []*plugin.Status) bool {
 	for _, s := range statuses {
-		if s.Status.Status != plugin.ScanStatusSucceeded {
+		if s.Status.Status != plugin.ScanStatusSucceeded && s.Status.Status != plugin.ScanStatusSkipped {
 			return true
 		}
 	}
@@ -21,23 +21,30 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/google/osv-scalibr/detector"
+	"github.com/google/osv-scalibr/enricher"
 	"github.com/google/osv-scalibr/extractor"
 	"github.com/google/osv-scalibr/extractor/filesystem"
+	"github.com/google/osv-scalibr/extractor/filesystem/language/erlang/mixlock"
 	scalibrfs "github.com/google/osv-scalibr/fs"
 	"github.com/google/osv-scalibr/inventoryindex"
 	"github.com/google/osv-scalibr/plugin"
 	"github.com/google/osv-scalibr/purl"
 	scalibr "github.com/google/osv-scalibr"
 	fd "github.com/google/osv-scalibr/testing/fakedetector"
+	fen "github.com/google/osv-scalibr/testing/fakeenricher"
 	fe "github.com/google/osv-scalibr/testing/fakeextractor"
+	ftr "github.com/google/osv-scalibr/testing/faketransformer"
+	"github.com/google/osv-scalibr/transformer"
 )
 
 func TestScan(t *testing.T) {
 	success := &plugin.ScanStatus{Status: plugin.ScanStatusSucceeded}
+	extSuccess := &plugin.ScanStatus{Status: plugin.ScanStatusSucceeded, InventoryCount: 1}
 	pluginFailure := "failed to run plugin"
 	extFailure := &plugin.ScanStatus{
 		Status:        plugin.ScanStatusFailed,
@@ -61,6 +68,20 @@ func TestScan(t *testing.T) {
 		Name:      invName,
 		Locations: []string{"file.txt"},
 		Extractor: fakeExtractor,
+		ScanRoot:  tmp,
+	}
+	enrichedInventory := &extractor.Inventory{
+		Name:      invName,
+		Locations: []string{"file.txt"},
+		Extractor: fakeExtractor,
+		ScanRoot:  tmp,
+		Metadata:  "team-a",
+	}
+	setOwner := func(ctx context.Context, inventory []*extractor.Inventory) error {
+		for _, i := range inventory {
+			i.Metadata = "team-a"
+		}
+		return nil
 	}
 	finding := &detector.Finding{Adv: &detector.Advisory{ID: &detector.AdvisoryID{Reference: "CVE-1234"}}}
 
@@ -82,7 +103,7 @@ func TestScan(t *testing.T) {
 				Status: success,
 				PluginStatus: []*plugin.Status{
 					&plugin.Status{Name: "detector", Version: 2, Status: success},
-					&plugin.Status{Name: "python/wheelegg", Version: 1, Status: success},
+					&plugin.Status{Name: "python/wheelegg", Version: 1, Status: extSuccess},
 				},
 				Inventories: []*extractor.Inventory{inventory},
 				Findings:    []*detector.Finding{withDetectorName(finding, "detector")},
@@ -128,7 +149,7 @@ func TestScan(t *testing.T) {
 					&plugin.Status{Name: "detector", Version: 2, Status: success},
 					&plugin.Status{Name: "python/wheelegg", Version: 1, Status: extFailure},
 				},
-				Inventories: nil,
+				Inventories: []*extractor.Inventory{},
 				Findings:    []*detector.Finding{withDetectorName(finding, "detector")},
 			},
 		},
@@ -145,7 +166,91 @@ func TestScan(t *testing.T) {
 				Status: success,
 				PluginStatus: []*plugin.Status{
 					&plugin.Status{Name: "detector", Version: 2, Status: detFailure},
-					&plugin.Status{Name: "python/wheelegg", Version: 1, Status: success},
+					&plugin.Status{Name: "python/wheelegg", Version: 1, Status: extSuccess},
+				},
+				Inventories: []*extractor.Inventory{inventory},
+				Findings:    []*detector.Finding{},
+			},
+		},
+		{
+			desc: "Enricher modifies inventory",
+			cfg: &scalibr.ScanConfig{
+				FilesystemExtractors: []filesystem.Extractor{fakeExtractor},
+				Enrichers:            []enricher.Enricher{fen.New("enricher", 3, setOwner)},
+				ScanRoots:            tmpRoot,
+			},
+			want: &scalibr.ScanResult{
+				Status: success,
+				PluginStatus: []*plugin.Status{
+					&plugin.Status{Name: "enricher", Version: 3, Status: success},
+					&plugin.Status{Name: "python/wheelegg", Version: 1, Status: extSuccess},
+				},
+				Inventories: []*extractor.Inventory{enrichedInventory},
+				Findings:    []*detector.Finding{},
+			},
+		},
+		{
+			desc: "Enricher plugin failed",
+			cfg: &scalibr.ScanConfig{
+				FilesystemExtractors: []filesystem.Extractor{fakeExtractor},
+				Enrichers: []enricher.Enricher{
+					fen.New("enricher", 3, func(context.Context, []*extractor.Inventory) error {
+						return errors.New(pluginFailure)
+					}),
+				},
+				ScanRoots: tmpRoot,
+			},
+			want: &scalibr.ScanResult{
+				Status: success,
+				PluginStatus: []*plugin.Status{
+					&plugin.Status{Name: "enricher", Version: 3, Status: detFailure},
+					&plugin.Status{Name: "python/wheelegg", Version: 1, Status: extSuccess},
+				},
+				Inventories: []*extractor.Inventory{inventory},
+				Findings:    []*detector.Finding{},
+			},
+		},
+		{
+			desc: "Transformer drops inventory after detection",
+			cfg: &scalibr.ScanConfig{
+				FilesystemExtractors: []filesystem.Extractor{fakeExtractor},
+				Detectors:            []detector.Detector{fd.New("detector", 2, finding, nil)},
+				Transformers: []transformer.Transformer{
+					ftr.New("transformer", 4, func(context.Context, []*extractor.Inventory) ([]*extractor.Inventory, error) {
+						return []*extractor.Inventory{}, nil
+					}),
+				},
+				ScanRoots: tmpRoot,
+			},
+			want: &scalibr.ScanResult{
+				Status: success,
+				PluginStatus: []*plugin.Status{
+					&plugin.Status{Name: "detector", Version: 2, Status: success},
+					&plugin.Status{Name: "python/wheelegg", Version: 1, Status: extSuccess},
+					&plugin.Status{Name: "transformer", Version: 4, Status: success},
+				},
+				Inventories: []*extractor.Inventory{},
+				Findings:    []*detector.Finding{withDetectorName(finding, "detector")},
+			},
+		},
+		{
+			desc: "Plugins with unsatisfied requirements are skipped",
+			cfg: &scalibr.ScanConfig{
+				FilesystemExtractors: []filesystem.Extractor{fakeExtractor, &fakeExNeedsNetwork{}},
+				Detectors:            []detector.Detector{&fakeDetNeedsFS{}},
+				Capabilities:         &plugin.Capabilities{DirectFS: true},
+				FilterByCapabilities: true,
+				ScanRoots:            tmpRoot,
+			},
+			want: &scalibr.ScanResult{
+				Status: success,
+				PluginStatus: []*plugin.Status{
+					&plugin.Status{Name: "fake-detector", Version: 0, Status: success},
+					&plugin.Status{Name: "fake-extractor", Version: 0, Status: &plugin.ScanStatus{
+						Status:        plugin.ScanStatusSkipped,
+						FailureReason: "plugin fake-extractor can't be enabled: needs network access but scan environment doesn't provide it",
+					}},
+					&plugin.Status{Name: "python/wheelegg", Version: 1, Status: extSuccess},
 				},
 				Inventories: []*extractor.Inventory{inventory},
 				Findings:    []*detector.Finding{},
@@ -166,6 +271,40 @@ func TestScan(t *testing.T) {
 				Findings:    []*detector.Finding{},
 			},
 		},
+		{
+			desc: "Metadata is copied to the result",
+			cfg: &scalibr.ScanConfig{
+				FilesystemExtractors: []filesystem.Extractor{fakeExtractor},
+				ScanRoots:            tmpRoot,
+				Metadata:             map[string]string{"role": "web", "build_id": "1234"},
+			},
+			want: &scalibr.ScanResult{
+				Status: success,
+				PluginStatus: []*plugin.Status{
+					&plugin.Status{Name: "python/wheelegg", Version: 1, Status: extSuccess},
+				},
+				Inventories: []*extractor.Inventory{inventory},
+				Findings:    []*detector.Finding{},
+				Metadata:    map[string]string{"role": "web", "build_id": "1234"},
+			},
+		},
+		{
+			desc: "Metadata is copied to failed results",
+			cfg: &scalibr.ScanConfig{
+				FilesystemExtractors: []filesystem.Extractor{fakeExtractor},
+				ScanRoots:            []*scalibrfs.ScanRoot{},
+				Metadata:             map[string]string{"role": "web"},
+			},
+			want: &scalibr.ScanResult{
+				Status: &plugin.ScanStatus{
+					Status:        plugin.ScanStatusFailed,
+					FailureReason: "no scan root specified",
+				},
+				Inventories: []*extractor.Inventory{},
+				Findings:    []*detector.Finding{},
+				Metadata:    map[string]string{"role": "web"},
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -175,6 +314,8 @@ func TestScan(t *testing.T) {
 			// We can't mock the time from here so we skip it in the comparison.
 			tc.want.StartTime = got.StartTime
 			tc.want.EndTime = got.EndTime
+			// The fingerprint is tested in TestPURLFingerprint.
+			tc.want.PURLFingerprint = scalibr.PURLFingerprint(tc.want.Inventories)
 
 			if diff := cmp.Diff(tc.want, got, fe.AllowUnexported); diff != "" {
 				t.Errorf("scalibr.New().Scan(%v): unexpected diff (-want +got):\n%s", tc.cfg, diff)
@@ -183,6 +324,226 @@ func TestScan(t *testing.T) {
 	}
 }
 
+func TestScanOSRelease(t *testing.T) {
+	withOSRelease := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(withOSRelease, "etc"), 0755); err != nil {
+		t.Fatalf("os.MkdirAll(): %v", err)
+	}
+	content := "PRETTY_NAME=\"Debian GNU/Linux 12 (bookworm)\"\nID=debian\nVERSION_ID=\"12\"\nVERSION_CODENAME=bookworm\n"
+	if err := os.WriteFile(filepath.Join(withOSRelease, "etc", "os-release"), []byte(content), 0644); err != nil {
+		t.Fatalf("os.WriteFile(): %v", err)
+	}
+	withoutOSRelease := t.TempDir()
+
+	testCases := []struct {
+		desc string
+		root string
+		want *scalibr.OSRelease
+	}{
+		{
+			desc: "os-release found",
+			root: withOSRelease,
+			want: &scalibr.OSRelease{
+				ID:              "debian",
+				VersionID:       "12",
+				VersionCodename: "bookworm",
+				PrettyName:      "Debian GNU/Linux 12 (bookworm)",
+			},
+		},
+		{
+			desc: "No os-release",
+			root: withoutOSRelease,
+			want: nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			cfg := &scalibr.ScanConfig{
+				ScanRoots: []*scalibrfs.ScanRoot{&scalibrfs.ScanRoot{FS: scalibrfs.DirFS(tc.root), Path: tc.root}},
+			}
+			got := scalibr.New().Scan(context.Background(), cfg)
+			if got.Status.Status != plugin.ScanStatusSucceeded {
+				t.Fatalf("scalibr.New().Scan(%v): got status %v, want success", cfg, got.Status)
+			}
+			if diff := cmp.Diff(tc.want, got.OSRelease); diff != "" {
+				t.Errorf("scalibr.New().Scan(%v): unexpected OSRelease (-want +got):\n%s", cfg, diff)
+			}
+		})
+	}
+}
+
+func TestPURLFingerprint(t *testing.T) {
+	ex := fe.New("python/wheelegg", 1, nil, nil)
+	inv := func(name, version, location string) *extractor.Inventory {
+		return &extractor.Inventory{Name: name, Version: version, Locations: []string{location}, Extractor: ex}
+	}
+	// sha256 of "pkg:pypi/a@1.0\npkg:pypi/b@2.0\n".
+	abFingerprint := "c0b728d0102faa2bd134d0129f074245d45f215f8335befe045a797b3cec8a93"
+
+	testCases := []struct {
+		desc        string
+		inventories []*extractor.Inventory
+		want        string
+	}{
+		{
+			desc:        "No inventory",
+			inventories: nil,
+			want:        "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855",
+		},
+		{
+			desc:        "Sorted inventory",
+			inventories: []*extractor.Inventory{inv("a", "1.0", "x"), inv("b", "2.0", "y")},
+			want:        abFingerprint,
+		},
+		{
+			desc:        "Unsorted inventory",
+			inventories: []*extractor.Inventory{inv("b", "2.0", "y"), inv("a", "1.0", "x")},
+			want:        abFingerprint,
+		},
+		{
+			desc:        "Same package in several locations",
+			inventories: []*extractor.Inventory{inv("a", "1.0", "x"), inv("b", "2.0", "y"), inv("a", "1.0", "z")},
+			want:        abFingerprint,
+		},
+		{
+			desc:        "Inventory without an extractor is skipped",
+			inventories: []*extractor.Inventory{inv("a", "1.0", "x"), inv("b", "2.0", "y"), &extractor.Inventory{Name: "c"}},
+			want:        abFingerprint,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			if got := scalibr.PURLFingerprint(tc.inventories); got != tc.want {
+				t.Errorf("scalibr.PURLFingerprint(%v): got %q, want %q", tc.inventories, got, tc.want)
+			}
+		})
+	}
+
+	other := scalibr.PURLFingerprint([]*extractor.Inventory{inv("a", "1.1", "x"), inv("b", "2.0", "y")})
+	if other == abFingerprint {
+		t.Errorf("scalibr.PURLFingerprint() returned the same fingerprint for different package versions")
+	}
+}
+
+func TestInventoryCountByScanRoot(t *testing.T) {
+	inventories := []*extractor.Inventory{
+		&extractor.Inventory{Name: "a", ScanRoot: "C:\\"},
+		&extractor.Inventory{Name: "b", ScanRoot: "D:\\"},
+		&extractor.Inventory{Name: "c", ScanRoot: "C:\\"},
+		&extractor.Inventory{Name: "standalone"},
+	}
+	want := map[string]int{"C:\\": 2, "D:\\": 1, "": 1}
+	got := scalibr.InventoryCountByScanRoot(inventories)
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("scalibr.InventoryCountByScanRoot(%v): unexpected diff (-want +got):\n%s", inventories, diff)
+	}
+}
+
+func TestScan_InMemory(t *testing.T) {
+	mixLock := `%{
+  "plug": {:hex, :plug, "1.15.3", "abcd", [:mix], [], "hexpm", "ef01"},
+}
+`
+	files := map[string][]byte{
+		"/app/mix.lock":  []byte(mixLock),
+		"other/mix.lock": []byte(`%{}`),
+		"README.md":      []byte("# Not a lockfile"),
+	}
+	e := mixlock.New(mixlock.DefaultConfig())
+	cfg := scalibr.InMemoryScanConfig(files, []filesystem.Extractor{e})
+
+	got := scalibr.New().Scan(context.Background(), cfg)
+
+	if got.Status.Status != plugin.ScanStatusSucceeded {
+		t.Fatalf("Scan(%v): unexpected status %v, want success", files, got.Status)
+	}
+	want := []*extractor.Inventory{
+		&extractor.Inventory{
+			Name:    "plug",
+			Version: "1.15.3",
+			Metadata: &mixlock.Metadata{
+				Source:   mixlock.SourceHex,
+				Repo:     "hexpm",
+				Checksum: "ef01",
+			},
+			Locations: []string{"app/mix.lock"},
+			Extractor: e,
+		},
+	}
+	if diff := cmp.Diff(want, got.Inventories, cmp.AllowUnexported(mixlock.Extractor{})); diff != "" {
+		t.Errorf("Scan(%v): unexpected inventory (-want +got):\n%s", files, diff)
+	}
+}
+
+// cleanupExtractor records the order in which the plugins are cleaned up.
+type cleanupExtractor struct {
+	filesystem.Extractor
+	cleaned *[]string
+}
+
+func (e cleanupExtractor) Cleanup() error {
+	*e.cleaned = append(*e.cleaned, e.Name())
+	return nil
+}
+
+// cleanupDetector records the order in which the plugins are cleaned up.
+type cleanupDetector struct {
+	detector.Detector
+	cleaned *[]string
+}
+
+func (d cleanupDetector) Cleanup() error {
+	*d.cleaned = append(*d.cleaned, d.Name())
+	return errors.New("cleanup failed")
+}
+
+func TestScan_Cleanup(t *testing.T) {
+	testCases := []struct {
+		desc       string
+		scanRoots  []*scalibrfs.ScanRoot
+		wantStatus plugin.ScanStatusEnum
+	}{
+		{
+			desc:       "successful_scan",
+			scanRoots:  []*scalibrfs.ScanRoot{scalibrfs.RealFSScanRoot(t.TempDir())},
+			wantStatus: plugin.ScanStatusSucceeded,
+		},
+		{
+			desc:       "failed_scan",
+			wantStatus: plugin.ScanStatusFailed,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			var cleaned []string
+			cfg := &scalibr.ScanConfig{
+				ScanRoots: tc.scanRoots,
+				FilesystemExtractors: []filesystem.Extractor{
+					cleanupExtractor{Extractor: fe.New("ex1", 1, nil, nil), cleaned: &cleaned},
+					fe.New("ex2", 1, nil, nil),
+				},
+				Detectors: []detector.Detector{
+					cleanupDetector{Detector: fd.New("det", 1, nil, nil), cleaned: &cleaned},
+				},
+			}
+
+			got := scalibr.New().Scan(context.Background(), cfg)
+			if got.Status.Status != tc.wantStatus {
+				t.Errorf("Scan(%v): got status %v, want %v", cfg, got.Status, tc.wantStatus)
+			}
+			// The failed cleanup of the detector doesn't fail the scan or stop the
+			// other plugins from being cleaned up.
+			want := []string{"det", "ex1"}
+			if diff := cmp.Diff(want, cleaned); diff != "" {
+				t.Errorf("Scan(%v): unexpected cleanup order (-want +got):\n%s", cfg, diff)
+			}
+		})
+	}
+}
+
 func withDetectorName(f *detector.Finding, det string) *detector.Finding {
 	copy := *f
 	copy.Detectors = []string{det}
@@ -286,7 +647,7 @@ func (fakeExNeedsNetwork) Requirements() *plugin.Capabilities {
 type fakeDetNeedsFS struct {
 }
 
-func (fakeDetNeedsFS) Name() string                 { return "fake-extractor" }
+func (fakeDetNeedsFS) Name() string                 { return "fake-detector" }
 func (fakeDetNeedsFS) Version() int                 { return 0 }
 func (fakeDetNeedsFS) RequiredExtractors() []string { return nil }
 func (fakeDetNeedsFS) Scan(ctx context.Context, scanRoot *scalibrfs.ScanRoot, ix *inventoryindex.InventoryIndex) ([]*detector.Finding, error) {
@@ -361,3 +722,42 @@ func TestValidatePluginRequirements(t *testing.T) {
 		})
 	}
 }
+
+func TestScan_PreviousInventoryWithoutExtractor(t *testing.T) {
+	tmp := t.TempDir()
+	path := filepath.Join(tmp, "file.txt")
+	if err := os.WriteFile(path, []byte("Content"), 0644); err != nil {
+		t.Fatalf("os.WriteFile(%q): %v", path, err)
+	}
+	since := time.Now()
+	old := since.Add(-time.Hour)
+	if err := os.Chtimes(path, old, old); err != nil {
+		t.Fatalf("os.Chtimes(%q): %v", path, err)
+	}
+
+	fakeExtractor := fe.New(
+		"python/wheelegg", 1, []string{"file.txt"},
+		map[string]fe.NamesErr{"file.txt": {Names: []string{"software"}, Err: nil}},
+	)
+	cfg := &scalibr.ScanConfig{
+		FilesystemExtractors: []filesystem.Extractor{fakeExtractor},
+		ScanRoots:            []*scalibrfs.ScanRoot{&scalibrfs.ScanRoot{FS: scalibrfs.DirFS(tmp), Path: tmp}},
+		Since:                since,
+		PreviousInventory: []*extractor.Inventory{
+			&extractor.Inventory{Name: "software", Locations: []string{"file.txt"}, Extractor: fakeExtractor},
+			&extractor.Inventory{Name: "software", Locations: []string{"file.txt"}},
+		},
+	}
+
+	got := scalibr.New().Scan(context.Background(), cfg)
+	want := &plugin.ScanStatus{
+		Status:        plugin.ScanStatusFailed,
+		FailureReason: `previous inventory "software" at [file.txt] has no extractor`,
+	}
+	if diff := cmp.Diff(want, got.Status); diff != "" {
+		t.Errorf("Scan(%v): unexpected status (-want +got):\n%s", cfg, diff)
+	}
+	if len(got.Inventories) != 0 {
+		t.Errorf("Scan(%v): got inventory %v, want none", cfg, got.Inventories)
+	}
+}
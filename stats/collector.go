@@ -24,6 +24,11 @@ import (
 
 // Collector is a component which is notified when certain events occur. It can be implemented with
 // different metric backends to enable monitoring of Scalibr.
+//
+// Concurrency: Scalibr may call the methods of a Collector from multiple goroutines at the same
+// time, e.g. when plugins run in parallel. Implementations must be safe for concurrent use.
+// Implementations which are not can be wrapped with NewSyncCollector, which serializes all calls.
+// Flush is the exception: it is called exactly once, after all other calls have returned.
 type Collector interface {
 	AfterInodeVisited(path string)
 	AfterExtractorRun(name string, runtime time.Duration, err error)
@@ -47,6 +52,15 @@ type Collector interface {
 	// the filesystem handling code. This allows plugins to report internal state
 	// for metric collection.
 	AfterFileExtracted(pluginName string, filestats *FileExtractedStats)
+
+	// AfterFilesUnmatched is called after a filesystem walk if unmatched file
+	// reporting is enabled. It receives a histogram of the files that no
+	// extractor required.
+	AfterFilesUnmatched(filestats *UnmatchedFilesStats)
+
+	// Flush is called once at the end of a scan, after AfterScan. Collectors that buffer or
+	// aggregate metrics should emit them here.
+	Flush()
 }
 
 // NoopCollector implements Collector by doing nothing.
@@ -72,3 +86,9 @@ func (c NoopCollector) AfterFileRequired(pluginName string, filestats *FileRequi
 
 // AfterFileExtracted implements Collector by doing nothing.
 func (c NoopCollector) AfterFileExtracted(pluginName string, filestats *FileExtractedStats) {}
+
+// AfterFilesUnmatched implements Collector by doing nothing.
+func (c NoopCollector) AfterFilesUnmatched(filestats *UnmatchedFilesStats) {}
+
+// Flush implements Collector by doing nothing.
+func (c NoopCollector) Flush() {}
@@ -0,0 +1,173 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stats
+
+import (
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/google/osv-scalibr/plugin"
+)
+
+// Summary contains aggregate metrics about a scan, computed by SyncCollector.
+type Summary struct {
+	InodesVisited   int
+	ExtractorRuns   int
+	ExtractorErrors int
+	DetectorRuns    int
+	DetectorErrors  int
+	FilesExtracted  int
+	// Runtime of the whole scan, as reported to AfterScan.
+	ScanRuntime time.Duration
+	// Percentiles of the individual extractor runtimes.
+	ExtractorRuntimeP50 time.Duration
+	ExtractorRuntimeP90 time.Duration
+	ExtractorRuntimeP99 time.Duration
+	ExtractorRuntimeMax time.Duration
+}
+
+// SyncCollector wraps a Collector and serializes all calls to it, making it safe
+// to use from multiple goroutines. It also aggregates the events it sees into a
+// Summary which is finalized when Flush is called.
+type SyncCollector struct {
+	mu                sync.Mutex
+	c                 Collector
+	summary           Summary
+	extractorRuntimes []time.Duration
+	flushed           bool
+}
+
+// NewSyncCollector returns a SyncCollector wrapping c. If c is nil, only the
+// aggregate metrics are collected.
+func NewSyncCollector(c Collector) *SyncCollector {
+	if c == nil {
+		c = NoopCollector{}
+	}
+	return &SyncCollector{c: c}
+}
+
+// AfterInodeVisited implements Collector.
+func (s *SyncCollector) AfterInodeVisited(path string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.summary.InodesVisited++
+	s.c.AfterInodeVisited(path)
+}
+
+// AfterExtractorRun implements Collector.
+func (s *SyncCollector) AfterExtractorRun(name string, runtime time.Duration, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.summary.ExtractorRuns++
+	if err != nil {
+		s.summary.ExtractorErrors++
+	}
+	s.extractorRuntimes = append(s.extractorRuntimes, runtime)
+	s.c.AfterExtractorRun(name, runtime, err)
+}
+
+// AfterDetectorRun implements Collector.
+func (s *SyncCollector) AfterDetectorRun(name string, runtime time.Duration, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.summary.DetectorRuns++
+	if err != nil {
+		s.summary.DetectorErrors++
+	}
+	s.c.AfterDetectorRun(name, runtime, err)
+}
+
+// AfterScan implements Collector.
+func (s *SyncCollector) AfterScan(runtime time.Duration, status *plugin.ScanStatus) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.summary.ScanRuntime = runtime
+	s.c.AfterScan(runtime, status)
+}
+
+// AfterResultsExported implements Collector.
+func (s *SyncCollector) AfterResultsExported(destination string, bytes int, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.c.AfterResultsExported(destination, bytes, err)
+}
+
+// AfterFileRequired implements Collector.
+func (s *SyncCollector) AfterFileRequired(pluginName string, filestats *FileRequiredStats) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.c.AfterFileRequired(pluginName, filestats)
+}
+
+// AfterFileExtracted implements Collector.
+func (s *SyncCollector) AfterFileExtracted(pluginName string, filestats *FileExtractedStats) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.summary.FilesExtracted++
+	s.c.AfterFileExtracted(pluginName, filestats)
+}
+
+// AfterFilesUnmatched implements Collector.
+func (s *SyncCollector) AfterFilesUnmatched(filestats *UnmatchedFilesStats) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.c.AfterFilesUnmatched(filestats)
+}
+
+// Flush computes the final Summary and flushes the wrapped collector. Calls
+// after the first one are no-ops.
+func (s *SyncCollector) Flush() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.flushed {
+		return
+	}
+	s.flushed = true
+	s.computePercentiles()
+	s.c.Flush()
+}
+
+// Summary returns the aggregate metrics collected so far. Runtime percentiles
+// are computed on each call until Flush finalizes them.
+func (s *SyncCollector) Summary() Summary {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.flushed {
+		s.computePercentiles()
+	}
+	return s.summary
+}
+
+func (s *SyncCollector) computePercentiles() {
+	if len(s.extractorRuntimes) == 0 {
+		return
+	}
+	slices.Sort(s.extractorRuntimes)
+	s.summary.ExtractorRuntimeP50 = percentile(s.extractorRuntimes, 50)
+	s.summary.ExtractorRuntimeP90 = percentile(s.extractorRuntimes, 90)
+	s.summary.ExtractorRuntimeP99 = percentile(s.extractorRuntimes, 99)
+	s.summary.ExtractorRuntimeMax = s.extractorRuntimes[len(s.extractorRuntimes)-1]
+}
+
+// percentile returns the p-th percentile of the sorted durations using the
+// nearest-rank method.
+func percentile(sorted []time.Duration, p int) time.Duration {
+	rank := (p*len(sorted) + 99) / 100
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
@@ -0,0 +1,101 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stats_test
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/osv-scalibr/stats"
+)
+
+// countingCollector is deliberately not thread-safe so that the race detector
+// flags any unserialized calls.
+type countingCollector struct {
+	stats.NoopCollector
+	inodes  int
+	runs    int
+	flushes int
+}
+
+func (c *countingCollector) AfterInodeVisited(path string) { c.inodes++ }
+func (c *countingCollector) AfterExtractorRun(name string, runtime time.Duration, err error) {
+	c.runs++
+}
+func (c *countingCollector) Flush() { c.flushes++ }
+
+func TestSyncCollectorConcurrentCalls(t *testing.T) {
+	inner := &countingCollector{}
+	s := stats.NewSyncCollector(inner)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				s.AfterInodeVisited("a")
+				s.AfterExtractorRun("ex", time.Millisecond, nil)
+			}
+		}()
+	}
+	wg.Wait()
+	s.Flush()
+	s.Flush()
+
+	if inner.inodes != 1000 || inner.runs != 1000 {
+		t.Errorf("wrapped collector got %d inodes and %d runs, want 1000 and 1000", inner.inodes, inner.runs)
+	}
+	if inner.flushes != 1 {
+		t.Errorf("wrapped collector flushed %d times, want 1", inner.flushes)
+	}
+}
+
+func TestSyncCollectorSummary(t *testing.T) {
+	s := stats.NewSyncCollector(nil)
+	for i := 1; i <= 100; i++ {
+		var err error
+		if i%10 == 0 {
+			err = errors.New("failed")
+		}
+		s.AfterExtractorRun("ex", time.Duration(i)*time.Millisecond, err)
+	}
+	s.AfterInodeVisited("a")
+	s.AfterInodeVisited("b")
+	s.AfterDetectorRun("det", time.Second, errors.New("failed"))
+	s.AfterFileExtracted("ex", &stats.FileExtractedStats{Path: "a"})
+	s.AfterScan(time.Minute, nil)
+	s.Flush()
+
+	want := stats.Summary{
+		InodesVisited:       2,
+		ExtractorRuns:       100,
+		ExtractorErrors:     10,
+		DetectorRuns:        1,
+		DetectorErrors:      1,
+		FilesExtracted:      1,
+		ScanRuntime:         time.Minute,
+		ExtractorRuntimeP50: 50 * time.Millisecond,
+		ExtractorRuntimeP90: 90 * time.Millisecond,
+		ExtractorRuntimeP99: 99 * time.Millisecond,
+		ExtractorRuntimeMax: 100 * time.Millisecond,
+	}
+	if diff := cmp.Diff(want, s.Summary()); diff != "" {
+		t.Errorf("Summary() unexpected diff (-want +got):\n%s", diff)
+	}
+}
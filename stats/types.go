@@ -64,4 +64,18 @@ const (
 	// FileExtractedResultErrorMemoryLimitExceeded indicates that the extraction
 	// failed because the memory limit inside the plugin was exceeded.
 	FileExtractedResultErrorMemoryLimitExceeded = "FILE_EXTRACTED_RESULT_ERROR_MEMORY_LIMIT_EXCEEDED"
+
+	// FileExtractedResultErrorMalformedInput indicates that the extraction
+	// failed because the content of the file was malformed.
+	FileExtractedResultErrorMalformedInput FileExtractedResult = "FILE_EXTRACTED_RESULT_ERROR_MALFORMED_INPUT"
 )
+
+// UnmatchedFilesStats summarizes the files that no extractor required during a
+// filesystem walk. Individual paths aren't listed, only a histogram by file
+// extension.
+type UnmatchedFilesStats struct {
+	Total int
+	// File extension (e.g. ".txt", or "<none>" for files without one) to the
+	// number of unmatched files with that extension.
+	ByExtension map[string]int
+}
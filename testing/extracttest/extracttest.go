@@ -0,0 +1,177 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package extracttest provides golden file helpers for extractor tests.
+//
+// Instead of spelling out the expected inventory in the test, the result of the
+// extraction is compared against a checked-in JSON file:
+// ```
+// got, err := e.Extract(ctx, input)
+// ...
+// extracttest.CompareGolden(t, "testdata/venv.golden.json", got)
+// ```
+// When the behavior of the extractor changes intentionally, rewrite the golden
+// files by running the tests with the -update flag and review the diff:
+// ```
+// go test ./extractor/filesystem/language/python/virtualenv/... -update
+// ```
+package extracttest
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/osv-scalibr/extractor"
+)
+
+var update = flag.Bool("update", false, "Rewrite the golden files with the current test output")
+
+// goldenInventory is the JSON representation of an Inventory in golden files.
+// The Extractor field is left out since it's an implementation rather than
+// data. ScanRoot is also set by the core library, it's empty when extractors
+// are called directly but compared for inventory from full scans.
+type goldenInventory struct {
+	Name            string                          `json:"name"`
+	Version         string                          `json:"version"`
+	SourceCode      *extractor.SourceCodeIdentifier `json:"sourceCode,omitempty"`
+	Locations       []string                        `json:"locations,omitempty"`
+	LocationDetails []*goldenLocation               `json:"locationDetails,omitempty"`
+	ScanRoot        string                          `json:"scanRoot,omitempty"`
+	MetadataType    string                          `json:"metadataType,omitempty"`
+	Metadata        any                             `json:"metadata,omitempty"`
+	Annotations     []extractor.Annotation          `json:"annotations,omitempty"`
+	Relationships   []*extractor.Relationship       `json:"relationships,omitempty"`
+	Confidence      string                          `json:"confidence,omitempty"`
+	Evidence        string                          `json:"evidence,omitempty"`
+}
+
+// goldenLocation is the JSON representation of a structured location in
+// golden files.
+type goldenLocation struct {
+	Path   string `json:"path"`
+	Kind   string `json:"kind,omitempty"`
+	Line   int    `json:"line,omitempty"`
+	Offset int64  `json:"offset,omitempty"`
+}
+
+// InventoryLess orders inventory by name, version and locations. It can be
+// used to sort the result of extractors that don't return a stable order, e.g.
+// with cmpopts.SortSlices(extracttest.InventoryLess).
+func InventoryLess(a, b *extractor.Inventory) bool {
+	if a.Name != b.Name {
+		return a.Name < b.Name
+	}
+	if a.Version != b.Version {
+		return a.Version < b.Version
+	}
+	return slices.Compare(a.Locations, b.Locations) < 0
+}
+
+// CompareGolden reports a test error if the inventory doesn't match the golden
+// file at goldenPath. The order of the inventory doesn't matter. If the test
+// runs with -update, the golden file is rewritten instead.
+func CompareGolden(tb testing.TB, goldenPath string, got []*extractor.Inventory) {
+	tb.Helper()
+	compareGolden(tb, goldenPath, got, *update)
+}
+
+func compareGolden(tb testing.TB, goldenPath string, got []*extractor.Inventory, update bool) {
+	tb.Helper()
+	gotJSON, err := marshalGolden(got)
+	if err != nil {
+		tb.Fatalf("failed to marshal inventory: %v", err)
+	}
+
+	if update {
+		if err := os.WriteFile(goldenPath, gotJSON, 0644); err != nil {
+			tb.Fatalf("failed to update golden file: %v", err)
+		}
+		return
+	}
+
+	wantJSON, err := os.ReadFile(goldenPath)
+	if err != nil {
+		tb.Fatalf("failed to read golden file (run with -update to create it): %v", err)
+	}
+	// Compare the decoded values so that the diff isn't affected by formatting.
+	var want, gotValue any
+	if err := json.Unmarshal(wantJSON, &want); err != nil {
+		tb.Fatalf("failed to parse golden file %s: %v", goldenPath, err)
+	}
+	if err := json.Unmarshal(gotJSON, &gotValue); err != nil {
+		tb.Fatalf("failed to parse inventory JSON: %v", err)
+	}
+	if diff := cmp.Diff(want, gotValue); diff != "" {
+		tb.Errorf("inventory doesn't match golden file %s (run with -update to rewrite it) (-want +got):\n%s", goldenPath, diff)
+	}
+}
+
+func marshalGolden(inventory []*extractor.Inventory) ([]byte, error) {
+	sorted := slices.Clone(inventory)
+	slices.SortStableFunc(sorted, func(a, b *extractor.Inventory) int {
+		switch {
+		case InventoryLess(a, b):
+			return -1
+		case InventoryLess(b, a):
+			return 1
+		default:
+			return 0
+		}
+	})
+
+	golden := make([]*goldenInventory, 0, len(sorted))
+	for _, i := range sorted {
+		g := &goldenInventory{
+			Name:          i.Name,
+			Version:       i.Version,
+			SourceCode:    i.SourceCode,
+			Locations:     i.Locations,
+			ScanRoot:      i.ScanRoot,
+			Metadata:      i.Metadata,
+			Annotations:   i.Annotations,
+			Relationships: i.Relationships,
+			Evidence:      i.Evidence,
+		}
+		for _, l := range i.LocationDetails {
+			gl := &goldenLocation{Path: l.Path, Line: l.Line, Offset: l.Offset}
+			if l.Kind != extractor.LocationKindUnspecified {
+				gl.Kind = l.Kind.String()
+			}
+			g.LocationDetails = append(g.LocationDetails, gl)
+		}
+		if i.Confidence != extractor.ConfidenceUnspecified {
+			g.Confidence = i.Confidence.String()
+		}
+		if i.Metadata != nil {
+			g.MetadataType = strings.TrimPrefix(fmt.Sprintf("%T", i.Metadata), "*")
+		}
+		golden = append(golden, g)
+	}
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetIndent("", "  ")
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(golden); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
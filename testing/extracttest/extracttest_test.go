@@ -0,0 +1,165 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package extracttest
+
+import (
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/google/osv-scalibr/extractor"
+)
+
+type testMetadata struct {
+	Author string `json:"author"`
+}
+
+// fakeTB records the reported errors instead of failing the test.
+type fakeTB struct {
+	testing.TB
+	errors []string
+}
+
+func (f *fakeTB) Helper() {}
+
+func (f *fakeTB) Errorf(format string, args ...any) {
+	f.errors = append(f.errors, fmt.Sprintf(format, args...))
+}
+
+func inventory() []*extractor.Inventory {
+	return []*extractor.Inventory{
+		{
+			Name:      "urllib3",
+			Version:   "2.0.7",
+			Locations: []string{"venv/lib/python3.11/site-packages/urllib3-2.0.7.dist-info/METADATA"},
+		},
+		{
+			Name:      "requests",
+			Version:   "2.31.0",
+			Locations: []string{"venv/lib/python3.11/site-packages/requests-2.31.0.dist-info/METADATA"},
+			Metadata:  &testMetadata{Author: "Kenneth Reitz"},
+		},
+	}
+}
+
+func TestCompareGolden(t *testing.T) {
+	tests := []struct {
+		name       string
+		inventory  []*extractor.Inventory
+		wantErrors int
+	}{
+		{
+			name:      "matches in different order",
+			inventory: inventory(),
+		},
+		{
+			name:       "different version",
+			inventory:  append(inventory()[1:], &extractor.Inventory{Name: "urllib3", Version: "2.0.6", Locations: []string{"venv/lib/python3.11/site-packages/urllib3-2.0.7.dist-info/METADATA"}}),
+			wantErrors: 1,
+		},
+		{
+			name:       "missing inventory",
+			inventory:  inventory()[1:],
+			wantErrors: 1,
+		},
+		{
+			name: "different metadata type",
+			inventory: append(inventory()[:1], &extractor.Inventory{
+				Name:      "requests",
+				Version:   "2.31.0",
+				Locations: []string{"venv/lib/python3.11/site-packages/requests-2.31.0.dist-info/METADATA"},
+				Metadata:  &struct{ Author string }{Author: "Kenneth Reitz"},
+			}),
+			wantErrors: 1,
+		},
+		{
+			name: "different location details",
+			inventory: append(inventory()[1:], &extractor.Inventory{
+				Name:      "urllib3",
+				Version:   "2.0.7",
+				Locations: []string{"venv/lib/python3.11/site-packages/urllib3-2.0.7.dist-info/METADATA"},
+				LocationDetails: []*extractor.Location{{
+					Path: "venv/lib/python3.11/site-packages/urllib3-2.0.7.dist-info/METADATA",
+					Kind: extractor.LocationKindInstalled,
+					Line: 3,
+				}},
+			}),
+			wantErrors: 1,
+		},
+		{
+			name: "different scan root",
+			inventory: append(inventory()[1:], &extractor.Inventory{
+				Name:      "urllib3",
+				Version:   "2.0.7",
+				Locations: []string{"venv/lib/python3.11/site-packages/urllib3-2.0.7.dist-info/METADATA"},
+				ScanRoot:  "/mnt/image",
+			}),
+			wantErrors: 1,
+		},
+		{
+			name: "different confidence and evidence",
+			inventory: append(inventory()[1:], &extractor.Inventory{
+				Name:       "urllib3",
+				Version:    "2.0.7",
+				Locations:  []string{"venv/lib/python3.11/site-packages/urllib3-2.0.7.dist-info/METADATA"},
+				Confidence: extractor.ConfidenceLow,
+				Evidence:   "version from the directory name",
+			}),
+			wantErrors: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tb := &fakeTB{TB: t}
+			compareGolden(tb, "testdata/inventory.golden.json", tt.inventory, false)
+			if len(tb.errors) != tt.wantErrors {
+				t.Errorf("compareGolden() reported %d errors, want %d: %v", len(tb.errors), tt.wantErrors, tb.errors)
+			}
+		})
+	}
+}
+
+func TestCompareGoldenUpdate(t *testing.T) {
+	goldenPath := filepath.Join(t.TempDir(), "inventory.golden.json")
+	compareGolden(t, goldenPath, inventory(), true)
+
+	tb := &fakeTB{TB: t}
+	compareGolden(tb, goldenPath, inventory(), false)
+	if len(tb.errors) != 0 {
+		t.Errorf("compareGolden() after update reported errors: %v", tb.errors)
+	}
+	compareGolden(tb, goldenPath, inventory()[1:], false)
+	if len(tb.errors) != 1 {
+		t.Errorf("compareGolden() with changed inventory reported %d errors, want 1", len(tb.errors))
+	}
+}
+
+// TestGoldenInventoryFields fails if a field is added to extractor.Inventory
+// without adding it to the golden format, which would make golden tests
+// silently ignore it.
+func TestGoldenInventoryFields(t *testing.T) {
+	// Inventory fields that aren't stored in golden files.
+	excluded := map[string]bool{"Extractor": true}
+	golden := reflect.TypeOf(goldenInventory{})
+	inv := reflect.TypeOf(extractor.Inventory{})
+	for i := range inv.NumField() {
+		name := inv.Field(i).Name
+		if _, ok := golden.FieldByName(name); !ok && !excluded[name] {
+			t.Errorf("goldenInventory has no field for Inventory.%s, add it to the golden format", name)
+		}
+	}
+}
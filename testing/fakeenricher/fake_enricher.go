@@ -0,0 +1,61 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package fakeenricher provides an Enricher implementation to be used in tests.
+package fakeenricher
+
+import (
+	"context"
+
+	"github.com/google/osv-scalibr/enricher"
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/plugin"
+)
+
+// EnrichFunc is called by the fake enricher on the inventory it's run on.
+type EnrichFunc func(ctx context.Context, inventory []*extractor.Inventory) error
+
+// fakeEnricher is an Enricher implementation to be used in tests.
+type fakeEnricher struct {
+	name    string
+	version int
+	enrich  EnrichFunc
+}
+
+// New returns a fake enricher that calls enrich when it's run. If enrich is
+// nil the enricher leaves the inventory unchanged.
+func New(name string, version int, enrich EnrichFunc) enricher.Enricher {
+	return &fakeEnricher{
+		name:    name,
+		version: version,
+		enrich:  enrich,
+	}
+}
+
+// Name returns the enricher's name.
+func (e *fakeEnricher) Name() string { return e.name }
+
+// Version returns the enricher's version.
+func (e *fakeEnricher) Version() int { return e.version }
+
+// Requirements returns the enricher's requirements.
+func (e *fakeEnricher) Requirements() *plugin.Capabilities { return &plugin.Capabilities{} }
+
+// Enrich calls the predefined EnrichFunc.
+func (e *fakeEnricher) Enrich(ctx context.Context, inventory []*extractor.Inventory) error {
+	if e.enrich == nil {
+		return nil
+	}
+	return e.enrich(ctx, inventory)
+}
@@ -0,0 +1,62 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package faketransformer provides a Transformer implementation to be used in
+// tests.
+package faketransformer
+
+import (
+	"context"
+
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/plugin"
+	"github.com/google/osv-scalibr/transformer"
+)
+
+// TransformFunc is called by the fake transformer on the inventory it's run on.
+type TransformFunc func(ctx context.Context, inventory []*extractor.Inventory) ([]*extractor.Inventory, error)
+
+// fakeTransformer is a Transformer implementation to be used in tests.
+type fakeTransformer struct {
+	name      string
+	version   int
+	transform TransformFunc
+}
+
+// New returns a fake transformer that calls transform when it's run. If
+// transform is nil the transformer returns the inventory unchanged.
+func New(name string, version int, transform TransformFunc) transformer.Transformer {
+	return &fakeTransformer{
+		name:      name,
+		version:   version,
+		transform: transform,
+	}
+}
+
+// Name returns the transformer's name.
+func (t *fakeTransformer) Name() string { return t.name }
+
+// Version returns the transformer's version.
+func (t *fakeTransformer) Version() int { return t.version }
+
+// Requirements returns the transformer's requirements.
+func (t *fakeTransformer) Requirements() *plugin.Capabilities { return &plugin.Capabilities{} }
+
+// Transform calls the predefined TransformFunc.
+func (t *fakeTransformer) Transform(ctx context.Context, inventory []*extractor.Inventory) ([]*extractor.Inventory, error) {
+	if t.transform == nil {
+		return inventory, nil
+	}
+	return t.transform(ctx, inventory)
+}
@@ -0,0 +1,79 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package dropdev implements a transformer that removes development-only
+// dependencies from the inventory.
+package dropdev
+
+import (
+	"context"
+
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem/language/python/pipfilelock"
+	"github.com/google/osv-scalibr/extractor/filesystem/misc/bazel"
+	"github.com/google/osv-scalibr/plugin"
+)
+
+// Name is the unique name of this transformer.
+const Name = "drop-dev"
+
+// Transformer removes the inventory items that the extractors report as only
+// needed for development, e.g. the packages listed only in the "develop"
+// section of a Pipfile.lock or the Bazel modules declared with
+// dev_dependency = True. Items without information about their
+// dependency type are kept.
+type Transformer struct{}
+
+// New returns a drop-dev transformer.
+func New() *Transformer { return &Transformer{} }
+
+// Name of the transformer.
+func (Transformer) Name() string { return Name }
+
+// Version of the transformer.
+func (Transformer) Version() int { return 0 }
+
+// Requirements of the transformer.
+func (Transformer) Requirements() *plugin.Capabilities { return &plugin.Capabilities{} }
+
+// Transform returns the inventory without the development-only dependencies.
+func (Transformer) Transform(ctx context.Context, inventory []*extractor.Inventory) ([]*extractor.Inventory, error) {
+	result := make([]*extractor.Inventory, 0, len(inventory))
+	for _, i := range inventory {
+		if !devOnly(i) {
+			result = append(result, i)
+		}
+	}
+	return result, nil
+}
+
+// devOnly returns whether the inventory item is only needed for development.
+func devOnly(i *extractor.Inventory) bool {
+	switch m := i.Metadata.(type) {
+	case *pipfilelock.Metadata:
+		if len(m.Sections) == 0 {
+			return false
+		}
+		for _, s := range m.Sections {
+			if s != pipfilelock.SectionDevelop {
+				return false
+			}
+		}
+		return true
+	case *bazel.Metadata:
+		return m.DevDependency
+	default:
+		return false
+	}
+}
@@ -0,0 +1,45 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dropdev_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem/language/python/pipfilelock"
+	"github.com/google/osv-scalibr/extractor/filesystem/misc/bazel"
+	"github.com/google/osv-scalibr/transformer/dropdev"
+)
+
+func TestTransform(t *testing.T) {
+	prod := &extractor.Inventory{Name: "requests", Metadata: &pipfilelock.Metadata{Sections: []string{pipfilelock.SectionDefault}}}
+	dev := &extractor.Inventory{Name: "pytest", Metadata: &pipfilelock.Metadata{Sections: []string{pipfilelock.SectionDevelop}}}
+	both := &extractor.Inventory{Name: "six", Metadata: &pipfilelock.Metadata{Sections: []string{pipfilelock.SectionDefault, pipfilelock.SectionDevelop}}}
+	noSections := &extractor.Inventory{Name: "idna", Metadata: &pipfilelock.Metadata{}}
+	bazelProd := &extractor.Inventory{Name: "rules_go", Metadata: &bazel.Metadata{}}
+	bazelDev := &extractor.Inventory{Name: "googletest", Metadata: &bazel.Metadata{DevDependency: true}}
+	other := &extractor.Inventory{Name: "curl"}
+
+	got, err := dropdev.New().Transform(context.Background(), []*extractor.Inventory{prod, dev, both, noSections, bazelProd, bazelDev, other})
+	if err != nil {
+		t.Fatalf("Transform(): %v", err)
+	}
+	want := []*extractor.Inventory{prod, both, noSections, bazelProd, other}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Transform() unexpected diff (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,84 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package list provides the registry of transformer plugins that can be
+// enabled by name.
+package list
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/google/osv-scalibr/transformer"
+	"github.com/google/osv-scalibr/transformer/dropdev"
+	"github.com/google/osv-scalibr/transformer/taginternal"
+)
+
+// Default transformers that are recommended to be enabled. Transformers
+// change what the scan reports, so by default the inventory is left unchanged.
+var Default []transformer.Transformer = []transformer.Transformer{}
+
+// All transformers, including the ones added through Register.
+var All []transformer.Transformer = []transformer.Transformer{
+	dropdev.New(),
+	taginternal.New(nil),
+}
+
+var transformerNames = map[string][]transformer.Transformer{
+	"default": Default,
+}
+
+func init() {
+	for _, t := range All {
+		transformerNames[strings.ToLower(t.Name())] = []transformer.Transformer{t}
+	}
+}
+
+// Register makes a transformer available under its name, e.g. so that it can
+// be enabled from the command line. Transformers are usually registered from
+// an init function of the binary that wraps SCALIBR.
+func Register(t transformer.Transformer) error {
+	name := strings.ToLower(t.Name())
+	if _, ok := transformerNames[name]; ok || name == "all" {
+		return fmt.Errorf("there's already a transformer with the name %q", t.Name())
+	}
+	transformerNames[name] = []transformer.Transformer{t}
+	All = append(All, t)
+	return nil
+}
+
+// TransformersFromNames returns a deduplicated list of transformers from a
+// list of names. The transformers are returned in the order they're listed
+// in, which is also the order they run in.
+func TransformersFromNames(names []string) ([]transformer.Transformer, error) {
+	result := []transformer.Transformer{}
+	seen := make(map[string]bool)
+	for _, n := range names {
+		name := strings.ToLower(n)
+		ts, ok := transformerNames[name]
+		if name == "all" {
+			ts, ok = All, true
+		}
+		if !ok {
+			return nil, fmt.Errorf("unknown transformer %s", n)
+		}
+		for _, t := range ts {
+			if !seen[t.Name()] {
+				seen[t.Name()] = true
+				result = append(result, t)
+			}
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,96 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package list_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	ftr "github.com/google/osv-scalibr/testing/faketransformer"
+	tl "github.com/google/osv-scalibr/transformer/list"
+)
+
+func init() {
+	for _, name := range []string{"rename-ecosystems", "catalog/Owners"} {
+		if err := tl.Register(ftr.New(name, 1, nil)); err != nil {
+			panic(err)
+		}
+	}
+}
+
+func TestRegisterDuplicate(t *testing.T) {
+	for _, name := range []string{"rename-ecosystems", "RENAME-ECOSYSTEMS", "drop-dev", "default", "all"} {
+		if err := tl.Register(ftr.New(name, 2, nil)); err == nil {
+			t.Errorf("tl.Register(%q) succeeded, want error", name)
+		}
+	}
+}
+
+func TestTransformersFromNames(t *testing.T) {
+	testCases := []struct {
+		desc      string
+		names     []string
+		wantNames []string
+		wantErr   error
+	}{
+		{
+			desc:      "Default transformers",
+			names:     []string{"default"},
+			wantNames: []string{},
+		},
+		{
+			desc:      "Built-in transformers",
+			names:     []string{"tag-internal", "drop-dev"},
+			wantNames: []string{"tag-internal", "drop-dev"},
+		},
+		{
+			desc:      "Case-insensitive",
+			names:     []string{"CATALOG/owners"},
+			wantNames: []string{"catalog/Owners"},
+		},
+		{
+			desc:      "All transformers",
+			names:     []string{"all"},
+			wantNames: []string{"drop-dev", "tag-internal", "rename-ecosystems", "catalog/Owners"},
+		},
+		{
+			desc:      "Remove duplicates",
+			names:     []string{"catalog/Owners", "all"},
+			wantNames: []string{"catalog/Owners", "drop-dev", "tag-internal", "rename-ecosystems"},
+		},
+		{
+			desc:    "Nonexistent plugin",
+			names:   []string{"nonexistent"},
+			wantErr: cmpopts.AnyError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := tl.TransformersFromNames(tc.names)
+			if diff := cmp.Diff(tc.wantErr, err, cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("tl.TransformersFromNames(%v) error got diff (-want +got):\n%s", tc.names, diff)
+			}
+			var gotNames []string
+			for _, tr := range got {
+				gotNames = append(gotNames, tr.Name())
+			}
+			if diff := cmp.Diff(tc.wantNames, gotNames, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("tl.TransformersFromNames(%v): got diff (-want +got):\n%s", tc.names, diff)
+			}
+		})
+	}
+}
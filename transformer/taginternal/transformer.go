@@ -0,0 +1,107 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package taginternal implements a transformer that annotates the packages
+// developed by the scanning organization.
+package taginternal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/plugin"
+)
+
+const (
+	// Name is the unique name of this transformer.
+	Name = "tag-internal"
+
+	// NamePrefixesOption is the name of the Configure option that sets
+	// NamePrefixes.
+	NamePrefixesOption = "name_prefixes"
+)
+
+// Transformer adds the extractor.Internal annotation to the inventory items
+// whose name starts with one of NamePrefixes, e.g. "com.example." for Maven
+// packages or "@example/" for scoped npm packages.
+type Transformer struct {
+	NamePrefixes []string
+}
+
+// New returns a tag-internal transformer that tags the packages with the
+// given name prefixes.
+func New(namePrefixes []string) *Transformer {
+	return &Transformer{NamePrefixes: namePrefixes}
+}
+
+// Name of the transformer.
+func (Transformer) Name() string { return Name }
+
+// Version of the transformer.
+func (Transformer) Version() int { return 0 }
+
+// Requirements of the transformer.
+func (Transformer) Requirements() *plugin.Capabilities { return &plugin.Capabilities{} }
+
+// Configure sets the transformer options. Supported options:
+// * name_prefixes ([]string): Name prefixes of the internal packages.
+func (t *Transformer) Configure(options map[string]any) error {
+	for k, v := range options {
+		switch k {
+		case NamePrefixesOption:
+			values, ok := v.([]any)
+			if !ok {
+				return fmt.Errorf("option %q must be a list of strings, got %T", k, v)
+			}
+			prefixes := make([]string, 0, len(values))
+			for _, value := range values {
+				p, ok := value.(string)
+				if !ok {
+					return fmt.Errorf("option %q must be a list of strings, got an element of type %T", k, value)
+				}
+				prefixes = append(prefixes, p)
+			}
+			t.NamePrefixes = prefixes
+		default:
+			return fmt.Errorf("unknown option %q", k)
+		}
+	}
+	return nil
+}
+
+// Transform annotates the internal packages of the inventory.
+func (t Transformer) Transform(ctx context.Context, inventory []*extractor.Inventory) ([]*extractor.Inventory, error) {
+	if len(t.NamePrefixes) == 0 {
+		return nil, errors.New("no internal package names configured, set the \"name_prefixes\" option")
+	}
+	for _, i := range inventory {
+		if t.isInternal(i) && !slices.Contains(i.Annotations, extractor.Internal) {
+			i.Annotations = append(i.Annotations, extractor.Internal)
+		}
+	}
+	return inventory, nil
+}
+
+func (t Transformer) isInternal(i *extractor.Inventory) bool {
+	for _, p := range t.NamePrefixes {
+		if strings.HasPrefix(i.Name, p) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,122 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package taginternal_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/plugin"
+	"github.com/google/osv-scalibr/transformer/taginternal"
+)
+
+func TestTransform(t *testing.T) {
+	testCases := []struct {
+		desc            string
+		options         map[string]any
+		wantAnnotations [][]extractor.Annotation
+		wantErr         error
+	}{
+		{
+			desc:    "No prefixes configured",
+			wantErr: cmpopts.AnyError,
+		},
+		{
+			desc:    "Matching prefixes",
+			options: map[string]any{"name_prefixes": []any{"com.example.", "@example/"}},
+			wantAnnotations: [][]extractor.Annotation{
+				{extractor.Internal},
+				{extractor.Transitional, extractor.Internal},
+				{extractor.Internal},
+				nil,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			tr := taginternal.New(nil)
+			if err := plugin.Configure(tr, tc.options); err != nil {
+				t.Fatalf("plugin.Configure(%v): %v", tc.options, err)
+			}
+			inventory := []*extractor.Inventory{
+				{Name: "com.example.app"},
+				{Name: "@example/ui", Annotations: []extractor.Annotation{extractor.Transitional}},
+				{Name: "com.example.lib", Annotations: []extractor.Annotation{extractor.Internal}},
+				{Name: "org.apache.commons"},
+			}
+			got, err := tr.Transform(context.Background(), inventory)
+			if diff := cmp.Diff(tc.wantErr, err, cmpopts.EquateErrors()); diff != "" {
+				t.Fatalf("Transform() error got diff (-want +got):\n%s", diff)
+			}
+			if err != nil {
+				return
+			}
+			var gotAnnotations [][]extractor.Annotation
+			for _, i := range got {
+				gotAnnotations = append(gotAnnotations, i.Annotations)
+			}
+			if diff := cmp.Diff(tc.wantAnnotations, gotAnnotations); diff != "" {
+				t.Errorf("Transform() unexpected annotations (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestConfigure(t *testing.T) {
+	testCases := []struct {
+		desc    string
+		options map[string]any
+		want    []string
+		wantErr error
+	}{
+		{
+			desc:    "Prefixes",
+			options: map[string]any{"name_prefixes": []any{"com.example."}},
+			want:    []string{"com.example."},
+		},
+		{
+			desc:    "Not a list",
+			options: map[string]any{"name_prefixes": "com.example."},
+			wantErr: cmpopts.AnyError,
+		},
+		{
+			desc:    "Not a list of strings",
+			options: map[string]any{"name_prefixes": []any{1}},
+			wantErr: cmpopts.AnyError,
+		},
+		{
+			desc:    "Unknown option",
+			options: map[string]any{"prefixes": []any{"com.example."}},
+			wantErr: cmpopts.AnyError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			tr := taginternal.New(nil)
+			err := tr.Configure(tc.options)
+			if diff := cmp.Diff(tc.wantErr, err, cmpopts.EquateErrors()); diff != "" {
+				t.Fatalf("Configure(%v) error got diff (-want +got):\n%s", tc.options, diff)
+			}
+			if diff := cmp.Diff(tc.want, tr.NamePrefixes); err == nil && diff != "" {
+				t.Errorf("Configure(%v) unexpected prefixes (-want +got):\n%s", tc.options, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,58 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package transformer provides the interface for transformer plugins, which
+// post-process the inventory found by the extractors before it's reported.
+package transformer
+
+import (
+	"context"
+
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/plugin"
+)
+
+// Transformer is the interface for a plugin that post-processes all of the
+// extracted inventory, e.g. to drop development dependencies or to tag
+// internal packages. Unlike enrichers, transformers can add and remove
+// inventory items, and they run after the detectors so that they only affect
+// the reported inventory.
+type Transformer interface {
+	plugin.Plugin
+	// Transform returns the transformed inventory. Implementations may modify
+	// the passed inventory items in place and return a different slice, e.g.
+	// with some items removed.
+	Transform(ctx context.Context, inventory []*extractor.Inventory) ([]*extractor.Inventory, error)
+}
+
+// Run runs the specified transformers on the inventory in the order they're
+// listed, so each transformer receives the output of the one before it. If a
+// transformer fails, its output is discarded and the next transformer
+// receives its input instead. Changes it made to the inventory items in place
+// before failing are kept. Returns the transformed inventory and whether the
+// plugin runs completed successfully.
+func Run(ctx context.Context, transformers []Transformer, inventory []*extractor.Inventory) ([]*extractor.Inventory, []*plugin.Status, error) {
+	status := []*plugin.Status{}
+	for _, t := range transformers {
+		if ctx.Err() != nil {
+			return nil, nil, ctx.Err()
+		}
+		transformed, err := t.Transform(ctx, inventory)
+		if err == nil {
+			inventory = transformed
+		}
+		status = append(status, plugin.StatusFromErr(t, false, err))
+	}
+	return inventory, status, nil
+}
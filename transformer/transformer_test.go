@@ -0,0 +1,128 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transformer_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/plugin"
+	ftr "github.com/google/osv-scalibr/testing/faketransformer"
+	"github.com/google/osv-scalibr/transformer"
+)
+
+// dropFirst is a TransformFunc that removes the first inventory item.
+func dropFirst(ctx context.Context, inventory []*extractor.Inventory) ([]*extractor.Inventory, error) {
+	return inventory[1:], nil
+}
+
+// rename returns a TransformFunc that appends s to the names of all
+// inventory items.
+func rename(s string) ftr.TransformFunc {
+	return func(ctx context.Context, inventory []*extractor.Inventory) ([]*extractor.Inventory, error) {
+		for _, i := range inventory {
+			i.Name += s
+		}
+		return inventory, nil
+	}
+}
+
+func TestRun(t *testing.T) {
+	success := &plugin.ScanStatus{Status: plugin.ScanStatusSucceeded}
+	failing := func(ctx context.Context, inventory []*extractor.Inventory) ([]*extractor.Inventory, error) {
+		return nil, errors.New("transformation failed")
+	}
+
+	testCases := []struct {
+		desc         string
+		transformers []transformer.Transformer
+		wantNames    []string
+		wantStatus   []*plugin.Status
+	}{
+		{
+			desc:       "No transformers",
+			wantNames:  []string{"software1", "software2", "software3"},
+			wantStatus: []*plugin.Status{},
+		},
+		{
+			desc: "Transformers run in order",
+			transformers: []transformer.Transformer{
+				ftr.New("first", 1, dropFirst),
+				ftr.New("second", 2, rename("-a")),
+				ftr.New("third", 3, dropFirst),
+			},
+			wantNames: []string{"software3-a"},
+			wantStatus: []*plugin.Status{
+				{Name: "first", Version: 1, Status: success},
+				{Name: "second", Version: 2, Status: success},
+				{Name: "third", Version: 3, Status: success},
+			},
+		},
+		{
+			desc: "Output of failing transformer is discarded",
+			transformers: []transformer.Transformer{
+				ftr.New("failing", 1, failing),
+				ftr.New("second", 2, dropFirst),
+			},
+			wantNames: []string{"software2", "software3"},
+			wantStatus: []*plugin.Status{
+				{Name: "failing", Version: 1, Status: &plugin.ScanStatus{
+					Status:        plugin.ScanStatusFailed,
+					FailureReason: "transformation failed",
+				}},
+				{Name: "second", Version: 2, Status: success},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			inventory := []*extractor.Inventory{{Name: "software1"}, {Name: "software2"}, {Name: "software3"}}
+			got, gotStatus, err := transformer.Run(context.Background(), tc.transformers, inventory)
+			if err != nil {
+				t.Fatalf("transformer.Run(%v): %v", tc.transformers, err)
+			}
+			if diff := cmp.Diff(tc.wantStatus, gotStatus); diff != "" {
+				t.Errorf("transformer.Run(%v): unexpected status (-want +got):\n%s", tc.transformers, diff)
+			}
+			gotNames := []string{}
+			for _, i := range got {
+				gotNames = append(gotNames, i.Name)
+			}
+			if diff := cmp.Diff(tc.wantNames, gotNames); diff != "" {
+				t.Errorf("transformer.Run(%v): unexpected inventory (-want +got):\n%s", tc.transformers, diff)
+			}
+		})
+	}
+}
+
+func TestRunCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	transformers := []transformer.Transformer{ftr.New("transformer", 1, rename("-a"))}
+	inventory := []*extractor.Inventory{{Name: "software"}}
+
+	_, _, err := transformer.Run(ctx, transformers, inventory)
+	if diff := cmp.Diff(context.Canceled, err, cmpopts.EquateErrors()); diff != "" {
+		t.Errorf("transformer.Run(%v) error got diff (-want +got):\n%s", transformers, diff)
+	}
+	if inventory[0].Name != "software" {
+		t.Errorf("transformer.Run(%v) ran the transformer after the context was canceled", transformers)
+	}
+}